@@ -0,0 +1,419 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	serviceInterfaces "github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/bootstrap"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/config"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+	"github.com/MayaCris/stock-info-app/scripts"
+)
+
+// cliApp comparte la configuración y el logger entre los subcomandos; se cargan al ejecutar el primero que los necesita
+type cliApp struct {
+	cfg    *config.Config
+	logger logger.Logger
+}
+
+// load inicializa el logger y carga la configuración
+func (a *cliApp) load() error {
+	if a.cfg != nil {
+		return nil
+	}
+
+	cfg, appLogger, err := bootstrap.Load()
+	if err != nil {
+		return err
+	}
+
+	a.cfg = cfg
+	a.logger = appLogger
+	return nil
+}
+
+// close cierra el logger si algún subcomando lo inicializó
+func (a *cliApp) close() {
+	if a.logger == nil {
+		return
+	}
+	if err := a.logger.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️ Warning: Failed to close logger: %v\n", err)
+	}
+}
+
+// runE envuelve un subcomando que necesita configuración y logger
+func (a *cliApp) runE(run func(cmd *cobra.Command, args []string) error) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := a.load(); err != nil {
+			return err
+		}
+		return run(cmd, args)
+	}
+}
+
+// optionalCommands son los subcomandos que solo se compilan con un build tag (p. ej. contract)
+var optionalCommands []func(app *cliApp) *cobra.Command
+
+// newRootCommand construye el árbol de subcomandos del binario
+func newRootCommand(app *cliApp) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "stock-info-app",
+		Short: "Stock Info API server and operational commands",
+		Long: `Stock Info API server and operational commands.
+
+Configuration is loaded from environment variables and the .env file.`,
+		Example: `  stock-info-app serve                       # Start the HTTP server
+  stock-info-app serve --mode=worker         # Run background workers only
+  stock-info-app migrate up                  # Apply pending schema migrations
+  stock-info-app populate --incremental      # Fetch only ratings newer than the last sync
+  stock-info-app refresh AAPL MSFT           # Refresh the quotes of some symbols
+  stock-info-app integrity check --repair    # Validate and repair minor integrity issues
+  stock-info-app export parquet AAPL         # Export prices and ratings of a symbol as Parquet
+  stock-info-app seed --companies 50         # Fill a local database with synthetic data`,
+		SilenceUsage: true,
+	}
+
+	root.AddCommand(
+		newServeCommand(app),
+		newConfigCommand(app),
+		newMigrateCommand(app),
+		newPopulateCommand(app),
+		newRatingsCommand(app),
+		newRefreshCommand(app),
+		newCacheCommand(app),
+		newIntegrityCommand(app),
+		newExportCommand(app),
+		newSeedCommand(app),
+		newVersionCommand(),
+	)
+	for _, newCommand := range optionalCommands {
+		root.AddCommand(newCommand(app))
+	}
+
+	return root
+}
+
+// newServeCommand arranca el servidor HTTP, los workers o ambos
+func newServeCommand(app *cliApp) *cobra.Command {
+	var (
+		mode   string
+		dryRun bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Start the HTTP server and/or the background workers",
+		Args:  cobra.NoArgs,
+		RunE: app.runE(func(cmd *cobra.Command, args []string) error {
+			if !isValidMode(mode) {
+				return fmt.Errorf("invalid mode %q (expected %s, %s or %s)", mode, ModeAPI, ModeWorker, ModeAll)
+			}
+
+			ctx := cmd.Context()
+			app.logger.Info(ctx, "Starting Stock Info API Server",
+				logger.String("component", "main"),
+				logger.String("app_name", app.cfg.App.Name),
+				logger.String("version", app.cfg.App.Version),
+				logger.String("environment", app.cfg.App.Env),
+				logger.String("server_mode", app.cfg.Server.Mode),
+				logger.String("run_mode", mode),
+			)
+
+			// Worker mode - background workloads only, no HTTP server (same as the cmd/worker binary)
+			if mode == ModeWorker {
+				return bootstrap.RunWorker(ctx, app.cfg, app.logger, dryRun)
+			}
+
+			runServer(ctx, app.cfg, app.logger, mode, dryRun)
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(&mode, "mode", ModeAPI, "Run mode: api, worker or all")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Validate setup without starting")
+	return cmd
+}
+
+// newConfigCommand agrupa las operaciones sobre la configuración
+func newConfigCommand(app *cliApp) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Configuration operations",
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "check",
+		Short: "Validate the configuration and exit",
+		Args:  cobra.NoArgs,
+		RunE: app.runE(func(cmd *cobra.Command, args []string) error {
+			if err := validateConfiguration(app.cfg, app.logger); err != nil {
+				return fmt.Errorf("configuration validation failed: %w", err)
+			}
+			app.logger.Info(cmd.Context(), "✅ Configuration validation passed")
+			return nil
+		}),
+	})
+
+	return cmd
+}
+
+// newMigrateCommand aplica, revierte o consulta las migraciones del esquema
+func newMigrateCommand(app *cliApp) *cobra.Command {
+	return &cobra.Command{
+		Use:       "migrate up|down|status",
+		Short:     "Run schema migrations: up applies pending ones, down reverts the last one",
+		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		ValidArgs: []string{"up", "down", "status"},
+		RunE: app.runE(func(cmd *cobra.Command, args []string) error {
+			runMigrations(cmd.Context(), app.cfg, app.logger, args[0])
+			return nil
+		}),
+	}
+}
+
+// newPopulateCommand ejecuta una población desde la API de ratings
+func newPopulateCommand(app *cliApp) *cobra.Command {
+	options := scripts.DefaultPopulationOptions()
+
+	cmd := &cobra.Command{
+		Use:   "populate",
+		Short: "Populate the database from the stock ratings API",
+		Args:  cobra.NoArgs,
+		RunE: app.runE(func(cmd *cobra.Command, args []string) error {
+			return scripts.PopulateDatabaseScript(app.cfg, options)
+		}),
+	}
+
+	flags := cmd.Flags()
+	flags.IntVar(&options.BatchSize, "batch-size", options.BatchSize, "Items per batch")
+	flags.IntVar(&options.MaxPages, "max-pages", options.MaxPages, "Maximum pages to fetch")
+	flags.IntVar(&options.DelayMs, "delay-ms", options.DelayMs, "Delay between pages in milliseconds")
+	flags.BoolVar(&options.ClearFirst, "clear-first", options.ClearFirst, "Clear the database before populating")
+	flags.BoolVar(&options.UseCache, "cache", options.UseCache, "Write companies, brokerages and ratings to the cache")
+	flags.BoolVar(&options.DryRun, "dry-run", options.DryRun, "Fetch and convert without writing")
+	flags.BoolVar(&options.ValidateAfter, "validate", options.ValidateAfter, "Validate integrity after populating")
+	flags.BoolVar(&options.Incremental, "incremental", options.Incremental, "Fetch only ratings newer than the last sync")
+	flags.BoolVar(&options.ShowDetails, "details", options.ShowDetails, "Show processing rate and error rate")
+	return cmd
+}
+
+// newRatingsCommand agrupa las operaciones de mantenimiento de stock ratings
+func newRatingsCommand(app *cliApp) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ratings",
+		Short: "Stock ratings maintenance",
+	}
+
+	var (
+		from, to string
+		dryRun   bool
+	)
+	reprocess := &cobra.Command{
+		Use:   "reprocess",
+		Short: "Re-parse the raw data of the ratings between --from and --to (YYYY-MM-DD, inclusive)",
+		Args:  cobra.NoArgs,
+		RunE: app.runE(func(cmd *cobra.Command, args []string) error {
+			runRatingsReprocess(cmd.Context(), app.cfg, app.logger, from, to, dryRun)
+			return nil
+		}),
+	}
+	reprocess.Flags().StringVar(&from, "from", "", "First event date (YYYY-MM-DD)")
+	reprocess.Flags().StringVar(&to, "to", "", "Last event date (YYYY-MM-DD, inclusive)")
+	reprocess.Flags().BoolVar(&dryRun, "dry-run", false, "Count the ratings that would change without writing")
+	_ = reprocess.MarkFlagRequired("from")
+	_ = reprocess.MarkFlagRequired("to")
+
+	var (
+		all       bool
+		batchSize int
+	)
+	backfillTargets := &cobra.Command{
+		Use:   "backfill-targets",
+		Short: "Parse the price targets of the ratings stored before target parsing (value and currency columns)",
+		Args:  cobra.NoArgs,
+		RunE: app.runE(func(cmd *cobra.Command, args []string) error {
+			return runTargetBackfill(cmd.Context(), app.cfg, app.logger, all, batchSize)
+		}),
+	}
+	backfillTargets.Flags().BoolVar(&all, "all", false, "Parse every rating again (after a parser change)")
+	backfillTargets.Flags().IntVar(&batchSize, "batch-size", 1000, "Ratings updated per statement")
+
+	cmd.AddCommand(reprocess, backfillTargets)
+	return cmd
+}
+
+// newRefreshCommand refresca las cotizaciones de market data
+func newRefreshCommand(app *cliApp) *cobra.Command {
+	var trending int
+
+	cmd := &cobra.Command{
+		Use:   "refresh [SYMBOL...]",
+		Short: "Refresh market data quotes (every active company unless symbols or --trending are given)",
+		RunE: app.runE(func(cmd *cobra.Command, args []string) error {
+			return runMarketDataRefresh(cmd.Context(), app.cfg, app.logger, args, trending)
+		}),
+	}
+
+	cmd.Flags().IntVar(&trending, "trending", 0, "Without symbols, refresh only the N most viewed symbols of the last 24 hours")
+	return cmd
+}
+
+// newCacheCommand agrupa las operaciones de la cache
+func newCacheCommand(app *cliApp) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Cache operations",
+	}
+
+	var fallback, performance, detailed bool
+	test := &cobra.Command{
+		Use:   "test",
+		Short: "Check Redis connectivity and the cache operations",
+		Args:  cobra.NoArgs,
+		RunE: app.runE(func(cmd *cobra.Command, args []string) error {
+			switch {
+			case fallback:
+				return scripts.TestCacheWithFallback(app.cfg)
+			case performance && detailed:
+				return scripts.TestCachePerformanceDetailed(app.cfg)
+			case performance:
+				return scripts.TestCachePerformance(app.cfg)
+			default:
+				return scripts.TestCacheOperations(app.cfg)
+			}
+		}),
+	}
+	test.Flags().BoolVar(&fallback, "fallback", false, "Test the in-memory fallback instead")
+	test.Flags().BoolVar(&performance, "performance", false, "Run the bulk operations benchmark instead")
+	test.Flags().BoolVar(&detailed, "detailed", false, "With --performance, benchmark several dataset sizes")
+
+	cmd.AddCommand(test)
+	return cmd
+}
+
+// newIntegrityCommand agrupa las operaciones de integridad de la base de datos
+func newIntegrityCommand(app *cliApp) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "integrity",
+		Short: "Database integrity operations",
+	}
+
+	options := scripts.QuickIntegrityCheck()
+	check := &cobra.Command{
+		Use:   "check",
+		Short: "Validate the integrity of companies, brokerages and ratings (fails on critical issues)",
+		Args:  cobra.NoArgs,
+		RunE: app.runE(func(cmd *cobra.Command, args []string) error {
+			if options.OutputFormat != "text" && options.OutputFormat != "json" {
+				return fmt.Errorf("invalid format %q (expected text or json)", options.OutputFormat)
+			}
+			options.GenerateReport = options.ReportPath != ""
+			return scripts.RunDatabaseIntegrityValidation(app.cfg, options)
+		}),
+	}
+
+	flags := check.Flags()
+	flags.BoolVar(&options.AutoRepair, "repair", options.AutoRepair, "Repair minor issues after validating")
+	flags.BoolVar(&options.DryRun, "dry-run", options.DryRun, "With --repair, only report what would be repaired")
+	flags.StringVar(&options.ReportPath, "report", options.ReportPath, "Write the report to this file")
+	flags.StringVar(&options.OutputFormat, "format", options.OutputFormat, "Report format: text or json")
+	flags.BoolVar(&options.ShowDetails, "details", options.ShowDetails, "Show every issue found")
+
+	cmd.AddCommand(check)
+	return cmd
+}
+
+// newExportCommand agrupa las exportaciones de datos
+func newExportCommand(app *cliApp) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Data export operations",
+	}
+
+	var (
+		datasets         []string
+		fromYear, toYear int
+	)
+	parquet := &cobra.Command{
+		Use:   "parquet [SYMBOL...]",
+		Short: "Export historical prices and ratings as Parquet files, one per dataset, symbol and year",
+		Long: `Export historical prices and ratings as Parquet files, one per dataset, symbol and year.
+
+Without symbols every active company is exported. Files are written to EXPORT_LOCAL_DIR or,
+with EXPORT_DESTINATION=s3, to the export bucket as <prefix>/<dataset>/<SYMBOL>/<year>.parquet.`,
+		RunE: app.runE(func(cmd *cobra.Command, args []string) error {
+			return runParquetExport(cmd.Context(), app.cfg, app.logger, serviceInterfaces.ParquetExportOptions{
+				Symbols:  args,
+				Datasets: datasets,
+				FromYear: fromYear,
+				ToYear:   toYear,
+			})
+		}),
+	}
+
+	flags := parquet.Flags()
+	flags.StringSliceVar(&datasets, "dataset", nil, "Datasets to export: historical_prices, ratings (default both)")
+	flags.IntVar(&fromYear, "from-year", 0, "First year to export (default four years before --to-year)")
+	flags.IntVar(&toYear, "to-year", 0, "Last year to export (default the current year)")
+
+	cmd.AddCommand(parquet)
+	return cmd
+}
+
+// newSeedCommand genera datos sintéticos para desarrollo local sin llamar a las APIs externas
+func newSeedCommand(app *cliApp) *cobra.Command {
+	options := serviceInterfaces.SeedOptions{
+		Companies:         25,
+		Brokerages:        10,
+		RatingsPerCompany: 20,
+		PriceDays:         365,
+		Seed:              1,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "seed",
+		Short: "Generate synthetic companies, brokerages, ratings, quotes and historical prices for local development",
+		Long: `Generate synthetic companies, brokerages, ratings, quotes and historical prices for local development.
+
+Data is written through the repositories without calling any external API. Prices follow the same model as
+EXTERNAL_PROVIDER=mock. Existing companies, ratings and prices are kept, so the command can be run again;
+it refuses to run with APP_ENV=production.`,
+		Args: cobra.NoArgs,
+		RunE: app.runE(func(cmd *cobra.Command, args []string) error {
+			if app.cfg.App.IsProduction() {
+				return fmt.Errorf("seed is not allowed in production")
+			}
+			return runSeed(cmd.Context(), app.cfg, app.logger, options)
+		}),
+	}
+
+	flags := cmd.Flags()
+	flags.IntVar(&options.Companies, "companies", options.Companies, "Number of companies (the first 14 are well-known tickers)")
+	flags.IntVar(&options.Brokerages, "brokerages", options.Brokerages, "Number of brokerages issuing ratings")
+	flags.IntVar(&options.RatingsPerCompany, "ratings", options.RatingsPerCompany, "Ratings per company")
+	flags.IntVar(&options.PriceDays, "days", options.PriceDays, "Days of daily historical prices up to today (0 skips them)")
+	flags.Int64Var(&options.Seed, "seed", options.Seed, "Random seed: the same seed on the same day generates the same data")
+	return cmd
+}
+
+// newVersionCommand muestra la versión; sin configuración válida usa los valores por defecto
+func newVersionCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "version",
+		Short: "Show version information",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, err := config.Load()
+			if err != nil {
+				showVersion("Stock Info API", "1.0.0")
+				fmt.Fprintf(os.Stderr, "⚠️ Warning: Could not load configuration: %v\n", err)
+				return
+			}
+			showVersion(cfg.App.Name, cfg.App.Version)
+		},
+	}
+}
@@ -0,0 +1,162 @@
+//go:build contract
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/contract"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/httpclient"
+)
+
+func init() {
+	optionalCommands = append(optionalCommands, newContractCommand)
+}
+
+// newContractCommand compara los modelos de respuesta con los payloads reales de los proveedores
+func newContractCommand(app *cliApp) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "contract",
+		Short: "Provider contract checks (built with -tags contract)",
+	}
+
+	var (
+		symbol, format, reportPath string
+		providers                  []string
+		interval                   time.Duration
+		newFields                  bool
+	)
+	check := &cobra.Command{
+		Use:   "check",
+		Short: "Check that the Finnhub and Alpha Vantage response models still match the live payloads (fails on drift)",
+		Long: `Check that the Finnhub and Alpha Vantage response models still match the live payloads.
+
+Every endpoint our clients call is requested once with PRIMARY_API_KEY/SECONDARY_API_KEY (use sandbox
+keys) and compared with its model: missing fields and type changes are drift and make the command fail;
+endpoints the key has no access to are skipped.`,
+		Example: `  stock-info-app contract check --symbol IBM --provider alphavantage --interval 15s
+  stock-info-app contract check --format json --report contract-report.json`,
+		Args: cobra.NoArgs,
+		RunE: app.runE(func(cmd *cobra.Command, args []string) error {
+			if format != "text" && format != "json" {
+				return fmt.Errorf("invalid format %q (expected text or json)", format)
+			}
+			for _, provider := range providers {
+				if provider != contract.ProviderFinnhub && provider != contract.ProviderAlphaVantage {
+					return fmt.Errorf("invalid provider %q (expected %s or %s)", provider, contract.ProviderFinnhub, contract.ProviderAlphaVantage)
+				}
+			}
+			if app.cfg.External.IsMock() {
+				return errors.New("contract checks need the live providers (EXTERNAL_PROVIDER=live)")
+			}
+
+			external := app.cfg.External
+			httpClient, err := httpclient.New(httpclient.FromConfig(external, external.Primary), app.logger)
+			if err != nil {
+				return fmt.Errorf("failed to create HTTP client: %w", err)
+			}
+
+			var checks []contract.Check
+			for _, c := range contract.DefaultChecks(symbol, time.Now()) {
+				if len(providers) == 0 || slices.Contains(providers, c.Provider) {
+					checks = append(checks, c)
+				}
+			}
+
+			runner := contract.NewRunner(contract.RunnerConfig{
+				HTTPClient:      httpClient,
+				Finnhub:         contract.Endpoint{BaseURL: external.Primary.BaseURL, APIKey: external.Primary.Key},
+				AlphaVantage:    contract.Endpoint{BaseURL: external.Secondary.BaseURL, APIKey: external.Secondary.Key},
+				Interval:        interval,
+				ReportNewFields: newFields,
+				Logger:          app.logger,
+			})
+			report := runner.Run(cmd.Context(), symbol, checks)
+
+			if reportPath != "" {
+				if err := writeContractReport(reportPath, report); err != nil {
+					return err
+				}
+			}
+			if format == "json" {
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				if err := encoder.Encode(report); err != nil {
+					return err
+				}
+			} else {
+				printContractReport(cmd.OutOrStdout(), report)
+			}
+
+			if report.Failed() {
+				return fmt.Errorf("contract check failed: %d drifted, %d errors", report.Summary.Drift, report.Summary.Error)
+			}
+			return nil
+		}),
+	}
+
+	flags := check.Flags()
+	flags.StringVar(&symbol, "symbol", "IBM", "Symbol to request (IBM works with the Alpha Vantage demo key)")
+	flags.StringSliceVar(&providers, "provider", nil, "Providers to check: finnhub, alphavantage (default both)")
+	flags.DurationVar(&interval, "interval", 0, "Pause between requests to the same provider (free tiers allow ~5 calls/minute)")
+	flags.BoolVar(&newFields, "new-fields", false, "Also list payload fields the models do not know")
+	flags.StringVar(&format, "format", "text", "Report format: text or json")
+	flags.StringVar(&reportPath, "report", "", "Write the JSON report to this file")
+
+	cmd.AddCommand(check)
+	return cmd
+}
+
+// printContractReport escribe un resumen legible del informe
+func printContractReport(w io.Writer, report *contract.Report) {
+	icons := map[string]string{
+		contract.StatusOK:      "✅",
+		contract.StatusDrift:   "❌",
+		contract.StatusSkipped: "⏭️",
+		contract.StatusError:   "⚠️",
+	}
+
+	fmt.Fprintf(w, "Provider contract check (%s)\n", report.Symbol)
+	for _, result := range report.Results {
+		fmt.Fprintf(w, "%s %-12s %-30s %s", icons[result.Status], result.Provider, result.Endpoint, result.Status)
+		if result.NewFields > 0 {
+			fmt.Fprintf(w, " (%d new fields)", result.NewFields)
+		}
+		if result.Error != "" {
+			fmt.Fprintf(w, ": %s", result.Error)
+		}
+		fmt.Fprintln(w)
+
+		for _, drift := range result.Drifts {
+			switch drift.Kind {
+			case contract.DriftTypeMismatch:
+				fmt.Fprintf(w, "     %s %s: expected %s, got %s\n", drift.Kind, drift.Path, drift.Expected, drift.Actual)
+			default:
+				fmt.Fprintf(w, "     %s %s\n", drift.Kind, drift.Path)
+			}
+		}
+	}
+
+	summary := report.Summary
+	fmt.Fprintf(w, "\n%d checks: %d ok, %d drift, %d skipped, %d errors\n",
+		summary.Total, summary.OK, summary.Drift, summary.Skipped, summary.Error)
+}
+
+func writeContractReport(path string, report *contract.Report) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write contract report: %w", err)
+	}
+	return nil
+}
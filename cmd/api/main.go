@@ -2,91 +2,39 @@ package main
 
 import (
 	"context"
-	"flag"
 	"fmt"
 	"os"
-
+	"os/signal"
+	"syscall"
+	"time"
+
+	serviceInterfaces "github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/application/usecases/population"
+	"github.com/MayaCris/stock-info-app/internal/bootstrap"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/implementation"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/config"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/database/cockroachdb"
+	infraFactory "github.com/MayaCris/stock-info-app/internal/infrastructure/factory"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/factory"
 )
 
 func main() {
-	// Parse command line flags
-	var (
-		help        = flag.Bool("help", false, "Show help message")
-		version     = flag.Bool("version", false, "Show version information")
-		configCheck = flag.Bool("config-check", false, "Validate configuration and exit")
-		dryRun      = flag.Bool("dry-run", false, "Validate setup without starting server")
-	)
-	flag.Parse()
+	app := &cliApp{}
 
-	// For help and version, we need to load config first to get app name and version
-	if *help || *version {
-		// Load configuration early for help/version commands
-		cfg, err := config.Load()
-		if err != nil {
-			// If config fails, use defaults for help/version
-			appName := "Stock Info API"
-			appVersion := "1.0.0"
-			if *help {
-				showHelp(appName, appVersion)
-			} else {
-				showVersion(appName, appVersion)
-			}
-			fmt.Fprintf(os.Stderr, "⚠️ Warning: Could not load configuration: %v\n", err)
-			return
-		}
+	// Ctrl+C cancela los comandos de una sola ejecución; serve y worker gestionan sus propias señales
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := newRootCommand(app).ExecuteContext(ctx)
+	stop()
+	app.close()
 
-		if *help {
-			showHelp(cfg.App.Name, cfg.App.Version)
-		} else {
-			showVersion(cfg.App.Name, cfg.App.Version)
-		}
-		return
-	}
-
-	// Initialize logger first
-	appLogger, err := logger.InitializeGlobalLogger()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "❌ Failed to initialize logger: %v\n", err)
 		os.Exit(1)
 	}
-	defer func() {
-		if closeErr := appLogger.Close(); closeErr != nil {
-			fmt.Fprintf(os.Stderr, "⚠️ Warning: Failed to close logger: %v\n", closeErr)
-		}
-	}()
-
-	ctx := context.Background() // Load configuration
-	cfg, err := config.Load()
-	if err != nil {
-		appLogger.Fatal(ctx, "Failed to load configuration", err,
-			logger.String("component", "main"),
-		)
-		return
-	}
-
-	// Log application startup
-	appLogger.Info(ctx, "Starting Stock Info API Server",
-		logger.String("component", "main"),
-		logger.String("app_name", cfg.App.Name),
-		logger.String("version", cfg.App.Version),
-		logger.String("environment", cfg.App.Env),
-		logger.String("server_mode", cfg.Server.Mode),
-	)
-
-	// Validate configuration if requested
-	if *configCheck {
-		if err := validateConfiguration(cfg, appLogger); err != nil {
-			appLogger.Fatal(ctx, "Configuration validation failed", err,
-				logger.String("component", "config_validation"),
-			)
-			return
-		}
-		appLogger.Info(ctx, "✅ Configuration validation passed")
-		return
-	}
+}
 
+// runServer arranca el servidor HTTP (y en modo "all" los procesos en segundo plano) y bloquea hasta el shutdown
+func runServer(ctx context.Context, cfg *config.Config, appLogger logger.Logger, mode string, dryRun bool) {
 	// Create and configure server
 	server, err := NewServer(cfg, appLogger)
 	if err != nil {
@@ -105,7 +53,7 @@ func main() {
 	}
 
 	// Dry run - validate setup without starting server
-	if *dryRun {
+	if dryRun {
 		appLogger.Info(ctx, "✅ Dry run completed successfully - server is ready to start",
 			logger.String("address", server.GetServerAddress()),
 			logger.String("mode", cfg.Server.Mode),
@@ -129,6 +77,17 @@ func main() {
 
 	// Configurar shutdown hooks personalizados
 	customHooks := setupCustomShutdownHooks(cfg, appLogger)
+
+	// Precarga de la cache en segundo plano para no retrasar el arranque del servidor
+	customHooks = append(customHooks, startCacheWarmup(cfg, server, appLogger)...)
+
+	// Recarga de la configuración no estructural con SIGHUP (equivalente a POST /admin/config/reload)
+	customHooks = append(customHooks, watchConfigReload(server, cfg.Secrets.RotationInterval, appLogger)...)
+
+	// En modo "all" el scheduler corre dentro del mismo proceso que el servidor
+	if mode == ModeAll {
+		customHooks = append(customHooks, setupBackgroundWorkers(cfg, server, appLogger)...)
+	}
 	appLogger.Info(ctx, "Configured custom shutdown hooks",
 		logger.Int("custom_hooks", len(customHooks)),
 	)
@@ -146,33 +105,441 @@ func main() {
 	)
 }
 
-// showHelp displays help information
-func showHelp(appName, appVersion string) {
-	fmt.Printf("%s - %s\n\n", appName, appVersion)
-	fmt.Println("USAGE:")
-	fmt.Printf("  %s [options]\n\n", os.Args[0])
-	fmt.Println("OPTIONS:")
-	fmt.Println("  -help          Show this help message")
-	fmt.Println("  -version       Show version information")
-	fmt.Println("  -config-check  Validate configuration and exit")
-	fmt.Println("  -dry-run       Validate setup without starting server")
-	fmt.Println("")
-	fmt.Println("ENVIRONMENT:")
-	fmt.Println("  Configuration is loaded from environment variables and .env file")
-	fmt.Println("  See docs/api/ for detailed configuration options")
-	fmt.Println("")
-	fmt.Println("EXAMPLES:")
-	fmt.Printf("  %s                    # Start the server\n", os.Args[0])
-	fmt.Printf("  %s -config-check      # Validate configuration\n", os.Args[0])
-	fmt.Printf("  %s -dry-run           # Test setup without starting\n", os.Args[0])
-	fmt.Printf("  %s -version           # Show version\n", os.Args[0])
-	fmt.Println("")
-	fmt.Println("API ENDPOINTS:")
-	fmt.Println("  GET  /                Health check and API info")
-	fmt.Println("  GET  /health          Detailed health status")
-	fmt.Println("  GET  /api/v1/*        REST API endpoints")
-	fmt.Println("  GET  /swagger/*       API documentation (debug mode)")
-	fmt.Println("")
+// runMigrations ejecuta el comando de migración (up, down o status) y muestra la versión resultante
+func runMigrations(ctx context.Context, cfg *config.Config, appLogger logger.Logger, command string) {
+	db, err := cockroachdb.NewConnection(cfg)
+	if err != nil {
+		appLogger.Fatal(ctx, "Failed to connect to database for migrations", err,
+			logger.String("component", "migrations"),
+		)
+		return
+	}
+	defer db.Close()
+
+	status, err := db.RunMigrateCommand(ctx, command)
+	if err != nil {
+		appLogger.Fatal(ctx, "Migration command failed", err,
+			logger.String("component", "migrations"),
+			logger.String("command", command),
+		)
+		return
+	}
+
+	appLogger.Info(ctx, "✅ Migration command completed",
+		logger.String("command", command),
+		logger.Int("current_version", int(status.CurrentVersion)),
+		logger.Int("latest_version", int(status.LatestVersion)),
+		logger.Int("pending", status.Pending),
+		logger.Bool("dirty", status.Dirty),
+	)
+}
+
+// runRatingsReprocess vuelve a convertir el RawData de los ratings del rango de fechas (inclusive) con el adapter
+// actual; con dryRun solo cuenta los ratings que cambiarían
+func runRatingsReprocess(ctx context.Context, cfg *config.Config, appLogger logger.Logger, from, to string, dryRun bool) {
+	start, end, err := population.ReprocessRangeFromDates(from, to)
+	if err != nil {
+		appLogger.Fatal(ctx, "Invalid ratings reprocess range", err,
+			logger.String("component", "ratings_reprocess"),
+		)
+		return
+	}
+
+	db, err := cockroachdb.NewConnection(cfg)
+	if err != nil {
+		appLogger.Fatal(ctx, "Failed to connect to database for ratings reprocess", err,
+			logger.String("component", "ratings_reprocess"),
+		)
+		return
+	}
+	defer db.Close()
+
+	useCase, err := infraFactory.NewPopulationUseCaseFactoryWithDB(cfg, db).CreatePopulateDatabaseUseCase()
+	if err != nil {
+		appLogger.Fatal(ctx, "Failed to create population use case", err,
+			logger.String("component", "ratings_reprocess"),
+		)
+		return
+	}
+
+	result, err := useCase.ReprocessRatings(ctx, population.RatingReprocessOptions{
+		From:   start,
+		To:     end,
+		DryRun: dryRun,
+	})
+	if err != nil {
+		appLogger.Fatal(ctx, "Ratings reprocess failed", err,
+			logger.String("component", "ratings_reprocess"),
+		)
+		return
+	}
+
+	appLogger.Info(ctx, "✅ Ratings reprocess completed",
+		logger.String("from", from),
+		logger.String("to", to),
+		logger.Bool("dry_run", dryRun),
+		logger.Int("scanned", result.Scanned),
+		logger.Int("updated", result.Updated),
+		logger.Int("unchanged", result.Unchanged),
+		logger.Int("failed", result.Failed),
+	)
+}
+
+// runTargetBackfill parsea los precios objetivo de los ratings guardados sin valor numérico ni moneda
+func runTargetBackfill(ctx context.Context, cfg *config.Config, appLogger logger.Logger, all bool, batchSize int) error {
+	if batchSize <= 0 {
+		return fmt.Errorf("invalid batch size %d: must be positive", batchSize)
+	}
+
+	db, err := cockroachdb.NewConnection(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	updated, err := implementation.NewStockRatingRepository(db.DB).BackfillTargetPrices(ctx, all, batchSize)
+	if err != nil {
+		return fmt.Errorf("target price backfill failed after %d ratings: %w", updated, err)
+	}
+
+	appLogger.Info(ctx, "✅ Target price backfill completed",
+		logger.Bool("all", all),
+		logger.Int64("updated", updated),
+	)
+	return nil
+}
+
+// runMarketDataRefresh refresca las cotizaciones de los símbolos indicados (o de los más vistos, o de todas las
+// companies activas) con la misma cola priorizada y cuota diaria que el job market_data_refresh
+func runMarketDataRefresh(ctx context.Context, cfg *config.Config, appLogger logger.Logger, symbols []string, trending int) error {
+	deps, err := factory.NewAPIFactory(cfg).CreateDependencies()
+	if err != nil {
+		return fmt.Errorf("failed to create dependencies: %w", err)
+	}
+	defer deps.Database.Close()
+	defer deps.EventBus.Close()
+
+	result, err := deps.MarketDataService.RefreshMarketData(ctx, serviceInterfaces.MarketDataRefreshOptions{
+		Symbols:  symbols,
+		Trending: trending,
+	})
+	if err != nil {
+		return fmt.Errorf("market data refresh failed: %w", err)
+	}
+
+	appLogger.Info(ctx, "✅ Market data refresh completed",
+		logger.Int("total", result.Total),
+		logger.Int("refreshed", result.Refreshed),
+		logger.Int("fresh", result.Fresh),
+		logger.Int("failed", result.Failed),
+		logger.Int("deferred", result.Deferred),
+	)
+	for _, refreshErr := range result.Errors {
+		appLogger.Warn(ctx, "Symbol refresh failed", logger.String("error", refreshErr))
+	}
+	return nil
+}
+
+// runSeed escribe los datos sintéticos del comando seed
+func runSeed(ctx context.Context, cfg *config.Config, appLogger logger.Logger, options serviceInterfaces.SeedOptions) error {
+	deps, err := factory.NewAPIFactory(cfg).CreateDependencies()
+	if err != nil {
+		return fmt.Errorf("failed to create dependencies: %w", err)
+	}
+	defer deps.Database.Close()
+	defer deps.EventBus.Close()
+
+	result, err := deps.Seed.Seed(ctx, options)
+	if err != nil {
+		return fmt.Errorf("seed failed: %w", err)
+	}
+
+	appLogger.Info(ctx, "✅ Seed completed",
+		logger.Int("companies", result.Companies),
+		logger.Int("existing_companies", result.ExistingCompanies),
+		logger.Int("brokerages", result.Brokerages),
+		logger.Int("ratings", result.Ratings),
+		logger.Int("market_data", result.MarketData),
+		logger.Int("historical_prices", result.HistoricalPrices),
+		logger.Int64("duration_ms", result.DurationMs),
+	)
+	return nil
+}
+
+// runParquetExport escribe los ficheros Parquet sin pasar por la cola de jobs
+func runParquetExport(ctx context.Context, cfg *config.Config, appLogger logger.Logger, options serviceInterfaces.ParquetExportOptions) error {
+	deps, err := factory.NewAPIFactory(cfg).CreateDependencies()
+	if err != nil {
+		return fmt.Errorf("failed to create dependencies: %w", err)
+	}
+	defer deps.Database.Close()
+	defer deps.EventBus.Close()
+
+	result, err := deps.ParquetExport.Export(ctx, options)
+	if err != nil {
+		return fmt.Errorf("parquet export failed: %w", err)
+	}
+
+	appLogger.Info(ctx, "✅ Parquet export completed",
+		logger.String("destination", result.Destination),
+		logger.String("prefix", result.Prefix),
+		logger.Int("symbols", result.Symbols),
+		logger.Int("files", len(result.Files)),
+		logger.Int64("rows", result.TotalRows),
+	)
+	for _, exportErr := range result.Errors {
+		appLogger.Warn(ctx, "Symbol export failed", logger.String("error", exportErr))
+	}
+	return nil
+}
+
+// startCacheWarmup lanza el warm-up de la cache con las companies más consultadas y devuelve
+// el hook que lo cancela si el servidor se detiene antes de que termine
+func startCacheWarmup(cfg *config.Config, server *Server, appLogger logger.Logger) []ShutdownHook {
+	warmer := server.dependencies.CacheWarmer
+	if !cfg.Cache.WarmOnStart || !warmer.Enabled() {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		if _, err := warmer.Warm(ctx, cfg.Cache.WarmTopCompanies); err != nil && ctx.Err() == nil {
+			appLogger.Warn(ctx, "⚠️ Cache warm-up failed",
+				logger.String("error", err.Error()),
+			)
+		}
+	}()
+
+	return []ShutdownHook{{
+		Name:     "cache_warmup",
+		Priority: 4,
+		Cleanup: func(ctx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	}}
+}
+
+// watchConfigReload recarga la configuración cada vez que el proceso recibe SIGHUP y, si hay
+// rotationInterval, vuelve a leer periódicamente los secretos para recoger API keys rotadas
+func watchConfigReload(server *Server, rotationInterval time.Duration, appLogger logger.Logger) []ShutdownHook {
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	done := make(chan struct{})
+
+	// Sin rotación el canal queda a nil y nunca se selecciona
+	var rotation <-chan time.Time
+	var rotationTicker *time.Ticker
+	if rotationInterval > 0 {
+		rotationTicker = time.NewTicker(rotationInterval)
+		rotation = rotationTicker.C
+	}
+
+	reloadConfig := func(trigger string) {
+		ctx := context.Background()
+		result, err := server.ReloadConfig()
+		if err != nil {
+			appLogger.Error(ctx, "Configuration reload failed, keeping current configuration", err,
+				logger.String("trigger", trigger),
+			)
+			return
+		}
+		if trigger == "secret_rotation" && !result.HasChanged(config.ReloadSectionProviderKeys) {
+			return
+		}
+		appLogger.Info(ctx, "🔄 Configuration reloaded",
+			logger.String("trigger", trigger),
+			logger.Any("changed", result.Changed),
+			logger.Any("failed", result.Failed),
+		)
+	}
+
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-reload:
+				reloadConfig("SIGHUP")
+			case <-rotation:
+				config.ExpireSecrets()
+				reloadConfig("secret_rotation")
+			}
+		}
+	}()
+
+	return []ShutdownHook{{
+		Name:     "config_reload",
+		Priority: 4,
+		Cleanup: func(ctx context.Context) error {
+			signal.Stop(reload)
+			if rotationTicker != nil {
+				rotationTicker.Stop()
+			}
+			close(done)
+			return nil
+		},
+	}}
+}
+
+// setupBackgroundWorkers inicia los procesos en segundo plano junto al servidor y
+// devuelve los hooks necesarios para detenerlos durante el shutdown
+func setupBackgroundWorkers(cfg *config.Config, server *Server, appLogger logger.Logger) []ShutdownHook {
+	var hooks []ShutdownHook
+
+	scheduler := bootstrap.NewPopulationScheduler(cfg, server.dependencies, appLogger)
+	if scheduler != nil {
+		scheduler.Start(context.Background())
+
+		hooks = append(hooks, ShutdownHook{
+			Name:     "population_scheduler",
+			Priority: 5,
+			Cleanup: func(ctx context.Context) error {
+				appLogger.Info(ctx, "Stopping population scheduler")
+				scheduler.Stop()
+				return server.dependencies.PopulationRunner.Wait(ctx)
+			},
+		})
+	} else {
+		appLogger.Warn(context.Background(), "⚠️ Population scheduler disabled - set WORKER_POPULATION_INTERVAL to enable it")
+	}
+
+	if enrichmentScheduler := bootstrap.NewEnrichmentScheduler(cfg, server.dependencies, appLogger); enrichmentScheduler != nil {
+		enrichmentScheduler.Start(context.Background())
+
+		hooks = append(hooks, ShutdownHook{
+			Name:     "enrichment_scheduler",
+			Priority: 5,
+			Cleanup: func(ctx context.Context) error {
+				appLogger.Info(ctx, "Stopping company enrichment scheduler")
+				enrichmentScheduler.Stop()
+				return nil
+			},
+		})
+	}
+
+	if analyticsScheduler := bootstrap.NewAnalyticsRefreshScheduler(cfg, server.dependencies, appLogger); analyticsScheduler != nil {
+		analyticsScheduler.Start(context.Background())
+
+		hooks = append(hooks, ShutdownHook{
+			Name:     "analytics_refresh_scheduler",
+			Priority: 5,
+			Cleanup: func(ctx context.Context) error {
+				appLogger.Info(ctx, "Stopping analytics views refresh scheduler")
+				analyticsScheduler.Stop()
+				return nil
+			},
+		})
+	}
+
+	if anomalyScheduler := bootstrap.NewAnomalyDetectionScheduler(cfg, server.dependencies, appLogger); anomalyScheduler != nil {
+		anomalyScheduler.Start(context.Background())
+
+		hooks = append(hooks, ShutdownHook{
+			Name:     "anomaly_detection_scheduler",
+			Priority: 5,
+			Cleanup: func(ctx context.Context) error {
+				appLogger.Info(ctx, "Stopping anomaly detection scheduler")
+				anomalyScheduler.Stop()
+				return nil
+			},
+		})
+	}
+
+	if refreshScheduler := bootstrap.NewMarketDataRefreshScheduler(cfg, server.dependencies, appLogger); refreshScheduler != nil {
+		refreshScheduler.Start(context.Background())
+
+		hooks = append(hooks, ShutdownHook{
+			Name:     "market_data_refresh_scheduler",
+			Priority: 5,
+			Cleanup: func(ctx context.Context) error {
+				appLogger.Info(ctx, "Stopping market data refresh scheduler")
+				refreshScheduler.Stop()
+				return nil
+			},
+		})
+	}
+
+	if usageScheduler := bootstrap.NewUsageFlushScheduler(cfg, server.dependencies, appLogger); usageScheduler != nil {
+		usageScheduler.Start(context.Background())
+
+		hooks = append(hooks, ShutdownHook{
+			Name:     "usage_flush_scheduler",
+			Priority: 5,
+			Cleanup: func(ctx context.Context) error {
+				appLogger.Info(ctx, "Stopping API usage flush scheduler")
+				usageScheduler.Stop()
+				return nil
+			},
+		})
+	}
+
+	if processingScheduler := bootstrap.NewRatingProcessingScheduler(cfg, server.dependencies, appLogger); processingScheduler != nil {
+		processingScheduler.Start(context.Background())
+
+		hooks = append(hooks, ShutdownHook{
+			Name:     "rating_processing_scheduler",
+			Priority: 5,
+			Cleanup: func(ctx context.Context) error {
+				appLogger.Info(ctx, "Stopping rating processing scheduler")
+				processingScheduler.Stop()
+				return nil
+			},
+		})
+	}
+
+	if benchmarkScheduler := bootstrap.NewBenchmarkRefreshScheduler(cfg, server.dependencies, appLogger); benchmarkScheduler != nil {
+		benchmarkScheduler.Start(context.Background())
+
+		hooks = append(hooks, ShutdownHook{
+			Name:     "benchmark_refresh_scheduler",
+			Priority: 5,
+			Cleanup: func(ctx context.Context) error {
+				appLogger.Info(ctx, "Stopping custom benchmark refresh scheduler")
+				benchmarkScheduler.Stop()
+				return nil
+			},
+		})
+	}
+
+	pool := server.dependencies.JobWorkerPool
+	if cfg.Worker.IsJobWorkersEnabled() && pool != nil {
+		pool.Start(context.Background())
+
+		hooks = append(hooks, ShutdownHook{
+			Name:     "job_workers",
+			Priority: 6,
+			Cleanup: func(ctx context.Context) error {
+				appLogger.Info(ctx, "Stopping job workers")
+				return pool.Stop(ctx)
+			},
+		})
+	}
+
+	// Relay del outbox: se detiene después de los productores y antes de cerrar el bus en cleanupDependencies
+	if relay := server.dependencies.OutboxRelay; relay != nil {
+		relay.Start(context.Background())
+
+		hooks = append(hooks, ShutdownHook{
+			Name:     "outbox_relay",
+			Priority: 7,
+			Cleanup: func(ctx context.Context) error {
+				appLogger.Info(ctx, "Stopping outbox relay")
+				relay.Stop()
+				return nil
+			},
+		})
+	}
+
+	return hooks
 }
 
 // showVersion displays version information
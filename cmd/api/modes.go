@@ -0,0 +1,18 @@
+package main
+
+// Run modes soportados por serve
+const (
+	ModeAPI    = "api"    // Solo servidor HTTP
+	ModeWorker = "worker" // Solo procesos en segundo plano
+	ModeAll    = "all"    // Servidor HTTP + procesos en segundo plano
+)
+
+// isValidMode verifica si el modo de ejecución es soportado
+func isValidMode(mode string) bool {
+	switch mode {
+	case ModeAPI, ModeWorker, ModeAll:
+		return true
+	default:
+		return false
+	}
+}
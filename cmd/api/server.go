@@ -21,11 +21,12 @@ import (
 
 // Server encapsula el servidor HTTP y sus dependencias
 type Server struct {
-	httpServer   *http.Server
-	router       *routes.Router
-	config       *config.Config
-	logger       logger.Logger
-	serverLogger logger.ServerLogger
+	httpServer     *http.Server
+	redirectServer *http.Server // Listener HTTP -> HTTPS (solo con TLS)
+	router         *routes.Router
+	config         *config.Config
+	logger         logger.Logger
+	serverLogger   logger.ServerLogger
 
 	// Dependencies for cleanup
 	dependencies  *factory.Dependencies
@@ -74,7 +75,7 @@ func NewServer(cfg *config.Config, appLogger logger.Logger) (*Server, error) {
 	}
 
 	// Crear router principal
-	mainRouter := routes.NewRouter(cfg, appLogger, serverLogger, handlers)
+	mainRouter := routes.NewRouter(cfg, appLogger, serverLogger, deps.CacheService, handlers)
 
 	// Configurar servidor HTTP
 	httpServer := &http.Server{
@@ -85,6 +86,13 @@ func NewServer(cfg *config.Config, appLogger logger.Logger) (*Server, error) {
 		IdleTimeout:    cfg.Server.IdleTimeout,
 		MaxHeaderBytes: cfg.Server.MaxHeaderBytes,
 	}
+
+	// Configurar HTTPS y el listener de redirección si TLS está habilitado
+	redirectServer, err := configureTLS(cfg, httpServer, appLogger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure TLS: %w", err)
+	}
+
 	// Configurar trusted proxies si están definidos
 	if len(cfg.Server.TrustedProxies) > 0 {
 		if err := mainRouter.GetEngine().SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
@@ -94,15 +102,57 @@ func NewServer(cfg *config.Config, appLogger logger.Logger) (*Server, error) {
 		}
 	}
 
-	return &Server{
-		httpServer:    httpServer,
-		router:        mainRouter,
-		config:        cfg,
-		logger:        appLogger,
-		serverLogger:  serverLogger,
-		dependencies:  deps,
-		shutdownHooks: make([]ShutdownHook, 0),
-	}, nil
+	server := &Server{
+		httpServer:     httpServer,
+		redirectServer: redirectServer,
+		router:         mainRouter,
+		config:         cfg,
+		logger:         appLogger,
+		serverLogger:   serverLogger,
+		dependencies:   deps,
+		shutdownHooks:  make([]ShutdownHook, 0),
+	}
+	server.registerConfigReload(deps.ConfigWatcher)
+
+	return server, nil
+}
+
+// registerConfigReload suscribe los loggers y el rate limiting del servidor a la recarga de configuración
+func (s *Server) registerConfigReload(watcher *config.Watcher) {
+	if watcher == nil {
+		return
+	}
+
+	watcher.Subscribe("log_level", func(previous, current *config.Config) error {
+		// El nivel inicial lo decide el entorno del logger: solo se toca si LOG_LEVEL cambia
+		if previous.Logging.Level == current.Logging.Level {
+			return nil
+		}
+
+		level, err := logger.ParseLogLevel(current.Logging.Level)
+		if err != nil {
+			return err
+		}
+		for _, l := range []logger.Logger{s.logger, s.serverLogger, s.dependencies.Logger} {
+			if l != nil {
+				l.SetLevel(level)
+			}
+		}
+		return nil
+	})
+
+	watcher.Subscribe("rate_limit", func(_, current *config.Config) error {
+		s.router.RateLimitSettings().Update(current.RateLimit)
+		return nil
+	})
+}
+
+// ReloadConfig recarga la configuración no estructural (usado al recibir SIGHUP)
+func (s *Server) ReloadConfig() (*config.ReloadResult, error) {
+	if s.dependencies == nil || s.dependencies.ConfigWatcher == nil {
+		return nil, fmt.Errorf("configuration reload is not configured")
+	}
+	return s.dependencies.ConfigWatcher.Reload()
 }
 
 // NewServerWithShutdownConfig crea un servidor con configuración avanzada de shutdown
@@ -154,6 +204,11 @@ func (s *Server) Shutdown() error {
 
 	// Phase 1: Stop accepting new connections
 	s.logger.Info(ctx, "Phase 1: Stopping HTTP server from accepting new connections")
+	if s.redirectServer != nil {
+		if err := s.redirectServer.Shutdown(ctx); err != nil {
+			s.logger.Error(ctx, "Failed to shutdown HTTP redirect server gracefully", err)
+		}
+	}
 	if err := s.httpServer.Shutdown(ctx); err != nil {
 		s.logger.Error(ctx, "Failed to shutdown HTTP server gracefully", err)
 		// Log el shutdown fallido con ServerLogger
@@ -194,6 +249,11 @@ func (s *Server) ForceShutdown() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	// El listener de redirección no tiene peticiones de larga duración: se cierra directamente
+	if s.redirectServer != nil {
+		_ = s.redirectServer.Close()
+	}
+
 	// Intentar shutdown graceful con timeout muy corto
 	done := make(chan error, 1)
 	go func() {
@@ -250,6 +310,7 @@ func (s *Server) logServerInfo() {
 		logger.String("idle_timeout", s.config.Server.IdleTimeout.String()),
 		logger.String("shutdown_timeout", s.config.Server.ShutdownTimeout.String()),
 		logger.Int("max_header_bytes", s.config.Server.MaxHeaderBytes),
+		logger.Bool("tls_enabled", s.config.Server.TLS.Enabled),
 		logger.Int("total_routes", len(routes)),
 	)
 
@@ -284,6 +345,8 @@ func (s *Server) logServerInfo() {
 	s.logger.Info(context.Background(), "Available endpoints",
 		logger.String("root", "/"),
 		logger.String("health", "/health"),
+		logger.String("liveness", "/healthz"),
+		logger.String("readiness", "/readyz"),
 		logger.String("api_base", s.config.RESTAPI.BasePath+"/v1"),
 		logger.String("swagger", "/swagger/index.html"),
 		logger.String("docs_redirect", "/docs"),
@@ -325,13 +388,13 @@ func (s *Server) IsRunning() bool {
 // createHandlers crea todas las instancias de handlers necesarias
 func createHandlers(cfg *config.Config, deps *factory.Dependencies) (*routes.Handlers, error) {
 	// Crear handler de health check
-	healthHandler := handlers.NewHealthHandler(cfg, deps.Logger, deps.CacheService)
+	healthHandler := handlers.NewHealthHandler(cfg, deps.Logger, deps.CacheService, deps.Database)
 
 	// Crear handler de stocks
-	stockHandler := handlers.NewStockHandler(deps.StockService, deps.Logger)
+	stockHandler := handlers.NewStockHandler(deps.StockService, deps.RatingPoll, deps.Logger)
 
 	// Crear handler de companies
-	companyHandler := handlers.NewCompanyHandler(deps.CompanyService, deps.Logger)
+	companyHandler := handlers.NewCompanyHandler(deps.CompanyService, deps.CompanyLogos, deps.CompanyOverview, deps.NoteService, cfg.Logo.MaxAge, deps.Logger)
 
 	// Crear handler de brokerages
 	brokerageHandler := handlers.NewBrokerageHandler(deps.BrokerageService, deps.Logger)
@@ -344,6 +407,60 @@ func createHandlers(cfg *config.Config, deps *factory.Dependencies) (*routes.Han
 	// Crear handler de Alpha Vantage
 	alphaVantageHandler := handlers.NewAlphaVantageHandler(deps.AlphaVantageService, deps.Logger)
 
+	// Crear handler de búsqueda
+	searchHandler := handlers.NewSearchHandler(deps.SearchService, deps.Logger)
+
+	// Crear handler de peers (competidores)
+	peerHandler := handlers.NewPeerHandler(deps.PeerService, deps.Logger)
+
+	// Crear handler de relaciones entre companies
+	relationHandler := handlers.NewCompanyRelationshipHandler(deps.RelationshipService, deps.Logger)
+
+	// Crear handler de etiquetas
+	tagHandler := handlers.NewTagHandler(deps.TagService, deps.Logger)
+
+	// Crear handler de estados financieros
+	financialsHandler := handlers.NewFinancialStatementHandler(deps.FinancialStatements, deps.Logger)
+
+	// Crear handler de benchmarks (índices de referencia)
+	benchmarkHandler := handlers.NewBenchmarkHandler(deps.BenchmarkService, deps.Logger)
+
+	// Crear handler de backtesting
+	backtestHandler := handlers.NewBacktestHandler(deps.BacktestService, deps.Logger)
+
+	// Crear handler de anomalías
+	anomalyHandler := handlers.NewAnomalyHandler(deps.AnomalyService, deps.Logger)
+
+	// Crear handler del estado del mercado
+	marketStatusHandler := handlers.NewMarketStatusHandler(deps.MarketStatusService, deps.Logger)
+
+	// Crear handler de los símbolos más consultados
+	trendingHandler := handlers.NewTrendingHandler(deps.TrendingService, deps.Logger)
+
+	// Crear handler del resumen de la pantalla de inicio
+	dashboardHandler := handlers.NewDashboardHandler(deps.DashboardService, deps.Logger)
+
+	// Crear handler administrativo
+	adminHandler := handlers.NewAdminHandler(deps.PopulationRunner, deps.RejectService, deps.EnrichmentService, deps.CompanyService, deps.AnalyticsViews, deps.JobQueue, deps.Database, deps.CacheWarmer, deps.ConfigWatcher, deps.PayloadArchive, deps.BackupService, deps.ParquetExport, deps.RatingTaxonomy, deps.RatingProcessing, deps.IntegrityHistory, deps.IntegrityRepair, deps.Logger)
+
+	// Crear handler de tenants y API keys (solo con TENANCY_ENABLED=true)
+	var tenantHandler *handlers.TenantHandler
+	if deps.TenantService != nil {
+		tenantHandler = handlers.NewTenantHandler(deps.TenantService, deps.Logger)
+	}
+
+	// Crear handler del uso de la API (solo con TENANCY_ENABLED=true)
+	var usageHandler *handlers.UsageHandler
+	if deps.UsageService != nil {
+		usageHandler = handlers.NewUsageHandler(deps.UsageService, deps.Logger)
+	}
+
+	// Crear handler de notas de los usuarios (solo con TENANCY_ENABLED=true)
+	var noteHandler *handlers.NoteHandler
+	if deps.NoteService != nil {
+		noteHandler = handlers.NewNoteHandler(deps.NoteService, deps.Logger)
+	}
+
 	return &routes.Handlers{
 		Health:       healthHandler,
 		Stock:        stockHandler,
@@ -352,6 +469,25 @@ func createHandlers(cfg *config.Config, deps *factory.Dependencies) (*routes.Han
 		Analysis:     analysisHandler,
 		MarketData:   marketDataHandler,
 		AlphaVantage: alphaVantageHandler,
+		Search:       searchHandler,
+		Peers:        peerHandler,
+		Relations:    relationHandler,
+		Tags:         tagHandler,
+		Financials:   financialsHandler,
+		Benchmarks:   benchmarkHandler,
+		Backtest:     backtestHandler,
+		Anomalies:    anomalyHandler,
+		MarketStatus: marketStatusHandler,
+		Trending:     trendingHandler,
+		Dashboard:    dashboardHandler,
+		Admin:        adminHandler,
+		Tenants:      tenantHandler,
+		Usage:        usageHandler,
+		Notes:        noteHandler,
+
+		TenantAuth:    deps.TenantService,
+		UsageCounters: deps.UsageCounters,
+		PlanQuotas:    deps.PlanQuotas,
 	}, nil
 }
 
@@ -413,6 +549,15 @@ func (s *Server) executeShutdownHooks(ctx context.Context) error {
 func (s *Server) cleanupDependencies(ctx context.Context) error {
 	var lastError error
 
+	// Entregar los eventos pendientes antes de cerrar el logger que usan los suscriptores
+	if s.dependencies != nil && s.dependencies.EventBus != nil {
+		s.logger.Info(ctx, "Closing event bus")
+		if err := s.dependencies.EventBus.Close(); err != nil {
+			s.logger.Error(ctx, "Failed to close event bus", err)
+			lastError = err
+		}
+	}
+
 	// Cleanup logger
 	if s.dependencies != nil && s.dependencies.Logger != nil {
 		s.logger.Info(ctx, "Cleaning up application logger")
@@ -427,8 +572,13 @@ func (s *Server) cleanupDependencies(ctx context.Context) error {
 	// Cleanup cache service if present
 	if s.dependencies != nil && s.dependencies.CacheService != nil {
 		s.logger.Info(ctx, "Cleaning up cache service")
-		// Note: CacheService interface might need a Close() method
-		// For now, we'll just log that it exists
+		// Solo algunas implementaciones mantienen conexiones (Redis y su suscripción de invalidaciones)
+		if closer, ok := s.dependencies.CacheService.(interface{ Close() error }); ok {
+			if err := closer.Close(); err != nil {
+				s.logger.Error(ctx, "Failed to close cache service", err)
+				lastError = err
+			}
+		}
 		s.logger.Info(ctx, "✅ Cache service cleanup completed")
 	}
 
@@ -481,8 +631,8 @@ func (s *Server) GracefulShutdownWithSignals(signals ...os.Signal) error {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, signals...)
 
-	// Canal para errores del servidor
-	serverErrors := make(chan error, 1)
+	// Canal para errores del servidor (HTTPS y listener de redirección)
+	serverErrors := make(chan error, 2)
 
 	// Registrar hooks por defecto
 	s.RegisterDefaultShutdownHooks()
@@ -532,12 +682,29 @@ func (s *Server) GracefulShutdownWithSignals(signals ...os.Signal) error {
 		}
 		s.serverLogger.LogServerReady(context.Background(), endpoints, features)
 
-		// Iniciar servidor
-		if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		// Iniciar servidor (el certificado ya está en TLSConfig)
+		var err error
+		if s.config.Server.TLS.Enabled {
+			err = s.httpServer.ListenAndServeTLS("", "")
+		} else {
+			err = s.httpServer.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
 			serverErrors <- fmt.Errorf("failed to start HTTP server: %w", err)
 		}
 	}()
 
+	if s.redirectServer != nil {
+		go func() {
+			s.logger.Info(context.Background(), "Starting HTTP to HTTPS redirect listener",
+				logger.String("address", s.redirectServer.Addr),
+			)
+			if err := s.redirectServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				serverErrors <- fmt.Errorf("failed to start HTTP redirect server: %w", err)
+			}
+		}()
+	}
+
 	// Esperar señal de shutdown o error
 	select {
 	case err := <-serverErrors:
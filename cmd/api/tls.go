@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+
+	"golang.org/x/crypto/acme/autocert"
+
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/config"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// configureTLS prepara el servidor para servir HTTPS (HTTP/2 se negocia automáticamente por ALPN)
+// y devuelve el servidor HTTP que redirige a HTTPS, o nil si no se usa
+func configureTLS(cfg *config.Config, httpServer *http.Server, appLogger logger.Logger) (*http.Server, error) {
+	tlsCfg := cfg.Server.TLS
+	if !tlsCfg.Enabled {
+		return nil, nil
+	}
+
+	minVersion := uint16(tls.VersionTLS12)
+	if tlsCfg.MinVersion == "1.3" {
+		minVersion = tls.VersionTLS13
+	}
+
+	var redirectHandler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		redirectToHTTPS(w, r, cfg.Server.Port)
+	})
+
+	if tlsCfg.Autocert {
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(tlsCfg.AutocertDomains...),
+			Cache:      autocert.DirCache(tlsCfg.AutocertCacheDir),
+			Email:      tlsCfg.AutocertEmail,
+		}
+		httpServer.TLSConfig = manager.TLSConfig()
+		httpServer.TLSConfig.MinVersion = minVersion
+
+		// El listener HTTP también atiende los challenges http-01 de Let's Encrypt
+		redirectHandler = manager.HTTPHandler(redirectHandler)
+
+		appLogger.Info(context.Background(), "🔒 TLS enabled with autocert",
+			logger.Any("domains", tlsCfg.AutocertDomains),
+			logger.String("cache_dir", tlsCfg.AutocertCacheDir),
+		)
+	} else {
+		// Cargar el certificado al arrancar para fallar pronto si falta o no es válido
+		certificate, err := tls.LoadX509KeyPair(tlsCfg.CertFile, tlsCfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+		}
+		httpServer.TLSConfig = &tls.Config{
+			Certificates: []tls.Certificate{certificate},
+			MinVersion:   minVersion,
+		}
+
+		appLogger.Info(context.Background(), "🔒 TLS enabled with certificate files",
+			logger.String("cert_file", tlsCfg.CertFile),
+		)
+	}
+
+	if !tlsCfg.RedirectHTTP {
+		return nil, nil
+	}
+
+	return &http.Server{
+		Addr:           cfg.Server.GetRedirectAddress(),
+		Handler:        redirectHandler,
+		ReadTimeout:    cfg.Server.ReadTimeout,
+		WriteTimeout:   cfg.Server.WriteTimeout,
+		IdleTimeout:    cfg.Server.IdleTimeout,
+		MaxHeaderBytes: cfg.Server.MaxHeaderBytes,
+	}, nil
+}
+
+// redirectToHTTPS redirige la petición a la misma URL en HTTPS. GET y HEAD usan 301;
+// el resto 308 para que el cliente repita el mismo método y cuerpo.
+func redirectToHTTPS(w http.ResponseWriter, r *http.Request, httpsPort string) {
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if httpsPort != "" && httpsPort != "443" {
+		host = net.JoinHostPort(host, httpsPort)
+	}
+
+	status := http.StatusPermanentRedirect
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		status = http.StatusMovedPermanently
+	}
+
+	http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), status)
+}
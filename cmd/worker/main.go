@@ -0,0 +1,55 @@
+// Command worker ejecuta solo los procesos en segundo plano (schedulers y workers de la cola de jobs), sin
+// servidor HTTP, para escalar la API y el procesamiento en segundo plano por separado
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MayaCris/stock-info-app/internal/bootstrap"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+func main() {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "stock-info-worker",
+		Short: "Run the schedulers and background job workers without the HTTP server",
+		Long: `Run the population scheduler, the job schedulers (enrichment, analytics refresh, anomaly detection,
+market data refresh) and the job queue workers, without the HTTP server.
+
+Configuration is loaded from environment variables and the .env file; see the WORKER_* settings.`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, appLogger, err := bootstrap.Load()
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if closeErr := appLogger.Close(); closeErr != nil {
+					fmt.Fprintf(os.Stderr, "⚠️ Warning: Failed to close logger: %v\n", closeErr)
+				}
+			}()
+
+			ctx := context.Background()
+			appLogger.Info(ctx, "Starting Stock Info worker",
+				logger.String("component", "main"),
+				logger.String("app_name", cfg.App.Name),
+				logger.String("version", cfg.App.Version),
+				logger.String("environment", cfg.App.Env),
+			)
+
+			return bootstrap.RunWorker(ctx, cfg, appLogger, dryRun)
+		},
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Validate setup without starting")
+
+	if err := cmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
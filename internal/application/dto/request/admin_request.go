@@ -0,0 +1,128 @@
+package request
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+// RunPopulationRequest represents request to trigger a population run on demand.
+// Omitted fields fall back to the default population configuration.
+type RunPopulationRequest struct {
+	BatchSize     *int  `json:"batch_size,omitempty" binding:"omitempty,min=1,max=1000"`
+	MaxPages      *int  `json:"max_pages,omitempty" binding:"omitempty,min=1,max=500"`
+	DelayMs       *int  `json:"delay_ms,omitempty" binding:"omitempty,min=0,max=60000"`
+	ClearFirst    bool  `json:"clear_first,omitempty"`
+	UseCache      *bool `json:"use_cache,omitempty"`
+	DryRun        bool  `json:"dry_run,omitempty"`
+	ValidateAfter *bool `json:"validate_after,omitempty"`
+	Incremental   bool  `json:"incremental,omitempty"`
+}
+
+// EnqueueJobRequest represents request to enqueue a background job. The job queue validates Type against
+// jobs.SupportedJobTypes, so new job types do not need to be listed here
+type EnqueueJobRequest struct {
+	Type        string          `json:"type" binding:"required"`
+	Payload     json.RawMessage `json:"payload,omitempty" swaggertype:"object"`
+	MaxAttempts *int            `json:"max_attempts,omitempty" binding:"omitempty,min=1,max=10"`
+}
+
+// JobFilterRequest represents filters for listing background jobs
+type JobFilterRequest struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending running succeeded failed cancelled"`
+}
+
+// IntegrityHistoryRequest represents the window of the integrity validation history
+type IntegrityHistoryRequest struct {
+	Days int `form:"days" binding:"omitempty,min=1,max=365"` // Últimos días (por defecto 30)
+}
+
+// IntegrityRepairRequest represents an integrity repair. A destructive run must carry the plan_token of a previous
+// dry run and is only applied if the plan has not changed since
+type IntegrityRepairRequest struct {
+	DryRun        *bool  `json:"dry_run,omitempty"` // Por defecto true
+	ApprovalToken string `json:"approval_token,omitempty" binding:"omitempty,len=64,hexadecimal"`
+}
+
+// IsDryRun reports whether the request only previews the repair (dry_run omitted or true)
+func (r *IntegrityRepairRequest) IsDryRun() bool {
+	return r.DryRun == nil || *r.DryRun
+}
+
+// PopulationRejectFilterRequest represents filters for listing rejected population items
+type PopulationRejectFilterRequest struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending reprocessed discarded"`
+}
+
+// EnrichmentConflictFilterRequest represents filters for listing company enrichment conflicts
+type EnrichmentConflictFilterRequest struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending resolved dismissed"`
+}
+
+// ReviewEnrichmentConflictRequest represents request to close a company enrichment conflict
+type ReviewEnrichmentConflictRequest struct {
+	Status string `json:"status" binding:"required,oneof=resolved dismissed"`
+}
+
+// ReprocessRejectsRequest represents request to reprocess rejected population items.
+// Without IDs the most recent pending rejects are reprocessed, up to Limit.
+type ReprocessRejectsRequest struct {
+	IDs   []uuid.UUID `json:"ids,omitempty" binding:"omitempty,max=500"`
+	Limit *int        `json:"limit,omitempty" binding:"omitempty,min=1,max=500"`
+}
+
+// ReprocessRatingsRequest represents request to re-parse the raw data of the ratings in an inclusive date range
+type ReprocessRatingsRequest struct {
+	From   string `json:"from" binding:"required,datetime=2006-01-02"`
+	To     string `json:"to" binding:"required,datetime=2006-01-02"`
+	DryRun bool   `json:"dry_run,omitempty"`
+}
+
+// PayloadArchiveFilterRequest represents filters for listing archived provider responses
+type PayloadArchiveFilterRequest struct {
+	Provider string `form:"provider" binding:"omitempty,oneof=finnhub alphavantage"`
+	Endpoint string `form:"endpoint"` // Ruta de Finnhub (/quote) o function de Alpha Vantage
+	Symbol   string `form:"symbol" binding:"omitempty,max=10"`
+	Since    string `form:"since" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00"` // RFC 3339
+}
+
+// ReplayPayloadsRequest represents request to re-process archived provider responses.
+// Without IDs the most recent payloads matching the filters are replayed, up to Limit.
+type ReplayPayloadsRequest struct {
+	IDs      []uuid.UUID `json:"ids,omitempty" binding:"omitempty,max=500"`
+	Endpoint string      `json:"endpoint,omitempty"`
+	Symbol   string      `json:"symbol,omitempty" binding:"omitempty,max=10"`
+	Since    string      `json:"since,omitempty" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	Limit    *int        `json:"limit,omitempty" binding:"omitempty,min=1,max=500"`
+}
+
+// CreatePartitionsRequest represents request to create the monthly partitions of the time-series tables
+type CreatePartitionsRequest struct {
+	MonthsAhead int `json:"months_ahead,omitempty" binding:"omitempty,min=0,max=36"`
+}
+
+// WarmCacheRequest represents request to pre-populate the cache with the most rated companies
+type WarmCacheRequest struct {
+	Limit int `json:"limit,omitempty" binding:"omitempty,min=1,max=500"`
+}
+
+// CreateBackupRequest represents request to snapshot the database to the backup bucket.
+// Without tables every configured table is exported.
+type CreateBackupRequest struct {
+	Tables []string `json:"tables,omitempty" binding:"omitempty,max=50,dive,required"`
+}
+
+// CreateParquetExportRequest represents request to export historical prices and ratings as Parquet files.
+// Without symbols every active company is exported; without years the last five years are exported.
+type CreateParquetExportRequest struct {
+	Symbols  []string `json:"symbols,omitempty" binding:"omitempty,max=500,dive,required,max=10"`
+	Datasets []string `json:"datasets,omitempty" binding:"omitempty,dive,oneof=historical_prices ratings"`
+	FromYear int      `json:"from_year,omitempty" binding:"omitempty,min=1900"`
+	ToYear   int      `json:"to_year,omitempty" binding:"omitempty,min=1900"`
+}
+
+// SaveRatingMappingRequest represents request to map a free-text brokerage rating to the canonical scale
+type SaveRatingMappingRequest struct {
+	Rating     string `json:"rating" binding:"required,max=100"` // Variante tal como la publica el brokerage ("Overweight")
+	Normalized string `json:"normalized" binding:"required,oneof=strong_buy buy hold sell strong_sell"`
+}
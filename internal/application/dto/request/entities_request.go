@@ -1,6 +1,7 @@
 package request
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/google/uuid"
@@ -24,6 +25,18 @@ type UpdateCompanyRequest struct {
 	Exchange  *string  `json:"exchange,omitempty"`
 	Logo      *string  `json:"logo,omitempty"`
 	IsActive  *bool    `json:"is_active,omitempty"`
+	Version   *int64   `json:"version,omitempty" binding:"omitempty,min=1"` // Versión leída; si difiere se responde 409
+}
+
+// RemapTickerRequest represents request to change the ticker of a company (e.g. FB -> META)
+type RemapTickerRequest struct {
+	Ticker string `json:"ticker" binding:"required,min=1,max=10"`
+}
+
+// MergeCompaniesRequest represents request to merge a duplicate company into the target company
+type MergeCompaniesRequest struct {
+	TargetID uuid.UUID `json:"target_id" binding:"required"`
+	DryRun   bool      `json:"dry_run"` // Solo calcula lo que se movería, sin aplicar cambios
 }
 
 // CreateBrokerageRequest represents request to create a brokerage
@@ -39,6 +52,7 @@ type UpdateBrokerageRequest struct {
 	Description *string `json:"description,omitempty"`
 	Website     *string `json:"website,omitempty" binding:"omitempty,url"`
 	IsActive    *bool   `json:"is_active,omitempty"`
+	Version     *int64  `json:"version,omitempty" binding:"omitempty,min=1"` // Versión leída; si difiere se responde 409
 }
 
 // CreateStockRatingRequest represents request to create a stock rating
@@ -61,6 +75,85 @@ type StockRatingFilterRequest struct {
 	RatingTo    string     `form:"rating_to"`
 	DateFrom    string     `form:"date_from" binding:"omitempty,datetime=2006-01-02"`
 	DateTo      string     `form:"date_to" binding:"omitempty,datetime=2006-01-02"`
+
+	// Parsed TargetTo; min and max compare only the targets quoted in TargetCurrency (default USD)
+	TargetMin      *float64 `form:"target_min" binding:"omitempty,gt=0"`
+	TargetMax      *float64 `form:"target_max" binding:"omitempty,gt=0"`
+	TargetCurrency string   `form:"target_currency" binding:"omitempty,len=3,alpha"`
+
+	Sort string `form:"sort" binding:"omitempty,max=200"` // field:asc|desc separados por comas; ver StockRatingSortFields
+}
+
+// HasTargetFilter reports whether the ratings must be filtered by parsed price target
+func (f *StockRatingFilterRequest) HasTargetFilter() bool {
+	return f != nil && (f.TargetMin != nil || f.TargetMax != nil || f.TargetCurrency != "")
+}
+
+// RatingSearchRequest represents the combined filters of GET /ratings. Every filter set narrows the result
+type RatingSearchRequest struct {
+	CompanyID   *uuid.UUID `form:"company_id"`
+	Ticker      string     `form:"ticker" binding:"omitempty,max=10"`
+	BrokerageID *uuid.UUID `form:"brokerage_id"`
+	ActionType  string     `form:"action_type" binding:"omitempty,max=200"` // Tipos separados por comas (upgrade,downgrade...)
+	RatingTo    string     `form:"rating_to" binding:"omitempty,max=200"`   // Ratings normalizados separados por comas
+
+	// Rango de rating normalizado, de strong_sell (más bajista) a strong_buy (más alcista)
+	MinRating string `form:"min_rating" binding:"omitempty,oneof=strong_buy buy hold sell strong_sell"`
+	MaxRating string `form:"max_rating" binding:"omitempty,oneof=strong_buy buy hold sell strong_sell"`
+
+	// Parsed TargetTo; min and max compare only the targets quoted in TargetCurrency (default USD)
+	MinTarget      *float64 `form:"min_target" binding:"omitempty,gt=0"`
+	MaxTarget      *float64 `form:"max_target" binding:"omitempty,gt=0"`
+	TargetCurrency string   `form:"target_currency" binding:"omitempty,len=3,alpha"`
+
+	// Días YYYY-MM-DD en la zona horaria de la petición, ambos incluidos
+	From string `form:"from" binding:"omitempty,datetime=2006-01-02"`
+	To   string `form:"to" binding:"omitempty,datetime=2006-01-02"`
+
+	Sort string `form:"sort" binding:"omitempty,max=200"` // field:asc|desc separados por comas; ver StockRatingSortFields
+}
+
+// HasTargetFilter reports whether the ratings must be filtered by parsed price target
+func (r *RatingSearchRequest) HasTargetFilter() bool {
+	return r != nil && (r.MinTarget != nil || r.MaxTarget != nil || r.TargetCurrency != "")
+}
+
+// RatingAsOfRequest represents the snapshot date of GET /companies/{id}/ratings/asof
+type RatingAsOfRequest struct {
+	Date string `form:"date" binding:"required,datetime=2006-01-02"` // Día incluido, en la zona horaria de la petición
+}
+
+// RatingPollRequest represents the long-poll of GET /ratings/poll
+type RatingPollRequest struct {
+	SinceID *uuid.UUID `form:"since_id"`                                // Último rating recibido; sin él solo llegan los creados tras la petición
+	Wait    int        `form:"wait" binding:"omitempty,min=1,max=25"`   // Segundos de espera sin ratings nuevos (por debajo de SERVER_WRITE_TIMEOUT)
+	Limit   int        `form:"limit" binding:"omitempty,min=1,max=500"` // Ratings devueltos como máximo
+}
+
+// RatingIncludes are the related entities embedded in rating list responses (?include=company,brokerage,notes)
+type RatingIncludes struct {
+	Company   bool
+	Brokerage bool
+	Notes     bool // Notas privadas del usuario autenticado sobre cada rating
+}
+
+// ParseRatingIncludes parses a comma-separated include list; unknown relations are an error
+func ParseRatingIncludes(value string) (RatingIncludes, error) {
+	var includes RatingIncludes
+	for _, part := range strings.Split(value, ",") {
+		switch strings.ToLower(strings.TrimSpace(part)) {
+		case "":
+		case "company":
+			includes.Company = true
+		case "brokerage":
+			includes.Brokerage = true
+		case "notes":
+			includes.Notes = true
+		default:
+			return RatingIncludes{}, fmt.Errorf("unknown include %q (allowed: company, brokerage, notes)", strings.TrimSpace(part))
+		}
+	}
+	return includes, nil
 }
 
 // CompanyFilterRequest represents filters for companies
@@ -70,12 +163,199 @@ type CompanyFilterRequest struct {
 	Sector   string `form:"sector"`
 	Exchange string `form:"exchange"`
 	IsActive *bool  `form:"is_active"`
+	Tags     string `form:"tags" binding:"omitempty,max=200"` // Slugs separados por comas: companies con todas las etiquetas
+	Sort     string `form:"sort" binding:"omitempty,max=200"` // field:asc|desc separados por comas; ver CompanySortFields
 }
 
 // BrokerageFilterRequest represents filters for brokerages
 type BrokerageFilterRequest struct {
 	Name     string `form:"name"`
 	IsActive *bool  `form:"is_active"`
+	Sort     string `form:"sort" binding:"omitempty,max=200"` // field:asc|desc separados por comas; ver BrokerageSortFields
+}
+
+// CompanySuggestRequest represents a typeahead query over company tickers and names
+type CompanySuggestRequest struct {
+	Query string `form:"q" binding:"required,min=1,max=20"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=25"`
+}
+
+// CompanyLogoRequest represents the size of a proxied company logo
+type CompanyLogoRequest struct {
+	Size int `form:"size" binding:"omitempty,min=16"` // Lado máximo en píxeles; el máximo lo fija LOGO_MAX_SIZE
+}
+
+// CompareCompaniesRequest represents a side-by-side comparison of several companies
+type CompareCompaniesRequest struct {
+	Tickers string `form:"tickers" binding:"required_without=Ticker"` // Lista separada por comas (AAPL,MSFT,NVDA), hasta 10
+	Ticker  string `form:"ticker" binding:"required_without=Tickers"` // Sin tickers: la company y sus peers
+}
+
+// CorrelationRequest represents a return correlation matrix between tickers
+type CorrelationRequest struct {
+	Tickers string `form:"tickers" binding:"required"`               // Lista separada por comas, hasta 20
+	Days    int    `form:"days" binding:"omitempty,min=10,max=1825"` // Ventana en días naturales (por defecto 90)
+}
+
+// PortfolioRiskRequest represents the risk metrics of a weighted portfolio of companies
+type PortfolioRiskRequest struct {
+	Holdings     string  `form:"holdings" binding:"required"`                      // TICKER:peso separados por comas (AAPL:60,MSFT:40); sin pesos se reparte por igual
+	Benchmark    string  `form:"benchmark" binding:"omitempty,max=10"`             // Índice de referencia para la beta (por defecto SPY)
+	Days         int     `form:"days" binding:"omitempty,min=30,max=1825"`         // Ventana en días naturales (por defecto 365)
+	RiskFreeRate float64 `form:"risk_free_rate" binding:"omitempty,min=0,max=0.2"` // Tasa libre de riesgo anual para el Sharpe (0.04 = 4%)
+}
+
+// BacktestRequest represents a simulation of a rating-following strategy over stored ratings and prices
+type BacktestRequest struct {
+	Tickers     string  `form:"tickers" binding:"required"`                                        // Lista separada por comas, hasta 20; el capital se reparte por igual
+	Strategy    string  `form:"strategy" binding:"omitempty,oneof=upgrade_downgrade upgrade_hold"` // Por defecto upgrade_downgrade
+	HoldingDays int     `form:"holding_days" binding:"omitempty,min=1,max=365"`                    // Días naturales máximos por operación (por defecto 30)
+	Capital     float64 `form:"capital" binding:"omitempty,min=100"`                               // Capital inicial (por defecto 10000)
+	DateFrom    string  `form:"date_from" binding:"omitempty,datetime=2006-01-02"`                 // Por defecto un año antes de date_to
+	DateTo      string  `form:"date_to" binding:"omitempty,datetime=2006-01-02"`                   // Por defecto hoy
+}
+
+// RatingTrendsRequest represents the rating trends over a preset period or an explicit date range
+type RatingTrendsRequest struct {
+	Period      string `form:"period" binding:"omitempty,oneof=week month quarter year"` // Últimos días del periodo si no hay from/to (por defecto month)
+	From        string `form:"from" binding:"omitempty,datetime=2006-01-02"`             // Primer día incluido, en la zona horaria de la petición
+	To          string `form:"to" binding:"omitempty,datetime=2006-01-02"`               // Último día incluido (por defecto hoy)
+	Granularity string `form:"granularity" binding:"omitempty,oneof=day week month"`     // Por defecto según la longitud del rango
+}
+
+// MarketBreadthRequest represents the daily breadth of the tracked companies over the last days
+type MarketBreadthRequest struct {
+	Days int `form:"days" binding:"omitempty,min=1,max=365"` // Días naturales hasta hoy (por defecto 30)
+}
+
+// CreateCompanyRelationshipRequest represents a declared relationship: related_company is the supplier,
+// competitor or subsidiary of the company in the path
+type CreateCompanyRelationshipRequest struct {
+	RelatedCompany string `json:"related_company" binding:"required,max=50"` // ID o ticker
+	Type           string `json:"type" binding:"required,oneof=supplier competitor subsidiary"`
+	Note           string `json:"note,omitempty" binding:"omitempty,max=500"`
+}
+
+// UpdateCompanyRelationshipRequest represents a change of the type or note of a relationship
+type UpdateCompanyRelationshipRequest struct {
+	Type string  `json:"type,omitempty" binding:"omitempty,oneof=supplier competitor subsidiary"`
+	Note *string `json:"note,omitempty" binding:"omitempty,max=500"` // "" borra la nota
+}
+
+// CompanyRelationshipsRequest represents the relationships of a company, optionally of one type
+type CompanyRelationshipsRequest struct {
+	Type string `form:"type" binding:"omitempty,oneof=supplier competitor subsidiary"`
+}
+
+// CompanyGraphRequest represents the companies reachable from a company through its relationships
+type CompanyGraphRequest struct {
+	Depth int    `form:"depth" binding:"omitempty,min=1,max=3"` // Saltos desde la company (por defecto 2)
+	Types string `form:"types" binding:"omitempty,max=100"`     // Tipos separados por comas (por defecto todos)
+}
+
+// CreateTagRequest represents a new thematic tag
+type CreateTagRequest struct {
+	Slug        string `json:"slug" binding:"omitempty,max=50"` // Por defecto se deriva del nombre ("Electric Vehicles" -> electric-vehicles)
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description" binding:"omitempty,max=500"`
+}
+
+// UpdateTagRequest represents the name and description of a tag; the slug does not change
+type UpdateTagRequest struct {
+	Name        *string `json:"name" binding:"omitempty,min=1,max=100"`
+	Description *string `json:"description" binding:"omitempty,max=500"`
+}
+
+// NoteFilterRequest represents the filters of the notes of the user
+type NoteFilterRequest struct {
+	Company  string     `form:"company" binding:"omitempty,max=36"` // ID o ticker; incluye las notas de sus ratings
+	RatingID *uuid.UUID `form:"rating_id"`
+}
+
+// CreateNoteRequest represents a new private note on a company or on one of its ratings
+type CreateNoteRequest struct {
+	Company  string     `json:"company" binding:"omitempty,max=36"` // ID o ticker; con rating_id basta el rating
+	RatingID *uuid.UUID `json:"rating_id"`
+	Body     string     `json:"body" binding:"required,max=5000"`
+}
+
+// UpdateNoteRequest represents the new body of a note
+type UpdateNoteRequest struct {
+	Body string `json:"body" binding:"required,max=5000"`
+}
+
+// MarketCapStatsRequest represents the market cap statistics of the active companies in one currency
+type MarketCapStatsRequest struct {
+	GroupBy  string `form:"group_by" binding:"omitempty,oneof=exchange currency"` // Sin agrupar por defecto
+	Currency string `form:"currency" binding:"omitempty,len=3,alpha"`             // Moneda del resultado (por defecto USD)
+}
+
+// SectorRotationRequest represents the upgrade/downgrade balance per sector over the last months
+type SectorRotationRequest struct {
+	Months int    `form:"months" binding:"omitempty,min=2,max=60"` // Meses incluidos (por defecto 12)
+	To     string `form:"to" binding:"omitempty,datetime=2006-01"` // Último mes incluido, YYYY-MM (por defecto el actual)
+}
+
+// AnomalyRequest represents a query over the detected rating and volume anomalies
+type AnomalyRequest struct {
+	Days  int    `form:"days" binding:"omitempty,min=1,max=90"`                 // Últimos días (por defecto 7)
+	Type  string `form:"type" binding:"omitempty,oneof=rating_activity volume"` // Vacío devuelve ambos tipos
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=200"`               // Por defecto 50
+}
+
+// MarketStatusRequest represents a query for the current trading session of an exchange
+type MarketStatusRequest struct {
+	Exchange string `form:"exchange" binding:"omitempty,max=50"` // NYSE, NASDAQ, AMEX o US (por defecto NYSE)
+}
+
+// DashboardRequest represents the home screen summary query
+type DashboardRequest struct {
+	AlertsSince string `form:"alerts_since" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00"` // Última visita del cliente (RFC 3339); por defecto el inicio de hoy
+}
+
+// FinancialStatementRequest represents a query for the reported financial statements of a company
+type FinancialStatementRequest struct {
+	Statement string `form:"statement" binding:"omitempty,oneof=income balance cash_flow"` // Por defecto income
+	Period    string `form:"period" binding:"omitempty,oneof=annual quarterly"`            // Por defecto quarterly
+	Limit     int    `form:"limit" binding:"omitempty,min=1,max=40"`                       // Periodos devueltos (por defecto 8)
+}
+
+// BenchmarkValuesRequest represents a query for the daily values of a benchmark
+type BenchmarkValuesRequest struct {
+	From string `form:"from" binding:"omitempty,datetime=2006-01-02"` // Por defecto un año antes de to
+	To   string `form:"to" binding:"omitempty,datetime=2006-01-02"`   // Por defecto hoy
+}
+
+// BenchmarkConstituentsRequest represents a query for the members of a benchmark on a date
+type BenchmarkConstituentsRequest struct {
+	Date string `form:"date" binding:"omitempty,datetime=2006-01-02"` // Por defecto hoy
+}
+
+// CreateCustomBenchmarkRequest represents the definition of a custom index from registered tickers
+type CreateCustomBenchmarkRequest struct {
+	Symbol    string   `json:"symbol" binding:"required,alphanum,min=2,max=10"`
+	Name      string   `json:"name" binding:"required,min=1,max=100"`
+	Tickers   []string `json:"tickers" binding:"required,min=2,max=50,dive,required,max=10"`
+	Weighting string   `json:"weighting,omitempty" binding:"omitempty,oneof=equal cap"`     // Por defecto equal
+	BaseDate  string   `json:"base_date,omitempty" binding:"omitempty,datetime=2006-01-02"` // Por defecto un año antes de hoy
+}
+
+// BenchmarkSyncRequest represents an ingestion of a benchmark from the provider
+type BenchmarkSyncRequest struct {
+	Full bool `form:"full"` // Historia completa en lugar de las últimas 100 sesiones
+}
+
+// TrendingRequest represents a query for the most requested symbols
+type TrendingRequest struct {
+	Hours int `form:"hours" binding:"omitempty,min=1,max=168"` // Ventana en horas (por defecto 24)
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"` // Por defecto 20
+}
+
+// SearchRequest represents a full-text search over companies and news
+type SearchRequest struct {
+	Query string `form:"q" binding:"required,min=2,max=100"`
+	Types string `form:"types"` // Lista separada por comas (company,news); vacío busca en todos
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=50"`
 }
 
 // PopulateDatabaseRequest represents request to populate database
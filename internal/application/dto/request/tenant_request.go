@@ -0,0 +1,34 @@
+package request
+
+import "time"
+
+// CreateTenantRequest represents request to create a tenant
+type CreateTenantRequest struct {
+	Name string `json:"name" binding:"required,min=2,max=100"`
+	Slug string `json:"slug" binding:"required,min=2,max=50"`              // Minúsculas, dígitos y guiones
+	Plan string `json:"plan,omitempty" binding:"omitempty,oneof=free pro"` // free por defecto
+}
+
+// UpdateTenantRequest represents request to activate or deactivate a tenant or to change its plan
+type UpdateTenantRequest struct {
+	Active *bool   `json:"active,omitempty"`
+	Plan   *string `json:"plan,omitempty" binding:"omitempty,oneof=free pro"`
+}
+
+// CreateAPIKeyRequest represents request to create an API key for a tenant
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" binding:"required,min=1,max=100"`
+	Role      string     `json:"role,omitempty" binding:"omitempty,oneof=viewer editor admin"` // viewer por defecto; no puede superar el rol de quien la crea
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`                                         // Sin fecha la clave no caduca
+	Timezone  string     `json:"timezone,omitempty" binding:"omitempty,timezone"`              // Zona horaria IANA del usuario de la clave
+}
+
+// SetAPIKeyTimezoneRequest changes the time zone preference of an API key; empty clears it
+type SetAPIKeyTimezoneRequest struct {
+	Timezone string `json:"timezone" binding:"omitempty,timezone"`
+}
+
+// UsageRequest represents a query for the API usage of the last days
+type UsageRequest struct {
+	Days int `form:"days" binding:"omitempty,min=1,max=90"` // Días UTC incluido el actual (por defecto 7)
+}
@@ -0,0 +1,425 @@
+package response
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+// PopulationJobResponse represents the state of an asynchronous population run
+type PopulationJobResponse struct {
+	ID         uuid.UUID                 `json:"id"`
+	Status     string                    `json:"status"`
+	Config     PopulationConfigResponse  `json:"config"`
+	Result     *PopulationResultResponse `json:"result,omitempty"`
+	Error      string                    `json:"error,omitempty"`
+	CreatedAt  time.Time                 `json:"created_at"`
+	StartedAt  *time.Time                `json:"started_at,omitempty"`
+	FinishedAt *time.Time                `json:"finished_at,omitempty"`
+}
+
+// PopulationConfigResponse represents the configuration used by a population run
+type PopulationConfigResponse struct {
+	BatchSize     int   `json:"batch_size"`
+	MaxPages      int   `json:"max_pages"`
+	DelayMs       int64 `json:"delay_ms"`
+	ClearFirst    bool  `json:"clear_first"`
+	UseCache      bool  `json:"use_cache"`
+	DryRun        bool  `json:"dry_run"`
+	ValidateAfter bool  `json:"validate_after"`
+	Incremental   bool  `json:"incremental"`
+}
+
+// PopulationResultResponse represents the (partial or final) result of a population run
+type PopulationResultResponse struct {
+	TotalPages     int      `json:"total_pages"`
+	PagesRequested int      `json:"pages_requested"`
+	TotalItems     int      `json:"total_items"`
+	ProcessedItems int      `json:"processed_items"`
+	SkippedItems   int      `json:"skipped_items"`
+	ErrorCount     int      `json:"error_count"`
+	Companies      int      `json:"companies"`
+	Brokerages     int      `json:"brokerages"`
+	StockRatings   int      `json:"stock_ratings"`
+	DurationMs     int64    `json:"duration_ms"`
+	Errors         []string `json:"errors,omitempty"`
+	RejectedItems  int      `json:"rejected_items"`
+
+	RuleViolations   map[string]int `json:"rule_violations,omitempty"` // Items desviados por cada regla de ingesta
+	ReachedWatermark bool           `json:"reached_watermark"`
+}
+
+// IngestionRuleResponse represents an ingestion validation rule with its configured action and metrics
+type IngestionRuleResponse struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Action      string `json:"action"`
+	Evaluated   int64  `json:"evaluated"`
+	Violations  int64  `json:"violations"`
+	Rejected    int64  `json:"rejected"`
+	Quarantined int64  `json:"quarantined"`
+	Corrected   int64  `json:"corrected"`
+}
+
+// IngestionRulesResponse lists the ingestion rules; the counters are accumulated since the server started
+type IngestionRulesResponse struct {
+	Enabled bool                    `json:"enabled"`
+	Rules   []IngestionRuleResponse `json:"rules"`
+}
+
+// IntegrityTrendResponse compares the latest integrity snapshot of the window with the first one
+type IntegrityTrendResponse struct {
+	Direction            string `json:"direction"` // improving, degrading, stable o insufficient_data
+	TotalIssuesChange    int    `json:"total_issues_change"`
+	CriticalIssuesChange int    `json:"critical_issues_change"`
+	WarningIssuesChange  int    `json:"warning_issues_change"`
+}
+
+// IntegrityHistoryResponse lists the integrity snapshots of the last days, oldest first
+type IntegrityHistoryResponse struct {
+	Days      int                           `json:"days"`
+	From      time.Time                     `json:"from"`
+	Latest    *entities.IntegritySnapshot   `json:"latest,omitempty"`
+	Trend     IntegrityTrendResponse        `json:"trend"`
+	Snapshots []*entities.IntegritySnapshot `json:"snapshots"`
+}
+
+// IntegrityRepairActionResponse is a record removed, merged or fixed by the integrity repair
+type IntegrityRepairActionResponse struct {
+	Action          string     `json:"action"` // delete, merge o update
+	Entity          string     `json:"entity"` // stock_rating, company o brokerage
+	ID              uuid.UUID  `json:"id"`
+	TargetID        *uuid.UUID `json:"target_id,omitempty"` // Registro que se conserva en los duplicados
+	Issue           string     `json:"issue"`
+	Changes         string     `json:"changes,omitempty"`
+	RelinkedRatings int64      `json:"relinked_ratings,omitempty"`
+}
+
+// IntegrityRepairIssueResponse is an issue the integrity repair could not fix
+type IntegrityRepairIssueResponse struct {
+	Type        string    `json:"type"`
+	ID          uuid.UUID `json:"id"`
+	Description string    `json:"description"`
+	Reason      string    `json:"reason"`
+}
+
+// IntegrityRepairResponse lists the records changed by an integrity repair, or that would be changed in dry run
+type IntegrityRepairResponse struct {
+	DryRun               bool                            `json:"dry_run"`
+	Status               string                          `json:"status"`
+	PlanToken            string                          `json:"plan_token,omitempty"` // Solo en dry run: se envía como approval_token para aplicar el plan
+	RepairedOrphans      int                             `json:"repaired_orphans"`
+	RemovedDuplicates    int                             `json:"removed_duplicates"`
+	FixedInconsistencies int                             `json:"fixed_inconsistencies"`
+	TotalRepairs         int                             `json:"total_repairs"`
+	Actions              []IntegrityRepairActionResponse `json:"actions"`
+	UnrepairableIssues   []IntegrityRepairIssueResponse  `json:"unrepairable_issues"`
+}
+
+// JobResponse represents a background job from the job queue
+type JobResponse struct {
+	ID              uuid.UUID       `json:"id"`
+	Type            string          `json:"type"`
+	Status          string          `json:"status"`
+	Payload         json.RawMessage `json:"payload,omitempty" swaggertype:"object"`
+	Result          json.RawMessage `json:"result,omitempty" swaggertype:"object"`
+	Error           string          `json:"error,omitempty"`
+	Attempts        int             `json:"attempts"`
+	MaxAttempts     int             `json:"max_attempts"`
+	CancelRequested bool            `json:"cancel_requested"`
+	RunAt           time.Time       `json:"run_at"`
+	StartedAt       *time.Time      `json:"started_at,omitempty"`
+	FinishedAt      *time.Time      `json:"finished_at,omitempty"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+}
+
+// PopulationRejectResponse represents an item rejected by the population pipeline (dead-letter)
+type PopulationRejectResponse struct {
+	ID            uuid.UUID       `json:"id"`
+	Source        string          `json:"source"`
+	Stage         string          `json:"stage"`
+	Reason        string          `json:"reason"`
+	Status        string          `json:"status"`
+	Ticker        string          `json:"ticker,omitempty"`
+	Brokerage     string          `json:"brokerage,omitempty"`
+	RawPayload    json.RawMessage `json:"raw_payload" swaggertype:"object"`
+	Error         string          `json:"error,omitempty"`
+	Occurrences   int             `json:"occurrences"`
+	Attempts      int             `json:"attempts"`
+	LastSeenAt    time.Time       `json:"last_seen_at"`
+	LastAttemptAt *time.Time      `json:"last_attempt_at,omitempty"`
+	ResolvedAt    *time.Time      `json:"resolved_at,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// EnrichmentConflictResponse represents a Company field that disagrees with its stored provider profile
+type EnrichmentConflictResponse struct {
+	ID           uuid.UUID  `json:"id"`
+	CompanyID    uuid.UUID  `json:"company_id"`
+	Ticker       string     `json:"ticker"`
+	Field        string     `json:"field"`
+	Status       string     `json:"status"`
+	CompanyValue string     `json:"company_value"`
+	ProfileValue string     `json:"profile_value"`
+	Source       string     `json:"source"`
+	Occurrences  int        `json:"occurrences"`
+	LastSeenAt   time.Time  `json:"last_seen_at"`
+	ResolvedAt   *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// ReprocessRejectsResponse represents the outcome of reprocessing rejected population items
+type ReprocessRejectsResponse struct {
+	Requested   int      `json:"requested"`
+	Reprocessed int      `json:"reprocessed"`
+	Failed      int      `json:"failed"`
+	Skipped     int      `json:"skipped"`
+	Errors      []string `json:"errors,omitempty"`
+}
+
+// ProviderPayloadResponse represents a raw provider response kept in the payload archive
+type ProviderPayloadResponse struct {
+	ID        uuid.UUID       `json:"id"`
+	Provider  string          `json:"provider"`
+	Endpoint  string          `json:"endpoint"`
+	Symbol    string          `json:"symbol,omitempty"`
+	Params    string          `json:"params,omitempty"`
+	RequestID string          `json:"request_id,omitempty"`
+	Size      int             `json:"size"`
+	Payload   json.RawMessage `json:"payload,omitempty" swaggertype:"object"` // Solo al consultar un payload concreto
+	FetchedAt time.Time       `json:"fetched_at"`
+	ExpiresAt time.Time       `json:"expires_at"`
+}
+
+// ReplayPayloadsResponse represents the outcome of replaying archived provider responses
+type ReplayPayloadsResponse struct {
+	Requested int      `json:"requested"`
+	Replayed  int      `json:"replayed"`
+	Failed    int      `json:"failed"`
+	Skipped   int      `json:"skipped"`
+	Errors    []string `json:"errors,omitempty"`
+}
+
+// SlowQueryResponse represents a query that exceeded the slow-query threshold
+type SlowQueryResponse struct {
+	SQL        string    `json:"sql"`
+	DurationMs float64   `json:"duration_ms"`
+	Rows       int64     `json:"rows"`
+	RequestID  string    `json:"request_id,omitempty"`
+	Source     string    `json:"source,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// SlowQueriesResponse represents the slow-query diagnostics of the database connection
+type SlowQueriesResponse struct {
+	Enabled       bool                   `json:"enabled"`
+	ThresholdMs   int64                  `json:"threshold_ms"`
+	TotalRecorded int64                  `json:"total_recorded"`
+	Pool          map[string]interface{} `json:"pool"`
+	PoolStats     map[string]interface{} `json:"pool_stats,omitempty"`
+	Queries       []SlowQueryResponse    `json:"queries"`
+}
+
+// AnalyticsRefreshResponse represents the outcome of refreshing the analytics views
+type AnalyticsRefreshResponse struct {
+	Views       []string  `json:"views"`
+	RefreshedAt time.Time `json:"refreshed_at"`
+	DurationMs  int64     `json:"duration_ms"`
+}
+
+// PartitionTableResponse represents the monthly partitions of one time-series table
+type PartitionTableResponse struct {
+	Table      string   `json:"table"`
+	Column     string   `json:"column"`
+	Partitions []string `json:"partitions"`
+	Created    []string `json:"created"`
+	Skipped    string   `json:"skipped,omitempty"`
+}
+
+// PartitionMaintenanceResponse represents the outcome of a partition maintenance run
+type PartitionMaintenanceResponse struct {
+	MonthsAhead int                      `json:"months_ahead"`
+	Tables      []PartitionTableResponse `json:"tables"`
+}
+
+// ConfigReloadResponse represents the outcome of a hot configuration reload
+type ConfigReloadResponse struct {
+	Changed    []string          `json:"changed"`
+	Applied    []string          `json:"applied"`
+	Failed     map[string]string `json:"failed,omitempty"`
+	ReloadedAt time.Time         `json:"reloaded_at"`
+}
+
+// CompanyMergeResponse represents the outcome (or dry-run preview) of merging a duplicate company into a target
+type CompanyMergeResponse struct {
+	Duplicate         *CompanyResponse `json:"duplicate"`
+	Target            *CompanyResponse `json:"target"`
+	DryRun            bool             `json:"dry_run"`
+	RatingsMoved      int64            `json:"ratings_moved"`
+	RatingsDuplicated int64            `json:"ratings_duplicated"`
+	MarketDataMoved   int64            `json:"market_data_moved"`
+	ProfileMoved      bool             `json:"profile_moved"`
+	NewsMoved         int64            `json:"news_moved"`
+	AliasesMoved      int64            `json:"aliases_moved"`
+}
+
+// CacheWarmResponse represents the outcome of a cache warm-up
+type CacheWarmResponse struct {
+	Requested         int      `json:"requested"`
+	CompaniesWarmed   int      `json:"companies_warmed"`
+	MarketDataWarmed  int      `json:"market_data_warmed"`
+	MarketDataMissing int      `json:"market_data_missing"`
+	Failed            int      `json:"failed"`
+	Errors            []string `json:"errors,omitempty"`
+	DurationMs        int64    `json:"duration_ms"`
+}
+
+// BackupResponse represents a database snapshot stored in the backup bucket (it is also its manifest.json)
+type BackupResponse struct {
+	ID            string                `json:"id"`
+	Format        string                `json:"format"`
+	SchemaVersion uint                  `json:"schema_version"`
+	Tables        []BackupTableResponse `json:"tables"`
+	TotalRows     int64                 `json:"total_rows"`
+	TotalBytes    int64                 `json:"total_bytes"`
+	StartedAt     time.Time             `json:"started_at"`
+	CompletedAt   time.Time             `json:"completed_at"`
+	DurationMs    int64                 `json:"duration_ms"`
+}
+
+// BackupTableResponse represents the export of one table inside a snapshot
+type BackupTableResponse struct {
+	Table   string `json:"table"`
+	Key     string `json:"key,omitempty"`
+	Rows    int64  `json:"rows"`
+	Bytes   int64  `json:"bytes"`
+	SHA256  string `json:"sha256,omitempty"`
+	Skipped string `json:"skipped,omitempty"` // Motivo si la tabla no se exportó (p. ej. no existe)
+}
+
+// RestoreDryRunResponse represents what restoring a snapshot would do, without writing anything
+type RestoreDryRunResponse struct {
+	BackupID              string                       `json:"backup_id"`
+	BackupSchemaVersion   uint                         `json:"backup_schema_version"`
+	CurrentSchemaVersion  uint                         `json:"current_schema_version"`
+	SchemaVersionMismatch bool                         `json:"schema_version_mismatch"`
+	Restorable            bool                         `json:"restorable"`
+	Tables                []RestoreDryRunTableResponse `json:"tables"`
+}
+
+// RestoreDryRunTableResponse represents the check of one exported table against the current database
+type RestoreDryRunTableResponse struct {
+	Table          string   `json:"table"`
+	BackupRows     int64    `json:"backup_rows"`
+	CurrentRows    int64    `json:"current_rows"`
+	InvalidRows    int64    `json:"invalid_rows"`
+	ChecksumValid  bool     `json:"checksum_valid"`
+	UnknownColumns []string `json:"unknown_columns,omitempty"` // En el backup pero no en la tabla: el restore fallaría
+	MissingColumns []string `json:"missing_columns,omitempty"` // En la tabla pero no en el backup: tomarían su valor por defecto
+	Problems       []string `json:"problems,omitempty"`
+}
+
+// SeedResponse summarizes the synthetic data written by the seed command
+type SeedResponse struct {
+	Companies         int       `json:"companies"`          // Creadas
+	ExistingCompanies int       `json:"existing_companies"` // Ya existían y se reutilizan
+	Brokerages        int       `json:"brokerages"`
+	Ratings           int       `json:"ratings"` // Insertados; los duplicados de una ejecución anterior se ignoran
+	MarketData        int       `json:"market_data"`
+	HistoricalPrices  int       `json:"historical_prices"`
+	StartedAt         time.Time `json:"started_at"`
+	CompletedAt       time.Time `json:"completed_at"`
+	DurationMs        int64     `json:"duration_ms"`
+}
+
+// ParquetExportResponse represents a Parquet export of historical prices and ratings
+type ParquetExportResponse struct {
+	Destination string                      `json:"destination"` // local o s3
+	Prefix      string                      `json:"prefix"`
+	Datasets    []string                    `json:"datasets"`
+	FromYear    int                         `json:"from_year"`
+	ToYear      int                         `json:"to_year"`
+	Symbols     int                         `json:"symbols"`
+	Processed   int                         `json:"processed"`
+	Files       []ParquetExportFileResponse `json:"files"`
+	TotalRows   int64                       `json:"total_rows"`
+	TotalBytes  int64                       `json:"total_bytes"`
+	Errors      []string                    `json:"errors,omitempty"`
+	StartedAt   time.Time                   `json:"started_at"`
+	CompletedAt time.Time                   `json:"completed_at,omitempty"`
+	DurationMs  int64                       `json:"duration_ms"`
+}
+
+// ParquetExportFileResponse represents one Parquet file (one dataset, symbol and year)
+type ParquetExportFileResponse struct {
+	Dataset string `json:"dataset"`
+	Symbol  string `json:"symbol"`
+	Year    int    `json:"year"`
+	Key     string `json:"key"`
+	Rows    int64  `json:"rows"`
+	Bytes   int64  `json:"bytes"`
+}
+
+// RatingMappingResponse represents the mapping of a free-text rating variant to the canonical scale
+type RatingMappingResponse struct {
+	Rating     string    `json:"rating"`
+	Normalized string    `json:"normalized"`
+	Label      string    `json:"label"` // Strong Buy, Buy, Hold, Sell o Strong Sell
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// UnmappedRatingResponse represents a rating variant without mapping and the ratings that use it
+type UnmappedRatingResponse struct {
+	Rating string `json:"rating"`
+	Count  int64  `json:"count"`
+}
+
+// RatingTaxonomyResponse represents the mapping table and the variants still without mapping
+type RatingTaxonomyResponse struct {
+	Scale    []string                 `json:"scale"` // De strong_buy a strong_sell
+	Mappings []RatingMappingResponse  `json:"mappings"`
+	Unmapped []UnmappedRatingResponse `json:"unmapped"`
+}
+
+// RatingNormalizationResponse reports the re-normalization of the stored ratings after a change of the mappings
+type RatingNormalizationResponse struct {
+	Mappings int                      `json:"mappings"`
+	Updated  int64                    `json:"updated"` // Columnas normalizadas cambiadas
+	Unmapped []UnmappedRatingResponse `json:"unmapped"`
+}
+
+// ProcessingStatusResponse represents the backlog of the rating processing job and its recent throughput
+type ProcessingStatusResponse struct {
+	Unprocessed         int64                        `json:"unprocessed"`
+	BySource            map[string]int64             `json:"by_source"`                       // Pendientes por fuente del rating
+	OldestUnprocessedAt *time.Time                   `json:"oldest_unprocessed_at,omitempty"` // Guardado del rating pendiente más antiguo
+	OldestAgeMs         int64                        `json:"oldest_age_ms"`
+	Threshold           int64                        `json:"threshold"` // 0 si la alerta está desactivada
+	OverThreshold       bool                         `json:"over_threshold"`
+	Throughput          []ProcessingThroughputWindow `json:"throughput"`
+}
+
+// ProcessingThroughputWindow counts the ratings processed in a recent window
+type ProcessingThroughputWindow struct {
+	Window    string  `json:"window"` // 15m, 1h, 24h
+	Processed int64   `json:"processed"`
+	PerMinute float64 `json:"per_minute"`
+}
+
+// RatingProcessingResponse reports a run of the rating processing job
+type RatingProcessingResponse struct {
+	BacklogBefore int64 `json:"backlog_before"`
+	BacklogAfter  int64 `json:"backlog_after"`
+	Batches       int   `json:"batches"`
+	Processed     int   `json:"processed"`  // Marcados como procesados
+	Normalized    int   `json:"normalized"` // Procesados cuya normalización cambió alguna columna
+	Failed        int   `json:"failed"`     // Siguen pendientes para la próxima ejecución
+	Events        int   `json:"events"`     // Eventos rating.processed publicados
+	Alerted       bool  `json:"alerted"`    // El backlog superó el umbral: se publicó rating.backlog_exceeded
+	DurationMs    int64 `json:"duration_ms"`
+}
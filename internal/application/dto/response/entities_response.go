@@ -16,8 +16,33 @@ type CompanyResponse struct {
 	Exchange  string    `json:"exchange,omitempty"`
 	Logo      string    `json:"logo,omitempty"`
 	IsActive  bool      `json:"is_active"`
+	Version   int64     `json:"version"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+
+	PreviousTickers []string `json:"previous_tickers,omitempty"` // Tickers anteriores que siguen resolviendo a esta company
+
+	// Solo con ?include=notes: notas del usuario sobre la company y sus ratings
+	Notes []NoteResponse `json:"notes,omitempty"`
+}
+
+// CompanyLogoResponse is a company logo served by the logo proxy
+type CompanyLogoResponse struct {
+	Data        []byte
+	ContentType string // image/png, o image/svg+xml si el logo original es SVG
+	ETag        string
+}
+
+// CompanyOverviewResponse gathers everything the company detail page shows in a single response
+type CompanyOverviewResponse struct {
+	Company       *CompanyResponse           `json:"company"`
+	Profile       *CompanyProfileResponse    `json:"profile,omitempty"`
+	Quote         *MarketDataResponse        `json:"quote,omitempty"`
+	Financials    *BasicFinancialsResponse   `json:"financials,omitempty"`
+	RecentRatings []*StockRatingListResponse `json:"recent_ratings"`
+	News          []*NewsResponse            `json:"news"`
+	Unavailable   map[string]string          `json:"unavailable,omitempty"` // Secciones que no se pudieron obtener y el motivo
+	GeneratedAt   time.Time                  `json:"generated_at"`
 }
 
 // CompanyListResponse represents a simplified company for list views
@@ -31,6 +56,13 @@ type CompanyListResponse struct {
 	IsActive bool      `json:"is_active"`
 }
 
+// CompanySuggestionResponse represents a typeahead suggestion
+type CompanySuggestionResponse struct {
+	ID     uuid.UUID `json:"id"`
+	Ticker string    `json:"ticker"`
+	Name   string    `json:"name"`
+}
+
 // BrokerageResponse represents a brokerage in API responses
 type BrokerageResponse struct {
 	ID          uuid.UUID `json:"id"`
@@ -38,38 +70,112 @@ type BrokerageResponse struct {
 	Description string    `json:"description,omitempty"`
 	Website     string    `json:"website,omitempty"`
 	IsActive    bool      `json:"is_active"`
+	Version     int64     `json:"version"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
 // StockRatingResponse represents a stock rating in API responses
 type StockRatingResponse struct {
-	ID          uuid.UUID          `json:"id"`
-	CompanyID   uuid.UUID          `json:"company_id"`
-	BrokerageID uuid.UUID          `json:"brokerage_id"`
-	Company     *CompanyResponse   `json:"company,omitempty"`
-	Brokerage   *BrokerageResponse `json:"brokerage,omitempty"`
-	Action      string             `json:"action"`
-	RatingFrom  string             `json:"rating_from,omitempty"`
-	RatingTo    string             `json:"rating_to,omitempty"`
-	TargetFrom  string             `json:"target_from,omitempty"`
-	TargetTo    string             `json:"target_to,omitempty"`
-	EventTime   time.Time          `json:"event_time"`
-	CreatedAt   time.Time          `json:"created_at"`
-	UpdatedAt   time.Time          `json:"updated_at"`
+	ID              uuid.UUID          `json:"id"`
+	CompanyID       uuid.UUID          `json:"company_id"`
+	BrokerageID     uuid.UUID          `json:"brokerage_id"`
+	Company         *CompanyResponse   `json:"company,omitempty"`
+	Brokerage       *BrokerageResponse `json:"brokerage,omitempty"`
+	Action          string             `json:"action"`
+	ActionType      string             `json:"action_type,omitempty"` // upgrade, downgrade, reiterate, initiate, target_raised...
+	RatingFrom      string             `json:"rating_from,omitempty"`
+	RatingTo        string             `json:"rating_to,omitempty"`
+	NormalizedFrom  string             `json:"normalized_rating_from,omitempty"` // Escala canónica: strong_buy, buy, hold, sell o strong_sell
+	NormalizedTo    string             `json:"normalized_rating_to,omitempty"`
+	TargetFrom      string             `json:"target_from,omitempty"`
+	TargetTo        string             `json:"target_to,omitempty"`
+	TargetFromValue *float64           `json:"target_from_value,omitempty"` // Parsed targets in major units of TargetCurrency
+	TargetToValue   *float64           `json:"target_to_value,omitempty"`
+	TargetCurrency  string             `json:"target_currency,omitempty"`
+	EventTime       time.Time          `json:"event_time"`
+	Version         int64              `json:"version"`
+	CreatedAt       time.Time          `json:"created_at"`
+	UpdatedAt       time.Time          `json:"updated_at"`
 }
 
 // StockRatingListResponse represents a simplified stock rating for list views
 type StockRatingListResponse struct {
+	ID             uuid.UUID `json:"id"`
+	CompanyID      uuid.UUID `json:"company_id"`
+	BrokerageID    uuid.UUID `json:"brokerage_id"`
+	Ticker         string    `json:"ticker"`
+	Company        string    `json:"company_name"`
+	Brokerage      string    `json:"brokerage_name"`
+	Action         string    `json:"action"`
+	ActionType     string    `json:"action_type,omitempty"`
+	RatingTo       string    `json:"rating_to,omitempty"`
+	TargetTo       string    `json:"target_to,omitempty"`
+	TargetToValue  *float64  `json:"target_to_value,omitempty"`
+	TargetCurrency string    `json:"target_currency,omitempty"`
+	EventTime      time.Time `json:"event_time"`
+
+	// Solo con ?include=company / ?include=brokerage / ?include=notes
+	CompanySummary   *CompanySummaryResponse   `json:"company,omitempty"`
+	BrokerageSummary *BrokerageSummaryResponse `json:"brokerage,omitempty"`
+	Notes            []NoteResponse            `json:"notes,omitempty"`
+}
+
+// RatingAsOfResponse represents the latest rating of each brokerage for a company up to a date
+type RatingAsOfResponse struct {
+	CompanyID uuid.UUID                  `json:"company_id"`
+	Ticker    string                     `json:"ticker"`
+	Date      string                     `json:"date"`    // Día incluido, en la zona horaria de la petición
+	Ratings   []*StockRatingListResponse `json:"ratings"` // Uno por brokerage, los más recientes primero
+}
+
+// RatingPollResponse represents the ratings returned by the long-poll, oldest first
+type RatingPollResponse struct {
+	Ratings     []*StockRatingListResponse `json:"ratings"`
+	NextSinceID *uuid.UUID                 `json:"next_since_id,omitempty"` // since_id de la siguiente petición
+	TimedOut    bool                       `json:"timed_out"`               // La espera terminó sin ratings nuevos
+}
+
+// CompanySummaryResponse is the company embedded in a rating with ?include=company
+type CompanySummaryResponse struct {
 	ID        uuid.UUID `json:"id"`
-	CompanyID uuid.UUID `json:"company_id"`
 	Ticker    string    `json:"ticker"`
-	Company   string    `json:"company_name"`
-	Brokerage string    `json:"brokerage_name"`
-	Action    string    `json:"action"`
-	RatingTo  string    `json:"rating_to,omitempty"`
-	TargetTo  string    `json:"target_to,omitempty"`
-	EventTime time.Time `json:"event_time"`
+	Name      string    `json:"name"`
+	Sector    string    `json:"sector,omitempty"`
+	Exchange  string    `json:"exchange,omitempty"`
+	MarketCap float64   `json:"market_cap,omitempty"`
+	Logo      string    `json:"logo,omitempty"`
+}
+
+// BrokerageSummaryResponse is the brokerage embedded in a rating with ?include=brokerage
+type BrokerageSummaryResponse struct {
+	ID      uuid.UUID `json:"id"`
+	Name    string    `json:"name"`
+	Website string    `json:"website,omitempty"`
+	Country string    `json:"country,omitempty"`
+}
+
+// DeletedCompanyResponse represents a soft-deleted company in the admin view
+type DeletedCompanyResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Ticker    string    `json:"ticker"`
+	Name      string    `json:"name"`
+	Sector    string    `json:"sector,omitempty"`
+	Exchange  string    `json:"exchange,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// DeletedStockRatingResponse represents a soft-deleted stock rating in the admin view
+type DeletedStockRatingResponse struct {
+	ID          uuid.UUID `json:"id"`
+	CompanyID   uuid.UUID `json:"company_id"`
+	BrokerageID uuid.UUID `json:"brokerage_id"`
+	Action      string    `json:"action"`
+	RatingFrom  string    `json:"rating_from,omitempty"`
+	RatingTo    string    `json:"rating_to,omitempty"`
+	EventTime   time.Time `json:"event_time"`
+	DeletedAt   time.Time `json:"deleted_at"`
 }
 
 // HealthCheckResponse represents health check status
@@ -95,6 +201,538 @@ type AnalysisResponse struct {
 	TotalRatings   int                       `json:"total_ratings"`
 	RecentRatings  []StockRatingListResponse `json:"recent_ratings"`
 	Recommendation string                    `json:"recommendation"`
-	Summary        map[string]interface{}    `json:"summary"`
-	GeneratedAt    time.Time                 `json:"generated_at"`
+	Label          string                    `json:"recommendation_label,omitempty"` // Recommendation en el idioma de Accept-Language
+	// RecommendationScore es la puntuación compuesta de GET /analysis/recommendations/companies/{id} (-1 a 1; nil sin datos)
+	RecommendationScore *float64               `json:"recommendation_score"`
+	Summary             map[string]interface{} `json:"summary"`
+	GeneratedAt         time.Time              `json:"generated_at"`
+}
+
+// RecommendationResponse represents a recommendation with the breakdown of its composite score
+type RecommendationResponse struct {
+	CompanyID      uuid.UUID              `json:"company_id"`
+	Ticker         string                 `json:"ticker"`
+	Recommendation string                 `json:"recommendation"`
+	Label          string                 `json:"recommendation_label,omitempty"` // Recommendation en el idioma de Accept-Language
+	Score          *float64               `json:"score"`                          // De -1 (sell) a 1 (buy); null sin datos
+	Factors        []RecommendationFactor `json:"factors"`
+	GeneratedAt    time.Time              `json:"generated_at"`
+}
+
+// RecommendationFactor represents one factor of the recommendation model. Score is null without data
+type RecommendationFactor struct {
+	Name         string             `json:"name"`
+	Weight       float64            `json:"weight"`
+	Score        *float64           `json:"score"`        // De -1 a 1
+	Contribution *float64           `json:"contribution"` // Aporte al score compuesto tras renormalizar los pesos
+	Details      map[string]float64 `json:"details,omitempty"`
+}
+
+// CompanyPeersResponse represents the competitors of a company, closest first
+type CompanyPeersResponse struct {
+	CompanyID    uuid.UUID      `json:"company_id"`
+	Ticker       string         `json:"ticker"`
+	Source       string         `json:"source,omitempty"` // finnhub o heuristic
+	Peers        []PeerResponse `json:"peers"`
+	DiscoveredAt *time.Time     `json:"discovered_at,omitempty"`
+}
+
+// CompanyRelationshipResponse represents a declared relationship: RelatedCompany is the supplier, competitor or
+// subsidiary of Company
+type CompanyRelationshipResponse struct {
+	ID             uuid.UUID           `json:"id"`
+	Type           string              `json:"type"`
+	Direction      string              `json:"direction,omitempty"` // outgoing o incoming respecto a la company consultada
+	Company        CompanyListResponse `json:"company"`
+	RelatedCompany CompanyListResponse `json:"related_company"`
+	Note           string              `json:"note,omitempty"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+}
+
+// CompanyRelationshipsResponse represents the relationships that start or end at a company
+type CompanyRelationshipsResponse struct {
+	CompanyID     uuid.UUID                     `json:"company_id"`
+	Ticker        string                        `json:"ticker"`
+	Relationships []CompanyRelationshipResponse `json:"relationships"`
+}
+
+// CompanyGraphResponse represents the companies reachable from a company through its relationships, for
+// supply-chain style exploration. Edges keep their declared direction (from company to related company)
+type CompanyGraphResponse struct {
+	Root      CompanyGraphNode   `json:"root"`
+	Depth     int                `json:"depth"`
+	Types     []string           `json:"types"`
+	Nodes     []CompanyGraphNode `json:"nodes"` // Incluye la raíz, por distancia
+	Edges     []CompanyGraphEdge `json:"edges"`
+	Truncated bool               `json:"truncated,omitempty"` // Se alcanzó el máximo de nodos
+}
+
+// CompanyGraphNode represents a company of the graph and its distance in hops from the root
+type CompanyGraphNode struct {
+	CompanyListResponse
+	Depth int `json:"depth"`
+}
+
+// CompanyGraphEdge represents a relationship of the graph
+type CompanyGraphEdge struct {
+	ID   uuid.UUID `json:"id"`
+	From uuid.UUID `json:"from"` // company_id
+	To   uuid.UUID `json:"to"`   // related_company_id
+	Type string    `json:"type"`
+	Note string    `json:"note,omitempty"`
+}
+
+// TagResponse represents a thematic tag
+type TagResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Slug        string    `json:"slug"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Companies   *int64    `json:"companies,omitempty"` // Companies activas con la etiqueta (solo en el listado)
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// CompanyTagsResponse represents the tags of a company by slug
+type CompanyTagsResponse struct {
+	CompanyID uuid.UUID     `json:"company_id"`
+	Ticker    string        `json:"ticker"`
+	Tags      []TagResponse `json:"tags"`
+}
+
+// NoteResponse represents a private note of the user on a company or on one of its ratings
+type NoteResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	CompanyID uuid.UUID  `json:"company_id"`
+	RatingID  *uuid.UUID `json:"rating_id,omitempty"`
+	Body      string     `json:"body"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// FinancialStatementsResponse represents the reported statements of a company, newest first, with the growth
+// rates and margins computed from the stored amounts
+type FinancialStatementsResponse struct {
+	CompanyID uuid.UUID                 `json:"company_id"`
+	Ticker    string                    `json:"ticker"`
+	Statement string                    `json:"statement"` // income, balance o cash_flow
+	Period    string                    `json:"period"`    // annual o quarterly
+	Periods   []FinancialPeriodResponse `json:"periods"`
+	Source    string                    `json:"source"`
+	FetchedAt *time.Time                `json:"fetched_at,omitempty"` // Última descarga del proveedor
+	Stale     bool                      `json:"stale,omitempty"`      // No se pudieron volver a descargar
+}
+
+// FinancialPeriodResponse represents one fiscal year or quarter. Growth rates and metrics are fractions
+// (0.12 = 12%); those that cannot be computed are omitted
+type FinancialPeriodResponse struct {
+	FiscalDateEnding string             `json:"fiscal_date_ending"`
+	Currency         string             `json:"currency,omitempty"`
+	Items            map[string]float64 `json:"items"`
+	Metrics          map[string]float64 `json:"metrics,omitempty"`    // Márgenes, ratios y free cash flow
+	YoYGrowth        map[string]float64 `json:"yoy_growth,omitempty"` // Frente al mismo periodo del año anterior
+	QoQGrowth        map[string]float64 `json:"qoq_growth,omitempty"` // Frente al trimestre anterior (solo quarterly)
+}
+
+// BenchmarkResponse represents a tracked benchmark (market index)
+type BenchmarkResponse struct {
+	ID           uuid.UUID  `json:"id"`
+	Symbol       string     `json:"symbol"`
+	Name         string     `json:"name"`
+	Kind         string     `json:"kind"`                    // provider o custom
+	SourceSymbol string     `json:"source_symbol,omitempty"` // Instrumento que se descarga del proveedor
+	Weighting    string     `json:"weighting,omitempty"`     // equal o cap (solo custom)
+	BaseDate     string     `json:"base_date,omitempty"`     // Primera sesión calculada (solo custom)
+	Currency     string     `json:"currency"`
+	LastSyncedAt *time.Time `json:"last_synced_at,omitempty"`
+}
+
+// BenchmarkValuesResponse represents the daily values of a benchmark over a date range
+type BenchmarkValuesResponse struct {
+	Benchmark BenchmarkResponse        `json:"benchmark"`
+	From      string                   `json:"from"`
+	To        string                   `json:"to"`
+	Return    *float64                 `json:"return,omitempty"` // Rentabilidad del periodo como fracción
+	Values    []BenchmarkValueResponse `json:"values"`
+}
+
+// BenchmarkValueResponse represents the value of a benchmark on one session
+type BenchmarkValueResponse struct {
+	Date          string  `json:"date"`
+	Open          float64 `json:"open"`
+	High          float64 `json:"high"`
+	Low           float64 `json:"low"`
+	Close         float64 `json:"close"`
+	AdjustedClose float64 `json:"adjusted_close,omitempty"`
+	Volume        int64   `json:"volume"`
+}
+
+// BenchmarkConstituentsResponse represents the members of a benchmark on a date
+type BenchmarkConstituentsResponse struct {
+	Benchmark    BenchmarkResponse              `json:"benchmark"`
+	Date         string                         `json:"date"`
+	Count        int                            `json:"count"`
+	Constituents []BenchmarkConstituentResponse `json:"constituents"`
+}
+
+// BenchmarkConstituentResponse represents the membership of a symbol in a benchmark
+type BenchmarkConstituentResponse struct {
+	Symbol    string     `json:"symbol"`
+	Name      string     `json:"name,omitempty"`
+	CompanyID *uuid.UUID `json:"company_id,omitempty"` // Solo si la company está registrada
+	Weight    *float64   `json:"weight,omitempty"`     // Fracción del índice
+	AddedAt   string     `json:"added_at"`
+}
+
+// BenchmarkSyncResponse represents the result of ingesting a benchmark from the provider
+type BenchmarkSyncResponse struct {
+	Benchmark           BenchmarkResponse `json:"benchmark"`
+	Values              int               `json:"values"`               // Sesiones guardadas o actualizadas
+	ConstituentsAdded   int               `json:"constituents_added"`   // Miembros nuevos
+	ConstituentsRemoved int               `json:"constituents_removed"` // Miembros que dejaron el índice
+	Warnings            []string          `json:"warnings,omitempty"`
+	SyncedAt            time.Time         `json:"synced_at"`
+}
+
+// BenchmarkRefreshResponse represents a scheduled recomputation of the custom benchmarks
+type BenchmarkRefreshResponse struct {
+	Benchmarks  int       `json:"benchmarks"`       // Índices personalizados registrados
+	Refreshed   int       `json:"refreshed"`        // Recalculados por no estar al día
+	Failed      []string  `json:"failed,omitempty"` // Símbolos que no se pudieron recalcular
+	RefreshedAt time.Time `json:"refreshed_at"`
+}
+
+// PeerResponse represents one competitor of a company
+type PeerResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Ticker    string    `json:"ticker"`
+	Name      string    `json:"name"`
+	Sector    string    `json:"sector,omitempty"`
+	Exchange  string    `json:"exchange,omitempty"`
+	MarketCap float64   `json:"market_cap,omitempty"`
+	Rank      int       `json:"rank"`
+}
+
+// CompanyComparisonResponse represents several companies compared side by side
+type CompanyComparisonResponse struct {
+	Tickers     []string                 `json:"tickers"`
+	Companies   []CompanyComparisonEntry `json:"companies"`
+	Leaders     map[string]string        `json:"leaders"` // Métrica -> ticker con el mejor valor
+	NotFound    []string                 `json:"not_found,omitempty"`
+	GeneratedAt time.Time                `json:"generated_at"`
+}
+
+// CompanyComparisonEntry represents one company within a comparison. Metrics without data are null
+type CompanyComparisonEntry struct {
+	CompanyID   uuid.UUID             `json:"company_id"`
+	Ticker      string                `json:"ticker"`
+	Name        string                `json:"name"`
+	Sector      string                `json:"sector,omitempty"`
+	Exchange    string                `json:"exchange,omitempty"`
+	MarketCap   float64               `json:"market_cap,omitempty"`
+	Valuation   ComparisonValuation   `json:"valuation"`
+	Growth      ComparisonGrowth      `json:"growth"`
+	Consensus   ComparisonConsensus   `json:"consensus"`
+	Performance ComparisonPerformance `json:"performance"`
+	Scores      map[string]float64    `json:"scores"` // Cada métrica normalizada a 0-1 dentro de la comparación (1 = mejor)
+}
+
+// ComparisonValuation holds the valuation ratios of a compared company
+type ComparisonValuation struct {
+	PERatio      *float64 `json:"pe_ratio"`
+	PEGRatio     *float64 `json:"peg_ratio"`
+	PriceToBook  *float64 `json:"price_to_book"`
+	PriceToSales *float64 `json:"price_to_sales"`
+	EVToEBITDA   *float64 `json:"ev_to_ebitda"`
+}
+
+// ComparisonGrowth holds the growth metrics of a compared company
+type ComparisonGrowth struct {
+	RevenueGrowthTTM  *float64 `json:"revenue_growth_ttm"`
+	EarningsGrowthTTM *float64 `json:"earnings_growth_ttm"`
+	EPSGrowthTTM      *float64 `json:"eps_growth_ttm"`
+	RevenueGrowth3Y   *float64 `json:"revenue_growth_3y"`
+	EarningsGrowth3Y  *float64 `json:"earnings_growth_3y"`
+}
+
+// ComparisonConsensus summarizes the latest rating of every brokerage covering a compared company
+type ComparisonConsensus struct {
+	Rating       string     `json:"rating"`
+	RatingLabel  string     `json:"rating_label,omitempty"` // Rating en el idioma de Accept-Language
+	Buy          int        `json:"buy"`
+	Hold         int        `json:"hold"`
+	Sell         int        `json:"sell"`
+	Brokerages   int        `json:"brokerages"`
+	Score        *float64   `json:"score,omitempty"` // Media de la escala canónica: 2 (strong buy) a -2 (strong sell)
+	LastRatingAt *time.Time `json:"last_rating_at,omitempty"`
+}
+
+// ComparisonPerformance holds the recent price returns (percent) of a compared company
+type ComparisonPerformance struct {
+	LastClose     *float64   `json:"last_close"`
+	LastCloseDate *time.Time `json:"last_close_date,omitempty"`
+	Return1W      *float64   `json:"return_1w"`
+	Return1M      *float64   `json:"return_1m"`
+	Return3M      *float64   `json:"return_3m"`
+	Return1Y      *float64   `json:"return_1y"`
+}
+
+// CorrelationMatrixResponse represents the pairwise correlation of daily returns between tickers.
+// Matrix[i][j] is the correlation between Tickers[i] and Tickers[j] (null without enough common sessions)
+type CorrelationMatrixResponse struct {
+	Tickers      []string     `json:"tickers"`
+	Days         int          `json:"days"`
+	From         time.Time    `json:"from"`
+	To           time.Time    `json:"to"`
+	Matrix       [][]*float64 `json:"matrix"`
+	Observations [][]int      `json:"observations"` // Rendimientos diarios comunes usados en cada par
+	NotFound     []string     `json:"not_found,omitempty"`
+	GeneratedAt  time.Time    `json:"generated_at"`
+}
+
+// MarketBreadthResponse represents the daily market breadth of the tracked (active) companies: advancers vs
+// decliners and the share trading above their 50 and 200-session moving averages, from the stored daily prices
+type MarketBreadthResponse struct {
+	Days        int                    `json:"days"`
+	From        string                 `json:"from"`
+	To          string                 `json:"to"`
+	Tracked     int                    `json:"tracked"`          // Companies activas
+	Latest      *MarketBreadthSnapshot `json:"latest,omitempty"` // Última cotización guardada de cada company
+	Series      []MarketBreadthDay     `json:"series"`           // Sesiones con precios, de la más antigua a la más reciente
+	GeneratedAt time.Time              `json:"generated_at"`
+}
+
+// MarketBreadthDay represents the breadth of one session
+type MarketBreadthDay struct {
+	Date                string   `json:"date"`
+	Symbols             int      `json:"symbols"` // Companies con cierre en la sesión
+	Advancers           int      `json:"advancers"`
+	Decliners           int      `json:"decliners"`
+	Unchanged           int      `json:"unchanged"`
+	NetAdvances         int      `json:"net_advances"`
+	AdvanceDeclineRatio *float64 `json:"advance_decline_ratio"` // null sin decliners
+	AdvanceDeclineLine  int      `json:"advance_decline_line"`  // Suma acumulada de net_advances desde el inicio del rango
+	AboveMA50           int      `json:"above_ma50"`
+	PercentAboveMA50    *float64 `json:"percent_above_ma50"` // Sobre las companies con 50 sesiones de historia; null si no hay
+	AboveMA200          int      `json:"above_ma200"`
+	PercentAboveMA200   *float64 `json:"percent_above_ma200"`
+}
+
+// MarketBreadthSnapshot represents advancers vs decliners in the latest stored quotes
+type MarketBreadthSnapshot struct {
+	Quotes              int        `json:"quotes"`
+	Advancers           int        `json:"advancers"`
+	Decliners           int        `json:"decliners"`
+	Unchanged           int        `json:"unchanged"`
+	AdvanceDeclineRatio *float64   `json:"advance_decline_ratio"`
+	AsOf                *time.Time `json:"as_of,omitempty"` // Cotización más reciente
+}
+
+// MarketCapStatsResponse represents the market cap statistics of the active companies converted to one currency
+// at the current exchange rates, overall and per exchange or listing currency
+type MarketCapStatsResponse struct {
+	Currency    string             `json:"currency"`
+	GroupBy     string             `json:"group_by,omitempty"`
+	Total       MarketCapGroup     `json:"total"`
+	Groups      []MarketCapGroup   `json:"groups,omitempty"` // Por capitalización total descendente
+	Rates       map[string]float64 `json:"rates,omitempty"`  // Tipo de cambio aplicado a cada moneda de origen
+	Warnings    []string           `json:"warnings,omitempty"`
+	GeneratedAt time.Time          `json:"generated_at"`
+}
+
+// MarketCapGroup represents the market cap statistics of a set of companies
+type MarketCapGroup struct {
+	Key        string   `json:"key,omitempty"`        // Exchange o moneda de cotización
+	Currencies []string `json:"currencies,omitempty"` // Monedas de origen del grupo
+	Count      int64    `json:"count"`
+	Total      float64  `json:"total"`
+	Min        float64  `json:"min"`
+	Max        float64  `json:"max"`
+	Avg        float64  `json:"avg"`
+	Share      *float64 `json:"share,omitempty"` // Fracción de la capitalización total
+}
+
+// SectorRotationResponse represents how the upgrade/downgrade balance moves between sectors month over month.
+// Cells[i][j] is the balance of Sectors[i] in Months[j]
+type SectorRotationResponse struct {
+	Sectors     []string               `json:"sectors"` // Por balance neto del rango, de mayor a menor
+	Months      []string               `json:"months"`  // YYYY-MM
+	Cells       [][]SectorRotationCell `json:"cells"`
+	Totals      []SectorRotationCell   `json:"totals"` // Balance de cada sector en todo el rango
+	From        string                 `json:"from"`
+	To          string                 `json:"to"`
+	Timezone    string                 `json:"timezone"`
+	GeneratedAt time.Time              `json:"generated_at"`
+}
+
+// SectorRotationCell represents the rating changes of one sector in one month
+type SectorRotationCell struct {
+	Ratings    int64    `json:"ratings"` // Todos los ratings, incluidos reiterados e iniciados
+	Upgrades   int64    `json:"upgrades"`
+	Downgrades int64    `json:"downgrades"`
+	Net        int64    `json:"net"`     // Upgrades - Downgrades
+	Balance    float64  `json:"balance"` // Net / (Upgrades + Downgrades), de -1 a 1; 0 sin cambios de rating
+	Change     *float64 `json:"change"`  // Balance menos el del mes anterior; null si alguno de los dos no tuvo cambios
+}
+
+// PortfolioRiskResponse represents the risk metrics of a portfolio and of each of its holdings
+type PortfolioRiskResponse struct {
+	Holdings      []PortfolioHolding `json:"holdings"`
+	Benchmark     string             `json:"benchmark"`
+	BenchmarkName string             `json:"benchmark_name,omitempty"` // Solo con un benchmark registrado (S&P 500)
+	Days          int                `json:"days"`
+	From          time.Time          `json:"from"`
+	To            time.Time          `json:"to"`
+	RiskFreeRate  float64            `json:"risk_free_rate"`
+	Observations  int                `json:"observations"` // Sesiones con precio de todas las posiciones
+	Portfolio     RiskMetrics        `json:"portfolio"`
+	BenchmarkRisk *RiskMetrics       `json:"benchmark_risk,omitempty"` // Métricas del propio índice en el mismo periodo
+	Warnings      []string           `json:"warnings,omitempty"`
+	GeneratedAt   time.Time          `json:"generated_at"`
+}
+
+// PortfolioHolding represents one position of a portfolio with its normalized weight
+type PortfolioHolding struct {
+	Ticker string      `json:"ticker"`
+	Weight float64     `json:"weight"` // Fracción del portfolio (suman 1)
+	Risk   RiskMetrics `json:"risk"`
+}
+
+// RiskMetrics holds annualized risk metrics computed from daily returns, as fractions (0.25 = 25%).
+// Metrics without enough data are null
+type RiskMetrics struct {
+	AnnualizedReturn *float64 `json:"annualized_return"`
+	Volatility       *float64 `json:"volatility"`
+	Beta             *float64 `json:"beta"`
+	SharpeRatio      *float64 `json:"sharpe_ratio"`
+	MaxDrawdown      *float64 `json:"max_drawdown"`
+}
+
+// BacktestResponse represents the result of simulating a rating-following strategy
+type BacktestResponse struct {
+	Tickers        []string        `json:"tickers"`
+	Strategy       string          `json:"strategy"`
+	HoldingDays    int             `json:"holding_days"`
+	From           time.Time       `json:"from"`
+	To             time.Time       `json:"to"`
+	InitialCapital float64         `json:"initial_capital"`
+	FinalEquity    float64         `json:"final_equity"`
+	TotalReturn    float64         `json:"total_return"` // Fracción (0.12 = 12%)
+	CAGR           *float64        `json:"cagr"`
+	MaxDrawdown    *float64        `json:"max_drawdown"`
+	HitRate        *float64        `json:"hit_rate"` // Operaciones con beneficio / operaciones
+	TotalTrades    int             `json:"total_trades"`
+	Trades         []BacktestTrade `json:"trades"`
+	EquityCurve    []EquityPoint   `json:"equity_curve"`
+	NotFound       []string        `json:"not_found,omitempty"`
+	Warnings       []string        `json:"warnings,omitempty"`
+	GeneratedAt    time.Time       `json:"generated_at"`
+}
+
+// BacktestTrade represents one simulated round trip
+type BacktestTrade struct {
+	Ticker     string    `json:"ticker"`
+	EntryDate  time.Time `json:"entry_date"`
+	EntryPrice float64   `json:"entry_price"`
+	ExitDate   time.Time `json:"exit_date"`
+	ExitPrice  float64   `json:"exit_price"`
+	ExitReason string    `json:"exit_reason"` // downgrade, holding_period o end_of_test
+	Shares     float64   `json:"shares"`
+	Return     float64   `json:"return"` // Fracción (0.05 = 5%)
+}
+
+// EquityPoint represents the value of a simulated portfolio at the close of a session
+type EquityPoint struct {
+	Date   time.Time `json:"date"`
+	Equity float64   `json:"equity"`
+}
+
+// AnomalyResponse represents a company flagged for abnormal rating activity or trading volume on a day
+type AnomalyResponse struct {
+	ID          uuid.UUID `json:"id"`
+	CompanyID   uuid.UUID `json:"company_id"`
+	Ticker      string    `json:"ticker"`
+	CompanyName string    `json:"company_name"`
+	Type        string    `json:"type"` // rating_activity o volume
+	Date        time.Time `json:"date"`
+	Value       float64   `json:"value"`    // Ratings del día o volumen de la sesión
+	Baseline    float64   `json:"baseline"` // Media de referencia de los días o sesiones anteriores
+	Ratio       float64   `json:"ratio"`    // value / baseline
+	DetectedAt  time.Time `json:"detected_at"`
+}
+
+// AnomaliesResponse represents the anomalies detected in the last days
+type AnomaliesResponse struct {
+	Days      int               `json:"days"`
+	Since     time.Time         `json:"since"`
+	Type      string            `json:"type,omitempty"`
+	Total     int               `json:"total"`
+	Anomalies []AnomalyResponse `json:"anomalies"`
+}
+
+// TrendingSymbolResponse represents a symbol ranked by its quote and profile requests
+type TrendingSymbolResponse struct {
+	Rank        int    `json:"rank"`
+	Symbol      string `json:"symbol"`
+	CompanyName string `json:"company_name,omitempty"`
+	Views       int64  `json:"views"`
+}
+
+// TrendingSymbolsResponse represents the most requested symbols of a window
+type TrendingSymbolsResponse struct {
+	Hours   int                      `json:"hours"`
+	Since   time.Time                `json:"since"`
+	Total   int                      `json:"total"`
+	Symbols []TrendingSymbolResponse `json:"symbols"`
+}
+
+// DashboardResponse is the home screen summary: everything but UnreadAlerts is shared by all clients and cached
+type DashboardResponse struct {
+	Market       *MarketOverviewResponse  `json:"market,omitempty"`
+	TopGainers   []*MarketDataResponse    `json:"top_gainers"`
+	TopLosers    []*MarketDataResponse    `json:"top_losers"`
+	Upgrades     DashboardRatingsResponse `json:"upgrades"`   // Upgrades de hoy en la zona horaria de la petición
+	Downgrades   DashboardRatingsResponse `json:"downgrades"` // Downgrades de hoy en la zona horaria de la petición
+	Trending     []TrendingSymbolResponse `json:"trending"`
+	UnreadAlerts *int64                   `json:"unread_alerts,omitempty"` // Anomalías detectadas desde alerts_since
+	Unavailable  map[string]string        `json:"unavailable,omitempty"`   // Secciones que no se pudieron obtener y el motivo
+	GeneratedAt  time.Time                `json:"generated_at"`
+}
+
+// DashboardRatingsResponse is the total of a kind of rating action today and the most recent ones
+type DashboardRatingsResponse struct {
+	Total  int                        `json:"total"`
+	Recent []*StockRatingListResponse `json:"recent"`
+}
+
+// AnomalyDetectionResponse represents the summary of an anomaly detection run
+type AnomalyDetectionResponse struct {
+	Days             int       `json:"days"`
+	From             time.Time `json:"from"`
+	To               time.Time `json:"to"`
+	CompaniesScanned int       `json:"companies_scanned"`
+	RatingAnomalies  int       `json:"rating_anomalies"`
+	VolumeAnomalies  int       `json:"volume_anomalies"`
+	DetectedAt       time.Time `json:"detected_at"`
+}
+
+// SearchResultResponse represents one ranked full-text search hit (company or news)
+type SearchResultResponse struct {
+	Type        string     `json:"type"`
+	ID          uuid.UUID  `json:"id"`
+	Symbol      string     `json:"symbol"`
+	Title       string     `json:"title"`
+	Snippet     string     `json:"snippet,omitempty"`
+	URL         string     `json:"url,omitempty"`
+	Rank        float64    `json:"rank"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+}
+
+// SearchResponse represents the mixed-type results of a full-text search, best match first
+type SearchResponse struct {
+	Query   string                 `json:"query"`
+	Types   []string               `json:"types"`
+	Total   int                    `json:"total"`
+	Results []SearchResultResponse `json:"results"`
 }
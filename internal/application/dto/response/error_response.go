@@ -1,8 +1,11 @@
 package response
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
 )
 
 // ErrorCode represents standard error codes
@@ -18,6 +21,8 @@ const (
 	ErrCodeValidationFailed  ErrorCode = "VALIDATION_FAILED"
 	ErrCodeRateLimitExceeded ErrorCode = "RATE_LIMIT_EXCEEDED"
 	ErrCodeRequestTooLarge   ErrorCode = "REQUEST_TOO_LARGE"
+	ErrCodeQuotaExceeded     ErrorCode = "QUOTA_EXCEEDED"
+	ErrCodePaymentRequired   ErrorCode = "PAYMENT_REQUIRED"
 
 	// Server errors (5xx)
 	ErrCodeInternalServer     ErrorCode = "INTERNAL_SERVER_ERROR"
@@ -30,6 +35,7 @@ const (
 	ErrCodeResourceNotFound     ErrorCode = "RESOURCE_NOT_FOUND"
 	ErrCodeDuplicateResource    ErrorCode = "DUPLICATE_RESOURCE"
 	ErrCodeBusinessRuleViolated ErrorCode = "BUSINESS_RULE_VIOLATED"
+	ErrCodeVersionMismatch      ErrorCode = "VERSION_MISMATCH"
 )
 
 // ErrorResponse contains structured error information
@@ -38,6 +44,9 @@ type ErrorResponse struct {
 	Message    string                 `json:"message"`
 	Details    map[string]interface{} `json:"details,omitempty"`
 	StatusCode int                    `json:"-"` // HTTP status code, not included in JSON
+
+	// ValidationErrors holds per-field errors, rendered as "errors" in problem+json
+	ValidationErrors []ValidationError `json:"-"`
 }
 
 // Error implements the error interface
@@ -112,6 +121,14 @@ func InternalServerError(message string) *ErrorResponse {
 	return NewErrorResponse(ErrCodeInternalServer, message, http.StatusInternalServerError)
 }
 
+// PaymentRequired creates a payment required error: the plan does not cover the operation
+func PaymentRequired(message string) *ErrorResponse {
+	if message == "" {
+		message = "The current plan does not cover this operation"
+	}
+	return NewErrorResponse(ErrCodePaymentRequired, message, http.StatusPaymentRequired)
+}
+
 // ServiceUnavailable creates a service unavailable error
 func ServiceUnavailable(message string) *ErrorResponse {
 	if message == "" {
@@ -134,17 +151,47 @@ func ExternalAPIError(apiName, message string) *ErrorResponse {
 	return NewErrorResponse(ErrCodeExternalAPIError, fullMessage, http.StatusBadGateway)
 }
 
+// FromError converts an error returned by services or repositories into an ErrorResponse.
+// ErrorResponses are returned as is, domain errors are mapped to 404/409 and any other
+// error becomes an internal server error with the fallback message.
+func FromError(err error, resource, fallback string) *ErrorResponse {
+	var errorResp *ErrorResponse
+	if errors.As(err, &errorResp) {
+		return errorResp
+	}
+
+	switch {
+	case domainerrors.IsNotFound(err):
+		return NotFound(resource)
+	case domainerrors.IsDuplicate(err):
+		return NewErrorResponse(ErrCodeDuplicateResource, fmt.Sprintf("%s already exists", resource), http.StatusConflict)
+	case domainerrors.IsConflict(err):
+		return Conflict(fmt.Sprintf("%s conflicts with existing data", resource))
+	case domainerrors.IsVersionMismatch(err):
+		return NewErrorResponse(ErrCodeVersionMismatch,
+			fmt.Sprintf("%s was modified by another request; reload it and retry", resource), http.StatusConflict)
+	default:
+		return InternalServerError(fallback)
+	}
+}
+
 // ValidationError represents a field validation error
 type ValidationError struct {
 	Field   string `json:"field"`
+	Tag     string `json:"tag,omitempty"` // Regla de validación que falló (required, min, oneof...)
 	Message string `json:"message"`
 	Value   string `json:"value,omitempty"`
 }
 
+// validationErrorsDetailKey is the details key used by the legacy envelope for field errors
+const validationErrorsDetailKey = "validation_errors"
+
 // ValidationFailedWithFields creates a validation error with field details
 func ValidationFailedWithFields(errors []ValidationError) *ErrorResponse {
 	details := map[string]interface{}{
-		"validation_errors": errors,
+		validationErrorsDetailKey: errors,
 	}
-	return ValidationFailed("Request validation failed").WithDetails(details)
+	errorResp := ValidationFailed("Request validation failed").WithDetails(details)
+	errorResp.ValidationErrors = errors
+	return errorResp
 }
@@ -33,9 +33,61 @@ type MarketDataResponse struct {
 	Currency     string `json:"currency"`
 	Exchange     string `json:"exchange"`
 
+	// Data Source and Freshness
+	DataSource        string     `json:"data_source"`
+	FetchedAt         *time.Time `json:"fetched_at,omitempty"`
+	ProviderRequestID string     `json:"provider_request_id,omitempty"`
+
 	// Timestamps
 	MarketTimestamp time.Time `json:"market_timestamp"`
 	LastUpdated     time.Time `json:"last_updated"`
+
+	// Stale indica que la cotización está caducada y se está refrescando en segundo plano
+	Stale bool `json:"stale"`
+}
+
+// IntradayQuotesResponse represents the recent quote history of a symbol, oldest point first (sparkline data)
+type IntradayQuotesResponse struct {
+	Symbol     string          `json:"symbol"`
+	Minutes    int             `json:"minutes"`
+	From       time.Time       `json:"from"`
+	To         time.Time       `json:"to"`
+	Count      int             `json:"count"`
+	High       *float64        `json:"high"`
+	Low        *float64        `json:"low"`
+	Change     *float64        `json:"change"`      // Último precio menos el primero de la ventana
+	ChangePerc *float64        `json:"change_perc"` // Porcentaje (1.5 = 1.5%)
+	Points     []IntradayPoint `json:"points"`
+}
+
+// IntradayPoint represents one stored quote snapshot
+type IntradayPoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	Price     float64   `json:"price"`
+	Volume    int64     `json:"volume"`
+}
+
+// MarketStatusResponse represents the current trading session of an exchange
+type MarketStatusResponse struct {
+	Exchange     string                `json:"exchange"`
+	Timezone     string                `json:"timezone"`
+	LocalTime    time.Time             `json:"local_time"`
+	Session      string                `json:"session"` // pre_market, regular, post_market o closed
+	IsOpen       bool                  `json:"is_open"` // Sesión regular
+	IsTradingDay bool                  `json:"is_trading_day"`
+	Holiday      string                `json:"holiday,omitempty"`
+	Hours        *TradingHoursResponse `json:"hours,omitempty"` // Sesiones de hoy; ausente si no se negocia
+	NextOpen     time.Time             `json:"next_open"`
+	NextClose    time.Time             `json:"next_close"`
+}
+
+// TradingHoursResponse represents the session windows of a trading day
+type TradingHoursResponse struct {
+	PreMarketOpen   time.Time `json:"pre_market_open"`
+	Open            time.Time `json:"open"`
+	Close           time.Time `json:"close"`
+	PostMarketClose time.Time `json:"post_market_close"`
+	EarlyClose      bool      `json:"early_close"`
 }
 
 // CompanyProfileResponse represents detailed company information
@@ -65,6 +117,11 @@ type CompanyProfileResponse struct {
 	IPODate       time.Time `json:"ipo_date"`
 	EmployeeCount int32     `json:"employee_count"`
 
+	// Data Source and Freshness
+	DataSource        string     `json:"data_source"`
+	FetchedAt         *time.Time `json:"fetched_at,omitempty"`
+	ProviderRequestID string     `json:"provider_request_id,omitempty"`
+
 	// Timestamps
 	LastUpdated time.Time `json:"last_updated"`
 }
@@ -85,6 +142,11 @@ type NewsResponse struct {
 	SentimentScore float64 `json:"sentiment_score"`
 	SentimentLabel string  `json:"sentiment_label"`
 
+	// Data Source and Freshness
+	DataSource        string     `json:"data_source"`
+	FetchedAt         *time.Time `json:"fetched_at,omitempty"`
+	ProviderRequestID string     `json:"provider_request_id,omitempty"`
+
 	// Timestamps
 	PublishedAt time.Time `json:"published_at"`
 	CreatedAt   time.Time `json:"created_at"`
@@ -131,6 +193,11 @@ type BasicFinancialsResponse struct {
 	FiscalYear    int    `json:"fiscal_year"`
 	FiscalQuarter int    `json:"fiscal_quarter"`
 
+	// Data Source and Freshness
+	DataSource        string     `json:"data_source"`
+	FetchedAt         *time.Time `json:"fetched_at,omitempty"`
+	ProviderRequestID string     `json:"provider_request_id,omitempty"`
+
 	// Timestamps
 	LastUpdated time.Time `json:"last_updated"`
 }
@@ -145,6 +212,32 @@ type MarketOverviewResponse struct {
 	LastUpdated    time.Time `json:"last_updated"`
 }
 
+// MarketDataRefreshResponse reports the progress or the outcome of a bulk quote refresh
+type MarketDataRefreshResponse struct {
+	Total          int        `json:"total"`
+	Processed      int        `json:"processed"`
+	Refreshed      int        `json:"refreshed"`
+	Fresh          int        `json:"fresh"` // Aún dentro del TTL, sin llamar al proveedor
+	Failed         int        `json:"failed"`
+	Deferred       int        `json:"deferred"` // Sin refrescar por agotarse la cuota diaria
+	QuotaLimit     int        `json:"quota_limit,omitempty"`
+	QuotaRemaining *int       `json:"quota_remaining,omitempty"`
+	Plan           string     `json:"plan,omitempty"` // Plan del tenant que encargó el refresco
+	PlanLimit      int        `json:"plan_limit,omitempty"`
+	PlanRemaining  *int       `json:"plan_remaining,omitempty"`
+	Errors         []string   `json:"errors,omitempty"` // Primeros errores
+	StartedAt      time.Time  `json:"started_at"`
+	FinishedAt     *time.Time `json:"finished_at,omitempty"`
+}
+
+// MarketMoversResponse represents a ranking of the latest quotes (top gainers, losers or most active)
+type MarketMoversResponse struct {
+	Type        string                `json:"type"` // gainers, losers o active
+	Count       int                   `json:"count"`
+	Movers      []*MarketDataResponse `json:"movers"`
+	GeneratedAt time.Time             `json:"generated_at"`
+}
+
 // MarketDataSummaryResponse represents aggregated market data
 type MarketDataSummaryResponse struct {
 	Symbol          string    `json:"symbol"`
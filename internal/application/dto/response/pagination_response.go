@@ -1,6 +1,10 @@
 package response
 
-import "strconv"
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
 
 // PaginatedResponse represents a paginated response
 type PaginatedResponse[T any] struct {
@@ -16,6 +20,17 @@ type Pagination struct {
 	TotalPages int  `json:"total_pages"`
 	HasNext    bool `json:"has_next"`
 	HasPrev    bool `json:"has_prev"`
+
+	Links *PaginationLinks `json:"links,omitempty"` // Enlaces de navegación; los añade la capa REST a partir de la URL de la petición
+}
+
+// PaginationLinks are the URLs of the neighbouring pages of a listing, relative to the API host (RFC 5988 relation
+// names). Prev and Next are omitted on the first and last page
+type PaginationLinks struct {
+	First string `json:"first"`
+	Prev  string `json:"prev,omitempty"`
+	Next  string `json:"next,omitempty"`
+	Last  string `json:"last"`
 }
 
 // PaginationRequest represents pagination parameters from request
@@ -44,6 +59,43 @@ func NewPagination(page, perPage, total int) Pagination {
 	}
 }
 
+// NewPaginationLinks builds the links of a page from the URL of the request that produced it: every other query
+// parameter (filters, sort, includes) is kept and only page and per_page change
+func NewPaginationLinks(requestURL *url.URL, meta Pagination) *PaginationLinks {
+	pageURL := func(page int) string {
+		query := requestURL.Query()
+		query.Set("page", strconv.Itoa(page))
+		query.Set("per_page", strconv.Itoa(meta.PerPage))
+		return (&url.URL{Path: requestURL.Path, RawPath: requestURL.RawPath, RawQuery: query.Encode()}).RequestURI()
+	}
+
+	links := &PaginationLinks{
+		First: pageURL(1),
+		Last:  pageURL(meta.TotalPages),
+	}
+	if meta.Page > 1 {
+		// Desde una página fuera de rango, la anterior es la última que existe
+		links.Prev = pageURL(min(meta.Page-1, meta.TotalPages))
+	}
+	if meta.Page < meta.TotalPages {
+		links.Next = pageURL(meta.Page + 1)
+	}
+	return links
+}
+
+// Header formats the links as the value of an RFC 5988 Link header
+func (l *PaginationLinks) Header() string {
+	var parts []string
+	for _, link := range []struct{ rel, url string }{
+		{"first", l.First}, {"prev", l.Prev}, {"next", l.Next}, {"last", l.Last},
+	} {
+		if link.url != "" {
+			parts = append(parts, "<"+link.url+`>; rel="`+link.rel+`"`)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
 // NewPaginatedResponse creates a new paginated response
 func NewPaginatedResponse[T any](items []T, page, perPage, total int) *PaginatedResponse[T] {
 	return &PaginatedResponse[T]{
@@ -102,7 +154,7 @@ func (p *PaginationRequest) GetLimit() int {
 // ParsePaginationFromQuery parses pagination parameters from query strings
 func ParsePaginationFromQuery(pageStr, perPageStr string) *PaginationRequest {
 	defaults := GetDefaultPagination()
-	
+
 	// Parse page
 	page := defaults.Page
 	if pageStr != "" {
@@ -110,7 +162,7 @@ func ParsePaginationFromQuery(pageStr, perPageStr string) *PaginationRequest {
 			page = parsedPage
 		}
 	}
-	
+
 	// Parse per_page
 	perPage := defaults.PerPage
 	if perPageStr != "" {
@@ -118,14 +170,14 @@ func ParsePaginationFromQuery(pageStr, perPageStr string) *PaginationRequest {
 			perPage = parsedPerPage
 		}
 	}
-	
+
 	pagination := &PaginationRequest{
 		Page:    page,
 		PerPage: perPage,
 	}
-	
+
 	// Validate and apply constraints
 	pagination.Validate()
-	
+
 	return pagination
 }
@@ -0,0 +1,91 @@
+package response
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ProblemContentType is the media type defined by RFC 7807 for problem details
+const ProblemContentType = "application/problem+json"
+
+// Formatos de respuesta de error soportados
+const (
+	ErrorFormatProblem = "problem" // RFC 7807 application/problem+json
+	ErrorFormatLegacy  = "legacy"  // Envelope APIResponse anterior ({"success": false, "error": {...}})
+)
+
+// DefaultProblemTypeBaseURI is used to build problem type URIs when none is configured
+const DefaultProblemTypeBaseURI = "/problems"
+
+// errorFormat y problemTypeBaseURI se configuran una sola vez al arrancar el servidor
+var (
+	errorFormat        = ErrorFormatProblem
+	problemTypeBaseURI = DefaultProblemTypeBaseURI
+)
+
+// ProblemDetails represents an RFC 7807 problem document
+type ProblemDetails struct {
+	Type      string                 `json:"type"`
+	Title     string                 `json:"title"`
+	Status    int                    `json:"status"`
+	Detail    string                 `json:"detail,omitempty"`
+	Instance  string                 `json:"instance,omitempty"`
+	Code      string                 `json:"code"`
+	Errors    []ValidationError      `json:"errors,omitempty"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+	Timestamp time.Time              `json:"timestamp"`
+}
+
+// ConfigureErrorFormat sets the error format and the base URI for problem types.
+// Unknown formats fall back to problem+json.
+func ConfigureErrorFormat(format, typeBaseURI string) {
+	if strings.EqualFold(format, ErrorFormatLegacy) {
+		errorFormat = ErrorFormatLegacy
+	} else {
+		errorFormat = ErrorFormatProblem
+	}
+
+	typeBaseURI = strings.TrimRight(strings.TrimSpace(typeBaseURI), "/")
+	if typeBaseURI == "" {
+		typeBaseURI = DefaultProblemTypeBaseURI
+	}
+	problemTypeBaseURI = typeBaseURI
+}
+
+// ProblemDetailsEnabled reports whether errors are rendered as application/problem+json
+func ProblemDetailsEnabled() bool {
+	return errorFormat == ErrorFormatProblem
+}
+
+// ProblemType returns the type URI for an error code (e.g. NOT_FOUND -> /problems/not-found)
+func ProblemType(code ErrorCode) string {
+	return problemTypeBaseURI + "/" + strings.ReplaceAll(strings.ToLower(string(code)), "_", "-")
+}
+
+// ToProblem converts ErrorResponse to an RFC 7807 problem document; the request ID is used as instance
+func (e *ErrorResponse) ToProblem(requestID string) *ProblemDetails {
+	problem := &ProblemDetails{
+		Type:      ProblemType(e.Code),
+		Title:     http.StatusText(e.StatusCode),
+		Status:    e.StatusCode,
+		Detail:    e.Message,
+		Instance:  requestID,
+		Code:      string(e.Code),
+		Errors:    e.ValidationErrors,
+		Timestamp: time.Now(),
+	}
+
+	// Los errores de validación ya van en "errors"; no se repiten en los detalles
+	for key, value := range e.Details {
+		if key == validationErrorsDetailKey && len(e.ValidationErrors) > 0 {
+			continue
+		}
+		if problem.Details == nil {
+			problem.Details = make(map[string]interface{})
+		}
+		problem.Details[key] = value
+	}
+
+	return problem
+}
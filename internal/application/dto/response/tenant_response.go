@@ -0,0 +1,49 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TenantResponse represents a tenant
+type TenantResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	Active    bool      `json:"active"`
+	Plan      string    `json:"plan"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// APIKeyResponse represents an API key without its value
+type APIKeyResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	TenantID   uuid.UUID  `json:"tenant_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Role       string     `json:"role"`
+	Timezone   string     `json:"timezone,omitempty"`
+	Active     bool       `json:"active"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// APIKeyCreatedResponse represents a new API key; Key is only returned once
+type APIKeyCreatedResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+// CurrentTenantResponse represents the tenant and credential of the request
+type CurrentTenantResponse struct {
+	Tenant   TenantResponse `json:"tenant"`
+	Subject  string         `json:"subject"`
+	Method   string         `json:"method"` // api_key o jwt
+	Role     string         `json:"role"`
+	Timezone string         `json:"timezone,omitempty"` // Zona horaria preferida de la credencial
+	APIKeyID *uuid.UUID     `json:"api_key_id,omitempty"`
+}
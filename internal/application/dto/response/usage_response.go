@@ -0,0 +1,63 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UsageCountsResponse represents the usage counters of a period
+type UsageCountsResponse struct {
+	Requests      int64 `json:"requests"`
+	Errors        int64 `json:"errors"`
+	ProviderCalls int64 `json:"provider_calls"` // Llamadas a proveedores externos causadas por las peticiones
+	CacheHits     int64 `json:"cache_hits"`     // Respuestas servidas desde datos guardados
+}
+
+// CredentialUsageResponse represents the usage of one credential (api_key:<id> o jwt:<sub>)
+type CredentialUsageResponse struct {
+	Credential string `json:"credential"`
+	UsageCountsResponse
+}
+
+// DailyUsageResponse represents the usage of one credential in one UTC day
+type DailyUsageResponse struct {
+	Date       string `json:"date"` // YYYY-MM-DD
+	Credential string `json:"credential"`
+	UsageCountsResponse
+}
+
+// UsageResponse represents the usage of the authenticated tenant
+type UsageResponse struct {
+	TenantID    uuid.UUID                 `json:"tenant_id"`
+	From        time.Time                 `json:"from"`
+	To          time.Time                 `json:"to"`
+	Days        int                       `json:"days"`
+	Totals      UsageCountsResponse       `json:"totals"`
+	Credentials []CredentialUsageResponse `json:"credentials"`
+	Daily       []DailyUsageResponse      `json:"daily"`
+}
+
+// TenantUsageResponse represents the usage of one tenant in the administration roll-up
+type TenantUsageResponse struct {
+	TenantID    uuid.UUID `json:"tenant_id"`
+	TenantSlug  string    `json:"tenant_slug,omitempty"`
+	Credentials int       `json:"credentials"` // Credenciales con uso en el periodo
+	UsageCountsResponse
+}
+
+// UsageRollupResponse represents the usage of every tenant
+type UsageRollupResponse struct {
+	From    time.Time             `json:"from"`
+	To      time.Time             `json:"to"`
+	Days    int                   `json:"days"`
+	Totals  UsageCountsResponse   `json:"totals"`
+	Tenants []TenantUsageResponse `json:"tenants"`
+}
+
+// UsageFlushResponse represents a flush of the usage counters to the usage table
+type UsageFlushResponse struct {
+	Hours       int `json:"hours"`        // Horas con contadores en la cache
+	Rows        int `json:"rows"`         // Filas guardadas (credencial y hora)
+	HoursPurged int `json:"hours_purged"` // Horas cerradas eliminadas de la cache
+}
@@ -0,0 +1,348 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/application/usecases/enrichment"
+	"github.com/MayaCris/stock-info-app/internal/application/usecases/population"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/google/uuid"
+)
+
+// PopulationPayload configura un job de población; los campos omitidos usan los valores por defecto
+type PopulationPayload struct {
+	BatchSize     *int  `json:"batch_size,omitempty"`
+	MaxPages      *int  `json:"max_pages,omitempty"`
+	DelayMs       *int  `json:"delay_ms,omitempty"`
+	ClearFirst    bool  `json:"clear_first,omitempty"`
+	UseCache      *bool `json:"use_cache,omitempty"`
+	DryRun        bool  `json:"dry_run,omitempty"`
+	ValidateAfter *bool `json:"validate_after,omitempty"`
+	Incremental   bool  `json:"incremental,omitempty"`
+}
+
+// IntegrityRepairPayload configura un job de reparación de integridad; sin dry_run solo se simula. Igual que
+// POST /admin/integrity/repair, aplicar exige el plan_token de una simulación como approval_token
+type IntegrityRepairPayload struct {
+	DryRun        *bool  `json:"dry_run,omitempty"` // Por defecto true: aplicar exige dry_run false explícito
+	ApprovalToken string `json:"approval_token,omitempty"`
+}
+
+// MarketDataRefreshPayload configura un job de refresco masivo de market data
+type MarketDataRefreshPayload struct {
+	Symbols  []string `json:"symbols,omitempty"`   // Vacío refresca todas las companies activas
+	Trending int      `json:"trending,omitempty"`  // Sin símbolos, solo los N más vistos de las últimas 24 horas
+	TenantID string   `json:"tenant_id,omitempty"` // Opcional: los refrescos consumen la cuota del plan del tenant
+}
+
+// CompanyEnrichmentPayload configura un job de enriquecimiento de companies desde sus perfiles
+type CompanyEnrichmentPayload struct {
+	DryRun             bool    `json:"dry_run,omitempty"`
+	MarketCapTolerance float64 `json:"market_cap_tolerance,omitempty"`
+}
+
+// AnomalyDetectionPayload configura un job de detección de anomalías
+type AnomalyDetectionPayload struct {
+	Days int `json:"days,omitempty"` // Últimos días a revisar (por defecto 7)
+}
+
+// RatingsReprocessPayload configura un job de re-proceso del RawData de los ratings
+type RatingsReprocessPayload struct {
+	From   string `json:"from"` // YYYY-MM-DD, inclusive
+	To     string `json:"to"`   // YYYY-MM-DD, inclusive
+	DryRun bool   `json:"dry_run,omitempty"`
+}
+
+// DatabaseBackupPayload configura un snapshot de la base de datos en el bucket de backups
+type DatabaseBackupPayload struct {
+	Tables []string `json:"tables,omitempty"` // Vacío exporta todas las tablas configuradas
+}
+
+// ParquetExportPayload configura una exportación Parquet de precios históricos y ratings
+type ParquetExportPayload struct {
+	Symbols  []string `json:"symbols,omitempty"`  // Vacío exporta todas las companies activas
+	Datasets []string `json:"datasets,omitempty"` // historical_prices y/o ratings
+	FromYear int      `json:"from_year,omitempty"`
+	ToYear   int      `json:"to_year,omitempty"`
+}
+
+// ActionTypeBackfillPayload configura el relleno de action_type a partir del texto de la acción
+type ActionTypeBackfillPayload struct {
+	All       bool `json:"all,omitempty"`        // Vuelve a clasificar todos los ratings (tras cambiar el parser)
+	BatchSize int  `json:"batch_size,omitempty"` // Filas por UPDATE (por defecto 1000)
+}
+
+// RatingProcessingPayload configura una ejecución del procesado de ratings pendientes; 0 usa los valores por defecto
+type RatingProcessingPayload struct {
+	BatchSize   int `json:"batch_size,omitempty"`
+	Concurrency int `json:"concurrency,omitempty"`
+	MaxBatches  int `json:"max_batches,omitempty"` // 0 procesa hasta vaciar el backlog
+}
+
+// ActionTypeBackfillResult es el resultado de un job action_type_backfill
+type ActionTypeBackfillResult struct {
+	Updated int64 `json:"updated"`
+}
+
+// NewPopulationJobHandler crea el handler que ejecuta el caso de uso de población
+func NewPopulationJobHandler(useCase *population.PopulateDatabaseUseCase) JobHandler {
+	return func(ctx context.Context, job *entities.Job) (interface{}, error) {
+		var payload PopulationPayload
+		if err := decodePayload(job, &payload); err != nil {
+			return nil, err
+		}
+
+		config := population.DefaultPopulationConfig()
+		if payload.BatchSize != nil {
+			config.BatchSize = *payload.BatchSize
+		}
+		if payload.MaxPages != nil {
+			config.MaxPages = *payload.MaxPages
+		}
+		if payload.DelayMs != nil {
+			config.DelayBetween = time.Duration(*payload.DelayMs) * time.Millisecond
+		}
+		if payload.UseCache != nil {
+			config.UseCache = *payload.UseCache
+		}
+		if payload.ValidateAfter != nil {
+			config.ValidateAfter = *payload.ValidateAfter
+		}
+		config.ClearFirst = payload.ClearFirst
+		config.DryRun = payload.DryRun
+		config.Incremental = payload.Incremental
+
+		return useCase.Execute(ctx, config)
+	}
+}
+
+// NewIntegrityRepairJobHandler crea el handler que simula o, con el token aprobado, repara problemas menores de
+// integridad; una aprobación que falta o no coincide con el plan vigente no se reintenta
+func NewIntegrityRepairJobHandler(repairService interfaces.IntegrityRepairService) JobHandler {
+	return func(ctx context.Context, job *entities.Job) (interface{}, error) {
+		var payload IntegrityRepairPayload
+		if err := decodePayload(job, &payload); err != nil {
+			return nil, err
+		}
+
+		result, err := repairService.Repair(ctx, &request.IntegrityRepairRequest{
+			DryRun:        payload.DryRun,
+			ApprovalToken: payload.ApprovalToken,
+		})
+		var errorResp *response.ErrorResponse
+		if errors.As(err, &errorResp) && errorResp.StatusCode < http.StatusInternalServerError {
+			return nil, Permanent(err)
+		}
+		return result, err
+	}
+}
+
+// NewIntegrityCheckJobHandler crea el handler que valida la integridad completa y guarda el snapshot en el historial
+func NewIntegrityCheckJobHandler(integrityHistory interfaces.IntegrityHistoryService) JobHandler {
+	return func(ctx context.Context, job *entities.Job) (interface{}, error) {
+		return integrityHistory.RecordSnapshot(ctx)
+	}
+}
+
+// NewMarketDataRefreshJobHandler crea el handler que refresca market data de varios símbolos. Con tenant_id
+// el refresco consume la cuota del plan del tenant; tenantRepo es nil sin multi-tenancy
+func NewMarketDataRefreshJobHandler(marketDataService interfaces.MarketDataService, tenantRepo repoInterfaces.TenantRepository) JobHandler {
+	return func(ctx context.Context, job *entities.Job) (interface{}, error) {
+		var payload MarketDataRefreshPayload
+		if err := decodePayload(job, &payload); err != nil {
+			return nil, err
+		}
+
+		options := interfaces.MarketDataRefreshOptions{
+			Symbols:  payload.Symbols,
+			Trending: payload.Trending,
+			OnProgress: func(progress response.MarketDataRefreshResponse) {
+				ReportProgress(ctx, progress)
+			},
+		}
+		if payload.TenantID != "" {
+			if tenantRepo == nil {
+				return nil, Permanent(errors.New("tenant_id requires multi-tenancy to be enabled"))
+			}
+			tenantID, err := uuid.Parse(payload.TenantID)
+			if err != nil {
+				return nil, Permanent(fmt.Errorf("invalid tenant_id %q: %w", payload.TenantID, err))
+			}
+			tenant, err := tenantRepo.GetByID(ctx, tenantID)
+			if err != nil {
+				return nil, err
+			}
+			options.TenantID = tenant.ID
+			options.Plan = tenant.Plan
+		}
+
+		return marketDataService.RefreshMarketData(ctx, options)
+	}
+}
+
+// NewCompanyEnrichmentJobHandler crea el handler que completa las companies desde sus perfiles guardados
+func NewCompanyEnrichmentJobHandler(enrichmentService *enrichment.CompanyEnrichmentService) JobHandler {
+	return func(ctx context.Context, job *entities.Job) (interface{}, error) {
+		var payload CompanyEnrichmentPayload
+		if err := decodePayload(job, &payload); err != nil {
+			return nil, err
+		}
+
+		return enrichmentService.Enrich(ctx, enrichment.EnrichmentOptions{
+			DryRun:             payload.DryRun,
+			MarketCapTolerance: payload.MarketCapTolerance,
+		})
+	}
+}
+
+// NewAnalyticsRefreshJobHandler crea el handler que refresca las vistas materializadas de analíticas
+func NewAnalyticsRefreshJobHandler(views repoInterfaces.AnalyticsViewRepository) JobHandler {
+	return func(ctx context.Context, job *entities.Job) (interface{}, error) {
+		return views.Refresh(ctx)
+	}
+}
+
+// NewAnomalyDetectionJobHandler crea el handler que detecta picos de ratings y de volumen
+func NewAnomalyDetectionJobHandler(anomalyService interfaces.AnomalyService) JobHandler {
+	return func(ctx context.Context, job *entities.Job) (interface{}, error) {
+		var payload AnomalyDetectionPayload
+		if err := decodePayload(job, &payload); err != nil {
+			return nil, err
+		}
+		return anomalyService.DetectAnomalies(ctx, payload.Days)
+	}
+}
+
+// NewRatingsReprocessJobHandler crea el handler que vuelve a convertir el RawData de los ratings de un rango
+func NewRatingsReprocessJobHandler(useCase *population.PopulateDatabaseUseCase) JobHandler {
+	return func(ctx context.Context, job *entities.Job) (interface{}, error) {
+		var payload RatingsReprocessPayload
+		if err := decodePayload(job, &payload); err != nil {
+			return nil, err
+		}
+
+		from, to, err := population.ReprocessRangeFromDates(payload.From, payload.To)
+		if err != nil {
+			return nil, err
+		}
+
+		return useCase.ReprocessRatings(ctx, population.RatingReprocessOptions{
+			From:   from,
+			To:     to,
+			DryRun: payload.DryRun,
+			OnProgress: func(progress population.RatingReprocessResult) {
+				ReportProgress(ctx, progress)
+			},
+		})
+	}
+}
+
+// NewDatabaseBackupJobHandler crea el handler que exporta las tablas al bucket de backups
+func NewDatabaseBackupJobHandler(backupService interfaces.BackupService) JobHandler {
+	return func(ctx context.Context, job *entities.Job) (interface{}, error) {
+		var payload DatabaseBackupPayload
+		if err := decodePayload(job, &payload); err != nil {
+			return nil, err
+		}
+		if err := backupService.ValidateTables(payload.Tables); err != nil {
+			return nil, Permanent(err)
+		}
+
+		return backupService.CreateBackup(ctx, interfaces.BackupOptions{
+			Tables: payload.Tables,
+			OnProgress: func(progress response.BackupResponse) {
+				ReportProgress(ctx, progress)
+			},
+		})
+	}
+}
+
+// NewParquetExportJobHandler crea el handler que exporta precios históricos y ratings en ficheros Parquet
+func NewParquetExportJobHandler(exportService interfaces.ParquetExportService) JobHandler {
+	return func(ctx context.Context, job *entities.Job) (interface{}, error) {
+		var payload ParquetExportPayload
+		if err := decodePayload(job, &payload); err != nil {
+			return nil, err
+		}
+
+		options := interfaces.ParquetExportOptions{
+			Symbols:  payload.Symbols,
+			Datasets: payload.Datasets,
+			FromYear: payload.FromYear,
+			ToYear:   payload.ToYear,
+			OnProgress: func(progress response.ParquetExportResponse) {
+				ReportProgress(ctx, progress)
+			},
+		}
+		if err := exportService.ValidateOptions(options); err != nil {
+			return nil, Permanent(err)
+		}
+
+		return exportService.Export(ctx, options)
+	}
+}
+
+// NewActionTypeBackfillJobHandler crea el handler que clasifica la acción de los ratings guardados sin action_type
+func NewActionTypeBackfillJobHandler(stockRatingRepo repoInterfaces.StockRatingRepository) JobHandler {
+	return func(ctx context.Context, job *entities.Job) (interface{}, error) {
+		var payload ActionTypeBackfillPayload
+		if err := decodePayload(job, &payload); err != nil {
+			return nil, err
+		}
+		if payload.BatchSize < 0 {
+			return nil, Permanent(errors.New("batch_size cannot be negative"))
+		}
+
+		updated, err := stockRatingRepo.BackfillActionTypes(ctx, payload.All, payload.BatchSize)
+		if err != nil {
+			return nil, err
+		}
+		return ActionTypeBackfillResult{Updated: updated}, nil
+	}
+}
+
+// NewRatingProcessingJobHandler crea el handler que normaliza los ratings pendientes y los marca como procesados
+func NewRatingProcessingJobHandler(processingService interfaces.RatingProcessingService) JobHandler {
+	return func(ctx context.Context, job *entities.Job) (interface{}, error) {
+		var payload RatingProcessingPayload
+		if err := decodePayload(job, &payload); err != nil {
+			return nil, err
+		}
+
+		options := interfaces.RatingProcessingOptions{
+			BatchSize:   payload.BatchSize,
+			Concurrency: payload.Concurrency,
+			MaxBatches:  payload.MaxBatches,
+			OnProgress: func(progress response.RatingProcessingResponse) {
+				ReportProgress(ctx, progress)
+			},
+		}
+		if err := processingService.ValidateOptions(options); err != nil {
+			return nil, Permanent(err)
+		}
+
+		return processingService.Process(ctx, options)
+	}
+}
+
+// NewUsageFlushJobHandler crea el handler que vuelca los contadores de uso de la API a la tabla api_usage_hourly
+func NewUsageFlushJobHandler(usageService interfaces.UsageService) JobHandler {
+	return func(ctx context.Context, job *entities.Job) (interface{}, error) {
+		return usageService.Flush(ctx)
+	}
+}
+
+// NewBenchmarkRefreshJobHandler crea el handler que recalcula los índices personalizados que no están al día
+func NewBenchmarkRefreshJobHandler(benchmarkService interfaces.BenchmarkService) JobHandler {
+	return func(ctx context.Context, job *entities.Job) (interface{}, error) {
+		return benchmarkService.RefreshCustom(ctx)
+	}
+}
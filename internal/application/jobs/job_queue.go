@@ -0,0 +1,137 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+)
+
+// Tipos de job soportados por el sistema
+const (
+	JobTypePopulation         = "population"
+	JobTypeIntegrityRepair    = "integrity_repair"
+	JobTypeMarketDataRefresh  = "market_data_refresh"
+	JobTypeCompanyEnrichment  = "company_enrichment"
+	JobTypeAnalyticsRefresh   = "analytics_refresh"
+	JobTypeAnomalyDetection   = "anomaly_detection"
+	JobTypeRatingsReprocess   = "ratings_reprocess"
+	JobTypeDatabaseBackup     = "database_backup"
+	JobTypeParquetExport      = "parquet_export"
+	JobTypeUsageFlush         = "usage_flush"
+	JobTypeActionTypeBackfill = "action_type_backfill"
+	JobTypeIntegrityCheck     = "integrity_check"
+	JobTypeRatingProcessing   = "rating_processing"
+	JobTypeBenchmarkRefresh   = "benchmark_refresh"
+)
+
+// DefaultMaxAttempts es el número de intentos por defecto de un job
+const DefaultMaxAttempts = 3
+
+// ErrUnknownJobType se retorna al encolar un tipo de job no soportado
+var ErrUnknownJobType = errors.New("unknown job type")
+
+// SupportedJobTypes retorna los tipos de job que los workers saben ejecutar
+func SupportedJobTypes() []string {
+	return []string{JobTypePopulation, JobTypeIntegrityRepair, JobTypeMarketDataRefresh, JobTypeCompanyEnrichment, JobTypeAnalyticsRefresh, JobTypeAnomalyDetection, JobTypeRatingsReprocess, JobTypeDatabaseBackup, JobTypeParquetExport, JobTypeUsageFlush, JobTypeActionTypeBackfill, JobTypeIntegrityCheck, JobTypeRatingProcessing, JobTypeBenchmarkRefresh}
+}
+
+// IsSupportedJobType verifica si un tipo de job es soportado
+func IsSupportedJobType(jobType string) bool {
+	for _, supported := range SupportedJobTypes() {
+		if supported == jobType {
+			return true
+		}
+	}
+	return false
+}
+
+// JobQueue encola y consulta jobs persistidos en base de datos.
+// Es el punto de entrada para los handlers HTTP: nunca ejecuta los jobs, solo los registra.
+type JobQueue struct {
+	repo interfaces.JobRepository
+}
+
+// NewJobQueue crea una nueva cola de jobs
+func NewJobQueue(repo interfaces.JobRepository) *JobQueue {
+	return &JobQueue{
+		repo: repo,
+	}
+}
+
+// Enqueue registra un nuevo job pendiente con el payload serializado como JSON
+func (q *JobQueue) Enqueue(ctx context.Context, jobType string, payload interface{}, maxAttempts int) (*entities.Job, error) {
+	if !IsSupportedJobType(jobType) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownJobType, jobType)
+	}
+
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+
+	encoded, err := encodePayload(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode job payload: %w", err)
+	}
+
+	job := entities.NewJob(jobType, encoded, maxAttempts)
+	if err := q.repo.Create(ctx, job); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// Get retorna un job por ID
+func (q *JobQueue) Get(ctx context.Context, id uuid.UUID) (*entities.Job, error) {
+	return q.repo.GetByID(ctx, id)
+}
+
+// List retorna una página de jobs y el total, opcionalmente filtrados por estado
+func (q *JobQueue) List(ctx context.Context, status entities.JobStatus, limit, offset int) ([]*entities.Job, int64, error) {
+	jobs, err := q.repo.List(ctx, status, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := q.repo.Count(ctx, status)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return jobs, total, nil
+}
+
+// Cancel cancela un job pendiente o solicita la cancelación de uno en ejecución
+func (q *JobQueue) Cancel(ctx context.Context, id uuid.UUID) (*entities.Job, error) {
+	return q.repo.RequestCancel(ctx, id)
+}
+
+// encodePayload serializa el payload; json.RawMessage y []byte se guardan tal cual
+func encodePayload(payload interface{}) (string, error) {
+	switch p := payload.(type) {
+	case nil:
+		return "", nil
+	case json.RawMessage:
+		if len(p) > 0 && !json.Valid(p) {
+			return "", fmt.Errorf("payload is not valid JSON")
+		}
+		return string(p), nil
+	case []byte:
+		if len(p) > 0 && !json.Valid(p) {
+			return "", fmt.Errorf("payload is not valid JSON")
+		}
+		return string(p), nil
+	default:
+		data, err := json.Marshal(p)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+}
@@ -0,0 +1,88 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// JobScheduler encola periódicamente un job de un tipo fijo.
+// Solo registra el job: lo ejecuta el WorkerPool de la instancia que lo reclame.
+type JobScheduler struct {
+	queue    *JobQueue
+	jobType  string
+	payload  interface{}
+	interval time.Duration
+	logger   logger.Logger
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewJobScheduler crea un scheduler que encola jobType cada interval
+func NewJobScheduler(queue *JobQueue, jobType string, payload interface{}, interval time.Duration, appLogger logger.Logger) *JobScheduler {
+	return &JobScheduler{
+		queue:    queue,
+		jobType:  jobType,
+		payload:  payload,
+		interval: interval,
+		logger:   appLogger,
+	}
+}
+
+// Start inicia el ciclo del scheduler en segundo plano
+func (s *JobScheduler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.loop(ctx)
+	}()
+}
+
+// Stop detiene el scheduler y espera a que el ciclo termine; los jobs ya encolados no se cancelan
+func (s *JobScheduler) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
+
+// loop encola un job en cada tick hasta que se cancele el contexto
+func (s *JobScheduler) loop(ctx context.Context) {
+	if s.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.enqueue(ctx)
+		}
+	}
+}
+
+// enqueue registra un nuevo job; los errores se registran y se reintenta en el siguiente tick
+func (s *JobScheduler) enqueue(ctx context.Context) {
+	job, err := s.queue.Enqueue(ctx, s.jobType, s.payload, DefaultMaxAttempts)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to enqueue scheduled job", err,
+			logger.String("job_type", s.jobType),
+		)
+		return
+	}
+
+	s.logger.Info(ctx, "Scheduled job enqueued",
+		logger.String("job_type", s.jobType),
+		logger.String("job_id", job.ID.String()),
+		logger.String("interval", s.interval.String()),
+	)
+}
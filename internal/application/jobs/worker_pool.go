@@ -0,0 +1,397 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// JobHandler ejecuta un job; el resultado se serializa como JSON en el job.
+// El contexto se cancela cuando se solicita la cancelación del job o el pool se detiene.
+type JobHandler func(ctx context.Context, job *entities.Job) (interface{}, error)
+
+// progressReporterKey guarda en el contexto del job la función que persiste su progreso
+type progressReporterKey struct{}
+
+// ReportProgress guarda el progreso parcial del job en ejecución como su resultado (visible en
+// GET /admin/jobs/{id} mientras corre); fuera de un job no hace nada
+func ReportProgress(ctx context.Context, progress interface{}) {
+	if report, ok := ctx.Value(progressReporterKey{}).(func(interface{})); ok {
+		report(progress)
+	}
+}
+
+// permanentError marca un error que no debe reintentarse
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent envuelve un error para que el job falle sin reintentos (ej. payload inválido)
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// isPermanent verifica si un error fue marcado como permanente
+func isPermanent(err error) bool {
+	var perm *permanentError
+	return errors.As(err, &perm)
+}
+
+// WorkerPoolConfig configura el pool de workers
+type WorkerPoolConfig struct {
+	Concurrency       int           // Número de workers concurrentes
+	PollInterval      time.Duration // Espera entre consultas cuando la cola está vacía
+	HeartbeatInterval time.Duration // Frecuencia de heartbeat y chequeo de cancelación
+	StaleAfter        time.Duration // Tiempo sin heartbeat tras el cual un job se re-encola
+	RetryBackoff      time.Duration // Delay base (exponencial) entre reintentos
+}
+
+// DefaultWorkerPoolConfig devuelve la configuración por defecto del pool
+func DefaultWorkerPoolConfig() WorkerPoolConfig {
+	return WorkerPoolConfig{
+		Concurrency:       2,
+		PollInterval:      2 * time.Second,
+		HeartbeatInterval: 10 * time.Second,
+		StaleAfter:        5 * time.Minute,
+		RetryBackoff:      30 * time.Second,
+	}
+}
+
+// WorkerPool consume jobs de la base de datos y los ejecuta con los handlers registrados
+type WorkerPool struct {
+	repo     interfaces.JobRepository
+	config   WorkerPoolConfig
+	logger   logger.Logger
+	handlers map[string]JobHandler
+	workerID string
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewWorkerPool crea un nuevo pool de workers
+func NewWorkerPool(repo interfaces.JobRepository, config WorkerPoolConfig, appLogger logger.Logger) *WorkerPool {
+	defaults := DefaultWorkerPoolConfig()
+	if config.Concurrency <= 0 {
+		config.Concurrency = defaults.Concurrency
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaults.PollInterval
+	}
+	if config.HeartbeatInterval <= 0 {
+		config.HeartbeatInterval = defaults.HeartbeatInterval
+	}
+	if config.StaleAfter <= 0 {
+		config.StaleAfter = defaults.StaleAfter
+	}
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = defaults.RetryBackoff
+	}
+
+	hostname, _ := os.Hostname()
+
+	return &WorkerPool{
+		repo:     repo,
+		config:   config,
+		logger:   appLogger,
+		handlers: make(map[string]JobHandler),
+		workerID: fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+	}
+}
+
+// Register asocia un handler a un tipo de job. Debe llamarse antes de Start.
+func (p *WorkerPool) Register(jobType string, handler JobHandler) {
+	p.handlers[jobType] = handler
+}
+
+// RegisteredTypes retorna los tipos de job con handler registrado
+func (p *WorkerPool) RegisteredTypes() []string {
+	types := make([]string, 0, len(p.handlers))
+	for jobType := range p.handlers {
+		types = append(types, jobType)
+	}
+	return types
+}
+
+// Start lanza los workers y el reaper de jobs huérfanos en segundo plano
+func (p *WorkerPool) Start(ctx context.Context) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cancel != nil {
+		return
+	}
+
+	ctx, p.cancel = context.WithCancel(ctx)
+
+	for i := 0; i < p.config.Concurrency; i++ {
+		workerID := fmt.Sprintf("%s-w%d", p.workerID, i)
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			p.work(ctx, workerID)
+		}()
+	}
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		p.reap(ctx)
+	}()
+
+	p.logger.Info(ctx, "Job worker pool started",
+		logger.Int("concurrency", p.config.Concurrency),
+		logger.Any("job_types", p.RegisteredTypes()),
+	)
+}
+
+// Stop detiene los workers y espera a que terminen los jobs en curso o expire el contexto.
+// Los jobs interrumpidos vuelven a la cola para que otro worker los retome.
+func (p *WorkerPool) Stop(ctx context.Context) error {
+	p.mu.Lock()
+	cancel := p.cancel
+	p.cancel = nil
+	p.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// work es el ciclo de un worker: reclamar, ejecutar, repetir
+func (p *WorkerPool) work(ctx context.Context, workerID string) {
+	jobTypes := p.RegisteredTypes()
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		job, err := p.repo.ClaimNext(ctx, workerID, jobTypes)
+		if err != nil && ctx.Err() == nil {
+			p.logger.Error(ctx, "Failed to claim job", err,
+				logger.String("worker_id", workerID),
+			)
+		}
+
+		if job == nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(p.config.PollInterval):
+			}
+			continue
+		}
+
+		p.process(ctx, workerID, job)
+	}
+}
+
+// process ejecuta un job y persiste el resultado
+func (p *WorkerPool) process(poolCtx context.Context, workerID string, job *entities.Job) {
+	// Las escrituras de estado no deben fallar porque el pool se esté deteniendo
+	storeCtx := context.Background()
+
+	p.logger.Info(storeCtx, "Job started",
+		logger.String("job_id", job.ID.String()),
+		logger.String("job_type", job.Type),
+		logger.String("worker_id", workerID),
+		logger.Int("attempt", job.Attempts),
+	)
+
+	jobCtx, cancelJob := context.WithCancel(poolCtx)
+	defer cancelJob()
+	jobCtx = context.WithValue(jobCtx, progressReporterKey{}, func(progress interface{}) {
+		p.storeProgress(storeCtx, workerID, job, progress)
+	})
+
+	var cancelRequested bool
+	var cancelMu sync.Mutex
+
+	heartbeatDone := make(chan struct{})
+	go func() {
+		defer close(heartbeatDone)
+		p.heartbeat(jobCtx, workerID, job, func() {
+			cancelMu.Lock()
+			cancelRequested = true
+			cancelMu.Unlock()
+			cancelJob()
+		})
+	}()
+
+	start := time.Now()
+	result, err := p.handlers[job.Type](jobCtx, job)
+	cancelJob()
+	<-heartbeatDone
+
+	cancelMu.Lock()
+	cancelled := cancelRequested
+	cancelMu.Unlock()
+
+	fields := []logger.Field{
+		logger.String("job_id", job.ID.String()),
+		logger.String("job_type", job.Type),
+		logger.String("duration", time.Since(start).String()),
+	}
+
+	switch {
+	case cancelled:
+		if storeErr := p.repo.MarkCancelled(storeCtx, job.ID); storeErr != nil {
+			p.logger.Error(storeCtx, "Failed to mark job as cancelled", storeErr, fields...)
+			return
+		}
+		p.logger.Info(storeCtx, "Job cancelled", fields...)
+
+	case err != nil && poolCtx.Err() != nil:
+		// El pool se detuvo a mitad de ejecución: devolver el job a la cola sin consumir el intento
+		if storeErr := p.repo.Release(storeCtx, job.ID); storeErr != nil {
+			p.logger.Error(storeCtx, "Failed to requeue interrupted job", storeErr, fields...)
+			return
+		}
+		p.logger.Warn(storeCtx, "Job interrupted by shutdown, requeued", fields...)
+
+	case err != nil && !isPermanent(err) && job.CanRetry():
+		runAt := time.Now().UTC().Add(job.NextRetryDelay(p.config.RetryBackoff))
+		if storeErr := p.repo.ScheduleRetry(storeCtx, job.ID, err.Error(), runAt); storeErr != nil {
+			p.logger.Error(storeCtx, "Failed to schedule job retry", storeErr, fields...)
+			return
+		}
+		p.logger.Warn(storeCtx, "Job failed, retry scheduled",
+			append(fields,
+				logger.String("error", err.Error()),
+				logger.Int("attempt", job.Attempts),
+				logger.Int("max_attempts", job.MaxAttempts),
+				logger.String("run_at", runAt.Format(time.RFC3339)),
+			)...,
+		)
+
+	case err != nil:
+		if storeErr := p.repo.MarkFailed(storeCtx, job.ID, err.Error()); storeErr != nil {
+			p.logger.Error(storeCtx, "Failed to mark job as failed", storeErr, fields...)
+			return
+		}
+		p.logger.Error(storeCtx, "Job failed", err,
+			append(fields, logger.Int("attempts", job.Attempts))...,
+		)
+
+	default:
+		encoded, encodeErr := encodePayload(result)
+		if encodeErr != nil {
+			p.logger.Warn(storeCtx, "Failed to encode job result",
+				append(fields, logger.String("error", encodeErr.Error()))...,
+			)
+			encoded = ""
+		}
+		if storeErr := p.repo.MarkSucceeded(storeCtx, job.ID, encoded); storeErr != nil {
+			p.logger.Error(storeCtx, "Failed to mark job as succeeded", storeErr, fields...)
+			return
+		}
+		p.logger.Info(storeCtx, "Job succeeded", fields...)
+	}
+}
+
+// storeProgress persiste el progreso parcial de un job; un fallo solo se registra
+func (p *WorkerPool) storeProgress(ctx context.Context, workerID string, job *entities.Job, progress interface{}) {
+	encoded, err := encodePayload(progress)
+	if err == nil {
+		err = p.repo.UpdateProgress(ctx, job.ID, workerID, encoded)
+	}
+	if err != nil {
+		p.logger.Warn(ctx, "Failed to store job progress",
+			logger.String("job_id", job.ID.String()),
+			logger.String("error", err.Error()),
+		)
+	}
+}
+
+// heartbeat mantiene el lock del job y detecta solicitudes de cancelación
+func (p *WorkerPool) heartbeat(ctx context.Context, workerID string, job *entities.Job, onCancel func()) {
+	ticker := time.NewTicker(p.config.HeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := p.repo.Heartbeat(ctx, job.ID, workerID); err != nil && ctx.Err() == nil {
+				p.logger.Warn(ctx, "Failed to send job heartbeat",
+					logger.String("job_id", job.ID.String()),
+					logger.String("error", err.Error()),
+				)
+			}
+
+			requested, err := p.repo.IsCancelRequested(ctx, job.ID)
+			if err == nil && requested {
+				onCancel()
+				return
+			}
+		}
+	}
+}
+
+// reap re-encola periódicamente los jobs cuyo worker dejó de enviar heartbeats
+func (p *WorkerPool) reap(ctx context.Context) {
+	ticker := time.NewTicker(p.config.StaleAfter / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			requeued, err := p.repo.RequeueStale(ctx, time.Now().UTC().Add(-p.config.StaleAfter))
+			if err != nil {
+				if ctx.Err() == nil {
+					p.logger.Error(ctx, "Failed to requeue stale jobs", err)
+				}
+				continue
+			}
+			if requeued > 0 {
+				p.logger.Warn(ctx, "Requeued stale jobs",
+					logger.Int("count", int(requeued)),
+				)
+			}
+		}
+	}
+}
+
+// decodePayload decodifica el payload JSON de un job; un payload vacío deja el destino sin cambios
+func decodePayload(job *entities.Job, dest interface{}) error {
+	if job.Payload == "" {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(job.Payload), dest); err != nil {
+		return Permanent(fmt.Errorf("invalid payload for job type %s: %w", job.Type, err))
+	}
+	return nil
+}
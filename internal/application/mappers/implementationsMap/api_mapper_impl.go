@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/MayaCris/stock-info-app/internal/application/mappers/interfacesMap"
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
 	"github.com/MayaCris/stock-info-app/internal/domain/entities"
 	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
 	"github.com/MayaCris/stock-info-app/internal/domain/services"
@@ -265,7 +266,7 @@ func (m *APIMapperImpl) findOrCreateCompany(ctx context.Context, item stock_api.
 	}
 
 	// Check if error is "not found" vs actual error
-	if !strings.Contains(err.Error(), "not found") {
+	if !domainerrors.IsNotFound(err) {
 		return nil, false, fmt.Errorf("failed to check existing company: %w", err)
 	}
 
@@ -314,7 +315,7 @@ func (m *APIMapperImpl) findOrCreateBrokerage(ctx context.Context, item stock_ap
 	}
 
 	// Check if error is "not found" vs actual error
-	if !strings.Contains(err.Error(), "not found") {
+	if !domainerrors.IsNotFound(err) {
 		return nil, false, fmt.Errorf("failed to check existing brokerage: %w", err)
 	}
 
@@ -0,0 +1,232 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/events"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// maxRetryBackoff limita la espera entre reintentos de un evento que no se pudo publicar
+const maxRetryBackoff = 10 * time.Minute
+
+// RelayConfig configura el relay del outbox
+type RelayConfig struct {
+	PollInterval  time.Duration // Espera entre pasadas cuando no quedan eventos pendientes
+	BatchSize     int           // Eventos leídos por pasada
+	ClaimLease    time.Duration // Tiempo que un lote reclamado queda reservado para este relay
+	RetryBackoff  time.Duration // Delay base (exponencial) tras un fallo de publicación
+	Retention     time.Duration // Tiempo que se conservan los eventos publicados (0 los conserva)
+	PruneInterval time.Duration // Frecuencia de la limpieza de eventos publicados
+}
+
+// DefaultRelayConfig devuelve la configuración por defecto del relay
+func DefaultRelayConfig() RelayConfig {
+	return RelayConfig{
+		PollInterval:  time.Second,
+		BatchSize:     100,
+		ClaimLease:    time.Minute,
+		RetryBackoff:  5 * time.Second,
+		Retention:     24 * time.Hour,
+		PruneInterval: time.Hour,
+	}
+}
+
+// RelayResult resume una pasada del relay
+type RelayResult struct {
+	Published int
+	Failed    int
+}
+
+// Relay publica en el bus los eventos pendientes del outbox y los marca como publicados. La API y el worker
+// ejecutan cada uno su relay: cada pasada reclama su lote (ClaimPending), así que no publican los mismos eventos a
+// la vez. La entrega sigue siendo at-least-once: si el proceso cae entre publicar y marcar, el evento se vuelve a
+// publicar al vencer la reserva, así que los suscriptores deduplican por el ID del evento
+type Relay struct {
+	repo   interfaces.OutboxRepository
+	bus    events.Publisher
+	config RelayConfig
+	logger logger.Logger
+
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	lastPrune time.Time
+}
+
+// NewRelay crea el relay del outbox; los valores no positivos de config usan DefaultRelayConfig
+func NewRelay(repo interfaces.OutboxRepository, bus events.Publisher, config RelayConfig, appLogger logger.Logger) *Relay {
+	defaults := DefaultRelayConfig()
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaults.PollInterval
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaults.BatchSize
+	}
+	if config.ClaimLease <= 0 {
+		config.ClaimLease = defaults.ClaimLease
+	}
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = defaults.RetryBackoff
+	}
+	if config.PruneInterval <= 0 {
+		config.PruneInterval = defaults.PruneInterval
+	}
+
+	return &Relay{
+		repo:   repo,
+		bus:    bus,
+		config: config,
+		logger: appLogger,
+	}
+}
+
+// Start inicia el ciclo del relay en segundo plano
+func (r *Relay) Start(ctx context.Context) {
+	ctx, r.cancel = context.WithCancel(ctx)
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		r.loop(ctx)
+	}()
+}
+
+// Stop detiene el relay y espera a que termine la pasada en curso; lo pendiente queda en el outbox
+func (r *Relay) Stop() {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+}
+
+// loop publica los pendientes en cuanto aparecen: tras una pasada con lote completo sigue sin esperar
+func (r *Relay) loop(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		result, err := r.RelayPending(ctx)
+		if err != nil && ctx.Err() == nil {
+			r.logger.Error(ctx, "Outbox relay pass failed", err)
+		}
+		r.prune(ctx)
+
+		wait := r.config.PollInterval
+		if err == nil && result.Failed == 0 && result.Published == r.config.BatchSize {
+			wait = 0
+		}
+		timer.Reset(wait)
+	}
+}
+
+// RelayPending publica un lote de eventos pendientes en orden. Tras el primer fallo de publicación la pasada
+// se detiene (el broker probablemente está caído) y el evento se reintenta con backoff exponencial; el resto del
+// lote se libera para el mismo momento, así se conserva el orden
+func (r *Relay) RelayPending(ctx context.Context) (RelayResult, error) {
+	var result RelayResult
+
+	rows, err := r.repo.ClaimPending(ctx, r.config.BatchSize, r.config.ClaimLease)
+	if err != nil {
+		return result, err
+	}
+
+	published := make([]events.Event, 0, len(rows))
+	for i, row := range rows {
+		event := events.FromOutbox(row)
+		if err := r.bus.Publish(ctx, event); err != nil {
+			result.Failed++
+			retryAt := time.Now().Add(RetryBackoff(r.config.RetryBackoff, row.Attempts))
+			if markErr := r.repo.MarkFailed(ctx, row.ID, err.Error(), retryAt); markErr != nil {
+				r.logger.Error(ctx, "Failed to record outbox delivery failure", markErr,
+					logger.String("event_id", row.ID.String()))
+			}
+			r.logger.Warn(ctx, "Failed to publish outbox event, will retry",
+				logger.String("event_id", row.ID.String()),
+				logger.String("event_type", row.EventType),
+				logger.Int("attempts", row.Attempts+1),
+				logger.String("retry_at", retryAt.UTC().Format(time.RFC3339)),
+				logger.String("error", err.Error()))
+			r.release(ctx, rows[i+1:], retryAt)
+			break
+		}
+		published = append(published, event)
+	}
+
+	if len(published) > 0 {
+		if err := r.repo.MarkPublished(ctx, eventIDs(published)); err != nil {
+			return result, err
+		}
+		result.Published = len(published)
+	}
+
+	return result, nil
+}
+
+// release devuelve al outbox los eventos reclamados que no se intentaron publicar; si falla, quedan reservados
+// hasta que vence ClaimLease
+func (r *Relay) release(ctx context.Context, rows []*entities.OutboxEvent, retryAt time.Time) {
+	if len(rows) == 0 {
+		return
+	}
+
+	ids := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+	if err := r.repo.Release(ctx, ids, retryAt); err != nil {
+		r.logger.Warn(ctx, "Failed to release claimed outbox events",
+			logger.Int("events", len(ids)),
+			logger.String("error", err.Error()))
+	}
+}
+
+// eventIDs devuelve los IDs de los eventos
+func eventIDs(evts []events.Event) []uuid.UUID {
+	ids := make([]uuid.UUID, len(evts))
+	for i, event := range evts {
+		ids[i] = event.ID
+	}
+	return ids
+}
+
+// prune borra los eventos publicados más antiguos que la retención, como mucho una vez por PruneInterval
+func (r *Relay) prune(ctx context.Context) {
+	if r.config.Retention <= 0 || time.Since(r.lastPrune) < r.config.PruneInterval {
+		return
+	}
+	r.lastPrune = time.Now()
+
+	deleted, err := r.repo.DeletePublishedBefore(ctx, time.Now().Add(-r.config.Retention))
+	if err != nil {
+		r.logger.Warn(ctx, "Failed to prune published outbox events",
+			logger.String("error", err.Error()))
+		return
+	}
+	if deleted > 0 {
+		r.logger.Info(ctx, "Pruned published outbox events",
+			logger.Int64("deleted", deleted),
+			logger.String("retention", r.config.Retention.String()))
+	}
+}
+
+// RetryBackoff returns the wait before retrying an event that already failed attempts times (0 on the first
+// failure): base doubled on every previous attempt, capped at 10 minutes
+func RetryBackoff(base time.Duration, attempts int) time.Duration {
+	backoff := base
+	for i := 0; i < attempts && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxRetryBackoff)
+}
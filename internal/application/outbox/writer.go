@@ -0,0 +1,43 @@
+// Package outbox implementa el patrón transactional outbox: los caminos de escritura guardan sus eventos de
+// dominio en la tabla event_outbox (en su misma transacción cuando la tienen) y el Relay los publica en el bus
+package outbox
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/events"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+)
+
+// Writer es el events.Publisher de los caminos de escritura cuando el outbox está activo: guarda los eventos en
+// lugar de publicarlos, así que un broker caído no hace perder ninguno
+type Writer struct {
+	repo interfaces.OutboxRepository
+}
+
+// NewWriter creates an outbox writer
+func NewWriter(repo interfaces.OutboxRepository) events.TxPublisher {
+	return &Writer{repo: repo}
+}
+
+// Publish guarda los eventos en el outbox en su propia transacción
+func (w *Writer) Publish(ctx context.Context, evts ...events.Event) error {
+	return w.repo.Add(ctx, toOutbox(evts))
+}
+
+// PublishWithTx guarda los eventos en la transacción del cambio que los produce
+func (w *Writer) PublishWithTx(ctx context.Context, tx *gorm.DB, evts ...events.Event) error {
+	return w.repo.AddWithTx(ctx, tx, toOutbox(evts))
+}
+
+// toOutbox convierte los eventos a filas del outbox
+func toOutbox(evts []events.Event) []*entities.OutboxEvent {
+	rows := make([]*entities.OutboxEvent, len(evts))
+	for i, event := range evts {
+		rows[i] = event.ToOutbox()
+	}
+	return rows
+}
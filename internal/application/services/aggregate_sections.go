@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// sectionGroup carga en paralelo las secciones independientes de una respuesta agregada (overview de company,
+// dashboard). Una sección que falla o supera su timeout no cancela a las demás: queda registrada con el motivo
+type sectionGroup struct {
+	group       *errgroup.Group
+	ctx         context.Context
+	timeout     time.Duration
+	logger      logger.Logger
+	fields      []logger.Field
+	mu          sync.Mutex
+	unavailable map[string]string
+}
+
+// newSectionGroup crea un grupo cuyas secciones tienen cada una timeout; fields se añaden a los logs de los fallos
+func newSectionGroup(ctx context.Context, timeout time.Duration, log logger.Logger, fields ...logger.Field) *sectionGroup {
+	group, groupCtx := errgroup.WithContext(ctx)
+	return &sectionGroup{
+		group:       group,
+		ctx:         groupCtx,
+		timeout:     timeout,
+		logger:      log,
+		fields:      fields,
+		unavailable: make(map[string]string),
+	}
+}
+
+// Go carga la sección name. load solo debe escribir en campos propios de la sección
+func (g *sectionGroup) Go(name string, load func(ctx context.Context) error) {
+	// Las secciones nunca devuelven error al grupo: un fallo no debe cancelar a las demás
+	g.group.Go(func() error {
+		ctx, cancel := context.WithTimeout(g.ctx, g.timeout)
+		defer cancel()
+
+		if err := load(ctx); err != nil {
+			fields := append([]logger.Field{logger.String("section", name), logger.ErrorField(err)}, g.fields...)
+			g.logger.Warn(ctx, "Aggregate section unavailable", fields...)
+
+			g.mu.Lock()
+			g.unavailable[name] = sectionErrorReason(ctx, err)
+			g.mu.Unlock()
+		}
+		return nil
+	})
+}
+
+// Wait espera a todas las secciones y devuelve las que no se pudieron cargar con su motivo (nil si ninguna)
+func (g *sectionGroup) Wait() map[string]string {
+	_ = g.group.Wait()
+	if len(g.unavailable) == 0 {
+		return nil
+	}
+	return g.unavailable
+}
+
+// sectionErrorReason describe por qué falta una sección sin exponer errores internos
+func sectionErrorReason(ctx context.Context, err error) string {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return "timed out"
+	}
+	var errorResp *response.ErrorResponse
+	if errors.As(err, &errorResp) && errorResp.StatusCode < 500 {
+		return errorResp.Message
+	}
+	return "temporarily unavailable"
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/alphavantage"
+)
+
+// alphaVantageError traduce un fallo de Alpha Vantage a la respuesta HTTP: límite de llamadas 429, símbolo
+// desconocido 404 y cualquier otro fallo del proveedor (respuesta mal formada, key rechazada) 502
+func alphaVantageError(err error, symbol, fallback string) *response.ErrorResponse {
+	var errorResp *response.ErrorResponse
+	if errors.As(err, &errorResp) {
+		return errorResp
+	}
+
+	switch {
+	case errors.Is(err, alphavantage.ErrRateLimited):
+		errorResp = response.NewErrorResponse(response.ErrCodeRateLimitExceeded,
+			"Alpha Vantage rate limit reached; retry later", http.StatusTooManyRequests)
+		details := map[string]interface{}{"provider": "alpha_vantage"}
+		var apiErr *alphavantage.APIError
+		if errors.As(err, &apiErr) && !apiErr.RetryAt.IsZero() {
+			details["retry_at"] = apiErr.RetryAt.Format(time.RFC3339)
+		}
+		return errorResp.WithDetails(details)
+	case errors.Is(err, alphavantage.ErrInvalidSymbol):
+		return response.NotFound("Company with symbol " + symbol)
+	default:
+		return response.ExternalAPIError("Alpha Vantage", fallback)
+	}
+}
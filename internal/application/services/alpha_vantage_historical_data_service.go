@@ -131,7 +131,8 @@ func (s *AlphaVantageHistoricalDataService) GetHistoricalDataFromAPI(ctx context
 	// Fetch data using strategy
 	response, err := strategy.FetchData(ctx, s.client, symbol, outputSize, interval, adjusted)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch %s data from Alpha Vantage: %w", period, err)
+		return nil, fmt.Errorf("failed to fetch %s data from Alpha Vantage: %w", period,
+			alphaVantageError(err, symbol, "Failed to retrieve historical data"))
 	}
 
 	// Convert to entities using strategy
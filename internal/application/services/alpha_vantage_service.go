@@ -3,8 +3,6 @@ package services
 import (
 	"context"
 	"fmt"
-	"strconv"
-	"strings"
 	"sync"
 	"time"
 
@@ -80,7 +78,8 @@ func (s *AlphaVantageService) GetFinancialMetricsFromAPI(ctx context.Context, sy
 	// Fetch overview data from Alpha Vantage
 	overviewData, err := s.client.GetCompanyOverview(ctx, symbol)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get company overview from Alpha Vantage: %w", err)
+		return nil, fmt.Errorf("failed to get company overview from Alpha Vantage: %w",
+			alphaVantageError(err, symbol, "Failed to retrieve financial metrics"))
 	}
 	// Update company with real information from AlphaVantage
 	if err := s.updateCompanyWithOverviewData(ctx, company, overviewData); err != nil {
@@ -313,12 +312,12 @@ func (s *AlphaVantageService) updateCompanyWithOverviewData(ctx context.Context,
 	company.Sector = overview.Sector
 	company.Exchange = overview.Exchange
 	// Parse market cap if available
-	if overview.MarketCapitalization != "" && overview.MarketCapitalization != "None" {
-		// Remove any non-numeric characters except decimal point
-		cleanValue := strings.ReplaceAll(overview.MarketCapitalization, ",", "")
-		if marketCap, err := strconv.ParseFloat(cleanValue, 64); err == nil {
-			company.MarketCap = marketCap
-		}
+	if marketCap, ok, err := alphavantage.ParseFloat(overview.MarketCapitalization); err != nil {
+		s.logger.Warn(ctx, "Ignoring malformed market cap from overview",
+			logger.String("symbol", overview.Symbol),
+			logger.String("value", overview.MarketCapitalization))
+	} else if ok {
+		company.MarketCap = marketCap
 	}
 
 	// Save updated company
@@ -228,7 +228,8 @@ func (s *AlphaVantageTechnicalIndicatorsService) processIndicatorStrategy(ctx co
 	// Fetch data using strategy
 	response, err := strategy.FetchData(ctx, s.client, symbol, interval, timePeriod, seriesType)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch %s data: %w", strategy.GetIndicatorName(), err)
+		return nil, fmt.Errorf("failed to fetch %s data: %w", strategy.GetIndicatorName(),
+			alphaVantageError(err, symbol, "Failed to retrieve technical indicators"))
 	} // Convert to entities using strategy
 	indicators, err := strategy.ConvertToEntity(ctx, s.adapter, response, symbol, companyID, timePeriod, interval)
 	if err != nil {
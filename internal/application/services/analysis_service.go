@@ -2,23 +2,67 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
 	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
 	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
 	"github.com/MayaCris/stock-info-app/internal/domain/entities"
 	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	domainServices "github.com/MayaCris/stock-info-app/internal/domain/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/timezone"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
 )
 
+// Límites del rango de GetRatingTrends
+const (
+	maxTrendRangeDays = 3660 // Diez años
+	maxDailyTrendDays = 731  // Con buckets diarios, dos años
+)
+
+// Meses por defecto de GetSectorRotation y formato de sus meses
+const (
+	defaultSectorRotationMonths = 12
+	sectorRotationMonthLayout   = "2006-01"
+)
+
+// trendPeriodDays son los días de los periodos predefinidos de las tendencias
+var trendPeriodDays = map[string]int{
+	"week":    7,
+	"month":   30,
+	"quarter": 90,
+	"year":    365,
+}
+
 // analysisService implements the AnalysisService interface
 type analysisService struct {
-	stockRatingRepo repoInterfaces.StockRatingRepository
-	companyRepo     repoInterfaces.CompanyRepository
-	brokerageRepo   repoInterfaces.BrokerageRepository
-	logger          logger.Logger
+	stockRatingRepo      repoInterfaces.StockRatingRepository
+	companyRepo          repoInterfaces.CompanyRepository
+	brokerageRepo        repoInterfaces.BrokerageRepository
+	financialMetricsRepo repoInterfaces.FinancialMetricsRepository // Opcional: sin él la comparación no incluye fundamentales
+	historicalDataRepo   repoInterfaces.HistoricalDataRepository   // Opcional: sin él la comparación no incluye rendimientos
+	marketDataRepo       repoInterfaces.MarketDataRepository       // Opcional: sin él la amplitud no incluye las cotizaciones
+	benchmarkRepo        repoInterfaces.BenchmarkRepository        // Opcional: sin él el riesgo usa los precios del símbolo del benchmark
+	fxService            interfaces.FXService                      // Opcional: sin él las capitalizaciones solo se agregan en su moneda
+	peerService          interfaces.PeerService                    // Opcional: sin él la comparación exige tickers
+	logger               logger.Logger
+
+	// Cache opcional de los cálculos costosos (matriz de correlación)
+	cacheService domainServices.CacheService
+	cacheTTL     time.Duration
+
+	// Pesos del modelo de recomendación (recargables en caliente)
+	weights   ScoringWeights
+	weightsMu sync.RWMutex
 }
 
 // NewAnalysisService creates a new analysis service
@@ -26,14 +70,33 @@ func NewAnalysisService(
 	stockRatingRepo repoInterfaces.StockRatingRepository,
 	companyRepo repoInterfaces.CompanyRepository,
 	brokerageRepo repoInterfaces.BrokerageRepository,
+	financialMetricsRepo repoInterfaces.FinancialMetricsRepository,
+	historicalDataRepo repoInterfaces.HistoricalDataRepository,
+	marketDataRepo repoInterfaces.MarketDataRepository,
+	benchmarkRepo repoInterfaces.BenchmarkRepository,
+	fxService interfaces.FXService,
+	peerService interfaces.PeerService,
+	cacheService domainServices.CacheService,
+	cacheTTL time.Duration,
+	weights ScoringWeights,
 	logger logger.Logger,
 ) interfaces.AnalysisService {
-	return &analysisService{
-		stockRatingRepo: stockRatingRepo,
-		companyRepo:     companyRepo,
-		brokerageRepo:   brokerageRepo,
-		logger:          logger,
+	service := &analysisService{
+		stockRatingRepo:      stockRatingRepo,
+		companyRepo:          companyRepo,
+		brokerageRepo:        brokerageRepo,
+		financialMetricsRepo: financialMetricsRepo,
+		historicalDataRepo:   historicalDataRepo,
+		marketDataRepo:       marketDataRepo,
+		benchmarkRepo:        benchmarkRepo,
+		fxService:            fxService,
+		peerService:          peerService,
+		logger:               logger,
+		cacheService:         cacheService,
+		cacheTTL:             cacheTTL,
 	}
+	service.UpdateScoringWeights(weights)
+	return service
 }
 
 // GetCompanyAnalysis provides detailed analysis for a specific company
@@ -65,31 +128,35 @@ func (s *analysisService) GetCompanyAnalysis(ctx context.Context, companyID uuid
 		for i := len(ratings) - limit; i < len(ratings); i++ {
 			rating := ratings[i]
 			recentRatingResponses = append(recentRatingResponses, response.StockRatingListResponse{
-				ID:        rating.ID,
-				CompanyID: rating.CompanyID,
-				Ticker:    company.Ticker,
-				Company:   company.Name,
-				Action:    rating.Action,
-				RatingTo:  rating.RatingTo,
-				TargetTo:  rating.TargetTo,
-				EventTime: rating.EventTime,
+				ID:             rating.ID,
+				CompanyID:      rating.CompanyID,
+				Ticker:         company.Ticker,
+				Company:        company.Name,
+				Action:         rating.Action,
+				ActionType:     string(rating.GetActionType()),
+				RatingTo:       rating.RatingTo,
+				TargetTo:       rating.TargetTo,
+				TargetToValue:  rating.TargetToValue,
+				TargetCurrency: rating.TargetCurrency,
+				EventTime:      rating.EventTime,
 			})
 		}
 	}
 
-	// Generate recommendation
-	recommendation := s.generateSimpleRecommendation(ratings)
+	// Misma recomendación que GenerateRecommendation
+	_, score := s.compositeRecommendation(ctx, company, ratings)
 
 	// Create analysis response
 	analysisResp := &response.AnalysisResponse{
-		CompanyID:      companyID,
-		CompanyName:    company.Name,
-		Ticker:         company.Ticker,
-		TotalRatings:   len(ratings),
-		RecentRatings:  recentRatingResponses,
-		Recommendation: recommendation,
-		Summary:        ratingStats,
-		GeneratedAt:    time.Now(),
+		CompanyID:           companyID,
+		CompanyName:         company.Name,
+		Ticker:              company.Ticker,
+		TotalRatings:        len(ratings),
+		RecentRatings:       recentRatingResponses,
+		Recommendation:      RecommendationLabel(score),
+		RecommendationScore: score,
+		Summary:             ratingStats,
+		GeneratedAt:         time.Now(),
 	}
 
 	return analysisResp, nil
@@ -176,10 +243,32 @@ func (s *analysisService) GetSectorAnalysis(ctx context.Context, sector string)
 	return analysis, nil
 }
 
-// GetTopRatedCompanies gets top rated companies
-func (s *analysisService) GetTopRatedCompanies(ctx context.Context, limit int) ([]*response.CompanyListResponse, error) {
+// GetTopRatedCompanies gets top rated companies, optionally only those with all the given tags (comma-separated slugs)
+func (s *analysisService) GetTopRatedCompanies(ctx context.Context, limit int, tags string) ([]*response.CompanyListResponse, error) {
+	tagFilter, err := parseTagFilter(tags)
+	if err != nil {
+		return nil, err
+	}
+
+	// Con etiquetas se ordenan todas las companies y se quedan las etiquetadas
+	var tagged map[uuid.UUID]bool
+	fetchLimit := limit
+	if len(tagFilter) > 0 {
+		companies, _, err := s.companyRepo.List(ctx, repoInterfaces.CompanyListFilter{Tags: tagFilter}, nil, -1, 0)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to get tagged companies", err,
+				logger.String("tags", strings.Join(tagFilter, ",")))
+			return nil, response.InternalServerError("Failed to get top rated companies")
+		}
+		tagged = make(map[uuid.UUID]bool, len(companies))
+		for _, company := range companies {
+			tagged[company.ID] = true
+		}
+		fetchLimit = 0
+	}
+
 	// Get top companies by rating count
-	topCompanies, err := s.stockRatingRepo.GetTopCompaniesByRatingCount(ctx, 30, limit)
+	topCompanies, err := s.stockRatingRepo.GetTopCompaniesByRatingCount(ctx, 30, fetchLimit)
 	if err != nil {
 		s.logger.Error(ctx, "Failed to get top rated companies", err)
 		return nil, response.InternalServerError("Failed to get top rated companies")
@@ -188,6 +277,12 @@ func (s *analysisService) GetTopRatedCompanies(ctx context.Context, limit int) (
 	// Convert to company list responses
 	responses := make([]*response.CompanyListResponse, 0, len(topCompanies))
 	for _, companyCount := range topCompanies {
+		if tagged != nil && !tagged[companyCount.CompanyID] {
+			continue
+		}
+		if len(responses) == limit {
+			break
+		}
 		// Get full company details
 		company, err := s.companyRepo.GetByID(ctx, companyCount.CompanyID)
 		if err != nil {
@@ -207,37 +302,561 @@ func (s *analysisService) GetTopRatedCompanies(ctx context.Context, limit int) (
 	return responses, nil
 }
 
-// GetRatingTrends provides rating trends over time
-func (s *analysisService) GetRatingTrends(ctx context.Context, period string) (map[string]interface{}, error) {
-	days := 30 // Default
-	switch period {
-	case "week":
-		days = 7
-	case "month":
-		days = 30
-	case "quarter":
-		days = 90
-	case "year":
-		days = 365
+// CompareCompanies compares up to maxComparisonTickers companies side by side: valuation ratios, growth,
+// brokerage consensus and recent price returns, plus every metric normalized within the compared set.
+// Without tickers, the company given by ticker is compared with its peers
+func (s *analysisService) CompareCompanies(ctx context.Context, req *request.CompareCompaniesRequest) (*response.CompanyComparisonResponse, error) {
+	rawTickers := req.Tickers
+	if rawTickers == "" && req.Ticker != "" {
+		peerTickers, err := s.peerTickers(ctx, req.Ticker)
+		if err != nil {
+			return nil, err
+		}
+		rawTickers = strings.Join(peerTickers, ",")
+	}
+
+	tickers, err := parseTickerList(rawTickers, 2, maxComparisonTickers)
+	if err != nil {
+		return nil, response.BadRequest(err.Error())
+	}
+
+	comparison := &response.CompanyComparisonResponse{
+		Tickers:     tickers,
+		Companies:   make([]response.CompanyComparisonEntry, 0, len(tickers)),
+		Leaders:     make(map[string]string),
+		GeneratedAt: time.Now(),
+	}
+
+	for _, ticker := range tickers {
+		company, err := s.companyRepo.GetByTicker(ctx, ticker)
+		if err != nil {
+			if domainerrors.IsNotFound(err) {
+				comparison.NotFound = append(comparison.NotFound, ticker)
+				continue
+			}
+			s.logger.Error(ctx, "Failed to get company for comparison", err,
+				logger.String("ticker", ticker))
+			return nil, response.InternalServerError("Failed to compare companies")
+		}
+
+		entry := response.CompanyComparisonEntry{
+			CompanyID: company.ID,
+			Ticker:    company.Ticker,
+			Name:      company.Name,
+			Sector:    company.Sector,
+			Exchange:  company.Exchange,
+			MarketCap: company.MarketCap,
+		}
+
+		ratings, err := s.stockRatingRepo.GetByCompanyID(ctx, company.ID)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to get company ratings for comparison", err,
+				logger.String("ticker", ticker))
+			return nil, response.InternalServerError("Failed to compare companies")
+		}
+		entry.Consensus = s.calculateConsensus(ratings)
+
+		if s.historicalDataRepo != nil {
+			prices, err := s.historicalDataRepo.GetBySymbolLastN(ctx, company.Ticker, tradingDaysPerYear+1)
+			if err != nil {
+				s.logger.Error(ctx, "Failed to get price history for comparison", err,
+					logger.String("ticker", ticker))
+				return nil, response.InternalServerError("Failed to compare companies")
+			}
+			entry.Performance = calculatePerformance(prices)
+		}
+
+		comparison.Companies = append(comparison.Companies, entry)
+	}
+
+	if len(comparison.Companies) == 0 {
+		return nil, response.NotFound("Companies " + strings.Join(tickers, ", "))
 	}
 
-	// Get action type distribution
-	actionDistribution, err := s.stockRatingRepo.GetActionTypeDistribution(ctx, days)
+	if s.financialMetricsRepo != nil {
+		if err := s.addComparisonFundamentals(ctx, comparison.Companies); err != nil {
+			s.logger.Error(ctx, "Failed to get financial metrics for comparison", err)
+			return nil, response.InternalServerError("Failed to compare companies")
+		}
+	}
+
+	scoreComparison(comparison)
+
+	return comparison, nil
+}
+
+// GetCorrelationMatrix computes the pairwise correlation of daily returns between tickers over the last
+// days calendar days of stored prices. Results are cached because the computation is O(n²·days)
+func (s *analysisService) GetCorrelationMatrix(ctx context.Context, req *request.CorrelationRequest) (*response.CorrelationMatrixResponse, error) {
+	if s.historicalDataRepo == nil {
+		return nil, response.ServiceUnavailable("Historical prices are not available")
+	}
+
+	tickers, err := parseTickerList(req.Tickers, 2, maxCorrelationTickers)
 	if err != nil {
-		s.logger.Error(ctx, "Failed to get rating trends", err)
+		return nil, response.BadRequest(err.Error())
+	}
+
+	days := req.Days
+	if days <= 0 {
+		days = defaultCorrelationDays
+	}
+
+	to := time.Now().UTC().Truncate(24 * time.Hour)
+	from := to.AddDate(0, 0, -days)
+
+	cacheKey := fmt.Sprintf("analysis:correlation:%s:%d:%s", strings.Join(tickers, ","), days, to.Format("2006-01-02"))
+	var cached response.CorrelationMatrixResponse
+	if s.getCachedAnalysis(ctx, cacheKey, &cached) {
+		return &cached, nil
+	}
+
+	matrix := &response.CorrelationMatrixResponse{
+		Tickers:     tickers,
+		Days:        days,
+		From:        from,
+		To:          to,
+		GeneratedAt: time.Now(),
+	}
+
+	returns := make([]map[string]float64, len(tickers))
+	for i, ticker := range tickers {
+		symbol := ticker
+		company, err := s.companyRepo.GetByTicker(ctx, ticker)
+		switch {
+		case err == nil:
+			symbol = company.Ticker
+		case domainerrors.IsNotFound(err):
+			matrix.NotFound = append(matrix.NotFound, ticker)
+			continue
+		default:
+			s.logger.Error(ctx, "Failed to get company for correlation", err,
+				logger.String("ticker", ticker))
+			return nil, response.InternalServerError("Failed to compute correlation matrix")
+		}
+
+		prices, err := s.historicalDataRepo.GetBySymbol(ctx, symbol, from, to)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to get price history for correlation", err,
+				logger.String("ticker", ticker))
+			return nil, response.InternalServerError("Failed to compute correlation matrix")
+		}
+		returns[i] = DailyReturns(prices)
+	}
+
+	if len(matrix.NotFound) == len(tickers) {
+		return nil, response.NotFound("Companies " + strings.Join(tickers, ", "))
+	}
+
+	matrix.Matrix = make([][]*float64, len(tickers))
+	matrix.Observations = make([][]int, len(tickers))
+	for i := range tickers {
+		matrix.Matrix[i] = make([]*float64, len(tickers))
+		matrix.Observations[i] = make([]int, len(tickers))
+	}
+	for i := range tickers {
+		for j := i; j < len(tickers); j++ {
+			x, y := pairReturns(returns[i], returns[j])
+			correlation := PearsonCorrelation(x, y)
+			matrix.Matrix[i][j], matrix.Matrix[j][i] = correlation, correlation
+			matrix.Observations[i][j], matrix.Observations[j][i] = len(x), len(x)
+		}
+	}
+
+	s.setCachedAnalysis(ctx, cacheKey, matrix)
+
+	return matrix, nil
+}
+
+// getCachedAnalysis carga en dest un resultado cacheado; false si no hay cache o la entrada no existe
+func (s *analysisService) getCachedAnalysis(ctx context.Context, key string, dest interface{}) bool {
+	if s.cacheService == nil || s.cacheTTL <= 0 {
+		return false
+	}
+
+	data, err := s.cacheService.Get(ctx, key)
+	if err != nil || data == nil {
+		return false
+	}
+
+	return json.Unmarshal(data, dest) == nil
+}
+
+// setCachedAnalysis guarda un resultado durante el TTL de analíticas; los fallos solo se registran
+func (s *analysisService) setCachedAnalysis(ctx context.Context, key string, value interface{}) {
+	if s.cacheService == nil || s.cacheTTL <= 0 {
+		return
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	if err := s.cacheService.Set(ctx, key, data, s.cacheTTL); err != nil {
+		s.logger.Warn(ctx, "Failed to cache analysis result",
+			logger.String("key", key),
+			logger.String("error", err.Error()),
+		)
+	}
+}
+
+// GetPortfolioRisk computes the risk metrics of a portfolio of stored companies (annualized return and
+// volatility, beta vs. a benchmark, Sharpe ratio and max drawdown) from the last days calendar days of
+// stored prices. The portfolio is rebalanced daily to the requested weights
+func (s *analysisService) GetPortfolioRisk(ctx context.Context, req *request.PortfolioRiskRequest) (*response.PortfolioRiskResponse, error) {
+	if s.historicalDataRepo == nil {
+		return nil, response.ServiceUnavailable("Historical prices are not available")
+	}
+
+	holdings, err := parseHoldings(req.Holdings, maxPortfolioHoldings)
+	if err != nil {
+		return nil, response.BadRequest(err.Error())
+	}
+
+	benchmark := strings.ToUpper(strings.TrimSpace(req.Benchmark))
+	if benchmark == "" {
+		benchmark = defaultBenchmark
+	}
+	days := req.Days
+	if days <= 0 {
+		days = defaultRiskDays
+	}
+
+	to := time.Now().UTC().Truncate(24 * time.Hour)
+	from := to.AddDate(0, 0, -days)
+
+	keyParts := make([]string, len(holdings))
+	for i, holding := range holdings {
+		keyParts[i] = fmt.Sprintf("%s=%g", holding.Ticker, holding.Weight)
+	}
+	cacheKey := fmt.Sprintf("analysis:risk:%s:%s:%d:%g:%s",
+		strings.Join(keyParts, ","), benchmark, days, req.RiskFreeRate, to.Format("2006-01-02"))
+	var cached response.PortfolioRiskResponse
+	if s.getCachedAnalysis(ctx, cacheKey, &cached) {
+		return &cached, nil
+	}
+
+	benchmarkReturns, tracked, err := s.benchmarkReturns(ctx, benchmark, from, to)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get benchmark price history", err,
+			logger.String("benchmark", benchmark))
+		return nil, response.InternalServerError("Failed to compute portfolio risk")
+	}
+
+	holdingReturns := make([]map[string]float64, len(holdings))
+	for i := range holdings {
+		company, err := s.companyRepo.GetByTicker(ctx, holdings[i].Ticker)
+		if err != nil {
+			return nil, response.FromError(err, "Company "+holdings[i].Ticker, "Failed to compute portfolio risk")
+		}
+
+		prices, err := s.historicalDataRepo.GetBySymbol(ctx, company.Ticker, from, to)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to get price history for portfolio risk", err,
+				logger.String("ticker", company.Ticker))
+			return nil, response.InternalServerError("Failed to compute portfolio risk")
+		}
+
+		holdingReturns[i] = DailyReturns(prices)
+		if len(holdingReturns[i]) == 0 {
+			return nil, response.BadRequest(fmt.Sprintf("no stored price history for %s in the last %d days", holdings[i].Ticker, days))
+		}
+		holdings[i].Risk = CalculateRiskMetrics(holdingReturns[i], benchmarkReturns, req.RiskFreeRate)
+	}
+
+	portfolioReturns := WeightedReturns(holdingReturns, holdings)
+	risk := &response.PortfolioRiskResponse{
+		Holdings:     holdings,
+		Benchmark:    benchmark,
+		Days:         days,
+		From:         from,
+		To:           to,
+		RiskFreeRate: req.RiskFreeRate,
+		Observations: len(portfolioReturns),
+		Portfolio:    CalculateRiskMetrics(portfolioReturns, benchmarkReturns, req.RiskFreeRate),
+		GeneratedAt:  time.Now(),
+	}
+	if tracked != nil {
+		risk.BenchmarkName = tracked.Name
+	}
+	if len(benchmarkReturns) == 0 {
+		risk.Warnings = append(risk.Warnings, fmt.Sprintf("no stored price history for benchmark %s; beta is not available", benchmark))
+	} else {
+		benchmarkRisk := CalculateRiskMetrics(benchmarkReturns, benchmarkReturns, req.RiskFreeRate)
+		risk.BenchmarkRisk = &benchmarkRisk
+	}
+
+	s.setCachedAnalysis(ctx, cacheKey, risk)
+
+	return risk, nil
+}
+
+// benchmarkReturns devuelve los rendimientos diarios del benchmark: los valores ingeridos si es un benchmark
+// registrado (SPX, o su ETF SPY) con valores en el rango y, si no, los precios guardados del símbolo
+func (s *analysisService) benchmarkReturns(ctx context.Context, symbol string, from, to time.Time) (map[string]float64, *entities.Benchmark, error) {
+	if s.benchmarkRepo != nil {
+		benchmark, err := s.benchmarkRepo.GetBySymbol(ctx, symbol)
+		if err == nil {
+			values, err := s.benchmarkRepo.GetValues(ctx, benchmark.ID, from, to)
+			if err != nil {
+				return nil, nil, err
+			}
+			if len(values) > 0 {
+				return BenchmarkReturns(values), benchmark, nil
+			}
+		} else if !domainerrors.IsNotFound(err) {
+			return nil, nil, err
+		}
+	}
+
+	prices, err := s.historicalDataRepo.GetBySymbol(ctx, symbol, from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+	return DailyReturns(prices), nil, nil
+}
+
+// GetRatingTrends provides the ratings per action type over a preset period (the last days up to today) or an explicit
+// from/to range, bucketed by day, week or month. Days are calendar days in the time zone of the context
+func (s *analysisService) GetRatingTrends(ctx context.Context, req *request.RatingTrendsRequest) (map[string]interface{}, error) {
+	// Sin zona horaria explícita se usa UTC: la hora local del servidor no tiene un nombre IANA para date_trunc
+	location := timezone.FromContext(ctx)
+	if location == time.Local {
+		location = time.UTC
+	}
+
+	period := req.Period
+	if period == "" {
+		period = "month"
+	}
+
+	to := timezone.StartOfDay(time.Now().In(location))
+	if req.To != "" {
+		parsed, err := time.ParseInLocation(ratingDateLayout, req.To, location)
+		if err != nil {
+			return nil, response.BadRequest("to must be a date in YYYY-MM-DD format")
+		}
+		to = parsed
+	}
+	from := to.AddDate(0, 0, 1-trendPeriodDays[period])
+	if req.From != "" {
+		parsed, err := time.ParseInLocation(ratingDateLayout, req.From, location)
+		if err != nil {
+			return nil, response.BadRequest("from must be a date in YYYY-MM-DD format")
+		}
+		from = parsed
+	}
+	if to.Before(from) {
+		return nil, response.BadRequest("to must not be before from")
+	}
+
+	// El último día se incluye completo; AddDate respeta los días de cambio de horario
+	end := to.AddDate(0, 0, 1)
+	days := int(math.Round(end.Sub(from).Hours() / 24))
+	if days > maxTrendRangeDays {
+		return nil, response.BadRequest(fmt.Sprintf("The range cannot exceed %d days", maxTrendRangeDays))
+	}
+
+	granularity := repoInterfaces.TrendGranularity(req.Granularity)
+	switch {
+	case granularity != "":
+	case days <= 31:
+		granularity = repoInterfaces.TrendGranularityDay
+	case days <= 184:
+		granularity = repoInterfaces.TrendGranularityWeek
+	default:
+		granularity = repoInterfaces.TrendGranularityMonth
+	}
+	if granularity == repoInterfaces.TrendGranularityDay && days > maxDailyTrendDays {
+		return nil, response.BadRequest(fmt.Sprintf("Daily granularity supports ranges of up to %d days", maxDailyTrendDays))
+	}
+
+	buckets, err := s.stockRatingRepo.GetActionTypeTrend(ctx, from, end, granularity, location)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get rating trends", err,
+			logger.String("from", from.Format(ratingDateLayout)),
+			logger.String("to", to.Format(ratingDateLayout)),
+			logger.String("granularity", string(granularity)),
+		)
 		return nil, response.InternalServerError("Failed to get rating trends")
 	}
 
+	series := fillTrendBuckets(buckets, from, end, granularity)
+	actionDistribution := make(map[string]int64)
+	for _, bucket := range series {
+		for action, count := range bucket.Actions {
+			actionDistribution[action] += count
+		}
+	}
+
 	trends := map[string]interface{}{
-		"period":       period,
 		"days":         days,
+		"from":         from.Format(ratingDateLayout),
+		"to":           to.Format(ratingDateLayout),
+		"timezone":     location.String(),
+		"granularity":  granularity,
 		"actions":      actionDistribution,
+		"buckets":      series,
 		"generated_at": time.Now(),
 	}
+	if req.From == "" {
+		trends["period"] = period
+	}
 
 	return trends, nil
 }
 
+// fillTrendBuckets devuelve un bucket por cada intervalo de [from, end), con ceros donde no hubo ratings,
+// para que las gráficas no tengan huecos
+func fillTrendBuckets(buckets []repoInterfaces.RatingTrendBucket, from, end time.Time, granularity repoInterfaces.TrendGranularity) []repoInterfaces.RatingTrendBucket {
+	byStart := make(map[int64]repoInterfaces.RatingTrendBucket, len(buckets))
+	for _, bucket := range buckets {
+		byStart[bucket.Start.Unix()] = bucket
+	}
+
+	start := timezone.StartOfDay(from)
+	switch granularity {
+	case repoInterfaces.TrendGranularityWeek:
+		// date_trunc('week') empieza las semanas el lunes
+		start = start.AddDate(0, 0, -(int(start.Weekday())+6)%7)
+	case repoInterfaces.TrendGranularityMonth:
+		start = timezone.StartOfMonth(start)
+	}
+
+	series := make([]repoInterfaces.RatingTrendBucket, 0)
+	for ; start.Before(end); start = nextTrendBucket(start, granularity) {
+		bucket, ok := byStart[start.Unix()]
+		if !ok {
+			bucket = repoInterfaces.RatingTrendBucket{Start: start, Actions: map[string]int64{}}
+		}
+		series = append(series, bucket)
+	}
+	return series
+}
+
+// nextTrendBucket devuelve el inicio del bucket siguiente
+func nextTrendBucket(start time.Time, granularity repoInterfaces.TrendGranularity) time.Time {
+	switch granularity {
+	case repoInterfaces.TrendGranularityWeek:
+		return start.AddDate(0, 0, 7)
+	case repoInterfaces.TrendGranularityMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// GetSectorRotation provides the upgrade/downgrade balance of each sector month over month, from the ratings of the
+// companies of the sector. Months are calendar months in the time zone of the context
+func (s *analysisService) GetSectorRotation(ctx context.Context, req *request.SectorRotationRequest) (*response.SectorRotationResponse, error) {
+	// Igual que en GetRatingTrends: sin zona horaria explícita se usa UTC
+	location := timezone.FromContext(ctx)
+	if location == time.Local {
+		location = time.UTC
+	}
+
+	months := req.Months
+	if months == 0 {
+		months = defaultSectorRotationMonths
+	}
+	last := timezone.StartOfMonth(time.Now().In(location))
+	if req.To != "" {
+		parsed, err := time.ParseInLocation(sectorRotationMonthLayout, req.To, location)
+		if err != nil {
+			return nil, response.BadRequest("to must be a month in YYYY-MM format")
+		}
+		last = parsed
+	}
+	from := last.AddDate(0, 1-months, 0)
+	end := last.AddDate(0, 1, 0)
+
+	counts, err := s.stockRatingRepo.GetSectorActionTrend(ctx, from, end, repoInterfaces.TrendGranularityMonth, location)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get sector rotation", err,
+			logger.String("from", from.Format(sectorRotationMonthLayout)),
+			logger.String("to", last.Format(sectorRotationMonthLayout)),
+		)
+		return nil, response.InternalServerError("Failed to get sector rotation")
+	}
+
+	rotation := &response.SectorRotationResponse{
+		Sectors:     make([]string, 0),
+		Months:      make([]string, 0, months),
+		Cells:       make([][]response.SectorRotationCell, 0),
+		Totals:      make([]response.SectorRotationCell, 0),
+		From:        from.Format(sectorRotationMonthLayout),
+		To:          last.Format(sectorRotationMonthLayout),
+		Timezone:    location.String(),
+		GeneratedAt: time.Now(),
+	}
+	monthIndex := make(map[int64]int, months)
+	for start := from; start.Before(end); start = start.AddDate(0, 1, 0) {
+		monthIndex[start.Unix()] = len(rotation.Months)
+		rotation.Months = append(rotation.Months, start.Format(sectorRotationMonthLayout))
+	}
+
+	// Una fila por sector con actividad en el rango; los meses sin ratings quedan a cero
+	rows := make(map[string][]response.SectorRotationCell)
+	totals := make(map[string]*response.SectorRotationCell)
+	for _, count := range counts {
+		month, ok := monthIndex[count.Start.Unix()]
+		if !ok {
+			continue
+		}
+		row, ok := rows[count.Sector]
+		if !ok {
+			row = make([]response.SectorRotationCell, len(rotation.Months))
+			rows[count.Sector] = row
+			totals[count.Sector] = &response.SectorRotationCell{}
+			rotation.Sectors = append(rotation.Sectors, count.Sector)
+		}
+		addSectorRotationCounts(&row[month], count)
+		addSectorRotationCounts(totals[count.Sector], count)
+	}
+
+	for _, cell := range totals {
+		setSectorRotationBalance(cell)
+	}
+	sort.SliceStable(rotation.Sectors, func(i, j int) bool {
+		a, b := totals[rotation.Sectors[i]], totals[rotation.Sectors[j]]
+		if a.Net != b.Net {
+			return a.Net > b.Net
+		}
+		return rotation.Sectors[i] < rotation.Sectors[j]
+	})
+
+	for _, sector := range rotation.Sectors {
+		row := rows[sector]
+		for i := range row {
+			setSectorRotationBalance(&row[i])
+			// El cambio solo tiene sentido si ambos meses tuvieron upgrades o downgrades
+			if i > 0 && row[i].Upgrades+row[i].Downgrades > 0 && row[i-1].Upgrades+row[i-1].Downgrades > 0 {
+				change := roundRatio(row[i].Balance - row[i-1].Balance)
+				row[i].Change = &change
+			}
+		}
+		rotation.Cells = append(rotation.Cells, row)
+		rotation.Totals = append(rotation.Totals, *totals[sector])
+	}
+
+	return rotation, nil
+}
+
+// addSectorRotationCounts acumula los ratings de un bucket en una celda
+func addSectorRotationCounts(cell *response.SectorRotationCell, count repoInterfaces.SectorActionCount) {
+	cell.Ratings += count.Total
+	cell.Upgrades += count.Upgrades
+	cell.Downgrades += count.Downgrades
+}
+
+// setSectorRotationBalance calcula el neto y el balance (de -1 a 1) de una celda
+func setSectorRotationBalance(cell *response.SectorRotationCell) {
+	cell.Net = cell.Upgrades - cell.Downgrades
+	if changes := cell.Upgrades + cell.Downgrades; changes > 0 {
+		cell.Balance = roundRatio(float64(cell.Net) / float64(changes))
+	}
+}
+
 // GetBrokerageActivity provides brokerage activity analysis
 func (s *analysisService) GetBrokerageActivity(ctx context.Context, period string) (map[string]interface{}, error) {
 	days := 30 // Default
@@ -270,19 +889,29 @@ func (s *analysisService) GetBrokerageActivity(ctx context.Context, period strin
 }
 
 // GenerateRecommendation generates a recommendation for a company
-func (s *analysisService) GenerateRecommendation(ctx context.Context, companyID uuid.UUID) (string, error) {
+func (s *analysisService) GenerateRecommendation(ctx context.Context, companyID uuid.UUID) (*response.RecommendationResponse, error) {
+	company, err := s.companyRepo.GetByID(ctx, companyID)
+	if err != nil {
+		return nil, response.FromError(err, "Company", "Failed to generate recommendation")
+	}
+
 	// Get company ratings
 	ratings, err := s.stockRatingRepo.GetByCompanyID(ctx, companyID)
 	if err != nil {
 		s.logger.Error(ctx, "Failed to get company ratings for recommendation", err)
-		return "", response.InternalServerError("Failed to generate recommendation")
+		return nil, response.InternalServerError("Failed to generate recommendation")
 	}
 
-	if len(ratings) == 0 {
-		return "No data available", nil
-	}
+	factors, score := s.compositeRecommendation(ctx, company, ratings)
 
-	return s.generateSimpleRecommendation(ratings), nil
+	return &response.RecommendationResponse{
+		CompanyID:      company.ID,
+		Ticker:         company.Ticker,
+		Recommendation: RecommendationLabel(score),
+		Score:          score,
+		Factors:        factors,
+		GeneratedAt:    time.Now(),
+	}, nil
 }
 
 // GetRecommendationsByRating gets recommendations by rating type
@@ -294,10 +923,11 @@ func (s *analysisService) GetRecommendationsByRating(ctx context.Context, rating
 		return nil, response.InternalServerError("Failed to get recommendations")
 	}
 
-	// Filter by rating type and get unique companies
+	// Filter by rating type and get unique companies; a canonical rating (buy, strong_sell...) matches every variant
+	canonical, canonicalErr := entities.ParseRatingScale(rating)
 	companyIDs := make(map[uuid.UUID]bool)
 	for _, r := range ratings {
-		if r.RatingTo == rating {
+		if r.RatingTo == rating || (canonicalErr == nil && normalizedRatingTo(r) == canonical) {
 			companyIDs[r.CompanyID] = true
 		}
 	}
@@ -344,8 +974,8 @@ func (s *analysisService) calculateCompanyRatingStats(ratings []*entities.StockR
 	ratingBreakdown := make(map[string]int)
 
 	for _, rating := range ratings {
-		// Count by action
-		actionBreakdown[rating.Action]++
+		// Count by action type
+		actionBreakdown[string(rating.GetActionType())]++
 
 		// Count by rating
 		if rating.RatingTo != "" {
@@ -360,40 +990,414 @@ func (s *analysisService) calculateCompanyRatingStats(ratings []*entities.StockR
 	}
 }
 
-// Helper method to generate simple recommendations
-func (s *analysisService) generateSimpleRecommendation(ratings []*entities.StockRating) string {
-	if len(ratings) == 0 {
-		return "No data available"
+// compositeRecommendation puntúa una company con el modelo compuesto: consenso de analistas, momentum de precios
+// y valoración, ponderados por configuración. El análisis de la company y la recomendación lo comparten
+func (s *analysisService) compositeRecommendation(ctx context.Context, company *entities.Company, ratings []*entities.StockRating) ([]response.RecommendationFactor, *float64) {
+	weights := s.scoringWeights()
+	factors := []response.RecommendationFactor{
+		s.consensusFactor(ratings, weights.Consensus),
+		s.momentumFactor(ctx, company.Ticker, weights.Momentum),
+		s.valuationFactor(ctx, company.Ticker, weights.Valuation),
 	}
+	return factors, CompositeScore(factors)
+}
+
+// Comparación de companies
 
-	// Count recent ratings by type
-	buyCount, holdCount, sellCount := 0, 0, 0
+const (
+	// maxComparisonTickers es el número máximo de companies en una comparación
+	maxComparisonTickers = 10
 
-	// Look at recent ratings (last 5 or all if less than 5)
-	recentCount := 5
-	if len(ratings) < recentCount {
-		recentCount = len(ratings)
+	// Sesiones bursátiles usadas para los rendimientos de la comparación
+	tradingDaysPerWeek    = 5
+	tradingDaysPerMonth   = 21
+	tradingDaysPerQuarter = 63
+	tradingDaysPerYear    = 252
+)
+
+// comparisonMetric describe una métrica comparable y en qué sentido es mejor
+type comparisonMetric struct {
+	name          string
+	lowerIsBetter bool
+	value         func(entry *response.CompanyComparisonEntry) *float64
+}
+
+// comparisonMetrics son las métricas normalizadas en scores y usadas para elegir leaders
+var comparisonMetrics = []comparisonMetric{
+	{"pe_ratio", true, func(e *response.CompanyComparisonEntry) *float64 { return e.Valuation.PERatio }},
+	{"peg_ratio", true, func(e *response.CompanyComparisonEntry) *float64 { return e.Valuation.PEGRatio }},
+	{"price_to_book", true, func(e *response.CompanyComparisonEntry) *float64 { return e.Valuation.PriceToBook }},
+	{"price_to_sales", true, func(e *response.CompanyComparisonEntry) *float64 { return e.Valuation.PriceToSales }},
+	{"ev_to_ebitda", true, func(e *response.CompanyComparisonEntry) *float64 { return e.Valuation.EVToEBITDA }},
+	{"revenue_growth_ttm", false, func(e *response.CompanyComparisonEntry) *float64 { return e.Growth.RevenueGrowthTTM }},
+	{"earnings_growth_ttm", false, func(e *response.CompanyComparisonEntry) *float64 { return e.Growth.EarningsGrowthTTM }},
+	{"eps_growth_ttm", false, func(e *response.CompanyComparisonEntry) *float64 { return e.Growth.EPSGrowthTTM }},
+	{"revenue_growth_3y", false, func(e *response.CompanyComparisonEntry) *float64 { return e.Growth.RevenueGrowth3Y }},
+	{"earnings_growth_3y", false, func(e *response.CompanyComparisonEntry) *float64 { return e.Growth.EarningsGrowth3Y }},
+	{"consensus", false, func(e *response.CompanyComparisonEntry) *float64 { return consensusBalance(e.Consensus) }},
+	{"return_1w", false, func(e *response.CompanyComparisonEntry) *float64 { return e.Performance.Return1W }},
+	{"return_1m", false, func(e *response.CompanyComparisonEntry) *float64 { return e.Performance.Return1M }},
+	{"return_3m", false, func(e *response.CompanyComparisonEntry) *float64 { return e.Performance.Return3M }},
+	{"return_1y", false, func(e *response.CompanyComparisonEntry) *float64 { return e.Performance.Return1Y }},
+}
+
+// parseTickerList valida una lista de tickers separada por comas (sin duplicados, de min a max)
+func parseTickerList(raw string, min, max int) ([]string, error) {
+	seen := make(map[string]bool)
+	var tickers []string
+	for _, ticker := range strings.Split(raw, ",") {
+		ticker = strings.ToUpper(strings.TrimSpace(ticker))
+		if ticker == "" || seen[ticker] {
+			continue
+		}
+		seen[ticker] = true
+		tickers = append(tickers, ticker)
+	}
+
+	if len(tickers) < min {
+		return nil, fmt.Errorf("at least %d distinct tickers are required", min)
+	}
+	if len(tickers) > max {
+		return nil, fmt.Errorf("at most %d tickers are allowed at once", max)
 	}
+	return tickers, nil
+}
 
-	recentRatings := ratings[len(ratings)-recentCount:]
+// peerTickers devuelve el ticker seguido de sus peers más cercanos, hasta maxComparisonTickers
+func (s *analysisService) peerTickers(ctx context.Context, ticker string) ([]string, error) {
+	if s.peerService == nil {
+		return nil, response.BadRequest("tickers is required")
+	}
 
-	for _, rating := range recentRatings {
-		switch rating.RatingTo {
-		case "Buy", "Strong Buy", "Outperform":
-			buyCount++
-		case "Hold", "Neutral":
-			holdCount++
-		case "Sell", "Strong Sell", "Underperform":
-			sellCount++
+	peers, err := s.peerService.GetPeers(ctx, ticker)
+	if err != nil {
+		return nil, err
+	}
+	if len(peers.Peers) == 0 {
+		return nil, response.BadRequest("No peers known for " + peers.Ticker + "; pass tickers explicitly")
+	}
+
+	tickers := []string{peers.Ticker}
+	for _, peer := range peers.Peers {
+		if len(tickers) == maxComparisonTickers {
+			break
 		}
+		tickers = append(tickers, peer.Ticker)
 	}
+	return tickers, nil
+}
 
-	// Generate recommendation based on majority
-	if buyCount > holdCount && buyCount > sellCount {
-		return "Buy"
-	} else if sellCount > buyCount && sellCount > holdCount {
-		return "Sell"
-	} else {
-		return "Hold"
+// addComparisonFundamentals completa valoración y crecimiento con las métricas de Alpha Vantage guardadas
+func (s *analysisService) addComparisonFundamentals(ctx context.Context, entries []response.CompanyComparisonEntry) error {
+	symbols := make([]string, len(entries))
+	for i, entry := range entries {
+		symbols[i] = entry.Ticker
+	}
+
+	metrics, err := s.financialMetricsRepo.GetBySymbols(ctx, symbols)
+	if err != nil {
+		return err
+	}
+
+	bySymbol := make(map[string]*entities.FinancialMetrics, len(metrics))
+	for _, m := range metrics {
+		if current, ok := bySymbol[m.Symbol]; !ok || m.LastUpdated.After(current.LastUpdated) {
+			bySymbol[m.Symbol] = m
+		}
 	}
+
+	for i := range entries {
+		m, ok := bySymbol[entries[i].Ticker]
+		if !ok {
+			continue
+		}
+		// Alpha Vantage informa "None" como 0 y los múltiplos negativos no son comparables
+		entries[i].Valuation = response.ComparisonValuation{
+			PERatio:      positiveOrNil(m.PERatio),
+			PEGRatio:     positiveOrNil(m.PEGRatio),
+			PriceToBook:  positiveOrNil(m.PriceToBook),
+			PriceToSales: positiveOrNil(m.PriceToSales),
+			EVToEBITDA:   positiveOrNil(m.EVToEBITDA),
+		}
+		entries[i].Growth = response.ComparisonGrowth{
+			RevenueGrowthTTM:  floatPtr(m.RevenueGrowthTTM),
+			EarningsGrowthTTM: floatPtr(m.EarningsGrowthTTM),
+			EPSGrowthTTM:      floatPtr(m.EPSGrowthTTM),
+			RevenueGrowth3Y:   floatPtr(m.RevenueGrowth3Y),
+			EarningsGrowth3Y:  floatPtr(m.EarningsGrowth3Y),
+		}
+	}
+
+	return nil
+}
+
+// calculateConsensus cuenta el último rating de cada brokerage que cubre la company
+func (s *analysisService) calculateConsensus(ratings []*entities.StockRating) response.ComparisonConsensus {
+	latest := make(map[uuid.UUID]*entities.StockRating)
+	for _, rating := range ratings {
+		if current, ok := latest[rating.BrokerageID]; !ok || rating.EventTime.After(current.EventTime) {
+			latest[rating.BrokerageID] = rating
+		}
+	}
+
+	consensus := response.ComparisonConsensus{Brokerages: len(latest)}
+	scored, scoreSum := 0, 0
+	for _, rating := range latest {
+		normalized := normalizedRatingTo(rating)
+		if normalized != "" {
+			scored++
+			scoreSum += normalized.Score()
+		}
+		switch normalized.Bucket() {
+		case "buy":
+			consensus.Buy++
+		case "hold":
+			consensus.Hold++
+		case "sell":
+			consensus.Sell++
+		}
+		if consensus.LastRatingAt == nil || rating.EventTime.After(*consensus.LastRatingAt) {
+			eventTime := rating.EventTime
+			consensus.LastRatingAt = &eventTime
+		}
+	}
+
+	if scored > 0 {
+		consensus.Score = floatPtr(float64(scoreSum) / float64(scored))
+	}
+
+	switch {
+	case consensus.Brokerages == 0:
+		consensus.Rating = "No data available"
+	case consensus.Buy > consensus.Hold && consensus.Buy > consensus.Sell:
+		consensus.Rating = "Buy"
+	case consensus.Sell > consensus.Buy && consensus.Sell > consensus.Hold:
+		consensus.Rating = "Sell"
+	default:
+		consensus.Rating = "Hold"
+	}
+
+	return consensus
+}
+
+// consensusBalance devuelve (buy - sell) / brokerages, o nil si ningún brokerage cubre la company
+func consensusBalance(consensus response.ComparisonConsensus) *float64 {
+	if consensus.Brokerages == 0 {
+		return nil
+	}
+	return floatPtr(float64(consensus.Buy-consensus.Sell) / float64(consensus.Brokerages))
+}
+
+// calculatePerformance calcula los rendimientos sobre precios ordenados del más reciente al más antiguo
+func calculatePerformance(prices []*entities.HistoricalData) response.ComparisonPerformance {
+	closes := make([]float64, len(prices))
+	for i, price := range prices {
+		closes[i] = price.AdjustedClose
+		if closes[i] <= 0 {
+			closes[i] = price.ClosePrice
+		}
+	}
+
+	performance := response.ComparisonPerformance{
+		Return1W: PeriodReturn(closes, tradingDaysPerWeek),
+		Return1M: PeriodReturn(closes, tradingDaysPerMonth),
+		Return3M: PeriodReturn(closes, tradingDaysPerQuarter),
+		Return1Y: PeriodReturn(closes, tradingDaysPerYear),
+	}
+	if len(prices) > 0 {
+		date := prices[0].Date
+		performance.LastClose = floatPtr(prices[0].ClosePrice)
+		performance.LastCloseDate = &date
+	}
+	return performance
+}
+
+// PeriodReturn returns the percent change over the last sessions given closes ordered newest first,
+// or nil when the history is too short or the starting close is not positive
+func PeriodReturn(closes []float64, sessions int) *float64 {
+	if sessions <= 0 || len(closes) <= sessions || closes[sessions] <= 0 {
+		return nil
+	}
+	return floatPtr((closes[0]/closes[sessions] - 1) * 100)
+}
+
+// scoreComparison normaliza cada métrica dentro del conjunto comparado y anota el ticker líder de cada una
+func scoreComparison(comparison *response.CompanyComparisonResponse) {
+	for i := range comparison.Companies {
+		comparison.Companies[i].Scores = make(map[string]float64)
+	}
+
+	for _, metric := range comparisonMetrics {
+		values := make([]*float64, len(comparison.Companies))
+		for i := range comparison.Companies {
+			values[i] = metric.value(&comparison.Companies[i])
+		}
+
+		for i, score := range NormalizeComparisonScores(values, metric.lowerIsBetter) {
+			if score == nil {
+				continue
+			}
+			comparison.Companies[i].Scores[metric.name] = *score
+			if *score == 1 {
+				if _, ok := comparison.Leaders[metric.name]; !ok {
+					comparison.Leaders[metric.name] = comparison.Companies[i].Ticker
+				}
+			}
+		}
+	}
+}
+
+// NormalizeComparisonScores scales the values to 0-1 (min-max) so that 1 is the best value of the set.
+// Missing values stay nil, and nothing is scored unless at least two values are present
+func NormalizeComparisonScores(values []*float64, lowerIsBetter bool) []*float64 {
+	scores := make([]*float64, len(values))
+
+	present := 0
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if v == nil {
+			continue
+		}
+		present++
+		low = math.Min(low, *v)
+		high = math.Max(high, *v)
+	}
+	if present < 2 {
+		return scores
+	}
+
+	for i, v := range values {
+		if v == nil {
+			continue
+		}
+		score := 1.0
+		if high > low {
+			score = (*v - low) / (high - low)
+			if lowerIsBetter {
+				score = 1 - score
+			}
+		}
+		scores[i] = &score
+	}
+	return scores
+}
+
+// normalizedRatingTo devuelve el rating canónico guardado; los ratings anteriores a la normalización se
+// clasifican con la tabla de mapeo vigente ("" si la variante no tiene mapeo)
+func normalizedRatingTo(rating *entities.StockRating) entities.RatingScale {
+	if rating.NormalizedTo != "" {
+		return rating.NormalizedTo
+	}
+	return entities.NormalizeRating(rating.RatingTo)
+}
+
+func positiveOrNil(value float64) *float64 {
+	if value <= 0 {
+		return nil
+	}
+	return &value
+}
+
+func floatPtr(value float64) *float64 {
+	return &value
+}
+
+// Correlación de rendimientos
+
+const (
+	// maxCorrelationTickers limita la matriz (el coste crece con el cuadrado de los tickers)
+	maxCorrelationTickers = 20
+
+	// defaultCorrelationDays es la ventana en días naturales si no se indica days
+	defaultCorrelationDays = 90
+
+	// minCorrelationObservations es el mínimo de rendimientos comunes para calcular una correlación
+	minCorrelationObservations = 3
+)
+
+// DailyReturns converts a price history (any order) into daily returns keyed by date (YYYY-MM-DD).
+// Each return is measured against the previous stored session; non-positive closes break the series
+func DailyReturns(prices []*entities.HistoricalData) map[string]float64 {
+	sorted := make([]*entities.HistoricalData, len(prices))
+	copy(sorted, prices)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Date.Before(sorted[j].Date) })
+
+	returns := make(map[string]float64, len(sorted))
+	for i := 1; i < len(sorted); i++ {
+		previous, current := closePrice(sorted[i-1]), closePrice(sorted[i])
+		if previous <= 0 || current <= 0 {
+			continue
+		}
+		returns[sorted[i].Date.Format("2006-01-02")] = current/previous - 1
+	}
+	return returns
+}
+
+// BenchmarkReturns computes the daily simple returns of benchmark values keyed by date, like DailyReturns
+func BenchmarkReturns(values []*entities.BenchmarkValue) map[string]float64 {
+	sorted := make([]*entities.BenchmarkValue, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Date.Before(sorted[j].Date) })
+
+	returns := make(map[string]float64, len(sorted))
+	for i := 1; i < len(sorted); i++ {
+		previous, current := sorted[i-1].Price(), sorted[i].Price()
+		if previous <= 0 || current <= 0 {
+			continue
+		}
+		returns[sorted[i].Date.Format("2006-01-02")] = current/previous - 1
+	}
+	return returns
+}
+
+// closePrice usa el cierre ajustado si existe
+func closePrice(price *entities.HistoricalData) float64 {
+	if price.AdjustedClose > 0 {
+		return price.AdjustedClose
+	}
+	return price.ClosePrice
+}
+
+// pairReturns devuelve los rendimientos de las fechas presentes en ambas series
+func pairReturns(a, b map[string]float64) ([]float64, []float64) {
+	var x, y []float64
+	for date, ra := range a {
+		if rb, ok := b[date]; ok {
+			x = append(x, ra)
+			y = append(y, rb)
+		}
+	}
+	return x, y
+}
+
+// PearsonCorrelation returns the correlation coefficient of paired samples, or nil with fewer than
+// minCorrelationObservations pairs or when either sample has no variance
+func PearsonCorrelation(x, y []float64) *float64 {
+	n := len(x)
+	if n != len(y) || n < minCorrelationObservations {
+		return nil
+	}
+
+	var meanX, meanY float64
+	for i := 0; i < n; i++ {
+		meanX += x[i]
+		meanY += y[i]
+	}
+	meanX /= float64(n)
+	meanY /= float64(n)
+
+	var cov, varX, varY float64
+	for i := 0; i < n; i++ {
+		dx, dy := x[i]-meanX, y[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return nil
+	}
+
+	correlation := cov / math.Sqrt(varX*varY)
+	// Evita que el redondeo salga de [-1, 1]
+	return floatPtr(math.Max(-1, math.Min(1, correlation)))
 }
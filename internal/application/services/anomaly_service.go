@@ -0,0 +1,251 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+const (
+	// defaultAnomalyDays es la ventana en días de la detección y de la consulta si no se indica otra
+	defaultAnomalyDays  = 7
+	defaultAnomalyLimit = 50
+
+	// Picos de ratings: días con al menos minRatingSpikeCount ratings y ratingSpikeRatio veces la media diaria
+	// de los anomalyTrailingDays anteriores (con un suelo para companies casi sin cobertura)
+	anomalyTrailingDays = 30
+	minRatingSpikeCount = 3
+	ratingSpikeRatio    = 3.0
+	ratingBaselineFloor = 0.25
+
+	// Picos de volumen: sesiones con volumeSpikeRatio veces el volumen medio de las volumeTrailingSessions
+	// anteriores (se exigen al menos minVolumeSessions)
+	volumeTrailingSessions = 20
+	minVolumeSessions      = 10
+	volumeSpikeRatio       = 3.0
+	volumeLookbackDays     = 45 // Días naturales que cubren las sesiones de referencia
+)
+
+// AnomalySpike is a day whose value is well above its trailing baseline
+type AnomalySpike struct {
+	Date     time.Time
+	Value    float64
+	Baseline float64
+	Ratio    float64
+}
+
+// anomalyService implements the AnomalyService interface
+type anomalyService struct {
+	stockRatingRepo    repoInterfaces.StockRatingRepository
+	historicalDataRepo repoInterfaces.HistoricalDataRepository // Opcional: sin él solo se detectan picos de ratings
+	anomalyRepo        repoInterfaces.AnomalyRepository
+	logger             logger.Logger
+}
+
+// NewAnomalyService creates a new anomaly detection service
+func NewAnomalyService(
+	stockRatingRepo repoInterfaces.StockRatingRepository,
+	historicalDataRepo repoInterfaces.HistoricalDataRepository,
+	anomalyRepo repoInterfaces.AnomalyRepository,
+	logger logger.Logger,
+) interfaces.AnomalyService {
+	return &anomalyService{
+		stockRatingRepo:    stockRatingRepo,
+		historicalDataRepo: historicalDataRepo,
+		anomalyRepo:        anomalyRepo,
+		logger:             logger,
+	}
+}
+
+// DetectAnomalies flags the companies with abnormal spikes of daily ratings or traded volume in the last days
+// and stores them as Anomaly records. Running it again over the same days updates the stored records
+func (s *anomalyService) DetectAnomalies(ctx context.Context, days int) (*response.AnomalyDetectionResponse, error) {
+	if days <= 0 {
+		days = defaultAnomalyDays
+	}
+	to := truncateToDay(time.Now().UTC())
+	from := to.AddDate(0, 0, -(days - 1))
+
+	result := &response.AnomalyDetectionResponse{Days: days, From: from, To: to}
+	var anomalies []*entities.Anomaly
+
+	ratings, err := s.stockRatingRepo.GetByEventTimeRange(ctx, from.AddDate(0, 0, -anomalyTrailingDays), to.Add(24*time.Hour))
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uuid.UUID]map[time.Time]int)
+	for _, rating := range ratings {
+		if counts[rating.CompanyID] == nil {
+			counts[rating.CompanyID] = make(map[time.Time]int)
+		}
+		counts[rating.CompanyID][truncateToDay(rating.EventTime)]++
+	}
+	for companyID, byDay := range counts {
+		for _, spike := range DetectRatingSpikes(byDay, from, to) {
+			anomalies = append(anomalies, entities.NewAnomaly(companyID, entities.AnomalyTypeRatingActivity,
+				spike.Date, spike.Value, spike.Baseline, spike.Ratio))
+			result.RatingAnomalies++
+		}
+	}
+	scanned := len(counts)
+
+	if s.historicalDataRepo != nil {
+		prices, err := s.historicalDataRepo.GetByDateRange(ctx, from.AddDate(0, 0, -volumeLookbackDays), to)
+		if err != nil {
+			return nil, err
+		}
+
+		sessions := make(map[uuid.UUID][]*entities.HistoricalData)
+		for _, price := range prices {
+			if price.CompanyID != uuid.Nil {
+				sessions[price.CompanyID] = append(sessions[price.CompanyID], price)
+			}
+		}
+		for companyID, companySessions := range sessions {
+			if _, ok := counts[companyID]; !ok {
+				scanned++
+			}
+			for _, spike := range DetectVolumeSpikes(companySessions, from) {
+				anomalies = append(anomalies, entities.NewAnomaly(companyID, entities.AnomalyTypeVolume,
+					spike.Date, spike.Value, spike.Baseline, spike.Ratio))
+				result.VolumeAnomalies++
+			}
+		}
+	}
+
+	if err := s.anomalyRepo.Upsert(ctx, anomalies); err != nil {
+		return nil, err
+	}
+
+	result.CompaniesScanned = scanned
+	result.DetectedAt = time.Now()
+
+	s.logger.Info(ctx, "Anomaly detection completed",
+		logger.Int("days", days),
+		logger.Int("companies_scanned", scanned),
+		logger.Int("rating_anomalies", result.RatingAnomalies),
+		logger.Int("volume_anomalies", result.VolumeAnomalies),
+	)
+
+	return result, nil
+}
+
+// GetAnomalies returns the stored anomalies of the last days, newest and largest first
+func (s *anomalyService) GetAnomalies(ctx context.Context, req *request.AnomalyRequest) (*response.AnomaliesResponse, error) {
+	days := req.Days
+	if days <= 0 {
+		days = defaultAnomalyDays
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultAnomalyLimit
+	}
+	since := truncateToDay(time.Now().UTC()).AddDate(0, 0, -(days - 1))
+
+	anomalies, err := s.anomalyRepo.GetSince(ctx, since, req.Type, limit)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get anomalies", err,
+			logger.Int("days", days),
+			logger.String("type", req.Type))
+		return nil, response.InternalServerError("Failed to get anomalies")
+	}
+
+	result := &response.AnomaliesResponse{
+		Days:      days,
+		Since:     since,
+		Type:      req.Type,
+		Total:     len(anomalies),
+		Anomalies: make([]response.AnomalyResponse, len(anomalies)),
+	}
+	for i, anomaly := range anomalies {
+		result.Anomalies[i] = response.AnomalyResponse{
+			ID:          anomaly.ID,
+			CompanyID:   anomaly.CompanyID,
+			Ticker:      anomaly.Company.Ticker,
+			CompanyName: anomaly.Company.Name,
+			Type:        anomaly.Type,
+			Date:        anomaly.Date,
+			Value:       anomaly.Value,
+			Baseline:    anomaly.Baseline,
+			Ratio:       anomaly.Ratio,
+			DetectedAt:  anomaly.UpdatedAt,
+		}
+	}
+
+	return result, nil
+}
+
+// DetectRatingSpikes returns the days between from and to (inclusive) whose rating count reaches
+// minRatingSpikeCount and ratingSpikeRatio times the daily average of the previous anomalyTrailingDays
+// calendar days. counts is keyed by day (midnight UTC); days without ratings count as zero
+func DetectRatingSpikes(counts map[time.Time]int, from, to time.Time) []AnomalySpike {
+	var spikes []AnomalySpike
+
+	for day := truncateToDay(from); !day.After(to); day = day.AddDate(0, 0, 1) {
+		count := counts[day]
+		if count < minRatingSpikeCount {
+			continue
+		}
+
+		trailing := 0
+		for k := 1; k <= anomalyTrailingDays; k++ {
+			trailing += counts[day.AddDate(0, 0, -k)]
+		}
+		baseline := float64(trailing) / anomalyTrailingDays
+
+		ratio := float64(count) / max(baseline, ratingBaselineFloor)
+		if ratio >= ratingSpikeRatio {
+			spikes = append(spikes, AnomalySpike{Date: day, Value: float64(count), Baseline: baseline, Ratio: ratio})
+		}
+	}
+
+	return spikes
+}
+
+// DetectVolumeSpikes returns the sessions on or after from whose volume is volumeSpikeRatio times the average
+// volume of the previous volumeTrailingSessions sessions. Sessions with fewer than minVolumeSessions before
+// them are skipped
+func DetectVolumeSpikes(sessions []*entities.HistoricalData, from time.Time) []AnomalySpike {
+	sorted := make([]*entities.HistoricalData, len(sessions))
+	copy(sorted, sessions)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Date.Before(sorted[j].Date) })
+
+	var spikes []AnomalySpike
+	for i, session := range sorted {
+		if session.Date.Before(truncateToDay(from)) || i < minVolumeSessions {
+			continue
+		}
+
+		window := sorted[max(0, i-volumeTrailingSessions):i]
+		total := 0.0
+		for _, previous := range window {
+			total += float64(previous.Volume)
+		}
+		baseline := total / float64(len(window))
+		if baseline <= 0 {
+			continue
+		}
+
+		ratio := float64(session.Volume) / baseline
+		if ratio >= volumeSpikeRatio {
+			spikes = append(spikes, AnomalySpike{
+				Date:     truncateToDay(session.Date),
+				Value:    float64(session.Volume),
+				Baseline: baseline,
+				Ratio:    ratio,
+			})
+		}
+	}
+
+	return spikes
+}
@@ -0,0 +1,350 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+const (
+	// Estrategias disponibles
+	StrategyUpgradeDowngrade = "upgrade_downgrade" // Compra con un upgrade, vende con un downgrade o al vencer el plazo
+	StrategyUpgradeHold      = "upgrade_hold"      // Compra con un upgrade y mantiene hasta vencer el plazo
+
+	// Motivos de cierre de una operación
+	ExitReasonDowngrade     = "downgrade"
+	ExitReasonHoldingPeriod = "holding_period"
+	ExitReasonEndOfTest     = "end_of_test"
+
+	maxBacktestTickers        = 20
+	defaultBacktestHolding    = 30
+	defaultBacktestCapital    = 10000.0
+	defaultBacktestWindowDays = 365
+	maxBacktestWindowDays     = 5 * 365
+)
+
+// BacktestStrategy holds the rules of a rating-following strategy
+type BacktestStrategy struct {
+	SellOnDowngrade bool
+	HoldingDays     int // Días naturales máximos por operación
+}
+
+// backtestService implements the BacktestService interface
+type backtestService struct {
+	stockRatingRepo    repoInterfaces.StockRatingRepository
+	companyRepo        repoInterfaces.CompanyRepository
+	historicalDataRepo repoInterfaces.HistoricalDataRepository
+	logger             logger.Logger
+}
+
+// NewBacktestService creates a new backtest service
+func NewBacktestService(
+	stockRatingRepo repoInterfaces.StockRatingRepository,
+	companyRepo repoInterfaces.CompanyRepository,
+	historicalDataRepo repoInterfaces.HistoricalDataRepository,
+	logger logger.Logger,
+) interfaces.BacktestService {
+	return &backtestService{
+		stockRatingRepo:    stockRatingRepo,
+		companyRepo:        companyRepo,
+		historicalDataRepo: historicalDataRepo,
+		logger:             logger,
+	}
+}
+
+// RunBacktest simulates a rating-following strategy over the stored ratings and daily prices of each ticker.
+// The capital is split equally between tickers and each ticker is traded independently at session closes
+func (s *backtestService) RunBacktest(ctx context.Context, req *request.BacktestRequest) (*response.BacktestResponse, error) {
+	tickers, err := parseTickerList(req.Tickers, 1, maxBacktestTickers)
+	if err != nil {
+		return nil, response.BadRequest(err.Error())
+	}
+
+	from, to, err := backtestWindow(req.DateFrom, req.DateTo)
+	if err != nil {
+		return nil, response.BadRequest(err.Error())
+	}
+
+	strategyName := req.Strategy
+	if strategyName == "" {
+		strategyName = StrategyUpgradeDowngrade
+	}
+	strategy := BacktestStrategy{
+		SellOnDowngrade: strategyName == StrategyUpgradeDowngrade,
+		HoldingDays:     req.HoldingDays,
+	}
+	if strategy.HoldingDays <= 0 {
+		strategy.HoldingDays = defaultBacktestHolding
+	}
+	capital := req.Capital
+	if capital <= 0 {
+		capital = defaultBacktestCapital
+	}
+
+	result := &response.BacktestResponse{
+		Tickers:        tickers,
+		Strategy:       strategyName,
+		HoldingDays:    strategy.HoldingDays,
+		From:           from,
+		To:             to,
+		InitialCapital: capital,
+		Trades:         []response.BacktestTrade{},
+		GeneratedAt:    time.Now(),
+	}
+
+	type sleeveData struct {
+		ticker  string
+		prices  []*entities.HistoricalData
+		ratings []*entities.StockRating
+	}
+	var sleeves []sleeveData
+	for _, ticker := range tickers {
+		company, err := s.companyRepo.GetByTicker(ctx, ticker)
+		if err != nil {
+			if domainerrors.IsNotFound(err) {
+				result.NotFound = append(result.NotFound, ticker)
+				continue
+			}
+			s.logger.Error(ctx, "Failed to get company for backtest", err,
+				logger.String("ticker", ticker))
+			return nil, response.InternalServerError("Failed to run backtest")
+		}
+
+		prices, err := s.historicalDataRepo.GetBySymbol(ctx, company.Ticker, from, to)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to get price history for backtest", err,
+				logger.String("ticker", company.Ticker))
+			return nil, response.InternalServerError("Failed to run backtest")
+		}
+		if len(prices) == 0 {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("no stored price history for %s; ticker skipped", company.Ticker))
+			continue
+		}
+
+		ratings, err := s.stockRatingRepo.GetByCompanyAndDateRange(ctx, company.ID, from, to.Add(24*time.Hour))
+		if err != nil {
+			s.logger.Error(ctx, "Failed to get ratings for backtest", err,
+				logger.String("ticker", company.Ticker))
+			return nil, response.InternalServerError("Failed to run backtest")
+		}
+
+		sleeves = append(sleeves, sleeveData{ticker: company.Ticker, prices: prices, ratings: ratings})
+	}
+
+	if len(sleeves) == 0 {
+		if len(result.NotFound) == len(tickers) {
+			return nil, response.NotFound("Companies")
+		}
+		return nil, response.BadRequest("none of the tickers has stored price history in the selected window")
+	}
+
+	sleeveCapital := capital / float64(len(sleeves))
+	curves := make([][]response.EquityPoint, len(sleeves))
+	for i, sleeve := range sleeves {
+		curve, trades := SimulateRatingStrategy(sleeve.ticker, sleeve.prices, sleeve.ratings, strategy, sleeveCapital)
+		curves[i] = curve
+		result.Trades = append(result.Trades, trades...)
+	}
+	sort.SliceStable(result.Trades, func(i, j int) bool { return result.Trades[i].EntryDate.Before(result.Trades[j].EntryDate) })
+
+	result.EquityCurve = CombineEquityCurves(curves, sleeveCapital)
+	if len(result.EquityCurve) == 0 {
+		return nil, response.BadRequest("none of the tickers has valid closing prices in the selected window")
+	}
+	result.FinalEquity = result.EquityCurve[len(result.EquityCurve)-1].Equity
+	result.TotalReturn = result.FinalEquity/capital - 1
+	result.TotalTrades = len(result.Trades)
+	result.HitRate = hitRate(result.Trades)
+	result.MaxDrawdown = MaxDrawdown(curveReturns(result.EquityCurve))
+
+	// CAGR sobre los días naturales que cubre la curva
+	elapsed := result.EquityCurve[len(result.EquityCurve)-1].Date.Sub(result.EquityCurve[0].Date).Hours() / 24
+	if elapsed >= 1 && result.FinalEquity > 0 {
+		result.CAGR = floatPtr(math.Pow(result.FinalEquity/capital, 365/elapsed) - 1)
+	}
+
+	return result, nil
+}
+
+// SimulateRatingStrategy trades one ticker with the given capital: it buys at the close of the session of an
+// upgrade (or the next one) while flat and sells on the first close past the holding period or, if the strategy
+// says so, on a downgrade. Open positions are closed at the last close. Returns the equity per session
+func SimulateRatingStrategy(
+	ticker string,
+	prices []*entities.HistoricalData,
+	ratings []*entities.StockRating,
+	strategy BacktestStrategy,
+	capital float64,
+) ([]response.EquityPoint, []response.BacktestTrade) {
+	sessions := make([]*entities.HistoricalData, 0, len(prices))
+	for _, price := range prices {
+		if closePrice(price) > 0 {
+			sessions = append(sessions, price)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].Date.Before(sessions[j].Date) })
+
+	events := make([]*entities.StockRating, len(ratings))
+	copy(events, ratings)
+	sort.SliceStable(events, func(i, j int) bool { return events[i].EventTime.Before(events[j].EventTime) })
+
+	var (
+		curve  = make([]response.EquityPoint, 0, len(sessions))
+		trades []response.BacktestTrade
+		cash   = capital
+		open   *response.BacktestTrade
+		next   int
+	)
+
+	closePosition := func(date time.Time, price float64, reason string) {
+		open.ExitDate = date
+		open.ExitPrice = price
+		open.ExitReason = reason
+		open.Return = price/open.EntryPrice - 1
+		cash = open.Shares * price
+		trades = append(trades, *open)
+		open = nil
+	}
+
+	for _, session := range sessions {
+		day := truncateToDay(session.Date)
+		price := closePrice(session)
+
+		// El plazo vence antes de mirar las señales del día, así un upgrade puede volver a entrar
+		if open != nil && !day.Before(open.EntryDate.AddDate(0, 0, strategy.HoldingDays)) {
+			closePosition(day, price, ExitReasonHoldingPeriod)
+		}
+
+		for ; next < len(events) && !truncateToDay(events[next].EventTime).After(day); next++ {
+			switch {
+			case events[next].IsUpgrade() && open == nil:
+				open = &response.BacktestTrade{
+					Ticker:     ticker,
+					EntryDate:  day,
+					EntryPrice: price,
+					Shares:     cash / price,
+				}
+				cash = 0
+			case events[next].IsDowngrade() && open != nil && strategy.SellOnDowngrade:
+				closePosition(day, price, ExitReasonDowngrade)
+			}
+		}
+
+		equity := cash
+		if open != nil {
+			equity = open.Shares * price
+		}
+		curve = append(curve, response.EquityPoint{Date: day, Equity: equity})
+	}
+
+	if open != nil {
+		last := sessions[len(sessions)-1]
+		closePosition(truncateToDay(last.Date), closePrice(last), ExitReasonEndOfTest)
+	}
+
+	return curve, trades
+}
+
+// CombineEquityCurves adds up the equity curves of independently traded sleeves on every session of any of
+// them. A sleeve without a close on a session keeps its last value (its initial capital before its first session)
+func CombineEquityCurves(curves [][]response.EquityPoint, initialCapital float64) []response.EquityPoint {
+	dateSet := make(map[time.Time]bool)
+	for _, curve := range curves {
+		for _, point := range curve {
+			dateSet[point.Date] = true
+		}
+	}
+	dates := make([]time.Time, 0, len(dateSet))
+	for date := range dateSet {
+		dates = append(dates, date)
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	positions := make([]int, len(curves))
+	values := make([]float64, len(curves))
+	for i := range values {
+		values[i] = initialCapital
+	}
+
+	combined := make([]response.EquityPoint, len(dates))
+	for d, date := range dates {
+		total := 0.0
+		for i, curve := range curves {
+			for positions[i] < len(curve) && !curve[positions[i]].Date.After(date) {
+				values[i] = curve[positions[i]].Equity
+				positions[i]++
+			}
+			total += values[i]
+		}
+		combined[d] = response.EquityPoint{Date: date, Equity: total}
+	}
+	return combined
+}
+
+// backtestWindow resuelve las fechas del backtest: por defecto el último año hasta hoy
+func backtestWindow(rawFrom, rawTo string) (time.Time, time.Time, error) {
+	to := time.Now().UTC().Truncate(24 * time.Hour)
+	if rawTo != "" {
+		parsed, err := time.Parse("2006-01-02", rawTo)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid date_to: %s", rawTo)
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, -defaultBacktestWindowDays)
+	if rawFrom != "" {
+		parsed, err := time.Parse("2006-01-02", rawFrom)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid date_from: %s", rawFrom)
+		}
+		from = parsed
+	}
+
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("date_from must be before date_to")
+	}
+	if to.Sub(from) > maxBacktestWindowDays*24*time.Hour {
+		return time.Time{}, time.Time{}, fmt.Errorf("the backtest window cannot exceed %d days", maxBacktestWindowDays)
+	}
+	return from, to, nil
+}
+
+// hitRate devuelve la fracción de operaciones con beneficio, o nil sin operaciones
+func hitRate(trades []response.BacktestTrade) *float64 {
+	if len(trades) == 0 {
+		return nil
+	}
+	wins := 0
+	for _, trade := range trades {
+		if trade.Return > 0 {
+			wins++
+		}
+	}
+	return floatPtr(float64(wins) / float64(len(trades)))
+}
+
+// curveReturns convierte una curva de capital en rendimientos entre sesiones consecutivas
+func curveReturns(curve []response.EquityPoint) []float64 {
+	returns := make([]float64, 0, len(curve))
+	for i := 1; i < len(curve); i++ {
+		if curve[i-1].Equity > 0 {
+			returns = append(returns, curve[i].Equity/curve[i-1].Equity-1)
+		}
+	}
+	return returns
+}
+
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,434 @@
+package services
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	domainServices "github.com/MayaCris/stock-info-app/internal/domain/services"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+const (
+	// BackupFormatNDJSON es el formato de las exportaciones: un objeto JSON por fila, comprimido con gzip
+	BackupFormatNDJSON = "ndjson.gz"
+
+	// backupIDLayout nombra cada snapshot por su instante de inicio (UTC), así el orden de las claves es cronológico
+	backupIDLayout = "20060102T150405Z"
+
+	backupManifestName = "manifest.json"
+
+	// maxBackupRowSize limita el tamaño de una fila al leer una exportación (RawData de ratings, noticias...)
+	maxBackupRowSize = 16 * 1024 * 1024
+)
+
+// backupIDPattern valida los IDs recibidos por la API antes de usarlos como prefijo en el bucket
+var backupIDPattern = regexp.MustCompile(`^\d{8}T\d{6}Z$`)
+
+// backupService implements the BackupService interface
+type backupService struct {
+	store  domainServices.ObjectStore
+	repo   repoInterfaces.TableSnapshotRepository
+	tables []string
+	prefix string
+	logger logger.Logger
+	now    func() time.Time
+}
+
+// NewBackupService creates the database snapshot service; snapshots are stored under prefix in the bucket
+func NewBackupService(
+	store domainServices.ObjectStore,
+	repo repoInterfaces.TableSnapshotRepository,
+	tables []string,
+	prefix string,
+	logger logger.Logger,
+) interfaces.BackupService {
+	return &backupService{
+		store:  store,
+		repo:   repo,
+		tables: tables,
+		prefix: strings.Trim(prefix, "/"),
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// ValidateTables rejects tables that are not part of the configured backup set
+func (s *backupService) ValidateTables(tables []string) error {
+	configured := make(map[string]bool, len(s.tables))
+	for _, table := range s.tables {
+		configured[table] = true
+	}
+	for _, table := range tables {
+		if !configured[table] {
+			return response.BadRequest(fmt.Sprintf("table %q is not part of the backup set (BACKUP_TABLES)", table))
+		}
+	}
+	return nil
+}
+
+// CreateBackup exports each table to its own object and writes the manifest last, so a snapshot
+// only shows up in the listing once every table has been uploaded
+func (s *backupService) CreateBackup(ctx context.Context, options interfaces.BackupOptions) (*response.BackupResponse, error) {
+	tables := s.tables
+	if len(options.Tables) > 0 {
+		if err := s.ValidateTables(options.Tables); err != nil {
+			return nil, err
+		}
+		tables = options.Tables
+	}
+
+	started := s.now().UTC()
+	backup := &response.BackupResponse{
+		ID:        started.Format(backupIDLayout),
+		Format:    BackupFormatNDJSON,
+		StartedAt: started,
+	}
+
+	schemaVersion, err := s.repo.SchemaVersion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	backup.SchemaVersion = schemaVersion
+
+	for _, table := range tables {
+		exported, err := s.exportTable(ctx, backup.ID, table)
+		if err != nil {
+			s.logger.Error(ctx, "Database backup failed", err,
+				logger.String("backup_id", backup.ID),
+				logger.String("table", table),
+			)
+			return nil, err
+		}
+
+		backup.Tables = append(backup.Tables, *exported)
+		backup.TotalRows += exported.Rows
+		backup.TotalBytes += exported.Bytes
+		if options.OnProgress != nil {
+			options.OnProgress(*backup)
+		}
+	}
+
+	backup.CompletedAt = s.now().UTC()
+	backup.DurationMs = backup.CompletedAt.Sub(started).Milliseconds()
+
+	manifest, err := json.MarshalIndent(backup, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode backup manifest: %w", err)
+	}
+	if err := s.store.Put(ctx, s.objectKey(backup.ID, backupManifestName), strings.NewReader(string(manifest)),
+		int64(len(manifest)), "application/json"); err != nil {
+		return nil, fmt.Errorf("failed to upload backup manifest: %w", err)
+	}
+
+	s.logger.Info(ctx, "Database backup completed",
+		logger.String("backup_id", backup.ID),
+		logger.Int("tables", len(backup.Tables)),
+		logger.Int64("rows", backup.TotalRows),
+		logger.Int64("bytes", backup.TotalBytes),
+		logger.Int64("duration_ms", backup.DurationMs),
+	)
+
+	return backup, nil
+}
+
+// exportTable vuelca la tabla a un fichero temporal comprimido y lo sube con su tamaño conocido
+// (S3 exige Content-Length); la memoria usada no depende del tamaño de la tabla
+func (s *backupService) exportTable(ctx context.Context, backupID, table string) (*response.BackupTableResponse, error) {
+	exported := &response.BackupTableResponse{Table: table}
+
+	exists, err := s.repo.TableExists(ctx, table)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		exported.Skipped = "table does not exist"
+		return exported, nil
+	}
+
+	file, err := os.CreateTemp("", "backup-"+table+"-*.ndjson.gz")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary file for %s: %w", table, err)
+	}
+	defer func() {
+		file.Close()
+		os.Remove(file.Name())
+	}()
+
+	checksum := sha256.New()
+	counter := &countingWriter{}
+	compressed := gzip.NewWriter(io.MultiWriter(file, checksum, counter))
+
+	rows, err := s.repo.ExportTable(ctx, table, compressed)
+	if err != nil {
+		return nil, err
+	}
+	if err := compressed.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress %s: %w", table, err)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to rewind %s export: %w", table, err)
+	}
+
+	key := s.objectKey(backupID, table+"."+BackupFormatNDJSON)
+	if err := s.store.Put(ctx, key, file, counter.written, "application/gzip"); err != nil {
+		return nil, fmt.Errorf("failed to upload %s: %w", table, err)
+	}
+
+	exported.Key = key
+	exported.Rows = rows
+	exported.Bytes = counter.written
+	exported.SHA256 = hex.EncodeToString(checksum.Sum(nil))
+	return exported, nil
+}
+
+// ListBackups returns the completed snapshots, newest first
+func (s *backupService) ListBackups(ctx context.Context) ([]*response.BackupResponse, error) {
+	objects, err := s.store.List(ctx, s.objectKey("", ""))
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list backups", err)
+		return nil, response.ServiceUnavailable("Failed to list backups from the backup bucket")
+	}
+
+	backups := make([]*response.BackupResponse, 0)
+	for _, object := range objects {
+		if path.Base(object.Key) != backupManifestName {
+			continue
+		}
+		backup, err := s.readManifest(ctx, object.Key)
+		if err != nil {
+			s.logger.Warn(ctx, "Skipping unreadable backup manifest",
+				logger.String("key", object.Key),
+				logger.String("error", err.Error()),
+			)
+			continue
+		}
+		backups = append(backups, backup)
+	}
+
+	sort.Slice(backups, func(i, j int) bool { return backups[i].ID > backups[j].ID })
+	return backups, nil
+}
+
+// GetBackup returns the manifest of a snapshot
+func (s *backupService) GetBackup(ctx context.Context, id string) (*response.BackupResponse, error) {
+	if !backupIDPattern.MatchString(id) {
+		return nil, response.BadRequest("Invalid backup ID format")
+	}
+
+	backup, err := s.readManifest(ctx, s.objectKey(id, backupManifestName))
+	if errors.Is(err, domainServices.ErrObjectNotFound) {
+		return nil, response.NotFound("Backup")
+	}
+	if err != nil {
+		s.logger.Error(ctx, "Failed to read backup manifest", err, logger.String("backup_id", id))
+		return nil, response.ServiceUnavailable("Failed to read backup from the backup bucket")
+	}
+	return backup, nil
+}
+
+// RestoreDryRun downloads every table of the snapshot, verifies its checksum and rows and compares its
+// columns with the current schema. Nothing is written to the database
+func (s *backupService) RestoreDryRun(ctx context.Context, id string) (*response.RestoreDryRunResponse, error) {
+	backup, err := s.GetBackup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	currentVersion, err := s.repo.SchemaVersion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	result := &response.RestoreDryRunResponse{
+		BackupID:              backup.ID,
+		BackupSchemaVersion:   backup.SchemaVersion,
+		CurrentSchemaVersion:  currentVersion,
+		SchemaVersionMismatch: backup.SchemaVersion != currentVersion,
+		Restorable:            true,
+	}
+
+	for _, table := range backup.Tables {
+		if table.Skipped != "" {
+			continue
+		}
+		check, err := s.checkTable(ctx, table)
+		if err != nil {
+			return nil, err
+		}
+		if len(check.Problems) > 0 {
+			result.Restorable = false
+		}
+		result.Tables = append(result.Tables, *check)
+	}
+
+	return result, nil
+}
+
+// checkTable lee la exportación de una tabla y la compara con la tabla actual
+func (s *backupService) checkTable(ctx context.Context, exported response.BackupTableResponse) (*response.RestoreDryRunTableResponse, error) {
+	check := &response.RestoreDryRunTableResponse{Table: exported.Table}
+
+	exists, err := s.repo.TableExists(ctx, exported.Table)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		check.Problems = append(check.Problems, "table does not exist in the current schema")
+		return check, nil
+	}
+
+	currentColumns, err := s.repo.Columns(ctx, exported.Table)
+	if err != nil {
+		return nil, err
+	}
+	if check.CurrentRows, err = s.repo.CountRows(ctx, exported.Table); err != nil {
+		return nil, err
+	}
+
+	object, err := s.store.Get(ctx, exported.Key)
+	if err != nil {
+		check.Problems = append(check.Problems, fmt.Sprintf("export could not be downloaded: %v", err))
+		return check, nil
+	}
+	defer object.Close()
+
+	backupColumns, err := scanBackupRows(object, exported, check)
+	if err != nil {
+		check.Problems = append(check.Problems, err.Error())
+		return check, nil
+	}
+
+	check.UnknownColumns, check.MissingColumns = compareColumns(backupColumns, currentColumns)
+	if !check.ChecksumValid {
+		check.Problems = append(check.Problems, "checksum does not match the manifest")
+	}
+	if check.BackupRows != exported.Rows {
+		check.Problems = append(check.Problems,
+			fmt.Sprintf("export has %d rows, manifest expects %d", check.BackupRows, exported.Rows))
+	}
+	if check.InvalidRows > 0 {
+		check.Problems = append(check.Problems, fmt.Sprintf("%d rows are not valid JSON objects", check.InvalidRows))
+	}
+	if len(check.UnknownColumns) > 0 {
+		check.Problems = append(check.Problems, "export has columns that no longer exist in the table")
+	}
+
+	return check, nil
+}
+
+// scanBackupRows cuenta las filas de la exportación, verifica el checksum del objeto comprimido
+// y devuelve las columnas presentes en alguna fila
+func scanBackupRows(object io.Reader, exported response.BackupTableResponse, check *response.RestoreDryRunTableResponse) (map[string]bool, error) {
+	checksum := sha256.New()
+	decompressed, err := gzip.NewReader(io.TeeReader(object, checksum))
+	if err != nil {
+		return nil, fmt.Errorf("export is not a gzip stream: %w", err)
+	}
+	defer decompressed.Close()
+	decompressed.Multistream(false)
+
+	columns := make(map[string]bool)
+	scanner := bufio.NewScanner(decompressed)
+	scanner.Buffer(make([]byte, 64*1024), maxBackupRowSize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		check.BackupRows++
+
+		var row map[string]json.RawMessage
+		if err := json.Unmarshal(line, &row); err != nil {
+			check.InvalidRows++
+			continue
+		}
+		for column := range row {
+			columns[column] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read export: %w", err)
+	}
+
+	// Lo que quede tras el final del stream gzip también forma parte del checksum
+	if _, err := io.Copy(io.Discard, object); err != nil {
+		return nil, fmt.Errorf("failed to read export: %w", err)
+	}
+	check.ChecksumValid = hex.EncodeToString(checksum.Sum(nil)) == exported.SHA256
+
+	return columns, nil
+}
+
+// compareColumns devuelve las columnas del backup que no existen en la tabla y las de la tabla que faltan en el backup
+func compareColumns(backupColumns map[string]bool, currentColumns []string) (unknown, missing []string) {
+	current := make(map[string]bool, len(currentColumns))
+	for _, column := range currentColumns {
+		current[column] = true
+		if !backupColumns[column] {
+			missing = append(missing, column)
+		}
+	}
+	for column := range backupColumns {
+		if !current[column] {
+			unknown = append(unknown, column)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown, missing
+}
+
+// readManifest descarga y decodifica el manifest.json de un snapshot
+func (s *backupService) readManifest(ctx context.Context, key string) (*response.BackupResponse, error) {
+	object, err := s.store.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer object.Close()
+
+	var backup response.BackupResponse
+	if err := json.NewDecoder(object).Decode(&backup); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", key, err)
+	}
+	return &backup, nil
+}
+
+// objectKey construye <prefix>/<id>/<name>; sin id devuelve el prefijo de todos los snapshots
+func (s *backupService) objectKey(id, name string) string {
+	parts := make([]string, 0, 3)
+	for _, part := range []string{s.prefix, id, name} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	key := strings.Join(parts, "/")
+	if name == "" && key != "" {
+		key += "/"
+	}
+	return key
+}
+
+// countingWriter cuenta los bytes comprimidos escritos en el fichero temporal
+type countingWriter struct {
+	written int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.written += int64(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,268 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	domainServices "github.com/MayaCris/stock-info-app/internal/domain/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/usage"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// benchmarkService implements the BenchmarkService interface
+type benchmarkService struct {
+	benchmarkRepo      repoInterfaces.BenchmarkRepository
+	companyRepo        repoInterfaces.CompanyRepository
+	historicalDataRepo repoInterfaces.HistoricalDataRepository // Precios de los índices personalizados
+	provider           domainServices.BenchmarkProvider        // nil: solo los valores ya guardados
+	logger             logger.Logger
+}
+
+// NewBenchmarkService creates a new benchmark service. Custom benchmarks are computed from the prices of
+// historicalDataRepo
+func NewBenchmarkService(
+	benchmarkRepo repoInterfaces.BenchmarkRepository,
+	companyRepo repoInterfaces.CompanyRepository,
+	historicalDataRepo repoInterfaces.HistoricalDataRepository,
+	provider domainServices.BenchmarkProvider,
+	logger logger.Logger,
+) interfaces.BenchmarkService {
+	return &benchmarkService{
+		benchmarkRepo:      benchmarkRepo,
+		companyRepo:        companyRepo,
+		historicalDataRepo: historicalDataRepo,
+		provider:           provider,
+		logger:             logger,
+	}
+}
+
+// ListBenchmarks returns every tracked benchmark
+func (s *benchmarkService) ListBenchmarks(ctx context.Context) ([]response.BenchmarkResponse, error) {
+	benchmarks, err := s.benchmarkRepo.GetAll(ctx)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list benchmarks", err)
+		return nil, response.InternalServerError("Failed to list benchmarks")
+	}
+
+	result := make([]response.BenchmarkResponse, len(benchmarks))
+	for i, benchmark := range benchmarks {
+		result[i] = toBenchmarkResponse(benchmark)
+	}
+	return result, nil
+}
+
+// GetValues returns the stored daily values of a benchmark between from and to (the last year by default) and
+// the return over the range
+func (s *benchmarkService) GetValues(ctx context.Context, symbol string, req *request.BenchmarkValuesRequest) (*response.BenchmarkValuesResponse, error) {
+	to := time.Now().UTC().Truncate(24 * time.Hour)
+	if req.To != "" {
+		parsed, err := time.Parse(ratingDateLayout, req.To)
+		if err != nil {
+			return nil, response.BadRequest("to must be a date in YYYY-MM-DD format")
+		}
+		to = parsed
+	}
+	from := to.AddDate(-1, 0, 0)
+	if req.From != "" {
+		parsed, err := time.Parse(ratingDateLayout, req.From)
+		if err != nil {
+			return nil, response.BadRequest("from must be a date in YYYY-MM-DD format")
+		}
+		from = parsed
+	}
+	if from.After(to) {
+		return nil, response.BadRequest("to must not be before from")
+	}
+
+	benchmark, err := s.benchmarkRepo.GetBySymbol(ctx, symbol)
+	if err != nil {
+		return nil, response.FromError(err, "Benchmark "+strings.ToUpper(symbol), "Failed to get benchmark")
+	}
+
+	values, err := s.benchmarkRepo.GetValues(ctx, benchmark.ID, from, to)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get benchmark values", err,
+			logger.String("benchmark", benchmark.Symbol))
+		return nil, response.InternalServerError("Failed to get benchmark values")
+	}
+
+	result := &response.BenchmarkValuesResponse{
+		Benchmark: toBenchmarkResponse(benchmark),
+		From:      from.Format(ratingDateLayout),
+		To:        to.Format(ratingDateLayout),
+		Values:    make([]response.BenchmarkValueResponse, len(values)),
+	}
+	for i, value := range values {
+		result.Values[i] = response.BenchmarkValueResponse{
+			Date:          value.Date.Format(ratingDateLayout),
+			Open:          value.Open,
+			High:          value.High,
+			Low:           value.Low,
+			Close:         value.Close,
+			AdjustedClose: value.AdjustedClose,
+			Volume:        value.Volume,
+		}
+	}
+	if len(values) > 1 && values[0].Price() > 0 {
+		result.Return = floatPtr(roundRatio(values[len(values)-1].Price()/values[0].Price() - 1))
+	}
+
+	return result, nil
+}
+
+// GetConstituents returns the members of a benchmark on a date (today by default)
+func (s *benchmarkService) GetConstituents(ctx context.Context, symbol string, req *request.BenchmarkConstituentsRequest) (*response.BenchmarkConstituentsResponse, error) {
+	date := time.Now().UTC().Truncate(24 * time.Hour)
+	if req.Date != "" {
+		parsed, err := time.Parse(ratingDateLayout, req.Date)
+		if err != nil {
+			return nil, response.BadRequest("date must be a date in YYYY-MM-DD format")
+		}
+		date = parsed
+	}
+
+	benchmark, err := s.benchmarkRepo.GetBySymbol(ctx, symbol)
+	if err != nil {
+		return nil, response.FromError(err, "Benchmark "+strings.ToUpper(symbol), "Failed to get benchmark")
+	}
+
+	constituents, err := s.benchmarkRepo.GetConstituents(ctx, benchmark.ID, date)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get benchmark constituents", err,
+			logger.String("benchmark", benchmark.Symbol))
+		return nil, response.InternalServerError("Failed to get benchmark constituents")
+	}
+
+	result := &response.BenchmarkConstituentsResponse{
+		Benchmark:    toBenchmarkResponse(benchmark),
+		Date:         date.Format(ratingDateLayout),
+		Count:        len(constituents),
+		Constituents: make([]response.BenchmarkConstituentResponse, len(constituents)),
+	}
+	for i, constituent := range constituents {
+		result.Constituents[i] = response.BenchmarkConstituentResponse{
+			Symbol:    constituent.Symbol,
+			Name:      constituent.Name,
+			CompanyID: constituent.CompanyID,
+			Weight:    constituent.Weight,
+			AddedAt:   constituent.AddedAt.Format(ratingDateLayout),
+		}
+	}
+
+	return result, nil
+}
+
+// Sync ingests the daily values and the current constituents of a benchmark from the provider. The values are
+// required; if the constituents cannot be fetched the stored composition is kept and a warning is returned.
+// Custom benchmarks are computed again from the stored prices instead
+func (s *benchmarkService) Sync(ctx context.Context, symbol string, req *request.BenchmarkSyncRequest) (*response.BenchmarkSyncResponse, error) {
+	benchmark, err := s.benchmarkRepo.GetBySymbol(ctx, symbol)
+	if err != nil {
+		return nil, response.FromError(err, "Benchmark "+strings.ToUpper(symbol), "Failed to get benchmark")
+	}
+	if benchmark.IsCustom() {
+		return s.rebuildCustom(ctx, benchmark)
+	}
+	if s.provider == nil {
+		return nil, response.ServiceUnavailable("Benchmark provider is not configured")
+	}
+
+	usage.RecordProviderCall(ctx)
+	values, err := s.provider.FetchValues(ctx, benchmark, req.Full)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to fetch benchmark values", err,
+			logger.String("benchmark", benchmark.Symbol),
+			logger.String("source_symbol", benchmark.SourceSymbol))
+		return nil, alphaVantageError(err, benchmark.SourceSymbol, "Failed to fetch benchmark values")
+	}
+	if err := s.benchmarkRepo.UpsertValues(ctx, values); err != nil {
+		s.logger.Error(ctx, "Failed to store benchmark values", err,
+			logger.String("benchmark", benchmark.Symbol))
+		return nil, response.InternalServerError("Failed to store benchmark values")
+	}
+
+	syncedAt := time.Now().UTC()
+	result := &response.BenchmarkSyncResponse{
+		Values:   len(values),
+		SyncedAt: syncedAt,
+	}
+
+	usage.RecordProviderCall(ctx)
+	constituents, err := s.provider.FetchConstituents(ctx, benchmark)
+	if err != nil {
+		s.logger.Warn(ctx, "Failed to fetch benchmark constituents, keeping the stored ones",
+			logger.String("benchmark", benchmark.Symbol),
+			logger.String("error", err.Error()))
+		result.Warnings = append(result.Warnings, fmt.Sprintf("constituents of %s could not be fetched; the stored composition is kept", benchmark.Symbol))
+	} else {
+		s.linkCompanies(ctx, constituents)
+		result.ConstituentsAdded, result.ConstituentsRemoved, err = s.benchmarkRepo.SyncConstituents(ctx, benchmark.ID, constituents, syncedAt)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to store benchmark constituents", err,
+				logger.String("benchmark", benchmark.Symbol))
+			return nil, response.InternalServerError("Failed to store benchmark constituents")
+		}
+	}
+
+	if err := s.benchmarkRepo.MarkSynced(ctx, benchmark.ID, syncedAt); err != nil {
+		s.logger.Warn(ctx, "Failed to record benchmark sync time",
+			logger.String("benchmark", benchmark.Symbol),
+			logger.String("error", err.Error()))
+	} else {
+		benchmark.LastSyncedAt = &syncedAt
+	}
+	result.Benchmark = toBenchmarkResponse(benchmark)
+
+	s.logger.Info(ctx, "Benchmark synced",
+		logger.String("benchmark", benchmark.Symbol),
+		logger.Int("values", result.Values),
+		logger.Int("constituents_added", result.ConstituentsAdded),
+		logger.Int("constituents_removed", result.ConstituentsRemoved))
+
+	return result, nil
+}
+
+// linkCompanies enlaza cada miembro con la company registrada de su ticker; los demás quedan sin company
+func (s *benchmarkService) linkCompanies(ctx context.Context, constituents []*entities.BenchmarkConstituent) {
+	companies, err := s.companyRepo.GetAllActive(ctx)
+	if err != nil {
+		s.logger.Warn(ctx, "Failed to get companies for benchmark constituents",
+			logger.String("error", err.Error()))
+		return
+	}
+
+	byTicker := make(map[string]*entities.Company, len(companies))
+	for _, company := range companies {
+		byTicker[strings.ToUpper(company.Ticker)] = company
+	}
+	for _, constituent := range constituents {
+		if company, ok := byTicker[strings.ToUpper(constituent.Symbol)]; ok {
+			id := company.ID
+			constituent.CompanyID = &id
+		}
+	}
+}
+
+func toBenchmarkResponse(benchmark *entities.Benchmark) response.BenchmarkResponse {
+	result := response.BenchmarkResponse{
+		ID:           benchmark.ID,
+		Symbol:       benchmark.Symbol,
+		Name:         benchmark.Name,
+		Kind:         benchmark.Kind,
+		SourceSymbol: benchmark.SourceSymbol,
+		Weighting:    benchmark.Weighting,
+		Currency:     benchmark.Currency,
+		LastSyncedAt: benchmark.LastSyncedAt,
+	}
+	if benchmark.BaseDate != nil {
+		result.BaseDate = benchmark.BaseDate.Format(ratingDateLayout)
+	}
+	return result
+}
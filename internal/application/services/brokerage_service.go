@@ -56,7 +56,7 @@ func (s *brokerageService) CreateBrokerage(ctx context.Context, req *request.Cre
 	if err := s.brokerageRepo.Create(ctx, brokerage); err != nil {
 		s.logger.Error(ctx, "Failed to create brokerage", err,
 			logger.String("name", req.Name))
-		return nil, response.InternalServerError("Failed to create brokerage")
+		return nil, response.FromError(err, "Brokerage", "Failed to create brokerage")
 	}
 
 	s.logger.Info(ctx, "Brokerage created successfully",
@@ -72,7 +72,7 @@ func (s *brokerageService) GetBrokerageByID(ctx context.Context, id uuid.UUID) (
 	if err != nil {
 		s.logger.Error(ctx, "Failed to get brokerage by ID", err,
 			logger.String("brokerage_id", id.String()))
-		return nil, response.NotFound("Brokerage")
+		return nil, response.FromError(err, "Brokerage", "Failed to get brokerage")
 	}
 
 	return s.convertToBrokerageResponse(brokerage), nil
@@ -83,7 +83,12 @@ func (s *brokerageService) UpdateBrokerage(ctx context.Context, id uuid.UUID, re
 	// Get existing brokerage
 	brokerage, err := s.brokerageRepo.GetByID(ctx, id)
 	if err != nil {
-		return nil, response.NotFound("Brokerage")
+		return nil, response.FromError(err, "Brokerage", "Failed to get brokerage")
+	}
+
+	// La versión enviada por el cliente es la que se compara al guardar
+	if req.Version != nil {
+		brokerage.Version = *req.Version
 	}
 
 	// Update fields if provided
@@ -101,7 +106,7 @@ func (s *brokerageService) UpdateBrokerage(ctx context.Context, id uuid.UUID, re
 	if err := s.brokerageRepo.Update(ctx, brokerage); err != nil {
 		s.logger.Error(ctx, "Failed to update brokerage", err,
 			logger.String("brokerage_id", id.String()))
-		return nil, response.InternalServerError("Failed to update brokerage")
+		return nil, response.FromError(err, "Brokerage", "Failed to update brokerage")
 	}
 
 	s.logger.Info(ctx, "Brokerage updated successfully",
@@ -116,13 +121,13 @@ func (s *brokerageService) DeleteBrokerage(ctx context.Context, id uuid.UUID) er
 	// Check if exists
 	_, err := s.brokerageRepo.GetByID(ctx, id)
 	if err != nil {
-		return response.NotFound("Brokerage")
+		return response.FromError(err, "Brokerage", "Failed to get brokerage")
 	}
 
 	if err := s.brokerageRepo.Delete(ctx, id); err != nil {
 		s.logger.Error(ctx, "Failed to delete brokerage", err,
 			logger.String("brokerage_id", id.String()))
-		return response.InternalServerError("Failed to delete brokerage")
+		return response.FromError(err, "Brokerage", "Failed to delete brokerage")
 	}
 
 	s.logger.Info(ctx, "Brokerage deleted successfully",
@@ -137,51 +142,26 @@ func (s *brokerageService) ListBrokerages(ctx context.Context, filter *request.B
 		return nil, response.BadRequest("Invalid pagination parameters")
 	}
 
-	var brokerages []*entities.Brokerage
-	var total int64
-	var err error
-
-	// Apply filters
+	// Apply filters and sort in the repository query
+	var listFilter repoInterfaces.BrokerageListFilter
+	var sort []repoInterfaces.SortField
 	if filter != nil {
-		if filter.IsActive != nil && *filter.IsActive {
-			brokerages, err = s.brokerageRepo.GetAllActive(ctx)
-		} else {
-			brokerages, err = s.brokerageRepo.GetAll(ctx)
+		var err error
+		if sort, err = parseSort(repoInterfaces.BrokerageSortFields, filter.Sort); err != nil {
+			return nil, err
+		}
+		listFilter = repoInterfaces.BrokerageListFilter{
+			Name:     filter.Name,
+			IsActive: filter.IsActive,
 		}
-	} else {
-		brokerages, err = s.brokerageRepo.GetAll(ctx)
 	}
 
+	paginatedBrokerages, total, err := s.brokerageRepo.List(ctx, listFilter, sort, pagination.GetLimit(), pagination.GetOffset())
 	if err != nil {
 		s.logger.Error(ctx, "Failed to get brokerages", err)
 		return nil, response.InternalServerError("Failed to get brokerages")
 	}
 
-	// Apply name filter if provided
-	if filter != nil && filter.Name != "" {
-		nameFilter := strings.ToLower(filter.Name)
-		var filteredBrokerages []*entities.Brokerage
-		for _, brokerage := range brokerages {
-			if strings.Contains(strings.ToLower(brokerage.Name), nameFilter) {
-				filteredBrokerages = append(filteredBrokerages, brokerage)
-			}
-		}
-		brokerages = filteredBrokerages
-	}
-
-	total = int64(len(brokerages))
-
-	// Apply pagination manually (in production, implement pagination in repository)
-	start := pagination.GetOffset()
-	end := start + pagination.GetLimit()
-	if start > len(brokerages) {
-		start = len(brokerages)
-	}
-	if end > len(brokerages) {
-		end = len(brokerages)
-	}
-	paginatedBrokerages := brokerages[start:end]
-
 	// Convert to responses
 	responses := make([]*response.BrokerageResponse, len(paginatedBrokerages))
 	for i, brokerage := range paginatedBrokerages {
@@ -229,7 +209,7 @@ func (s *brokerageService) ActivateBrokerage(ctx context.Context, id uuid.UUID)
 	if err := s.brokerageRepo.Activate(ctx, id); err != nil {
 		s.logger.Error(ctx, "Failed to activate brokerage", err,
 			logger.String("brokerage_id", id.String()))
-		return response.InternalServerError("Failed to activate brokerage")
+		return response.FromError(err, "Brokerage", "Failed to activate brokerage")
 	}
 
 	s.logger.Info(ctx, "Brokerage activated successfully",
@@ -242,7 +222,7 @@ func (s *brokerageService) DeactivateBrokerage(ctx context.Context, id uuid.UUID
 	if err := s.brokerageRepo.Deactivate(ctx, id); err != nil {
 		s.logger.Error(ctx, "Failed to deactivate brokerage", err,
 			logger.String("brokerage_id", id.String()))
-		return response.InternalServerError("Failed to deactivate brokerage")
+		return response.FromError(err, "Brokerage", "Failed to deactivate brokerage")
 	}
 
 	s.logger.Info(ctx, "Brokerage deactivated successfully",
@@ -302,6 +282,7 @@ func (s *brokerageService) convertToBrokerageResponse(brokerage *entities.Broker
 		Name:      brokerage.Name,
 		Website:   brokerage.Website,
 		IsActive:  brokerage.IsActive,
+		Version:   brokerage.Version,
 		CreatedAt: brokerage.CreatedAt,
 		UpdatedAt: brokerage.UpdatedAt,
 	}
@@ -0,0 +1,353 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // Decoders de los formatos de logo admitidos
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	domainServices "github.com/MayaCris/stock-info-app/internal/domain/services"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// Tipos de contenido de los logos servidos
+const (
+	logoContentTypePNG = "image/png"
+	logoContentTypeSVG = "image/svg+xml"
+)
+
+// logoMaxPixels limita el ancho por alto de los logos que se decodifican: un PNG de pocos KB puede declarar
+// dimensiones que ocupan gigas una vez decodificado
+const logoMaxPixels = 4096 * 4096
+
+// logoMaxRedirects limita las redirecciones que sigue la descarga de un logo
+const logoMaxRedirects = 3
+
+// logoSizeBuckets son los lados en los que se sirven los logos; el tamaño pedido se redondea al siguiente para que
+// cada logo se guarde en pocos tamaños
+var logoSizeBuckets = []int{16, 32, 64, 128, 256, 512, 1024, 2048}
+
+// logoBlockedPrefixes son los rangos no públicos que no cubren los métodos de netip.Addr
+var logoBlockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"), // CGNAT
+	netip.MustParsePrefix("198.18.0.0/15"), // Pruebas de rendimiento
+}
+
+// errLogoAddressNotPublic se retorna al conectar con un logo alojado en una dirección interna
+var errLogoAddressNotPublic = errors.New("logo host is not a public address")
+
+// CompanyLogoOptions configura la descarga y el tamaño de los logos
+type CompanyLogoOptions struct {
+	DefaultSize  int           // Lado máximo en píxeles si no se pide otro
+	MaxSize      int           // Lado máximo que se puede pedir
+	MaxBytes     int           // Tamaño máximo del logo original
+	FetchTimeout time.Duration // Tiempo máximo de la descarga del logo original
+	HTTPClient   *http.Client  // Opcional; por defecto uno con FetchTimeout que solo conecta con direcciones públicas
+}
+
+// companyLogoService implements the CompanyLogoService interface
+type companyLogoService struct {
+	companyRepo repoInterfaces.CompanyRepository
+	store       domainServices.ObjectStore
+	options     CompanyLogoOptions
+	client      *http.Client
+	fetches     singleflight.Group
+	logger      logger.Logger
+}
+
+// NewCompanyLogoService creates the logo proxy. Logos are downloaded once per logo URL and size bucket, resized to
+// fit in a square of that side and kept in the store, so expired provider URLs keep being served. Logo URLs come
+// from the provider, so the default client refuses hosts that resolve to loopback, private or link-local addresses
+func NewCompanyLogoService(companyRepo repoInterfaces.CompanyRepository, store domainServices.ObjectStore, options CompanyLogoOptions, logger logger.Logger) interfaces.CompanyLogoService {
+	client := options.HTTPClient
+	if client == nil {
+		client = newLogoHTTPClient(options.FetchTimeout)
+	}
+	return &companyLogoService{
+		companyRepo: companyRepo,
+		store:       store,
+		options:     options,
+		client:      client,
+		logger:      logger,
+	}
+}
+
+// GetLogo returns the logo of the company resized to size pixels (0 uses the default size), rounded up to the next
+// size bucket
+func (s *companyLogoService) GetLogo(ctx context.Context, ticker string, size int) (*response.CompanyLogoResponse, error) {
+	if size == 0 {
+		size = s.options.DefaultSize
+	}
+	if size < 16 || size > s.options.MaxSize {
+		return nil, response.BadRequest(fmt.Sprintf("size must be between 16 and %d", s.options.MaxSize))
+	}
+	size = logoSizeBucket(size, s.options.MaxSize)
+
+	company, err := s.companyRepo.GetByTicker(ctx, strings.ToUpper(strings.TrimSpace(ticker)))
+	if err != nil {
+		return nil, response.FromError(err, "Company", "Failed to get company")
+	}
+	if company.Logo == "" {
+		return nil, response.NotFound("Company logo")
+	}
+
+	// La clave incluye el hash de la URL: si el proveedor cambia el logo se descarga el nuevo
+	urlHash := sha256.Sum256([]byte(company.Logo))
+	baseKey := fmt.Sprintf("%s/%d-%s", company.Ticker, size, hex.EncodeToString(urlHash[:6]))
+
+	if logo, err := s.cached(ctx, baseKey); err != nil || logo != nil {
+		return logo, err
+	}
+
+	result, err, _ := s.fetches.Do(baseKey, func() (interface{}, error) {
+		return s.fetch(ctx, company.Ticker, company.Logo, baseKey, size)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*response.CompanyLogoResponse), nil
+}
+
+// cached lee el logo ya redimensionado (PNG, o el SVG original); nil si no está guardado
+func (s *companyLogoService) cached(ctx context.Context, baseKey string) (*response.CompanyLogoResponse, error) {
+	for _, contentType := range []string{logoContentTypePNG, logoContentTypeSVG} {
+		reader, err := s.store.Get(ctx, logoKey(baseKey, contentType))
+		if errors.Is(err, domainServices.ErrObjectNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			return nil, err
+		}
+		return newCompanyLogoResponse(data, contentType), nil
+	}
+	return nil, nil
+}
+
+// fetch descarga el logo original, lo redimensiona y lo guarda
+func (s *companyLogoService) fetch(ctx context.Context, ticker, logoURL, baseKey string, size int) (*response.CompanyLogoResponse, error) {
+	parsed, err := url.Parse(logoURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return nil, response.NotFound("Company logo")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, logoURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		s.logger.Warn(ctx, "Failed to download company logo",
+			logger.String("ticker", ticker),
+			logger.String("error", err.Error()),
+		)
+		return nil, response.ExternalAPIError("logo", "the logo could not be downloaded")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		s.logger.Warn(ctx, "Company logo download returned an error status",
+			logger.String("ticker", ticker),
+			logger.Int("status", resp.StatusCode),
+		)
+		return nil, response.ExternalAPIError("logo", fmt.Sprintf("the logo server answered %d", resp.StatusCode))
+	}
+
+	original, err := io.ReadAll(io.LimitReader(resp.Body, int64(s.options.MaxBytes)+1))
+	if err != nil {
+		return nil, response.ExternalAPIError("logo", "the logo could not be downloaded")
+	}
+	if len(original) > s.options.MaxBytes {
+		return nil, response.ExternalAPIError("logo", fmt.Sprintf("the logo is larger than %d bytes", s.options.MaxBytes))
+	}
+
+	data, contentType, err := resizeLogo(original, resp.Header.Get("Content-Type"), size)
+	if err != nil {
+		s.logger.Warn(ctx, "Company logo could not be decoded",
+			logger.String("ticker", ticker),
+			logger.String("error", err.Error()),
+		)
+		return nil, response.ExternalAPIError("logo", "the logo format is not supported")
+	}
+
+	// Un fallo al guardar no impide servir el logo; se volverá a descargar en la próxima petición
+	if err := s.store.Put(ctx, logoKey(baseKey, contentType), bytes.NewReader(data), int64(len(data)), contentType); err != nil {
+		s.logger.Warn(ctx, "Failed to cache company logo",
+			logger.String("ticker", ticker),
+			logger.String("error", err.Error()),
+		)
+	}
+
+	s.logger.Info(ctx, "Company logo cached",
+		logger.String("ticker", ticker),
+		logger.Int("size", size),
+		logger.Int("bytes", len(data)),
+	)
+	return newCompanyLogoResponse(data, contentType), nil
+}
+
+// logoSizeBucket redondea size al siguiente lado de logoSizeBuckets sin pasar de maxSize
+func logoSizeBucket(size, maxSize int) int {
+	for _, bucket := range logoSizeBuckets {
+		if bucket >= size {
+			return min(bucket, maxSize)
+		}
+	}
+	return maxSize
+}
+
+// newLogoHTTPClient crea el cliente de descarga de los logos. La dirección se comprueba al conectar, ya resuelta, así
+// que tampoco pasan los nombres que resuelven a la red interna ni las redirecciones hacia ella; sin proxy para que
+// la comprobación se haga sobre el servidor del logo
+func newLogoHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: publicLogoAddress}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= logoMaxRedirects {
+				return fmt.Errorf("stopped after %d logo redirects", logoMaxRedirects)
+			}
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return fmt.Errorf("logo redirect to unsupported scheme %q", req.URL.Scheme)
+			}
+			return nil
+		},
+	}
+}
+
+// publicLogoAddress rechaza las conexiones a direcciones de loopback, privadas, link-local o sin especificar
+func publicLogoAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	ip = ip.Unmap()
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return fmt.Errorf("%w: %s", errLogoAddressNotPublic, ip)
+	}
+	for _, prefix := range logoBlockedPrefixes {
+		if prefix.Contains(ip) {
+			return fmt.Errorf("%w: %s", errLogoAddressNotPublic, ip)
+		}
+	}
+	return nil
+}
+
+// logoKey devuelve la clave del logo guardado según su formato
+func logoKey(baseKey, contentType string) string {
+	if contentType == logoContentTypeSVG {
+		return baseKey + ".svg"
+	}
+	return baseKey + ".png"
+}
+
+// newCompanyLogoResponse calcula el ETag del contenido del logo
+func newCompanyLogoResponse(data []byte, contentType string) *response.CompanyLogoResponse {
+	sum := sha256.Sum256(data)
+	return &response.CompanyLogoResponse{
+		Data:        data,
+		ContentType: contentType,
+		ETag:        `"` + hex.EncodeToString(sum[:8]) + `"`,
+	}
+}
+
+// resizeLogo reduce el logo para que quepa en un cuadrado de size píxeles y lo codifica en PNG.
+// Los SVG se devuelven sin cambios porque escalan en el navegador (el handler los sirve con una CSP que bloquea sus
+// scripts); los logos más pequeños no se amplían y los de más de logoMaxPixels no se decodifican
+func resizeLogo(original []byte, contentType string, size int) ([]byte, string, error) {
+	if strings.Contains(contentType, "svg") || bytes.Contains(original[:min(len(original), 512)], []byte("<svg")) {
+		return original, logoContentTypeSVG, nil
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(original))
+	if err != nil {
+		return nil, "", err
+	}
+	if config.Width <= 0 || config.Height <= 0 || int64(config.Width)*int64(config.Height) > logoMaxPixels {
+		return nil, "", fmt.Errorf("logo of %dx%d pixels exceeds the %d pixel limit", config.Width, config.Height, logoMaxPixels)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(original))
+	if err != nil {
+		return nil, "", err
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, downscale(src, size)); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), logoContentTypePNG, nil
+}
+
+// downscale promedia los píxeles de origen que cubre cada píxel de destino (filtro de caja)
+func downscale(src image.Image, size int) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= size && height <= size {
+		return src
+	}
+
+	dstWidth, dstHeight := size, size
+	if width > height {
+		dstHeight = max(1, height*size/width)
+	} else {
+		dstWidth = max(1, width*size/height)
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < dstHeight; y++ {
+		y0 := bounds.Min.Y + y*height/dstHeight
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*height/dstHeight)
+		for x := 0; x < dstWidth; x++ {
+			x0 := bounds.Min.X + x*width/dstWidth
+			x1 := max(x0+1, bounds.Min.X+(x+1)*width/dstWidth)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+			dst.Set(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n)})
+		}
+	}
+	return dst
+}
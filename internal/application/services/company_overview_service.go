@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// Secciones del overview; son también las claves de Unavailable
+const (
+	overviewSectionProfile    = "profile"
+	overviewSectionQuote      = "quote"
+	overviewSectionFinancials = "financials"
+	overviewSectionRatings    = "ratings"
+	overviewSectionNews       = "news"
+)
+
+// CompanyOverviewOptions configura qué y cuánto reúne el overview de una company
+type CompanyOverviewOptions struct {
+	RatingsLimit   int           // Ratings más recientes incluidos
+	NewsDays       int           // Días de noticias consultados
+	NewsLimit      int           // Noticias incluidas como máximo
+	SectionTimeout time.Duration // Tiempo máximo de cada sección; las lentas se omiten
+}
+
+// DefaultCompanyOverviewOptions returns the options used by the company detail page
+func DefaultCompanyOverviewOptions() CompanyOverviewOptions {
+	return CompanyOverviewOptions{
+		RatingsLimit:   5,
+		NewsDays:       7,
+		NewsLimit:      10,
+		SectionTimeout: 5 * time.Second,
+	}
+}
+
+// companyOverviewService implements the CompanyOverviewService interface
+type companyOverviewService struct {
+	companyService    interfaces.CompanyService
+	ratingService     interfaces.StockRatingService
+	marketDataService interfaces.MarketDataService
+	options           CompanyOverviewOptions
+	logger            logger.Logger
+}
+
+// NewCompanyOverviewService creates the aggregate used by the company detail page
+func NewCompanyOverviewService(
+	companyService interfaces.CompanyService,
+	ratingService interfaces.StockRatingService,
+	marketDataService interfaces.MarketDataService,
+	options CompanyOverviewOptions,
+	logger logger.Logger,
+) interfaces.CompanyOverviewService {
+	return &companyOverviewService{
+		companyService:    companyService,
+		ratingService:     ratingService,
+		marketDataService: marketDataService,
+		options:           options,
+		logger:            logger,
+	}
+}
+
+// GetOverview resolves the company and then gathers its profile, latest quote, basic financials, most recent ratings and
+// news concurrently. Only an unknown company fails the call: a section that fails or times out is left empty and listed
+// in Unavailable, so the page can still render everything else
+func (s *companyOverviewService) GetOverview(ctx context.Context, ticker string) (*response.CompanyOverviewResponse, error) {
+	company, err := s.companyService.GetCompanyByTicker(ctx, ticker)
+	if err != nil {
+		return nil, err
+	}
+
+	overview := &response.CompanyOverviewResponse{
+		Company:       company,
+		RecentRatings: []*response.StockRatingListResponse{},
+		News:          []*response.NewsResponse{},
+	}
+
+	sections := newSectionGroup(ctx, s.options.SectionTimeout, s.logger, logger.String("ticker", company.Ticker))
+
+	// Los datos de mercado se piden con el ticker canónico, aunque la petición use un alias
+	sections.Go(overviewSectionProfile, func(ctx context.Context) error {
+		profile, err := s.marketDataService.GetCompanyProfile(ctx, company.Ticker)
+		overview.Profile = profile
+		return err
+	})
+	sections.Go(overviewSectionQuote, func(ctx context.Context) error {
+		quote, err := s.marketDataService.GetRealTimeQuote(ctx, company.Ticker)
+		overview.Quote = quote
+		return err
+	})
+	sections.Go(overviewSectionFinancials, func(ctx context.Context) error {
+		financials, err := s.marketDataService.GetBasicFinancials(ctx, company.Ticker)
+		overview.Financials = financials
+		return err
+	})
+	sections.Go(overviewSectionRatings, func(ctx context.Context) error {
+		ratings, err := s.ratingService.GetRatingsByTicker(ctx, company.Ticker, request.RatingIncludes{Brokerage: true},
+			&response.PaginationRequest{Page: 1, PerPage: s.options.RatingsLimit})
+		if err != nil {
+			return err
+		}
+		overview.RecentRatings = ratings.Items
+		return nil
+	})
+	sections.Go(overviewSectionNews, func(ctx context.Context) error {
+		news, err := s.marketDataService.GetCompanyNews(ctx, company.Ticker, s.options.NewsDays)
+		if err != nil {
+			return err
+		}
+		if len(news) > s.options.NewsLimit {
+			news = news[:s.options.NewsLimit]
+		}
+		if news != nil {
+			overview.News = news
+		}
+		return nil
+	})
+
+	overview.Unavailable = sections.Wait()
+	overview.GeneratedAt = time.Now()
+
+	return overview, nil
+}
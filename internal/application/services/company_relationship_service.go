@@ -0,0 +1,332 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+const (
+	// defaultGraphDepth son los saltos desde la company si no se indican
+	defaultGraphDepth = 2
+
+	// maxGraphNodes limita las companies de un grafo (raíz incluida)
+	maxGraphNodes = 200
+)
+
+// companyRelationshipService implements the CompanyRelationshipService interface
+type companyRelationshipService struct {
+	companyRepo      repoInterfaces.CompanyRepository
+	relationshipRepo repoInterfaces.CompanyRelationshipRepository
+	logger           logger.Logger
+}
+
+// NewCompanyRelationshipService creates a new company relationship service
+func NewCompanyRelationshipService(
+	companyRepo repoInterfaces.CompanyRepository,
+	relationshipRepo repoInterfaces.CompanyRelationshipRepository,
+	logger logger.Logger,
+) interfaces.CompanyRelationshipService {
+	return &companyRelationshipService{
+		companyRepo:      companyRepo,
+		relationshipRepo: relationshipRepo,
+		logger:           logger,
+	}
+}
+
+// ListRelationships returns the relationships that start or end at a company (by ID or ticker), oldest first
+func (s *companyRelationshipService) ListRelationships(ctx context.Context, ref string, req *request.CompanyRelationshipsRequest) (*response.CompanyRelationshipsResponse, error) {
+	company, err := findCompanyByRef(ctx, s.companyRepo, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	var types []string
+	if req.Type != "" {
+		types = []string{req.Type}
+	}
+	relationships, err := s.relationshipRepo.GetByCompanyIDs(ctx, []uuid.UUID{company.ID}, types)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get company relationships", err,
+			logger.String("company_id", company.ID.String()))
+		return nil, response.InternalServerError("Failed to get company relationships")
+	}
+
+	result := &response.CompanyRelationshipsResponse{
+		CompanyID:     company.ID,
+		Ticker:        company.Ticker,
+		Relationships: make([]response.CompanyRelationshipResponse, len(relationships)),
+	}
+	for i, relationship := range relationships {
+		result.Relationships[i] = toCompanyRelationshipResponse(relationship, company.ID)
+	}
+
+	return result, nil
+}
+
+// CreateRelationship declares that the related company is a supplier, competitor or subsidiary of the company.
+// Competition is symmetric, so a competitor edge that already exists in the other direction is a conflict
+func (s *companyRelationshipService) CreateRelationship(ctx context.Context, ref string, req *request.CreateCompanyRelationshipRequest) (*response.CompanyRelationshipResponse, error) {
+	company, err := findCompanyByRef(ctx, s.companyRepo, ref)
+	if err != nil {
+		return nil, err
+	}
+	related, err := findCompanyByRef(ctx, s.companyRepo, req.RelatedCompany)
+	if err != nil {
+		return nil, err
+	}
+	if company.ID == related.ID {
+		return nil, response.BadRequest("a company cannot be related to itself")
+	}
+
+	if req.Type == entities.RelationshipCompetitor {
+		existing, err := s.relationshipRepo.GetByCompanyIDs(ctx, []uuid.UUID{related.ID}, []string{entities.RelationshipCompetitor})
+		if err != nil {
+			s.logger.Error(ctx, "Failed to get company relationships", err,
+				logger.String("company_id", related.ID.String()))
+			return nil, response.InternalServerError("Failed to create company relationship")
+		}
+		for _, relationship := range existing {
+			if relationship.CompanyID == related.ID && relationship.RelatedCompanyID == company.ID {
+				return nil, response.Conflict(fmt.Sprintf("%s is already a competitor of %s", company.Ticker, related.Ticker))
+			}
+		}
+	}
+
+	relationship := entities.NewCompanyRelationship(company.ID, related.ID, req.Type, strings.TrimSpace(req.Note))
+	if err := s.relationshipRepo.Create(ctx, relationship); err != nil {
+		s.logger.Warn(ctx, "Failed to create company relationship",
+			logger.String("company", company.Ticker),
+			logger.String("related_company", related.Ticker),
+			logger.String("error", err.Error()))
+		return nil, response.FromError(err, "Relationship", "Failed to create company relationship")
+	}
+	relationship.Company, relationship.RelatedCompany = *company, *related
+
+	s.logger.Info(ctx, "Company relationship created",
+		logger.String("relationship_id", relationship.ID.String()),
+		logger.String("company", company.Ticker),
+		logger.String("related_company", related.Ticker),
+		logger.String("type", relationship.Type))
+
+	result := toCompanyRelationshipResponse(relationship, company.ID)
+	return &result, nil
+}
+
+// UpdateRelationship changes the type or note of a relationship of the company
+func (s *companyRelationshipService) UpdateRelationship(ctx context.Context, ref string, id uuid.UUID, req *request.UpdateCompanyRelationshipRequest) (*response.CompanyRelationshipResponse, error) {
+	company, relationship, err := s.companyRelationship(ctx, ref, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Type != "" {
+		relationship.Type = req.Type
+	}
+	if req.Note != nil {
+		relationship.Note = strings.TrimSpace(*req.Note)
+	}
+	if err := s.relationshipRepo.Update(ctx, relationship); err != nil {
+		s.logger.Warn(ctx, "Failed to update company relationship",
+			logger.String("relationship_id", id.String()),
+			logger.String("error", err.Error()))
+		return nil, response.FromError(err, "Relationship", "Failed to update company relationship")
+	}
+
+	result := toCompanyRelationshipResponse(relationship, company.ID)
+	return &result, nil
+}
+
+// DeleteRelationship removes a relationship of the company
+func (s *companyRelationshipService) DeleteRelationship(ctx context.Context, ref string, id uuid.UUID) error {
+	_, relationship, err := s.companyRelationship(ctx, ref, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.relationshipRepo.Delete(ctx, relationship.ID); err != nil {
+		s.logger.Warn(ctx, "Failed to delete company relationship",
+			logger.String("relationship_id", id.String()),
+			logger.String("error", err.Error()))
+		return response.FromError(err, "Relationship", "Failed to delete company relationship")
+	}
+
+	s.logger.Info(ctx, "Company relationship deleted", logger.String("relationship_id", id.String()))
+	return nil
+}
+
+// GetGraph returns the companies reachable from a company in up to depth hops (2 by default) through its
+// relationships in either direction, optionally only of some types. Traversal is breadth-first, one query per
+// hop, and stops adding companies at maxGraphNodes
+func (s *companyRelationshipService) GetGraph(ctx context.Context, ref string, req *request.CompanyGraphRequest) (*response.CompanyGraphResponse, error) {
+	depth := req.Depth
+	if depth <= 0 {
+		depth = defaultGraphDepth
+	}
+	types, err := parseRelationshipTypes(req.Types)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := findCompanyByRef(ctx, s.companyRepo, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	graph := &response.CompanyGraphResponse{
+		Root:  response.CompanyGraphNode{CompanyListResponse: toCompanyListResponse(root)},
+		Depth: depth,
+		Types: types,
+		Nodes: []response.CompanyGraphNode{{CompanyListResponse: toCompanyListResponse(root)}},
+		Edges: []response.CompanyGraphEdge{},
+	}
+	if len(graph.Types) == 0 {
+		graph.Types = entities.RelationshipTypes
+	}
+
+	visited := map[uuid.UUID]bool{root.ID: true}
+	seenEdges := make(map[uuid.UUID]bool)
+	addEdges := func(relationships []*entities.CompanyRelationship) {
+		for _, relationship := range relationships {
+			if seenEdges[relationship.ID] || !visited[relationship.CompanyID] || !visited[relationship.RelatedCompanyID] {
+				continue
+			}
+			seenEdges[relationship.ID] = true
+			graph.Edges = append(graph.Edges, response.CompanyGraphEdge{
+				ID:   relationship.ID,
+				From: relationship.CompanyID,
+				To:   relationship.RelatedCompanyID,
+				Type: relationship.Type,
+				Note: relationship.Note,
+			})
+		}
+	}
+
+	frontier := []uuid.UUID{root.ID}
+	for hop := 1; hop <= depth+1 && len(frontier) > 0; hop++ {
+		relationships, err := s.relationshipRepo.GetByCompanyIDs(ctx, frontier, types)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to get company relationships for graph", err,
+				logger.String("company_id", root.ID.String()),
+				logger.Int("hop", hop))
+			return nil, response.InternalServerError("Failed to get company graph")
+		}
+
+		// El salto extra solo recoge las relaciones entre las companies del último nivel
+		var next []uuid.UUID
+		for _, relationship := range relationships {
+			if hop > depth {
+				break
+			}
+			for _, company := range []*entities.Company{&relationship.Company, &relationship.RelatedCompany} {
+				if visited[company.ID] {
+					continue
+				}
+				if len(graph.Nodes) >= maxGraphNodes {
+					graph.Truncated = true
+					continue
+				}
+				visited[company.ID] = true
+				next = append(next, company.ID)
+				graph.Nodes = append(graph.Nodes, response.CompanyGraphNode{CompanyListResponse: toCompanyListResponse(company), Depth: hop})
+			}
+		}
+		addEdges(relationships)
+		frontier = next
+	}
+
+	return graph, nil
+}
+
+// findCompanyByRef busca la company por ID o, si ref no es un UUID, por ticker
+func findCompanyByRef(ctx context.Context, companyRepo repoInterfaces.CompanyRepository, ref string) (*entities.Company, error) {
+	var (
+		company  *entities.Company
+		err      error
+		resource string
+	)
+	if id, parseErr := uuid.Parse(ref); parseErr == nil {
+		company, err = companyRepo.GetByID(ctx, id)
+		resource = "Company with id " + ref
+	} else {
+		company, err = companyRepo.GetByTicker(ctx, strings.ToUpper(ref))
+		resource = "Company with ticker " + strings.ToUpper(ref)
+	}
+	if err != nil {
+		return nil, response.FromError(err, resource, "Failed to get company")
+	}
+	return company, nil
+}
+
+// companyRelationship devuelve la company y una relación suya; la de otra company no se encuentra
+func (s *companyRelationshipService) companyRelationship(ctx context.Context, ref string, id uuid.UUID) (*entities.Company, *entities.CompanyRelationship, error) {
+	company, err := findCompanyByRef(ctx, s.companyRepo, ref)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	relationship, err := s.relationshipRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, response.FromError(err, "Relationship with id "+id.String(), "Failed to get company relationship")
+	}
+	if relationship.CompanyID != company.ID && relationship.RelatedCompanyID != company.ID {
+		return nil, nil, response.NotFound("Relationship with id " + id.String())
+	}
+
+	return company, relationship, nil
+}
+
+// parseRelationshipTypes valida la lista de tipos separados por comas; vacía es cualquier tipo
+func parseRelationshipTypes(value string) ([]string, error) {
+	var types []string
+	for _, relationshipType := range strings.Split(value, ",") {
+		relationshipType = strings.ToLower(strings.TrimSpace(relationshipType))
+		if relationshipType == "" || slices.Contains(types, relationshipType) {
+			continue
+		}
+		if !slices.Contains(entities.RelationshipTypes, relationshipType) {
+			return nil, response.BadRequest("types must be supplier, competitor or subsidiary")
+		}
+		types = append(types, relationshipType)
+	}
+	return types, nil
+}
+
+func toCompanyRelationshipResponse(relationship *entities.CompanyRelationship, companyID uuid.UUID) response.CompanyRelationshipResponse {
+	direction := "outgoing"
+	if relationship.CompanyID != companyID {
+		direction = "incoming"
+	}
+	return response.CompanyRelationshipResponse{
+		ID:             relationship.ID,
+		Type:           relationship.Type,
+		Direction:      direction,
+		Company:        toCompanyListResponse(&relationship.Company),
+		RelatedCompany: toCompanyListResponse(&relationship.RelatedCompany),
+		Note:           relationship.Note,
+		CreatedAt:      relationship.CreatedAt,
+		UpdatedAt:      relationship.UpdatedAt,
+	}
+}
+
+func toCompanyListResponse(company *entities.Company) response.CompanyListResponse {
+	return response.CompanyListResponse{
+		ID:       company.ID,
+		Ticker:   company.Ticker,
+		Name:     company.Name,
+		Sector:   company.Sector,
+		Exchange: company.Exchange,
+		Logo:     company.Logo,
+		IsActive: company.IsActive,
+	}
+}
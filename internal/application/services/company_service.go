@@ -5,29 +5,34 @@ import (
 	"strings"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 
 	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
 	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
 	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
 	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/events"
 	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
 )
 
 // companyService implements the CompanyService interface
 type companyService struct {
-	companyRepo repoInterfaces.CompanyRepository
-	logger      logger.Logger
+	companyRepo    repoInterfaces.CompanyRepository
+	eventPublisher events.Publisher // nil: no se publican eventos
+	logger         logger.Logger
 }
 
-// NewCompanyService creates a new company service
+// NewCompanyService creates a new company service; eventPublisher (optional) receives CompanyUpdated events
 func NewCompanyService(
 	companyRepo repoInterfaces.CompanyRepository,
+	eventPublisher events.Publisher,
 	logger logger.Logger,
 ) interfaces.CompanyService {
 	return &companyService{
-		companyRepo: companyRepo,
-		logger:      logger,
+		companyRepo:    companyRepo,
+		eventPublisher: eventPublisher,
+		logger:         logger,
 	}
 }
 
@@ -62,7 +67,7 @@ func (s *companyService) CreateCompany(ctx context.Context, req *request.CreateC
 		s.logger.Error(ctx, "Failed to create company", err,
 			logger.String("ticker", req.Ticker),
 			logger.String("name", req.Name))
-		return nil, response.InternalServerError("Failed to create company")
+		return nil, response.FromError(err, "Company", "Failed to create company")
 	}
 
 	s.logger.Info(ctx, "Company created successfully",
@@ -79,7 +84,7 @@ func (s *companyService) GetCompanyByID(ctx context.Context, id uuid.UUID) (*res
 	if err != nil {
 		s.logger.Error(ctx, "Failed to get company by ID", err,
 			logger.String("company_id", id.String()))
-		return nil, response.NotFound("Company")
+		return nil, response.FromError(err, "Company", "Failed to get company")
 	}
 
 	return s.convertToCompanyResponse(company), nil
@@ -91,7 +96,7 @@ func (s *companyService) GetCompanyByTicker(ctx context.Context, ticker string)
 	if err != nil {
 		s.logger.Error(ctx, "Failed to get company by ticker", err,
 			logger.String("ticker", ticker))
-		return nil, response.NotFound("Company")
+		return nil, response.FromError(err, "Company", "Failed to get company")
 	}
 
 	return s.convertToCompanyResponse(company), nil
@@ -102,7 +107,12 @@ func (s *companyService) UpdateCompany(ctx context.Context, id uuid.UUID, req *r
 	// Get existing company
 	company, err := s.companyRepo.GetByID(ctx, id)
 	if err != nil {
-		return nil, response.NotFound("Company")
+		return nil, response.FromError(err, "Company", "Failed to get company")
+	}
+
+	// La versión enviada por el cliente es la que se compara al guardar
+	if req.Version != nil {
+		company.Version = *req.Version
 	}
 
 	// Update fields if provided
@@ -126,15 +136,16 @@ func (s *companyService) UpdateCompany(ctx context.Context, id uuid.UUID, req *r
 	}
 
 	// Save changes
-	if err := s.companyRepo.Update(ctx, company); err != nil {
+	if err := s.companyRepo.Update(ctx, company, s.companyUpdatedHooks(ctx)...); err != nil {
 		s.logger.Error(ctx, "Failed to update company", err,
 			logger.String("company_id", id.String()))
-		return nil, response.InternalServerError("Failed to update company")
+		return nil, response.FromError(err, "Company", "Failed to update company")
 	}
 
 	s.logger.Info(ctx, "Company updated successfully",
 		logger.String("company_id", company.ID.String()),
 		logger.String("ticker", company.Ticker))
+	s.publishCompanyUpdated(ctx, company)
 
 	return s.convertToCompanyResponse(company), nil
 }
@@ -144,13 +155,13 @@ func (s *companyService) DeleteCompany(ctx context.Context, id uuid.UUID) error
 	// Check if exists
 	_, err := s.companyRepo.GetByID(ctx, id)
 	if err != nil {
-		return response.NotFound("Company")
+		return response.FromError(err, "Company", "Failed to get company")
 	}
 
 	if err := s.companyRepo.Delete(ctx, id); err != nil {
 		s.logger.Error(ctx, "Failed to delete company", err,
 			logger.String("company_id", id.String()))
-		return response.InternalServerError("Failed to delete company")
+		return response.FromError(err, "Company", "Failed to delete company")
 	}
 
 	s.logger.Info(ctx, "Company deleted successfully",
@@ -158,6 +169,61 @@ func (s *companyService) DeleteCompany(ctx context.Context, id uuid.UUID) error
 	return nil
 }
 
+// RestoreCompany restores a soft-deleted company
+func (s *companyService) RestoreCompany(ctx context.Context, id uuid.UUID) (*response.CompanyResponse, error) {
+	if err := s.companyRepo.Restore(ctx, id); err != nil {
+		s.logger.Error(ctx, "Failed to restore company", err,
+			logger.String("company_id", id.String()))
+		return nil, response.FromError(err, "Deleted company", "Failed to restore company")
+	}
+
+	company, err := s.companyRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, response.FromError(err, "Company", "Failed to get company")
+	}
+
+	s.logger.Info(ctx, "Company restored successfully",
+		logger.String("company_id", company.ID.String()),
+		logger.String("ticker", company.Ticker))
+
+	return s.convertToCompanyResponse(company), nil
+}
+
+// ListDeletedCompanies lists soft-deleted companies, most recently deleted first
+func (s *companyService) ListDeletedCompanies(ctx context.Context, pagination *response.PaginationRequest) (*response.PaginatedResponse[*response.DeletedCompanyResponse], error) {
+	// Validate pagination
+	if err := pagination.Validate(); err != nil {
+		return nil, response.BadRequest("Invalid pagination parameters")
+	}
+
+	total, err := s.companyRepo.CountDeleted(ctx)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to count deleted companies", err)
+		return nil, response.InternalServerError("Failed to get deleted companies")
+	}
+
+	companies, err := s.companyRepo.ListDeleted(ctx, pagination.GetLimit(), pagination.GetOffset())
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list deleted companies", err)
+		return nil, response.InternalServerError("Failed to get deleted companies")
+	}
+
+	items := make([]*response.DeletedCompanyResponse, len(companies))
+	for i, company := range companies {
+		items[i] = &response.DeletedCompanyResponse{
+			ID:        company.ID,
+			Ticker:    company.Ticker,
+			Name:      company.Name,
+			Sector:    company.Sector,
+			Exchange:  company.Exchange,
+			CreatedAt: company.CreatedAt,
+			DeletedAt: company.DeletedAt.Time,
+		}
+	}
+
+	return response.NewPaginatedResponse(items, pagination.Page, pagination.PerPage, int(total)), nil
+}
+
 // ListCompanies lists companies with filters and pagination
 func (s *companyService) ListCompanies(ctx context.Context, filter *request.CompanyFilterRequest, pagination *response.PaginationRequest) (*response.PaginatedResponse[*response.CompanyListResponse], error) {
 	// Validate pagination
@@ -165,42 +231,32 @@ func (s *companyService) ListCompanies(ctx context.Context, filter *request.Comp
 		return nil, response.BadRequest("Invalid pagination parameters")
 	}
 
-	var companies []*entities.Company
-	var total int64
-	var err error
-	// Apply filters
+	// Apply filters and sort in the repository query
+	var listFilter repoInterfaces.CompanyListFilter
+	var sort []repoInterfaces.SortField
 	if filter != nil {
-		if filter.Sector != "" {
-			companies, err = s.companyRepo.GetBySector(ctx, filter.Sector)
-		} else if filter.Exchange != "" {
-			companies, err = s.companyRepo.GetByExchange(ctx, filter.Exchange)
-		} else if filter.IsActive != nil && *filter.IsActive {
-			companies, err = s.companyRepo.GetAllActive(ctx)
-		} else {
-			companies, err = s.companyRepo.GetAll(ctx)
+		var err error
+		if sort, err = parseSort(repoInterfaces.CompanySortFields, filter.Sort); err != nil {
+			return nil, err
+		}
+		tags, err := parseTagFilter(filter.Tags)
+		if err != nil {
+			return nil, err
+		}
+		listFilter = repoInterfaces.CompanyListFilter{
+			Sector:   filter.Sector,
+			Exchange: filter.Exchange,
+			IsActive: filter.IsActive,
+			Tags:     tags,
 		}
-	} else {
-		companies, err = s.companyRepo.GetAll(ctx)
 	}
 
+	paginatedCompanies, total, err := s.companyRepo.List(ctx, listFilter, sort, pagination.GetLimit(), pagination.GetOffset())
 	if err != nil {
 		s.logger.Error(ctx, "Failed to get companies", err)
 		return nil, response.InternalServerError("Failed to get companies")
 	}
 
-	total = int64(len(companies))
-
-	// Apply pagination manually (in production, implement pagination in repository)
-	start := pagination.GetOffset()
-	end := start + pagination.GetLimit()
-	if start > len(companies) {
-		start = len(companies)
-	}
-	if end > len(companies) {
-		end = len(companies)
-	}
-	paginatedCompanies := companies[start:end]
-
 	// Convert to list responses
 	listResponses := make([]*response.CompanyListResponse, len(paginatedCompanies))
 	for i, company := range paginatedCompanies {
@@ -366,37 +422,155 @@ func (s *companyService) GetCompanyStats(ctx context.Context) (map[string]interf
 
 // ActivateCompany activates a company
 func (s *companyService) ActivateCompany(ctx context.Context, id uuid.UUID) error {
-	if err := s.companyRepo.Activate(ctx, id); err != nil {
+	if err := s.companyRepo.Activate(ctx, id, s.companyUpdatedHooks(ctx)...); err != nil {
 		s.logger.Error(ctx, "Failed to activate company", err,
 			logger.String("company_id", id.String()))
-		return response.InternalServerError("Failed to activate company")
+		return response.FromError(err, "Company", "Failed to activate company")
 	}
 
 	s.logger.Info(ctx, "Company activated successfully",
 		logger.String("company_id", id.String()))
+	s.publishCompanyUpdatedByID(ctx, id)
 	return nil
 }
 
 // DeactivateCompany deactivates a company
 func (s *companyService) DeactivateCompany(ctx context.Context, id uuid.UUID) error {
-	if err := s.companyRepo.Deactivate(ctx, id); err != nil {
+	if err := s.companyRepo.Deactivate(ctx, id, s.companyUpdatedHooks(ctx)...); err != nil {
 		s.logger.Error(ctx, "Failed to deactivate company", err,
 			logger.String("company_id", id.String()))
-		return response.InternalServerError("Failed to deactivate company")
+		return response.FromError(err, "Company", "Failed to deactivate company")
 	}
 
 	s.logger.Info(ctx, "Company deactivated successfully",
 		logger.String("company_id", id.String()))
+	s.publishCompanyUpdatedByID(ctx, id)
 	return nil
 }
 
+// RemapTicker changes a company's ticker keeping the previous one as an alias, so ratings
+// published under either symbol stay attached to the same company
+func (s *companyService) RemapTicker(ctx context.Context, id uuid.UUID, req *request.RemapTickerRequest) (*response.CompanyResponse, error) {
+	company, err := s.companyRepo.RemapTicker(ctx, id, req.Ticker, s.companyUpdatedHooks(ctx)...)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to remap company ticker", err,
+			logger.String("company_id", id.String()),
+			logger.String("new_ticker", req.Ticker))
+		return nil, response.FromError(err, "Ticker", "Failed to remap company ticker")
+	}
+
+	aliases, err := s.companyRepo.GetTickerAliases(ctx, id)
+	if err != nil {
+		return nil, response.FromError(err, "Company", "Failed to get ticker aliases")
+	}
+
+	s.logger.Info(ctx, "Company ticker remapped successfully",
+		logger.String("company_id", company.ID.String()),
+		logger.String("ticker", company.Ticker))
+	s.publishCompanyUpdated(ctx, company)
+
+	resp := s.convertToCompanyResponse(company)
+	for _, alias := range aliases {
+		resp.PreviousTickers = append(resp.PreviousTickers, alias.Ticker)
+	}
+
+	return resp, nil
+}
+
+// MergeCompanies moves ratings, market data, profile and news of a duplicate company to the
+// target and soft-deletes the duplicate. With DryRun only the preview counts are returned
+func (s *companyService) MergeCompanies(ctx context.Context, duplicateID uuid.UUID, req *request.MergeCompaniesRequest) (*response.CompanyMergeResponse, error) {
+	result, err := s.companyRepo.MergeInto(ctx, duplicateID, req.TargetID, req.DryRun)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to merge companies", err,
+			logger.String("duplicate_id", duplicateID.String()),
+			logger.String("target_id", req.TargetID.String()))
+		return nil, response.FromError(err, "Company", "Failed to merge companies")
+	}
+
+	s.logger.Info(ctx, "Companies merged",
+		logger.String("duplicate_ticker", result.Duplicate.Ticker),
+		logger.String("target_ticker", result.Target.Ticker),
+		logger.Bool("dry_run", req.DryRun),
+		logger.Int64("ratings_moved", result.RatingsMoved))
+
+	return &response.CompanyMergeResponse{
+		Duplicate:         s.convertToCompanyResponse(result.Duplicate),
+		Target:            s.convertToCompanyResponse(result.Target),
+		DryRun:            req.DryRun,
+		RatingsMoved:      result.RatingsMoved,
+		RatingsDuplicated: result.RatingsDuplicated,
+		MarketDataMoved:   result.MarketDataMoved,
+		ProfileMoved:      result.ProfileMoved,
+		NewsMoved:         result.NewsMoved,
+		AliasesMoved:      result.AliasesMoved,
+	}, nil
+}
+
+// companyUpdatedHooks guarda el evento CompanyUpdated en la transacción del cambio cuando el publisher es el
+// outbox (events.TxPublisher): si el evento no se puede guardar, el cambio se revierte
+func (s *companyService) companyUpdatedHooks(ctx context.Context) []repoInterfaces.WriteHook[entities.Company] {
+	txPublisher, ok := s.eventPublisher.(events.TxPublisher)
+	if !ok {
+		return nil
+	}
+
+	return []repoInterfaces.WriteHook[entities.Company]{func(tx *gorm.DB, company *entities.Company) error {
+		event, err := events.NewCompanyUpdated(company)
+		if err != nil {
+			return err
+		}
+		return txPublisher.PublishWithTx(ctx, tx, event)
+	}}
+}
+
+// publishesAfterWrite indica si CompanyUpdated se publica tras guardar el cambio: con el outbox ya se guardó en su
+// transacción
+func (s *companyService) publishesAfterWrite() bool {
+	_, transactional := s.eventPublisher.(events.TxPublisher)
+	return s.eventPublisher != nil && !transactional
+}
+
+// publishCompanyUpdated publica el evento CompanyUpdated; un fallo solo se registra, el cambio ya está guardado
+func (s *companyService) publishCompanyUpdated(ctx context.Context, company *entities.Company) {
+	if !s.publishesAfterWrite() {
+		return
+	}
+
+	event, err := events.NewCompanyUpdated(company)
+	if err == nil {
+		err = s.eventPublisher.Publish(ctx, event)
+	}
+	if err != nil {
+		s.logger.Warn(ctx, "Failed to publish company updated event",
+			logger.String("company_id", company.ID.String()),
+			logger.String("error", err.Error()))
+	}
+}
+
+// publishCompanyUpdatedByID vuelve a leer la company para publicar su estado tras un cambio parcial
+func (s *companyService) publishCompanyUpdatedByID(ctx context.Context, id uuid.UUID) {
+	if !s.publishesAfterWrite() {
+		return
+	}
+
+	company, err := s.companyRepo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.Warn(ctx, "Failed to load company for updated event",
+			logger.String("company_id", id.String()),
+			logger.String("error", err.Error()))
+		return
+	}
+	s.publishCompanyUpdated(ctx, company)
+}
+
 // UpdateMarketCap updates a company's market cap
 func (s *companyService) UpdateMarketCap(ctx context.Context, ticker string, marketCap float64) error {
 	if err := s.companyRepo.UpdateMarketCap(ctx, strings.ToUpper(ticker), marketCap); err != nil {
 		s.logger.Error(ctx, "Failed to update market cap", err,
 			logger.String("ticker", ticker),
 			logger.Float64("market_cap", marketCap))
-		return response.InternalServerError("Failed to update market cap")
+		return response.FromError(err, "Company", "Failed to update market cap")
 	}
 
 	s.logger.Info(ctx, "Market cap updated successfully",
@@ -444,6 +618,27 @@ func (s *companyService) SearchCompaniesByName(ctx context.Context, name string,
 	return s.SearchCompanies(ctx, name, pagination)
 }
 
+// SuggestCompanies returns the ticker+name pairs for a typeahead, ticker prefix matches first
+func (s *companyService) SuggestCompanies(ctx context.Context, prefix string, limit int) ([]*response.CompanySuggestionResponse, error) {
+	suggestions, err := s.companyRepo.SuggestByPrefix(ctx, prefix, limit)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to suggest companies", err,
+			logger.String("prefix", prefix))
+		return nil, response.InternalServerError("Failed to suggest companies")
+	}
+
+	responses := make([]*response.CompanySuggestionResponse, len(suggestions))
+	for i, suggestion := range suggestions {
+		responses[i] = &response.CompanySuggestionResponse{
+			ID:     suggestion.ID,
+			Ticker: suggestion.Ticker,
+			Name:   suggestion.Name,
+		}
+	}
+
+	return responses, nil
+}
+
 // Helper methods
 
 func (s *companyService) convertToCompanyResponse(company *entities.Company) *response.CompanyResponse {
@@ -456,6 +651,7 @@ func (s *companyService) convertToCompanyResponse(company *entities.Company) *re
 		Exchange:  company.Exchange,
 		Logo:      company.Logo,
 		IsActive:  company.IsActive,
+		Version:   company.Version,
 		CreatedAt: company.CreatedAt,
 		UpdatedAt: company.UpdatedAt,
 	}
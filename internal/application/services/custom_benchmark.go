@@ -0,0 +1,406 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/tenancy"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+	"github.com/google/uuid"
+)
+
+// customBenchmarkBaseValue es el valor del índice personalizado en su primera sesión
+const customBenchmarkBaseValue = 100.0
+
+// customBenchmarkSource identifica los valores calculados con los precios guardados
+const customBenchmarkSource = "custom"
+
+// CreateCustom defines a custom benchmark from registered tickers and computes its daily values from the stored
+// prices since the base date (one year ago by default). Equal weighting rebalances every session; cap weighting
+// starts from the current market caps and drifts with the prices. The symbol cannot be the ticker of a registered
+// company, and the benchmark belongs to the credential that creates it
+func (s *benchmarkService) CreateCustom(ctx context.Context, req *request.CreateCustomBenchmarkRequest) (*response.BenchmarkSyncResponse, error) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	baseDate := today.AddDate(-1, 0, 0)
+	if req.BaseDate != "" {
+		parsed, err := time.Parse(ratingDateLayout, req.BaseDate)
+		if err != nil {
+			return nil, response.BadRequest("base_date must be a date in YYYY-MM-DD format")
+		}
+		baseDate = parsed
+	}
+	if !baseDate.Before(today) {
+		return nil, response.BadRequest("base_date must be before today")
+	}
+	weighting := req.Weighting
+	if weighting == "" {
+		weighting = entities.BenchmarkWeightingEqual
+	}
+
+	tickers := make([]string, 0, len(req.Tickers))
+	seen := make(map[string]bool, len(req.Tickers))
+	for _, ticker := range req.Tickers {
+		ticker = strings.ToUpper(strings.TrimSpace(ticker))
+		if ticker != "" && !seen[ticker] {
+			seen[ticker] = true
+			tickers = append(tickers, ticker)
+		}
+	}
+	if len(tickers) < 2 {
+		return nil, response.BadRequest("a custom benchmark needs at least 2 distinct tickers")
+	}
+
+	if _, err := s.benchmarkRepo.GetBySymbol(ctx, req.Symbol); err == nil {
+		return nil, response.Conflict(fmt.Sprintf("benchmark %s already exists", strings.ToUpper(req.Symbol)))
+	} else if !domainerrors.IsNotFound(err) {
+		s.logger.Error(ctx, "Failed to check benchmark symbol", err,
+			logger.String("benchmark", req.Symbol))
+		return nil, response.InternalServerError("Failed to create custom benchmark")
+	}
+	// Las comparaciones aceptan tanto tickers como benchmarks, así que el símbolo no puede ocultar una empresa
+	if _, err := s.companyRepo.GetByTicker(ctx, req.Symbol); err == nil {
+		return nil, response.Conflict(fmt.Sprintf("%s is the ticker of a registered company", strings.ToUpper(req.Symbol)))
+	} else if !domainerrors.IsNotFound(err) {
+		s.logger.Error(ctx, "Failed to check company ticker", err,
+			logger.String("benchmark", req.Symbol))
+		return nil, response.InternalServerError("Failed to create custom benchmark")
+	}
+
+	ownerTenantID, owner, err := benchmarkOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	benchmark := entities.NewCustomBenchmark(req.Symbol, req.Name, weighting, baseDate)
+	benchmark.OwnerTenantID, benchmark.Owner = ownerTenantID, owner
+	constituents, err := s.customConstituents(ctx, tickers, weighting, baseDate)
+	if err != nil {
+		return nil, err
+	}
+
+	values, warnings, err := s.computeCustomValues(ctx, benchmark, constituents)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.benchmarkRepo.Create(ctx, benchmark, constituents); err != nil {
+		s.logger.Error(ctx, "Failed to create custom benchmark", err,
+			logger.String("benchmark", benchmark.Symbol))
+		return nil, response.FromError(err, "Benchmark "+benchmark.Symbol, "Failed to create custom benchmark")
+	}
+
+	result, err := s.storeCustomValues(ctx, benchmark, values)
+	if err != nil {
+		return nil, err
+	}
+	result.ConstituentsAdded = len(constituents)
+	result.Warnings = warnings
+
+	s.logger.Info(ctx, "Custom benchmark created",
+		logger.String("benchmark", benchmark.Symbol),
+		logger.String("weighting", weighting),
+		logger.Int("constituents", len(constituents)),
+		logger.Int("values", result.Values))
+
+	return result, nil
+}
+
+// DeleteCustom removes a custom benchmark with its values. Only the credential that created it can remove it, and
+// provider benchmarks cannot be removed
+func (s *benchmarkService) DeleteCustom(ctx context.Context, symbol string) error {
+	benchmark, err := s.benchmarkRepo.GetBySymbol(ctx, symbol)
+	if err != nil {
+		return response.FromError(err, "Benchmark "+strings.ToUpper(symbol), "Failed to get benchmark")
+	}
+	if !benchmark.IsCustom() {
+		return response.BadRequest("only custom benchmarks can be deleted")
+	}
+	ownerTenantID, owner, err := benchmarkOwner(ctx)
+	if err != nil {
+		return err
+	}
+	if !benchmark.IsOwnedBy(ownerTenantID, owner) {
+		return response.Forbidden("only the creator of a custom benchmark can delete it")
+	}
+
+	if err := s.benchmarkRepo.Delete(ctx, benchmark.ID); err != nil {
+		s.logger.Error(ctx, "Failed to delete custom benchmark", err,
+			logger.String("benchmark", benchmark.Symbol))
+		return response.FromError(err, "Benchmark "+benchmark.Symbol, "Failed to delete custom benchmark")
+	}
+
+	s.logger.Info(ctx, "Custom benchmark deleted", logger.String("benchmark", benchmark.Symbol))
+	return nil
+}
+
+// RefreshCustom computes again the values of the custom benchmarks not computed yet today, so the prices stored
+// since the last run are included. It runs from the benchmark_refresh job; a benchmark that fails is reported and
+// the rest are still refreshed
+func (s *benchmarkService) RefreshCustom(ctx context.Context) (*response.BenchmarkRefreshResponse, error) {
+	benchmarks, err := s.benchmarkRepo.GetAll(ctx)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list benchmarks", err)
+		return nil, response.InternalServerError("Failed to list benchmarks")
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	result := &response.BenchmarkRefreshResponse{}
+	for _, benchmark := range benchmarks {
+		if !benchmark.IsCustom() {
+			continue
+		}
+		result.Benchmarks++
+		if benchmark.LastSyncedAt != nil && !benchmark.LastSyncedAt.Before(today) {
+			continue
+		}
+		if _, err := s.rebuildCustom(ctx, benchmark); err != nil {
+			s.logger.Warn(ctx, "Failed to recompute custom benchmark",
+				logger.String("benchmark", benchmark.Symbol),
+				logger.String("error", err.Error()))
+			result.Failed = append(result.Failed, benchmark.Symbol)
+			continue
+		}
+		result.Refreshed++
+	}
+	result.RefreshedAt = time.Now().UTC()
+
+	s.logger.Info(ctx, "Custom benchmarks refreshed",
+		logger.Int("benchmarks", result.Benchmarks),
+		logger.Int("refreshed", result.Refreshed),
+		logger.Int("failed", len(result.Failed)))
+
+	return result, nil
+}
+
+// benchmarkOwner devuelve el tenant y la credencial de la petición; sin tenant (multi-tenancy deshabilitado o clave
+// de administración) el índice no tiene dueño
+func benchmarkOwner(ctx context.Context) (*uuid.UUID, string, error) {
+	if _, err := tenancy.TenantID(ctx); err != nil {
+		return nil, "", nil
+	}
+	tenantID, owner, err := tenancy.Owner(ctx)
+	if err != nil {
+		return nil, "", response.Unauthorized("Custom benchmarks require the API key or bearer token of a user")
+	}
+	return &tenantID, owner, nil
+}
+
+// rebuildCustom calcula de nuevo los valores de un índice personalizado con sus miembros y pesos iniciales
+func (s *benchmarkService) rebuildCustom(ctx context.Context, benchmark *entities.Benchmark) (*response.BenchmarkSyncResponse, error) {
+	constituents, err := s.benchmarkRepo.GetConstituents(ctx, benchmark.ID, time.Now().UTC().Truncate(24*time.Hour))
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get benchmark constituents", err,
+			logger.String("benchmark", benchmark.Symbol))
+		return nil, response.InternalServerError("Failed to get benchmark constituents")
+	}
+
+	values, warnings, err := s.computeCustomValues(ctx, benchmark, constituents)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.storeCustomValues(ctx, benchmark, values)
+	if err != nil {
+		return nil, err
+	}
+	result.Warnings = warnings
+
+	s.logger.Info(ctx, "Custom benchmark recomputed",
+		logger.String("benchmark", benchmark.Symbol),
+		logger.Int("values", result.Values))
+
+	return result, nil
+}
+
+// customConstituents resuelve los tickers a companies registradas con su peso inicial (fracción del índice)
+func (s *benchmarkService) customConstituents(ctx context.Context, tickers []string, weighting string, baseDate time.Time) ([]*entities.BenchmarkConstituent, error) {
+	var unknown, withoutCap []string
+	constituents := make([]*entities.BenchmarkConstituent, 0, len(tickers))
+	caps := make([]float64, 0, len(tickers))
+	for _, ticker := range tickers {
+		company, err := s.companyRepo.GetByTicker(ctx, ticker)
+		switch {
+		case err == nil:
+		case domainerrors.IsNotFound(err):
+			unknown = append(unknown, ticker)
+			continue
+		default:
+			s.logger.Error(ctx, "Failed to get company for custom benchmark", err,
+				logger.String("ticker", ticker))
+			return nil, response.InternalServerError("Failed to create custom benchmark")
+		}
+		if weighting == entities.BenchmarkWeightingCap && company.MarketCap <= 0 {
+			withoutCap = append(withoutCap, company.Ticker)
+		}
+
+		id := company.ID
+		constituents = append(constituents, &entities.BenchmarkConstituent{
+			CompanyID: &id,
+			Symbol:    strings.ToUpper(company.Ticker),
+			Name:      company.Name,
+			AddedAt:   baseDate,
+		})
+		caps = append(caps, company.MarketCap)
+	}
+	if len(unknown) > 0 {
+		return nil, response.BadRequest("unknown tickers: " + strings.Join(unknown, ", "))
+	}
+	if len(withoutCap) > 0 {
+		return nil, response.BadRequest("cap weighting needs the market cap of: " + strings.Join(withoutCap, ", "))
+	}
+
+	total := 0.0
+	for _, marketCap := range caps {
+		total += marketCap
+	}
+	for i, constituent := range constituents {
+		weight := 1 / float64(len(constituents))
+		if weighting == entities.BenchmarkWeightingCap {
+			weight = caps[i] / total
+		}
+		constituent.Weight = floatPtr(roundRatio(weight))
+	}
+
+	return constituents, nil
+}
+
+// computeCustomValues calcula la serie del índice con los precios diarios guardados de sus miembros. Los miembros
+// sin precios se ignoran con un aviso; se necesitan al menos dos sesiones
+func (s *benchmarkService) computeCustomValues(ctx context.Context, benchmark *entities.Benchmark, constituents []*entities.BenchmarkConstituent) ([]*entities.BenchmarkValue, []string, error) {
+	base := time.Now().UTC().AddDate(-1, 0, 0).Truncate(24 * time.Hour)
+	if benchmark.BaseDate != nil {
+		base = *benchmark.BaseDate
+	}
+	to := time.Now().UTC()
+
+	var warnings []string
+	prices := make(map[string][]*entities.HistoricalData, len(constituents))
+	weights := make(map[string]float64, len(constituents))
+	for _, constituent := range constituents {
+		history, err := s.historicalDataRepo.GetBySymbol(ctx, constituent.Symbol, base, to)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to get price history for custom benchmark", err,
+				logger.String("benchmark", benchmark.Symbol),
+				logger.String("symbol", constituent.Symbol))
+			return nil, nil, response.InternalServerError("Failed to compute custom benchmark")
+		}
+		if len(history) == 0 {
+			warnings = append(warnings, fmt.Sprintf("%s has no stored prices since %s and is left out", constituent.Symbol, base.Format(ratingDateLayout)))
+			continue
+		}
+		prices[constituent.Symbol] = history
+		weights[constituent.Symbol] = 1
+		if constituent.Weight != nil {
+			weights[constituent.Symbol] = *constituent.Weight
+		}
+	}
+
+	values := CustomIndexValues(prices, weights, benchmark.Weighting != entities.BenchmarkWeightingCap, base)
+	if len(values) < 2 {
+		return nil, nil, response.BadRequest("not enough stored prices to compute the benchmark; sync the historical data of its tickers first")
+	}
+	for _, value := range values {
+		value.BenchmarkID = benchmark.ID
+	}
+
+	return values, warnings, nil
+}
+
+// storeCustomValues guarda los valores calculados y registra la fecha del cálculo
+func (s *benchmarkService) storeCustomValues(ctx context.Context, benchmark *entities.Benchmark, values []*entities.BenchmarkValue) (*response.BenchmarkSyncResponse, error) {
+	if err := s.benchmarkRepo.UpsertValues(ctx, values); err != nil {
+		s.logger.Error(ctx, "Failed to store benchmark values", err,
+			logger.String("benchmark", benchmark.Symbol))
+		return nil, response.InternalServerError("Failed to store benchmark values")
+	}
+
+	syncedAt := time.Now().UTC()
+	if err := s.benchmarkRepo.MarkSynced(ctx, benchmark.ID, syncedAt); err != nil {
+		s.logger.Warn(ctx, "Failed to record benchmark sync time",
+			logger.String("benchmark", benchmark.Symbol),
+			logger.String("error", err.Error()))
+	} else {
+		benchmark.LastSyncedAt = &syncedAt
+	}
+
+	return &response.BenchmarkSyncResponse{
+		Benchmark: toBenchmarkResponse(benchmark),
+		Values:    len(values),
+		SyncedAt:  syncedAt,
+	}, nil
+}
+
+// CustomIndexValues computes the daily series of an index over the daily prices of its members since base,
+// starting at 100 on the first session. Each session moves by the weighted mean of the returns of the members
+// that traded; with rebalance the weights stay fixed (equal weighting), otherwise every holding drifts with its
+// price (cap weighting). Open, high, low and close are all the computed value
+func CustomIndexValues(prices map[string][]*entities.HistoricalData, weights map[string]float64, rebalance bool, base time.Time) []*entities.BenchmarkValue {
+	returns := make(map[string]map[string]float64, len(prices))
+	sessions := make(map[string]time.Time)
+	for symbol, history := range prices {
+		daily := make([]*entities.HistoricalData, 0, len(history))
+		for _, price := range history {
+			if price.Date.Before(base) || (price.TimeFrame != "" && price.TimeFrame != "1D") {
+				continue
+			}
+			daily = append(daily, price)
+			sessions[price.Date.Format(ratingDateLayout)] = price.Date
+		}
+		returns[symbol] = DailyReturns(daily)
+	}
+
+	dates := make([]string, 0, len(sessions))
+	for date := range sessions {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+
+	holdings := make(map[string]float64, len(weights))
+	for symbol, weight := range weights {
+		if _, ok := returns[symbol]; ok && weight > 0 {
+			holdings[symbol] = weight
+		}
+	}
+
+	var values []*entities.BenchmarkValue
+	level := customBenchmarkBaseValue
+	for i, date := range dates {
+		if i > 0 {
+			weighted, total := 0.0, 0.0
+			for symbol, holding := range holdings {
+				if r, ok := returns[symbol][date]; ok {
+					weighted += holding * r
+					total += holding
+				}
+			}
+			if total == 0 {
+				continue
+			}
+			level *= 1 + weighted/total
+			if !rebalance {
+				for symbol, holding := range holdings {
+					if r, ok := returns[symbol][date]; ok {
+						holdings[symbol] = holding * (1 + r)
+					}
+				}
+			}
+		}
+		values = append(values, &entities.BenchmarkValue{
+			Date:   sessions[date],
+			Open:   level,
+			High:   level,
+			Low:    level,
+			Close:  level,
+			Source: customBenchmarkSource,
+		})
+	}
+
+	return values
+}
@@ -0,0 +1,226 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	domainServices "github.com/MayaCris/stock-info-app/internal/domain/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/timezone"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// Secciones del dashboard; son también las claves de Unavailable
+const (
+	dashboardSectionMarket     = "market"
+	dashboardSectionGainers    = "top_gainers"
+	dashboardSectionLosers     = "top_losers"
+	dashboardSectionUpgrades   = "upgrades"
+	dashboardSectionDowngrades = "downgrades"
+	dashboardSectionTrending   = "trending"
+	dashboardSectionAlerts     = "alerts"
+)
+
+// DashboardOptions configura el tamaño de las secciones del dashboard y su cache
+type DashboardOptions struct {
+	MoversLimit    int           // Gainers y losers incluidos
+	RatingsLimit   int           // Upgrades y downgrades de hoy incluidos
+	TrendingLimit  int           // Símbolos trending incluidos
+	SectionTimeout time.Duration // Tiempo máximo de cada sección; las lentas se omiten
+	CacheTTL       time.Duration // Reutilización de la parte compartida (0 desactiva la cache)
+}
+
+// DefaultDashboardOptions returns the options used by the home screen
+func DefaultDashboardOptions(cacheTTL time.Duration) DashboardOptions {
+	return DashboardOptions{
+		MoversLimit:    5,
+		RatingsLimit:   5,
+		TrendingLimit:  10,
+		SectionTimeout: 5 * time.Second,
+		CacheTTL:       cacheTTL,
+	}
+}
+
+// dashboardService implements the DashboardService interface
+type dashboardService struct {
+	marketDataService interfaces.MarketDataService
+	ratingService     interfaces.StockRatingService
+	trendingService   interfaces.TrendingService
+	anomalyRepo       repoInterfaces.AnomalyRepository
+	cacheService      domainServices.CacheService
+	options           DashboardOptions
+	logger            logger.Logger
+}
+
+// NewDashboardService creates the home screen summary service. cacheService is optional
+func NewDashboardService(
+	marketDataService interfaces.MarketDataService,
+	ratingService interfaces.StockRatingService,
+	trendingService interfaces.TrendingService,
+	anomalyRepo repoInterfaces.AnomalyRepository,
+	cacheService domainServices.CacheService,
+	options DashboardOptions,
+	logger logger.Logger,
+) interfaces.DashboardService {
+	return &dashboardService{
+		marketDataService: marketDataService,
+		ratingService:     ratingService,
+		trendingService:   trendingService,
+		anomalyRepo:       anomalyRepo,
+		cacheService:      cacheService,
+		options:           options,
+		logger:            logger,
+	}
+}
+
+// GetDashboard returns the home screen summary: market overview, top gainers and losers, today's upgrades and
+// downgrades, trending symbols and the anomalies detected since the client's last visit. The shared sections are
+// gathered concurrently and cached per time zone and day; only complete summaries are cached, so a section that
+// failed is retried on the next request. The alert count depends on alerts_since and is never cached
+func (s *dashboardService) GetDashboard(ctx context.Context, req *request.DashboardRequest) (*response.DashboardResponse, error) {
+	location := timezone.FromContext(ctx)
+	now := time.Now().In(location)
+
+	alertsSince := timezone.StartOfDay(now)
+	if req.AlertsSince != "" {
+		since, err := time.Parse(time.RFC3339, req.AlertsSince)
+		if err != nil {
+			return nil, response.BadRequest("alerts_since must be an RFC 3339 timestamp")
+		}
+		alertsSince = since
+	}
+
+	// "Hoy" depende de la zona horaria: la cache se separa por zona y por día
+	today := now.Format("2006-01-02")
+	cacheKey := "dashboard:" + location.String() + ":" + today
+
+	dashboard := s.getCachedDashboard(ctx, cacheKey)
+	if dashboard == nil {
+		dashboard = s.buildDashboard(ctx, today)
+		if dashboard.Unavailable == nil {
+			s.setCachedDashboard(ctx, cacheKey, dashboard)
+		}
+	}
+
+	unread, err := s.anomalyRepo.CountCreatedSince(ctx, alertsSince)
+	if err != nil {
+		s.logger.Warn(ctx, "Failed to count dashboard alerts", logger.ErrorField(err))
+		if dashboard.Unavailable == nil {
+			dashboard.Unavailable = make(map[string]string)
+		}
+		dashboard.Unavailable[dashboardSectionAlerts] = "temporarily unavailable"
+	} else {
+		dashboard.UnreadAlerts = &unread
+	}
+
+	return dashboard, nil
+}
+
+// buildDashboard carga en paralelo las secciones compartidas del dashboard
+func (s *dashboardService) buildDashboard(ctx context.Context, today string) *response.DashboardResponse {
+	dashboard := &response.DashboardResponse{
+		TopGainers: []*response.MarketDataResponse{},
+		TopLosers:  []*response.MarketDataResponse{},
+		Upgrades:   response.DashboardRatingsResponse{Recent: []*response.StockRatingListResponse{}},
+		Downgrades: response.DashboardRatingsResponse{Recent: []*response.StockRatingListResponse{}},
+		Trending:   []response.TrendingSymbolResponse{},
+	}
+
+	sections := newSectionGroup(ctx, s.options.SectionTimeout, s.logger, logger.String("aggregate", "dashboard"))
+
+	sections.Go(dashboardSectionMarket, func(ctx context.Context) error {
+		market, err := s.marketDataService.GetMarketOverview(ctx)
+		dashboard.Market = market
+		return err
+	})
+	sections.Go(dashboardSectionGainers, func(ctx context.Context) error {
+		movers, err := s.marketDataService.GetMarketMovers(ctx, "gainers", s.options.MoversLimit)
+		if err != nil {
+			return err
+		}
+		dashboard.TopGainers = movers.Movers
+		return nil
+	})
+	sections.Go(dashboardSectionLosers, func(ctx context.Context) error {
+		movers, err := s.marketDataService.GetMarketMovers(ctx, "losers", s.options.MoversLimit)
+		if err != nil {
+			return err
+		}
+		dashboard.TopLosers = movers.Movers
+		return nil
+	})
+	sections.Go(dashboardSectionUpgrades, func(ctx context.Context) error {
+		return s.loadTodayRatings(ctx, "upgrade", today, &dashboard.Upgrades)
+	})
+	sections.Go(dashboardSectionDowngrades, func(ctx context.Context) error {
+		return s.loadTodayRatings(ctx, "downgrade", today, &dashboard.Downgrades)
+	})
+	sections.Go(dashboardSectionTrending, func(ctx context.Context) error {
+		trending, err := s.trendingService.GetTrending(ctx, &request.TrendingRequest{Limit: s.options.TrendingLimit})
+		if err != nil {
+			return err
+		}
+		dashboard.Trending = trending.Symbols
+		return nil
+	})
+
+	dashboard.Unavailable = sections.Wait()
+	dashboard.GeneratedAt = time.Now()
+
+	return dashboard
+}
+
+// loadTodayRatings carga el total de ratings de hoy con la acción actionType y los más recientes, con su company
+func (s *dashboardService) loadTodayRatings(ctx context.Context, actionType, today string, dest *response.DashboardRatingsResponse) error {
+	ratings, err := s.ratingService.SearchRatings(ctx,
+		&request.RatingSearchRequest{ActionType: actionType, From: today, To: today},
+		request.RatingIncludes{Company: true},
+		&response.PaginationRequest{Page: 1, PerPage: s.options.RatingsLimit})
+	if err != nil {
+		return err
+	}
+
+	dest.Total = ratings.Meta.Total
+	dest.Recent = ratings.Items
+	return nil
+}
+
+// getCachedDashboard devuelve el dashboard cacheado o nil si no hay cache o la entrada no existe
+func (s *dashboardService) getCachedDashboard(ctx context.Context, key string) *response.DashboardResponse {
+	if s.cacheService == nil || s.options.CacheTTL <= 0 {
+		return nil
+	}
+
+	data, err := s.cacheService.Get(ctx, key)
+	if err != nil || data == nil {
+		return nil
+	}
+
+	var dashboard response.DashboardResponse
+	if json.Unmarshal(data, &dashboard) != nil {
+		return nil
+	}
+	return &dashboard
+}
+
+// setCachedDashboard guarda la parte compartida del dashboard; los fallos solo se registran
+func (s *dashboardService) setCachedDashboard(ctx context.Context, key string, dashboard *response.DashboardResponse) {
+	if s.cacheService == nil || s.options.CacheTTL <= 0 {
+		return
+	}
+
+	data, err := json.Marshal(dashboard)
+	if err != nil {
+		return
+	}
+	if err := s.cacheService.Set(ctx, key, data, s.options.CacheTTL); err != nil {
+		s.logger.Warn(ctx, "Failed to cache dashboard",
+			logger.String("key", key),
+			logger.String("error", err.Error()),
+		)
+	}
+}
@@ -1,8 +1,12 @@
 package services
 
 import (
+	"time"
+
 	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/events"
 	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	domainServices "github.com/MayaCris/stock-info-app/internal/domain/services"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/alphavantage"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
 )
@@ -16,11 +20,24 @@ type ServiceFactory struct {
 	financialMetricsRepo    repoInterfaces.FinancialMetricsRepository
 	technicalIndicatorsRepo repoInterfaces.TechnicalIndicatorsRepository
 	historicalDataRepo      repoInterfaces.HistoricalDataRepository
+	marketDataRepo          repoInterfaces.MarketDataRepository
+	benchmarkRepo           repoInterfaces.BenchmarkRepository
+	noteRepo                repoInterfaces.NoteRepository
+
+	// Currency conversion
+	fxService interfaces.FXService
 
 	// External clients
 	alphaVantageClient  *alphavantage.Client
 	alphaVantageAdapter *alphavantage.Adapter
 
+	// Optional collaborators
+	peerService      interfaces.PeerService
+	cacheService     domainServices.CacheService
+	analysisCacheTTL time.Duration
+	scoringWeights   ScoringWeights
+	eventPublisher   events.Publisher
+
 	// Services (lazy initialization)
 	stockService               interfaces.StockRatingService
 	companyService             interfaces.CompanyService
@@ -29,6 +46,7 @@ type ServiceFactory struct {
 	financialMetricsService    *FinancialMetricsService
 	technicalIndicatorsService *TechnicalIndicatorsService
 	alphaVantageService        interfaces.AlphaVantageService
+	backtestService            interfaces.BacktestService
 
 	// Infrastructure
 	logger logger.Logger
@@ -42,8 +60,17 @@ type ServiceFactoryConfig struct {
 	FinancialMetricsRepo    repoInterfaces.FinancialMetricsRepository
 	TechnicalIndicatorsRepo repoInterfaces.TechnicalIndicatorsRepository
 	HistoricalDataRepo      repoInterfaces.HistoricalDataRepository
+	MarketDataRepo          repoInterfaces.MarketDataRepository // Opcional: cotizaciones de la amplitud de mercado
+	BenchmarkRepo           repoInterfaces.BenchmarkRepository  // Opcional: valores de los índices para el riesgo de portfolio
+	NoteRepo                repoInterfaces.NoteRepository       // Opcional: notas de los usuarios en los ratings con ?include=notes
+	FXService               interfaces.FXService                // Opcional: conversión de las capitalizaciones a una moneda
 	AlphaVantageClient      *alphavantage.Client
 	AlphaVantageAdapter     *alphavantage.Adapter
+	PeerService             interfaces.PeerService      // Opcional: la comparación sin tickers usa los peers de la company
+	CacheService            domainServices.CacheService // Opcional: cache de los cálculos de análisis costosos
+	AnalysisCacheTTL        time.Duration
+	ScoringWeights          ScoringWeights   // Pesos del modelo de recomendación; vacío usa DefaultScoringWeights
+	EventPublisher          events.Publisher // Opcional: publica CompanyUpdated
 	Logger                  logger.Logger
 }
 
@@ -56,8 +83,17 @@ func NewServiceFactory(config ServiceFactoryConfig) *ServiceFactory {
 		financialMetricsRepo:    config.FinancialMetricsRepo,
 		technicalIndicatorsRepo: config.TechnicalIndicatorsRepo,
 		historicalDataRepo:      config.HistoricalDataRepo,
+		marketDataRepo:          config.MarketDataRepo,
+		benchmarkRepo:           config.BenchmarkRepo,
+		noteRepo:                config.NoteRepo,
+		fxService:               config.FXService,
 		alphaVantageClient:      config.AlphaVantageClient,
 		alphaVantageAdapter:     config.AlphaVantageAdapter,
+		peerService:             config.PeerService,
+		cacheService:            config.CacheService,
+		analysisCacheTTL:        config.AnalysisCacheTTL,
+		scoringWeights:          config.ScoringWeights,
+		eventPublisher:          config.EventPublisher,
 		logger:                  config.Logger,
 	}
 }
@@ -69,6 +105,7 @@ func (f *ServiceFactory) GetStockRatingService() interfaces.StockRatingService {
 			f.stockRatingRepo,
 			f.companyRepo,
 			f.brokerageRepo,
+			f.noteRepo,
 			f.logger,
 		)
 	}
@@ -80,6 +117,7 @@ func (f *ServiceFactory) GetCompanyService() interfaces.CompanyService {
 	if f.companyService == nil {
 		f.companyService = NewCompanyService(
 			f.companyRepo,
+			f.eventPublisher,
 			f.logger,
 		)
 	}
@@ -104,12 +142,34 @@ func (f *ServiceFactory) GetAnalysisService() interfaces.AnalysisService {
 			f.stockRatingRepo,
 			f.companyRepo,
 			f.brokerageRepo,
+			f.financialMetricsRepo,
+			f.historicalDataRepo,
+			f.marketDataRepo,
+			f.benchmarkRepo,
+			f.fxService,
+			f.peerService,
+			f.cacheService,
+			f.analysisCacheTTL,
+			f.scoringWeights,
 			f.logger,
 		)
 	}
 	return f.analysisService
 }
 
+// GetBacktestService returns the backtest service instance
+func (f *ServiceFactory) GetBacktestService() interfaces.BacktestService {
+	if f.backtestService == nil {
+		f.backtestService = NewBacktestService(
+			f.stockRatingRepo,
+			f.companyRepo,
+			f.historicalDataRepo,
+			f.logger,
+		)
+	}
+	return f.backtestService
+}
+
 // GetFinancialMetricsService returns the financial metrics service instance
 func (f *ServiceFactory) GetFinancialMetricsService() *FinancialMetricsService {
 	if f.financialMetricsService == nil {
@@ -176,4 +236,5 @@ func (f *ServiceFactory) Reset() {
 	f.financialMetricsService = nil
 	f.technicalIndicatorsService = nil
 	f.alphaVantageService = nil
+	f.backtestService = nil
 }
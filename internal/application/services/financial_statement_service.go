@@ -0,0 +1,295 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	domainServices "github.com/MayaCris/stock-info-app/internal/domain/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/usage"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+const (
+	// defaultStatementsTTL es el tiempo tras el que se vuelven a descargar los estados si no se configura otro
+	defaultStatementsTTL = 24 * time.Hour
+
+	// defaultStatementPeriods es el número de periodos devueltos si no se indica limit
+	defaultStatementPeriods = 8
+
+	// statementDateTolerance es el margen para emparejar un periodo con el del año o trimestre anterior: las
+	// fechas de cierre fiscal varían unos días (52/53 semanas)
+	statementDateTolerance = 20 * 24 * time.Hour
+)
+
+// financialStatementService implements the FinancialStatementService interface
+type financialStatementService struct {
+	companyRepo   repoInterfaces.CompanyRepository
+	statementRepo repoInterfaces.FinancialStatementRepository
+	provider      domainServices.FinancialStatementProvider // nil: solo los estados ya guardados
+	logger        logger.Logger
+
+	ttl   time.Duration
+	ttlMu sync.RWMutex
+}
+
+// NewFinancialStatementService creates a new financial statement service. Stored statements older than ttl
+// are fetched again from the provider
+func NewFinancialStatementService(
+	companyRepo repoInterfaces.CompanyRepository,
+	statementRepo repoInterfaces.FinancialStatementRepository,
+	provider domainServices.FinancialStatementProvider,
+	ttl time.Duration,
+	logger logger.Logger,
+) interfaces.FinancialStatementService {
+	service := &financialStatementService{
+		companyRepo:   companyRepo,
+		statementRepo: statementRepo,
+		provider:      provider,
+		logger:        logger,
+	}
+	service.UpdateTTL(ttl)
+	return service
+}
+
+// UpdateTTL reemplaza el TTL de los estados guardados; un valor no positivo usa defaultStatementsTTL
+func (s *financialStatementService) UpdateTTL(ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = defaultStatementsTTL
+	}
+	s.ttlMu.Lock()
+	defer s.ttlMu.Unlock()
+	s.ttl = ttl
+}
+
+func (s *financialStatementService) currentTTL() time.Duration {
+	s.ttlMu.RLock()
+	defer s.ttlMu.RUnlock()
+	return s.ttl
+}
+
+// GetStatements returns the latest periods of a statement of a company with their YoY and QoQ growth rates and
+// margins. Statements are served from the database and fetched again once they are older than the TTL; if the
+// provider fails the stored ones are served as stale
+func (s *financialStatementService) GetStatements(ctx context.Context, ticker string, req *request.FinancialStatementRequest) (*response.FinancialStatementsResponse, error) {
+	statement, period, limit := entities.StatementIncome, entities.StatementPeriodQuarterly, defaultStatementPeriods
+	if req != nil {
+		if req.Statement != "" {
+			statement = req.Statement
+		}
+		if req.Period != "" {
+			period = req.Period
+		}
+		if req.Limit > 0 {
+			limit = req.Limit
+		}
+	}
+	if !entities.IsValidStatement(statement) {
+		return nil, response.BadRequest("statement must be one of income, balance or cash_flow")
+	}
+	if period != entities.StatementPeriodAnnual && period != entities.StatementPeriodQuarterly {
+		return nil, response.BadRequest("period must be annual or quarterly")
+	}
+
+	company, err := s.companyRepo.GetByTicker(ctx, ticker)
+	if err != nil {
+		return nil, response.FromError(err, "Company", "Failed to get company")
+	}
+
+	// Periodos extra para calcular el crecimiento del más antiguo devuelto
+	lookback := 1
+	if period == entities.StatementPeriodQuarterly {
+		lookback = 4
+	}
+
+	stored, err := s.statementRepo.GetByCompany(ctx, company.ID, statement, period, limit+lookback)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get stored financial statements", err,
+			logger.String("ticker", company.Ticker))
+		return nil, response.InternalServerError("Failed to get financial statements")
+	}
+
+	stale := false
+	if len(stored) == 0 || time.Since(stored[0].UpdatedAt) > s.currentTTL() {
+		switch {
+		case s.provider == nil:
+			stale = len(stored) > 0
+		case !usage.RefreshAllowed(ctx):
+			if len(stored) == 0 {
+				return nil, refreshQuotaError(ctx, company.Ticker)
+			}
+			stale = true
+		default:
+			refreshed, err := s.refresh(ctx, company, statement, period, limit+lookback)
+			switch {
+			case err == nil:
+				stored = refreshed
+			case len(stored) == 0:
+				s.logger.Error(ctx, "Failed to fetch financial statements", err,
+					logger.String("ticker", company.Ticker),
+					logger.String("statement", statement))
+				return nil, alphaVantageError(err, company.Ticker, "Failed to fetch financial statements")
+			default:
+				s.logger.Warn(ctx, "Failed to refresh financial statements, serving stored ones",
+					logger.String("ticker", company.Ticker),
+					logger.String("statement", statement),
+					logger.String("error", err.Error()))
+				stale = true
+			}
+		}
+	} else {
+		usage.RecordCacheHit(ctx)
+	}
+
+	if len(stored) == 0 {
+		return nil, response.NotFound("Financial statements for symbol " + company.Ticker)
+	}
+
+	result := &response.FinancialStatementsResponse{
+		CompanyID: company.ID,
+		Ticker:    company.Ticker,
+		Statement: statement,
+		Period:    period,
+		Periods:   buildFinancialPeriods(stored, statement, period, limit),
+		Stale:     stale,
+	}
+	if s.provider != nil {
+		result.Source = s.provider.Source()
+	}
+	fetchedAt := stored[0].UpdatedAt
+	result.FetchedAt = &fetchedAt
+
+	return result, nil
+}
+
+// refresh descarga de nuevo el estado (anual y trimestral en una sola llamada), lo guarda y relee el periodo pedido
+func (s *financialStatementService) refresh(ctx context.Context, company *entities.Company, statement, period string, limit int) ([]*entities.FinancialStatement, error) {
+	usage.RecordProviderCall(ctx)
+	fetched, err := s.provider.FetchStatements(ctx, company.ID, company.Ticker, statement)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.statementRepo.Upsert(ctx, fetched); err != nil {
+		return nil, err
+	}
+	return s.statementRepo.GetByCompany(ctx, company.ID, statement, period, limit)
+}
+
+// buildFinancialPeriods devuelve los limit periodos más recientes con sus métricas y su crecimiento frente al
+// mismo periodo del año anterior y, en trimestrales, frente al trimestre anterior
+func buildFinancialPeriods(statements []*entities.FinancialStatement, statement, period string, limit int) []response.FinancialPeriodResponse {
+	sorted := make([]*entities.FinancialStatement, len(statements))
+	copy(sorted, statements)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].FiscalDateEnding.After(sorted[j].FiscalDateEnding)
+	})
+
+	if limit > len(sorted) {
+		limit = len(sorted)
+	}
+	periods := make([]response.FinancialPeriodResponse, 0, limit)
+	for _, current := range sorted[:limit] {
+		item := response.FinancialPeriodResponse{
+			FiscalDateEnding: current.FiscalDateEnding.Format("2006-01-02"),
+			Currency:         current.Currency,
+			Items:            current.LineItems,
+			Metrics:          statementMetrics(statement, current.LineItems),
+		}
+		if item.Items == nil {
+			item.Items = map[string]float64{}
+		}
+
+		if previous := findStatementNear(sorted, current.FiscalDateEnding.AddDate(-1, 0, 0)); previous != nil {
+			item.YoYGrowth = growthRates(current.LineItems, previous.LineItems)
+		}
+		if period == entities.StatementPeriodQuarterly {
+			if previous := findStatementNear(sorted, current.FiscalDateEnding.AddDate(0, -3, 0)); previous != nil {
+				item.QoQGrowth = growthRates(current.LineItems, previous.LineItems)
+			}
+		}
+
+		periods = append(periods, item)
+	}
+
+	return periods
+}
+
+// findStatementNear devuelve el estado cuya fecha fiscal está más cerca de target, dentro del margen tolerado
+func findStatementNear(statements []*entities.FinancialStatement, target time.Time) *entities.FinancialStatement {
+	var best *entities.FinancialStatement
+	bestDistance := statementDateTolerance + 1
+	for _, candidate := range statements {
+		distance := candidate.FiscalDateEnding.Sub(target)
+		if distance < 0 {
+			distance = -distance
+		}
+		if distance <= statementDateTolerance && distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best
+}
+
+// growthRates calcula (actual - anterior) / |anterior| de cada partida presente en ambos periodos; se omiten
+// las partidas cuyo valor anterior es cero
+func growthRates(current, previous entities.StatementLineItems) map[string]float64 {
+	rates := make(map[string]float64)
+	for name, value := range current {
+		base, ok := previous[name]
+		if !ok || base == 0 {
+			continue
+		}
+		rates[name] = roundRatio((value - base) / math.Abs(base))
+	}
+	if len(rates) == 0 {
+		return nil
+	}
+	return rates
+}
+
+// statementMetrics calcula los márgenes (income), ratios (balance) o el free cash flow (cash_flow) del periodo
+func statementMetrics(statement string, items entities.StatementLineItems) map[string]float64 {
+	metrics := make(map[string]float64)
+	ratio := func(name, numerator, denominator string) {
+		num, okNum := items[numerator]
+		den, okDen := items[denominator]
+		if okNum && okDen && den != 0 {
+			metrics[name] = roundRatio(num / den)
+		}
+	}
+
+	switch statement {
+	case entities.StatementIncome:
+		ratio("gross_margin", "gross_profit", "total_revenue")
+		ratio("operating_margin", "operating_income", "total_revenue")
+		ratio("net_margin", "net_income", "total_revenue")
+		ratio("ebitda_margin", "ebitda", "total_revenue")
+	case entities.StatementBalance:
+		ratio("current_ratio", "total_current_assets", "total_current_liabilities")
+		ratio("debt_to_equity", "short_long_term_debt_total", "total_shareholder_equity")
+	case entities.StatementCashFlow:
+		operating, okOperating := items["operating_cash_flow"]
+		capex, okCapex := items["capital_expenditures"]
+		if okOperating && okCapex {
+			// Alpha Vantage informa el capex en positivo
+			metrics["free_cash_flow"] = operating - math.Abs(capex)
+		}
+	}
+
+	if len(metrics) == 0 {
+		return nil
+	}
+	return metrics
+}
+
+// roundRatio redondea un ratio a 4 decimales (0.1234 = 12.34%)
+func roundRatio(value float64) float64 {
+	return math.Round(value*10000) / 10000
+}
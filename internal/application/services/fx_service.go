@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	domainServices "github.com/MayaCris/stock-info-app/internal/domain/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/usage"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/alphavantage"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// fxRate es un tipo de cambio descargado y cuándo deja de ser fresco
+type fxRate struct {
+	rate      float64
+	expiresAt time.Time
+}
+
+// fxService implements the FXService interface
+type fxService struct {
+	provider domainServices.FXRateProvider // nil: solo se convierte entre la misma moneda
+	ttl      time.Duration
+	logger   logger.Logger
+
+	mu    sync.Mutex
+	rates map[string]fxRate // Por par "EUR/USD"
+}
+
+// NewFXService creates a new exchange rate service. Rates are kept in memory for ttl and the inverse of a
+// cached pair is reused without another provider call
+func NewFXService(provider domainServices.FXRateProvider, ttl time.Duration, logger logger.Logger) interfaces.FXService {
+	return &fxService{
+		provider: provider,
+		ttl:      ttl,
+		logger:   logger,
+		rates:    make(map[string]fxRate),
+	}
+}
+
+// Rate returns how many units of toCurrency one unit of fromCurrency buys
+func (s *fxService) Rate(ctx context.Context, fromCurrency, toCurrency string) (float64, error) {
+	fromCurrency, toCurrency = strings.ToUpper(fromCurrency), strings.ToUpper(toCurrency)
+	if fromCurrency == toCurrency {
+		return 1, nil
+	}
+
+	pair := fromCurrency + "/" + toCurrency
+	if rate, ok := s.cachedRate(pair, toCurrency+"/"+fromCurrency); ok {
+		return rate, nil
+	}
+	if s.provider == nil {
+		return 0, response.ServiceUnavailable("Exchange rates are not available")
+	}
+
+	usage.RecordProviderCall(ctx)
+	rate, err := s.provider.GetRate(ctx, fromCurrency, toCurrency)
+	if err != nil {
+		s.logger.Warn(ctx, "Failed to fetch exchange rate",
+			logger.String("pair", pair),
+			logger.String("error", err.Error()))
+		if errors.Is(err, alphavantage.ErrInvalidSymbol) {
+			return 0, response.BadRequest("unknown currency pair " + pair)
+		}
+		return 0, alphaVantageError(err, pair, "Failed to fetch exchange rate")
+	}
+
+	s.mu.Lock()
+	s.rates[pair] = fxRate{rate: rate, expiresAt: time.Now().Add(s.ttl)}
+	s.mu.Unlock()
+
+	return rate, nil
+}
+
+// Convert returns amount in fromCurrency expressed in toCurrency
+func (s *fxService) Convert(ctx context.Context, amount float64, fromCurrency, toCurrency string) (float64, error) {
+	rate, err := s.Rate(ctx, fromCurrency, toCurrency)
+	if err != nil {
+		return 0, err
+	}
+	return amount * rate, nil
+}
+
+// cachedRate devuelve el tipo fresco del par o, en su defecto, el inverso del par contrario
+func (s *fxService) cachedRate(pair, inverse string) (float64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if cached, ok := s.rates[pair]; ok && now.Before(cached.expiresAt) {
+		return cached.rate, true
+	}
+	if cached, ok := s.rates[inverse]; ok && now.Before(cached.expiresAt) {
+		return 1 / cached.rate, true
+	}
+	return 0, false
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	domainServices "github.com/MayaCris/stock-info-app/internal/domain/services"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+const (
+	// defaultIntegrityHistoryDays es la ventana del historial si no se indica otra
+	defaultIntegrityHistoryDays = 30
+	// maxIntegritySnapshots limita los snapshots devueltos (varias validaciones diarias durante un año)
+	maxIntegritySnapshots = 1000
+)
+
+// integrityHistoryService implements the IntegrityHistoryService interface
+type integrityHistoryService struct {
+	integrityService domainServices.IntegrityValidationService
+	stockRatingRepo  repoInterfaces.StockRatingRepository
+	snapshotRepo     repoInterfaces.IntegritySnapshotRepository
+	logger           logger.Logger
+}
+
+// NewIntegrityHistoryService creates the service that stores and compares the integrity validations
+func NewIntegrityHistoryService(
+	integrityService domainServices.IntegrityValidationService,
+	stockRatingRepo repoInterfaces.StockRatingRepository,
+	snapshotRepo repoInterfaces.IntegritySnapshotRepository,
+	logger logger.Logger,
+) interfaces.IntegrityHistoryService {
+	return &integrityHistoryService{
+		integrityService: integrityService,
+		stockRatingRepo:  stockRatingRepo,
+		snapshotRepo:     snapshotRepo,
+		logger:           logger,
+	}
+}
+
+// RecordSnapshot runs ValidateFullIntegrity and the stock rating quality checks and stores their summary
+func (s *integrityHistoryService) RecordSnapshot(ctx context.Context) (*entities.IntegritySnapshot, error) {
+	startTime := time.Now()
+
+	report, err := s.integrityService.ValidateFullIntegrity(ctx)
+	if err != nil {
+		s.logger.Error(ctx, "Integrity validation failed", err)
+		return nil, err
+	}
+	quality, err := s.stockRatingRepo.ValidateDataIntegrity(ctx)
+	if err != nil {
+		s.logger.Error(ctx, "Stock rating quality checks failed", err)
+		return nil, err
+	}
+
+	snapshot := newIntegritySnapshot(report, quality)
+	snapshot.DurationMs = time.Since(startTime).Milliseconds()
+	if err := s.snapshotRepo.Create(ctx, snapshot); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "Integrity snapshot recorded",
+		logger.String("status", snapshot.Status),
+		logger.Int("total_issues", snapshot.TotalIssues),
+		logger.Int("critical_issues", snapshot.CriticalIssues),
+		logger.Int64("duration_ms", snapshot.DurationMs),
+	)
+	return snapshot, nil
+}
+
+// GetHistory returns the snapshots of the last days and whether data quality improved or degraded in the window
+func (s *integrityHistoryService) GetHistory(ctx context.Context, req *request.IntegrityHistoryRequest) (*response.IntegrityHistoryResponse, error) {
+	days := req.Days
+	if days <= 0 {
+		days = defaultIntegrityHistoryDays
+	}
+	from := time.Now().UTC().AddDate(0, 0, -days)
+
+	snapshots, err := s.snapshotRepo.GetSince(ctx, from, maxIntegritySnapshots)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &response.IntegrityHistoryResponse{
+		Days:      days,
+		From:      from,
+		Trend:     response.IntegrityTrendResponse{Direction: entities.IntegrityTrendInsufficient},
+		Snapshots: snapshots,
+	}
+	if len(snapshots) == 0 {
+		return result, nil
+	}
+
+	first, latest := snapshots[0], snapshots[len(snapshots)-1]
+	result.Latest = latest
+	if len(snapshots) > 1 {
+		result.Trend = response.IntegrityTrendResponse{
+			Direction:            entities.IntegrityTrend(first, latest),
+			TotalIssuesChange:    latest.TotalIssues - first.TotalIssues,
+			CriticalIssuesChange: latest.CriticalIssues - first.CriticalIssues,
+			WarningIssuesChange:  latest.WarningIssues - first.WarningIssues,
+		}
+	}
+	return result, nil
+}
+
+// newIntegritySnapshot resume el informe de integridad y las métricas de calidad de stock_ratings
+func newIntegritySnapshot(report *domainServices.IntegrityReport, quality repoInterfaces.DataIntegrityReport) *entities.IntegritySnapshot {
+	snapshot := &entities.IntegritySnapshot{
+		Status:           string(report.OverallStatus),
+		TotalIssues:      report.TotalIssues,
+		CriticalIssues:   report.CriticalIssues,
+		WarningIssues:    report.WarningIssues,
+		TotalRatings:     quality.TotalRatings,
+		MissingCompany:   quality.MissingCompany,
+		MissingBrokerage: quality.MissingBrokerage,
+		InvalidEventTime: quality.InvalidEventTime,
+		EmptyAction:      quality.EmptyAction,
+		DuplicateRatings: quality.DuplicateCount,
+		OrphanedRatings:  quality.OrphanedRatings,
+	}
+	if report.OrphanReport != nil {
+		snapshot.Orphans = report.OrphanReport.TotalOrphans
+	}
+	if report.ConsistencyReport != nil {
+		snapshot.Inconsistencies = report.ConsistencyReport.TotalInconsistencies
+	}
+	if report.DuplicateReport != nil {
+		snapshot.Duplicates = report.DuplicateReport.TotalDuplicates
+	}
+	if report.BusinessReport != nil {
+		snapshot.BusinessViolations = report.BusinessReport.TotalViolations
+	}
+	return snapshot
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	domainServices "github.com/MayaCris/stock-info-app/internal/domain/services"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// integrityRepairService implements the IntegrityRepairService interface
+type integrityRepairService struct {
+	integrityService domainServices.IntegrityValidationService
+	logger           logger.Logger
+}
+
+// NewIntegrityRepairService creates the service that previews and applies RepairMinorIssues with an approval step
+func NewIntegrityRepairService(integrityService domainServices.IntegrityValidationService, logger logger.Logger) interfaces.IntegrityRepairService {
+	return &integrityRepairService{
+		integrityService: integrityService,
+		logger:           logger,
+	}
+}
+
+// Repair returns the plan of a dry run with its token. A destructive run recomputes the plan and is applied only if
+// the approval token matches it, so nothing is deleted or merged that the administrator did not review
+func (s *integrityRepairService) Repair(ctx context.Context, req *request.IntegrityRepairRequest) (*response.IntegrityRepairResponse, error) {
+	dryRun := req.IsDryRun()
+	if !dryRun && req.ApprovalToken == "" {
+		return nil, response.BadRequest("approval_token is required: run a dry run and approve its plan_token first")
+	}
+
+	preview, err := s.integrityService.RepairMinorIssues(ctx, true)
+	if err != nil {
+		s.logger.Error(ctx, "Integrity repair preview failed", err)
+		return nil, err
+	}
+	planToken := preview.PlanToken()
+
+	if dryRun {
+		result := newIntegrityRepairResponse(preview)
+		result.PlanToken = planToken
+		return result, nil
+	}
+
+	if req.ApprovalToken != planToken {
+		s.logger.Warn(ctx, "Integrity repair rejected: plan changed since the dry run",
+			logger.Int("planned_actions", len(preview.Actions)),
+		)
+		return nil, response.Conflict("the repair plan changed since the dry run; review the new plan and approve its plan_token")
+	}
+
+	report, err := s.integrityService.RepairMinorIssues(ctx, false)
+	if err != nil {
+		s.logger.Error(ctx, "Integrity repair failed", err)
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "Integrity repair applied",
+		logger.Int("total_repairs", report.TotalRepairs),
+		logger.Int("unrepairable_issues", len(report.UnrepairableIssues)),
+		logger.String("plan_token", planToken),
+	)
+	return newIntegrityRepairResponse(report), nil
+}
+
+// newIntegrityRepairResponse convierte el informe de reparación del dominio
+func newIntegrityRepairResponse(report *domainServices.RepairReport) *response.IntegrityRepairResponse {
+	result := &response.IntegrityRepairResponse{
+		DryRun:               report.DryRun,
+		Status:               string(report.Status),
+		RepairedOrphans:      report.RepairedOrphans,
+		RemovedDuplicates:    report.RemovedDuplicates,
+		FixedInconsistencies: report.FixedInconsistencies,
+		TotalRepairs:         report.TotalRepairs,
+		Actions:              make([]response.IntegrityRepairActionResponse, 0, len(report.Actions)),
+		UnrepairableIssues:   make([]response.IntegrityRepairIssueResponse, 0, len(report.UnrepairableIssues)),
+	}
+	for _, action := range report.Actions {
+		result.Actions = append(result.Actions, response.IntegrityRepairActionResponse{
+			Action:          action.Action,
+			Entity:          action.Entity,
+			ID:              action.ID,
+			TargetID:        action.TargetID,
+			Issue:           action.Issue,
+			Changes:         action.Changes,
+			RelinkedRatings: action.RelinkedRatings,
+		})
+	}
+	for _, issue := range report.UnrepairableIssues {
+		result.UnrepairableIssues = append(result.UnrepairableIssues, response.IntegrityRepairIssueResponse{
+			Type:        issue.Type,
+			ID:          issue.ID,
+			Description: issue.Description,
+			Reason:      issue.Reason,
+		})
+	}
+	return result
+}
@@ -4,12 +4,15 @@ import (
 	"context"
 
 	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/google/uuid"
 )
 
 // MarketDataService defines the interface for market data operations
 type MarketDataService interface {
 	// Real-time data
 	GetRealTimeQuote(ctx context.Context, symbol string) (*response.MarketDataResponse, error)
+	GetIntradayQuotes(ctx context.Context, symbol string, minutes int) (*response.IntradayQuotesResponse, error)
 
 	// Company information
 	GetCompanyProfile(ctx context.Context, symbol string) (*response.CompanyProfileResponse, error)
@@ -22,9 +25,13 @@ type MarketDataService interface {
 
 	// Market overview
 	GetMarketOverview(ctx context.Context) (*response.MarketOverviewResponse, error)
+	GetMarketMovers(ctx context.Context, moverType string, limit int) (*response.MarketMoversResponse, error)
 
 	// Bulk operations
-	RefreshMarketData(ctx context.Context, symbols []string) error
+	RefreshMarketData(ctx context.Context, options MarketDataRefreshOptions) (*response.MarketDataRefreshResponse, error)
+
+	// Re-procesa una respuesta archivada del proveedor sin volver a llamarlo
+	ReplayPayload(ctx context.Context, payload *entities.ProviderPayload) error
 
 	// Alpha Vantage specific methods
 	GetHistoricalData(ctx context.Context, symbol, period, outputSize string) (*response.HistoricalDataResponse, error)
@@ -33,3 +40,17 @@ type MarketDataService interface {
 	GetEarningsData(ctx context.Context, symbol string) (*response.EarningsDataResponse, error)
 	AlphaVantageHealthCheck(ctx context.Context) (bool, error)
 }
+
+// MarketDataRefreshOptions configura un refresco masivo de cotizaciones
+type MarketDataRefreshOptions struct {
+	Symbols  []string // Vacío refresca todas las companies activas
+	Trending int      // Sin símbolos, refresca solo los N más vistos de las últimas 24 horas
+
+	// Tenant que encarga el refresco (opcional): cada símbolo obtenido del proveedor consume la cuota
+	// diaria de refrescos de su plan, y el refresco se detiene al agotarla
+	TenantID uuid.UUID
+	Plan     string
+
+	// OnProgress recibe el progreso parcial cada pocos símbolos procesados (opcional)
+	OnProgress func(progress response.MarketDataRefreshResponse)
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
 	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/tenancy"
 	"github.com/google/uuid"
 )
 
@@ -20,14 +22,33 @@ type CompanyService interface {
 	ListCompanies(ctx context.Context, filter *request.CompanyFilterRequest, pagination *response.PaginationRequest) (*response.PaginatedResponse[*response.CompanyListResponse], error)
 	ListActiveCompanies(ctx context.Context, pagination *response.PaginationRequest) (*response.PaginatedResponse[*response.CompanyListResponse], error)
 
+	// Soft-delete recovery operations
+	RestoreCompany(ctx context.Context, id uuid.UUID) (*response.CompanyResponse, error)
+	ListDeletedCompanies(ctx context.Context, pagination *response.PaginationRequest) (*response.PaginatedResponse[*response.DeletedCompanyResponse], error)
+
 	// Business operations
 	ActivateCompany(ctx context.Context, id uuid.UUID) error
 	DeactivateCompany(ctx context.Context, id uuid.UUID) error
 	UpdateMarketCap(ctx context.Context, ticker string, marketCap float64) error
+	RemapTicker(ctx context.Context, id uuid.UUID, req *request.RemapTickerRequest) (*response.CompanyResponse, error)
+	MergeCompanies(ctx context.Context, duplicateID uuid.UUID, req *request.MergeCompaniesRequest) (*response.CompanyMergeResponse, error)
 
 	// Search operations
 	SearchCompaniesByName(ctx context.Context, name string, pagination *response.PaginationRequest) (*response.PaginatedResponse[*response.CompanyListResponse], error)
 	GetCompaniesBySector(ctx context.Context, sector string, pagination *response.PaginationRequest) (*response.PaginatedResponse[*response.CompanyListResponse], error)
+	SuggestCompanies(ctx context.Context, prefix string, limit int) ([]*response.CompanySuggestionResponse, error)
+}
+
+// CompanyLogoService defines the interface for the company logo proxy
+type CompanyLogoService interface {
+	// GetLogo returns the logo resized to fit in size pixels (0 uses the default size)
+	GetLogo(ctx context.Context, ticker string, size int) (*response.CompanyLogoResponse, error)
+}
+
+// CompanyOverviewService defines the interface for the company detail aggregate
+type CompanyOverviewService interface {
+	// GetOverview gathers profile, quote, financials, recent ratings and news of a company in one call
+	GetOverview(ctx context.Context, ticker string) (*response.CompanyOverviewResponse, error)
 }
 
 // BrokerageService defines the interface for brokerage business logic
@@ -55,18 +76,35 @@ type StockRatingService interface {
 	CreateStockRating(ctx context.Context, req *request.CreateStockRatingRequest) (*response.StockRatingResponse, error)
 	GetStockRatingByID(ctx context.Context, id uuid.UUID) (*response.StockRatingResponse, error)
 	DeleteStockRating(ctx context.Context, id uuid.UUID) error
+	// Soft-delete recovery operations
+	RestoreStockRating(ctx context.Context, id uuid.UUID) (*response.StockRatingResponse, error)
+	ListDeletedStockRatings(ctx context.Context, pagination *response.PaginationRequest) (*response.PaginatedResponse[*response.DeletedStockRatingResponse], error)
 	// List operations
-	ListStockRatings(ctx context.Context, filter *request.StockRatingFilterRequest, pagination *response.PaginationRequest) (*response.PaginatedResponse[*response.StockRatingListResponse], error)
-	GetRatingsByCompany(ctx context.Context, companyID uuid.UUID, pagination *response.PaginationRequest) (*response.PaginatedResponse[*response.StockRatingListResponse], error)
-	GetRatingsByTicker(ctx context.Context, ticker string, pagination *response.PaginationRequest) (*response.PaginatedResponse[*response.StockRatingListResponse], error)
-	GetRatingsByBrokerage(ctx context.Context, brokerageID uuid.UUID, pagination *response.PaginationRequest) (*response.PaginatedResponse[*response.StockRatingListResponse], error)
+	ListStockRatings(ctx context.Context, filter *request.StockRatingFilterRequest, includes request.RatingIncludes, pagination *response.PaginationRequest) (*response.PaginatedResponse[*response.StockRatingListResponse], error)
+	// SearchRatings combines brokerage, action type, rating range, target range and date filters in one query
+	SearchRatings(ctx context.Context, req *request.RatingSearchRequest, includes request.RatingIncludes, pagination *response.PaginationRequest) (*response.PaginatedResponse[*response.StockRatingListResponse], error)
+	GetRatingsByCompany(ctx context.Context, companyID uuid.UUID, includes request.RatingIncludes, pagination *response.PaginationRequest) (*response.PaginatedResponse[*response.StockRatingListResponse], error)
+	GetRatingsByTicker(ctx context.Context, ticker string, includes request.RatingIncludes, pagination *response.PaginationRequest) (*response.PaginatedResponse[*response.StockRatingListResponse], error)
+	GetRatingsByBrokerage(ctx context.Context, brokerageID uuid.UUID, includes request.RatingIncludes, pagination *response.PaginationRequest) (*response.PaginatedResponse[*response.StockRatingListResponse], error)
+	// GetRatingsAsOf returns the latest rating of each brokerage for the company up to date (YYYY-MM-DD, inclusive,
+	// in the time zone of the context)
+	GetRatingsAsOf(ctx context.Context, companyID uuid.UUID, date string, includes request.RatingIncludes) (*response.RatingAsOfResponse, error)
 
 	// Analytics operations
 	GetRecentRatings(ctx context.Context, limit int) ([]*response.StockRatingListResponse, error)
+	// Today, this week or this month in the time zone of the context (see timezone.WithLocation)
+	GetRatingsForPeriod(ctx context.Context, period string) ([]*response.StockRatingListResponse, error)
 	GetRatingsByDateRange(ctx context.Context, startDate, endDate string, pagination *response.PaginationRequest) (*response.PaginatedResponse[*response.StockRatingListResponse], error)
 	GetRatingStatsByCompany(ctx context.Context, companyID uuid.UUID) (map[string]interface{}, error)
 }
 
+// RatingPollService defines the long-poll of new ratings for clients that can't use WebSockets or SSE
+type RatingPollService interface {
+	// Poll returns the ratings created after since_id, waiting up to the request wait for a rating.created event
+	// when there are none yet
+	Poll(ctx context.Context, req *request.RatingPollRequest, includes request.RatingIncludes) (*response.RatingPollResponse, error)
+}
+
 // AnalysisService defines the interface for analysis and recommendation business logic
 type AnalysisService interface {
 	// Company analysis
@@ -76,17 +114,253 @@ type AnalysisService interface {
 	// Market analysis
 	GetMarketOverview(ctx context.Context) (map[string]interface{}, error)
 	GetSectorAnalysis(ctx context.Context, sector string) (map[string]interface{}, error)
-	GetTopRatedCompanies(ctx context.Context, limit int) ([]*response.CompanyListResponse, error)
+	GetMarketBreadth(ctx context.Context, req *request.MarketBreadthRequest) (*response.MarketBreadthResponse, error)
+	GetMarketCapStats(ctx context.Context, req *request.MarketCapStatsRequest) (*response.MarketCapStatsResponse, error)
+	GetTopRatedCompanies(ctx context.Context, limit int, tags string) ([]*response.CompanyListResponse, error)
+	CompareCompanies(ctx context.Context, req *request.CompareCompaniesRequest) (*response.CompanyComparisonResponse, error)
+	GetCorrelationMatrix(ctx context.Context, req *request.CorrelationRequest) (*response.CorrelationMatrixResponse, error)
+	GetPortfolioRisk(ctx context.Context, req *request.PortfolioRiskRequest) (*response.PortfolioRiskResponse, error)
 
 	// Trend analysis
-	GetRatingTrends(ctx context.Context, period string) (map[string]interface{}, error)
+	GetRatingTrends(ctx context.Context, req *request.RatingTrendsRequest) (map[string]interface{}, error)
+	GetSectorRotation(ctx context.Context, req *request.SectorRotationRequest) (*response.SectorRotationResponse, error)
 	GetBrokerageActivity(ctx context.Context, period string) (map[string]interface{}, error)
 
 	// Recommendations
-	GenerateRecommendation(ctx context.Context, companyID uuid.UUID) (string, error)
+	GenerateRecommendation(ctx context.Context, companyID uuid.UUID) (*response.RecommendationResponse, error)
 	GetRecommendationsByRating(ctx context.Context, rating string, limit int) ([]*response.CompanyListResponse, error)
 }
 
+// PeerService defines the interface for competitor discovery
+type PeerService interface {
+	GetPeers(ctx context.Context, ticker string) (*response.CompanyPeersResponse, error)
+}
+
+// CompanyRelationshipService defines the interface for the supplier, competitor and subsidiary graph of companies
+type CompanyRelationshipService interface {
+	ListRelationships(ctx context.Context, ref string, req *request.CompanyRelationshipsRequest) (*response.CompanyRelationshipsResponse, error)
+	CreateRelationship(ctx context.Context, ref string, req *request.CreateCompanyRelationshipRequest) (*response.CompanyRelationshipResponse, error)
+	UpdateRelationship(ctx context.Context, ref string, id uuid.UUID, req *request.UpdateCompanyRelationshipRequest) (*response.CompanyRelationshipResponse, error)
+	DeleteRelationship(ctx context.Context, ref string, id uuid.UUID) error
+	GetGraph(ctx context.Context, ref string, req *request.CompanyGraphRequest) (*response.CompanyGraphResponse, error)
+}
+
+// TagService defines the interface for thematic tags and their assignment to companies
+type TagService interface {
+	ListTags(ctx context.Context) ([]response.TagResponse, error)
+	GetCompanyTags(ctx context.Context, ref string) (*response.CompanyTagsResponse, error)
+	CreateTag(ctx context.Context, req *request.CreateTagRequest) (*response.TagResponse, error)
+	UpdateTag(ctx context.Context, slug string, req *request.UpdateTagRequest) (*response.TagResponse, error)
+	DeleteTag(ctx context.Context, slug string) error
+	TagCompany(ctx context.Context, slug, ref string) (*response.CompanyTagsResponse, error)
+	UntagCompany(ctx context.Context, slug, ref string) error
+}
+
+// NoteService defines the interface for the private notes of the authenticated user on companies and ratings
+type NoteService interface {
+	ListNotes(ctx context.Context, filter *request.NoteFilterRequest, pagination *response.PaginationRequest) (*response.PaginatedResponse[*response.NoteResponse], error)
+	GetNote(ctx context.Context, id uuid.UUID) (*response.NoteResponse, error)
+	CreateNote(ctx context.Context, req *request.CreateNoteRequest) (*response.NoteResponse, error)
+	UpdateNote(ctx context.Context, id uuid.UUID, req *request.UpdateNoteRequest) (*response.NoteResponse, error)
+	DeleteNote(ctx context.Context, id uuid.UUID) error
+
+	// GetCompanyNotes returns the notes of the user on a company and its ratings, for ?include=notes
+	GetCompanyNotes(ctx context.Context, companyID uuid.UUID) ([]response.NoteResponse, error)
+}
+
+// FinancialStatementService defines the interface for reported financial statements
+type FinancialStatementService interface {
+	GetStatements(ctx context.Context, ticker string, req *request.FinancialStatementRequest) (*response.FinancialStatementsResponse, error)
+}
+
+// FXService defines the interface for currency conversion at the current exchange rates
+type FXService interface {
+	Rate(ctx context.Context, fromCurrency, toCurrency string) (float64, error)
+	Convert(ctx context.Context, amount float64, fromCurrency, toCurrency string) (float64, error)
+}
+
+// BenchmarkService defines the interface for benchmarks (market indices) and their ingestion from the provider
+type BenchmarkService interface {
+	ListBenchmarks(ctx context.Context) ([]response.BenchmarkResponse, error)
+	GetValues(ctx context.Context, symbol string, req *request.BenchmarkValuesRequest) (*response.BenchmarkValuesResponse, error)
+	GetConstituents(ctx context.Context, symbol string, req *request.BenchmarkConstituentsRequest) (*response.BenchmarkConstituentsResponse, error)
+	Sync(ctx context.Context, symbol string, req *request.BenchmarkSyncRequest) (*response.BenchmarkSyncResponse, error)
+	CreateCustom(ctx context.Context, req *request.CreateCustomBenchmarkRequest) (*response.BenchmarkSyncResponse, error)
+	DeleteCustom(ctx context.Context, symbol string) error
+	RefreshCustom(ctx context.Context) (*response.BenchmarkRefreshResponse, error)
+}
+
+// BacktestService defines the interface for simulating rating-following strategies
+type BacktestService interface {
+	RunBacktest(ctx context.Context, req *request.BacktestRequest) (*response.BacktestResponse, error)
+}
+
+// AnomalyService defines the interface for detecting abnormal rating activity and trading volume
+type AnomalyService interface {
+	DetectAnomalies(ctx context.Context, days int) (*response.AnomalyDetectionResponse, error)
+	GetAnomalies(ctx context.Context, req *request.AnomalyRequest) (*response.AnomaliesResponse, error)
+}
+
+// MarketStatusService defines the interface for the trading calendar of the exchanges
+type MarketStatusService interface {
+	GetMarketStatus(ctx context.Context, exchange string) (*response.MarketStatusResponse, error)
+}
+
+// DashboardService defines the interface for the home screen summary
+type DashboardService interface {
+	GetDashboard(ctx context.Context, req *request.DashboardRequest) (*response.DashboardResponse, error)
+}
+
+// TrendingService defines the interface for the most requested symbols
+type TrendingService interface {
+	GetTrending(ctx context.Context, req *request.TrendingRequest) (*response.TrendingSymbolsResponse, error)
+}
+
+// SearchService defines the interface for full-text search across companies and news
+type SearchService interface {
+	Search(ctx context.Context, req *request.SearchRequest) (*response.SearchResponse, error)
+}
+
+// PayloadArchiveService defines the interface for the archive of raw provider responses
+type PayloadArchiveService interface {
+	ListPayloads(ctx context.Context, filter *request.PayloadArchiveFilterRequest, limit, offset int) ([]*response.ProviderPayloadResponse, int64, error)
+	GetPayload(ctx context.Context, id uuid.UUID) (*response.ProviderPayloadResponse, error)
+	ReplayPayloads(ctx context.Context, req *request.ReplayPayloadsRequest) (*response.ReplayPayloadsResponse, error)
+}
+
+// BackupOptions configures a database snapshot
+type BackupOptions struct {
+	Tables []string // Vacío exporta todas las tablas configuradas
+
+	// OnProgress recibe el snapshot parcial después de cada tabla exportada (opcional)
+	OnProgress func(progress response.BackupResponse)
+}
+
+// BackupService defines the interface for the database snapshots kept in the backup bucket
+type BackupService interface {
+	// ValidateTables rejects tables that are not part of the configured backup set
+	ValidateTables(tables []string) error
+	CreateBackup(ctx context.Context, options BackupOptions) (*response.BackupResponse, error)
+	ListBackups(ctx context.Context) ([]*response.BackupResponse, error)
+	GetBackup(ctx context.Context, id string) (*response.BackupResponse, error)
+	// RestoreDryRun downloads the snapshot and checks it against the current database without writing anything
+	RestoreDryRun(ctx context.Context, id string) (*response.RestoreDryRunResponse, error)
+}
+
+// ParquetExportOptions configures a Parquet export of historical prices and ratings
+type ParquetExportOptions struct {
+	Symbols  []string // Vacío exporta todas las companies activas
+	Datasets []string // historical_prices y/o ratings; vacío exporta ambos
+	FromYear int      // 0 usa los últimos cinco años
+	ToYear   int      // 0 usa el año en curso
+
+	// OnProgress recibe la exportación parcial después de cada símbolo (opcional)
+	OnProgress func(progress response.ParquetExportResponse)
+}
+
+// ParquetExportService defines the interface for the Parquet exports (one file per dataset, symbol and year)
+type ParquetExportService interface {
+	// ValidateOptions rejects unknown datasets and invalid year ranges
+	ValidateOptions(options ParquetExportOptions) error
+	Export(ctx context.Context, options ParquetExportOptions) (*response.ParquetExportResponse, error)
+}
+
+// SeedOptions configures the synthetic data written by the seed command
+type SeedOptions struct {
+	Companies         int   // Companies (las primeras son las del universo del proveedor mock: AAPL, MSFT...)
+	Brokerages        int   // Brokerages que emiten los ratings
+	RatingsPerCompany int   // Ratings por company, repartidos en la ventana de precios
+	PriceDays         int   // Días naturales de precios históricos hasta hoy (0 no genera precios)
+	Seed              int64 // Semilla del generador: la misma semilla el mismo día produce los mismos datos
+}
+
+// SeedService defines the interface of the synthetic data generator for local environments
+type SeedService interface {
+	// ValidateOptions rejects volumes out of range
+	ValidateOptions(options SeedOptions) error
+	// Seed writes companies, brokerages, ratings, quotes and historical prices through the repositories; existing
+	// companies, brokerages, ratings and prices are kept
+	Seed(ctx context.Context, options SeedOptions) (*response.SeedResponse, error)
+}
+
+// TenantService defines the interface for the tenants and their API keys
+type TenantService interface {
+	// Tenant administration
+	CreateTenant(ctx context.Context, req *request.CreateTenantRequest) (*response.TenantResponse, error)
+	GetTenant(ctx context.Context, id uuid.UUID) (*response.TenantResponse, error)
+	ListTenants(ctx context.Context) ([]*response.TenantResponse, error)
+	SetTenantActive(ctx context.Context, id uuid.UUID, active bool) (*response.TenantResponse, error)
+	SetTenantPlan(ctx context.Context, id uuid.UUID, plan string) (*response.TenantResponse, error)
+
+	// API keys of the tenant of the context (tenancy.WithTenant for administrative calls)
+	CreateAPIKey(ctx context.Context, req *request.CreateAPIKeyRequest) (*response.APIKeyCreatedResponse, error)
+	ListAPIKeys(ctx context.Context) ([]*response.APIKeyResponse, error)
+	RevokeAPIKey(ctx context.Context, id uuid.UUID) error
+	SetAPIKeyTimezone(ctx context.Context, id uuid.UUID, req *request.SetAPIKeyTimezoneRequest) (*response.APIKeyResponse, error)
+
+	// Authentication: resolve the principal of an API key or a JWT; invalid credentials return 401 errors
+	AuthenticateAPIKey(ctx context.Context, key string) (*tenancy.Principal, error)
+	AuthenticateToken(ctx context.Context, token string) (*tenancy.Principal, error)
+	CurrentTenant(ctx context.Context) (*response.CurrentTenantResponse, error)
+}
+
+// UsageService defines the interface for the API usage of the credentials of the tenants
+type UsageService interface {
+	// Flush saves the usage counters of the cache in the usage table and purges the closed hours
+	Flush(ctx context.Context) (*response.UsageFlushResponse, error)
+	// GetMyUsage returns the usage of the tenant of the context per credential and day
+	GetMyUsage(ctx context.Context, req *request.UsageRequest) (*response.UsageResponse, error)
+	// GetUsageRollup returns the usage of every tenant
+	GetUsageRollup(ctx context.Context, req *request.UsageRequest) (*response.UsageRollupResponse, error)
+}
+
+// RatingTaxonomyService defines the interface for the normalization of the free-text brokerage ratings
+type RatingTaxonomyService interface {
+	// Load reads the mapping table; the ratings saved from then on are normalized with it
+	Load(ctx context.Context) error
+	GetTaxonomy(ctx context.Context) (*response.RatingTaxonomyResponse, error)
+	// SaveMapping and DeleteMapping change the table and re-normalize the stored ratings
+	SaveMapping(ctx context.Context, req *request.SaveRatingMappingRequest) (*response.RatingNormalizationResponse, error)
+	DeleteMapping(ctx context.Context, rating string) (*response.RatingNormalizationResponse, error)
+	// Apply reloads the table and re-normalizes the stored ratings (e.g. after editing it from another instance)
+	Apply(ctx context.Context) (*response.RatingNormalizationResponse, error)
+}
+
+// RatingProcessingOptions configures a run of the rating processing job
+type RatingProcessingOptions struct {
+	BatchSize   int // Ratings leídos y marcados como procesados por lote
+	Concurrency int // Ratings de un lote procesados a la vez
+	MaxBatches  int // 0 procesa lotes hasta vaciar el backlog
+
+	// OnProgress recibe el resultado parcial después de cada lote (opcional)
+	OnProgress func(progress response.RatingProcessingResponse)
+}
+
+// RatingProcessingService defines the interface of the enrichment of the ratings stored without processing
+type RatingProcessingService interface {
+	// ValidateOptions rejects negative sizes
+	ValidateOptions(options RatingProcessingOptions) error
+	// Process normalizes the unprocessed ratings batch by batch, marks them processed and publishes a
+	// rating.processed event for each one; ratings that fail stay in the backlog. A backlog over the threshold
+	// at the start or the end of the run publishes rating.backlog_exceeded
+	Process(ctx context.Context, options RatingProcessingOptions) (*response.RatingProcessingResponse, error)
+	// GetStatus returns the backlog per source, the age of its oldest rating and the recent throughput
+	GetStatus(ctx context.Context) (*response.ProcessingStatusResponse, error)
+}
+
+// IntegrityHistoryService defines the interface for the history of the scheduled integrity validations
+type IntegrityHistoryService interface {
+	// RecordSnapshot runs a full integrity validation and stores its summary
+	RecordSnapshot(ctx context.Context) (*entities.IntegritySnapshot, error)
+	GetHistory(ctx context.Context, req *request.IntegrityHistoryRequest) (*response.IntegrityHistoryResponse, error)
+}
+
+// IntegrityRepairService defines the interface for the on-demand repair of minor integrity issues
+type IntegrityRepairService interface {
+	// Repair previews the repair in dry run; otherwise applies it if the approval token matches the current plan
+	Repair(ctx context.Context, req *request.IntegrityRepairRequest) (*response.IntegrityRepairResponse, error)
+}
+
 // AdminService defines the interface for administrative operations
 type AdminService interface {
 	// Database operations
@@ -0,0 +1,18 @@
+package services
+
+import (
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+)
+
+// parseSort valida el parámetro sort contra los campos permitidos del listado; un campo o dirección desconocidos
+// se devuelven como error de validación del campo sort
+func parseSort(fields repoInterfaces.SortableFields, value string) ([]repoInterfaces.SortField, error) {
+	sort, err := fields.Parse(value)
+	if err != nil {
+		return nil, response.ValidationFailedWithFields([]response.ValidationError{
+			{Field: "sort", Tag: "oneof", Message: err.Error(), Value: value},
+		})
+	}
+	return sort, nil
+}
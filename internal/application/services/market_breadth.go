@@ -0,0 +1,222 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+const (
+	defaultBreadthDays = 30
+
+	// Medias móviles de la amplitud, en sesiones
+	breadthShortMA = 50
+	breadthLongMA  = 200
+
+	// breadthLookbackDays son los días naturales previos al rango que cubren las 200 sesiones de la media larga
+	breadthLookbackDays = 300
+)
+
+// GetMarketBreadth computes the daily breadth of the active companies over the last days calendar days from the
+// stored daily prices: advancers vs decliners against the previous session and the share above their 50 and
+// 200-session moving averages. With market data, the latest stored quotes give today's advancers and decliners.
+// Results are cached
+func (s *analysisService) GetMarketBreadth(ctx context.Context, req *request.MarketBreadthRequest) (*response.MarketBreadthResponse, error) {
+	if s.historicalDataRepo == nil {
+		return nil, response.ServiceUnavailable("Historical prices are not available")
+	}
+
+	days := req.Days
+	if days <= 0 {
+		days = defaultBreadthDays
+	}
+
+	to := time.Now().UTC().Truncate(24 * time.Hour)
+	from := to.AddDate(0, 0, 1-days)
+
+	cacheKey := fmt.Sprintf("analysis:breadth:%d:%s", days, to.Format(ratingDateLayout))
+	var cached response.MarketBreadthResponse
+	if s.getCachedAnalysis(ctx, cacheKey, &cached) {
+		return &cached, nil
+	}
+
+	companies, err := s.companyRepo.GetAllActive(ctx)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get active companies for market breadth", err)
+		return nil, response.InternalServerError("Failed to compute market breadth")
+	}
+	tracked := make(map[string]bool, len(companies))
+	for _, company := range companies {
+		tracked[strings.ToUpper(company.Ticker)] = true
+	}
+
+	prices, err := s.historicalDataRepo.GetByDateRange(ctx, from.AddDate(0, 0, -breadthLookbackDays), to)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get price history for market breadth", err,
+			logger.Int("days", days))
+		return nil, response.InternalServerError("Failed to compute market breadth")
+	}
+
+	// Solo barras diarias de companies activas
+	sessions := make(map[string][]*entities.HistoricalData)
+	for _, price := range prices {
+		symbol := strings.ToUpper(price.Symbol)
+		if !tracked[symbol] || (price.TimeFrame != "" && price.TimeFrame != "1D") {
+			continue
+		}
+		sessions[symbol] = append(sessions[symbol], price)
+	}
+
+	breadth := &response.MarketBreadthResponse{
+		Days:        days,
+		From:        from.Format(ratingDateLayout),
+		To:          to.Format(ratingDateLayout),
+		Tracked:     len(companies),
+		Series:      MarketBreadthSeries(sessions, from),
+		GeneratedAt: time.Now(),
+	}
+
+	if s.marketDataRepo != nil && len(companies) > 0 {
+		ids := make([]uuid.UUID, len(companies))
+		for i, company := range companies {
+			ids[i] = company.ID
+		}
+		quotes, err := s.marketDataRepo.GetByCompanyIDs(ctx, ids)
+		if err != nil {
+			// Las cotizaciones son un extra: la serie de precios se devuelve igual
+			s.logger.Warn(ctx, "Failed to get latest quotes for market breadth",
+				logger.String("error", err.Error()))
+		} else {
+			breadth.Latest = quoteBreadth(quotes)
+		}
+	}
+
+	s.setCachedAnalysis(ctx, cacheKey, breadth)
+
+	return breadth, nil
+}
+
+// MarketBreadthSeries aggregates the daily sessions of each symbol into the breadth of every session since from.
+// Sessions before from only feed the previous close and the moving averages
+func MarketBreadthSeries(sessions map[string][]*entities.HistoricalData, from time.Time) []response.MarketBreadthDay {
+	type dayCounts struct {
+		day                 response.MarketBreadthDay
+		withShort, withLong int
+	}
+	byDate := make(map[string]*dayCounts)
+
+	for _, symbolSessions := range sessions {
+		sorted := make([]*entities.HistoricalData, len(symbolSessions))
+		copy(sorted, symbolSessions)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i].Date.Before(sorted[j].Date) })
+
+		closes := make([]float64, 0, len(sorted))
+		for i, session := range sorted {
+			price := closePrice(session)
+			closes = append(closes, price)
+			if session.Date.Before(from) {
+				continue
+			}
+
+			date := session.Date.Format(ratingDateLayout)
+			counts, ok := byDate[date]
+			if !ok {
+				counts = &dayCounts{day: response.MarketBreadthDay{Date: date}}
+				byDate[date] = counts
+			}
+			counts.day.Symbols++
+
+			// Sin sesión anterior no se sabe si sube o baja
+			if i > 0 {
+				switch previous := closes[i-1]; {
+				case price > previous:
+					counts.day.Advancers++
+				case price < previous:
+					counts.day.Decliners++
+				default:
+					counts.day.Unchanged++
+				}
+			}
+			if average := simpleMovingAverage(closes, breadthShortMA); average != nil {
+				counts.withShort++
+				if price > *average {
+					counts.day.AboveMA50++
+				}
+			}
+			if average := simpleMovingAverage(closes, breadthLongMA); average != nil {
+				counts.withLong++
+				if price > *average {
+					counts.day.AboveMA200++
+				}
+			}
+		}
+	}
+
+	dates := make([]string, 0, len(byDate))
+	for date := range byDate {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+
+	series := make([]response.MarketBreadthDay, 0, len(dates))
+	line := 0
+	for _, date := range dates {
+		counts := byDate[date]
+		day := counts.day
+		day.NetAdvances = day.Advancers - day.Decliners
+		line += day.NetAdvances
+		day.AdvanceDeclineLine = line
+		day.AdvanceDeclineRatio = advanceDeclineRatio(day.Advancers, day.Decliners)
+		day.PercentAboveMA50 = percentOf(day.AboveMA50, counts.withShort)
+		day.PercentAboveMA200 = percentOf(day.AboveMA200, counts.withLong)
+		series = append(series, day)
+	}
+	return series
+}
+
+// quoteBreadth cuenta subidas y bajadas en la última cotización de cada company
+func quoteBreadth(quotes []*entities.MarketData) *response.MarketBreadthSnapshot {
+	snapshot := &response.MarketBreadthSnapshot{Quotes: len(quotes)}
+	for _, quote := range quotes {
+		switch {
+		case quote.PriceChange > 0:
+			snapshot.Advancers++
+		case quote.PriceChange < 0:
+			snapshot.Decliners++
+		default:
+			snapshot.Unchanged++
+		}
+		if snapshot.AsOf == nil || quote.MarketTimestamp.After(*snapshot.AsOf) {
+			asOf := quote.MarketTimestamp
+			snapshot.AsOf = &asOf
+		}
+	}
+	snapshot.AdvanceDeclineRatio = advanceDeclineRatio(snapshot.Advancers, snapshot.Decliners)
+	return snapshot
+}
+
+// advanceDeclineRatio devuelve advancers / decliners, o nil sin decliners
+func advanceDeclineRatio(advancers, decliners int) *float64 {
+	if decliners == 0 {
+		return nil
+	}
+	return floatPtr(roundRatio(float64(advancers) / float64(decliners)))
+}
+
+// percentOf devuelve part sobre total en porcentaje con dos decimales, o nil si total es cero
+func percentOf(part, total int) *float64 {
+	if total == 0 {
+		return nil
+	}
+	return floatPtr(math.Round(float64(part)/float64(total)*10000) / 100)
+}
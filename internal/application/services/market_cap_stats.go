@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// defaultMarketCapCurrency es la moneda del resultado y la que se asume en las companies sin moneda registrada
+const defaultMarketCapCurrency = "USD"
+
+// unknownMarketCapGroup agrupa las companies sin exchange registrado
+const unknownMarketCapGroup = "UNKNOWN"
+
+// GetMarketCapStats returns the market cap statistics of the active companies converted to one currency (USD by
+// default) at the current exchange rates, overall and optionally per exchange or listing currency. Companies in a
+// currency whose rate cannot be obtained are left out with a warning. Results are cached
+func (s *analysisService) GetMarketCapStats(ctx context.Context, req *request.MarketCapStatsRequest) (*response.MarketCapStatsResponse, error) {
+	target := strings.ToUpper(req.Currency)
+	if target == "" {
+		target = defaultMarketCapCurrency
+	}
+
+	cacheKey := fmt.Sprintf("analysis:market_cap:%s:%s", req.GroupBy, target)
+	var cached response.MarketCapStatsResponse
+	if s.getCachedAnalysis(ctx, cacheKey, &cached) {
+		return &cached, nil
+	}
+
+	buckets, err := s.companyRepo.GetMarketCapStatsByGroup(ctx)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get market cap stats", err)
+		return nil, response.InternalServerError("Failed to compute market cap stats")
+	}
+
+	stats := &response.MarketCapStatsResponse{
+		Currency:    target,
+		GroupBy:     req.GroupBy,
+		Rates:       make(map[string]float64),
+		GeneratedAt: time.Now(),
+	}
+	skipped := make(map[string]bool)
+	groups := make(map[string]*response.MarketCapGroup)
+	for _, bucket := range buckets {
+		currency := marketCapCurrency(bucket)
+		if skipped[currency] {
+			continue
+		}
+		rate, ok := stats.Rates[currency]
+		if !ok {
+			rate, err = s.marketCapRate(ctx, currency, target)
+			if err != nil {
+				skipped[currency] = true
+				stats.Warnings = append(stats.Warnings, fmt.Sprintf("companies listed in %s are left out: %s", currency, err.Error()))
+				continue
+			}
+			stats.Rates[currency] = rate
+		}
+
+		addMarketCapBucket(&stats.Total, bucket, currency, rate)
+		if req.GroupBy == "" {
+			continue
+		}
+		key := currency
+		if req.GroupBy == "exchange" {
+			key = strings.ToUpper(bucket.Exchange)
+			if key == "" {
+				key = unknownMarketCapGroup
+			}
+		}
+		group, ok := groups[key]
+		if !ok {
+			group = &response.MarketCapGroup{Key: key}
+			groups[key] = group
+		}
+		addMarketCapBucket(group, bucket, currency, rate)
+	}
+
+	finishMarketCapGroup(&stats.Total, 0)
+	for _, group := range groups {
+		finishMarketCapGroup(group, stats.Total.Total)
+		stats.Groups = append(stats.Groups, *group)
+	}
+	sort.Slice(stats.Groups, func(i, j int) bool {
+		if stats.Groups[i].Total != stats.Groups[j].Total {
+			return stats.Groups[i].Total > stats.Groups[j].Total
+		}
+		return stats.Groups[i].Key < stats.Groups[j].Key
+	})
+
+	s.setCachedAnalysis(ctx, cacheKey, stats)
+
+	return stats, nil
+}
+
+// marketCapRate devuelve el tipo de cambio de currency a target; sin servicio de divisas solo la misma moneda
+func (s *analysisService) marketCapRate(ctx context.Context, currency, target string) (float64, error) {
+	if currency == target {
+		return 1, nil
+	}
+	if s.fxService == nil {
+		return 0, fmt.Errorf("exchange rates are not available")
+	}
+
+	rate, err := s.fxService.Rate(ctx, currency, target)
+	if err != nil {
+		s.logger.Warn(ctx, "Failed to get exchange rate for market cap stats",
+			logger.String("currency", currency),
+			logger.String("target", target),
+			logger.String("error", err.Error()))
+		return 0, err
+	}
+	return rate, nil
+}
+
+// marketCapCurrency es la moneda de cotización del grupo; sin moneda registrada se asume USD
+func marketCapCurrency(bucket repoInterfaces.MarketCapGroupStats) string {
+	if bucket.Currency == "" {
+		return defaultMarketCapCurrency
+	}
+	return strings.ToUpper(bucket.Currency)
+}
+
+// addMarketCapBucket suma al grupo las estadísticas de un exchange y moneda convertidas con rate
+func addMarketCapBucket(group *response.MarketCapGroup, bucket repoInterfaces.MarketCapGroupStats, currency string, rate float64) {
+	minCap, maxCap := bucket.Min*rate, bucket.Max*rate
+	if group.Count == 0 || minCap < group.Min {
+		group.Min = minCap
+	}
+	group.Max = math.Max(group.Max, maxCap)
+	group.Count += bucket.Count
+	group.Total += bucket.Sum * rate
+
+	for _, existing := range group.Currencies {
+		if existing == currency {
+			return
+		}
+	}
+	group.Currencies = append(group.Currencies, currency)
+	sort.Strings(group.Currencies)
+}
+
+// finishMarketCapGroup calcula la media y la fracción de overall (0: sin fracción) y redondea a céntimos
+func finishMarketCapGroup(group *response.MarketCapGroup, overall float64) {
+	if group.Count > 0 {
+		group.Avg = group.Total / float64(group.Count)
+	}
+	if overall > 0 {
+		group.Share = floatPtr(roundRatio(group.Total / overall))
+	}
+
+	round := func(value float64) float64 { return math.Round(value*100) / 100 }
+	group.Total, group.Min, group.Max, group.Avg = round(group.Total), round(group.Min), round(group.Max), round(group.Avg)
+}
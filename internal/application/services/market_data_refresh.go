@@ -0,0 +1,246 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	domainServices "github.com/MayaCris/stock-info-app/internal/domain/services"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+	"github.com/google/uuid"
+)
+
+const (
+	// refreshProgressInterval es cada cuántos símbolos procesados se notifica el progreso
+	refreshProgressInterval = 25
+
+	// maxRefreshErrors limita los errores devueltos en el resultado del refresco
+	maxRefreshErrors = 20
+
+	// refreshViewWindowHours es la ventana de vistas que ordena la cola de refresco
+	refreshViewWindowHours = 24
+)
+
+// RefreshCandidate is a symbol waiting in the bulk refresh queue
+type RefreshCandidate struct {
+	Symbol      string
+	LastUpdated time.Time // Timestamp de la última cotización guardada; cero si nunca se obtuvo
+	Views       int64     // Peticiones recientes del símbolo
+}
+
+// PrioritizeRefresh orders the refresh queue: most viewed first, then the most stale (symbols without any
+// quote before the oldest ones), then by symbol so the order is stable
+func PrioritizeRefresh(candidates []RefreshCandidate) []RefreshCandidate {
+	ordered := append([]RefreshCandidate(nil), candidates...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		a, b := ordered[i], ordered[j]
+		if a.Views != b.Views {
+			return a.Views > b.Views
+		}
+		if !a.LastUpdated.Equal(b.LastUpdated) {
+			return a.LastUpdated.Before(b.LastUpdated)
+		}
+		return a.Symbol < b.Symbol
+	})
+	return ordered
+}
+
+// RefreshMarketData refreshes the quotes of many symbols in priority order. Symbols whose quote is still fresh are
+// skipped without calling the provider, and the run stops once the provider's daily quota is spent; the symbols
+// left are reported as deferred and go first in the next run, since they are now the most stale. A refresh ordered
+// for a tenant also stops once the daily refresh quota of the tenant's plan is spent
+func (s *marketDataService) RefreshMarketData(ctx context.Context, options interfaces.MarketDataRefreshOptions) (*response.MarketDataRefreshResponse, error) {
+	symbols, err := s.refreshSymbols(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &response.MarketDataRefreshResponse{
+		Total:     len(symbols),
+		StartedAt: time.Now().UTC(),
+	}
+	if len(symbols) == 0 {
+		return s.finishRefresh(ctx, options, result), nil
+	}
+
+	latest, err := s.marketDataRepo.GetLatestTimestamps(ctx, symbols)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get latest market data timestamps", err,
+			logger.Int("symbol_count", len(symbols)))
+		return nil, response.InternalServerError("Failed to prioritize market data refresh")
+	}
+
+	views, err := s.views.Counts(ctx, refreshViewWindowHours)
+	if err != nil {
+		// Sin vistas la cola se ordena solo por antigüedad
+		s.logger.Warn(ctx, "Failed to get symbol views for refresh priority",
+			logger.String("error", err.Error()))
+	}
+
+	candidates := make([]RefreshCandidate, len(symbols))
+	for i, symbol := range symbols {
+		candidates[i] = RefreshCandidate{Symbol: symbol, LastUpdated: latest[symbol], Views: views[symbol]}
+	}
+	queue := PrioritizeRefresh(candidates)
+
+	s.logger.Info(ctx, "Starting bulk market data refresh",
+		logger.Int("symbol_count", len(queue)),
+		logger.Int("daily_quota", s.quota.Limit(domainServices.ProviderFinnhub)))
+
+	maxAge := s.currentTTLs().Quote
+	for i, candidate := range queue {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if !candidate.LastUpdated.IsZero() && time.Since(candidate.LastUpdated) <= maxAge {
+			result.Fresh++
+		} else if s.checkQuota(ctx, result) || s.checkPlanQuota(ctx, options, result) {
+			result.Deferred = len(queue) - i
+			break
+		} else if err := s.refreshSymbol(ctx, candidate.Symbol); err != nil {
+			result.Failed++
+			if len(result.Errors) < maxRefreshErrors {
+				result.Errors = append(result.Errors, candidate.Symbol+": "+err.Error())
+			}
+		} else {
+			result.Refreshed++
+			s.consumePlanQuota(ctx, options)
+		}
+
+		result.Processed++
+		if options.OnProgress != nil && result.Processed%refreshProgressInterval == 0 {
+			options.OnProgress(*result)
+		}
+	}
+	s.finishRefresh(ctx, options, result)
+
+	s.logger.Info(ctx, "Bulk market data refresh completed",
+		logger.Int("refreshed", result.Refreshed),
+		logger.Int("fresh", result.Fresh),
+		logger.Int("failed", result.Failed),
+		logger.Int("deferred", result.Deferred),
+		logger.Int("total_symbols", result.Total))
+
+	if result.Failed > 0 && result.Refreshed == 0 && result.Fresh == 0 && result.Deferred == 0 {
+		return nil, response.InternalServerError("Failed to refresh data for all symbols")
+	}
+
+	return result, nil
+}
+
+// refreshSymbols normaliza y deduplica los símbolos pedidos; sin símbolos usa los más vistos (con Trending)
+// o todas las companies activas
+func (s *marketDataService) refreshSymbols(ctx context.Context, options interfaces.MarketDataRefreshOptions) ([]string, error) {
+	requested := options.Symbols
+	switch {
+	case len(requested) > 0:
+		// Símbolos explícitos
+	case options.Trending > 0:
+		trending, err := s.views.Top(ctx, refreshViewWindowHours, options.Trending)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to get trending symbols for refresh", err)
+			return nil, response.InternalServerError("Failed to get trending symbols to refresh")
+		}
+		for _, symbol := range trending {
+			requested = append(requested, symbol.Symbol)
+		}
+	default:
+		companies, err := s.companyRepo.GetAllActive(ctx)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to get active companies for refresh", err)
+			return nil, response.InternalServerError("Failed to get companies to refresh")
+		}
+		for _, company := range companies {
+			requested = append(requested, company.Ticker)
+		}
+	}
+
+	symbols := make([]string, 0, len(requested))
+	seen := make(map[string]struct{}, len(requested))
+	for _, symbol := range requested {
+		symbol = strings.ToUpper(strings.TrimSpace(symbol))
+		if _, duplicated := seen[symbol]; symbol == "" || duplicated {
+			continue
+		}
+		seen[symbol] = struct{}{}
+		symbols = append(symbols, symbol)
+	}
+	return symbols, nil
+}
+
+// refreshSymbol obtiene una cotización nueva del proveedor, salvo para símbolos que se sabe que no existen
+func (s *marketDataService) refreshSymbol(ctx context.Context, symbol string) error {
+	if s.unknownSymbols.isUnknown(ctx, symbol) {
+		return response.NotFound("Company with symbol " + symbol)
+	}
+	_, err := s.fetchQuote(ctx, symbol)
+	return err
+}
+
+// checkQuota actualiza la cuota restante del resultado e indica si ya no quedan llamadas hoy.
+// Si la cache no responde se sigue refrescando: la cuota protege de excesos, no debe bloquear el refresco
+func (s *marketDataService) checkQuota(ctx context.Context, result *response.MarketDataRefreshResponse) bool {
+	remaining, limited, err := s.quota.Remaining(ctx, domainServices.ProviderFinnhub)
+	if err != nil {
+		s.logger.Warn(ctx, "Failed to read provider quota",
+			logger.String("provider", domainServices.ProviderFinnhub),
+			logger.String("error", err.Error()))
+		return false
+	}
+	if !limited {
+		return false
+	}
+
+	result.QuotaLimit = s.quota.Limit(domainServices.ProviderFinnhub)
+	result.QuotaRemaining = &remaining
+	return remaining <= 0
+}
+
+// checkPlanQuota actualiza los refrescos que le quedan hoy al plan del tenant que encargó el refresco e indica
+// si ya no queda ninguno. Igual que checkQuota, si la cache no responde se sigue refrescando
+func (s *marketDataService) checkPlanQuota(ctx context.Context, options interfaces.MarketDataRefreshOptions, result *response.MarketDataRefreshResponse) bool {
+	if options.TenantID == uuid.Nil {
+		return false
+	}
+
+	status, err := s.planQuotas.Status(ctx, options.TenantID, options.Plan, domainServices.QuotaRefreshes)
+	if err != nil {
+		s.logger.Warn(ctx, "Failed to read plan refresh quota",
+			logger.String("tenant_id", options.TenantID.String()),
+			logger.String("error", err.Error()))
+		return false
+	}
+
+	result.Plan = status.Plan
+	if !status.Limited() {
+		return false
+	}
+	result.PlanLimit = status.Limit
+	result.PlanRemaining = &status.Remaining
+	return status.Exhausted()
+}
+
+// consumePlanQuota descuenta un símbolo refrescado de la cuota del plan del tenant que encargó el refresco
+func (s *marketDataService) consumePlanQuota(ctx context.Context, options interfaces.MarketDataRefreshOptions) {
+	if options.TenantID == uuid.Nil {
+		return
+	}
+	if _, err := s.planQuotas.Consume(ctx, options.TenantID, options.Plan, domainServices.QuotaRefreshes, 1); err != nil {
+		s.logger.Warn(ctx, "Failed to consume plan refresh quota",
+			logger.String("tenant_id", options.TenantID.String()),
+			logger.String("error", err.Error()))
+	}
+}
+
+// finishRefresh marca el final del refresco con la cuota que queda
+func (s *marketDataService) finishRefresh(ctx context.Context, options interfaces.MarketDataRefreshOptions, result *response.MarketDataRefreshResponse) *response.MarketDataRefreshResponse {
+	s.checkQuota(ctx, result)
+	s.checkPlanQuota(ctx, options, result)
+	now := time.Now().UTC()
+	result.FinishedAt = &now
+	return result
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/finnhub"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// ReplayPayload re-processes an archived Finnhub response through the same conversion and storage as a live call,
+// without calling the provider. The stored data keeps the provenance (fetch time and request ID) of the archived
+// response, and replaces whatever is stored for the symbol
+func (s *marketDataService) ReplayPayload(ctx context.Context, payload *entities.ProviderPayload) error {
+	if payload.Provider != entities.PayloadProviderFinnhub {
+		return response.BadRequest("Replay is only supported for Finnhub payloads")
+	}
+	if payload.Symbol == "" {
+		return response.BadRequest("Payload has no symbol to replay")
+	}
+
+	raw, err := payload.Raw()
+	if err != nil {
+		s.logger.Error(ctx, "Failed to read archived payload", err,
+			logger.String("payload_id", payload.ID.String()))
+		return response.InternalServerError("Failed to read archived payload")
+	}
+
+	// Los adapters toman la procedencia del contexto, como tras una llamada real
+	ctx, metadata := finnhub.WithResponseMetadata(ctx)
+	metadata.RequestID = payload.RequestID
+	metadata.FetchedAt = payload.FetchedAt
+
+	symbol := payload.Symbol
+	switch payload.Endpoint {
+	case "/quote":
+		var quote finnhub.QuoteResponse
+		if err := decodePayload(raw, &quote); err != nil {
+			return err
+		}
+		company, err := s.companyRepo.GetByTicker(ctx, symbol)
+		if err != nil {
+			if domainerrors.IsNotFound(err) {
+				return response.NotFound("Company with symbol " + symbol)
+			}
+			return response.InternalServerError("Failed to get company")
+		}
+		_, err = s.storeQuote(ctx, symbol, company.ID, &quote)
+		return err
+
+	case "/stock/profile2":
+		var profile finnhub.CompanyProfileResponse
+		if err := decodePayload(raw, &profile); err != nil {
+			return err
+		}
+		existingCompany, err := s.companyRepo.GetByTicker(ctx, symbol)
+		if err != nil && !domainerrors.IsNotFound(err) {
+			return response.InternalServerError("Failed to get company")
+		}
+		_, err = s.storeProfile(ctx, symbol, &profile, existingCompany)
+		return err
+
+	case "/company-news":
+		var news finnhub.NewsResponse
+		if err := decodePayload(raw, &news); err != nil {
+			return err
+		}
+		_, err := s.storeNews(ctx, symbol, news)
+		return err
+
+	case "/stock/metric":
+		var financials finnhub.BasicFinancialsResponse
+		if err := decodePayload(raw, &financials); err != nil {
+			return err
+		}
+		_, err := s.storeBasicFinancials(ctx, symbol, &financials)
+		return err
+	}
+
+	return response.BadRequest("Replay is not supported for endpoint " + payload.Endpoint)
+}
+
+// decodePayload interpreta la respuesta archivada con el modelo del endpoint
+func decodePayload(raw []byte, target interface{}) error {
+	if err := json.Unmarshal(raw, target); err != nil {
+		return response.BadRequest("Archived payload is not valid JSON for its endpoint: " + err.Error())
+	}
+	return nil
+}
@@ -2,14 +2,26 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/google/uuid"
+	"golang.org/x/sync/singleflight"
+	"gorm.io/gorm"
+
 	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
 	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
 	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/events"
 	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	domainServices "github.com/MayaCris/stock-info-app/internal/domain/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/tenancy"
+	"github.com/MayaCris/stock-info-app/internal/domain/usage"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/alphavantage"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/finnhub"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
@@ -23,6 +35,7 @@ type marketDataService struct {
 	newsRepo            repoInterfaces.NewsRepository
 	basicFinancialsRepo repoInterfaces.BasicFinancialsRepository
 	companyRepo         repoInterfaces.CompanyRepository
+	quoteSnapshotRepo   repoInterfaces.QuoteSnapshotRepository // Opcional: histórico intradía de cotizaciones
 	// External API clients
 	finnhubClient       *finnhub.Client
 	finnhubAdapter      *finnhub.Adapter
@@ -31,6 +44,94 @@ type marketDataService struct {
 
 	// Logger
 	logger logger.Logger
+
+	// Cache opcional (noticias) y antigüedad máxima aceptada por tipo de dato (recargable en caliente)
+	cacheService domainServices.CacheService
+	ttls         MarketDataTTLs
+	ttlMu        sync.RWMutex
+
+	// Símbolos inexistentes recientes (nil si el negative caching está desactivado)
+	unknownSymbols *negativeSymbolCache
+
+	// Símbolos con un refresco en segundo plano en curso (evita refrescos duplicados)
+	refreshing sync.Map
+
+	// Peticiones a Finnhub en curso por símbolo: las peticiones concurrentes del mismo símbolo comparten una
+	quoteFetches singleflight.Group
+
+	// Calendario de negociación: is_market_open se calcula al responder, no se confía en el guardado
+	calendar *domainServices.TradingCalendar
+
+	// Llamadas diarias a los proveedores; el refresco masivo se detiene al agotar el presupuesto
+	quota *domainServices.ProviderQuota
+
+	// Cuotas diarias de los planes de los tenants (nil: sin cuotas por plan)
+	planQuotas *domainServices.PlanQuotas
+
+	// Peticiones por símbolo (trending); el refresco masivo empieza por los más vistos
+	views *domainServices.SymbolViews
+
+	// Publica QuoteUpdated tras guardar cada cotización (nil: no se publican eventos)
+	eventPublisher events.Publisher
+}
+
+// Tiempo máximo de un refresco en segundo plano
+const quoteRefreshTimeout = 30 * time.Second
+
+const (
+	// intradaySnapshotCapacity es el máximo de snapshots guardados por símbolo (buffer circular)
+	intradaySnapshotCapacity = 1440
+
+	// Ventana por defecto y máxima del histórico intradía, en minutos
+	defaultIntradayMinutes = 60
+	maxIntradayMinutes     = 1440
+)
+
+// Rankings de top movers (GET /market-data/movers)
+const (
+	MoverTypeGainers = "gainers"
+	MoverTypeLosers  = "losers"
+	MoverTypeActive  = "active"
+
+	defaultMoversLimit = 20
+	maxMoversLimit     = 100
+)
+
+// MarketDataTTLs define cuánto tiempo se consideran frescos los datos de cada tipo
+// antes de volver a pedirlos al proveedor externo
+type MarketDataTTLs struct {
+	Quote           time.Duration
+	CompanyProfile  time.Duration
+	BasicFinancials time.Duration
+	News            time.Duration
+}
+
+// DefaultMarketDataTTLs returns the TTLs used when none are configured
+func DefaultMarketDataTTLs() MarketDataTTLs {
+	return MarketDataTTLs{
+		Quote:           5 * time.Minute,
+		CompanyProfile:  24 * time.Hour,
+		BasicFinancials: 24 * time.Hour,
+		News:            15 * time.Minute,
+	}
+}
+
+// withDefaults sustituye los TTLs no positivos por los valores por defecto
+func (t MarketDataTTLs) withDefaults() MarketDataTTLs {
+	defaults := DefaultMarketDataTTLs()
+	if t.Quote <= 0 {
+		t.Quote = defaults.Quote
+	}
+	if t.CompanyProfile <= 0 {
+		t.CompanyProfile = defaults.CompanyProfile
+	}
+	if t.BasicFinancials <= 0 {
+		t.BasicFinancials = defaults.BasicFinancials
+	}
+	if t.News <= 0 {
+		t.News = defaults.News
+	}
+	return t
 }
 
 // MarketDataServiceConfig represents configuration for market data service
@@ -40,11 +141,45 @@ type MarketDataServiceConfig struct {
 	NewsRepo            repoInterfaces.NewsRepository
 	BasicFinancialsRepo repoInterfaces.BasicFinancialsRepository
 	CompanyRepo         repoInterfaces.CompanyRepository
+	QuoteSnapshotRepo   repoInterfaces.QuoteSnapshotRepository // Opcional: sin él no se guarda el histórico intradía
 	FinnhubClient       *finnhub.Client
 	FinnhubAdapter      *finnhub.Adapter
 	AlphaVantageClient  *alphavantage.Client
 	AlphaVantageAdapter *alphavantage.Adapter
 	Logger              logger.Logger
+
+	// Cache opcional: negative caching de símbolos inexistentes (TTL 0 lo desactiva) y noticias
+	CacheService     domainServices.CacheService
+	NegativeCacheTTL time.Duration
+
+	// TTLs por tipo de dato (los valores no positivos usan DefaultMarketDataTTLs)
+	TTLs MarketDataTTLs
+
+	// Opcional: cuenta las llamadas a los proveedores; sin él el refresco masivo no tiene límite diario
+	ProviderQuota *domainServices.ProviderQuota
+
+	// Opcional: cuotas de refrescos por plan para los refrescos masivos encargados por un tenant
+	PlanQuotas *domainServices.PlanQuotas
+
+	// Opcional: cuenta las peticiones de cotizaciones y perfiles por símbolo
+	SymbolViews *domainServices.SymbolViews
+
+	// Opcional: bus de eventos de dominio (QuoteUpdated)
+	EventPublisher events.Publisher
+}
+
+// UpdateTTLs reemplaza los TTLs vigentes; los valores no positivos usan DefaultMarketDataTTLs
+func (s *marketDataService) UpdateTTLs(ttls MarketDataTTLs) {
+	s.ttlMu.Lock()
+	defer s.ttlMu.Unlock()
+	s.ttls = ttls.withDefaults()
+}
+
+// currentTTLs returns the TTLs in effect
+func (s *marketDataService) currentTTLs() MarketDataTTLs {
+	s.ttlMu.RLock()
+	defer s.ttlMu.RUnlock()
+	return s.ttls
 }
 
 // NewMarketDataService creates a new market data service
@@ -55,45 +190,196 @@ func NewMarketDataService(config MarketDataServiceConfig) interfaces.MarketDataS
 		newsRepo:            config.NewsRepo,
 		basicFinancialsRepo: config.BasicFinancialsRepo,
 		companyRepo:         config.CompanyRepo,
+		quoteSnapshotRepo:   config.QuoteSnapshotRepo,
 		finnhubClient:       config.FinnhubClient,
 		finnhubAdapter:      config.FinnhubAdapter,
 		alphavantageClient:  config.AlphaVantageClient,
 		alphavantageAdapter: config.AlphaVantageAdapter,
 		logger:              config.Logger,
+		cacheService:        config.CacheService,
+		ttls:                config.TTLs.withDefaults(),
+		unknownSymbols:      newNegativeSymbolCache(config.CacheService, config.NegativeCacheTTL, config.Logger),
+		calendar:            domainServices.NewTradingCalendar(),
+		quota:               config.ProviderQuota,
+		planQuotas:          config.PlanQuotas,
+		views:               config.SymbolViews,
+		eventPublisher:      config.EventPublisher,
 	}
 }
 
-// GetRealTimeQuote gets real-time quote for a symbol
+// GetRealTimeQuote gets real-time quote for a symbol.
+// Stale stored data is returned immediately flagged as stale while it is refreshed in the background.
 func (s *marketDataService) GetRealTimeQuote(ctx context.Context, symbol string) (*response.MarketDataResponse, error) {
-	// First, try to get from cache/database (recent data)
-	existingData, err := s.marketDataRepo.GetBySymbol(ctx, symbol)
-	if err == nil && !existingData.IsStale(5*time.Minute) {
-		s.logger.Debug(ctx, "Returning cached market data",
+	quote, err := s.getQuote(ctx, symbol, true)
+	if err == nil {
+		s.recordView(ctx, symbol)
+	}
+	return quote, err
+}
+
+// recordView cuenta la petición del símbolo para el ranking de trending; un fallo solo se registra
+func (s *marketDataService) recordView(ctx context.Context, symbol string) {
+	if err := s.views.Record(ctx, symbol); err != nil {
+		s.logger.Warn(ctx, "Failed to record symbol view",
 			logger.String("symbol", symbol),
+			logger.String("error", err.Error()),
 		)
-		return s.convertToMarketDataResponse(existingData), nil
 	}
+}
+
+// getQuote returns the stored quote while it is fresh. Con allowStale una cotización caducada se
+// devuelve al momento y se refresca en segundo plano; sin él se espera a la API externa.
+func (s *marketDataService) getQuote(ctx context.Context, symbol string, allowStale bool) (*response.MarketDataResponse, error) {
+	if s.unknownSymbols.isUnknown(ctx, symbol) {
+		return nil, response.NotFound("Company with symbol " + symbol)
+	}
+
+	// First, try to get from cache/database (recent data)
+	existingData, err := s.marketDataRepo.GetBySymbol(ctx, symbol)
+	if err == nil {
+		if !existingData.IsStale(s.currentTTLs().Quote) {
+			s.logger.Debug(ctx, "Returning cached market data",
+				logger.String("symbol", symbol),
+			)
+			usage.RecordCacheHit(ctx)
+			return s.convertToMarketDataResponse(existingData), nil
+		}
+
+		// Sin refrescos en el plan se sirve la cotización caducada, sin refrescarla en segundo plano
+		if allowStale || !usage.RefreshAllowed(ctx) {
+			s.logger.Debug(ctx, "Returning stale market data while refreshing",
+				logger.String("symbol", symbol),
+				logger.Duration("age", time.Since(existingData.MarketTimestamp)),
+			)
+			if usage.RefreshAllowed(ctx) {
+				s.refreshQuoteInBackground(symbol)
+			}
+			usage.RecordCacheHit(ctx)
+
+			staleResponse := s.convertToMarketDataResponse(existingData)
+			staleResponse.Stale = true
+			return staleResponse, nil
+		}
+	}
+
+	if !usage.RefreshAllowed(ctx) {
+		return nil, refreshQuotaError(ctx, symbol)
+	}
+	return s.fetchQuote(ctx, symbol)
+}
+
+// refreshQuotaError rechaza con 402 una petición que necesita datos del proveedor cuando el plan de su
+// tenant ya no tiene refrescos hoy y no hay datos guardados que servir
+func refreshQuotaError(ctx context.Context, symbol string) *response.ErrorResponse {
+	details := map[string]interface{}{
+		"quota":  domainServices.QuotaRefreshes,
+		"symbol": symbol,
+	}
+	if principal, ok := tenancy.PrincipalFromContext(ctx); ok && principal.Plan != "" {
+		details["plan"] = principal.Plan
+	}
+	return response.PaymentRequired("The daily symbol refresh quota of the plan is exhausted and there is no stored data for " +
+		symbol + "; upgrade the plan or retry tomorrow").WithDetails(details)
+}
+
+// refreshQuoteInBackground fetches a fresh quote without blocking the caller. Como máximo hay un
+// refresco en curso por símbolo; el contexto es independiente de la petición que lo disparó.
+func (s *marketDataService) refreshQuoteInBackground(symbol string) {
+	key := strings.ToUpper(strings.TrimSpace(symbol))
+	if _, inProgress := s.refreshing.LoadOrStore(key, struct{}{}); inProgress {
+		return
+	}
+
+	go func() {
+		defer s.refreshing.Delete(key)
+
+		ctx, cancel := context.WithTimeout(context.Background(), quoteRefreshTimeout)
+		defer cancel()
+
+		if _, err := s.fetchQuote(ctx, symbol); err != nil {
+			s.logger.Warn(ctx, "Background quote refresh failed",
+				logger.String("symbol", symbol),
+				logger.String("error", err.Error()),
+			)
+		}
+	}()
+}
+
+// fetchQuote fetches the quote from Finnhub and stores it. Concurrent fetches of the same symbol are coalesced
+// into one upstream request whose result every caller receives; the request does not depend on the caller
+// that started it, so a client that gives up does not fail the others
+func (s *marketDataService) fetchQuote(ctx context.Context, symbol string) (*response.MarketDataResponse, error) {
+	key := strings.ToUpper(strings.TrimSpace(symbol))
+	fetch := s.quoteFetches.DoChan(key, func() (interface{}, error) {
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), quoteRefreshTimeout)
+		defer cancel()
+		return s.fetchQuoteFromProvider(fetchCtx, symbol)
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-fetch:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		if result.Shared {
+			s.logger.Debug(ctx, "Shared in-flight quote fetch",
+				logger.String("symbol", key),
+			)
+		}
+		// Cada llamador recibe su propia copia de la respuesta compartida
+		quote := *result.Val.(*response.MarketDataResponse)
+		return &quote, nil
+	}
+}
 
+// fetchQuoteFromProvider pide la cotización a Finnhub y la guarda
+func (s *marketDataService) fetchQuoteFromProvider(ctx context.Context, symbol string) (*response.MarketDataResponse, error) {
 	// Get company info to link market data
 	company, err := s.companyRepo.GetByTicker(ctx, symbol)
 	if err != nil {
 		s.logger.Error(ctx, "Company not found for symbol", err,
 			logger.String("symbol", symbol),
 		)
+		if domainerrors.IsNotFound(err) {
+			s.unknownSymbols.markUnknown(ctx, symbol)
+		}
 		return nil, response.NotFound("Company with symbol " + symbol)
 	}
 
-	// Fetch fresh data from Finnhub
+	// Fetch fresh data from Finnhub; la respuesta deja su procedencia en el contexto para el adapter
+	ctx, _ = finnhub.WithResponseMetadata(ctx)
 	quote, err := s.finnhubClient.GetRealTimeQuote(ctx, symbol)
+	s.recordProviderCall(ctx, domainServices.ProviderFinnhub)
 	if err != nil {
 		s.logger.Error(ctx, "Failed to fetch real-time quote from Finnhub", err,
 			logger.String("symbol", symbol),
 		)
+		if errors.Is(err, finnhub.ErrSymbolNotFound) {
+			s.unknownSymbols.markUnknown(ctx, symbol)
+			return nil, response.NotFound("Market data for symbol " + symbol)
+		}
 		return nil, response.InternalServerError("Failed to fetch real-time data")
 	}
 
+	marketData, err := s.storeQuote(ctx, symbol, company.ID, quote)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "Successfully retrieved and saved real-time quote",
+		logger.String("symbol", symbol),
+		logger.Float64("price", marketData.CurrentPrice),
+	)
+
+	return s.convertToMarketDataResponse(marketData), nil
+}
+
+// storeQuote convierte una cotización de Finnhub, la valida y la guarda con su snapshot intradía
+func (s *marketDataService) storeQuote(ctx context.Context, symbol string, companyID uuid.UUID, quote *finnhub.QuoteResponse) (*entities.MarketData, error) {
 	// Convert to domain entity
-	marketData, err := s.finnhubAdapter.QuoteToMarketData(ctx, quote, symbol, company.ID)
+	marketData, err := s.finnhubAdapter.QuoteToMarketData(ctx, quote, symbol, companyID)
 	if err != nil {
 		s.logger.Error(ctx, "Failed to convert quote to market data", err,
 			logger.String("symbol", symbol),
@@ -110,42 +396,222 @@ func (s *marketDataService) GetRealTimeQuote(ctx context.Context, symbol string)
 	}
 
 	// Save to database
-	if err := s.marketDataRepo.UpsertBySymbol(ctx, marketData); err != nil {
+	if err := s.marketDataRepo.UpsertBySymbol(ctx, marketData, s.quoteUpdatedHooks(ctx)...); err != nil {
 		s.logger.Error(ctx, "Failed to save market data", err,
 			logger.String("symbol", symbol),
 		)
 		// Don't return error here, we can still return the data
+	} else {
+		s.publishQuoteUpdated(ctx, marketData)
 	}
+	s.recordQuoteSnapshot(ctx, marketData)
 
-	s.logger.Info(ctx, "Successfully retrieved and saved real-time quote",
-		logger.String("symbol", symbol),
-		logger.Float64("price", marketData.CurrentPrice),
-	)
+	return marketData, nil
+}
 
-	return s.convertToMarketDataResponse(marketData), nil
+// recordProviderCall cuenta una llamada al proveedor en su cuota diaria; un fallo solo se registra
+func (s *marketDataService) recordProviderCall(ctx context.Context, provider string) {
+	// Atribuye la llamada a la credencial de la petición para el uso de la API
+	usage.RecordProviderCall(ctx)
+	if err := s.quota.Record(ctx, provider); err != nil {
+		s.logger.Warn(ctx, "Failed to record provider call",
+			logger.String("provider", provider),
+			logger.String("error", err.Error()),
+		)
+	}
+}
+
+// quoteUpdatedHooks guarda el evento QuoteUpdated en la transacción del upsert cuando el publisher es el outbox
+// (events.TxPublisher): si el evento no se puede guardar, la cotización tampoco
+func (s *marketDataService) quoteUpdatedHooks(ctx context.Context) []repoInterfaces.WriteHook[entities.MarketData] {
+	txPublisher, ok := s.eventPublisher.(events.TxPublisher)
+	if !ok {
+		return nil
+	}
+
+	return []repoInterfaces.WriteHook[entities.MarketData]{func(tx *gorm.DB, marketData *entities.MarketData) error {
+		event, err := events.NewQuoteUpdated(marketData)
+		if err != nil {
+			return err
+		}
+		return txPublisher.PublishWithTx(ctx, tx, event)
+	}}
+}
+
+// publishQuoteUpdated publica el evento QuoteUpdated; un fallo solo se registra, la cotización ya está guardada.
+// Con el outbox no hace nada: el evento ya se guardó en la transacción del upsert
+func (s *marketDataService) publishQuoteUpdated(ctx context.Context, marketData *entities.MarketData) {
+	if s.eventPublisher == nil {
+		return
+	}
+	if _, transactional := s.eventPublisher.(events.TxPublisher); transactional {
+		return
+	}
+
+	event, err := events.NewQuoteUpdated(marketData)
+	if err == nil {
+		err = s.eventPublisher.Publish(ctx, event)
+	}
+	if err != nil {
+		s.logger.Warn(ctx, "Failed to publish quote updated event",
+			logger.String("symbol", marketData.Symbol),
+			logger.String("error", err.Error()),
+		)
+	}
+}
+
+// recordQuoteSnapshot añade la cotización al histórico intradía del símbolo; un fallo solo se registra
+func (s *marketDataService) recordQuoteSnapshot(ctx context.Context, marketData *entities.MarketData) {
+	if s.quoteSnapshotRepo == nil {
+		return
+	}
+
+	snapshot := entities.NewQuoteSnapshot(marketData)
+	snapshot.Symbol = strings.ToUpper(strings.TrimSpace(snapshot.Symbol))
+	if err := s.quoteSnapshotRepo.Append(ctx, snapshot, intradaySnapshotCapacity); err != nil {
+		s.logger.Warn(ctx, "Failed to store intraday quote snapshot",
+			logger.String("symbol", snapshot.Symbol),
+			logger.String("error", err.Error()),
+		)
+	}
+}
+
+// GetIntradayQuotes returns the quote snapshots stored for a symbol in the last minutes. Every quote refresh
+// adds a snapshot, so the density of the points follows how often the quote is requested or refreshed
+func (s *marketDataService) GetIntradayQuotes(ctx context.Context, symbol string, minutes int) (*response.IntradayQuotesResponse, error) {
+	if s.quoteSnapshotRepo == nil {
+		return nil, response.ServiceUnavailable("Intraday quote history is not available")
+	}
+	if minutes <= 0 {
+		minutes = defaultIntradayMinutes
+	}
+	minutes = min(minutes, maxIntradayMinutes)
+
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	if s.unknownSymbols.isUnknown(ctx, symbol) {
+		return nil, response.NotFound("Company with symbol " + symbol)
+	}
+	if _, err := s.companyRepo.GetByTicker(ctx, symbol); err != nil {
+		if domainerrors.IsNotFound(err) {
+			return nil, response.NotFound("Company with symbol " + symbol)
+		}
+		return nil, response.InternalServerError("Failed to get company")
+	}
+
+	to := time.Now().UTC()
+	from := to.Add(-time.Duration(minutes) * time.Minute)
+	snapshots, err := s.quoteSnapshotRepo.GetBySymbolSince(ctx, symbol, from)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get intraday quote snapshots", err,
+			logger.String("symbol", symbol),
+			logger.Int("minutes", minutes),
+		)
+		return nil, response.InternalServerError("Failed to retrieve intraday quotes")
+	}
+
+	return NewIntradayQuotesResponse(symbol, minutes, from, to, snapshots), nil
+}
+
+// NewIntradayQuotesResponse builds the intraday history of a symbol from its snapshots, oldest first.
+// High, low and change are nil without snapshots; change is the last price minus the first one
+func NewIntradayQuotesResponse(symbol string, minutes int, from, to time.Time, snapshots []*entities.QuoteSnapshot) *response.IntradayQuotesResponse {
+	result := &response.IntradayQuotesResponse{
+		Symbol:  symbol,
+		Minutes: minutes,
+		From:    from,
+		To:      to,
+		Count:   len(snapshots),
+		Points:  make([]response.IntradayPoint, len(snapshots)),
+	}
+	if len(snapshots) == 0 {
+		return result
+	}
+
+	high, low := snapshots[0].Price, snapshots[0].Price
+	for i, snapshot := range snapshots {
+		result.Points[i] = response.IntradayPoint{
+			Timestamp: snapshot.MarketTimestamp,
+			Price:     snapshot.Price,
+			Volume:    snapshot.Volume,
+		}
+		high = max(high, snapshot.Price)
+		low = min(low, snapshot.Price)
+	}
+	result.High, result.Low = floatPtr(high), floatPtr(low)
+
+	first, last := snapshots[0].Price, snapshots[len(snapshots)-1].Price
+	result.Change = floatPtr(last - first)
+	if first != 0 {
+		result.ChangePerc = floatPtr((last - first) / first * 100)
+	}
+
+	return result
 }
 
 // GetCompanyProfile gets detailed company profile
 func (s *marketDataService) GetCompanyProfile(ctx context.Context, symbol string) (*response.CompanyProfileResponse, error) {
+	profile, err := s.getCompanyProfile(ctx, symbol)
+	if err == nil {
+		s.recordView(ctx, symbol)
+	}
+	return profile, err
+}
+
+// getCompanyProfile devuelve el perfil guardado mientras sea reciente o lo obtiene de Finnhub
+func (s *marketDataService) getCompanyProfile(ctx context.Context, symbol string) (*response.CompanyProfileResponse, error) {
+	if s.unknownSymbols.isUnknown(ctx, symbol) {
+		return nil, response.NotFound("Company profile for symbol " + symbol)
+	}
+
 	// Try to get from companies table first
 	existingCompany, err := s.companyRepo.GetByTicker(ctx, symbol)
-	if err == nil && existingCompany.ProfileLastUpdated != nil && 
-		time.Since(*existingCompany.ProfileLastUpdated).Hours() < 24 {
+	if err == nil && existingCompany.ProfileLastUpdated != nil &&
+		time.Since(*existingCompany.ProfileLastUpdated) < s.currentTTLs().CompanyProfile {
 		s.logger.Debug(ctx, "Returning cached company profile",
 			logger.String("symbol", symbol),
 		)
+		usage.RecordCacheHit(ctx)
 		return s.convertCompanyToProfileResponse(existingCompany), nil
 	}
+	if !usage.RefreshAllowed(ctx) {
+		// Sin refrescos en el plan se sirve el perfil guardado aunque sea antiguo
+		if err == nil && existingCompany.ProfileLastUpdated != nil {
+			usage.RecordCacheHit(ctx)
+			return s.convertCompanyToProfileResponse(existingCompany), nil
+		}
+		return nil, refreshQuotaError(ctx, symbol)
+	}
 
-	// Fetch fresh data from Finnhub
+	// Fetch fresh data from Finnhub; la respuesta deja su procedencia en el contexto para el adapter
+	ctx, _ = finnhub.WithResponseMetadata(ctx)
 	profile, err := s.finnhubClient.GetCompanyProfile(ctx, symbol)
+	s.recordProviderCall(ctx, domainServices.ProviderFinnhub)
 	if err != nil {
 		s.logger.Error(ctx, "Failed to fetch company profile from Finnhub", err,
 			logger.String("symbol", symbol),
 		)
+		if errors.Is(err, finnhub.ErrSymbolNotFound) && existingCompany == nil {
+			s.unknownSymbols.markUnknown(ctx, symbol)
+			return nil, response.NotFound("Company profile for symbol " + symbol)
+		}
 		return nil, response.InternalServerError("Failed to fetch company profile")
 	}
 
+	company, err := s.storeProfile(ctx, symbol, profile, existingCompany)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "Successfully retrieved and saved company profile",
+		logger.String("symbol", symbol),
+		logger.String("company_name", company.Name),
+	)
+
+	return s.convertCompanyToProfileResponse(company), nil
+}
+
+// storeProfile actualiza (o crea) la company con el perfil de Finnhub y guarda el perfil del proveedor
+func (s *marketDataService) storeProfile(ctx context.Context, symbol string, profile *finnhub.CompanyProfileResponse, existingCompany *entities.Company) (*entities.Company, error) {
 	// Convert to company entity and update/create company
 	company, err := s.convertFinnhubProfileToCompany(ctx, symbol, profile, existingCompany)
 	if err != nil {
@@ -163,6 +629,9 @@ func (s *marketDataService) GetCompanyProfile(ctx context.Context, symbol string
 	} else {
 		// Create new company
 		saveErr = s.companyRepo.Create(ctx, company)
+		if saveErr == nil {
+			s.unknownSymbols.forget(ctx, symbol)
+		}
 	}
 
 	if saveErr != nil {
@@ -172,26 +641,42 @@ func (s *marketDataService) GetCompanyProfile(ctx context.Context, symbol string
 		// Don't return error here, we can still return the data
 	}
 
-	s.logger.Info(ctx, "Successfully retrieved and saved company profile",
-		logger.String("symbol", symbol),
-		logger.String("company_name", company.Name),
-	)
+	// El perfil del proveedor se guarda aparte para el pipeline de enriquecimiento
+	s.storeCompanyProfile(ctx, profile)
 
-	return s.convertCompanyToProfileResponse(company), nil
+	return company, nil
 }
 
 // GetCompanyNews gets recent news for a company
 func (s *marketDataService) GetCompanyNews(ctx context.Context, symbol string, days int) ([]*response.NewsResponse, error) {
+	if s.unknownSymbols.isUnknown(ctx, symbol) {
+		return nil, response.NotFound("Company with symbol " + symbol)
+	}
+
 	if days <= 0 {
 		days = 7 // Default to 7 days
 	}
 
+	cacheKey := fmt.Sprintf("news:%s:%d", strings.ToUpper(strings.TrimSpace(symbol)), days)
+	if cached := s.getCachedNews(ctx, cacheKey); cached != nil {
+		s.logger.Debug(ctx, "Returning cached company news",
+			logger.String("symbol", symbol),
+		)
+		usage.RecordCacheHit(ctx)
+		return cached, nil
+	}
+	if !usage.RefreshAllowed(ctx) {
+		return nil, refreshQuotaError(ctx, symbol)
+	}
+
 	// Calculate date range
 	to := time.Now()
 	from := to.AddDate(0, 0, -days)
 
-	// Fetch news from Finnhub
+	// Fetch news from Finnhub; la respuesta deja su procedencia en el contexto para el adapter
+	ctx, _ = finnhub.WithResponseMetadata(ctx)
 	news, err := s.finnhubClient.GetCompanyNews(ctx, symbol, from, to)
+	s.recordProviderCall(ctx, domainServices.ProviderFinnhub)
 	if err != nil {
 		s.logger.Error(ctx, "Failed to fetch company news from Finnhub", err,
 			logger.String("symbol", symbol),
@@ -199,6 +684,29 @@ func (s *marketDataService) GetCompanyNews(ctx context.Context, symbol string, d
 		return nil, response.InternalServerError("Failed to fetch company news")
 	}
 
+	newsItems, err := s.storeNews(ctx, symbol, news)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "Successfully retrieved and saved company news",
+		logger.String("symbol", symbol),
+		logger.Int("news_count", len(newsItems)),
+	)
+
+	// Convert to response DTOs
+	newsResponses := make([]*response.NewsResponse, len(newsItems))
+	for i, newsItem := range newsItems {
+		newsResponses[i] = s.convertToNewsResponse(newsItem)
+	}
+
+	s.setCachedNews(ctx, cacheKey, newsResponses)
+
+	return newsResponses, nil
+}
+
+// storeNews convierte las noticias de Finnhub y guarda las nuevas
+func (s *marketDataService) storeNews(ctx context.Context, symbol string, news finnhub.NewsResponse) ([]*entities.NewsItem, error) {
 	// Convert to domain entities
 	newsItems, err := s.finnhubAdapter.NewsToNewsItems(ctx, news, symbol)
 	if err != nil {
@@ -218,33 +726,73 @@ func (s *marketDataService) GetCompanyNews(ctx context.Context, symbol string, d
 		}
 	}
 
-	s.logger.Info(ctx, "Successfully retrieved and saved company news",
-		logger.String("symbol", symbol),
-		logger.Int("news_count", len(newsItems)),
-	)
+	return newsItems, nil
+}
 
-	// Convert to response DTOs
-	newsResponses := make([]*response.NewsResponse, len(newsItems))
-	for i, newsItem := range newsItems {
-		newsResponses[i] = s.convertToNewsResponse(newsItem)
+// getCachedNews devuelve las noticias cacheadas o nil si no hay cache o la entrada no existe
+func (s *marketDataService) getCachedNews(ctx context.Context, key string) []*response.NewsResponse {
+	if s.cacheService == nil {
+		return nil
 	}
 
-	return newsResponses, nil
+	data, err := s.cacheService.Get(ctx, key)
+	if err != nil || data == nil {
+		return nil
+	}
+
+	var news []*response.NewsResponse
+	if err := json.Unmarshal(data, &news); err != nil {
+		return nil
+	}
+	return news
+}
+
+// setCachedNews guarda las noticias durante el TTL configurado; los fallos solo se registran
+func (s *marketDataService) setCachedNews(ctx context.Context, key string, news []*response.NewsResponse) {
+	if s.cacheService == nil {
+		return
+	}
+
+	data, err := json.Marshal(news)
+	if err != nil {
+		return
+	}
+	if err := s.cacheService.Set(ctx, key, data, s.currentTTLs().News); err != nil {
+		s.logger.Warn(ctx, "Failed to cache company news",
+			logger.String("key", key),
+			logger.String("error", err.Error()),
+		)
+	}
 }
 
 // GetBasicFinancials gets basic financial metrics for a company
 func (s *marketDataService) GetBasicFinancials(ctx context.Context, symbol string) (*response.BasicFinancialsResponse, error) {
+	if s.unknownSymbols.isUnknown(ctx, symbol) {
+		return nil, response.NotFound("Company with symbol " + symbol)
+	}
+
 	// Try to get from database first
 	existingFinancials, err := s.basicFinancialsRepo.GetLatestBySymbol(ctx, symbol)
-	if err == nil && time.Since(existingFinancials.LastUpdated).Hours() < 24 {
+	if err == nil && time.Since(existingFinancials.LastUpdated) < s.currentTTLs().BasicFinancials {
 		s.logger.Debug(ctx, "Returning cached basic financials",
 			logger.String("symbol", symbol),
 		)
+		usage.RecordCacheHit(ctx)
 		return s.convertToBasicFinancialsResponse(existingFinancials), nil
 	}
+	if !usage.RefreshAllowed(ctx) {
+		// Sin refrescos en el plan se sirven los fundamentales guardados aunque sean antiguos
+		if err == nil {
+			usage.RecordCacheHit(ctx)
+			return s.convertToBasicFinancialsResponse(existingFinancials), nil
+		}
+		return nil, refreshQuotaError(ctx, symbol)
+	}
 
-	// Fetch fresh data from Finnhub
+	// Fetch fresh data from Finnhub; la respuesta deja su procedencia en el contexto para el adapter
+	ctx, _ = finnhub.WithResponseMetadata(ctx)
 	financials, err := s.finnhubClient.GetBasicFinancials(ctx, symbol)
+	s.recordProviderCall(ctx, domainServices.ProviderFinnhub)
 	if err != nil {
 		s.logger.Error(ctx, "Failed to fetch basic financials from Finnhub", err,
 			logger.String("symbol", symbol),
@@ -252,6 +800,20 @@ func (s *marketDataService) GetBasicFinancials(ctx context.Context, symbol strin
 		return nil, response.InternalServerError("Failed to fetch financial data")
 	}
 
+	basicFinancials, err := s.storeBasicFinancials(ctx, symbol, financials)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "Successfully retrieved and saved basic financials",
+		logger.String("symbol", symbol),
+	)
+
+	return s.convertToBasicFinancialsResponse(basicFinancials), nil
+}
+
+// storeBasicFinancials convierte las métricas de Finnhub y las guarda
+func (s *marketDataService) storeBasicFinancials(ctx context.Context, symbol string, financials *finnhub.BasicFinancialsResponse) (*entities.BasicFinancials, error) {
 	// Convert to domain entity
 	basicFinancials, err := s.finnhubAdapter.FinancialsToBasicFinancials(ctx, financials)
 	if err != nil {
@@ -269,11 +831,7 @@ func (s *marketDataService) GetBasicFinancials(ctx context.Context, symbol strin
 		// Don't return error here, we can still return the data
 	}
 
-	s.logger.Info(ctx, "Successfully retrieved and saved basic financials",
-		logger.String("symbol", symbol),
-	)
-
-	return s.convertToBasicFinancialsResponse(basicFinancials), nil
+	return basicFinancials, nil
 }
 
 // GetMarketOverview gets general market overview
@@ -324,6 +882,63 @@ func (s *marketDataService) GetMarketOverview(ctx context.Context) (*response.Ma
 	return overview, nil
 }
 
+// GetMarketMovers ranks the latest quote of every symbol: gainers by percentage change descending, losers
+// ascending and active by volume. The ranking is resolved in the database, not over a page of rows
+func (s *marketDataService) GetMarketMovers(ctx context.Context, moverType string, limit int) (*response.MarketMoversResponse, error) {
+	moverType, ok := NormalizeMoverType(moverType)
+	if !ok {
+		return nil, response.BadRequest("Invalid mover type: must be one of gainers, losers, active")
+	}
+	if limit <= 0 {
+		limit = defaultMoversLimit
+	}
+	limit = min(limit, maxMoversLimit)
+
+	var (
+		movers []*entities.MarketData
+		err    error
+	)
+	switch moverType {
+	case MoverTypeGainers:
+		movers, err = s.marketDataRepo.GetTopGainers(ctx, limit)
+	case MoverTypeLosers:
+		movers, err = s.marketDataRepo.GetTopLosers(ctx, limit)
+	default:
+		movers, err = s.marketDataRepo.GetMostActive(ctx, limit)
+	}
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get market movers", err,
+			logger.String("type", moverType),
+			logger.Int("limit", limit),
+		)
+		return nil, response.InternalServerError("Failed to get market movers")
+	}
+
+	result := &response.MarketMoversResponse{
+		Type:        moverType,
+		Count:       len(movers),
+		Movers:      make([]*response.MarketDataResponse, len(movers)),
+		GeneratedAt: time.Now().UTC(),
+	}
+	for i, md := range movers {
+		result.Movers[i] = s.convertToMarketDataResponse(md)
+	}
+
+	return result, nil
+}
+
+// NormalizeMoverType validates a movers ranking type; an empty type defaults to gainers
+func NormalizeMoverType(moverType string) (string, bool) {
+	switch normalized := strings.ToLower(strings.TrimSpace(moverType)); normalized {
+	case "":
+		return MoverTypeGainers, true
+	case MoverTypeGainers, MoverTypeLosers, MoverTypeActive:
+		return normalized, true
+	default:
+		return "", false
+	}
+}
+
 // GetHistoricalData gets historical price data from Alpha Vantage
 func (s *marketDataService) GetHistoricalData(ctx context.Context, symbol, period, outputSize string) (*response.HistoricalDataResponse, error) {
 	s.logger.Info(ctx, "Fetching historical data from Alpha Vantage",
@@ -348,7 +963,7 @@ func (s *marketDataService) GetHistoricalData(ctx context.Context, symbol, perio
 		s.logger.Error(ctx, "Failed to fetch historical data from Alpha Vantage", err,
 			logger.String("symbol", symbol),
 			logger.String("period", period))
-		return nil, response.InternalServerError("Failed to fetch historical data")
+		return nil, alphaVantageError(err, symbol, "Failed to fetch historical data")
 	}
 
 	// Use the response data (placeholder to avoid unused variable error)
@@ -409,7 +1024,7 @@ func (s *marketDataService) GetTechnicalIndicators(ctx context.Context, symbol,
 		s.logger.Error(ctx, "Failed to fetch technical indicators from Alpha Vantage", err,
 			logger.String("symbol", symbol),
 			logger.String("indicator", indicator))
-		return nil, response.InternalServerError("Failed to fetch technical indicators")
+		return nil, alphaVantageError(err, symbol, "Failed to fetch technical indicators")
 	}
 
 	// Use the response data (placeholder to avoid unused variable error)
@@ -445,7 +1060,7 @@ func (s *marketDataService) GetFundamentalData(ctx context.Context, symbol strin
 	if err != nil {
 		s.logger.Error(ctx, "Failed to fetch company overview from Alpha Vantage", err,
 			logger.String("symbol", symbol))
-		return nil, response.InternalServerError("Failed to fetch fundamental data")
+		return nil, alphaVantageError(err, symbol, "Failed to fetch fundamental data")
 	}
 	// Get income statement
 	_, err = s.alphavantageClient.GetIncomeStatement(ctx, symbol)
@@ -501,13 +1116,24 @@ func (s *marketDataService) GetEarningsData(ctx context.Context, symbol string)
 	if err != nil {
 		s.logger.Error(ctx, "Failed to fetch earnings from Alpha Vantage", err,
 			logger.String("symbol", symbol))
-		return nil, response.InternalServerError("Failed to fetch earnings data")
+		return nil, alphaVantageError(err, symbol, "Failed to fetch earnings data")
+	}
+
+	// Convert to response format; los valores sin dato ("None") quedan a cero
+	parse := func(field, value string) float64 {
+		parsed, _, err := alphavantage.ParseFloat(value)
+		if err != nil {
+			s.logger.Warn(ctx, "Ignoring malformed earnings value",
+				logger.String("symbol", symbol),
+				logger.String("field", field),
+				logger.String("value", value))
+		}
+		return parsed
 	}
 
-	// Convert to response format
 	var annualEarnings []*response.AnnualEarning
 	for _, ae := range earnings.AnnualEarnings {
-		eps, _ := strconv.ParseFloat(ae.ReportedEPS, 64)
+		eps := parse("reportedEPS", ae.ReportedEPS)
 		annualEarnings = append(annualEarnings, &response.AnnualEarning{
 			FiscalDateEnding: ae.FiscalDateEnding,
 			ReportedEPS:      eps,
@@ -516,10 +1142,10 @@ func (s *marketDataService) GetEarningsData(ctx context.Context, symbol string)
 
 	var quarterlyEarnings []*response.QuarterlyEarning
 	for _, qe := range earnings.QuarterlyEarnings {
-		reportedEPS, _ := strconv.ParseFloat(qe.ReportedEPS, 64)
-		estimatedEPS, _ := strconv.ParseFloat(qe.EstimatedEPS, 64)
-		surprise, _ := strconv.ParseFloat(qe.Surprise, 64)
-		surprisePercentage, _ := strconv.ParseFloat(qe.SurprisePercentage, 64)
+		reportedEPS := parse("reportedEPS", qe.ReportedEPS)
+		estimatedEPS := parse("estimatedEPS", qe.EstimatedEPS)
+		surprise := parse("surprise", qe.Surprise)
+		surprisePercentage := parse("surprisePercentage", qe.SurprisePercentage)
 
 		quarterlyEarnings = append(quarterlyEarnings, &response.QuarterlyEarning{
 			FiscalDateEnding:   qe.FiscalDateEnding,
@@ -563,44 +1189,14 @@ func (s *marketDataService) AlphaVantageHealthCheck(ctx context.Context) (bool,
 	return true, nil
 }
 
-// RefreshMarketData refreshes market data for multiple symbols
-func (s *marketDataService) RefreshMarketData(ctx context.Context, symbols []string) error {
-	if len(symbols) == 0 {
-		return nil
-	}
-
-	s.logger.Info(ctx, "Starting bulk market data refresh",
-		logger.Int("symbol_count", len(symbols)))
-
-	var errors []string
-	successCount := 0
-
-	for _, symbol := range symbols {
-		_, err := s.GetRealTimeQuote(ctx, symbol)
-		if err != nil {
-			s.logger.Error(ctx, "Failed to refresh data for symbol", err,
-				logger.String("symbol", symbol))
-			errors = append(errors, symbol+": "+err.Error())
-		} else {
-			successCount++
-		}
-	}
-
-	s.logger.Info(ctx, "Bulk market data refresh completed",
-		logger.Int("success_count", successCount),
-		logger.Int("error_count", len(errors)),
-		logger.Int("total_symbols", len(symbols)))
-
-	if len(errors) > 0 && successCount == 0 {
-		return response.InternalServerError("Failed to refresh data for all symbols")
-	}
-
-	return nil
-}
-
 // Helper conversion methods
 
 func (s *marketDataService) convertToMarketDataResponse(md *entities.MarketData) *response.MarketDataResponse {
+	isMarketOpen := md.IsMarketOpen
+	if _, ok := domainServices.NormalizeExchange(md.Exchange); ok {
+		isMarketOpen = s.calendar.IsOpen(time.Now())
+	}
+
 	return &response.MarketDataResponse{
 		ID:              md.ID,
 		CompanyID:       md.CompanyID,
@@ -615,11 +1211,15 @@ func (s *marketDataService) convertToMarketDataResponse(md *entities.MarketData)
 		Volume:          md.Volume,
 		AvgVolume:       md.AvgVolume,
 		MarketCap:       md.MarketCap,
-		IsMarketOpen:    md.IsMarketOpen,
+		IsMarketOpen:    isMarketOpen,
 		Currency:        md.Currency,
 		Exchange:        md.Exchange,
 		MarketTimestamp: md.MarketTimestamp,
 		LastUpdated:     md.UpdatedAt,
+
+		DataSource:        md.DataSource,
+		FetchedAt:         md.FetchedAt,
+		ProviderRequestID: md.ProviderRequestID,
 	}
 }
 
@@ -645,6 +1245,9 @@ func (s *marketDataService) convertToCompanyProfileResponse(cp *entities.Company
 		Logo:              cp.Logo,
 		IPODate:           cp.IPODate,
 		EmployeeCount:     cp.EmployeeCount,
+		DataSource:        cp.DataSource,
+		FetchedAt:         cp.FetchedAt,
+		ProviderRequestID: cp.ProviderRequestID,
 		LastUpdated:       cp.LastUpdated,
 	}
 }
@@ -664,6 +1267,10 @@ func (s *marketDataService) convertToNewsResponse(ni *entities.NewsItem) *respon
 		SentimentLabel: ni.SentimentLabel,
 		PublishedAt:    ni.PublishedAt,
 		CreatedAt:      ni.CreatedAt,
+
+		DataSource:        ni.DataSource,
+		FetchedAt:         ni.FetchedAt,
+		ProviderRequestID: ni.ProviderRequestID,
 	}
 }
 
@@ -695,6 +1302,9 @@ func (s *marketDataService) convertToBasicFinancialsResponse(bf *entities.BasicF
 		Period:            bf.Period,
 		FiscalYear:        bf.FiscalYear,
 		FiscalQuarter:     bf.FiscalQuarter,
+		DataSource:        bf.DataSource,
+		FetchedAt:         bf.FetchedAt,
+		ProviderRequestID: bf.ProviderRequestID,
 		LastUpdated:       bf.LastUpdated,
 	}
 }
@@ -705,7 +1315,7 @@ func (s *marketDataService) convertCompanyToProfileResponse(company *entities.Co
 	if company.ProfileLastUpdated != nil {
 		lastUpdated = *company.ProfileLastUpdated
 	}
-	
+
 	var ipoDate time.Time
 	if company.IPODate != nil {
 		ipoDate = *company.IPODate
@@ -730,10 +1340,32 @@ func (s *marketDataService) convertCompanyToProfileResponse(company *entities.Co
 		Logo:              company.Logo,
 		IPODate:           ipoDate,
 		EmployeeCount:     company.EmployeeCount,
+		DataSource:        company.DataSource,
+		FetchedAt:         company.ProfileLastUpdated,
+		ProviderRequestID: company.ProfileRequestID,
 		LastUpdated:       lastUpdated,
 	}
 }
 
+// storeCompanyProfile guarda el perfil de Finnhub en company_profiles; los fallos solo se registran
+func (s *marketDataService) storeCompanyProfile(ctx context.Context, profile *finnhub.CompanyProfileResponse) {
+	if s.companyProfileRepo == nil {
+		return
+	}
+
+	companyProfile, err := s.finnhubAdapter.ProfileToCompanyProfile(ctx, profile)
+	if err != nil {
+		return
+	}
+
+	if err := s.companyProfileRepo.UpsertBySymbol(ctx, companyProfile); err != nil {
+		s.logger.Warn(ctx, "Failed to store company profile",
+			logger.String("symbol", companyProfile.Symbol),
+			logger.String("error", err.Error()),
+		)
+	}
+}
+
 // convertFinnhubProfileToCompany converts Finnhub profile to Company entity, updating existing if provided
 func (s *marketDataService) convertFinnhubProfileToCompany(ctx context.Context, symbol string, profile interface{}, existingCompany *entities.Company) (*entities.Company, error) {
 	// Type assert the profile to the correct type
@@ -778,8 +1410,9 @@ func (s *marketDataService) convertFinnhubProfileToCompany(ctx context.Context,
 	company.Website = companyProfile.Website
 	company.Logo = companyProfile.Logo
 	company.EmployeeCount = companyProfile.EmployeeCount
-	company.DataSource = "finnhub"
+	company.DataSource = companyProfile.DataSource
 	company.ProfileLastUpdated = &now
+	company.ProfileRequestID = companyProfile.ProviderRequestID
 
 	if !companyProfile.IPODate.IsZero() {
 		company.IPODate = &companyProfile.IPODate
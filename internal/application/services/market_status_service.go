@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	domainServices "github.com/MayaCris/stock-info-app/internal/domain/services"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// defaultMarketExchange es el exchange consultado si no se indica otro
+const defaultMarketExchange = "NYSE"
+
+// marketStatusService implements the MarketStatusService interface
+type marketStatusService struct {
+	calendar *domainServices.TradingCalendar
+	logger   logger.Logger
+}
+
+// NewMarketStatusService creates a new market status service
+func NewMarketStatusService(calendar *domainServices.TradingCalendar, logger logger.Logger) interfaces.MarketStatusService {
+	return &marketStatusService{
+		calendar: calendar,
+		logger:   logger,
+	}
+}
+
+// GetMarketStatus returns the current session of an exchange from the trading calendar
+func (s *marketStatusService) GetMarketStatus(ctx context.Context, exchange string) (*response.MarketStatusResponse, error) {
+	if exchange == "" {
+		exchange = defaultMarketExchange
+	}
+
+	status, err := s.calendar.Status(exchange, time.Now())
+	if err != nil {
+		if errors.Is(err, domainServices.ErrUnsupportedExchange) {
+			return nil, response.BadRequest("Unsupported exchange " + exchange + " (supported: NYSE, NASDAQ, AMEX, US)")
+		}
+		return nil, response.InternalServerError("Failed to get market status")
+	}
+
+	return NewMarketStatusResponse(status), nil
+}
+
+// NewMarketStatusResponse converts a calendar status to its response
+func NewMarketStatusResponse(status *domainServices.MarketStatus) *response.MarketStatusResponse {
+	result := &response.MarketStatusResponse{
+		Exchange:     status.Exchange,
+		Timezone:     status.Timezone,
+		LocalTime:    status.LocalTime,
+		Session:      status.Session,
+		IsOpen:       status.IsOpen,
+		IsTradingDay: status.IsTradingDay,
+		Holiday:      status.Holiday,
+		NextOpen:     status.NextOpen,
+		NextClose:    status.NextClose,
+	}
+	if status.Hours != nil {
+		result.Hours = &response.TradingHoursResponse{
+			PreMarketOpen:   status.Hours.PreMarketOpen,
+			Open:            status.Hours.Open,
+			Close:           status.Hours.Close,
+			PostMarketClose: status.Hours.PostMarketClose,
+			EarlyClose:      status.Hours.EarlyClose,
+		}
+	}
+	return result
+}
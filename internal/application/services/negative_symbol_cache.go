@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	domainServices "github.com/MayaCris/stock-info-app/internal/domain/services"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// negativeSymbolCache remembers for a short time the symbols that do not exist, so repeated
+// lookups of typo'd or abusive tickers answer 404 without touching the database or the provider quota
+type negativeSymbolCache struct {
+	cache  domainServices.CacheService
+	prefix string
+	ttl    time.Duration
+	logger logger.Logger
+}
+
+// newNegativeSymbolCache devuelve nil (negative caching desactivado) sin cache o con TTL no positivo
+func newNegativeSymbolCache(cache domainServices.CacheService, ttl time.Duration, appLogger logger.Logger) *negativeSymbolCache {
+	if cache == nil || ttl <= 0 {
+		return nil
+	}
+	return &negativeSymbolCache{
+		cache:  cache,
+		prefix: domainServices.DefaultCacheConfiguration().NegativeSymbolPrefix,
+		ttl:    ttl,
+		logger: appLogger,
+	}
+}
+
+// isUnknown reports whether the symbol was recently confirmed not to exist. Ante un fallo de la
+// cache se asume que el símbolo existe para no rechazar peticiones válidas.
+func (n *negativeSymbolCache) isUnknown(ctx context.Context, symbol string) bool {
+	if n == nil {
+		return false
+	}
+	exists, err := n.cache.Exists(ctx, domainServices.GenerateNegativeSymbolKey(n.prefix, symbol))
+	return err == nil && exists
+}
+
+// markUnknown records that the symbol does not exist
+func (n *negativeSymbolCache) markUnknown(ctx context.Context, symbol string) {
+	if n == nil {
+		return
+	}
+	if err := n.cache.Set(ctx, domainServices.GenerateNegativeSymbolKey(n.prefix, symbol), []byte("1"), n.ttl); err != nil {
+		n.logger.Warn(ctx, "Failed to cache unknown symbol",
+			logger.String("symbol", symbol),
+			logger.String("error", err.Error()),
+		)
+	}
+}
+
+// forget drops the negative entry once the symbol is known to exist
+func (n *negativeSymbolCache) forget(ctx context.Context, symbol string) {
+	if n == nil {
+		return
+	}
+	if err := n.cache.DeleteByPrefix(ctx, domainServices.GenerateNegativeSymbolKey(n.prefix, symbol)); err != nil {
+		n.logger.Warn(ctx, "Failed to forget unknown symbol",
+			logger.String("symbol", symbol),
+			logger.String("error", err.Error()),
+		)
+	}
+}
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/tenancy"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// errNoteOwner es el mensaje de las peticiones sin la credencial de un usuario
+const errNoteOwner = "Notes require the API key or bearer token of a user"
+
+// noteService implements the NoteService interface
+type noteService struct {
+	noteRepo        repoInterfaces.NoteRepository
+	companyRepo     repoInterfaces.CompanyRepository
+	stockRatingRepo repoInterfaces.StockRatingRepository
+	logger          logger.Logger
+}
+
+// NewNoteService creates a new note service
+func NewNoteService(
+	noteRepo repoInterfaces.NoteRepository,
+	companyRepo repoInterfaces.CompanyRepository,
+	stockRatingRepo repoInterfaces.StockRatingRepository,
+	logger logger.Logger,
+) interfaces.NoteService {
+	return &noteService{
+		noteRepo:        noteRepo,
+		companyRepo:     companyRepo,
+		stockRatingRepo: stockRatingRepo,
+		logger:          logger,
+	}
+}
+
+// ListNotes returns the notes of the user, newest first, optionally of a company (by ID or ticker) or a rating
+func (s *noteService) ListNotes(ctx context.Context, filter *request.NoteFilterRequest, pagination *response.PaginationRequest) (*response.PaginatedResponse[*response.NoteResponse], error) {
+	listFilter := repoInterfaces.NoteListFilter{RatingID: filter.RatingID}
+	if ref := strings.TrimSpace(filter.Company); ref != "" {
+		company, err := findCompanyByRef(ctx, s.companyRepo, ref)
+		if err != nil {
+			return nil, err
+		}
+		listFilter.CompanyID = &company.ID
+	}
+
+	notes, total, err := s.noteRepo.List(ctx, listFilter, pagination.GetLimit(), pagination.GetOffset())
+	if err != nil {
+		return nil, s.noteError(ctx, err, "Note", "Failed to get notes")
+	}
+
+	items := make([]*response.NoteResponse, len(notes))
+	for i, note := range notes {
+		item := toNoteResponse(note)
+		items[i] = &item
+	}
+	return response.NewPaginatedResponse(items, pagination.Page, pagination.PerPage, int(total)), nil
+}
+
+// GetNote returns a note of the user
+func (s *noteService) GetNote(ctx context.Context, id uuid.UUID) (*response.NoteResponse, error) {
+	note, err := s.noteRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, s.noteError(ctx, err, "Note with id "+id.String(), "Failed to get note")
+	}
+
+	result := toNoteResponse(note)
+	return &result, nil
+}
+
+// CreateNote creates a note of the user on a company or, with rating_id, on one of its ratings
+func (s *noteService) CreateNote(ctx context.Context, req *request.CreateNoteRequest) (*response.NoteResponse, error) {
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, response.BadRequest("note body cannot be empty")
+	}
+
+	var company *entities.Company
+	if ref := strings.TrimSpace(req.Company); ref != "" {
+		var err error
+		if company, err = findCompanyByRef(ctx, s.companyRepo, ref); err != nil {
+			return nil, err
+		}
+	}
+
+	companyID := uuid.Nil
+	if company != nil {
+		companyID = company.ID
+	}
+	if req.RatingID != nil {
+		rating, err := s.stockRatingRepo.GetByID(ctx, *req.RatingID)
+		if err != nil {
+			return nil, response.FromError(err, "Stock rating with id "+req.RatingID.String(), "Failed to get stock rating")
+		}
+		if company != nil && rating.CompanyID != company.ID {
+			return nil, response.BadRequest("the rating does not belong to the company")
+		}
+		companyID = rating.CompanyID
+	}
+	if companyID == uuid.Nil {
+		return nil, response.BadRequest("a note needs a company or a rating_id")
+	}
+
+	note := entities.NewNote(companyID, req.RatingID, body)
+	if err := s.noteRepo.Create(ctx, note); err != nil {
+		return nil, s.noteError(ctx, err, "Note", "Failed to create note")
+	}
+
+	s.logger.Info(ctx, "Note created",
+		logger.String("note_id", note.ID.String()),
+		logger.String("company_id", companyID.String()))
+
+	result := toNoteResponse(note)
+	return &result, nil
+}
+
+// UpdateNote replaces the body of a note of the user
+func (s *noteService) UpdateNote(ctx context.Context, id uuid.UUID, req *request.UpdateNoteRequest) (*response.NoteResponse, error) {
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, response.BadRequest("note body cannot be empty")
+	}
+
+	note, err := s.noteRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, s.noteError(ctx, err, "Note with id "+id.String(), "Failed to get note")
+	}
+	note.Body = body
+	if err := s.noteRepo.Update(ctx, note); err != nil {
+		return nil, s.noteError(ctx, err, "Note with id "+id.String(), "Failed to update note")
+	}
+
+	result := toNoteResponse(note)
+	return &result, nil
+}
+
+// DeleteNote deletes a note of the user
+func (s *noteService) DeleteNote(ctx context.Context, id uuid.UUID) error {
+	if err := s.noteRepo.Delete(ctx, id); err != nil {
+		return s.noteError(ctx, err, "Note with id "+id.String(), "Failed to delete note")
+	}
+	return nil
+}
+
+// GetCompanyNotes returns every note of the user on a company and its ratings, newest first
+func (s *noteService) GetCompanyNotes(ctx context.Context, companyID uuid.UUID) ([]response.NoteResponse, error) {
+	notes, _, err := s.noteRepo.List(ctx, repoInterfaces.NoteListFilter{CompanyID: &companyID}, 0, 0)
+	if err != nil {
+		return nil, s.noteError(ctx, err, "Note", "Failed to get notes")
+	}
+	return toNoteResponses(notes), nil
+}
+
+// noteError traduce los errores del repositorio: sin la credencial de un usuario es 401 y las notas de otros
+// usuarios no se encuentran
+func (s *noteService) noteError(ctx context.Context, err error, resource, fallback string) error {
+	if errors.Is(err, tenancy.ErrNoTenant) || errors.Is(err, tenancy.ErrNoCredential) {
+		return response.Unauthorized(errNoteOwner)
+	}
+	s.logger.Warn(ctx, fallback, logger.String("error", err.Error()))
+	return response.FromError(err, resource, fallback)
+}
+
+func toNoteResponse(note *entities.Note) response.NoteResponse {
+	return response.NoteResponse{
+		ID:        note.ID,
+		CompanyID: note.CompanyID,
+		RatingID:  note.RatingID,
+		Body:      note.Body,
+		CreatedAt: note.CreatedAt,
+		UpdatedAt: note.UpdatedAt,
+	}
+}
+
+func toNoteResponses(notes []*entities.Note) []response.NoteResponse {
+	result := make([]response.NoteResponse, len(notes))
+	for i, note := range notes {
+		result[i] = toNoteResponse(note)
+	}
+	return result
+}
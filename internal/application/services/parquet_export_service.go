@@ -0,0 +1,375 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	domainServices "github.com/MayaCris/stock-info-app/internal/domain/services"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/parquet"
+)
+
+// Datasets de la exportación Parquet
+const (
+	ParquetDatasetHistoricalPrices = "historical_prices"
+	ParquetDatasetRatings          = "ratings"
+
+	// defaultParquetExportYears es el rango exportado si no se indica from_year
+	defaultParquetExportYears = 5
+)
+
+// parquetDatasets es el orden en que se exportan los datasets de cada símbolo
+var parquetDatasets = []string{ParquetDatasetHistoricalPrices, ParquetDatasetRatings}
+
+// historicalPriceColumns es el esquema de historical_prices/<SYMBOL>/<year>.parquet
+var historicalPriceColumns = []parquet.Column{
+	{Name: "symbol", Type: parquet.String},
+	{Name: "date", Type: parquet.Date},
+	{Name: "open", Type: parquet.Double},
+	{Name: "high", Type: parquet.Double},
+	{Name: "low", Type: parquet.Double},
+	{Name: "close", Type: parquet.Double},
+	{Name: "adjusted_close", Type: parquet.Double},
+	{Name: "volume", Type: parquet.Int64},
+	{Name: "daily_return", Type: parquet.Double},
+	{Name: "time_frame", Type: parquet.String},
+	{Name: "data_source", Type: parquet.String},
+}
+
+// ratingColumns es el esquema de ratings/<SYMBOL>/<year>.parquet; los campos vacíos se exportan como null
+var ratingColumns = []parquet.Column{
+	{Name: "id", Type: parquet.String},
+	{Name: "symbol", Type: parquet.String},
+	{Name: "event_time", Type: parquet.Timestamp},
+	{Name: "brokerage", Type: parquet.String, Optional: true},
+	{Name: "action", Type: parquet.String},
+	{Name: "rating_from", Type: parquet.String, Optional: true},
+	{Name: "rating_to", Type: parquet.String, Optional: true},
+	{Name: "target_from", Type: parquet.String, Optional: true},
+	{Name: "target_to", Type: parquet.String, Optional: true},
+	{Name: "source", Type: parquet.String},
+}
+
+// parquetExportService implements the ParquetExportService interface
+type parquetExportService struct {
+	store          domainServices.ObjectStore
+	companyRepo    repoInterfaces.CompanyRepository
+	brokerageRepo  repoInterfaces.BrokerageRepository
+	historicalRepo repoInterfaces.HistoricalDataRepository
+	ratingRepo     repoInterfaces.StockRatingRepository
+	destination    string
+	prefix         string
+	logger         logger.Logger
+	now            func() time.Time
+}
+
+// NewParquetExportService creates the Parquet export service; files are stored under prefix in the store
+func NewParquetExportService(
+	store domainServices.ObjectStore,
+	companyRepo repoInterfaces.CompanyRepository,
+	brokerageRepo repoInterfaces.BrokerageRepository,
+	historicalRepo repoInterfaces.HistoricalDataRepository,
+	ratingRepo repoInterfaces.StockRatingRepository,
+	destination string,
+	prefix string,
+	logger logger.Logger,
+) interfaces.ParquetExportService {
+	return &parquetExportService{
+		store:          store,
+		companyRepo:    companyRepo,
+		brokerageRepo:  brokerageRepo,
+		historicalRepo: historicalRepo,
+		ratingRepo:     ratingRepo,
+		destination:    destination,
+		prefix:         strings.Trim(prefix, "/"),
+		logger:         logger,
+		now:            time.Now,
+	}
+}
+
+// ValidateOptions rejects unknown datasets and invalid year ranges
+func (s *parquetExportService) ValidateOptions(options interfaces.ParquetExportOptions) error {
+	for _, dataset := range options.Datasets {
+		if dataset != ParquetDatasetHistoricalPrices && dataset != ParquetDatasetRatings {
+			return response.BadRequest(fmt.Sprintf("unknown dataset %q (expected %s or %s)",
+				dataset, ParquetDatasetHistoricalPrices, ParquetDatasetRatings))
+		}
+	}
+
+	fromYear, toYear := s.yearRange(options)
+	if toYear > s.now().UTC().Year() {
+		return response.BadRequest("to_year cannot be in the future")
+	}
+	if fromYear > toYear {
+		return response.BadRequest("from_year must not be after to_year")
+	}
+	return nil
+}
+
+// yearRange aplica los valores por defecto: el año en curso y los cuatro anteriores
+func (s *parquetExportService) yearRange(options interfaces.ParquetExportOptions) (int, int) {
+	toYear := options.ToYear
+	if toYear == 0 {
+		toYear = s.now().UTC().Year()
+	}
+	fromYear := options.FromYear
+	if fromYear == 0 {
+		fromYear = toYear - defaultParquetExportYears + 1
+	}
+	return fromYear, toYear
+}
+
+// Export writes one Parquet file per dataset, symbol and year. A symbol that fails is reported in
+// Errors and the export continues with the next one
+func (s *parquetExportService) Export(ctx context.Context, options interfaces.ParquetExportOptions) (*response.ParquetExportResponse, error) {
+	if err := s.ValidateOptions(options); err != nil {
+		return nil, err
+	}
+
+	datasets := parquetDatasets
+	if len(options.Datasets) > 0 {
+		datasets = options.Datasets
+	}
+	fromYear, toYear := s.yearRange(options)
+
+	companies, err := s.resolveCompanies(ctx, options.Symbols)
+	if err != nil {
+		return nil, err
+	}
+
+	started := s.now().UTC()
+	export := &response.ParquetExportResponse{
+		Destination: s.destination,
+		Prefix:      s.prefix,
+		Datasets:    datasets,
+		FromYear:    fromYear,
+		ToYear:      toYear,
+		Symbols:     len(companies),
+		Files:       make([]response.ParquetExportFileResponse, 0),
+		StartedAt:   started,
+	}
+
+	start := time.Date(fromYear, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(toYear+1, time.January, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond)
+	brokerages := make(map[uuid.UUID]string)
+
+	for _, company := range companies {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		for _, dataset := range datasets {
+			var files []response.ParquetExportFileResponse
+			var err error
+			switch dataset {
+			case ParquetDatasetHistoricalPrices:
+				files, err = s.exportHistoricalPrices(ctx, company, start, end)
+			case ParquetDatasetRatings:
+				files, err = s.exportRatings(ctx, company, start, end, brokerages)
+			}
+			if err != nil {
+				s.logger.Warn(ctx, "Parquet export failed for symbol",
+					logger.String("symbol", company.Ticker),
+					logger.String("dataset", dataset),
+					logger.String("error", err.Error()),
+				)
+				export.Errors = append(export.Errors, fmt.Sprintf("%s %s: %v", company.Ticker, dataset, err))
+				continue
+			}
+
+			for _, file := range files {
+				export.Files = append(export.Files, file)
+				export.TotalRows += file.Rows
+				export.TotalBytes += file.Bytes
+			}
+		}
+
+		export.Processed++
+		if options.OnProgress != nil {
+			options.OnProgress(*export)
+		}
+	}
+
+	export.CompletedAt = s.now().UTC()
+	export.DurationMs = export.CompletedAt.Sub(started).Milliseconds()
+
+	s.logger.Info(ctx, "Parquet export completed",
+		logger.String("destination", s.destination),
+		logger.Int("symbols", export.Symbols),
+		logger.Int("files", len(export.Files)),
+		logger.Int64("rows", export.TotalRows),
+		logger.Int("errors", len(export.Errors)),
+		logger.Int64("duration_ms", export.DurationMs),
+	)
+
+	return export, nil
+}
+
+// resolveCompanies busca los símbolos pedidos (resolviendo tickers antiguos) o todas las companies activas
+func (s *parquetExportService) resolveCompanies(ctx context.Context, symbols []string) ([]*entities.Company, error) {
+	if len(symbols) == 0 {
+		companies, err := s.companyRepo.GetAllActive(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list active companies: %w", err)
+		}
+		sort.Slice(companies, func(i, j int) bool { return companies[i].Ticker < companies[j].Ticker })
+		return companies, nil
+	}
+
+	companies := make([]*entities.Company, 0, len(symbols))
+	for _, symbol := range symbols {
+		company, err := s.companyRepo.GetByTicker(ctx, strings.ToUpper(strings.TrimSpace(symbol)))
+		if err != nil || company == nil {
+			return nil, response.NotFound(fmt.Sprintf("Company %s", symbol))
+		}
+		companies = append(companies, company)
+	}
+	return companies, nil
+}
+
+// exportHistoricalPrices escribe las sesiones de la company agrupadas por año
+func (s *parquetExportService) exportHistoricalPrices(ctx context.Context, company *entities.Company, start, end time.Time) ([]response.ParquetExportFileResponse, error) {
+	sessions, err := s.historicalRepo.GetByCompanyID(ctx, company.ID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read historical data: %w", err)
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].Date.Before(sessions[j].Date) })
+
+	byYear := make(map[int]*parquet.Writer)
+	var years []int
+	for _, session := range sessions {
+		year := session.Date.Year()
+		writer, ok := byYear[year]
+		if !ok {
+			writer = parquet.NewWriter(historicalPriceColumns, parquet.Gzip)
+			byYear[year] = writer
+			years = append(years, year)
+		}
+		err := writer.Append(
+			company.Ticker,
+			session.Date,
+			session.OpenPrice,
+			session.HighPrice,
+			session.LowPrice,
+			session.ClosePrice,
+			session.AdjustedClose,
+			session.Volume,
+			session.DailyReturn,
+			session.TimeFrame,
+			session.DataSource,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.uploadYears(ctx, ParquetDatasetHistoricalPrices, company.Ticker, years, byYear)
+}
+
+// exportRatings escribe los ratings de la company agrupados por el año del evento
+func (s *parquetExportService) exportRatings(ctx context.Context, company *entities.Company, start, end time.Time, brokerages map[uuid.UUID]string) ([]response.ParquetExportFileResponse, error) {
+	ratings, err := s.ratingRepo.GetByCompanyAndDateRange(ctx, company.ID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ratings: %w", err)
+	}
+	sort.Slice(ratings, func(i, j int) bool { return ratings[i].EventTime.Before(ratings[j].EventTime) })
+
+	byYear := make(map[int]*parquet.Writer)
+	var years []int
+	for _, rating := range ratings {
+		year := rating.EventTime.UTC().Year()
+		writer, ok := byYear[year]
+		if !ok {
+			writer = parquet.NewWriter(ratingColumns, parquet.Gzip)
+			byYear[year] = writer
+			years = append(years, year)
+		}
+		err := writer.Append(
+			rating.ID.String(),
+			company.Ticker,
+			rating.EventTime,
+			nullableString(s.brokerageName(ctx, rating.BrokerageID, brokerages)),
+			rating.Action,
+			nullableString(rating.RatingFrom),
+			nullableString(rating.RatingTo),
+			nullableString(rating.TargetFrom),
+			nullableString(rating.TargetTo),
+			rating.Source,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.uploadYears(ctx, ParquetDatasetRatings, company.Ticker, years, byYear)
+}
+
+// brokerageName resuelve el nombre de la brokerage una sola vez por exportación
+func (s *parquetExportService) brokerageName(ctx context.Context, id uuid.UUID, cache map[uuid.UUID]string) string {
+	if name, ok := cache[id]; ok {
+		return name
+	}
+	name := ""
+	if brokerage, err := s.brokerageRepo.GetByID(ctx, id); err == nil && brokerage != nil {
+		name = brokerage.Name
+	}
+	cache[id] = name
+	return name
+}
+
+// uploadYears serializa y sube un fichero por año, en orden cronológico
+func (s *parquetExportService) uploadYears(ctx context.Context, dataset, symbol string, years []int, byYear map[int]*parquet.Writer) ([]response.ParquetExportFileResponse, error) {
+	sort.Ints(years)
+	files := make([]response.ParquetExportFileResponse, 0, len(years))
+
+	for _, year := range years {
+		writer := byYear[year]
+		var buf bytes.Buffer
+		if _, err := writer.WriteTo(&buf); err != nil {
+			return nil, fmt.Errorf("failed to encode %d: %w", year, err)
+		}
+
+		key := ParquetExportKey(s.prefix, dataset, symbol, year)
+		size := int64(buf.Len())
+		if err := s.store.Put(ctx, key, &buf, size, "application/vnd.apache.parquet"); err != nil {
+			return nil, fmt.Errorf("failed to upload %s: %w", key, err)
+		}
+
+		files = append(files, response.ParquetExportFileResponse{
+			Dataset: dataset,
+			Symbol:  symbol,
+			Year:    year,
+			Key:     key,
+			Rows:    int64(writer.Rows()),
+			Bytes:   size,
+		})
+	}
+	return files, nil
+}
+
+// ParquetExportKey returns the key of a Parquet file: <prefix>/<dataset>/<SYMBOL>/<year>.parquet
+func ParquetExportKey(prefix, dataset, symbol string, year int) string {
+	key := fmt.Sprintf("%s/%s/%d.parquet", dataset, symbol, year)
+	if prefix == "" {
+		return key
+	}
+	return prefix + "/" + key
+}
+
+// nullableString exporta las cadenas vacías como null
+func nullableString(value string) any {
+	if value == "" {
+		return nil
+	}
+	return value
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+const (
+	// DefaultReplayLimit es el número de payloads re-procesados cuando no se indican IDs ni límite
+	DefaultReplayLimit = 100
+
+	// maxReplayErrors limita los errores devueltos en el resultado del replay
+	maxReplayErrors = 20
+)
+
+// payloadArchiveService implements the PayloadArchiveService interface
+type payloadArchiveService struct {
+	repo              repoInterfaces.ProviderPayloadRepository
+	marketDataService interfaces.MarketDataService
+	logger            logger.Logger
+}
+
+// NewPayloadArchiveService creates a new provider payload archive service
+func NewPayloadArchiveService(
+	repo repoInterfaces.ProviderPayloadRepository,
+	marketDataService interfaces.MarketDataService,
+	logger logger.Logger,
+) interfaces.PayloadArchiveService {
+	return &payloadArchiveService{
+		repo:              repo,
+		marketDataService: marketDataService,
+		logger:            logger,
+	}
+}
+
+// ListPayloads lists the archived responses matching the filters, newest first, without their body
+func (s *payloadArchiveService) ListPayloads(ctx context.Context, filter *request.PayloadArchiveFilterRequest, limit, offset int) ([]*response.ProviderPayloadResponse, int64, error) {
+	payloadFilter := repoInterfaces.ProviderPayloadFilter{
+		Provider: filter.Provider,
+		Endpoint: filter.Endpoint,
+		Symbol:   strings.ToUpper(strings.TrimSpace(filter.Symbol)),
+	}
+	if err := parsePayloadSince(filter.Since, &payloadFilter); err != nil {
+		return nil, 0, err
+	}
+
+	payloads, err := s.repo.List(ctx, payloadFilter, limit, offset)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list provider payloads", err)
+		return nil, 0, response.InternalServerError("Failed to list provider payloads")
+	}
+	total, err := s.repo.Count(ctx, payloadFilter)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to count provider payloads", err)
+		return nil, 0, response.InternalServerError("Failed to count provider payloads")
+	}
+
+	items := make([]*response.ProviderPayloadResponse, len(payloads))
+	for i, payload := range payloads {
+		items[i] = toProviderPayloadResponse(payload)
+	}
+	return items, total, nil
+}
+
+// GetPayload returns an archived response including its decompressed body
+func (s *payloadArchiveService) GetPayload(ctx context.Context, id uuid.UUID) (*response.ProviderPayloadResponse, error) {
+	payload, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if domainerrors.IsNotFound(err) {
+			return nil, response.NotFound("Provider payload")
+		}
+		s.logger.Error(ctx, "Failed to get provider payload", err,
+			logger.String("payload_id", id.String()))
+		return nil, response.InternalServerError("Failed to get provider payload")
+	}
+
+	raw, err := payload.Raw()
+	if err != nil {
+		s.logger.Error(ctx, "Failed to read provider payload", err,
+			logger.String("payload_id", id.String()))
+		return nil, response.InternalServerError("Failed to read provider payload")
+	}
+
+	item := toProviderPayloadResponse(payload)
+	if json.Valid(raw) {
+		item.Payload = raw
+	} else {
+		// Respuestas que no son JSON (p. ej. CSV de Alpha Vantage) se devuelven como cadena
+		item.Payload, _ = json.Marshal(string(raw))
+	}
+	return item, nil
+}
+
+// ReplayPayloads re-processes archived Finnhub responses, oldest first so the newest one is the data left stored.
+// Without IDs the most recent payloads matching the filters are replayed, up to the limit. Payloads that are
+// unknown, expired or not replayable count as skipped
+func (s *payloadArchiveService) ReplayPayloads(ctx context.Context, req *request.ReplayPayloadsRequest) (*response.ReplayPayloadsResponse, error) {
+	ids := req.IDs
+	result := &response.ReplayPayloadsResponse{Requested: len(ids)}
+
+	if len(ids) == 0 {
+		filter := repoInterfaces.ProviderPayloadFilter{
+			Provider: entities.PayloadProviderFinnhub,
+			Endpoint: req.Endpoint,
+			Symbol:   strings.ToUpper(strings.TrimSpace(req.Symbol)),
+		}
+		if err := parsePayloadSince(req.Since, &filter); err != nil {
+			return nil, err
+		}
+		limit := DefaultReplayLimit
+		if req.Limit != nil {
+			limit = *req.Limit
+		}
+
+		listed, err := s.repo.List(ctx, filter, limit, 0)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to list provider payloads to replay", err)
+			return nil, response.InternalServerError("Failed to list provider payloads")
+		}
+		for _, payload := range listed {
+			ids = append(ids, payload.ID)
+		}
+		result.Requested = len(ids)
+	}
+
+	payloads, err := s.repo.GetByIDs(ctx, ids)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get provider payloads to replay", err)
+		return nil, response.InternalServerError("Failed to get provider payloads")
+	}
+	result.Skipped = result.Requested - len(payloads)
+
+	for _, payload := range payloads {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if payload.Provider != entities.PayloadProviderFinnhub {
+			result.Skipped++
+			continue
+		}
+		if err := s.marketDataService.ReplayPayload(ctx, payload); err != nil {
+			result.Failed++
+			if len(result.Errors) < maxReplayErrors {
+				result.Errors = append(result.Errors, payload.ID.String()+": "+err.Error())
+			}
+			continue
+		}
+		result.Replayed++
+	}
+
+	s.logger.Info(ctx, "Provider payload replay completed",
+		logger.Int("requested", result.Requested),
+		logger.Int("replayed", result.Replayed),
+		logger.Int("failed", result.Failed),
+		logger.Int("skipped", result.Skipped))
+
+	return result, nil
+}
+
+// parsePayloadSince aplica al filtro el instante since (RFC 3339) si se indicó
+func parsePayloadSince(since string, filter *repoInterfaces.ProviderPayloadFilter) error {
+	if since == "" {
+		return nil
+	}
+	parsed, err := time.Parse(time.RFC3339, since)
+	if err != nil {
+		return response.BadRequest("since must be an RFC 3339 timestamp")
+	}
+	filter.Since = parsed
+	return nil
+}
+
+// toProviderPayloadResponse convierte un payload archivado en su DTO, sin el cuerpo
+func toProviderPayloadResponse(payload *entities.ProviderPayload) *response.ProviderPayloadResponse {
+	return &response.ProviderPayloadResponse{
+		ID:        payload.ID,
+		Provider:  payload.Provider,
+		Endpoint:  payload.Endpoint,
+		Symbol:    payload.Symbol,
+		Params:    payload.Params,
+		RequestID: payload.RequestID,
+		Size:      payload.Size,
+		FetchedAt: payload.FetchedAt,
+		ExpiresAt: payload.ExpiresAt,
+	}
+}
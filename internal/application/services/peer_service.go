@@ -0,0 +1,224 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/finnhub"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+const (
+	// maxPeers es el número máximo de competidores guardados por company
+	maxPeers = 10
+
+	// defaultPeersTTL es el tiempo tras el que se vuelven a descubrir los peers si no se configura otro
+	defaultPeersTTL = 7 * 24 * time.Hour
+)
+
+// peerService implements the PeerService interface
+type peerService struct {
+	companyRepo   repoInterfaces.CompanyRepository
+	peerRepo      repoInterfaces.CompanyPeerRepository
+	finnhubClient *finnhub.Client // nil: solo la heurística de sector y capitalización
+	logger        logger.Logger
+
+	ttl   time.Duration
+	ttlMu sync.RWMutex
+}
+
+// NewPeerService creates a new peer discovery service. Stored peers older than ttl are rediscovered
+func NewPeerService(
+	companyRepo repoInterfaces.CompanyRepository,
+	peerRepo repoInterfaces.CompanyPeerRepository,
+	finnhubClient *finnhub.Client,
+	ttl time.Duration,
+	logger logger.Logger,
+) interfaces.PeerService {
+	service := &peerService{
+		companyRepo:   companyRepo,
+		peerRepo:      peerRepo,
+		finnhubClient: finnhubClient,
+		logger:        logger,
+	}
+	service.UpdateTTL(ttl)
+	return service
+}
+
+// UpdateTTL reemplaza el TTL del grafo de peers; un valor no positivo usa defaultPeersTTL
+func (s *peerService) UpdateTTL(ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = defaultPeersTTL
+	}
+	s.ttlMu.Lock()
+	defer s.ttlMu.Unlock()
+	s.ttl = ttl
+}
+
+func (s *peerService) currentTTL() time.Duration {
+	s.ttlMu.RLock()
+	defer s.ttlMu.RUnlock()
+	return s.ttl
+}
+
+// GetPeers returns the competitors of a company from the stored peer graph, rediscovering them when
+// they are missing or older than the TTL. If rediscovery fails the stored peers are served as they are
+func (s *peerService) GetPeers(ctx context.Context, ticker string) (*response.CompanyPeersResponse, error) {
+	company, err := s.companyRepo.GetByTicker(ctx, ticker)
+	if err != nil {
+		return nil, response.FromError(err, "Company", "Failed to get company")
+	}
+
+	peers, err := s.peerRepo.GetByCompanyID(ctx, company.ID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get stored peers", err,
+			logger.String("ticker", company.Ticker))
+		return nil, response.InternalServerError("Failed to get peers")
+	}
+
+	if len(peers) == 0 || time.Since(peers[0].UpdatedAt) > s.currentTTL() {
+		discovered, err := s.discoverPeers(ctx, company)
+		if err == nil {
+			err = s.peerRepo.ReplaceForCompany(ctx, company.ID, discovered)
+		}
+		if err == nil {
+			peers, err = s.peerRepo.GetByCompanyID(ctx, company.ID)
+		}
+		if err != nil {
+			if len(peers) == 0 {
+				s.logger.Error(ctx, "Failed to discover peers", err,
+					logger.String("ticker", company.Ticker))
+				return nil, response.InternalServerError("Failed to get peers")
+			}
+			s.logger.Warn(ctx, "Failed to rediscover peers, serving stored ones",
+				logger.String("ticker", company.Ticker),
+				logger.String("error", err.Error()))
+		}
+	}
+
+	return convertToPeersResponse(company, peers), nil
+}
+
+// discoverPeers pide los peers a Finnhub y, si no devuelve ninguno conocido, recurre a la heurística
+func (s *peerService) discoverPeers(ctx context.Context, company *entities.Company) ([]*entities.CompanyPeer, error) {
+	var peers []*entities.CompanyPeer
+
+	if s.finnhubClient != nil {
+		symbols, err := s.finnhubClient.GetPeers(ctx, company.Ticker)
+		if err != nil {
+			// Sin Finnhub (cuota, caída) se sigue con la heurística
+			s.logger.Warn(ctx, "Finnhub peers unavailable, using sector heuristic",
+				logger.String("ticker", company.Ticker),
+				logger.String("error", err.Error()))
+		}
+
+		seen := map[string]bool{company.Ticker: true}
+		for _, symbol := range symbols {
+			symbol = strings.ToUpper(strings.TrimSpace(symbol))
+			if symbol == "" || seen[symbol] {
+				continue
+			}
+			seen[symbol] = true
+
+			// Solo se enlazan peers que ya existen como companies
+			peer, err := s.companyRepo.GetByTicker(ctx, symbol)
+			if err != nil {
+				if domainerrors.IsNotFound(err) {
+					continue
+				}
+				return nil, err
+			}
+			if peer.ID == company.ID || !peer.IsActive {
+				continue
+			}
+
+			peers = append(peers, entities.NewCompanyPeer(company.ID, peer.ID, entities.PeerSourceFinnhub, len(peers)+1))
+			if len(peers) == maxPeers {
+				break
+			}
+		}
+	}
+
+	if len(peers) > 0 || company.Sector == "" {
+		return peers, nil
+	}
+
+	candidates, err := s.companyRepo.GetBySector(ctx, company.Sector)
+	if err != nil {
+		return nil, err
+	}
+	for i, peer := range RankPeersByMarketCap(company, candidates, maxPeers) {
+		peers = append(peers, entities.NewCompanyPeer(company.ID, peer.ID, entities.PeerSourceHeuristic, i+1))
+	}
+
+	return peers, nil
+}
+
+// RankPeersByMarketCap returns up to limit active candidates other than company, ordered by how close their
+// market cap is to the company's (log scale). Candidates without market cap go last, by ticker
+func RankPeersByMarketCap(company *entities.Company, candidates []*entities.Company, limit int) []*entities.Company {
+	distance := func(candidate *entities.Company) float64 {
+		if company.MarketCap <= 0 || candidate.MarketCap <= 0 {
+			return math.Inf(1)
+		}
+		return math.Abs(math.Log(candidate.MarketCap) - math.Log(company.MarketCap))
+	}
+
+	ranked := make([]*entities.Company, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate.ID == company.ID || !candidate.IsActive {
+			continue
+		}
+		ranked = append(ranked, candidate)
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		di, dj := distance(ranked[i]), distance(ranked[j])
+		if di != dj {
+			return di < dj
+		}
+		return ranked[i].Ticker < ranked[j].Ticker
+	})
+
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	return ranked
+}
+
+func convertToPeersResponse(company *entities.Company, peers []*entities.CompanyPeer) *response.CompanyPeersResponse {
+	peersResponse := &response.CompanyPeersResponse{
+		CompanyID: company.ID,
+		Ticker:    company.Ticker,
+		Peers:     make([]response.PeerResponse, len(peers)),
+	}
+
+	for i, peer := range peers {
+		peersResponse.Peers[i] = response.PeerResponse{
+			ID:        peer.Peer.ID,
+			Ticker:    peer.Peer.Ticker,
+			Name:      peer.Peer.Name,
+			Sector:    peer.Peer.Sector,
+			Exchange:  peer.Peer.Exchange,
+			MarketCap: peer.Peer.MarketCap,
+			Rank:      peer.Rank,
+		}
+	}
+
+	if len(peers) > 0 {
+		discoveredAt := peers[0].UpdatedAt
+		peersResponse.Source = peers[0].Source
+		peersResponse.DiscoveredAt = &discoveredAt
+	}
+
+	return peersResponse
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/events"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// ratingBacklogWebhookTimeout limita cada envío de la alerta al webhook
+const ratingBacklogWebhookTimeout = 10 * time.Second
+
+// RatingBacklogNotifier delivers the rating.backlog_exceeded alerts: it logs each one as an error and, with a webhook
+// URL, POSTs the event as JSON
+type RatingBacklogNotifier struct {
+	webhookURL string
+	client     *http.Client
+	logger     logger.Logger
+}
+
+// NewRatingBacklogNotifier creates the notifier and subscribes it to the rating.backlog_exceeded events of the bus;
+// an empty webhookURL only logs the alerts
+func NewRatingBacklogNotifier(bus events.Bus, webhookURL string, appLogger logger.Logger) *RatingBacklogNotifier {
+	n := &RatingBacklogNotifier{
+		webhookURL: webhookURL,
+		client:     &http.Client{Timeout: ratingBacklogWebhookTimeout},
+		logger:     appLogger,
+	}
+
+	if bus != nil {
+		if _, err := bus.Subscribe(events.RatingBacklogExceeded, n.Notify); err != nil {
+			appLogger.Warn(context.Background(), "Failed to subscribe to rating backlog alerts",
+				logger.String("error", err.Error()))
+		}
+	}
+	return n
+}
+
+// Notify logs the alert and sends it to the webhook; a failed delivery is returned so the bus logs it
+func (n *RatingBacklogNotifier) Notify(ctx context.Context, event events.Event) error {
+	var payload events.RatingBacklogExceededPayload
+	if err := event.Decode(&payload); err != nil {
+		return err
+	}
+
+	fields := []logger.Field{
+		logger.Int64("backlog", payload.Unprocessed),
+		logger.Int64("previous", payload.Previous),
+		logger.Int64("threshold", payload.Threshold),
+	}
+	if payload.OldestUnprocessedAt != nil {
+		fields = append(fields, logger.String("oldest_unprocessed_at", payload.OldestUnprocessedAt.Format(time.RFC3339)))
+	}
+	n.logger.Error(ctx, "Rating processing backlog alert", nil, fields...)
+
+	if n.webhookURL == "" {
+		return nil
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode rating backlog alert: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create rating backlog webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send rating backlog webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("rating backlog webhook answered %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/events"
+	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// Valores por defecto del long-poll de ratings; la espera máxima (25s, validada en la petición) queda por debajo
+// de SERVER_WRITE_TIMEOUT
+const (
+	DefaultRatingPollWait  = 20 * time.Second
+	DefaultRatingPollLimit = 100
+)
+
+// ratingPollOverlap es la ventana anterior a since_id que cada respuesta vuelve a leer: created_at se fija al
+// empezar la transacción, así que un lote que confirma tarde queda detrás de un cursor ya entregado. Los ratings
+// de la ventana se repiten entre respuestas y el cliente los deduplica por id
+const (
+	ratingPollOverlap      = time.Minute
+	ratingPollOverlapLimit = 500
+)
+
+// ratingPollService implements the RatingPollService interface
+type ratingPollService struct {
+	stockRatingRepo repoInterfaces.StockRatingRepository
+	lister          *stockRatingService // Conversión a list responses, igual que GET /ratings
+	logger          logger.Logger
+
+	mu      sync.Mutex
+	created chan struct{} // Se cierra (y se reemplaza) con cada rating.created: despierta a todas las esperas
+}
+
+// NewRatingPollService creates the long-poll service and subscribes it to the rating.created events of the bus.
+// Without a bus (or if the subscription fails) a poll only sees new ratings when its wait ends
+func NewRatingPollService(
+	stockRatingRepo repoInterfaces.StockRatingRepository,
+	companyRepo repoInterfaces.CompanyRepository,
+	brokerageRepo repoInterfaces.BrokerageRepository,
+	bus events.Bus,
+	appLogger logger.Logger,
+) interfaces.RatingPollService {
+	s := &ratingPollService{
+		stockRatingRepo: stockRatingRepo,
+		lister: &stockRatingService{
+			stockRatingRepo: stockRatingRepo,
+			companyRepo:     companyRepo,
+			brokerageRepo:   brokerageRepo,
+			logger:          appLogger,
+		},
+		logger:  appLogger,
+		created: make(chan struct{}),
+	}
+
+	if bus != nil {
+		if _, err := bus.Subscribe(events.RatingCreated, s.onRatingCreated); err != nil {
+			appLogger.Warn(context.Background(), "Failed to subscribe rating poll to rating created events",
+				logger.String("error", err.Error()))
+		}
+	}
+	return s
+}
+
+// onRatingCreated despierta las esperas en curso; cada una vuelve a consultar desde su cursor
+func (s *ratingPollService) onRatingCreated(_ context.Context, _ events.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	close(s.created)
+	s.created = make(chan struct{})
+	return nil
+}
+
+// createdSignal devuelve el canal que se cerrará con el próximo rating.created
+func (s *ratingPollService) createdSignal() <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.created
+}
+
+// Poll returns the ratings created after since_id (or after the request started) as soon as there is one. A
+// rating.created event triggers a new query; when the wait ends the ratings are queried a last time, which also
+// catches ratings stored without an event (e.g. POST /stocks). With since_id the response also repeats the ratings
+// of the overlap window before it, so a rating committed late is not skipped; since_id may be a deleted rating
+func (s *ratingPollService) Poll(ctx context.Context, req *request.RatingPollRequest, includes request.RatingIncludes) (*response.RatingPollResponse, error) {
+	wait := DefaultRatingPollWait
+	if req.Wait > 0 {
+		wait = time.Duration(req.Wait) * time.Second
+	}
+	limit := DefaultRatingPollLimit
+	if req.Limit > 0 {
+		limit = req.Limit
+	}
+
+	afterCreatedAt, afterID := time.Now().UTC(), uuid.Nil
+	if req.SinceID != nil {
+		// Un since_id eliminado después de entregarse sigue sirviendo de cursor
+		since, err := s.stockRatingRepo.GetByIDWithDeleted(ctx, *req.SinceID)
+		if err != nil {
+			return nil, response.FromError(err, "Stock rating", "Failed to get since_id rating")
+		}
+		afterCreatedAt, afterID = since.CreatedAt, since.ID
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	for timedOut := false; ; {
+		// La señal se toma antes de consultar: un evento durante la consulta no se pierde
+		created := s.createdSignal()
+		ratings, err := s.stockRatingRepo.ListCreatedAfter(ctx, afterCreatedAt, afterID, limit)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to poll new stock ratings", err)
+			return nil, response.InternalServerError("Failed to poll new ratings")
+		}
+		if len(ratings) > 0 || timedOut {
+			var late []*entities.StockRating
+			if req.SinceID != nil {
+				if late, err = s.lateRatings(ctx, afterCreatedAt, afterID); err != nil {
+					s.logger.Error(ctx, "Failed to poll late stock ratings", err)
+					return nil, response.InternalServerError("Failed to poll new ratings")
+				}
+			}
+			return s.pollResponse(ctx, late, ratings, req.SinceID, includes), nil
+		}
+
+		select {
+		case <-created:
+		case <-timer.C:
+			timedOut = true
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// lateRatings devuelve los ratings de la ventana de solapamiento anterior al cursor (afterCreatedAt, afterID). Se
+// consultan aparte de los nuevos para que la ventana no consuma el limit de la respuesta
+func (s *ratingPollService) lateRatings(ctx context.Context, afterCreatedAt time.Time, afterID uuid.UUID) ([]*entities.StockRating, error) {
+	window, err := s.stockRatingRepo.ListCreatedAfter(ctx, afterCreatedAt.Add(-ratingPollOverlap), uuid.Nil, ratingPollOverlapLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	late := window[:0]
+	for _, rating := range window {
+		if rating.CreatedAt.Before(afterCreatedAt) || (rating.CreatedAt.Equal(afterCreatedAt) && bytes.Compare(rating.ID[:], afterID[:]) < 0) {
+			late = append(late, rating)
+		}
+	}
+	return late, nil
+}
+
+// pollResponse convierte los ratings tardíos y los nuevos, deduplicados por id; el cursor avanza al último rating
+// nuevo y sin ratings nuevos sigue siendo since_id
+func (s *ratingPollService) pollResponse(ctx context.Context, late, ratings []*entities.StockRating, sinceID *uuid.UUID, includes request.RatingIncludes) *response.RatingPollResponse {
+	seen := make(map[uuid.UUID]bool, len(late)+len(ratings))
+	merged := make([]*entities.StockRating, 0, len(late)+len(ratings))
+	for _, rating := range slices.Concat(late, ratings) {
+		if !seen[rating.ID] {
+			seen[rating.ID] = true
+			merged = append(merged, rating)
+		}
+	}
+
+	result := &response.RatingPollResponse{
+		Ratings:     s.lister.toListResponses(ctx, merged, includes),
+		NextSinceID: sinceID,
+		TimedOut:    len(ratings) == 0,
+	}
+	if len(ratings) > 0 {
+		last := ratings[len(ratings)-1].ID
+		result.NextSinceID = &last
+	}
+	return result
+}
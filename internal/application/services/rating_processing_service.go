@@ -0,0 +1,364 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/events"
+	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// Valores por defecto y límites de una ejecución del procesado de ratings
+const (
+	DefaultRatingProcessingBatchSize   = 500
+	DefaultRatingProcessingConcurrency = 4
+	maxRatingProcessingBatchSize       = 5000
+	maxRatingProcessingConcurrency     = 32
+)
+
+// processingThroughputWindows son las ventanas del throughput reciente del estado del procesado
+var processingThroughputWindows = []struct {
+	label    string
+	duration time.Duration
+}{
+	{"15m", 15 * time.Minute},
+	{"1h", time.Hour},
+	{"24h", 24 * time.Hour},
+}
+
+// ratingProcessingService implements the RatingProcessingService interface
+type ratingProcessingService struct {
+	stockRatingRepo repoInterfaces.StockRatingRepository
+	eventPublisher  events.Publisher // Opcional: publica RatingProcessed por cada rating procesado y la alerta del backlog
+	threshold       int64            // Backlog a partir del cual se alerta; 0 la desactiva
+	alertCooldown   time.Duration    // Tiempo mínimo entre dos alertas
+	logger          logger.Logger
+
+	// Última alerta: solo se vuelve a alertar si el backlog sigue creciendo; bajar del umbral la rearma
+	alertMu     sync.Mutex
+	lastAlerted int64
+	lastAlertAt time.Time
+}
+
+// NewRatingProcessingService creates the service that consumes the unprocessed ratings backlog; backlogThreshold
+// 0 disables the backlog alert and alertCooldown is the minimum time between two alerts
+func NewRatingProcessingService(stockRatingRepo repoInterfaces.StockRatingRepository, eventPublisher events.Publisher, backlogThreshold int64, alertCooldown time.Duration, logger logger.Logger) interfaces.RatingProcessingService {
+	return &ratingProcessingService{
+		stockRatingRepo: stockRatingRepo,
+		eventPublisher:  eventPublisher,
+		threshold:       backlogThreshold,
+		alertCooldown:   alertCooldown,
+		logger:          logger,
+	}
+}
+
+// ValidateOptions rejects negative or too large batch sizes and concurrency
+func (s *ratingProcessingService) ValidateOptions(options interfaces.RatingProcessingOptions) error {
+	switch {
+	case options.BatchSize < 0 || options.BatchSize > maxRatingProcessingBatchSize:
+		return response.BadRequest(fmt.Sprintf("batch size must be between 0 and %d", maxRatingProcessingBatchSize))
+	case options.Concurrency < 0 || options.Concurrency > maxRatingProcessingConcurrency:
+		return response.BadRequest(fmt.Sprintf("concurrency must be between 0 and %d", maxRatingProcessingConcurrency))
+	case options.MaxBatches < 0:
+		return response.BadRequest("max batches cannot be negative")
+	}
+	return nil
+}
+
+// Process consumes the backlog oldest first until it is empty, MaxBatches is reached or a batch makes no progress
+func (s *ratingProcessingService) Process(ctx context.Context, options interfaces.RatingProcessingOptions) (*response.RatingProcessingResponse, error) {
+	if err := s.ValidateOptions(options); err != nil {
+		return nil, err
+	}
+	if options.BatchSize == 0 {
+		options.BatchSize = DefaultRatingProcessingBatchSize
+	}
+	if options.Concurrency == 0 {
+		options.Concurrency = DefaultRatingProcessingConcurrency
+	}
+
+	start := time.Now()
+	backlog, err := s.stockRatingRepo.CountUnprocessed(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := &response.RatingProcessingResponse{BacklogBefore: backlog}
+
+	for options.MaxBatches == 0 || result.Batches < options.MaxBatches {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		batch, err := s.stockRatingRepo.GetProcessingBatch(ctx, options.BatchSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		processed, err := s.processBatch(ctx, batch, options.Concurrency, result)
+		if err != nil {
+			return nil, err
+		}
+		result.Batches++
+		if options.OnProgress != nil {
+			options.OnProgress(*result)
+		}
+
+		// Un lote sin progreso se repetiría tal cual: los fallidos esperan a la próxima ejecución
+		if processed == 0 || len(batch) < options.BatchSize {
+			break
+		}
+	}
+
+	if result.BacklogAfter, err = s.stockRatingRepo.CountUnprocessed(ctx); err != nil {
+		return nil, err
+	}
+	// Se alerta al cruzar el umbral o si el backlog sigue creciendo desde la última alerta
+	if s.shouldAlert(result.BacklogAfter, time.Now()) {
+		result.Alerted = s.alertBacklog(ctx, result.BacklogBefore, result.BacklogAfter)
+	}
+	result.DurationMs = time.Since(start).Milliseconds()
+
+	s.logger.Info(ctx, "Rating processing completed",
+		logger.Int64("backlog_before", result.BacklogBefore),
+		logger.Int64("backlog_after", result.BacklogAfter),
+		logger.Int("batches", result.Batches),
+		logger.Int("processed", result.Processed),
+		logger.Int("normalized", result.Normalized),
+		logger.Int("failed", result.Failed),
+		logger.Int64("duration_ms", result.DurationMs),
+	)
+	return result, nil
+}
+
+// processBatch normaliza los ratings del lote con concurrency workers, marca como procesados los que se
+// guardaron y publica sus eventos; devuelve cuántos se marcaron
+func (s *ratingProcessingService) processBatch(ctx context.Context, batch []*entities.StockRating, concurrency int, result *response.RatingProcessingResponse) (int, error) {
+	ratings := make(chan *entities.StockRating)
+	var (
+		mu         sync.Mutex
+		processed  []*entities.StockRating
+		normalized int
+		failed     int
+		wg         sync.WaitGroup
+	)
+
+	for i := 0; i < min(concurrency, len(batch)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for rating := range ratings {
+				changed, err := s.processRating(ctx, rating)
+
+				mu.Lock()
+				if err != nil {
+					failed++
+				} else {
+					processed = append(processed, rating)
+					if changed {
+						normalized++
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, rating := range batch {
+		ratings <- rating
+	}
+	close(ratings)
+	wg.Wait()
+
+	ids := make([]uuid.UUID, len(processed))
+	for i, rating := range processed {
+		ids[i] = rating.ID
+	}
+	if err := s.stockRatingRepo.MarkManyAsProcessed(ctx, ids); err != nil {
+		return 0, err
+	}
+
+	result.Processed += len(processed)
+	result.Normalized += normalized
+	result.Failed += failed
+	result.Events += s.publishProcessed(ctx, processed)
+	return len(processed), nil
+}
+
+// processRating aplica la normalización de los hooks (acción, escala de ratings, precios objetivo) y guarda el
+// rating solo si cambió. Un fallo (ej. editado a la vez) deja el rating pendiente
+func (s *ratingProcessingService) processRating(ctx context.Context, rating *entities.StockRating) (bool, error) {
+	before := *rating
+	rating.Normalize()
+	if !ratingNormalizationChanged(&before, rating) {
+		return false, nil
+	}
+
+	if err := s.stockRatingRepo.Update(ctx, rating); err != nil {
+		s.logger.Warn(ctx, "Failed to save processed rating",
+			logger.String("rating_id", rating.ID.String()),
+			logger.String("error", err.Error()))
+		return false, err
+	}
+	return true, nil
+}
+
+// ratingNormalizationChanged compara las columnas que escribe la normalización
+func ratingNormalizationChanged(before, after *entities.StockRating) bool {
+	return before.Action != after.Action || before.ActionType != after.ActionType ||
+		before.RatingFrom != after.RatingFrom || before.RatingTo != after.RatingTo ||
+		before.NormalizedFrom != after.NormalizedFrom || before.NormalizedTo != after.NormalizedTo ||
+		before.TargetCurrency != after.TargetCurrency ||
+		!equalTargets(before.TargetFromValue, after.TargetFromValue) || !equalTargets(before.TargetToValue, after.TargetToValue)
+}
+
+func equalTargets(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// publishProcessed publica un evento RatingProcessed por cada rating procesado; un fallo solo se registra.
+// Devuelve los eventos publicados
+func (s *ratingProcessingService) publishProcessed(ctx context.Context, ratings []*entities.StockRating) int {
+	if s.eventPublisher == nil || len(ratings) == 0 {
+		return 0
+	}
+
+	batch := make([]events.Event, 0, len(ratings))
+	for _, rating := range ratings {
+		event, err := events.NewRatingProcessed(rating)
+		if err != nil {
+			s.logger.Warn(ctx, "Failed to build rating processed event",
+				logger.String("rating_id", rating.ID.String()),
+				logger.String("error", err.Error()))
+			continue
+		}
+		batch = append(batch, event)
+	}
+
+	if err := s.eventPublisher.Publish(ctx, batch...); err != nil {
+		s.logger.Warn(ctx, "Failed to publish rating processed events",
+			logger.Int("events", len(batch)),
+			logger.String("error", err.Error()))
+		return 0
+	}
+	return len(batch)
+}
+
+// overThreshold indica si el backlog supera el umbral configurado
+func (s *ratingProcessingService) overThreshold(backlog int64) bool {
+	return s.threshold > 0 && backlog > s.threshold
+}
+
+// shouldAlert decide si el backlog que deja una ejecución merece una alerta: por encima del umbral y mayor que el
+// de la última alerta (la primera vez, al cruzarlo), y nunca antes de que pase el cooldown. Bajar del umbral
+// rearma la alerta
+func (s *ratingProcessingService) shouldAlert(unprocessed int64, now time.Time) bool {
+	s.alertMu.Lock()
+	defer s.alertMu.Unlock()
+
+	if !s.overThreshold(unprocessed) {
+		s.lastAlerted = 0
+		return false
+	}
+	if unprocessed <= s.lastAlerted {
+		return false
+	}
+	if !s.lastAlertAt.IsZero() && now.Sub(s.lastAlertAt) < s.alertCooldown {
+		return false
+	}
+	s.lastAlerted, s.lastAlertAt = unprocessed, now
+	return true
+}
+
+// alertBacklog registra y publica la alerta de un backlog por encima del umbral. Devuelve si el evento se publicó
+func (s *ratingProcessingService) alertBacklog(ctx context.Context, previous, unprocessed int64) bool {
+	oldestAt, err := s.oldestUnprocessedAt(ctx)
+	if err != nil {
+		s.logger.Warn(ctx, "Failed to get oldest unprocessed rating", logger.String("error", err.Error()))
+	}
+
+	fields := []logger.Field{
+		logger.Int64("backlog", unprocessed),
+		logger.Int64("previous", previous),
+		logger.Int64("threshold", s.threshold),
+	}
+	if oldestAt != nil {
+		fields = append(fields, logger.String("oldest_unprocessed_at", oldestAt.Format(time.RFC3339)))
+	}
+	s.logger.Warn(ctx, "Rating processing backlog over threshold", fields...)
+
+	if s.eventPublisher == nil {
+		return false
+	}
+	event, err := events.NewRatingBacklogExceeded(unprocessed, previous, s.threshold, oldestAt)
+	if err == nil {
+		err = s.eventPublisher.Publish(ctx, event)
+	}
+	if err != nil {
+		s.logger.Warn(ctx, "Failed to publish rating backlog exceeded event", logger.String("error", err.Error()))
+		return false
+	}
+	return true
+}
+
+// oldestUnprocessedAt devuelve cuándo se guardó el rating pendiente más antiguo, o nil sin backlog
+func (s *ratingProcessingService) oldestUnprocessedAt(ctx context.Context) (*time.Time, error) {
+	oldest, err := s.stockRatingRepo.GetUnprocessed(ctx, 1)
+	if err != nil || len(oldest) == 0 {
+		return nil, err
+	}
+	createdAt := oldest[0].CreatedAt
+	return &createdAt, nil
+}
+
+// GetStatus returns the backlog per source, the age of the oldest unprocessed rating, the ratings processed in the
+// last 15 minutes, hour and day, and whether the backlog is over the alert threshold
+func (s *ratingProcessingService) GetStatus(ctx context.Context) (*response.ProcessingStatusResponse, error) {
+	bySource, err := s.stockRatingRepo.CountUnprocessedBySource(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result := &response.ProcessingStatusResponse{
+		BySource:   bySource,
+		Threshold:  s.threshold,
+		Throughput: make([]response.ProcessingThroughputWindow, 0, len(processingThroughputWindows)),
+	}
+	for _, count := range bySource {
+		result.Unprocessed += count
+	}
+	result.OverThreshold = s.overThreshold(result.Unprocessed)
+
+	if result.OldestUnprocessedAt, err = s.oldestUnprocessedAt(ctx); err != nil {
+		return nil, err
+	}
+	if result.OldestUnprocessedAt != nil {
+		result.OldestAgeMs = now.Sub(*result.OldestUnprocessedAt).Milliseconds()
+	}
+
+	for _, window := range processingThroughputWindows {
+		processed, err := s.stockRatingRepo.CountProcessedSince(ctx, now.Add(-window.duration))
+		if err != nil {
+			return nil, err
+		}
+		result.Throughput = append(result.Throughput, response.ProcessingThroughputWindow{
+			Window:    window.label,
+			Processed: processed,
+			PerMinute: float64(processed) / window.duration.Minutes(),
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// maxUnmappedRatings limita las variantes sin mapeo devueltas a los administradores
+const maxUnmappedRatings = 50
+
+// ratingTaxonomyService implements the RatingTaxonomyService interface
+type ratingTaxonomyService struct {
+	mappingRepo repoInterfaces.RatingMappingRepository
+	logger      logger.Logger
+}
+
+// NewRatingTaxonomyService creates the rating normalization service. Until Load succeeds the ratings are
+// normalized with entities.DefaultRatingMappings
+func NewRatingTaxonomyService(mappingRepo repoInterfaces.RatingMappingRepository, logger logger.Logger) interfaces.RatingTaxonomyService {
+	return &ratingTaxonomyService{
+		mappingRepo: mappingRepo,
+		logger:      logger,
+	}
+}
+
+// Load reads the mapping table into the taxonomy used by the stock rating hooks
+func (s *ratingTaxonomyService) Load(ctx context.Context) error {
+	_, err := s.load(ctx)
+	return err
+}
+
+// load reemplaza la taxonomía del proceso por la tabla de mapeo y devuelve el número de mapeos
+func (s *ratingTaxonomyService) load(ctx context.Context) (int, error) {
+	mappings, err := s.mappingRepo.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	table := make(map[string]entities.RatingScale, len(mappings))
+	for _, mapping := range mappings {
+		table[mapping.Rating] = mapping.Normalized
+	}
+	entities.SetRatingMappings(table)
+
+	s.logger.Debug(ctx, "Rating mappings loaded", logger.Int("mappings", len(table)))
+	return len(table), nil
+}
+
+// GetTaxonomy returns the canonical scale, the mappings and the most used variants without mapping
+func (s *ratingTaxonomyService) GetTaxonomy(ctx context.Context) (*response.RatingTaxonomyResponse, error) {
+	mappings, err := s.mappingRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	unmapped, err := s.unmapped(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &response.RatingTaxonomyResponse{
+		Scale:    make([]string, 0, len(entities.RatingScales)),
+		Mappings: make([]response.RatingMappingResponse, 0, len(mappings)),
+		Unmapped: unmapped,
+	}
+	for _, scale := range entities.RatingScales {
+		result.Scale = append(result.Scale, string(scale))
+	}
+	for _, mapping := range mappings {
+		result.Mappings = append(result.Mappings, response.RatingMappingResponse{
+			Rating:     mapping.Rating,
+			Normalized: string(mapping.Normalized),
+			Label:      mapping.Normalized.Label(),
+			UpdatedAt:  mapping.UpdatedAt,
+		})
+	}
+	return result, nil
+}
+
+// SaveMapping maps a variant (case and spacing are ignored) and re-normalizes the stored ratings
+func (s *ratingTaxonomyService) SaveMapping(ctx context.Context, req *request.SaveRatingMappingRequest) (*response.RatingNormalizationResponse, error) {
+	rating := entities.RatingMappingKey(req.Rating)
+	if rating == "" {
+		return nil, response.BadRequest("rating cannot be blank")
+	}
+	normalized, err := entities.ParseRatingScale(req.Normalized)
+	if err != nil {
+		return nil, response.BadRequest(err.Error())
+	}
+
+	if err := s.mappingRepo.Upsert(ctx, &entities.RatingMapping{Rating: rating, Normalized: normalized}); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "Rating mapping saved",
+		logger.String("rating", rating),
+		logger.String("normalized", string(normalized)),
+	)
+	return s.Apply(ctx)
+}
+
+// DeleteMapping removes the mapping of a variant; its ratings are left without normalized value
+func (s *ratingTaxonomyService) DeleteMapping(ctx context.Context, rating string) (*response.RatingNormalizationResponse, error) {
+	rating = entities.RatingMappingKey(rating)
+	if rating == "" {
+		return nil, response.BadRequest("rating is required")
+	}
+
+	if err := s.mappingRepo.Delete(ctx, rating); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "Rating mapping deleted", logger.String("rating", rating))
+	return s.Apply(ctx)
+}
+
+// Apply reloads the table and writes the normalized ratings that changed
+func (s *ratingTaxonomyService) Apply(ctx context.Context) (*response.RatingNormalizationResponse, error) {
+	mappings, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.mappingRepo.ApplyToRatings(ctx)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to re-normalize stock ratings", err)
+		return nil, err
+	}
+	unmapped, err := s.unmapped(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "Stock ratings re-normalized",
+		logger.Int64("updated", updated),
+		logger.Int("unmapped_variants", len(unmapped)),
+	)
+	return &response.RatingNormalizationResponse{
+		Mappings: mappings,
+		Updated:  updated,
+		Unmapped: unmapped,
+	}, nil
+}
+
+// unmapped lista las variantes sin mapeo más usadas
+func (s *ratingTaxonomyService) unmapped(ctx context.Context) ([]response.UnmappedRatingResponse, error) {
+	variants, err := s.mappingRepo.Unmapped(ctx, maxUnmappedRatings)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.UnmappedRatingResponse, 0, len(variants))
+	for _, variant := range variants {
+		result = append(result, response.UnmappedRatingResponse{Rating: variant.Rating, Count: variant.Count})
+	}
+	return result, nil
+}
@@ -0,0 +1,288 @@
+package services
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// Factores del modelo de recomendación
+const (
+	FactorConsensus = "consensus"
+	FactorMomentum  = "momentum"
+	FactorValuation = "valuation"
+)
+
+const (
+	// Umbrales del score compuesto (-1 a 1) para cada etiqueta
+	buyScoreThreshold  = 0.25
+	sellScoreThreshold = -0.25
+
+	// rsiPeriod es el periodo del RSI de Wilder
+	rsiPeriod = 14
+
+	// momentumLookbackDays cubre las 200 sesiones de la media larga
+	momentumLookbackDays = 300
+)
+
+// ScoringWeights holds the weight of each factor of the recommendation model. Only the ratio between weights
+// matters; factors without data are left out and the rest are renormalized
+type ScoringWeights struct {
+	Consensus float64
+	Momentum  float64
+	Valuation float64
+}
+
+// DefaultScoringWeights returns the weights used when none are configured
+func DefaultScoringWeights() ScoringWeights {
+	return ScoringWeights{Consensus: 0.5, Momentum: 0.25, Valuation: 0.25}
+}
+
+// UpdateScoringWeights reemplaza los pesos del modelo; pesos negativos o todos a cero usan los de por defecto
+func (s *analysisService) UpdateScoringWeights(weights ScoringWeights) {
+	if weights.Consensus < 0 || weights.Momentum < 0 || weights.Valuation < 0 ||
+		weights.Consensus+weights.Momentum+weights.Valuation == 0 {
+		weights = DefaultScoringWeights()
+	}
+	s.weightsMu.Lock()
+	defer s.weightsMu.Unlock()
+	s.weights = weights
+}
+
+func (s *analysisService) scoringWeights() ScoringWeights {
+	s.weightsMu.RLock()
+	defer s.weightsMu.RUnlock()
+	return s.weights
+}
+
+// consensusFactor puntúa el último rating de cada brokerage: (buy - sell) / brokerages
+func (s *analysisService) consensusFactor(ratings []*entities.StockRating, weight float64) response.RecommendationFactor {
+	consensus := s.calculateConsensus(ratings)
+	factor := response.RecommendationFactor{
+		Name:   FactorConsensus,
+		Weight: weight,
+		Score:  consensusBalance(consensus),
+	}
+	if consensus.Brokerages > 0 {
+		factor.Details = map[string]float64{
+			"buy":        float64(consensus.Buy),
+			"hold":       float64(consensus.Hold),
+			"sell":       float64(consensus.Sell),
+			"brokerages": float64(consensus.Brokerages),
+		}
+	}
+	return factor
+}
+
+// momentumFactor puntúa el RSI y la tendencia de las medias móviles sobre los precios guardados
+func (s *analysisService) momentumFactor(ctx context.Context, ticker string, weight float64) response.RecommendationFactor {
+	factor := response.RecommendationFactor{Name: FactorMomentum, Weight: weight}
+	if s.historicalDataRepo == nil {
+		return factor
+	}
+
+	to := time.Now().UTC()
+	prices, err := s.historicalDataRepo.GetBySymbol(ctx, ticker, to.AddDate(0, 0, -momentumLookbackDays), to)
+	if err != nil {
+		s.logger.Warn(ctx, "Failed to get price history for recommendation",
+			logger.String("ticker", ticker),
+			logger.String("error", err.Error()))
+		return factor
+	}
+
+	// Cierres en orden cronológico
+	closes := make([]float64, 0, len(prices))
+	for i := len(prices) - 1; i >= 0; i-- {
+		if price := closePrice(prices[i]); price > 0 {
+			closes = append(closes, price)
+		}
+	}
+
+	rsi := RelativeStrengthIndex(closes, rsiPeriod)
+	sma50, sma200 := simpleMovingAverage(closes, 50), simpleMovingAverage(closes, 200)
+	factor.Score = ScoreMomentum(closes, rsi, sma50, sma200)
+
+	factor.Details = make(map[string]float64)
+	for name, value := range map[string]*float64{"rsi_14": rsi, "sma_50": sma50, "sma_200": sma200} {
+		if value != nil {
+			factor.Details[name] = *value
+		}
+	}
+	if len(closes) > 0 {
+		factor.Details["last_close"] = closes[len(closes)-1]
+	}
+	return factor
+}
+
+// valuationFactor puntúa los múltiplos P/E y PEG de las métricas financieras guardadas
+func (s *analysisService) valuationFactor(ctx context.Context, ticker string, weight float64) response.RecommendationFactor {
+	factor := response.RecommendationFactor{Name: FactorValuation, Weight: weight}
+	if s.financialMetricsRepo == nil {
+		return factor
+	}
+
+	metrics, err := s.financialMetricsRepo.GetBySymbol(ctx, ticker)
+	if err != nil {
+		if !domainerrors.IsNotFound(err) {
+			s.logger.Warn(ctx, "Failed to get financial metrics for recommendation",
+				logger.String("ticker", ticker),
+				logger.String("error", err.Error()))
+		}
+		return factor
+	}
+
+	// Alpha Vantage informa "None" como 0 y los múltiplos negativos no son comparables
+	peRatio, pegRatio := positiveOrNil(metrics.PERatio), positiveOrNil(metrics.PEGRatio)
+	factor.Score = ScoreValuation(peRatio, pegRatio)
+
+	factor.Details = make(map[string]float64)
+	if peRatio != nil {
+		factor.Details["pe_ratio"] = *peRatio
+	}
+	if pegRatio != nil {
+		factor.Details["peg_ratio"] = *pegRatio
+	}
+	return factor
+}
+
+// RelativeStrengthIndex returns Wilder's RSI of chronological closes, or nil with fewer than period+1 closes
+func RelativeStrengthIndex(closes []float64, period int) *float64 {
+	if period <= 0 || len(closes) <= period {
+		return nil
+	}
+
+	var gain, loss float64
+	for i := 1; i <= period; i++ {
+		if change := closes[i] - closes[i-1]; change > 0 {
+			gain += change
+		} else {
+			loss -= change
+		}
+	}
+	gain /= float64(period)
+	loss /= float64(period)
+
+	for i := period + 1; i < len(closes); i++ {
+		change := closes[i] - closes[i-1]
+		gain = (gain*float64(period-1) + math.Max(change, 0)) / float64(period)
+		loss = (loss*float64(period-1) + math.Max(-change, 0)) / float64(period)
+	}
+
+	if loss == 0 {
+		return floatPtr(100)
+	}
+	return floatPtr(100 - 100/(1+gain/loss))
+}
+
+// ScoreMomentum scores chronological closes from -1 (bearish) to 1 (bullish) as the average of the trend (last
+// close against the 50-session average and the 50 against the 200-session average) and the RSI, which scores up
+// to ±0.5 following momentum between 30 and 70 and against it when overbought or oversold. Nil without data
+func ScoreMomentum(closes []float64, rsi, sma50, sma200 *float64) *float64 {
+	var parts []float64
+
+	if sma50 != nil && len(closes) > 0 {
+		trend := -0.5
+		if closes[len(closes)-1] > *sma50 {
+			trend = 0.5
+		}
+		if sma200 != nil {
+			if *sma50 > *sma200 {
+				trend += 0.5
+			} else {
+				trend -= 0.5
+			}
+		} else {
+			trend *= 2
+		}
+		parts = append(parts, trend)
+	}
+
+	if rsi != nil {
+		switch {
+		case *rsi > 70:
+			parts = append(parts, -0.5)
+		case *rsi < 30:
+			parts = append(parts, 0.5)
+		default:
+			parts = append(parts, (*rsi-50)/40)
+		}
+	}
+
+	if len(parts) == 0 {
+		return nil
+	}
+	return floatPtr(clampScore(mean(parts)))
+}
+
+// ScoreValuation scores valuation multiples from -1 (expensive) to 1 (cheap): a P/E of 15 or less scores 1
+// and 35 or more -1, a PEG of 1 or less scores 1 and 3 or more -1, linearly in between. Nil without multiples
+func ScoreValuation(peRatio, pegRatio *float64) *float64 {
+	var parts []float64
+	if peRatio != nil {
+		parts = append(parts, clampScore(1-(*peRatio-15)/10))
+	}
+	if pegRatio != nil {
+		parts = append(parts, clampScore(1-(*pegRatio-1)))
+	}
+
+	if len(parts) == 0 {
+		return nil
+	}
+	return floatPtr(mean(parts))
+}
+
+// CompositeScore blends the factor scores by weight, renormalizing over the factors with a score, and fills in
+// each factor's contribution. Returns nil when no factor with weight has a score
+func CompositeScore(factors []response.RecommendationFactor) *float64 {
+	totalWeight := 0.0
+	for _, factor := range factors {
+		if factor.Score != nil && factor.Weight > 0 {
+			totalWeight += factor.Weight
+		}
+	}
+	if totalWeight == 0 {
+		return nil
+	}
+
+	score := 0.0
+	for i := range factors {
+		if factors[i].Score == nil || factors[i].Weight <= 0 {
+			continue
+		}
+		contribution := *factors[i].Score * factors[i].Weight / totalWeight
+		factors[i].Contribution = floatPtr(contribution)
+		score += contribution
+	}
+	return floatPtr(score)
+}
+
+// RecommendationLabel maps a composite score to Buy, Hold or Sell
+func RecommendationLabel(score *float64) string {
+	switch {
+	case score == nil:
+		return "No data available"
+	case *score >= buyScoreThreshold:
+		return "Buy"
+	case *score <= sellScoreThreshold:
+		return "Sell"
+	default:
+		return "Hold"
+	}
+}
+
+// simpleMovingAverage devuelve la media de los últimos period cierres, o nil si no hay suficientes
+func simpleMovingAverage(closes []float64, period int) *float64 {
+	if period <= 0 || len(closes) < period {
+		return nil
+	}
+	return floatPtr(mean(closes[len(closes)-period:]))
+}
+
+func clampScore(score float64) float64 {
+	return math.Max(-1, math.Min(1, score))
+}
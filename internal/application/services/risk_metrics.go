@@ -0,0 +1,215 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+)
+
+const (
+	// maxPortfolioHoldings limita las posiciones de un portfolio (una consulta de precios por posición)
+	maxPortfolioHoldings = 25
+
+	// defaultBenchmark es el índice de referencia para la beta si no se indica otro
+	defaultBenchmark = "SPY"
+
+	// defaultRiskDays es la ventana en días naturales de las métricas de riesgo si no se indica days
+	defaultRiskDays = 365
+)
+
+// parseHoldings interpreta "AAPL:60,MSFT:40" y normaliza los pesos para que sumen 1.
+// Sin ningún peso las posiciones se reparten por igual; mezclar posiciones con y sin peso es un error
+func parseHoldings(raw string, max int) ([]response.PortfolioHolding, error) {
+	var holdings []response.PortfolioHolding
+	seen := make(map[string]bool)
+	weighted := 0
+
+	for _, part := range strings.Split(raw, ",") {
+		ticker, weightText, hasWeight := strings.Cut(strings.TrimSpace(part), ":")
+		ticker = strings.ToUpper(strings.TrimSpace(ticker))
+		if ticker == "" {
+			continue
+		}
+		if seen[ticker] {
+			return nil, fmt.Errorf("ticker %s is repeated", ticker)
+		}
+		seen[ticker] = true
+
+		weight := 1.0
+		if hasWeight {
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(weightText), 64)
+			if err != nil || parsed <= 0 || math.IsNaN(parsed) || math.IsInf(parsed, 0) {
+				return nil, fmt.Errorf("invalid weight %q for %s", weightText, ticker)
+			}
+			weight = parsed
+			weighted++
+		}
+		holdings = append(holdings, response.PortfolioHolding{Ticker: ticker, Weight: weight})
+	}
+
+	if len(holdings) == 0 {
+		return nil, fmt.Errorf("at least 1 holding is required")
+	}
+	if len(holdings) > max {
+		return nil, fmt.Errorf("at most %d holdings are allowed at once", max)
+	}
+	if weighted > 0 && weighted < len(holdings) {
+		return nil, fmt.Errorf("either every holding has a weight or none does")
+	}
+
+	total := 0.0
+	for _, holding := range holdings {
+		total += holding.Weight
+	}
+	if math.IsInf(total, 0) {
+		return nil, fmt.Errorf("the weights are too large")
+	}
+	for i := range holdings {
+		holdings[i].Weight /= total
+	}
+	return holdings, nil
+}
+
+// WeightedReturns combines the daily returns of each holding (keyed by date) into the returns of a portfolio
+// rebalanced daily to the holdings' weights. Only dates with a return for every holding are kept
+func WeightedReturns(returns []map[string]float64, holdings []response.PortfolioHolding) map[string]float64 {
+	combined := make(map[string]float64)
+	if len(returns) == 0 || len(returns) != len(holdings) {
+		return combined
+	}
+
+	for date := range returns[0] {
+		value, complete := 0.0, true
+		for i, holdingReturns := range returns {
+			r, ok := holdingReturns[date]
+			if !ok {
+				complete = false
+				break
+			}
+			value += holdings[i].Weight * r
+		}
+		if complete {
+			combined[date] = value
+		}
+	}
+	return combined
+}
+
+// CalculateRiskMetrics computes the risk metrics of a series of daily returns keyed by date (YYYY-MM-DD).
+// Beta uses the dates shared with the benchmark; riskFreeRate is annual
+func CalculateRiskMetrics(returns, benchmark map[string]float64, riskFreeRate float64) response.RiskMetrics {
+	series := orderedReturns(returns)
+
+	metrics := response.RiskMetrics{
+		Volatility:  AnnualizedVolatility(series),
+		SharpeRatio: SharpeRatio(series, riskFreeRate),
+		MaxDrawdown: MaxDrawdown(series),
+	}
+	if len(series) > 0 {
+		metrics.AnnualizedReturn = floatPtr(mean(series) * tradingDaysPerYear)
+	}
+
+	asset, market := pairReturns(returns, benchmark)
+	metrics.Beta = Beta(asset, market)
+
+	return metrics
+}
+
+// AnnualizedVolatility returns the sample standard deviation of daily returns scaled to a year of trading
+// sessions, or nil with fewer than 2 returns
+func AnnualizedVolatility(returns []float64) *float64 {
+	if len(returns) < 2 {
+		return nil
+	}
+	return floatPtr(sampleStdDev(returns) * math.Sqrt(tradingDaysPerYear))
+}
+
+// SharpeRatio returns the annualized excess return over riskFreeRate (annual) per unit of annualized
+// volatility, or nil when the volatility is unknown or zero
+func SharpeRatio(returns []float64, riskFreeRate float64) *float64 {
+	volatility := AnnualizedVolatility(returns)
+	if volatility == nil || *volatility == 0 {
+		return nil
+	}
+	return floatPtr((mean(returns)*tradingDaysPerYear - riskFreeRate) / *volatility)
+}
+
+// Beta returns the sensitivity of asset returns to paired benchmark returns (cov/var), or nil with fewer
+// than minCorrelationObservations pairs or a benchmark without variance
+func Beta(asset, benchmark []float64) *float64 {
+	n := len(asset)
+	if n != len(benchmark) || n < minCorrelationObservations {
+		return nil
+	}
+
+	meanAsset, meanBenchmark := mean(asset), mean(benchmark)
+	var cov, variance float64
+	for i := 0; i < n; i++ {
+		db := benchmark[i] - meanBenchmark
+		cov += (asset[i] - meanAsset) * db
+		variance += db * db
+	}
+	if variance == 0 {
+		return nil
+	}
+	return floatPtr(cov / variance)
+}
+
+// MaxDrawdown returns the largest peak-to-trough fall of the equity curve built from chronological daily
+// returns, as a positive fraction (0.2 = -20%), or nil without returns
+func MaxDrawdown(returns []float64) *float64 {
+	if len(returns) == 0 {
+		return nil
+	}
+
+	equity, peak, drawdown := 1.0, 1.0, 0.0
+	for _, r := range returns {
+		equity *= 1 + r
+		if equity > peak {
+			peak = equity
+		}
+		if fall := (peak - equity) / peak; fall > drawdown {
+			drawdown = fall
+		}
+	}
+	return floatPtr(drawdown)
+}
+
+// orderedReturns devuelve los rendimientos en orden cronológico (las claves YYYY-MM-DD ordenan como fechas)
+func orderedReturns(returns map[string]float64) []float64 {
+	dates := make([]string, 0, len(returns))
+	for date := range returns {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+
+	series := make([]float64, len(dates))
+	for i, date := range dates {
+		series[i] = returns[date]
+	}
+	return series
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
+
+func sampleStdDev(values []float64) float64 {
+	avg := mean(values)
+	sum := 0.0
+	for _, value := range values {
+		sum += (value - avg) * (value - avg)
+	}
+	return math.Sqrt(sum / float64(len(values)-1))
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// defaultSearchLimit es el número de resultados devueltos si no se indica limit
+const defaultSearchLimit = 20
+
+// searchService implements the SearchService interface
+type searchService struct {
+	searchRepo repoInterfaces.SearchRepository
+	logger     logger.Logger
+}
+
+// NewSearchService creates a new search service
+func NewSearchService(
+	searchRepo repoInterfaces.SearchRepository,
+	logger logger.Logger,
+) interfaces.SearchService {
+	return &searchService{
+		searchRepo: searchRepo,
+		logger:     logger,
+	}
+}
+
+// Search runs a full-text search over the requested types and returns the hits ranked together
+func (s *searchService) Search(ctx context.Context, req *request.SearchRequest) (*response.SearchResponse, error) {
+	types, err := parseSearchTypes(req.Types)
+	if err != nil {
+		return nil, response.BadRequest(err.Error())
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+
+	hits, err := s.searchRepo.Search(ctx, req.Query, types, limit)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to run full-text search", err,
+			logger.String("query", req.Query))
+		return nil, response.InternalServerError("Failed to search")
+	}
+
+	results := make([]response.SearchResultResponse, len(hits))
+	for i, hit := range hits {
+		results[i] = response.SearchResultResponse{
+			Type:        hit.Type,
+			ID:          hit.ID,
+			Symbol:      hit.Symbol,
+			Title:       hit.Title,
+			Snippet:     hit.Snippet,
+			URL:         hit.URL,
+			Rank:        hit.Rank,
+			PublishedAt: hit.PublishedAt,
+		}
+	}
+
+	if len(types) == 0 {
+		types = []string{repoInterfaces.SearchTypeCompany, repoInterfaces.SearchTypeNews}
+	}
+
+	return &response.SearchResponse{
+		Query:   req.Query,
+		Types:   types,
+		Total:   len(results),
+		Results: results,
+	}, nil
+}
+
+// parseSearchTypes valida la lista de tipos separada por comas; vacía significa todos
+func parseSearchTypes(raw string) ([]string, error) {
+	var types []string
+	for _, t := range strings.Split(raw, ",") {
+		t = strings.ToLower(strings.TrimSpace(t))
+		switch t {
+		case "":
+			continue
+		case repoInterfaces.SearchTypeCompany, repoInterfaces.SearchTypeNews:
+			types = append(types, t)
+		default:
+			return nil, fmt.Errorf("unknown search type %q (expected %s or %s)", t,
+				repoInterfaces.SearchTypeCompany, repoInterfaces.SearchTypeNews)
+		}
+	}
+	return types, nil
+}
@@ -0,0 +1,451 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/mockprovider"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// SeedDataSource marca los datos generados por el seed, para distinguirlos de los de los proveedores
+const SeedDataSource = "seed"
+
+// seedBrokerage es una brokerage del catálogo del seed
+type seedBrokerage struct {
+	name, website, country string
+}
+
+var seedBrokerages = []seedBrokerage{
+	{"The Goldman Sachs Group", "https://www.goldmansachs.com", "US"},
+	{"Morgan Stanley", "https://www.morganstanley.com", "US"},
+	{"JPMorgan Chase & Co.", "https://www.jpmorganchase.com", "US"},
+	{"Bank of America Securities", "https://www.bofa.com", "US"},
+	{"Citigroup", "https://www.citigroup.com", "US"},
+	{"Wells Fargo & Company", "https://www.wellsfargo.com", "US"},
+	{"Barclays", "https://www.barclays.com", "GB"},
+	{"UBS Group", "https://www.ubs.com", "CH"},
+	{"Deutsche Bank", "https://www.db.com", "DE"},
+	{"Jefferies Financial Group", "https://www.jefferies.com", "US"},
+	{"Raymond James", "https://www.raymondjames.com", "US"},
+	{"Piper Sandler", "https://www.pipersandler.com", "US"},
+	{"Evercore ISI", "https://www.evercore.com", "US"},
+	{"Bernstein", "https://www.bernstein.com", "US"},
+	{"Mizuho", "https://www.mizuhogroup.com", "JP"},
+	{"BMO Capital Markets", "https://capitalmarkets.bmo.com", "CA"},
+	{"RBC Capital Markets", "https://www.rbccm.com", "CA"},
+	{"Stifel Nicolaus", "https://www.stifel.com", "US"},
+	{"Oppenheimer", "https://www.oppenheimer.com", "US"},
+	{"Needham & Company", "https://www.needhamco.com", "US"},
+}
+
+// Los nombres de las companies sintéticas combinan un prefijo y un sufijo; el sufijo fija sector e industria
+var (
+	seedNamePrefixes = []string{
+		"Apex", "Bluewater", "Cedar", "Delta", "Evergreen", "Frontier", "Granite", "Harbor",
+		"Ion", "Juniper", "Keystone", "Lumen", "Meridian", "Northwind", "Orion", "Pinnacle",
+		"Quantum", "Redwood", "Summit", "Titan", "Unity", "Vertex", "Westfield", "Zenith",
+	}
+	seedNameSuffixes = []struct {
+		name, code, sector, industry string
+	}{
+		{"Technologies", "T", "Technology", "Software"},
+		{"Therapeutics", "X", "Healthcare", "Biotechnology"},
+		{"Energy", "E", "Energy", "Oil & Gas E&P"},
+		{"Financial", "F", "Financial Services", "Asset Management"},
+		{"Logistics", "L", "Industrials", "Integrated Freight & Logistics"},
+		{"Foods", "D", "Consumer Defensive", "Packaged Foods"},
+		{"Semiconductors", "S", "Technology", "Semiconductors"},
+		{"Media", "M", "Communication Services", "Entertainment"},
+		{"Industries", "I", "Industrials", "Specialty Industrial Machinery"},
+		{"Retail", "R", "Consumer Cyclical", "Specialty Retail"},
+	}
+)
+
+// Límites de los volúmenes del seed
+var (
+	MaxSeedCompanies  = len(mockprovider.Companies) + len(seedNamePrefixes)*len(seedNameSuffixes)
+	MaxSeedBrokerages = len(seedBrokerages)
+)
+
+const (
+	maxSeedRatingsPerCompany = 1000
+	maxSeedPriceDays         = 3650
+	seedRatingWindowDays     = 90 // Ventana mínima de los ratings aunque no se generen precios
+)
+
+// seedRatingScale va de peor a mejor; las mejoras y rebajas mueven un escalón
+var seedRatingScale = []string{"Sell", "Underperform", "Hold", "Outperform", "Buy"}
+
+// seedActions con su peso relativo: reiteraciones y cambios de precio objetivo son lo más frecuente
+var seedActions = []struct {
+	action string
+	weight int
+}{
+	{"target raised by", 30},
+	{"target lowered by", 20},
+	{"reiterated by", 20},
+	{"upgraded by", 12},
+	{"downgraded by", 10},
+	{"initiated by", 8},
+}
+
+// seedService implements the SeedService interface
+type seedService struct {
+	companyRepo    repoInterfaces.CompanyRepository
+	brokerageRepo  repoInterfaces.BrokerageRepository
+	ratingRepo     repoInterfaces.StockRatingRepository
+	marketDataRepo repoInterfaces.MarketDataRepository
+	historicalRepo repoInterfaces.HistoricalDataRepository
+	logger         logger.Logger
+	now            func() time.Time
+}
+
+// NewSeedService creates the synthetic data generator; prices come from the model of the mock provider, so the
+// seeded quotes and candles match what EXTERNAL_PROVIDER=mock serves
+func NewSeedService(
+	companyRepo repoInterfaces.CompanyRepository,
+	brokerageRepo repoInterfaces.BrokerageRepository,
+	ratingRepo repoInterfaces.StockRatingRepository,
+	marketDataRepo repoInterfaces.MarketDataRepository,
+	historicalRepo repoInterfaces.HistoricalDataRepository,
+	logger logger.Logger,
+) interfaces.SeedService {
+	return &seedService{
+		companyRepo:    companyRepo,
+		brokerageRepo:  brokerageRepo,
+		ratingRepo:     ratingRepo,
+		marketDataRepo: marketDataRepo,
+		historicalRepo: historicalRepo,
+		logger:         logger,
+		now:            time.Now,
+	}
+}
+
+// ValidateOptions rejects volumes out of range
+func (s *seedService) ValidateOptions(options interfaces.SeedOptions) error {
+	switch {
+	case options.Companies < 1 || options.Companies > MaxSeedCompanies:
+		return response.BadRequest(fmt.Sprintf("companies must be between 1 and %d", MaxSeedCompanies))
+	case options.Brokerages < 1 || options.Brokerages > MaxSeedBrokerages:
+		return response.BadRequest(fmt.Sprintf("brokerages must be between 1 and %d", MaxSeedBrokerages))
+	case options.RatingsPerCompany < 0 || options.RatingsPerCompany > maxSeedRatingsPerCompany:
+		return response.BadRequest(fmt.Sprintf("ratings per company must be between 0 and %d", maxSeedRatingsPerCompany))
+	case options.PriceDays < 0 || options.PriceDays > maxSeedPriceDays:
+		return response.BadRequest(fmt.Sprintf("price days must be between 0 and %d", maxSeedPriceDays))
+	}
+	return nil
+}
+
+// Seed writes the synthetic data company by company
+func (s *seedService) Seed(ctx context.Context, options interfaces.SeedOptions) (*response.SeedResponse, error) {
+	if err := s.ValidateOptions(options); err != nil {
+		return nil, err
+	}
+
+	now := s.now().UTC()
+	result := &response.SeedResponse{StartedAt: now}
+	prices := mockprovider.New(func() time.Time { return now })
+
+	brokerages, err := s.seedBrokerages(ctx, options.Brokerages)
+	if err != nil {
+		return nil, err
+	}
+	result.Brokerages = len(brokerages)
+
+	for i := 0; i < options.Companies; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		profile := seedCompanyProfile(i)
+		company, created, err := s.seedCompany(ctx, profile, prices)
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			result.Companies++
+		} else {
+			result.ExistingCompanies++
+		}
+
+		// Un generador por company: sus datos no dependen de cuántas companies se generen
+		rng := rand.New(rand.NewSource(options.Seed ^ int64(seedHash(company.Ticker))))
+
+		inserted, err := s.seedRatings(ctx, company, brokerages, options, prices, rng, now)
+		if err != nil {
+			return nil, err
+		}
+		result.Ratings += inserted
+
+		if err := s.seedMarketData(ctx, company, prices); err != nil {
+			return nil, err
+		}
+		result.MarketData++
+
+		candles, err := s.seedHistoricalPrices(ctx, company, options.PriceDays, prices, now)
+		if err != nil {
+			return nil, err
+		}
+		result.HistoricalPrices += candles
+	}
+
+	result.CompletedAt = s.now().UTC()
+	result.DurationMs = result.CompletedAt.Sub(result.StartedAt).Milliseconds()
+
+	s.logger.Info(ctx, "Seed data generated",
+		logger.Int("companies", result.Companies),
+		logger.Int("existing_companies", result.ExistingCompanies),
+		logger.Int("brokerages", result.Brokerages),
+		logger.Int("ratings", result.Ratings),
+		logger.Int("market_data", result.MarketData),
+		logger.Int("historical_prices", result.HistoricalPrices),
+		logger.Int64("duration_ms", result.DurationMs))
+	return result, nil
+}
+
+// seedCompanyProfile devuelve la company i: primero el universo del proveedor mock, después las sintéticas
+func seedCompanyProfile(i int) mockprovider.Company {
+	if i < len(mockprovider.Companies) {
+		return mockprovider.Companies[i]
+	}
+
+	i -= len(mockprovider.Companies)
+	prefix := seedNamePrefixes[i%len(seedNamePrefixes)]
+	suffix := seedNameSuffixes[i/len(seedNamePrefixes)]
+	exchange := "NYSE"
+	if i%2 == 0 {
+		exchange = "NASDAQ"
+	}
+	return mockprovider.Company{
+		Ticker:   strings.ToUpper(prefix[:3]) + suffix.code,
+		Name:     prefix + " " + suffix.name + " Inc.",
+		Sector:   suffix.sector,
+		Industry: suffix.industry,
+		Exchange: exchange,
+	}
+}
+
+func (s *seedService) seedBrokerages(ctx context.Context, count int) ([]*entities.Brokerage, error) {
+	brokerages := make([]*entities.Brokerage, 0, count)
+	for _, seed := range seedBrokerages[:count] {
+		brokerage, err := s.brokerageRepo.FindOrCreateWithDetails(ctx, seed.name, seed.website, seed.country)
+		if err != nil {
+			return nil, fmt.Errorf("failed to seed brokerage %s: %w", seed.name, err)
+		}
+		brokerages = append(brokerages, brokerage)
+	}
+	return brokerages, nil
+}
+
+// seedCompany crea la company si no existe; una company existente se reutiliza sin modificarla
+func (s *seedService) seedCompany(ctx context.Context, profile mockprovider.Company, prices *mockprovider.Provider) (*entities.Company, bool, error) {
+	existing, err := s.companyRepo.GetByTicker(ctx, profile.Ticker)
+	if err == nil {
+		return existing, false, nil
+	}
+	if !domainerrors.IsNotFound(err) {
+		return nil, false, fmt.Errorf("failed to look up company %s: %w", profile.Ticker, err)
+	}
+
+	shares := mockprovider.SharesOutstanding(profile.Ticker)
+	price := prices.Quote(profile.Ticker).Price
+	company := entities.NewCompanyWithDetails(profile.Ticker, profile.Name, profile.Sector, profile.Exchange,
+		math.Round(price*shares/1e6*100)/100)
+	company.Industry = profile.Industry
+	company.Country = "US"
+	company.Currency = "USD"
+	company.SharesOutstanding = int64(shares)
+	company.DataSource = SeedDataSource
+
+	if err := s.companyRepo.Create(ctx, company); err != nil {
+		return nil, false, fmt.Errorf("failed to seed company %s: %w", profile.Ticker, err)
+	}
+	return company, true, nil
+}
+
+// seedRatings genera los ratings de la company anclados al día en curso: repetir el seed el mismo día produce
+// los mismos eventos, que el repositorio ignora como duplicados
+func (s *seedService) seedRatings(ctx context.Context, company *entities.Company, brokerages []*entities.Brokerage,
+	options interfaces.SeedOptions, prices *mockprovider.Provider, rng *rand.Rand, now time.Time) (int, error) {
+	if options.RatingsPerCompany == 0 {
+		return 0, nil
+	}
+
+	anchor := now.Truncate(24 * time.Hour)
+	window := time.Duration(max(options.PriceDays, seedRatingWindowDays)) * 24 * time.Hour
+	totalWeight := 0
+	for _, action := range seedActions {
+		totalWeight += action.weight
+	}
+
+	ratings := make([]*entities.StockRating, 0, options.RatingsPerCompany)
+	for i := 0; i < options.RatingsPerCompany; i++ {
+		eventTime := anchor.Add(-time.Duration(rng.Int63n(int64(window)))).Truncate(time.Second)
+		brokerage := brokerages[rng.Intn(len(brokerages))]
+
+		action := pickSeedAction(rng, totalWeight)
+
+		from := rng.Intn(len(seedRatingScale))
+		to := from
+		switch action {
+		case "upgraded by":
+			to = min(from+1, len(seedRatingScale)-1)
+		case "downgraded by":
+			to = max(from-1, 0)
+		}
+
+		targetFrom := prices.Candle(company.Ticker, eventTime).Close * (0.95 + 0.3*rng.Float64())
+		targetTo := targetFrom
+		switch action {
+		case "target raised by", "upgraded by":
+			targetTo = targetFrom * (1.05 + 0.1*rng.Float64())
+		case "target lowered by", "downgraded by":
+			targetTo = targetFrom * (0.85 + 0.1*rng.Float64())
+		}
+
+		rating := entities.NewStockRating(company.ID, brokerage.ID, action, eventTime)
+		rating.RatingTo = seedRatingScale[to]
+		rating.TargetTo = fmt.Sprintf("$%.2f", targetTo)
+		if action != "initiated by" {
+			rating.RatingFrom = seedRatingScale[from]
+			rating.TargetFrom = fmt.Sprintf("$%.2f", targetFrom)
+		}
+		rating.Source = SeedDataSource
+		rating.IsProcessed = true
+		rating.Normalize()
+		ratings = append(ratings, rating)
+	}
+
+	inserted, err := s.ratingRepo.BulkInsertIgnoreDuplicates(ctx, ratings)
+	if err != nil {
+		return 0, fmt.Errorf("failed to seed ratings of %s: %w", company.Ticker, err)
+	}
+	return inserted, nil
+}
+
+// seedMarketData guarda la cotización actual del modelo de precios
+func (s *seedService) seedMarketData(ctx context.Context, company *entities.Company, prices *mockprovider.Provider) error {
+	quote := prices.Quote(company.Ticker)
+	recent := prices.LastCandles(company.Ticker, 20)
+	var totalVolume int64
+	for _, candle := range recent {
+		totalVolume += candle.Volume
+	}
+	fetchedAt := quote.Timestamp
+
+	marketData := &entities.MarketData{
+		ID:              uuid.New(),
+		CompanyID:       company.ID,
+		Symbol:          company.Ticker,
+		CurrentPrice:    quote.Price,
+		OpenPrice:       quote.Open,
+		HighPrice:       quote.High,
+		LowPrice:        quote.Low,
+		PreviousClose:   quote.PreviousClose,
+		PriceChange:     quote.Change,
+		PriceChangePerc: quote.PercentChange,
+		Volume:          recent[len(recent)-1].Volume,
+		AvgVolume:       totalVolume / int64(len(recent)),
+		MarketCap:       int64(quote.Price * mockprovider.SharesOutstanding(company.Ticker)),
+		Currency:        "USD",
+		Exchange:        company.Exchange,
+		DataSource:      SeedDataSource,
+		FetchedAt:       &fetchedAt,
+		MarketTimestamp: quote.Timestamp,
+	}
+	if err := s.marketDataRepo.UpsertBySymbol(ctx, marketData); err != nil {
+		return fmt.Errorf("failed to seed market data of %s: %w", company.Ticker, err)
+	}
+	return nil
+}
+
+// seedHistoricalPrices guarda las velas diarias de los últimos days días que aún no existan
+func (s *seedService) seedHistoricalPrices(ctx context.Context, company *entities.Company, days int,
+	prices *mockprovider.Provider, now time.Time) (int, error) {
+	if days == 0 {
+		return 0, nil
+	}
+
+	from := now.Truncate(24*time.Hour).AddDate(0, 0, -days)
+	existing, err := s.historicalRepo.GetBySymbol(ctx, company.Ticker, from, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read historical prices of %s: %w", company.Ticker, err)
+	}
+	stored := make(map[string]bool, len(existing))
+	for _, data := range existing {
+		stored[data.Date.Format("2006-01-02")] = true
+	}
+
+	var (
+		historical    []*entities.HistoricalData
+		previousClose float64
+	)
+	for _, candle := range prices.Candles(company.Ticker, from, now) {
+		closePrice := previousClose
+		previousClose = candle.Close
+		if stored[candle.Date.Format("2006-01-02")] {
+			continue
+		}
+
+		data := &entities.HistoricalData{
+			ID:            uuid.New(),
+			CompanyID:     company.ID,
+			Symbol:        company.Ticker,
+			Date:          candle.Date,
+			OpenPrice:     candle.Open,
+			HighPrice:     candle.High,
+			LowPrice:      candle.Low,
+			ClosePrice:    candle.Close,
+			AdjustedClose: candle.Close,
+			Volume:        candle.Volume,
+			DailyRange:    candle.High - candle.Low,
+			TimeFrame:     "1D",
+			DataSource:    SeedDataSource,
+			LastUpdated:   now,
+		}
+		if closePrice > 0 {
+			data.DailyReturn = (candle.Close - closePrice) / closePrice
+			data.GapPercent = (candle.Open - closePrice) / closePrice * 100
+			data.IsGapUp = data.GapPercent >= 2
+			data.IsGapDown = data.GapPercent <= -2
+		}
+		historical = append(historical, data)
+	}
+
+	if err := s.historicalRepo.BulkCreate(ctx, historical); err != nil {
+		return 0, fmt.Errorf("failed to seed historical prices of %s: %w", company.Ticker, err)
+	}
+	return len(historical), nil
+}
+
+// pickSeedAction elige una acción según los pesos de seedActions
+func pickSeedAction(rng *rand.Rand, totalWeight int) string {
+	pick := rng.Intn(totalWeight)
+	for _, candidate := range seedActions {
+		if pick < candidate.weight {
+			return candidate.action
+		}
+		pick -= candidate.weight
+	}
+	return seedActions[len(seedActions)-1].action
+}
+
+// seedHash deriva la semilla de cada company de su ticker
+func seedHash(ticker string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(ticker))
+	return h.Sum64()
+}
@@ -2,22 +2,38 @@ package services
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
 	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
 	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
 	"github.com/MayaCris/stock-info-app/internal/domain/entities"
 	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/timezone"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
 )
 
+// Periodos de GetRatingsForPeriod, calculados en la zona horaria de la petición
+const (
+	RatingPeriodToday = "today"
+	RatingPeriodWeek  = "week"
+	RatingPeriodMonth = "month"
+)
+
+// ratingDateLayout es el formato de start_date y end_date en las consultas por rango de fechas
+const ratingDateLayout = "2006-01-02"
+
 // stockRatingService implements the StockRatingService interface
 type stockRatingService struct {
 	stockRatingRepo repoInterfaces.StockRatingRepository
 	companyRepo     repoInterfaces.CompanyRepository
 	brokerageRepo   repoInterfaces.BrokerageRepository
+	noteRepo        repoInterfaces.NoteRepository // Opcional: notas del usuario con ?include=notes
 	logger          logger.Logger
 }
 
@@ -26,12 +42,14 @@ func NewStockRatingService(
 	stockRatingRepo repoInterfaces.StockRatingRepository,
 	companyRepo repoInterfaces.CompanyRepository,
 	brokerageRepo repoInterfaces.BrokerageRepository,
+	noteRepo repoInterfaces.NoteRepository,
 	logger logger.Logger,
 ) interfaces.StockRatingService {
 	return &stockRatingService{
 		stockRatingRepo: stockRatingRepo,
 		companyRepo:     companyRepo,
 		brokerageRepo:   brokerageRepo,
+		noteRepo:        noteRepo,
 		logger:          logger,
 	}
 }
@@ -43,7 +61,7 @@ func (s *stockRatingService) CreateStockRating(ctx context.Context, req *request
 	if err != nil {
 		s.logger.Error(ctx, "Failed to find company for stock rating", err,
 			logger.String("company_id", req.CompanyID.String()))
-		return nil, response.NotFound("Company")
+		return nil, response.FromError(err, "Company", "Failed to get company")
 	}
 
 	// Validate that brokerage exists
@@ -51,7 +69,7 @@ func (s *stockRatingService) CreateStockRating(ctx context.Context, req *request
 	if err != nil {
 		s.logger.Error(ctx, "Failed to find brokerage for stock rating", err,
 			logger.String("brokerage_id", req.BrokerageID.String()))
-		return nil, response.NotFound("Brokerage")
+		return nil, response.FromError(err, "Brokerage", "Failed to get brokerage")
 	}
 
 	// Create stock rating entity
@@ -71,7 +89,7 @@ func (s *stockRatingService) CreateStockRating(ctx context.Context, req *request
 		s.logger.Error(ctx, "Failed to create stock rating", err,
 			logger.String("company_id", req.CompanyID.String()),
 			logger.String("brokerage_id", req.BrokerageID.String()))
-		return nil, response.InternalServerError("Failed to create stock rating")
+		return nil, response.FromError(err, "Stock rating", "Failed to create stock rating")
 	}
 
 	s.logger.Info(ctx, "Stock rating created successfully",
@@ -89,7 +107,7 @@ func (s *stockRatingService) GetStockRatingByID(ctx context.Context, id uuid.UUI
 	if err != nil {
 		s.logger.Error(ctx, "Failed to get stock rating by ID", err,
 			logger.String("stock_rating_id", id.String()))
-		return nil, response.NotFound("Stock rating")
+		return nil, response.FromError(err, "Stock rating", "Failed to get stock rating")
 	}
 
 	// Get related entities
@@ -104,13 +122,13 @@ func (s *stockRatingService) DeleteStockRating(ctx context.Context, id uuid.UUID
 	// Check if exists
 	_, err := s.stockRatingRepo.GetByID(ctx, id)
 	if err != nil {
-		return response.NotFound("Stock rating")
+		return response.FromError(err, "Stock rating", "Failed to get stock rating")
 	}
 
 	if err := s.stockRatingRepo.Delete(ctx, id); err != nil {
 		s.logger.Error(ctx, "Failed to delete stock rating", err,
 			logger.String("stock_rating_id", id.String()))
-		return response.InternalServerError("Failed to delete stock rating")
+		return response.FromError(err, "Stock rating", "Failed to delete stock rating")
 	}
 
 	s.logger.Info(ctx, "Stock rating deleted successfully",
@@ -118,123 +136,325 @@ func (s *stockRatingService) DeleteStockRating(ctx context.Context, id uuid.UUID
 	return nil
 }
 
-// ListStockRatings lists stock ratings with filters and pagination
-func (s *stockRatingService) ListStockRatings(ctx context.Context, filter *request.StockRatingFilterRequest, pagination *response.PaginationRequest) (*response.PaginatedResponse[*response.StockRatingListResponse], error) {
+// RestoreStockRating restores a soft-deleted stock rating; its company has to be restored first
+func (s *stockRatingService) RestoreStockRating(ctx context.Context, id uuid.UUID) (*response.StockRatingResponse, error) {
+	rating, err := s.stockRatingRepo.GetByIDWithDeleted(ctx, id)
+	if err != nil {
+		return nil, response.FromError(err, "Deleted stock rating", "Failed to restore stock rating")
+	}
+
+	// Un rating restaurado sobre una compañía eliminada quedaría huérfano para el resto de la API
+	if _, err := s.companyRepo.GetByID(ctx, rating.CompanyID); err != nil {
+		if domainerrors.IsNotFound(err) {
+			return nil, response.Conflict(fmt.Sprintf("Company %s of this stock rating is deleted; restore the company first", rating.CompanyID))
+		}
+		return nil, response.FromError(err, "Company", "Failed to restore stock rating")
+	}
+
+	if err := s.stockRatingRepo.Restore(ctx, id); err != nil {
+		s.logger.Error(ctx, "Failed to restore stock rating", err,
+			logger.String("stock_rating_id", id.String()))
+		return nil, response.FromError(err, "Deleted stock rating", "Failed to restore stock rating")
+	}
+
+	s.logger.Info(ctx, "Stock rating restored successfully",
+		logger.String("stock_rating_id", id.String()))
+
+	return s.GetStockRatingByID(ctx, id)
+}
+
+// ListDeletedStockRatings lists soft-deleted stock ratings, most recently deleted first
+func (s *stockRatingService) ListDeletedStockRatings(ctx context.Context, pagination *response.PaginationRequest) (*response.PaginatedResponse[*response.DeletedStockRatingResponse], error) {
 	// Validate pagination
 	if err := pagination.Validate(); err != nil {
 		return nil, response.BadRequest("Invalid pagination parameters")
 	}
 
-	// Get total count for pagination
-	total, err := s.stockRatingRepo.Count(ctx)
+	total, err := s.stockRatingRepo.CountDeleted(ctx)
 	if err != nil {
-		s.logger.Error(ctx, "Failed to count stock ratings", err)
-		return nil, response.InternalServerError("Failed to count stock ratings")
+		s.logger.Error(ctx, "Failed to count deleted stock ratings", err)
+		return nil, response.InternalServerError("Failed to get deleted stock ratings")
 	}
-	// Get stock ratings using GetAll with pagination logic
-	allRatings, err := s.stockRatingRepo.GetAll(ctx)
+
+	ratings, err := s.stockRatingRepo.ListDeleted(ctx, pagination.GetLimit(), pagination.GetOffset())
 	if err != nil {
-		s.logger.Error(ctx, "Failed to get stock ratings", err)
-		return nil, response.InternalServerError("Failed to get stock ratings")
+		s.logger.Error(ctx, "Failed to list deleted stock ratings", err)
+		return nil, response.InternalServerError("Failed to get deleted stock ratings")
+	}
+
+	items := make([]*response.DeletedStockRatingResponse, len(ratings))
+	for i, rating := range ratings {
+		items[i] = &response.DeletedStockRatingResponse{
+			ID:          rating.ID,
+			CompanyID:   rating.CompanyID,
+			BrokerageID: rating.BrokerageID,
+			Action:      rating.Action,
+			RatingFrom:  rating.RatingFrom,
+			RatingTo:    rating.RatingTo,
+			EventTime:   rating.EventTime,
+			DeletedAt:   rating.DeletedAt.Time,
+		}
 	}
 
-	// Apply pagination manually (in production, implement GetWithPagination in repository)
-	start := pagination.GetOffset()
-	end := start + pagination.GetLimit()
-	if start > len(allRatings) {
-		start = len(allRatings)
+	return response.NewPaginatedResponse(items, pagination.Page, pagination.PerPage, int(total)), nil
+}
+
+// ListStockRatings lists stock ratings with filters and pagination
+func (s *stockRatingService) ListStockRatings(ctx context.Context, filter *request.StockRatingFilterRequest, includes request.RatingIncludes, pagination *response.PaginationRequest) (*response.PaginatedResponse[*response.StockRatingListResponse], error) {
+	// Validate pagination
+	if err := pagination.Validate(); err != nil {
+		return nil, response.BadRequest("Invalid pagination parameters")
 	}
-	if end > len(allRatings) {
-		end = len(allRatings)
+
+	sort, err := parseSort(repoInterfaces.StockRatingSortFields, filter.Sort)
+	if err != nil {
+		return nil, err
 	}
-	stockRatings := allRatings[start:end]
 
-	// Convert to list responses
-	listResponses := make([]*response.StockRatingListResponse, len(stockRatings))
-	for i, rating := range stockRatings {
-		// Get company and brokerage info
-		company, _ := s.companyRepo.GetByID(ctx, rating.CompanyID)
-		brokerage, _ := s.brokerageRepo.GetByID(ctx, rating.BrokerageID)
-		listResponses[i] = s.convertToStockRatingListResponse(rating, company, brokerage)
+	searchFilter := repoInterfaces.RatingSearchFilter{
+		CompanyID:   filter.CompanyID,
+		BrokerageID: filter.BrokerageID,
+	}
+	// Solo se comparan objetivos de la misma moneda
+	if filter.HasTargetFilter() {
+		if filter.TargetMin != nil && filter.TargetMax != nil && *filter.TargetMin > *filter.TargetMax {
+			return nil, response.BadRequest("target_min cannot be greater than target_max")
+		}
+		searchFilter.TargetCurrency = targetCurrency(filter.TargetCurrency)
+		searchFilter.TargetMin = filter.TargetMin
+		searchFilter.TargetMax = filter.TargetMax
 	}
 
-	return response.NewPaginatedResponse(listResponses, pagination.Page, pagination.PerPage, int(total)), nil
+	return s.searchRatings(ctx, searchFilter, sort, includes, pagination)
 }
 
-// GetRatingsByCompany gets ratings for a specific company
-func (s *stockRatingService) GetRatingsByCompany(ctx context.Context, companyID uuid.UUID, pagination *response.PaginationRequest) (*response.PaginatedResponse[*response.StockRatingListResponse], error) {
-	// Validate pagination
+// SearchRatings lists the ratings matching every filter of the request (brokerage, action types, normalized
+// rating range, target price range and dates) with the filtering, sort and pagination done in a single query
+func (s *stockRatingService) SearchRatings(ctx context.Context, req *request.RatingSearchRequest, includes request.RatingIncludes, pagination *response.PaginationRequest) (*response.PaginatedResponse[*response.StockRatingListResponse], error) {
 	if err := pagination.Validate(); err != nil {
 		return nil, response.BadRequest("Invalid pagination parameters")
 	}
 
-	// Check if company exists
-	company, err := s.companyRepo.GetByID(ctx, companyID)
+	sort, err := parseSort(repoInterfaces.StockRatingSortFields, req.Sort)
 	if err != nil {
-		return nil, response.NotFound("Company")
+		return nil, err
 	}
 
-	// Get ratings by company
-	stockRatings, err := s.stockRatingRepo.GetByCompanyID(ctx, companyID)
+	filter := repoInterfaces.RatingSearchFilter{
+		CompanyID:   req.CompanyID,
+		BrokerageID: req.BrokerageID,
+	}
+
+	if req.Ticker != "" {
+		if req.CompanyID != nil {
+			return nil, response.BadRequest("company_id and ticker cannot be combined")
+		}
+		company, err := s.companyRepo.GetByTicker(ctx, req.Ticker)
+		if err != nil {
+			return nil, response.NotFound("Company with ticker " + req.Ticker)
+		}
+		filter.CompanyID = &company.ID
+	}
+
+	if filter.ActionTypes, err = parseActionTypes(req.ActionType); err != nil {
+		return nil, err
+	}
+
+	ratings, filtered, err := ratingsInRange(req.RatingTo, req.MinRating, req.MaxRating)
 	if err != nil {
-		s.logger.Error(ctx, "Failed to get stock ratings by company", err,
-			logger.String("company_id", companyID.String()))
+		return nil, err
+	}
+	if filtered && len(ratings) == 0 {
+		// La lista y el rango no tienen ningún rating en común
+		return response.NewPaginatedResponse([]*response.StockRatingListResponse{}, pagination.Page, pagination.PerPage, 0), nil
+	}
+	filter.Ratings = ratings
+
+	if req.HasTargetFilter() {
+		if req.MinTarget != nil && req.MaxTarget != nil && *req.MinTarget > *req.MaxTarget {
+			return nil, response.BadRequest("min_target cannot be greater than max_target")
+		}
+		filter.TargetCurrency = targetCurrency(req.TargetCurrency)
+		filter.TargetMin = req.MinTarget
+		filter.TargetMax = req.MaxTarget
+	}
+
+	// Los días se interpretan en la zona horaria de la petición; to se incluye completo
+	location := timezone.FromContext(ctx)
+	if req.From != "" {
+		if filter.From, err = time.ParseInLocation(ratingDateLayout, req.From, location); err != nil {
+			return nil, response.BadRequest("from must be a date in YYYY-MM-DD format")
+		}
+	}
+	if req.To != "" {
+		to, err := time.ParseInLocation(ratingDateLayout, req.To, location)
+		if err != nil {
+			return nil, response.BadRequest("to must be a date in YYYY-MM-DD format")
+		}
+		if !filter.From.IsZero() && to.Before(filter.From) {
+			return nil, response.BadRequest("to must not be before from")
+		}
+		filter.To = to.AddDate(0, 0, 1)
+	}
+
+	return s.searchRatings(ctx, filter, sort, includes, pagination)
+}
+
+// searchRatings ejecuta la búsqueda combinada paginando en la consulta. Las relaciones incluidas se precargan en
+// la misma búsqueda en lugar de resolverse rating a rating
+func (s *stockRatingService) searchRatings(ctx context.Context, filter repoInterfaces.RatingSearchFilter, sort []repoInterfaces.SortField, includes request.RatingIncludes, pagination *response.PaginationRequest) (*response.PaginatedResponse[*response.StockRatingListResponse], error) {
+	filter.PreloadCompany = includes.Company
+	filter.PreloadBrokerage = includes.Brokerage
+
+	stockRatings, total, err := s.stockRatingRepo.Search(ctx, filter, sort, pagination.GetLimit(), pagination.GetOffset())
+	if err != nil {
+		s.logger.Error(ctx, "Failed to search stock ratings", err,
+			logger.String("target_currency", filter.TargetCurrency))
 		return nil, response.InternalServerError("Failed to get stock ratings")
 	}
 
-	// Convert to list responses
-	listResponses := make([]*response.StockRatingListResponse, len(stockRatings))
-	for i, rating := range stockRatings {
-		brokerage, _ := s.brokerageRepo.GetByID(ctx, rating.BrokerageID)
-		listResponses[i] = s.convertToStockRatingListResponse(rating, company, brokerage)
+	return response.NewPaginatedResponse(s.toListResponses(ctx, stockRatings, includes), pagination.Page, pagination.PerPage, int(total)), nil
+}
+
+// targetCurrency normaliza la moneda del filtro de precio objetivo (USD por defecto)
+func targetCurrency(currency string) string {
+	if currency == "" {
+		return entities.DefaultTargetCurrency
+	}
+	return strings.ToUpper(currency)
+}
+
+// parseActionTypes valida una lista de tipos de acción separados por comas
+func parseActionTypes(value string) ([]entities.ActionType, error) {
+	var actionTypes []entities.ActionType
+	for _, part := range strings.Split(value, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+		actionType := entities.ActionType(part)
+		if !actionType.Valid() {
+			allowed := make([]string, len(entities.ActionTypes))
+			for i, candidate := range entities.ActionTypes {
+				allowed[i] = string(candidate)
+			}
+			return nil, response.ValidationFailedWithFields([]response.ValidationError{
+				{Field: "action_type", Tag: "oneof", Message: "Must be one of: " + strings.Join(allowed, ", "), Value: part},
+			})
+		}
+		actionTypes = append(actionTypes, actionType)
+	}
+	return actionTypes, nil
+}
+
+// ratingsInRange devuelve los ratings normalizados de la lista que caen entre minRating (más bajista) y maxRating
+// (más alcista). filtered indica si se pidió algún filtro de rating, para distinguir "sin filtro" de "ninguno"
+func ratingsInRange(list, minRating, maxRating string) (ratings []entities.RatingScale, filtered bool, err error) {
+	allowed := make(map[entities.RatingScale]bool)
+	for _, part := range strings.Split(list, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		scale, parseErr := entities.ParseRatingScale(part)
+		if parseErr != nil {
+			return nil, false, response.ValidationFailedWithFields([]response.ValidationError{
+				{Field: "rating_to", Tag: "oneof", Message: "Must be one of: strong_buy, buy, hold, sell, strong_sell", Value: strings.TrimSpace(part)},
+			})
+		}
+		allowed[scale] = true
+	}
+
+	if len(allowed) == 0 && minRating == "" && maxRating == "" {
+		return nil, false, nil
+	}
+
+	lowest, highest := entities.RatingStrongSell.Score(), entities.RatingStrongBuy.Score()
+	if minRating != "" {
+		lowest = entities.RatingScale(minRating).Score()
+	}
+	if maxRating != "" {
+		highest = entities.RatingScale(maxRating).Score()
+	}
+	if lowest > highest {
+		return nil, false, response.BadRequest("min_rating cannot be more bullish than max_rating")
+	}
+
+	for _, scale := range entities.RatingScales {
+		if (len(allowed) == 0 || allowed[scale]) && scale.Score() >= lowest && scale.Score() <= highest {
+			ratings = append(ratings, scale)
+		}
+	}
+	return ratings, true, nil
+}
+
+// GetRatingsByCompany gets ratings for a specific company
+func (s *stockRatingService) GetRatingsByCompany(ctx context.Context, companyID uuid.UUID, includes request.RatingIncludes, pagination *response.PaginationRequest) (*response.PaginatedResponse[*response.StockRatingListResponse], error) {
+	// Validate pagination
+	if err := pagination.Validate(); err != nil {
+		return nil, response.BadRequest("Invalid pagination parameters")
 	}
 
-	// For simplicity, we'll return all results (in production, implement proper pagination in repository)
-	total := len(listResponses)
-	return response.NewPaginatedResponse(listResponses, pagination.Page, pagination.PerPage, total), nil
+	// Check if company exists
+	if _, err := s.companyRepo.GetByID(ctx, companyID); err != nil {
+		return nil, response.FromError(err, "Company", "Failed to get company")
+	}
+
+	return s.searchRatings(ctx, repoInterfaces.RatingSearchFilter{CompanyID: &companyID}, nil, includes, pagination)
 }
 
 // GetRatingsByTicker gets ratings for a company by ticker
-func (s *stockRatingService) GetRatingsByTicker(ctx context.Context, ticker string, pagination *response.PaginationRequest) (*response.PaginatedResponse[*response.StockRatingListResponse], error) {
+func (s *stockRatingService) GetRatingsByTicker(ctx context.Context, ticker string, includes request.RatingIncludes, pagination *response.PaginationRequest) (*response.PaginatedResponse[*response.StockRatingListResponse], error) {
 	// Get company by ticker
 	company, err := s.companyRepo.GetByTicker(ctx, ticker)
 	if err != nil {
 		return nil, response.NotFound("Company with ticker " + ticker)
 	}
 
-	return s.GetRatingsByCompany(ctx, company.ID, pagination)
+	return s.GetRatingsByCompany(ctx, company.ID, includes, pagination)
 }
 
 // GetRatingsByBrokerage gets ratings by brokerage
-func (s *stockRatingService) GetRatingsByBrokerage(ctx context.Context, brokerageID uuid.UUID, pagination *response.PaginationRequest) (*response.PaginatedResponse[*response.StockRatingListResponse], error) {
+func (s *stockRatingService) GetRatingsByBrokerage(ctx context.Context, brokerageID uuid.UUID, includes request.RatingIncludes, pagination *response.PaginationRequest) (*response.PaginatedResponse[*response.StockRatingListResponse], error) {
 	// Validate pagination
 	if err := pagination.Validate(); err != nil {
 		return nil, response.BadRequest("Invalid pagination parameters")
 	}
 
 	// Check if brokerage exists
-	brokerage, err := s.brokerageRepo.GetByID(ctx, brokerageID)
+	if _, err := s.brokerageRepo.GetByID(ctx, brokerageID); err != nil {
+		return nil, response.FromError(err, "Brokerage", "Failed to get brokerage")
+	}
+
+	return s.searchRatings(ctx, repoInterfaces.RatingSearchFilter{BrokerageID: &brokerageID}, nil, includes, pagination)
+}
+
+// GetRatingsAsOf gets the rating snapshot of a company at the end of date: the latest rating of each brokerage
+// with event time before the next midnight in the time zone of the context
+func (s *stockRatingService) GetRatingsAsOf(ctx context.Context, companyID uuid.UUID, date string, includes request.RatingIncludes) (*response.RatingAsOfResponse, error) {
+	day, err := time.ParseInLocation(ratingDateLayout, date, timezone.FromContext(ctx))
 	if err != nil {
-		return nil, response.NotFound("Brokerage")
+		return nil, response.BadRequest("date must be a date in YYYY-MM-DD format")
 	}
 
-	// Get ratings by brokerage
-	stockRatings, err := s.stockRatingRepo.GetByBrokerageID(ctx, brokerageID)
+	company, err := s.companyRepo.GetByID(ctx, companyID)
 	if err != nil {
-		s.logger.Error(ctx, "Failed to get stock ratings by brokerage", err,
-			logger.String("brokerage_id", brokerageID.String()))
-		return nil, response.InternalServerError("Failed to get stock ratings")
+		return nil, response.FromError(err, "Company", "Failed to get company")
 	}
 
-	// Convert to list responses
-	listResponses := make([]*response.StockRatingListResponse, len(stockRatings))
-	for i, rating := range stockRatings {
-		company, _ := s.companyRepo.GetByID(ctx, rating.CompanyID)
-		listResponses[i] = s.convertToStockRatingListResponse(rating, company, brokerage)
+	stockRatings, err := s.stockRatingRepo.GetLatestByBrokerageAsOf(ctx, companyID, day.AddDate(0, 0, 1))
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get stock ratings as of date", err,
+			logger.String("company_id", companyID.String()),
+			logger.String("date", date))
+		return nil, response.InternalServerError("Failed to get stock ratings")
 	}
 
-	total := len(listResponses)
-	return response.NewPaginatedResponse(listResponses, pagination.Page, pagination.PerPage, total), nil
+	return &response.RatingAsOfResponse{
+		CompanyID: company.ID,
+		Ticker:    company.Ticker,
+		Date:      date,
+		Ratings:   s.toListResponses(ctx, stockRatings, includes),
+	}, nil
 }
 
 // GetRecentRatings gets recent stock ratings
@@ -256,11 +476,133 @@ func (s *stockRatingService) GetRecentRatings(ctx context.Context, limit int) ([
 	return listResponses, nil
 }
 
-// GetRatingsByDateRange gets ratings within a date range
+// GetRatingsForPeriod gets the ratings of today, this week or this month. The period starts at midnight in the
+// time zone of the context, so "today" is the caller's day and not the server's
+func (s *stockRatingService) GetRatingsForPeriod(ctx context.Context, period string) ([]*response.StockRatingListResponse, error) {
+	var (
+		stockRatings []*entities.StockRating
+		err          error
+	)
+	switch period {
+	case RatingPeriodToday:
+		stockRatings, err = s.stockRatingRepo.GetTodaysRatings(ctx)
+	case RatingPeriodWeek:
+		stockRatings, err = s.stockRatingRepo.GetThisWeeksRatings(ctx)
+	case RatingPeriodMonth:
+		stockRatings, err = s.stockRatingRepo.GetThisMonthsRatings(ctx)
+	default:
+		return nil, response.BadRequest(fmt.Sprintf("Unknown period %q", period))
+	}
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get stock ratings for period", err,
+			logger.String("period", period),
+			logger.String("timezone", timezone.FromContext(ctx).String()))
+		return nil, response.InternalServerError("Failed to get stock ratings")
+	}
+
+	return s.toListResponses(ctx, stockRatings, request.RatingIncludes{}), nil
+}
+
+// GetRatingsByDateRange gets ratings within a date range. Dates are YYYY-MM-DD days in the time zone of the
+// context and both are inclusive
 func (s *stockRatingService) GetRatingsByDateRange(ctx context.Context, startDate, endDate string, pagination *response.PaginationRequest) (*response.PaginatedResponse[*response.StockRatingListResponse], error) {
-	// Implementation would parse dates and query repository
-	// For now, return empty result
-	return response.NewPaginatedResponse([]*response.StockRatingListResponse{}, pagination.Page, pagination.PerPage, 0), nil
+	if err := pagination.Validate(); err != nil {
+		return nil, response.BadRequest("Invalid pagination parameters")
+	}
+
+	location := timezone.FromContext(ctx)
+	start, err := time.ParseInLocation(ratingDateLayout, startDate, location)
+	if err != nil {
+		return nil, response.BadRequest("start_date must be a date in YYYY-MM-DD format")
+	}
+	end, err := time.ParseInLocation(ratingDateLayout, endDate, location)
+	if err != nil {
+		return nil, response.BadRequest("end_date must be a date in YYYY-MM-DD format")
+	}
+	if end.Before(start) {
+		return nil, response.BadRequest("end_date must not be before start_date")
+	}
+
+	// El último día se incluye completo: hasta justo antes de la medianoche siguiente
+	stockRatings, err := s.stockRatingRepo.GetByEventTimeRange(ctx, start, end.AddDate(0, 0, 1).Add(-time.Nanosecond))
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get stock ratings by date range", err,
+			logger.String("start_date", startDate),
+			logger.String("end_date", endDate),
+			logger.String("timezone", location.String()))
+		return nil, response.InternalServerError("Failed to get stock ratings")
+	}
+
+	total := len(stockRatings)
+	from := min(pagination.GetOffset(), total)
+	to := min(from+pagination.GetLimit(), total)
+	return response.NewPaginatedResponse(s.toListResponses(ctx, stockRatings[from:to], request.RatingIncludes{}), pagination.Page, pagination.PerPage, total), nil
+}
+
+// toListResponses convierte ratings a list responses. Company y brokerage se toman de la relación precargada y,
+// si no se precargó, se buscan por ID; con includes se embeben además como resumen y las notas del usuario
+func (s *stockRatingService) toListResponses(ctx context.Context, stockRatings []*entities.StockRating, includes request.RatingIncludes) []*response.StockRatingListResponse {
+	listResponses := make([]*response.StockRatingListResponse, len(stockRatings))
+	for i, rating := range stockRatings {
+		company := &rating.Company
+		if company.ID == uuid.Nil {
+			company, _ = s.companyRepo.GetByID(ctx, rating.CompanyID)
+		}
+		brokerage := &rating.Brokerage
+		if brokerage.ID == uuid.Nil {
+			brokerage, _ = s.brokerageRepo.GetByID(ctx, rating.BrokerageID)
+		}
+
+		listResponses[i] = s.convertToStockRatingListResponse(rating, company, brokerage)
+		if includes.Company && company != nil {
+			listResponses[i].CompanySummary = &response.CompanySummaryResponse{
+				ID:        company.ID,
+				Ticker:    company.Ticker,
+				Name:      company.Name,
+				Sector:    company.Sector,
+				Exchange:  company.Exchange,
+				MarketCap: company.MarketCap,
+				Logo:      company.Logo,
+			}
+		}
+		if includes.Brokerage && brokerage != nil {
+			listResponses[i].BrokerageSummary = &response.BrokerageSummaryResponse{
+				ID:      brokerage.ID,
+				Name:    brokerage.Name,
+				Website: brokerage.Website,
+				Country: brokerage.Country,
+			}
+		}
+	}
+	if includes.Notes {
+		s.attachRatingNotes(ctx, listResponses)
+	}
+	return listResponses
+}
+
+// attachRatingNotes añade a cada rating las notas del usuario del contexto; si fallan se omiten
+func (s *stockRatingService) attachRatingNotes(ctx context.Context, listResponses []*response.StockRatingListResponse) {
+	if s.noteRepo == nil || len(listResponses) == 0 {
+		return
+	}
+
+	ratingIDs := make([]uuid.UUID, len(listResponses))
+	for i, listResponse := range listResponses {
+		ratingIDs[i] = listResponse.ID
+	}
+	notes, err := s.noteRepo.GetByRatingIDs(ctx, ratingIDs)
+	if err != nil {
+		s.logger.Warn(ctx, "Failed to get rating notes", logger.String("error", err.Error()))
+		return
+	}
+
+	byRating := make(map[uuid.UUID][]response.NoteResponse)
+	for _, note := range notes {
+		byRating[*note.RatingID] = append(byRating[*note.RatingID], toNoteResponse(note))
+	}
+	for _, listResponse := range listResponses {
+		listResponse.Notes = byRating[listResponse.ID]
+	}
 }
 
 // GetRatingStatsByCompany gets statistics for a company's ratings
@@ -268,7 +610,7 @@ func (s *stockRatingService) GetRatingStatsByCompany(ctx context.Context, compan
 	// Check if company exists
 	company, err := s.companyRepo.GetByID(ctx, companyID)
 	if err != nil {
-		return nil, response.NotFound("Company")
+		return nil, response.FromError(err, "Company", "Failed to get company")
 	}
 
 	// Get all ratings for the company
@@ -291,17 +633,12 @@ func (s *stockRatingService) GetRatingStatsByCompany(ctx context.Context, compan
 		},
 	}
 
-	// Count ratings by type
+	// Count ratings by type on the canonical scale
 	ratingBreakdown := stats["rating_breakdown"].(map[string]int)
 	for _, rating := range ratings {
-		switch rating.RatingTo {
-		case "Buy", "Strong Buy", "Outperform":
-			ratingBreakdown["buy"]++
-		case "Hold", "Neutral":
-			ratingBreakdown["hold"]++
-		case "Sell", "Strong Sell", "Underperform":
-			ratingBreakdown["sell"]++
-		default:
+		if bucket := normalizedRatingTo(rating).Bucket(); bucket != "" {
+			ratingBreakdown[bucket]++
+		} else {
 			ratingBreakdown["other"]++
 		}
 	}
@@ -313,17 +650,24 @@ func (s *stockRatingService) GetRatingStatsByCompany(ctx context.Context, compan
 
 func (s *stockRatingService) convertToStockRatingResponse(rating *entities.StockRating, company *entities.Company, brokerage *entities.Brokerage) *response.StockRatingResponse {
 	resp := &response.StockRatingResponse{
-		ID:          rating.ID,
-		CompanyID:   rating.CompanyID,
-		BrokerageID: rating.BrokerageID,
-		Action:      rating.Action,
-		RatingFrom:  rating.RatingFrom,
-		RatingTo:    rating.RatingTo,
-		TargetFrom:  rating.TargetFrom,
-		TargetTo:    rating.TargetTo,
-		EventTime:   rating.EventTime,
-		CreatedAt:   rating.CreatedAt,
-		UpdatedAt:   rating.UpdatedAt,
+		ID:              rating.ID,
+		CompanyID:       rating.CompanyID,
+		BrokerageID:     rating.BrokerageID,
+		Action:          rating.Action,
+		ActionType:      string(rating.GetActionType()),
+		RatingFrom:      rating.RatingFrom,
+		RatingTo:        rating.RatingTo,
+		NormalizedFrom:  string(rating.NormalizedFrom),
+		NormalizedTo:    string(rating.NormalizedTo),
+		TargetFrom:      rating.TargetFrom,
+		TargetTo:        rating.TargetTo,
+		TargetFromValue: rating.TargetFromValue,
+		TargetToValue:   rating.TargetToValue,
+		TargetCurrency:  rating.TargetCurrency,
+		EventTime:       rating.EventTime,
+		Version:         rating.Version,
+		CreatedAt:       rating.CreatedAt,
+		UpdatedAt:       rating.UpdatedAt,
 	}
 
 	if company != nil {
@@ -336,6 +680,7 @@ func (s *stockRatingService) convertToStockRatingResponse(rating *entities.Stock
 			Exchange:  company.Exchange,
 			Logo:      company.Logo,
 			IsActive:  company.IsActive,
+			Version:   company.Version,
 			CreatedAt: company.CreatedAt,
 			UpdatedAt: company.UpdatedAt,
 		}
@@ -346,6 +691,7 @@ func (s *stockRatingService) convertToStockRatingResponse(rating *entities.Stock
 			Name:      brokerage.Name,
 			Website:   brokerage.Website,
 			IsActive:  brokerage.IsActive,
+			Version:   brokerage.Version,
 			CreatedAt: brokerage.CreatedAt,
 			UpdatedAt: brokerage.UpdatedAt,
 		}
@@ -356,12 +702,16 @@ func (s *stockRatingService) convertToStockRatingResponse(rating *entities.Stock
 
 func (s *stockRatingService) convertToStockRatingListResponse(rating *entities.StockRating, company *entities.Company, brokerage *entities.Brokerage) *response.StockRatingListResponse {
 	resp := &response.StockRatingListResponse{
-		ID:        rating.ID,
-		CompanyID: rating.CompanyID,
-		Action:    rating.Action,
-		RatingTo:  rating.RatingTo,
-		TargetTo:  rating.TargetTo,
-		EventTime: rating.EventTime,
+		ID:             rating.ID,
+		CompanyID:      rating.CompanyID,
+		BrokerageID:    rating.BrokerageID,
+		Action:         rating.Action,
+		ActionType:     string(rating.GetActionType()),
+		RatingTo:       rating.RatingTo,
+		TargetTo:       rating.TargetTo,
+		TargetToValue:  rating.TargetToValue,
+		TargetCurrency: rating.TargetCurrency,
+		EventTime:      rating.EventTime,
 	}
 
 	if company != nil {
@@ -0,0 +1,245 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+const (
+	// maxTagSlugLength es la longitud máxima de un slug ya normalizado
+	maxTagSlugLength = 50
+
+	// maxTagFilter limita las etiquetas de un filtro ?tags=
+	maxTagFilter = 10
+)
+
+// tagService implements the TagService interface
+type tagService struct {
+	companyRepo repoInterfaces.CompanyRepository
+	tagRepo     repoInterfaces.TagRepository
+	logger      logger.Logger
+}
+
+// NewTagService creates a new tag service
+func NewTagService(
+	companyRepo repoInterfaces.CompanyRepository,
+	tagRepo repoInterfaces.TagRepository,
+	logger logger.Logger,
+) interfaces.TagService {
+	return &tagService{
+		companyRepo: companyRepo,
+		tagRepo:     tagRepo,
+		logger:      logger,
+	}
+}
+
+// ListTags returns every tag by slug with its number of active companies
+func (s *tagService) ListTags(ctx context.Context) ([]response.TagResponse, error) {
+	usages, err := s.tagRepo.GetAll(ctx)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get tags", err)
+		return nil, response.InternalServerError("Failed to get tags")
+	}
+
+	tags := make([]response.TagResponse, len(usages))
+	for i, usage := range usages {
+		companies := usage.Companies
+		tags[i] = toTagResponse(usage.Tag)
+		tags[i].Companies = &companies
+	}
+	return tags, nil
+}
+
+// GetCompanyTags returns the tags of a company (by ID or ticker)
+func (s *tagService) GetCompanyTags(ctx context.Context, ref string) (*response.CompanyTagsResponse, error) {
+	company, err := findCompanyByRef(ctx, s.companyRepo, ref)
+	if err != nil {
+		return nil, err
+	}
+	return s.companyTags(ctx, company)
+}
+
+// CreateTag creates a tag; without slug it is derived from the name
+func (s *tagService) CreateTag(ctx context.Context, req *request.CreateTagRequest) (*response.TagResponse, error) {
+	tag := entities.NewTag(req.Slug, req.Name, req.Description)
+	if tag.Slug == "" {
+		return nil, response.BadRequest("tag slug must contain letters or digits")
+	}
+	if len(tag.Slug) > maxTagSlugLength {
+		return nil, response.BadRequest(fmt.Sprintf("tag slug must be at most %d characters", maxTagSlugLength))
+	}
+	if tag.Name == "" {
+		return nil, response.BadRequest("tag name cannot be empty")
+	}
+
+	if err := s.tagRepo.Create(ctx, tag); err != nil {
+		s.logger.Warn(ctx, "Failed to create tag",
+			logger.String("tag", tag.Slug),
+			logger.String("error", err.Error()))
+		return nil, response.FromError(err, "Tag with slug "+tag.Slug, "Failed to create tag")
+	}
+
+	s.logger.Info(ctx, "Tag created", logger.String("tag", tag.Slug))
+
+	result := toTagResponse(tag)
+	return &result, nil
+}
+
+// UpdateTag changes the name or description of a tag
+func (s *tagService) UpdateTag(ctx context.Context, slug string, req *request.UpdateTagRequest) (*response.TagResponse, error) {
+	tag, err := s.getTag(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		if strings.TrimSpace(*req.Name) == "" {
+			return nil, response.BadRequest("tag name cannot be empty")
+		}
+		tag.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		tag.Description = strings.TrimSpace(*req.Description)
+	}
+	if err := s.tagRepo.Update(ctx, tag); err != nil {
+		s.logger.Warn(ctx, "Failed to update tag",
+			logger.String("tag", tag.Slug),
+			logger.String("error", err.Error()))
+		return nil, response.FromError(err, "Tag with slug "+tag.Slug, "Failed to update tag")
+	}
+
+	result := toTagResponse(tag)
+	return &result, nil
+}
+
+// DeleteTag removes a tag from every company and deletes it
+func (s *tagService) DeleteTag(ctx context.Context, slug string) error {
+	tag, err := s.getTag(ctx, slug)
+	if err != nil {
+		return err
+	}
+
+	if err := s.tagRepo.Delete(ctx, tag.ID); err != nil {
+		s.logger.Warn(ctx, "Failed to delete tag",
+			logger.String("tag", tag.Slug),
+			logger.String("error", err.Error()))
+		return response.FromError(err, "Tag with slug "+tag.Slug, "Failed to delete tag")
+	}
+
+	s.logger.Info(ctx, "Tag deleted", logger.String("tag", tag.Slug))
+	return nil
+}
+
+// TagCompany tags a company (by ID or ticker) and returns its tags; tagging it again changes nothing
+func (s *tagService) TagCompany(ctx context.Context, slug, ref string) (*response.CompanyTagsResponse, error) {
+	tag, err := s.getTag(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	company, err := findCompanyByRef(ctx, s.companyRepo, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.tagRepo.AddCompany(ctx, tag.ID, company.ID); err != nil {
+		s.logger.Warn(ctx, "Failed to tag company",
+			logger.String("tag", tag.Slug),
+			logger.String("ticker", company.Ticker),
+			logger.String("error", err.Error()))
+		return nil, response.FromError(err, "Company with ticker "+company.Ticker, "Failed to tag company")
+	}
+
+	return s.companyTags(ctx, company)
+}
+
+// UntagCompany removes a tag from a company (by ID or ticker)
+func (s *tagService) UntagCompany(ctx context.Context, slug, ref string) error {
+	tag, err := s.getTag(ctx, slug)
+	if err != nil {
+		return err
+	}
+	company, err := findCompanyByRef(ctx, s.companyRepo, ref)
+	if err != nil {
+		return err
+	}
+
+	if err := s.tagRepo.RemoveCompany(ctx, tag.ID, company.ID); err != nil {
+		s.logger.Warn(ctx, "Failed to untag company",
+			logger.String("tag", tag.Slug),
+			logger.String("ticker", company.Ticker),
+			logger.String("error", err.Error()))
+		if domainerrors.IsNotFound(err) {
+			return response.NewErrorResponse(response.ErrCodeNotFound,
+				fmt.Sprintf("%s is not tagged with %s", company.Ticker, tag.Slug), http.StatusNotFound)
+		}
+		return response.InternalServerError("Failed to untag company")
+	}
+	return nil
+}
+
+// getTag busca la etiqueta por slug
+func (s *tagService) getTag(ctx context.Context, slug string) (*entities.Tag, error) {
+	tag, err := s.tagRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, response.FromError(err, "Tag with slug "+entities.NormalizeTagSlug(slug), "Failed to get tag")
+	}
+	return tag, nil
+}
+
+// companyTags devuelve las etiquetas de la company
+func (s *tagService) companyTags(ctx context.Context, company *entities.Company) (*response.CompanyTagsResponse, error) {
+	tags, err := s.tagRepo.GetByCompanyID(ctx, company.ID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get company tags", err,
+			logger.String("ticker", company.Ticker))
+		return nil, response.InternalServerError("Failed to get company tags")
+	}
+
+	result := &response.CompanyTagsResponse{
+		CompanyID: company.ID,
+		Ticker:    company.Ticker,
+		Tags:      make([]response.TagResponse, len(tags)),
+	}
+	for i, tag := range tags {
+		result.Tags[i] = toTagResponse(tag)
+	}
+	return result, nil
+}
+
+// parseTagFilter normaliza los slugs separados por comas de un filtro ?tags= y quita los repetidos
+func parseTagFilter(value string) ([]string, error) {
+	var tags []string
+	for _, tag := range strings.Split(value, ",") {
+		tag = entities.NormalizeTagSlug(tag)
+		if tag == "" || slices.Contains(tags, tag) {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	if len(tags) > maxTagFilter {
+		return nil, response.BadRequest(fmt.Sprintf("at most %d tags can be combined", maxTagFilter))
+	}
+	return tags, nil
+}
+
+func toTagResponse(tag *entities.Tag) response.TagResponse {
+	return response.TagResponse{
+		ID:          tag.ID,
+		Slug:        tag.Slug,
+		Name:        tag.Name,
+		Description: tag.Description,
+		CreatedAt:   tag.CreatedAt,
+		UpdatedAt:   tag.UpdatedAt,
+	}
+}
@@ -0,0 +1,471 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/tenancy"
+	"github.com/MayaCris/stock-info-app/internal/domain/timezone"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/auth"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+const (
+	// APIKeyPrefix identifica las API keys de la aplicación (sia = stock-info-app)
+	APIKeyPrefix = "sia_"
+
+	// apiKeyRandomBytes es la entropía de cada clave (64 caracteres hex)
+	apiKeyRandomBytes = 32
+
+	// apiKeyDisplayLength son los caracteres de la clave que se guardan en claro para reconocerla
+	apiKeyDisplayLength = 12
+
+	// apiKeyTouchInterval evita escribir last_used_at en cada petición
+	apiKeyTouchInterval = time.Minute
+)
+
+// tenantSlugPattern admite minúsculas, dígitos y guiones, sin guiones en los extremos
+var tenantSlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// tenantService implements the TenantService interface
+type tenantService struct {
+	tenantRepo repoInterfaces.TenantRepository
+	apiKeyRepo repoInterfaces.APIKeyRepository
+	jwtSecret  []byte
+	jwtIssuer  string
+	jwtEnabled bool
+	adminKey   string
+	logger     logger.Logger
+	now        func() time.Time
+}
+
+// NewTenantService creates the tenant service; JWTs are verified with jwtSecret when jwtEnabled.
+// adminKey, if not empty, authenticates as admin without a tenant to bootstrap the first tenants
+func NewTenantService(
+	tenantRepo repoInterfaces.TenantRepository,
+	apiKeyRepo repoInterfaces.APIKeyRepository,
+	jwtSecret string,
+	jwtIssuer string,
+	jwtEnabled bool,
+	adminKey string,
+	logger logger.Logger,
+) interfaces.TenantService {
+	return &tenantService{
+		tenantRepo: tenantRepo,
+		apiKeyRepo: apiKeyRepo,
+		jwtSecret:  []byte(jwtSecret),
+		jwtIssuer:  jwtIssuer,
+		jwtEnabled: jwtEnabled,
+		adminKey:   adminKey,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// HashAPIKey returns the hex SHA-256 of an API key, the value stored in api_keys.key_hash
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// GenerateAPIKey creates a new random API key with the application prefix
+func GenerateAPIKey() (string, error) {
+	buf := make([]byte, apiKeyRandomBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	return APIKeyPrefix + hex.EncodeToString(buf), nil
+}
+
+// CreateTenant creates a new active tenant
+func (s *tenantService) CreateTenant(ctx context.Context, req *request.CreateTenantRequest) (*response.TenantResponse, error) {
+	slug := strings.ToLower(strings.TrimSpace(req.Slug))
+	if !tenantSlugPattern.MatchString(slug) {
+		return nil, response.BadRequest("slug must contain only lowercase letters, digits and hyphens")
+	}
+
+	tenant := &entities.Tenant{
+		Name:   strings.TrimSpace(req.Name),
+		Slug:   slug,
+		Active: true,
+		Plan:   req.Plan,
+	}
+	if err := s.tenantRepo.Create(ctx, tenant); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "Tenant created",
+		logger.String("tenant_id", tenant.ID.String()),
+		logger.String("slug", tenant.Slug),
+		logger.String("plan", tenant.Plan),
+	)
+	return toTenantResponse(tenant), nil
+}
+
+// GetTenant retrieves a tenant by ID
+func (s *tenantService) GetTenant(ctx context.Context, id uuid.UUID) (*response.TenantResponse, error) {
+	tenant, err := s.tenantRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toTenantResponse(tenant), nil
+}
+
+// ListTenants retrieves all the tenants
+func (s *tenantService) ListTenants(ctx context.Context) ([]*response.TenantResponse, error) {
+	tenants, err := s.tenantRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*response.TenantResponse, 0, len(tenants))
+	for _, tenant := range tenants {
+		result = append(result, toTenantResponse(tenant))
+	}
+	return result, nil
+}
+
+// SetTenantActive activates or deactivates a tenant; the credentials of an inactive tenant are rejected
+func (s *tenantService) SetTenantActive(ctx context.Context, id uuid.UUID, active bool) (*response.TenantResponse, error) {
+	if err := s.tenantRepo.SetActive(ctx, id, active); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "Tenant status updated",
+		logger.String("tenant_id", id.String()),
+		logger.Bool("active", active),
+	)
+	return s.GetTenant(ctx, id)
+}
+
+// SetTenantPlan changes the plan of a tenant; the new quotas apply from the next request of its credentials
+// and the use already counted today is kept
+func (s *tenantService) SetTenantPlan(ctx context.Context, id uuid.UUID, plan string) (*response.TenantResponse, error) {
+	if err := s.tenantRepo.SetPlan(ctx, id, plan); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "Tenant plan updated",
+		logger.String("tenant_id", id.String()),
+		logger.String("plan", plan),
+	)
+	return s.GetTenant(ctx, id)
+}
+
+// CreateAPIKey creates a key for the tenant of the context; the key value is only returned here.
+// The role of the key cannot exceed the role of the principal that creates it
+func (s *tenantService) CreateAPIKey(ctx context.Context, req *request.CreateAPIKeyRequest) (*response.APIKeyCreatedResponse, error) {
+	principal, ok := tenancy.PrincipalFromContext(ctx)
+	if !ok || principal.TenantID == uuid.Nil {
+		return nil, response.Unauthorized("")
+	}
+	tenantID := principal.TenantID
+
+	role := tenancy.RoleViewer
+	if req.Role != "" {
+		parsed, err := tenancy.ParseRole(req.Role)
+		if err != nil {
+			return nil, response.BadRequest(err.Error())
+		}
+		role = parsed
+	}
+	if !principal.Role.Allows(role) {
+		return nil, response.Forbidden(fmt.Sprintf("cannot create an API key with role %s", role))
+	}
+	if _, err := s.tenantRepo.GetByID(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
+	now := s.now().UTC()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return nil, response.BadRequest("expires_at must be in the future")
+	}
+	keyTimezone, err := normalizeTimezone(req.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := GenerateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	key := &entities.APIKey{
+		TenantOwned: entities.TenantOwned{TenantID: tenantID},
+		Name:        strings.TrimSpace(req.Name),
+		Prefix:      value[:apiKeyDisplayLength],
+		KeyHash:     HashAPIKey(value),
+		Role:        role,
+		Timezone:    keyTimezone,
+		ExpiresAt:   req.ExpiresAt,
+	}
+	if err := s.apiKeyRepo.Create(ctx, key); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "API key created",
+		logger.String("tenant_id", tenantID.String()),
+		logger.String("api_key_id", key.ID.String()),
+		logger.String("prefix", key.Prefix),
+		logger.String("role", string(key.Role)),
+	)
+	return &response.APIKeyCreatedResponse{
+		APIKeyResponse: *toAPIKeyResponse(key, now),
+		Key:            value,
+	}, nil
+}
+
+// ListAPIKeys retrieves the keys of the tenant of the context
+func (s *tenantService) ListAPIKeys(ctx context.Context) ([]*response.APIKeyResponse, error) {
+	keys, err := s.apiKeyRepo.List(ctx)
+	if err != nil {
+		if errors.Is(err, tenancy.ErrNoTenant) {
+			return nil, response.Unauthorized("")
+		}
+		return nil, err
+	}
+
+	now := s.now().UTC()
+	result := make([]*response.APIKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, toAPIKeyResponse(key, now))
+	}
+	return result, nil
+}
+
+// RevokeAPIKey revokes a key of the tenant of the context
+func (s *tenantService) RevokeAPIKey(ctx context.Context, id uuid.UUID) error {
+	if err := s.apiKeyRepo.Revoke(ctx, id, s.now().UTC()); err != nil {
+		if errors.Is(err, tenancy.ErrNoTenant) {
+			return response.Unauthorized("")
+		}
+		return err
+	}
+
+	s.logger.Info(ctx, "API key revoked", logger.String("api_key_id", id.String()))
+	return nil
+}
+
+// SetAPIKeyTimezone changes the time zone preference of a key of the tenant of the context. Requests made with
+// the key compute "today" and "this week" in that time zone unless they pass tz=
+func (s *tenantService) SetAPIKeyTimezone(ctx context.Context, id uuid.UUID, req *request.SetAPIKeyTimezoneRequest) (*response.APIKeyResponse, error) {
+	keyTimezone, err := normalizeTimezone(req.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.apiKeyRepo.SetTimezone(ctx, id, keyTimezone); err != nil {
+		if errors.Is(err, tenancy.ErrNoTenant) {
+			return nil, response.Unauthorized("")
+		}
+		return nil, err
+	}
+	key, err := s.apiKeyRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "API key timezone updated",
+		logger.String("api_key_id", id.String()),
+		logger.String("timezone", keyTimezone),
+	)
+	return toAPIKeyResponse(key, s.now().UTC()), nil
+}
+
+// AuthenticateAPIKey resolves the principal of an API key
+func (s *tenantService) AuthenticateAPIKey(ctx context.Context, key string) (*tenancy.Principal, error) {
+	if s.adminKey != "" && subtle.ConstantTimeCompare([]byte(key), []byte(s.adminKey)) == 1 {
+		return &tenancy.Principal{
+			Subject: "admin",
+			Method:  tenancy.MethodAdminKey,
+			Role:    tenancy.RoleAdmin,
+		}, nil
+	}
+	if !strings.HasPrefix(key, APIKeyPrefix) {
+		return nil, response.Unauthorized("Invalid API key")
+	}
+
+	apiKey, err := s.apiKeyRepo.GetByHash(ctx, HashAPIKey(key))
+	if err != nil {
+		if domainerrors.IsNotFound(err) {
+			return nil, response.Unauthorized("Invalid API key")
+		}
+		return nil, err
+	}
+
+	now := s.now().UTC()
+	if !apiKey.IsUsable(now) {
+		return nil, response.Unauthorized("API key revoked or expired")
+	}
+
+	tenant, err := s.activeTenant(ctx, apiKey.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyTouchInterval {
+		if err := s.apiKeyRepo.TouchLastUsed(ctx, apiKey.ID, now); err != nil {
+			s.logger.Warn(ctx, "Failed to update API key last use",
+				logger.String("api_key_id", apiKey.ID.String()),
+				logger.ErrorField(err),
+			)
+		}
+	}
+
+	return &tenancy.Principal{
+		TenantID:   tenant.ID,
+		TenantSlug: tenant.Slug,
+		Subject:    apiKey.Prefix,
+		APIKeyID:   apiKey.ID,
+		Method:     tenancy.MethodAPIKey,
+		Role:       keyRole(apiKey),
+		Plan:       tenant.Plan,
+		Timezone:   apiKey.Timezone,
+	}, nil
+}
+
+// AuthenticateToken resolves the principal of a JWT signed with the JWT secret
+func (s *tenantService) AuthenticateToken(ctx context.Context, token string) (*tenancy.Principal, error) {
+	if !s.jwtEnabled {
+		return nil, response.Unauthorized("JWT authentication is disabled")
+	}
+
+	claims, err := auth.VerifyHS256(token, s.jwtSecret, s.jwtIssuer, s.now())
+	if err != nil {
+		return nil, response.Unauthorized(fmt.Sprintf("Invalid token: %v", err))
+	}
+
+	tenantID, err := uuid.Parse(claims.TenantID)
+	if err != nil {
+		return nil, response.Unauthorized("Invalid token: tid is not a tenant ID")
+	}
+
+	role := tenancy.RoleViewer
+	if claims.Role != "" {
+		if role, err = tenancy.ParseRole(claims.Role); err != nil {
+			return nil, response.Unauthorized(fmt.Sprintf("Invalid token: %v", err))
+		}
+	}
+
+	tenant, err := s.activeTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tenancy.Principal{
+		TenantID:   tenant.ID,
+		TenantSlug: tenant.Slug,
+		Subject:    claims.Subject,
+		Method:     tenancy.MethodJWT,
+		Role:       role,
+		Plan:       tenant.Plan,
+		Timezone:   claims.Zoneinfo,
+	}, nil
+}
+
+// CurrentTenant describes the tenant and credential of the request
+func (s *tenantService) CurrentTenant(ctx context.Context) (*response.CurrentTenantResponse, error) {
+	principal, ok := tenancy.PrincipalFromContext(ctx)
+	if !ok || principal.TenantID == uuid.Nil {
+		return nil, response.Unauthorized("")
+	}
+
+	tenant, err := s.GetTenant(ctx, principal.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	current := &response.CurrentTenantResponse{
+		Tenant:   *tenant,
+		Subject:  principal.Subject,
+		Method:   principal.Method,
+		Role:     string(principal.Role),
+		Timezone: principal.Timezone,
+	}
+	if principal.APIKeyID != uuid.Nil {
+		keyID := principal.APIKeyID
+		current.APIKeyID = &keyID
+	}
+	return current, nil
+}
+
+// activeTenant loads the tenant of a credential; unknown or inactive tenants are unauthorized
+func (s *tenantService) activeTenant(ctx context.Context, id uuid.UUID) (*entities.Tenant, error) {
+	tenant, err := s.tenantRepo.GetByID(ctx, id)
+	if err != nil {
+		if domainerrors.IsNotFound(err) {
+			return nil, response.Unauthorized("Unknown tenant")
+		}
+		return nil, err
+	}
+	if !tenant.Active {
+		return nil, response.Unauthorized("Tenant is inactive")
+	}
+	return tenant, nil
+}
+
+// normalizeTimezone valida una zona horaria IANA y devuelve su nombre canónico; vacío la elimina
+func normalizeTimezone(name string) (string, error) {
+	if strings.TrimSpace(name) == "" {
+		return "", nil
+	}
+	location, err := timezone.Load(name)
+	if err != nil {
+		return "", response.BadRequest(err.Error())
+	}
+	return location.String(), nil
+}
+
+// keyRole returns the role of a key; keys without a valid role are viewers
+func keyRole(key *entities.APIKey) tenancy.Role {
+	if key.Role.Valid() {
+		return key.Role
+	}
+	return tenancy.RoleViewer
+}
+
+// toTenantResponse converts a tenant entity to its response
+func toTenantResponse(tenant *entities.Tenant) *response.TenantResponse {
+	return &response.TenantResponse{
+		ID:        tenant.ID,
+		Name:      tenant.Name,
+		Slug:      tenant.Slug,
+		Active:    tenant.Active,
+		Plan:      tenant.Plan,
+		CreatedAt: tenant.CreatedAt,
+		UpdatedAt: tenant.UpdatedAt,
+	}
+}
+
+// toAPIKeyResponse converts an API key entity to its response, without the hash
+func toAPIKeyResponse(key *entities.APIKey, now time.Time) *response.APIKeyResponse {
+	return &response.APIKeyResponse{
+		ID:         key.ID,
+		TenantID:   key.TenantID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Role:       string(keyRole(key)),
+		Timezone:   key.Timezone,
+		Active:     key.IsUsable(now),
+		LastUsedAt: key.LastUsedAt,
+		ExpiresAt:  key.ExpiresAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
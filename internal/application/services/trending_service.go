@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	domainServices "github.com/MayaCris/stock-info-app/internal/domain/services"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+const (
+	defaultTrendingHours = 24
+	defaultTrendingLimit = 20
+)
+
+// trendingService implements the TrendingService interface
+type trendingService struct {
+	views       *domainServices.SymbolViews
+	companyRepo repoInterfaces.CompanyRepository
+	logger      logger.Logger
+}
+
+// NewTrendingService creates a new trending symbols service
+func NewTrendingService(
+	views *domainServices.SymbolViews,
+	companyRepo repoInterfaces.CompanyRepository,
+	logger logger.Logger,
+) interfaces.TrendingService {
+	return &trendingService{
+		views:       views,
+		companyRepo: companyRepo,
+		logger:      logger,
+	}
+}
+
+// GetTrending ranks the symbols by their quote and profile requests in the last hours
+func (s *trendingService) GetTrending(ctx context.Context, req *request.TrendingRequest) (*response.TrendingSymbolsResponse, error) {
+	hours := req.Hours
+	if hours <= 0 {
+		hours = defaultTrendingHours
+	}
+	hours = min(hours, domainServices.MaxViewWindowHours)
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultTrendingLimit
+	}
+
+	ranking, err := s.views.Top(ctx, hours, limit)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get symbol views", err,
+			logger.Int("hours", hours))
+		return nil, response.ServiceUnavailable("Symbol view counters are not available")
+	}
+
+	// Los buckets son horarios: la ventana empieza al inicio de la hora más antigua
+	since := time.Now().UTC().Truncate(time.Hour).Add(-time.Duration(hours-1) * time.Hour)
+	result := &response.TrendingSymbolsResponse{
+		Hours:   hours,
+		Since:   since,
+		Total:   len(ranking),
+		Symbols: make([]response.TrendingSymbolResponse, len(ranking)),
+	}
+	for i, entry := range ranking {
+		result.Symbols[i] = response.TrendingSymbolResponse{
+			Rank:   i + 1,
+			Symbol: entry.Symbol,
+			Views:  entry.Views,
+		}
+		// El nombre es informativo: un símbolo sin company se devuelve igualmente
+		if company, err := s.companyRepo.GetByTicker(ctx, entry.Symbol); err == nil {
+			result.Symbols[i].CompanyName = company.Name
+		}
+	}
+
+	return result, nil
+}
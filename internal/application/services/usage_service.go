@@ -0,0 +1,231 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	domainServices "github.com/MayaCris/stock-info-app/internal/domain/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/tenancy"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// defaultUsageDays es el periodo por defecto de las consultas de uso
+const defaultUsageDays = 7
+
+// usageService implements the UsageService interface
+type usageService struct {
+	counters   *domainServices.UsageCounters
+	usageRepo  repoInterfaces.UsageRepository
+	tenantRepo repoInterfaces.TenantRepository
+	logger     logger.Logger
+	now        func() time.Time
+}
+
+// NewUsageService creates the API usage service; the middleware writes the counters and Flush saves them
+func NewUsageService(
+	counters *domainServices.UsageCounters,
+	usageRepo repoInterfaces.UsageRepository,
+	tenantRepo repoInterfaces.TenantRepository,
+	logger logger.Logger,
+) interfaces.UsageService {
+	return &usageService{
+		counters:   counters,
+		usageRepo:  usageRepo,
+		tenantRepo: tenantRepo,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// Flush saves every hour still in the cache. The counters are cumulative and the rows are replaced, so
+// flushing an hour again is safe; the closed hours are purged one hour late, once no request can still count
+func (s *usageService) Flush(ctx context.Context) (*response.UsageFlushResponse, error) {
+	result := &response.UsageFlushResponse{}
+	purgeBefore := s.now().UTC().Truncate(time.Hour).Add(-time.Hour)
+
+	for _, hour := range s.counters.Hours() {
+		counts, err := s.counters.Hour(ctx, hour)
+		if err != nil {
+			return result, fmt.Errorf("failed to read usage counters: %w", err)
+		}
+		if len(counts) == 0 {
+			continue
+		}
+		result.Hours++
+
+		rows := make([]*entities.APIUsage, 0, len(counts))
+		for key, count := range counts {
+			rows = append(rows, &entities.APIUsage{
+				TenantOwned:   entities.TenantOwned{TenantID: key.TenantID},
+				Credential:    key.Credential,
+				Hour:          hour,
+				Requests:      count.Requests,
+				Errors:        count.Errors,
+				ProviderCalls: count.ProviderCalls,
+				CacheHits:     count.CacheHits,
+			})
+		}
+		if err := s.usageRepo.SaveHourly(ctx, rows); err != nil {
+			return result, err
+		}
+		result.Rows += len(rows)
+
+		if hour.Before(purgeBefore) {
+			if err := s.counters.DeleteHour(ctx, hour); err != nil {
+				s.logger.Warn(ctx, "Failed to purge flushed usage counters",
+					logger.String("hour", hour.Format(time.RFC3339)),
+					logger.String("error", err.Error()),
+				)
+				continue
+			}
+			result.HoursPurged++
+		}
+	}
+
+	s.logger.Info(ctx, "API usage counters flushed",
+		logger.Int("hours", result.Hours),
+		logger.Int("rows", result.Rows),
+		logger.Int("hours_purged", result.HoursPurged),
+	)
+	return result, nil
+}
+
+// GetMyUsage returns the usage of the tenant of the context in the last days
+func (s *usageService) GetMyUsage(ctx context.Context, req *request.UsageRequest) (*response.UsageResponse, error) {
+	tenantID, err := tenancy.TenantID(ctx)
+	if err != nil {
+		return nil, response.Unauthorized("")
+	}
+
+	days, from, to := s.usagePeriod(req)
+	rows, err := s.usageRepo.DailyByTenant(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &response.UsageResponse{
+		TenantID:    tenantID,
+		From:        from,
+		To:          to,
+		Days:        days,
+		Credentials: []response.CredentialUsageResponse{},
+		Daily:       make([]response.DailyUsageResponse, 0, len(rows)),
+	}
+	credentials := make(map[string]*response.CredentialUsageResponse)
+	for _, row := range rows {
+		counts := usageCounts(row)
+		addUsageCounts(&result.Totals, counts)
+		result.Daily = append(result.Daily, response.DailyUsageResponse{
+			Date:                row.Hour.UTC().Format("2006-01-02"),
+			Credential:          row.Credential,
+			UsageCountsResponse: counts,
+		})
+
+		credential, ok := credentials[row.Credential]
+		if !ok {
+			credential = &response.CredentialUsageResponse{Credential: row.Credential}
+			credentials[row.Credential] = credential
+		}
+		addUsageCounts(&credential.UsageCountsResponse, counts)
+	}
+
+	for _, credential := range credentials {
+		result.Credentials = append(result.Credentials, *credential)
+	}
+	// Las credenciales más usadas primero
+	sort.Slice(result.Credentials, func(i, j int) bool {
+		if result.Credentials[i].Requests != result.Credentials[j].Requests {
+			return result.Credentials[i].Requests > result.Credentials[j].Requests
+		}
+		return result.Credentials[i].Credential < result.Credentials[j].Credential
+	})
+	return result, nil
+}
+
+// GetUsageRollup returns the usage of every tenant in the last days, the most active first
+func (s *usageService) GetUsageRollup(ctx context.Context, req *request.UsageRequest) (*response.UsageRollupResponse, error) {
+	days, from, to := s.usagePeriod(req)
+	rows, err := s.usageRepo.Daily(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &response.UsageRollupResponse{
+		From:    from,
+		To:      to,
+		Days:    days,
+		Tenants: []response.TenantUsageResponse{},
+	}
+	tenants := make(map[uuid.UUID]*response.TenantUsageResponse)
+	credentials := make(map[uuid.UUID]map[string]bool)
+	for _, row := range rows {
+		counts := usageCounts(row)
+		addUsageCounts(&result.Totals, counts)
+
+		tenant, ok := tenants[row.TenantID]
+		if !ok {
+			tenant = &response.TenantUsageResponse{TenantID: row.TenantID}
+			tenants[row.TenantID] = tenant
+			credentials[row.TenantID] = make(map[string]bool)
+		}
+		addUsageCounts(&tenant.UsageCountsResponse, counts)
+		credentials[row.TenantID][row.Credential] = true
+	}
+
+	// El slug es informativo: sin él el roll-up se devuelve igualmente
+	slugs := make(map[uuid.UUID]string)
+	if list, err := s.tenantRepo.List(ctx); err == nil {
+		for _, tenant := range list {
+			slugs[tenant.ID] = tenant.Slug
+		}
+	}
+	for id, tenant := range tenants {
+		tenant.TenantSlug = slugs[id]
+		tenant.Credentials = len(credentials[id])
+		result.Tenants = append(result.Tenants, *tenant)
+	}
+	sort.Slice(result.Tenants, func(i, j int) bool {
+		if result.Tenants[i].Requests != result.Tenants[j].Requests {
+			return result.Tenants[i].Requests > result.Tenants[j].Requests
+		}
+		return result.Tenants[i].TenantID.String() < result.Tenants[j].TenantID.String()
+	})
+	return result, nil
+}
+
+// usagePeriod calcula los días UTC consultados, incluido el actual: [from, to)
+func (s *usageService) usagePeriod(req *request.UsageRequest) (int, time.Time, time.Time) {
+	days := defaultUsageDays
+	if req != nil && req.Days > 0 {
+		days = req.Days
+	}
+	to := s.now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1)
+	return days, to.AddDate(0, 0, -days), to
+}
+
+// usageCounts convierte una fila de uso en sus contadores
+func usageCounts(row *entities.APIUsage) response.UsageCountsResponse {
+	return response.UsageCountsResponse{
+		Requests:      row.Requests,
+		Errors:        row.Errors,
+		ProviderCalls: row.ProviderCalls,
+		CacheHits:     row.CacheHits,
+	}
+}
+
+// addUsageCounts acumula counts en total
+func addUsageCounts(total *response.UsageCountsResponse, counts response.UsageCountsResponse) {
+	total.Requests += counts.Requests
+	total.Errors += counts.Errors
+	total.ProviderCalls += counts.ProviderCalls
+	total.CacheHits += counts.CacheHits
+}
@@ -0,0 +1,253 @@
+package enrichment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// DefaultMarketCapTolerance es la diferencia relativa de market cap a partir de la cual se marca un conflicto.
+// El market cap cambia a diario, así que solo las discrepancias grandes (unidades, company equivocada) se revisan.
+const DefaultMarketCapTolerance = 0.5
+
+// profilePageSize es el tamaño de página al leer company_profiles
+const profilePageSize = 500
+
+// ErrConflictNotPending se devuelve al revisar un conflicto ya cerrado
+var ErrConflictNotPending = errors.New("enrichment conflict is not pending")
+
+// EnrichmentOptions configura una ejecución del enriquecimiento
+type EnrichmentOptions struct {
+	DryRun             bool    // Calcula cambios y conflictos sin guardarlos
+	MarketCapTolerance float64 // <= 0 usa DefaultMarketCapTolerance
+}
+
+// EnrichmentResult resume una ejecución del enriquecimiento
+type EnrichmentResult struct {
+	CompaniesScanned  int            `json:"companies_scanned"`
+	CompaniesEnriched int            `json:"companies_enriched"`
+	ProfilesMissing   int            `json:"profiles_missing"` // Companies sin CompanyProfile guardado
+	FieldsBackfilled  map[string]int `json:"fields_backfilled"`
+	ConflictsFlagged  int            `json:"conflicts_flagged"`
+	Failed            int            `json:"failed"`
+	Errors            []string       `json:"errors,omitempty"`
+	DryRun            bool           `json:"dry_run"`
+	Duration          time.Duration  `json:"duration"`
+}
+
+// CompanyEnrichmentService completa Sector, Exchange y MarketCap de las companies a partir de los
+// CompanyProfile guardados. Nunca sobrescribe un valor existente: si difiere del perfil lo marca para revisión.
+type CompanyEnrichmentService struct {
+	companyRepo  repoInterfaces.CompanyRepository
+	profileRepo  repoInterfaces.CompanyProfileRepository
+	conflictRepo repoInterfaces.CompanyEnrichmentConflictRepository
+	logger       logger.Logger
+}
+
+// NewCompanyEnrichmentService crea un nuevo servicio de enriquecimiento
+func NewCompanyEnrichmentService(companyRepo repoInterfaces.CompanyRepository, profileRepo repoInterfaces.CompanyProfileRepository,
+	conflictRepo repoInterfaces.CompanyEnrichmentConflictRepository, appLogger logger.Logger) *CompanyEnrichmentService {
+	return &CompanyEnrichmentService{
+		companyRepo:  companyRepo,
+		profileRepo:  profileRepo,
+		conflictRepo: conflictRepo,
+		logger:       appLogger,
+	}
+}
+
+// Enrich recorre las companies activas y las completa desde su CompanyProfile.
+// Los fallos por company no detienen la ejecución; se cuentan en el resultado.
+func (s *CompanyEnrichmentService) Enrich(ctx context.Context, opts EnrichmentOptions) (*EnrichmentResult, error) {
+	if opts.MarketCapTolerance <= 0 {
+		opts.MarketCapTolerance = DefaultMarketCapTolerance
+	}
+
+	start := time.Now()
+
+	profiles, err := s.loadProfiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	companies, err := s.companyRepo.GetAllActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active companies: %w", err)
+	}
+
+	result := &EnrichmentResult{
+		FieldsBackfilled: make(map[string]int),
+		DryRun:           opts.DryRun,
+	}
+
+	var conflicts []*entities.CompanyEnrichmentConflict
+	for _, company := range companies {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		result.CompaniesScanned++
+
+		profile, ok := profiles[strings.ToUpper(company.Ticker)]
+		if !ok {
+			result.ProfilesMissing++
+			continue
+		}
+
+		filled, found := EnrichCompany(company, profile, opts.MarketCapTolerance)
+		conflicts = append(conflicts, found...)
+
+		if len(filled) == 0 {
+			continue
+		}
+
+		if !opts.DryRun {
+			if err := s.companyRepo.Update(ctx, company); err != nil {
+				result.Failed++
+				result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", company.Ticker, err))
+				continue
+			}
+		}
+
+		result.CompaniesEnriched++
+		for _, field := range filled {
+			result.FieldsBackfilled[field]++
+		}
+	}
+
+	result.ConflictsFlagged = len(conflicts)
+	if !opts.DryRun {
+		if err := s.conflictRepo.Record(ctx, conflicts); err != nil {
+			return result, err
+		}
+	}
+
+	result.Duration = time.Since(start)
+
+	s.logger.Info(ctx, "Company enrichment completed",
+		logger.Int("companies_scanned", result.CompaniesScanned),
+		logger.Int("companies_enriched", result.CompaniesEnriched),
+		logger.Int("profiles_missing", result.ProfilesMissing),
+		logger.Int("conflicts_flagged", result.ConflictsFlagged),
+		logger.Int("failed", result.Failed),
+		logger.Bool("dry_run", result.DryRun),
+		logger.Duration("duration", result.Duration),
+	)
+
+	return result, nil
+}
+
+// ListConflicts retorna una página de conflictos y el total, opcionalmente filtrados por estado
+func (s *CompanyEnrichmentService) ListConflicts(ctx context.Context, status entities.EnrichmentConflictStatus, limit, offset int) ([]*entities.CompanyEnrichmentConflict, int64, error) {
+	conflicts, err := s.conflictRepo.List(ctx, status, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.conflictRepo.Count(ctx, status)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return conflicts, total, nil
+}
+
+// ReviewConflict cierra un conflicto pendiente como resuelto o descartado
+func (s *CompanyEnrichmentService) ReviewConflict(ctx context.Context, id uuid.UUID, status entities.EnrichmentConflictStatus) (*entities.CompanyEnrichmentConflict, error) {
+	conflict, err := s.conflictRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !conflict.IsPending() {
+		return conflict, ErrConflictNotPending
+	}
+
+	if err := s.conflictRepo.MarkReviewed(ctx, id, status); err != nil {
+		return nil, err
+	}
+
+	return s.conflictRepo.GetByID(ctx, id)
+}
+
+// loadProfiles lee todos los perfiles guardados indexados por símbolo
+func (s *CompanyEnrichmentService) loadProfiles(ctx context.Context) (map[string]*entities.CompanyProfile, error) {
+	profiles := make(map[string]*entities.CompanyProfile)
+
+	for offset := 0; ; offset += profilePageSize {
+		page, err := s.profileRepo.GetAll(ctx, profilePageSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get company profiles: %w", err)
+		}
+
+		for _, profile := range page {
+			profiles[strings.ToUpper(profile.Symbol)] = profile
+		}
+
+		if len(page) < profilePageSize {
+			break
+		}
+	}
+
+	return profiles, nil
+}
+
+// EnrichCompany completa los campos vacíos de company con los del perfil y retorna los campos completados.
+// Los campos ya informados que difieren del perfil se devuelven como conflictos sin modificarse.
+func EnrichCompany(company *entities.Company, profile *entities.CompanyProfile, marketCapTolerance float64) ([]string, []*entities.CompanyEnrichmentConflict) {
+	var (
+		filled    []string
+		conflicts []*entities.CompanyEnrichmentConflict
+	)
+
+	flag := func(field, companyValue, profileValue string) {
+		conflicts = append(conflicts, entities.NewCompanyEnrichmentConflict(company, field, companyValue, profileValue, profile.DataSource))
+	}
+
+	if sector := strings.TrimSpace(profile.Sector); sector != "" {
+		switch {
+		case strings.TrimSpace(company.Sector) == "":
+			company.Sector = sector
+			filled = append(filled, entities.EnrichmentFieldSector)
+		case !strings.EqualFold(strings.TrimSpace(company.Sector), sector):
+			flag(entities.EnrichmentFieldSector, company.Sector, sector)
+		}
+	}
+
+	if exchange := strings.ToUpper(strings.TrimSpace(profile.Exchange)); exchange != "" {
+		switch {
+		case strings.TrimSpace(company.Exchange) == "":
+			company.Exchange = exchange
+			filled = append(filled, entities.EnrichmentFieldExchange)
+		case !strings.EqualFold(strings.TrimSpace(company.Exchange), exchange):
+			flag(entities.EnrichmentFieldExchange, company.Exchange, exchange)
+		}
+	}
+
+	// CompanyProfile guarda el valor absoluto; Company lo guarda en millones USD
+	if profile.MarketCap > 0 {
+		marketCap := math.Round(float64(profile.MarketCap)/1_000_000*100) / 100
+		switch {
+		case company.MarketCap <= 0:
+			company.MarketCap = marketCap
+			filled = append(filled, entities.EnrichmentFieldMarketCap)
+		case math.Abs(company.MarketCap-marketCap)/math.Max(company.MarketCap, marketCap) > marketCapTolerance:
+			flag(entities.EnrichmentFieldMarketCap, formatMarketCap(company.MarketCap), formatMarketCap(marketCap))
+		}
+	}
+
+	return filled, conflicts
+}
+
+// formatMarketCap serializa un market cap (millones USD) para guardarlo en un conflicto
+func formatMarketCap(value float64) string {
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
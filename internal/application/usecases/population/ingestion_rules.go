@@ -0,0 +1,315 @@
+package population
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+// RuleAction es lo que hace una regla de ingesta con los items que la incumplen
+type RuleAction string
+
+const (
+	RuleActionOff        RuleAction = "off"        // La regla no se evalúa
+	RuleActionReject     RuleAction = "reject"     // El item se descarta y queda en rechazos como descartado
+	RuleActionQuarantine RuleAction = "quarantine" // El item queda en rechazos como pendiente hasta que se revise o reprocese
+	RuleActionCorrect    RuleAction = "correct"    // El item se corrige y se ingiere
+)
+
+// Reglas de validación de la ingesta, en el orden en que se evalúan
+const (
+	RuleMissingFields     = "missing_fields"
+	RuleTickerFormat      = "ticker_format"
+	RuleFutureEventTime   = "future_event_time"
+	RuleStaleEventTime    = "stale_event_time"
+	RuleUnknownRating     = "unknown_rating"
+	RuleUnparseableTarget = "unparseable_target"
+)
+
+// tickerPattern es el formato aceptado de los tickers (BRK.B, RDS-A...)
+var tickerPattern = regexp.MustCompile(`^[A-Z][A-Z0-9.\-]{0,9}$`)
+
+// RuleSettings configura el motor de reglas de ingesta
+type RuleSettings struct {
+	Actions         map[string]RuleAction // Acción por regla; las reglas ausentes usan su acción por defecto
+	FutureTolerance time.Duration         // Margen sobre la hora actual antes de considerar futuro un event_time
+	MinEventTime    time.Time             // event_time más antiguo aceptado
+}
+
+// DefaultRuleSettings returns the same limits used by GetRatingsWithInvalidDates
+func DefaultRuleSettings() RuleSettings {
+	return RuleSettings{
+		FutureTolerance: 24 * time.Hour,
+		MinEventTime:    time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// IngestionRule valida una condición de los items antes de ingerirlos
+type IngestionRule struct {
+	Name          string
+	Description   string
+	DefaultAction RuleAction
+
+	// check devuelve el motivo de la infracción ("" si el item cumple la regla)
+	check func(item StockDataItem, now time.Time) string
+	// correct arregla el item y devuelve false si no pudo; nil si la regla no admite corrección
+	correct func(item *StockDataItem, now time.Time) bool
+}
+
+// CanCorrect reports whether the rule supports the correct action
+func (r IngestionRule) CanCorrect() bool {
+	return r.correct != nil
+}
+
+// IngestionRules returns the built-in rules with the limits of the settings
+func IngestionRules(settings RuleSettings) []IngestionRule {
+	return []IngestionRule{
+		{
+			Name:          RuleMissingFields,
+			Description:   "ticker, company, brokerage, action and event time are required",
+			DefaultAction: RuleActionQuarantine,
+			check: func(item StockDataItem, _ time.Time) string {
+				if err := item.Validate(); err != nil {
+					return err.Error()
+				}
+				return ""
+			},
+		},
+		{
+			Name:          RuleTickerFormat,
+			Description:   "ticker must be uppercase letters, digits, dots or dashes (correct: trim and uppercase)",
+			DefaultAction: RuleActionCorrect,
+			check: func(item StockDataItem, _ time.Time) string {
+				if !tickerPattern.MatchString(item.Ticker) {
+					return fmt.Sprintf("invalid ticker %q", item.Ticker)
+				}
+				return ""
+			},
+			correct: func(item *StockDataItem, _ time.Time) bool {
+				item.Ticker = strings.ToUpper(strings.TrimSpace(item.Ticker))
+				return tickerPattern.MatchString(item.Ticker)
+			},
+		},
+		{
+			Name:          RuleFutureEventTime,
+			Description:   fmt.Sprintf("event time cannot be more than %s in the future (correct: use the current time)", settings.FutureTolerance),
+			DefaultAction: RuleActionQuarantine,
+			check: func(item StockDataItem, now time.Time) string {
+				if item.EventTime.After(now.Add(settings.FutureTolerance)) {
+					return fmt.Sprintf("event time %s is in the future", item.EventTime.Format(time.RFC3339))
+				}
+				return ""
+			},
+			correct: func(item *StockDataItem, now time.Time) bool {
+				item.EventTime = now
+				return true
+			},
+		},
+		{
+			Name:          RuleStaleEventTime,
+			Description:   fmt.Sprintf("event time cannot be before %s", settings.MinEventTime.Format("2006-01-02")),
+			DefaultAction: RuleActionQuarantine,
+			check: func(item StockDataItem, _ time.Time) string {
+				if !item.EventTime.IsZero() && item.EventTime.Before(settings.MinEventTime) {
+					return fmt.Sprintf("event time %s is too old", item.EventTime.Format(time.RFC3339))
+				}
+				return ""
+			},
+		},
+		{
+			Name:          RuleUnknownRating,
+			Description:   "ratings must be mapped in the rating taxonomy",
+			DefaultAction: RuleActionOff,
+			check: func(item StockDataItem, _ time.Time) string {
+				for _, rating := range []string{item.RatingFrom, item.RatingTo} {
+					if strings.TrimSpace(rating) != "" && entities.NormalizeRating(rating) == "" {
+						return fmt.Sprintf("rating %q is not mapped", rating)
+					}
+				}
+				return ""
+			},
+		},
+		{
+			Name:          RuleUnparseableTarget,
+			Description:   "target prices must be parseable (correct: clear the unparseable target)",
+			DefaultAction: RuleActionOff,
+			check: func(item StockDataItem, _ time.Time) string {
+				for _, target := range []string{item.TargetFrom, item.TargetTo} {
+					if !targetParseable(target) {
+						return fmt.Sprintf("target price %q cannot be parsed", target)
+					}
+				}
+				return ""
+			},
+			correct: func(item *StockDataItem, _ time.Time) bool {
+				if !targetParseable(item.TargetFrom) {
+					item.TargetFrom = ""
+				}
+				if !targetParseable(item.TargetTo) {
+					item.TargetTo = ""
+				}
+				return true
+			},
+		},
+	}
+}
+
+// targetParseable indica si un precio objetivo está vacío o se puede convertir a valor numérico
+func targetParseable(target string) bool {
+	if strings.TrimSpace(target) == "" {
+		return true
+	}
+	_, ok := entities.ParseTargetPrice(target)
+	return ok
+}
+
+// RuleViolation es un item que una regla desvió a la tabla de rechazos
+type RuleViolation struct {
+	Item    StockDataItem
+	Rule    string
+	Action  RuleAction // reject o quarantine
+	Message string
+}
+
+// RuleStats son las métricas acumuladas de una regla desde el arranque
+type RuleStats struct {
+	Name        string
+	Description string
+	Action      RuleAction
+	Evaluated   int64 // Items evaluados por la regla
+	Violations  int64 // Items que incumplieron la regla
+	Rejected    int64
+	Quarantined int64
+	Corrected   int64
+}
+
+// activeRule es una regla con su acción configurada y sus contadores
+type activeRule struct {
+	IngestionRule
+	action RuleAction
+
+	evaluated   atomic.Int64
+	violations  atomic.Int64
+	rejected    atomic.Int64
+	quarantined atomic.Int64
+	corrected   atomic.Int64
+}
+
+// RuleEngine evalúa las reglas de ingesta sobre los items de cada página; es seguro para uso concurrente
+type RuleEngine struct {
+	rules []*activeRule
+	now   func() time.Time
+}
+
+// NewRuleEngine builds the engine with the built-in rules. Unknown rules, unknown actions and the correct
+// action on a rule that cannot correct are configuration errors
+func NewRuleEngine(settings RuleSettings) (*RuleEngine, error) {
+	rules := IngestionRules(settings)
+
+	known := make(map[string]IngestionRule, len(rules))
+	for _, rule := range rules {
+		known[rule.Name] = rule
+	}
+	for name, action := range settings.Actions {
+		rule, ok := known[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown ingestion rule %q", name)
+		}
+		switch action {
+		case RuleActionOff, RuleActionReject, RuleActionQuarantine:
+		case RuleActionCorrect:
+			if !rule.CanCorrect() {
+				return nil, fmt.Errorf("ingestion rule %s cannot auto-correct items", name)
+			}
+		default:
+			return nil, fmt.Errorf("invalid action %q for ingestion rule %s", action, name)
+		}
+	}
+
+	engine := &RuleEngine{
+		rules: make([]*activeRule, 0, len(rules)),
+		now:   time.Now,
+	}
+	for _, rule := range rules {
+		action := rule.DefaultAction
+		if configured, ok := settings.Actions[rule.Name]; ok {
+			action = configured
+		}
+		engine.rules = append(engine.rules, &activeRule{IngestionRule: rule, action: action})
+	}
+	return engine, nil
+}
+
+// Apply evalúa las reglas sobre los items y devuelve los que se pueden ingerir (corregidos si corresponde)
+// y los que se desvían a rechazos. La primera regla que rechaza o pone en cuarentena un item corta su evaluación;
+// si una corrección no basta el item queda en cuarentena
+func (e *RuleEngine) Apply(items []StockDataItem) ([]StockDataItem, []RuleViolation) {
+	now := e.now().UTC()
+	accepted := make([]StockDataItem, 0, len(items))
+	var violations []RuleViolation
+
+	for _, item := range items {
+		violation, ok := e.applyItem(&item, now)
+		if !ok {
+			violations = append(violations, violation)
+			continue
+		}
+		accepted = append(accepted, item)
+	}
+	return accepted, violations
+}
+
+// applyItem evalúa las reglas activas sobre un item; devuelve false si el item no se debe ingerir
+func (e *RuleEngine) applyItem(item *StockDataItem, now time.Time) (RuleViolation, bool) {
+	for _, rule := range e.rules {
+		if rule.action == RuleActionOff {
+			continue
+		}
+
+		rule.evaluated.Add(1)
+		message := rule.check(*item, now)
+		if message == "" {
+			continue
+		}
+		rule.violations.Add(1)
+
+		action := rule.action
+		if action == RuleActionCorrect {
+			if rule.correct(item, now) {
+				rule.corrected.Add(1)
+				continue
+			}
+			action = RuleActionQuarantine
+		}
+
+		if action == RuleActionReject {
+			rule.rejected.Add(1)
+		} else {
+			rule.quarantined.Add(1)
+		}
+		return RuleViolation{Item: *item, Rule: rule.Name, Action: action, Message: message}, false
+	}
+	return RuleViolation{}, true
+}
+
+// Stats returns the configured action and the counters of every rule in evaluation order
+func (e *RuleEngine) Stats() []RuleStats {
+	stats := make([]RuleStats, 0, len(e.rules))
+	for _, rule := range e.rules {
+		stats = append(stats, RuleStats{
+			Name:        rule.Name,
+			Description: rule.Description,
+			Action:      rule.action,
+			Evaluated:   rule.evaluated.Load(),
+			Violations:  rule.violations.Load(),
+			Rejected:    rule.rejected.Load(),
+			Quarantined: rule.quarantined.Load(),
+			Corrected:   rule.corrected.Load(),
+		})
+	}
+	return stats
+}
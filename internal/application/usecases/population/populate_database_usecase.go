@@ -2,10 +2,13 @@ package population
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/events"
 	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
 	"github.com/MayaCris/stock-info-app/internal/domain/services"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
@@ -21,6 +24,27 @@ type PopulationConfig struct {
 	UseCache      bool          // Si usar cache durante la población
 	DryRun        bool          // Solo mostrar qué se haría sin ejecutar
 	ValidateAfter bool          // Validar integridad después de la población
+	Incremental   bool          // Solo ingerir ratings más nuevos que el último sync y dejar de paginar al alcanzarlo
+	Source        string        // Fuente de datos para el estado de sync (por defecto DefaultSyncSource)
+
+	// OnProgress se invoca después de cada página procesada con una copia del resultado parcial
+	OnProgress func(partial PopulationResult)
+}
+
+// DefaultSyncSource identifica la fuente de datos por defecto en la tabla de estado de sync
+const DefaultSyncSource = "stock_api"
+
+// DefaultPopulationConfig devuelve la configuración de población por defecto
+func DefaultPopulationConfig() PopulationConfig {
+	return PopulationConfig{
+		BatchSize:     20,
+		MaxPages:      5,
+		DelayBetween:  100 * time.Millisecond,
+		ClearFirst:    false,
+		UseCache:      true,
+		DryRun:        false,
+		ValidateAfter: true,
+	}
 }
 
 // PopulationResult contiene los resultados de la población
@@ -36,6 +60,24 @@ type PopulationResult struct {
 	StockRatings   int
 	Duration       time.Duration
 	Errors         []string
+	RejectedItems  int            // Items enviados a la tabla de rechazos (dead-letter)
+	RuleViolations map[string]int // Items rechazados o en cuarentena por cada regla de ingesta
+
+	// Sync incremental
+	NewestEventTime  time.Time // event_time más reciente visto en esta ejecución
+	OldestEventTime  time.Time // event_time más antiguo recibido de la fuente en esta ejecución
+	ReachedWatermark bool      // Se dejó de paginar al alcanzar datos ya ingeridos
+	SourceExhausted  bool      // La fuente no tenía más páginas
+}
+
+// CoversWatermark reports whether the run fetched every page newer than the previous watermark: it reached
+// already-ingested data, the source ran out of pages or it received an item at or before the watermark.
+// A run cut short by MaxPages does not, and advancing the watermark would skip the unfetched pages forever
+func (r *PopulationResult) CoversWatermark(previous *entities.SyncState) bool {
+	if !previous.HasWatermark() || r.ReachedWatermark || r.SourceExhausted {
+		return true
+	}
+	return !r.OldestEventTime.IsZero() && !r.OldestEventTime.After(previous.LastEventTime)
 }
 
 // StockDataProvider representa cualquier fuente de datos de stock
@@ -48,21 +90,34 @@ type StockDataProvider interface {
 // StockDataPage representa una página de datos
 type StockDataPage struct {
 	Items    []StockDataItem
+	Rejected []RejectedItem // Items descartados por la fuente al no pasar validación
 	NextPage string
 	HasMore  bool
 }
 
-// StockDataItem representa un item de datos de stock
+// StockDataItem representa un item de datos de stock.
+// Los tags JSON coinciden con el formato de la API para poder reprocesar payloads rechazados.
 type StockDataItem struct {
+	Ticker     string    `json:"ticker"`
+	Company    string    `json:"company"`
+	Brokerage  string    `json:"brokerage"`
+	Action     string    `json:"action"`
+	RatingFrom string    `json:"rating_from"`
+	RatingTo   string    `json:"rating_to"`
+	TargetFrom string    `json:"target_from"`
+	TargetTo   string    `json:"target_to"`
+	EventTime  time.Time `json:"time"`
+
+	RawData json.RawMessage `json:"-"` // Item original de la fuente, se guarda en el rating para poder re-procesarlo
+}
+
+// RejectedItem representa un item que la fuente no pudo convertir a StockDataItem
+type RejectedItem struct {
 	Ticker     string
-	Company    string
 	Brokerage  string
-	Action     string
-	RatingFrom string
-	RatingTo   string
-	TargetFrom string
-	TargetTo   string
-	EventTime  time.Time
+	Reason     string
+	Error      string
+	RawPayload string // Item original serializado como JSON
 }
 
 // PopulateDatabaseUseCase implementa el caso de uso de población de base de datos
@@ -70,19 +125,59 @@ type PopulateDatabaseUseCase struct {
 	companyRepo        interfaces.TransactionalCompanyRepository
 	brokerageRepo      interfaces.TransactionalBrokerageRepository
 	stockRatingRepo    interfaces.TransactionalStockRatingRepository
+	syncStateRepo      interfaces.SyncStateRepository
+	rejectRepo         interfaces.PopulationRejectRepository
 	cacheService       services.CacheService
+	cacheTTLs          CacheTTLs
+	cacheTTLsMu        sync.RWMutex
 	dataProvider       StockDataProvider
 	transactionService services.TransactionService
 	integrityService   services.IntegrityValidationService
+	eventPublisher     events.Publisher // Opcional: publica RatingCreated por cada rating insertado
+	ruleEngine         *RuleEngine      // Opcional: reglas de validación evaluadas antes de procesar cada página
 	logger             logger.PopulationLogger
 }
 
+// CacheTTLs define el TTL de las entradas que la población escribe en la cache
+type CacheTTLs struct {
+	Company     time.Duration
+	Brokerage   time.Duration
+	StockRating time.Duration
+}
+
+// DefaultCacheTTLs returns the TTLs used when none are configured
+func DefaultCacheTTLs() CacheTTLs {
+	return CacheTTLs{
+		Company:     5 * time.Minute,
+		Brokerage:   5 * time.Minute,
+		StockRating: 5 * time.Minute,
+	}
+}
+
+// withDefaults sustituye los TTLs no positivos por los valores por defecto
+func (t CacheTTLs) withDefaults() CacheTTLs {
+	defaults := DefaultCacheTTLs()
+	if t.Company <= 0 {
+		t.Company = defaults.Company
+	}
+	if t.Brokerage <= 0 {
+		t.Brokerage = defaults.Brokerage
+	}
+	if t.StockRating <= 0 {
+		t.StockRating = defaults.StockRating
+	}
+	return t
+}
+
 // NewPopulateDatabaseUseCase crea una nueva instancia del caso de uso
 func NewPopulateDatabaseUseCase(
 	companyRepo interfaces.TransactionalCompanyRepository,
 	brokerageRepo interfaces.TransactionalBrokerageRepository,
 	stockRatingRepo interfaces.TransactionalStockRatingRepository,
+	syncStateRepo interfaces.SyncStateRepository,
+	rejectRepo interfaces.PopulationRejectRepository,
 	cacheService services.CacheService,
+	cacheTTLs CacheTTLs,
 	dataProvider StockDataProvider,
 	transactionService services.TransactionService,
 	integrityService services.IntegrityValidationService,
@@ -92,7 +187,10 @@ func NewPopulateDatabaseUseCase(
 		companyRepo:        companyRepo,
 		brokerageRepo:      brokerageRepo,
 		stockRatingRepo:    stockRatingRepo,
+		syncStateRepo:      syncStateRepo,
+		rejectRepo:         rejectRepo,
 		cacheService:       cacheService,
+		cacheTTLs:          cacheTTLs.withDefaults(),
 		dataProvider:       dataProvider,
 		transactionService: transactionService,
 		integrityService:   integrityService,
@@ -100,6 +198,38 @@ func NewPopulateDatabaseUseCase(
 	}
 }
 
+// SetCacheTTLs reemplaza los TTLs de las entradas de cache (recarga de configuración en caliente)
+func (uc *PopulateDatabaseUseCase) SetCacheTTLs(ttls CacheTTLs) {
+	uc.cacheTTLsMu.Lock()
+	defer uc.cacheTTLsMu.Unlock()
+	uc.cacheTTLs = ttls.withDefaults()
+}
+
+// SetEventPublisher configura el bus al que se publican los eventos RatingCreated (nil deja de publicarlos)
+func (uc *PopulateDatabaseUseCase) SetEventPublisher(publisher events.Publisher) {
+	uc.eventPublisher = publisher
+}
+
+// SetRuleEngine configura las reglas de validación de la ingesta (nil deja de evaluarlas)
+func (uc *PopulateDatabaseUseCase) SetRuleEngine(engine *RuleEngine) {
+	uc.ruleEngine = engine
+}
+
+// RuleStats returns the metrics of the ingestion rules (nil if the rules are disabled)
+func (uc *PopulateDatabaseUseCase) RuleStats() []RuleStats {
+	if uc.ruleEngine == nil {
+		return nil
+	}
+	return uc.ruleEngine.Stats()
+}
+
+// currentCacheTTLs returns the cache TTLs in effect
+func (uc *PopulateDatabaseUseCase) currentCacheTTLs() CacheTTLs {
+	uc.cacheTTLsMu.RLock()
+	defer uc.cacheTTLsMu.RUnlock()
+	return uc.cacheTTLs
+}
+
 // Execute ejecuta el caso de uso de población
 func (uc *PopulateDatabaseUseCase) Execute(ctx context.Context, config PopulationConfig) (*PopulationResult, error) {
 	startTime := time.Now()
@@ -129,12 +259,27 @@ func (uc *PopulateDatabaseUseCase) Execute(ctx context.Context, config Populatio
 		uc.logger.Info(ctx, "🧹 Database cleared successfully", logger.String("operation", "clear_database"))
 	}
 
-	// 2. Process pages
-	if err := uc.processPages(ctx, config, result); err != nil {
+	if config.Source == "" {
+		config.Source = DefaultSyncSource
+	}
+
+	// 2. Load watermark for incremental runs
+	var syncState *entities.SyncState
+	if config.Incremental {
+		syncState = uc.loadSyncState(ctx, config.Source)
+	}
+
+	// 3. Process pages
+	if err := uc.processPages(ctx, config, syncState, result); err != nil {
 		return nil, fmt.Errorf("failed to process pages: %w", err)
 	}
 
-	// 3. Validate after population if requested
+	// 4. Advance watermark
+	if !config.DryRun {
+		uc.saveSyncState(ctx, config.Source, syncState, result)
+	}
+
+	// 5. Validate after population if requested
 	if config.ValidateAfter && !config.DryRun {
 		if err := uc.validateIntegrityEnhanced(ctx, result); err != nil {
 			uc.logger.Warn(ctx, "⚠️ Validation warnings encountered", logger.ErrorField(err))
@@ -164,11 +309,11 @@ func (uc *PopulateDatabaseUseCase) Execute(ctx context.Context, config Populatio
 }
 
 // processPages procesa todas las páginas de datos
-func (uc *PopulateDatabaseUseCase) processPages(ctx context.Context, config PopulationConfig, result *PopulationResult) error {
+func (uc *PopulateDatabaseUseCase) processPages(ctx context.Context, config PopulationConfig, syncState *entities.SyncState, result *PopulationResult) error {
 	currentPage := ""
 
 	for pageNum := 1; pageNum <= config.MaxPages; pageNum++ {
-		uc.logger.LogPageProcessing(ctx, pageNum, config.MaxPages,  0)
+		uc.logger.LogPageProcessing(ctx, pageNum, config.MaxPages, 0)
 
 		// Increment pages requested (including empty ones) - count every page we attempt to fetch
 		result.PagesRequested++
@@ -185,26 +330,53 @@ func (uc *PopulateDatabaseUseCase) processPages(ctx context.Context, config Popu
 			continue
 		}
 
+		// Enviar a la tabla de rechazos los items que no pasaron validación en la fuente
+		if len(dataPage.Rejected) > 0 && !config.DryRun {
+			uc.recordSourceRejects(ctx, config.Source, dataPage.Rejected, result)
+		}
+
 		if len(dataPage.Items) == 0 {
 			uc.logger.Info(ctx, "📄 No more data available",
 				logger.Int("page_number", pageNum),
 				logger.String("operation", "page_complete"))
+			result.SourceExhausted = true
 			break
 		}
+		trackOldestEventTime(dataPage.Items, result)
 
-		// Update page processing log with actual item count
-		uc.logger.LogPageProcessing(ctx, pageNum, config.MaxPages, len(dataPage.Items))
+		// Incremental: descartar items ya ingeridos y detenerse al alcanzar el watermark
+		if syncState.HasWatermark() {
+			newItems := filterNewItems(dataPage.Items, syncState)
+			result.SkippedItems += len(dataPage.Items) - len(newItems)
+
+			if len(newItems) == 0 {
+				result.ReachedWatermark = true
+				uc.logger.Info(ctx, "⏹️ Reached already-ingested data, stopping incremental sync",
+					logger.Int("page_number", pageNum),
+					logger.String("watermark", syncState.LastEventTime.Format(time.RFC3339)),
+					logger.String("operation", "incremental_sync"))
+				break
+			}
+			dataPage.Items = newItems
+		}
 
 		// Only count pages with data
 		result.TotalPages++
 		result.TotalItems += len(dataPage.Items)
 
+		// Las reglas de ingesta corrigen items o los desvían a rechazos antes de mover el watermark
+		dataPage.Items = uc.applyIngestionRules(ctx, config, dataPage.Items, result)
+		trackNewestEventTime(dataPage.Items, result)
+
+		// Update page processing log with actual item count
+		uc.logger.LogPageProcessing(ctx, pageNum, config.MaxPages, len(dataPage.Items))
+
 		if config.DryRun {
 			uc.logger.Info(ctx, "🔍 DRY RUN: Would process items",
 				logger.Int("item_count", len(dataPage.Items)),
 				logger.String("operation", "dry_run"))
 			result.ProcessedItems += len(dataPage.Items)
-		} else {
+		} else if len(dataPage.Items) > 0 {
 			// Process batch
 			if err := uc.processBatch(ctx, dataPage.Items, config, result); err != nil {
 				errMsg := fmt.Sprintf("Failed to process batch on page %d: %v", pageNum, err)
@@ -216,8 +388,11 @@ func (uc *PopulateDatabaseUseCase) processPages(ctx context.Context, config Popu
 			}
 		}
 
+		uc.reportProgress(config, result)
+
 		// Check if there are more pages
 		if !dataPage.HasMore {
+			result.SourceExhausted = true
 			break
 		}
 		currentPage = dataPage.NextPage
@@ -231,13 +406,167 @@ func (uc *PopulateDatabaseUseCase) processPages(ctx context.Context, config Popu
 	return nil
 }
 
+// filterNewItems retorna los items que no son anteriores al watermark.
+// Los items con el mismo event_time se conservan: pueden ser ratings distintos y el insert ignora duplicados.
+func filterNewItems(items []StockDataItem, syncState *entities.SyncState) []StockDataItem {
+	newItems := make([]StockDataItem, 0, len(items))
+	for _, item := range items {
+		if !syncState.IsAlreadyIngested(item.EventTime) {
+			newItems = append(newItems, item)
+		}
+	}
+	return newItems
+}
+
+// trackOldestEventTime actualiza el event_time más antiguo recibido de la fuente, antes de filtrar o aplicar reglas
+func trackOldestEventTime(items []StockDataItem, result *PopulationResult) {
+	for _, item := range items {
+		if !item.EventTime.IsZero() && (result.OldestEventTime.IsZero() || item.EventTime.Before(result.OldestEventTime)) {
+			result.OldestEventTime = item.EventTime
+		}
+	}
+}
+
+// trackNewestEventTime actualiza el event_time más reciente visto en la ejecución
+func trackNewestEventTime(items []StockDataItem, result *PopulationResult) {
+	for _, item := range items {
+		if item.EventTime.After(result.NewestEventTime) {
+			result.NewestEventTime = item.EventTime
+		}
+	}
+}
+
+// loadSyncState obtiene el watermark de la fuente; si no existe la ejecución se comporta como completa
+func (uc *PopulateDatabaseUseCase) loadSyncState(ctx context.Context, source string) *entities.SyncState {
+	if uc.syncStateRepo == nil {
+		uc.logger.Warn(ctx, "⚠️ Sync state repository not configured, running full population",
+			logger.String("operation", "incremental_sync"))
+		return nil
+	}
+
+	syncState, err := uc.syncStateRepo.GetBySource(ctx, source)
+	if err != nil {
+		uc.logger.Warn(ctx, "⚠️ Failed to load sync state, running full population",
+			logger.String("source", source),
+			logger.ErrorField(err),
+			logger.String("operation", "incremental_sync"))
+		return nil
+	}
+
+	if !syncState.HasWatermark() {
+		uc.logger.Info(ctx, "📭 No previous sync found, running full population",
+			logger.String("source", source),
+			logger.String("operation", "incremental_sync"))
+		return nil
+	}
+
+	uc.logger.Info(ctx, "🔖 Incremental sync from watermark",
+		logger.String("source", source),
+		logger.String("watermark", syncState.LastEventTime.Format(time.RFC3339)),
+		logger.String("operation", "incremental_sync"))
+
+	return syncState
+}
+
+// saveSyncState avanza el watermark de la fuente tras una ejecución sin errores.
+// Si hubo errores, o la ejecución se cortó en MaxPages antes de llegar al watermark, no se avanza
+// para que el próximo sync reintente los datos no ingeridos.
+func (uc *PopulateDatabaseUseCase) saveSyncState(ctx context.Context, source string, previous *entities.SyncState, result *PopulationResult) {
+	if uc.syncStateRepo == nil || result.NewestEventTime.IsZero() {
+		return
+	}
+
+	if result.ErrorCount > 0 {
+		uc.logger.Warn(ctx, "⚠️ Population finished with errors, sync watermark not advanced",
+			logger.String("source", source),
+			logger.Int("error_count", result.ErrorCount),
+			logger.String("operation", "sync_state"))
+		return
+	}
+
+	// Las ejecuciones completas no cargan el watermark: nunca retroceder el almacenado
+	if previous == nil {
+		previous, _ = uc.syncStateRepo.GetBySource(ctx, source)
+	}
+	if previous.HasWatermark() && !result.NewestEventTime.After(previous.LastEventTime) {
+		return
+	}
+	if !result.CoversWatermark(previous) {
+		uc.logger.Warn(ctx, "⚠️ Population stopped before reaching the sync watermark, watermark not advanced",
+			logger.String("source", source),
+			logger.String("watermark", previous.LastEventTime.Format(time.RFC3339)),
+			logger.Int("pages_requested", result.PagesRequested),
+			logger.String("operation", "sync_state"))
+		return
+	}
+
+	state := entities.NewSyncState(source, result.NewestEventTime)
+	state.LastRunItems = result.ProcessedItems
+
+	if err := uc.syncStateRepo.Upsert(ctx, state); err != nil {
+		uc.logger.Warn(ctx, "⚠️ Failed to save sync state",
+			logger.String("source", source),
+			logger.ErrorField(err),
+			logger.String("operation", "sync_state"))
+		return
+	}
+
+	uc.logger.Info(ctx, "🔖 Sync watermark advanced",
+		logger.String("source", source),
+		logger.String("watermark", state.LastEventTime.Format(time.RFC3339)),
+		logger.String("operation", "sync_state"))
+}
+
+// reportProgress notifica el progreso parcial si la configuración define un callback
+func (uc *PopulateDatabaseUseCase) reportProgress(config PopulationConfig, result *PopulationResult) {
+	if config.OnProgress == nil {
+		return
+	}
+
+	partial := *result
+	partial.Errors = append([]string(nil), result.Errors...)
+	if result.RuleViolations != nil {
+		partial.RuleViolations = make(map[string]int, len(result.RuleViolations))
+		for rule, count := range result.RuleViolations {
+			partial.RuleViolations[rule] = count
+		}
+	}
+	config.OnProgress(partial)
+}
+
 // processBatch procesa un lote de items de forma atómica con transacciones
 func (uc *PopulateDatabaseUseCase) processBatch(ctx context.Context, items []StockDataItem, config PopulationConfig, result *PopulationResult) error {
+	rejects, inserted, err := uc.executeBatch(ctx, items, result)
+	if err != nil {
+		return err
+	}
+
+	// Los rechazos se guardan fuera de la transacción del lote para que no se pierdan con un rollback
+	uc.recordBatchRejects(ctx, config.Source, rejects, result)
+
+	// Los eventos se publican solo cuando el lote ya está confirmado
+	uc.publishRatingsCreated(ctx, inserted)
+
+	return nil
+}
+
+// executeBatch ejecuta la transacción del lote y devuelve los items que no se pudieron resolver
+// y los ratings insertados
+func (uc *PopulateDatabaseUseCase) executeBatch(ctx context.Context, items []StockDataItem, result *PopulationResult) ([]itemReject, []*entities.StockRating, error) {
 	startTime := time.Now()
 	uc.logger.LogBatchProcessing(ctx, len(items), "transactional_batch")
 
+	var (
+		rejects  []itemReject
+		inserted []*entities.StockRating
+	)
+
 	// Usar el servicio transaccional para garantizar atomicidad
 	err := uc.transactionService.ExecuteWithRetry(ctx, 3, func(ctx context.Context) error {
+		// Cada reintento vuelve a evaluar todos los items
+		rejects = rejects[:0]
+		inserted = inserted[:0]
+
 		return uc.transactionService.ExecuteInTransaction(ctx, func(ctx context.Context, tx *gorm.DB) error {
 			// Process companies and brokerages first (to ensure they exist)
 			if err := uc.processCompaniesAndBrokeragesTransactional(ctx, tx, items, result); err != nil {
@@ -245,7 +574,7 @@ func (uc *PopulateDatabaseUseCase) processBatch(ctx context.Context, items []Sto
 			}
 
 			// Then process stock ratings
-			if err := uc.processStockRatingsTransactional(ctx, tx, items, result); err != nil {
+			if err := uc.processStockRatingsTransactional(ctx, tx, items, result, &rejects, &inserted); err != nil {
 				return fmt.Errorf("failed to process stock ratings: %w", err)
 			}
 
@@ -256,7 +585,44 @@ func (uc *PopulateDatabaseUseCase) processBatch(ctx context.Context, items []Sto
 	duration := time.Since(startTime)
 	uc.logger.LogTransactionOperation(ctx, "batch_processing", 0, err == nil, duration)
 
-	return err
+	if err != nil {
+		return nil, nil, err
+	}
+	return rejects, inserted, nil
+}
+
+// publishRatingsCreated publica un evento RatingCreated por cada rating insertado; un fallo solo se registra.
+// Con un events.TxPublisher (outbox) no hace nada: los eventos ya se guardaron en la transacción del lote
+func (uc *PopulateDatabaseUseCase) publishRatingsCreated(ctx context.Context, ratings []*entities.StockRating) {
+	if uc.eventPublisher == nil || len(ratings) == 0 {
+		return
+	}
+	if _, transactional := uc.eventPublisher.(events.TxPublisher); transactional {
+		return
+	}
+
+	batch := uc.ratingsCreatedEvents(ctx, ratings)
+	if err := uc.eventPublisher.Publish(ctx, batch...); err != nil {
+		uc.logger.Warn(ctx, "Failed to publish rating created events",
+			logger.Int("events", len(batch)),
+			logger.String("error", err.Error()))
+	}
+}
+
+// ratingsCreatedEvents construye los eventos RatingCreated de los ratings insertados
+func (uc *PopulateDatabaseUseCase) ratingsCreatedEvents(ctx context.Context, ratings []*entities.StockRating) []events.Event {
+	batch := make([]events.Event, 0, len(ratings))
+	for _, rating := range ratings {
+		event, err := events.NewRatingCreated(rating)
+		if err != nil {
+			uc.logger.Warn(ctx, "Failed to build rating created event",
+				logger.String("rating_id", rating.ID.String()),
+				logger.String("error", err.Error()))
+			continue
+		}
+		batch = append(batch, event)
+	}
+	return batch
 }
 
 // processCompaniesAndBrokerages procesa companies y brokerages
@@ -297,7 +663,7 @@ func (uc *PopulateDatabaseUseCase) processCompaniesAndBrokerages(ctx context.Con
 
 		// Cache if enabled
 		if uc.cacheService != nil {
-			uc.cacheService.SetCompany(ctx, ticker, company, 5*time.Minute)
+			uc.cacheService.SetCompany(ctx, ticker, company, uc.currentCacheTTLs().Company)
 		}
 	}
 
@@ -321,7 +687,7 @@ func (uc *PopulateDatabaseUseCase) processCompaniesAndBrokerages(ctx context.Con
 
 		// Cache if enabled
 		if uc.cacheService != nil {
-			uc.cacheService.SetBrokerage(ctx, name, brokerage, 5*time.Minute)
+			uc.cacheService.SetBrokerage(ctx, name, brokerage, uc.currentCacheTTLs().Brokerage)
 		}
 	}
 
@@ -359,6 +725,7 @@ func (uc *PopulateDatabaseUseCase) processStockRatings(ctx context.Context, item
 		stockRating.RatingTo = item.RatingTo
 		stockRating.TargetFrom = item.TargetFrom
 		stockRating.TargetTo = item.TargetTo
+		stockRating.RawData = item.RawData
 
 		if err := uc.stockRatingRepo.Create(ctx, stockRating); err != nil {
 			result.ErrorCount++
@@ -371,7 +738,7 @@ func (uc *PopulateDatabaseUseCase) processStockRatings(ctx context.Context, item
 
 		// Cache if enabled
 		if uc.cacheService != nil {
-			uc.cacheService.SetStockRating(ctx, stockRating, 5*time.Minute)
+			uc.cacheService.SetStockRating(ctx, stockRating, uc.currentCacheTTLs().StockRating)
 		}
 	}
 
@@ -484,7 +851,7 @@ func (uc *PopulateDatabaseUseCase) processCompaniesAndBrokeragesTransactional(ct
 
 		// Cache if enabled (cache operations outside transaction for better performance)
 		if uc.cacheService != nil {
-			uc.cacheService.SetCompany(ctx, ticker, createdOrExisting, 5*time.Minute)
+			uc.cacheService.SetCompany(ctx, ticker, createdOrExisting, uc.currentCacheTTLs().Company)
 		}
 	}
 	// Process brokerages using transaction with duplicate handling
@@ -516,7 +883,7 @@ func (uc *PopulateDatabaseUseCase) processCompaniesAndBrokeragesTransactional(ct
 
 		// Cache if enabled
 		if uc.cacheService != nil {
-			uc.cacheService.SetBrokerage(ctx, name, createdOrExisting, 5*time.Minute)
+			uc.cacheService.SetBrokerage(ctx, name, createdOrExisting, uc.currentCacheTTLs().Brokerage)
 		}
 	}
 
@@ -524,7 +891,7 @@ func (uc *PopulateDatabaseUseCase) processCompaniesAndBrokeragesTransactional(ct
 }
 
 // processStockRatingsTransactional procesa los stock ratings usando transacciones
-func (uc *PopulateDatabaseUseCase) processStockRatingsTransactional(ctx context.Context, tx *gorm.DB, items []StockDataItem, result *PopulationResult) error {
+func (uc *PopulateDatabaseUseCase) processStockRatingsTransactional(ctx context.Context, tx *gorm.DB, items []StockDataItem, result *PopulationResult, rejects *[]itemReject, inserted *[]*entities.StockRating) error {
 	uc.logger.Debug(ctx, "Processing stock ratings in transaction",
 		logger.String("operation", "process_stock_ratings_tx"),
 		logger.Int("items_count", len(items)))
@@ -540,7 +907,8 @@ func (uc *PopulateDatabaseUseCase) processStockRatingsTransactional(ctx context.
 			result.Errors = append(result.Errors, fmt.Sprintf("Company not found for ticker %s: %v", item.Ticker, err))
 			uc.logger.LogEntityError(ctx, "stock_rating", fmt.Sprintf("%s-%s", item.Ticker, item.Brokerage), err,
 				logger.String("ticker", item.Ticker),
-				logger.String("reason", "company_not_found"))
+				logger.String("reason", RejectReasonCompanyNotFound))
+			*rejects = append(*rejects, itemReject{item: item, stage: entities.RejectStageCompanyResolution, reason: RejectReasonCompanyNotFound, err: err})
 			continue
 		}
 
@@ -551,7 +919,8 @@ func (uc *PopulateDatabaseUseCase) processStockRatingsTransactional(ctx context.
 			result.Errors = append(result.Errors, fmt.Sprintf("Brokerage not found %s: %v", item.Brokerage, err))
 			uc.logger.LogEntityError(ctx, "stock_rating", fmt.Sprintf("%s-%s", item.Ticker, item.Brokerage), err,
 				logger.String("brokerage", item.Brokerage),
-				logger.String("reason", "brokerage_not_found"))
+				logger.String("reason", RejectReasonBrokerageNotFound))
+			*rejects = append(*rejects, itemReject{item: item, stage: entities.RejectStageBrokerageResolution, reason: RejectReasonBrokerageNotFound, err: err})
 			continue
 		}
 
@@ -568,6 +937,7 @@ func (uc *PopulateDatabaseUseCase) processStockRatingsTransactional(ctx context.
 		stockRating.RatingTo = item.RatingTo
 		stockRating.TargetFrom = item.TargetFrom
 		stockRating.TargetTo = item.TargetTo
+		stockRating.RawData = item.RawData
 
 		// Add to bulk insert collection
 		stockRatings = append(stockRatings, stockRating)
@@ -575,7 +945,7 @@ func (uc *PopulateDatabaseUseCase) processStockRatingsTransactional(ctx context.
 
 	// Perform bulk insert ignoring duplicates
 	if len(stockRatings) > 0 {
-		insertedCount, err := uc.stockRatingRepo.BulkInsertIgnoreDuplicatesWithTx(ctx, tx, stockRatings)
+		insertedRatings, err := uc.stockRatingRepo.BulkInsertIgnoreDuplicatesReturningWithTx(ctx, tx, stockRatings)
 		if err != nil {
 			result.ErrorCount++
 			result.Errors = append(result.Errors, fmt.Sprintf("Failed to bulk insert stock ratings: %v", err))
@@ -584,6 +954,20 @@ func (uc *PopulateDatabaseUseCase) processStockRatingsTransactional(ctx context.
 			return err
 		}
 
+		// Outbox: los eventos se guardan en la misma transacción, si no se pueden guardar el lote se revierte
+		if txPublisher, ok := uc.eventPublisher.(events.TxPublisher); ok && len(insertedRatings) > 0 {
+			if err := txPublisher.PublishWithTx(ctx, tx, uc.ratingsCreatedEvents(ctx, insertedRatings)...); err != nil {
+				result.ErrorCount++
+				result.Errors = append(result.Errors, fmt.Sprintf("Failed to store rating created events: %v", err))
+				uc.logger.Error(ctx, "❌ Failed to store rating created events in the outbox", err,
+					logger.String("operation", "bulk_insert_stock_ratings"))
+				return err
+			}
+		}
+
+		*inserted = append(*inserted, insertedRatings...)
+		insertedCount := len(insertedRatings)
+
 		// Update metrics
 		result.StockRatings += insertedCount
 		result.ProcessedItems += insertedCount
@@ -600,7 +984,7 @@ func (uc *PopulateDatabaseUseCase) processStockRatingsTransactional(ctx context.
 		// Cache inserted ratings if enabled
 		if uc.cacheService != nil {
 			for _, stockRating := range stockRatings {
-				uc.cacheService.SetStockRating(ctx, stockRating, 5*time.Minute)
+				uc.cacheService.SetStockRating(ctx, stockRating, uc.currentCacheTTLs().StockRating)
 			}
 		}
 	}
@@ -0,0 +1,95 @@
+package population
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+)
+
+// DefaultReprocessLimit es el máximo de rechazos pendientes que se reprocesan cuando no se indican IDs
+const DefaultReprocessLimit = 100
+
+// ErrRejectNotPending se devuelve al intentar descartar un rechazo ya resuelto
+var ErrRejectNotPending = errors.New("population reject is not pending")
+
+// RejectService expone la tabla de rechazos (dead-letter) de la población para su inspección y reproceso
+type RejectService struct {
+	repo    interfaces.PopulationRejectRepository
+	useCase *PopulateDatabaseUseCase
+}
+
+// NewRejectService crea un nuevo servicio de rechazos
+func NewRejectService(repo interfaces.PopulationRejectRepository, useCase *PopulateDatabaseUseCase) *RejectService {
+	return &RejectService{
+		repo:    repo,
+		useCase: useCase,
+	}
+}
+
+// List retorna una página de rechazos y el total, opcionalmente filtrados por estado
+func (s *RejectService) List(ctx context.Context, status entities.PopulationRejectStatus, limit, offset int) ([]*entities.PopulationReject, int64, error) {
+	rejects, err := s.repo.List(ctx, status, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.repo.Count(ctx, status)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return rejects, total, nil
+}
+
+// Get retorna un rechazo por ID
+func (s *RejectService) Get(ctx context.Context, id uuid.UUID) (*entities.PopulationReject, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// Discard descarta un rechazo pendiente para que no se vuelva a reprocesar
+func (s *RejectService) Discard(ctx context.Context, id uuid.UUID) (*entities.PopulationReject, error) {
+	reject, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !reject.IsPending() {
+		return reject, ErrRejectNotPending
+	}
+
+	if err := s.repo.MarkDiscarded(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByID(ctx, id)
+}
+
+// Reprocess reprocesa los rechazos indicados, o los pendientes más recientes (hasta limit) si no se indican IDs
+func (s *RejectService) Reprocess(ctx context.Context, ids []uuid.UUID, limit int) (*ReprocessResult, error) {
+	if s.useCase == nil {
+		return nil, ErrRejectsNotConfigured
+	}
+
+	var (
+		rejects []*entities.PopulationReject
+		err     error
+	)
+
+	if len(ids) > 0 {
+		rejects, err = s.repo.GetByIDs(ctx, ids)
+	} else {
+		if limit <= 0 {
+			limit = DefaultReprocessLimit
+		}
+		rejects, err = s.repo.List(ctx, entities.PopulationRejectStatusPending, limit, 0)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.useCase.ReprocessRejects(ctx, rejects)
+}
@@ -0,0 +1,249 @@
+package population
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// Motivos de rechazo de items durante la población
+const (
+	RejectReasonMissingFields     = "missing_required_fields"
+	RejectReasonInvalidEventTime  = "invalid_event_time"
+	RejectReasonInvalidPayload    = "invalid_payload"
+	RejectReasonCompanyNotFound   = "company_not_found"
+	RejectReasonBrokerageNotFound = "brokerage_not_found"
+)
+
+// ErrRejectsNotConfigured se devuelve cuando el caso de uso no tiene repositorio de rechazos
+var ErrRejectsNotConfigured = errors.New("population rejects repository is not configured")
+
+// ReprocessResult resume el reproceso de items rechazados
+type ReprocessResult struct {
+	Requested   int
+	Reprocessed int
+	Failed      int
+	Skipped     int // Rechazos que ya no estaban pendientes
+	Errors      []string
+}
+
+// itemReject es un item que no se pudo resolver dentro de la transacción del lote
+type itemReject struct {
+	item   StockDataItem
+	stage  string
+	reason string
+	err    error
+}
+
+// Validate verifica que el item tenga los campos requeridos para ser ingerido
+func (item StockDataItem) Validate() error {
+	if item.Ticker == "" || item.Company == "" || item.Brokerage == "" || item.Action == "" {
+		return fmt.Errorf("%s: ticker, company, brokerage and action are required", RejectReasonMissingFields)
+	}
+	if item.EventTime.IsZero() {
+		return fmt.Errorf("%s: event time is required", RejectReasonInvalidEventTime)
+	}
+	return nil
+}
+
+// key identifica un item dentro de un lote
+func (item StockDataItem) key() string {
+	return fmt.Sprintf("%s|%s|%s|%d", item.Ticker, item.Brokerage, item.Action, item.EventTime.UnixNano())
+}
+
+// ReprocessRejects vuelve a ejecutar el pipeline para los rechazos pendientes indicados.
+// Los que se ingieren se marcan como reprocesados; los que fallan siguen pendientes con el nuevo error.
+func (uc *PopulateDatabaseUseCase) ReprocessRejects(ctx context.Context, rejects []*entities.PopulationReject) (*ReprocessResult, error) {
+	if uc.rejectRepo == nil {
+		return nil, ErrRejectsNotConfigured
+	}
+
+	result := &ReprocessResult{
+		Requested: len(rejects),
+		Errors:    make([]string, 0),
+	}
+
+	type candidate struct {
+		reject *entities.PopulationReject
+		item   StockDataItem
+	}
+
+	candidates := make([]candidate, 0, len(rejects))
+	items := make([]StockDataItem, 0, len(rejects))
+	for _, reject := range rejects {
+		if !reject.IsPending() {
+			result.Skipped++
+			continue
+		}
+
+		var item StockDataItem
+		if err := json.Unmarshal([]byte(reject.RawPayload), &item); err != nil {
+			uc.failReprocess(ctx, reject, fmt.Errorf("%s: %w", RejectReasonInvalidPayload, err), result)
+			continue
+		}
+		if err := item.Validate(); err != nil {
+			uc.failReprocess(ctx, reject, err, result)
+			continue
+		}
+		item.RawData = json.RawMessage(reject.RawPayload)
+
+		candidates = append(candidates, candidate{reject: reject, item: item})
+		items = append(items, item)
+	}
+
+	if len(items) == 0 {
+		return result, nil
+	}
+
+	batchResult := &PopulationResult{Errors: make([]string, 0)}
+	failed, inserted, err := uc.executeBatch(ctx, items, batchResult)
+	if err != nil {
+		for _, c := range candidates {
+			uc.failReprocess(ctx, c.reject, err, result)
+		}
+		return result, nil
+	}
+	uc.publishRatingsCreated(ctx, inserted)
+
+	failedByKey := make(map[string]itemReject, len(failed))
+	for _, f := range failed {
+		failedByKey[f.item.key()] = f
+	}
+
+	for _, c := range candidates {
+		if f, stillFailing := failedByKey[c.item.key()]; stillFailing {
+			uc.failReprocess(ctx, c.reject, fmt.Errorf("%s: %w", f.reason, f.err), result)
+			continue
+		}
+
+		if err := uc.rejectRepo.MarkReprocessed(ctx, c.reject.ID); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("Failed to mark reject %s as reprocessed: %v", c.reject.ID, err))
+			continue
+		}
+		result.Reprocessed++
+	}
+
+	uc.logger.Info(ctx, "♻️ Reprocessed population rejects",
+		logger.String("operation", "reprocess_rejects"),
+		logger.Int("requested", result.Requested),
+		logger.Int("reprocessed", result.Reprocessed),
+		logger.Int("failed", result.Failed),
+		logger.Int("skipped", result.Skipped))
+
+	return result, nil
+}
+
+// failReprocess registra un intento de reproceso fallido
+func (uc *PopulateDatabaseUseCase) failReprocess(ctx context.Context, reject *entities.PopulationReject, cause error, result *ReprocessResult) {
+	result.Failed++
+	result.Errors = append(result.Errors, fmt.Sprintf("Reject %s: %v", reject.ID, cause))
+
+	if err := uc.rejectRepo.RecordFailedAttempt(ctx, reject.ID, cause.Error()); err != nil {
+		uc.logger.Warn(ctx, "⚠️ Failed to record reprocess attempt",
+			logger.String("operation", "reprocess_rejects"),
+			logger.String("reject_id", reject.ID.String()),
+			logger.ErrorField(err))
+	}
+}
+
+// recordSourceRejects guarda los items descartados por la fuente de datos
+func (uc *PopulateDatabaseUseCase) recordSourceRejects(ctx context.Context, source string, rejected []RejectedItem, result *PopulationResult) {
+	rejects := make([]*entities.PopulationReject, 0, len(rejected))
+	for _, r := range rejected {
+		reject := entities.NewPopulationReject(source, entities.RejectStageValidation, r.Reason, r.RawPayload)
+		reject.Ticker = r.Ticker
+		reject.Brokerage = r.Brokerage
+		reject.Error = r.Error
+		rejects = append(rejects, reject)
+	}
+
+	uc.recordRejects(ctx, rejects, result)
+}
+
+// recordBatchRejects guarda los items que no se pudieron resolver dentro de un lote
+func (uc *PopulateDatabaseUseCase) recordBatchRejects(ctx context.Context, source string, batchRejects []itemReject, result *PopulationResult) {
+	rejects := make([]*entities.PopulationReject, 0, len(batchRejects))
+	for _, r := range batchRejects {
+		payload, err := json.Marshal(r.item)
+		if err != nil {
+			continue
+		}
+
+		reject := entities.NewPopulationReject(source, r.stage, r.reason, string(payload))
+		reject.Ticker = r.item.Ticker
+		reject.Brokerage = r.item.Brokerage
+		if r.err != nil {
+			reject.Error = r.err.Error()
+		}
+		rejects = append(rejects, reject)
+	}
+
+	uc.recordRejects(ctx, rejects, result)
+}
+
+// applyIngestionRules evalúa las reglas de ingesta sobre una página y guarda los items desviados:
+// reject los deja descartados y quarantine pendientes, de modo que se pueden reprocesar sin pasar por las reglas
+func (uc *PopulateDatabaseUseCase) applyIngestionRules(ctx context.Context, config PopulationConfig, items []StockDataItem, result *PopulationResult) []StockDataItem {
+	if uc.ruleEngine == nil || len(items) == 0 {
+		return items
+	}
+
+	accepted, violations := uc.ruleEngine.Apply(items)
+	if len(violations) == 0 {
+		return accepted
+	}
+
+	if result.RuleViolations == nil {
+		result.RuleViolations = make(map[string]int)
+	}
+	rejects := make([]*entities.PopulationReject, 0, len(violations))
+	for _, v := range violations {
+		result.RuleViolations[v.Rule]++
+
+		payload, err := json.Marshal(v.Item)
+		if err != nil {
+			continue
+		}
+		reject := entities.NewPopulationReject(config.Source, entities.RejectStageIngestionRule, v.Rule, string(payload))
+		reject.Ticker = v.Item.Ticker
+		reject.Brokerage = v.Item.Brokerage
+		reject.Error = v.Message
+		if v.Action == RuleActionReject {
+			resolvedAt := reject.LastSeenAt
+			reject.Status = entities.PopulationRejectStatusDiscarded
+			reject.ResolvedAt = &resolvedAt
+		}
+		rejects = append(rejects, reject)
+	}
+
+	uc.logger.Info(ctx, "🚧 Items diverted by ingestion rules",
+		logger.String("operation", "ingestion_rules"),
+		logger.Int("accepted", len(accepted)),
+		logger.Int("diverted", len(violations)))
+
+	if !config.DryRun {
+		uc.recordRejects(ctx, rejects, result)
+	}
+	return accepted
+}
+
+// recordRejects persiste los rechazos; un fallo al guardarlos no interrumpe la población
+func (uc *PopulateDatabaseUseCase) recordRejects(ctx context.Context, rejects []*entities.PopulationReject, result *PopulationResult) {
+	if uc.rejectRepo == nil || len(rejects) == 0 {
+		return
+	}
+
+	if err := uc.rejectRepo.Record(ctx, rejects); err != nil {
+		uc.logger.Warn(ctx, "⚠️ Failed to record population rejects",
+			logger.String("operation", "record_rejects"),
+			logger.Int("rejects", len(rejects)),
+			logger.ErrorField(err))
+		return
+	}
+
+	result.RejectedItems += len(rejects)
+}
@@ -0,0 +1,193 @@
+package population
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrPopulationAlreadyRunning se retorna cuando se intenta iniciar una población mientras otra está en curso
+var ErrPopulationAlreadyRunning = errors.New("a population job is already running")
+
+// maxRetainedJobs limita cuántos jobs finalizados se conservan en memoria
+const maxRetainedJobs = 50
+
+// PopulationJobStatus representa el estado de una ejecución de población
+type PopulationJobStatus string
+
+const (
+	PopulationJobStatusPending   PopulationJobStatus = "pending"
+	PopulationJobStatusRunning   PopulationJobStatus = "running"
+	PopulationJobStatusCompleted PopulationJobStatus = "completed"
+	PopulationJobStatusFailed    PopulationJobStatus = "failed"
+)
+
+// PopulationJob representa una ejecución asíncrona del caso de uso de población
+type PopulationJob struct {
+	ID         uuid.UUID
+	Status     PopulationJobStatus
+	Config     PopulationConfig
+	Result     *PopulationResult // Resultado parcial mientras corre, final al terminar
+	Error      string
+	CreatedAt  time.Time
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+}
+
+// IsFinished indica si el job ya terminó (con éxito o con error)
+func (j *PopulationJob) IsFinished() bool {
+	return j.Status == PopulationJobStatusCompleted || j.Status == PopulationJobStatusFailed
+}
+
+// PopulationRunner ejecuta el caso de uso de población en segundo plano y conserva el estado de cada ejecución
+type PopulationRunner struct {
+	useCase *PopulateDatabaseUseCase
+
+	mu        sync.RWMutex
+	jobs      map[uuid.UUID]*PopulationJob
+	order     []uuid.UUID
+	activeJob uuid.UUID
+	wg        sync.WaitGroup
+}
+
+// NewPopulationRunner crea un nuevo runner para el caso de uso de población
+func NewPopulationRunner(useCase *PopulateDatabaseUseCase) *PopulationRunner {
+	return &PopulationRunner{
+		useCase: useCase,
+		jobs:    make(map[uuid.UUID]*PopulationJob),
+		order:   make([]uuid.UUID, 0),
+	}
+}
+
+// Start registra un nuevo job y lanza la población en una goroutine.
+// Solo se permite una población activa a la vez.
+func (r *PopulationRunner) Start(config PopulationConfig) (*PopulationJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.activeJob != uuid.Nil {
+		return nil, ErrPopulationAlreadyRunning
+	}
+
+	job := &PopulationJob{
+		ID:        uuid.New(),
+		Status:    PopulationJobStatusPending,
+		Config:    config,
+		CreatedAt: time.Now(),
+	}
+
+	r.jobs[job.ID] = job
+	r.order = append(r.order, job.ID)
+	r.activeJob = job.ID
+	r.pruneLocked()
+
+	r.wg.Add(1)
+	go r.run(job.ID, config)
+
+	return job.snapshot(), nil
+}
+
+// GetJob retorna una copia del estado actual de un job
+func (r *PopulationRunner) GetJob(id uuid.UUID) (*PopulationJob, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	job, exists := r.jobs[id]
+	if !exists {
+		return nil, false
+	}
+	return job.snapshot(), true
+}
+
+// RuleStats returns the metrics of the ingestion rules applied by the runs
+func (r *PopulationRunner) RuleStats() []RuleStats {
+	return r.useCase.RuleStats()
+}
+
+// Wait bloquea hasta que termine la población activa o se cancele el contexto
+func (r *PopulationRunner) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run ejecuta el caso de uso y actualiza el estado del job
+func (r *PopulationRunner) run(jobID uuid.UUID, config PopulationConfig) {
+	defer r.wg.Done()
+
+	r.update(jobID, func(job *PopulationJob) {
+		now := time.Now()
+		job.Status = PopulationJobStatusRunning
+		job.StartedAt = &now
+	})
+
+	config.OnProgress = func(partial PopulationResult) {
+		r.update(jobID, func(job *PopulationJob) {
+			job.Result = &partial
+		})
+	}
+
+	// La población no debe depender del contexto de la request que la inició
+	result, err := r.useCase.Execute(context.Background(), config)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job := r.jobs[jobID]
+	now := time.Now()
+	job.FinishedAt = &now
+	if err != nil {
+		job.Status = PopulationJobStatusFailed
+		job.Error = err.Error()
+	} else {
+		job.Status = PopulationJobStatusCompleted
+		job.Result = result
+	}
+	r.activeJob = uuid.Nil
+}
+
+// update aplica una modificación al job bajo lock
+func (r *PopulationRunner) update(jobID uuid.UUID, fn func(job *PopulationJob)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if job, exists := r.jobs[jobID]; exists {
+		fn(job)
+	}
+}
+
+// pruneLocked elimina los jobs finalizados más antiguos cuando se supera el límite
+func (r *PopulationRunner) pruneLocked() {
+	for len(r.order) > maxRetainedJobs {
+		oldest := r.order[0]
+		if job := r.jobs[oldest]; job != nil && !job.IsFinished() {
+			return
+		}
+		delete(r.jobs, oldest)
+		r.order = r.order[1:]
+	}
+}
+
+// snapshot crea una copia del job segura para compartir fuera del lock
+func (j *PopulationJob) snapshot() *PopulationJob {
+	clone := *j
+	clone.Config.OnProgress = nil
+	if j.Result != nil {
+		result := *j.Result
+		result.Errors = append([]string(nil), j.Result.Errors...)
+		clone.Result = &result
+	}
+	return &clone
+}
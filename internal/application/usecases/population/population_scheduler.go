@@ -0,0 +1,98 @@
+package population
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// PopulationScheduler lanza poblaciones periódicas a través del PopulationRunner
+type PopulationScheduler struct {
+	runner     *PopulationRunner
+	interval   time.Duration
+	runOnStart bool
+	config     PopulationConfig
+	logger     logger.Logger
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewPopulationScheduler crea un nuevo scheduler de población
+func NewPopulationScheduler(runner *PopulationRunner, interval time.Duration, runOnStart, incremental bool, appLogger logger.Logger) *PopulationScheduler {
+	config := DefaultPopulationConfig()
+	config.Incremental = incremental
+
+	return &PopulationScheduler{
+		runner:     runner,
+		interval:   interval,
+		runOnStart: runOnStart,
+		config:     config,
+		logger:     appLogger,
+	}
+}
+
+// Start inicia el ciclo del scheduler en segundo plano
+func (s *PopulationScheduler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.loop(ctx)
+	}()
+}
+
+// Stop detiene el scheduler y espera a que el ciclo termine.
+// Las poblaciones ya lanzadas continúan en el runner.
+func (s *PopulationScheduler) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
+
+// loop dispara una población en cada tick hasta que se cancele el contexto
+func (s *PopulationScheduler) loop(ctx context.Context) {
+	if s.runOnStart {
+		s.trigger(ctx)
+	}
+
+	if s.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.trigger(ctx)
+		}
+	}
+}
+
+// trigger lanza una población; si ya hay una en curso se omite este tick
+func (s *PopulationScheduler) trigger(ctx context.Context) {
+	job, err := s.runner.Start(s.config)
+	if err != nil {
+		if errors.Is(err, ErrPopulationAlreadyRunning) {
+			s.logger.Info(ctx, "Skipping scheduled population, another run is in progress")
+			return
+		}
+		s.logger.Error(ctx, "Failed to start scheduled population", err)
+		return
+	}
+
+	s.logger.Info(ctx, "Scheduled population started",
+		logger.String("job_id", job.ID.String()),
+		logger.String("interval", s.interval.String()),
+		logger.Bool("incremental", s.config.Incremental),
+	)
+}
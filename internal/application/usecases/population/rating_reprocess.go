@@ -0,0 +1,185 @@
+package population
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+const (
+	// ratingReprocessBatchSize es cuántos ratings se leen por página al re-procesar
+	ratingReprocessBatchSize = 500
+
+	// maxRatingReprocessErrors limita los errores devueltos en el resultado
+	maxRatingReprocessErrors = 20
+)
+
+// ErrRawParserNotAvailable se devuelve cuando la fuente de datos no sabe volver a convertir su RawData
+var ErrRawParserNotAvailable = errors.New("data provider cannot re-parse raw rating data")
+
+// RawItemParser lo implementan las fuentes de datos que pueden volver a convertir el item original
+// guardado en el RawData de un rating
+type RawItemParser interface {
+	ParseRawItem(raw []byte) (StockDataItem, error)
+}
+
+// RatingReprocessOptions selecciona los ratings a re-procesar: event time en [From, To)
+type RatingReprocessOptions struct {
+	From       time.Time
+	To         time.Time
+	DryRun     bool                        // Cuenta los cambios sin escribirlos
+	OnProgress func(RatingReprocessResult) // Se llama tras cada página
+}
+
+// RatingReprocessResult resume un re-proceso de ratings
+type RatingReprocessResult struct {
+	From      time.Time     `json:"from"`
+	To        time.Time     `json:"to"`
+	DryRun    bool          `json:"dry_run"`
+	Scanned   int           `json:"scanned"`
+	Updated   int           `json:"updated"`
+	Unchanged int           `json:"unchanged"`
+	Failed    int           `json:"failed"`
+	Errors    []string      `json:"errors,omitempty"`
+	Duration  time.Duration `json:"duration"`
+}
+
+// ReprocessRangeFromDates converts an inclusive YYYY-MM-DD date range to the [from, to) UTC range of event times
+func ReprocessRangeFromDates(from, to string) (time.Time, time.Time, error) {
+	start, err := time.Parse("2006-01-02", from)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid from date %q: expected YYYY-MM-DD", from)
+	}
+	end, err := time.Parse("2006-01-02", to)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid to date %q: expected YYYY-MM-DD", to)
+	}
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("to date %s is before from date %s", to, from)
+	}
+	return start, end.AddDate(0, 0, 1), nil
+}
+
+// ReprocessRatings vuelve a pasar por el adapter el RawData de los ratings del rango, por ejemplo tras corregir
+// un bug de conversión. Cada rating se reescribe con un upsert sobre (company, brokerage, event time), así que
+// repetir el re-proceso deja los mismos datos; los ratings cuyo resultado no cambia no se tocan
+func (uc *PopulateDatabaseUseCase) ReprocessRatings(ctx context.Context, options RatingReprocessOptions) (*RatingReprocessResult, error) {
+	parser, ok := uc.dataProvider.(RawItemParser)
+	if !ok {
+		return nil, ErrRawParserNotAvailable
+	}
+
+	startTime := time.Now()
+	result := &RatingReprocessResult{
+		From:   options.From,
+		To:     options.To,
+		DryRun: options.DryRun,
+	}
+
+	uc.logger.Info(ctx, "♻️ Starting ratings reprocess",
+		logger.String("operation", "reprocess_ratings"),
+		logger.String("from", options.From.Format(time.RFC3339)),
+		logger.String("to", options.To.Format(time.RFC3339)),
+		logger.Bool("dry_run", options.DryRun))
+
+	var afterEventTime time.Time
+	afterID := uuid.Nil
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		ratings, err := uc.stockRatingRepo.ListWithRawData(ctx, options.From, options.To, afterEventTime, afterID, ratingReprocessBatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list ratings to reprocess: %w", err)
+		}
+
+		for _, rating := range ratings {
+			result.Scanned++
+			if err := uc.reprocessRating(ctx, parser, rating, options.DryRun, result); err != nil {
+				result.Failed++
+				if len(result.Errors) < maxRatingReprocessErrors {
+					result.Errors = append(result.Errors, fmt.Sprintf("rating %s: %v", rating.ID, err))
+				}
+			}
+		}
+
+		if len(ratings) > 0 {
+			last := ratings[len(ratings)-1]
+			afterEventTime, afterID = last.EventTime, last.ID
+		}
+
+		result.Duration = time.Since(startTime)
+		if options.OnProgress != nil {
+			options.OnProgress(*result)
+		}
+		if len(ratings) < ratingReprocessBatchSize {
+			break
+		}
+	}
+
+	uc.logger.Info(ctx, "✅ Ratings reprocess completed",
+		logger.String("operation", "reprocess_ratings"),
+		logger.Int("scanned", result.Scanned),
+		logger.Int("updated", result.Updated),
+		logger.Int("unchanged", result.Unchanged),
+		logger.Int("failed", result.Failed),
+		logger.Bool("dry_run", options.DryRun))
+
+	return result, nil
+}
+
+// reprocessRating convierte de nuevo el RawData de un rating y lo reemplaza si el resultado cambió
+func (uc *PopulateDatabaseUseCase) reprocessRating(ctx context.Context, parser RawItemParser, original *entities.StockRating, dryRun bool, result *RatingReprocessResult) error {
+	item, err := parser.ParseRawItem(original.RawData)
+	if err != nil {
+		return err
+	}
+	if err := item.Validate(); err != nil {
+		return err
+	}
+
+	company, err := uc.companyRepo.GetByTicker(ctx, item.Ticker)
+	if err != nil {
+		return fmt.Errorf("%s: %w", RejectReasonCompanyNotFound, err)
+	}
+	brokerage, err := uc.brokerageRepo.GetByName(ctx, item.Brokerage)
+	if err != nil {
+		return fmt.Errorf("%s: %w", RejectReasonBrokerageNotFound, err)
+	}
+
+	replacement := entities.NewStockRating(company.ID, brokerage.ID, item.Action, item.EventTime)
+	replacement.RatingFrom = item.RatingFrom
+	replacement.RatingTo = item.RatingTo
+	replacement.TargetFrom = item.TargetFrom
+	replacement.TargetTo = item.TargetTo
+	replacement.Source = original.Source
+	replacement.RawData = original.RawData
+	replacement.IsProcessed = original.IsProcessed
+	replacement.Normalize()
+
+	// Mismo evento: se conserva la fila original
+	if replacement.SameEvent(original) {
+		replacement.ID = original.ID
+		replacement.EventTime = original.EventTime
+	}
+
+	if replacement.HasSameData(original) {
+		result.Unchanged++
+		return nil
+	}
+
+	if !dryRun {
+		if err := uc.stockRatingRepo.ReplaceRating(ctx, original.ID, replacement); err != nil {
+			return err
+		}
+	}
+	result.Updated++
+	return nil
+}
@@ -0,0 +1,123 @@
+package warmup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// ratingWindowDays es la ventana usada para elegir las companies con más ratings
+const ratingWindowDays = 90
+
+// MaxWarmCompanies limita cuántas companies se pueden precargar en una ejecución
+const MaxWarmCompanies = 500
+
+// ErrNothingToWarm se retorna cuando no hay cache de consultas configurada
+var ErrNothingToWarm = errors.New("query cache is not configured")
+
+// CacheWarmResult resume una ejecución del warm-up de la cache
+type CacheWarmResult struct {
+	Requested         int
+	CompaniesWarmed   int
+	MarketDataWarmed  int
+	MarketDataMissing int // Companies sin market data guardada todavía
+	Failed            int
+	Errors            []string
+	Duration          time.Duration
+}
+
+// CacheWarmer precarga en la cache las companies con más ratings, su perfil y su última market data,
+// leyendo a través de los repositorios cacheados para que las primeras peticiones tras un deploy no fallen en frío
+type CacheWarmer struct {
+	companyRepo     repoInterfaces.CompanyRepository
+	stockRatingRepo repoInterfaces.StockRatingRepository
+	marketDataRepo  repoInterfaces.MarketDataRepository
+	defaultLimit    int
+	enabled         bool
+	logger          logger.Logger
+}
+
+// NewCacheWarmer crea el warmer; enabled indica si los repositorios están envueltos con cache
+func NewCacheWarmer(companyRepo repoInterfaces.CompanyRepository, stockRatingRepo repoInterfaces.StockRatingRepository,
+	marketDataRepo repoInterfaces.MarketDataRepository, defaultLimit int, enabled bool, appLogger logger.Logger) *CacheWarmer {
+	return &CacheWarmer{
+		companyRepo:     companyRepo,
+		stockRatingRepo: stockRatingRepo,
+		marketDataRepo:  marketDataRepo,
+		defaultLimit:    defaultLimit,
+		enabled:         enabled,
+		logger:          appLogger,
+	}
+}
+
+// Enabled reports whether warming has any effect
+func (w *CacheWarmer) Enabled() bool {
+	return w != nil && w.enabled
+}
+
+// Warm precarga las limit companies con más ratings (limit <= 0 usa el valor configurado).
+// Los fallos por company no detienen el warm-up; se cuentan en el resultado.
+func (w *CacheWarmer) Warm(ctx context.Context, limit int) (*CacheWarmResult, error) {
+	if !w.Enabled() {
+		return nil, ErrNothingToWarm
+	}
+
+	if limit <= 0 {
+		limit = w.defaultLimit
+	}
+	if limit > MaxWarmCompanies {
+		limit = MaxWarmCompanies
+	}
+
+	start := time.Now()
+
+	// La consulta de ranking también queda cacheada por el repositorio
+	topCompanies, err := w.stockRatingRepo.GetTopCompaniesByRatingCount(ctx, ratingWindowDays, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get most rated companies: %w", err)
+	}
+
+	result := &CacheWarmResult{Requested: len(topCompanies)}
+	for _, top := range topCompanies {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		// Company y perfil salen de la misma fila (GetCompanyProfile lee la company por ticker)
+		if _, err := w.companyRepo.GetByTicker(ctx, top.Ticker); err != nil {
+			result.Failed++
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", top.Ticker, err))
+			continue
+		}
+		result.CompaniesWarmed++
+
+		if _, err := w.marketDataRepo.GetBySymbol(ctx, top.Ticker); err != nil {
+			if domainerrors.IsNotFound(err) {
+				result.MarketDataMissing++
+				continue
+			}
+			result.Failed++
+			result.Errors = append(result.Errors, fmt.Sprintf("%s market data: %v", top.Ticker, err))
+			continue
+		}
+		result.MarketDataWarmed++
+	}
+
+	result.Duration = time.Since(start)
+
+	w.logger.Info(ctx, "Cache warm-up completed",
+		logger.Int("requested", result.Requested),
+		logger.Int("companies_warmed", result.CompaniesWarmed),
+		logger.Int("market_data_warmed", result.MarketDataWarmed),
+		logger.Int("market_data_missing", result.MarketDataMissing),
+		logger.Int("failed", result.Failed),
+		logger.Duration("duration", result.Duration),
+	)
+
+	return result, nil
+}
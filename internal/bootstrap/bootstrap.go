@@ -0,0 +1,26 @@
+// Package bootstrap arranca los procesos del sistema (servidor HTTP y worker) compartiendo la carga de
+// configuración, el logger y las dependencias, para que cada binario solo decida qué ejecutar
+package bootstrap
+
+import (
+	"fmt"
+
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/config"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// Load inicializa el logger global y carga la configuración
+func Load() (*config.Config, logger.Logger, error) {
+	appLogger, err := logger.InitializeGlobalLogger()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to initialize logger: %w", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		_ = appLogger.Close()
+		return nil, nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	return cfg, appLogger, nil
+}
@@ -0,0 +1,385 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/application/jobs"
+	"github.com/MayaCris/stock-info-app/internal/application/usecases/population"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/config"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/factory"
+)
+
+// RunWorker crea el worker, verifica que esté listo y, salvo en dryRun, ejecuta los procesos en segundo plano
+// hasta recibir una señal de shutdown
+func RunWorker(ctx context.Context, cfg *config.Config, appLogger logger.Logger, dryRun bool) error {
+	worker, err := NewWorker(cfg, appLogger)
+	if err != nil {
+		return fmt.Errorf("failed to create worker: %w", err)
+	}
+
+	if err := worker.HealthCheck(); err != nil {
+		return fmt.Errorf("worker health check failed: %w", err)
+	}
+
+	if dryRun {
+		appLogger.Info(ctx, "✅ Dry run completed successfully - worker is ready to start")
+		return nil
+	}
+
+	appLogger.Info(ctx, "🚀 Starting background worker...",
+		logger.String("population_interval", cfg.Worker.PopulationInterval.String()),
+		logger.Bool("populate_on_start", cfg.Worker.PopulateOnStart),
+	)
+
+	if err := worker.Start(); err != nil {
+		return fmt.Errorf("worker failed or encountered an error during shutdown: %w", err)
+	}
+
+	appLogger.Info(ctx, "✅ Worker shutdown completed successfully",
+		logger.String("component", "main"),
+	)
+	return nil
+}
+
+// Worker encapsula los procesos en segundo plano sin servidor HTTP
+type Worker struct {
+	config     *config.Config
+	logger     logger.Logger
+	scheduler  *population.PopulationScheduler
+	enrichment *jobs.JobScheduler
+	analytics  *jobs.JobScheduler
+	anomalies  *jobs.JobScheduler
+	refresh    *jobs.JobScheduler
+	integrity  *jobs.JobScheduler
+	usage      *jobs.JobScheduler
+	processing *jobs.JobScheduler
+	benchmarks *jobs.JobScheduler
+
+	// Dependencies for cleanup
+	dependencies *factory.Dependencies
+}
+
+// NewWorker crea una nueva instancia del proceso worker
+func NewWorker(cfg *config.Config, appLogger logger.Logger) (*Worker, error) {
+	apiFactory := factory.NewAPIFactory(cfg)
+
+	deps, err := apiFactory.CreateDependencies()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dependencies: %w", err)
+	}
+
+	return &Worker{
+		config:       cfg,
+		logger:       appLogger,
+		scheduler:    NewPopulationScheduler(cfg, deps, appLogger),
+		enrichment:   NewEnrichmentScheduler(cfg, deps, appLogger),
+		analytics:    NewAnalyticsRefreshScheduler(cfg, deps, appLogger),
+		anomalies:    NewAnomalyDetectionScheduler(cfg, deps, appLogger),
+		refresh:      NewMarketDataRefreshScheduler(cfg, deps, appLogger),
+		integrity:    NewIntegrityCheckScheduler(cfg, deps, appLogger),
+		usage:        NewUsageFlushScheduler(cfg, deps, appLogger),
+		processing:   NewRatingProcessingScheduler(cfg, deps, appLogger),
+		benchmarks:   NewBenchmarkRefreshScheduler(cfg, deps, appLogger),
+		dependencies: deps,
+	}, nil
+}
+
+// HealthCheck realiza un health check básico del worker
+func (w *Worker) HealthCheck() error {
+	if w.config == nil {
+		return fmt.Errorf("configuration is not loaded")
+	}
+
+	if w.logger == nil {
+		return fmt.Errorf("logger is not initialized")
+	}
+
+	if w.dependencies == nil || w.dependencies.PopulationRunner == nil {
+		return fmt.Errorf("population runner is not initialized")
+	}
+
+	return nil
+}
+
+// Start inicia los procesos en segundo plano y bloquea hasta recibir una señal de shutdown
+func (w *Worker) Start() error {
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
+
+	if w.scheduler != nil {
+		w.scheduler.Start(context.Background())
+		w.logger.Info(context.Background(), "Population scheduler started",
+			logger.String("interval", w.config.Worker.PopulationInterval.String()),
+			logger.Bool("populate_on_start", w.config.Worker.PopulateOnStart),
+		)
+	}
+
+	if w.jobWorkersEnabled() {
+		w.dependencies.JobWorkerPool.Start(context.Background())
+	}
+
+	if w.enrichment != nil {
+		w.enrichment.Start(context.Background())
+		w.logger.Info(context.Background(), "Company enrichment scheduler started",
+			logger.String("interval", w.config.Worker.EnrichmentInterval.String()),
+		)
+	}
+
+	if w.analytics != nil {
+		w.analytics.Start(context.Background())
+		w.logger.Info(context.Background(), "Analytics views refresh scheduler started",
+			logger.String("interval", w.config.Worker.AnalyticsRefresh.String()),
+		)
+	}
+
+	if w.anomalies != nil {
+		w.anomalies.Start(context.Background())
+		w.logger.Info(context.Background(), "Anomaly detection scheduler started",
+			logger.String("interval", w.config.Worker.AnomalyDetection.String()),
+		)
+	}
+
+	if w.refresh != nil {
+		w.refresh.Start(context.Background())
+		w.logger.Info(context.Background(), "Market data refresh scheduler started",
+			logger.String("interval", w.config.Worker.MarketDataRefresh.String()),
+			logger.Int("trending", w.config.Worker.RefreshTrending),
+		)
+	}
+
+	if w.integrity != nil {
+		w.integrity.Start(context.Background())
+		w.logger.Info(context.Background(), "Integrity check scheduler started",
+			logger.String("interval", w.config.Worker.IntegrityCheck.String()),
+		)
+	}
+
+	if w.usage != nil {
+		w.usage.Start(context.Background())
+		w.logger.Info(context.Background(), "API usage flush scheduler started",
+			logger.String("interval", w.config.Worker.UsageFlush.String()),
+		)
+	}
+
+	if w.processing != nil {
+		w.processing.Start(context.Background())
+		w.logger.Info(context.Background(), "Rating processing scheduler started",
+			logger.String("interval", w.config.Worker.RatingProcessing.String()),
+			logger.Int("batch_size", w.config.Worker.RatingProcessingBatchSize),
+			logger.Int("concurrency", w.config.Worker.RatingProcessingConcurrency),
+			logger.Int("backlog_threshold", w.config.Worker.RatingBacklogThreshold),
+		)
+	}
+
+	if w.benchmarks != nil {
+		w.benchmarks.Start(context.Background())
+		w.logger.Info(context.Background(), "Custom benchmark refresh scheduler started",
+			logger.String("interval", w.config.Worker.BenchmarkRefresh.String()),
+		)
+	}
+
+	if w.dependencies.OutboxRelay != nil {
+		w.dependencies.OutboxRelay.Start(context.Background())
+		w.logger.Info(context.Background(), "Outbox relay started",
+			logger.String("poll_interval", w.config.Events.OutboxPollInterval.String()),
+		)
+	}
+
+	if w.scheduler == nil && !w.jobWorkersEnabled() {
+		w.logger.Warn(context.Background(), "⚠️ Population scheduler and job workers disabled - worker has nothing to do")
+	}
+
+	sig := <-quit
+	w.logger.Info(context.Background(), "Received shutdown signal",
+		logger.String("signal", sig.String()),
+	)
+
+	return w.Shutdown()
+}
+
+// Shutdown detiene los procesos en segundo plano esperando a que termine el trabajo en curso
+func (w *Worker) Shutdown() error {
+	shutdownStart := time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), w.config.Worker.ShutdownTimeout)
+	defer cancel()
+
+	// Phase 1: Stop scheduling new work
+	if w.scheduler != nil {
+		w.logger.Info(ctx, "Phase 1: Stopping population scheduler")
+		w.scheduler.Stop()
+	}
+	if w.enrichment != nil {
+		w.enrichment.Stop()
+	}
+	if w.analytics != nil {
+		w.analytics.Stop()
+	}
+	if w.anomalies != nil {
+		w.anomalies.Stop()
+	}
+	if w.refresh != nil {
+		w.refresh.Stop()
+	}
+	if w.integrity != nil {
+		w.integrity.Stop()
+	}
+	if w.usage != nil {
+		w.usage.Stop()
+	}
+	if w.processing != nil {
+		w.processing.Stop()
+	}
+	if w.benchmarks != nil {
+		w.benchmarks.Stop()
+	}
+
+	// Phase 2: Stop job workers, requeueing interrupted jobs
+	if w.jobWorkersEnabled() {
+		w.logger.Info(ctx, "Phase 2: Stopping job workers")
+		if err := w.dependencies.JobWorkerPool.Stop(ctx); err != nil {
+			w.logger.Error(ctx, "Timed out waiting for job workers", err,
+				logger.String("timeout", w.config.Worker.ShutdownTimeout.String()),
+			)
+			return fmt.Errorf("failed to shutdown worker gracefully: %w", err)
+		}
+	}
+
+	// Phase 3: Wait for in-flight work
+	w.logger.Info(ctx, "Phase 3: Waiting for active population runs to finish")
+	if err := w.dependencies.PopulationRunner.Wait(ctx); err != nil {
+		w.logger.Error(ctx, "Timed out waiting for active population run", err,
+			logger.String("timeout", w.config.Worker.ShutdownTimeout.String()),
+		)
+		return fmt.Errorf("failed to shutdown worker gracefully: %w", err)
+	}
+
+	// Phase 4: Deliver pending domain events (lo que quede en el outbox lo publica el próximo arranque)
+	if w.dependencies.OutboxRelay != nil {
+		w.dependencies.OutboxRelay.Stop()
+	}
+	if w.dependencies.EventBus != nil {
+		if err := w.dependencies.EventBus.Close(); err != nil {
+			w.logger.Error(ctx, "Failed to close event bus", err)
+		}
+	}
+
+	w.logger.Info(ctx, "✅ Worker shutdown completed",
+		logger.String("duration", time.Since(shutdownStart).String()),
+	)
+
+	return nil
+}
+
+// jobWorkersEnabled indica si el pool de workers de la cola de jobs debe ejecutarse
+func (w *Worker) jobWorkersEnabled() bool {
+	return w.config.Worker.IsJobWorkersEnabled() && w.dependencies.JobWorkerPool != nil
+}
+
+// NewPopulationScheduler crea el scheduler de población si está habilitado en la configuración
+func NewPopulationScheduler(cfg *config.Config, deps *factory.Dependencies, appLogger logger.Logger) *population.PopulationScheduler {
+	if deps.PopulationRunner == nil {
+		return nil
+	}
+
+	if !cfg.Worker.IsSchedulerEnabled() && !cfg.Worker.PopulateOnStart {
+		return nil
+	}
+
+	return population.NewPopulationScheduler(
+		deps.PopulationRunner,
+		cfg.Worker.PopulationInterval,
+		cfg.Worker.PopulateOnStart,
+		cfg.Worker.PopulationIncremental,
+		appLogger,
+	)
+}
+
+// NewEnrichmentScheduler crea el scheduler que encola el enriquecimiento de companies, o nil si está deshabilitado
+func NewEnrichmentScheduler(cfg *config.Config, deps *factory.Dependencies, appLogger logger.Logger) *jobs.JobScheduler {
+	if !cfg.Worker.IsEnrichmentEnabled() || deps.JobQueue == nil {
+		return nil
+	}
+
+	return jobs.NewJobScheduler(deps.JobQueue, jobs.JobTypeCompanyEnrichment, jobs.CompanyEnrichmentPayload{},
+		cfg.Worker.EnrichmentInterval, appLogger)
+}
+
+// NewAnalyticsRefreshScheduler crea el scheduler que encola el refresco de las vistas de analíticas, o nil si está deshabilitado
+func NewAnalyticsRefreshScheduler(cfg *config.Config, deps *factory.Dependencies, appLogger logger.Logger) *jobs.JobScheduler {
+	if !cfg.Worker.IsAnalyticsRefreshEnabled() || deps.AnalyticsViews == nil || deps.JobQueue == nil {
+		return nil
+	}
+
+	return jobs.NewJobScheduler(deps.JobQueue, jobs.JobTypeAnalyticsRefresh, nil, cfg.Worker.AnalyticsRefresh, appLogger)
+}
+
+// NewAnomalyDetectionScheduler crea el scheduler que encola la detección de anomalías, o nil si está deshabilitado
+func NewAnomalyDetectionScheduler(cfg *config.Config, deps *factory.Dependencies, appLogger logger.Logger) *jobs.JobScheduler {
+	if !cfg.Worker.IsAnomalyDetectionEnabled() || deps.AnomalyService == nil || deps.JobQueue == nil {
+		return nil
+	}
+
+	return jobs.NewJobScheduler(deps.JobQueue, jobs.JobTypeAnomalyDetection, jobs.AnomalyDetectionPayload{},
+		cfg.Worker.AnomalyDetection, appLogger)
+}
+
+// NewMarketDataRefreshScheduler crea el scheduler que encola el refresco de cotizaciones de los símbolos
+// más vistos (o de todas las companies activas), o nil si está deshabilitado
+func NewMarketDataRefreshScheduler(cfg *config.Config, deps *factory.Dependencies, appLogger logger.Logger) *jobs.JobScheduler {
+	if !cfg.Worker.IsMarketDataRefreshEnabled() || deps.MarketDataService == nil || deps.JobQueue == nil {
+		return nil
+	}
+
+	return jobs.NewJobScheduler(deps.JobQueue, jobs.JobTypeMarketDataRefresh,
+		jobs.MarketDataRefreshPayload{Trending: cfg.Worker.RefreshTrending}, cfg.Worker.MarketDataRefresh, appLogger)
+}
+
+// NewIntegrityCheckScheduler crea el scheduler que encola la validación de integridad para el historial de calidad
+// de datos, o nil si está deshabilitado
+func NewIntegrityCheckScheduler(cfg *config.Config, deps *factory.Dependencies, appLogger logger.Logger) *jobs.JobScheduler {
+	if !cfg.Worker.IsIntegrityCheckEnabled() || deps.IntegrityHistory == nil || deps.JobQueue == nil {
+		return nil
+	}
+
+	return jobs.NewJobScheduler(deps.JobQueue, jobs.JobTypeIntegrityCheck, nil, cfg.Worker.IntegrityCheck, appLogger)
+}
+
+// NewUsageFlushScheduler crea el scheduler que encola el volcado de los contadores de uso de la API,
+// o nil si está deshabilitado o sin multi-tenancy
+func NewUsageFlushScheduler(cfg *config.Config, deps *factory.Dependencies, appLogger logger.Logger) *jobs.JobScheduler {
+	if !cfg.Worker.IsUsageFlushEnabled() || deps.UsageService == nil || deps.JobQueue == nil {
+		return nil
+	}
+
+	return jobs.NewJobScheduler(deps.JobQueue, jobs.JobTypeUsageFlush, nil, cfg.Worker.UsageFlush, appLogger)
+}
+
+// NewRatingProcessingScheduler crea el scheduler que encola el procesado de los ratings pendientes, o nil si está
+// deshabilitado
+func NewRatingProcessingScheduler(cfg *config.Config, deps *factory.Dependencies, appLogger logger.Logger) *jobs.JobScheduler {
+	if !cfg.Worker.IsRatingProcessingEnabled() || deps.RatingProcessing == nil || deps.JobQueue == nil {
+		return nil
+	}
+
+	return jobs.NewJobScheduler(deps.JobQueue, jobs.JobTypeRatingProcessing, jobs.RatingProcessingPayload{
+		BatchSize:   cfg.Worker.RatingProcessingBatchSize,
+		Concurrency: cfg.Worker.RatingProcessingConcurrency,
+	}, cfg.Worker.RatingProcessing, appLogger)
+}
+
+// NewBenchmarkRefreshScheduler crea el scheduler que encola el recálculo de los índices personalizados, o nil si
+// está deshabilitado
+func NewBenchmarkRefreshScheduler(cfg *config.Config, deps *factory.Dependencies, appLogger logger.Logger) *jobs.JobScheduler {
+	if !cfg.Worker.IsBenchmarkRefreshEnabled() || deps.BenchmarkService == nil || deps.JobQueue == nil {
+		return nil
+	}
+
+	return jobs.NewJobScheduler(deps.JobQueue, jobs.JobTypeBenchmarkRefresh, nil, cfg.Worker.BenchmarkRefresh, appLogger)
+}
@@ -0,0 +1,92 @@
+package domainerrors
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errores base del dominio. Los repositorios envuelven los errores de la BD en uno de estos
+// tipos para que las capas superiores no dependan del mensaje ni del driver.
+var (
+	ErrNotFound  = errors.New("resource not found")
+	ErrDuplicate = errors.New("resource already exists")
+	ErrConflict  = errors.New("resource conflict")
+
+	// ErrVersionMismatch indica que el registro cambió desde que se leyó (bloqueo optimista)
+	ErrVersionMismatch = errors.New("resource version mismatch")
+)
+
+// Error is a domain error that keeps a descriptive message, its kind and the original cause
+type Error struct {
+	kind    error
+	message string
+	cause   error
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return fmt.Sprintf("%s: %v", e.message, e.cause)
+	}
+	return e.message
+}
+
+// Is reports whether the error belongs to the given kind (ErrNotFound, ErrDuplicate, ErrConflict or ErrVersionMismatch)
+func (e *Error) Is(target error) bool {
+	return target == e.kind
+}
+
+// Unwrap returns the original cause, if any
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Kind returns the sentinel that classifies the error
+func (e *Error) Kind() error {
+	return e.kind
+}
+
+// Message returns the descriptive message without the cause
+func (e *Error) Message() string {
+	return e.message
+}
+
+// NotFound creates a not found error
+func NotFound(format string, args ...interface{}) error {
+	return &Error{kind: ErrNotFound, message: fmt.Sprintf(format, args...)}
+}
+
+// Duplicate creates a duplicate error (unique constraint violation)
+func Duplicate(cause error, format string, args ...interface{}) error {
+	return &Error{kind: ErrDuplicate, message: fmt.Sprintf(format, args...), cause: cause}
+}
+
+// Conflict creates a conflict error (e.g. foreign key violation)
+func Conflict(cause error, format string, args ...interface{}) error {
+	return &Error{kind: ErrConflict, message: fmt.Sprintf(format, args...), cause: cause}
+}
+
+// VersionMismatch creates an optimistic locking error: the record was modified by someone else
+func VersionMismatch(format string, args ...interface{}) error {
+	return &Error{kind: ErrVersionMismatch, message: fmt.Sprintf(format, args...)}
+}
+
+// IsNotFound checks if the error is a not found error
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsDuplicate checks if the error is a duplicate error
+func IsDuplicate(err error) bool {
+	return errors.Is(err, ErrDuplicate)
+}
+
+// IsConflict checks if the error is a conflict error
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// IsVersionMismatch checks if the error is an optimistic locking error
+func IsVersionMismatch(err error) bool {
+	return errors.Is(err, ErrVersionMismatch)
+}
@@ -0,0 +1,59 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Tipos de actividad anómala
+const (
+	AnomalyTypeRatingActivity = "rating_activity" // Pico de ratings publicados en un día
+	AnomalyTypeVolume         = "volume"          // Pico de volumen negociado en una sesión
+)
+
+// Anomaly is a day of abnormal activity of a company: Value is well above the Baseline (trailing average).
+// There is one record per company, type and day; detecting the same day again updates it.
+type Anomaly struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;not null"`
+	CompanyID uuid.UUID `json:"company_id" gorm:"type:uuid;not null;index" validate:"required"`
+	Type      string    `json:"type" gorm:"type:string;not null"` // rating_activity, volume
+	Date      time.Time `json:"date" gorm:"type:date;not null"`
+	Value     float64   `json:"value" gorm:"type:decimal(20,4);not null"`    // Ratings del día o volumen de la sesión
+	Baseline  float64   `json:"baseline" gorm:"type:decimal(20,4);not null"` // Media de referencia previa
+	Ratio     float64   `json:"ratio" gorm:"type:decimal(12,4);not null"`    // Value / Baseline
+
+	// Auditoría - timestamps automáticos por la BD
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime;not null"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime;not null"` // Última detección
+
+	// Relationships
+	Company Company `json:"company,omitempty" gorm:"foreignKey:CompanyID"`
+}
+
+// TableName specifies the table name for GORM
+func (Anomaly) TableName() string {
+	return "anomalies"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a record
+func (a *Anomaly) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+// NewAnomaly creates a new Anomaly record
+func NewAnomaly(companyID uuid.UUID, anomalyType string, date time.Time, value, baseline, ratio float64) *Anomaly {
+	return &Anomaly{
+		ID:        uuid.New(),
+		CompanyID: companyID,
+		Type:      anomalyType,
+		Date:      date,
+		Value:     value,
+		Baseline:  baseline,
+		Ratio:     ratio,
+	}
+}
@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/tenancy"
+)
+
+// APIKey authenticates the requests of a tenant. Only the SHA-256 hash of the key is stored
+type APIKey struct {
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;not null"`
+	TenantOwned
+
+	Name    string       `json:"name" gorm:"type:string;not null"`
+	Prefix  string       `json:"prefix" gorm:"type:string;not null"` // Primeros caracteres de la clave, para reconocerla
+	KeyHash string       `json:"-" gorm:"type:string;not null;uniqueIndex:uq_api_keys_key_hash"`
+	Role    tenancy.Role `json:"role" gorm:"type:string;not null;default:viewer"`
+
+	// Timezone es la zona horaria IANA del usuario de la clave: define "hoy" y "esta semana" en sus consultas
+	Timezone string `json:"timezone,omitempty" gorm:"type:string;not null;default:''"`
+
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" gorm:"null"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty" gorm:"null"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" gorm:"null"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"autoCreateTime;not null"`
+}
+
+// TableName specifies the table name for GORM
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a record
+func (k *APIKey) BeforeCreate(tx *gorm.DB) error {
+	if k.ID == uuid.Nil {
+		k.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsUsable reports whether the key can authenticate requests at the given time
+func (k *APIKey) IsUsable(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
@@ -0,0 +1,23 @@
+package entities
+
+import (
+	"time"
+)
+
+// APIUsage holds the usage of a credential (API key or JWT subject) of a tenant in one UTC hour.
+// The aggregated queries reuse it with Hour set to the start of the day
+type APIUsage struct {
+	TenantOwned
+	Credential    string    `json:"credential" gorm:"type:string;primary_key;not null"` // api_key:<id> o jwt:<sub>
+	Hour          time.Time `json:"hour" gorm:"primary_key;not null"`
+	Requests      int64     `json:"requests" gorm:"not null;default:0"`
+	Errors        int64     `json:"errors" gorm:"not null;default:0"`
+	ProviderCalls int64     `json:"provider_calls" gorm:"not null;default:0"`
+	CacheHits     int64     `json:"cache_hits" gorm:"not null;default:0"`
+	UpdatedAt     time.Time `json:"updated_at" gorm:"autoUpdateTime;not null"`
+}
+
+// TableName specifies the table name for GORM
+func (APIUsage) TableName() string {
+	return "api_usage_hourly"
+}
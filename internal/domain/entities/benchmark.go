@@ -0,0 +1,162 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Tipos de benchmark y ponderaciones de los índices personalizados
+const (
+	BenchmarkKindProvider = "provider" // Valores y composición descargados del proveedor
+	BenchmarkKindCustom   = "custom"   // Definido por el usuario y calculado con los precios guardados
+
+	BenchmarkWeightingEqual = "equal" // Mismo peso, rebalanceado cada sesión
+	BenchmarkWeightingCap   = "cap"   // Peso por capitalización en la fecha base, que deriva con los precios
+)
+
+// Benchmark is a market index. Provider benchmarks (S&P 500, NASDAQ-100) are tracked through the instrument that
+// replicates them at the provider (SPY, QQQ), whose values and constituents are ingested; custom benchmarks are
+// defined from registered tickers and their values are computed from the stored prices since BaseDate
+type Benchmark struct {
+	ID           uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;not null"`
+	Symbol       string     `json:"symbol" gorm:"type:string;uniqueIndex;not null" validate:"required"` // SPX, NDX
+	Name         string     `json:"name" gorm:"type:string;not null" validate:"required"`
+	Kind         string     `json:"kind" gorm:"type:string;not null;default:'provider'"` // provider, custom
+	SourceSymbol string     `json:"source_symbol" gorm:"type:string;not null"`           // ETF que replica el índice en el proveedor (vacío en los personalizados)
+	Weighting    string     `json:"weighting,omitempty" gorm:"type:string;null"`         // equal, cap (solo personalizados)
+	BaseDate     *time.Time `json:"base_date,omitempty" gorm:"type:date;null"`           // Primera sesión calculada (solo personalizados)
+	Currency     string     `json:"currency" gorm:"type:string;not null;default:'USD'"`
+	LastSyncedAt *time.Time `json:"last_synced_at,omitempty" gorm:"type:timestamptz;null"`
+
+	// Creador de un índice personalizado (vacío en los de proveedor y en los creados sin tenant)
+	OwnerTenantID *uuid.UUID `json:"-" gorm:"type:uuid;null"`
+	Owner         string     `json:"-" gorm:"type:string;null"` // api_key:<id> o jwt:<sub>
+
+	// Auditoría - timestamps automáticos por la BD
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime;not null"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime;not null"`
+}
+
+// TableName specifies the table name for GORM
+func (Benchmark) TableName() string {
+	return "benchmarks"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a record
+func (b *Benchmark) BeforeCreate(tx *gorm.DB) error {
+	if b.ID == uuid.Nil {
+		b.ID = uuid.New()
+	}
+	b.Symbol = strings.ToUpper(b.Symbol)
+	b.SourceSymbol = strings.ToUpper(b.SourceSymbol)
+	return nil
+}
+
+// NewBenchmark creates a new Benchmark tracked through sourceSymbol
+func NewBenchmark(symbol, name, sourceSymbol string) *Benchmark {
+	return &Benchmark{
+		ID:           uuid.New(),
+		Symbol:       strings.ToUpper(symbol),
+		Name:         name,
+		Kind:         BenchmarkKindProvider,
+		SourceSymbol: strings.ToUpper(sourceSymbol),
+		Currency:     "USD",
+	}
+}
+
+// NewCustomBenchmark creates a custom Benchmark computed from stored prices since baseDate
+func NewCustomBenchmark(symbol, name, weighting string, baseDate time.Time) *Benchmark {
+	return &Benchmark{
+		ID:        uuid.New(),
+		Symbol:    strings.ToUpper(symbol),
+		Name:      name,
+		Kind:      BenchmarkKindCustom,
+		Weighting: weighting,
+		BaseDate:  &baseDate,
+		Currency:  "USD",
+	}
+}
+
+// IsOwnedBy reports whether the custom benchmark was created by the credential of the tenant; a nil tenantID
+// matches the benchmarks created without a tenant
+func (b *Benchmark) IsOwnedBy(tenantID *uuid.UUID, owner string) bool {
+	if b.OwnerTenantID == nil || tenantID == nil {
+		return b.OwnerTenantID == nil && tenantID == nil
+	}
+	return *b.OwnerTenantID == *tenantID && b.Owner == owner
+}
+
+// IsCustom reports whether the benchmark is computed from stored prices instead of ingested from the provider
+func (b *Benchmark) IsCustom() bool {
+	return b.Kind == BenchmarkKindCustom
+}
+
+// BenchmarkValue is the daily value of a benchmark. There is one record per benchmark and date; ingesting the
+// values again updates it
+type BenchmarkValue struct {
+	BenchmarkID   uuid.UUID `json:"benchmark_id" gorm:"type:uuid;primaryKey;not null"`
+	Date          time.Time `json:"date" gorm:"type:date;primaryKey;not null"`
+	Open          float64   `json:"open" gorm:"type:decimal(15,4);not null"`
+	High          float64   `json:"high" gorm:"type:decimal(15,4);not null"`
+	Low           float64   `json:"low" gorm:"type:decimal(15,4);not null"`
+	Close         float64   `json:"close" gorm:"type:decimal(15,4);not null"`
+	AdjustedClose float64   `json:"adjusted_close" gorm:"type:decimal(15,4);not null;default:0"` // Incluye dividendos (rentabilidad total)
+	Volume        int64     `json:"volume" gorm:"type:bigint;not null;default:0"`
+	Source        string    `json:"source" gorm:"type:string;not null"`
+
+	// Auditoría - timestamps automáticos por la BD
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime;not null"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime;not null"`
+}
+
+// TableName specifies the table name for GORM
+func (BenchmarkValue) TableName() string {
+	return "benchmark_values"
+}
+
+// Price returns the adjusted close when the provider reports it and the close otherwise
+func (v *BenchmarkValue) Price() float64 {
+	if v.AdjustedClose > 0 {
+		return v.AdjustedClose
+	}
+	return v.Close
+}
+
+// BenchmarkConstituent is the membership of a symbol in a benchmark from AddedAt until RemovedAt (exclusive).
+// A symbol that leaves and joins again gets a new record, so past compositions can be rebuilt
+type BenchmarkConstituent struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;not null"`
+	BenchmarkID uuid.UUID  `json:"benchmark_id" gorm:"type:uuid;not null;index" validate:"required"`
+	CompanyID   *uuid.UUID `json:"company_id,omitempty" gorm:"type:uuid;null"` // nil si la company no está registrada
+	Symbol      string     `json:"symbol" gorm:"type:string;not null" validate:"required"`
+	Name        string     `json:"name" gorm:"type:string;null"`
+	Weight      *float64   `json:"weight,omitempty" gorm:"type:decimal(9,6);null"` // Fracción del índice (0.07 = 7%)
+	AddedAt     time.Time  `json:"added_at" gorm:"type:date;not null"`
+	RemovedAt   *time.Time `json:"removed_at,omitempty" gorm:"type:date;null"`
+
+	// Auditoría - timestamps automáticos por la BD
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime;not null"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime;not null"`
+}
+
+// TableName specifies the table name for GORM
+func (BenchmarkConstituent) TableName() string {
+	return "benchmark_constituents"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a record
+func (c *BenchmarkConstituent) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	c.Symbol = strings.ToUpper(c.Symbol)
+	return nil
+}
+
+// IsMemberOn reports whether the symbol belonged to the benchmark on date
+func (c *BenchmarkConstituent) IsMemberOn(date time.Time) bool {
+	return !c.AddedAt.After(date) && (c.RemovedAt == nil || c.RemovedAt.After(date))
+}
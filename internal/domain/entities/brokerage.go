@@ -18,6 +18,9 @@ type Brokerage struct {
 	UpdatedAt time.Time      `json:"updated_at" gorm:"autoUpdateTime;not null"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 	
+	// Bloqueo optimista - se incrementa en cada Update
+	Version int64 `json:"version" gorm:"not null;default:1"`
+	
 	// Control de estado
 	IsActive bool `json:"is_active" gorm:"default:true;not null"`
 	
@@ -39,6 +42,9 @@ func (b *Brokerage) BeforeCreate(tx *gorm.DB) error {
 	if b.ID == uuid.Nil {
 		b.ID = uuid.New()
 	}
+	if b.Version == 0 {
+		b.Version = 1
+	}
 	// Solo normalización básica de datos
 	b.normalizeName()
 	b.normalizeWebsite()
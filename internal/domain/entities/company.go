@@ -19,6 +19,9 @@ type Company struct {
 	UpdatedAt time.Time      `json:"updated_at" gorm:"autoUpdateTime;not null"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 	
+	// Bloqueo optimista - se incrementa en cada Update
+	Version int64 `json:"version" gorm:"not null;default:1"`
+	
 	// Control de estado
 	IsActive bool `json:"is_active" gorm:"default:true;not null"`
 	
@@ -47,6 +50,7 @@ type Company struct {
 	// Control de datos
 	DataSource           string     `json:"data_source,omitempty" gorm:"column:data_source;type:string;default:'manual';null"`
 	ProfileLastUpdated   *time.Time `json:"profile_last_updated,omitempty" gorm:"column:profile_last_updated;null"`
+	ProfileRequestID     string     `json:"profile_request_id,omitempty" gorm:"column:profile_request_id;type:string;null"` // Request ID del proveedor del último perfil
 	
 	// Relationships
 	StockRatings []StockRating `json:"stock_ratings,omitempty" gorm:"foreignKey:CompanyID"`
@@ -62,6 +66,9 @@ func (c *Company) BeforeCreate(tx *gorm.DB) error {
 	if c.ID == uuid.Nil {
 		c.ID = uuid.New()
 	}
+	if c.Version == 0 {
+		c.Version = 1
+	}
 	// Solo normalización básica de datos
 	c.normalizeTicker()
 	c.normalizeName()
@@ -0,0 +1,100 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EnrichmentConflictStatus represents the review state of an enrichment conflict
+type EnrichmentConflictStatus string
+
+const (
+	EnrichmentConflictStatusPending   EnrichmentConflictStatus = "pending"
+	EnrichmentConflictStatusResolved  EnrichmentConflictStatus = "resolved"
+	EnrichmentConflictStatusDismissed EnrichmentConflictStatus = "dismissed"
+)
+
+// Campos de Company que el pipeline de enriquecimiento completa desde CompanyProfile
+const (
+	EnrichmentFieldSector    = "sector"
+	EnrichmentFieldExchange  = "exchange"
+	EnrichmentFieldMarketCap = "market_cap"
+)
+
+// CompanyEnrichmentConflict records a Company field whose value disagrees with the stored CompanyProfile.
+// The enrichment pipeline never overwrites a populated field; it flags the mismatch for review instead.
+type CompanyEnrichmentConflict struct {
+	ID        uuid.UUID                `json:"id" gorm:"type:uuid;primary_key;not null"`
+	CompanyID uuid.UUID                `json:"company_id" gorm:"type:uuid;not null;index" validate:"required"`
+	Ticker    string                   `json:"ticker" gorm:"type:string;not null;index" validate:"required,max=10"`
+	Field     string                   `json:"field" gorm:"type:string;not null" validate:"required,oneof=sector exchange market_cap"`
+	Status    EnrichmentConflictStatus `json:"status" gorm:"type:string;not null;default:'pending';index"`
+
+	// Valores en conflicto serializados como texto
+	CompanyValue string `json:"company_value" gorm:"type:string;not null"`
+	ProfileValue string `json:"profile_value" gorm:"type:string;not null"`
+	Source       string `json:"source" gorm:"type:string;not null"` // DataSource del CompanyProfile (finnhub, ...)
+
+	Fingerprint string     `json:"-" gorm:"type:string;not null;uniqueIndex"` // Evita duplicar el mismo conflicto entre ejecuciones
+	Occurrences int        `json:"occurrences" gorm:"not null;default:1"`
+	LastSeenAt  time.Time  `json:"last_seen_at" gorm:"not null"`
+	ResolvedAt  *time.Time `json:"resolved_at,omitempty" gorm:"null"`
+
+	// Auditoría - timestamps automáticos por la BD
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime;not null"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime;not null"`
+}
+
+// TableName specifies the table name for GORM
+func (CompanyEnrichmentConflict) TableName() string {
+	return "company_enrichment_conflicts"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a record
+func (c *CompanyEnrichmentConflict) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	if c.Status == "" {
+		c.Status = EnrichmentConflictStatusPending
+	}
+	if c.Fingerprint == "" {
+		c.Fingerprint = EnrichmentConflictFingerprint(c.CompanyID, c.Field, c.CompanyValue, c.ProfileValue)
+	}
+	if c.LastSeenAt.IsZero() {
+		c.LastSeenAt = time.Now().UTC()
+	}
+	return nil
+}
+
+// NewCompanyEnrichmentConflict creates a new pending CompanyEnrichmentConflict instance
+func NewCompanyEnrichmentConflict(company *Company, field, companyValue, profileValue, source string) *CompanyEnrichmentConflict {
+	return &CompanyEnrichmentConflict{
+		ID:           uuid.New(),
+		CompanyID:    company.ID,
+		Ticker:       company.Ticker,
+		Field:        field,
+		Status:       EnrichmentConflictStatusPending,
+		CompanyValue: companyValue,
+		ProfileValue: profileValue,
+		Source:       source,
+		Fingerprint:  EnrichmentConflictFingerprint(company.ID, field, companyValue, profileValue),
+		Occurrences:  1,
+		LastSeenAt:   time.Now().UTC(),
+	}
+}
+
+// EnrichmentConflictFingerprint identifies a conflict by company, field and the two disagreeing values
+func EnrichmentConflictFingerprint(companyID uuid.UUID, field, companyValue, profileValue string) string {
+	sum := sha256.Sum256([]byte(companyID.String() + "|" + field + "|" + companyValue + "|" + profileValue))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsPending checks if the conflict is still waiting for review
+func (c *CompanyEnrichmentConflict) IsPending() bool {
+	return c.Status == EnrichmentConflictStatusPending
+}
@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Origen de una relación de competencia entre companies
+const (
+	PeerSourceFinnhub   = "finnhub"   // API de peers de Finnhub
+	PeerSourceHeuristic = "heuristic" // Mismo sector y capitalización parecida
+)
+
+// CompanyPeer is one edge of the competitor graph: PeerCompanyID competes with CompanyID.
+// The peers of a company are replaced as a whole every time they are rediscovered.
+type CompanyPeer struct {
+	ID            uuid.UUID `json:"id" gorm:"type:uuid;primary_key;not null"`
+	CompanyID     uuid.UUID `json:"company_id" gorm:"type:uuid;not null;index" validate:"required"`
+	PeerCompanyID uuid.UUID `json:"peer_company_id" gorm:"type:uuid;not null;index" validate:"required"`
+	Source        string    `json:"source" gorm:"type:string;not null"` // finnhub, heuristic
+	Rank          int       `json:"rank" gorm:"not null"`               // 1 = competidor más cercano
+
+	// Auditoría - timestamps automáticos por la BD
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime;not null"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime;not null"` // Fecha del último descubrimiento
+
+	// Relationships
+	Peer Company `json:"peer,omitempty" gorm:"foreignKey:PeerCompanyID"`
+}
+
+// TableName specifies the table name for GORM
+func (CompanyPeer) TableName() string {
+	return "company_peers"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a record
+func (p *CompanyPeer) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+// NewCompanyPeer creates a new CompanyPeer edge
+func NewCompanyPeer(companyID, peerCompanyID uuid.UUID, source string, rank int) *CompanyPeer {
+	return &CompanyPeer{
+		ID:            uuid.New(),
+		CompanyID:     companyID,
+		PeerCompanyID: peerCompanyID,
+		Source:        source,
+		Rank:          rank,
+	}
+}
@@ -0,0 +1,60 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Tipos de relación entre companies: RelatedCompanyID es el proveedor, el competidor o la filial de CompanyID
+const (
+	RelationshipSupplier   = "supplier"
+	RelationshipCompetitor = "competitor"
+	RelationshipSubsidiary = "subsidiary"
+)
+
+// RelationshipTypes son los tipos de relación admitidos
+var RelationshipTypes = []string{RelationshipSupplier, RelationshipCompetitor, RelationshipSubsidiary}
+
+// CompanyRelationship is one declared edge of the company graph: RelatedCompanyID is a supplier, competitor or
+// subsidiary of CompanyID. Unlike the discovered peers, relationships are maintained by hand
+type CompanyRelationship struct {
+	ID               uuid.UUID `json:"id" gorm:"type:uuid;primary_key;not null"`
+	CompanyID        uuid.UUID `json:"company_id" gorm:"type:uuid;not null;index" validate:"required"`
+	RelatedCompanyID uuid.UUID `json:"related_company_id" gorm:"type:uuid;not null;index" validate:"required"`
+	Type             string    `json:"type" gorm:"type:string;not null" validate:"required,oneof=supplier competitor subsidiary"`
+	Note             string    `json:"note,omitempty" gorm:"type:string;null"` // Contexto libre ("proveedor de pantallas")
+
+	// Auditoría - timestamps automáticos por la BD
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime;not null"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime;not null"`
+
+	// Relationships
+	Company        Company `json:"company,omitempty" gorm:"foreignKey:CompanyID"`
+	RelatedCompany Company `json:"related_company,omitempty" gorm:"foreignKey:RelatedCompanyID"`
+}
+
+// TableName specifies the table name for GORM
+func (CompanyRelationship) TableName() string {
+	return "company_relationships"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a record
+func (r *CompanyRelationship) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// NewCompanyRelationship creates a new CompanyRelationship edge
+func NewCompanyRelationship(companyID, relatedCompanyID uuid.UUID, relationshipType, note string) *CompanyRelationship {
+	return &CompanyRelationship{
+		ID:               uuid.New(),
+		CompanyID:        companyID,
+		RelatedCompanyID: relatedCompanyID,
+		Type:             relationshipType,
+		Note:             note,
+	}
+}
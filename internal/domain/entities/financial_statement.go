@@ -0,0 +1,106 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Estados financieros y periodos guardados
+const (
+	StatementIncome   = "income"    // Cuenta de resultados
+	StatementBalance  = "balance"   // Balance
+	StatementCashFlow = "cash_flow" // Flujos de caja
+
+	StatementPeriodAnnual    = "annual"
+	StatementPeriodQuarterly = "quarterly"
+)
+
+// StatementLineItems are the amounts of a statement by line item (snake_case), e.g. total_revenue. Items the
+// provider does not report are missing rather than zero
+type StatementLineItems map[string]float64
+
+// Value stores the line items as JSON
+func (i StatementLineItems) Value() (driver.Value, error) {
+	if i == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(i)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan reads the line items from a JSON column
+func (i *StatementLineItems) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	case nil:
+		*i = nil
+		return nil
+	default:
+		return fmt.Errorf("unsupported line items type %T", value)
+	}
+	return json.Unmarshal(data, i)
+}
+
+// FinancialStatement is one reported statement of a company (income statement, balance sheet or cash flow) for a
+// fiscal year or quarter. There is one record per company, statement, period and fiscal date; fetching the
+// statements again updates it
+type FinancialStatement struct {
+	ID               uuid.UUID          `json:"id" gorm:"type:uuid;primary_key;not null"`
+	CompanyID        uuid.UUID          `json:"company_id" gorm:"type:uuid;not null;index" validate:"required"`
+	Statement        string             `json:"statement" gorm:"type:string;not null"` // income, balance, cash_flow
+	Period           string             `json:"period" gorm:"type:string;not null"`    // annual, quarterly
+	FiscalDateEnding time.Time          `json:"fiscal_date_ending" gorm:"type:date;not null"`
+	Currency         string             `json:"currency" gorm:"type:string;null"`
+	LineItems        StatementLineItems `json:"line_items" gorm:"type:jsonb;not null"`
+
+	// Auditoría - timestamps automáticos por la BD
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime;not null"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime;not null"` // Última descarga del proveedor
+}
+
+// TableName specifies the table name for GORM
+func (FinancialStatement) TableName() string {
+	return "financial_statements"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a record
+func (s *FinancialStatement) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// NewFinancialStatement creates a new FinancialStatement record
+func NewFinancialStatement(companyID uuid.UUID, statement, period string, fiscalDateEnding time.Time, currency string, items StatementLineItems) *FinancialStatement {
+	return &FinancialStatement{
+		ID:               uuid.New(),
+		CompanyID:        companyID,
+		Statement:        statement,
+		Period:           period,
+		FiscalDateEnding: fiscalDateEnding,
+		Currency:         currency,
+		LineItems:        items,
+	}
+}
+
+// IsValidStatement reports whether statement is one of the stored statements
+func IsValidStatement(statement string) bool {
+	switch statement {
+	case StatementIncome, StatementBalance, StatementCashFlow:
+		return true
+	}
+	return false
+}
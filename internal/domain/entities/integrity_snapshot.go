@@ -0,0 +1,78 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Tendencia de la calidad de datos entre dos snapshots de integridad
+const (
+	IntegrityTrendImproving    = "improving"
+	IntegrityTrendDegrading    = "degrading"
+	IntegrityTrendStable       = "stable"
+	IntegrityTrendInsufficient = "insufficient_data" // Menos de dos snapshots en la ventana
+)
+
+// IntegritySnapshot stores the summary of a full integrity validation so data quality can be compared over time
+type IntegritySnapshot struct {
+	ID     uuid.UUID `json:"id" gorm:"type:uuid;primary_key;not null"`
+	Status string    `json:"status" gorm:"type:string;not null"` // GOOD, WARNING o CRITICAL
+
+	// Resumen de ValidateFullIntegrity
+	TotalIssues        int `json:"total_issues" gorm:"not null;default:0"`
+	CriticalIssues     int `json:"critical_issues" gorm:"not null;default:0"`
+	WarningIssues      int `json:"warning_issues" gorm:"not null;default:0"`
+	Orphans            int `json:"orphans" gorm:"not null;default:0"`
+	Inconsistencies    int `json:"inconsistencies" gorm:"not null;default:0"`
+	Duplicates         int `json:"duplicates" gorm:"not null;default:0"`
+	BusinessViolations int `json:"business_violations" gorm:"not null;default:0"`
+
+	// Métricas de calidad de stock_ratings (DataIntegrityReport)
+	TotalRatings     int64 `json:"total_ratings" gorm:"not null;default:0"`
+	MissingCompany   int64 `json:"missing_company" gorm:"not null;default:0"`
+	MissingBrokerage int64 `json:"missing_brokerage" gorm:"not null;default:0"`
+	InvalidEventTime int64 `json:"invalid_event_time" gorm:"not null;default:0"`
+	EmptyAction      int64 `json:"empty_action" gorm:"not null;default:0"`
+	DuplicateRatings int64 `json:"duplicate_ratings" gorm:"not null;default:0"`
+	OrphanedRatings  int64 `json:"orphaned_ratings" gorm:"not null;default:0"`
+
+	DurationMs int64     `json:"duration_ms" gorm:"not null;default:0"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime;not null;index"`
+}
+
+// TableName specifies the table name for GORM
+func (IntegritySnapshot) TableName() string {
+	return "integrity_snapshots"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a record
+func (s *IntegritySnapshot) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// IntegrityTrend compares the latest snapshot with an earlier one. Critical issues weigh first: fewer critical
+// issues is an improvement even if the warnings grew
+func IntegrityTrend(earlier, latest *IntegritySnapshot) string {
+	if earlier == nil || latest == nil {
+		return IntegrityTrendInsufficient
+	}
+
+	switch critical := latest.CriticalIssues - earlier.CriticalIssues; {
+	case critical < 0:
+		return IntegrityTrendImproving
+	case critical > 0:
+		return IntegrityTrendDegrading
+	}
+	switch total := latest.TotalIssues - earlier.TotalIssues; {
+	case total < 0:
+		return IntegrityTrendImproving
+	case total > 0:
+		return IntegrityTrendDegrading
+	}
+	return IntegrityTrendStable
+}
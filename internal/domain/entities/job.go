@@ -0,0 +1,104 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// JobStatus represents the lifecycle state of a background job
+type JobStatus string
+
+const (
+	JobStatusPending   JobStatus = "pending"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusSucceeded JobStatus = "succeeded"
+	JobStatusFailed    JobStatus = "failed"
+	JobStatusCancelled JobStatus = "cancelled"
+)
+
+// Job represents a long-running task persisted in the job queue
+type Job struct {
+	ID     uuid.UUID `json:"id" gorm:"type:uuid;primary_key;not null"`
+	Type   string    `json:"type" gorm:"type:string;not null;index" validate:"required,max=100"`
+	Status JobStatus `json:"status" gorm:"type:string;not null;default:'pending';index"`
+
+	// Payload y resultado serializados como JSON
+	Payload string `json:"payload,omitempty" gorm:"type:text;null"`
+	Result  string `json:"result,omitempty" gorm:"type:text;null"`
+	Error   string `json:"error,omitempty" gorm:"type:text;null"`
+
+	// Control de reintentos
+	Attempts    int       `json:"attempts" gorm:"not null;default:0"`
+	MaxAttempts int       `json:"max_attempts" gorm:"not null;default:3"`
+	RunAt       time.Time `json:"run_at" gorm:"not null;index"` // No se ejecuta antes de esta fecha
+
+	// Control de ejecución
+	LockedBy        string     `json:"locked_by,omitempty" gorm:"type:string;null"`
+	LockedAt        *time.Time `json:"locked_at,omitempty" gorm:"null"`
+	CancelRequested bool       `json:"cancel_requested" gorm:"not null;default:false"`
+	StartedAt       *time.Time `json:"started_at,omitempty" gorm:"null"`
+	FinishedAt      *time.Time `json:"finished_at,omitempty" gorm:"null"`
+
+	// Auditoría - timestamps automáticos por la BD
+	CreatedAt time.Time      `json:"created_at" gorm:"autoCreateTime;not null"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"autoUpdateTime;not null"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// TableName specifies the table name for GORM
+func (Job) TableName() string {
+	return "jobs"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a record
+func (j *Job) BeforeCreate(tx *gorm.DB) error {
+	if j.ID == uuid.Nil {
+		j.ID = uuid.New()
+	}
+	if j.Status == "" {
+		j.Status = JobStatusPending
+	}
+	if j.RunAt.IsZero() {
+		j.RunAt = time.Now().UTC()
+	}
+	return nil
+}
+
+// NewJob creates a new pending Job instance
+func NewJob(jobType, payload string, maxAttempts int) *Job {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	return &Job{
+		ID:          uuid.New(),
+		Type:        jobType,
+		Status:      JobStatusPending,
+		Payload:     payload,
+		MaxAttempts: maxAttempts,
+		RunAt:       time.Now().UTC(),
+	}
+}
+
+// IsTerminal checks if the job reached a final state
+func (j *Job) IsTerminal() bool {
+	return j.Status == JobStatusSucceeded || j.Status == JobStatusFailed || j.Status == JobStatusCancelled
+}
+
+// CanRetry checks if the job has attempts left
+func (j *Job) CanRetry() bool {
+	return j.Attempts < j.MaxAttempts
+}
+
+// NextRetryDelay returns the exponential backoff delay for the next attempt
+func (j *Job) NextRetryDelay(base time.Duration) time.Duration {
+	if j.Attempts <= 1 {
+		return base
+	}
+	shift := j.Attempts - 1
+	if shift > 10 {
+		shift = 10
+	}
+	return base * time.Duration(1<<uint(shift))
+}
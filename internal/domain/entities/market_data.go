@@ -34,6 +34,11 @@ type MarketData struct {
 	Currency     string `json:"currency" gorm:"type:string;size:3;default:'USD'"`
 	Exchange     string `json:"exchange" gorm:"type:string;size:10"`
 
+	// Data Source and Freshness
+	DataSource        string     `json:"data_source" gorm:"type:string;default:'finnhub'"`
+	FetchedAt         *time.Time `json:"fetched_at,omitempty"`                             // When the provider answered
+	ProviderRequestID string     `json:"provider_request_id,omitempty" gorm:"type:string"` // Request ID sent back by the provider
+
 	// Timestamps
 	MarketTimestamp time.Time      `json:"market_timestamp" gorm:"not null"` // When data was generated
 	CreatedAt       time.Time      `json:"created_at" gorm:"autoCreateTime;not null"`
@@ -69,6 +74,7 @@ type CompanyProfile struct {
 	Sector      string `json:"sector" gorm:"type:string"`
 	Country     string `json:"country" gorm:"type:string"`
 	Currency    string `json:"currency" gorm:"type:string;size:3"`
+	Exchange    string `json:"exchange" gorm:"type:string"`
 
 	// Financial Metrics
 	MarketCap         int64   `json:"market_cap" gorm:"type:bigint"`
@@ -81,18 +87,20 @@ type CompanyProfile struct {
 
 	// Trading Information
 	Beta       float64 `json:"beta" gorm:"type:decimal(8,4)"`
-	Week52High float64 `json:"week_52_high" gorm:"type:decimal(15,4)"`
-	Week52Low  float64 `json:"week_52_low" gorm:"type:decimal(15,4)"`
+	Week52High float64 `json:"week_52_high" gorm:"column:week_52_high;type:decimal(15,4)"`
+	Week52Low  float64 `json:"week_52_low" gorm:"column:week_52_low;type:decimal(15,4)"`
 
 	// Company Details
 	Website       string    `json:"website" gorm:"type:string"`
 	Logo          string    `json:"logo" gorm:"type:string"`
-	IPODate       time.Time `json:"ipo_date" gorm:"type:date"`
+	IPODate       time.Time `json:"ipo_date" gorm:"column:ipo_date;type:date"`
 	EmployeeCount int32     `json:"employee_count" gorm:"type:integer"`
 
 	// Data Source and Freshness
-	DataSource  string    `json:"data_source" gorm:"type:string;default:'finnhub'"`
-	LastUpdated time.Time `json:"last_updated" gorm:"not null"`
+	DataSource        string     `json:"data_source" gorm:"type:string;default:'finnhub'"`
+	FetchedAt         *time.Time `json:"fetched_at,omitempty"`                             // When the provider answered
+	ProviderRequestID string     `json:"provider_request_id,omitempty" gorm:"type:string"` // Request ID sent back by the provider
+	LastUpdated       time.Time  `json:"last_updated" gorm:"not null"`
 
 	// Timestamps
 	CreatedAt time.Time      `json:"created_at" gorm:"autoCreateTime;not null"`
@@ -129,6 +137,11 @@ type NewsItem struct {
 	Category string `json:"category" gorm:"type:string"`
 	Language string `json:"language" gorm:"type:string;size:2;default:'en'"`
 
+	// Data Source and Freshness (Source is the publisher, DataSource the provider the article came from)
+	DataSource        string     `json:"data_source" gorm:"type:string;default:'finnhub'"`
+	FetchedAt         *time.Time `json:"fetched_at,omitempty"`                             // When the provider answered
+	ProviderRequestID string     `json:"provider_request_id,omitempty" gorm:"type:string"` // Request ID sent back by the provider
+
 	// Sentiment Analysis
 	SentimentScore float64 `json:"sentiment_score" gorm:"type:decimal(4,3)"` // -1 to 1
 	SentimentLabel string  `json:"sentiment_label" gorm:"type:string"`       // positive, negative, neutral
@@ -196,8 +209,10 @@ type BasicFinancials struct {
 	FiscalQuarter int    `json:"fiscal_quarter" gorm:"type:integer"`
 
 	// Data Source and Freshness
-	DataSource  string    `json:"data_source" gorm:"type:string;default:'finnhub'"`
-	LastUpdated time.Time `json:"last_updated" gorm:"not null"`
+	DataSource        string     `json:"data_source" gorm:"type:string;default:'finnhub'"`
+	FetchedAt         *time.Time `json:"fetched_at,omitempty"`                             // When the provider answered
+	ProviderRequestID string     `json:"provider_request_id,omitempty" gorm:"type:string"` // Request ID sent back by the provider
+	LastUpdated       time.Time  `json:"last_updated" gorm:"not null"`
 
 	// Timestamps
 	CreatedAt time.Time      `json:"created_at" gorm:"autoCreateTime;not null"`
@@ -0,0 +1,49 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Note is a private annotation of a user on a company or on one of its ratings. It belongs to the credential
+// that wrote it (api_key:<id> o jwt:<sub>) within its tenant
+type Note struct {
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;not null"`
+	TenantOwned
+	Author    string     `json:"author" gorm:"type:string;not null"` // api_key:<id> o jwt:<sub>
+	CompanyID uuid.UUID  `json:"company_id" gorm:"type:uuid;not null;index"`
+	RatingID  *uuid.UUID `json:"rating_id,omitempty" gorm:"type:uuid;null"` // Nil si la nota es de la company
+	Body      string     `json:"body" gorm:"type:string;not null" validate:"required,max=5000"`
+
+	// Auditoría - timestamps automáticos por la BD
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime;not null"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime;not null"`
+}
+
+// TableName specifies the table name for GORM
+func (Note) TableName() string {
+	return "notes"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a record
+func (n *Note) BeforeCreate(tx *gorm.DB) error {
+	if n.ID == uuid.Nil {
+		n.ID = uuid.New()
+	}
+	n.Body = strings.TrimSpace(n.Body)
+	return nil
+}
+
+// NewNote creates a new Note on a company or, with ratingID, on one of its ratings. The repository assigns
+// the tenant and the author of the request
+func NewNote(companyID uuid.UUID, ratingID *uuid.UUID, body string) *Note {
+	return &Note{
+		ID:        uuid.New(),
+		CompanyID: companyID,
+		RatingID:  ratingID,
+		Body:      strings.TrimSpace(body),
+	}
+}
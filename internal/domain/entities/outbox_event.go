@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OutboxEvent is a domain event stored in the outbox in the same transaction as the change that produced it,
+// waiting for the relay to publish it on the event bus
+type OutboxEvent struct {
+	ID         uuid.UUID `json:"id" gorm:"type:uuid;primary_key;not null"` // ID del evento
+	EventType  string    `json:"event_type" gorm:"type:string;not null"`
+	Payload    string    `json:"payload" gorm:"type:jsonb;not null"`
+	OccurredAt time.Time `json:"occurred_at" gorm:"not null"`
+
+	// Entrega: los fallos se reintentan a partir de NextAttemptAt
+	Attempts      int        `json:"attempts" gorm:"not null;default:0"`
+	LastError     string     `json:"last_error,omitempty" gorm:"type:string;null"`
+	NextAttemptAt time.Time  `json:"next_attempt_at" gorm:"not null"`
+	PublishedAt   *time.Time `json:"published_at,omitempty" gorm:"null"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime;not null"`
+}
+
+// TableName specifies the table name for GORM
+func (OutboxEvent) TableName() string {
+	return "event_outbox"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a record
+func (o *OutboxEvent) BeforeCreate(tx *gorm.DB) error {
+	if o.ID == uuid.Nil {
+		o.ID = uuid.New()
+	}
+	if o.NextAttemptAt.IsZero() {
+		o.NextAttemptAt = time.Now().UTC()
+	}
+	return nil
+}
+
+// IsPublished reports whether the relay already published the event
+func (o *OutboxEvent) IsPublished() bool {
+	return o.PublishedAt != nil
+}
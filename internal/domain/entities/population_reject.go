@@ -0,0 +1,136 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PopulationRejectStatus represents the lifecycle state of a rejected population item
+type PopulationRejectStatus string
+
+const (
+	PopulationRejectStatusPending     PopulationRejectStatus = "pending"
+	PopulationRejectStatusReprocessed PopulationRejectStatus = "reprocessed"
+	PopulationRejectStatusDiscarded   PopulationRejectStatus = "discarded"
+)
+
+// Etapas del pipeline de población en las que se puede rechazar un item
+const (
+	RejectStageValidation          = "validation"
+	RejectStageCompanyResolution   = "company_resolution"
+	RejectStageBrokerageResolution = "brokerage_resolution"
+	RejectStageIngestionRule       = "ingestion_rule" // El motivo es el nombre de la regla incumplida
+)
+
+// PopulationReject stores an item that the population pipeline could not ingest (dead-letter)
+type PopulationReject struct {
+	ID     uuid.UUID              `json:"id" gorm:"type:uuid;primary_key;not null"`
+	Source string                 `json:"source" gorm:"type:string;not null;index" validate:"required,max=100"`
+	Stage  string                 `json:"stage" gorm:"type:string;not null;index" validate:"required,max=50"`
+	Reason string                 `json:"reason" gorm:"type:string;not null" validate:"required,max=100"`
+	Status PopulationRejectStatus `json:"status" gorm:"type:string;not null;default:'pending';index"`
+
+	// Identificación del item (puede venir vacía si el item no pasó validación)
+	Ticker    string `json:"ticker,omitempty" gorm:"type:string;null"`
+	Brokerage string `json:"brokerage,omitempty" gorm:"type:string;null"`
+
+	// Payload original serializado como JSON y detalle del error
+	RawPayload  string `json:"raw_payload" gorm:"type:text;not null"`
+	Error       string `json:"error,omitempty" gorm:"type:text;null"`
+	Fingerprint string `json:"-" gorm:"type:string;not null;uniqueIndex"` // Evita duplicar el mismo rechazo entre ejecuciones
+
+	// Seguimiento de ocurrencias y reprocesos
+	Occurrences   int        `json:"occurrences" gorm:"not null;default:1"`
+	LastSeenAt    time.Time  `json:"last_seen_at" gorm:"not null"`
+	Attempts      int        `json:"attempts" gorm:"not null;default:0"`
+	LastAttemptAt *time.Time `json:"last_attempt_at,omitempty" gorm:"null"`
+	ResolvedAt    *time.Time `json:"resolved_at,omitempty" gorm:"null"`
+
+	// Auditoría - timestamps automáticos por la BD
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime;not null"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime;not null"`
+}
+
+// TableName specifies the table name for GORM
+func (PopulationReject) TableName() string {
+	return "population_rejects"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a record
+func (r *PopulationReject) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	if r.Status == "" {
+		r.Status = PopulationRejectStatusPending
+	}
+	if r.Fingerprint == "" {
+		r.Fingerprint = RejectFingerprint(r.Source, r.Stage, r.RawPayload)
+	}
+	if r.LastSeenAt.IsZero() {
+		r.LastSeenAt = time.Now().UTC()
+	}
+	return nil
+}
+
+// NewPopulationReject creates a new pending PopulationReject instance
+func NewPopulationReject(source, stage, reason, rawPayload string) *PopulationReject {
+	source = strings.TrimSpace(source)
+	return &PopulationReject{
+		ID:          uuid.New(),
+		Source:      source,
+		Stage:       stage,
+		Reason:      reason,
+		Status:      PopulationRejectStatusPending,
+		RawPayload:  rawPayload,
+		Fingerprint: RejectFingerprint(source, stage, rawPayload),
+		Occurrences: 1,
+		LastSeenAt:  time.Now().UTC(),
+	}
+}
+
+// MergeRejectsByFingerprint collapses the rejects of the same payload in one batch into the first of them,
+// adding up their occurrences and keeping the reason, error and last_seen_at of the latest one. A batch upsert
+// cannot touch the same row twice, so Record merges them before writing
+func MergeRejectsByFingerprint(rejects []*PopulationReject) []*PopulationReject {
+	merged := make([]*PopulationReject, 0, len(rejects))
+	byFingerprint := make(map[string]*PopulationReject, len(rejects))
+
+	for _, reject := range rejects {
+		if reject.Fingerprint == "" {
+			reject.Fingerprint = RejectFingerprint(reject.Source, reject.Stage, reject.RawPayload)
+		}
+		if reject.Occurrences < 1 {
+			reject.Occurrences = 1
+		}
+
+		first, seen := byFingerprint[reject.Fingerprint]
+		if !seen {
+			byFingerprint[reject.Fingerprint] = reject
+			merged = append(merged, reject)
+			continue
+		}
+		first.Occurrences += reject.Occurrences
+		first.Reason, first.Error = reject.Reason, reject.Error
+		if reject.LastSeenAt.After(first.LastSeenAt) {
+			first.LastSeenAt = reject.LastSeenAt
+		}
+	}
+	return merged
+}
+
+// RejectFingerprint identifies a rejected payload within a source and stage
+func RejectFingerprint(source, stage, rawPayload string) string {
+	sum := sha256.Sum256([]byte(source + "|" + stage + "|" + rawPayload))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsPending checks if the reject is still waiting to be reprocessed or discarded
+func (r *PopulationReject) IsPending() bool {
+	return r.Status == PopulationRejectStatusPending
+}
@@ -0,0 +1,91 @@
+package entities
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Proveedores externos cuyas respuestas se archivan
+const (
+	PayloadProviderFinnhub      = "finnhub"
+	PayloadProviderAlphaVantage = "alphavantage"
+)
+
+// ProviderPayload archives the raw response of an external API call, gzip-compressed, so the data it produced
+// can be re-processed later without calling the provider again. Rows expire at ExpiresAt (row-level TTL)
+type ProviderPayload struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;not null"`
+	Provider  string    `json:"provider" gorm:"type:string;not null"`
+	Endpoint  string    `json:"endpoint" gorm:"type:string;not null"`     // Ruta de Finnhub (/quote) o function de Alpha Vantage
+	Symbol    string    `json:"symbol,omitempty" gorm:"type:string;null"` // Parámetro symbol de la petición, si lo tiene
+	Params    string    `json:"params,omitempty" gorm:"type:string;null"` // Query string sin credenciales
+	RequestID string    `json:"request_id,omitempty" gorm:"type:string;null"`
+
+	// Respuesta JSON comprimida con gzip y su tamaño sin comprimir
+	Payload []byte `json:"-" gorm:"type:bytes;not null"`
+	Size    int    `json:"size" gorm:"type:integer;not null"`
+
+	FetchedAt time.Time `json:"fetched_at" gorm:"not null"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime;not null"`
+}
+
+// TableName specifies the table name for GORM
+func (ProviderPayload) TableName() string {
+	return "provider_payloads"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a record
+func (p *ProviderPayload) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+// NewProviderPayload compresses a raw provider response for the archive; it expires ttl after fetchedAt
+func NewProviderPayload(provider, endpoint, symbol, params, requestID string, raw []byte, fetchedAt time.Time, ttl time.Duration) (*ProviderPayload, error) {
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err := writer.Write(raw); err != nil {
+		return nil, fmt.Errorf("failed to compress provider payload: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress provider payload: %w", err)
+	}
+
+	return &ProviderPayload{
+		ID:        uuid.New(),
+		Provider:  provider,
+		Endpoint:  endpoint,
+		Symbol:    strings.ToUpper(strings.TrimSpace(symbol)),
+		Params:    params,
+		RequestID: requestID,
+		Payload:   compressed.Bytes(),
+		Size:      len(raw),
+		FetchedAt: fetchedAt.UTC(),
+		ExpiresAt: fetchedAt.Add(ttl).UTC(),
+	}, nil
+}
+
+// Raw returns the original, decompressed response
+func (p *ProviderPayload) Raw() ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(p.Payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress provider payload: %w", err)
+	}
+	defer reader.Close()
+
+	raw, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress provider payload: %w", err)
+	}
+	return raw, nil
+}
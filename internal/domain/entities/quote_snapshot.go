@@ -0,0 +1,49 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// QuoteSnapshot is one intraday point of a symbol's quote, stored on each quote refresh.
+// Only the most recent snapshots of each symbol are kept (see QuoteSnapshotRepository.Append).
+type QuoteSnapshot struct {
+	ID              uuid.UUID `json:"id" gorm:"type:uuid;primary_key;not null"`
+	CompanyID       uuid.UUID `json:"company_id" gorm:"type:uuid;not null;index" validate:"required"`
+	Symbol          string    `json:"symbol" gorm:"type:string;not null" validate:"required"`
+	Price           float64   `json:"price" gorm:"type:decimal(15,4);not null"`
+	PriceChange     float64   `json:"price_change" gorm:"type:decimal(15,4)"`
+	PriceChangePerc float64   `json:"price_change_perc" gorm:"type:decimal(8,4)"`
+	Volume          int64     `json:"volume" gorm:"type:bigint"`
+	MarketTimestamp time.Time `json:"market_timestamp" gorm:"not null"` // Momento de la cotización según el proveedor
+	CreatedAt       time.Time `json:"created_at" gorm:"autoCreateTime;not null"`
+}
+
+// TableName specifies the table name for GORM
+func (QuoteSnapshot) TableName() string {
+	return "quote_snapshots"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a record
+func (qs *QuoteSnapshot) BeforeCreate(tx *gorm.DB) error {
+	if qs.ID == uuid.Nil {
+		qs.ID = uuid.New()
+	}
+	return nil
+}
+
+// NewQuoteSnapshot creates the intraday snapshot of a quote
+func NewQuoteSnapshot(marketData *MarketData) *QuoteSnapshot {
+	return &QuoteSnapshot{
+		ID:              uuid.New(),
+		CompanyID:       marketData.CompanyID,
+		Symbol:          marketData.Symbol,
+		Price:           marketData.CurrentPrice,
+		PriceChange:     marketData.PriceChange,
+		PriceChangePerc: marketData.PriceChangePerc,
+		Volume:          marketData.Volume,
+		MarketTimestamp: marketData.MarketTimestamp,
+	}
+}
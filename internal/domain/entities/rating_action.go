@@ -0,0 +1,62 @@
+package entities
+
+import "strings"
+
+// ActionType is the structured form of the free-text rating action ("upgraded by", "target raised by"...)
+type ActionType string
+
+const (
+	ActionUpgrade       ActionType = "upgrade"
+	ActionDowngrade     ActionType = "downgrade"
+	ActionReiterate     ActionType = "reiterate"
+	ActionInitiate      ActionType = "initiate"
+	ActionTargetRaised  ActionType = "target_raised"
+	ActionTargetLowered ActionType = "target_lowered"
+	ActionTargetSet     ActionType = "target_set"
+	ActionOther         ActionType = "other"
+)
+
+// ActionTypes lists every action type produced by ParseActionType
+var ActionTypes = []ActionType{
+	ActionUpgrade, ActionDowngrade, ActionReiterate, ActionInitiate,
+	ActionTargetRaised, ActionTargetLowered, ActionTargetSet, ActionOther,
+}
+
+// actionPatterns se evalúan en orden; el primero contenido en la acción decide el tipo
+var actionPatterns = []struct {
+	pattern    string
+	actionType ActionType
+}{
+	{"upgrade", ActionUpgrade},
+	{"downgrade", ActionDowngrade},
+	{"reiterat", ActionReiterate},
+	{"initiat", ActionInitiate},
+	{"resumed", ActionInitiate},
+	{"target raised", ActionTargetRaised},
+	{"target lowered", ActionTargetLowered},
+	{"target set", ActionTargetSet},
+}
+
+// ParseActionType classifies a rating action; unknown actions are ActionOther and an empty action is ""
+func ParseActionType(action string) ActionType {
+	action = strings.Join(strings.Fields(strings.ToLower(action)), " ")
+	if action == "" {
+		return ""
+	}
+	for _, candidate := range actionPatterns {
+		if strings.Contains(action, candidate.pattern) {
+			return candidate.actionType
+		}
+	}
+	return ActionOther
+}
+
+// Valid reports whether the action type is one of ActionTypes
+func (a ActionType) Valid() bool {
+	for _, actionType := range ActionTypes {
+		if a == actionType {
+			return true
+		}
+	}
+	return false
+}
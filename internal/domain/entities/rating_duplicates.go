@@ -0,0 +1,87 @@
+package entities
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// GroupDuplicateRatings groups the ratings that are the same event. Ratings of the same company and brokerage with
+// the same event time are duplicates; with a window > 0 so are those with the same action, ratings and targets whose
+// event times are at most window apart (providers re-emit ratings with a few seconds of jitter). Each group is sorted
+// by event time and creation time, so the first rating is the original emission
+func GroupDuplicateRatings(ratings []*StockRating, window time.Duration) [][]*StockRating {
+	parent := make([]int, len(ratings))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	union := func(a, b int) {
+		parent[find(a)] = find(b)
+	}
+
+	// Duplicados exactos: misma company, brokerage y event_time
+	exact := make(map[string]int, len(ratings))
+	for i, rating := range ratings {
+		key := strings.Join([]string{rating.CompanyID.String(), rating.BrokerageID.String(), rating.EventTime.UTC().Format(time.RFC3339Nano)}, "|")
+		if first, ok := exact[key]; ok {
+			union(i, first)
+		} else {
+			exact[key] = i
+		}
+	}
+
+	// Duplicados cercanos: mismo cambio de rating re-emitido dentro de la ventana
+	if window > 0 {
+		sameChange := make(map[string][]int)
+		for i, rating := range ratings {
+			key := strings.Join([]string{
+				rating.CompanyID.String(), rating.BrokerageID.String(),
+				strings.ToLower(strings.TrimSpace(rating.Action)),
+				strings.TrimSpace(rating.RatingFrom), strings.TrimSpace(rating.RatingTo),
+				strings.TrimSpace(rating.TargetFrom), strings.TrimSpace(rating.TargetTo),
+			}, "|")
+			sameChange[key] = append(sameChange[key], i)
+		}
+		for _, indexes := range sameChange {
+			sort.Slice(indexes, func(a, b int) bool {
+				return ratings[indexes[a]].EventTime.Before(ratings[indexes[b]].EventTime)
+			})
+			for k := 1; k < len(indexes); k++ {
+				if ratings[indexes[k]].EventTime.Sub(ratings[indexes[k-1]].EventTime) <= window {
+					union(indexes[k], indexes[k-1])
+				}
+			}
+		}
+	}
+
+	components := make(map[int][]*StockRating)
+	for i, rating := range ratings {
+		root := find(i)
+		components[root] = append(components[root], rating)
+	}
+
+	groups := make([][]*StockRating, 0)
+	for _, group := range components {
+		if len(group) < 2 {
+			continue
+		}
+		sort.Slice(group, func(a, b int) bool {
+			if !group[a].EventTime.Equal(group[b].EventTime) {
+				return group[a].EventTime.Before(group[b].EventTime)
+			}
+			return group[a].CreatedAt.Before(group[b].CreatedAt)
+		})
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(a, b int) bool {
+		return groups[a][0].EventTime.Before(groups[b][0].EventTime)
+	})
+	return groups
+}
@@ -0,0 +1,165 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// RatingScale is the canonical brokerage rating scale into which the free-text ratings are normalized
+type RatingScale string
+
+const (
+	RatingStrongBuy  RatingScale = "strong_buy"
+	RatingBuy        RatingScale = "buy"
+	RatingHold       RatingScale = "hold"
+	RatingSell       RatingScale = "sell"
+	RatingStrongSell RatingScale = "strong_sell"
+)
+
+// RatingScales lists the canonical scale from the most bullish to the most bearish
+var RatingScales = []RatingScale{RatingStrongBuy, RatingBuy, RatingHold, RatingSell, RatingStrongSell}
+
+// ParseRatingScale validates a canonical rating
+func ParseRatingScale(value string) (RatingScale, error) {
+	scale := RatingScale(strings.ToLower(strings.TrimSpace(value)))
+	if !scale.Valid() {
+		return "", fmt.Errorf("invalid rating %q: must be strong_buy, buy, hold, sell or strong_sell", value)
+	}
+	return scale, nil
+}
+
+// Valid reports whether the rating belongs to the canonical scale
+func (s RatingScale) Valid() bool {
+	switch s {
+	case RatingStrongBuy, RatingBuy, RatingHold, RatingSell, RatingStrongSell:
+		return true
+	}
+	return false
+}
+
+// Score returns the numeric value of the rating: 2 (strong buy) to -2 (strong sell), 0 if unknown
+func (s RatingScale) Score() int {
+	switch s {
+	case RatingStrongBuy:
+		return 2
+	case RatingBuy:
+		return 1
+	case RatingSell:
+		return -1
+	case RatingStrongSell:
+		return -2
+	}
+	return 0
+}
+
+// Bucket groups the rating as buy, hold or sell for consensus counts ("" if unknown)
+func (s RatingScale) Bucket() string {
+	switch s {
+	case RatingStrongBuy, RatingBuy:
+		return "buy"
+	case RatingHold:
+		return "hold"
+	case RatingSell, RatingStrongSell:
+		return "sell"
+	}
+	return ""
+}
+
+// Label returns the display name of the rating ("Strong Buy", "Hold"...)
+func (s RatingScale) Label() string {
+	switch s {
+	case RatingStrongBuy:
+		return "Strong Buy"
+	case RatingBuy:
+		return "Buy"
+	case RatingHold:
+		return "Hold"
+	case RatingSell:
+		return "Sell"
+	case RatingStrongSell:
+		return "Strong Sell"
+	}
+	return ""
+}
+
+// RatingMapping maps a free-text brokerage rating ("Overweight", "Market Perform"...) to the canonical scale.
+// The table is editable by administrators; Rating is stored lowercased and trimmed
+type RatingMapping struct {
+	Rating     string      `json:"rating" gorm:"column:raw_rating;type:string;primaryKey"`
+	Normalized RatingScale `json:"normalized" gorm:"type:string;not null"`
+	UpdatedAt  time.Time   `json:"updated_at" gorm:"autoUpdateTime;not null"`
+}
+
+// TableName specifies the table name for GORM
+func (RatingMapping) TableName() string {
+	return "rating_mappings"
+}
+
+// RatingMappingKey normalizes a free-text rating into the key of the mapping table
+func RatingMappingKey(rating string) string {
+	return strings.Join(strings.Fields(strings.ToLower(rating)), " ")
+}
+
+// DefaultRatingMappings are the variants seeded by migration 000022; they are also used until the table is loaded
+var DefaultRatingMappings = map[string]RatingScale{
+	"strong buy":          RatingStrongBuy,
+	"strong-buy":          RatingStrongBuy,
+	"conviction buy":      RatingStrongBuy,
+	"top pick":            RatingStrongBuy,
+	"buy":                 RatingBuy,
+	"outperform":          RatingBuy,
+	"overweight":          RatingBuy,
+	"market outperform":   RatingBuy,
+	"sector outperform":   RatingBuy,
+	"positive":            RatingBuy,
+	"accumulate":          RatingBuy,
+	"add":                 RatingBuy,
+	"speculative buy":     RatingBuy,
+	"moderate buy":        RatingBuy,
+	"hold":                RatingHold,
+	"neutral":             RatingHold,
+	"equal weight":        RatingHold,
+	"equal-weight":        RatingHold,
+	"market perform":      RatingHold,
+	"sector perform":      RatingHold,
+	"sector weight":       RatingHold,
+	"in-line":             RatingHold,
+	"peer perform":        RatingHold,
+	"mixed":               RatingHold,
+	"sell":                RatingSell,
+	"underperform":        RatingSell,
+	"underweight":         RatingSell,
+	"market underperform": RatingSell,
+	"sector underperform": RatingSell,
+	"negative":            RatingSell,
+	"reduce":              RatingSell,
+	"moderate sell":       RatingSell,
+	"strong sell":         RatingStrongSell,
+	"strong-sell":         RatingStrongSell,
+}
+
+// ratingTaxonomy es la tabla de mapeo vigente en el proceso; los hooks de StockRating la usan al guardar
+var ratingTaxonomy atomic.Pointer[map[string]RatingScale]
+
+func init() {
+	SetRatingMappings(DefaultRatingMappings)
+}
+
+// SetRatingMappings replaces the mapping table used to normalize the ratings saved from now on
+func SetRatingMappings(mappings map[string]RatingScale) {
+	table := make(map[string]RatingScale, len(mappings))
+	for rating, scale := range mappings {
+		table[RatingMappingKey(rating)] = scale
+	}
+	ratingTaxonomy.Store(&table)
+}
+
+// NormalizeRating maps a free-text rating to the canonical scale ("" if the variant is not mapped)
+func NormalizeRating(rating string) RatingScale {
+	if rating == "" {
+		return ""
+	}
+	return (*ratingTaxonomy.Load())[RatingMappingKey(rating)]
+}
@@ -21,17 +21,31 @@ type StockRating struct {
 	RatingTo   string `json:"rating_to,omitempty" gorm:"type:string;null"`                   // "Buy", "Sell", "Hold", etc.
 	TargetFrom string `json:"target_from,omitempty" gorm:"type:string;null"`                 // "$4.20"
 	TargetTo   string `json:"target_to,omitempty" gorm:"type:string;null"`                   // "$4.70"
+
+	// Parsed price targets: value in major units and ISO currency; nil when the text could not be parsed
+	TargetFromValue *float64 `json:"target_from_value,omitempty" gorm:"column:target_from_value;type:decimal(18,4);null"`
+	TargetToValue   *float64 `json:"target_to_value,omitempty" gorm:"column:target_to_value;type:decimal(18,4);null"`
+	TargetCurrency  string   `json:"target_currency,omitempty" gorm:"column:target_currency;type:string;size:3;null"`
+
+	// ActionType is parsed from Action on every save (upgrade, downgrade, reiterate, target_raised...)
+	ActionType ActionType `json:"action_type,omitempty" gorm:"column:action_type;type:string;null"`
+
+	// Normalized ratings: canonical scale (strong_buy ... strong_sell) from the rating_mappings table; empty if not mapped
+	NormalizedFrom RatingScale `json:"normalized_rating_from,omitempty" gorm:"column:normalized_rating_from;type:string;null"`
+	NormalizedTo   RatingScale `json:"normalized_rating_to,omitempty" gorm:"column:normalized_rating_to;type:string;null"`
 	
 	// Timestamps
 	EventTime time.Time `json:"event_time" gorm:"not null" validate:"required"`              // When the rating occurred (from API)
 	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime;not null"`                   // When saved to our DB
 	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime;not null"`                   // Last modification
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`                                        // Soft delete
+	Version   int64          `json:"version" gorm:"not null;default:1"`                     // Optimistic locking
 	
 	// Processing metadata
 	Source      string          `json:"source" gorm:"type:string;default:'api';not null"`     // Data source
 	RawData     json.RawMessage `json:"raw_data,omitempty" gorm:"type:jsonb;null"`            // Original API response
 	IsProcessed bool            `json:"is_processed" gorm:"default:false;not null"`           // Processing status
+	ProcessedAt *time.Time      `json:"processed_at,omitempty" gorm:"null"`                   // When the rating_processing job marked it
 	
 	// Relationships
 	Company   Company   `json:"company,omitempty" gorm:"foreignKey:CompanyID;constraint:OnDelete:CASCADE"`
@@ -48,9 +62,13 @@ func (sr *StockRating) BeforeCreate(tx *gorm.DB) error {
 	if sr.ID == uuid.Nil {
 		sr.ID = uuid.New()
 	}
+	if sr.Version == 0 {
+		sr.Version = 1
+	}
 	// Solo normalización básica de datos
 	sr.normalizeAction()
 	sr.normalizeRatings()
+	sr.normalizeTargets()
 	return nil
 }
 
@@ -58,12 +76,14 @@ func (sr *StockRating) BeforeCreate(tx *gorm.DB) error {
 func (sr *StockRating) BeforeUpdate(tx *gorm.DB) error {
 	sr.normalizeAction()
 	sr.normalizeRatings()
+	sr.normalizeTargets()
 	return nil
 }
 
 // Private normalization methods (domain logic)
 func (sr *StockRating) normalizeAction() {
 	sr.Action = strings.ToLower(strings.TrimSpace(sr.Action))
+	sr.ActionType = ParseActionType(sr.Action)
 }
 
 func (sr *StockRating) normalizeRatings() {
@@ -73,6 +93,23 @@ func (sr *StockRating) normalizeRatings() {
 	if sr.RatingTo != "" {
 		sr.RatingTo = strings.TrimSpace(sr.RatingTo)
 	}
+	sr.NormalizedFrom = NormalizeRating(sr.RatingFrom)
+	sr.NormalizedTo = NormalizeRating(sr.RatingTo)
+}
+
+// normalizeTargets parses both price targets; a rating has a single currency, so a TargetFrom quoted in another
+// currency than TargetTo is left without value
+func (sr *StockRating) normalizeTargets() {
+	sr.TargetFromValue, sr.TargetToValue, sr.TargetCurrency = nil, nil, ""
+
+	from, fromOK := ParseTargetPrice(sr.TargetFrom)
+	to, toOK := ParseTargetPrice(sr.TargetTo)
+	if toOK {
+		sr.TargetToValue, sr.TargetCurrency = &to.Value, to.Currency
+	}
+	if fromOK && (!toOK || from.Currency == to.Currency) {
+		sr.TargetFromValue, sr.TargetCurrency = &from.Value, from.Currency
+	}
 }
 
 // NewStockRating creates a new StockRating instance
@@ -104,25 +141,36 @@ func (sr *StockRating) IsValid() bool {
 
 // MarkAsProcessed marks the rating as processed (state change - domain logic)
 func (sr *StockRating) MarkAsProcessed() {
+	now := time.Now().UTC()
 	sr.IsProcessed = true
+	sr.ProcessedAt = &now
 }
 
 // MarkAsUnprocessed marks the rating as unprocessed (state change - domain logic)
 func (sr *StockRating) MarkAsUnprocessed() {
 	sr.IsProcessed = false
+	sr.ProcessedAt = nil
 }
 
 // Basic domain logic for action classification
 func (sr *StockRating) IsUpgrade() bool {
-	return strings.Contains(strings.ToLower(sr.Action), "upgrade")
+	return sr.GetActionType() == ActionUpgrade
 }
 
 func (sr *StockRating) IsDowngrade() bool {
-	return strings.Contains(strings.ToLower(sr.Action), "downgrade")
+	return sr.GetActionType() == ActionDowngrade
 }
 
 func (sr *StockRating) IsReiteration() bool {
-	return strings.Contains(strings.ToLower(sr.Action), "reiterat")
+	return sr.GetActionType() == ActionReiterate
+}
+
+// GetActionType returns the stored action type, parsing the action for ratings not saved yet or not backfilled
+func (sr *StockRating) GetActionType() ActionType {
+	if sr.ActionType != "" {
+		return sr.ActionType
+	}
+	return ParseActionType(sr.Action)
 }
 
 // HasRatingChange checks if the rating changed (simple comparison - domain logic)
@@ -135,6 +183,28 @@ func (sr *StockRating) HasTargetChange() bool {
 	return sr.TargetFrom != "" && sr.TargetTo != "" && sr.TargetFrom != sr.TargetTo
 }
 
+// Normalize applies the same normalization as the GORM hooks (lowercase action, trimmed ratings, parsed targets)
+func (sr *StockRating) Normalize() {
+	sr.normalizeAction()
+	sr.normalizeRatings()
+	sr.normalizeTargets()
+}
+
+// SameEvent reports whether both ratings identify the same event: company, brokerage and event time.
+// The database keeps microseconds, so event times less than a microsecond apart are the same event
+func (sr *StockRating) SameEvent(other *StockRating) bool {
+	gap := sr.EventTime.Sub(other.EventTime)
+	return sr.CompanyID == other.CompanyID && sr.BrokerageID == other.BrokerageID &&
+		gap > -time.Microsecond && gap < time.Microsecond
+}
+
+// HasSameData reports whether both ratings describe the same event with the same action, ratings and targets
+func (sr *StockRating) HasSameData(other *StockRating) bool {
+	return sr.SameEvent(other) && sr.Action == other.Action &&
+		sr.RatingFrom == other.RatingFrom && sr.RatingTo == other.RatingTo &&
+		sr.TargetFrom == other.TargetFrom && sr.TargetTo == other.TargetTo
+}
+
 // String returns a string representation of the StockRating
 func (sr *StockRating) String() string {
 	return sr.Action
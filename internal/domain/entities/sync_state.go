@@ -0,0 +1,52 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SyncState stores the ingestion watermark of an external data source
+type SyncState struct {
+	Source string `json:"source" gorm:"type:string;primary_key;not null" validate:"required,max=100"`
+
+	// Watermark: event_time más reciente ya ingerido desde la fuente
+	LastEventTime time.Time `json:"last_event_time" gorm:"not null"`
+	LastSyncedAt  time.Time `json:"last_synced_at" gorm:"not null"`
+	LastRunItems  int       `json:"last_run_items" gorm:"not null;default:0"`
+
+	// Auditoría - timestamps automáticos por la BD
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime;not null"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime;not null"`
+}
+
+// TableName specifies the table name for GORM
+func (SyncState) TableName() string {
+	return "sync_states"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a record
+func (s *SyncState) BeforeCreate(tx *gorm.DB) error {
+	s.Source = strings.TrimSpace(s.Source)
+	return nil
+}
+
+// NewSyncState creates a new SyncState for a data source
+func NewSyncState(source string, lastEventTime time.Time) *SyncState {
+	return &SyncState{
+		Source:        strings.TrimSpace(source),
+		LastEventTime: lastEventTime.UTC(),
+		LastSyncedAt:  time.Now().UTC(),
+	}
+}
+
+// HasWatermark checks if the source has been synced at least once
+func (s *SyncState) HasWatermark() bool {
+	return s != nil && !s.LastEventTime.IsZero()
+}
+
+// IsAlreadyIngested checks if an event is older than the watermark
+func (s *SyncState) IsAlreadyIngested(eventTime time.Time) bool {
+	return s.HasWatermark() && eventTime.Before(s.LastEventTime)
+}
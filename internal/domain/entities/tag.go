@@ -0,0 +1,69 @@
+package entities
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// tagSlugSeparators son los caracteres que NormalizeTagSlug convierte en guiones
+var tagSlugSeparators = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Tag is a thematic label (ai, ev, semiconductors...) used to build curated lists of companies
+type Tag struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primary_key;not null"`
+	Slug        string    `json:"slug" gorm:"type:string;not null;uniqueIndex:uq_tags_slug" validate:"required,max=50"`
+	Name        string    `json:"name" gorm:"type:string;not null" validate:"required,max=100"`
+	Description string    `json:"description,omitempty" gorm:"type:string;null"`
+
+	// Auditoría - timestamps automáticos por la BD
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime;not null"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime;not null"`
+}
+
+// TableName specifies the table name for GORM
+func (Tag) TableName() string {
+	return "tags"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a record
+func (t *Tag) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	t.Slug = NormalizeTagSlug(t.Slug)
+	return nil
+}
+
+// NewTag creates a new Tag; the slug is derived from the name when empty
+func NewTag(slug, name, description string) *Tag {
+	if slug == "" {
+		slug = name
+	}
+	return &Tag{
+		ID:          uuid.New(),
+		Slug:        NormalizeTagSlug(slug),
+		Name:        strings.TrimSpace(name),
+		Description: strings.TrimSpace(description),
+	}
+}
+
+// NormalizeTagSlug lowercases a tag and joins its words with hyphens ("Electric Vehicles" -> "electric-vehicles")
+func NormalizeTagSlug(value string) string {
+	return strings.Trim(tagSlugSeparators.ReplaceAllString(strings.ToLower(value), "-"), "-")
+}
+
+// CompanyTag assigns a tag to a company
+type CompanyTag struct {
+	CompanyID uuid.UUID `json:"company_id" gorm:"type:uuid;primaryKey"`
+	TagID     uuid.UUID `json:"tag_id" gorm:"type:uuid;primaryKey;index"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime;not null"`
+}
+
+// TableName specifies the table name for GORM
+func (CompanyTag) TableName() string {
+	return "company_tags"
+}
@@ -0,0 +1,127 @@
+package entities
+
+import (
+	"strconv"
+	"strings"
+)
+
+// DefaultTargetCurrency is assumed for price targets without currency symbol or code, as the ratings feed quotes in USD
+const DefaultTargetCurrency = "USD"
+
+// TargetPrice is a brokerage price target parsed from its text ("$150.00", "€12.5", "GBX 250", "250p")
+type TargetPrice struct {
+	Value    float64 // In major units of Currency (pence and cents are converted)
+	Currency string  // ISO 4217 code
+}
+
+// currencyUnit es un símbolo o código de moneda reconocido y su factor a la unidad principal
+type currencyUnit struct {
+	token    string
+	currency string
+	divisor  float64
+}
+
+// currencyPrefixes se prueban en orden, así "US$" o "HK$" se reconocen antes que "$"
+var currencyPrefixes = []currencyUnit{
+	{"US$", "USD", 1}, {"CA$", "CAD", 1}, {"AU$", "AUD", 1}, {"HK$", "HKD", 1}, {"NZ$", "NZD", 1},
+	{"C$", "CAD", 1}, {"A$", "AUD", 1}, {"R$", "BRL", 1}, {"S$", "SGD", 1}, {"$", "USD", 1},
+	{"€", "EUR", 1}, {"£", "GBP", 1}, {"¥", "JPY", 1}, {"₹", "INR", 1}, {"₩", "KRW", 1},
+}
+
+// currencySuffixes son unidades escritas tras el número ("250p", "99¢")
+var currencySuffixes = []currencyUnit{
+	{"P", "GBP", 100}, {"¢", "USD", 100},
+}
+
+// currencyCodeUnits son los códigos que no equivalen a su moneda principal
+var currencyCodeUnits = map[string]currencyUnit{
+	"GBX": {"GBX", "GBP", 100},
+	"GBP": {"GBP", "GBP", 1},
+}
+
+// ParseTargetPrice extracts the value and currency of a price target; ok is false for empty or unparseable text
+func ParseTargetPrice(raw string) (TargetPrice, bool) {
+	text := strings.ToUpper(strings.Join(strings.Fields(raw), ""))
+	if text == "" {
+		return TargetPrice{}, false
+	}
+
+	currency, divisor := "", 1.0
+	for _, unit := range currencyPrefixes {
+		if strings.HasPrefix(text, unit.token) {
+			currency, divisor = unit.currency, unit.divisor
+			text = strings.TrimPrefix(text, unit.token)
+			break
+		}
+	}
+	if currency == "" {
+		currency, divisor, text = parseCurrencyCode(text)
+	}
+	if currency == "" {
+		for _, unit := range currencySuffixes {
+			if strings.HasSuffix(text, unit.token) {
+				currency, divisor = unit.currency, unit.divisor
+				text = strings.TrimSuffix(text, unit.token)
+				break
+			}
+		}
+	}
+	if currency == "" {
+		currency = DefaultTargetCurrency
+	}
+
+	value, ok := parseTargetNumber(text)
+	if !ok {
+		return TargetPrice{}, false
+	}
+	return TargetPrice{Value: value / divisor, Currency: currency}, true
+}
+
+// parseCurrencyCode reconoce un código ISO de tres letras antes o después del número ("USD150", "150EUR")
+func parseCurrencyCode(text string) (string, float64, string) {
+	if len(text) <= 3 {
+		return "", 1, text
+	}
+	if code := text[:3]; isCurrencyCode(code) {
+		return codeUnit(code, text[3:])
+	}
+	if code := text[len(text)-3:]; isCurrencyCode(code) {
+		return codeUnit(code, text[:len(text)-3])
+	}
+	return "", 1, text
+}
+
+func codeUnit(code, rest string) (string, float64, string) {
+	if unit, ok := currencyCodeUnits[code]; ok {
+		return unit.currency, unit.divisor, rest
+	}
+	return code, 1, rest
+}
+
+func isCurrencyCode(code string) bool {
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// parseTargetNumber acepta separadores de miles ("1,234.50") y coma decimal ("12,50")
+func parseTargetNumber(text string) (float64, bool) {
+	if comma := strings.LastIndex(text, ","); comma >= 0 && !strings.Contains(text, ".") && len(text)-comma-1 != 3 {
+		text = text[:comma] + "." + text[comma+1:]
+	}
+	text = strings.ReplaceAll(text, ",", "")
+	for _, r := range text {
+		if (r < '0' || r > '9') && r != '.' {
+			return 0, false
+		}
+	}
+
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil || value <= 0 {
+		return 0, false
+	}
+	return value, true
+}
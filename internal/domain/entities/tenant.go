@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DefaultTenantPlan es el plan de los tenants creados sin plan
+const DefaultTenantPlan = "free"
+
+// Tenant is a customer of a shared deployment. Resources owned by users (API keys and the per-user
+// features built on them) carry its ID; market data is shared by every tenant
+type Tenant struct {
+	ID     uuid.UUID `json:"id" gorm:"type:uuid;primary_key;not null"`
+	Name   string    `json:"name" gorm:"type:string;not null" validate:"required,min=2,max=100"`
+	Slug   string    `json:"slug" gorm:"type:string;not null;uniqueIndex:uq_tenants_slug" validate:"required"` // Identificador estable en URLs y logs
+	Active bool      `json:"active" gorm:"not null;default:true"`
+	Plan   string    `json:"plan" gorm:"type:string;not null;default:'free'"` // Plan con las cuotas diarias (free o pro)
+
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime;not null"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime;not null"`
+}
+
+// TableName specifies the table name for GORM
+func (Tenant) TableName() string {
+	return "tenants"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a record
+func (t *Tenant) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	if t.Plan == "" {
+		t.Plan = DefaultTenantPlan
+	}
+	return nil
+}
+
+// TenantOwned is embedded by the entities that belong to a tenant; their repositories filter every
+// query by the tenant of the request context
+type TenantOwned struct {
+	TenantID uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;index"`
+}
@@ -0,0 +1,44 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TickerAlias maps a former ticker to the company that now trades under a different one (e.g. FB -> META).
+// Lookups by ticker fall back to aliases so data reported under the old symbol stays attached to the same company.
+type TickerAlias struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;not null"`
+	Ticker    string    `json:"ticker" gorm:"type:string;not null;uniqueIndex" validate:"required,max=10"` // Ticker anterior
+	CompanyID uuid.UUID `json:"company_id" gorm:"type:uuid;not null;index" validate:"required"`
+
+	// Auditoría - timestamps automáticos por la BD
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime;not null"` // Fecha del cambio de ticker
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime;not null"`
+}
+
+// TableName specifies the table name for GORM
+func (TickerAlias) TableName() string {
+	return "ticker_aliases"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a record
+func (a *TickerAlias) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	a.Ticker = strings.ToUpper(strings.TrimSpace(a.Ticker))
+	return nil
+}
+
+// NewTickerAlias creates a new TickerAlias pointing oldTicker to a company
+func NewTickerAlias(oldTicker string, companyID uuid.UUID) *TickerAlias {
+	return &TickerAlias{
+		ID:        uuid.New(),
+		Ticker:    strings.ToUpper(strings.TrimSpace(oldTicker)),
+		CompanyID: companyID,
+	}
+}
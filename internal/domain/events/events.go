@@ -0,0 +1,79 @@
+// Package events define los eventos de dominio que publican los caminos de escritura (ratings, cotizaciones,
+// companies) y el bus por el que se entregan, de modo que webhooks, invalidación de cache o alertas puedan
+// suscribirse sin acoplarse a quien escribe
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Type identifica un tipo de evento de dominio
+type Type string
+
+// Tipos de evento publicados
+const (
+	RatingCreated         Type = "rating.created"
+	RatingProcessed       Type = "rating.processed"
+	RatingBacklogExceeded Type = "rating.backlog_exceeded"
+	QuoteUpdated          Type = "quote.updated"
+	CompanyUpdated        Type = "company.updated"
+)
+
+// ErrBusClosed se devuelve al publicar o suscribirse en un bus ya cerrado
+var ErrBusClosed = errors.New("event bus is closed")
+
+// Event is a domain event: its payload is kept as JSON so every bus implementation delivers the same bytes
+type Event struct {
+	ID         uuid.UUID       `json:"id"`
+	Type       Type            `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+// New creates an event of the given type with the payload encoded as JSON
+func New(eventType Type, payload interface{}) (Event, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Event{}, fmt.Errorf("failed to encode %s payload: %w", eventType, err)
+	}
+
+	return Event{
+		ID:         uuid.New(),
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		Payload:    data,
+	}, nil
+}
+
+// Decode unmarshals the payload of the event into target
+func (e Event) Decode(target interface{}) error {
+	if err := json.Unmarshal(e.Payload, target); err != nil {
+		return fmt.Errorf("failed to decode %s payload: %w", e.Type, err)
+	}
+	return nil
+}
+
+// Handler procesa un evento entregado por el bus; un error solo se registra, el evento no se reintenta
+type Handler func(ctx context.Context, event Event) error
+
+// Publisher lo usan los caminos de escritura para publicar eventos
+type Publisher interface {
+	Publish(ctx context.Context, events ...Event) error
+}
+
+// Bus publica eventos y los entrega a los handlers suscritos a su tipo
+type Bus interface {
+	Publisher
+
+	// Subscribe registra un handler para un tipo de evento y devuelve la función que lo da de baja
+	Subscribe(eventType Type, handler Handler) (func(), error)
+
+	// Close deja de aceptar eventos y entrega los pendientes
+	Close() error
+}
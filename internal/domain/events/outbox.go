@@ -0,0 +1,37 @@
+package events
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+// TxPublisher lo implementan los publishers que guardan los eventos en la transacción del cambio que los produce
+// (outbox): si la transacción se revierte, los eventos tampoco existen
+type TxPublisher interface {
+	Publisher
+	PublishWithTx(ctx context.Context, tx *gorm.DB, events ...Event) error
+}
+
+// ToOutbox converts the event to its outbox row
+func (e Event) ToOutbox() *entities.OutboxEvent {
+	return &entities.OutboxEvent{
+		ID:            e.ID,
+		EventType:     string(e.Type),
+		Payload:       string(e.Payload),
+		OccurredAt:    e.OccurredAt,
+		NextAttemptAt: e.OccurredAt,
+	}
+}
+
+// FromOutbox rebuilds the event stored in an outbox row
+func FromOutbox(row *entities.OutboxEvent) Event {
+	return Event{
+		ID:         row.ID,
+		Type:       Type(row.EventType),
+		OccurredAt: row.OccurredAt,
+		Payload:    []byte(row.Payload),
+	}
+}
@@ -0,0 +1,136 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+// RatingCreatedPayload describes a stock rating inserted by a population run
+type RatingCreatedPayload struct {
+	RatingID    uuid.UUID `json:"rating_id"`
+	CompanyID   uuid.UUID `json:"company_id"`
+	BrokerageID uuid.UUID `json:"brokerage_id"`
+	Action      string    `json:"action"`
+	RatingFrom  string    `json:"rating_from"`
+	RatingTo    string    `json:"rating_to"`
+	TargetFrom  string    `json:"target_from"`
+	TargetTo    string    `json:"target_to"`
+	EventTime   time.Time `json:"event_time"`
+	Source      string    `json:"source"`
+}
+
+// RatingProcessedPayload describes a stock rating enriched by the rating processing job
+type RatingProcessedPayload struct {
+	RatingID       uuid.UUID            `json:"rating_id"`
+	CompanyID      uuid.UUID            `json:"company_id"`
+	BrokerageID    uuid.UUID            `json:"brokerage_id"`
+	ActionType     entities.ActionType  `json:"action_type"`
+	NormalizedFrom entities.RatingScale `json:"normalized_rating_from,omitempty"`
+	NormalizedTo   entities.RatingScale `json:"normalized_rating_to,omitempty"`
+	TargetToValue  *float64             `json:"target_to_value,omitempty"`
+	TargetCurrency string               `json:"target_currency,omitempty"`
+	EventTime      time.Time            `json:"event_time"`
+}
+
+// RatingBacklogExceededPayload describes a run of the rating processing job that found or left the backlog over
+// the threshold
+type RatingBacklogExceededPayload struct {
+	Unprocessed         int64      `json:"unprocessed"`
+	Previous            int64      `json:"previous"` // Backlog al empezar la ejecución
+	Threshold           int64      `json:"threshold"`
+	OldestUnprocessedAt *time.Time `json:"oldest_unprocessed_at,omitempty"`
+}
+
+// QuoteUpdatedPayload describes a quote stored after fetching it from the provider
+type QuoteUpdatedPayload struct {
+	Symbol          string    `json:"symbol"`
+	CompanyID       uuid.UUID `json:"company_id"`
+	CurrentPrice    float64   `json:"current_price"`
+	PreviousClose   float64   `json:"previous_close"`
+	PriceChange     float64   `json:"price_change"`
+	PriceChangePerc float64   `json:"price_change_perc"`
+	DataSource      string    `json:"data_source"`
+	MarketTimestamp time.Time `json:"market_timestamp"`
+}
+
+// CompanyUpdatedPayload describes the state of a company after an update
+type CompanyUpdatedPayload struct {
+	CompanyID uuid.UUID `json:"company_id"`
+	Ticker    string    `json:"ticker"`
+	Name      string    `json:"name"`
+	Sector    string    `json:"sector,omitempty"`
+	Exchange  string    `json:"exchange,omitempty"`
+	IsActive  bool      `json:"is_active"`
+	Version   int64     `json:"version"`
+}
+
+// NewRatingCreated creates the RatingCreated event of a stock rating
+func NewRatingCreated(rating *entities.StockRating) (Event, error) {
+	return New(RatingCreated, RatingCreatedPayload{
+		RatingID:    rating.ID,
+		CompanyID:   rating.CompanyID,
+		BrokerageID: rating.BrokerageID,
+		Action:      rating.Action,
+		RatingFrom:  rating.RatingFrom,
+		RatingTo:    rating.RatingTo,
+		TargetFrom:  rating.TargetFrom,
+		TargetTo:    rating.TargetTo,
+		EventTime:   rating.EventTime,
+		Source:      rating.Source,
+	})
+}
+
+// NewRatingProcessed creates the RatingProcessed event of a processed stock rating
+func NewRatingProcessed(rating *entities.StockRating) (Event, error) {
+	return New(RatingProcessed, RatingProcessedPayload{
+		RatingID:       rating.ID,
+		CompanyID:      rating.CompanyID,
+		BrokerageID:    rating.BrokerageID,
+		ActionType:     rating.ActionType,
+		NormalizedFrom: rating.NormalizedFrom,
+		NormalizedTo:   rating.NormalizedTo,
+		TargetToValue:  rating.TargetToValue,
+		TargetCurrency: rating.TargetCurrency,
+		EventTime:      rating.EventTime,
+	})
+}
+
+// NewRatingBacklogExceeded creates the RatingBacklogExceeded event of the rating processing backlog
+func NewRatingBacklogExceeded(unprocessed, previous, threshold int64, oldestUnprocessedAt *time.Time) (Event, error) {
+	return New(RatingBacklogExceeded, RatingBacklogExceededPayload{
+		Unprocessed:         unprocessed,
+		Previous:            previous,
+		Threshold:           threshold,
+		OldestUnprocessedAt: oldestUnprocessedAt,
+	})
+}
+
+// NewQuoteUpdated creates the QuoteUpdated event of a stored quote
+func NewQuoteUpdated(marketData *entities.MarketData) (Event, error) {
+	return New(QuoteUpdated, QuoteUpdatedPayload{
+		Symbol:          marketData.Symbol,
+		CompanyID:       marketData.CompanyID,
+		CurrentPrice:    marketData.CurrentPrice,
+		PreviousClose:   marketData.PreviousClose,
+		PriceChange:     marketData.PriceChange,
+		PriceChangePerc: marketData.PriceChangePerc,
+		DataSource:      marketData.DataSource,
+		MarketTimestamp: marketData.MarketTimestamp,
+	})
+}
+
+// NewCompanyUpdated creates the CompanyUpdated event of a company
+func NewCompanyUpdated(company *entities.Company) (Event, error) {
+	return New(CompanyUpdated, CompanyUpdatedPayload{
+		CompanyID: company.ID,
+		Ticker:    company.Ticker,
+		Name:      company.Name,
+		Sector:    company.Sector,
+		Exchange:  company.Exchange,
+		IsActive:  company.IsActive,
+		Version:   company.Version,
+	})
+}
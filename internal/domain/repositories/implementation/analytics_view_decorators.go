@@ -0,0 +1,81 @@
+package implementation
+
+import (
+	"context"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/services"
+)
+
+// viewStockRatingRepository serves the rating aggregations from the analytics views
+type viewStockRatingRepository struct {
+	interfaces.StockRatingRepository
+	views interfaces.AnalyticsViewRepository
+}
+
+// NewViewStockRatingRepository routes GetTopCompaniesByRatingCount and GetActionTypeDistribution
+// to the analytics views. Without views the repository is returned unchanged.
+func NewViewStockRatingRepository(repo interfaces.StockRatingRepository, views interfaces.AnalyticsViewRepository) interfaces.StockRatingRepository {
+	if views == nil {
+		return repo
+	}
+	return &viewStockRatingRepository{StockRatingRepository: repo, views: views}
+}
+
+func (r *viewStockRatingRepository) GetTopCompaniesByRatingCount(ctx context.Context, days int, limit int) ([]interfaces.CompanyRatingCount, error) {
+	return r.views.GetTopCompaniesByRatingCount(ctx, days, limit)
+}
+
+func (r *viewStockRatingRepository) GetActionTypeDistribution(ctx context.Context, days int) (map[string]int64, error) {
+	return r.views.GetActionTypeDistribution(ctx, days)
+}
+
+// viewCompanyRepository serves the sector distribution from the analytics views
+type viewCompanyRepository struct {
+	interfaces.CompanyRepository
+	views interfaces.AnalyticsViewRepository
+}
+
+// NewViewCompanyRepository routes GetSectorDistribution to the analytics views. Without views
+// the repository is returned unchanged.
+func NewViewCompanyRepository(repo interfaces.CompanyRepository, views interfaces.AnalyticsViewRepository) interfaces.CompanyRepository {
+	if views == nil {
+		return repo
+	}
+	return &viewCompanyRepository{CompanyRepository: repo, views: views}
+}
+
+func (r *viewCompanyRepository) GetSectorDistribution(ctx context.Context) (map[string]int64, error) {
+	return r.views.GetSectorDistribution(ctx)
+}
+
+// cachedAnalyticsViewRepository drops the cached rating and company queries after a refresh,
+// since they may have been computed from the previous contents of the views
+type cachedAnalyticsViewRepository struct {
+	interfaces.AnalyticsViewRepository
+	ratingQueries  *queryCache
+	companyQueries *queryCache
+}
+
+// NewCachedAnalyticsViewRepository wraps repo so a refresh invalidates the query cache. Without cache
+// service or with a non-positive TTL the repository is returned unchanged.
+func NewCachedAnalyticsViewRepository(repo interfaces.AnalyticsViewRepository, cache services.CacheService, ttl time.Duration) interfaces.AnalyticsViewRepository {
+	if repo == nil || cache == nil || ttl <= 0 {
+		return repo
+	}
+	return &cachedAnalyticsViewRepository{
+		AnalyticsViewRepository: repo,
+		ratingQueries:           newQueryCache(cache, "stock_rating", ttl),
+		companyQueries:          newQueryCache(cache, "company", ttl),
+	}
+}
+
+func (r *cachedAnalyticsViewRepository) Refresh(ctx context.Context) (*interfaces.AnalyticsRefreshResult, error) {
+	result, err := r.AnalyticsViewRepository.Refresh(ctx)
+	if err == nil {
+		r.ratingQueries.invalidate(ctx)
+		r.companyQueries.invalidate(ctx)
+	}
+	return result, err
+}
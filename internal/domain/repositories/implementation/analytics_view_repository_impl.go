@@ -0,0 +1,126 @@
+package implementation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+)
+
+// Vistas materializadas de analíticas (migración 000008)
+const (
+	companyDailyRatingsView = "analytics_company_daily_ratings"
+	actionDailyCountsView   = "analytics_action_daily_counts"
+	sectorDistributionView  = "analytics_sector_distribution"
+)
+
+// analyticsViews es el orden de refresco de las vistas
+var analyticsViews = []string{companyDailyRatingsView, actionDailyCountsView, sectorDistributionView}
+
+// analyticsViewRepositoryImpl implements the AnalyticsViewRepository interface using GORM
+type analyticsViewRepositoryImpl struct {
+	db     *gorm.DB
+	reader *gorm.DB
+}
+
+// NewAnalyticsViewRepository creates a new analytics view repository; reads go to reader
+func NewAnalyticsViewRepository(db, reader *gorm.DB) interfaces.AnalyticsViewRepository {
+	return &analyticsViewRepositoryImpl{
+		db:     db,
+		reader: reader,
+	}
+}
+
+// Refresh recomputes every analytics view. Las lecturas siguen viendo los datos anteriores mientras se refresca.
+func (r *analyticsViewRepositoryImpl) Refresh(ctx context.Context) (*interfaces.AnalyticsRefreshResult, error) {
+	start := time.Now()
+	for _, view := range analyticsViews {
+		if err := r.db.WithContext(ctx).Exec(fmt.Sprintf("REFRESH MATERIALIZED VIEW %s", view)).Error; err != nil {
+			return nil, fmt.Errorf("failed to refresh %s: %w", view, err)
+		}
+	}
+
+	return &interfaces.AnalyticsRefreshResult{
+		Views:       analyticsViews,
+		RefreshedAt: time.Now().UTC(),
+		Duration:    time.Since(start),
+	}, nil
+}
+
+// GetTopCompaniesByRatingCount returns companies with most ratings in the last N days
+func (r *analyticsViewRepositoryImpl) GetTopCompaniesByRatingCount(ctx context.Context, days int, limit int) ([]interfaces.CompanyRatingCount, error) {
+	var results []interfaces.CompanyRatingCount
+
+	query := r.reader.WithContext(ctx).
+		Table(companyDailyRatingsView).
+		Select("company_id, company_name, ticker, SUM(rating_count) as rating_count").
+		Where("day >= ?", analyticsCutoffDay(days)).
+		Group("company_id, company_name, ticker").
+		Order("rating_count DESC")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Scan(&results).Error; err != nil {
+		return nil, fmt.Errorf("failed to get top companies by rating count: %w", err)
+	}
+
+	return results, nil
+}
+
+// GetActionTypeDistribution returns count of each action type in the last N days
+func (r *analyticsViewRepositoryImpl) GetActionTypeDistribution(ctx context.Context, days int) (map[string]int64, error) {
+	var results []struct {
+		ActionType string
+		Count      int64
+	}
+
+	err := r.reader.WithContext(ctx).
+		Table(actionDailyCountsView).
+		Select("action_type, SUM(rating_count) as count").
+		Where("day >= ?", analyticsCutoffDay(days)).
+		Group("action_type").
+		Scan(&results).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get action type distribution: %w", err)
+	}
+
+	distribution := make(map[string]int64, len(results))
+	for _, result := range results {
+		distribution[result.ActionType] = result.Count
+	}
+
+	return distribution, nil
+}
+
+// GetSectorDistribution returns the count of active companies per sector
+func (r *analyticsViewRepositoryImpl) GetSectorDistribution(ctx context.Context) (map[string]int64, error) {
+	var results []struct {
+		Sector string
+		Count  int64
+	}
+
+	err := r.reader.WithContext(ctx).
+		Table(sectorDistributionView).
+		Select("sector, company_count as count").
+		Scan(&results).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sector distribution: %w", err)
+	}
+
+	distribution := make(map[string]int64, len(results))
+	for _, result := range results {
+		distribution[result.Sector] = result.Count
+	}
+
+	return distribution, nil
+}
+
+// analyticsCutoffDay devuelve el primer día (UTC) incluido en una ventana de N días
+func analyticsCutoffDay(days int) string {
+	return time.Now().UTC().AddDate(0, 0, -days).Format("2006-01-02")
+}
@@ -0,0 +1,86 @@
+package implementation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+)
+
+// anomalyRepositoryImpl implements the AnomalyRepository interface using GORM
+type anomalyRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewAnomalyRepository creates a new anomaly repository implementation
+func NewAnomalyRepository(db *gorm.DB) interfaces.AnomalyRepository {
+	return &anomalyRepositoryImpl{
+		db: db,
+	}
+}
+
+// Upsert stores anomalies; one already stored for the same company, type and day gets the new values
+func (r *anomalyRepositoryImpl) Upsert(ctx context.Context, anomalies []*entities.Anomaly) error {
+	if len(anomalies) == 0 {
+		return nil
+	}
+
+	err := r.db.WithContext(ctx).Omit("Company").Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "company_id"}, {Name: "type"}, {Name: "date"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"value":      gorm.Expr("excluded.value"),
+			"baseline":   gorm.Expr("excluded.baseline"),
+			"ratio":      gorm.Expr("excluded.ratio"),
+			"updated_at": time.Now().UTC(),
+		}),
+	}).Create(&anomalies).Error
+	if err != nil {
+		return fmt.Errorf("failed to store anomalies: %w", err)
+	}
+
+	return nil
+}
+
+// GetSince retrieves the anomalies dated on or after since, newest first and then by ratio.
+// Anomalies whose company was soft-deleted are dropped
+func (r *anomalyRepositoryImpl) GetSince(ctx context.Context, since time.Time, anomalyType string, limit int) ([]*entities.Anomaly, error) {
+	var anomalies []*entities.Anomaly
+
+	query := r.db.WithContext(ctx).
+		InnerJoins("Company").
+		Where("anomalies.date >= ?", since).
+		Order("anomalies.date DESC").
+		Order("anomalies.ratio DESC").
+		Limit(limit)
+	if anomalyType != "" {
+		query = query.Where("anomalies.type = ?", anomalyType)
+	}
+
+	if err := query.Find(&anomalies).Error; err != nil {
+		return nil, fmt.Errorf("failed to get anomalies since %s: %w", since.Format("2006-01-02"), err)
+	}
+
+	return anomalies, nil
+}
+
+// CountCreatedSince counts the anomalies first detected at or after since; as in GetSince, anomalies whose company was
+// soft-deleted are not counted
+func (r *anomalyRepositoryImpl) CountCreatedSince(ctx context.Context, since time.Time) (int64, error) {
+	var count int64
+
+	err := r.db.WithContext(ctx).
+		Model(&entities.Anomaly{}).
+		Joins("JOIN companies ON companies.id = anomalies.company_id AND companies.deleted_at IS NULL").
+		Where("anomalies.created_at >= ?", since).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count anomalies since %s: %w", since.Format(time.RFC3339), err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,127 @@
+package implementation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/tenancy"
+)
+
+// apiKeyRepositoryImpl implements the APIKeyRepository interface using GORM
+type apiKeyRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewAPIKeyRepository creates a new API key repository implementation
+func NewAPIKeyRepository(db *gorm.DB) interfaces.APIKeyRepository {
+	return &apiKeyRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create stores a key for the tenant of the context; a key for another tenant is rejected
+func (r *apiKeyRepositoryImpl) Create(ctx context.Context, key *entities.APIKey) error {
+	tenantID, err := tenancy.TenantID(ctx)
+	if err != nil {
+		return err
+	}
+	if key.TenantID == uuid.Nil {
+		key.TenantID = tenantID
+	}
+	if key.TenantID != tenantID {
+		return fmt.Errorf("cannot create an API key for tenant %s from tenant %s", key.TenantID, tenantID)
+	}
+
+	if err := r.db.WithContext(ctx).Create(key).Error; err != nil {
+		return translateDBError(err, "failed to create API key %s", key.Name)
+	}
+	return nil
+}
+
+// GetByID retrieves a key of the tenant of the context
+func (r *apiKeyRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*entities.APIKey, error) {
+	query, err := tenantScoped(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	var key entities.APIKey
+	if err := query.Where("id = ?", id).First(&key).Error; err != nil {
+		return nil, translateDBError(err, "API key %s", id)
+	}
+	return &key, nil
+}
+
+// List retrieves the keys of the tenant of the context, newest first
+func (r *apiKeyRepositoryImpl) List(ctx context.Context) ([]*entities.APIKey, error) {
+	query, err := tenantScoped(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []*entities.APIKey
+	if err := query.Order("created_at DESC").Find(&keys).Error; err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	return keys, nil
+}
+
+// Revoke revokes a key of the tenant of the context; revoking it again keeps the first revocation time
+func (r *apiKeyRepositoryImpl) Revoke(ctx context.Context, id uuid.UUID, at time.Time) error {
+	query, err := tenantScoped(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	result := query.Model(&entities.APIKey{}).Where("id = ?", id).
+		Update("revoked_at", gorm.Expr("COALESCE(revoked_at, ?)", at))
+	if result.Error != nil {
+		return fmt.Errorf("failed to revoke API key %s: %w", id, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.NotFound("API key %s", id)
+	}
+	return nil
+}
+
+// SetTimezone changes the time zone preference of a key of the tenant of the context
+func (r *apiKeyRepositoryImpl) SetTimezone(ctx context.Context, id uuid.UUID, timezone string) error {
+	query, err := tenantScoped(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	result := query.Model(&entities.APIKey{}).Where("id = ?", id).Update("timezone", timezone)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update API key %s: %w", id, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.NotFound("API key %s", id)
+	}
+	return nil
+}
+
+// GetByHash finds a key by the hash of its value, whatever its tenant
+func (r *apiKeyRepositoryImpl) GetByHash(ctx context.Context, keyHash string) (*entities.APIKey, error) {
+	var key entities.APIKey
+	if err := r.db.WithContext(ctx).Where("key_hash = ?", keyHash).First(&key).Error; err != nil {
+		return nil, translateDBError(err, "API key")
+	}
+	return &key, nil
+}
+
+// TouchLastUsed records when the key was last used
+func (r *apiKeyRepositoryImpl) TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	err := r.db.WithContext(ctx).Model(&entities.APIKey{}).Where("id = ?", id).Update("last_used_at", at).Error
+	if err != nil {
+		return fmt.Errorf("failed to update API key %s: %w", id, err)
+	}
+	return nil
+}
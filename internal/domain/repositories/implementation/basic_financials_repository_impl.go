@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
 	"github.com/MayaCris/stock-info-app/internal/domain/entities"
 	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
 )
@@ -45,7 +46,7 @@ func (r *basicFinancialsRepositoryImpl) GetByID(ctx context.Context, id uuid.UUI
 	var financials entities.BasicFinancials
 	if err := r.db.WithContext(ctx).First(&financials, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("basic financials not found with id %s", id.String())
+			return nil, domainerrors.NotFound("basic financials not found with id %s", id.String())
 		}
 		return nil, fmt.Errorf("failed to get basic financials by id: %w", err)
 	}
@@ -60,7 +61,7 @@ func (r *basicFinancialsRepositoryImpl) GetBySymbol(ctx context.Context, symbol
 		Order("created_at DESC").
 		First(&financials).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("basic financials not found for symbol %s", symbol)
+			return nil, domainerrors.NotFound("basic financials not found for symbol %s", symbol)
 		}
 		return nil, fmt.Errorf("failed to get basic financials by symbol: %w", err)
 	}
@@ -74,7 +75,7 @@ func (r *basicFinancialsRepositoryImpl) GetBySymbolAndPeriod(ctx context.Context
 		Where("symbol = ? AND period = ? AND fiscal_year = ?", symbol, period, fiscalYear).
 		First(&financials).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("basic financials not found for symbol %s, period %s, year %d", symbol, period, fiscalYear)
+			return nil, domainerrors.NotFound("basic financials not found for symbol %s, period %s, year %d", symbol, period, fiscalYear)
 		}
 		return nil, fmt.Errorf("failed to get basic financials by symbol, period and year: %w", err)
 	}
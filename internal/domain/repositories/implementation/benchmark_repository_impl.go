@@ -0,0 +1,220 @@
+package implementation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+)
+
+// benchmarkRepositoryImpl implements the BenchmarkRepository interface using GORM
+type benchmarkRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewBenchmarkRepository creates a new benchmark repository implementation
+func NewBenchmarkRepository(db *gorm.DB) interfaces.BenchmarkRepository {
+	return &benchmarkRepositoryImpl{
+		db: db,
+	}
+}
+
+// GetAll returns every benchmark ordered by symbol
+func (r *benchmarkRepositoryImpl) GetAll(ctx context.Context) ([]*entities.Benchmark, error) {
+	var benchmarks []*entities.Benchmark
+
+	if err := r.db.WithContext(ctx).Order("symbol").Find(&benchmarks).Error; err != nil {
+		return nil, fmt.Errorf("failed to get benchmarks: %w", err)
+	}
+
+	return benchmarks, nil
+}
+
+// GetBySymbol returns the benchmark with that symbol or, failing that, tracked through that source symbol
+func (r *benchmarkRepositoryImpl) GetBySymbol(ctx context.Context, symbol string) (*entities.Benchmark, error) {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+
+	var benchmark entities.Benchmark
+	err := r.db.WithContext(ctx).
+		Where("symbol = ? OR source_symbol = ?", symbol, symbol).
+		Order(clause.OrderBy{Expression: clause.Expr{SQL: "symbol = ? DESC", Vars: []interface{}{symbol}}}).
+		First(&benchmark).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.NotFound("benchmark %s not found", symbol)
+		}
+		return nil, fmt.Errorf("failed to get benchmark %s: %w", symbol, err)
+	}
+
+	return &benchmark, nil
+}
+
+// Create stores a new benchmark with its constituents in one transaction; a symbol already in use is a duplicate
+func (r *benchmarkRepositoryImpl) Create(ctx context.Context, benchmark *entities.Benchmark, constituents []*entities.BenchmarkConstituent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(benchmark).Error; err != nil {
+			return translateDBError(err, "failed to create benchmark %s", benchmark.Symbol)
+		}
+		for _, constituent := range constituents {
+			constituent.BenchmarkID = benchmark.ID
+			if err := tx.Create(constituent).Error; err != nil {
+				return fmt.Errorf("failed to create constituent %s of benchmark %s: %w", constituent.Symbol, benchmark.Symbol, err)
+			}
+		}
+		return nil
+	})
+}
+
+// Delete removes a benchmark; its values and constituents are removed by the cascade
+func (r *benchmarkRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&entities.Benchmark{}, "id = ?", id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete benchmark %s: %w", id, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.NotFound("benchmark %s not found", id)
+	}
+
+	return nil
+}
+
+// MarkSynced records when the benchmark was last ingested from the provider
+func (r *benchmarkRepositoryImpl) MarkSynced(ctx context.Context, id uuid.UUID, at time.Time) error {
+	err := r.db.WithContext(ctx).Model(&entities.Benchmark{}).
+		Where("id = ?", id).
+		Update("last_synced_at", at).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark benchmark %s as synced: %w", id, err)
+	}
+
+	return nil
+}
+
+// UpsertValues stores daily values; one already stored for the same benchmark and date gets the new prices, so
+// the adjusted closes restated after a dividend replace the previous ones
+func (r *benchmarkRepositoryImpl) UpsertValues(ctx context.Context, values []*entities.BenchmarkValue) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "benchmark_id"}, {Name: "date"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"open":           gorm.Expr("excluded.open"),
+			"high":           gorm.Expr("excluded.high"),
+			"low":            gorm.Expr("excluded.low"),
+			"close":          gorm.Expr("excluded.close"),
+			"adjusted_close": gorm.Expr("excluded.adjusted_close"),
+			"volume":         gorm.Expr("excluded.volume"),
+			"source":         gorm.Expr("excluded.source"),
+			"updated_at":     time.Now().UTC(),
+		}),
+	}).CreateInBatches(&values, 500).Error
+	if err != nil {
+		return fmt.Errorf("failed to store benchmark values: %w", err)
+	}
+
+	return nil
+}
+
+// GetValues returns the daily values of a benchmark between from and to (inclusive), oldest first
+func (r *benchmarkRepositoryImpl) GetValues(ctx context.Context, benchmarkID uuid.UUID, from, to time.Time) ([]*entities.BenchmarkValue, error) {
+	var values []*entities.BenchmarkValue
+
+	err := r.db.WithContext(ctx).
+		Where("benchmark_id = ? AND date BETWEEN ? AND ?", benchmarkID, from, to).
+		Order("date ASC").
+		Find(&values).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get values of benchmark %s: %w", benchmarkID, err)
+	}
+
+	return values, nil
+}
+
+// SyncConstituents replaces the current composition of a benchmark as of asOf in one transaction. Members
+// missing from constituents get removed_at = asOf, new symbols open a membership from asOf and the weight and
+// company of the members that stay are updated
+func (r *benchmarkRepositoryImpl) SyncConstituents(ctx context.Context, benchmarkID uuid.UUID, constituents []*entities.BenchmarkConstituent, asOf time.Time) (added, removed int, err error) {
+	asOf = asOf.UTC().Truncate(24 * time.Hour)
+
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current []*entities.BenchmarkConstituent
+		if err := tx.Where("benchmark_id = ? AND removed_at IS NULL", benchmarkID).Find(&current).Error; err != nil {
+			return err
+		}
+		bySymbol := make(map[string]*entities.BenchmarkConstituent, len(current))
+		for _, member := range current {
+			bySymbol[member.Symbol] = member
+		}
+
+		seen := make(map[string]bool, len(constituents))
+		for _, constituent := range constituents {
+			symbol := strings.ToUpper(constituent.Symbol)
+			if symbol == "" || seen[symbol] {
+				continue
+			}
+			seen[symbol] = true
+
+			if member, ok := bySymbol[symbol]; ok {
+				err := tx.Model(member).Updates(map[string]interface{}{
+					"company_id": constituent.CompanyID,
+					"name":       constituent.Name,
+					"weight":     constituent.Weight,
+				}).Error
+				if err != nil {
+					return err
+				}
+				continue
+			}
+
+			constituent.BenchmarkID = benchmarkID
+			constituent.AddedAt = asOf
+			constituent.RemovedAt = nil
+			if err := tx.Create(constituent).Error; err != nil {
+				return err
+			}
+			added++
+		}
+
+		for symbol, member := range bySymbol {
+			if seen[symbol] {
+				continue
+			}
+			if err := tx.Model(member).Update("removed_at", asOf).Error; err != nil {
+				return err
+			}
+			removed++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to sync constituents of benchmark %s: %w", benchmarkID, err)
+	}
+
+	return added, removed, nil
+}
+
+// GetConstituents returns the members of a benchmark on date, by weight descending (members without weight last)
+func (r *benchmarkRepositoryImpl) GetConstituents(ctx context.Context, benchmarkID uuid.UUID, date time.Time) ([]*entities.BenchmarkConstituent, error) {
+	var constituents []*entities.BenchmarkConstituent
+
+	err := r.db.WithContext(ctx).
+		Where("benchmark_id = ? AND added_at <= ? AND (removed_at IS NULL OR removed_at > ?)", benchmarkID, date, date).
+		Order("weight DESC NULLS LAST, symbol ASC").
+		Find(&constituents).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get constituents of benchmark %s: %w", benchmarkID, err)
+	}
+
+	return constituents, nil
+}
@@ -4,30 +4,44 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
 	"github.com/MayaCris/stock-info-app/internal/domain/entities"
 	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
 )
 
 // brokerageRepositoryImpl implements the BrokerageRepository interface using GORM
 type brokerageRepositoryImpl struct {
-	db *gorm.DB
+	db     *gorm.DB
+	reader *gorm.DB // Listados, conteos y analíticas; puede apuntar a réplicas de lectura
 }
 
 // NewBrokerageRepository creates a new brokerage repository implementation
 func NewBrokerageRepository(db *gorm.DB) interfaces.BrokerageRepository {
 	return &brokerageRepositoryImpl{
-		db: db,
+		db:     db,
+		reader: db,
+	}
+}
+
+// NewBrokerageRepositoryWithReader creates a brokerage repository whose read-only list and analytics
+// queries go to reader (e.g. read replicas) while writes and lookups by key stay on db
+func NewBrokerageRepositoryWithReader(db, reader *gorm.DB) interfaces.BrokerageRepository {
+	return &brokerageRepositoryImpl{
+		db:     db,
+		reader: reader,
 	}
 }
 
 // NewTransactionalBrokerageRepository creates a new transactional brokerage repository implementation
 func NewTransactionalBrokerageRepository(db *gorm.DB) interfaces.TransactionalBrokerageRepository {
 	return &brokerageRepositoryImpl{
-		db: db,
+		db:     db,
+		reader: db,
 	}
 }
 
@@ -38,7 +52,7 @@ func NewTransactionalBrokerageRepository(db *gorm.DB) interfaces.TransactionalBr
 // Create creates a new brokerage in the database
 func (r *brokerageRepositoryImpl) Create(ctx context.Context, brokerage *entities.Brokerage) error {
 	if err := r.db.WithContext(ctx).Create(brokerage).Error; err != nil {
-		return fmt.Errorf("failed to create brokerage: %w", err)
+		return translateDBError(err, "failed to create brokerage %s", brokerage.Name)
 	}
 	return nil
 }
@@ -70,7 +84,7 @@ func (r *brokerageRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*e
 	err := r.db.WithContext(ctx).Where("id = ?", id).First(&brokerage).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("brokerage with id %s not found", id)
+			return nil, domainerrors.NotFound("brokerage with id %s not found", id)
 		}
 		return nil, fmt.Errorf("failed to get brokerage by id: %w", err)
 	}
@@ -85,7 +99,7 @@ func (r *brokerageRepositoryImpl) GetByName(ctx context.Context, name string) (*
 	err := r.db.WithContext(ctx).Where("name = ?", name).First(&brokerage).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("brokerage with name %s not found", name)
+			return nil, domainerrors.NotFound("brokerage with name %s not found", name)
 		}
 		return nil, fmt.Errorf("failed to get brokerage by name: %w", err)
 	}
@@ -97,7 +111,7 @@ func (r *brokerageRepositoryImpl) GetByName(ctx context.Context, name string) (*
 func (r *brokerageRepositoryImpl) GetAll(ctx context.Context) ([]*entities.Brokerage, error) {
 	var brokerages []*entities.Brokerage
 
-	err := r.db.WithContext(ctx).Find(&brokerages).Error
+	err := r.reader.WithContext(ctx).Find(&brokerages).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all brokerages: %w", err)
 	}
@@ -109,7 +123,7 @@ func (r *brokerageRepositoryImpl) GetAll(ctx context.Context) ([]*entities.Broke
 func (r *brokerageRepositoryImpl) GetAllActive(ctx context.Context) ([]*entities.Brokerage, error) {
 	var brokerages []*entities.Brokerage
 
-	err := r.db.WithContext(ctx).Where("is_active = ?", true).Find(&brokerages).Error
+	err := r.reader.WithContext(ctx).Where("is_active = ?", true).Find(&brokerages).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get active brokerages: %w", err)
 	}
@@ -117,22 +131,38 @@ func (r *brokerageRepositoryImpl) GetAllActive(ctx context.Context) ([]*entities
 	return brokerages, nil
 }
 
-// ========================================
-// UPDATE OPERATIONS
-// ========================================
+// List retrieves a page of the brokerages matching filter, ordered by sort (name by default)
+func (r *brokerageRepositoryImpl) List(ctx context.Context, filter interfaces.BrokerageListFilter, sort []interfaces.SortField, limit, offset int) ([]*entities.Brokerage, int64, error) {
+	query := r.reader.WithContext(ctx).Model(&entities.Brokerage{})
+	if name := strings.TrimSpace(filter.Name); name != "" {
+		query = query.Where("LOWER(name) LIKE ?", "%"+strings.ToLower(name)+"%")
+	}
+	if filter.IsActive != nil {
+		query = query.Where("is_active = ?", *filter.IsActive)
+	}
 
-// Update updates an existing brokerage
-func (r *brokerageRepositoryImpl) Update(ctx context.Context, brokerage *entities.Brokerage) error {
-	result := r.db.WithContext(ctx).Save(brokerage)
-	if result.Error != nil {
-		return fmt.Errorf("failed to update brokerage: %w", result.Error)
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count brokerages: %w", err)
 	}
 
-	if result.RowsAffected == 0 {
-		return fmt.Errorf("brokerage with id %s not found for update", brokerage.ID)
+	var brokerages []*entities.Brokerage
+	err := applySort(query, sort, interfaces.SortField{Column: "name"}).
+		Limit(limit).Offset(offset).Find(&brokerages).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list brokerages: %w", err)
 	}
 
-	return nil
+	return brokerages, total, nil
+}
+
+// ========================================
+// UPDATE OPERATIONS
+// ========================================
+
+// Update updates an existing brokerage if its version has not changed since it was read
+func (r *brokerageRepositoryImpl) Update(ctx context.Context, brokerage *entities.Brokerage) error {
+	return updateWithVersion(r.db.WithContext(ctx), brokerage, brokerage.ID, &brokerage.Version, "brokerage")
 }
 
 // Activate activates a brokerage by ID
@@ -143,7 +173,7 @@ func (r *brokerageRepositoryImpl) Activate(ctx context.Context, id uuid.UUID) er
 	}
 
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("brokerage with id %s not found for activation", id)
+		return domainerrors.NotFound("brokerage with id %s not found for activation", id)
 	}
 
 	return nil
@@ -157,7 +187,7 @@ func (r *brokerageRepositoryImpl) Deactivate(ctx context.Context, id uuid.UUID)
 	}
 
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("brokerage with id %s not found for deactivation", id)
+		return domainerrors.NotFound("brokerage with id %s not found for deactivation", id)
 	}
 
 	return nil
@@ -175,7 +205,7 @@ func (r *brokerageRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) erro
 	}
 
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("brokerage with id %s not found for deletion", id)
+		return domainerrors.NotFound("brokerage with id %s not found for deletion", id)
 	}
 
 	return nil
@@ -189,7 +219,7 @@ func (r *brokerageRepositoryImpl) HardDelete(ctx context.Context, id uuid.UUID)
 	}
 
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("brokerage with id %s not found for hard deletion", id)
+		return domainerrors.NotFound("brokerage with id %s not found for hard deletion", id)
 	}
 
 	return nil
@@ -215,7 +245,7 @@ func (r *brokerageRepositoryImpl) Exists(ctx context.Context, name string) (bool
 func (r *brokerageRepositoryImpl) Count(ctx context.Context) (int64, error) {
 	var count int64
 
-	err := r.db.WithContext(ctx).Model(&entities.Brokerage{}).Count(&count).Error
+	err := r.reader.WithContext(ctx).Model(&entities.Brokerage{}).Count(&count).Error
 	if err != nil {
 		return 0, fmt.Errorf("failed to count brokerages: %w", err)
 	}
@@ -227,7 +257,7 @@ func (r *brokerageRepositoryImpl) Count(ctx context.Context) (int64, error) {
 func (r *brokerageRepositoryImpl) CountActive(ctx context.Context) (int64, error) {
 	var count int64
 
-	err := r.db.WithContext(ctx).Model(&entities.Brokerage{}).Where("is_active = ?", true).Count(&count).Error
+	err := r.reader.WithContext(ctx).Model(&entities.Brokerage{}).Where("is_active = ?", true).Count(&count).Error
 	if err != nil {
 		return 0, fmt.Errorf("failed to count active brokerages: %w", err)
 	}
@@ -248,9 +278,7 @@ func (r *brokerageRepositoryImpl) FindOrCreate(ctx context.Context, name string)
 	}
 
 	// If not found, create a new one
-	if errors.Is(err, gorm.ErrRecordNotFound) ||
-		(err != nil && fmt.Sprintf("%v", err) == fmt.Sprintf("brokerage with name %s not found", name)) {
-
+	if domainerrors.IsNotFound(err) {
 		newBrokerage := entities.NewBrokerage(name)
 		if err := r.Create(ctx, newBrokerage); err != nil {
 			return nil, fmt.Errorf("failed to create new brokerage %s: %w", name, err)
@@ -290,9 +318,7 @@ func (r *brokerageRepositoryImpl) FindOrCreateWithDetails(ctx context.Context, n
 	}
 
 	// If not found, create a new one with details
-	if errors.Is(err, gorm.ErrRecordNotFound) ||
-		(err != nil && fmt.Sprintf("%v", err) == fmt.Sprintf("brokerage with name %s not found", name)) {
-
+	if domainerrors.IsNotFound(err) {
 		newBrokerage := entities.NewBrokerageWithDetails(name, website, country)
 		if err := r.Create(ctx, newBrokerage); err != nil {
 			return nil, fmt.Errorf("failed to create new brokerage with details %s: %w", name, err)
@@ -316,7 +342,7 @@ func (r *brokerageRepositoryImpl) GetWithRatings(ctx context.Context, id uuid.UU
 	err := r.db.WithContext(ctx).Preload("StockRatings").Where("id = ?", id).First(&brokerage).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("brokerage with id %s not found", id)
+			return nil, domainerrors.NotFound("brokerage with id %s not found", id)
 		}
 		return nil, fmt.Errorf("failed to get brokerage with ratings: %w", err)
 	}
@@ -328,7 +354,7 @@ func (r *brokerageRepositoryImpl) GetWithRatings(ctx context.Context, id uuid.UU
 func (r *brokerageRepositoryImpl) GetByRatingCount(ctx context.Context, limit int) ([]*entities.Brokerage, error) {
 	var brokerages []*entities.Brokerage
 
-	query := r.db.WithContext(ctx).
+	query := r.reader.WithContext(ctx).
 		Select("brokerages.*, COUNT(stock_ratings.id) as rating_count").
 		Joins("LEFT JOIN stock_ratings ON brokerages.id = stock_ratings.brokerage_id").
 		Group("brokerages.id").
@@ -379,7 +405,7 @@ func (r *brokerageRepositoryImpl) GetByNameWithTx(ctx context.Context, tx *gorm.
 	err := tx.WithContext(ctx).Where("name = ?", name).First(&brokerage).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("brokerage with name %s not found", name)
+			return nil, domainerrors.NotFound("brokerage with name %s not found", name)
 		}
 		return nil, fmt.Errorf("failed to get brokerage by name with transaction: %w", err)
 	}
@@ -0,0 +1,159 @@
+package implementation
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/services"
+)
+
+// cachedCompanyRepository caches the read-heavy company queries and invalidates them on every write.
+// El resto de métodos se delegan tal cual al repositorio envuelto.
+type cachedCompanyRepository struct {
+	interfaces.CompanyRepository
+	queries *queryCache
+}
+
+// NewCachedCompanyRepository wraps repo with query caching. Without cache service or with a
+// non-positive TTL the repository is returned unchanged.
+func NewCachedCompanyRepository(repo interfaces.CompanyRepository, cache services.CacheService, ttl time.Duration) interfaces.CompanyRepository {
+	if cache == nil || ttl <= 0 {
+		return repo
+	}
+	return &cachedCompanyRepository{
+		CompanyRepository: repo,
+		queries:           newQueryCache(cache, "company", ttl),
+	}
+}
+
+// ========================================
+// CACHED READS
+// ========================================
+
+func (r *cachedCompanyRepository) GetByTicker(ctx context.Context, ticker string) (*entities.Company, error) {
+	return cachedQuery(ctx, r.queries, r.queries.key("by_ticker", ticker), func() (*entities.Company, error) {
+		return r.CompanyRepository.GetByTicker(ctx, ticker)
+	})
+}
+
+func (r *cachedCompanyRepository) GetBySector(ctx context.Context, sector string) ([]*entities.Company, error) {
+	return cachedQuery(ctx, r.queries, r.queries.key("by_sector", sector), func() ([]*entities.Company, error) {
+		return r.CompanyRepository.GetBySector(ctx, sector)
+	})
+}
+
+func (r *cachedCompanyRepository) GetByExchange(ctx context.Context, exchange string) ([]*entities.Company, error) {
+	return cachedQuery(ctx, r.queries, r.queries.key("by_exchange", exchange), func() ([]*entities.Company, error) {
+		return r.CompanyRepository.GetByExchange(ctx, exchange)
+	})
+}
+
+func (r *cachedCompanyRepository) SuggestByPrefix(ctx context.Context, prefix string, limit int) ([]interfaces.CompanySuggestion, error) {
+	return cachedQuery(ctx, r.queries, r.queries.key("suggest", strings.ToUpper(strings.TrimSpace(prefix)), limit), func() ([]interfaces.CompanySuggestion, error) {
+		return r.CompanyRepository.SuggestByPrefix(ctx, prefix, limit)
+	})
+}
+
+func (r *cachedCompanyRepository) GetSectorDistribution(ctx context.Context) (map[string]int64, error) {
+	return cachedQuery(ctx, r.queries, r.queries.key("sector_distribution"), func() (map[string]int64, error) {
+		return r.CompanyRepository.GetSectorDistribution(ctx)
+	})
+}
+
+func (r *cachedCompanyRepository) GetExchangeDistribution(ctx context.Context) (map[string]int64, error) {
+	return cachedQuery(ctx, r.queries, r.queries.key("exchange_distribution"), func() (map[string]int64, error) {
+		return r.CompanyRepository.GetExchangeDistribution(ctx)
+	})
+}
+
+func (r *cachedCompanyRepository) GetMarketCapStats(ctx context.Context) (map[string]float64, error) {
+	return cachedQuery(ctx, r.queries, r.queries.key("market_cap_stats"), func() (map[string]float64, error) {
+		return r.CompanyRepository.GetMarketCapStats(ctx)
+	})
+}
+
+func (r *cachedCompanyRepository) GetMarketCapStatsByGroup(ctx context.Context) ([]interfaces.MarketCapGroupStats, error) {
+	return cachedQuery(ctx, r.queries, r.queries.key("market_cap_stats_by_group"), func() ([]interfaces.MarketCapGroupStats, error) {
+		return r.CompanyRepository.GetMarketCapStatsByGroup(ctx)
+	})
+}
+
+// ========================================
+// WRITES (INVALIDATE)
+// ========================================
+
+// invalidateOnSuccess drops the cached company queries when the write succeeded
+func (r *cachedCompanyRepository) invalidateOnSuccess(ctx context.Context, err error) error {
+	if err == nil {
+		r.queries.invalidate(ctx)
+	}
+	return err
+}
+
+func (r *cachedCompanyRepository) Create(ctx context.Context, company *entities.Company) error {
+	return r.invalidateOnSuccess(ctx, r.CompanyRepository.Create(ctx, company))
+}
+
+func (r *cachedCompanyRepository) CreateMany(ctx context.Context, companies []*entities.Company) error {
+	return r.invalidateOnSuccess(ctx, r.CompanyRepository.CreateMany(ctx, companies))
+}
+
+func (r *cachedCompanyRepository) Update(ctx context.Context, company *entities.Company, hooks ...interfaces.WriteHook[entities.Company]) error {
+	return r.invalidateOnSuccess(ctx, r.CompanyRepository.Update(ctx, company, hooks...))
+}
+
+func (r *cachedCompanyRepository) UpdateMarketCap(ctx context.Context, ticker string, marketCap float64) error {
+	return r.invalidateOnSuccess(ctx, r.CompanyRepository.UpdateMarketCap(ctx, ticker, marketCap))
+}
+
+func (r *cachedCompanyRepository) Activate(ctx context.Context, id uuid.UUID, hooks ...interfaces.WriteHook[entities.Company]) error {
+	return r.invalidateOnSuccess(ctx, r.CompanyRepository.Activate(ctx, id, hooks...))
+}
+
+func (r *cachedCompanyRepository) Deactivate(ctx context.Context, id uuid.UUID, hooks ...interfaces.WriteHook[entities.Company]) error {
+	return r.invalidateOnSuccess(ctx, r.CompanyRepository.Deactivate(ctx, id, hooks...))
+}
+
+func (r *cachedCompanyRepository) RemapTicker(ctx context.Context, id uuid.UUID, newTicker string, hooks ...interfaces.WriteHook[entities.Company]) (*entities.Company, error) {
+	company, err := r.CompanyRepository.RemapTicker(ctx, id, newTicker, hooks...)
+	return company, r.invalidateOnSuccess(ctx, err)
+}
+
+func (r *cachedCompanyRepository) MergeInto(ctx context.Context, duplicateID, targetID uuid.UUID, dryRun bool) (*interfaces.CompanyMergeResult, error) {
+	result, err := r.CompanyRepository.MergeInto(ctx, duplicateID, targetID, dryRun)
+	if dryRun {
+		return result, err
+	}
+	return result, r.invalidateOnSuccess(ctx, err)
+}
+
+func (r *cachedCompanyRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.invalidateOnSuccess(ctx, r.CompanyRepository.Delete(ctx, id))
+}
+
+func (r *cachedCompanyRepository) HardDelete(ctx context.Context, id uuid.UUID) error {
+	return r.invalidateOnSuccess(ctx, r.CompanyRepository.HardDelete(ctx, id))
+}
+
+func (r *cachedCompanyRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return r.invalidateOnSuccess(ctx, r.CompanyRepository.Restore(ctx, id))
+}
+
+func (r *cachedCompanyRepository) UpsertMany(ctx context.Context, companies []*entities.Company) error {
+	return r.invalidateOnSuccess(ctx, r.CompanyRepository.UpsertMany(ctx, companies))
+}
+
+func (r *cachedCompanyRepository) FindOrCreateByTicker(ctx context.Context, ticker, name string) (*entities.Company, error) {
+	company, err := r.CompanyRepository.FindOrCreateByTicker(ctx, ticker, name)
+	return company, r.invalidateOnSuccess(ctx, err)
+}
+
+func (r *cachedCompanyRepository) FindOrCreateWithDetails(ctx context.Context, ticker, name, sector, exchange string, marketCap float64) (*entities.Company, error) {
+	company, err := r.CompanyRepository.FindOrCreateWithDetails(ctx, ticker, name, sector, exchange, marketCap)
+	return company, r.invalidateOnSuccess(ctx, err)
+}
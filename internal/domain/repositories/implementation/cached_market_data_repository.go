@@ -0,0 +1,97 @@
+package implementation
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/services"
+)
+
+// cachedMarketDataRepository caches the latest market data per symbol. Writes for a symbol only
+// drop that symbol; writes without a known symbol drop every cached entry.
+type cachedMarketDataRepository struct {
+	interfaces.MarketDataRepository
+	queries *queryCache
+}
+
+// NewCachedMarketDataRepository wraps repo with query caching. Without cache service or with a
+// non-positive TTL the repository is returned unchanged.
+func NewCachedMarketDataRepository(repo interfaces.MarketDataRepository, cache services.CacheService, ttl time.Duration) interfaces.MarketDataRepository {
+	if cache == nil || ttl <= 0 {
+		return repo
+	}
+	return &cachedMarketDataRepository{
+		MarketDataRepository: repo,
+		queries:              newQueryCache(cache, "market_data", ttl),
+	}
+}
+
+// ========================================
+// CACHED READS
+// ========================================
+
+func (r *cachedMarketDataRepository) GetBySymbol(ctx context.Context, symbol string) (*entities.MarketData, error) {
+	return cachedQuery(ctx, r.queries, r.symbolKey(symbol), func() (*entities.MarketData, error) {
+		return r.MarketDataRepository.GetBySymbol(ctx, symbol)
+	})
+}
+
+func (r *cachedMarketDataRepository) symbolKey(symbol string) string {
+	return r.queries.key("by_symbol", symbol)
+}
+
+// ========================================
+// WRITES (INVALIDATE)
+// ========================================
+
+// invalidateSymbolOnSuccess drops the cached entry of the symbol when the write succeeded
+func (r *cachedMarketDataRepository) invalidateSymbolOnSuccess(ctx context.Context, symbol string, err error) error {
+	if err == nil {
+		r.queries.invalidateKey(ctx, r.symbolKey(symbol))
+	}
+	return err
+}
+
+// invalidateOnSuccess drops every cached market data entry when the write succeeded
+func (r *cachedMarketDataRepository) invalidateOnSuccess(ctx context.Context, err error) error {
+	if err == nil {
+		r.queries.invalidate(ctx)
+	}
+	return err
+}
+
+func (r *cachedMarketDataRepository) Create(ctx context.Context, marketData *entities.MarketData) error {
+	return r.invalidateSymbolOnSuccess(ctx, marketData.Symbol, r.MarketDataRepository.Create(ctx, marketData))
+}
+
+func (r *cachedMarketDataRepository) Update(ctx context.Context, marketData *entities.MarketData) error {
+	return r.invalidateSymbolOnSuccess(ctx, marketData.Symbol, r.MarketDataRepository.Update(ctx, marketData))
+}
+
+func (r *cachedMarketDataRepository) UpsertBySymbol(ctx context.Context, marketData *entities.MarketData, hooks ...interfaces.WriteHook[entities.MarketData]) error {
+	return r.invalidateSymbolOnSuccess(ctx, marketData.Symbol, r.MarketDataRepository.UpsertBySymbol(ctx, marketData, hooks...))
+}
+
+func (r *cachedMarketDataRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.invalidateOnSuccess(ctx, r.MarketDataRepository.Delete(ctx, id))
+}
+
+func (r *cachedMarketDataRepository) BulkCreate(ctx context.Context, marketData []*entities.MarketData) error {
+	return r.invalidateOnSuccess(ctx, r.MarketDataRepository.BulkCreate(ctx, marketData))
+}
+
+func (r *cachedMarketDataRepository) BulkUpdate(ctx context.Context, marketData []*entities.MarketData) error {
+	return r.invalidateOnSuccess(ctx, r.MarketDataRepository.BulkUpdate(ctx, marketData))
+}
+
+func (r *cachedMarketDataRepository) CleanupOldData(ctx context.Context, olderThan time.Time) (int64, error) {
+	removed, err := r.MarketDataRepository.CleanupOldData(ctx, olderThan)
+	if err == nil && removed > 0 {
+		r.queries.invalidate(ctx)
+	}
+	return removed, err
+}
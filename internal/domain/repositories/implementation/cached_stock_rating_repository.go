@@ -0,0 +1,133 @@
+package implementation
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/services"
+)
+
+// cachedStockRatingRepository caches the rating analytics aggregations and invalidates them on every write
+type cachedStockRatingRepository struct {
+	interfaces.StockRatingRepository
+	queries *queryCache
+}
+
+// NewCachedStockRatingRepository wraps repo with query caching. Without cache service or with a
+// non-positive TTL the repository is returned unchanged.
+func NewCachedStockRatingRepository(repo interfaces.StockRatingRepository, cache services.CacheService, ttl time.Duration) interfaces.StockRatingRepository {
+	if cache == nil || ttl <= 0 {
+		return repo
+	}
+	return &cachedStockRatingRepository{
+		StockRatingRepository: repo,
+		queries:               newQueryCache(cache, "stock_rating", ttl),
+	}
+}
+
+// ========================================
+// CACHED READS
+// ========================================
+
+func (r *cachedStockRatingRepository) GetActionTypeDistribution(ctx context.Context, days int) (map[string]int64, error) {
+	return cachedQuery(ctx, r.queries, r.queries.key("action_type_distribution", days), func() (map[string]int64, error) {
+		return r.StockRatingRepository.GetActionTypeDistribution(ctx, days)
+	})
+}
+
+func (r *cachedStockRatingRepository) GetTopCompaniesByRatingCount(ctx context.Context, days int, limit int) ([]interfaces.CompanyRatingCount, error) {
+	return cachedQuery(ctx, r.queries, r.queries.key("top_companies", days, limit), func() ([]interfaces.CompanyRatingCount, error) {
+		return r.StockRatingRepository.GetTopCompaniesByRatingCount(ctx, days, limit)
+	})
+}
+
+func (r *cachedStockRatingRepository) GetTopBrokeragesByRatingCount(ctx context.Context, days int, limit int) ([]interfaces.BrokerageRatingCount, error) {
+	return cachedQuery(ctx, r.queries, r.queries.key("top_brokerages", days, limit), func() ([]interfaces.BrokerageRatingCount, error) {
+		return r.StockRatingRepository.GetTopBrokeragesByRatingCount(ctx, days, limit)
+	})
+}
+
+func (r *cachedStockRatingRepository) GetRatingTrend(ctx context.Context, companyID uuid.UUID, days int) ([]interfaces.DailyRatingCount, error) {
+	return cachedQuery(ctx, r.queries, r.queries.key("rating_trend", companyID, days), func() ([]interfaces.DailyRatingCount, error) {
+		return r.StockRatingRepository.GetRatingTrend(ctx, companyID, days)
+	})
+}
+
+// ========================================
+// WRITES (INVALIDATE)
+// ========================================
+
+// invalidateOnSuccess drops the cached rating queries when the write succeeded
+func (r *cachedStockRatingRepository) invalidateOnSuccess(ctx context.Context, err error) error {
+	if err == nil {
+		r.queries.invalidate(ctx)
+	}
+	return err
+}
+
+func (r *cachedStockRatingRepository) Create(ctx context.Context, rating *entities.StockRating) error {
+	return r.invalidateOnSuccess(ctx, r.StockRatingRepository.Create(ctx, rating))
+}
+
+func (r *cachedStockRatingRepository) BackfillActionTypes(ctx context.Context, all bool, batchSize int) (int64, error) {
+	updated, err := r.StockRatingRepository.BackfillActionTypes(ctx, all, batchSize)
+	if updated > 0 {
+		r.queries.invalidate(ctx)
+	}
+	return updated, err
+}
+
+func (r *cachedStockRatingRepository) CreateMany(ctx context.Context, ratings []*entities.StockRating) error {
+	return r.invalidateOnSuccess(ctx, r.StockRatingRepository.CreateMany(ctx, ratings))
+}
+
+func (r *cachedStockRatingRepository) Update(ctx context.Context, rating *entities.StockRating) error {
+	return r.invalidateOnSuccess(ctx, r.StockRatingRepository.Update(ctx, rating))
+}
+
+func (r *cachedStockRatingRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.invalidateOnSuccess(ctx, r.StockRatingRepository.Delete(ctx, id))
+}
+
+func (r *cachedStockRatingRepository) HardDelete(ctx context.Context, id uuid.UUID) error {
+	return r.invalidateOnSuccess(ctx, r.StockRatingRepository.HardDelete(ctx, id))
+}
+
+func (r *cachedStockRatingRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return r.invalidateOnSuccess(ctx, r.StockRatingRepository.Restore(ctx, id))
+}
+
+func (r *cachedStockRatingRepository) FindOrCreateRating(ctx context.Context, companyID, brokerageID uuid.UUID, eventTime time.Time,
+	action, ratingFrom, ratingTo, targetFrom, targetTo string, rawData []byte) (*entities.StockRating, error) {
+	rating, err := r.StockRatingRepository.FindOrCreateRating(ctx, companyID, brokerageID, eventTime,
+		action, ratingFrom, ratingTo, targetFrom, targetTo, rawData)
+	return rating, r.invalidateOnSuccess(ctx, err)
+}
+
+func (r *cachedStockRatingRepository) UpsertMany(ctx context.Context, ratings []*entities.StockRating) error {
+	return r.invalidateOnSuccess(ctx, r.StockRatingRepository.UpsertMany(ctx, ratings))
+}
+
+func (r *cachedStockRatingRepository) BulkInsertIgnoreDuplicates(ctx context.Context, ratings []*entities.StockRating) (int, error) {
+	inserted, err := r.StockRatingRepository.BulkInsertIgnoreDuplicates(ctx, ratings)
+	if err == nil && inserted > 0 {
+		r.queries.invalidate(ctx)
+	}
+	return inserted, err
+}
+
+func (r *cachedStockRatingRepository) ReplaceRating(ctx context.Context, originalID uuid.UUID, rating *entities.StockRating) error {
+	return r.invalidateOnSuccess(ctx, r.StockRatingRepository.ReplaceRating(ctx, originalID, rating))
+}
+
+func (r *cachedStockRatingRepository) RemoveDuplicates(ctx context.Context, keepNewest bool) (int, error) {
+	removed, err := r.StockRatingRepository.RemoveDuplicates(ctx, keepNewest)
+	if err == nil && removed > 0 {
+		r.queries.invalidate(ctx)
+	}
+	return removed, err
+}
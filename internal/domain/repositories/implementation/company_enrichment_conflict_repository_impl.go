@@ -0,0 +1,126 @@
+package implementation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+)
+
+// companyEnrichmentConflictRepositoryImpl implements the CompanyEnrichmentConflictRepository interface using GORM
+type companyEnrichmentConflictRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewCompanyEnrichmentConflictRepository creates a new enrichment conflict repository implementation
+func NewCompanyEnrichmentConflictRepository(db *gorm.DB) interfaces.CompanyEnrichmentConflictRepository {
+	return &companyEnrichmentConflictRepositoryImpl{
+		db: db,
+	}
+}
+
+// ========================================
+// CREATE OPERATIONS
+// ========================================
+
+// Record stores conflicts; when the same mismatch was already flagged it bumps its occurrences.
+// A conflict that was reviewed keeps its status: it only reappears if one of the values changes.
+func (r *companyEnrichmentConflictRepositoryImpl) Record(ctx context.Context, conflicts []*entities.CompanyEnrichmentConflict) error {
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "fingerprint"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"occurrences":  gorm.Expr("company_enrichment_conflicts.occurrences + 1"),
+			"last_seen_at": gorm.Expr("excluded.last_seen_at"),
+			"updated_at":   time.Now().UTC(),
+		}),
+	}).Create(&conflicts).Error
+	if err != nil {
+		return fmt.Errorf("failed to record enrichment conflicts: %w", err)
+	}
+
+	return nil
+}
+
+// ========================================
+// READ OPERATIONS
+// ========================================
+
+// GetByID retrieves a conflict by its ID
+func (r *companyEnrichmentConflictRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*entities.CompanyEnrichmentConflict, error) {
+	var conflict entities.CompanyEnrichmentConflict
+
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&conflict).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.NotFound("enrichment conflict with id %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get enrichment conflict by id: %w", err)
+	}
+
+	return &conflict, nil
+}
+
+// List retrieves conflicts ordered by last occurrence, optionally filtered by status
+func (r *companyEnrichmentConflictRepositoryImpl) List(ctx context.Context, status entities.EnrichmentConflictStatus, limit, offset int) ([]*entities.CompanyEnrichmentConflict, error) {
+	var conflicts []*entities.CompanyEnrichmentConflict
+
+	query := r.db.WithContext(ctx).Order("last_seen_at DESC").Limit(limit).Offset(offset)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Find(&conflicts).Error; err != nil {
+		return nil, fmt.Errorf("failed to list enrichment conflicts: %w", err)
+	}
+
+	return conflicts, nil
+}
+
+// Count returns the number of conflicts, optionally filtered by status
+func (r *companyEnrichmentConflictRepositoryImpl) Count(ctx context.Context, status entities.EnrichmentConflictStatus) (int64, error) {
+	var count int64
+
+	query := r.db.WithContext(ctx).Model(&entities.CompanyEnrichmentConflict{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count enrichment conflicts: %w", err)
+	}
+
+	return count, nil
+}
+
+// ========================================
+// REVIEW OPERATIONS
+// ========================================
+
+// MarkReviewed closes a conflict as resolved or dismissed
+func (r *companyEnrichmentConflictRepositoryImpl) MarkReviewed(ctx context.Context, id uuid.UUID, status entities.EnrichmentConflictStatus) error {
+	result := r.db.WithContext(ctx).Model(&entities.CompanyEnrichmentConflict{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":      status,
+		"resolved_at": time.Now().UTC(),
+	})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update enrichment conflict: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return domainerrors.NotFound("enrichment conflict with id %s not found", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package implementation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+)
+
+// companyPeerRepositoryImpl implements the CompanyPeerRepository interface using GORM
+type companyPeerRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewCompanyPeerRepository creates a new company peer repository implementation
+func NewCompanyPeerRepository(db *gorm.DB) interfaces.CompanyPeerRepository {
+	return &companyPeerRepositoryImpl{
+		db: db,
+	}
+}
+
+// GetByCompanyID retrieves the peers of a company ordered by rank. Peers whose company was
+// soft-deleted are dropped
+func (r *companyPeerRepositoryImpl) GetByCompanyID(ctx context.Context, companyID uuid.UUID) ([]*entities.CompanyPeer, error) {
+	var peers []*entities.CompanyPeer
+
+	err := r.db.WithContext(ctx).
+		InnerJoins("Peer").
+		Where("company_peers.company_id = ?", companyID).
+		Order("company_peers.rank ASC").
+		Find(&peers).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get peers for company %s: %w", companyID, err)
+	}
+
+	return peers, nil
+}
+
+// ReplaceForCompany deletes the current peers of a company and inserts the given ones
+func (r *companyPeerRepositoryImpl) ReplaceForCompany(ctx context.Context, companyID uuid.UUID, peers []*entities.CompanyPeer) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("company_id = ?", companyID).Delete(&entities.CompanyPeer{}).Error; err != nil {
+			return fmt.Errorf("failed to delete peers for company %s: %w", companyID, err)
+		}
+		if len(peers) == 0 {
+			return nil
+		}
+		if err := tx.Omit("Peer").Create(&peers).Error; err != nil {
+			return fmt.Errorf("failed to create peers for company %s: %w", companyID, err)
+		}
+		return nil
+	})
+}
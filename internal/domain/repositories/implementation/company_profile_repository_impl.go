@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
 	"github.com/MayaCris/stock-info-app/internal/domain/entities"
 	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
 )
@@ -45,7 +46,7 @@ func (r *companyProfileRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID
 	var profile entities.CompanyProfile
 	if err := r.db.WithContext(ctx).First(&profile, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("company profile not found with id %s", id.String())
+			return nil, domainerrors.NotFound("company profile not found with id %s", id.String())
 		}
 		return nil, fmt.Errorf("failed to get company profile by id: %w", err)
 	}
@@ -59,7 +60,7 @@ func (r *companyProfileRepositoryImpl) GetBySymbol(ctx context.Context, symbol s
 		Where("symbol = ?", symbol).
 		First(&profile).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("company profile not found for symbol %s", symbol)
+			return nil, domainerrors.NotFound("company profile not found for symbol %s", symbol)
 		}
 		return nil, fmt.Errorf("failed to get company profile by symbol: %w", err)
 	}
@@ -0,0 +1,104 @@
+package implementation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+)
+
+// companyRelationshipRepositoryImpl implements the CompanyRelationshipRepository interface using GORM
+type companyRelationshipRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewCompanyRelationshipRepository creates a new company relationship repository implementation
+func NewCompanyRelationshipRepository(db *gorm.DB) interfaces.CompanyRelationshipRepository {
+	return &companyRelationshipRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create stores a new relationship
+func (r *companyRelationshipRepositoryImpl) Create(ctx context.Context, relationship *entities.CompanyRelationship) error {
+	err := r.db.WithContext(ctx).Omit("Company", "RelatedCompany").Create(relationship).Error
+	return translateDBError(err, "failed to create %s relationship %s -> %s",
+		relationship.Type, relationship.CompanyID, relationship.RelatedCompanyID)
+}
+
+// GetByID retrieves a relationship with both companies. Relationships of a soft-deleted company are not found
+func (r *companyRelationshipRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*entities.CompanyRelationship, error) {
+	var relationship entities.CompanyRelationship
+
+	err := r.db.WithContext(ctx).
+		InnerJoins("Company").
+		InnerJoins("RelatedCompany").
+		Where("company_relationships.id = ?", id).
+		First(&relationship).Error
+	if err != nil {
+		return nil, translateDBError(err, "relationship %s not found", id)
+	}
+
+	return &relationship, nil
+}
+
+// Update stores the type and note of a relationship
+func (r *companyRelationshipRepositoryImpl) Update(ctx context.Context, relationship *entities.CompanyRelationship) error {
+	result := r.db.WithContext(ctx).
+		Model(&entities.CompanyRelationship{}).
+		Where("id = ?", relationship.ID).
+		Updates(map[string]interface{}{
+			"type": relationship.Type,
+			"note": relationship.Note,
+		})
+	if result.Error != nil {
+		return translateDBError(result.Error, "failed to update relationship %s", relationship.ID)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.NotFound("relationship %s not found", relationship.ID)
+	}
+
+	return nil
+}
+
+// Delete removes a relationship
+func (r *companyRelationshipRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&entities.CompanyRelationship{}, "id = ?", id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete relationship %s: %w", id, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.NotFound("relationship %s not found", id)
+	}
+
+	return nil
+}
+
+// GetByCompanyIDs retrieves the relationships touching any of the companies, oldest first. Relationships of a
+// soft-deleted company are dropped
+func (r *companyRelationshipRepositoryImpl) GetByCompanyIDs(ctx context.Context, companyIDs []uuid.UUID, types []string) ([]*entities.CompanyRelationship, error) {
+	var relationships []*entities.CompanyRelationship
+	if len(companyIDs) == 0 {
+		return relationships, nil
+	}
+
+	query := r.db.WithContext(ctx).
+		InnerJoins("Company").
+		InnerJoins("RelatedCompany").
+		Where("(company_relationships.company_id IN ? OR company_relationships.related_company_id IN ?)", companyIDs, companyIDs)
+	if len(types) > 0 {
+		query = query.Where("company_relationships.type IN ?", types)
+	}
+
+	err := query.Order("company_relationships.created_at ASC, company_relationships.id ASC").Find(&relationships).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get relationships for %d companies: %w", len(companyIDs), err)
+	}
+
+	return relationships, nil
+}
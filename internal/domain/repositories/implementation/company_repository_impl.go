@@ -5,30 +5,45 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
 	"github.com/MayaCris/stock-info-app/internal/domain/entities"
 	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
 )
 
 // companyRepositoryImpl implements the CompanyRepository interface using GORM
 type companyRepositoryImpl struct {
-	db *gorm.DB
+	db     *gorm.DB
+	reader *gorm.DB // Listados, conteos y analíticas; puede apuntar a réplicas de lectura
 }
 
 // NewCompanyRepository creates a new company repository implementation
 func NewCompanyRepository(db *gorm.DB) interfaces.CompanyRepository {
 	return &companyRepositoryImpl{
-		db: db,
+		db:     db,
+		reader: db,
+	}
+}
+
+// NewCompanyRepositoryWithReader creates a company repository whose read-only list and analytics
+// queries go to reader (e.g. read replicas) while writes and lookups by key stay on db
+func NewCompanyRepositoryWithReader(db, reader *gorm.DB) interfaces.CompanyRepository {
+	return &companyRepositoryImpl{
+		db:     db,
+		reader: reader,
 	}
 }
 
 // NewTransactionalCompanyRepository creates a new transactional company repository implementation
 func NewTransactionalCompanyRepository(db *gorm.DB) interfaces.TransactionalCompanyRepository {
 	return &companyRepositoryImpl{
-		db: db,
+		db:     db,
+		reader: db,
 	}
 }
 
@@ -39,7 +54,7 @@ func NewTransactionalCompanyRepository(db *gorm.DB) interfaces.TransactionalComp
 // Create creates a new company in the database
 func (r *companyRepositoryImpl) Create(ctx context.Context, company *entities.Company) error {
 	if err := r.db.WithContext(ctx).Create(company).Error; err != nil {
-		return fmt.Errorf("failed to create company: %w", err)
+		return translateDBError(err, "failed to create company %s", company.Ticker)
 	}
 	return nil
 }
@@ -71,7 +86,7 @@ func (r *companyRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*ent
 	err := r.db.WithContext(ctx).Where("id = ?", id).First(&company).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("company with id %s not found", id)
+			return nil, domainerrors.NotFound("company with id %s not found", id)
 		}
 		return nil, fmt.Errorf("failed to get company by id: %w", err)
 	}
@@ -81,16 +96,31 @@ func (r *companyRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*ent
 
 // GetByTicker retrieves a company by its ticker symbol (CRITICAL for API sync)
 func (r *companyRepositoryImpl) GetByTicker(ctx context.Context, ticker string) (*entities.Company, error) {
-	var company entities.Company
-
-	err := r.db.WithContext(ctx).Where("ticker = ?", strings.ToUpper(ticker)).First(&company).Error
+	company, err := findCompanyByTicker(r.db.WithContext(ctx), ticker)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("company with ticker %s not found", ticker)
+			return nil, domainerrors.NotFound("company with ticker %s not found", ticker)
 		}
 		return nil, fmt.Errorf("failed to get company by ticker: %w", err)
 	}
 
+	return company, nil
+}
+
+// findCompanyByTicker busca por ticker actual y, si no existe, por un ticker anterior (alias).
+// El ticker actual tiene prioridad: un símbolo reutilizado por otra company no se resuelve al alias.
+func findCompanyByTicker(db *gorm.DB, ticker string) (*entities.Company, error) {
+	ticker = strings.ToUpper(ticker)
+
+	var company entities.Company
+	err := db.Where("ticker = ?", ticker).First(&company).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = db.Where("id = (SELECT company_id FROM ticker_aliases WHERE ticker = ?)", ticker).First(&company).Error
+	}
+	if err != nil {
+		return nil, err
+	}
+
 	return &company, nil
 }
 
@@ -101,7 +131,7 @@ func (r *companyRepositoryImpl) GetByName(ctx context.Context, name string) (*en
 	err := r.db.WithContext(ctx).Where("name = ?", name).First(&company).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("company with name %s not found", name)
+			return nil, domainerrors.NotFound("company with name %s not found", name)
 		}
 		return nil, fmt.Errorf("failed to get company by name: %w", err)
 	}
@@ -113,7 +143,7 @@ func (r *companyRepositoryImpl) GetByName(ctx context.Context, name string) (*en
 func (r *companyRepositoryImpl) GetAll(ctx context.Context) ([]*entities.Company, error) {
 	var companies []*entities.Company
 
-	err := r.db.WithContext(ctx).Find(&companies).Error
+	err := r.reader.WithContext(ctx).Find(&companies).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all companies: %w", err)
 	}
@@ -125,7 +155,7 @@ func (r *companyRepositoryImpl) GetAll(ctx context.Context) ([]*entities.Company
 func (r *companyRepositoryImpl) GetAllActive(ctx context.Context) ([]*entities.Company, error) {
 	var companies []*entities.Company
 
-	err := r.db.WithContext(ctx).Where("is_active = ?", true).Find(&companies).Error
+	err := r.reader.WithContext(ctx).Where("is_active = ?", true).Find(&companies).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get active companies: %w", err)
 	}
@@ -133,22 +163,59 @@ func (r *companyRepositoryImpl) GetAllActive(ctx context.Context) ([]*entities.C
 	return companies, nil
 }
 
-// ========================================
-// UPDATE OPERATIONS
-// ========================================
+// List retrieves a page of the companies matching filter, ordered by sort (ticker by default)
+func (r *companyRepositoryImpl) List(ctx context.Context, filter interfaces.CompanyListFilter, sort []interfaces.SortField, limit, offset int) ([]*entities.Company, int64, error) {
+	query := r.reader.WithContext(ctx).Model(&entities.Company{})
+	if filter.Sector != "" {
+		query = query.Where("sector = ?", filter.Sector)
+	}
+	if filter.Exchange != "" {
+		query = query.Where("exchange = ?", filter.Exchange)
+	}
+	if filter.IsActive != nil {
+		query = query.Where("is_active = ?", *filter.IsActive)
+	}
+	if len(filter.Tags) > 0 {
+		tagged := r.reader.WithContext(ctx).
+			Table("company_tags").
+			Select("company_tags.company_id").
+			Joins("JOIN tags ON tags.id = company_tags.tag_id").
+			Where("tags.slug IN ?", filter.Tags).
+			Group("company_tags.company_id").
+			Having("COUNT(*) = ?", len(filter.Tags))
+		query = query.Where("id IN (?)", tagged)
+	}
 
-// Update updates an existing company
-func (r *companyRepositoryImpl) Update(ctx context.Context, company *entities.Company) error {
-	result := r.db.WithContext(ctx).Save(company)
-	if result.Error != nil {
-		return fmt.Errorf("failed to update company: %w", result.Error)
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count companies: %w", err)
 	}
 
-	if result.RowsAffected == 0 {
-		return fmt.Errorf("company with id %s not found for update", company.ID)
+	var companies []*entities.Company
+	err := applySort(query, sort, interfaces.SortField{Column: "ticker"}).
+		Limit(limit).Offset(offset).Find(&companies).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list companies: %w", err)
 	}
 
-	return nil
+	return companies, total, nil
+}
+
+// ========================================
+// UPDATE OPERATIONS
+// ========================================
+
+// Update updates an existing company if its version has not changed since it was read
+func (r *companyRepositoryImpl) Update(ctx context.Context, company *entities.Company, hooks ...interfaces.WriteHook[entities.Company]) error {
+	version := company.Version
+	err := withWriteHooks(r.db.WithContext(ctx), hooks, func(tx *gorm.DB) (*entities.Company, error) {
+		return company, updateWithVersion(tx, company, company.ID, &company.Version, "company")
+	})
+	if err != nil {
+		// Un hook fallido revierte también el incremento de versión
+		company.Version = version
+	}
+	return err
 }
 
 // UpdateMarketCap updates only the market cap of a company by ticker
@@ -162,38 +229,244 @@ func (r *companyRepositoryImpl) UpdateMarketCap(ctx context.Context, ticker stri
 	}
 
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("company with ticker %s not found for market cap update", ticker)
+		return domainerrors.NotFound("company with ticker %s not found for market cap update", ticker)
 	}
 
 	return nil
 }
 
 // Activate activates a company by ID
-func (r *companyRepositoryImpl) Activate(ctx context.Context, id uuid.UUID) error {
-	result := r.db.WithContext(ctx).Model(&entities.Company{}).Where("id = ?", id).Update("is_active", true)
-	if result.Error != nil {
-		return fmt.Errorf("failed to activate company: %w", result.Error)
+func (r *companyRepositoryImpl) Activate(ctx context.Context, id uuid.UUID, hooks ...interfaces.WriteHook[entities.Company]) error {
+	return r.setActive(ctx, id, true, "activate", "activation", hooks)
+}
+
+// Deactivate deactivates a company by ID
+func (r *companyRepositoryImpl) Deactivate(ctx context.Context, id uuid.UUID, hooks ...interfaces.WriteHook[entities.Company]) error {
+	return r.setActive(ctx, id, false, "deactivate", "deactivation", hooks)
+}
+
+// setActive cambia is_active; con hooks relee la company en la transacción para pasarles su estado actualizado
+func (r *companyRepositoryImpl) setActive(ctx context.Context, id uuid.UUID, active bool, action, operation string, hooks []interfaces.WriteHook[entities.Company]) error {
+	return withWriteHooks(r.db.WithContext(ctx), hooks, func(tx *gorm.DB) (*entities.Company, error) {
+		result := tx.Model(&entities.Company{}).Where("id = ?", id).Update("is_active", active)
+		if result.Error != nil {
+			return nil, fmt.Errorf("failed to %s company: %w", action, result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			return nil, domainerrors.NotFound("company with id %s not found for %s", id, operation)
+		}
+
+		if len(hooks) == 0 {
+			return nil, nil
+		}
+		var company entities.Company
+		if err := tx.Where("id = ?", id).First(&company).Error; err != nil {
+			return nil, fmt.Errorf("failed to reload company after %s: %w", operation, err)
+		}
+		return &company, nil
+	})
+}
+
+// ========================================
+// TICKER CHANGE OPERATIONS
+// ========================================
+
+// RemapTicker renames the company ticker in place, so its ratings and market data stay attached,
+// and records the previous ticker as an alias that lookups keep resolving
+func (r *companyRepositoryImpl) RemapTicker(ctx context.Context, id uuid.UUID, newTicker string, hooks ...interfaces.WriteHook[entities.Company]) (*entities.Company, error) {
+	newTicker = strings.ToUpper(strings.TrimSpace(newTicker))
+
+	var company entities.Company
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ?", id).First(&company).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domainerrors.NotFound("company with id %s not found", id)
+			}
+			return fmt.Errorf("failed to get company for ticker remap: %w", err)
+		}
+
+		if company.Ticker == newTicker {
+			return runWriteHooks(tx, &company, hooks)
+		}
+
+		// La restricción única de ticker incluye las companies eliminadas (soft delete)
+		var taken int64
+		if err := tx.Unscoped().Model(&entities.Company{}).Where("ticker = ? AND id <> ?", newTicker, id).Count(&taken).Error; err != nil {
+			return fmt.Errorf("failed to check ticker availability: %w", err)
+		}
+		if taken > 0 {
+			return domainerrors.Duplicate(nil, "ticker %s is already used by another company", newTicker)
+		}
+
+		// El nuevo ticker deja de ser alias (p. ej. al revertir un cambio) y el anterior pasa a serlo
+		if err := tx.Where("ticker = ?", newTicker).Delete(&entities.TickerAlias{}).Error; err != nil {
+			return fmt.Errorf("failed to remove ticker alias: %w", err)
+		}
+		alias := entities.NewTickerAlias(company.Ticker, company.ID)
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "ticker"}},
+			DoUpdates: clause.AssignmentColumns([]string{"company_id", "updated_at"}),
+		}).Create(alias).Error; err != nil {
+			return translateDBError(err, "failed to create ticker alias")
+		}
+
+		result := tx.Model(&entities.Company{}).
+			Where("id = ? AND version = ?", id, company.Version).
+			Updates(map[string]interface{}{
+				"ticker":  newTicker,
+				"version": gorm.Expr("version + 1"),
+			})
+		if result.Error != nil {
+			return translateDBError(result.Error, "failed to remap company ticker")
+		}
+		if result.RowsAffected == 0 {
+			return domainerrors.VersionMismatch("company with id %s was modified concurrently (expected version %d)", id, company.Version)
+		}
+
+		company.Ticker = newTicker
+		company.Version++
+		return runWriteHooks(tx, &company, hooks)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if result.RowsAffected == 0 {
-		return fmt.Errorf("company with id %s not found for activation", id)
+	return &company, nil
+}
+
+// GetTickerAliases retrieves the former tickers of a company, most recent change first
+func (r *companyRepositoryImpl) GetTickerAliases(ctx context.Context, id uuid.UUID) ([]*entities.TickerAlias, error) {
+	var aliases []*entities.TickerAlias
+
+	if err := r.db.WithContext(ctx).Where("company_id = ?", id).Order("created_at DESC").Find(&aliases).Error; err != nil {
+		return nil, fmt.Errorf("failed to get ticker aliases: %w", err)
 	}
 
-	return nil
+	return aliases, nil
 }
 
-// Deactivate deactivates a company by ID
-func (r *companyRepositoryImpl) Deactivate(ctx context.Context, id uuid.UUID) error {
-	result := r.db.WithContext(ctx).Model(&entities.Company{}).Where("id = ?", id).Update("is_active", false)
-	if result.Error != nil {
-		return fmt.Errorf("failed to deactivate company: %w", result.Error)
+// ========================================
+// MERGE OPERATIONS
+// ========================================
+
+// errMergeDryRun deshace la transacción de un merge en modo dry-run
+var errMergeDryRun = errors.New("company merge dry run")
+
+// MergeInto re-points the ratings, market data, profile, news and ticker aliases of a duplicate company to
+// target, keeps the duplicate ticker as an alias of target and soft-deletes the duplicate, all in one transaction.
+// Ratings that target already has (same brokerage and event time) are left on the duplicate and deleted with it.
+func (r *companyRepositoryImpl) MergeInto(ctx context.Context, duplicateID, targetID uuid.UUID, dryRun bool) (*interfaces.CompanyMergeResult, error) {
+	if duplicateID == targetID {
+		return nil, domainerrors.Conflict(nil, "a company cannot be merged into itself")
 	}
 
-	if result.RowsAffected == 0 {
-		return fmt.Errorf("company with id %s not found for deactivation", id)
+	result := &interfaces.CompanyMergeResult{}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var duplicate, target entities.Company
+		if err := tx.Where("id = ?", duplicateID).First(&duplicate).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domainerrors.NotFound("company with id %s not found", duplicateID)
+			}
+			return fmt.Errorf("failed to get duplicate company: %w", err)
+		}
+		if err := tx.Where("id = ?", targetID).First(&target).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domainerrors.NotFound("company with id %s not found", targetID)
+			}
+			return fmt.Errorf("failed to get target company: %w", err)
+		}
+		result.Duplicate = &duplicate
+		result.Target = &target
+
+		// Stock ratings: la restricción única (company, brokerage, event_time) incluye los eliminados
+		moved := tx.Exec(`UPDATE stock_ratings SET company_id = ?, updated_at = now()
+			WHERE company_id = ? AND NOT EXISTS (
+				SELECT 1 FROM stock_ratings t
+				WHERE t.company_id = ? AND t.brokerage_id = stock_ratings.brokerage_id AND t.event_time = stock_ratings.event_time
+			)`, targetID, duplicateID, targetID)
+		if moved.Error != nil {
+			return translateDBError(moved.Error, "failed to move stock ratings")
+		}
+		result.RatingsMoved = moved.RowsAffected
+
+		duplicated := tx.Where("company_id = ?", duplicateID).Delete(&entities.StockRating{})
+		if duplicated.Error != nil {
+			return fmt.Errorf("failed to delete duplicated stock ratings: %w", duplicated.Error)
+		}
+		result.RatingsDuplicated = duplicated.RowsAffected
+
+		// Tablas de market data: en bases anteriores a las migraciones pueden no existir
+		if tx.Migrator().HasTable(&entities.MarketData{}) {
+			marketData := tx.Model(&entities.MarketData{}).Unscoped().Where("company_id = ?", duplicateID).
+				Updates(map[string]interface{}{"company_id": targetID, "symbol": target.Ticker})
+			if marketData.Error != nil {
+				return fmt.Errorf("failed to move market data: %w", marketData.Error)
+			}
+			result.MarketDataMoved = marketData.RowsAffected
+		}
+
+		if tx.Migrator().HasTable(&entities.NewsItem{}) {
+			news := tx.Model(&entities.NewsItem{}).Unscoped().Where("symbol = ?", duplicate.Ticker).Update("symbol", target.Ticker)
+			if news.Error != nil {
+				return fmt.Errorf("failed to move news: %w", news.Error)
+			}
+			result.NewsMoved = news.RowsAffected
+		}
+
+		// El perfil del duplicado solo se conserva si target no tiene uno propio
+		var targetProfiles int64
+		if err := tx.Model(&entities.CompanyProfile{}).Where("symbol = ?", target.Ticker).Count(&targetProfiles).Error; err != nil {
+			return fmt.Errorf("failed to check target profile: %w", err)
+		}
+		if targetProfiles == 0 {
+			profile := tx.Model(&entities.CompanyProfile{}).Where("symbol = ?", duplicate.Ticker).Update("symbol", target.Ticker)
+			if profile.Error != nil {
+				return fmt.Errorf("failed to move company profile: %w", profile.Error)
+			}
+			result.ProfileMoved = profile.RowsAffected > 0
+		} else if err := tx.Where("symbol = ?", duplicate.Ticker).Delete(&entities.CompanyProfile{}).Error; err != nil {
+			return fmt.Errorf("failed to delete duplicate company profile: %w", err)
+		}
+
+		// Los tickers del duplicado siguen resolviendo, ahora a target
+		aliases := tx.Model(&entities.TickerAlias{}).Where("company_id = ?", duplicateID).Update("company_id", targetID)
+		if aliases.Error != nil {
+			return fmt.Errorf("failed to move ticker aliases: %w", aliases.Error)
+		}
+		result.AliasesMoved = aliases.RowsAffected
+
+		// Las notas de los usuarios siguen a la company y a sus ratings
+		if tx.Migrator().HasTable(&entities.Note{}) {
+			if err := tx.Model(&entities.Note{}).Where("company_id = ?", duplicateID).Update("company_id", targetID).Error; err != nil {
+				return fmt.Errorf("failed to move notes: %w", err)
+			}
+		}
+
+		if duplicate.Ticker != target.Ticker {
+			alias := entities.NewTickerAlias(duplicate.Ticker, targetID)
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "ticker"}},
+				DoUpdates: clause.AssignmentColumns([]string{"company_id", "updated_at"}),
+			}).Create(alias).Error; err != nil {
+				return translateDBError(err, "failed to create ticker alias")
+			}
+		}
+
+		if err := tx.Delete(&duplicate).Error; err != nil {
+			return fmt.Errorf("failed to delete duplicate company: %w", err)
+		}
+
+		if dryRun {
+			return errMergeDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errMergeDryRun) {
+		return nil, err
 	}
 
-	return nil
+	return result, nil
 }
 
 // ========================================
@@ -208,7 +481,7 @@ func (r *companyRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error
 	}
 
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("company with id %s not found for deletion", id)
+		return domainerrors.NotFound("company with id %s not found for deletion", id)
 	}
 
 	return nil
@@ -222,7 +495,55 @@ func (r *companyRepositoryImpl) HardDelete(ctx context.Context, id uuid.UUID) er
 	}
 
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("company with id %s not found for hard deletion", id)
+		return domainerrors.NotFound("company with id %s not found for hard deletion", id)
+	}
+
+	return nil
+}
+
+// ========================================
+// SOFT-DELETE RECOVERY OPERATIONS
+// ========================================
+
+// ListDeleted retrieves soft-deleted companies, most recently deleted first
+func (r *companyRepositoryImpl) ListDeleted(ctx context.Context, limit, offset int) ([]*entities.Company, error) {
+	var companies []*entities.Company
+
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&companies).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted companies: %w", err)
+	}
+
+	return companies, nil
+}
+
+// CountDeleted returns the number of soft-deleted companies
+func (r *companyRepositoryImpl) CountDeleted(ctx context.Context) (int64, error) {
+	var count int64
+
+	err := r.db.WithContext(ctx).Unscoped().Model(&entities.Company{}).Where("deleted_at IS NOT NULL").Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count deleted companies: %w", err)
+	}
+
+	return count, nil
+}
+
+// Restore undoes the soft delete of a company
+func (r *companyRepositoryImpl) Restore(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Unscoped().Model(&entities.Company{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return translateDBError(result.Error, "failed to restore company")
+	}
+
+	if result.RowsAffected == 0 {
+		return domainerrors.NotFound("deleted company with id %s not found", id)
 	}
 
 	return nil
@@ -260,7 +581,7 @@ func (r *companyRepositoryImpl) ExistsByName(ctx context.Context, name string) (
 func (r *companyRepositoryImpl) Count(ctx context.Context) (int64, error) {
 	var count int64
 
-	err := r.db.WithContext(ctx).Model(&entities.Company{}).Count(&count).Error
+	err := r.reader.WithContext(ctx).Model(&entities.Company{}).Count(&count).Error
 	if err != nil {
 		return 0, fmt.Errorf("failed to count companies: %w", err)
 	}
@@ -272,7 +593,7 @@ func (r *companyRepositoryImpl) Count(ctx context.Context) (int64, error) {
 func (r *companyRepositoryImpl) CountActive(ctx context.Context) (int64, error) {
 	var count int64
 
-	err := r.db.WithContext(ctx).Model(&entities.Company{}).Where("is_active = ?", true).Count(&count).Error
+	err := r.reader.WithContext(ctx).Model(&entities.Company{}).Where("is_active = ?", true).Count(&count).Error
 	if err != nil {
 		return 0, fmt.Errorf("failed to count active companies: %w", err)
 	}
@@ -288,7 +609,7 @@ func (r *companyRepositoryImpl) CountActive(ctx context.Context) (int64, error)
 func (r *companyRepositoryImpl) GetBySector(ctx context.Context, sector string) ([]*entities.Company, error) {
 	var companies []*entities.Company
 
-	err := r.db.WithContext(ctx).Where("sector = ? AND is_active = ?", sector, true).Find(&companies).Error
+	err := r.reader.WithContext(ctx).Where("sector = ? AND is_active = ?", sector, true).Find(&companies).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get companies by sector: %w", err)
 	}
@@ -300,7 +621,7 @@ func (r *companyRepositoryImpl) GetBySector(ctx context.Context, sector string)
 func (r *companyRepositoryImpl) GetByExchange(ctx context.Context, exchange string) ([]*entities.Company, error) {
 	var companies []*entities.Company
 
-	err := r.db.WithContext(ctx).Where("exchange = ? AND is_active = ?", strings.ToUpper(exchange), true).Find(&companies).Error
+	err := r.reader.WithContext(ctx).Where("exchange = ? AND is_active = ?", strings.ToUpper(exchange), true).Find(&companies).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get companies by exchange: %w", err)
 	}
@@ -312,7 +633,7 @@ func (r *companyRepositoryImpl) GetByExchange(ctx context.Context, exchange stri
 func (r *companyRepositoryImpl) GetByMarketCapRange(ctx context.Context, minCap, maxCap float64) ([]*entities.Company, error) {
 	var companies []*entities.Company
 
-	query := r.db.WithContext(ctx).Where("is_active = ?", true)
+	query := r.reader.WithContext(ctx).Where("is_active = ?", true)
 
 	if minCap > 0 {
 		query = query.Where("market_cap >= ?", minCap)
@@ -333,7 +654,7 @@ func (r *companyRepositoryImpl) GetByMarketCapRange(ctx context.Context, minCap,
 func (r *companyRepositoryImpl) GetLargestByMarketCap(ctx context.Context, limit int) ([]*entities.Company, error) {
 	var companies []*entities.Company
 
-	query := r.db.WithContext(ctx).
+	query := r.reader.WithContext(ctx).
 		Where("is_active = ? AND market_cap > 0", true).
 		Order("market_cap DESC")
 
@@ -362,9 +683,7 @@ func (r *companyRepositoryImpl) FindOrCreateByTicker(ctx context.Context, ticker
 	}
 
 	// If not found, create a new one
-	if errors.Is(err, gorm.ErrRecordNotFound) ||
-		(err != nil && strings.Contains(fmt.Sprintf("%v", err), "not found")) {
-
+	if domainerrors.IsNotFound(err) {
 		newCompany := entities.NewCompany(ticker, name)
 		if err := r.Create(ctx, newCompany); err != nil {
 			return nil, fmt.Errorf("failed to create new company %s: %w", ticker, err)
@@ -408,9 +727,7 @@ func (r *companyRepositoryImpl) FindOrCreateWithDetails(ctx context.Context, tic
 	}
 
 	// If not found, create a new one with details
-	if errors.Is(err, gorm.ErrRecordNotFound) ||
-		(err != nil && strings.Contains(fmt.Sprintf("%v", err), "not found")) {
-
+	if domainerrors.IsNotFound(err) {
 		newCompany := entities.NewCompanyWithDetails(ticker, name, sector, exchange, marketCap)
 		if err := r.Create(ctx, newCompany); err != nil {
 			return nil, fmt.Errorf("failed to create new company with details %s: %w", ticker, err)
@@ -465,7 +782,7 @@ func (r *companyRepositoryImpl) GetWithRatings(ctx context.Context, id uuid.UUID
 	err := r.db.WithContext(ctx).Preload("StockRatings").Where("id = ?", id).First(&company).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("company with id %s not found", id)
+			return nil, domainerrors.NotFound("company with id %s not found", id)
 		}
 		return nil, fmt.Errorf("failed to get company with ratings: %w", err)
 	}
@@ -477,7 +794,7 @@ func (r *companyRepositoryImpl) GetWithRatings(ctx context.Context, id uuid.UUID
 func (r *companyRepositoryImpl) GetByRatingCount(ctx context.Context, limit int) ([]*entities.Company, error) {
 	var companies []*entities.Company
 
-	query := r.db.WithContext(ctx).
+	query := r.reader.WithContext(ctx).
 		Select("companies.*, COUNT(stock_ratings.id) as rating_count").
 		Joins("LEFT JOIN stock_ratings ON companies.id = stock_ratings.company_id").
 		Where("companies.is_active = ?", true).
@@ -500,10 +817,12 @@ func (r *companyRepositoryImpl) GetByRatingCount(ctx context.Context, limit int)
 func (r *companyRepositoryImpl) GetMostActiveCompanies(ctx context.Context, days int, limit int) ([]*entities.Company, error) {
 	var companies []*entities.Company
 
-	query := r.db.WithContext(ctx).
+	// Corte calculado en la aplicación: una constante sobre event_time permite descartar particiones
+	cutoffTime := time.Now().AddDate(0, 0, -days)
+	query := r.reader.WithContext(ctx).
 		Select("companies.*, COUNT(stock_ratings.id) as recent_rating_count").
 		Joins("LEFT JOIN stock_ratings ON companies.id = stock_ratings.company_id").
-		Where("companies.is_active = ? AND stock_ratings.event_time >= NOW() - INTERVAL ? DAY", true, days).
+		Where("companies.is_active = ? AND stock_ratings.event_time >= ?", true, cutoffTime).
 		Group("companies.id").
 		Order("recent_rating_count DESC")
 
@@ -527,7 +846,7 @@ func (r *companyRepositoryImpl) GetMostActiveCompanies(ctx context.Context, days
 func (r *companyRepositoryImpl) SearchByName(ctx context.Context, query string, limit int) ([]*entities.Company, error) {
 	var companies []*entities.Company
 
-	searchQuery := r.db.WithContext(ctx).
+	searchQuery := r.reader.WithContext(ctx).
 		Where("name ILIKE ? AND is_active = ?", "%"+query+"%", true).
 		Order("name ASC")
 
@@ -543,11 +862,63 @@ func (r *companyRepositoryImpl) SearchByName(ctx context.Context, query string,
 	return companies, nil
 }
 
+// SuggestByPrefix returns active companies whose ticker starts with prefix (shortest tickers first) followed,
+// if there is room left, by those whose name starts with it. Cada consulta usa su propio índice (migración 000010).
+func (r *companyRepositoryImpl) SuggestByPrefix(ctx context.Context, prefix string, limit int) ([]interfaces.CompanySuggestion, error) {
+	pattern := EscapeLikePattern(strings.TrimSpace(prefix)) + "%"
+
+	var suggestions []interfaces.CompanySuggestion
+	err := r.reader.WithContext(ctx).
+		Model(&entities.Company{}).
+		Select("id, ticker, name").
+		Where("ticker LIKE ? AND is_active = ?", strings.ToUpper(pattern), true).
+		Order("length(ticker) ASC, ticker ASC").
+		Limit(limit).
+		Scan(&suggestions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest companies by ticker: %w", err)
+	}
+
+	if len(suggestions) >= limit {
+		return suggestions, nil
+	}
+
+	var byName []interfaces.CompanySuggestion
+	query := r.reader.WithContext(ctx).
+		Model(&entities.Company{}).
+		Select("id, ticker, name").
+		Where("name ILIKE ? AND is_active = ?", pattern, true).
+		Order("name ASC").
+		Limit(limit)
+	if len(suggestions) > 0 {
+		ids := make([]uuid.UUID, len(suggestions))
+		for i, suggestion := range suggestions {
+			ids[i] = suggestion.ID
+		}
+		query = query.Where("id NOT IN ?", ids)
+	}
+	if err := query.Scan(&byName).Error; err != nil {
+		return nil, fmt.Errorf("failed to suggest companies by name: %w", err)
+	}
+
+	suggestions = append(suggestions, byName...)
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+
+	return suggestions, nil
+}
+
+// EscapeLikePattern escapa los comodines de LIKE para que el texto se busque literalmente
+func EscapeLikePattern(text string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(text)
+}
+
 // SearchByTicker searches companies by ticker using partial matching
 func (r *companyRepositoryImpl) SearchByTicker(ctx context.Context, query string, limit int) ([]*entities.Company, error) {
 	var companies []*entities.Company
 
-	searchQuery := r.db.WithContext(ctx).
+	searchQuery := r.reader.WithContext(ctx).
 		Where("ticker ILIKE ? AND is_active = ?", "%"+strings.ToUpper(query)+"%", true).
 		Order("ticker ASC")
 
@@ -574,7 +945,7 @@ func (r *companyRepositoryImpl) GetSectorDistribution(ctx context.Context) (map[
 		Count  int64
 	}
 
-	err := r.db.WithContext(ctx).
+	err := r.reader.WithContext(ctx).
 		Model(&entities.Company{}).
 		Select("sector, COUNT(*) as count").
 		Where("is_active = ? AND sector IS NOT NULL AND sector != ''", true).
@@ -601,7 +972,7 @@ func (r *companyRepositoryImpl) GetExchangeDistribution(ctx context.Context) (ma
 		Count    int64
 	}
 
-	err := r.db.WithContext(ctx).
+	err := r.reader.WithContext(ctx).
 		Model(&entities.Company{}).
 		Select("exchange, COUNT(*) as count").
 		Where("is_active = ? AND exchange IS NOT NULL AND exchange != ''", true).
@@ -630,7 +1001,7 @@ func (r *companyRepositoryImpl) GetMarketCapStats(ctx context.Context) (map[stri
 		Count  int64
 	}
 
-	err := r.db.WithContext(ctx).
+	err := r.reader.WithContext(ctx).
 		Model(&entities.Company{}).
 		Select("MIN(market_cap) as min_cap, MAX(market_cap) as max_cap, AVG(market_cap) as avg_cap, COUNT(*) as count").
 		Where("is_active = ? AND market_cap > 0", true).
@@ -650,6 +1021,26 @@ func (r *companyRepositoryImpl) GetMarketCapStats(ctx context.Context) (map[stri
 	return stats, nil
 }
 
+// GetMarketCapStatsByGroup returns market cap statistics per exchange and currency, without conversion
+func (r *companyRepositoryImpl) GetMarketCapStatsByGroup(ctx context.Context) ([]interfaces.MarketCapGroupStats, error) {
+	var results []interfaces.MarketCapGroupStats
+
+	err := r.reader.WithContext(ctx).
+		Model(&entities.Company{}).
+		Select("COALESCE(exchange, '') as exchange, UPPER(COALESCE(currency, '')) as currency, COUNT(*) as count, "+
+			"SUM(market_cap) as sum, MIN(market_cap) as min, MAX(market_cap) as max").
+		Where("is_active = ? AND market_cap > 0", true).
+		Group("1, 2").
+		Order("exchange, currency").
+		Scan(&results).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get market cap stats by group: %w", err)
+	}
+
+	return results, nil
+}
+
 // ========================================
 // TRANSACTIONAL OPERATIONS
 // ========================================
@@ -678,17 +1069,15 @@ func (r *companyRepositoryImpl) CreateManyWithTx(ctx context.Context, tx *gorm.D
 
 // GetByTickerWithTx retrieves a company by ticker using the provided transaction
 func (r *companyRepositoryImpl) GetByTickerWithTx(ctx context.Context, tx *gorm.DB, ticker string) (*entities.Company, error) {
-	var company entities.Company
-
-	err := tx.WithContext(ctx).Where("ticker = ?", strings.ToUpper(ticker)).First(&company).Error
+	company, err := findCompanyByTicker(tx.WithContext(ctx), ticker)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("company with ticker %s not found", ticker)
+			return nil, domainerrors.NotFound("company with ticker %s not found", ticker)
 		}
 		return nil, fmt.Errorf("failed to get company by ticker with transaction: %w", err)
 	}
 
-	return &company, nil
+	return company, nil
 }
 
 // FindOrCreateByTickerWithTx finds or creates a company by ticker using the provided transaction
@@ -0,0 +1,56 @@
+package implementation
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+)
+
+// Códigos SQLSTATE de Postgres/CockroachDB que se traducen a errores de dominio
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+)
+
+// translateDBError maps GORM/pgx errors to domain errors (not found, duplicate, conflict).
+// Any other error is wrapped with the given message so the original cause is preserved.
+func translateDBError(err error, format string, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+
+	message := fmt.Sprintf(format, args...)
+
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return domainerrors.NotFound("%s", message)
+	case errors.Is(err, gorm.ErrDuplicatedKey) || pgErrorCode(err) == pgUniqueViolation:
+		return domainerrors.Duplicate(err, "%s", message)
+	case errors.Is(err, gorm.ErrForeignKeyViolated) || pgErrorCode(err) == pgForeignKeyViolation:
+		return domainerrors.Conflict(err, "%s", message)
+	default:
+		return fmt.Errorf("%s: %w", message, err)
+	}
+}
+
+// isUniqueViolation checks if the error is a unique violation of the given constraint
+func isUniqueViolation(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == pgUniqueViolation && pgErr.ConstraintName == constraint
+	}
+	return false
+}
+
+// pgErrorCode returns the SQLSTATE code of a driver error, or an empty string
+func pgErrorCode(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code
+	}
+	return ""
+}
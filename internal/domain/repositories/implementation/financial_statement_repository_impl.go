@@ -0,0 +1,64 @@
+package implementation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+)
+
+// financialStatementRepositoryImpl implements the FinancialStatementRepository interface using GORM
+type financialStatementRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewFinancialStatementRepository creates a new financial statement repository implementation
+func NewFinancialStatementRepository(db *gorm.DB) interfaces.FinancialStatementRepository {
+	return &financialStatementRepositoryImpl{
+		db: db,
+	}
+}
+
+// Upsert stores statements; one already stored for the same company, statement, period and fiscal date gets the
+// new amounts, so restated figures replace the previous ones
+func (r *financialStatementRepositoryImpl) Upsert(ctx context.Context, statements []*entities.FinancialStatement) error {
+	if len(statements) == 0 {
+		return nil
+	}
+
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "company_id"}, {Name: "statement"}, {Name: "period"}, {Name: "fiscal_date_ending"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"currency":   gorm.Expr("excluded.currency"),
+			"line_items": gorm.Expr("excluded.line_items"),
+			"updated_at": time.Now().UTC(),
+		}),
+	}).Create(&statements).Error
+	if err != nil {
+		return fmt.Errorf("failed to store financial statements: %w", err)
+	}
+
+	return nil
+}
+
+// GetByCompany returns the latest limit statements of a kind and period of a company, newest first
+func (r *financialStatementRepositoryImpl) GetByCompany(ctx context.Context, companyID uuid.UUID, statement, period string, limit int) ([]*entities.FinancialStatement, error) {
+	var statements []*entities.FinancialStatement
+
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND statement = ? AND period = ?", companyID, statement, period).
+		Order("fiscal_date_ending DESC").
+		Limit(limit).
+		Find(&statements).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s %s statements of company %s: %w", period, statement, companyID, err)
+	}
+
+	return statements, nil
+}
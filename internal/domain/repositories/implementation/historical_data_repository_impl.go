@@ -70,6 +70,16 @@ func (r *HistoricalDataRepositoryImpl) GetBySymbol(ctx context.Context, symbol s
 	return data, err
 }
 
+// GetByDateRange retrieves the historical data of every symbol within a date range, by symbol and date
+func (r *HistoricalDataRepositoryImpl) GetByDateRange(ctx context.Context, startDate, endDate time.Time) ([]*entities.HistoricalData, error) {
+	var data []*entities.HistoricalData
+	err := r.db.WithContext(ctx).
+		Where("date >= ? AND date <= ?", startDate, endDate).
+		Order("symbol ASC, date ASC").
+		Find(&data).Error
+	return data, err
+}
+
 // GetBySymbolLastN retrieves the last N days of historical data for a symbol
 func (r *HistoricalDataRepositoryImpl) GetBySymbolLastN(ctx context.Context, symbol string, days int) ([]*entities.HistoricalData, error) {
 	var data []*entities.HistoricalData
@@ -0,0 +1,52 @@
+package implementation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+)
+
+// integritySnapshotRepositoryImpl implements the IntegritySnapshotRepository interface using GORM
+type integritySnapshotRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewIntegritySnapshotRepository creates a new integrity snapshot repository implementation
+func NewIntegritySnapshotRepository(db *gorm.DB) interfaces.IntegritySnapshotRepository {
+	return &integritySnapshotRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create stores the summary of an integrity validation
+func (r *integritySnapshotRepositoryImpl) Create(ctx context.Context, snapshot *entities.IntegritySnapshot) error {
+	if err := r.db.WithContext(ctx).Create(snapshot).Error; err != nil {
+		return fmt.Errorf("failed to store integrity snapshot: %w", err)
+	}
+	return nil
+}
+
+// GetSince retrieves the snapshots taken on or after since, oldest first. With more than limit snapshots
+// the most recent ones are returned
+func (r *integritySnapshotRepositoryImpl) GetSince(ctx context.Context, since time.Time, limit int) ([]*entities.IntegritySnapshot, error) {
+	var snapshots []*entities.IntegritySnapshot
+
+	err := r.db.WithContext(ctx).
+		Where("created_at >= ?", since).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&snapshots).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get integrity snapshots since %s: %w", since.Format("2006-01-02"), err)
+	}
+
+	for i, j := 0, len(snapshots)-1; i < j; i, j = i+1, j-1 {
+		snapshots[i], snapshots[j] = snapshots[j], snapshots[i]
+	}
+	return snapshots, nil
+}
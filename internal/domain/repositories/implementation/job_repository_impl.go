@@ -0,0 +1,323 @@
+package implementation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+)
+
+// jobRepositoryImpl implements the JobRepository interface using GORM
+type jobRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewJobRepository creates a new job repository implementation
+func NewJobRepository(db *gorm.DB) interfaces.JobRepository {
+	return &jobRepositoryImpl{
+		db: db,
+	}
+}
+
+// ========================================
+// CREATE OPERATIONS
+// ========================================
+
+// Create enqueues a new job
+func (r *jobRepositoryImpl) Create(ctx context.Context, job *entities.Job) error {
+	if err := r.db.WithContext(ctx).Create(job).Error; err != nil {
+		return fmt.Errorf("failed to create job: %w", err)
+	}
+	return nil
+}
+
+// ========================================
+// READ OPERATIONS
+// ========================================
+
+// GetByID retrieves a job by its ID
+func (r *jobRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*entities.Job, error) {
+	var job entities.Job
+
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&job).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.NotFound("job with id %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get job by id: %w", err)
+	}
+
+	return &job, nil
+}
+
+// List retrieves jobs ordered by creation date, optionally filtered by status
+func (r *jobRepositoryImpl) List(ctx context.Context, status entities.JobStatus, limit, offset int) ([]*entities.Job, error) {
+	var jobs []*entities.Job
+
+	query := r.db.WithContext(ctx).Order("created_at DESC").Limit(limit).Offset(offset)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Find(&jobs).Error; err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// Count returns the number of jobs, optionally filtered by status
+func (r *jobRepositoryImpl) Count(ctx context.Context, status entities.JobStatus) (int64, error) {
+	var count int64
+
+	query := r.db.WithContext(ctx).Model(&entities.Job{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count jobs: %w", err)
+	}
+
+	return count, nil
+}
+
+// ========================================
+// WORKER OPERATIONS
+// ========================================
+
+// ClaimNext locks the next runnable job so that concurrent workers never pick the same one
+func (r *jobRepositoryImpl) ClaimNext(ctx context.Context, workerID string, jobTypes []string) (*entities.Job, error) {
+	if len(jobTypes) == 0 {
+		return nil, nil
+	}
+
+	var claimed *entities.Job
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var job entities.Job
+
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND run_at <= ? AND type IN ?", entities.JobStatusPending, time.Now().UTC(), jobTypes).
+			Order("run_at ASC").
+			First(&job).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		now := time.Now().UTC()
+		job.Status = entities.JobStatusRunning
+		job.LockedBy = workerID
+		job.LockedAt = &now
+		job.Attempts++
+		if job.StartedAt == nil {
+			job.StartedAt = &now
+		}
+
+		err = tx.Model(&entities.Job{}).Where("id = ?", job.ID).Updates(map[string]interface{}{
+			"status":     job.Status,
+			"locked_by":  job.LockedBy,
+			"locked_at":  job.LockedAt,
+			"attempts":   job.Attempts,
+			"started_at": job.StartedAt,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		claimed = &job
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim next job: %w", err)
+	}
+
+	return claimed, nil
+}
+
+// MarkSucceeded stores the result of a finished job
+func (r *jobRepositoryImpl) MarkSucceeded(ctx context.Context, id uuid.UUID, result string) error {
+	return r.finish(ctx, id, map[string]interface{}{
+		"status": entities.JobStatusSucceeded,
+		"result": result,
+		"error":  "",
+	})
+}
+
+// MarkFailed marks a job as permanently failed
+func (r *jobRepositoryImpl) MarkFailed(ctx context.Context, id uuid.UUID, errMsg string) error {
+	return r.finish(ctx, id, map[string]interface{}{
+		"status": entities.JobStatusFailed,
+		"error":  errMsg,
+	})
+}
+
+// Release returns a running job to the queue, undoing the attempt counted by ClaimNext
+func (r *jobRepositoryImpl) Release(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Model(&entities.Job{}).
+		Where("id = ? AND status = ?", id, entities.JobStatusRunning).
+		Updates(map[string]interface{}{
+			"status":    entities.JobStatusPending,
+			"attempts":  gorm.Expr("GREATEST(attempts - 1, 0)"),
+			"run_at":    time.Now().UTC(),
+			"locked_by": nil,
+			"locked_at": nil,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to release job: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return domainerrors.NotFound("running job with id %s not found for release", id)
+	}
+
+	return nil
+}
+
+// MarkCancelled marks a job as cancelled
+func (r *jobRepositoryImpl) MarkCancelled(ctx context.Context, id uuid.UUID) error {
+	return r.finish(ctx, id, map[string]interface{}{
+		"status": entities.JobStatusCancelled,
+	})
+}
+
+// ScheduleRetry releases a failed job back to the queue to run again at runAt
+func (r *jobRepositoryImpl) ScheduleRetry(ctx context.Context, id uuid.UUID, errMsg string, runAt time.Time) error {
+	result := r.db.WithContext(ctx).Model(&entities.Job{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":    entities.JobStatusPending,
+		"error":     errMsg,
+		"run_at":    runAt,
+		"locked_by": nil,
+		"locked_at": nil,
+	})
+	if result.Error != nil {
+		return fmt.Errorf("failed to schedule job retry: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return domainerrors.NotFound("job with id %s not found for retry", id)
+	}
+
+	return nil
+}
+
+// Heartbeat refreshes the lock of a running job so it is not considered stale
+func (r *jobRepositoryImpl) Heartbeat(ctx context.Context, id uuid.UUID, workerID string) error {
+	result := r.db.WithContext(ctx).Model(&entities.Job{}).
+		Where("id = ? AND locked_by = ? AND status = ?", id, workerID, entities.JobStatusRunning).
+		Update("locked_at", time.Now().UTC())
+	if result.Error != nil {
+		return fmt.Errorf("failed to update job heartbeat: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("job with id %s is no longer locked by %s", id, workerID)
+	}
+
+	return nil
+}
+
+// UpdateProgress stores the partial result of a running job
+func (r *jobRepositoryImpl) UpdateProgress(ctx context.Context, id uuid.UUID, workerID, progress string) error {
+	result := r.db.WithContext(ctx).Model(&entities.Job{}).
+		Where("id = ? AND locked_by = ? AND status = ?", id, workerID, entities.JobStatusRunning).
+		Update("result", progress)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update job progress: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("job with id %s is no longer locked by %s", id, workerID)
+	}
+
+	return nil
+}
+
+// RequeueStale releases running jobs whose worker stopped sending heartbeats
+func (r *jobRepositoryImpl) RequeueStale(ctx context.Context, lockedBefore time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&entities.Job{}).
+		Where("status = ? AND locked_at < ?", entities.JobStatusRunning, lockedBefore).
+		Updates(map[string]interface{}{
+			"status":    entities.JobStatusPending,
+			"locked_by": nil,
+			"locked_at": nil,
+		})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to requeue stale jobs: %w", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+// ========================================
+// CANCELLATION OPERATIONS
+// ========================================
+
+// RequestCancel cancels a pending job immediately or flags a running job for cancellation.
+// Both updates are conditional on the status so a worker claiming the job concurrently cannot be overwritten
+func (r *jobRepositoryImpl) RequestCancel(ctx context.Context, id uuid.UUID) (*entities.Job, error) {
+	result := r.db.WithContext(ctx).Model(&entities.Job{}).
+		Where("id = ? AND status = ?", id, entities.JobStatusPending).
+		Updates(map[string]interface{}{
+			"status":      entities.JobStatusCancelled,
+			"finished_at": time.Now().UTC(),
+			"locked_by":   nil,
+			"locked_at":   nil,
+		})
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to cancel job: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		// Not pending anymore: flag it if a worker holds it, terminal jobs are returned unchanged
+		err := r.db.WithContext(ctx).Model(&entities.Job{}).
+			Where("id = ? AND status = ?", id, entities.JobStatusRunning).
+			Update("cancel_requested", true).Error
+		if err != nil {
+			return nil, fmt.Errorf("failed to request job cancellation: %w", err)
+		}
+	}
+
+	return r.GetByID(ctx, id)
+}
+
+// IsCancelRequested checks whether cancellation was requested for a job
+func (r *jobRepositoryImpl) IsCancelRequested(ctx context.Context, id uuid.UUID) (bool, error) {
+	var job entities.Job
+
+	err := r.db.WithContext(ctx).Select("cancel_requested").Where("id = ?", id).First(&job).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check job cancellation: %w", err)
+	}
+
+	return job.CancelRequested, nil
+}
+
+// finish moves a job to a terminal state and releases its lock
+func (r *jobRepositoryImpl) finish(ctx context.Context, id uuid.UUID, fields map[string]interface{}) error {
+	fields["finished_at"] = time.Now().UTC()
+	fields["locked_by"] = nil
+	fields["locked_at"] = nil
+
+	result := r.db.WithContext(ctx).Model(&entities.Job{}).Where("id = ?", id).Updates(fields)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update job status: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return domainerrors.NotFound("job with id %s not found for status update", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package implementation
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+)
+
+// applySort ordena el listado por los campos pedidos o, sin ellos, por el orden por defecto del repositorio.
+// Las columnas llegan ya validadas contra interfaces.SortableFields; el id desempata para que la paginación
+// sea estable cuando varias filas comparten valor
+func applySort(query *gorm.DB, sort []interfaces.SortField, defaults ...interfaces.SortField) *gorm.DB {
+	if len(sort) == 0 {
+		sort = defaults
+	}
+	for _, field := range sort {
+		query = query.Order(clause.OrderByColumn{Column: clause.Column{Name: field.Column}, Desc: field.Desc})
+	}
+	return query.Order(clause.OrderByColumn{Column: clause.Column{Name: "id"}})
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
 	"github.com/MayaCris/stock-info-app/internal/domain/entities"
 	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
 )
@@ -62,7 +63,7 @@ func (r *marketDataRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*
 	var marketData entities.MarketData
 	if err := r.db.WithContext(ctx).First(&marketData, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("market data not found with id %s", id.String())
+			return nil, domainerrors.NotFound("market data not found with id %s", id.String())
 		}
 		return nil, fmt.Errorf("failed to get market data by id: %w", err)
 	}
@@ -74,10 +75,10 @@ func (r *marketDataRepositoryImpl) GetBySymbol(ctx context.Context, symbol strin
 	var marketData entities.MarketData
 	if err := r.db.WithContext(ctx).
 		Where("symbol = ?", symbol).
-		Order("market_market_timestamp DESC").
+		Order("market_timestamp DESC").
 		First(&marketData).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("market data not found for symbol %s", symbol)
+			return nil, domainerrors.NotFound("market data not found for symbol %s", symbol)
 		}
 		return nil, fmt.Errorf("failed to get market data by symbol: %w", err)
 	}
@@ -234,7 +235,7 @@ func (r *marketDataRepositoryImpl) GetByCompanyID(ctx context.Context, companyID
 		Order("market_timestamp DESC").
 		First(&marketData).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("market data not found for company id %s", companyID.String())
+			return nil, domainerrors.NotFound("market data not found for company id %s", companyID.String())
 		}
 		return nil, fmt.Errorf("failed to get market data by company id: %w", err)
 	}
@@ -292,6 +293,37 @@ func (r *marketDataRepositoryImpl) GetByTimeRange(ctx context.Context, startTime
 	return marketDataList, nil
 }
 
+// latestTimestampsChunkSize limita los símbolos por consulta IN
+const latestTimestampsChunkSize = 1000
+
+// GetLatestTimestamps retrieves the market timestamp of the latest quote of each symbol
+func (r *marketDataRepositoryImpl) GetLatestTimestamps(ctx context.Context, symbols []string) (map[string]time.Time, error) {
+	latest := make(map[string]time.Time, len(symbols))
+
+	for start := 0; start < len(symbols); start += latestTimestampsChunkSize {
+		end := min(start+latestTimestampsChunkSize, len(symbols))
+
+		var rows []struct {
+			Symbol          string
+			MarketTimestamp time.Time
+		}
+		if err := r.db.WithContext(ctx).
+			Model(&entities.MarketData{}).
+			Select("symbol, MAX(market_timestamp) AS market_timestamp").
+			Where("symbol IN ?", symbols[start:end]).
+			Group("symbol").
+			Scan(&rows).Error; err != nil {
+			return nil, fmt.Errorf("failed to get latest market data timestamps: %w", err)
+		}
+
+		for _, row := range rows {
+			latest[row.Symbol] = row.MarketTimestamp
+		}
+	}
+
+	return latest, nil
+}
+
 // GetStaleData retrieves market data older than maxAge
 func (r *marketDataRepositoryImpl) GetStaleData(ctx context.Context, maxAge time.Duration) ([]*entities.MarketData, error) {
 	staleTime := time.Now().Add(-maxAge)
@@ -309,19 +341,26 @@ func (r *marketDataRepositoryImpl) GetStaleData(ctx context.Context, maxAge time
 // MARKET ANALYSIS OPERATIONS
 // ========================================
 
+// latestMarketData limita la consulta a la cotización más reciente (no eliminada) de cada símbolo;
+// usa el índice idx_market_data_symbol_timestamp
+func (r *marketDataRepositoryImpl) latestMarketData(ctx context.Context) *gorm.DB {
+	latest := r.db.Model(&entities.MarketData{}).
+		Select("symbol, MAX(market_timestamp) AS max_market_timestamp").
+		Group("symbol")
+
+	return r.db.WithContext(ctx).
+		Model(&entities.MarketData{}).
+		Joins("JOIN (?) AS latest ON market_data.symbol = latest.symbol AND market_data.market_timestamp = latest.max_market_timestamp", latest)
+}
+
 // GetTopGainers retrieves stocks with highest percentage gains
 func (r *marketDataRepositoryImpl) GetTopGainers(ctx context.Context, limit int) ([]*entities.MarketData, error) {
 	var marketDataList []*entities.MarketData
 
 	// Get latest records and order by percentage change descending
-	subQuery := r.db.Select("symbol, MAX(market_timestamp) as max_market_timestamp").
-		Group("symbol")
-
-	query := r.db.WithContext(ctx).
-		Table("market_data").
-		Joins("JOIN (?) as latest ON market_data.symbol = latest.symbol AND market_data.market_timestamp = latest.max_market_timestamp", subQuery).
-		Where("price_change_perc > 0").
-		Order("price_change_perc DESC")
+	query := r.latestMarketData(ctx).
+		Where("market_data.price_change_perc > 0").
+		Order("market_data.price_change_perc DESC")
 
 	if limit > 0 {
 		query = query.Limit(limit)
@@ -339,14 +378,9 @@ func (r *marketDataRepositoryImpl) GetTopLosers(ctx context.Context, limit int)
 	var marketDataList []*entities.MarketData
 
 	// Get latest records and order by percentage change ascending
-	subQuery := r.db.Select("symbol, MAX(market_timestamp) as max_market_timestamp").
-		Group("symbol")
-
-	query := r.db.WithContext(ctx).
-		Table("market_data").
-		Joins("JOIN (?) as latest ON market_data.symbol = latest.symbol AND market_data.market_timestamp = latest.max_market_timestamp", subQuery).
-		Where("price_change_perc < 0").
-		Order("price_change_perc ASC")
+	query := r.latestMarketData(ctx).
+		Where("market_data.price_change_perc < 0").
+		Order("market_data.price_change_perc ASC")
 
 	if limit > 0 {
 		query = query.Limit(limit)
@@ -364,13 +398,9 @@ func (r *marketDataRepositoryImpl) GetMostActive(ctx context.Context, limit int)
 	var marketDataList []*entities.MarketData
 
 	// Get latest records and order by volume descending
-	subQuery := r.db.Select("symbol, MAX(market_timestamp) as max_market_timestamp").
-		Group("symbol")
-
-	query := r.db.WithContext(ctx).
-		Table("market_data").
-		Joins("JOIN (?) as latest ON market_data.symbol = latest.symbol AND market_data.market_timestamp = latest.max_market_timestamp", subQuery).
-		Order("volume DESC")
+	query := r.latestMarketData(ctx).
+		Where("market_data.volume > 0").
+		Order("market_data.volume DESC")
 
 	if limit > 0 {
 		query = query.Limit(limit)
@@ -411,29 +441,32 @@ func (r *marketDataRepositoryImpl) BulkUpdate(ctx context.Context, marketData []
 	return nil
 }
 
-// UpsertBySymbol creates or updates market data for a symbol
-func (r *marketDataRepositoryImpl) UpsertBySymbol(ctx context.Context, marketData *entities.MarketData) error { // Check if record exists for the symbol and market_timestamp
-	var existing entities.MarketData
-	err := r.db.WithContext(ctx).
-		Where("symbol = ? AND market_timestamp = ?", marketData.Symbol, marketData.MarketTimestamp).
-		First(&existing).Error
+// UpsertBySymbol creates or updates market data for a symbol; the hooks run in the same transaction
+func (r *marketDataRepositoryImpl) UpsertBySymbol(ctx context.Context, marketData *entities.MarketData, hooks ...interfaces.WriteHook[entities.MarketData]) error {
+	return withWriteHooks(r.db.WithContext(ctx), hooks, func(tx *gorm.DB) (*entities.MarketData, error) {
+		// Check if record exists for the symbol and market_timestamp
+		var existing entities.MarketData
+		err := tx.
+			Where("symbol = ? AND market_timestamp = ?", marketData.Symbol, marketData.MarketTimestamp).
+			First(&existing).Error
 
-	if err == gorm.ErrRecordNotFound {
-		// Create new record
-		if err := r.db.WithContext(ctx).Create(marketData).Error; err != nil {
-			return fmt.Errorf("failed to create market data during upsert: %w", err)
-		}
-	} else if err != nil {
-		return fmt.Errorf("failed to check existing market data during upsert: %w", err)
-	} else {
-		// Update existing record
-		marketData.ID = existing.ID
-		if err := r.db.WithContext(ctx).Save(marketData).Error; err != nil {
-			return fmt.Errorf("failed to update market data during upsert: %w", err)
+		if err == gorm.ErrRecordNotFound {
+			// Create new record
+			if err := tx.Create(marketData).Error; err != nil {
+				return nil, fmt.Errorf("failed to create market data during upsert: %w", err)
+			}
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to check existing market data during upsert: %w", err)
+		} else {
+			// Update existing record
+			marketData.ID = existing.ID
+			if err := tx.Save(marketData).Error; err != nil {
+				return nil, fmt.Errorf("failed to update market data during upsert: %w", err)
+			}
 		}
-	}
 
-	return nil
+		return marketData, nil
+	})
 }
 
 // ========================================
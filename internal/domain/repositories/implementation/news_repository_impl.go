@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
 	"github.com/MayaCris/stock-info-app/internal/domain/entities"
 	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
 )
@@ -45,7 +46,7 @@ func (r *newsRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*entiti
 	var news entities.NewsItem
 	if err := r.db.WithContext(ctx).First(&news, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("news item not found with id %s", id.String())
+			return nil, domainerrors.NotFound("news item not found with id %s", id.String())
 		}
 		return nil, fmt.Errorf("failed to get news item by id: %w", err)
 	}
@@ -0,0 +1,135 @@
+package implementation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/tenancy"
+)
+
+// noteRepositoryImpl implements the NoteRepository interface using GORM
+type noteRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewNoteRepository creates a new note repository implementation
+func NewNoteRepository(db *gorm.DB) interfaces.NoteRepository {
+	return &noteRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create stores a note of the user of the context
+func (r *noteRepositoryImpl) Create(ctx context.Context, note *entities.Note) error {
+	tenantID, author, err := tenancy.Owner(ctx)
+	if err != nil {
+		return err
+	}
+	note.TenantID, note.Author = tenantID, author
+
+	if err := r.db.WithContext(ctx).Create(note).Error; err != nil {
+		return translateDBError(err, "failed to create note")
+	}
+	return nil
+}
+
+// GetByID retrieves a note of the user of the context
+func (r *noteRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*entities.Note, error) {
+	query, err := ownerScoped(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	var note entities.Note
+	if err := query.Where("id = ?", id).First(&note).Error; err != nil {
+		return nil, translateDBError(err, "note with id %s", id)
+	}
+	return &note, nil
+}
+
+// List retrieves the notes of the user of the context, newest first
+func (r *noteRepositoryImpl) List(ctx context.Context, filter interfaces.NoteListFilter, limit, offset int) ([]*entities.Note, int64, error) {
+	query, err := ownerScoped(ctx, r.db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query = query.Model(&entities.Note{})
+	if filter.CompanyID != nil {
+		query = query.Where("company_id = ?", *filter.CompanyID)
+	}
+	if filter.RatingID != nil {
+		query = query.Where("rating_id = ?", *filter.RatingID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count notes: %w", err)
+	}
+
+	var notes []*entities.Note
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Order("created_at DESC, id").Offset(offset).Find(&notes).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list notes: %w", err)
+	}
+	return notes, total, nil
+}
+
+// GetByRatingIDs retrieves the notes of the user of the context on the given ratings, newest first
+func (r *noteRepositoryImpl) GetByRatingIDs(ctx context.Context, ratingIDs []uuid.UUID) ([]*entities.Note, error) {
+	query, err := ownerScoped(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	if len(ratingIDs) == 0 {
+		return []*entities.Note{}, nil
+	}
+
+	var notes []*entities.Note
+	if err := query.Where("rating_id IN ?", ratingIDs).Order("created_at DESC, id").Find(&notes).Error; err != nil {
+		return nil, fmt.Errorf("failed to get rating notes: %w", err)
+	}
+	return notes, nil
+}
+
+// Update stores the body of a note of the user of the context
+func (r *noteRepositoryImpl) Update(ctx context.Context, note *entities.Note) error {
+	query, err := ownerScoped(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	result := query.Model(note).Where("id = ?", note.ID).Update("body", note.Body)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update note %s: %w", note.ID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.NotFound("note with id %s", note.ID)
+	}
+	return nil
+}
+
+// Delete removes a note of the user of the context
+func (r *noteRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	query, err := ownerScoped(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	result := query.Where("id = ?", id).Delete(&entities.Note{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete note %s: %w", id, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.NotFound("note with id %s", id)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package implementation
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+)
+
+// updateWithVersion saves every column of the entity only if the stored version still matches
+// the one that was read, incrementing it. It returns NotFound when the record does not exist
+// and VersionMismatch when someone else updated it first.
+func updateWithVersion(db *gorm.DB, entity interface{}, id uuid.UUID, version *int64, resource string) error {
+	expectedVersion := *version
+	*version = expectedVersion + 1
+
+	// Select("*") guarda también los valores cero; a diferencia de Save, nunca hace upsert
+	result := db.Model(entity).
+		Select("*").
+		Omit(clause.Associations).
+		Where("version = ?", expectedVersion).
+		Updates(entity)
+	if result.Error != nil {
+		*version = expectedVersion
+		return translateDBError(result.Error, "failed to update %s", resource)
+	}
+
+	if result.RowsAffected > 0 {
+		return nil
+	}
+	*version = expectedVersion
+
+	// Sin filas afectadas: el registro no existe o su versión ya cambió
+	var count int64
+	if err := db.Model(entity).Where("id = ?", id).Count(&count).Error; err != nil {
+		return translateDBError(err, "failed to check %s version", resource)
+	}
+	if count == 0 {
+		return domainerrors.NotFound("%s with id %s not found for update", resource, id)
+	}
+
+	return domainerrors.VersionMismatch("%s with id %s was modified concurrently (expected version %d)", resource, id, expectedVersion)
+}
@@ -0,0 +1,172 @@
+package implementation
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+)
+
+// outboxDeleteBatchSize limita las filas borradas por sentencia al limpiar el outbox
+const outboxDeleteBatchSize = 1000
+
+// outboxRepositoryImpl implements the OutboxRepository interface using GORM
+type outboxRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewOutboxRepository creates a new outbox repository implementation
+func NewOutboxRepository(db *gorm.DB) interfaces.OutboxRepository {
+	return &outboxRepositoryImpl{
+		db: db,
+	}
+}
+
+// ========================================
+// CREATE OPERATIONS
+// ========================================
+
+// Add stores events in their own transaction
+func (r *outboxRepositoryImpl) Add(ctx context.Context, events []*entities.OutboxEvent) error {
+	return r.AddWithTx(ctx, r.db, events)
+}
+
+// AddWithTx stores events using the provided transaction
+func (r *outboxRepositoryImpl) AddWithTx(ctx context.Context, tx *gorm.DB, events []*entities.OutboxEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	if err := tx.WithContext(ctx).CreateInBatches(events, bulkInsertChunkSize).Error; err != nil {
+		return fmt.Errorf("failed to add %d events to the outbox: %w", len(events), err)
+	}
+	return nil
+}
+
+// ========================================
+// READ OPERATIONS
+// ========================================
+
+// ClaimPending reserves the unpublished events due for an attempt, oldest first. FOR UPDATE SKIP LOCKED keeps two
+// relays from claiming the same rows at once, and moving next_attempt_at lease ahead keeps them reserved after the
+// statement commits; a relay that dies before publishing releases them when the lease expires
+func (r *outboxRepositoryImpl) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*entities.OutboxEvent, error) {
+	var events []*entities.OutboxEvent
+
+	now := time.Now().UTC()
+	err := r.db.WithContext(ctx).Raw(`
+		UPDATE event_outbox SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT id FROM event_outbox
+			WHERE published_at IS NULL AND next_attempt_at <= ?
+			ORDER BY created_at ASC, id ASC
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`, now.Add(lease), now, limit).
+		Scan(&events).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim pending outbox events: %w", err)
+	}
+
+	// RETURNING no garantiza orden: se restaura el orden de escritura
+	slices.SortFunc(events, func(a, b *entities.OutboxEvent) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), bytes.Compare(a.ID[:], b.ID[:]))
+	})
+	return events, nil
+}
+
+// CountPending counts the unpublished events, including those waiting for a retry
+func (r *outboxRepositoryImpl) CountPending(ctx context.Context) (int64, error) {
+	var count int64
+
+	err := r.db.WithContext(ctx).Model(&entities.OutboxEvent{}).Where("published_at IS NULL").Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count pending outbox events: %w", err)
+	}
+
+	return count, nil
+}
+
+// ========================================
+// DELIVERY OPERATIONS
+// ========================================
+
+// MarkPublished marks the events as published
+func (r *outboxRepositoryImpl) MarkPublished(ctx context.Context, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	err := r.db.WithContext(ctx).Model(&entities.OutboxEvent{}).
+		Where("id IN ? AND published_at IS NULL", ids).
+		Updates(map[string]interface{}{
+			"published_at": time.Now().UTC(),
+			"last_error":   nil,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark %d outbox events as published: %w", len(ids), err)
+	}
+	return nil
+}
+
+// MarkFailed records a failed delivery attempt and when the event may be retried
+func (r *outboxRepositoryImpl) MarkFailed(ctx context.Context, id uuid.UUID, cause string, nextAttemptAt time.Time) error {
+	err := r.db.WithContext(ctx).Model(&entities.OutboxEvent{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"last_error":      cause,
+			"next_attempt_at": nextAttemptAt.UTC(),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to record outbox delivery failure: %w", err)
+	}
+	return nil
+}
+
+// Release makes claimed events that were not attempted due again at nextAttemptAt
+func (r *outboxRepositoryImpl) Release(ctx context.Context, ids []uuid.UUID, nextAttemptAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	err := r.db.WithContext(ctx).Model(&entities.OutboxEvent{}).
+		Where("id IN ? AND published_at IS NULL", ids).
+		Update("next_attempt_at", nextAttemptAt.UTC()).Error
+	if err != nil {
+		return fmt.Errorf("failed to release %d outbox events: %w", len(ids), err)
+	}
+	return nil
+}
+
+// ========================================
+// MAINTENANCE OPERATIONS
+// ========================================
+
+// DeletePublishedBefore removes the events published before the given time in batches
+func (r *outboxRepositoryImpl) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	var deleted int64
+
+	for {
+		result := r.db.WithContext(ctx).Exec(
+			`DELETE FROM event_outbox WHERE published_at IS NOT NULL AND published_at < ? LIMIT ?`,
+			before.UTC(), outboxDeleteBatchSize)
+		if result.Error != nil {
+			return deleted, fmt.Errorf("failed to delete published outbox events: %w", result.Error)
+		}
+
+		deleted += result.RowsAffected
+		if result.RowsAffected < outboxDeleteBatchSize {
+			return deleted, nil
+		}
+	}
+}
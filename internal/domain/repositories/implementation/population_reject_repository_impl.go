@@ -0,0 +1,172 @@
+package implementation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+)
+
+// populationRejectRepositoryImpl implements the PopulationRejectRepository interface using GORM
+type populationRejectRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewPopulationRejectRepository creates a new population reject repository implementation
+func NewPopulationRejectRepository(db *gorm.DB) interfaces.PopulationRejectRepository {
+	return &populationRejectRepositoryImpl{
+		db: db,
+	}
+}
+
+// ========================================
+// CREATE OPERATIONS
+// ========================================
+
+// Record stores rejects; when the same payload was already rejected it bumps its occurrences
+// and moves it back to pending unless it was discarded. Repeated payloads within the batch are merged first
+func (r *populationRejectRepositoryImpl) Record(ctx context.Context, rejects []*entities.PopulationReject) error {
+	if len(rejects) == 0 {
+		return nil
+	}
+	rejects = entities.MergeRejectsByFingerprint(rejects)
+
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "fingerprint"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"occurrences":  gorm.Expr("population_rejects.occurrences + excluded.occurrences"),
+			"last_seen_at": gorm.Expr("excluded.last_seen_at"),
+			"reason":       gorm.Expr("excluded.reason"),
+			"error":        gorm.Expr("excluded.error"),
+			"status": gorm.Expr("CASE WHEN population_rejects.status = ? THEN population_rejects.status ELSE ? END",
+				entities.PopulationRejectStatusDiscarded, entities.PopulationRejectStatusPending),
+			"updated_at": time.Now().UTC(),
+		}),
+	}).Create(&rejects).Error
+	if err != nil {
+		return fmt.Errorf("failed to record population rejects: %w", err)
+	}
+
+	return nil
+}
+
+// ========================================
+// READ OPERATIONS
+// ========================================
+
+// GetByID retrieves a reject by its ID
+func (r *populationRejectRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*entities.PopulationReject, error) {
+	var reject entities.PopulationReject
+
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&reject).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.NotFound("population reject with id %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get population reject by id: %w", err)
+	}
+
+	return &reject, nil
+}
+
+// GetByIDs retrieves the rejects matching the given IDs; unknown IDs are ignored
+func (r *populationRejectRepositoryImpl) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entities.PopulationReject, error) {
+	var rejects []*entities.PopulationReject
+
+	if len(ids) == 0 {
+		return rejects, nil
+	}
+
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Order("created_at ASC").Find(&rejects).Error; err != nil {
+		return nil, fmt.Errorf("failed to get population rejects by ids: %w", err)
+	}
+
+	return rejects, nil
+}
+
+// List retrieves rejects ordered by last occurrence, optionally filtered by status
+func (r *populationRejectRepositoryImpl) List(ctx context.Context, status entities.PopulationRejectStatus, limit, offset int) ([]*entities.PopulationReject, error) {
+	var rejects []*entities.PopulationReject
+
+	query := r.db.WithContext(ctx).Order("last_seen_at DESC").Limit(limit).Offset(offset)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Find(&rejects).Error; err != nil {
+		return nil, fmt.Errorf("failed to list population rejects: %w", err)
+	}
+
+	return rejects, nil
+}
+
+// Count returns the number of rejects, optionally filtered by status
+func (r *populationRejectRepositoryImpl) Count(ctx context.Context, status entities.PopulationRejectStatus) (int64, error) {
+	var count int64
+
+	query := r.db.WithContext(ctx).Model(&entities.PopulationReject{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count population rejects: %w", err)
+	}
+
+	return count, nil
+}
+
+// ========================================
+// REPROCESS OPERATIONS
+// ========================================
+
+// MarkReprocessed marks a reject as successfully ingested
+func (r *populationRejectRepositoryImpl) MarkReprocessed(ctx context.Context, id uuid.UUID) error {
+	now := time.Now().UTC()
+	return r.update(ctx, id, map[string]interface{}{
+		"status":          entities.PopulationRejectStatusReprocessed,
+		"attempts":        gorm.Expr("attempts + 1"),
+		"last_attempt_at": now,
+		"resolved_at":     now,
+		"error":           "",
+	})
+}
+
+// MarkDiscarded marks a reject as discarded so it is no longer reprocessed
+func (r *populationRejectRepositoryImpl) MarkDiscarded(ctx context.Context, id uuid.UUID) error {
+	return r.update(ctx, id, map[string]interface{}{
+		"status":      entities.PopulationRejectStatusDiscarded,
+		"resolved_at": time.Now().UTC(),
+	})
+}
+
+// RecordFailedAttempt keeps a reject pending and stores the error of the last reprocess attempt
+func (r *populationRejectRepositoryImpl) RecordFailedAttempt(ctx context.Context, id uuid.UUID, errMsg string) error {
+	return r.update(ctx, id, map[string]interface{}{
+		"attempts":        gorm.Expr("attempts + 1"),
+		"last_attempt_at": time.Now().UTC(),
+		"error":           errMsg,
+	})
+}
+
+// update applies the given changes to a reject
+func (r *populationRejectRepositoryImpl) update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	result := r.db.WithContext(ctx).Model(&entities.PopulationReject{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update population reject: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return domainerrors.NotFound("population reject with id %s not found", id)
+	}
+
+	return nil
+}
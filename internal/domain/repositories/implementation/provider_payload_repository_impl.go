@@ -0,0 +1,118 @@
+package implementation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+)
+
+// providerPayloadRepositoryImpl implements the ProviderPayloadRepository interface using GORM
+type providerPayloadRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewProviderPayloadRepository creates a new provider payload repository implementation
+func NewProviderPayloadRepository(db *gorm.DB) interfaces.ProviderPayloadRepository {
+	return &providerPayloadRepositoryImpl{
+		db: db,
+	}
+}
+
+// ========================================
+// CREATE OPERATIONS
+// ========================================
+
+// Create stores an archived payload
+func (r *providerPayloadRepositoryImpl) Create(ctx context.Context, payload *entities.ProviderPayload) error {
+	if err := r.db.WithContext(ctx).Create(payload).Error; err != nil {
+		return fmt.Errorf("failed to create provider payload: %w", err)
+	}
+	return nil
+}
+
+// ========================================
+// READ OPERATIONS
+// ========================================
+
+// GetByID retrieves an unexpired payload by its ID
+func (r *providerPayloadRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*entities.ProviderPayload, error) {
+	var payload entities.ProviderPayload
+
+	err := r.db.WithContext(ctx).Where("id = ? AND expires_at > ?", id, time.Now().UTC()).First(&payload).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.NotFound("provider payload with id %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get provider payload by id: %w", err)
+	}
+
+	return &payload, nil
+}
+
+// GetByIDs retrieves the unexpired payloads matching the given IDs, oldest first; unknown IDs are ignored
+func (r *providerPayloadRepositoryImpl) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entities.ProviderPayload, error) {
+	var payloads []*entities.ProviderPayload
+
+	if len(ids) == 0 {
+		return payloads, nil
+	}
+
+	err := r.db.WithContext(ctx).
+		Where("id IN ? AND expires_at > ?", ids, time.Now().UTC()).
+		Order("fetched_at ASC").
+		Find(&payloads).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get provider payloads by ids: %w", err)
+	}
+
+	return payloads, nil
+}
+
+// List retrieves the payloads matching the filter, newest first, without the compressed body
+func (r *providerPayloadRepositoryImpl) List(ctx context.Context, filter interfaces.ProviderPayloadFilter, limit, offset int) ([]*entities.ProviderPayload, error) {
+	var payloads []*entities.ProviderPayload
+
+	query := r.filtered(ctx, filter).Omit("payload").Order("fetched_at DESC").Limit(limit).Offset(offset)
+	if err := query.Find(&payloads).Error; err != nil {
+		return nil, fmt.Errorf("failed to list provider payloads: %w", err)
+	}
+
+	return payloads, nil
+}
+
+// Count returns the number of payloads matching the filter
+func (r *providerPayloadRepositoryImpl) Count(ctx context.Context, filter interfaces.ProviderPayloadFilter) (int64, error) {
+	var count int64
+
+	if err := r.filtered(ctx, filter).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count provider payloads: %w", err)
+	}
+
+	return count, nil
+}
+
+// filtered aplica el filtro y descarta los payloads caducados que el TTL aún no ha borrado
+func (r *providerPayloadRepositoryImpl) filtered(ctx context.Context, filter interfaces.ProviderPayloadFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&entities.ProviderPayload{}).Where("expires_at > ?", time.Now().UTC())
+	if filter.Provider != "" {
+		query = query.Where("provider = ?", filter.Provider)
+	}
+	if filter.Endpoint != "" {
+		query = query.Where("endpoint = ?", filter.Endpoint)
+	}
+	if filter.Symbol != "" {
+		query = query.Where("symbol = ?", filter.Symbol)
+	}
+	if !filter.Since.IsZero() {
+		query = query.Where("fetched_at >= ?", filter.Since)
+	}
+	return query
+}
@@ -0,0 +1,81 @@
+package implementation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/services"
+)
+
+// queryCachePrefix agrupa las claves de las consultas cacheadas de los repositorios
+const queryCachePrefix = "repo:"
+
+// queryCache stores serialized results of repository reads under a per-entity namespace,
+// so a write to the entity can drop every cached query of that entity at once
+type queryCache struct {
+	cache     services.CacheService
+	namespace string
+	ttl       time.Duration
+}
+
+func newQueryCache(cache services.CacheService, entity string, ttl time.Duration) *queryCache {
+	return &queryCache{
+		cache:     cache,
+		namespace: queryCachePrefix + entity + ":",
+		ttl:       ttl,
+	}
+}
+
+// key builds the cache key for a method call; args are normalized so "aapl" and "AAPL " share the entry
+func (q *queryCache) key(method string, args ...interface{}) string {
+	parts := make([]string, 0, len(args)+1)
+	parts = append(parts, method)
+	for _, arg := range args {
+		parts = append(parts, strings.ToUpper(strings.TrimSpace(fmt.Sprint(arg))))
+	}
+	return q.namespace + strings.Join(parts, ":")
+}
+
+// invalidate drops every cached query of the entity. Un fallo de la cache no debe romper la
+// escritura que ya se confirmó en la base de datos; las entradas caducan igualmente con el TTL.
+func (q *queryCache) invalidate(ctx context.Context) {
+	if err := q.cache.DeleteByPrefix(ctx, q.namespace); err != nil {
+		log.Printf("⚠️  Failed to invalidate query cache %s: %v", q.namespace, err)
+	}
+}
+
+// invalidateKey drops a single cached query. Se borra por prefijo, así que también caen las claves
+// que empiezan igual (p. ej. "AAPL" y "AAPLX"); eso solo cuesta un miss.
+func (q *queryCache) invalidateKey(ctx context.Context, key string) {
+	if err := q.cache.DeleteByPrefix(ctx, key); err != nil {
+		log.Printf("⚠️  Failed to invalidate cached query %s: %v", key, err)
+	}
+}
+
+// cachedQuery returns the cached result for key or runs load and caches its result.
+// Errors from load are never cached, and cache failures fall back to the database.
+func cachedQuery[T any](ctx context.Context, q *queryCache, key string, load func() (T, error)) (T, error) {
+	if data, err := q.cache.Get(ctx, key); err == nil && data != nil {
+		var cached T
+		if err := json.Unmarshal(data, &cached); err == nil {
+			return cached, nil
+		}
+	}
+
+	result, err := load()
+	if err != nil {
+		return result, err
+	}
+
+	if data, err := json.Marshal(result); err == nil {
+		if err := q.cache.Set(ctx, key, data, q.ttl); err != nil {
+			log.Printf("⚠️  Failed to cache query %s: %v", key, err)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,72 @@
+package implementation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+)
+
+// quoteSnapshotRepositoryImpl implements the QuoteSnapshotRepository interface using GORM
+type quoteSnapshotRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewQuoteSnapshotRepository creates a new quote snapshot repository implementation
+func NewQuoteSnapshotRepository(db *gorm.DB) interfaces.QuoteSnapshotRepository {
+	return &quoteSnapshotRepositoryImpl{
+		db: db,
+	}
+}
+
+// Append stores a snapshot and prunes the symbol's snapshots beyond capacity, oldest first
+func (r *quoteSnapshotRepositoryImpl) Append(ctx context.Context, snapshot *entities.QuoteSnapshot, capacity int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "symbol"}, {Name: "market_timestamp"}},
+			DoNothing: true,
+		}).Create(snapshot).Error
+		if err != nil {
+			return fmt.Errorf("failed to store quote snapshot for %s: %w", snapshot.Symbol, err)
+		}
+
+		if capacity <= 0 {
+			return nil
+		}
+
+		// Los timestamps son únicos por símbolo: todo lo anterior al capacity-ésimo más reciente sobra.
+		// Con menos snapshots la subconsulta no devuelve filas y no se borra nada
+		err = tx.Exec(`DELETE FROM quote_snapshots
+			WHERE symbol = ? AND market_timestamp < (
+				SELECT market_timestamp FROM quote_snapshots
+				WHERE symbol = ?
+				ORDER BY market_timestamp DESC
+				OFFSET ? LIMIT 1
+			)`, snapshot.Symbol, snapshot.Symbol, capacity-1).Error
+		if err != nil {
+			return fmt.Errorf("failed to prune quote snapshots for %s: %w", snapshot.Symbol, err)
+		}
+
+		return nil
+	})
+}
+
+// GetBySymbolSince retrieves the snapshots of a symbol taken on or after since, oldest first
+func (r *quoteSnapshotRepositoryImpl) GetBySymbolSince(ctx context.Context, symbol string, since time.Time) ([]*entities.QuoteSnapshot, error) {
+	var snapshots []*entities.QuoteSnapshot
+
+	err := r.db.WithContext(ctx).
+		Where("symbol = ? AND market_timestamp >= ?", symbol, since).
+		Order("market_timestamp ASC").
+		Find(&snapshots).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quote snapshots for %s: %w", symbol, err)
+	}
+
+	return snapshots, nil
+}
@@ -0,0 +1,121 @@
+package implementation
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+)
+
+// ratingKeySQL calcula la clave de rating_mappings de una columna, igual que entities.RatingMappingKey
+const ratingKeySQL = `regexp_replace(lower(trim(%s)), '\s+', ' ', 'g')`
+
+// ratingMappingRepositoryImpl implements the RatingMappingRepository interface using GORM
+type ratingMappingRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewRatingMappingRepository creates a new rating mapping repository implementation
+func NewRatingMappingRepository(db *gorm.DB) interfaces.RatingMappingRepository {
+	return &ratingMappingRepositoryImpl{
+		db: db,
+	}
+}
+
+// List returns every mapping ordered by canonical rating and variant
+func (r *ratingMappingRepositoryImpl) List(ctx context.Context) ([]*entities.RatingMapping, error) {
+	var mappings []*entities.RatingMapping
+	if err := r.db.WithContext(ctx).Order("normalized, raw_rating").Find(&mappings).Error; err != nil {
+		return nil, fmt.Errorf("failed to list rating mappings: %w", err)
+	}
+	return mappings, nil
+}
+
+// Upsert creates the mapping of a variant or changes its canonical rating
+func (r *ratingMappingRepositoryImpl) Upsert(ctx context.Context, mapping *entities.RatingMapping) error {
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "raw_rating"}},
+		DoUpdates: clause.AssignmentColumns([]string{"normalized", "updated_at"}),
+	}).Create(mapping).Error
+	if err != nil {
+		return fmt.Errorf("failed to save rating mapping %q: %w", mapping.Rating, err)
+	}
+	return nil
+}
+
+// Delete removes the mapping of a variant; its ratings stay unmapped until ApplyToRatings
+func (r *ratingMappingRepositoryImpl) Delete(ctx context.Context, rating string) error {
+	result := r.db.WithContext(ctx).Where("raw_rating = ?", rating).Delete(&entities.RatingMapping{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete rating mapping %q: %w", rating, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.NotFound("rating mapping %q", rating)
+	}
+	return nil
+}
+
+// ApplyToRatings actualiza en la BD las columnas normalizadas que no coinciden con la tabla de mapeo,
+// incluidas las de variantes que ya no tienen mapeo; solo escribe las filas que cambian
+func (r *ratingMappingRepositoryImpl) ApplyToRatings(ctx context.Context) (int64, error) {
+	var changed int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, columns := range [][2]string{
+			{"rating_from", "normalized_rating_from"},
+			{"rating_to", "normalized_rating_to"},
+		} {
+			key := fmt.Sprintf(ratingKeySQL, "stock_ratings."+columns[0])
+
+			mapped := tx.Exec(fmt.Sprintf(`
+				UPDATE stock_ratings SET %[1]s = m.normalized, updated_at = now()
+				FROM rating_mappings m
+				WHERE m.raw_rating = %[2]s AND stock_ratings.%[1]s IS DISTINCT FROM m.normalized`,
+				columns[1], key))
+			if mapped.Error != nil {
+				return fmt.Errorf("failed to normalize %s: %w", columns[0], mapped.Error)
+			}
+
+			unmapped := tx.Exec(fmt.Sprintf(`
+				UPDATE stock_ratings SET %[1]s = NULL, updated_at = now()
+				WHERE COALESCE(%[1]s, '') <> ''
+				AND NOT EXISTS (SELECT 1 FROM rating_mappings m WHERE m.raw_rating = %[2]s)`,
+				columns[1], key))
+			if unmapped.Error != nil {
+				return fmt.Errorf("failed to clear unmapped %s: %w", columns[0], unmapped.Error)
+			}
+
+			changed += mapped.RowsAffected + unmapped.RowsAffected
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return changed, nil
+}
+
+// Unmapped agrupa las variantes de rating_from y rating_to sin mapeo de los ratings no borrados
+func (r *ratingMappingRepositoryImpl) Unmapped(ctx context.Context, limit int) ([]interfaces.UnmappedRating, error) {
+	var variants []interfaces.UnmappedRating
+	err := r.db.WithContext(ctx).Raw(fmt.Sprintf(`
+		SELECT rating, COUNT(*) AS count FROM (
+			SELECT %s AS rating FROM stock_ratings
+			WHERE deleted_at IS NULL AND COALESCE(rating_from, '') <> '' AND COALESCE(normalized_rating_from, '') = ''
+			UNION ALL
+			SELECT %s AS rating FROM stock_ratings
+			WHERE deleted_at IS NULL AND COALESCE(rating_to, '') <> '' AND COALESCE(normalized_rating_to, '') = ''
+		) AS variants
+		GROUP BY rating
+		ORDER BY count DESC, rating
+		LIMIT ?`, fmt.Sprintf(ratingKeySQL, "rating_from"), fmt.Sprintf(ratingKeySQL, "rating_to")), limit).
+		Scan(&variants).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unmapped ratings: %w", err)
+	}
+	return variants, nil
+}
@@ -0,0 +1,135 @@
+package implementation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+)
+
+// Configuración de texto de las columnas search_vector (migración 000009)
+const searchTextConfig = "english"
+
+// tickerMatchBoost sube al principio las companies cuyo ticker coincide exactamente con la búsqueda
+const tickerMatchBoost = 1.0
+
+// searchRepositoryImpl implements the SearchRepository interface using GORM
+type searchRepositoryImpl struct {
+	reader *gorm.DB
+}
+
+// NewSearchRepository creates a new search repository; searches go to reader
+func NewSearchRepository(reader *gorm.DB) interfaces.SearchRepository {
+	return &searchRepositoryImpl{
+		reader: reader,
+	}
+}
+
+// Search runs the query against the requested types and merges the hits by rank
+func (r *searchRepositoryImpl) Search(ctx context.Context, query string, types []string, limit int) ([]interfaces.SearchHit, error) {
+	tsQuery := BuildPrefixTSQuery(query)
+	if tsQuery == "" {
+		return []interfaces.SearchHit{}, nil
+	}
+
+	hits := make([]interfaces.SearchHit, 0)
+	if searchIncludes(types, interfaces.SearchTypeCompany) {
+		companies, err := r.searchCompanies(ctx, tsQuery, strings.TrimSpace(query), limit)
+		if err != nil {
+			return nil, err
+		}
+		hits = append(hits, companies...)
+	}
+	if searchIncludes(types, interfaces.SearchTypeNews) {
+		news, err := r.searchNews(ctx, tsQuery, limit)
+		if err != nil {
+			return nil, err
+		}
+		hits = append(hits, news...)
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].Rank > hits[j].Rank
+	})
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	return hits, nil
+}
+
+func (r *searchRepositoryImpl) searchCompanies(ctx context.Context, tsQuery, rawQuery string, limit int) ([]interfaces.SearchHit, error) {
+	var hits []interfaces.SearchHit
+
+	query := r.reader.WithContext(ctx).
+		Table("companies").
+		Select(`'company' AS type, id, ticker AS symbol, name AS title, COALESCE(sector, '') AS snippet,
+			ts_rank(search_vector, to_tsquery(?, ?)) + CASE WHEN upper(ticker) = upper(?) THEN ? ELSE 0 END AS rank`,
+			searchTextConfig, tsQuery, rawQuery, tickerMatchBoost).
+		Where("search_vector @@ to_tsquery(?, ?) AND is_active = ? AND deleted_at IS NULL", searchTextConfig, tsQuery, true).
+		Order("rank DESC")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Scan(&hits).Error; err != nil {
+		return nil, fmt.Errorf("failed to search companies: %w", err)
+	}
+
+	return hits, nil
+}
+
+func (r *searchRepositoryImpl) searchNews(ctx context.Context, tsQuery string, limit int) ([]interfaces.SearchHit, error) {
+	var hits []interfaces.SearchHit
+
+	query := r.reader.WithContext(ctx).
+		Table("news_items").
+		Select(`'news' AS type, id, symbol, title, COALESCE(summary, '') AS snippet, url, published_at,
+			ts_rank(search_vector, to_tsquery(?, ?)) AS rank`, searchTextConfig, tsQuery).
+		Where("search_vector @@ to_tsquery(?, ?) AND deleted_at IS NULL", searchTextConfig, tsQuery).
+		Order("rank DESC, published_at DESC")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Scan(&hits).Error; err != nil {
+		return nil, fmt.Errorf("failed to search news: %w", err)
+	}
+
+	return hits, nil
+}
+
+// BuildPrefixTSQuery converts free text into a tsquery that requires every word as a prefix
+// ("appl inc" -> "appl:* & inc:*"). Punctuation is dropped, so the result is always valid tsquery syntax.
+func BuildPrefixTSQuery(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := make([]string, len(words))
+	for i, word := range words {
+		terms[i] = word + ":*"
+	}
+
+	return strings.Join(terms, " & ")
+}
+
+// searchIncludes indica si el tipo se busca; sin tipos se buscan todos
+func searchIncludes(types []string, searchType string) bool {
+	if len(types) == 0 {
+		return true
+	}
+	for _, t := range types {
+		if t == searchType {
+			return true
+		}
+	}
+	return false
+}
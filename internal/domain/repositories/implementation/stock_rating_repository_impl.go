@@ -10,26 +10,46 @@ import (
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
 	"github.com/MayaCris/stock-info-app/internal/domain/entities"
 	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/timezone"
 )
 
+// uniqueRatingConstraint is the unique index on (company_id, brokerage_id, event_time)
+const uniqueRatingConstraint = "unique_rating_per_company_brokerage_time"
+
+// bulkInsertChunkSize limits rows per multi-row INSERT (12 params per row, well below the 65535 placeholder limit)
+const bulkInsertChunkSize = 500
+
 // stockRatingRepositoryImpl implements the StockRatingRepository interface using GORM
 type stockRatingRepositoryImpl struct {
-	db *gorm.DB
+	db     *gorm.DB
+	reader *gorm.DB // Listados, conteos y analíticas; puede apuntar a réplicas de lectura
 }
 
 // NewStockRatingRepository creates a new stock rating repository implementation
 func NewStockRatingRepository(db *gorm.DB) interfaces.StockRatingRepository {
 	return &stockRatingRepositoryImpl{
-		db: db,
+		db:     db,
+		reader: db,
+	}
+}
+
+// NewStockRatingRepositoryWithReader creates a stock rating repository whose read-only list and analytics
+// queries go to reader (e.g. read replicas) while writes and lookups by key stay on db
+func NewStockRatingRepositoryWithReader(db, reader *gorm.DB) interfaces.StockRatingRepository {
+	return &stockRatingRepositoryImpl{
+		db:     db,
+		reader: reader,
 	}
 }
 
 // NewTransactionalStockRatingRepository creates a new transactional stock rating repository implementation
 func NewTransactionalStockRatingRepository(db *gorm.DB) interfaces.TransactionalStockRatingRepository {
 	return &stockRatingRepositoryImpl{
-		db: db,
+		db:     db,
+		reader: db,
 	}
 }
 
@@ -41,11 +61,11 @@ func NewTransactionalStockRatingRepository(db *gorm.DB) interfaces.Transactional
 func (r *stockRatingRepositoryImpl) Create(ctx context.Context, rating *entities.StockRating) error {
 	if err := r.db.WithContext(ctx).Create(rating).Error; err != nil {
 		// Handle unique constraint violation
-		if strings.Contains(err.Error(), "unique_rating_per_company_brokerage_time") {
-			return fmt.Errorf("rating already exists for company %s, brokerage %s at time %s",
+		if isUniqueViolation(err, uniqueRatingConstraint) {
+			return domainerrors.Duplicate(err, "rating already exists for company %s, brokerage %s at time %s",
 				rating.CompanyID, rating.BrokerageID, rating.EventTime)
 		}
-		return fmt.Errorf("failed to create stock rating: %w", err)
+		return translateDBError(err, "failed to create stock rating")
 	}
 	return nil
 }
@@ -60,7 +80,7 @@ func (r *stockRatingRepositoryImpl) CreateMany(ctx context.Context, ratings []*e
 		for _, rating := range ratings {
 			if err := tx.Create(rating).Error; err != nil {
 				// Skip duplicates but log them
-				if strings.Contains(err.Error(), "unique_rating_per_company_brokerage_time") {
+				if isUniqueViolation(err, uniqueRatingConstraint) {
 					continue // Skip duplicate, don't fail the entire batch
 				}
 				return fmt.Errorf("failed to create stock rating in batch: %w", err)
@@ -81,7 +101,22 @@ func (r *stockRatingRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (
 	err := r.db.WithContext(ctx).Where("id = ?", id).First(&rating).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("stock rating with id %s not found", id)
+			return nil, domainerrors.NotFound("stock rating with id %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get stock rating by id: %w", err)
+	}
+
+	return &rating, nil
+}
+
+// GetByIDWithDeleted retrieves a stock rating by its ID, including soft-deleted ones
+func (r *stockRatingRepositoryImpl) GetByIDWithDeleted(ctx context.Context, id uuid.UUID) (*entities.StockRating, error) {
+	var rating entities.StockRating
+
+	err := r.db.WithContext(ctx).Unscoped().Where("id = ?", id).First(&rating).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.NotFound("stock rating with id %s not found", id)
 		}
 		return nil, fmt.Errorf("failed to get stock rating by id: %w", err)
 	}
@@ -93,7 +128,7 @@ func (r *stockRatingRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (
 func (r *stockRatingRepositoryImpl) GetAll(ctx context.Context) ([]*entities.StockRating, error) {
 	var ratings []*entities.StockRating
 
-	err := r.db.WithContext(ctx).Order("event_time DESC").Find(&ratings).Error
+	err := r.reader.WithContext(ctx).Order("event_time DESC").Find(&ratings).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all stock ratings: %w", err)
 	}
@@ -154,7 +189,7 @@ func (r *stockRatingRepositoryImpl) GetByCompanyAndBrokerage(ctx context.Context
 func (r *stockRatingRepositoryImpl) GetByEventTimeRange(ctx context.Context, startTime, endTime time.Time) ([]*entities.StockRating, error) {
 	var ratings []*entities.StockRating
 
-	err := r.db.WithContext(ctx).
+	err := r.reader.WithContext(ctx).
 		Where("event_time >= ? AND event_time <= ?", startTime, endTime).
 		Order("event_time DESC").
 		Find(&ratings).Error
@@ -180,13 +215,38 @@ func (r *stockRatingRepositoryImpl) GetByCompanyAndDateRange(ctx context.Context
 	return ratings, nil
 }
 
+// GetLatestByBrokerageAsOf retrieves the latest rating of each brokerage for the company before the given time.
+// DISTINCT ON keeps the first row of each brokerage_id in event_time DESC order, which the unique
+// (company_id, brokerage_id, event_time) index serves; the outer query orders the snapshot newest first
+func (r *stockRatingRepositoryImpl) GetLatestByBrokerageAsOf(ctx context.Context, companyID uuid.UUID, before time.Time) ([]*entities.StockRating, error) {
+	var ratings []*entities.StockRating
+
+	latest := r.reader.WithContext(ctx).
+		Model(&entities.StockRating{}).
+		Select("DISTINCT ON (brokerage_id) *").
+		Where("company_id = ? AND event_time < ?", companyID, before).
+		Order("brokerage_id, event_time DESC")
+
+	err := r.reader.WithContext(ctx).
+		Table("(?) AS stock_ratings", latest).
+		Preload("Company").
+		Preload("Brokerage").
+		Order("event_time DESC, brokerage_id").
+		Find(&ratings).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest ratings by brokerage: %w", err)
+	}
+
+	return ratings, nil
+}
+
 // GetRecent retrieves recent ratings from the last N days
 func (r *stockRatingRepositoryImpl) GetRecent(ctx context.Context, days int, limit int) ([]*entities.StockRating, error) {
 	var ratings []*entities.StockRating
 
 	cutoffTime := time.Now().AddDate(0, 0, -days)
 
-	query := r.db.WithContext(ctx).
+	query := r.reader.WithContext(ctx).
 		Where("event_time >= ?", cutoffTime).
 		Order("event_time DESC")
 
@@ -202,31 +262,52 @@ func (r *stockRatingRepositoryImpl) GetRecent(ctx context.Context, days int, lim
 	return ratings, nil
 }
 
+// ListCreatedAfter retrieves the ratings created after the (afterCreatedAt, afterID) cursor, oldest first. Reads
+// the primary: the long-poll runs right after a rating.created event and a lagging replica would miss the rating
+func (r *stockRatingRepositoryImpl) ListCreatedAfter(ctx context.Context, afterCreatedAt time.Time, afterID uuid.UUID, limit int) ([]*entities.StockRating, error) {
+	var ratings []*entities.StockRating
+
+	query := r.db.WithContext(ctx).
+		Where("(created_at, id) > (?, ?)", afterCreatedAt, afterID).
+		Order("created_at ASC, id ASC")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	err := query.Find(&ratings).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ratings created after cursor: %w", err)
+	}
+
+	return ratings, nil
+}
+
 // ========================================
 // READ OPERATIONS - BY ACTION TYPE
 // ========================================
 
 // GetUpgrades retrieves upgrade ratings
 func (r *stockRatingRepositoryImpl) GetUpgrades(ctx context.Context, limit int) ([]*entities.StockRating, error) {
-	return r.GetByActionType(ctx, "upgraded by", limit)
+	return r.GetByActionType(ctx, entities.ActionUpgrade, limit)
 }
 
 // GetDowngrades retrieves downgrade ratings
 func (r *stockRatingRepositoryImpl) GetDowngrades(ctx context.Context, limit int) ([]*entities.StockRating, error) {
-	return r.GetByActionType(ctx, "downgraded by", limit)
+	return r.GetByActionType(ctx, entities.ActionDowngrade, limit)
 }
 
 // GetReiterations retrieves reiteration ratings
 func (r *stockRatingRepositoryImpl) GetReiterations(ctx context.Context, limit int) ([]*entities.StockRating, error) {
-	return r.GetByActionType(ctx, "reiterated by", limit)
+	return r.GetByActionType(ctx, entities.ActionReiterate, limit)
 }
 
-// GetByActionType retrieves ratings by action type
-func (r *stockRatingRepositoryImpl) GetByActionType(ctx context.Context, actionType string, limit int) ([]*entities.StockRating, error) {
+// GetByActionType retrieves ratings by action type (exact match on the indexed action_type column)
+func (r *stockRatingRepositoryImpl) GetByActionType(ctx context.Context, actionType entities.ActionType, limit int) ([]*entities.StockRating, error) {
 	var ratings []*entities.StockRating
 
-	query := r.db.WithContext(ctx).
-		Where("action ILIKE ?", "%"+actionType+"%").
+	query := r.reader.WithContext(ctx).
+		Where("action_type = ?", actionType).
 		Order("event_time DESC")
 
 	if limit > 0 {
@@ -245,32 +326,23 @@ func (r *stockRatingRepositoryImpl) GetByActionType(ctx context.Context, actionT
 // UPDATE OPERATIONS
 // ========================================
 
-// Update updates an existing stock rating
+// Update updates an existing stock rating if its version has not changed since it was read
 func (r *stockRatingRepositoryImpl) Update(ctx context.Context, rating *entities.StockRating) error {
-	result := r.db.WithContext(ctx).Save(rating)
-	if result.Error != nil {
-		return fmt.Errorf("failed to update stock rating: %w", result.Error)
-	}
-
-	if result.RowsAffected == 0 {
-		return fmt.Errorf("stock rating with id %s not found for update", rating.ID)
-	}
-
-	return nil
+	return updateWithVersion(r.db.WithContext(ctx), rating, rating.ID, &rating.Version, "stock rating")
 }
 
 // MarkAsProcessed marks a rating as processed
 func (r *stockRatingRepositoryImpl) MarkAsProcessed(ctx context.Context, id uuid.UUID) error {
 	result := r.db.WithContext(ctx).Model(&entities.StockRating{}).
 		Where("id = ?", id).
-		Update("is_processed", true)
+		Updates(map[string]interface{}{"is_processed": true, "processed_at": time.Now().UTC()})
 
 	if result.Error != nil {
 		return fmt.Errorf("failed to mark rating as processed: %w", result.Error)
 	}
 
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("stock rating with id %s not found for processing", id)
+		return domainerrors.NotFound("stock rating with id %s not found for processing", id)
 	}
 
 	return nil
@@ -280,14 +352,14 @@ func (r *stockRatingRepositoryImpl) MarkAsProcessed(ctx context.Context, id uuid
 func (r *stockRatingRepositoryImpl) MarkAsUnprocessed(ctx context.Context, id uuid.UUID) error {
 	result := r.db.WithContext(ctx).Model(&entities.StockRating{}).
 		Where("id = ?", id).
-		Update("is_processed", false)
+		Updates(map[string]interface{}{"is_processed": false, "processed_at": nil})
 
 	if result.Error != nil {
 		return fmt.Errorf("failed to mark rating as unprocessed: %w", result.Error)
 	}
 
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("stock rating with id %s not found for unprocessing", id)
+		return domainerrors.NotFound("stock rating with id %s not found for unprocessing", id)
 	}
 
 	return nil
@@ -301,7 +373,7 @@ func (r *stockRatingRepositoryImpl) MarkManyAsProcessed(ctx context.Context, ids
 
 	result := r.db.WithContext(ctx).Model(&entities.StockRating{}).
 		Where("id IN ?", ids).
-		Update("is_processed", true)
+		Updates(map[string]interface{}{"is_processed": true, "processed_at": time.Now().UTC()})
 
 	if result.Error != nil {
 		return fmt.Errorf("failed to mark ratings as processed: %w", result.Error)
@@ -322,7 +394,7 @@ func (r *stockRatingRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) er
 	}
 
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("stock rating with id %s not found for deletion", id)
+		return domainerrors.NotFound("stock rating with id %s not found for deletion", id)
 	}
 
 	return nil
@@ -336,7 +408,55 @@ func (r *stockRatingRepositoryImpl) HardDelete(ctx context.Context, id uuid.UUID
 	}
 
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("stock rating with id %s not found for hard deletion", id)
+		return domainerrors.NotFound("stock rating with id %s not found for hard deletion", id)
+	}
+
+	return nil
+}
+
+// ========================================
+// SOFT-DELETE RECOVERY OPERATIONS
+// ========================================
+
+// ListDeleted retrieves soft-deleted stock ratings, most recently deleted first
+func (r *stockRatingRepositoryImpl) ListDeleted(ctx context.Context, limit, offset int) ([]*entities.StockRating, error) {
+	var ratings []*entities.StockRating
+
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&ratings).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted stock ratings: %w", err)
+	}
+
+	return ratings, nil
+}
+
+// CountDeleted returns the number of soft-deleted stock ratings
+func (r *stockRatingRepositoryImpl) CountDeleted(ctx context.Context) (int64, error) {
+	var count int64
+
+	err := r.db.WithContext(ctx).Unscoped().Model(&entities.StockRating{}).Where("deleted_at IS NOT NULL").Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count deleted stock ratings: %w", err)
+	}
+
+	return count, nil
+}
+
+// Restore undoes the soft delete of a stock rating
+func (r *stockRatingRepositoryImpl) Restore(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Unscoped().Model(&entities.StockRating{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return translateDBError(result.Error, "failed to restore stock rating")
+	}
+
+	if result.RowsAffected == 0 {
+		return domainerrors.NotFound("deleted stock rating with id %s not found", id)
 	}
 
 	return nil
@@ -350,7 +470,7 @@ func (r *stockRatingRepositoryImpl) HardDelete(ctx context.Context, id uuid.UUID
 func (r *stockRatingRepositoryImpl) Count(ctx context.Context) (int64, error) {
 	var count int64
 
-	err := r.db.WithContext(ctx).Model(&entities.StockRating{}).Count(&count).Error
+	err := r.reader.WithContext(ctx).Model(&entities.StockRating{}).Count(&count).Error
 	if err != nil {
 		return 0, fmt.Errorf("failed to count stock ratings: %w", err)
 	}
@@ -362,7 +482,7 @@ func (r *stockRatingRepositoryImpl) Count(ctx context.Context) (int64, error) {
 func (r *stockRatingRepositoryImpl) CountByCompany(ctx context.Context, companyID uuid.UUID) (int64, error) {
 	var count int64
 
-	err := r.db.WithContext(ctx).Model(&entities.StockRating{}).
+	err := r.reader.WithContext(ctx).Model(&entities.StockRating{}).
 		Where("company_id = ?", companyID).Count(&count).Error
 	if err != nil {
 		return 0, fmt.Errorf("failed to count ratings by company: %w", err)
@@ -375,7 +495,7 @@ func (r *stockRatingRepositoryImpl) CountByCompany(ctx context.Context, companyI
 func (r *stockRatingRepositoryImpl) CountByBrokerage(ctx context.Context, brokerageID uuid.UUID) (int64, error) {
 	var count int64
 
-	err := r.db.WithContext(ctx).Model(&entities.StockRating{}).
+	err := r.reader.WithContext(ctx).Model(&entities.StockRating{}).
 		Where("brokerage_id = ?", brokerageID).Count(&count).Error
 	if err != nil {
 		return 0, fmt.Errorf("failed to count ratings by brokerage: %w", err)
@@ -384,12 +504,67 @@ func (r *stockRatingRepositoryImpl) CountByBrokerage(ctx context.Context, broker
 	return count, nil
 }
 
+// Search retrieves a page of the ratings matching every filter set, newest first by default.
+// Cada filtro añade un predicado; los índices compuestos de la migración 000027 cubren brokerage + acción,
+// rating normalizado y precio objetivo, siempre con event_time como segunda columna para ordenar sin sort
+func (r *stockRatingRepositoryImpl) Search(ctx context.Context, filter interfaces.RatingSearchFilter, sort []interfaces.SortField, limit, offset int) ([]*entities.StockRating, int64, error) {
+	query := r.reader.WithContext(ctx).Model(&entities.StockRating{})
+	if filter.CompanyID != nil {
+		query = query.Where("company_id = ?", *filter.CompanyID)
+	}
+	if filter.BrokerageID != nil {
+		query = query.Where("brokerage_id = ?", *filter.BrokerageID)
+	}
+	if len(filter.ActionTypes) > 0 {
+		query = query.Where("action_type IN ?", filter.ActionTypes)
+	}
+	if len(filter.Ratings) > 0 {
+		query = query.Where("normalized_rating_to IN ?", filter.Ratings)
+	}
+	if filter.TargetCurrency != "" {
+		query = query.Where("target_currency = ? AND target_to_value IS NOT NULL", filter.TargetCurrency)
+		if filter.TargetMin != nil {
+			query = query.Where("target_to_value >= ?", *filter.TargetMin)
+		}
+		if filter.TargetMax != nil {
+			query = query.Where("target_to_value <= ?", *filter.TargetMax)
+		}
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("event_time >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("event_time < ?", filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count stock ratings: %w", err)
+	}
+
+	// Las relaciones se cargan con una consulta IN por tabla para toda la página, sin N+1
+	page := applySort(query, sort, interfaces.SortField{Column: "event_time", Desc: true}).Limit(limit).Offset(offset)
+	if filter.PreloadCompany {
+		page = page.Preload("Company")
+	}
+	if filter.PreloadBrokerage {
+		page = page.Preload("Brokerage")
+	}
+
+	var ratings []*entities.StockRating
+	if err := page.Find(&ratings).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to search stock ratings: %w", err)
+	}
+
+	return ratings, total, nil
+}
+
 // CountByActionType returns the number of ratings by action type
-func (r *stockRatingRepositoryImpl) CountByActionType(ctx context.Context, actionType string) (int64, error) {
+func (r *stockRatingRepositoryImpl) CountByActionType(ctx context.Context, actionType entities.ActionType) (int64, error) {
 	var count int64
 
-	err := r.db.WithContext(ctx).Model(&entities.StockRating{}).
-		Where("action ILIKE ?", "%"+actionType+"%").Count(&count).Error
+	err := r.reader.WithContext(ctx).Model(&entities.StockRating{}).
+		Where("action_type = ?", actionType).Count(&count).Error
 	if err != nil {
 		return 0, fmt.Errorf("failed to count ratings by action type: %w", err)
 	}
@@ -491,18 +666,9 @@ func (r *stockRatingRepositoryImpl) BulkInsertIgnoreDuplicates(ctx context.Conte
 	insertedCount := 0
 
 	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		for _, rating := range ratings {
-			err := tx.Create(rating).Error
-			if err != nil {
-				// Skip if duplicate
-				if strings.Contains(err.Error(), "unique_rating_per_company_brokerage_time") {
-					continue
-				}
-				return fmt.Errorf("failed to insert rating: %w", err)
-			}
-			insertedCount++
-		}
-		return nil
+		var err error
+		insertedCount, err = r.BulkInsertIgnoreDuplicatesWithTx(ctx, tx, ratings)
+		return err
 	})
 
 	return insertedCount, err
@@ -510,47 +676,309 @@ func (r *stockRatingRepositoryImpl) BulkInsertIgnoreDuplicates(ctx context.Conte
 
 // BulkInsertIgnoreDuplicatesWithTx inserts ratings ignoring duplicates using provided transaction
 func (r *stockRatingRepositoryImpl) BulkInsertIgnoreDuplicatesWithTx(ctx context.Context, tx *gorm.DB, ratings []*entities.StockRating) (int, error) {
+	inserted, err := r.BulkInsertIgnoreDuplicatesReturningWithTx(ctx, tx, ratings)
+	return len(inserted), err
+}
+
+// BulkInsertIgnoreDuplicatesReturningWithTx inserts ratings ignoring duplicates and returns the inserted ones
+func (r *stockRatingRepositoryImpl) BulkInsertIgnoreDuplicatesReturningWithTx(ctx context.Context, tx *gorm.DB, ratings []*entities.StockRating) ([]*entities.StockRating, error) {
 	if len(ratings) == 0 {
-		return 0, nil
+		return nil, nil
 	}
 
-	insertedCount := 0
+	var inserted []*entities.StockRating
+
+	// Multi-row INSERT per chunk instead of one round trip per rating
+	for start := 0; start < len(ratings); start += bulkInsertChunkSize {
+		end := start + bulkInsertChunkSize
+		if end > len(ratings) {
+			end = len(ratings)
+		}
+
+		chunkInserted, err := r.insertChunkIgnoreDuplicates(ctx, tx, ratings[start:end])
+		if err != nil {
+			return inserted, err
+		}
+		inserted = append(inserted, chunkInserted...)
+	}
 
+	return inserted, nil
+}
+
+// insertChunkIgnoreDuplicates inserts a chunk of ratings with a single statement.
+// ON CONFLICT DO NOTHING avoids transaction aborts; RETURNING lists only the inserted rows.
+func (r *stockRatingRepositoryImpl) insertChunkIgnoreDuplicates(ctx context.Context, tx *gorm.DB, ratings []*entities.StockRating) ([]*entities.StockRating, error) {
+	var query strings.Builder
+	query.WriteString(`
+		INSERT INTO stock_ratings (
+			id, company_id, brokerage_id, action, action_type, rating_from, rating_to,
+			normalized_rating_from, normalized_rating_to,
+			target_from, target_to, target_from_value, target_to_value, target_currency,
+			event_time, created_at, updated_at,
+			source, raw_data, is_processed
+		)
+		VALUES `)
+
+	args := make([]interface{}, 0, len(ratings)*18)
+	for i, rating := range ratings {
+		// Raw SQL skips GORM hooks: apply ID generation and normalization explicitly
+		if err := rating.BeforeCreate(tx); err != nil {
+			return nil, fmt.Errorf("failed to prepare rating: %w", err)
+		}
+
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		query.WriteString("(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NOW(), NOW(), ?, ?, ?)")
+
+		var rawData interface{}
+		if len(rating.RawData) > 0 {
+			rawData = string(rating.RawData)
+		}
+
+		args = append(args,
+			rating.ID,
+			rating.CompanyID,
+			rating.BrokerageID,
+			rating.Action,
+			rating.ActionType,
+			rating.RatingFrom,
+			rating.RatingTo,
+			rating.NormalizedFrom,
+			rating.NormalizedTo,
+			rating.TargetFrom,
+			rating.TargetTo,
+			rating.TargetFromValue,
+			rating.TargetToValue,
+			rating.TargetCurrency,
+			rating.EventTime,
+			rating.Source,
+			rawData,
+			rating.IsProcessed,
+		)
+	}
+	query.WriteString(" ON CONFLICT (company_id, brokerage_id, event_time) DO NOTHING RETURNING id")
+
+	rows, err := tx.WithContext(ctx).Raw(query.String(), args...).Rows()
+	if err != nil {
+		return nil, fmt.Errorf("failed to bulk insert %d ratings: %w", len(ratings), err)
+	}
+	defer rows.Close()
+
+	insertedIDs := make(map[uuid.UUID]struct{}, len(ratings))
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to read inserted rating id: %w", err)
+		}
+		insertedIDs[id] = struct{}{}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to bulk insert %d ratings: %w", len(ratings), err)
+	}
+
+	inserted := make([]*entities.StockRating, 0, len(insertedIDs))
 	for _, rating := range ratings {
-		// Use raw SQL with ON CONFLICT DO NOTHING to avoid transaction aborts
-		query := `
+		if _, ok := insertedIDs[rating.ID]; ok {
+			inserted = append(inserted, rating)
+		}
+	}
+	return inserted, nil
+}
+
+// ========================================
+// REPROCESSING OPERATIONS
+// ========================================
+
+// ListWithRawData retrieves ratings that keep their raw data in [from, to), paginated by (event_time, id) keyset
+func (r *stockRatingRepositoryImpl) ListWithRawData(ctx context.Context, from, to time.Time, afterEventTime time.Time, afterID uuid.UUID, limit int) ([]*entities.StockRating, error) {
+	var ratings []*entities.StockRating
+
+	query := r.db.WithContext(ctx).
+		Where("raw_data IS NOT NULL").
+		Where("event_time >= ? AND event_time < ?", from, to)
+	if afterID != uuid.Nil {
+		query = query.Where("(event_time, id) > (?, ?)", afterEventTime, afterID)
+	}
+
+	err := query.Order("event_time ASC, id ASC").
+		Limit(limit).
+		Find(&ratings).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ratings with raw data: %w", err)
+	}
+
+	return ratings, nil
+}
+
+// ReplaceRating upserts the re-parsed rating on its (company_id, brokerage_id, event_time) key, so running it
+// twice leaves the same row; when the key changed the original row is soft-deleted
+func (r *stockRatingRepositoryImpl) ReplaceRating(ctx context.Context, originalID uuid.UUID, rating *entities.StockRating) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Raw SQL skips GORM hooks: apply ID generation and normalization explicitly
+		if err := rating.BeforeCreate(tx); err != nil {
+			return fmt.Errorf("failed to prepare rating: %w", err)
+		}
+
+		var rawData interface{}
+		if len(rating.RawData) > 0 {
+			rawData = string(rating.RawData)
+		}
+
+		var storedID uuid.UUID
+		err := tx.Raw(`
 			INSERT INTO stock_ratings (
-				id, company_id, brokerage_id, action, rating_from, rating_to, 
-				target_from, target_to, event_time, created_at, updated_at, 
-				source, is_processed
+				id, company_id, brokerage_id, action, action_type, rating_from, rating_to,
+				normalized_rating_from, normalized_rating_to,
+				target_from, target_to, target_from_value, target_to_value, target_currency,
+				event_time, created_at, updated_at,
+				source, raw_data, is_processed
 			)
-			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, NOW(), NOW(), ?, ?)
-			ON CONFLICT (company_id, brokerage_id, event_time) DO NOTHING
-		`
-
-		result := tx.WithContext(ctx).Exec(query,
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NOW(), NOW(), ?, ?, ?)
+			ON CONFLICT (company_id, brokerage_id, event_time) DO UPDATE SET
+				action = excluded.action,
+				action_type = excluded.action_type,
+				rating_from = excluded.rating_from,
+				rating_to = excluded.rating_to,
+				normalized_rating_from = excluded.normalized_rating_from,
+				normalized_rating_to = excluded.normalized_rating_to,
+				target_from = excluded.target_from,
+				target_to = excluded.target_to,
+				target_from_value = excluded.target_from_value,
+				target_to_value = excluded.target_to_value,
+				target_currency = excluded.target_currency,
+				raw_data = excluded.raw_data,
+				deleted_at = NULL,
+				updated_at = NOW(),
+				version = stock_ratings.version + 1
+			RETURNING id`,
 			rating.ID,
 			rating.CompanyID,
 			rating.BrokerageID,
 			rating.Action,
+			rating.ActionType,
 			rating.RatingFrom,
 			rating.RatingTo,
+			rating.NormalizedFrom,
+			rating.NormalizedTo,
 			rating.TargetFrom,
 			rating.TargetTo,
+			rating.TargetFromValue,
+			rating.TargetToValue,
+			rating.TargetCurrency,
 			rating.EventTime,
 			rating.Source,
+			rawData,
 			rating.IsProcessed,
-		)
+		).Scan(&storedID).Error
+		if err != nil {
+			return fmt.Errorf("failed to upsert rating: %w", err)
+		}
 
-		if result.Error != nil {
-			return insertedCount, fmt.Errorf("failed to insert rating: %w", result.Error)
+		if storedID != originalID {
+			if err := tx.Delete(&entities.StockRating{}, "id = ?", originalID).Error; err != nil {
+				return fmt.Errorf("failed to delete replaced rating: %w", err)
+			}
 		}
+		rating.ID = storedID
+		return nil
+	})
+}
 
-		// Count rows affected (1 = inserted, 0 = skipped due to conflict)
-		insertedCount += int(result.RowsAffected)
+// BackfillActionTypes parses each distinct action once and updates its ratings in batches, so a large table
+// is never rewritten in a single transaction
+func (r *stockRatingRepositoryImpl) BackfillActionTypes(ctx context.Context, all bool, batchSize int) (int64, error) {
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+
+	query := r.db.WithContext(ctx).Unscoped().Model(&entities.StockRating{}).Distinct("action")
+	if !all {
+		query = query.Where("action_type IS NULL")
+	}
+	var actions []string
+	if err := query.Pluck("action", &actions).Error; err != nil {
+		return 0, fmt.Errorf("failed to list rating actions: %w", err)
+	}
+
+	var updated int64
+	for _, action := range actions {
+		actionType := entities.ParseActionType(action)
+		for {
+			result := r.db.WithContext(ctx).Exec(`
+				UPDATE stock_ratings SET action_type = ?
+				WHERE id IN (
+					SELECT id FROM stock_ratings
+					WHERE action = ? AND action_type IS DISTINCT FROM ?
+					LIMIT ?
+				)`, actionType, action, actionType, batchSize)
+			if result.Error != nil {
+				return updated, fmt.Errorf("failed to backfill action type %q: %w", action, result.Error)
+			}
+			updated += result.RowsAffected
+			if result.RowsAffected < int64(batchSize) {
+				break
+			}
+		}
 	}
 
-	return insertedCount, nil
+	return updated, nil
+}
+
+// BackfillTargetPrices walks the ratings by ID and writes the parsed targets of each batch with a single UPDATE.
+// Ratings whose targets cannot be parsed keep NULL values and an empty currency, so they are not visited again
+func (r *stockRatingRepositoryImpl) BackfillTargetPrices(ctx context.Context, all bool, batchSize int) (int64, error) {
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+
+	var updated int64
+	afterID := uuid.Nil
+	for {
+		query := r.db.WithContext(ctx).Unscoped().
+			Select("id", "target_from", "target_to").
+			Where("id > ?", afterID)
+		if !all {
+			query = query.Where("target_currency IS NULL")
+		}
+
+		var ratings []*entities.StockRating
+		if err := query.Order("id ASC").Limit(batchSize).Find(&ratings).Error; err != nil {
+			return updated, fmt.Errorf("failed to list ratings for target backfill: %w", err)
+		}
+		if len(ratings) == 0 {
+			return updated, nil
+		}
+
+		var values strings.Builder
+		args := make([]interface{}, 0, len(ratings)*4)
+		for i, rating := range ratings {
+			// Only the parsed target columns are written back
+			rating.Normalize()
+			if i > 0 {
+				values.WriteString(", ")
+			}
+			values.WriteString("(?::UUID, ?::DECIMAL, ?::DECIMAL, ?::STRING)")
+			args = append(args, rating.ID, rating.TargetFromValue, rating.TargetToValue, rating.TargetCurrency)
+		}
+
+		result := r.db.WithContext(ctx).Exec(`
+			UPDATE stock_ratings SET
+				target_from_value = v.from_value,
+				target_to_value = v.to_value,
+				target_currency = v.currency
+			FROM (VALUES `+values.String()+`) AS v(id, from_value, to_value, currency)
+			WHERE stock_ratings.id = v.id`, args...)
+		if result.Error != nil {
+			return updated, fmt.Errorf("failed to backfill target prices: %w", result.Error)
+		}
+		updated += result.RowsAffected
+		afterID = ratings[len(ratings)-1].ID
+
+		if len(ratings) < batchSize {
+			return updated, nil
+		}
+	}
 }
 
 // ========================================
@@ -602,6 +1030,54 @@ func (r *stockRatingRepositoryImpl) GetProcessingBatch(ctx context.Context, batc
 	return r.GetUnprocessed(ctx, batchSize)
 }
 
+// CountUnprocessed counts the ratings waiting to be processed
+func (r *stockRatingRepositoryImpl) CountUnprocessed(ctx context.Context) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entities.StockRating{}).
+		Where("is_processed = ?", false).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unprocessed ratings: %w", err)
+	}
+	return count, nil
+}
+
+// CountUnprocessedBySource counts the ratings waiting to be processed grouped by source
+func (r *stockRatingRepositoryImpl) CountUnprocessedBySource(ctx context.Context) (map[string]int64, error) {
+	var results []struct {
+		Source string
+		Count  int64
+	}
+
+	err := r.db.WithContext(ctx).
+		Model(&entities.StockRating{}).
+		Select("source, COUNT(*) as count").
+		Where("is_processed = ?", false).
+		Group("source").
+		Scan(&results).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count unprocessed ratings by source: %w", err)
+	}
+
+	counts := make(map[string]int64, len(results))
+	for _, result := range results {
+		counts[result.Source] = result.Count
+	}
+	return counts, nil
+}
+
+// CountProcessedSince counts the ratings processed at or after since (throughput of the processing job)
+func (r *stockRatingRepositoryImpl) CountProcessedSince(ctx context.Context, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entities.StockRating{}).
+		Where("processed_at >= ?", since).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count processed ratings: %w", err)
+	}
+	return count, nil
+}
+
 // ========================================
 // RELATIONSHIP OPERATIONS - WITH PRELOADING
 // ========================================
@@ -613,7 +1089,7 @@ func (r *stockRatingRepositoryImpl) GetWithCompany(ctx context.Context, id uuid.
 	err := r.db.WithContext(ctx).Preload("Company").Where("id = ?", id).First(&rating).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("stock rating with id %s not found", id)
+			return nil, domainerrors.NotFound("stock rating with id %s not found", id)
 		}
 		return nil, fmt.Errorf("failed to get rating with company: %w", err)
 	}
@@ -628,7 +1104,7 @@ func (r *stockRatingRepositoryImpl) GetWithBrokerage(ctx context.Context, id uui
 	err := r.db.WithContext(ctx).Preload("Brokerage").Where("id = ?", id).First(&rating).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("stock rating with id %s not found", id)
+			return nil, domainerrors.NotFound("stock rating with id %s not found", id)
 		}
 		return nil, fmt.Errorf("failed to get rating with brokerage: %w", err)
 	}
@@ -643,7 +1119,7 @@ func (r *stockRatingRepositoryImpl) GetWithRelations(ctx context.Context, id uui
 	err := r.db.WithContext(ctx).Preload("Company").Preload("Brokerage").Where("id = ?", id).First(&rating).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("stock rating with id %s not found", id)
+			return nil, domainerrors.NotFound("stock rating with id %s not found", id)
 		}
 		return nil, fmt.Errorf("failed to get rating with relations: %w", err)
 	}
@@ -655,7 +1131,7 @@ func (r *stockRatingRepositoryImpl) GetWithRelations(ctx context.Context, id uui
 func (r *stockRatingRepositoryImpl) GetAllWithRelations(ctx context.Context, limit int) ([]*entities.StockRating, error) {
 	var ratings []*entities.StockRating
 
-	query := r.db.WithContext(ctx).
+	query := r.reader.WithContext(ctx).
 		Preload("Company").
 		Preload("Brokerage").
 		Order("event_time DESC")
@@ -679,17 +1155,17 @@ func (r *stockRatingRepositoryImpl) GetAllWithRelations(ctx context.Context, lim
 // GetActionTypeDistribution returns count of each action type in the last N days
 func (r *stockRatingRepositoryImpl) GetActionTypeDistribution(ctx context.Context, days int) (map[string]int64, error) {
 	var results []struct {
-		Action string
-		Count  int64
+		ActionType string
+		Count      int64
 	}
 
 	cutoffTime := time.Now().AddDate(0, 0, -days)
 
-	err := r.db.WithContext(ctx).
+	err := r.reader.WithContext(ctx).
 		Model(&entities.StockRating{}).
-		Select("action, COUNT(*) as count").
-		Where("event_time >= ?", cutoffTime).
-		Group("action").
+		Select("action_type, COUNT(*) as count").
+		Where("event_time >= ? AND action_type IS NOT NULL", cutoffTime).
+		Group("action_type").
 		Order("count DESC").
 		Scan(&results).Error
 
@@ -699,19 +1175,107 @@ func (r *stockRatingRepositoryImpl) GetActionTypeDistribution(ctx context.Contex
 
 	distribution := make(map[string]int64)
 	for _, result := range results {
-		distribution[result.Action] = result.Count
+		distribution[result.ActionType] = result.Count
 	}
 
 	return distribution, nil
 }
 
+// GetActionTypeTrend counts ratings per bucket and action type; date_trunc runs on the local time of the location
+// so days and weeks start at the caller's midnight
+func (r *stockRatingRepositoryImpl) GetActionTypeTrend(ctx context.Context, from, to time.Time, granularity interfaces.TrendGranularity, location *time.Location) ([]interfaces.RatingTrendBucket, error) {
+	if !granularity.Valid() {
+		return nil, fmt.Errorf("invalid trend granularity %q", granularity)
+	}
+
+	var rows []struct {
+		Bucket     time.Time
+		ActionType string
+		Count      int64
+	}
+
+	err := r.reader.WithContext(ctx).
+		Model(&entities.StockRating{}).
+		Select("date_trunc(?, event_time AT TIME ZONE ?) AS bucket, COALESCE(action_type, ?) AS action_type, COUNT(*) AS count",
+			string(granularity), location.String(), string(entities.ActionOther)).
+		Where("event_time >= ? AND event_time < ?", from, to).
+		Group("1, 2").
+		Order("1").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get action type trend: %w", err)
+	}
+
+	buckets := make([]interfaces.RatingTrendBucket, 0)
+	for _, row := range rows {
+		// date_trunc devuelve la hora local sin zona: se reinterpreta en la zona pedida
+		start := time.Date(row.Bucket.Year(), row.Bucket.Month(), row.Bucket.Day(), 0, 0, 0, 0, location)
+		if len(buckets) == 0 || !buckets[len(buckets)-1].Start.Equal(start) {
+			buckets = append(buckets, interfaces.RatingTrendBucket{Start: start, Actions: make(map[string]int64)})
+		}
+		bucket := &buckets[len(buckets)-1]
+		bucket.Actions[row.ActionType] += row.Count
+		bucket.Total += row.Count
+	}
+
+	return buckets, nil
+}
+
+// GetSectorActionTrend counts ratings per bucket and company sector, like GetActionTypeTrend. Deleted companies and
+// companies without sector are left out
+func (r *stockRatingRepositoryImpl) GetSectorActionTrend(ctx context.Context, from, to time.Time, granularity interfaces.TrendGranularity, location *time.Location) ([]interfaces.SectorActionCount, error) {
+	if !granularity.Valid() {
+		return nil, fmt.Errorf("invalid trend granularity %q", granularity)
+	}
+
+	var rows []struct {
+		Bucket     time.Time
+		Sector     string
+		Total      int64
+		Upgrades   int64
+		Downgrades int64
+	}
+
+	err := r.reader.WithContext(ctx).
+		Model(&entities.StockRating{}).
+		Select(`date_trunc(?, stock_ratings.event_time AT TIME ZONE ?) AS bucket, companies.sector AS sector,
+			COUNT(*) AS total,
+			SUM(CASE WHEN stock_ratings.action_type = ? THEN 1 ELSE 0 END) AS upgrades,
+			SUM(CASE WHEN stock_ratings.action_type = ? THEN 1 ELSE 0 END) AS downgrades`,
+			string(granularity), location.String(), string(entities.ActionUpgrade), string(entities.ActionDowngrade)).
+		Joins("JOIN companies ON companies.id = stock_ratings.company_id AND companies.deleted_at IS NULL").
+		Where("stock_ratings.event_time >= ? AND stock_ratings.event_time < ?", from, to).
+		Where("companies.sector IS NOT NULL AND companies.sector != ''").
+		Group("1, 2").
+		Order("1, 2").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sector action trend: %w", err)
+	}
+
+	counts := make([]interfaces.SectorActionCount, 0, len(rows))
+	for _, row := range rows {
+		// date_trunc devuelve la hora local sin zona: se reinterpreta en la zona pedida
+		start := time.Date(row.Bucket.Year(), row.Bucket.Month(), row.Bucket.Day(), 0, 0, 0, 0, location)
+		counts = append(counts, interfaces.SectorActionCount{
+			Start:      start,
+			Sector:     row.Sector,
+			Total:      row.Total,
+			Upgrades:   row.Upgrades,
+			Downgrades: row.Downgrades,
+		})
+	}
+
+	return counts, nil
+}
+
 // GetTopCompaniesByRatingCount returns companies with most ratings in last N days
 func (r *stockRatingRepositoryImpl) GetTopCompaniesByRatingCount(ctx context.Context, days int, limit int) ([]interfaces.CompanyRatingCount, error) {
 	var results []interfaces.CompanyRatingCount
 
 	cutoffTime := time.Now().AddDate(0, 0, -days)
 
-	query := r.db.WithContext(ctx).
+	query := r.reader.WithContext(ctx).
 		Select("companies.id as company_id, companies.name as company_name, companies.ticker, COUNT(stock_ratings.id) as rating_count").
 		Table("stock_ratings").
 		Joins("JOIN companies ON stock_ratings.company_id = companies.id").
@@ -737,7 +1301,7 @@ func (r *stockRatingRepositoryImpl) GetTopBrokeragesByRatingCount(ctx context.Co
 
 	cutoffTime := time.Now().AddDate(0, 0, -days)
 
-	query := r.db.WithContext(ctx).
+	query := r.reader.WithContext(ctx).
 		Select("brokerages.id as brokerage_id, brokerages.name as brokerage_name, COUNT(stock_ratings.id) as rating_count").
 		Table("stock_ratings").
 		Joins("JOIN brokerages ON stock_ratings.brokerage_id = brokerages.id").
@@ -763,14 +1327,14 @@ func (r *stockRatingRepositoryImpl) GetRatingTrend(ctx context.Context, companyI
 
 	cutoffTime := time.Now().AddDate(0, 0, -days)
 
-	err := r.db.WithContext(ctx).
+	err := r.reader.WithContext(ctx).
 		Select(`
 			DATE(event_time) as date,
 			COUNT(*) as rating_count,
-			COUNT(CASE WHEN action ILIKE '%upgrade%' THEN 1 END) as upgrades,
-			COUNT(CASE WHEN action ILIKE '%downgrade%' THEN 1 END) as downgrades,
-			COUNT(CASE WHEN action ILIKE '%reiterat%' THEN 1 END) as reiterations
-		`).
+			COUNT(CASE WHEN action_type = ? THEN 1 END) as upgrades,
+			COUNT(CASE WHEN action_type = ? THEN 1 END) as downgrades,
+			COUNT(CASE WHEN action_type = ? THEN 1 END) as reiterations
+		`, entities.ActionUpgrade, entities.ActionDowngrade, entities.ActionReiterate).
 		Model(&entities.StockRating{}).
 		Where("company_id = ? AND event_time >= ?", companyID, cutoffTime).
 		Group("DATE(event_time)").
@@ -788,30 +1352,25 @@ func (r *stockRatingRepositoryImpl) GetRatingTrend(ctx context.Context, companyI
 // TIME-BASED QUERIES
 // ========================================
 
-// GetTodaysRatings retrieves ratings from today
+// GetTodaysRatings retrieves ratings from today in the time zone of the context
 func (r *stockRatingRepositoryImpl) GetTodaysRatings(ctx context.Context) ([]*entities.StockRating, error) {
-	now := time.Now()
-	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-	endOfDay := startOfDay.Add(24 * time.Hour)
+	startOfDay := timezone.StartOfDay(timezone.Now(ctx))
+	// AddDate y no 24h: los días de cambio de horario duran 23 o 25 horas
+	endOfDay := startOfDay.AddDate(0, 0, 1)
 
 	return r.GetByEventTimeRange(ctx, startOfDay, endOfDay)
 }
 
-// GetThisWeeksRatings retrieves ratings from this week
+// GetThisWeeksRatings retrieves ratings from this week (starting on Sunday) in the time zone of the context
 func (r *stockRatingRepositoryImpl) GetThisWeeksRatings(ctx context.Context) ([]*entities.StockRating, error) {
-	now := time.Now()
-	startOfWeek := now.AddDate(0, 0, -int(now.Weekday()))
-	startOfWeek = time.Date(startOfWeek.Year(), startOfWeek.Month(), startOfWeek.Day(), 0, 0, 0, 0, startOfWeek.Location())
-
-	return r.GetByEventTimeRange(ctx, startOfWeek, now)
+	now := timezone.Now(ctx)
+	return r.GetByEventTimeRange(ctx, timezone.StartOfWeek(now), now)
 }
 
-// GetThisMonthsRatings retrieves ratings from this month
+// GetThisMonthsRatings retrieves ratings from this month in the time zone of the context
 func (r *stockRatingRepositoryImpl) GetThisMonthsRatings(ctx context.Context) ([]*entities.StockRating, error) {
-	now := time.Now()
-	startOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
-
-	return r.GetByEventTimeRange(ctx, startOfMonth, now)
+	now := timezone.Now(ctx)
+	return r.GetByEventTimeRange(ctx, timezone.StartOfMonth(now), now)
 }
 
 // ========================================
@@ -853,6 +1412,38 @@ func (r *stockRatingRepositoryImpl) FindDuplicates(ctx context.Context) ([]inter
 	return results, nil
 }
 
+// FindNearDuplicates finds groups of duplicate ratings including the same rating change re-emitted within window.
+// RatingIDs are sorted by event time, so the first one is the original emission
+func (r *stockRatingRepositoryImpl) FindNearDuplicates(ctx context.Context, window time.Duration) ([]interfaces.DuplicateGroup, error) {
+	var ratings []*entities.StockRating
+	err := r.db.WithContext(ctx).
+		Select("id, company_id, brokerage_id, action, rating_from, rating_to, target_from, target_to, event_time, created_at").
+		Order("company_id, brokerage_id, event_time").
+		Find(&ratings).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find near duplicates: %w", err)
+	}
+
+	groups := entities.GroupDuplicateRatings(ratings, window)
+	results := make([]interfaces.DuplicateGroup, 0, len(groups))
+	for _, group := range groups {
+		first, last := group[0], group[len(group)-1]
+		result := interfaces.DuplicateGroup{
+			CompanyID:   first.CompanyID,
+			BrokerageID: first.BrokerageID,
+			EventTime:   first.EventTime,
+			RatingIDs:   make([]uuid.UUID, 0, len(group)),
+			Count:       len(group),
+			Near:        !last.EventTime.Equal(first.EventTime),
+		}
+		for _, rating := range group {
+			result.RatingIDs = append(result.RatingIDs, rating.ID)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
 // RemoveDuplicates removes duplicate ratings, keeping either newest or oldest
 func (r *stockRatingRepositoryImpl) RemoveDuplicates(ctx context.Context, keepNewest bool) (int, error) {
 	duplicates, err := r.FindDuplicates(ctx)
@@ -1061,11 +1652,11 @@ func (r *stockRatingRepositoryImpl) GetOrphanedStockRatingsWithReasons(ctx conte
 func (r *stockRatingRepositoryImpl) CreateWithTx(ctx context.Context, tx *gorm.DB, rating *entities.StockRating) error {
 	if err := tx.WithContext(ctx).Create(rating).Error; err != nil {
 		// Handle unique constraint violation
-		if strings.Contains(err.Error(), "unique_rating_per_company_brokerage_time") {
-			return fmt.Errorf("rating already exists for company %s, brokerage %s at time %s",
+		if isUniqueViolation(err, uniqueRatingConstraint) {
+			return domainerrors.Duplicate(err, "rating already exists for company %s, brokerage %s at time %s",
 				rating.CompanyID, rating.BrokerageID, rating.EventTime)
 		}
-		return fmt.Errorf("failed to create stock rating with transaction: %w", err)
+		return translateDBError(err, "failed to create stock rating with transaction")
 	}
 	return nil
 }
@@ -1091,7 +1682,7 @@ func (r *stockRatingRepositoryImpl) GetByIDWithTx(ctx context.Context, tx *gorm.
 	err := tx.WithContext(ctx).Where("id = ?", id).First(&rating).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("stock rating with id %s not found", id)
+			return nil, domainerrors.NotFound("stock rating with id %s not found", id)
 		}
 		return nil, fmt.Errorf("failed to get stock rating by id with transaction: %w", err)
 	}
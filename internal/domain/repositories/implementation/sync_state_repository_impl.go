@@ -0,0 +1,64 @@
+package implementation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+)
+
+// syncStateRepositoryImpl implements the SyncStateRepository interface using GORM
+type syncStateRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewSyncStateRepository creates a new sync state repository implementation
+func NewSyncStateRepository(db *gorm.DB) interfaces.SyncStateRepository {
+	return &syncStateRepositoryImpl{
+		db: db,
+	}
+}
+
+// GetBySource retrieves the sync state of a source; returns nil without error if it does not exist
+func (r *syncStateRepositoryImpl) GetBySource(ctx context.Context, source string) (*entities.SyncState, error) {
+	var state entities.SyncState
+
+	err := r.db.WithContext(ctx).Where("source = ?", source).First(&state).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get sync state for source %s: %w", source, err)
+	}
+
+	return &state, nil
+}
+
+// Upsert creates or replaces the sync state of a source
+func (r *syncStateRepositoryImpl) Upsert(ctx context.Context, state *entities.SyncState) error {
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "source"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_event_time", "last_synced_at", "last_run_items", "updated_at"}),
+	}).Create(state).Error
+	if err != nil {
+		return fmt.Errorf("failed to upsert sync state for source %s: %w", state.Source, err)
+	}
+
+	return nil
+}
+
+// GetAll retrieves the sync state of every source
+func (r *syncStateRepositoryImpl) GetAll(ctx context.Context) ([]*entities.SyncState, error) {
+	var states []*entities.SyncState
+
+	if err := r.db.WithContext(ctx).Order("source ASC").Find(&states).Error; err != nil {
+		return nil, fmt.Errorf("failed to get sync states: %w", err)
+	}
+
+	return states, nil
+}
@@ -0,0 +1,131 @@
+package implementation
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+
+	"gorm.io/gorm"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+)
+
+// tableNamePattern limita los nombres de tabla a identificadores simples (se interpolan en el SQL)
+var tableNamePattern = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// tableSnapshotRepositoryImpl implements the TableSnapshotRepository interface using GORM
+type tableSnapshotRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewTableSnapshotRepository creates a new table snapshot repository; exports read from db (the primary)
+// so a backup never misses rows a replica has not received yet
+func NewTableSnapshotRepository(db *gorm.DB) interfaces.TableSnapshotRepository {
+	return &tableSnapshotRepositoryImpl{
+		db: db,
+	}
+}
+
+// TableExists reports whether the table is present in the current schema
+func (r *tableSnapshotRepositoryImpl) TableExists(ctx context.Context, table string) (bool, error) {
+	if err := validateTableName(table); err != nil {
+		return false, err
+	}
+
+	var exists bool
+	if err := r.db.WithContext(ctx).
+		Raw("SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = ?)", table).
+		Scan(&exists).Error; err != nil {
+		return false, fmt.Errorf("failed to check table %s: %w", table, err)
+	}
+	return exists, nil
+}
+
+// ExportTable streams the rows of the table as NDJSON. row_to_json keeps the column names and the
+// JSON types of the database, so JSONB columns are exported as objects and not as strings
+func (r *tableSnapshotRepositoryImpl) ExportTable(ctx context.Context, table string, w io.Writer) (int64, error) {
+	if err := validateTableName(table); err != nil {
+		return 0, err
+	}
+
+	rows, err := r.db.WithContext(ctx).
+		Raw(fmt.Sprintf("SELECT row_to_json(t.*)::TEXT FROM %s AS t", table)).
+		Rows()
+	if err != nil {
+		return 0, fmt.Errorf("failed to export %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	var exported int64
+	var line []byte
+	for rows.Next() {
+		if err := rows.Scan(&line); err != nil {
+			return exported, fmt.Errorf("failed to read %s row: %w", table, err)
+		}
+		if _, err := w.Write(append(line, '\n')); err != nil {
+			return exported, fmt.Errorf("failed to write %s row: %w", table, err)
+		}
+		exported++
+	}
+	if err := rows.Err(); err != nil {
+		return exported, fmt.Errorf("failed to export %s: %w", table, err)
+	}
+
+	return exported, nil
+}
+
+// Columns returns the column names of the table in declaration order
+func (r *tableSnapshotRepositoryImpl) Columns(ctx context.Context, table string) ([]string, error) {
+	if err := validateTableName(table); err != nil {
+		return nil, err
+	}
+
+	var columns []string
+	if err := r.db.WithContext(ctx).
+		Raw("SELECT column_name FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = ? ORDER BY ordinal_position", table).
+		Scan(&columns).Error; err != nil {
+		return nil, fmt.Errorf("failed to read columns of %s: %w", table, err)
+	}
+	return columns, nil
+}
+
+// CountRows returns the number of rows in the table
+func (r *tableSnapshotRepositoryImpl) CountRows(ctx context.Context, table string) (int64, error) {
+	if err := validateTableName(table); err != nil {
+		return 0, err
+	}
+
+	var count int64
+	if err := r.db.WithContext(ctx).Table(table).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count rows of %s: %w", table, err)
+	}
+	return count, nil
+}
+
+// SchemaVersion reads the version golang-migrate recorded in schema_migrations
+func (r *tableSnapshotRepositoryImpl) SchemaVersion(ctx context.Context) (uint, error) {
+	exists, err := r.TableExists(ctx, "schema_migrations")
+	if err != nil || !exists {
+		return 0, err
+	}
+
+	var versions []int64
+	if err := r.db.WithContext(ctx).
+		Raw("SELECT version FROM schema_migrations LIMIT 1").
+		Scan(&versions).Error; err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if len(versions) == 0 || versions[0] < 0 {
+		return 0, nil
+	}
+	return uint(versions[0]), nil
+}
+
+// validateTableName rechaza nombres que no sean identificadores simples
+func validateTableName(table string) error {
+	if !tableNamePattern.MatchString(table) {
+		return fmt.Errorf("invalid table name %q", table)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package implementation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+)
+
+// tagRepositoryImpl implements the TagRepository interface using GORM
+type tagRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewTagRepository creates a new tag repository implementation
+func NewTagRepository(db *gorm.DB) interfaces.TagRepository {
+	return &tagRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create stores a new tag
+func (r *tagRepositoryImpl) Create(ctx context.Context, tag *entities.Tag) error {
+	err := r.db.WithContext(ctx).Create(tag).Error
+	return translateDBError(err, "tag %s already exists", tag.Slug)
+}
+
+// GetBySlug retrieves a tag by its slug
+func (r *tagRepositoryImpl) GetBySlug(ctx context.Context, slug string) (*entities.Tag, error) {
+	var tag entities.Tag
+
+	err := r.db.WithContext(ctx).Where("slug = ?", entities.NormalizeTagSlug(slug)).First(&tag).Error
+	if err != nil {
+		return nil, translateDBError(err, "tag %s not found", slug)
+	}
+
+	return &tag, nil
+}
+
+// GetAll retrieves every tag by slug with its number of active companies; soft-deleted companies do not count
+func (r *tagRepositoryImpl) GetAll(ctx context.Context) ([]interfaces.TagUsage, error) {
+	var rows []struct {
+		entities.Tag
+		Companies int64
+	}
+
+	err := r.db.WithContext(ctx).
+		Table("tags").
+		Select("tags.*, COUNT(companies.id) AS companies").
+		Joins("LEFT JOIN company_tags ON company_tags.tag_id = tags.id").
+		Joins("LEFT JOIN companies ON companies.id = company_tags.company_id AND companies.deleted_at IS NULL AND companies.is_active = true").
+		Group("tags.id").
+		Order("tags.slug ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	usages := make([]interfaces.TagUsage, len(rows))
+	for i := range rows {
+		usages[i] = interfaces.TagUsage{Tag: &rows[i].Tag, Companies: rows[i].Companies}
+	}
+	return usages, nil
+}
+
+// Update stores the name and description of a tag
+func (r *tagRepositoryImpl) Update(ctx context.Context, tag *entities.Tag) error {
+	result := r.db.WithContext(ctx).
+		Model(&entities.Tag{}).
+		Where("id = ?", tag.ID).
+		Updates(map[string]interface{}{
+			"name":        tag.Name,
+			"description": tag.Description,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update tag %s: %w", tag.Slug, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.NotFound("tag %s not found", tag.Slug)
+	}
+
+	return nil
+}
+
+// Delete removes a tag; its assignments go with it (ON DELETE CASCADE)
+func (r *tagRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&entities.Tag{}, "id = ?", id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete tag %s: %w", id, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.NotFound("tag %s not found", id)
+	}
+
+	return nil
+}
+
+// GetByCompanyID retrieves the tags of a company by slug
+func (r *tagRepositoryImpl) GetByCompanyID(ctx context.Context, companyID uuid.UUID) ([]*entities.Tag, error) {
+	var tags []*entities.Tag
+
+	err := r.db.WithContext(ctx).
+		Joins("JOIN company_tags ON company_tags.tag_id = tags.id").
+		Where("company_tags.company_id = ?", companyID).
+		Order("tags.slug ASC").
+		Find(&tags).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags of company %s: %w", companyID, err)
+	}
+
+	return tags, nil
+}
+
+// AddCompany tags a company; an existing assignment is kept as is
+func (r *tagRepositoryImpl) AddCompany(ctx context.Context, tagID, companyID uuid.UUID) error {
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&entities.CompanyTag{CompanyID: companyID, TagID: tagID}).Error
+	return translateDBError(err, "failed to tag company %s with %s", companyID, tagID)
+}
+
+// RemoveCompany untags a company
+func (r *tagRepositoryImpl) RemoveCompany(ctx context.Context, tagID, companyID uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&entities.CompanyTag{}, "tag_id = ? AND company_id = ?", tagID, companyID)
+	if result.Error != nil {
+		return fmt.Errorf("failed to untag company %s: %w", companyID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.NotFound("company %s is not tagged with %s", companyID, tagID)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package implementation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+)
+
+// tenantRepositoryImpl implements the TenantRepository interface using GORM
+type tenantRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewTenantRepository creates a new tenant repository implementation
+func NewTenantRepository(db *gorm.DB) interfaces.TenantRepository {
+	return &tenantRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create stores a new tenant
+func (r *tenantRepositoryImpl) Create(ctx context.Context, tenant *entities.Tenant) error {
+	if err := r.db.WithContext(ctx).Create(tenant).Error; err != nil {
+		return translateDBError(err, "failed to create tenant %s", tenant.Slug)
+	}
+	return nil
+}
+
+// GetByID retrieves a tenant by ID
+func (r *tenantRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*entities.Tenant, error) {
+	var tenant entities.Tenant
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&tenant).Error; err != nil {
+		return nil, translateDBError(err, "tenant %s", id)
+	}
+	return &tenant, nil
+}
+
+// GetBySlug retrieves a tenant by slug
+func (r *tenantRepositoryImpl) GetBySlug(ctx context.Context, slug string) (*entities.Tenant, error) {
+	var tenant entities.Tenant
+	if err := r.db.WithContext(ctx).Where("slug = ?", slug).First(&tenant).Error; err != nil {
+		return nil, translateDBError(err, "tenant %s", slug)
+	}
+	return &tenant, nil
+}
+
+// List retrieves every tenant by name
+func (r *tenantRepositoryImpl) List(ctx context.Context) ([]*entities.Tenant, error) {
+	var tenants []*entities.Tenant
+	if err := r.db.WithContext(ctx).Order("name ASC").Find(&tenants).Error; err != nil {
+		return nil, fmt.Errorf("failed to list tenants: %w", err)
+	}
+	return tenants, nil
+}
+
+// SetActive activates or deactivates a tenant; the API keys of an inactive tenant stop authenticating
+func (r *tenantRepositoryImpl) SetActive(ctx context.Context, id uuid.UUID, active bool) error {
+	result := r.db.WithContext(ctx).Model(&entities.Tenant{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"active": active, "updated_at": time.Now().UTC()})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update tenant %s: %w", id, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.NotFound("tenant %s", id)
+	}
+	return nil
+}
+
+// SetPlan changes the plan of a tenant; its credentials get the new quotas on their next request
+func (r *tenantRepositoryImpl) SetPlan(ctx context.Context, id uuid.UUID, plan string) error {
+	result := r.db.WithContext(ctx).Model(&entities.Tenant{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"plan": plan, "updated_at": time.Now().UTC()})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update tenant %s: %w", id, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.NotFound("tenant %s", id)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package implementation
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/tenancy"
+)
+
+// tenantScoped restringe la consulta al tenant del contexto. Sin tenant devuelve tenancy.ErrNoTenant en lugar
+// de una consulta sin filtro, así un handler que olvide autenticar nunca ve datos de otros tenants
+func tenantScoped(ctx context.Context, db *gorm.DB) (*gorm.DB, error) {
+	tenantID, err := tenancy.TenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return db.WithContext(ctx).Where("tenant_id = ?", tenantID), nil
+}
+
+// ownerScoped restringe la consulta a los recursos privados de la credencial del contexto dentro de su tenant
+func ownerScoped(ctx context.Context, db *gorm.DB) (*gorm.DB, error) {
+	tenantID, author, err := tenancy.Owner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return db.WithContext(ctx).Where("tenant_id = ? AND author = ?", tenantID, author), nil
+}
@@ -0,0 +1,75 @@
+package implementation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+)
+
+// usageDailyColumns agrega las horas de cada credencial por día UTC
+const usageDailyColumns = `tenant_id, credential, date_trunc('day', hour) AS hour,
+	SUM(requests) AS requests, SUM(errors) AS errors, SUM(provider_calls) AS provider_calls,
+	SUM(cache_hits) AS cache_hits, MAX(updated_at) AS updated_at`
+
+// usageRepositoryImpl implements the UsageRepository interface using GORM
+type usageRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewUsageRepository creates a new API usage repository implementation
+func NewUsageRepository(db *gorm.DB) interfaces.UsageRepository {
+	return &usageRepositoryImpl{
+		db: db,
+	}
+}
+
+// SaveHourly upserts the hourly counters; the counters of the cache are cumulative, so the stored values are replaced
+func (r *usageRepositoryImpl) SaveHourly(ctx context.Context, rows []*entities.APIUsage) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tenant_id"}, {Name: "credential"}, {Name: "hour"}},
+		DoUpdates: clause.AssignmentColumns([]string{"requests", "errors", "provider_calls", "cache_hits", "updated_at"}),
+	}).CreateInBatches(&rows, 500).Error
+	if err != nil {
+		return fmt.Errorf("failed to save API usage: %w", err)
+	}
+	return nil
+}
+
+// DailyByTenant aggregates per day the usage of the tenant of the context
+func (r *usageRepositoryImpl) DailyByTenant(ctx context.Context, from, to time.Time) ([]*entities.APIUsage, error) {
+	query, err := tenantScoped(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	return r.daily(query, from, to)
+}
+
+// Daily aggregates per day the usage of every tenant
+func (r *usageRepositoryImpl) Daily(ctx context.Context, from, to time.Time) ([]*entities.APIUsage, error) {
+	return r.daily(r.db.WithContext(ctx), from, to)
+}
+
+// daily agrupa por tenant, credencial y día las horas de [from, to)
+func (r *usageRepositoryImpl) daily(query *gorm.DB, from, to time.Time) ([]*entities.APIUsage, error) {
+	var rows []*entities.APIUsage
+	err := query.Model(&entities.APIUsage{}).
+		Select(usageDailyColumns).
+		Where("hour >= ? AND hour < ?", from, to).
+		Group("tenant_id, credential, date_trunc('day', hour)").
+		Order("hour, tenant_id, credential").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API usage: %w", err)
+	}
+	return rows, nil
+}
@@ -0,0 +1,34 @@
+package implementation
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+)
+
+// withWriteHooks ejecuta write y, en su misma transacción, los hooks con la entidad guardada. Sin hooks la
+// escritura usa db directamente, como antes de existir los hooks
+func withWriteHooks[T any](db *gorm.DB, hooks []interfaces.WriteHook[T], write func(tx *gorm.DB) (*T, error)) error {
+	if len(hooks) == 0 {
+		_, err := write(db)
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		entity, err := write(tx)
+		if err != nil {
+			return err
+		}
+		return runWriteHooks(tx, entity, hooks)
+	})
+}
+
+// runWriteHooks ejecuta los hooks en orden; el primer error detiene el resto y revierte la transacción
+func runWriteHooks[T any](tx *gorm.DB, entity *T, hooks []interfaces.WriteHook[T]) error {
+	for _, hook := range hooks {
+		if err := hook(tx, entity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
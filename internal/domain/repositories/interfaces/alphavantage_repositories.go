@@ -127,6 +127,7 @@ type HistoricalDataRepository interface {
 	GetBySymbolLastN(ctx context.Context, symbol string, days int) ([]*entities.HistoricalData, error)
 	GetByCompanyID(ctx context.Context, companyID uuid.UUID, startDate, endDate time.Time) ([]*entities.HistoricalData, error)
 	GetByTimeFrame(ctx context.Context, symbol string, timeFrame string, startDate, endDate time.Time) ([]*entities.HistoricalData, error)
+	GetByDateRange(ctx context.Context, startDate, endDate time.Time) ([]*entities.HistoricalData, error) // Todos los símbolos
 
 	// Price Analysis
 	GetHighestPrice(ctx context.Context, symbol string, startDate, endDate time.Time) (*entities.HistoricalData, error)
@@ -0,0 +1,25 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+)
+
+// AnalyticsViewRepository reads the rating and sector aggregates from the materialized views
+// created by migration 000008. The views are only as fresh as their last Refresh.
+type AnalyticsViewRepository interface {
+	// Refresh recomputes every analytics view
+	Refresh(ctx context.Context) (*AnalyticsRefreshResult, error)
+
+	// Reads (counts are aggregated by UTC day, so the window starts at midnight N days ago)
+	GetTopCompaniesByRatingCount(ctx context.Context, days int, limit int) ([]CompanyRatingCount, error)
+	GetActionTypeDistribution(ctx context.Context, days int) (map[string]int64, error)
+	GetSectorDistribution(ctx context.Context) (map[string]int64, error)
+}
+
+// AnalyticsRefreshResult summarizes a refresh of the analytics views
+type AnalyticsRefreshResult struct {
+	Views       []string      `json:"views"`
+	RefreshedAt time.Time     `json:"refreshed_at"`
+	Duration    time.Duration `json:"duration"`
+}
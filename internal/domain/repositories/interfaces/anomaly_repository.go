@@ -0,0 +1,21 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+// AnomalyRepository defines the contract for anomaly data access
+type AnomalyRepository interface {
+	// Upsert stores anomalies; one already stored for the same company, type and day is updated
+	Upsert(ctx context.Context, anomalies []*entities.Anomaly) error
+
+	// GetSince retrieves the anomalies dated on or after since with their company, newest and largest first.
+	// An empty anomalyType returns every type
+	GetSince(ctx context.Context, since time.Time, anomalyType string, limit int) ([]*entities.Anomaly, error)
+
+	// CountCreatedSince counts the anomalies first detected at or after since, e.g. the ones a client has not seen yet
+	CountCreatedSince(ctx context.Context, since time.Time) (int64, error)
+}
@@ -0,0 +1,41 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+// BenchmarkRepository defines the contract for benchmark (market index) data access
+type BenchmarkRepository interface {
+	// GetAll returns every benchmark ordered by symbol
+	GetAll(ctx context.Context) ([]*entities.Benchmark, error)
+
+	// GetBySymbol returns the benchmark with that symbol or source symbol (SPX or SPY), case-insensitive
+	GetBySymbol(ctx context.Context, symbol string) (*entities.Benchmark, error)
+
+	// Create stores a new benchmark with its constituents; a symbol already in use is a duplicate
+	Create(ctx context.Context, benchmark *entities.Benchmark, constituents []*entities.BenchmarkConstituent) error
+
+	// Delete removes a benchmark with its values and constituents
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// MarkSynced records when the benchmark was last ingested from the provider
+	MarkSynced(ctx context.Context, id uuid.UUID, at time.Time) error
+
+	// UpsertValues stores daily values; one already stored for the same benchmark and date is updated
+	UpsertValues(ctx context.Context, values []*entities.BenchmarkValue) error
+
+	// GetValues returns the daily values of a benchmark between from and to (inclusive), oldest first
+	GetValues(ctx context.Context, benchmarkID uuid.UUID, from, to time.Time) ([]*entities.BenchmarkValue, error)
+
+	// SyncConstituents replaces the current composition of a benchmark as of asOf: members missing from
+	// constituents are closed on asOf, new ones are opened and the weights of the rest are updated
+	SyncConstituents(ctx context.Context, benchmarkID uuid.UUID, constituents []*entities.BenchmarkConstituent, asOf time.Time) (added, removed int, err error)
+
+	// GetConstituents returns the members of a benchmark on date, by weight descending
+	GetConstituents(ctx context.Context, benchmarkID uuid.UUID, date time.Time) ([]*entities.BenchmarkConstituent, error)
+}
@@ -3,8 +3,8 @@ package interfaces
 import (
 	"context"
 
-	"github.com/google/uuid"
 	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/google/uuid"
 )
 
 // BrokerageRepository defines the contract for brokerage data access
@@ -18,6 +18,8 @@ type BrokerageRepository interface {
 	GetByName(ctx context.Context, name string) (*entities.Brokerage, error)
 	GetAll(ctx context.Context) ([]*entities.Brokerage, error)
 	GetAllActive(ctx context.Context) ([]*entities.Brokerage, error)
+	// List returns a page of the brokerages matching filter in the given order (name by default) and the total matching
+	List(ctx context.Context, filter BrokerageListFilter, sort []SortField, limit, offset int) ([]*entities.Brokerage, int64, error)
 
 	// Update operations
 	Update(ctx context.Context, brokerage *entities.Brokerage) error
@@ -25,7 +27,7 @@ type BrokerageRepository interface {
 	Deactivate(ctx context.Context, id uuid.UUID) error
 
 	// Delete operations
-	Delete(ctx context.Context, id uuid.UUID) error // Soft delete
+	Delete(ctx context.Context, id uuid.UUID) error     // Soft delete
 	HardDelete(ctx context.Context, id uuid.UUID) error // Permanent delete
 
 	// Query operations
@@ -40,4 +42,10 @@ type BrokerageRepository interface {
 	// Relationship operations
 	GetWithRatings(ctx context.Context, id uuid.UUID) (*entities.Brokerage, error)
 	GetByRatingCount(ctx context.Context, limit int) ([]*entities.Brokerage, error)
-}
\ No newline at end of file
+}
+
+// BrokerageListFilter selects the brokerages of a listing; empty fields do not filter
+type BrokerageListFilter struct {
+	Name     string // Substring, case-insensitive
+	IsActive *bool
+}
@@ -0,0 +1,24 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+// CompanyEnrichmentConflictRepository defines the contract for enrichment conflict data access
+type CompanyEnrichmentConflictRepository interface {
+	// Create operations
+	// Record stores conflicts; a conflict already recorded (same fingerprint) is bumped instead of duplicated
+	Record(ctx context.Context, conflicts []*entities.CompanyEnrichmentConflict) error
+
+	// Read operations
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.CompanyEnrichmentConflict, error)
+	List(ctx context.Context, status entities.EnrichmentConflictStatus, limit, offset int) ([]*entities.CompanyEnrichmentConflict, error)
+	Count(ctx context.Context, status entities.EnrichmentConflictStatus) (int64, error)
+
+	// Review operations
+	MarkReviewed(ctx context.Context, id uuid.UUID, status entities.EnrichmentConflictStatus) error
+}
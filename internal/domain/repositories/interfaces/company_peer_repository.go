@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+// CompanyPeerRepository defines the contract for the competitor graph data access
+type CompanyPeerRepository interface {
+	// GetByCompanyID returns the peers of a company with the peer company loaded, closest first
+	GetByCompanyID(ctx context.Context, companyID uuid.UUID) ([]*entities.CompanyPeer, error)
+
+	// ReplaceForCompany replaces every peer of a company in one transaction
+	ReplaceForCompany(ctx context.Context, companyID uuid.UUID, peers []*entities.CompanyPeer) error
+}
@@ -0,0 +1,28 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+// CompanyRelationshipRepository defines the contract for the declared company graph data access
+type CompanyRelationshipRepository interface {
+	// Create stores a new relationship; the same edge twice is a duplicate and an unknown company a conflict
+	Create(ctx context.Context, relationship *entities.CompanyRelationship) error
+
+	// GetByID returns a relationship with both companies loaded
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.CompanyRelationship, error)
+
+	// Update stores the type and note of a relationship
+	Update(ctx context.Context, relationship *entities.CompanyRelationship) error
+
+	// Delete removes a relationship
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// GetByCompanyIDs returns the relationships that start or end at any of the companies, with both companies
+	// loaded, optionally only of the given types
+	GetByCompanyIDs(ctx context.Context, companyIDs []uuid.UUID, types []string) ([]*entities.CompanyRelationship, error)
+}
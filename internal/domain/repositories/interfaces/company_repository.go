@@ -3,8 +3,8 @@ package interfaces
 import (
 	"context"
 
-	"github.com/google/uuid"
 	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/google/uuid"
 )
 
 // CompanyRepository defines the contract for company data access
@@ -15,21 +15,38 @@ type CompanyRepository interface {
 
 	// Read operations
 	GetByID(ctx context.Context, id uuid.UUID) (*entities.Company, error)
-	GetByTicker(ctx context.Context, ticker string) (*entities.Company, error)
+	GetByTicker(ctx context.Context, ticker string) (*entities.Company, error) // Resolves former tickers (aliases)
 	GetByName(ctx context.Context, name string) (*entities.Company, error)
 	GetAll(ctx context.Context) ([]*entities.Company, error)
 	GetAllActive(ctx context.Context) ([]*entities.Company, error)
+	// List returns a page of the companies matching filter in the given order (ticker by default) and the total matching
+	List(ctx context.Context, filter CompanyListFilter, sort []SortField, limit, offset int) ([]*entities.Company, int64, error)
 
-	// Update operations
-	Update(ctx context.Context, company *entities.Company) error
+	// Update operations: the hooks run in the transaction of the change with the updated company
+	Update(ctx context.Context, company *entities.Company, hooks ...WriteHook[entities.Company]) error
 	UpdateMarketCap(ctx context.Context, ticker string, marketCap float64) error
-	Activate(ctx context.Context, id uuid.UUID) error
-	Deactivate(ctx context.Context, id uuid.UUID) error
+	Activate(ctx context.Context, id uuid.UUID, hooks ...WriteHook[entities.Company]) error
+	Deactivate(ctx context.Context, id uuid.UUID, hooks ...WriteHook[entities.Company]) error
+
+	// Ticker change operations
+	// RemapTicker renames the company ticker and keeps the previous one as an alias
+	RemapTicker(ctx context.Context, id uuid.UUID, newTicker string, hooks ...WriteHook[entities.Company]) (*entities.Company, error)
+	GetTickerAliases(ctx context.Context, id uuid.UUID) ([]*entities.TickerAlias, error)
+
+	// Merge operations
+	// MergeInto moves the data of a duplicate company to target and soft-deletes the duplicate.
+	// With dryRun the changes are computed inside the transaction and rolled back.
+	MergeInto(ctx context.Context, duplicateID, targetID uuid.UUID, dryRun bool) (*CompanyMergeResult, error)
 
 	// Delete operations
-	Delete(ctx context.Context, id uuid.UUID) error // Soft delete
+	Delete(ctx context.Context, id uuid.UUID) error     // Soft delete
 	HardDelete(ctx context.Context, id uuid.UUID) error // Permanent delete
 
+	// Soft-delete recovery operations
+	ListDeleted(ctx context.Context, limit, offset int) ([]*entities.Company, error)
+	CountDeleted(ctx context.Context) (int64, error)
+	Restore(ctx context.Context, id uuid.UUID) error
+
 	// Query operations - Basic
 	ExistsByTicker(ctx context.Context, ticker string) (bool, error)
 	ExistsByName(ctx context.Context, name string) (bool, error)
@@ -45,10 +62,10 @@ type CompanyRepository interface {
 	// Business operations (for API sync) - CRITICAL
 	FindOrCreateByTicker(ctx context.Context, ticker, name string) (*entities.Company, error)
 	FindOrCreateWithDetails(ctx context.Context, ticker, name, sector, exchange string, marketCap float64) (*entities.Company, error)
-	
+
 	// Batch operations for sync
 	UpsertMany(ctx context.Context, companies []*entities.Company) error
-	
+
 	// Relationship operations
 	GetWithRatings(ctx context.Context, id uuid.UUID) (*entities.Company, error)
 	GetByRatingCount(ctx context.Context, limit int) ([]*entities.Company, error)
@@ -57,9 +74,52 @@ type CompanyRepository interface {
 	// Search operations
 	SearchByName(ctx context.Context, query string, limit int) ([]*entities.Company, error)
 	SearchByTicker(ctx context.Context, query string, limit int) ([]*entities.Company, error)
+	// SuggestByPrefix returns active companies whose ticker, or else name, starts with prefix (typeahead)
+	SuggestByPrefix(ctx context.Context, prefix string, limit int) ([]CompanySuggestion, error)
 
 	// Analytics operations
 	GetSectorDistribution(ctx context.Context) (map[string]int64, error)
 	GetExchangeDistribution(ctx context.Context) (map[string]int64, error)
 	GetMarketCapStats(ctx context.Context) (map[string]float64, error) // min, max, avg, median
-}
\ No newline at end of file
+	// GetMarketCapStatsByGroup returns the market cap statistics of the active companies per exchange and
+	// currency, in the currency of each group
+	GetMarketCapStatsByGroup(ctx context.Context) ([]MarketCapGroupStats, error)
+}
+
+// MarketCapGroupStats are the market cap statistics of the active companies of one exchange and currency
+// (empty when the company does not record it)
+type MarketCapGroupStats struct {
+	Exchange string
+	Currency string
+	Count    int64
+	Sum      float64
+	Min      float64
+	Max      float64
+}
+
+// CompanyListFilter selects the companies of a listing; empty fields do not filter
+type CompanyListFilter struct {
+	Sector   string
+	Exchange string
+	IsActive *bool
+	Tags     []string // Slugs distintos: solo las companies con todas las etiquetas
+}
+
+// CompanySuggestion is the ticker+name pair returned by the typeahead
+type CompanySuggestion struct {
+	ID     uuid.UUID `json:"id"`
+	Ticker string    `json:"ticker"`
+	Name   string    `json:"name"`
+}
+
+// CompanyMergeResult summarizes the rows moved (or that would be moved) by a company merge
+type CompanyMergeResult struct {
+	Duplicate         *entities.Company `json:"duplicate"`
+	Target            *entities.Company `json:"target"`
+	RatingsMoved      int64             `json:"ratings_moved"`
+	RatingsDuplicated int64             `json:"ratings_duplicated"` // Ya existían en target; se eliminan con el duplicado
+	MarketDataMoved   int64             `json:"market_data_moved"`
+	ProfileMoved      bool              `json:"profile_moved"`
+	NewsMoved         int64             `json:"news_moved"`
+	AliasesMoved      int64             `json:"aliases_moved"`
+}
@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+// FinancialStatementRepository defines the contract for financial statement data access
+type FinancialStatementRepository interface {
+	// Upsert stores statements; one already stored for the same company, statement, period and fiscal date is updated
+	Upsert(ctx context.Context, statements []*entities.FinancialStatement) error
+
+	// GetByCompany returns the latest limit statements of a kind and period of a company, newest first
+	GetByCompany(ctx context.Context, companyID uuid.UUID, statement, period string, limit int) ([]*entities.FinancialStatement, error)
+}
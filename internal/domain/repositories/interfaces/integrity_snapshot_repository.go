@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+// IntegritySnapshotRepository defines the contract for the integrity validation history
+type IntegritySnapshotRepository interface {
+	// Create stores the summary of an integrity validation
+	Create(ctx context.Context, snapshot *entities.IntegritySnapshot) error
+
+	// GetSince retrieves the snapshots taken on or after since, oldest first
+	GetSince(ctx context.Context, since time.Time, limit int) ([]*entities.IntegritySnapshot, error)
+}
@@ -0,0 +1,38 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/google/uuid"
+)
+
+// JobRepository defines the contract for background job data access
+type JobRepository interface {
+	// Create operations
+	Create(ctx context.Context, job *entities.Job) error
+
+	// Read operations
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Job, error)
+	List(ctx context.Context, status entities.JobStatus, limit, offset int) ([]*entities.Job, error)
+	Count(ctx context.Context, status entities.JobStatus) (int64, error)
+
+	// Worker operations
+	// ClaimNext locks the next runnable job of the given types for workerID; returns nil when the queue is empty
+	ClaimNext(ctx context.Context, workerID string, jobTypes []string) (*entities.Job, error)
+	MarkSucceeded(ctx context.Context, id uuid.UUID, result string) error
+	MarkFailed(ctx context.Context, id uuid.UUID, errMsg string) error
+	ScheduleRetry(ctx context.Context, id uuid.UUID, errMsg string, runAt time.Time) error
+	// Release returns a running job to the queue without counting the attempt it was claimed for
+	Release(ctx context.Context, id uuid.UUID) error
+	MarkCancelled(ctx context.Context, id uuid.UUID) error
+	Heartbeat(ctx context.Context, id uuid.UUID, workerID string) error
+	// UpdateProgress stores the partial result of a running job locked by workerID
+	UpdateProgress(ctx context.Context, id uuid.UUID, workerID, progress string) error
+	RequeueStale(ctx context.Context, lockedBefore time.Time) (int64, error)
+
+	// Cancellation operations
+	RequestCancel(ctx context.Context, id uuid.UUID) (*entities.Job, error)
+	IsCancelRequested(ctx context.Context, id uuid.UUID) (bool, error)
+}
@@ -26,6 +26,8 @@ type MarketDataRepository interface {
 	GetLatest(ctx context.Context, limit int) ([]*entities.MarketData, error)
 	GetByTimeRange(ctx context.Context, startTime, endTime time.Time) ([]*entities.MarketData, error)
 	GetStaleData(ctx context.Context, maxAge time.Duration) ([]*entities.MarketData, error)
+	// GetLatestTimestamps returns the market timestamp of the latest quote of each symbol; symbols without quotes are absent
+	GetLatestTimestamps(ctx context.Context, symbols []string) (map[string]time.Time, error)
 
 	// Market analysis
 	GetTopGainers(ctx context.Context, limit int) ([]*entities.MarketData, error)
@@ -35,7 +37,8 @@ type MarketDataRepository interface {
 	// Bulk operations
 	BulkCreate(ctx context.Context, marketData []*entities.MarketData) error
 	BulkUpdate(ctx context.Context, marketData []*entities.MarketData) error
-	UpsertBySymbol(ctx context.Context, marketData *entities.MarketData) error
+	// UpsertBySymbol runs the hooks in the transaction of the upsert with the saved quote
+	UpsertBySymbol(ctx context.Context, marketData *entities.MarketData, hooks ...WriteHook[entities.MarketData]) error
 
 	// Data management
 	CleanupOldData(ctx context.Context, olderThan time.Time) (int64, error)
@@ -0,0 +1,38 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+// NoteRepository defines the contract for the private notes of the users. Every operation is scoped to the
+// tenant and the credential of the context and fails with tenancy.ErrNoTenant or tenancy.ErrNoCredential
+// without them; the notes of other users are not found
+type NoteRepository interface {
+	// Create stores a note of the user of the context; an unknown company or rating is a conflict
+	Create(ctx context.Context, note *entities.Note) error
+
+	// GetByID returns a note of the user
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Note, error)
+
+	// List returns the notes of the user that match the filter, newest first, and their total
+	List(ctx context.Context, filter NoteListFilter, limit, offset int) ([]*entities.Note, int64, error)
+
+	// GetByRatingIDs returns the notes of the user on the given ratings, newest first
+	GetByRatingIDs(ctx context.Context, ratingIDs []uuid.UUID) ([]*entities.Note, error)
+
+	// Update stores the body of a note of the user
+	Update(ctx context.Context, note *entities.Note) error
+
+	// Delete removes a note of the user
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// NoteListFilter narrows the notes of a user; zero values do not filter
+type NoteListFilter struct {
+	CompanyID *uuid.UUID // Notas de la company, incluidas las de sus ratings
+	RatingID  *uuid.UUID
+}
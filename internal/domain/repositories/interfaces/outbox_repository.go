@@ -0,0 +1,32 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+// OutboxRepository defines the contract for the outbox of domain events
+type OutboxRepository interface {
+	// Create operations: AddWithTx writes the events in the transaction of the change that produced them
+	Add(ctx context.Context, events []*entities.OutboxEvent) error
+	AddWithTx(ctx context.Context, tx *gorm.DB, events []*entities.OutboxEvent) error
+
+	// ClaimPending reserves the unpublished events due for an attempt, in write order, pushing their next attempt
+	// lease into the future so other relays (API and worker) skip them; rows locked by another claim are skipped
+	ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*entities.OutboxEvent, error)
+	CountPending(ctx context.Context) (int64, error)
+
+	// Delivery operations
+	MarkPublished(ctx context.Context, ids []uuid.UUID) error
+	MarkFailed(ctx context.Context, id uuid.UUID, cause string, nextAttemptAt time.Time) error
+	// Release hands claimed events that were not attempted back for an attempt at nextAttemptAt, without a failure
+	Release(ctx context.Context, ids []uuid.UUID, nextAttemptAt time.Time) error
+
+	// DeletePublishedBefore removes the events published before the given time and returns how many were removed
+	DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error)
+}
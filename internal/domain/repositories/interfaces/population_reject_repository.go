@@ -0,0 +1,27 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+// PopulationRejectRepository defines the contract for population dead-letter data access
+type PopulationRejectRepository interface {
+	// Create operations
+	// Record stores rejects; a reject already recorded (same fingerprint) is bumped instead of duplicated
+	Record(ctx context.Context, rejects []*entities.PopulationReject) error
+
+	// Read operations
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.PopulationReject, error)
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entities.PopulationReject, error)
+	List(ctx context.Context, status entities.PopulationRejectStatus, limit, offset int) ([]*entities.PopulationReject, error)
+	Count(ctx context.Context, status entities.PopulationRejectStatus) (int64, error)
+
+	// Reprocess operations
+	MarkReprocessed(ctx context.Context, id uuid.UUID) error
+	MarkDiscarded(ctx context.Context, id uuid.UUID) error
+	RecordFailedAttempt(ctx context.Context, id uuid.UUID, errMsg string) error
+}
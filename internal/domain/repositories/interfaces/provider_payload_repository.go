@@ -0,0 +1,32 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+// ProviderPayloadFilter narrows the archived provider payloads; empty fields do not filter
+type ProviderPayloadFilter struct {
+	Provider string
+	Endpoint string
+	Symbol   string
+	Since    time.Time // Solo respuestas recibidas desde este instante
+}
+
+// ProviderPayloadRepository defines the contract for the archive of raw provider responses.
+// Expired payloads are never returned, even before the row-level TTL deletes them
+type ProviderPayloadRepository interface {
+	// Create operations
+	Create(ctx context.Context, payload *entities.ProviderPayload) error
+
+	// Read operations
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.ProviderPayload, error)
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entities.ProviderPayload, error)
+	// List returns the payloads matching the filter, newest first, without their compressed body
+	List(ctx context.Context, filter ProviderPayloadFilter, limit, offset int) ([]*entities.ProviderPayload, error)
+	Count(ctx context.Context, filter ProviderPayloadFilter) (int64, error)
+}
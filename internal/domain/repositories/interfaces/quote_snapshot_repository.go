@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+// QuoteSnapshotRepository defines the contract for the intraday quote history of each symbol
+type QuoteSnapshotRepository interface {
+	// Append stores a snapshot (ignored if the symbol already has one with the same market timestamp) and
+	// prunes the symbol's oldest snapshots so that at most capacity remain
+	Append(ctx context.Context, snapshot *entities.QuoteSnapshot, capacity int) error
+
+	// GetBySymbolSince retrieves the snapshots of a symbol taken on or after since, oldest first
+	GetBySymbolSince(ctx context.Context, symbol string, since time.Time) ([]*entities.QuoteSnapshot, error)
+}
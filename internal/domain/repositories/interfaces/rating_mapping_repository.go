@@ -0,0 +1,27 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+// RatingMappingRepository defines the contract for the table that maps free-text brokerage ratings to the
+// canonical scale, and for applying it to the stored stock ratings
+type RatingMappingRepository interface {
+	List(ctx context.Context) ([]*entities.RatingMapping, error)
+	Upsert(ctx context.Context, mapping *entities.RatingMapping) error
+	Delete(ctx context.Context, rating string) error
+
+	// ApplyToRatings re-normalizes the stored ratings with the current table and returns the ratings changed
+	ApplyToRatings(ctx context.Context) (int64, error)
+
+	// Unmapped returns the rating variants without mapping, the most frequent first
+	Unmapped(ctx context.Context, limit int) ([]UnmappedRating, error)
+}
+
+// UnmappedRating is a free-text rating variant without mapping and the number of ratings that use it
+type UnmappedRating struct {
+	Rating string `json:"rating"`
+	Count  int64  `json:"count"`
+}
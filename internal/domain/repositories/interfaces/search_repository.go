@@ -0,0 +1,33 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tipos de resultado de la búsqueda de texto completo
+const (
+	SearchTypeCompany = "company"
+	SearchTypeNews    = "news"
+)
+
+// SearchRepository runs full-text searches over companies and news (migration 000009)
+type SearchRepository interface {
+	// Search returns the hits of every requested type ranked by relevance, best first.
+	// An empty types slice searches every type.
+	Search(ctx context.Context, query string, types []string, limit int) ([]SearchHit, error)
+}
+
+// SearchHit is a ranked search result of any type
+type SearchHit struct {
+	Type        string     `json:"type"`
+	ID          uuid.UUID  `json:"id"`
+	Symbol      string     `json:"symbol"`
+	Title       string     `json:"title"`   // Nombre de la company o titular de la noticia
+	Snippet     string     `json:"snippet"` // Sector de la company o resumen de la noticia
+	URL         string     `json:"url,omitempty"`
+	Rank        float64    `json:"rank"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+}
@@ -0,0 +1,96 @@
+package interfaces
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SortField orders a listing by a whitelisted column
+type SortField struct {
+	Column string
+	Desc   bool
+}
+
+// SortableFields maps the field names accepted by the `sort` parameter of a listing to their columns.
+// Only these columns ever reach ORDER BY, so the parameter cannot inject SQL
+type SortableFields map[string]string
+
+var (
+	// CompanySortFields are the sortable fields of the companies listing
+	CompanySortFields = SortableFields{
+		"ticker":     "ticker",
+		"name":       "name",
+		"sector":     "sector",
+		"exchange":   "exchange",
+		"market_cap": "market_cap",
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+	}
+
+	// BrokerageSortFields are the sortable fields of the brokerages listing
+	BrokerageSortFields = SortableFields{
+		"name":       "name",
+		"country":    "country",
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+	}
+
+	// StockRatingSortFields are the sortable fields of the ratings listing
+	StockRatingSortFields = SortableFields{
+		"event_time":  "event_time",
+		"created_at":  "created_at",
+		"action":      "action",
+		"action_type": "action_type",
+		"rating_to":   "rating_to",
+		"target_to":   "target_to_value",
+	}
+)
+
+// Parse parses a comma-separated list of `field:asc|desc` (the direction defaults to asc), e.g.
+// "market_cap:desc,ticker". An empty value returns no fields, so the repository applies its default order
+func (f SortableFields) Parse(value string) ([]SortField, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	var fields []SortField
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ",") {
+		name, direction, _ := strings.Cut(strings.TrimSpace(part), ":")
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		column, ok := f[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown sort field %q (allowed: %s)", name, strings.Join(f.Names(), ", "))
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate sort field %q", name)
+		}
+		seen[name] = true
+
+		var desc bool
+		switch strings.ToLower(strings.TrimSpace(direction)) {
+		case "", "asc":
+		case "desc":
+			desc = true
+		default:
+			return nil, fmt.Errorf("invalid sort direction %q for field %q (use asc or desc)", direction, name)
+		}
+
+		fields = append(fields, SortField{Column: column, Desc: desc})
+	}
+
+	return fields, nil
+}
+
+// Names returns the accepted field names in alphabetical order
+func (f SortableFields) Names() []string {
+	names := make([]string, 0, len(f))
+	for name := range f {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -17,6 +17,8 @@ type StockRatingRepository interface {
 
 	// Read operations - Basic
 	GetByID(ctx context.Context, id uuid.UUID) (*entities.StockRating, error)
+	// GetByIDWithDeleted also finds soft-deleted ratings (e.g. the since_id cursor of the long-poll)
+	GetByIDWithDeleted(ctx context.Context, id uuid.UUID) (*entities.StockRating, error)
 	GetAll(ctx context.Context) ([]*entities.StockRating, error)
 	GetByCompanyID(ctx context.Context, companyID uuid.UUID) ([]*entities.StockRating, error)
 	GetByBrokerageID(ctx context.Context, brokerageID uuid.UUID) ([]*entities.StockRating, error)
@@ -25,13 +27,21 @@ type StockRatingRepository interface {
 	GetByCompanyAndBrokerage(ctx context.Context, companyID, brokerageID uuid.UUID) ([]*entities.StockRating, error)
 	GetByEventTimeRange(ctx context.Context, startTime, endTime time.Time) ([]*entities.StockRating, error)
 	GetByCompanyAndDateRange(ctx context.Context, companyID uuid.UUID, startTime, endTime time.Time) ([]*entities.StockRating, error)
+	// GetLatestByBrokerageAsOf returns the latest rating of each brokerage for the company with event time before
+	// before, newest first, with company and brokerage preloaded
+	GetLatestByBrokerageAsOf(ctx context.Context, companyID uuid.UUID, before time.Time) ([]*entities.StockRating, error)
 	GetRecent(ctx context.Context, days int, limit int) ([]*entities.StockRating, error)
+	// ListCreatedAfter returns the ratings created after the (afterCreatedAt, afterID) cursor, oldest first
+	ListCreatedAfter(ctx context.Context, afterCreatedAt time.Time, afterID uuid.UUID, limit int) ([]*entities.StockRating, error)
 
 	// Read operations - By action type
 	GetUpgrades(ctx context.Context, limit int) ([]*entities.StockRating, error)
 	GetDowngrades(ctx context.Context, limit int) ([]*entities.StockRating, error)
 	GetReiterations(ctx context.Context, limit int) ([]*entities.StockRating, error)
-	GetByActionType(ctx context.Context, actionType string, limit int) ([]*entities.StockRating, error)
+	GetByActionType(ctx context.Context, actionType entities.ActionType, limit int) ([]*entities.StockRating, error)
+
+	// Read operations - Combined filters, newest first by default; returns the page and the total matching
+	Search(ctx context.Context, filter RatingSearchFilter, sort []SortField, limit, offset int) ([]*entities.StockRating, int64, error)
 
 	// Update operations
 	Update(ctx context.Context, rating *entities.StockRating) error
@@ -43,11 +53,16 @@ type StockRatingRepository interface {
 	Delete(ctx context.Context, id uuid.UUID) error     // Soft delete
 	HardDelete(ctx context.Context, id uuid.UUID) error // Permanent delete
 
+	// Soft-delete recovery operations
+	ListDeleted(ctx context.Context, limit, offset int) ([]*entities.StockRating, error)
+	CountDeleted(ctx context.Context) (int64, error)
+	Restore(ctx context.Context, id uuid.UUID) error
+
 	// Query operations - Basic stats
 	Count(ctx context.Context) (int64, error)
 	CountByCompany(ctx context.Context, companyID uuid.UUID) (int64, error)
 	CountByBrokerage(ctx context.Context, brokerageID uuid.UUID) (int64, error)
-	CountByActionType(ctx context.Context, actionType string) (int64, error)
+	CountByActionType(ctx context.Context, actionType entities.ActionType) (int64, error)
 
 	// Business operations - CRITICAL for API sync
 	FindExisting(ctx context.Context, companyID, brokerageID uuid.UUID, eventTime time.Time) (*entities.StockRating, error)
@@ -56,10 +71,27 @@ type StockRatingRepository interface {
 	UpsertMany(ctx context.Context, ratings []*entities.StockRating) error
 	BulkInsertIgnoreDuplicates(ctx context.Context, ratings []*entities.StockRating) (int, error) // Returns count inserted
 
+	// Reprocessing operations (re-parse RawData after adapter fixes)
+	// ListWithRawData returns ratings with raw data and event time in [from, to), ordered by event time and ID,
+	// after the (afterEventTime, afterID) cursor; a nil afterID starts at from
+	ListWithRawData(ctx context.Context, from, to time.Time, afterEventTime time.Time, afterID uuid.UUID, limit int) ([]*entities.StockRating, error)
+	// ReplaceRating upserts rating by its event (company, brokerage, event time) and soft-deletes the original
+	// rating when the upsert landed on a different row
+	ReplaceRating(ctx context.Context, originalID uuid.UUID, rating *entities.StockRating) error
+	// BackfillActionTypes parses the action of the ratings without action type (every rating when all is true)
+	// and stores it in batches of batchSize rows; returns the number of rows updated
+	BackfillActionTypes(ctx context.Context, all bool, batchSize int) (int64, error)
+	// BackfillTargetPrices parses the price targets of the ratings never parsed (every rating when all is true)
+	// in batches of batchSize rows; returns the number of rows updated
+	BackfillTargetPrices(ctx context.Context, all bool, batchSize int) (int64, error)
+
 	// Processing operations (for background jobs)
 	GetUnprocessed(ctx context.Context, limit int) ([]*entities.StockRating, error)
 	GetUnprocessedBySource(ctx context.Context, source string, limit int) ([]*entities.StockRating, error)
 	GetProcessingBatch(ctx context.Context, batchSize int) ([]*entities.StockRating, error)
+	CountUnprocessed(ctx context.Context) (int64, error) // Backlog of the rating processing job
+	CountUnprocessedBySource(ctx context.Context) (map[string]int64, error)
+	CountProcessedSince(ctx context.Context, since time.Time) (int64, error)
 
 	// Relationship operations - with preloading
 	GetWithCompany(ctx context.Context, id uuid.UUID) (*entities.StockRating, error)
@@ -72,6 +104,12 @@ type StockRatingRepository interface {
 	GetTopCompaniesByRatingCount(ctx context.Context, days int, limit int) ([]CompanyRatingCount, error)
 	GetTopBrokeragesByRatingCount(ctx context.Context, days int, limit int) ([]BrokerageRatingCount, error)
 	GetRatingTrend(ctx context.Context, companyID uuid.UUID, days int) ([]DailyRatingCount, error)
+	// GetActionTypeTrend counts the ratings of [from, to) per action type in buckets of the granularity, truncated in
+	// SQL in the given location. Buckets without ratings are not returned
+	GetActionTypeTrend(ctx context.Context, from, to time.Time, granularity TrendGranularity, location *time.Location) ([]RatingTrendBucket, error)
+	// GetSectorActionTrend counts the ratings of [from, to) per sector of the company in buckets of the granularity,
+	// truncated in SQL in the given location. Companies without sector and empty buckets are not returned
+	GetSectorActionTrend(ctx context.Context, from, to time.Time, granularity TrendGranularity, location *time.Location) ([]SectorActionCount, error)
 
 	// Time-based queries
 	GetTodaysRatings(ctx context.Context) ([]*entities.StockRating, error)
@@ -80,6 +118,8 @@ type StockRatingRepository interface {
 
 	// Duplicate detection and cleanup
 	FindDuplicates(ctx context.Context) ([]DuplicateGroup, error)
+	// FindNearDuplicates also groups the same rating change re-emitted with event times at most window apart
+	FindNearDuplicates(ctx context.Context, window time.Duration) ([]DuplicateGroup, error)
 	RemoveDuplicates(ctx context.Context, keepNewest bool) (int, error) // Returns count removed
 
 	// Data quality operations
@@ -109,6 +149,26 @@ type BrokerageRatingCount struct {
 	RatingCount   int64     `json:"rating_count"`
 }
 
+// RatingSearchFilter combines the filters of a ratings search; empty fields do not filter.
+// Parsed targets are only comparable within a currency, so TargetMin and TargetMax require TargetCurrency
+type RatingSearchFilter struct {
+	CompanyID   *uuid.UUID
+	BrokerageID *uuid.UUID
+	ActionTypes []entities.ActionType
+	Ratings     []entities.RatingScale // Normalized RatingTo
+
+	TargetCurrency string // Only ratings with a parsed TargetTo in this currency
+	TargetMin      *float64
+	TargetMax      *float64
+
+	From time.Time // event_time >= From
+	To   time.Time // event_time < To
+
+	// Relations preloaded with the page (they do not filter)
+	PreloadCompany   bool
+	PreloadBrokerage bool
+}
+
 // DailyRatingCount represents rating count per day
 type DailyRatingCount struct {
 	Date         time.Time `json:"date"`
@@ -118,6 +178,36 @@ type DailyRatingCount struct {
 	Reiterations int64     `json:"reiterations"`
 }
 
+// TrendGranularity is the size of the buckets of a trend; the values are date_trunc units
+type TrendGranularity string
+
+const (
+	TrendGranularityDay   TrendGranularity = "day"
+	TrendGranularityWeek  TrendGranularity = "week" // Semanas ISO: empiezan el lunes
+	TrendGranularityMonth TrendGranularity = "month"
+)
+
+// Valid reports whether the granularity is supported
+func (g TrendGranularity) Valid() bool {
+	return g == TrendGranularityDay || g == TrendGranularityWeek || g == TrendGranularityMonth
+}
+
+// RatingTrendBucket represents the ratings of one time bucket by action type
+type RatingTrendBucket struct {
+	Start   time.Time        `json:"start"`
+	Total   int64            `json:"total"`
+	Actions map[string]int64 `json:"actions"`
+}
+
+// SectorActionCount represents the ratings of the companies of one sector in one time bucket
+type SectorActionCount struct {
+	Start      time.Time `json:"start"`
+	Sector     string    `json:"sector"`
+	Total      int64     `json:"total"`
+	Upgrades   int64     `json:"upgrades"`
+	Downgrades int64     `json:"downgrades"`
+}
+
 // DuplicateGroup represents a group of duplicate ratings
 type DuplicateGroup struct {
 	CompanyID   uuid.UUID   `json:"company_id"`
@@ -125,6 +215,7 @@ type DuplicateGroup struct {
 	EventTime   time.Time   `json:"event_time"`
 	RatingIDs   []uuid.UUID `json:"rating_ids"`
 	Count       int         `json:"count"`
+	Near        bool        `json:"near,omitempty"` // Event times differ within the duplicate window
 }
 
 // DataIntegrityReport represents data quality metrics
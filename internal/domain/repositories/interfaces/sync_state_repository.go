@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+// SyncStateRepository defines the contract for ingestion watermark data access
+type SyncStateRepository interface {
+	// GetBySource returns the sync state of a source, or nil if it has never been synced
+	GetBySource(ctx context.Context, source string) (*entities.SyncState, error)
+
+	// Upsert creates or replaces the sync state of a source
+	Upsert(ctx context.Context, state *entities.SyncState) error
+
+	// GetAll returns the sync state of every known source
+	GetAll(ctx context.Context) ([]*entities.SyncState, error)
+}
@@ -0,0 +1,23 @@
+package interfaces
+
+import (
+	"context"
+	"io"
+)
+
+// TableSnapshotRepository reads whole tables for the database backups. Table names come from
+// configuration and are validated as plain identifiers before being used in a query
+type TableSnapshotRepository interface {
+	// TableExists reports whether the table is present in the current schema
+	TableExists(ctx context.Context, table string) (bool, error)
+
+	// ExportTable writes every row of the table to w as one JSON object per line and returns the rows written
+	ExportTable(ctx context.Context, table string, w io.Writer) (int64, error)
+
+	// Columns returns the column names of the table in declaration order
+	Columns(ctx context.Context, table string) ([]string, error)
+	CountRows(ctx context.Context, table string) (int64, error)
+
+	// SchemaVersion returns the migration version applied to the database (0 if none)
+	SchemaVersion(ctx context.Context) (uint, error)
+}
@@ -0,0 +1,42 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+// TagRepository defines the contract for tags and their assignment to companies
+type TagRepository interface {
+	// Create stores a new tag; a slug already in use is a duplicate
+	Create(ctx context.Context, tag *entities.Tag) error
+
+	// GetBySlug returns a tag by its slug
+	GetBySlug(ctx context.Context, slug string) (*entities.Tag, error)
+
+	// GetAll returns every tag by slug with the number of active companies tagged with it
+	GetAll(ctx context.Context) ([]TagUsage, error)
+
+	// Update stores the name and description of a tag
+	Update(ctx context.Context, tag *entities.Tag) error
+
+	// Delete removes a tag and its assignments
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// GetByCompanyID returns the tags of a company by slug
+	GetByCompanyID(ctx context.Context, companyID uuid.UUID) ([]*entities.Tag, error)
+
+	// AddCompany tags a company; tagging it again is a no-op and an unknown company a conflict
+	AddCompany(ctx context.Context, tagID, companyID uuid.UUID) error
+
+	// RemoveCompany untags a company; a company without the tag is not found
+	RemoveCompany(ctx context.Context, tagID, companyID uuid.UUID) error
+}
+
+// TagUsage is a tag with the number of active companies tagged with it
+type TagUsage struct {
+	Tag       *entities.Tag
+	Companies int64
+}
@@ -0,0 +1,34 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+// TenantRepository defines the contract for the tenants of a shared deployment
+type TenantRepository interface {
+	Create(ctx context.Context, tenant *entities.Tenant) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Tenant, error)
+	GetBySlug(ctx context.Context, slug string) (*entities.Tenant, error)
+	List(ctx context.Context) ([]*entities.Tenant, error)
+	SetActive(ctx context.Context, id uuid.UUID, active bool) error
+	SetPlan(ctx context.Context, id uuid.UUID, plan string) error
+}
+
+// APIKeyRepository defines the contract for the API keys of the tenants. Every operation except the
+// authentication lookup is scoped to the tenant of the context and fails with tenancy.ErrNoTenant without one
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *entities.APIKey) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.APIKey, error)
+	List(ctx context.Context) ([]*entities.APIKey, error)
+	Revoke(ctx context.Context, id uuid.UUID, at time.Time) error
+	SetTimezone(ctx context.Context, id uuid.UUID, timezone string) error
+
+	// GetByHash finds a key across tenants; only the authentication middleware uses it
+	GetByHash(ctx context.Context, keyHash string) (*entities.APIKey, error)
+	TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error
+}
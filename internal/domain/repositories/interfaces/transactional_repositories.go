@@ -8,6 +8,10 @@ import (
 	"gorm.io/gorm"
 )
 
+// WriteHook runs inside the transaction of a repository write, after the change and with the saved entity; an error
+// rolls the write back. The outbox uses it to store the domain event of the change in the same transaction
+type WriteHook[T any] func(tx *gorm.DB, entity *T) error
+
 // TransactionalCompanyRepository extends CompanyRepository with transactional operations
 type TransactionalCompanyRepository interface {
 	CompanyRepository
@@ -43,4 +47,6 @@ type TransactionalStockRatingRepository interface {
 	CreateManyWithTx(ctx context.Context, tx *gorm.DB, stockRatings []*entities.StockRating) error
 	GetByIDWithTx(ctx context.Context, tx *gorm.DB, id uuid.UUID) (*entities.StockRating, error)
 	BulkInsertIgnoreDuplicatesWithTx(ctx context.Context, tx *gorm.DB, stockRatings []*entities.StockRating) (int, error)
+	// Igual que BulkInsertIgnoreDuplicatesWithTx pero devuelve los ratings realmente insertados
+	BulkInsertIgnoreDuplicatesReturningWithTx(ctx context.Context, tx *gorm.DB, stockRatings []*entities.StockRating) ([]*entities.StockRating, error)
 }
@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+// UsageRepository defines the contract for the hourly API usage of the credentials of the tenants
+type UsageRepository interface {
+	// SaveHourly stores the counters of complete or in-progress hours, replacing the stored values
+	SaveHourly(ctx context.Context, rows []*entities.APIUsage) error
+
+	// DailyByTenant returns the usage of the tenant of the context per credential and day in [from, to)
+	DailyByTenant(ctx context.Context, from, to time.Time) ([]*entities.APIUsage, error)
+
+	// Daily returns the usage of every tenant per credential and day in [from, to), for the administration roll-up
+	Daily(ctx context.Context, from, to time.Time) ([]*entities.APIUsage, error)
+}
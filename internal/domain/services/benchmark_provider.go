@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+// BenchmarkProvider defines the contract of the provider of benchmark values and constituents (Alpha Vantage).
+// Benchmarks are fetched through the instrument that replicates them (SourceSymbol)
+type BenchmarkProvider interface {
+	// FetchValues returns the daily values of the benchmark, the last 100 sessions or the full history
+	FetchValues(ctx context.Context, benchmark *entities.Benchmark, full bool) ([]*entities.BenchmarkValue, error)
+
+	// FetchConstituents returns the current members of the benchmark with their weights
+	FetchConstituents(ctx context.Context, benchmark *entities.Benchmark) ([]*entities.BenchmarkConstituent, error)
+
+	// Source names the provider
+	Source() string
+}
@@ -42,10 +42,63 @@ type CacheService interface {
 	GetStats(ctx context.Context) (CacheStats, error)
 	Ping(ctx context.Context) error
 	
+	// Generic value operations (serialized query results, e.g. repository caching)
+	// Get returns nil without error on a cache miss
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	DeleteByPrefix(ctx context.Context, prefix string) error
+
 	// Key operations
 	Exists(ctx context.Context, key string) (bool, error)
 	TTL(ctx context.Context, key string) (time.Duration, error)
 	Expire(ctx context.Context, key string, ttl time.Duration) error
+
+	// Rate limiting operations
+	// TakeToken consumes one token from the token bucket identified by key.
+	// The bucket holds up to capacity tokens and refills completely every window.
+	TakeToken(ctx context.Context, key string, capacity int, window time.Duration) (TokenBucketResult, error)
+
+	// Counter operations
+	// IncrementBy adds delta to the integer counter at key and returns the new value. A new counter
+	// starts at 0 and expires after ttl (0 keeps it forever); later increments do not extend it.
+	IncrementBy(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error)
+
+	// Sorted set operations (rankings)
+	// IncrementScore adds delta to the score of member in the sorted set at key. A new set expires after ttl
+	// (0 keeps it forever); later increments do not extend it.
+	IncrementScore(ctx context.Context, key, member string, delta float64, ttl time.Duration) error
+	// GetScores returns every member of the sorted set at key with its score; empty on a cache miss
+	GetScores(ctx context.Context, key string) (map[string]float64, error)
+}
+
+// TokenBucketResult represents the outcome of consuming a token from a rate limit bucket
+type TokenBucketResult struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	ResetAfter time.Duration // Time until the bucket is full again
+	RetryAfter time.Duration // Time until the next token is available (only when not allowed)
+}
+
+// NewTokenBucketResult builds the result for a bucket holding tokens after the current request
+func NewTokenBucketResult(allowed bool, capacity int, tokens float64, window time.Duration) TokenBucketResult {
+	result := TokenBucketResult{
+		Allowed:   allowed,
+		Limit:     capacity,
+		Remaining: int(tokens),
+	}
+
+	if capacity <= 0 || window <= 0 {
+		return result
+	}
+
+	perToken := float64(window) / float64(capacity)
+	result.ResetAfter = time.Duration((float64(capacity) - tokens) * perToken)
+	if !allowed {
+		result.RetryAfter = time.Duration((1 - tokens) * perToken)
+	}
+
+	return result
 }
 
 // CacheStats represents cache statistics
@@ -93,6 +146,7 @@ type CacheConfiguration struct {
 	CompanyPrefix   string `json:"company_prefix"`
 	BrokeragePrefix string `json:"brokerage_prefix"`
 	StockRatingPrefix string `json:"stock_rating_prefix"` // e.g. "stock_rating:"
+	NegativeSymbolPrefix string `json:"negative_symbol_prefix"` // Símbolos que no existen (negative caching)
 	
 	// Behavior settings
 	EnableCompression bool `json:"enable_compression"`
@@ -114,6 +168,7 @@ func DefaultCacheConfiguration() CacheConfiguration {
 		CompanyPrefix:   "company:ticker:",
 		BrokeragePrefix: "brokerage:name:",
 		StockRatingPrefix: "stock_rating:",
+		NegativeSymbolPrefix: "negative:symbol:",
 		EnableCompression: false,
 		MaxRetries:      3,
 		RetryDelay:      100 * time.Millisecond,
@@ -151,6 +206,11 @@ func GenerateBrokerageKey(prefix, name string) string {
 	return prefix + normalizeKey(name)
 }
 
+// GenerateNegativeSymbolKey generates the cache key that marks a symbol as not found
+func GenerateNegativeSymbolKey(prefix, symbol string) string {
+	return prefix + normalizeKey(symbol)
+}
+
 // GenerateStockRatingKey generates a cache key for a stock rating
 func GenerateStockRatingKey(prefix string, companyID, brokerageID uuid.UUID) string {
 	return fmt.Sprintf("%s%s:%s", prefix, companyID.String(), brokerageID.String())
@@ -0,0 +1,19 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+// FinancialStatementProvider defines the contract of the provider of reported financial statements (Alpha Vantage)
+type FinancialStatementProvider interface {
+	// FetchStatements returns every annual and quarterly report of one statement (income, balance or cash_flow)
+	// of symbol, ready to be stored for companyID
+	FetchStatements(ctx context.Context, companyID uuid.UUID, symbol, statement string) ([]*entities.FinancialStatement, error)
+
+	// Source names the provider
+	Source() string
+}
@@ -0,0 +1,12 @@
+package services
+
+import "context"
+
+// FXRateProvider defines the contract of the provider of currency exchange rates (Alpha Vantage)
+type FXRateProvider interface {
+	// GetRate returns how many units of toCurrency one unit of fromCurrency buys (ISO 4217 codes)
+	GetRate(ctx context.Context, fromCurrency, toCurrency string) (float64, error)
+
+	// Source names the provider
+	Source() string
+}
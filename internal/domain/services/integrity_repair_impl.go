@@ -15,6 +15,7 @@ func (s *IntegrityValidationServiceImpl) RepairMinorIssues(ctx context.Context,
 	report := &RepairReport{
 		UnrepairableIssues: make([]UnrepairableIssue, 0),
 		DryRun:             dryRun,
+		Actions:            make([]RepairAction, 0),
 	}
 
 	// First, get a full integrity report to understand what needs fixing
@@ -28,7 +29,7 @@ func (s *IntegrityValidationServiceImpl) RepairMinorIssues(ctx context.Context,
 		log.Printf("⚠️ Failed to repair orphaned records: %v", err)
 	}
 
-	// Repair duplicate records (keep the oldest, remove newer ones)
+	// Repair duplicate records (keep the oldest, merge or remove newer ones)
 	if err := s.repairDuplicateRecords(ctx, integrityReport.DuplicateReport, report, dryRun); err != nil {
 		log.Printf("⚠️ Failed to repair duplicate records: %v", err)
 	}
@@ -69,9 +70,12 @@ func (s *IntegrityValidationServiceImpl) repairOrphanedRecords(ctx context.Conte
 	log.Printf("🔧 Repairing %d orphaned stock rating records...", len(orphanReport.OrphanedStockRatings))
 
 	for _, orphan := range orphanReport.OrphanedStockRatings {
+		action := RepairAction{Action: RepairActionDelete, Entity: "stock_rating", ID: orphan.ID, Issue: orphan.Reason}
+
 		if dryRun {
 			log.Printf("🔍 DRY RUN: Would delete orphaned stock rating %s", orphan.ID)
 			repairReport.RepairedOrphans++
+			repairReport.Actions = append(repairReport.Actions, action)
 		} else {
 			// Attempt to delete the orphaned record
 			if err := s.stockRatingRepo.Delete(ctx, orphan.ID); err != nil {
@@ -85,6 +89,7 @@ func (s *IntegrityValidationServiceImpl) repairOrphanedRecords(ctx context.Conte
 			} else {
 				log.Printf("✅ Deleted orphaned stock rating %s", orphan.ID)
 				repairReport.RepairedOrphans++
+				repairReport.Actions = append(repairReport.Actions, action)
 			}
 		}
 	}
@@ -92,7 +97,8 @@ func (s *IntegrityValidationServiceImpl) repairOrphanedRecords(ctx context.Conte
 	return nil
 }
 
-// repairDuplicateRecords removes duplicate records, keeping the oldest
+// repairDuplicateRecords removes duplicate records, keeping the oldest. Duplicate companies are merged into the
+// oldest one so their ratings are relinked instead of orphaned
 func (s *IntegrityValidationServiceImpl) repairDuplicateRecords(ctx context.Context, duplicateReport *DuplicateReport, repairReport *RepairReport, dryRun bool) error {
 	if duplicateReport == nil {
 		return nil
@@ -168,28 +174,38 @@ func (s *IntegrityValidationServiceImpl) repairDuplicateCompanies(ctx context.Co
 		}
 	}
 
-	// Delete all duplicates except the oldest
+	// Merge all duplicates except the oldest; MergeInto re-points their data and rolls back in dry run
 	for _, id := range duplicate.IDs {
 		if id == oldestID {
 			continue // Keep the oldest
 		}
 
+		result, err := s.companyRepo.MergeInto(ctx, id, oldestID, dryRun)
+		if err != nil {
+			repairReport.UnrepairableIssues = append(repairReport.UnrepairableIssues, UnrepairableIssue{
+				Type:        "duplicate_company",
+				ID:          id,
+				Description: fmt.Sprintf("Duplicate company with ticker %s", duplicate.Ticker),
+				Reason:      fmt.Sprintf("Merge failed: %v", err),
+			})
+			continue
+		}
+
 		if dryRun {
-			log.Printf("🔍 DRY RUN: Would delete duplicate company %s (ticker: %s)", id, duplicate.Ticker)
-			repairReport.RemovedDuplicates++
+			log.Printf("🔍 DRY RUN: Would merge duplicate company %s into %s (ticker: %s)", id, oldestID, duplicate.Ticker)
 		} else {
-			if err := s.companyRepo.Delete(ctx, id); err != nil {
-				repairReport.UnrepairableIssues = append(repairReport.UnrepairableIssues, UnrepairableIssue{
-					Type:        "duplicate_company",
-					ID:          id,
-					Description: fmt.Sprintf("Duplicate company with ticker %s", duplicate.Ticker),
-					Reason:      fmt.Sprintf("Delete failed: %v", err),
-				})
-			} else {
-				log.Printf("✅ Deleted duplicate company %s (ticker: %s)", id, duplicate.Ticker)
-				repairReport.RemovedDuplicates++
-			}
+			log.Printf("✅ Merged duplicate company %s into %s (ticker: %s)", id, oldestID, duplicate.Ticker)
 		}
+		targetID := oldestID
+		repairReport.RemovedDuplicates++
+		repairReport.Actions = append(repairReport.Actions, RepairAction{
+			Action:          RepairActionMerge,
+			Entity:          "company",
+			ID:              id,
+			TargetID:        &targetID,
+			Issue:           fmt.Sprintf("Duplicate company with ticker %s", duplicate.Ticker),
+			RelinkedRatings: result.RatingsMoved,
+		})
 	}
 
 	return nil
@@ -222,9 +238,19 @@ func (s *IntegrityValidationServiceImpl) repairDuplicateBrokerages(ctx context.C
 			continue
 		}
 
+		targetID := oldestID
+		action := RepairAction{
+			Action:   RepairActionDelete,
+			Entity:   "brokerage",
+			ID:       id,
+			TargetID: &targetID,
+			Issue:    fmt.Sprintf("Duplicate brokerage with name %s", duplicate.Name),
+		}
+
 		if dryRun {
 			log.Printf("🔍 DRY RUN: Would delete duplicate brokerage %s (name: %s)", id, duplicate.Name)
 			repairReport.RemovedDuplicates++
+			repairReport.Actions = append(repairReport.Actions, action)
 		} else {
 			if err := s.brokerageRepo.Delete(ctx, id); err != nil {
 				repairReport.UnrepairableIssues = append(repairReport.UnrepairableIssues, UnrepairableIssue{
@@ -236,6 +262,7 @@ func (s *IntegrityValidationServiceImpl) repairDuplicateBrokerages(ctx context.C
 			} else {
 				log.Printf("✅ Deleted duplicate brokerage %s (name: %s)", id, duplicate.Name)
 				repairReport.RemovedDuplicates++
+				repairReport.Actions = append(repairReport.Actions, action)
 			}
 		}
 	}
@@ -249,14 +276,18 @@ func (s *IntegrityValidationServiceImpl) repairDuplicateStockRatings(ctx context
 	}
 
 	// Keep the first one, delete the rest
+	keptID := duplicate.IDs[0]
 	for i, id := range duplicate.IDs {
 		if i == 0 {
 			continue // Keep the first
 		}
 
+		action := RepairAction{Action: RepairActionDelete, Entity: "stock_rating", ID: id, TargetID: &keptID, Issue: "Duplicate stock rating"}
+
 		if dryRun {
 			log.Printf("🔍 DRY RUN: Would delete duplicate stock rating %s", id)
 			repairReport.RemovedDuplicates++
+			repairReport.Actions = append(repairReport.Actions, action)
 		} else {
 			if err := s.stockRatingRepo.Delete(ctx, id); err != nil {
 				repairReport.UnrepairableIssues = append(repairReport.UnrepairableIssues, UnrepairableIssue{
@@ -268,6 +299,7 @@ func (s *IntegrityValidationServiceImpl) repairDuplicateStockRatings(ctx context
 			} else {
 				log.Printf("✅ Deleted duplicate stock rating %s", id)
 				repairReport.RemovedDuplicates++
+				repairReport.Actions = append(repairReport.Actions, action)
 			}
 		}
 	}
@@ -287,23 +319,35 @@ func (s *IntegrityValidationServiceImpl) repairCompanyConsistency(ctx context.Co
 	}
 
 	fixed := false
+	changes := make([]string, 0, 2)
 
 	// Fix ticker case
 	if strings.Contains(issue.Issue, "uppercase") {
+		changes = append(changes, fmt.Sprintf("ticker %q -> %q", company.Ticker, strings.ToUpper(company.Ticker)))
 		company.Ticker = strings.ToUpper(company.Ticker)
 		fixed = true
 	}
 
 	// Fix name whitespace
 	if strings.Contains(issue.Issue, "whitespace") {
+		changes = append(changes, fmt.Sprintf("name %q -> %q", company.Name, strings.TrimSpace(company.Name)))
 		company.Name = strings.TrimSpace(company.Name)
 		fixed = true
 	}
 
 	if fixed {
+		action := RepairAction{
+			Action:  RepairActionUpdate,
+			Entity:  "company",
+			ID:      company.ID,
+			Issue:   issue.Issue,
+			Changes: strings.Join(changes, ", "),
+		}
+
 		if dryRun {
 			log.Printf("🔍 DRY RUN: Would fix consistency issues for company %s", company.Ticker)
 			repairReport.FixedInconsistencies++
+			repairReport.Actions = append(repairReport.Actions, action)
 		} else {
 			if err := s.companyRepo.Update(ctx, company); err != nil {
 				repairReport.UnrepairableIssues = append(repairReport.UnrepairableIssues, UnrepairableIssue{
@@ -315,6 +359,7 @@ func (s *IntegrityValidationServiceImpl) repairCompanyConsistency(ctx context.Co
 			} else {
 				log.Printf("✅ Fixed consistency issues for company %s", company.Ticker)
 				repairReport.FixedInconsistencies++
+				repairReport.Actions = append(repairReport.Actions, action)
 			}
 		}
 	}
@@ -338,9 +383,18 @@ func (s *IntegrityValidationServiceImpl) repairBrokerageConsistency(ctx context.
 	brokerage.Name = strings.TrimSpace(brokerage.Name)
 
 	if brokerage.Name != originalName {
+		action := RepairAction{
+			Action:  RepairActionUpdate,
+			Entity:  "brokerage",
+			ID:      brokerage.ID,
+			Issue:   issue.Issue,
+			Changes: fmt.Sprintf("name %q -> %q", originalName, brokerage.Name),
+		}
+
 		if dryRun {
 			log.Printf("🔍 DRY RUN: Would fix consistency issues for brokerage %s", brokerage.Name)
 			repairReport.FixedInconsistencies++
+			repairReport.Actions = append(repairReport.Actions, action)
 		} else {
 			if err := s.brokerageRepo.Update(ctx, brokerage); err != nil {
 				repairReport.UnrepairableIssues = append(repairReport.UnrepairableIssues, UnrepairableIssue{
@@ -352,6 +406,7 @@ func (s *IntegrityValidationServiceImpl) repairBrokerageConsistency(ctx context.
 			} else {
 				log.Printf("✅ Fixed consistency issues for brokerage %s", brokerage.Name)
 				repairReport.FixedInconsistencies++
+				repairReport.Actions = append(repairReport.Actions, action)
 			}
 		}
 	}
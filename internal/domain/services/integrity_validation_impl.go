@@ -364,36 +364,26 @@ func (s *IntegrityValidationServiceImpl) validateBrokerageDuplicates(ctx context
 
 // validateStockRatingDuplicates checks for duplicate stock ratings
 func (s *IntegrityValidationServiceImpl) validateStockRatingDuplicates(ctx context.Context, report *DuplicateReport) error {
-	ratings, err := s.stockRatingRepo.GetAll(ctx)
+	// Same company + brokerage + event_time, or the same rating change re-emitted within the duplicate window
+	groups, err := s.stockRatingRepo.FindNearDuplicates(ctx, s.config.Rules.StockRating.DuplicateWindow)
 	if err != nil {
 		return err
 	}
 
-	// Group ratings by company_id + brokerage_id + event_time
-	ratingGroups := make(map[string][]uuid.UUID)
-	for _, rating := range ratings {
-		key := fmt.Sprintf("%s_%s_%d", rating.CompanyID, rating.BrokerageID, rating.EventTime.Unix())
-		ratingGroups[key] = append(ratingGroups[key], rating.ID)
-	}
-
-	// Find duplicates
-	for key, ids := range ratingGroups {
-		if len(ids) > 1 {
-			// Parse the key to get company and brokerage IDs
-			parts := strings.Split(key, "_")
-			if len(parts) >= 3 {
-				companyID, _ := uuid.Parse(parts[0])
-				brokerageID, _ := uuid.Parse(parts[1])
-
-				report.DuplicateStockRatings = append(report.DuplicateStockRatings, DuplicateStockRating{
-					IDs:         ids,
-					CompanyID:   companyID,
-					BrokerageID: brokerageID,
-					Count:       len(ids),
-					Severity:    "critical",
-				})
-			}
+	for _, group := range groups {
+		severity := "critical"
+		if group.Near {
+			severity = "warning"
 		}
+		report.DuplicateStockRatings = append(report.DuplicateStockRatings, DuplicateStockRating{
+			IDs:         group.RatingIDs,
+			CompanyID:   group.CompanyID,
+			BrokerageID: group.BrokerageID,
+			EventTime:   group.EventTime,
+			Count:       group.Count,
+			Near:        group.Near,
+			Severity:    severity,
+		})
 	}
 
 	return nil
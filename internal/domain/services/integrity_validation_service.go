@@ -2,7 +2,11 @@ package services
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
@@ -99,6 +103,42 @@ type RepairReport struct {
 	TotalRepairs         int                 `json:"total_repairs"`
 	Status               IntegrityStatus     `json:"status"`
 	DryRun               bool                `json:"dry_run"`
+	Actions              []RepairAction      `json:"actions"` // Registros eliminados, fusionados o corregidos (o que lo serían en dry run)
+}
+
+// Acciones de la reparación automática sobre cada registro
+const (
+	RepairActionDelete = "delete" // Se elimina el registro
+	RepairActionMerge  = "merge"  // Se re-enlazan sus datos al registro que se conserva y se elimina
+	RepairActionUpdate = "update" // Se corrige el formato de sus campos
+)
+
+// RepairAction is a change applied (or planned, in dry run) to one record by RepairMinorIssues
+type RepairAction struct {
+	Action          string     `json:"action"`
+	Entity          string     `json:"entity"` // stock_rating, company o brokerage
+	ID              uuid.UUID  `json:"id"`
+	TargetID        *uuid.UUID `json:"target_id,omitempty"` // Registro que se conserva en los duplicados
+	Issue           string     `json:"issue"`
+	Changes         string     `json:"changes,omitempty"`
+	RelinkedRatings int64      `json:"relinked_ratings,omitempty"` // Ratings que pasan al registro conservado
+}
+
+// PlanToken fingerprints the records touched by the report so that a dry run can be approved and applied
+// only while the plan is unchanged. Relinked counts are left out: new ratings keep arriving during ingestion
+func (r *RepairReport) PlanToken() string {
+	lines := make([]string, 0, len(r.Actions))
+	for _, action := range r.Actions {
+		target := ""
+		if action.TargetID != nil {
+			target = action.TargetID.String()
+		}
+		lines = append(lines, strings.Join([]string{action.Action, action.Entity, action.ID.String(), target, action.Changes}, "|"))
+	}
+	sort.Strings(lines)
+
+	sum := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	return hex.EncodeToString(sum[:])
 }
 
 // Specific issue types
@@ -151,6 +191,7 @@ type DuplicateStockRating struct {
 	BrokerageID uuid.UUID   `json:"brokerage_id"`
 	EventTime   time.Time   `json:"event_time"`
 	Count       int         `json:"count"`
+	Near        bool        `json:"near_duplicate,omitempty"` // Re-emitido con otro event_time dentro de la ventana de duplicados
 	Severity    string      `json:"severity"`
 }
 
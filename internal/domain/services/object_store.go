@@ -0,0 +1,31 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrObjectNotFound is returned when the requested key does not exist in the object store
+var ErrObjectNotFound = errors.New("object not found")
+
+// ObjectStore defines the contract of the bucket that keeps database backups and data exports
+// (S3, S3-compatible or a local directory)
+type ObjectStore interface {
+	// Put uploads size bytes read from body under key, replacing any previous object
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+
+	// Get opens the object stored under key; the caller must close the reader
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// List returns every object whose key starts with prefix, in key order
+	List(ctx context.Context, prefix string) ([]ObjectInfo, error)
+}
+
+// ObjectInfo describes a stored object
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
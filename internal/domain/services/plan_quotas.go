@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cuotas diarias de cada plan
+const (
+	QuotaRequests  = "requests"  // Peticiones a la API
+	QuotaRefreshes = "refreshes" // Símbolos obtenidos del proveedor externo
+)
+
+// PlanLimits are the daily quotas of a plan (0 = unlimited)
+type PlanLimits struct {
+	DailyRequests  int
+	DailyRefreshes int
+}
+
+// QuotaStatus describes the use of a daily quota of a tenant
+type QuotaStatus struct {
+	Plan      string
+	Quota     string
+	Limit     int // 0 = ilimitada
+	Used      int
+	Remaining int
+	ResetAt   time.Time // Inicio del siguiente día UTC
+}
+
+// Limited reports whether the plan has a limit for the quota
+func (s QuotaStatus) Limited() bool {
+	return s.Limit > 0
+}
+
+// Exceeded reports whether the use went over the limit
+func (s QuotaStatus) Exceeded() bool {
+	return s.Limited() && s.Used > s.Limit
+}
+
+// Exhausted reports whether nothing is left of the quota today
+func (s QuotaStatus) Exhausted() bool {
+	return s.Limited() && s.Remaining <= 0
+}
+
+// PlanQuotas counts the daily use of each tenant against the quotas of its plan. Like ProviderQuota the
+// counters live in the cache per UTC day, so every instance shares them
+type PlanQuotas struct {
+	cache       CacheService
+	plans       map[string]PlanLimits
+	defaultPlan string
+	now         func() time.Time
+}
+
+// NewPlanQuotas creates the plan quota counters; a tenant with an unknown plan uses defaultPlan.
+// Without cache service nothing is counted and every quota is unlimited
+func NewPlanQuotas(cache CacheService, plans map[string]PlanLimits, defaultPlan string) *PlanQuotas {
+	return &PlanQuotas{
+		cache:       cache,
+		plans:       plans,
+		defaultPlan: defaultPlan,
+		now:         time.Now,
+	}
+}
+
+// Plan returns the plan whose quotas apply: the given one if it exists, otherwise the default plan
+func (q *PlanQuotas) Plan(plan string) string {
+	if _, ok := q.plans[plan]; ok {
+		return plan
+	}
+	return q.defaultPlan
+}
+
+// Limit returns the daily limit of the quota for the plan (0 = unlimited)
+func (q *PlanQuotas) Limit(plan, quota string) int {
+	if q == nil || q.cache == nil {
+		return 0
+	}
+	limits := q.plans[q.Plan(plan)]
+	switch quota {
+	case QuotaRequests:
+		return limits.DailyRequests
+	case QuotaRefreshes:
+		return limits.DailyRefreshes
+	default:
+		return 0
+	}
+}
+
+// Consume adds n to the use of the quota today and returns the resulting status; n = 0 only reads it
+func (q *PlanQuotas) Consume(ctx context.Context, tenantID uuid.UUID, plan, quota string, n int) (QuotaStatus, error) {
+	if q == nil || q.cache == nil {
+		return QuotaStatus{Plan: plan, Quota: quota}, nil
+	}
+
+	now := q.now().UTC()
+	status := QuotaStatus{
+		Plan:    q.Plan(plan),
+		Quota:   quota,
+		ResetAt: now.Truncate(24 * time.Hour).Add(24 * time.Hour),
+	}
+	status.Limit = q.Limit(plan, quota)
+	if !status.Limited() {
+		return status, nil
+	}
+
+	used, err := q.cache.IncrementBy(ctx, q.key(tenantID, quota, now), int64(n), quotaKeyTTL)
+	if err != nil {
+		return status, err
+	}
+	status.Used = int(used)
+	status.Remaining = max(status.Limit-status.Used, 0)
+	return status, nil
+}
+
+// Status returns the use of the quota today without consuming it
+func (q *PlanQuotas) Status(ctx context.Context, tenantID uuid.UUID, plan, quota string) (QuotaStatus, error) {
+	return q.Consume(ctx, tenantID, plan, quota, 0)
+}
+
+// key identifica el contador de la cuota del tenant para el día UTC de now
+func (q *PlanQuotas) key(tenantID uuid.UUID, quota string, now time.Time) string {
+	return "planquota:" + tenantID.String() + ":" + quota + ":" + now.Format("2006-01-02")
+}
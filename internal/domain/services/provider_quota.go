@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"time"
+)
+
+// ProviderFinnhub identifica las llamadas a Finnhub en la cuota diaria
+const ProviderFinnhub = "finnhub"
+
+// quotaKeyTTL mantiene el contador del día un poco más que el propio día
+const quotaKeyTTL = 48 * time.Hour
+
+// ProviderQuota counts the calls made to each external provider per UTC day against a daily budget.
+// The counters live in the cache, so every instance shares them; a provider without a budget is unlimited
+type ProviderQuota struct {
+	cache  CacheService
+	limits map[string]int
+	now    func() time.Time
+}
+
+// NewProviderQuota creates the daily quota counters; limits maps a provider to its daily budget (0 = unlimited).
+// Without cache service nothing is counted and every provider is unlimited
+func NewProviderQuota(cache CacheService, limits map[string]int) *ProviderQuota {
+	return &ProviderQuota{
+		cache:  cache,
+		limits: limits,
+		now:    time.Now,
+	}
+}
+
+// Record counts one call to the provider
+func (q *ProviderQuota) Record(ctx context.Context, provider string) error {
+	if q == nil || q.cache == nil {
+		return nil
+	}
+	_, err := q.cache.IncrementBy(ctx, q.key(provider), 1, quotaKeyTTL)
+	return err
+}
+
+// Used returns the calls made to the provider today
+func (q *ProviderQuota) Used(ctx context.Context, provider string) (int, error) {
+	if q == nil || q.cache == nil {
+		return 0, nil
+	}
+	used, err := q.cache.IncrementBy(ctx, q.key(provider), 0, quotaKeyTTL)
+	return int(used), err
+}
+
+// Remaining returns the calls left today; limited is false when the provider has no budget
+func (q *ProviderQuota) Remaining(ctx context.Context, provider string) (remaining int, limited bool, err error) {
+	limit := q.Limit(provider)
+	if limit <= 0 {
+		return 0, false, nil
+	}
+
+	used, err := q.Used(ctx, provider)
+	if err != nil {
+		return 0, true, err
+	}
+	return max(limit-used, 0), true, nil
+}
+
+// Limit returns the daily budget of the provider (0 = unlimited)
+func (q *ProviderQuota) Limit(provider string) int {
+	if q == nil || q.cache == nil {
+		return 0
+	}
+	return q.limits[provider]
+}
+
+// key identifica el contador del proveedor para el día UTC en curso
+func (q *ProviderQuota) key(provider string) string {
+	return "quota:" + provider + ":" + q.now().UTC().Format("2006-01-02")
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxViewWindowHours es la ventana más larga de los rankings de vistas (una semana)
+	MaxViewWindowHours = 168
+
+	// Buckets horarios de vistas: se conservan una hora más que la ventana máxima
+	viewKeyPrefix = "views:"
+	viewBucketTTL = (MaxViewWindowHours + 1) * time.Hour
+)
+
+// SymbolViewCount is the number of times a symbol was requested in a window
+type SymbolViewCount struct {
+	Symbol string
+	Views  int64
+}
+
+// SymbolViews counts the quote and profile requests of each symbol in hourly buckets kept in the cache
+// (Redis sorted sets, shared by every instance), so the most requested symbols can be ranked for any window
+// of up to a week
+type SymbolViews struct {
+	cache CacheService
+	now   func() time.Time
+}
+
+// NewSymbolViews creates the view counters; without cache service nothing is counted
+func NewSymbolViews(cache CacheService) *SymbolViews {
+	return &SymbolViews{
+		cache: cache,
+		now:   time.Now,
+	}
+}
+
+// Record counts one request of the symbol in the current hour
+func (v *SymbolViews) Record(ctx context.Context, symbol string) error {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	if v == nil || v.cache == nil || symbol == "" {
+		return nil
+	}
+	return v.cache.IncrementScore(ctx, v.key(v.now()), symbol, 1, viewBucketTTL)
+}
+
+// Counts returns the requests of every symbol in the last hours (including the current one), capped at a week
+func (v *SymbolViews) Counts(ctx context.Context, hours int) (map[string]int64, error) {
+	counts := make(map[string]int64)
+	if v == nil || v.cache == nil {
+		return counts, nil
+	}
+
+	hours = min(max(hours, 1), MaxViewWindowHours)
+	now := v.now()
+	for i := 0; i < hours; i++ {
+		scores, err := v.cache.GetScores(ctx, v.key(now.Add(-time.Duration(i)*time.Hour)))
+		if err != nil {
+			return nil, err
+		}
+		for symbol, score := range scores {
+			counts[symbol] += int64(math.Round(score))
+		}
+	}
+	return counts, nil
+}
+
+// Top returns the most requested symbols of the last hours, most viewed first
+func (v *SymbolViews) Top(ctx context.Context, hours, limit int) ([]SymbolViewCount, error) {
+	counts, err := v.Counts(ctx, hours)
+	if err != nil {
+		return nil, err
+	}
+	return RankSymbolViews(counts, limit), nil
+}
+
+// RankSymbolViews orders view counts by views descending (ties by symbol) and keeps the first limit (0 keeps all)
+func RankSymbolViews(counts map[string]int64, limit int) []SymbolViewCount {
+	ranking := make([]SymbolViewCount, 0, len(counts))
+	for symbol, views := range counts {
+		if views > 0 {
+			ranking = append(ranking, SymbolViewCount{Symbol: symbol, Views: views})
+		}
+	}
+
+	sort.Slice(ranking, func(i, j int) bool {
+		if ranking[i].Views != ranking[j].Views {
+			return ranking[i].Views > ranking[j].Views
+		}
+		return ranking[i].Symbol < ranking[j].Symbol
+	})
+
+	if limit > 0 && len(ranking) > limit {
+		ranking = ranking[:limit]
+	}
+	return ranking
+}
+
+// key identifica el bucket de la hora UTC de t
+func (v *SymbolViews) key(t time.Time) string {
+	return viewKeyPrefix + t.UTC().Format("2006-01-02T15")
+}
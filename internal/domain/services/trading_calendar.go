@@ -0,0 +1,265 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+	_ "time/tzdata" // La zona America/New_York no depende de la base de datos de zonas del sistema
+)
+
+// Sesiones de mercado
+const (
+	MarketSessionPreMarket  = "pre_market"
+	MarketSessionRegular    = "regular"
+	MarketSessionPostMarket = "post_market"
+	MarketSessionClosed     = "closed"
+)
+
+// ErrUnsupportedExchange se retorna al consultar un exchange sin calendario
+var ErrUnsupportedExchange = errors.New("unsupported exchange")
+
+// TradingHours holds the session windows of one trading day in the exchange's time zone
+type TradingHours struct {
+	PreMarketOpen   time.Time
+	Open            time.Time
+	Close           time.Time
+	PostMarketClose time.Time
+	EarlyClose      bool
+}
+
+// MarketStatus describes an exchange at a point in time
+type MarketStatus struct {
+	Exchange     string
+	Timezone     string
+	LocalTime    time.Time
+	Session      string // pre_market, regular, post_market o closed
+	IsOpen       bool   // Sesión regular
+	IsTradingDay bool
+	Holiday      string        // Nombre del festivo, vacío si no lo es
+	Hours        *TradingHours // Nil si no es día de negociación
+	NextOpen     time.Time     // Próxima apertura de la sesión regular
+	NextClose    time.Time     // Próximo cierre de la sesión regular
+}
+
+// TradingCalendar knows the holidays, early closes and session windows of the US equity exchanges
+// (NYSE and NASDAQ share one calendar). Holidays follow the NYSE rules, so any year can be computed
+type TradingCalendar struct {
+	location *time.Location
+}
+
+// NewTradingCalendar creates the US equity trading calendar
+func NewTradingCalendar() *TradingCalendar {
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		location = time.FixedZone("EST", -5*3600)
+	}
+	return &TradingCalendar{location: location}
+}
+
+// NormalizeExchange maps an exchange name to the calendar's exchange code. Accepts codes (NYSE, NASDAQ, AMEX,
+// US for the composite feed) and the long names reported by the providers ("NASDAQ NMS - GLOBAL MARKET")
+func NormalizeExchange(exchange string) (string, bool) {
+	name := strings.ToUpper(strings.TrimSpace(exchange))
+	switch {
+	case strings.HasPrefix(name, "NASDAQ"):
+		return "NASDAQ", true
+	case strings.HasPrefix(name, "NYSE"), strings.HasPrefix(name, "NEW YORK STOCK EXCHANGE"):
+		return "NYSE", true
+	case name == "AMEX":
+		return "AMEX", true
+	case name == "US":
+		return "US", true
+	default:
+		return "", false
+	}
+}
+
+// Status returns the session of an exchange at a point in time
+func (c *TradingCalendar) Status(exchange string, at time.Time) (*MarketStatus, error) {
+	code, ok := NormalizeExchange(exchange)
+	if !ok {
+		return nil, ErrUnsupportedExchange
+	}
+
+	local := at.In(c.location)
+	status := &MarketStatus{
+		Exchange:  code,
+		Timezone:  c.location.String(),
+		LocalTime: local,
+		Session:   MarketSessionClosed,
+	}
+	status.Holiday, _ = c.Holiday(local)
+	status.IsTradingDay = c.IsTradingDay(local)
+
+	if status.IsTradingDay {
+		hours := c.TradingHours(local)
+		status.Hours = &hours
+		switch {
+		case local.Before(hours.PreMarketOpen):
+			// Antes del pre-market sigue cerrado
+		case local.Before(hours.Open):
+			status.Session = MarketSessionPreMarket
+		case local.Before(hours.Close):
+			status.Session = MarketSessionRegular
+		case local.Before(hours.PostMarketClose):
+			status.Session = MarketSessionPostMarket
+		}
+	}
+	status.IsOpen = status.Session == MarketSessionRegular
+	status.NextOpen, status.NextClose = c.nextOpenAndClose(local)
+
+	return status, nil
+}
+
+// IsOpen reports whether the regular session of the US equity exchanges is open at a point in time
+func (c *TradingCalendar) IsOpen(at time.Time) bool {
+	status, err := c.Status("US", at)
+	return err == nil && status.IsOpen
+}
+
+// IsTradingDay reports whether the exchanges trade on the date of t (in the exchange's time zone)
+func (c *TradingCalendar) IsTradingDay(t time.Time) bool {
+	local := t.In(c.location)
+	if local.Weekday() == time.Saturday || local.Weekday() == time.Sunday {
+		return false
+	}
+	_, holiday := c.Holiday(local)
+	return !holiday
+}
+
+// Holiday returns the name of the exchange holiday on the date of t, if any
+func (c *TradingCalendar) Holiday(t time.Time) (string, bool) {
+	local := t.In(c.location)
+	name, ok := marketHolidays(local.Year())[civilDate(local.Year(), local.Month(), local.Day())]
+	return name, ok
+}
+
+// TradingHours returns the session windows of the date of t; early closes end the regular session at 13:00
+// and the post-market at 17:00. The result is meaningless on days that are not trading days
+func (c *TradingCalendar) TradingHours(t time.Time) TradingHours {
+	local := t.In(c.location)
+	at := func(hour, minute int) time.Time {
+		return time.Date(local.Year(), local.Month(), local.Day(), hour, minute, 0, 0, c.location)
+	}
+
+	hours := TradingHours{
+		PreMarketOpen:   at(4, 0),
+		Open:            at(9, 30),
+		Close:           at(16, 0),
+		PostMarketClose: at(20, 0),
+	}
+	if c.isEarlyClose(local) {
+		hours.Close = at(13, 0)
+		hours.PostMarketClose = at(17, 0)
+		hours.EarlyClose = true
+	}
+	return hours
+}
+
+// nextOpenAndClose busca la próxima apertura y el próximo cierre de la sesión regular desde local
+func (c *TradingCalendar) nextOpenAndClose(local time.Time) (time.Time, time.Time) {
+	var nextOpen, nextClose time.Time
+	day := local
+	// Ningún tramo sin sesiones supera los 4 días (festivo junto a fin de semana); 10 deja margen
+	for i := 0; i < 10 && (nextOpen.IsZero() || nextClose.IsZero()); i++ {
+		if c.IsTradingDay(day) {
+			hours := c.TradingHours(day)
+			if nextOpen.IsZero() && local.Before(hours.Open) {
+				nextOpen = hours.Open
+			}
+			if nextClose.IsZero() && local.Before(hours.Close) {
+				nextClose = hours.Close
+			}
+		}
+		day = time.Date(day.Year(), day.Month(), day.Day()+1, 12, 0, 0, 0, c.location)
+	}
+	return nextOpen, nextClose
+}
+
+// isEarlyClose: 3 de julio, viernes después de Thanksgiving y 24 de diciembre, si son días de negociación
+func (c *TradingCalendar) isEarlyClose(local time.Time) bool {
+	if !c.IsTradingDay(local) {
+		return false
+	}
+
+	year, month, day := local.Date()
+	switch {
+	case month == time.July && day == 3:
+		return true
+	case month == time.December && day == 24:
+		return true
+	case month == time.November:
+		thanksgiving := nthWeekday(year, time.November, time.Thursday, 4)
+		return civilDate(year, month, day).Equal(thanksgiving.AddDate(0, 0, 1))
+	}
+	return false
+}
+
+// marketHolidays devuelve los festivos del año según las reglas del NYSE, con la fecha en que se observan
+func marketHolidays(year int) map[time.Time]string {
+	holidays := make(map[time.Time]string)
+
+	// Año Nuevo en sábado no se traslada al viernes anterior (regla del NYSE)
+	if newYear := civilDate(year, time.January, 1); newYear.Weekday() != time.Saturday {
+		holidays[observed(newYear)] = "New Year's Day"
+	}
+	holidays[nthWeekday(year, time.January, time.Monday, 3)] = "Martin Luther King Jr. Day"
+	holidays[nthWeekday(year, time.February, time.Monday, 3)] = "Washington's Birthday"
+	holidays[easterSunday(year).AddDate(0, 0, -2)] = "Good Friday"
+	holidays[lastWeekday(year, time.May, time.Monday)] = "Memorial Day"
+	if year >= 2022 {
+		holidays[observed(civilDate(year, time.June, 19))] = "Juneteenth National Independence Day"
+	}
+	holidays[observed(civilDate(year, time.July, 4))] = "Independence Day"
+	holidays[nthWeekday(year, time.September, time.Monday, 1)] = "Labor Day"
+	holidays[nthWeekday(year, time.November, time.Thursday, 4)] = "Thanksgiving Day"
+	holidays[observed(civilDate(year, time.December, 25))] = "Christmas Day"
+
+	return holidays
+}
+
+// civilDate representa una fecha del calendario (medianoche UTC) como clave de festivos
+func civilDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// observed traslada un festivo en sábado al viernes anterior y uno en domingo al lunes siguiente
+func observed(date time.Time) time.Time {
+	switch date.Weekday() {
+	case time.Saturday:
+		return date.AddDate(0, 0, -1)
+	case time.Sunday:
+		return date.AddDate(0, 0, 1)
+	}
+	return date
+}
+
+// nthWeekday devuelve el n-ésimo weekday del mes (n empieza en 1)
+func nthWeekday(year int, month time.Month, weekday time.Weekday, n int) time.Time {
+	first := civilDate(year, month, 1)
+	offset := (int(weekday) - int(first.Weekday()) + 7) % 7
+	return first.AddDate(0, 0, offset+7*(n-1))
+}
+
+// lastWeekday devuelve el último weekday del mes
+func lastWeekday(year int, month time.Month, weekday time.Weekday) time.Time {
+	last := civilDate(year, month+1, 0)
+	offset := (int(last.Weekday()) - int(weekday) + 7) % 7
+	return last.AddDate(0, 0, -offset)
+}
+
+// easterSunday calcula el domingo de Pascua gregoriano (algoritmo anónimo de Meeus/Jones/Butcher)
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return civilDate(year, time.Month(month), day)
+}
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// UsageRetentionHours son las horas que se conservan los buckets de uso en la cache antes de volcarse
+	UsageRetentionHours = 48
+
+	// Buckets horarios de uso: se conservan una hora más que la retención
+	usageKeyPrefix = "usage:"
+	usageBucketTTL = (UsageRetentionHours + 1) * time.Hour
+)
+
+// Métricas de uso de cada credencial
+const (
+	UsageMetricRequests      = "requests"
+	UsageMetricErrors        = "errors"
+	UsageMetricProviderCalls = "provider_calls"
+	UsageMetricCacheHits     = "cache_hits"
+)
+
+// UsageKey identifies the credential that made the requests: an API key ("api_key:<id>") or a JWT subject ("jwt:<sub>")
+type UsageKey struct {
+	TenantID   uuid.UUID
+	Credential string
+}
+
+// UsageCounts are the counters of a credential in a period
+type UsageCounts struct {
+	Requests      int64
+	Errors        int64
+	ProviderCalls int64
+	CacheHits     int64
+}
+
+// UsageCounters counts the requests of each credential in hourly buckets kept in the cache (Redis sorted sets,
+// shared by every instance). The buckets are flushed periodically to the usage table
+type UsageCounters struct {
+	cache CacheService
+	now   func() time.Time
+}
+
+// NewUsageCounters creates the usage counters; without cache service nothing is counted
+func NewUsageCounters(cache CacheService) *UsageCounters {
+	return &UsageCounters{
+		cache: cache,
+		now:   time.Now,
+	}
+}
+
+// Record adds the counts of a request to the bucket of the current hour
+func (u *UsageCounters) Record(ctx context.Context, key UsageKey, counts UsageCounts) error {
+	if u == nil || u.cache == nil || key.TenantID == uuid.Nil {
+		return nil
+	}
+
+	bucket := u.key(u.now())
+	for metric, value := range map[string]int64{
+		UsageMetricRequests:      counts.Requests,
+		UsageMetricErrors:        counts.Errors,
+		UsageMetricProviderCalls: counts.ProviderCalls,
+		UsageMetricCacheHits:     counts.CacheHits,
+	} {
+		if value == 0 {
+			continue
+		}
+		if err := u.cache.IncrementScore(ctx, bucket, usageMember(key, metric), float64(value), usageBucketTTL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Hour returns the counters of every credential in the bucket of the UTC hour of t
+func (u *UsageCounters) Hour(ctx context.Context, t time.Time) (map[UsageKey]UsageCounts, error) {
+	counts := make(map[UsageKey]UsageCounts)
+	if u == nil || u.cache == nil {
+		return counts, nil
+	}
+
+	scores, err := u.cache.GetScores(ctx, u.key(t))
+	if err != nil {
+		return nil, err
+	}
+	for member, score := range scores {
+		key, metric, ok := parseUsageMember(member)
+		if !ok {
+			continue
+		}
+		value := int64(math.Round(score))
+		current := counts[key]
+		switch metric {
+		case UsageMetricRequests:
+			current.Requests += value
+		case UsageMetricErrors:
+			current.Errors += value
+		case UsageMetricProviderCalls:
+			current.ProviderCalls += value
+		case UsageMetricCacheHits:
+			current.CacheHits += value
+		default:
+			continue
+		}
+		counts[key] = current
+	}
+	return counts, nil
+}
+
+// DeleteHour removes the bucket of the UTC hour of t once it has been flushed
+func (u *UsageCounters) DeleteHour(ctx context.Context, t time.Time) error {
+	if u == nil || u.cache == nil {
+		return nil
+	}
+	return u.cache.DeleteByPrefix(ctx, u.key(t))
+}
+
+// Hours returns the UTC hours whose buckets may still be in the cache, from the current one backwards
+func (u *UsageCounters) Hours() []time.Time {
+	current := u.now().UTC().Truncate(time.Hour)
+	hours := make([]time.Time, 0, UsageRetentionHours)
+	for i := 0; i < UsageRetentionHours; i++ {
+		hours = append(hours, current.Add(-time.Duration(i)*time.Hour))
+	}
+	return hours
+}
+
+// key identifica el bucket de la hora UTC de t
+func (u *UsageCounters) key(t time.Time) string {
+	return usageKeyPrefix + t.UTC().Format("2006-01-02T15")
+}
+
+// usageMember codifica tenant, métrica y credencial; la credencial va al final porque puede contener '|'
+func usageMember(key UsageKey, metric string) string {
+	return key.TenantID.String() + "|" + metric + "|" + key.Credential
+}
+
+// parseUsageMember decodifica un miembro escrito por usageMember
+func parseUsageMember(member string) (UsageKey, string, bool) {
+	parts := strings.SplitN(member, "|", 3)
+	if len(parts) != 3 {
+		return UsageKey{}, "", false
+	}
+	tenantID, err := uuid.Parse(parts[0])
+	if err != nil {
+		return UsageKey{}, "", false
+	}
+	return UsageKey{TenantID: tenantID, Credential: parts[2]}, parts[1], true
+}
@@ -1,5 +1,7 @@
 package services
 
+import "time"
+
 // ValidationThresholds - Umbrales para determinar severidad
 type ValidationThresholds struct {
 	// Umbrales de consistencia
@@ -40,6 +42,9 @@ type StockRatingValidationRules struct {
 	MaxAgeYearsConsistency int
 	MaxAgeYearsBusiness    int
 	ViolationsForCritical  int
+	// DuplicateWindow agrupa como duplicado el mismo cambio de rating re-emitido con este margen en el event_time
+	// (0: solo duplicados exactos)
+	DuplicateWindow time.Duration
 }
 
 // ValidationConfig - Configuración completa de validaciones
@@ -87,6 +92,8 @@ func DefaultValidationConfig() *ValidationConfig {
 				MaxAgeYearsBusiness: 20,
 				// Basado en línea 547: len(violations) > 2
 				ViolationsForCritical: 2,
+				// Los proveedores re-emiten ratings con segundos de diferencia
+				DuplicateWindow: 5 * time.Minute,
 			},
 		},
 	}
@@ -0,0 +1,99 @@
+// Package tenancy propaga por el context la identidad (tenant y credencial) de la petición, para que los
+// repositorios de recursos de usuario filtren por tenant sin depender de la capa HTTP
+package tenancy
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// ErrNoTenant is returned when a tenant-scoped operation runs without a tenant in the context
+var ErrNoTenant = errors.New("no tenant in context")
+
+// ErrNoCredential is returned when an operation on the private resources of a user runs without the credential
+// of a user in the context (the administration key and internal contexts have none)
+var ErrNoCredential = errors.New("no credential in context")
+
+// Métodos con los que se autenticó la petición
+const (
+	MethodAPIKey = "api_key"
+	MethodJWT    = "jwt"
+
+	// MethodAdminKey es la clave de administración de la configuración, sin tenant
+	MethodAdminKey = "admin_key"
+)
+
+// Principal identifies who performs a request: the tenant and the credential used
+type Principal struct {
+	TenantID   uuid.UUID `json:"tenant_id"`
+	TenantSlug string    `json:"tenant_slug"`
+	Subject    string    `json:"subject"`              // sub del JWT o prefijo de la API key
+	APIKeyID   uuid.UUID `json:"api_key_id,omitempty"` // uuid.Nil si se autenticó con JWT
+	Method     string    `json:"method"`
+	Role       Role      `json:"role"`
+	Plan       string    `json:"plan,omitempty"`     // Plan del tenant, para las cuotas diarias
+	Timezone   string    `json:"timezone,omitempty"` // Zona horaria IANA preferida por el usuario de la credencial
+}
+
+// Credential identifies the credential of the principal for usage attribution: "api_key:<id>" for API keys
+// and "jwt:<sub>" for tokens; empty for the administration key and internal contexts
+func (p *Principal) Credential() string {
+	switch p.Method {
+	case MethodAPIKey:
+		return MethodAPIKey + ":" + p.APIKeyID.String()
+	case MethodJWT:
+		return MethodJWT + ":" + p.Subject
+	default:
+		return ""
+	}
+}
+
+// IsPlatformAdmin reports whether the principal is the administration key of the configuration. Only it reaches
+// the administration routes, which act on every tenant; the roles of tenant credentials never do
+func (p *Principal) IsPlatformAdmin() bool {
+	return p.Method == MethodAdminKey && p.TenantID == uuid.Nil
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a context carrying the principal of the request
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// WithTenant returns a context scoped to the tenant, for internal operations on its resources
+func WithTenant(ctx context.Context, tenantID uuid.UUID) context.Context {
+	return WithPrincipal(ctx, &Principal{TenantID: tenantID})
+}
+
+// PrincipalFromContext returns the principal of the request, if it was authenticated
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
+
+// TenantID returns the tenant of the context or ErrNoTenant
+func TenantID(ctx context.Context) (uuid.UUID, error) {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok || principal.TenantID == uuid.Nil {
+		return uuid.Nil, ErrNoTenant
+	}
+	return principal.TenantID, nil
+}
+
+// Owner returns the tenant and the credential of the context, which own the private resources of a user,
+// or ErrNoTenant / ErrNoCredential
+func Owner(ctx context.Context) (uuid.UUID, string, error) {
+	tenantID, err := TenantID(ctx)
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+	principal, _ := PrincipalFromContext(ctx)
+	credential := principal.Credential()
+	if credential == "" {
+		return uuid.Nil, "", ErrNoCredential
+	}
+	return tenantID, credential, nil
+}
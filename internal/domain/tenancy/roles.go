@@ -0,0 +1,49 @@
+package tenancy
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Role is the access level of a credential; each role includes the permissions of the lower ones
+type Role string
+
+// Roles ordenados de menor a mayor nivel de acceso
+const (
+	RoleViewer Role = "viewer" // Lecturas de market data, companies y análisis
+	RoleEditor Role = "editor" // Además, altas, cambios y bajas de companies, brokerages y ratings
+	RoleAdmin  Role = "admin"  // Además, claves admin del propio tenant (los endpoints administrativos exigen la clave de administración)
+)
+
+// roleRanks define la jerarquía de los roles; un rol desconocido o vacío no tiene nivel
+var roleRanks = map[Role]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleAdmin:  3,
+}
+
+// Roles returns every role from the lowest to the highest access level
+func Roles() []Role {
+	return []Role{RoleViewer, RoleEditor, RoleAdmin}
+}
+
+// ParseRole parses a role name, case insensitive
+func ParseRole(name string) (Role, error) {
+	role := Role(strings.ToLower(strings.TrimSpace(name)))
+	if _, ok := roleRanks[role]; !ok {
+		return "", fmt.Errorf("unknown role %q (expected viewer, editor or admin)", name)
+	}
+	return role, nil
+}
+
+// Valid reports whether the role is one of the known roles
+func (r Role) Valid() bool {
+	_, ok := roleRanks[r]
+	return ok
+}
+
+// Allows reports whether the role grants the access of the required role
+func (r Role) Allows(required Role) bool {
+	rank, ok := roleRanks[r]
+	return ok && rank >= roleRanks[required]
+}
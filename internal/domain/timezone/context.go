@@ -0,0 +1,59 @@
+// Package timezone propaga por el context la zona horaria de quien hace la petición, para que los cálculos de
+// "hoy" o "esta semana" de los repositorios usen el día del mercado del usuario y no la hora local del servidor
+package timezone
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type locationKey struct{}
+
+// Load returns the location of an IANA time zone name (America/New_York, Europe/Madrid, UTC).
+// "Local" is rejected because it would depend on the server configuration
+func Load(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.EqualFold(name, "local") {
+		return nil, fmt.Errorf("unknown time zone %q", name)
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q", name)
+	}
+	return location, nil
+}
+
+// WithLocation returns a context whose time range calculations use the location
+func WithLocation(ctx context.Context, location *time.Location) context.Context {
+	return context.WithValue(ctx, locationKey{}, location)
+}
+
+// FromContext returns the location of the context; without one the server local time is used
+func FromContext(ctx context.Context) *time.Location {
+	if location, ok := ctx.Value(locationKey{}).(*time.Location); ok && location != nil {
+		return location
+	}
+	return time.Local
+}
+
+// Now returns the current time in the location of the context
+func Now(ctx context.Context) time.Time {
+	return time.Now().In(FromContext(ctx))
+}
+
+// StartOfDay returns midnight of the day of t in its location
+func StartOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// StartOfWeek returns midnight of the Sunday that starts the week of t in its location
+func StartOfWeek(t time.Time) time.Time {
+	return StartOfDay(t.AddDate(0, 0, -int(t.Weekday())))
+}
+
+// StartOfMonth returns midnight of the first day of the month of t in its location
+func StartOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,67 @@
+// Package usage acumula por el context lo que consume cada petición (llamadas a proveedores externos y
+// respuestas servidas desde datos guardados) para atribuirlo a la credencial que la hizo
+package usage
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// Tracker counts the work done on behalf of one request; it is safe for concurrent use
+type Tracker struct {
+	providerCalls atomic.Int64
+	cacheHits     atomic.Int64
+
+	// Sin cuota de refrescos del plan la petición solo puede servir datos guardados
+	refreshesBlocked atomic.Bool
+}
+
+type trackerKey struct{}
+
+// WithTracker returns a context carrying a new tracker for the request
+func WithTracker(ctx context.Context) (context.Context, *Tracker) {
+	tracker := &Tracker{}
+	return context.WithValue(ctx, trackerKey{}, tracker), tracker
+}
+
+// FromContext returns the tracker of the request, if it is tracked
+func FromContext(ctx context.Context) (*Tracker, bool) {
+	tracker, ok := ctx.Value(trackerKey{}).(*Tracker)
+	return tracker, ok && tracker != nil
+}
+
+// RecordProviderCall attributes one external provider call to the request of the context
+func RecordProviderCall(ctx context.Context) {
+	if tracker, ok := FromContext(ctx); ok {
+		tracker.providerCalls.Add(1)
+	}
+}
+
+// RecordCacheHit attributes one response served from stored data (without calling a provider) to the request
+func RecordCacheHit(ctx context.Context) {
+	if tracker, ok := FromContext(ctx); ok {
+		tracker.cacheHits.Add(1)
+	}
+}
+
+// RefreshAllowed reports whether the request may fetch data from an external provider; requests
+// without tracker (jobs, internal calls) are always allowed
+func RefreshAllowed(ctx context.Context) bool {
+	tracker, ok := FromContext(ctx)
+	return !ok || !tracker.refreshesBlocked.Load()
+}
+
+// BlockRefreshes stops the request from fetching data from external providers
+func (t *Tracker) BlockRefreshes() {
+	t.refreshesBlocked.Store(true)
+}
+
+// ProviderCalls returns the provider calls attributed to the request
+func (t *Tracker) ProviderCalls() int64 {
+	return t.providerCalls.Load()
+}
+
+// CacheHits returns the responses served from stored data for the request
+func (t *Tracker) CacheHits() int64 {
+	return t.cacheHits.Load()
+}
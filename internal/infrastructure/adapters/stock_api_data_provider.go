@@ -2,6 +2,8 @@ package adapters
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/MayaCris/stock-info-app/internal/application/usecases/population"
@@ -30,29 +32,23 @@ func (p *StockAPIDataProvider) FetchPage(ctx context.Context, page string) (*pop
 
 	// Convert API response to domain model
 	items := make([]population.StockDataItem, 0, len(apiResponse.Items))
+	var rejected []population.RejectedItem
 	for _, apiItem := range apiResponse.Items {
-		// Validate item
-		if !apiItem.IsValid() {
-			continue // Skip invalid items
-		}
-
-		// Parse event time
-		eventTime, err := apiItem.GetEventTime()
+		raw, err := json.Marshal(apiItem)
 		if err != nil {
-			continue // Skip items with invalid time
+			raw = []byte("{}")
 		}
 
-		// Convert to domain model
-		domainItem := population.StockDataItem{
-			Ticker:     apiItem.Ticker,
-			Company:    apiItem.Company,
-			Brokerage:  apiItem.Brokerage,
-			Action:     apiItem.Action,
-			RatingFrom: apiItem.RatingFrom,
-			RatingTo:   apiItem.RatingTo,
-			TargetFrom: apiItem.TargetFrom,
-			TargetTo:   apiItem.TargetTo,
-			EventTime:  eventTime,
+		domainItem, reason, err := toStockDataItem(apiItem, raw)
+		if err != nil {
+			rejected = append(rejected, population.RejectedItem{
+				Ticker:     apiItem.Ticker,
+				Brokerage:  apiItem.Brokerage,
+				Reason:     reason,
+				Error:      err.Error(),
+				RawPayload: string(raw),
+			})
+			continue
 		}
 
 		items = append(items, domainItem)
@@ -60,11 +56,56 @@ func (p *StockAPIDataProvider) FetchPage(ctx context.Context, page string) (*pop
 
 	return &population.StockDataPage{
 		Items:    items,
+		Rejected: rejected,
 		NextPage: apiResponse.NextPage,
 		HasMore:  apiResponse.HasNextPage(),
 	}, nil
 }
 
+// ParseRawItem implementa population.RawItemParser: vuelve a convertir el item original de la API guardado
+// en el RawData de un rating, con la misma conversión que FetchPage
+func (p *StockAPIDataProvider) ParseRawItem(raw []byte) (population.StockDataItem, error) {
+	var apiItem stock_api.StockRatingItem
+	if err := json.Unmarshal(raw, &apiItem); err != nil {
+		return population.StockDataItem{}, fmt.Errorf("%s: %w", population.RejectReasonInvalidPayload, err)
+	}
+
+	item, reason, err := toStockDataItem(apiItem, raw)
+	if err != nil {
+		return population.StockDataItem{}, fmt.Errorf("%s: %w", reason, err)
+	}
+	return item, nil
+}
+
+// toStockDataItem valida y convierte un item de la API al modelo de dominio; si no es válido devuelve
+// el motivo de rechazo y el error
+func toStockDataItem(apiItem stock_api.StockRatingItem, raw []byte) (population.StockDataItem, string, error) {
+	// Validate item
+	if !apiItem.IsValid() {
+		return population.StockDataItem{}, population.RejectReasonMissingFields,
+			errors.New("ticker, company, brokerage, action and time are required")
+	}
+
+	// Parse event time
+	eventTime, err := apiItem.GetEventTime()
+	if err != nil {
+		return population.StockDataItem{}, population.RejectReasonInvalidEventTime, err
+	}
+
+	return population.StockDataItem{
+		Ticker:     apiItem.Ticker,
+		Company:    apiItem.Company,
+		Brokerage:  apiItem.Brokerage,
+		Action:     apiItem.Action,
+		RatingFrom: apiItem.RatingFrom,
+		RatingTo:   apiItem.RatingTo,
+		TargetFrom: apiItem.TargetFrom,
+		TargetTo:   apiItem.TargetTo,
+		EventTime:  eventTime,
+		RawData:    raw,
+	}, "", nil
+}
+
 // GetNextPageToken implementa StockDataProvider.GetNextPageToken
 func (p *StockAPIDataProvider) GetNextPageToken(currentPage string) string {
 	// Para la primera página, devolver string vacío
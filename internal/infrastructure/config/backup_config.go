@@ -0,0 +1,71 @@
+package config
+
+import "strings"
+
+// DefaultBackupTables son las tablas exportadas si no se indica BACKUP_TABLES. Las tablas operativas
+// (jobs, event_outbox, provider_payloads, population_rejects) no forman parte del snapshot.
+var DefaultBackupTables = []string{
+	"companies",
+	"brokerages",
+	"stock_ratings",
+	"ticker_aliases",
+	"company_profiles",
+	"company_peers",
+	"company_relationships",
+	"tags",
+	"company_tags",
+	"news_items",
+	"basic_financials",
+	"market_data",
+	"quote_snapshots",
+	"historical_data",
+	"financial_metrics",
+	"financial_statements",
+	"benchmarks",
+	"benchmark_values",
+	"benchmark_constituents",
+	"technical_indicators",
+	"anomalies",
+	"sync_states",
+}
+
+// BackupConfig configura los snapshots de la base de datos en un bucket S3 o compatible (MinIO, R2...)
+type BackupConfig struct {
+	Enabled bool     `mapstructure:"enabled"`
+	Tables  []string `mapstructure:"tables"`
+
+	// Bucket de destino; los snapshots se guardan en <Prefix>/<id>/
+	Endpoint  string `mapstructure:"endpoint"` // Vacío usa AWS S3 de la región
+	Region    string `mapstructure:"region"`
+	Bucket    string `mapstructure:"bucket" validate:"required_if=Enabled true"`
+	Prefix    string `mapstructure:"prefix"`
+	PathStyle bool   `mapstructure:"path_style"` // bucket en la ruta en lugar del host (MinIO)
+
+	// Credenciales; admiten referencias secret:<nombre>#<campo>
+	AccessKeyID     string `mapstructure:"access_key_id" validate:"required_if=Enabled true"`
+	SecretAccessKey string `mapstructure:"secret_access_key" validate:"required_if=Enabled true"`
+	SessionToken    string `mapstructure:"session_token"`
+}
+
+// loadBackupConfig lee la configuración de los snapshots de la base de datos
+func loadBackupConfig() BackupConfig {
+	tables := getEnvAsSlice("BACKUP_TABLES")
+	if len(tables) == 0 {
+		tables = DefaultBackupTables
+	}
+
+	return BackupConfig{
+		Enabled: getEnvAsBoolWithDefault("BACKUP_ENABLED", false),
+		Tables:  tables,
+
+		Endpoint:  getEnvWithDefault("BACKUP_S3_ENDPOINT", ""),
+		Region:    getEnvWithDefault("BACKUP_S3_REGION", "us-east-1"),
+		Bucket:    getEnvWithDefault("BACKUP_S3_BUCKET", ""),
+		Prefix:    strings.Trim(getEnvWithDefault("BACKUP_S3_PREFIX", "backups"), "/"),
+		PathStyle: getEnvAsBoolWithDefault("BACKUP_S3_PATH_STYLE", false),
+
+		AccessKeyID:     getEnvWithDefault("BACKUP_S3_ACCESS_KEY_ID", ""),
+		SecretAccessKey: getEnvWithDefault("BACKUP_S3_SECRET_ACCESS_KEY", ""),
+		SessionToken:    getEnvWithDefault("BACKUP_S3_SESSION_TOKEN", ""),
+	}
+}
@@ -19,6 +19,16 @@ type Config struct {
 	Logging       LoggingConfig       `mapstructure:"logging"`
 	ServerLogging ServerLoggingConfig `mapstructure:"server_logging"`
 	ThirdStockAPI ThirdStockAPIConfig `mapstructure:"third_stock_api"`
+	Worker        WorkerConfig        `mapstructure:"worker"`
+	Secrets       SecretsConfig       `mapstructure:"secrets"`
+	Analysis      AnalysisConfig      `mapstructure:"analysis"`
+	Events        EventsConfig        `mapstructure:"events"`
+	Backup        BackupConfig        `mapstructure:"backup"`
+	Export        ExportConfig        `mapstructure:"export"`
+	Tenancy       TenancyConfig       `mapstructure:"tenancy"`
+	Plans         PlansConfig         `mapstructure:"plans"`
+	Ingestion     IngestionConfig     `mapstructure:"ingestion"`
+	Logo          LogoConfig          `mapstructure:"logo"`
 }
 
 // AppConfig holds application-specific configuration
@@ -41,6 +51,14 @@ type DatabaseConfig struct {
 	MaxOpenConns    int           `mapstructure:"max_open_conns" validate:"min=1"`
 	MaxIdleConns    int           `mapstructure:"max_idle_conns" validate:"min=1"`
 	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime" validate:"required"`
+	ConnMaxIdleTime time.Duration `mapstructure:"conn_max_idle_time"` // 0 = las conexiones inactivas no caducan
+	AutoMigrate     bool          `mapstructure:"auto_migrate"`       // Aplica las migraciones pendientes al arrancar
+	ReplicaDSNs     []string      `mapstructure:"replica_dsns"`       // Réplicas de lectura para listados y analíticas
+	AnalyticsViews  bool          `mapstructure:"analytics_views"`    // Las analíticas leen de las vistas materializadas
+
+	// Diagnóstico de queries lentas (0 desactiva el registro)
+	SlowQueryThreshold  time.Duration `mapstructure:"slow_query_threshold"`
+	SlowQueryBufferSize int           `mapstructure:"slow_query_buffer_size" validate:"min=0"`
 }
 
 // CacheConfig holds cache configuration
@@ -50,20 +68,125 @@ type CacheConfig struct {
 	Password string `mapstructure:"password"`
 	DB       int    `mapstructure:"db" validate:"min=0"`
 	Username string `mapstructure:"username"`
+
+	// TTL de las consultas de repositorio cacheadas (0 desactiva la cache de consultas)
+	QueryTTL time.Duration `mapstructure:"query_ttl"`
+
+	// TTL de las entradas de "símbolo no encontrado" (0 desactiva el negative caching)
+	NegativeTTL time.Duration `mapstructure:"negative_ttl"`
+
+	// TTLs por tipo de entidad
+	TTL CacheTTLConfig `mapstructure:"ttl"`
+
+	// Warm-up de la cache con las companies más consultadas al arrancar la API
+	WarmOnStart      bool `mapstructure:"warm_on_start"`
+	WarmTopCompanies int  `mapstructure:"warm_top_companies" validate:"min=0"`
+}
+
+// AnalysisConfig holds the configuration of the analysis models
+type AnalysisConfig struct {
+	// Pesos del modelo de recomendación compuesto; solo importa la proporción entre ellos
+	ConsensusWeight float64 `mapstructure:"consensus_weight" validate:"min=0"`
+	MomentumWeight  float64 `mapstructure:"momentum_weight" validate:"min=0"`
+	ValuationWeight float64 `mapstructure:"valuation_weight" validate:"min=0"`
 }
 
+// CacheTTLConfig define cuánto tiempo se consideran frescos los datos de cada tipo de entidad
+type CacheTTLConfig struct {
+	MarketData      time.Duration `mapstructure:"market_data"`      // Cotizaciones en tiempo real
+	CompanyProfile  time.Duration `mapstructure:"company_profile"`  // Perfiles de compañía
+	BasicFinancials time.Duration `mapstructure:"basic_financials"` // Métricas financieras básicas
+	News            time.Duration `mapstructure:"news"`             // Noticias por símbolo
+	Peers           time.Duration `mapstructure:"peers"`            // Grafo de competidores
+	Statements      time.Duration `mapstructure:"statements"`       // Estados financieros trimestrales y anuales
+	Analytics       time.Duration `mapstructure:"analytics"`        // Resultados de análisis costosos (correlaciones)
+	Dashboard       time.Duration `mapstructure:"dashboard"`        // Resumen de la pantalla de inicio
+
+	// Entradas escritas por el proceso de population
+	Company     time.Duration `mapstructure:"company"`
+	Brokerage   time.Duration `mapstructure:"brokerage"`
+	StockRating time.Duration `mapstructure:"stock_rating"`
+}
+
+// Fuentes de datos externas (EXTERNAL_PROVIDER)
+const (
+	ExternalProviderLive = "live" // Finnhub, Alpha Vantage y la API de ratings reales
+	ExternalProviderMock = "mock" // Datos sintéticos deterministas, sin API keys
+)
+
 // ExternalConfig holds external APIs configuration
 type ExternalConfig struct {
-	Primary   APIConfig `mapstructure:"primary"`   // Finnhub - Real-time data
-	Secondary APIConfig `mapstructure:"secondary"` // Alpha Vantage - Historical analysis
+	Provider    string    `mapstructure:"provider" validate:"oneof=live mock"`
+	Primary     APIConfig `mapstructure:"primary"`                                       // Finnhub - Real-time data
+	Secondary   APIConfig `mapstructure:"secondary"`                                     // Alpha Vantage - Historical analysis
+	KeyStrategy string    `mapstructure:"key_strategy" validate:"oneof=round_robin lru"` // Reparto entre las keys del pool
+
+	// Cliente HTTP compartido por los proveedores (transport, reintentos y proxy)
+	HTTP HTTPClientConfig `mapstructure:"http"`
+
+	// Tiempo que se conservan las respuestas crudas de los proveedores (0 = no se archivan)
+	PayloadArchiveTTL time.Duration `mapstructure:"payload_archive_ttl" validate:"min=0"`
 }
 
 // APIConfig holds API configuration
 type APIConfig struct {
-	Name      string `mapstructure:"name"`
-	Key       string `mapstructure:"key" validate:"required"`
-	SecretKey string `mapstructure:"secret_key"` // Opcional
-	BaseURL   string `mapstructure:"base_url" validate:"required,url"`
+	Name      string   `mapstructure:"name"`
+	Key       string   `mapstructure:"key" validate:"required"`
+	Keys      []string `mapstructure:"keys"`       // Keys adicionales para el pool (opcional)
+	SecretKey string   `mapstructure:"secret_key"` // Opcional
+	BaseURL   string   `mapstructure:"base_url" validate:"required,url"`
+
+	// Presupuesto diario de llamadas que el refresco masivo no debe superar (0 = sin límite)
+	DailyQuota int `mapstructure:"daily_quota" validate:"min=0"`
+
+	// Tiempo máximo de una petición al proveedor, reintentos incluidos (0 = el de External.HTTP)
+	Timeout time.Duration `mapstructure:"timeout" validate:"min=0"`
+}
+
+// IsMock reports whether the synthetic mock provider replaces the external APIs
+func (e ExternalConfig) IsMock() bool {
+	return e.Provider == ExternalProviderMock
+}
+
+// HTTPClientConfig holds the settings of the HTTP client shared by the external providers
+type HTTPClientConfig struct {
+	Timeout        time.Duration `mapstructure:"timeout" validate:"min=0"`
+	MaxRetries     int           `mapstructure:"max_retries" validate:"min=0,max=10"` // Reintentos tras 5xx, 429 o errores de red
+	InitialBackoff time.Duration `mapstructure:"initial_backoff" validate:"min=0"`    // Se duplica en cada reintento
+	MaxBackoff     time.Duration `mapstructure:"max_backoff" validate:"min=0"`
+	ProxyURL       string        `mapstructure:"proxy_url" validate:"omitempty,url"`
+
+	// Fixtures de respuestas de los proveedores, solo para tests y desarrollo
+	FixtureMode string `mapstructure:"fixture_mode" validate:"oneof=off record replay"`
+	FixtureDir  string `mapstructure:"fixture_dir"`
+}
+
+// Validate rejects recording or replaying fixtures in production, where the providers must always be called
+func (h HTTPClientConfig) Validate(production bool) error {
+	if production && h.FixtureMode != "off" {
+		return fmt.Errorf("EXTERNAL_HTTP_FIXTURE_MODE=%s is only allowed outside production", h.FixtureMode)
+	}
+	if h.FixtureMode != "off" && h.FixtureDir == "" {
+		return fmt.Errorf("EXTERNAL_HTTP_FIXTURE_DIR is required with EXTERNAL_HTTP_FIXTURE_MODE=%s", h.FixtureMode)
+	}
+	return nil
+}
+
+// AllKeys devuelve la key principal seguida de las adicionales, sin vacías ni duplicadas
+func (a APIConfig) AllKeys() []string {
+	keys := make([]string, 0, len(a.Keys)+1)
+	seen := make(map[string]struct{}, len(a.Keys)+1)
+	for _, key := range append([]string{a.Key}, a.Keys...) {
+		if key == "" {
+			continue
+		}
+		if _, duplicated := seen[key]; duplicated {
+			continue
+		}
+		seen[key] = struct{}{}
+		keys = append(keys, key)
+	}
+	return keys
 }
 
 // SecurityConfig holds security configuration
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -48,10 +50,17 @@ func GetDefaultCORSConfig() *CORSConfig {
 			"Authorization",
 			"X-Requested-With",
 			"X-Request-ID",
+			"X-API-Key",
 		},
 		ExposeHeaders: []string{
 			"X-Request-ID",
 			"X-Response-Time",
+			"RateLimit-Limit",
+			"RateLimit-Remaining",
+			"RateLimit-Reset",
+			"RateLimit-Policy",
+			"Retry-After",
+			"Link",
 		},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
@@ -65,17 +74,18 @@ func GetProductionCORSConfig() *CORSConfig {
 		Enabled:          true,
 		AllowOrigins:     []string{}, // Should be set via environment variables
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type", "Accept", "Authorization", "X-Request-ID"},
-		ExposeHeaders:    []string{"X-Request-ID", "X-Response-Time"},
+		AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type", "Accept", "Authorization", "X-Request-ID", "X-API-Key"},
+		ExposeHeaders:    []string{"X-Request-ID", "X-Response-Time", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Retry-After", "Link"},
 		AllowCredentials: true,
 		MaxAge:           24 * time.Hour,
 		AllowWildcard:    false,
 	}
 }
 
-// IsOriginAllowed checks if an origin is allowed
+// IsOriginAllowed checks if an origin is allowed. Los orígenes admiten un comodín para subdominios
+// (p.ej. https://*.example.com) y "*" permite cualquier origen.
 func (c *CORSConfig) IsOriginAllowed(origin string) bool {
-	if !c.Enabled {
+	if !c.Enabled || origin == "" {
 		return false
 	}
 
@@ -84,10 +94,50 @@ func (c *CORSConfig) IsOriginAllowed(origin string) bool {
 	}
 
 	for _, allowedOrigin := range c.AllowOrigins {
-		if allowedOrigin == "*" || allowedOrigin == origin {
+		if allowedOrigin == "*" || strings.EqualFold(allowedOrigin, origin) {
+			return true
+		}
+		if matchOriginPattern(allowedOrigin, origin) {
 			return true
 		}
 	}
 
 	return false
 }
+
+// AllowsAnyOrigin indica si la configuración acepta cualquier origen
+func (c *CORSConfig) AllowsAnyOrigin() bool {
+	if c.AllowWildcard {
+		return true
+	}
+	for _, allowedOrigin := range c.AllowOrigins {
+		if allowedOrigin == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate rechaza en producción aceptar cualquier origen con credenciales: cualquier web podría
+// hacer peticiones autenticadas en nombre del usuario
+func (c *CORSConfig) Validate(production bool) error {
+	if !c.Enabled || !production {
+		return nil
+	}
+	if c.AllowCredentials && c.AllowsAnyOrigin() {
+		return fmt.Errorf("CORS_ALLOW_CREDENTIALS cannot be combined with a wildcard origin in production")
+	}
+	return nil
+}
+
+// matchOriginPattern comprueba un origen contra un patrón con un único "*" (p.ej. https://*.example.com)
+func matchOriginPattern(pattern, origin string) bool {
+	prefix, suffix, found := strings.Cut(strings.ToLower(pattern), "*")
+	if !found {
+		return false
+	}
+	origin = strings.ToLower(origin)
+	return len(origin) > len(prefix)+len(suffix) &&
+		strings.HasPrefix(origin, prefix) &&
+		strings.HasSuffix(origin, suffix)
+}
@@ -14,6 +14,12 @@ import (
 
 var validate *validator.Validate
 
+// .env cargado al arrancar y variables que ya venían del proceso (para la recarga en caliente)
+var (
+	loadedEnvPath  string
+	processEnvKeys = make(map[string]struct{})
+)
+
 func init() {
 	validate = validator.New()
 }
@@ -27,10 +33,20 @@ func Load() (*Config, error) {
 		"../../.env", // For tests running from test/integration
 	}
 
+	// Solo en la primera carga: después el entorno ya incluye lo leído del .env
+	if len(processEnvKeys) == 0 {
+		for _, entry := range os.Environ() {
+			if key, _, found := strings.Cut(entry, "="); found {
+				processEnvKeys[key] = struct{}{}
+			}
+		}
+	}
+
 	envLoaded := false
 	for _, path := range envPaths {
 		if err := godotenv.Load(path); err == nil {
 			envLoaded = true
+			loadedEnvPath = path
 			break
 		}
 	}
@@ -39,6 +55,12 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("failed to load .env file from any of the following locations: %v", envPaths)
 	}
 
+	// Las variables con valor secret:<nombre>#<campo> se resuelven antes de leer el resto
+	secretsConfig := loadSecretsConfig()
+	if err := resolveSecretReferences(secretsConfig); err != nil {
+		return nil, fmt.Errorf("failed to resolve secrets: %w", err)
+	}
+
 	config := &Config{
 		App:           loadAppConfig(),
 		Server:        loadServerConfig(),
@@ -52,12 +74,43 @@ func Load() (*Config, error) {
 		Logging:       loadLoggingConfig(),
 		ServerLogging: loadServerLoggingConfig(),
 		ThirdStockAPI: loadThirdStockAPIConfig(),
+		Worker:        loadWorkerConfig(),
+		Secrets:       secretsConfig,
+		Analysis:      loadAnalysisConfig(),
+		Events:        loadEventsConfig(),
+		Backup:        loadBackupConfig(),
+		Export:        loadExportConfig(),
+		Tenancy:       loadTenancyConfig(),
+		Plans:         loadPlansConfig(),
+		Ingestion:     loadIngestionConfig(),
+		Logo:          loadLogoConfig(),
 	}
 
 	// Validate configuration
 	if err := validate.Struct(config); err != nil {
 		return nil, fmt.Errorf("configuration validation failed: %w", err)
 	}
+	if err := config.Server.TLS.Validate(); err != nil {
+		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	}
+	if err := config.CORS.Validate(config.App.IsProduction()); err != nil {
+		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	}
+	if err := config.Tenancy.Validate(); err != nil {
+		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	}
+	if err := config.Plans.Validate(); err != nil {
+		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	}
+	if err := config.Ingestion.Validate(); err != nil {
+		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	}
+	if err := config.Logo.Validate(); err != nil {
+		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	}
+	if err := config.External.HTTP.Validate(config.App.IsProduction()); err != nil {
+		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	}
 
 	return config, nil
 }
@@ -83,31 +136,73 @@ func loadDatabaseConfig() DatabaseConfig {
 		MaxOpenConns:    getEnvAsIntRequired("DB_MAX_OPEN_CONNS"),
 		MaxIdleConns:    getEnvAsIntRequired("DB_MAX_IDLE_CONNS"),
 		ConnMaxLifetime: getEnvAsDurationRequired("DB_CONN_MAX_LIFETIME"),
+		ConnMaxIdleTime: getEnvAsDurationWithDefault("DB_CONN_MAX_IDLE_TIME", "0s"),
+		AutoMigrate:     getEnvAsBoolWithDefault("DB_AUTO_MIGRATE", true),
+		ReplicaDSNs:     getEnvAsSlice("DB_REPLICA_DSNS"),
+		AnalyticsViews:  getEnvAsBoolWithDefault("DB_ANALYTICS_VIEWS", true),
+
+		SlowQueryThreshold:  getEnvAsDurationWithDefault("DB_SLOW_QUERY_THRESHOLD", "200ms"),
+		SlowQueryBufferSize: getEnvAsIntWithDefault("DB_SLOW_QUERY_BUFFER_SIZE", 100),
 	}
 }
 
 func loadCacheConfig() CacheConfig {
 	return CacheConfig{
-		Host:     getEnvRequired("REDIS_HOST"),
-		Port:     getEnvRequired("REDIS_PORT"),
-		Password: getEnvRequired("REDIS_PASSWORD"),
-		Username: getEnvRequired("REDIS_USERNAME"),
-		DB:       getEnvAsIntRequired("REDIS_DB"),
+		Host:        getEnvRequired("REDIS_HOST"),
+		Port:        getEnvRequired("REDIS_PORT"),
+		Password:    getEnvRequired("REDIS_PASSWORD"),
+		Username:    getEnvRequired("REDIS_USERNAME"),
+		DB:          getEnvAsIntRequired("REDIS_DB"),
+		QueryTTL:    getEnvAsDurationWithDefault("CACHE_QUERY_TTL", "5m"),
+		NegativeTTL: getEnvAsDurationWithDefault("CACHE_NEGATIVE_TTL", "1m"),
+		TTL: CacheTTLConfig{
+			MarketData:      getEnvAsDurationWithDefault("CACHE_TTL_MARKET_DATA", "5m"),
+			CompanyProfile:  getEnvAsDurationWithDefault("CACHE_TTL_COMPANY_PROFILE", "24h"),
+			BasicFinancials: getEnvAsDurationWithDefault("CACHE_TTL_BASIC_FINANCIALS", "24h"),
+			News:            getEnvAsDurationWithDefault("CACHE_TTL_NEWS", "15m"),
+			Peers:           getEnvAsDurationWithDefault("CACHE_TTL_PEERS", "168h"),
+			Statements:      getEnvAsDurationWithDefault("CACHE_TTL_FINANCIAL_STATEMENTS", "24h"),
+			Analytics:       getEnvAsDurationWithDefault("CACHE_TTL_ANALYTICS", "1h"),
+			Dashboard:       getEnvAsDurationWithDefault("CACHE_TTL_DASHBOARD", "1m"),
+			Company:         getEnvAsDurationWithDefault("CACHE_TTL_COMPANY", "5m"),
+			Brokerage:       getEnvAsDurationWithDefault("CACHE_TTL_BROKERAGE", "5m"),
+			StockRating:     getEnvAsDurationWithDefault("CACHE_TTL_STOCK_RATING", "5m"),
+		},
+
+		WarmOnStart:      getEnvAsBoolWithDefault("CACHE_WARM_ON_START", true),
+		WarmTopCompanies: getEnvAsIntWithDefault("CACHE_WARM_TOP_COMPANIES", 50),
 	}
 }
 
 func loadExternalConfig() ExternalConfig {
 	return ExternalConfig{
+		Provider: externalProvider(),
 		Primary: APIConfig{
-			Name:      "Finnhub",
-			Key:       getEnvRequired("PRIMARY_API_KEY"),
-			SecretKey: getEnvRequired("PRIMARY_SECRET_KEY"),
-			BaseURL:   getEnvRequired("PRIMARY_API_BASE_URL"),
+			Name:       "Finnhub",
+			Key:        getProviderEnv("PRIMARY_API_KEY", "mock"),
+			Keys:       getEnvAsSlice("PRIMARY_API_KEYS"),
+			SecretKey:  getProviderEnv("PRIMARY_SECRET_KEY", "mock"),
+			BaseURL:    getProviderEnv("PRIMARY_API_BASE_URL", "http://finnhub.mock/api/v1"),
+			DailyQuota: getEnvAsIntWithDefault("PRIMARY_API_DAILY_QUOTA", 0),
+			Timeout:    getEnvAsDurationWithDefault("PRIMARY_API_TIMEOUT", "0s"),
 		},
 		Secondary: APIConfig{
 			Name:    "Alpha Vantage",
-			Key:     getEnvRequired("SECONDARY_API_KEY"),
-			BaseURL: getEnvRequired("SECONDARY_API_BASE_URL"),
+			Key:     getProviderEnv("SECONDARY_API_KEY", "mock"),
+			Keys:    getEnvAsSlice("SECONDARY_API_KEYS"),
+			BaseURL: getProviderEnv("SECONDARY_API_BASE_URL", "http://alphavantage.mock/query"),
+			Timeout: getEnvAsDurationWithDefault("SECONDARY_API_TIMEOUT", "0s"),
+		},
+		KeyStrategy:       strings.ToLower(getEnvWithDefault("API_KEY_STRATEGY", "round_robin")),
+		PayloadArchiveTTL: getEnvAsDurationWithDefault("EXTERNAL_PAYLOAD_ARCHIVE_TTL", "0s"),
+		HTTP: HTTPClientConfig{
+			Timeout:        getEnvAsDurationWithDefault("EXTERNAL_HTTP_TIMEOUT", "30s"),
+			MaxRetries:     getEnvAsIntWithDefault("EXTERNAL_HTTP_MAX_RETRIES", 2),
+			InitialBackoff: getEnvAsDurationWithDefault("EXTERNAL_HTTP_INITIAL_BACKOFF", "500ms"),
+			MaxBackoff:     getEnvAsDurationWithDefault("EXTERNAL_HTTP_MAX_BACKOFF", "10s"),
+			ProxyURL:       getEnvWithDefault("EXTERNAL_HTTP_PROXY_URL", ""),
+			FixtureMode:    strings.ToLower(getEnvWithDefault("EXTERNAL_HTTP_FIXTURE_MODE", "off")),
+			FixtureDir:     getEnvWithDefault("EXTERNAL_HTTP_FIXTURE_DIR", "test/fixtures/http"),
 		},
 	}
 }
@@ -142,9 +237,23 @@ func loadServerLoggingConfig() ServerLoggingConfig {
 func loadThirdStockAPIConfig() ThirdStockAPIConfig {
 	return ThirdStockAPIConfig{
 		Name:    "Third Stock API",
-		Auth:    getEnvRequired("THIRD_STOCK_API_AUTH"),
-		BaseURL: getEnvRequired("THIRD_STOCK_API_BASE_URL"),
+		Auth:    getProviderEnv("THIRD_STOCK_API_AUTH", "mock"),
+		BaseURL: getProviderEnv("THIRD_STOCK_API_BASE_URL", "http://ratings.mock"),
+	}
+}
+
+// externalProvider devuelve la fuente de datos externa configurada (live por defecto)
+func externalProvider() string {
+	return strings.ToLower(getEnvWithDefault("EXTERNAL_PROVIDER", ExternalProviderLive))
+}
+
+// getProviderEnv lee una variable de los proveedores externos: obligatoria con datos reales y con un valor
+// de relleno con EXTERNAL_PROVIDER=mock, que no hace peticiones
+func getProviderEnv(key, mockValue string) string {
+	if externalProvider() == ExternalProviderMock {
+		return getEnvWithDefault(key, mockValue)
 	}
+	return getEnvRequired(key)
 }
 
 // loadServerConfig loads server configuration from environment variables
@@ -159,6 +268,23 @@ func loadServerConfig() ServerConfig {
 		ShutdownTimeout: getEnvAsDurationWithDefault("SERVER_SHUTDOWN_TIMEOUT", "30s"),
 		MaxHeaderBytes:  getEnvAsIntWithDefault("SERVER_MAX_HEADER_BYTES", 1048576), // 1MB
 		TrustedProxies:  getEnvAsSlice("SERVER_TRUSTED_PROXIES"),
+		TLS:             loadTLSConfig(),
+	}
+}
+
+// loadTLSConfig loads HTTPS configuration from environment variables
+func loadTLSConfig() TLSConfig {
+	return TLSConfig{
+		Enabled:          getEnvAsBoolWithDefault("SERVER_TLS_ENABLED", false),
+		CertFile:         getEnvWithDefault("SERVER_TLS_CERT_FILE", ""),
+		KeyFile:          getEnvWithDefault("SERVER_TLS_KEY_FILE", ""),
+		MinVersion:       getEnvWithDefault("SERVER_TLS_MIN_VERSION", "1.2"),
+		Autocert:         getEnvAsBoolWithDefault("SERVER_TLS_AUTOCERT", false),
+		AutocertDomains:  getEnvAsSlice("SERVER_TLS_AUTOCERT_DOMAINS"),
+		AutocertEmail:    getEnvWithDefault("SERVER_TLS_AUTOCERT_EMAIL", ""),
+		AutocertCacheDir: getEnvWithDefault("SERVER_TLS_AUTOCERT_CACHE_DIR", "certs"),
+		RedirectHTTP:     getEnvAsBoolWithDefault("SERVER_TLS_REDIRECT_HTTP", true),
+		HTTPPort:         getEnvWithDefault("SERVER_HTTP_PORT", "80"),
 	}
 }
 
@@ -171,28 +297,28 @@ func loadRESTAPIConfig() RESTAPIConfig {
 		EnableHealthChecks: getEnvAsBoolWithDefault("API_ENABLE_HEALTH_CHECKS", true),
 		EnableMetrics:      getEnvAsBoolWithDefault("API_ENABLE_METRICS", false),
 		EnableProfiling:    getEnvAsBoolWithDefault("API_ENABLE_PROFILING", false),
+		ErrorFormat:        getEnvWithDefault("API_ERROR_FORMAT", "problem"),
+		ProblemTypeBaseURI: getEnvWithDefault("API_PROBLEM_TYPE_BASE_URI", "/problems"),
+		ValidateRequests:   getEnvAsBoolWithDefault("API_VALIDATE_REQUESTS", false),
+		OpenAPISpecPath:    getEnvWithDefault("API_OPENAPI_SPEC_PATH", "docs/swagger.json"),
 	}
 }
 
 // loadCORSConfig loads CORS configuration from environment variables
 func loadCORSConfig() CORSConfig {
-	// Check if specific environment is set, otherwise use defaults
+	// Valores por defecto según el entorno: producción no permite ningún origen hasta configurarlos
 	env := getEnvWithDefault("APP_ENV", "development")
 
+	config := GetDefaultCORSConfig()
 	if env == "production" {
-		config := GetProductionCORSConfig()
-		// Override with environment variables if provided
-		if origins := getEnvAsSlice("CORS_ALLOW_ORIGINS"); len(origins) > 0 {
-			config.AllowOrigins = origins
-		}
-		return *config
+		config = GetProductionCORSConfig()
 	}
 
-	// Development/staging defaults
-	config := GetDefaultCORSConfig()
-	config.Enabled = getEnvAsBoolWithDefault("CORS_ENABLED", true)
-	config.AllowCredentials = getEnvAsBoolWithDefault("CORS_ALLOW_CREDENTIALS", true)
-	config.AllowWildcard = getEnvAsBoolWithDefault("CORS_ALLOW_WILDCARD", false)
+	// Las variables de entorno sobrescriben los valores por defecto en cualquier entorno
+	config.Enabled = getEnvAsBoolWithDefault("CORS_ENABLED", config.Enabled)
+	config.AllowCredentials = getEnvAsBoolWithDefault("CORS_ALLOW_CREDENTIALS", config.AllowCredentials)
+	config.AllowWildcard = getEnvAsBoolWithDefault("CORS_ALLOW_WILDCARD", config.AllowWildcard)
+	config.MaxAge = getEnvAsDurationWithDefault("CORS_MAX_AGE", config.MaxAge.String())
 
 	if origins := getEnvAsSlice("CORS_ALLOW_ORIGINS"); len(origins) > 0 {
 		config.AllowOrigins = origins
@@ -203,6 +329,9 @@ func loadCORSConfig() CORSConfig {
 	if headers := getEnvAsSlice("CORS_ALLOW_HEADERS"); len(headers) > 0 {
 		config.AllowHeaders = headers
 	}
+	if exposeHeaders := getEnvAsSlice("CORS_EXPOSE_HEADERS"); len(exposeHeaders) > 0 {
+		config.ExposeHeaders = exposeHeaders
+	}
 
 	return *config
 }
@@ -214,6 +343,52 @@ func loadRateLimitConfig() RateLimitConfig {
 		RequestsPer: getEnvAsDurationWithDefault("RATE_LIMIT_REQUESTS_PER", "1m"),
 		Limit:       getEnvAsIntWithDefault("RATE_LIMIT_LIMIT", 100),
 		KeyFunc:     getEnvWithDefault("RATE_LIMIT_KEY_FUNC", "ip"),
+
+		GroupLimits: map[string]int{
+			RateLimitGroupRead:   getEnvAsIntWithDefault("RATE_LIMIT_READ_LIMIT", 0),
+			RateLimitGroupWrite:  getEnvAsIntWithDefault("RATE_LIMIT_WRITE_LIMIT", 0),
+			RateLimitGroupAdmin:  getEnvAsIntWithDefault("RATE_LIMIT_ADMIN_LIMIT", 0),
+			RateLimitGroupSearch: getEnvAsIntWithDefault("RATE_LIMIT_SEARCH_LIMIT", 0),
+		},
+		APIKeyHeader: getEnvWithDefault("RATE_LIMIT_API_KEY_HEADER", "X-API-Key"),
+		APIKeyLimits: getEnvAsIntMap("RATE_LIMIT_API_KEYS"),
+	}
+}
+
+// loadWorkerConfig loads background worker configuration from environment variables
+func loadWorkerConfig() WorkerConfig {
+	return WorkerConfig{
+		PopulationInterval:          getEnvAsDurationWithDefault("WORKER_POPULATION_INTERVAL", "0s"),
+		PopulateOnStart:             getEnvAsBoolWithDefault("WORKER_POPULATE_ON_START", false),
+		PopulationIncremental:       getEnvAsBoolWithDefault("WORKER_POPULATION_INCREMENTAL", true),
+		ShutdownTimeout:             getEnvAsDurationWithDefault("WORKER_SHUTDOWN_TIMEOUT", "30s"),
+		EnrichmentInterval:          getEnvAsDurationWithDefault("WORKER_ENRICHMENT_INTERVAL", "0s"),
+		AnalyticsRefresh:            getEnvAsDurationWithDefault("WORKER_ANALYTICS_REFRESH_INTERVAL", "15m"),
+		AnomalyDetection:            getEnvAsDurationWithDefault("WORKER_ANOMALY_DETECTION_INTERVAL", "1h"),
+		MarketDataRefresh:           getEnvAsDurationWithDefault("WORKER_MARKET_DATA_REFRESH_INTERVAL", "0s"),
+		RefreshTrending:             getEnvAsIntWithDefault("WORKER_MARKET_DATA_REFRESH_TRENDING", 100),
+		IntegrityCheck:              getEnvAsDurationWithDefault("WORKER_INTEGRITY_CHECK_INTERVAL", "24h"),
+		UsageFlush:                  getEnvAsDurationWithDefault("WORKER_USAGE_FLUSH_INTERVAL", "5m"),
+		BenchmarkRefresh:            getEnvAsDurationWithDefault("WORKER_BENCHMARK_REFRESH_INTERVAL", "1h"),
+		RatingProcessing:            getEnvAsDurationWithDefault("WORKER_RATING_PROCESSING_INTERVAL", "5m"),
+		RatingProcessingBatchSize:   getEnvAsIntWithDefault("WORKER_RATING_PROCESSING_BATCH_SIZE", 500),
+		RatingProcessingConcurrency: getEnvAsIntWithDefault("WORKER_RATING_PROCESSING_CONCURRENCY", 4),
+		RatingBacklogThreshold:      getEnvAsIntWithDefault("WORKER_RATING_BACKLOG_THRESHOLD", 10000),
+		RatingBacklogAlertCooldown:  getEnvAsDurationWithDefault("WORKER_RATING_BACKLOG_ALERT_COOLDOWN", "1h"),
+		RatingBacklogWebhookURL:     getEnvWithDefault("WORKER_RATING_BACKLOG_WEBHOOK_URL", ""),
+		JobConcurrency:              getEnvAsIntWithDefault("WORKER_JOB_CONCURRENCY", 2),
+		JobPollInterval:             getEnvAsDurationWithDefault("WORKER_JOB_POLL_INTERVAL", "2s"),
+		JobStaleAfter:               getEnvAsDurationWithDefault("WORKER_JOB_STALE_AFTER", "5m"),
+		JobRetryBackoff:             getEnvAsDurationWithDefault("WORKER_JOB_RETRY_BACKOFF", "30s"),
+	}
+}
+
+// loadAnalysisConfig loads the analysis model configuration from environment variables
+func loadAnalysisConfig() AnalysisConfig {
+	return AnalysisConfig{
+		ConsensusWeight: getEnvAsFloatWithDefault("ANALYSIS_WEIGHT_CONSENSUS", 0.5),
+		MomentumWeight:  getEnvAsFloatWithDefault("ANALYSIS_WEIGHT_MOMENTUM", 0.25),
+		ValuationWeight: getEnvAsFloatWithDefault("ANALYSIS_WEIGHT_VALUATION", 0.25),
 	}
 }
 
@@ -221,7 +396,7 @@ func loadRateLimitConfig() RateLimitConfig {
 
 // getEnvRequired gets an environment variable or fails immediately if not found
 func getEnvRequired(key string) string {
-	value := os.Getenv(key)
+	value := getenv(key)
 	if value == "" {
 		log.Fatalf("❌ Required environment variable %s is not set", key)
 	}
@@ -252,7 +427,7 @@ func getEnvAsDurationRequired(key string) time.Duration {
 
 // getEnvWithDefault gets an environment variable with a default value
 func getEnvWithDefault(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
+	if value := getenv(key); value != "" {
 		return value
 	}
 	return defaultValue
@@ -260,7 +435,7 @@ func getEnvWithDefault(key, defaultValue string) string {
 
 // getEnvAsBoolWithDefault gets a boolean environment variable with a default value
 func getEnvAsBoolWithDefault(key string, defaultValue bool) bool {
-	if value := os.Getenv(key); value != "" {
+	if value := getenv(key); value != "" {
 		if parsed, err := strconv.ParseBool(value); err == nil {
 			return parsed
 		}
@@ -270,7 +445,7 @@ func getEnvAsBoolWithDefault(key string, defaultValue bool) bool {
 
 // getEnvAsIntWithDefault gets an integer environment variable with a default value
 func getEnvAsIntWithDefault(key string, defaultValue int) int {
-	if value := os.Getenv(key); value != "" {
+	if value := getenv(key); value != "" {
 		if parsed, err := strconv.Atoi(value); err == nil {
 			return parsed
 		}
@@ -278,6 +453,16 @@ func getEnvAsIntWithDefault(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getEnvAsFloatWithDefault gets a float environment variable with a default value
+func getEnvAsFloatWithDefault(key string, defaultValue float64) float64 {
+	if value := getenv(key); value != "" {
+		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
 // getEnvAsDurationWithDefault gets a duration environment variable with a default value
 func getEnvAsDurationWithDefault(key, defaultValue string) time.Duration {
 	value := getEnvWithDefault(key, defaultValue)
@@ -289,9 +474,41 @@ func getEnvAsDurationWithDefault(key, defaultValue string) time.Duration {
 	return duration
 }
 
+// getEnvAsIntMap gets an environment variable as comma-separated key:value pairs with int values.
+// Invalid pairs are ignored.
+func getEnvAsIntMap(key string) map[string]int {
+	result := make(map[string]int)
+	for _, pair := range getEnvAsSlice(key) {
+		idx := strings.LastIndex(pair, ":")
+		if idx <= 0 {
+			continue
+		}
+		value, err := strconv.Atoi(strings.TrimSpace(pair[idx+1:]))
+		if err != nil {
+			continue
+		}
+		result[strings.TrimSpace(pair[:idx])] = value
+	}
+	return result
+}
+
+// getEnvAsStringMap gets an environment variable as comma-separated key:value pairs.
+// Pairs without a key are ignored.
+func getEnvAsStringMap(key string) map[string]string {
+	result := make(map[string]string)
+	for _, pair := range getEnvAsSlice(key) {
+		idx := strings.Index(pair, ":")
+		if idx <= 0 {
+			continue
+		}
+		result[strings.TrimSpace(pair[:idx])] = strings.TrimSpace(pair[idx+1:])
+	}
+	return result
+}
+
 // getEnvAsSlice gets an environment variable as a comma-separated slice
 func getEnvAsSlice(key string) []string {
-	value := os.Getenv(key)
+	value := getenv(key)
 	if value == "" {
 		return []string{}
 	}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"strings"
+	"time"
+)
+
+// Backends del bus de eventos de dominio
+const (
+	EventsBackendMemory = "memory"
+	EventsBackendNATS   = "nats"
+)
+
+// EventsConfig configura el bus de eventos de dominio (RatingCreated, RatingProcessed, RatingBacklogExceeded, QuoteUpdated, CompanyUpdated)
+type EventsConfig struct {
+	Backend    string `mapstructure:"backend" validate:"oneof=memory nats"`
+	BufferSize int    `mapstructure:"buffer_size" validate:"min=0"` // Eventos pendientes de entrega del bus en memoria
+
+	// NATS: los eventos se publican en <SubjectPrefix>.<tipo>
+	NATSURL       string `mapstructure:"nats_url"`
+	SubjectPrefix string `mapstructure:"subject_prefix"`
+	QueueGroup    string `mapstructure:"queue_group"` // Vacío: cada instancia recibe todos los eventos
+
+	// Transactional outbox: los eventos se guardan en event_outbox y un relay los publica en el bus
+	Outbox             bool          `mapstructure:"outbox"`
+	OutboxPollInterval time.Duration `mapstructure:"outbox_poll_interval" validate:"min=0"`
+	OutboxBatchSize    int           `mapstructure:"outbox_batch_size" validate:"min=0"`
+	OutboxRetryBackoff time.Duration `mapstructure:"outbox_retry_backoff" validate:"min=0"`
+	OutboxRetention    time.Duration `mapstructure:"outbox_retention" validate:"min=0"` // 0 conserva los eventos publicados
+}
+
+// IsNATS indica si los eventos se distribuyen por NATS en lugar de dentro del proceso
+func (e EventsConfig) IsNATS() bool {
+	return e.Backend == EventsBackendNATS
+}
+
+// loadEventsConfig lee la configuración del bus de eventos
+func loadEventsConfig() EventsConfig {
+	return EventsConfig{
+		Backend:       strings.ToLower(getEnvWithDefault("EVENTS_BACKEND", EventsBackendMemory)),
+		BufferSize:    getEnvAsIntWithDefault("EVENTS_BUFFER_SIZE", 1024),
+		NATSURL:       getEnvWithDefault("EVENTS_NATS_URL", "nats://localhost:4222"),
+		SubjectPrefix: getEnvWithDefault("EVENTS_SUBJECT_PREFIX", "stockinfo"),
+		QueueGroup:    getEnvWithDefault("EVENTS_NATS_QUEUE_GROUP", ""),
+
+		Outbox:             getEnvAsBoolWithDefault("EVENTS_OUTBOX", false),
+		OutboxPollInterval: getEnvAsDurationWithDefault("EVENTS_OUTBOX_POLL_INTERVAL", "1s"),
+		OutboxBatchSize:    getEnvAsIntWithDefault("EVENTS_OUTBOX_BATCH_SIZE", 100),
+		OutboxRetryBackoff: getEnvAsDurationWithDefault("EVENTS_OUTBOX_RETRY_BACKOFF", "5s"),
+		OutboxRetention:    getEnvAsDurationWithDefault("EVENTS_OUTBOX_RETENTION", "24h"),
+	}
+}
@@ -0,0 +1,29 @@
+package config
+
+import "strings"
+
+// Destinos de las exportaciones Parquet
+const (
+	ExportDestinationLocal = "local"
+	ExportDestinationS3    = "s3"
+)
+
+// ExportConfig configura las exportaciones Parquet de precios históricos y ratings
+type ExportConfig struct {
+	Destination string `mapstructure:"destination" validate:"oneof=local s3"`
+	LocalDir    string `mapstructure:"local_dir"` // Directorio raíz con destino local
+	Prefix      string `mapstructure:"prefix"`    // Prefijo de las claves (carpeta dentro de LocalDir o del bucket)
+
+	// Con destino s3 se reutilizan el endpoint y las credenciales de BACKUP_S3_*; el bucket puede ser otro
+	Bucket string `mapstructure:"bucket"`
+}
+
+// loadExportConfig lee la configuración de las exportaciones Parquet
+func loadExportConfig() ExportConfig {
+	return ExportConfig{
+		Destination: strings.ToLower(getEnvWithDefault("EXPORT_DESTINATION", ExportDestinationLocal)),
+		LocalDir:    getEnvWithDefault("EXPORT_LOCAL_DIR", "./exports"),
+		Prefix:      strings.Trim(getEnvWithDefault("EXPORT_PREFIX", "parquet"), "/"),
+		Bucket:      getEnvWithDefault("EXPORT_S3_BUCKET", getEnvWithDefault("BACKUP_S3_BUCKET", "")),
+	}
+}
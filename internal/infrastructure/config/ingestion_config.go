@@ -0,0 +1,71 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Acciones de las reglas de validación de la ingesta
+const (
+	RuleActionOff        = "off"
+	RuleActionReject     = "reject"
+	RuleActionQuarantine = "quarantine"
+	RuleActionCorrect    = "correct"
+)
+
+// IngestionConfig configura las reglas de validación que se aplican a los ratings antes de ingerirlos
+type IngestionConfig struct {
+	RulesEnabled    bool              `mapstructure:"rules_enabled"`
+	Rules           map[string]string `mapstructure:"rules"`            // Regla -> acción; las reglas ausentes usan su acción por defecto
+	FutureTolerance time.Duration     `mapstructure:"future_tolerance"` // Margen antes de considerar futuro un event_time
+	MinEventDate    string            `mapstructure:"min_event_date"`   // event_time más antiguo aceptado (YYYY-MM-DD)
+	DuplicateWindow time.Duration     `mapstructure:"duplicate_window"` // Margen de event_time con el que un cambio de rating re-emitido es duplicado
+}
+
+// loadIngestionConfig lee las reglas de ingesta; INGESTION_RULES tiene el formato regla:acción,regla:acción
+func loadIngestionConfig() IngestionConfig {
+	rules := make(map[string]string)
+	for name, action := range getEnvAsStringMap("INGESTION_RULES") {
+		rules[strings.ToLower(name)] = strings.ToLower(action)
+	}
+
+	return IngestionConfig{
+		RulesEnabled:    getEnvAsBoolWithDefault("INGESTION_RULES_ENABLED", true),
+		Rules:           rules,
+		FutureTolerance: getEnvAsDurationWithDefault("INGESTION_FUTURE_TOLERANCE", "24h"),
+		MinEventDate:    getEnvWithDefault("INGESTION_MIN_EVENT_DATE", "2000-01-01"),
+		DuplicateWindow: getEnvAsDurationWithDefault("INGESTION_DUPLICATE_WINDOW", "5m"),
+	}
+}
+
+// Validate checks the rule actions, the tolerance and the minimum event date.
+// The rule names are checked by the rules engine, which knows the available rules
+func (i IngestionConfig) Validate() error {
+	for name, action := range i.Rules {
+		switch action {
+		case RuleActionOff, RuleActionReject, RuleActionQuarantine, RuleActionCorrect:
+		default:
+			return fmt.Errorf("INGESTION_RULES: invalid action %q for rule %s: must be off, reject, quarantine or correct", action, name)
+		}
+	}
+	if i.FutureTolerance < 0 {
+		return fmt.Errorf("INGESTION_FUTURE_TOLERANCE cannot be negative")
+	}
+	if i.DuplicateWindow < 0 {
+		return fmt.Errorf("INGESTION_DUPLICATE_WINDOW cannot be negative")
+	}
+	if _, err := i.MinEventTime(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// MinEventTime parses MinEventDate as a UTC date
+func (i IngestionConfig) MinEventTime() (time.Time, error) {
+	minTime, err := time.Parse("2006-01-02", i.MinEventDate)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("INGESTION_MIN_EVENT_DATE must be a YYYY-MM-DD date, got %q", i.MinEventDate)
+	}
+	return minTime, nil
+}
@@ -68,7 +68,7 @@ func DefaultServerLoggingConfig() ServerLoggingConfig {
 			LogSlowRequests:     true,
 			SlowThreshold:       1 * time.Second,
 			SkipPaths:           []string{"/metrics", "/favicon.ico"},
-			SkipSuccessfulPaths: []string{"/health"},
+			SkipSuccessfulPaths: []string{"/health", "/readyz"},
 		},
 
 		Handlers: HandlersLogConfig{
@@ -120,7 +120,7 @@ func ProductionServerLoggingConfig() ServerLoggingConfig {
 	config := DefaultServerLoggingConfig()
 	config.Level = "warn"
 	config.Middleware.LogHeaders = false
-	config.Middleware.SkipSuccessfulPaths = []string{"/health", "/metrics"}
+	config.Middleware.SkipSuccessfulPaths = []string{"/health", "/readyz", "/metrics"}
 
 	return config
 }
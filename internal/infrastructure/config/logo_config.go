@@ -0,0 +1,51 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// LogoConfig configura el proxy de logos de companies: descarga, redimensiona y guarda en disco los logos
+type LogoConfig struct {
+	CacheDir     string        `mapstructure:"cache_dir"`     // Directorio de los logos redimensionados
+	DefaultSize  int           `mapstructure:"default_size"`  // Lado máximo en píxeles si no se indica ?size=
+	MaxSize      int           `mapstructure:"max_size"`      // Lado máximo admitido en ?size=
+	MaxBytes     int           `mapstructure:"max_bytes"`     // Tamaño máximo de la imagen descargada
+	FetchTimeout time.Duration `mapstructure:"fetch_timeout"` // Tiempo máximo de la descarga del logo original
+	MaxAge       time.Duration `mapstructure:"max_age"`       // max-age de Cache-Control de las respuestas
+}
+
+// loadLogoConfig lee la configuración del proxy de logos
+func loadLogoConfig() LogoConfig {
+	return LogoConfig{
+		CacheDir:     getEnvWithDefault("LOGO_CACHE_DIR", "./cache/logos"),
+		DefaultSize:  getEnvAsIntWithDefault("LOGO_DEFAULT_SIZE", 128),
+		MaxSize:      getEnvAsIntWithDefault("LOGO_MAX_SIZE", 512),
+		MaxBytes:     getEnvAsIntWithDefault("LOGO_MAX_BYTES", 2<<20),
+		FetchTimeout: getEnvAsDurationWithDefault("LOGO_FETCH_TIMEOUT", "10s"),
+		MaxAge:       getEnvAsDurationWithDefault("LOGO_CACHE_MAX_AGE", "720h"),
+	}
+}
+
+// Validate checks the sizes and durations of the logo proxy
+func (l LogoConfig) Validate() error {
+	if l.CacheDir == "" {
+		return fmt.Errorf("LOGO_CACHE_DIR is required")
+	}
+	if l.MaxSize < 16 {
+		return fmt.Errorf("LOGO_MAX_SIZE must be at least 16")
+	}
+	if l.DefaultSize < 16 || l.DefaultSize > l.MaxSize {
+		return fmt.Errorf("LOGO_DEFAULT_SIZE must be between 16 and LOGO_MAX_SIZE (%d)", l.MaxSize)
+	}
+	if l.MaxBytes <= 0 {
+		return fmt.Errorf("LOGO_MAX_BYTES must be positive")
+	}
+	if l.FetchTimeout <= 0 {
+		return fmt.Errorf("LOGO_FETCH_TIMEOUT must be positive")
+	}
+	if l.MaxAge < 0 {
+		return fmt.Errorf("LOGO_CACHE_MAX_AGE cannot be negative")
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Planes de los tenants; cada tenant tiene uno (free por defecto)
+const (
+	PlanFree = "free"
+	PlanPro  = "pro"
+)
+
+// PlanConfig holds the daily quotas of a plan tier (0 = unlimited)
+type PlanConfig struct {
+	DailyRequests  int `mapstructure:"daily_requests" validate:"min=0"`  // Peticiones a /api/v1 por día UTC
+	DailyRefreshes int `mapstructure:"daily_refreshes" validate:"min=0"` // Símbolos obtenidos del proveedor por día UTC
+}
+
+// PlansConfig configura las cuotas diarias de cada plan; solo se aplican con multi-tenancy
+type PlansConfig struct {
+	Enabled bool                  `mapstructure:"enabled"`
+	Default string                `mapstructure:"default"` // Plan de los tenants con un plan desconocido
+	Tiers   map[string]PlanConfig `mapstructure:"tiers"`
+}
+
+// loadPlansConfig lee los planes free y pro
+func loadPlansConfig() PlansConfig {
+	return PlansConfig{
+		Enabled: getEnvAsBoolWithDefault("PLANS_ENABLED", true),
+		Default: strings.ToLower(getEnvWithDefault("PLANS_DEFAULT", PlanFree)),
+		Tiers: map[string]PlanConfig{
+			PlanFree: {
+				DailyRequests:  getEnvAsIntWithDefault("PLAN_FREE_DAILY_REQUESTS", 1000),
+				DailyRefreshes: getEnvAsIntWithDefault("PLAN_FREE_DAILY_REFRESHES", 50),
+			},
+			PlanPro: {
+				DailyRequests:  getEnvAsIntWithDefault("PLAN_PRO_DAILY_REQUESTS", 100000),
+				DailyRefreshes: getEnvAsIntWithDefault("PLAN_PRO_DAILY_REFRESHES", 5000),
+			},
+		},
+	}
+}
+
+// Validate checks that the default plan exists and that no quota is negative
+func (p PlansConfig) Validate() error {
+	if _, ok := p.Tiers[p.Default]; !ok {
+		return fmt.Errorf("PLANS_DEFAULT must be free or pro, got %q", p.Default)
+	}
+	for name, plan := range p.Tiers {
+		if plan.DailyRequests < 0 || plan.DailyRefreshes < 0 {
+			return fmt.Errorf("plan %s: daily quotas cannot be negative", name)
+		}
+	}
+	return nil
+}
+
+// IsPlan reports whether name is one of the plan tiers
+func (p PlansConfig) IsPlan(name string) bool {
+	_, ok := p.Tiers[name]
+	return ok
+}
@@ -0,0 +1,190 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// Secciones de configuración que se pueden recargar sin reiniciar el servidor
+const (
+	ReloadSectionLogLevel     = "log_level"
+	ReloadSectionRateLimit    = "rate_limit"
+	ReloadSectionCacheTTL     = "cache_ttl"
+	ReloadSectionProviderKeys = "provider_keys"
+)
+
+// ReloadFunc aplica la configuración recargada en un componente.
+// Recibe la configuración anterior para que cada componente decida si algo le afecta.
+type ReloadFunc func(previous, current *Config) error
+
+// ReloadResult resume el resultado de una recarga de configuración
+type ReloadResult struct {
+	Changed    []string          `json:"changed"`          // Secciones que cambiaron
+	Applied    []string          `json:"applied"`          // Componentes que aplicaron la nueva configuración
+	Failed     map[string]string `json:"failed,omitempty"` // Componente -> error
+	ReloadedAt time.Time         `json:"reloaded_at"`
+}
+
+// Watcher mantiene la configuración vigente y la recarga en caliente (SIGHUP o endpoint de administración).
+// Solo se recarga la configuración no estructural: nivel de log, rate limits, TTLs de cache y API keys de
+// los proveedores. Puertos, base de datos, Redis, etc. siguen necesitando un reinicio.
+type Watcher struct {
+	mu          sync.Mutex
+	current     *Config
+	load        func(current *Config) (*Config, error)
+	subscribers []reloadSubscriber
+}
+
+type reloadSubscriber struct {
+	name  string
+	apply ReloadFunc
+}
+
+// NewWatcher crea un watcher a partir de la configuración cargada al arrancar
+func NewWatcher(cfg *Config) *Watcher {
+	return &Watcher{
+		current: cfg,
+		load:    loadReloadable,
+	}
+}
+
+// Subscribe registra un componente que se actualiza en cada recarga
+func (w *Watcher) Subscribe(name string, apply ReloadFunc) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.subscribers = append(w.subscribers, reloadSubscriber{name: name, apply: apply})
+}
+
+// Current returns the configuration currently in effect
+func (w *Watcher) Current() *Config {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.current
+}
+
+// Reload vuelve a leer la configuración y la aplica en los componentes suscritos.
+// Si la nueva configuración no es válida no se aplica nada y se mantiene la vigente.
+func (w *Watcher) Reload() (*ReloadResult, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	next, err := w.load(w.current)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ReloadResult{
+		Changed:    changedSections(w.current, next),
+		Applied:    make([]string, 0, len(w.subscribers)),
+		Failed:     make(map[string]string),
+		ReloadedAt: time.Now(),
+	}
+
+	for _, subscriber := range w.subscribers {
+		if err := subscriber.apply(w.current, next); err != nil {
+			result.Failed[subscriber.name] = err.Error()
+			continue
+		}
+		result.Applied = append(result.Applied, subscriber.name)
+	}
+
+	w.current = next
+	return result, nil
+}
+
+// loadReloadable relee el .env y construye una copia de la configuración vigente con las secciones recargables actualizadas
+func loadReloadable(current *Config) (*Config, error) {
+	if err := reloadEnvFile(); err != nil {
+		return nil, err
+	}
+	if err := resolveSecretReferences(current.Secrets); err != nil {
+		return nil, fmt.Errorf("failed to resolve secrets: %w", err)
+	}
+
+	next := *current
+	next.Logging.Level = getEnvWithDefault("LOG_LEVEL", current.Logging.Level)
+
+	// Activar o desactivar el rate limiting cambia los middlewares instalados: es estructural
+	rateLimit := loadRateLimitConfig()
+	rateLimit.Enabled = current.RateLimit.Enabled
+	next.RateLimit = rateLimit
+
+	next.Cache.TTL = loadCacheConfig().TTL
+
+	external := loadExternalConfig()
+	next.External.Primary.Key = external.Primary.Key
+	next.External.Primary.Keys = external.Primary.Keys
+	next.External.Primary.SecretKey = external.Primary.SecretKey
+	next.External.Secondary.Key = external.Secondary.Key
+	next.External.Secondary.Keys = external.Secondary.Keys
+	next.External.KeyStrategy = external.KeyStrategy
+
+	if err := validate.Struct(&next); err != nil {
+		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	return &next, nil
+}
+
+// reloadEnvFile vuelve a leer el .env usado al arrancar. Las variables definidas por el proceso
+// (p.ej. por Kubernetes) tienen prioridad igual que en Load, y una variable vacía no borra el valor anterior.
+func reloadEnvFile() error {
+	if loadedEnvPath == "" {
+		return nil
+	}
+
+	values, err := godotenv.Read(loadedEnvPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", loadedEnvPath, err)
+	}
+
+	for key, value := range values {
+		if _, fromProcess := processEnvKeys[key]; fromProcess || value == "" {
+			continue
+		}
+		if err := os.Setenv(key, value); err != nil {
+			return fmt.Errorf("failed to set %s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// changedSections compara las secciones recargables de dos configuraciones
+func changedSections(previous, current *Config) []string {
+	changed := make([]string, 0)
+
+	if previous.Logging.Level != current.Logging.Level {
+		changed = append(changed, ReloadSectionLogLevel)
+	}
+	if !reflect.DeepEqual(previous.RateLimit, current.RateLimit) {
+		changed = append(changed, ReloadSectionRateLimit)
+	}
+	if previous.Cache.TTL != current.Cache.TTL {
+		changed = append(changed, ReloadSectionCacheTTL)
+	}
+	if !reflect.DeepEqual(previous.External, current.External) {
+		changed = append(changed, ReloadSectionProviderKeys)
+	}
+
+	sort.Strings(changed)
+	return changed
+}
+
+// HasChanged indica si una sección cambió en la recarga
+func (r *ReloadResult) HasChanged(section string) bool {
+	for _, changed := range r.Changed {
+		if changed == section {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/secrets"
+)
+
+// Proveedores de secretos soportados
+const (
+	SecretsProviderNone  = "none"
+	SecretsProviderVault = "vault"
+	SecretsProviderAWS   = "aws"
+)
+
+// SecretsConfig configura la resolución de referencias secret:<nombre>#<campo> en las variables de entorno
+type SecretsConfig struct {
+	Provider         string        `mapstructure:"provider" validate:"oneof=none vault aws"`
+	CacheTTL         time.Duration `mapstructure:"cache_ttl"`         // Reutilización de un secreto entre recargas
+	RotationInterval time.Duration `mapstructure:"rotation_interval"` // 0 = sin rotación periódica de las API keys
+
+	// HashiCorp Vault (KV v2)
+	VaultAddress   string `mapstructure:"vault_address"`
+	VaultToken     string `mapstructure:"vault_token"`
+	VaultMount     string `mapstructure:"vault_mount"`
+	VaultNamespace string `mapstructure:"vault_namespace"`
+
+	// AWS Secrets Manager (credenciales por la cadena estándar del SDK)
+	AWSRegion string `mapstructure:"aws_region"`
+}
+
+// IsEnabled indica si hay un gestor de secretos configurado
+func (s SecretsConfig) IsEnabled() bool {
+	return s.Provider != "" && s.Provider != SecretsProviderNone
+}
+
+// Estado de la resolución de secretos compartido entre Load y las recargas en caliente
+var (
+	secretsMu       sync.RWMutex
+	secretResolver  *secrets.Resolver
+	resolvedSecrets = make(map[string]string) // Variable de entorno -> valor resuelto
+)
+
+// secretResolveTimeout limita el tiempo de resolución de todas las referencias
+const secretResolveTimeout = 30 * time.Second
+
+// loadSecretsConfig lee la configuración del gestor de secretos. Sus propias credenciales
+// (token de Vault, credenciales de AWS) no pueden ser referencias y vienen del entorno.
+func loadSecretsConfig() SecretsConfig {
+	return SecretsConfig{
+		Provider:         strings.ToLower(getEnvWithDefault("SECRETS_PROVIDER", SecretsProviderNone)),
+		CacheTTL:         getEnvAsDurationWithDefault("SECRETS_CACHE_TTL", "5m"),
+		RotationInterval: getEnvAsDurationWithDefault("SECRETS_ROTATION_INTERVAL", "0s"),
+		VaultAddress:     os.Getenv("VAULT_ADDR"),
+		VaultToken:       os.Getenv("VAULT_TOKEN"),
+		VaultMount:       getEnvWithDefault("VAULT_KV_MOUNT", "secret"),
+		VaultNamespace:   os.Getenv("VAULT_NAMESPACE"),
+		AWSRegion:        os.Getenv("AWS_REGION"),
+	}
+}
+
+// newSecretsProvider crea el proveedor configurado
+func newSecretsProvider(cfg SecretsConfig) (secrets.Provider, error) {
+	switch cfg.Provider {
+	case SecretsProviderVault:
+		return secrets.NewVaultProvider(secrets.VaultConfig{
+			Address:   cfg.VaultAddress,
+			Token:     cfg.VaultToken,
+			Mount:     cfg.VaultMount,
+			Namespace: cfg.VaultNamespace,
+		})
+	case SecretsProviderAWS:
+		return secrets.NewAWSProvider(context.Background(), secrets.AWSConfig{
+			Region: cfg.AWSRegion,
+		})
+	default:
+		return nil, fmt.Errorf("unknown secrets provider %q", cfg.Provider)
+	}
+}
+
+// resolveSecretReferences resuelve todas las variables de entorno con valor secret:... y guarda
+// los valores para los helpers de env.go. Sin gestor configurado una referencia es un error.
+func resolveSecretReferences(cfg SecretsConfig) error {
+	references := make(map[string]secrets.Reference)
+	for _, entry := range os.Environ() {
+		key, value, found := strings.Cut(entry, "=")
+		if !found {
+			continue
+		}
+		if ref, ok := secrets.ParseReference(value); ok {
+			references[key] = ref
+		}
+	}
+
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+
+	if len(references) == 0 {
+		resolvedSecrets = make(map[string]string)
+		return nil
+	}
+
+	if !cfg.IsEnabled() {
+		return fmt.Errorf("environment references secrets but SECRETS_PROVIDER is not configured")
+	}
+
+	if secretResolver == nil {
+		provider, err := newSecretsProvider(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to create secrets provider: %w", err)
+		}
+		secretResolver = secrets.NewResolver(provider, cfg.CacheTTL)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), secretResolveTimeout)
+	defer cancel()
+
+	resolved := make(map[string]string, len(references))
+	for key, ref := range references {
+		value, err := secretResolver.Resolve(ctx, ref)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s (%s): %w", key, ref, err)
+		}
+		resolved[key] = value
+	}
+
+	resolvedSecrets = resolved
+	return nil
+}
+
+// ExpireSecrets fuerza a releer los secretos en la siguiente recarga (rotación)
+func ExpireSecrets() {
+	secretsMu.RLock()
+	defer secretsMu.RUnlock()
+
+	if secretResolver != nil {
+		secretResolver.Expire()
+	}
+}
+
+// getenv devuelve la variable de entorno con las referencias a secretos ya resueltas
+func getenv(key string) string {
+	secretsMu.RLock()
+	value, resolved := resolvedSecrets[key]
+	secretsMu.RUnlock()
+
+	if resolved {
+		return value
+	}
+	return os.Getenv(key)
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"time"
 )
 
@@ -15,6 +16,42 @@ type ServerConfig struct {
 	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout" validate:"required"`
 	MaxHeaderBytes  int           `mapstructure:"max_header_bytes" validate:"min=1"`
 	TrustedProxies  []string      `mapstructure:"trusted_proxies"`
+	TLS             TLSConfig     `mapstructure:"tls"`
+}
+
+// TLSConfig configura HTTPS en el propio servidor: certificado en disco o autocert (Let's Encrypt)
+type TLSConfig struct {
+	Enabled    bool   `mapstructure:"enabled"`
+	CertFile   string `mapstructure:"cert_file"`
+	KeyFile    string `mapstructure:"key_file"`
+	MinVersion string `mapstructure:"min_version" validate:"oneof=1.2 1.3"`
+
+	// Autocert: certificados de Let's Encrypt para los dominios indicados (requiere el puerto 80 accesible)
+	Autocert         bool     `mapstructure:"autocert"`
+	AutocertDomains  []string `mapstructure:"autocert_domains"`
+	AutocertEmail    string   `mapstructure:"autocert_email"`
+	AutocertCacheDir string   `mapstructure:"autocert_cache_dir"`
+
+	// Listener HTTP que redirige a HTTPS (y atiende los challenges de autocert)
+	RedirectHTTP bool   `mapstructure:"redirect_http"`
+	HTTPPort     string `mapstructure:"http_port"`
+}
+
+// Validate comprueba que la configuración TLS sea coherente
+func (t *TLSConfig) Validate() error {
+	if !t.Enabled {
+		return nil
+	}
+	if t.Autocert {
+		if len(t.AutocertDomains) == 0 {
+			return fmt.Errorf("SERVER_TLS_AUTOCERT_DOMAINS is required when autocert is enabled")
+		}
+		return nil
+	}
+	if t.CertFile == "" || t.KeyFile == "" {
+		return fmt.Errorf("SERVER_TLS_CERT_FILE and SERVER_TLS_KEY_FILE are required when TLS is enabled without autocert")
+	}
+	return nil
 }
 
 // RESTAPIConfig holds REST API-specific configuration
@@ -25,6 +62,14 @@ type RESTAPIConfig struct {
 	EnableHealthChecks bool   `mapstructure:"enable_health_checks"`
 	EnableMetrics      bool   `mapstructure:"enable_metrics"`
 	EnableProfiling    bool   `mapstructure:"enable_profiling"`
+
+	// Formato de las respuestas de error: "problem" (RFC 7807) o "legacy" (envelope anterior)
+	ErrorFormat        string `mapstructure:"error_format" validate:"oneof=problem legacy"`
+	ProblemTypeBaseURI string `mapstructure:"problem_type_base_uri"`
+
+	// Validación de peticiones contra la especificación OpenAPI generada (swag init)
+	ValidateRequests bool   `mapstructure:"validate_requests"`
+	OpenAPISpecPath  string `mapstructure:"openapi_spec_path"`
 }
 
 // RateLimitConfig holds rate limiting configuration
@@ -32,7 +77,40 @@ type RateLimitConfig struct {
 	Enabled     bool          `mapstructure:"enabled"`
 	RequestsPer time.Duration `mapstructure:"requests_per" validate:"required"`
 	Limit       int           `mapstructure:"limit" validate:"min=1"`
-	KeyFunc     string        `mapstructure:"key_func" validate:"oneof=ip user_id"`
+	KeyFunc     string        `mapstructure:"key_func" validate:"oneof=ip user_id api_key"`
+
+	// Token bucket (Redis via cache service): capacidad por grupo de rutas y por API key
+	GroupLimits  map[string]int `mapstructure:"group_limits"`   // read, write, admin, search -> requests por ventana
+	APIKeyHeader string         `mapstructure:"api_key_header"` // Header que identifica al cliente
+	APIKeyLimits map[string]int `mapstructure:"api_key_limits"` // API key -> requests por ventana
+}
+
+// Grupos de rutas con límite propio
+const (
+	RateLimitGroupDefault = "default"
+	RateLimitGroupRead    = "read"
+	RateLimitGroupWrite   = "write"
+	RateLimitGroupAdmin   = "admin"
+	RateLimitGroupSearch  = "search"
+)
+
+// LimitFor returns the bucket capacity for a route group and API key.
+// A per-key limit wins over the group limit, which wins over the global limit.
+func (r *RateLimitConfig) LimitFor(group, apiKey string) int {
+	if apiKey != "" {
+		if limit, ok := r.APIKeyLimits[apiKey]; ok && limit > 0 {
+			return limit
+		}
+	}
+	if limit, ok := r.GroupLimits[group]; ok && limit > 0 {
+		return limit
+	}
+	return r.Limit
+}
+
+// HasGroupLimit checks if a route group has its own limit configured
+func (r *RateLimitConfig) HasGroupLimit(group string) bool {
+	return r.GroupLimits[group] > 0
 }
 
 // GetServerAddress returns the full server address
@@ -40,6 +118,11 @@ func (s *ServerConfig) GetServerAddress() string {
 	return s.Host + ":" + s.Port
 }
 
+// GetRedirectAddress returns the address of the HTTP listener that redirects to HTTPS
+func (s *ServerConfig) GetRedirectAddress() string {
+	return s.Host + ":" + s.TLS.HTTPPort
+}
+
 // IsDebugMode returns true if server is in debug mode
 func (s *ServerConfig) IsDebugMode() bool {
 	return s.Mode == "debug"
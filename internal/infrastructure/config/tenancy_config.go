@@ -0,0 +1,82 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AnonymousRoleNone exige credenciales en todas las rutas protegidas por rol
+const AnonymousRoleNone = "none"
+
+// TenancyConfig configura la identificación de tenants por API key o JWT
+type TenancyConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+
+	// Header con la API key del tenant (también se acepta Authorization: Bearer <api key>)
+	APIKeyHeader string `mapstructure:"api_key_header"`
+
+	// JWT HS256 firmados con JWT_SECRET por un proveedor de identidad externo (claims sub, tid y role)
+	JWTEnabled bool   `mapstructure:"jwt_enabled"`
+	JWTIssuer  string `mapstructure:"jwt_issuer"` // Vacío acepta cualquier emisor
+
+	// Control de acceso por roles (viewer, editor, admin) en cada grupo de rutas
+	RBACEnabled     bool              `mapstructure:"rbac_enabled"`
+	AnonymousRole   string            `mapstructure:"anonymous_role"`   // Rol de las peticiones sin credenciales ("none" = ninguno)
+	RolePermissions map[string]string `mapstructure:"role_permissions"` // Grupo de rutas (read, search, write) → rol mínimo
+
+	// Clave de administración sin tenant, la única que llega a las rutas admin (admite secret:<name>#<field>)
+	AdminAPIKey string `mapstructure:"admin_api_key"`
+}
+
+// defaultRolePermissions: las lecturas quedan abiertas a viewers y las escrituras exigen editor. El grupo admin no
+// tiene rol: exige siempre la clave de administración (TENANCY_ADMIN_API_KEY)
+var defaultRolePermissions = map[string]string{
+	RateLimitGroupRead:   "viewer",
+	RateLimitGroupSearch: "viewer",
+	RateLimitGroupWrite:  "editor",
+}
+
+// loadTenancyConfig lee la configuración de multi-tenancy
+func loadTenancyConfig() TenancyConfig {
+	permissions := make(map[string]string, len(defaultRolePermissions))
+	for group, role := range defaultRolePermissions {
+		permissions[group] = role
+	}
+	for group, role := range getEnvAsStringMap("TENANCY_ROLE_PERMISSIONS") {
+		permissions[group] = strings.ToLower(role)
+	}
+
+	return TenancyConfig{
+		Enabled:         getEnvAsBoolWithDefault("TENANCY_ENABLED", false),
+		APIKeyHeader:    getEnvWithDefault("TENANCY_API_KEY_HEADER", "X-API-Key"),
+		JWTEnabled:      getEnvAsBoolWithDefault("TENANCY_JWT_ENABLED", true),
+		JWTIssuer:       getEnvWithDefault("TENANCY_JWT_ISSUER", ""),
+		RBACEnabled:     getEnvAsBoolWithDefault("TENANCY_RBAC_ENABLED", true),
+		AnonymousRole:   strings.ToLower(getEnvWithDefault("TENANCY_ANONYMOUS_ROLE", "viewer")),
+		RolePermissions: permissions,
+		AdminAPIKey:     getEnvWithDefault("TENANCY_ADMIN_API_KEY", ""),
+	}
+}
+
+// Validate checks the roles of the permission map and of anonymous requests
+func (t TenancyConfig) Validate() error {
+	isRole := func(role string) bool {
+		return role == "viewer" || role == "editor" || role == "admin"
+	}
+
+	if t.AnonymousRole != AnonymousRoleNone && !isRole(t.AnonymousRole) {
+		return fmt.Errorf("TENANCY_ANONYMOUS_ROLE must be none, viewer, editor or admin, got %q", t.AnonymousRole)
+	}
+	if _, ok := t.RolePermissions[RateLimitGroupAdmin]; ok {
+		return fmt.Errorf("TENANCY_ROLE_PERMISSIONS cannot set the admin route group, which always requires TENANCY_ADMIN_API_KEY")
+	}
+	for group, role := range t.RolePermissions {
+		if !isRole(role) {
+			return fmt.Errorf("TENANCY_ROLE_PERMISSIONS: unknown role %q for route group %q", role, group)
+		}
+	}
+	if t.AdminAPIKey != "" && len(t.AdminAPIKey) < 16 {
+		return fmt.Errorf("TENANCY_ADMIN_API_KEY must be at least 16 characters long")
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"time"
+)
+
+// WorkerConfig holds configuration for background workloads (scheduler, job workers)
+type WorkerConfig struct {
+	PopulationInterval    time.Duration `mapstructure:"population_interval" validate:"min=0"` // 0 disables the scheduler
+	PopulateOnStart       bool          `mapstructure:"populate_on_start"`
+	PopulationIncremental bool          `mapstructure:"population_incremental"` // Scheduled runs only ingest new ratings
+	ShutdownTimeout       time.Duration `mapstructure:"shutdown_timeout" validate:"required"`
+
+	// Enriquecimiento de companies desde sus perfiles (encola un job company_enrichment)
+	EnrichmentInterval time.Duration `mapstructure:"enrichment_interval" validate:"min=0"` // 0 disables scheduled enrichment
+
+	// Refresco de las vistas materializadas de analíticas (encola un job analytics_refresh)
+	AnalyticsRefresh time.Duration `mapstructure:"analytics_refresh_interval" validate:"min=0"` // 0 disables scheduled refreshes
+
+	// Detección de picos de ratings y de volumen (encola un job anomaly_detection)
+	AnomalyDetection time.Duration `mapstructure:"anomaly_detection_interval" validate:"min=0"` // 0 disables scheduled detection
+
+	// Refresco de cotizaciones, empezando por los símbolos más vistos (encola un job market_data_refresh)
+	MarketDataRefresh time.Duration `mapstructure:"market_data_refresh_interval" validate:"min=0"` // 0 disables scheduled refreshes
+	RefreshTrending   int           `mapstructure:"market_data_refresh_trending" validate:"min=0"` // 0 refreshes every active company
+
+	// Validación de integridad completa guardada en el historial (encola un job integrity_check)
+	IntegrityCheck time.Duration `mapstructure:"integrity_check_interval" validate:"min=0"` // 0 disables scheduled checks
+
+	// Volcado de los contadores de uso de la API de Redis a la tabla api_usage_hourly (encola un job usage_flush)
+	UsageFlush time.Duration `mapstructure:"usage_flush_interval" validate:"min=0"` // 0 disables scheduled flushes
+
+	// Recálculo de los índices personalizados que no están al día (encola un job benchmark_refresh)
+	BenchmarkRefresh time.Duration `mapstructure:"benchmark_refresh_interval" validate:"min=0"` // 0 disables scheduled refreshes
+
+	// Procesado de los ratings guardados sin procesar (encola un job rating_processing)
+	RatingProcessing            time.Duration `mapstructure:"rating_processing_interval" validate:"min=0"`         // 0 disables scheduled processing
+	RatingProcessingBatchSize   int           `mapstructure:"rating_processing_batch_size" validate:"min=0"`       // 0 uses the default batch size
+	RatingProcessingConcurrency int           `mapstructure:"rating_processing_concurrency" validate:"min=0"`      // 0 uses the default concurrency
+	RatingBacklogThreshold      int           `mapstructure:"rating_backlog_threshold" validate:"min=0"`           // 0 disables the backlog alert
+	RatingBacklogAlertCooldown  time.Duration `mapstructure:"rating_backlog_alert_cooldown" validate:"min=0"`      // Minimum time between two backlog alerts
+	RatingBacklogWebhookURL     string        `mapstructure:"rating_backlog_webhook_url" validate:"omitempty,url"` // Empty only logs the backlog alerts
+
+	// Job queue workers
+	JobConcurrency  int           `mapstructure:"job_concurrency" validate:"min=0"` // 0 disables job workers
+	JobPollInterval time.Duration `mapstructure:"job_poll_interval" validate:"required"`
+	JobStaleAfter   time.Duration `mapstructure:"job_stale_after" validate:"required"`
+	JobRetryBackoff time.Duration `mapstructure:"job_retry_backoff" validate:"required"`
+}
+
+// IsSchedulerEnabled returns true if periodic population runs are enabled
+func (w *WorkerConfig) IsSchedulerEnabled() bool {
+	return w.PopulationInterval > 0
+}
+
+// IsEnrichmentEnabled returns true if company enrichment jobs are scheduled periodically
+func (w *WorkerConfig) IsEnrichmentEnabled() bool {
+	return w.EnrichmentInterval > 0
+}
+
+// IsAnalyticsRefreshEnabled returns true if the analytics views are refreshed periodically
+func (w *WorkerConfig) IsAnalyticsRefreshEnabled() bool {
+	return w.AnalyticsRefresh > 0
+}
+
+// IsAnomalyDetectionEnabled returns true if anomaly detection runs periodically
+func (w *WorkerConfig) IsAnomalyDetectionEnabled() bool {
+	return w.AnomalyDetection > 0
+}
+
+// IsMarketDataRefreshEnabled returns true if quotes are refreshed periodically
+func (w *WorkerConfig) IsMarketDataRefreshEnabled() bool {
+	return w.MarketDataRefresh > 0
+}
+
+// IsIntegrityCheckEnabled returns true if the full integrity validation runs periodically
+func (w *WorkerConfig) IsIntegrityCheckEnabled() bool {
+	return w.IntegrityCheck > 0
+}
+
+// IsUsageFlushEnabled returns true if the API usage counters are flushed periodically
+func (w *WorkerConfig) IsUsageFlushEnabled() bool {
+	return w.UsageFlush > 0
+}
+
+// IsBenchmarkRefreshEnabled returns true if the custom benchmarks are recomputed periodically
+func (w *WorkerConfig) IsBenchmarkRefreshEnabled() bool {
+	return w.BenchmarkRefresh > 0
+}
+
+// IsRatingProcessingEnabled returns true if the unprocessed ratings are processed periodically
+func (w *WorkerConfig) IsRatingProcessingEnabled() bool {
+	return w.RatingProcessing > 0
+}
+
+// IsJobWorkersEnabled returns true if the job queue workers should run
+func (w *WorkerConfig) IsJobWorkersEnabled() bool {
+	return w.JobConcurrency > 0
+}
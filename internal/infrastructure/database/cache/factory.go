@@ -18,6 +18,27 @@ type fallbackCacheService struct {
 	primary  services.CacheService // Redis cache
 	fallback services.CacheService // Memory cache
 	config   services.CacheConfiguration
+
+	// Propaga las evicciones entre instancias para que la cache en memoria no quede obsoleta
+	broadcaster *invalidationBroadcaster
+}
+
+// broadcast notifies the other instances of an eviction already applied locally
+func (f *fallbackCacheService) broadcast(ctx context.Context, message invalidationMessage) {
+	if f.broadcaster != nil {
+		f.broadcaster.publish(ctx, message)
+	}
+}
+
+// Close stops listening for invalidations and closes the Redis connection
+func (f *fallbackCacheService) Close() error {
+	if f.broadcaster != nil {
+		f.broadcaster.stop()
+	}
+	if closer, ok := f.primary.(interface{ Close() error }); ok {
+		return closer.Close()
+	}
+	return nil
 }
 
 // Company cache operations
@@ -41,6 +62,7 @@ func (f *fallbackCacheService) DeleteCompany(ctx context.Context, ticker string)
 	// Try to delete from both caches to ensure consistency
 	primaryErr := f.primary.DeleteCompany(ctx, ticker)
 	fallbackErr := f.fallback.DeleteCompany(ctx, ticker)
+	f.broadcast(ctx, invalidationMessage{Operation: invalidateCompany, Key: ticker})
 
 	// If primary succeeds, ignore fallback errors (data might not exist there)
 	if primaryErr == nil {
@@ -73,6 +95,7 @@ func (f *fallbackCacheService) DeleteBrokerage(ctx context.Context, name string)
 	// Try to delete from both caches to ensure consistency
 	primaryErr := f.primary.DeleteBrokerage(ctx, name)
 	fallbackErr := f.fallback.DeleteBrokerage(ctx, name)
+	f.broadcast(ctx, invalidationMessage{Operation: invalidateBrokerage, Key: name})
 
 	// If primary succeeds, ignore fallback errors (data might not exist there)
 	if primaryErr == nil {
@@ -106,6 +129,7 @@ func (f *fallbackCacheService) DeleteStockRating(ctx context.Context, companyID,
 	// Try to delete from both caches to ensure consistency
 	primaryErr := f.primary.DeleteStockRating(ctx, companyID, brokerageID)
 	fallbackErr := f.fallback.DeleteStockRating(ctx, companyID, brokerageID)
+	f.broadcast(ctx, invalidationMessage{Operation: invalidateStockRating, CompanyID: companyID, BrokerageID: brokerageID})
 
 	// If primary succeeds, ignore fallback errors (data might not exist there)
 	if primaryErr == nil {
@@ -155,6 +179,7 @@ func (f *fallbackCacheService) Clear(ctx context.Context) error {
 	// Try to clear both caches to ensure consistency
 	primaryErr := f.primary.Clear(ctx)
 	fallbackErr := f.fallback.Clear(ctx)
+	f.broadcast(ctx, invalidationMessage{Operation: invalidateAll})
 
 	// If primary succeeds, ignore fallback errors
 	if primaryErr == nil {
@@ -170,6 +195,7 @@ func (f *fallbackCacheService) ClearCompanies(ctx context.Context) error {
 	// Try to clear both caches to ensure consistency
 	primaryErr := f.primary.ClearCompanies(ctx)
 	fallbackErr := f.fallback.ClearCompanies(ctx)
+	f.broadcast(ctx, invalidationMessage{Operation: invalidateAllCompanies})
 
 	// If primary succeeds, ignore fallback errors
 	if primaryErr == nil {
@@ -185,6 +211,7 @@ func (f *fallbackCacheService) ClearBrokerages(ctx context.Context) error {
 	// Try to clear both caches to ensure consistency
 	primaryErr := f.primary.ClearBrokerages(ctx)
 	fallbackErr := f.fallback.ClearBrokerages(ctx)
+	f.broadcast(ctx, invalidationMessage{Operation: invalidateAllBrokerages})
 
 	// If primary succeeds, ignore fallback errors
 	if primaryErr == nil {
@@ -213,6 +240,44 @@ func (f *fallbackCacheService) Ping(ctx context.Context) error {
 	return nil
 }
 
+// PingPrimary checks only the primary cache, without falling back
+func (f *fallbackCacheService) PingPrimary(ctx context.Context) error {
+	return f.primary.Ping(ctx)
+}
+
+// Generic value operations
+func (f *fallbackCacheService) Get(ctx context.Context, key string) ([]byte, error) {
+	if data, err := f.primary.Get(ctx, key); err == nil {
+		return data, nil
+	}
+	log.Printf("⚠️  Primary cache failed, using fallback for Get(%s)", key)
+	return f.fallback.Get(ctx, key)
+}
+
+func (f *fallbackCacheService) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := f.primary.Set(ctx, key, value, ttl); err != nil {
+		log.Printf("⚠️  Primary cache failed, using fallback for Set(%s): %v", key, err)
+		return f.fallback.Set(ctx, key, value, ttl)
+	}
+	return nil
+}
+
+func (f *fallbackCacheService) DeleteByPrefix(ctx context.Context, prefix string) error {
+	// Try to delete from both caches to ensure consistency
+	primaryErr := f.primary.DeleteByPrefix(ctx, prefix)
+	fallbackErr := f.fallback.DeleteByPrefix(ctx, prefix)
+	f.broadcast(ctx, invalidationMessage{Operation: invalidatePrefix, Key: prefix})
+
+	// If primary succeeds, ignore fallback errors
+	if primaryErr == nil {
+		return nil
+	}
+
+	// If primary fails, log and return fallback result
+	log.Printf("⚠️  Primary cache DeleteByPrefix failed for %s: %v", prefix, primaryErr)
+	return fallbackErr
+}
+
 // Key operations
 func (f *fallbackCacheService) Exists(ctx context.Context, key string) (bool, error) {
 	if exists, err := f.primary.Exists(ctx, key); err == nil {
@@ -240,6 +305,41 @@ func (f *fallbackCacheService) Expire(ctx context.Context, key string, ttl time.
 	return fallbackErr
 }
 
+// Rate limiting operations
+func (f *fallbackCacheService) TakeToken(ctx context.Context, key string, capacity int, window time.Duration) (services.TokenBucketResult, error) {
+	if result, err := f.primary.TakeToken(ctx, key, capacity, window); err == nil {
+		return result, nil
+	}
+	log.Printf("⚠️  Primary cache failed, using fallback for TakeToken(%s)", key)
+	return f.fallback.TakeToken(ctx, key, capacity, window)
+}
+
+// Counter operations
+func (f *fallbackCacheService) IncrementBy(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	if value, err := f.primary.IncrementBy(ctx, key, delta, ttl); err == nil {
+		return value, nil
+	}
+	log.Printf("⚠️  Primary cache failed, using fallback for IncrementBy(%s)", key)
+	return f.fallback.IncrementBy(ctx, key, delta, ttl)
+}
+
+// Sorted set operations
+func (f *fallbackCacheService) IncrementScore(ctx context.Context, key, member string, delta float64, ttl time.Duration) error {
+	if err := f.primary.IncrementScore(ctx, key, member, delta, ttl); err == nil {
+		return nil
+	}
+	log.Printf("⚠️  Primary cache failed, using fallback for IncrementScore(%s)", key)
+	return f.fallback.IncrementScore(ctx, key, member, delta, ttl)
+}
+
+func (f *fallbackCacheService) GetScores(ctx context.Context, key string) (map[string]float64, error) {
+	if scores, err := f.primary.GetScores(ctx, key); err == nil {
+		return scores, nil
+	}
+	log.Printf("⚠️  Primary cache failed, using fallback for GetScores(%s)", key)
+	return f.fallback.GetScores(ctx, key)
+}
+
 // NewCacheService creates a cache service based on configuration
 // It attempts to use Redis first, falling back to memory cache if Redis fails
 func NewCacheService(cfg *config.Config) services.CacheService {
@@ -257,19 +357,25 @@ func NewCacheService(cfg *config.Config) services.CacheService {
 }
 
 // NewRedisCacheServiceWithFallback creates a Redis cache service with memory fallback
-// This wrapper automatically falls back to memory cache for operations if Redis fails
+// This wrapper automatically falls back to memory cache for operations if Redis fails.
+// Evictions are broadcast through Redis pub/sub so every instance drops its in-memory copy.
 func NewRedisCacheServiceWithFallback(cfg *config.Config) services.CacheService {
-	redisService, err := NewRedisCacheService(cfg)
+	redisService, err := newRedisCacheService(cfg)
 	if err != nil {
 		log.Printf("⚠️  Redis unavailable, using memory cache: %v", err)
 		return NewMemoryCacheService()
 	}
 
+	fallback := NewMemoryCacheService()
+	broadcaster := newInvalidationBroadcaster(redisService, fallback)
+	broadcaster.start()
+
 	// Create a fallback wrapper
 	return &fallbackCacheService{
-		primary:  redisService,
-		fallback: NewMemoryCacheService(),
-		config:   services.DefaultCacheConfiguration(),
+		primary:     redisService,
+		fallback:    fallback,
+		config:      services.DefaultCacheConfiguration(),
+		broadcaster: broadcaster,
 	}
 }
 
@@ -0,0 +1,146 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/services"
+)
+
+// invalidationChannel es el canal de Redis por el que las instancias se avisan de las evicciones
+const invalidationChannel = "cache:invalidations"
+
+// Operaciones de invalidación que viajan por el canal
+const (
+	invalidateCompany       = "company"
+	invalidateBrokerage     = "brokerage"
+	invalidateStockRating   = "stock_rating"
+	invalidatePrefix        = "prefix"
+	invalidateAll           = "clear"
+	invalidateAllCompanies  = "clear_companies"
+	invalidateAllBrokerages = "clear_brokerages"
+)
+
+// invalidationMessage describes an eviction performed by one instance
+type invalidationMessage struct {
+	Origin      string    `json:"origin"` // Instancia que la publicó; ella misma ya evictó su copia local
+	Operation   string    `json:"operation"`
+	Key         string    `json:"key,omitempty"`
+	CompanyID   uuid.UUID `json:"company_id,omitempty"`
+	BrokerageID uuid.UUID `json:"brokerage_id,omitempty"`
+}
+
+// invalidationBroadcaster publishes local evictions through Redis pub/sub and applies the evictions
+// of other instances to the local in-memory cache, so fallback copies do not outlive a write elsewhere
+type invalidationBroadcaster struct {
+	redis      *redisCacheService
+	local      services.CacheService
+	instanceID string
+
+	cancel context.CancelFunc
+	done   chan struct{}
+	once   sync.Once
+}
+
+func newInvalidationBroadcaster(redis *redisCacheService, local services.CacheService) *invalidationBroadcaster {
+	return &invalidationBroadcaster{
+		redis:      redis,
+		local:      local,
+		instanceID: uuid.New().String(),
+		done:       make(chan struct{}),
+	}
+}
+
+// start subscribes to the invalidation channel. go-redis reconnects the subscription on its own;
+// los mensajes publicados mientras Redis no está disponible se pierden y solo queda el TTL.
+func (b *invalidationBroadcaster) start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	b.cancel = cancel
+
+	pubsub := b.redis.client.Subscribe(ctx, invalidationChannel)
+
+	go func() {
+		defer close(b.done)
+		defer pubsub.Close()
+
+		channel := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-channel:
+				if !ok {
+					return
+				}
+				b.handle(ctx, msg.Payload)
+			}
+		}
+	}()
+}
+
+// stop ends the subscription and waits for the listener to exit
+func (b *invalidationBroadcaster) stop() {
+	b.once.Do(func() {
+		if b.cancel != nil {
+			b.cancel()
+			<-b.done
+		}
+	})
+}
+
+// publish notifies the other instances; a failure only means they keep their copy until the TTL expires
+func (b *invalidationBroadcaster) publish(ctx context.Context, message invalidationMessage) {
+	message.Origin = b.instanceID
+
+	payload, err := json.Marshal(message)
+	if err != nil {
+		log.Printf("⚠️  Failed to encode cache invalidation %s: %v", message.Operation, err)
+		return
+	}
+
+	if err := b.redis.client.Publish(ctx, invalidationChannel, payload).Err(); err != nil {
+		log.Printf("⚠️  Failed to publish cache invalidation %s: %v", message.Operation, err)
+	}
+}
+
+// handle applies an invalidation received from another instance to the local cache
+func (b *invalidationBroadcaster) handle(ctx context.Context, payload string) {
+	var message invalidationMessage
+	if err := json.Unmarshal([]byte(payload), &message); err != nil {
+		log.Printf("⚠️  Ignoring malformed cache invalidation: %v", err)
+		return
+	}
+
+	if message.Origin == b.instanceID {
+		return
+	}
+
+	var err error
+	switch message.Operation {
+	case invalidateCompany:
+		err = b.local.DeleteCompany(ctx, message.Key)
+	case invalidateBrokerage:
+		err = b.local.DeleteBrokerage(ctx, message.Key)
+	case invalidateStockRating:
+		err = b.local.DeleteStockRating(ctx, message.CompanyID, message.BrokerageID)
+	case invalidatePrefix:
+		err = b.local.DeleteByPrefix(ctx, message.Key)
+	case invalidateAll:
+		err = b.local.Clear(ctx)
+	case invalidateAllCompanies:
+		err = b.local.ClearCompanies(ctx)
+	case invalidateAllBrokerages:
+		err = b.local.ClearBrokerages(ctx)
+	default:
+		log.Printf("⚠️  Ignoring unknown cache invalidation %q", message.Operation)
+		return
+	}
+
+	if err != nil {
+		log.Printf("⚠️  Failed to apply cache invalidation %s: %v", message.Operation, err)
+	}
+}
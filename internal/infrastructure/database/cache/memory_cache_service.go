@@ -2,6 +2,7 @@ package cache
 
 import (
 	"context"
+	"strings"
 	"sync"
 	"time"
 
@@ -16,6 +17,10 @@ type memoryCacheService struct {
 	companies    map[string]*cacheItem
 	brokerages   map[string]*cacheItem
 	stockRatings map[string]*cacheItem
+	values       map[string]*cacheItem // Valores genéricos (Get/Set)
+	buckets      map[string]*tokenBucket
+	counters     map[string]*counter
+	sortedSets   map[string]*sortedSet
 	config       services.CacheConfiguration
 	stats        *cacheStats
 	mutex        sync.RWMutex
@@ -27,12 +32,43 @@ type cacheItem struct {
 	expiresAt time.Time
 }
 
+// counter represents an integer counter in the memory cache (zero expiresAt never expires)
+type counter struct {
+	value     int64
+	expiresAt time.Time
+}
+
+func (c *counter) isExpired(now time.Time) bool {
+	return !c.expiresAt.IsZero() && now.After(c.expiresAt)
+}
+
+// sortedSet represents the member scores of a ranking in the memory cache (zero expiresAt never expires)
+type sortedSet struct {
+	scores    map[string]float64
+	expiresAt time.Time
+}
+
+func (s *sortedSet) isExpired(now time.Time) bool {
+	return !s.expiresAt.IsZero() && now.After(s.expiresAt)
+}
+
+// tokenBucket represents a rate limit bucket in the memory cache
+type tokenBucket struct {
+	tokens    float64
+	updatedAt time.Time
+	expiresAt time.Time
+}
+
 // NewMemoryCacheService creates a new memory cache service
 func NewMemoryCacheService() services.CacheService {
 	service := &memoryCacheService{
 		companies:    make(map[string]*cacheItem),
 		brokerages:   make(map[string]*cacheItem),
 		stockRatings: make(map[string]*cacheItem),
+		values:       make(map[string]*cacheItem),
+		buckets:      make(map[string]*tokenBucket),
+		counters:     make(map[string]*counter),
+		sortedSets:   make(map[string]*sortedSet),
 		config:       services.DefaultCacheConfiguration(),
 		stats: &cacheStats{
 			startTime: time.Now(),
@@ -81,6 +117,34 @@ func (m *memoryCacheService) cleanup() {
 			delete(m.stockRatings, key)
 		}
 	}
+
+	// Cleanup generic values
+	for key, item := range m.values {
+		if now.After(item.expiresAt) {
+			delete(m.values, key)
+		}
+	}
+
+	// Cleanup rate limit buckets
+	for key, bucket := range m.buckets {
+		if now.After(bucket.expiresAt) {
+			delete(m.buckets, key)
+		}
+	}
+
+	// Cleanup counters
+	for key, c := range m.counters {
+		if c.isExpired(now) {
+			delete(m.counters, key)
+		}
+	}
+
+	// Cleanup sorted sets
+	for key, set := range m.sortedSets {
+		if set.isExpired(now) {
+			delete(m.sortedSets, key)
+		}
+	}
 }
 
 // isExpired checks if a cache item has expired
@@ -305,6 +369,7 @@ func (m *memoryCacheService) Clear(ctx context.Context) error {
 
 	m.companies = make(map[string]*cacheItem)
 	m.brokerages = make(map[string]*cacheItem)
+	m.values = make(map[string]*cacheItem)
 
 	return nil
 }
@@ -380,6 +445,86 @@ func (m *memoryCacheService) Ping(ctx context.Context) error {
 	return nil
 }
 
+// ========================================
+// GENERIC VALUE OPERATIONS
+// ========================================
+
+// Get retrieves a raw value from memory cache
+func (m *memoryCacheService) Get(ctx context.Context, key string) ([]byte, error) {
+	m.stats.lastAccess = time.Now()
+
+	m.mutex.RLock()
+	item, exists := m.values[key]
+	m.mutex.RUnlock()
+
+	if !exists || item.isExpired() {
+		if exists {
+			m.mutex.Lock()
+			delete(m.values, key)
+			m.mutex.Unlock()
+		}
+		m.stats.missCount++
+		return nil, nil // Cache miss
+	}
+
+	data, ok := item.data.([]byte)
+	if !ok {
+		m.mutex.Lock()
+		delete(m.values, key) // Remove corrupted data
+		m.mutex.Unlock()
+		m.stats.missCount++
+		return nil, nil
+	}
+
+	m.stats.hitCount++
+	return data, nil
+}
+
+// Set stores a raw value in memory cache
+func (m *memoryCacheService) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if ttl == 0 {
+		ttl = m.config.DefaultTTL
+	}
+
+	// Copia para que el llamador pueda reutilizar su buffer
+	data := make([]byte, len(value))
+	copy(data, value)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.values[key] = &cacheItem{
+		data:      data,
+		expiresAt: time.Now().Add(ttl),
+	}
+
+	return nil
+}
+
+// DeleteByPrefix removes every generic value, counter and ranking whose key starts with prefix, like Redis
+func (m *memoryCacheService) DeleteByPrefix(ctx context.Context, prefix string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for key := range m.values {
+		if strings.HasPrefix(key, prefix) {
+			delete(m.values, key)
+		}
+	}
+	for key := range m.counters {
+		if strings.HasPrefix(key, prefix) {
+			delete(m.counters, key)
+		}
+	}
+	for key := range m.sortedSets {
+		if strings.HasPrefix(key, prefix) {
+			delete(m.sortedSets, key)
+		}
+	}
+
+	return nil
+}
+
 // ========================================
 // KEY OPERATIONS
 // ========================================
@@ -399,6 +544,11 @@ func (m *memoryCacheService) Exists(ctx context.Context, key string) (bool, erro
 		return true, nil
 	}
 
+	// Check in generic values
+	if item, exists := m.values[key]; exists && !item.isExpired() {
+		return true, nil
+	}
+
 	return false, nil
 }
 
@@ -454,6 +604,110 @@ func (m *memoryCacheService) Expire(ctx context.Context, key string, ttl time.Du
 	}
 }
 
+// ========================================
+// RATE LIMITING OPERATIONS
+// ========================================
+
+// TakeToken consumes a token from an in-memory token bucket (only shared within this process)
+func (m *memoryCacheService) TakeToken(ctx context.Context, key string, capacity int, window time.Duration) (services.TokenBucketResult, error) {
+	if capacity <= 0 || window <= 0 {
+		return services.TokenBucketResult{}, &services.CacheError{
+			Operation: "take_token",
+			Key:       key,
+			Message:   "invalid token bucket parameters",
+		}
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	now := time.Now()
+	bucket, exists := m.buckets[key]
+	if !exists || now.After(bucket.expiresAt) {
+		bucket = &tokenBucket{
+			tokens:    float64(capacity),
+			updatedAt: now,
+		}
+		m.buckets[key] = bucket
+	}
+
+	// Refill proporcional al tiempo transcurrido
+	elapsed := now.Sub(bucket.updatedAt)
+	bucket.tokens += float64(elapsed) / float64(window) * float64(capacity)
+	if bucket.tokens > float64(capacity) {
+		bucket.tokens = float64(capacity)
+	}
+	bucket.updatedAt = now
+	bucket.expiresAt = now.Add(window)
+
+	allowed := bucket.tokens >= 1
+	if allowed {
+		bucket.tokens--
+	}
+
+	return services.NewTokenBucketResult(allowed, capacity, bucket.tokens, window), nil
+}
+
+// ========================================
+// COUNTER OPERATIONS
+// ========================================
+
+// IncrementBy adds delta to an in-memory counter (only shared within this process)
+func (m *memoryCacheService) IncrementBy(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	now := time.Now()
+	c, exists := m.counters[key]
+	if !exists || c.isExpired(now) {
+		c = &counter{}
+		if ttl > 0 {
+			c.expiresAt = now.Add(ttl)
+		}
+		m.counters[key] = c
+	}
+
+	c.value += delta
+	return c.value, nil
+}
+
+// ========================================
+// SORTED SET OPERATIONS
+// ========================================
+
+// IncrementScore adds delta to a member of an in-memory ranking (only shared within this process)
+func (m *memoryCacheService) IncrementScore(ctx context.Context, key, member string, delta float64, ttl time.Duration) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	now := time.Now()
+	set, exists := m.sortedSets[key]
+	if !exists || set.isExpired(now) {
+		set = &sortedSet{scores: make(map[string]float64)}
+		if ttl > 0 {
+			set.expiresAt = now.Add(ttl)
+		}
+		m.sortedSets[key] = set
+	}
+
+	set.scores[member] += delta
+	return nil
+}
+
+// GetScores returns a copy of the member scores of an in-memory ranking
+func (m *memoryCacheService) GetScores(ctx context.Context, key string) (map[string]float64, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	scores := make(map[string]float64)
+	if set, exists := m.sortedSets[key]; exists && !set.isExpired(time.Now()) {
+		for member, score := range set.scores {
+			scores[member] = score
+		}
+	}
+	return scores, nil
+}
+
 // ========================================
 // STOCK RATING OPERATIONS
 // ========================================
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -33,6 +34,15 @@ type cacheStats struct {
 
 // NewRedisCacheService creates a new Redis cache service
 func NewRedisCacheService(cfg *config.Config) (services.CacheService, error) {
+	service, err := newRedisCacheService(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return service, nil
+}
+
+// newRedisCacheService devuelve el tipo concreto (el fallback necesita el cliente para pub/sub)
+func newRedisCacheService(cfg *config.Config) (*redisCacheService, error) {
 	// Create Redis client
 	client := redis.NewClient(&redis.Options{
 		Addr:         cfg.Cache.GetRedisAddr(),
@@ -545,6 +555,56 @@ func (r *redisCacheService) Ping(ctx context.Context) error {
 	return nil
 }
 
+// ========================================
+// GENERIC VALUE OPERATIONS
+// ========================================
+
+// Get retrieves a raw value from cache
+func (r *redisCacheService) Get(ctx context.Context, key string) ([]byte, error) {
+	r.stats.lastAccess = time.Now()
+
+	data, err := r.client.Get(ctx, key).Bytes()
+	if err != nil {
+		r.stats.missCount++
+		if err == redis.Nil {
+			return nil, nil // Cache miss, not an error
+		}
+		return nil, r.wrapError("get", key, "Redis get failed", err)
+	}
+
+	r.stats.hitCount++
+	return data, nil
+}
+
+// Set stores a raw value in cache
+func (r *redisCacheService) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if ttl == 0 {
+		ttl = r.config.DefaultTTL
+	}
+
+	if err := r.client.Set(ctx, key, value, ttl).Err(); err != nil {
+		return r.wrapError("set", key, "Redis set failed", err)
+	}
+
+	return nil
+}
+
+// DeleteByPrefix removes every key starting with prefix
+func (r *redisCacheService) DeleteByPrefix(ctx context.Context, prefix string) error {
+	keys, err := r.client.Keys(ctx, prefix+"*").Result()
+	if err != nil {
+		return r.wrapError("delete_by_prefix", prefix, "Failed to get keys", err)
+	}
+
+	if len(keys) > 0 {
+		if err := r.client.Del(ctx, keys...).Err(); err != nil {
+			return r.wrapError("delete_by_prefix", fmt.Sprintf("%d keys", len(keys)), "Redis delete failed", err)
+		}
+	}
+
+	return nil
+}
+
 // ========================================
 // KEY OPERATIONS
 // ========================================
@@ -575,6 +635,127 @@ func (r *redisCacheService) Expire(ctx context.Context, key string, ttl time.Dur
 	return nil
 }
 
+// ========================================
+// RATE LIMITING OPERATIONS
+// ========================================
+
+// tokenBucketScript refills and consumes a token atomically, using the Redis clock so that
+// every API instance shares the same bucket state
+var tokenBucketScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local window_ms = tonumber(ARGV[2])
+local now_parts = redis.call('TIME')
+local now = tonumber(now_parts[1]) * 1000 + math.floor(tonumber(now_parts[2]) / 1000)
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(bucket[1])
+local ts = tonumber(bucket[2])
+if tokens == nil or ts == nil then
+	tokens = capacity
+	ts = now
+end
+
+local elapsed = math.max(0, now - ts)
+tokens = math.min(capacity, tokens + elapsed * capacity / window_ms)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], window_ms)
+return {allowed, tostring(tokens)}
+`)
+
+// TakeToken consumes a token from a Redis-backed token bucket
+func (r *redisCacheService) TakeToken(ctx context.Context, key string, capacity int, window time.Duration) (services.TokenBucketResult, error) {
+	windowMs := window.Milliseconds()
+	if capacity <= 0 || windowMs <= 0 {
+		return services.TokenBucketResult{}, r.wrapError("take_token", key, "Invalid token bucket parameters", fmt.Errorf("capacity=%d window=%s", capacity, window))
+	}
+
+	values, err := tokenBucketScript.Run(ctx, r.client, []string{key}, capacity, windowMs).Slice()
+	if err != nil {
+		return services.TokenBucketResult{}, r.wrapError("take_token", key, "Redis token bucket script failed", err)
+	}
+
+	if len(values) != 2 {
+		return services.TokenBucketResult{}, r.wrapError("take_token", key, "Unexpected token bucket script result", fmt.Errorf("got %v", values))
+	}
+
+	allowed, _ := values[0].(int64)
+	tokensStr, _ := values[1].(string)
+	tokens, err := strconv.ParseFloat(tokensStr, 64)
+	if err != nil {
+		return services.TokenBucketResult{}, r.wrapError("take_token", key, "Invalid token count", err)
+	}
+
+	return services.NewTokenBucketResult(allowed == 1, capacity, tokens, window), nil
+}
+
+// ========================================
+// COUNTER OPERATIONS
+// ========================================
+
+// incrementScript increments a counter and sets its TTL only when the key has none (new counter)
+var incrementScript = redis.NewScript(`
+local value = redis.call('INCRBY', KEYS[1], ARGV[1])
+local ttl_ms = tonumber(ARGV[2])
+if ttl_ms > 0 and redis.call('PTTL', KEYS[1]) < 0 then
+	redis.call('PEXPIRE', KEYS[1], ttl_ms)
+end
+return value
+`)
+
+// IncrementBy adds delta to a counter shared by every API instance
+func (r *redisCacheService) IncrementBy(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	value, err := incrementScript.Run(ctx, r.client, []string{key}, delta, ttl.Milliseconds()).Int64()
+	if err != nil {
+		return 0, r.wrapError("increment", key, "Redis increment failed", err)
+	}
+	return value, nil
+}
+
+// ========================================
+// SORTED SET OPERATIONS
+// ========================================
+
+// incrementScoreScript increments a member score and sets the TTL only when the set has none (new set)
+var incrementScoreScript = redis.NewScript(`
+redis.call('ZINCRBY', KEYS[1], ARGV[1], ARGV[2])
+local ttl_ms = tonumber(ARGV[3])
+if ttl_ms > 0 and redis.call('PTTL', KEYS[1]) < 0 then
+	redis.call('PEXPIRE', KEYS[1], ttl_ms)
+end
+return 1
+`)
+
+// IncrementScore adds delta to a member of a ranking shared by every API instance
+func (r *redisCacheService) IncrementScore(ctx context.Context, key, member string, delta float64, ttl time.Duration) error {
+	if err := incrementScoreScript.Run(ctx, r.client, []string{key}, delta, member, ttl.Milliseconds()).Err(); err != nil {
+		return r.wrapError("increment_score", key, "Redis sorted set increment failed", err)
+	}
+	return nil
+}
+
+// GetScores returns every member of a ranking with its score
+func (r *redisCacheService) GetScores(ctx context.Context, key string) (map[string]float64, error) {
+	members, err := r.client.ZRangeWithScores(ctx, key, 0, -1).Result()
+	if err != nil {
+		return nil, r.wrapError("get_scores", key, "Redis sorted set range failed", err)
+	}
+
+	scores := make(map[string]float64, len(members))
+	for _, member := range members {
+		if name, ok := member.Member.(string); ok {
+			scores[name] = member.Score
+		}
+	}
+	return scores, nil
+}
+
 // ========================================
 // HELPER METHODS
 // ========================================
@@ -2,8 +2,10 @@ package cockroachdb
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"log"
+	"os"
 	"time"
 
 	"gorm.io/driver/postgres"
@@ -17,21 +19,37 @@ import (
 type DB struct {
 	*gorm.DB
 	config *config.DatabaseConfig
+
+	// Réplicas de lectura (opcionales); reader reparte las consultas entre ellas
+	reader   *gorm.DB
+	replicas []*sql.DB
+
+	slowQueries *SlowQueryRecorder
 }
 
 // NewConnection creates a new database connection
 func NewConnection(cfg *config.Config) (*DB, error) {
 	// Configure GORM logger based on environment
-	var gormLogger logger.Interface
+	logLevel := logger.Error
 	if cfg.App.IsDevelopment() {
-		gormLogger = logger.Default.LogMode(logger.Info)
-	} else {
-		gormLogger = logger.Default.LogMode(logger.Error)
+		logLevel = logger.Info
+	}
+
+	// Las queries lentas las registra slowQueryLogger con su request ID; el logger base no las repite
+	slowQueries := NewSlowQueryRecorder(cfg.Database.SlowQueryThreshold, cfg.Database.SlowQueryBufferSize)
+	baseSlowThreshold := 200 * time.Millisecond
+	if slowQueries.Enabled() {
+		baseSlowThreshold = 0
 	}
+	baseLogger := logger.New(log.New(os.Stdout, "\r\n", log.LstdFlags), logger.Config{
+		SlowThreshold: baseSlowThreshold,
+		LogLevel:      logLevel,
+		Colorful:      true,
+	})
 
 	// GORM configuration
 	gormConfig := &gorm.Config{
-		Logger: gormLogger,
+		Logger: newSlowQueryLogger(baseLogger, slowQueries),
 		NowFunc: func() time.Time {
 			return time.Now().UTC()
 		},
@@ -54,6 +72,7 @@ func NewConnection(cfg *config.Config) (*DB, error) {
 	sqlDB.SetMaxOpenConns(cfg.Database.MaxOpenConns)
 	sqlDB.SetMaxIdleConns(cfg.Database.MaxIdleConns)
 	sqlDB.SetConnMaxLifetime(cfg.Database.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.Database.ConnMaxIdleTime)
 
 	// Test the connection
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -65,9 +84,14 @@ func NewConnection(cfg *config.Config) (*DB, error) {
 
 	log.Printf("✅ Database connected successfully to %s:%s", cfg.Database.Host, cfg.Database.Port)
 
+	reader, replicas := openReplicas(cfg, gormConfig)
+
 	return &DB{
-		DB:     db,
-		config: &cfg.Database,
+		DB:          db,
+		config:      &cfg.Database,
+		reader:      reader,
+		replicas:    replicas,
+		slowQueries: slowQueries,
 	}, nil
 }
 
@@ -82,6 +106,10 @@ func (db *DB) Close() error {
 		return fmt.Errorf("failed to close database connection: %w", err)
 	}
 
+	if err := closeReplicas(db.replicas); err != nil {
+		return fmt.Errorf("failed to close read replica connection: %w", err)
+	}
+
 	log.Println("📁 Database connection closed")
 	return nil
 }
@@ -104,6 +132,7 @@ func (db *DB) GetStats() (map[string]interface{}, error) {
 		"max_idle_closed":         stats.MaxIdleClosed,
 		"max_idle_time_closed":    stats.MaxIdleTimeClosed,
 		"max_lifetime_closed":     stats.MaxLifetimeClosed,
+		"read_replicas":           len(db.replicas),
 	}, nil
 }
 
@@ -137,4 +166,18 @@ func (db *DB) IsHealthy(ctx context.Context) bool {
 	}
 
 	return result == 1
-}
\ No newline at end of file
+}
+// SlowQueries returns the recorder with the most recent slow queries of this connection
+func (db *DB) SlowQueries() *SlowQueryRecorder {
+	return db.slowQueries
+}
+
+// PoolSettings returns the configured connection pool limits
+func (db *DB) PoolSettings() map[string]interface{} {
+	return map[string]interface{}{
+		"max_open_conns":     db.config.MaxOpenConns,
+		"max_idle_conns":     db.config.MaxIdleConns,
+		"conn_max_lifetime":  db.config.ConnMaxLifetime.String(),
+		"conn_max_idle_time": db.config.ConnMaxIdleTime.String(),
+	}
+}
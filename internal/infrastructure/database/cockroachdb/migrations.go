@@ -0,0 +1,190 @@
+package cockroachdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"regexp"
+	"strconv"
+
+	"github.com/golang-migrate/migrate/v4"
+	migratecockroach "github.com/golang-migrate/migrate/v4/database/cockroachdb"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+
+	"github.com/MayaCris/stock-info-app/migrations"
+)
+
+// Tablas que usa golang-migrate para registrar la versión aplicada y el lock entre procesos
+const (
+	migrationsTable = "schema_migrations"
+	migrationsLock  = "schema_lock"
+)
+
+// Comandos aceptados por el flag -migrate
+const (
+	MigrateCommandUp     = "up"
+	MigrateCommandDown   = "down"
+	MigrateCommandStatus = "status"
+)
+
+// migrationFilePattern reconoce los archivos NNNNNN_descripcion.up.sql
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_.*\.up\.sql$`)
+
+// MigrationStatus describes the schema version of the database compared to the embedded migrations
+type MigrationStatus struct {
+	CurrentVersion uint `json:"current_version"`
+	LatestVersion  uint `json:"latest_version"`
+	Pending        int  `json:"pending"`
+	Dirty          bool `json:"dirty"` // Una migración falló a medias; requiere intervención manual
+}
+
+// UpToDate reports whether every embedded migration has been applied cleanly
+func (s *MigrationStatus) UpToDate() bool {
+	return !s.Dirty && s.Pending == 0
+}
+
+// MigrateUp applies every pending migration
+func (db *DB) MigrateUp() error {
+	return db.runMigrations(func(m *migrate.Migrate) error {
+		return m.Up()
+	})
+}
+
+// MigrateDown reverts the last applied migration
+func (db *DB) MigrateDown() error {
+	return db.runMigrations(func(m *migrate.Migrate) error {
+		return m.Steps(-1)
+	})
+}
+
+// RunMigrateCommand ejecuta el comando recibido por el flag -migrate (up, down o status)
+func (db *DB) RunMigrateCommand(ctx context.Context, command string) (*MigrationStatus, error) {
+	switch command {
+	case MigrateCommandUp:
+		if err := db.MigrateUp(); err != nil {
+			return nil, err
+		}
+	case MigrateCommandDown:
+		if err := db.MigrateDown(); err != nil {
+			return nil, err
+		}
+	case MigrateCommandStatus:
+	default:
+		return nil, fmt.Errorf("unknown migrate command %q (expected %s, %s or %s)",
+			command, MigrateCommandUp, MigrateCommandDown, MigrateCommandStatus)
+	}
+
+	return db.MigrationStatus(ctx)
+}
+
+// MigrationStatus reads the applied version from schema_migrations without taking the migration lock
+func (db *DB) MigrationStatus(ctx context.Context) (*MigrationStatus, error) {
+	versions, err := embeddedMigrationVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	status := &MigrationStatus{}
+	if len(versions) > 0 {
+		status.LatestVersion = versions[len(versions)-1]
+	}
+
+	var exists bool
+	if err := db.DB.WithContext(ctx).
+		Raw("SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name = ?)", migrationsTable).
+		Scan(&exists).Error; err != nil {
+		return nil, fmt.Errorf("failed to check migrations table: %w", err)
+	}
+
+	if exists {
+		var row struct {
+			Version int64
+			Dirty   bool
+		}
+		result := db.DB.WithContext(ctx).
+			Raw(fmt.Sprintf("SELECT version, dirty FROM %s LIMIT 1", migrationsTable)).
+			Scan(&row)
+		if result.Error != nil {
+			return nil, fmt.Errorf("failed to read schema version: %w", result.Error)
+		}
+		if result.RowsAffected > 0 && row.Version > 0 {
+			status.CurrentVersion = uint(row.Version)
+			status.Dirty = row.Dirty
+		}
+	}
+
+	for _, version := range versions {
+		if version > status.CurrentVersion {
+			status.Pending++
+		}
+	}
+
+	return status, nil
+}
+
+// runMigrations abre una conexión dedicada para golang-migrate (al cerrarse cierra su *sql.DB,
+// así que no puede compartir el pool de GORM) y ejecuta la operación indicada
+func (db *DB) runMigrations(operation func(m *migrate.Migrate) error) error {
+	source, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return fmt.Errorf("failed to load embedded migrations: %w", err)
+	}
+
+	sqlDB, err := sql.Open("pgx", db.config.GetDSN())
+	if err != nil {
+		return fmt.Errorf("failed to open migrations connection: %w", err)
+	}
+
+	driver, err := migratecockroach.WithInstance(sqlDB, &migratecockroach.Config{
+		MigrationsTable: migrationsTable,
+		LockTable:       migrationsLock,
+	})
+	if err != nil {
+		sqlDB.Close()
+		return fmt.Errorf("failed to create migration driver: %w", err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", source, db.config.Name, driver)
+	if err != nil {
+		sqlDB.Close()
+		return fmt.Errorf("failed to initialize migrations: %w", err)
+	}
+	defer m.Close()
+
+	if err := operation(m); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	if version, dirty, err := m.Version(); err == nil {
+		log.Printf("✅ Database schema at version %d (dirty: %t)", version, dirty)
+	}
+
+	return nil
+}
+
+// embeddedMigrationVersions returns the sorted versions of the embedded .up.sql files
+func embeddedMigrationVersions() ([]uint, error) {
+	entries, err := fs.ReadDir(migrations.FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embedded migrations: %w", err)
+	}
+
+	// fs.ReadDir devuelve las entradas ordenadas por nombre, y los prefijos tienen ancho fijo
+	var versions []uint
+	for _, entry := range entries {
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %s: %w", entry.Name(), err)
+		}
+		versions = append(versions, uint(version))
+	}
+
+	return versions, nil
+}
@@ -0,0 +1,155 @@
+package cockroachdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// partitionNameLayout nombra cada partición mensual: p2026_01 cubre enero de 2026
+const partitionNameLayout = "p2006_01"
+
+// PartitionedTable describes a time-series table partitioned by month on a timestamp column.
+// The column must lead the primary key (migrations 000006 and 000007).
+type PartitionedTable struct {
+	Name   string
+	Column string
+}
+
+// PartitionedTables lists the tables kept partitioned by month
+var PartitionedTables = []PartitionedTable{
+	{Name: "stock_ratings", Column: "event_time"},
+	{Name: "market_data", Column: "market_timestamp"},
+}
+
+// MonthlyPartition is the [From, To) range of one month
+type MonthlyPartition struct {
+	Name string
+	From time.Time
+	To   time.Time
+}
+
+// PartitionMaintenanceResult reports the partitions of a table after maintenance
+type PartitionMaintenanceResult struct {
+	Table      string
+	Column     string
+	Partitions []string
+	Created    []string
+	Skipped    string // Motivo si la tabla no se particionó (p. ej. no existe)
+}
+
+// MonthlyPartitions returns one partition per month from the month of from to the month of to, both included
+func MonthlyPartitions(from, to time.Time) []MonthlyPartition {
+	start := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(to.Year(), to.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	var partitions []MonthlyPartition
+	for month := start; !month.After(end); month = month.AddDate(0, 1, 0) {
+		partitions = append(partitions, MonthlyPartition{
+			Name: month.Format(partitionNameLayout),
+			From: month,
+			To:   month.AddDate(0, 1, 0),
+		})
+	}
+
+	return partitions
+}
+
+// PartitionByRangeSQL builds the statement that partitions table by month. CockroachDB replaces the
+// whole partitioning on every PARTITION BY, so it has to list every partition, old and new.
+func PartitionByRangeSQL(table PartitionedTable, partitions []MonthlyPartition) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "ALTER TABLE %s PARTITION BY RANGE (%s) (", table.Name, table.Column)
+	for i, partition := range partitions {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "PARTITION %s VALUES FROM ('%s') TO ('%s')", partition.Name,
+			partition.From.Format(time.RFC3339), partition.To.Format(time.RFC3339))
+	}
+	sb.WriteString(")")
+
+	return sb.String()
+}
+
+// EnsureMonthlyPartitions partitions every table in PartitionedTables by month, from the oldest row
+// (or oldest existing partition) up to monthsAhead months after now, creating the missing partitions
+func (db *DB) EnsureMonthlyPartitions(ctx context.Context, monthsAhead int, now time.Time) ([]PartitionMaintenanceResult, error) {
+	results := make([]PartitionMaintenanceResult, 0, len(PartitionedTables))
+	for _, table := range PartitionedTables {
+		result, err := db.ensureTablePartitions(ctx, table, monthsAhead, now)
+		if err != nil {
+			return results, fmt.Errorf("failed to partition %s: %w", table.Name, err)
+		}
+		results = append(results, *result)
+	}
+
+	return results, nil
+}
+
+func (db *DB) ensureTablePartitions(ctx context.Context, table PartitionedTable, monthsAhead int, now time.Time) (*PartitionMaintenanceResult, error) {
+	result := &PartitionMaintenanceResult{Table: table.Name, Column: table.Column}
+
+	if !db.Migrator().HasTable(table.Name) {
+		result.Skipped = "table does not exist"
+		return result, nil
+	}
+
+	existing, err := db.existingPartitions(ctx, table.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	// La primera partición cubre el dato más antiguo o la partición más antigua ya creada
+	from := now.UTC()
+	var oldest sql.NullTime
+	if err := db.WithContext(ctx).Raw(fmt.Sprintf("SELECT min(%s) FROM %s", table.Column, table.Name)).Scan(&oldest).Error; err != nil {
+		return nil, fmt.Errorf("failed to read oldest %s: %w", table.Column, err)
+	}
+	if oldest.Valid && oldest.Time.Before(from) {
+		from = oldest.Time.UTC()
+	}
+	for name := range existing {
+		if month, err := time.Parse(partitionNameLayout, name); err == nil && month.Before(from) {
+			from = month
+		}
+	}
+
+	partitions := MonthlyPartitions(from, now.UTC().AddDate(0, monthsAhead, 0))
+	for _, partition := range partitions {
+		result.Partitions = append(result.Partitions, partition.Name)
+		if !existing[partition.Name] {
+			result.Created = append(result.Created, partition.Name)
+		}
+	}
+
+	if len(result.Created) == 0 {
+		return result, nil
+	}
+
+	if err := db.WithContext(ctx).Exec(PartitionByRangeSQL(table, partitions)).Error; err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// existingPartitions devuelve los nombres de las particiones de la tabla
+func (db *DB) existingPartitions(ctx context.Context, tableName string) (map[string]bool, error) {
+	var names []string
+	err := db.WithContext(ctx).
+		Raw(fmt.Sprintf("SELECT partition_name FROM [SHOW PARTITIONS FROM TABLE %s]", tableName)).
+		Scan(&names).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list partitions of %s: %w", tableName, err)
+	}
+
+	existing := make(map[string]bool, len(names))
+	for _, name := range names {
+		existing[name] = true
+	}
+
+	return existing, nil
+}
@@ -0,0 +1,117 @@
+package cockroachdb
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/config"
+)
+
+// replicaPool implements gorm.ConnPool spreading queries across the read replicas in round-robin.
+// No implementa BeginTx: las transacciones siempre deben ir al primario.
+type replicaPool struct {
+	replicas []*sql.DB
+	next     atomic.Uint64
+}
+
+func (p *replicaPool) pick() *sql.DB {
+	n := p.next.Add(1) - 1
+	return p.replicas[n%uint64(len(p.replicas))]
+}
+
+// PrepareContext implements gorm.ConnPool
+func (p *replicaPool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return p.pick().PrepareContext(ctx, query)
+}
+
+// ExecContext implements gorm.ConnPool
+func (p *replicaPool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return p.pick().ExecContext(ctx, query, args...)
+}
+
+// QueryContext implements gorm.ConnPool
+func (p *replicaPool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return p.pick().QueryContext(ctx, query, args...)
+}
+
+// QueryRowContext implements gorm.ConnPool
+func (p *replicaPool) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return p.pick().QueryRowContext(ctx, query, args...)
+}
+
+// openReplicas conecta con las réplicas configuradas. Una réplica que no responde se descarta
+// con un aviso en vez de impedir el arranque; sin réplicas disponibles las lecturas van al primario.
+func openReplicas(cfg *config.Config, gormConfig *gorm.Config) (*gorm.DB, []*sql.DB) {
+	var replicas []*sql.DB
+	for i, dsn := range cfg.Database.ReplicaDSNs {
+		sqlDB, err := sql.Open("pgx", dsn)
+		if err != nil {
+			log.Printf("⚠️  Skipping read replica #%d: %v", i+1, err)
+			continue
+		}
+
+		sqlDB.SetMaxOpenConns(cfg.Database.MaxOpenConns)
+		sqlDB.SetMaxIdleConns(cfg.Database.MaxIdleConns)
+		sqlDB.SetConnMaxLifetime(cfg.Database.ConnMaxLifetime)
+		sqlDB.SetConnMaxIdleTime(cfg.Database.ConnMaxIdleTime)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		err = sqlDB.PingContext(ctx)
+		cancel()
+		if err != nil {
+			log.Printf("⚠️  Skipping read replica #%d: failed to ping: %v", i+1, err)
+			sqlDB.Close()
+			continue
+		}
+
+		replicas = append(replicas, sqlDB)
+	}
+
+	if len(replicas) == 0 {
+		return nil, nil
+	}
+
+	readerConfig := *gormConfig
+	readerConfig.SkipDefaultTransaction = true
+
+	reader, err := gorm.Open(postgres.New(postgres.Config{Conn: &replicaPool{replicas: replicas}}), &readerConfig)
+	if err != nil {
+		log.Printf("⚠️  Read replicas disabled: %v", err)
+		closeReplicas(replicas)
+		return nil, nil
+	}
+
+	log.Printf("✅ Connected to %d read replica(s)", len(replicas))
+	return reader, replicas
+}
+
+// closeReplicas cierra las conexiones de las réplicas y devuelve el primer error
+func closeReplicas(replicas []*sql.DB) error {
+	var firstErr error
+	for _, replica := range replicas {
+		if err := replica.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Reader returns the handle for read-only queries: the replicas when configured, the primary otherwise.
+// Solo debe usarse para lecturas que toleran el retraso de replicación (listados, conteos, analíticas).
+func (db *DB) Reader() *gorm.DB {
+	if db.reader != nil {
+		return db.reader
+	}
+	return db.DB
+}
+
+// ReplicaCount returns how many read replicas are in use
+func (db *DB) ReplicaCount() int {
+	return len(db.replicas)
+}
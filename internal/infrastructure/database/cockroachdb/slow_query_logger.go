@@ -0,0 +1,172 @@
+package cockroachdb
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/utils"
+)
+
+// defaultSlowQueryBufferSize is used when the configured buffer size is not positive
+const defaultSlowQueryBufferSize = 100
+
+// maxRecordedSQLLength evita guardar sentencias enormes (p. ej. INSERT masivos) en memoria
+const maxRecordedSQLLength = 2000
+
+// requestIDResolver extrae el request ID del contexto de la query. La capa REST registra el suyo
+// con SetRequestIDResolver; por defecto se busca la key "request_id" de tipo string.
+var requestIDResolver = func(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if requestID, ok := ctx.Value("request_id").(string); ok {
+		return requestID
+	}
+	return ""
+}
+
+// SetRequestIDResolver sets how the request ID is read from the context of slow queries
+func SetRequestIDResolver(resolver func(ctx context.Context) string) {
+	if resolver != nil {
+		requestIDResolver = resolver
+	}
+}
+
+// SlowQuery is a query that took longer than the configured threshold
+type SlowQuery struct {
+	SQL        string        `json:"sql"`
+	Duration   time.Duration `json:"duration"`
+	Rows       int64         `json:"rows"`
+	RequestID  string        `json:"request_id,omitempty"`
+	Source     string        `json:"source,omitempty"` // Archivo:línea del repositorio que lanzó la query
+	Error      string        `json:"error,omitempty"`
+	OccurredAt time.Time     `json:"occurred_at"`
+}
+
+// SlowQueryRecorder keeps the most recent slow queries in a fixed-size ring buffer
+type SlowQueryRecorder struct {
+	threshold time.Duration
+
+	mu      sync.Mutex
+	entries []SlowQuery
+	next    int
+	total   int64
+}
+
+// NewSlowQueryRecorder creates a recorder; a threshold of zero disables recording
+func NewSlowQueryRecorder(threshold time.Duration, bufferSize int) *SlowQueryRecorder {
+	if bufferSize <= 0 {
+		bufferSize = defaultSlowQueryBufferSize
+	}
+	return &SlowQueryRecorder{
+		threshold: threshold,
+		entries:   make([]SlowQuery, 0, bufferSize),
+	}
+}
+
+// Enabled reports whether slow queries are being recorded
+func (r *SlowQueryRecorder) Enabled() bool {
+	return r != nil && r.threshold > 0
+}
+
+// Threshold returns the duration from which a query is considered slow
+func (r *SlowQueryRecorder) Threshold() time.Duration {
+	return r.threshold
+}
+
+// Total returns how many slow queries have been recorded since startup, including evicted ones
+func (r *SlowQueryRecorder) Total() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.total
+}
+
+// Record adds a slow query, overwriting the oldest one when the buffer is full
+func (r *SlowQueryRecorder) Record(query SlowQuery) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.total++
+	if len(r.entries) < cap(r.entries) {
+		r.entries = append(r.entries, query)
+		return
+	}
+	r.entries[r.next] = query
+	r.next = (r.next + 1) % len(r.entries)
+}
+
+// Recent returns up to limit slow queries, newest first (limit <= 0 returns all of them)
+func (r *SlowQueryRecorder) Recent(limit int) []SlowQuery {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := len(r.entries)
+	if limit <= 0 || limit > count {
+		limit = count
+	}
+
+	// Hasta llenarse la más nueva es la última; después, la anterior a r.next
+	newest := count - 1
+	if count == cap(r.entries) {
+		newest = (r.next - 1 + count) % count
+	}
+
+	result := make([]SlowQuery, 0, limit)
+	for i := 0; i < limit; i++ {
+		result = append(result, r.entries[(newest-i+count)%count])
+	}
+	return result
+}
+
+// slowQueryLogger wraps the GORM logger to record and log queries above the threshold with their request ID
+type slowQueryLogger struct {
+	logger.Interface
+	recorder *SlowQueryRecorder
+}
+
+// newSlowQueryLogger devuelve el logger base tal cual si el registro de queries lentas está desactivado
+func newSlowQueryLogger(base logger.Interface, recorder *SlowQueryRecorder) logger.Interface {
+	if !recorder.Enabled() {
+		return base
+	}
+	return &slowQueryLogger{Interface: base, recorder: recorder}
+}
+
+// LogMode keeps the recorder when GORM changes the log level (e.g. db.Debug())
+func (l *slowQueryLogger) LogMode(level logger.LogLevel) logger.Interface {
+	return &slowQueryLogger{Interface: l.Interface.LogMode(level), recorder: l.recorder}
+}
+
+// Trace implements logger.Interface
+func (l *slowQueryLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	l.Interface.Trace(ctx, begin, fc, err)
+
+	elapsed := time.Since(begin)
+	if elapsed < l.recorder.threshold {
+		return
+	}
+
+	sql, rows := fc()
+	if len(sql) > maxRecordedSQLLength {
+		sql = sql[:maxRecordedSQLLength] + "..."
+	}
+
+	query := SlowQuery{
+		SQL:        sql,
+		Duration:   elapsed,
+		Rows:       rows,
+		RequestID:  requestIDResolver(ctx),
+		Source:     utils.FileWithLineNum(),
+		OccurredAt: begin,
+	}
+	if err != nil {
+		query.Error = err.Error()
+	}
+	l.recorder.Record(query)
+
+	log.Printf("🐢 Slow query (%s >= %s) request_id=%s source=%s rows=%d: %s",
+		elapsed, l.recorder.threshold, query.RequestID, query.Source, rows, sql)
+}
@@ -0,0 +1,40 @@
+// Package eventbus implementa el bus de eventos de dominio: en memoria (un solo proceso) o sobre NATS
+// (todas las instancias y otros servicios)
+package eventbus
+
+import (
+	"fmt"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/events"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/config"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// DefaultBufferSize is the number of undelivered events the in-memory bus keeps when none is configured
+const DefaultBufferSize = 1024
+
+// New creates the event bus selected by EVENTS_BACKEND
+func New(cfg *config.Config, appLogger logger.Logger) (events.Bus, error) {
+	switch cfg.Events.Backend {
+	case config.EventsBackendMemory, "":
+		return NewMemoryBus(cfg.Events.BufferSize, appLogger), nil
+	case config.EventsBackendNATS:
+		return NewNATSBus(NATSOptions{
+			URL:           cfg.Events.NATSURL,
+			SubjectPrefix: cfg.Events.SubjectPrefix,
+			QueueGroup:    cfg.Events.QueueGroup,
+			ClientName:    cfg.App.Name,
+		}, appLogger)
+	default:
+		return nil, fmt.Errorf("unknown event bus backend %q (expected %s or %s)",
+			cfg.Events.Backend, config.EventsBackendMemory, config.EventsBackendNATS)
+	}
+}
+
+// Subject returns the NATS subject of an event type: <prefix>.<type>, or just the type without prefix
+func Subject(prefix string, eventType events.Type) string {
+	if prefix == "" {
+		return string(eventType)
+	}
+	return prefix + "." + string(eventType)
+}
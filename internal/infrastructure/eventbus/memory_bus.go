@@ -0,0 +1,144 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/events"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// memoryBus entrega los eventos a los handlers del mismo proceso. Publish no bloquea: los eventos se encolan
+// en un buffer y un único dispatcher los entrega en orden; con el buffer lleno el evento se descarta
+type memoryBus struct {
+	mu       sync.RWMutex
+	handlers map[events.Type]map[uint64]events.Handler
+	nextID   uint64
+	closed   bool
+
+	queue  chan events.Event
+	done   chan struct{}
+	logger logger.Logger
+}
+
+// NewMemoryBus creates an in-process bus buffering up to bufferSize undelivered events
+func NewMemoryBus(bufferSize int, appLogger logger.Logger) events.Bus {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+
+	bus := &memoryBus{
+		handlers: make(map[events.Type]map[uint64]events.Handler),
+		queue:    make(chan events.Event, bufferSize),
+		done:     make(chan struct{}),
+		logger:   appLogger,
+	}
+	go bus.dispatch()
+	return bus
+}
+
+// Publish encola los eventos para su entrega
+func (b *memoryBus) Publish(ctx context.Context, evts ...events.Event) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		return events.ErrBusClosed
+	}
+
+	dropped := 0
+	for _, event := range evts {
+		select {
+		case b.queue <- event:
+		default:
+			dropped++
+		}
+	}
+
+	if dropped > 0 {
+		b.logger.Warn(ctx, "Event bus buffer full, events dropped",
+			logger.Int("dropped", dropped),
+			logger.Int("buffer_size", cap(b.queue)),
+		)
+		return fmt.Errorf("event bus buffer full: %d of %d events dropped", dropped, len(evts))
+	}
+	return nil
+}
+
+// Subscribe registra el handler para el tipo de evento
+func (b *memoryBus) Subscribe(eventType events.Type, handler events.Handler) (func(), error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil, events.ErrBusClosed
+	}
+
+	b.nextID++
+	id := b.nextID
+	if b.handlers[eventType] == nil {
+		b.handlers[eventType] = make(map[uint64]events.Handler)
+	}
+	b.handlers[eventType][id] = handler
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.handlers[eventType], id)
+	}, nil
+}
+
+// Close deja de aceptar eventos y espera a que se entreguen los encolados
+func (b *memoryBus) Close() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	close(b.queue)
+	b.mu.Unlock()
+
+	<-b.done
+	return nil
+}
+
+// dispatch entrega los eventos encolados hasta que se cierra el bus
+func (b *memoryBus) dispatch() {
+	defer close(b.done)
+	for event := range b.queue {
+		b.deliver(event)
+	}
+}
+
+// deliver llama a los handlers suscritos al tipo del evento
+func (b *memoryBus) deliver(event events.Event) {
+	b.mu.RLock()
+	handlers := make([]events.Handler, 0, len(b.handlers[event.Type]))
+	for _, handler := range b.handlers[event.Type] {
+		handlers = append(handlers, handler)
+	}
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handle(context.Background(), handler, event, b.logger)
+	}
+}
+
+// handle ejecuta un handler aislando sus errores y panics del resto de suscriptores
+func handle(ctx context.Context, handler events.Handler, event events.Event, appLogger logger.Logger) {
+	defer func() {
+		if r := recover(); r != nil {
+			appLogger.Error(ctx, "Event handler panicked", fmt.Errorf("panic: %v", r),
+				logger.String("event_type", string(event.Type)),
+				logger.String("event_id", event.ID.String()),
+			)
+		}
+	}()
+
+	if err := handler(ctx, event); err != nil {
+		appLogger.Error(ctx, "Event handler failed", err,
+			logger.String("event_type", string(event.Type)),
+			logger.String("event_id", event.ID.String()),
+		)
+	}
+}
@@ -0,0 +1,151 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/events"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// natsDrainTimeout limita la espera a que se entreguen los mensajes pendientes al cerrar
+const natsDrainTimeout = 10 * time.Second
+
+// natsBus publica los eventos como JSON en el subject <prefijo>.<tipo>, así que los suscriptores de cualquier
+// instancia (o de otros servicios) los reciben. Con queue group cada evento lo procesa una sola instancia
+type natsBus struct {
+	conn   *nats.Conn
+	prefix string
+	queue  string
+	closed chan struct{}
+	once   sync.Once
+	logger logger.Logger
+}
+
+// NATSOptions configura la conexión del bus de NATS
+type NATSOptions struct {
+	URL           string
+	SubjectPrefix string
+	QueueGroup    string // Vacío: cada instancia recibe todos los eventos
+	ClientName    string
+}
+
+// NewNATSBus connects to the NATS server; the client reconnects on its own after the initial connection
+func NewNATSBus(options NATSOptions, appLogger logger.Logger) (events.Bus, error) {
+	bus := &natsBus{
+		prefix: options.SubjectPrefix,
+		queue:  options.QueueGroup,
+		closed: make(chan struct{}),
+		logger: appLogger,
+	}
+
+	conn, err := nats.Connect(options.URL,
+		nats.Name(options.ClientName),
+		nats.MaxReconnects(-1),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			if err != nil {
+				appLogger.Warn(context.Background(), "Event bus disconnected from NATS",
+					logger.String("error", err.Error()))
+			}
+		}),
+		nats.ReconnectHandler(func(conn *nats.Conn) {
+			appLogger.Info(context.Background(), "Event bus reconnected to NATS",
+				logger.String("url", conn.ConnectedUrlRedacted()))
+		}),
+		nats.ClosedHandler(func(*nats.Conn) {
+			bus.once.Do(func() { close(bus.closed) })
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS at %s: %w", options.URL, err)
+	}
+	bus.conn = conn
+
+	return bus, nil
+}
+
+// Publish envía cada evento a su subject
+func (b *natsBus) Publish(ctx context.Context, evts ...events.Event) error {
+	if b.conn.IsClosed() || b.conn.IsDraining() {
+		return events.ErrBusClosed
+	}
+
+	for _, event := range evts {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to encode event %s: %w", event.ID, err)
+		}
+		if err := b.conn.Publish(b.subject(event.Type), data); err != nil {
+			return fmt.Errorf("failed to publish event %s: %w", event.ID, err)
+		}
+	}
+	return nil
+}
+
+// Subscribe suscribe el handler al subject del tipo de evento
+func (b *natsBus) Subscribe(eventType events.Type, handler events.Handler) (func(), error) {
+	if b.conn.IsClosed() || b.conn.IsDraining() {
+		return nil, events.ErrBusClosed
+	}
+
+	onMessage := func(msg *nats.Msg) {
+		var event events.Event
+		if err := json.Unmarshal(msg.Data, &event); err != nil {
+			b.logger.Warn(context.Background(), "Discarding malformed event",
+				logger.String("subject", msg.Subject),
+				logger.String("error", err.Error()))
+			return
+		}
+		handle(context.Background(), handler, event, b.logger)
+	}
+
+	var (
+		subscription *nats.Subscription
+		err          error
+	)
+	if b.queue != "" {
+		subscription, err = b.conn.QueueSubscribe(b.subject(eventType), b.queue, onMessage)
+	} else {
+		subscription, err = b.conn.Subscribe(b.subject(eventType), onMessage)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to %s: %w", eventType, err)
+	}
+
+	return func() {
+		if err := subscription.Unsubscribe(); err != nil && !b.conn.IsClosed() {
+			b.logger.Warn(context.Background(), "Failed to unsubscribe from events",
+				logger.String("event_type", string(eventType)),
+				logger.String("error", err.Error()))
+		}
+	}, nil
+}
+
+// Close entrega los mensajes pendientes (drain) y cierra la conexión
+func (b *natsBus) Close() error {
+	if b.conn.IsClosed() {
+		return nil
+	}
+	if err := b.conn.Drain(); err != nil {
+		b.conn.Close()
+		return fmt.Errorf("failed to drain NATS connection: %w", err)
+	}
+
+	select {
+	case <-b.closed:
+		return nil
+	case <-time.After(natsDrainTimeout):
+		b.conn.Close()
+		return fmt.Errorf("timed out draining NATS connection after %s", natsDrainTimeout)
+	}
+}
+
+// subject devuelve el subject de un tipo de evento
+func (b *natsBus) subject(eventType events.Type) string {
+	return Subject(b.prefix, eventType)
+}
@@ -0,0 +1,278 @@
+// Package contract comprueba que los modelos de respuesta de Finnhub y Alpha Vantage siguen coincidiendo con la
+// forma de los payloads reales (presencia y tipo de los campos). Las comprobaciones llaman a las APIs, así que
+// solo se ejecutan desde el comando y los tests compilados con el build tag "contract"
+package contract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/alphavantage"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/finnhub"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// Proveedores comprobados
+const (
+	ProviderFinnhub      = entities.PayloadProviderFinnhub
+	ProviderAlphaVantage = entities.PayloadProviderAlphaVantage
+)
+
+// Estados del resultado de una comprobación
+const (
+	StatusOK      = "ok"      // El payload coincide con el modelo
+	StatusDrift   = "drift"   // Hay campos ausentes o con otro tipo
+	StatusSkipped = "skipped" // La key no tiene acceso al endpoint (premium)
+	StatusError   = "error"   // La petición falló (red, key inválida, límite de llamadas)
+)
+
+// Check is a provider endpoint and the model our client decodes it into
+type Check struct {
+	Provider string     `json:"provider"`
+	Endpoint string     `json:"endpoint"` // Ruta de Finnhub o función de Alpha Vantage
+	Params   url.Values `json:"-"`
+	Model    any        `json:"-"` // Valor del tipo de respuesta, p. ej. finnhub.QuoteResponse{}
+}
+
+// Result is the outcome of a check
+type Result struct {
+	Provider  string        `json:"provider"`
+	Endpoint  string        `json:"endpoint"`
+	Model     string        `json:"model"`
+	Status    string        `json:"status"`
+	Drifts    []Drift       `json:"drifts,omitempty"`
+	NewFields int           `json:"new_fields"` // Campos que el modelo no conoce (solo listados con ReportNewFields)
+	Error     string        `json:"error,omitempty"`
+	Duration  time.Duration `json:"duration_ns"`
+}
+
+// Summary counts the results by status
+type Summary struct {
+	Total   int `json:"total"`
+	OK      int `json:"ok"`
+	Drift   int `json:"drift"`
+	Skipped int `json:"skipped"`
+	Error   int `json:"error"`
+}
+
+// Report is the outcome of a contract run
+type Report struct {
+	Symbol      string    `json:"symbol"`
+	GeneratedAt time.Time `json:"generated_at"`
+	Summary     Summary   `json:"summary"`
+	Results     []Result  `json:"results"`
+}
+
+// Failed reports whether any check found drift or could not run
+func (r *Report) Failed() bool {
+	return r.Summary.Drift > 0 || r.Summary.Error > 0
+}
+
+// DefaultChecks returns the checks of every endpoint our clients call, for symbol
+func DefaultChecks(symbol string, now time.Time) []Check {
+	symbol = strings.ToUpper(symbol)
+	bySymbol := url.Values{"symbol": {symbol}}
+	indicator := url.Values{"symbol": {symbol}, "interval": {"daily"}, "time_period": {"14"}, "series_type": {"close"}}
+
+	return []Check{
+		{Provider: ProviderFinnhub, Endpoint: "/quote", Params: bySymbol, Model: finnhub.QuoteResponse{}},
+		{Provider: ProviderFinnhub, Endpoint: "/stock/profile2", Params: bySymbol, Model: finnhub.CompanyProfileResponse{}},
+		{Provider: ProviderFinnhub, Endpoint: "/company-news", Params: url.Values{
+			"symbol": {symbol},
+			"from":   {now.AddDate(0, 0, -7).Format("2006-01-02")},
+			"to":     {now.Format("2006-01-02")},
+		}, Model: finnhub.NewsResponse{}},
+		{Provider: ProviderFinnhub, Endpoint: "/stock/metric", Params: url.Values{"symbol": {symbol}, "metric": {"all"}}, Model: finnhub.BasicFinancialsResponse{}},
+		{Provider: ProviderFinnhub, Endpoint: "/stock/recommendation", Params: bySymbol, Model: finnhub.RecommendationTrendsResponse{}},
+		{Provider: ProviderFinnhub, Endpoint: "/stock/peers", Params: bySymbol, Model: finnhub.PeersResponse{}},
+		{Provider: ProviderFinnhub, Endpoint: "/stock/earnings", Params: bySymbol, Model: finnhub.EarningsResponse{}},
+
+		{Provider: ProviderAlphaVantage, Endpoint: "TIME_SERIES_DAILY_ADJUSTED", Params: url.Values{"symbol": {symbol}, "outputsize": {"compact"}}, Model: alphavantage.TimeSeriesDailyResponse{}},
+		{Provider: ProviderAlphaVantage, Endpoint: "TIME_SERIES_WEEKLY_ADJUSTED", Params: bySymbol, Model: alphavantage.TimeSeriesWeeklyResponse{}},
+		{Provider: ProviderAlphaVantage, Endpoint: "TIME_SERIES_MONTHLY_ADJUSTED", Params: bySymbol, Model: alphavantage.TimeSeriesMonthlyResponse{}},
+		{Provider: ProviderAlphaVantage, Endpoint: "OVERVIEW", Params: bySymbol, Model: alphavantage.CompanyOverviewResponse{}},
+		{Provider: ProviderAlphaVantage, Endpoint: "EARNINGS", Params: bySymbol, Model: alphavantage.EarningsResponse{}},
+		{Provider: ProviderAlphaVantage, Endpoint: "INCOME_STATEMENT", Params: bySymbol, Model: alphavantage.IncomeStatementResponse{}},
+		{Provider: ProviderAlphaVantage, Endpoint: "BALANCE_SHEET", Params: bySymbol, Model: alphavantage.BalanceSheetResponse{}},
+		{Provider: ProviderAlphaVantage, Endpoint: "CASH_FLOW", Params: bySymbol, Model: alphavantage.CashFlowResponse{}},
+		{Provider: ProviderAlphaVantage, Endpoint: "RSI", Params: indicator, Model: alphavantage.RSIResponse{}},
+	}
+}
+
+// Endpoint is the base URL and API key of a provider (sandbox or live)
+type Endpoint struct {
+	BaseURL string
+	APIKey  string
+}
+
+// RunnerConfig represents the configuration of a contract runner
+type RunnerConfig struct {
+	HTTPClient      *http.Client
+	Finnhub         Endpoint
+	AlphaVantage    Endpoint
+	Interval        time.Duration // Pausa entre comprobaciones del mismo proveedor (límites por minuto de la capa gratuita)
+	ReportNewFields bool          // Lista también los campos nuevos, que no rompen la decodificación
+	Logger          logger.Logger
+}
+
+// Runner runs contract checks against the provider APIs
+type Runner struct {
+	config RunnerConfig
+}
+
+// NewRunner creates a contract runner
+func NewRunner(config RunnerConfig) *Runner {
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &Runner{config: config}
+}
+
+// Run executes the checks in order and returns the report. symbol only labels the report
+func (r *Runner) Run(ctx context.Context, symbol string, checks []Check) *Report {
+	report := &Report{Symbol: strings.ToUpper(symbol), GeneratedAt: time.Now().UTC()}
+	lastCall := make(map[string]time.Time)
+
+	for _, check := range checks {
+		if ctx.Err() != nil {
+			break
+		}
+		if last, ok := lastCall[check.Provider]; ok && r.config.Interval > 0 {
+			select {
+			case <-time.After(time.Until(last.Add(r.config.Interval))):
+			case <-ctx.Done():
+			}
+		}
+		lastCall[check.Provider] = time.Now()
+
+		result := r.runCheck(ctx, check)
+		report.Results = append(report.Results, result)
+
+		report.Summary.Total++
+		switch result.Status {
+		case StatusOK:
+			report.Summary.OK++
+		case StatusDrift:
+			report.Summary.Drift++
+		case StatusSkipped:
+			report.Summary.Skipped++
+		default:
+			report.Summary.Error++
+		}
+	}
+	return report
+}
+
+// runCheck pide el payload crudo y lo compara con el modelo
+func (r *Runner) runCheck(ctx context.Context, check Check) Result {
+	start := time.Now()
+	result := Result{Provider: check.Provider, Endpoint: check.Endpoint, Model: fmt.Sprintf("%T", check.Model)}
+
+	body, err := r.fetch(ctx, check)
+	result.Duration = time.Since(start)
+	switch {
+	case errors.Is(err, alphavantage.ErrPremiumEndpoint), errors.Is(err, errForbidden):
+		result.Status = StatusSkipped
+		result.Error = err.Error()
+		return result
+	case err != nil:
+		result.Status = StatusError
+		result.Error = err.Error()
+		r.config.Logger.Warn(ctx, "Contract check could not run",
+			logger.String("provider", check.Provider),
+			logger.String("endpoint", check.Endpoint),
+			logger.String("error", err.Error()))
+		return result
+	}
+
+	drifts, err := CompareShape(body, check.Model)
+	if err != nil {
+		result.Status = StatusError
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Status = StatusOK
+	for _, drift := range drifts {
+		if !drift.Breaking() {
+			result.NewFields++
+			if !r.config.ReportNewFields {
+				continue
+			}
+		} else {
+			result.Status = StatusDrift
+		}
+		result.Drifts = append(result.Drifts, drift)
+	}
+
+	if result.Status == StatusDrift {
+		r.config.Logger.Warn(ctx, "Provider payload drifted from the response model",
+			logger.String("provider", check.Provider),
+			logger.String("endpoint", check.Endpoint),
+			logger.String("model", result.Model),
+			logger.Int("drifts", len(result.Drifts)))
+	}
+	return result
+}
+
+// errForbidden es la respuesta 403 de Finnhub a los endpoints fuera del plan de la key
+var errForbidden = errors.New("endpoint not available for this API key")
+
+// fetch hace la petición GET del check y devuelve el cuerpo si trae datos
+func (r *Runner) fetch(ctx context.Context, check Check) ([]byte, error) {
+	query := url.Values{}
+	for key, values := range check.Params {
+		query[key] = values
+	}
+
+	var reqURL string
+	switch check.Provider {
+	case ProviderFinnhub:
+		query.Set("token", r.config.Finnhub.APIKey)
+		reqURL = strings.TrimRight(r.config.Finnhub.BaseURL, "/") + check.Endpoint + "?" + query.Encode()
+	case ProviderAlphaVantage:
+		query.Set("function", check.Endpoint)
+		query.Set("apikey", r.config.AlphaVantage.APIKey)
+		reqURL = r.config.AlphaVantage.BaseURL + "?" + query.Encode()
+	default:
+		return nil, fmt.Errorf("unknown provider %q", check.Provider)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := r.config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusForbidden:
+		return nil, errForbidden
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("status %d: %s", resp.StatusCode, body[:min(200, len(body))])
+	}
+
+	if check.Provider == ProviderAlphaVantage {
+		if err := alphavantage.CheckResponse(body); err != nil {
+			return nil, err
+		}
+	}
+	return body, nil
+}
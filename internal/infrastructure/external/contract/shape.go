@@ -0,0 +1,226 @@
+package contract
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Tipos de desviación entre un payload y el modelo que lo decodifica
+const (
+	DriftMissingField = "missing_field" // El modelo espera un campo que el payload ya no trae
+	DriftTypeMismatch = "type_mismatch" // El campo existe pero con otro tipo JSON
+	DriftNewField     = "new_field"     // El payload trae un campo que el modelo no conoce (no rompe nada)
+)
+
+// Drift is a difference between a live payload and our response model
+type Drift struct {
+	Path     string `json:"path"` // Ruta del campo: "metric.beta", "Time Series (Daily).*.1. open", "[].headline"
+	Kind     string `json:"kind"`
+	Expected string `json:"expected,omitempty"` // Tipo JSON que espera el modelo
+	Actual   string `json:"actual,omitempty"`   // Tipo JSON del payload
+}
+
+// Breaking reports whether the drift can break the decoding or leave fields silently empty
+func (d Drift) Breaking() bool {
+	return d.Kind != DriftNewField
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	rawMessageType      = reflect.TypeOf(json.RawMessage{})
+)
+
+// CompareShape compares the JSON payload with the shape of model (a value or pointer of the response type):
+// presence of the fields without omitempty and JSON type of every field. Arrays and maps are checked element
+// by element and each drift is reported once per path
+func CompareShape(payload []byte, model any) ([]Drift, error) {
+	var document any
+	if err := json.Unmarshal(payload, &document); err != nil {
+		return nil, fmt.Errorf("payload is not JSON: %w", err)
+	}
+
+	c := &shapeComparer{seen: make(map[string]bool)}
+	c.compare("", reflect.TypeOf(model), document)
+
+	sort.Slice(c.drifts, func(i, j int) bool {
+		if c.drifts[i].Path != c.drifts[j].Path {
+			return c.drifts[i].Path < c.drifts[j].Path
+		}
+		return c.drifts[i].Kind < c.drifts[j].Kind
+	})
+	return c.drifts, nil
+}
+
+type shapeComparer struct {
+	drifts []Drift
+	seen   map[string]bool
+}
+
+func (c *shapeComparer) add(drift Drift) {
+	key := drift.Path + "|" + drift.Kind
+	if c.seen[key] {
+		return
+	}
+	c.seen[key] = true
+	c.drifts = append(c.drifts, drift)
+}
+
+func (c *shapeComparer) compare(path string, t reflect.Type, value any) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	// null decodifica al valor cero de cualquier tipo
+	if value == nil || acceptsAnything(t) {
+		return
+	}
+
+	expected := jsonKind(t)
+	actual := jsonKindOf(value)
+	if expected != actual {
+		c.add(Drift{Path: displayPath(path), Kind: DriftTypeMismatch, Expected: expected, Actual: actual})
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		c.compareObject(path, t, value.(map[string]any))
+	case reflect.Map:
+		for _, element := range value.(map[string]any) {
+			c.compare(join(path, "*"), t.Elem(), element)
+		}
+	case reflect.Slice, reflect.Array:
+		for _, element := range value.([]any) {
+			c.compare(path+"[]", t.Elem(), element)
+		}
+	}
+}
+
+func (c *shapeComparer) compareObject(path string, t reflect.Type, object map[string]any) {
+	fields := jsonFields(t)
+	for name, field := range fields {
+		value, ok := object[name]
+		if !ok {
+			if !field.optional {
+				c.add(Drift{Path: join(path, name), Kind: DriftMissingField, Expected: jsonKind(field.typ)})
+			}
+			continue
+		}
+		c.compare(join(path, name), field.typ, value)
+	}
+
+	for name, value := range object {
+		if _, ok := fields[name]; !ok {
+			c.add(Drift{Path: join(path, name), Kind: DriftNewField, Actual: jsonKindOf(value)})
+		}
+	}
+}
+
+type jsonField struct {
+	typ      reflect.Type
+	optional bool
+}
+
+// jsonFields devuelve los campos JSON del struct, con los structs embebidos sin tag aplanados como hace encoding/json
+func jsonFields(t reflect.Type) map[string]jsonField {
+	fields := make(map[string]jsonField)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for embeddedName, embeddedField := range jsonFields(embedded) {
+					fields[embeddedName] = embeddedField
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = jsonField{typ: field.Type, optional: strings.Contains(options, "omitempty")}
+	}
+	return fields
+}
+
+// acceptsAnything indica los tipos que decodifican cualquier valor JSON o lo hacen a su manera
+func acceptsAnything(t reflect.Type) bool {
+	if t.Kind() == reflect.Interface || t == rawMessageType {
+		return true
+	}
+	pointer := reflect.PointerTo(t)
+	return pointer.Implements(jsonUnmarshalerType) || pointer.Implements(textUnmarshalerType)
+}
+
+// jsonKind es el tipo JSON en el que encoding/json espera el tipo Go
+func jsonKind(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Struct, reflect.Map:
+		return "object"
+	default:
+		return "any"
+	}
+}
+
+// jsonKindOf es el tipo JSON de un valor decodificado en any
+func jsonKindOf(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return "any"
+	}
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "$"
+	}
+	return path
+}
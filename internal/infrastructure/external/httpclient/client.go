@@ -0,0 +1,232 @@
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/config"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// Valores por defecto cuando la configuración los deja a cero
+const (
+	DefaultTimeout        = 30 * time.Second
+	DefaultInitialBackoff = 500 * time.Millisecond
+	DefaultMaxBackoff     = 10 * time.Second
+)
+
+// Options configures the HTTP client of an external provider
+type Options struct {
+	Timeout        time.Duration // Tiempo total de la petición, reintentos incluidos
+	MaxRetries     int           // Reintentos tras un 5xx, un 429 o un error de red (0 = sin reintentos)
+	InitialBackoff time.Duration // Espera antes del primer reintento; se duplica en cada uno
+	MaxBackoff     time.Duration // Espera máxima entre reintentos
+	ProxyURL       string        // Proxy HTTP(S) opcional; vacío usa HTTP_PROXY/HTTPS_PROXY del entorno
+	FixtureMode    string        // off (por defecto), record o replay
+	FixtureDir     string        // Directorio de las fixtures grabadas
+}
+
+// FromConfig combines the shared settings of config.External with the timeout of a provider (falling back to
+// the shared one when the provider does not set it)
+func FromConfig(external config.ExternalConfig, provider config.APIConfig) Options {
+	options := Options{
+		Timeout:        external.HTTP.Timeout,
+		MaxRetries:     external.HTTP.MaxRetries,
+		InitialBackoff: external.HTTP.InitialBackoff,
+		MaxBackoff:     external.HTTP.MaxBackoff,
+		ProxyURL:       external.HTTP.ProxyURL,
+		FixtureMode:    external.HTTP.FixtureMode,
+		FixtureDir:     external.HTTP.FixtureDir,
+	}
+	if provider.Timeout > 0 {
+		options.Timeout = provider.Timeout
+	}
+	return options
+}
+
+// New creates an HTTP client over the transport shared by every provider with the same proxy, retrying
+// 5xx responses, 429 responses and network errors with exponential backoff. With FixtureMode record the final
+// responses are also saved as fixtures; with replay they are served from the fixtures without network access
+func New(options Options, log logger.Logger) (*http.Client, error) {
+	if options.Timeout <= 0 {
+		options.Timeout = DefaultTimeout
+	}
+	if options.InitialBackoff <= 0 {
+		options.InitialBackoff = DefaultInitialBackoff
+	}
+	if options.MaxBackoff < options.InitialBackoff {
+		options.MaxBackoff = max(DefaultMaxBackoff, options.InitialBackoff)
+	}
+
+	switch options.FixtureMode {
+	case "", FixtureModeOff, FixtureModeRecord:
+	case FixtureModeReplay:
+		return &http.Client{Timeout: options.Timeout, Transport: &replayTransport{dir: options.FixtureDir}}, nil
+	default:
+		return nil, fmt.Errorf("invalid fixture mode %q (use off, record or replay)", options.FixtureMode)
+	}
+
+	transport, err := sharedTransport(options.ProxyURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var client http.RoundTripper = &retryTransport{
+		next:    transport,
+		options: options,
+		logger:  log,
+	}
+	if options.FixtureMode == FixtureModeRecord {
+		client = &recordTransport{next: client, dir: options.FixtureDir}
+	}
+
+	return &http.Client{Timeout: options.Timeout, Transport: client}, nil
+}
+
+var (
+	transportsMu sync.Mutex
+	transports   = make(map[string]*http.Transport) // Por proxy: los proveedores comparten conexiones
+)
+
+// sharedTransport devuelve el transport del proxy dado, creándolo la primera vez
+func sharedTransport(proxyURL string) (*http.Transport, error) {
+	transportsMu.Lock()
+	defer transportsMu.Unlock()
+
+	if transport, ok := transports[proxyURL]; ok {
+		return transport, nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = 10
+	if proxyURL != "" {
+		proxy, err := url.Parse(proxyURL)
+		if err != nil || proxy.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", proxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	transports[proxyURL] = transport
+	return transport, nil
+}
+
+// retryTransport reintenta las peticiones idempotentes que fallan por causas transitorias
+type retryTransport struct {
+	next    http.RoundTripper
+	options Options
+	logger  logger.Logger
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+
+		wait, retry := t.retryAfter(req, resp, err, attempt)
+		if !retry {
+			return resp, err
+		}
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		status := 0
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		t.logger.Warn(req.Context(), "Retrying external API request",
+			logger.String("host", req.URL.Host),
+			logger.Int("attempt", attempt+1),
+			logger.Int("status", status),
+			logger.String("wait", wait.String()),
+		)
+
+		if err := sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// retryAfter decide si se reintenta y cuánto se espera. Un 429 cuyo proveedor pide esperar más que el backoff
+// máximo no se reintenta: el pool de keys del cliente la aparta y rota a la siguiente
+func (t *retryTransport) retryAfter(req *http.Request, resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	if attempt >= t.options.MaxRetries || !isIdempotent(req) || req.Context().Err() != nil {
+		return 0, false
+	}
+
+	wait := t.backoff(attempt)
+	switch {
+	case err != nil:
+		// Errores de red (conexión rechazada o cortada); la cancelación del contexto ya se descartó arriba
+		return wait, true
+	case resp.StatusCode == http.StatusTooManyRequests:
+		if hint, ok := retryHint(resp.Header); ok {
+			if hint > t.options.MaxBackoff {
+				return 0, false
+			}
+			wait = max(wait, hint)
+		}
+		return wait, true
+	case resp.StatusCode >= 500:
+		return wait, true
+	}
+	return 0, false
+}
+
+// backoff devuelve la espera del reintento attempt: InitialBackoff * 2^attempt, acotada por MaxBackoff y con
+// jitter en la mitad superior para que los clientes no reintenten a la vez
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	wait := t.options.InitialBackoff << attempt
+	if wait <= 0 || wait > t.options.MaxBackoff {
+		wait = t.options.MaxBackoff
+	}
+	half := wait / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// retryHint interpreta Retry-After (segundos o fecha HTTP) y, si no está, X-Ratelimit-Reset (timestamp Unix)
+func retryHint(header http.Header) (time.Duration, bool) {
+	if value := header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if date, err := http.ParseTime(value); err == nil {
+			return time.Until(date), true
+		}
+	}
+	if seconds, err := strconv.ParseInt(header.Get("X-Ratelimit-Reset"), 10, 64); err == nil {
+		return time.Until(time.Unix(seconds, 0)), true
+	}
+	return 0, false
+}
+
+// isIdempotent indica si la petición se puede repetir tal cual: métodos idempotentes sin cuerpo
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions:
+		return req.Body == nil || req.Body == http.NoBody
+	}
+	return false
+}
+
+// sleep espera d o hasta que se cancele el contexto
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,169 @@
+package httpclient
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Modos de las fixtures HTTP (EXTERNAL_HTTP_FIXTURE_MODE)
+const (
+	FixtureModeOff    = "off"
+	FixtureModeRecord = "record" // Llama al proveedor y guarda cada respuesta en una fixture
+	FixtureModeReplay = "replay" // Responde solo con fixtures, sin acceso a la red
+)
+
+// ErrFixtureNotFound is returned in replay mode when no fixture was recorded for the request
+var ErrFixtureNotFound = errors.New("http fixture not found")
+
+// credentialParams son los parámetros con API keys, que nunca llegan a las fixtures
+var credentialParams = []string{"apikey", "token"}
+
+// Fixture is a recorded provider response
+type Fixture struct {
+	Method     string          `json:"method"`
+	URL        string          `json:"url"` // Sin credenciales
+	Status     int             `json:"status"`
+	Header     http.Header     `json:"header,omitempty"`
+	Body       json.RawMessage `json:"body,omitempty"`      // Cuerpos JSON, legibles en la fixture
+	BodyText   string          `json:"body_text,omitempty"` // Cualquier otro cuerpo
+	RecordedAt time.Time       `json:"recorded_at"`
+}
+
+// FixturePath returns the file of the fixture of a request: <dir>/<host>/<path>/<query>.json, with the query
+// sorted and without credentials so the same call always maps to the same file
+func FixturePath(dir string, req *http.Request) string {
+	query := redactedQuery(req.URL).Encode()
+	name := unsafeFileChars.ReplaceAllString(query, "_")
+	if name == "" {
+		name = "_"
+	}
+	if len(name) > 120 {
+		sum := sha256.Sum256([]byte(query))
+		name = hex.EncodeToString(sum[:8])
+	}
+
+	path := strings.Trim(req.URL.Path, "/")
+	if method := strings.ToUpper(req.Method); method != "" && method != http.MethodGet {
+		path = filepath.Join(path, method)
+	}
+	return filepath.Join(dir, req.URL.Host, filepath.FromSlash(path), name+".json")
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9=&._-]`)
+
+// redactedQuery devuelve los parámetros de la URL sin las credenciales
+func redactedQuery(u *url.URL) url.Values {
+	query := u.Query()
+	for _, param := range credentialParams {
+		query.Del(param)
+	}
+	return query
+}
+
+// recordTransport guarda en una fixture la respuesta final de cada petición (tras los reintentos)
+type recordTransport struct {
+	next http.RoundTripper
+	dir  string
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *recordTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	redacted := *req.URL
+	redacted.RawQuery = redactedQuery(req.URL).Encode()
+	fixture := Fixture{
+		Method:     req.Method,
+		URL:        redacted.String(),
+		Status:     resp.StatusCode,
+		Header:     resp.Header.Clone(),
+		RecordedAt: time.Now().UTC(),
+	}
+	fixture.Header.Del("Set-Cookie")
+	if json.Valid(body) {
+		fixture.Body = body
+	} else {
+		fixture.BodyText = string(body)
+	}
+
+	if err := writeFixture(FixturePath(t.dir, req), fixture); err != nil {
+		return nil, fmt.Errorf("failed to record http fixture: %w", err)
+	}
+	return resp, nil
+}
+
+func writeFixture(path string, fixture Fixture) error {
+	data, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// replayTransport responde con las fixtures grabadas y nunca accede a la red
+type replayTransport struct {
+	dir string
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := FixturePath(t.dir, req)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s %s (expected %s)", ErrFixtureNotFound, req.Method, req.URL.Path, path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var fixture Fixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("invalid http fixture %s: %w", path, err)
+	}
+
+	body := []byte(fixture.Body)
+	if len(body) == 0 {
+		body = []byte(fixture.BodyText)
+	}
+	header := fixture.Header
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		StatusCode:    fixture.Status,
+		Status:        fmt.Sprintf("%d %s", fixture.Status, http.StatusText(fixture.Status)),
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
@@ -3,8 +3,6 @@ package alphavantage
 import (
 	"context"
 	"fmt"
-	"strconv"
-	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -40,51 +38,45 @@ func (a *Adapter) TimeSeriesDataToHistoricalData(ctx context.Context, response *
 			continue
 		}
 
-		openPrice, err := strconv.ParseFloat(data.Open, 64)
+		openPrice, err := RequireFloat(data.Open)
 		if err != nil {
 			a.logger.Error(ctx, "Failed to parse open price", err, logger.String("price", data.Open))
 			continue
 		}
 
-		highPrice, err := strconv.ParseFloat(data.High, 64)
+		highPrice, err := RequireFloat(data.High)
 		if err != nil {
 			a.logger.Error(ctx, "Failed to parse high price", err, logger.String("price", data.High))
 			continue
 		}
 
-		lowPrice, err := strconv.ParseFloat(data.Low, 64)
+		lowPrice, err := RequireFloat(data.Low)
 		if err != nil {
 			a.logger.Error(ctx, "Failed to parse low price", err, logger.String("price", data.Low))
 			continue
 		}
 
-		closePrice, err := strconv.ParseFloat(data.Close, 64)
+		closePrice, err := RequireFloat(data.Close)
 		if err != nil {
 			a.logger.Error(ctx, "Failed to parse close price", err, logger.String("price", data.Close))
 			continue
 		}
 		adjustedClose := closePrice // Default to close price
-		if data.AdjustedClose != "" {
-			parsed, err := strconv.ParseFloat(data.AdjustedClose, 64)
-			if err != nil {
-				a.logger.Warn(ctx, "Failed to parse adjusted close, using close price",
-					logger.String("price", data.AdjustedClose),
-					logger.String("date", dateStr))
-			} else {
-				adjustedClose = parsed
-			}
+		if parsed, ok, err := ParseFloat(data.AdjustedClose); err != nil {
+			a.logger.Warn(ctx, "Failed to parse adjusted close, using close price",
+				logger.String("price", data.AdjustedClose),
+				logger.String("date", dateStr))
+		} else if ok {
+			adjustedClose = parsed
 		}
 
 		volume := int64(0) // Default to 0
-		if data.Volume != "" {
-			parsed, err := strconv.ParseInt(data.Volume, 10, 64)
-			if err != nil {
-				a.logger.Warn(ctx, "Failed to parse volume, using default value",
-					logger.String("volume", data.Volume),
-					logger.String("date", dateStr))
-			} else {
-				volume = parsed
-			}
+		if parsed, ok, err := ParseInt(data.Volume); err != nil {
+			a.logger.Warn(ctx, "Failed to parse volume, using default value",
+				logger.String("volume", data.Volume),
+				logger.String("date", dateStr))
+		} else if ok {
+			volume = parsed
 		}
 
 		historical := &entities.HistoricalData{
@@ -119,26 +111,31 @@ func (a *Adapter) TimeSeriesDataToHistoricalData(ctx context.Context, response *
 func (a *Adapter) CompanyOverviewToFinancialMetrics(ctx context.Context, overview *CompanyOverviewResponse, companyID uuid.UUID) (*entities.FinancialMetrics, error) {
 	if overview == nil || overview.Symbol == "" {
 		return nil, fmt.Errorf("invalid company overview response")
-	} // Parse required values
-	marketCap, _ := a.parseNumericString(overview.MarketCapitalization)
-	ebitda, _ := a.parseNumericString(overview.EBITDA)
-	eps, _ := a.parseNumericString(overview.EPS)
-	peRatio, _ := a.parseNumericString(overview.PERatio)
-	pegRatio, _ := a.parseNumericString(overview.PEGRatio)
-	bookValue, _ := a.parseNumericString(overview.BookValue)
-	dividendPerShare, _ := a.parseNumericString(overview.DividendPerShare)
-	dividendYield, _ := a.parseNumericString(overview.DividendYield)
-	profitMargin, _ := a.parseNumericString(overview.ProfitMargin)
-	operatingMarginTTM, _ := a.parseNumericString(overview.OperatingMarginTTM)
-	returnOnAssetsTTM, _ := a.parseNumericString(overview.ReturnOnAssetsTTM)
-	returnOnEquityTTM, _ := a.parseNumericString(overview.ReturnOnEquityTTM)
-	quarterlyEarningsGrowthYOY, _ := a.parseNumericString(overview.QuarterlyEarningsGrowthYOY)
-	quarterlyRevenueGrowthYOY, _ := a.parseNumericString(overview.QuarterlyRevenueGrowthYOY)
-	analystTargetPrice, _ := a.parseNumericString(overview.AnalystTargetPrice)
-	priceToSalesRatioTTM, _ := a.parseNumericString(overview.PriceToSalesRatioTTM)
-	priceToBookRatio, _ := a.parseNumericString(overview.PriceToBookRatio)
-	evToRevenue, _ := a.parseNumericString(overview.EVToRevenue)
-	evToEBITDA, _ := a.parseNumericString(overview.EVToEBITDA)
+	}
+
+	// Los campos sin dato ("None") quedan a cero; los mal formados se registran y también quedan a cero
+	parse := func(field, value string) float64 {
+		return a.optionalFloat(ctx, overview.Symbol, field, value)
+	}
+	marketCap := parse("MarketCapitalization", overview.MarketCapitalization)
+	ebitda := parse("EBITDA", overview.EBITDA)
+	eps := parse("EPS", overview.EPS)
+	peRatio := parse("PERatio", overview.PERatio)
+	pegRatio := parse("PEGRatio", overview.PEGRatio)
+	bookValue := parse("BookValue", overview.BookValue)
+	dividendPerShare := parse("DividendPerShare", overview.DividendPerShare)
+	dividendYield := parse("DividendYield", overview.DividendYield)
+	profitMargin := parse("ProfitMargin", overview.ProfitMargin)
+	operatingMarginTTM := parse("OperatingMarginTTM", overview.OperatingMarginTTM)
+	returnOnAssetsTTM := parse("ReturnOnAssetsTTM", overview.ReturnOnAssetsTTM)
+	returnOnEquityTTM := parse("ReturnOnEquityTTM", overview.ReturnOnEquityTTM)
+	quarterlyEarningsGrowthYOY := parse("QuarterlyEarningsGrowthYOY", overview.QuarterlyEarningsGrowthYOY)
+	quarterlyRevenueGrowthYOY := parse("QuarterlyRevenueGrowthYOY", overview.QuarterlyRevenueGrowthYOY)
+	analystTargetPrice := parse("AnalystTargetPrice", overview.AnalystTargetPrice)
+	priceToSalesRatioTTM := parse("PriceToSalesRatioTTM", overview.PriceToSalesRatioTTM)
+	priceToBookRatio := parse("PriceToBookRatio", overview.PriceToBookRatio)
+	evToRevenue := parse("EVToRevenue", overview.EVToRevenue)
+	evToEBITDA := parse("EVToEBITDA", overview.EVToEBITDA)
 	// Calculate enterprise value
 	enterpriseValue := int64(marketCap)
 	if ebitda > 0 {
@@ -200,7 +197,7 @@ func (a *Adapter) RSIResponseToTechnicalIndicators(ctx context.Context, response
 			continue
 		}
 
-		rsi, err := strconv.ParseFloat(rsiValue.RSI, 64)
+		rsi, err := RequireFloat(rsiValue.RSI)
 		if err != nil {
 			a.logger.Error(ctx, "Failed to parse RSI value", err, logger.String("value", rsiValue.RSI))
 			continue
@@ -249,19 +246,19 @@ func (a *Adapter) MACDResponseToTechnicalIndicators(ctx context.Context, respons
 			continue
 		}
 
-		macd, err := strconv.ParseFloat(macdValues.MACD, 64)
+		macd, err := RequireFloat(macdValues.MACD)
 		if err != nil {
 			a.logger.Error(ctx, "Failed to parse MACD value", err, logger.String("value", macdValues.MACD))
 			continue
 		}
 
-		macdSignal, err := strconv.ParseFloat(macdValues.MACDSignal, 64)
+		macdSignal, err := RequireFloat(macdValues.MACDSignal)
 		if err != nil {
 			a.logger.Error(ctx, "Failed to parse MACD Signal value", err, logger.String("value", macdValues.MACDSignal))
 			continue
 		}
 
-		macdHist, err := strconv.ParseFloat(macdValues.MACDHist, 64)
+		macdHist, err := RequireFloat(macdValues.MACDHist)
 		if err != nil {
 			a.logger.Error(ctx, "Failed to parse MACD Histogram value", err, logger.String("value", macdValues.MACDHist))
 			continue
@@ -314,7 +311,7 @@ func (a *Adapter) SMAResponseToTechnicalIndicators(ctx context.Context, response
 			continue
 		}
 
-		sma, err := strconv.ParseFloat(smaValue.SMA, 64)
+		sma, err := RequireFloat(smaValue.SMA)
 		if err != nil {
 			a.logger.Error(ctx, "Failed to parse SMA value", err, logger.String("value", smaValue.SMA))
 			continue
@@ -377,7 +374,7 @@ func (a *Adapter) EMAResponseToTechnicalIndicators(ctx context.Context, response
 			continue
 		}
 
-		ema, err := strconv.ParseFloat(emaValue.EMA, 64)
+		ema, err := RequireFloat(emaValue.EMA)
 		if err != nil {
 			a.logger.Error(ctx, "Failed to parse EMA value", err, logger.String("value", emaValue.EMA))
 			continue
@@ -490,19 +487,14 @@ func (a *Adapter) ValidateTechnicalIndicators(indicator *entities.TechnicalIndic
 	return nil
 }
 
-// Helper function to parse numeric strings from Alpha Vantage API
-func (a *Adapter) parseNumericString(value string) (float64, error) {
-	if value == "" || value == "None" || value == "-" {
-		return 0, nil
-	}
-
-	// Remove any commas or other formatting
-	cleanValue := strings.ReplaceAll(value, ",", "")
-
-	parsed, err := strconv.ParseFloat(cleanValue, 64)
+// optionalFloat parses an optional numeric field: a missing value is zero and a malformed one is logged and zero
+func (a *Adapter) optionalFloat(ctx context.Context, symbol, field, value string) float64 {
+	parsed, _, err := ParseFloat(value)
 	if err != nil {
-		return 0, err
+		a.logger.Warn(ctx, "Failed to parse Alpha Vantage field, using zero",
+			logger.String("symbol", symbol),
+			logger.String("field", field),
+			logger.String("value", value))
 	}
-
-	return parsed, nil
+	return parsed
 }
@@ -0,0 +1,114 @@
+package alphavantage
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	domainServices "github.com/MayaCris/stock-info-app/internal/domain/services"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// BenchmarkProvider fetches the daily values and holdings of the ETF that replicates a benchmark
+type BenchmarkProvider struct {
+	client *Client
+	logger logger.Logger
+}
+
+// NewBenchmarkProvider creates the Alpha Vantage benchmark provider
+func NewBenchmarkProvider(client *Client, appLogger logger.Logger) domainServices.BenchmarkProvider {
+	return &BenchmarkProvider{
+		client: client,
+		logger: appLogger,
+	}
+}
+
+// Source names the provider
+func (p *BenchmarkProvider) Source() string {
+	return "alpha_vantage"
+}
+
+// FetchValues returns the daily values of the source symbol of the benchmark (one API call). Sessions with a
+// malformed or missing close are skipped
+func (p *BenchmarkProvider) FetchValues(ctx context.Context, benchmark *entities.Benchmark, full bool) ([]*entities.BenchmarkValue, error) {
+	outputSize := "compact"
+	if full {
+		outputSize = "full"
+	}
+
+	resp, err := p.client.GetTimeSeriesDaily(ctx, benchmark.SourceSymbol, outputSize)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]*entities.BenchmarkValue, 0, len(resp.TimeSeries))
+	for dateStr, data := range resp.TimeSeries {
+		date, err := time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			p.logger.Warn(ctx, "Skipping benchmark session with invalid date",
+				logger.String("benchmark", benchmark.Symbol),
+				logger.String("date", dateStr))
+			continue
+		}
+
+		closeValue, err := RequireFloat(data.Close)
+		if err != nil {
+			p.logger.Warn(ctx, "Skipping benchmark session without close",
+				logger.String("benchmark", benchmark.Symbol),
+				logger.String("date", dateStr))
+			continue
+		}
+
+		value := &entities.BenchmarkValue{
+			BenchmarkID: benchmark.ID,
+			Date:        date,
+			Close:       closeValue,
+			Source:      p.Source(),
+		}
+		// El resto de campos es opcional: el endpoint básico no trae cierre ajustado
+		value.Open, _, _ = ParseFloat(data.Open)
+		value.High, _, _ = ParseFloat(data.High)
+		value.Low, _, _ = ParseFloat(data.Low)
+		value.AdjustedClose, _, _ = ParseFloat(data.AdjustedClose)
+		value.Volume, _, _ = ParseInt(data.Volume)
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+// FetchConstituents returns the holdings of the source ETF as the members of the benchmark (one API call).
+// Cash and holdings without a ticker are left out
+func (p *BenchmarkProvider) FetchConstituents(ctx context.Context, benchmark *entities.Benchmark) ([]*entities.BenchmarkConstituent, error) {
+	resp, err := p.client.GetETFProfile(ctx, benchmark.SourceSymbol)
+	if err != nil {
+		return nil, err
+	}
+
+	constituents := make([]*entities.BenchmarkConstituent, 0, len(resp.Holdings))
+	for _, holding := range resp.Holdings {
+		symbol := strings.ToUpper(strings.TrimSpace(holding.Symbol))
+		if IsMissingValue(symbol) {
+			continue
+		}
+
+		constituent := &entities.BenchmarkConstituent{
+			BenchmarkID: benchmark.ID,
+			Symbol:      symbol,
+			Name:        holding.Description,
+		}
+		weight, ok, err := ParseFloat(holding.Weight)
+		if err != nil {
+			p.logger.Warn(ctx, "Ignoring malformed benchmark constituent weight",
+				logger.String("benchmark", benchmark.Symbol),
+				logger.String("symbol", symbol),
+				logger.String("weight", holding.Weight))
+		} else if ok {
+			constituent.Weight = &weight
+		}
+		constituents = append(constituents, constituent)
+	}
+
+	return constituents, nil
+}
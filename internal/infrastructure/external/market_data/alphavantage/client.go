@@ -3,50 +3,106 @@ package alphavantage
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
-	"strings"
 	"time"
 
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/config"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/httpclient"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/archive"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/keypool"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
 )
 
 // Client represents the Alpha Vantage API client
 type Client struct {
 	baseURL    string
-	apiKey     string
+	keys       *keypool.Pool // Pool de API keys, rotable en caliente
 	httpClient *http.Client
+	archiver   *archive.Archiver // Opcional: archivo de respuestas crudas
 	logger     logger.Logger
 }
 
-// NewClient creates a new Alpha Vantage API client
+// NewClient creates a new Alpha Vantage API client over the shared external HTTP client (config.External.HTTP)
 func NewClient(cfg *config.Config, log logger.Logger) *Client {
+	httpClient, err := httpclient.New(httpclient.FromConfig(cfg.External, cfg.External.Secondary), log)
+	if err != nil {
+		log.Error(context.Background(), "Invalid external HTTP client configuration, using defaults", err)
+		httpClient = &http.Client{Timeout: httpclient.DefaultTimeout}
+	}
+
 	return &Client{
-		baseURL: cfg.External.Secondary.BaseURL,
-		apiKey:  cfg.External.Secondary.Key,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		logger: log,
+		baseURL:    cfg.External.Secondary.BaseURL,
+		keys:       keypool.New(cfg.External.Secondary.AllKeys(), cfg.External.KeyStrategy),
+		httpClient: httpClient,
+		logger:     log,
 	}
 }
 
-// makeRequest makes an HTTP request to the Alpha Vantage API
+// SetHTTPClient replaces the HTTP client, e.g. with the transport of the mock provider
+func (c *Client) SetHTTPClient(httpClient *http.Client) {
+	c.httpClient = httpClient
+}
+
+// SetAPIKeys replaces the API keys used by subsequent requests
+func (c *Client) SetAPIKeys(keys []string, strategy string) {
+	c.keys.SetKeys(keys)
+	c.keys.SetStrategy(strategy)
+}
+
+// SetArchiver enables the archival of the raw responses; nil disables it
+func (c *Client) SetArchiver(archiver *archive.Archiver) {
+	c.archiver = archiver
+}
+
+// makeRequest makes an HTTP request to the Alpha Vantage API. Si una key alcanza el límite de
+// llamadas se aparta hasta que se reinicia su ventana y se reintenta con la siguiente del pool.
 func (c *Client) makeRequest(ctx context.Context, function string, params map[string]string) ([]byte, error) {
+	var lastErr error
+	for attempt := 0; attempt < c.keys.Size(); attempt++ {
+		apiKey, err := c.keys.Acquire()
+		if err != nil {
+			return nil, fmt.Errorf("alpha Vantage: %w: %w", ErrRateLimited, err)
+		}
+
+		body, resetAt, err := c.doRequest(ctx, function, params, apiKey)
+		if !errors.Is(err, ErrRateLimited) {
+			return body, err
+		}
+
+		lastErr = err
+		c.keys.Sideline(apiKey, resetAt)
+		c.logger.Warn(ctx, "Alpha Vantage API key rate limited, rotating to next key",
+			logger.String("function", function),
+			logger.String("reset_time", resetAt.Format(time.RFC3339)),
+		)
+	}
+
+	// Todas las keys agotadas: el último APIError conserva el mensaje y cuándo se libera la key
+	if lastErr != nil {
+		return nil, lastErr
+	}
+	return nil, fmt.Errorf("alpha Vantage: %w: %w", ErrRateLimited, keypool.ErrNoAvailableKeys)
+}
+
+// doRequest ejecuta la petición con una key concreta. Los fallos que Alpha Vantage explica en el cuerpo se
+// devuelven como *APIError; con ErrRateLimited también el instante en que la key vuelve a estar disponible.
+func (c *Client) doRequest(ctx context.Context, function string, params map[string]string, apiKey string) ([]byte, time.Time, error) {
 	// Build URL
 	u, err := url.Parse(c.baseURL)
 	if err != nil {
 		c.logger.Error(ctx, "Failed to parse base URL", err, logger.String("baseURL", c.baseURL))
-		return nil, fmt.Errorf("failed to parse base URL: %w", err)
+		return nil, time.Time{}, fmt.Errorf("failed to parse base URL: %w", err)
 	}
 
 	// Add query parameters
 	query := u.Query()
 	query.Set("function", function)
-	query.Set("apikey", c.apiKey)
+	query.Set("apikey", apiKey)
 
 	for key, value := range params {
 		query.Set(key, value)
@@ -58,7 +114,7 @@ func (c *Client) makeRequest(ctx context.Context, function string, params map[st
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
 		c.logger.Error(ctx, "Failed to create request", err, logger.String("url", u.String()))
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, time.Time{}, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set headers
@@ -74,7 +130,7 @@ func (c *Client) makeRequest(ctx context.Context, function string, params map[st
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		c.logger.Error(ctx, "Request failed", err, logger.String("url", u.String()))
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, time.Time{}, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -82,7 +138,7 @@ func (c *Client) makeRequest(ctx context.Context, function string, params map[st
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		c.logger.Error(ctx, "Failed to read response body", err)
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, time.Time{}, fmt.Errorf("failed to read response body: %w", err)
 	}
 
 	// Check HTTP status
@@ -91,29 +147,40 @@ func (c *Client) makeRequest(ctx context.Context, function string, params map[st
 			logger.Int("statusCode", resp.StatusCode),
 			logger.String("status", resp.Status),
 			logger.String("body", string(body)))
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		kind := ErrRequestRejected
+		if resp.StatusCode == http.StatusTooManyRequests {
+			kind = ErrRateLimited
+		}
+		apiErr := &APIError{Kind: kind, Message: fmt.Sprintf("status %d: %s", resp.StatusCode, string(body[:min(200, len(body))]))}
+		if kind == ErrRateLimited {
+			apiErr.RetryAt = rateLimitReset("")
+		}
+		return nil, apiErr.RetryAt, apiErr
 	}
 	// Check for API errors in response
 	var errorCheck AlphaVantageResponse
-	if err := json.Unmarshal(body, &errorCheck); err == nil {
-		if errorCheck.ErrorMessage != "" {
-			c.logger.Error(ctx, "Alpha Vantage API error", fmt.Errorf("API error: %s", errorCheck.ErrorMessage))
-			return nil, fmt.Errorf("alpha Vantage API error: %s", errorCheck.ErrorMessage)
-		}
-		if errorCheck.Information != "" && errorCheck.Information == "Thank you for using Alpha Vantage! Our standard API call frequency is 5 calls per minute and 500 calls per day." {
-			c.logger.Warn(ctx, "Alpha Vantage rate limit information", logger.String("info", errorCheck.Information))
-		}
-		if errorCheck.Note != "" {
-			c.logger.Warn(ctx, "Alpha Vantage API note", logger.String("note", errorCheck.Note))
-			return nil, fmt.Errorf("alpha Vantage API note: %s", errorCheck.Note)
+	if err := json.Unmarshal(body, &errorCheck); err != nil {
+		c.logger.Error(ctx, "Alpha Vantage response is not JSON", err,
+			logger.String("function", function),
+			logger.String("responsePreview", string(body[:min(200, len(body))])))
+		return nil, time.Time{}, malformed(err)
+	}
+	if apiErr := classifyResponse(errorCheck); apiErr != nil {
+		if !errors.Is(apiErr, ErrRateLimited) {
+			c.logger.Warn(ctx, "Alpha Vantage API error",
+				logger.String("function", function),
+				logger.String("error", apiErr.Error()))
 		}
+		return nil, apiErr.RetryAt, apiErr
 	}
 
 	c.logger.Debug(ctx, "Alpha Vantage API request successful",
 		logger.String("function", function),
 		logger.Int("responseSize", len(body)))
 
-	return body, nil
+	c.archiver.Archive(ctx, entities.PayloadProviderAlphaVantage, function, query, "", body, time.Now().UTC())
+
+	return body, time.Time{}, nil
 }
 
 // GetTimeSeriesDaily retrieves daily historical data for a symbol
@@ -123,6 +190,14 @@ func (c *Client) GetTimeSeriesDaily(ctx context.Context, symbol string, outputSi
 		"outputsize": outputSize, // "compact" or "full"
 	}
 	body, err := c.makeRequest(ctx, "TIME_SERIES_DAILY_ADJUSTED", params)
+	if errors.Is(err, ErrPremiumEndpoint) {
+		c.logger.Warn(ctx, "Alpha Vantage premium endpoint access required, falling back to basic endpoint",
+			logger.String("symbol", symbol),
+			logger.String("error", err.Error()))
+
+		// Try the basic (free) endpoint
+		return c.GetTimeSeriesDailyBasic(ctx, symbol, outputSize)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get daily time series for %s: %w", symbol, err)
 	}
@@ -132,7 +207,7 @@ func (c *Client) GetTimeSeriesDaily(ctx context.Context, symbol string, outputSi
 		c.logger.Error(ctx, "Failed to unmarshal daily time series response", err,
 			logger.String("symbol", symbol),
 			logger.String("responsePreview", string(body[:min(500, len(body))])))
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal response: %w", malformed(err))
 	}
 
 	// Log the response content for debugging when it's small or empty
@@ -144,28 +219,8 @@ func (c *Client) GetTimeSeriesDaily(ctx context.Context, symbol string, outputSi
 			logger.String("information", response.Information),
 			logger.String("note", response.Note))
 	}
-	// Check for Alpha Vantage API errors or limits in the response
-	if response.ErrorMessage != "" {
-		c.logger.Error(ctx, "Alpha Vantage API returned error in time series response", nil,
-			logger.String("symbol", symbol),
-			logger.String("error", response.ErrorMessage))
-		return nil, fmt.Errorf("alpha Vantage API error: %s", response.ErrorMessage)
-	}
-
-	if response.Note != "" {
-		c.logger.Warn(ctx, "Alpha Vantage API returned note in time series response",
-			logger.String("symbol", symbol),
-			logger.String("note", response.Note))
-		return nil, fmt.Errorf("alpha Vantage API note: %s", response.Note)
-	}
-	// Check for premium endpoint message and fallback to basic endpoint
-	if response.Information != "" && strings.Contains(response.Information, "premium endpoint") {
-		c.logger.Warn(ctx, "Alpha Vantage premium endpoint access required, falling back to basic endpoint",
-			logger.String("symbol", symbol),
-			logger.String("information", response.Information))
-
-		// Try the basic (free) endpoint
-		return c.GetTimeSeriesDailyBasic(ctx, symbol, outputSize)
+	if len(response.TimeSeries) == 0 {
+		return nil, emptyPayload(symbol)
 	}
 
 	c.logger.Info(ctx, "Successfully retrieved daily time series",
@@ -192,7 +247,7 @@ func (c *Client) GetTimeSeriesDailyBasic(ctx context.Context, symbol string, out
 		c.logger.Error(ctx, "Failed to unmarshal basic daily time series response", err,
 			logger.String("symbol", symbol),
 			logger.String("responsePreview", string(body[:min(500, len(body))])))
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal response: %w", malformed(err))
 	}
 
 	// Log the response content for debugging when it's small or empty
@@ -205,19 +260,8 @@ func (c *Client) GetTimeSeriesDailyBasic(ctx context.Context, symbol string, out
 			logger.String("note", response.Note))
 	}
 
-	// Check for Alpha Vantage API errors or limits in the response
-	if response.ErrorMessage != "" {
-		c.logger.Error(ctx, "Alpha Vantage API returned error in basic time series response", nil,
-			logger.String("symbol", symbol),
-			logger.String("error", response.ErrorMessage))
-		return nil, fmt.Errorf("alpha Vantage API error: %s", response.ErrorMessage)
-	}
-
-	if response.Note != "" {
-		c.logger.Warn(ctx, "Alpha Vantage API returned note in basic time series response",
-			logger.String("symbol", symbol),
-			logger.String("note", response.Note))
-		return nil, fmt.Errorf("alpha Vantage API note: %s", response.Note)
+	if len(response.TimeSeries) == 0 {
+		return nil, emptyPayload(symbol)
 	}
 
 	c.logger.Info(ctx, "Successfully retrieved basic daily time series",
@@ -242,7 +286,7 @@ func (c *Client) GetTimeSeriesWeekly(ctx context.Context, symbol string) (*TimeS
 		c.logger.Error(ctx, "Failed to unmarshal weekly time series response", err,
 			logger.String("symbol", symbol),
 			logger.String("responsePreview", string(body[:min(500, len(body))])))
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal response: %w", malformed(err))
 	}
 
 	c.logger.Info(ctx, "Successfully retrieved weekly time series",
@@ -267,7 +311,7 @@ func (c *Client) GetTimeSeriesMonthly(ctx context.Context, symbol string) (*Time
 		c.logger.Error(ctx, "Failed to unmarshal monthly time series response", err,
 			logger.String("symbol", symbol),
 			logger.String("responsePreview", string(body[:min(500, len(body))])))
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal response: %w", malformed(err))
 	}
 
 	c.logger.Info(ctx, "Successfully retrieved monthly time series",
@@ -292,7 +336,12 @@ func (c *Client) GetCompanyOverview(ctx context.Context, symbol string) (*Compan
 		c.logger.Error(ctx, "Failed to unmarshal company overview response", err,
 			logger.String("symbol", symbol),
 			logger.String("responsePreview", string(body[:min(500, len(body))])))
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal response: %w", malformed(err))
+	}
+
+	// Alpha Vantage responde {} a los símbolos que no conoce
+	if response.Symbol == "" {
+		return nil, emptyPayload(symbol)
 	}
 
 	c.logger.Info(ctx, "Successfully retrieved company overview",
@@ -320,7 +369,7 @@ func (c *Client) GetRSI(ctx context.Context, symbol, interval, timePeriod, serie
 		c.logger.Error(ctx, "Failed to unmarshal RSI response", err,
 			logger.String("symbol", symbol),
 			logger.String("responsePreview", string(body[:min(500, len(body))])))
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal response: %w", malformed(err))
 	}
 
 	c.logger.Info(ctx, "Successfully retrieved RSI",
@@ -351,7 +400,7 @@ func (c *Client) GetMACD(ctx context.Context, symbol, interval, fastPeriod, slow
 		c.logger.Error(ctx, "Failed to unmarshal MACD response", err,
 			logger.String("symbol", symbol),
 			logger.String("responsePreview", string(body[:min(500, len(body))])))
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal response: %w", malformed(err))
 	}
 
 	c.logger.Info(ctx, "Successfully retrieved MACD",
@@ -383,7 +432,7 @@ func (c *Client) GetBollingerBands(ctx context.Context, symbol, interval, timePe
 		c.logger.Error(ctx, "Failed to unmarshal Bollinger Bands response", err,
 			logger.String("error", err.Error()),
 			logger.String("symbol", symbol))
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal response: %w", malformed(err))
 	}
 
 	c.logger.Info(ctx, "Successfully retrieved Bollinger Bands",
@@ -412,7 +461,7 @@ func (c *Client) GetSMA(ctx context.Context, symbol, interval, timePeriod, serie
 	if err := json.Unmarshal(body, &response); err != nil {
 		c.logger.Error(ctx, "Failed to unmarshal SMA response", err,
 			logger.String("symbol", symbol))
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal response: %w", malformed(err))
 	}
 
 	c.logger.Info(ctx, "Successfully retrieved SMA",
@@ -442,7 +491,7 @@ func (c *Client) GetEMA(ctx context.Context, symbol, interval, timePeriod, serie
 	if err := json.Unmarshal(body, &response); err != nil {
 		c.logger.Error(ctx, "Failed to unmarshal EMA response", err,
 			logger.String("symbol", symbol))
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal response: %w", malformed(err))
 	}
 
 	c.logger.Info(ctx, "Successfully retrieved EMA",
@@ -475,7 +524,7 @@ func (c *Client) GetSTOCH(ctx context.Context, symbol, interval, fastkperiod, sl
 	if err := json.Unmarshal(body, &response); err != nil {
 		c.logger.Error(ctx, "Failed to unmarshal STOCH response", err,
 			logger.String("symbol", symbol))
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal response: %w", malformed(err))
 	}
 
 	c.logger.Info(ctx, "Successfully retrieved STOCH",
@@ -503,7 +552,7 @@ func (c *Client) GetADX(ctx context.Context, symbol, interval, timePeriod string
 	if err := json.Unmarshal(body, &response); err != nil {
 		c.logger.Error(ctx, "Failed to unmarshal ADX response", err,
 			logger.String("symbol", symbol))
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal response: %w", malformed(err))
 	}
 
 	c.logger.Info(ctx, "Successfully retrieved ADX",
@@ -531,7 +580,7 @@ func (c *Client) GetCCI(ctx context.Context, symbol, interval, timePeriod string
 	if err := json.Unmarshal(body, &response); err != nil {
 		c.logger.Error(ctx, "Failed to unmarshal CCI response", err,
 			logger.String("symbol", symbol))
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal response: %w", malformed(err))
 	}
 
 	c.logger.Info(ctx, "Successfully retrieved CCI",
@@ -559,7 +608,7 @@ func (c *Client) GetAROON(ctx context.Context, symbol, interval, timePeriod stri
 	if err := json.Unmarshal(body, &response); err != nil {
 		c.logger.Error(ctx, "Failed to unmarshal AROON response", err,
 			logger.String("symbol", symbol))
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal response: %w", malformed(err))
 	}
 
 	c.logger.Info(ctx, "Successfully retrieved AROON",
@@ -585,7 +634,12 @@ func (c *Client) GetEarnings(ctx context.Context, symbol string) (*EarningsRespo
 	if err := json.Unmarshal(body, &response); err != nil {
 		c.logger.Error(ctx, "Failed to unmarshal earnings response", err,
 			logger.String("symbol", symbol))
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal response: %w", malformed(err))
+	}
+
+	// Alpha Vantage responde {} a los símbolos que no conoce
+	if response.Symbol == "" {
+		return nil, emptyPayload(symbol)
 	}
 
 	c.logger.Info(ctx, "Successfully retrieved earnings",
@@ -611,7 +665,12 @@ func (c *Client) GetIncomeStatement(ctx context.Context, symbol string) (*Income
 	if err := json.Unmarshal(body, &response); err != nil {
 		c.logger.Error(ctx, "Failed to unmarshal income statement response", err,
 			logger.String("symbol", symbol))
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal response: %w", malformed(err))
+	}
+
+	// Alpha Vantage responde {} a los símbolos que no conoce
+	if response.Symbol == "" {
+		return nil, emptyPayload(symbol)
 	}
 
 	c.logger.Info(ctx, "Successfully retrieved income statement",
@@ -637,7 +696,12 @@ func (c *Client) GetBalanceSheet(ctx context.Context, symbol string) (*BalanceSh
 	if err := json.Unmarshal(body, &response); err != nil {
 		c.logger.Error(ctx, "Failed to unmarshal balance sheet response", err,
 			logger.String("symbol", symbol))
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal response: %w", malformed(err))
+	}
+
+	// Alpha Vantage responde {} a los símbolos que no conoce
+	if response.Symbol == "" {
+		return nil, emptyPayload(symbol)
 	}
 
 	c.logger.Info(ctx, "Successfully retrieved balance sheet",
@@ -663,7 +727,12 @@ func (c *Client) GetCashFlow(ctx context.Context, symbol string) (*CashFlowRespo
 	if err := json.Unmarshal(body, &response); err != nil {
 		c.logger.Error(ctx, "Failed to unmarshal cash flow response", err,
 			logger.String("symbol", symbol))
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal response: %w", malformed(err))
+	}
+
+	// Alpha Vantage responde {} a los símbolos que no conoce
+	if response.Symbol == "" {
+		return nil, emptyPayload(symbol)
 	}
 
 	c.logger.Info(ctx, "Successfully retrieved cash flow",
@@ -674,6 +743,67 @@ func (c *Client) GetCashFlow(ctx context.Context, symbol string) (*CashFlowRespo
 	return &response, nil
 }
 
+// GetETFProfile retrieves the profile and holdings of an ETF
+func (c *Client) GetETFProfile(ctx context.Context, symbol string) (*ETFProfileResponse, error) {
+	params := map[string]string{
+		"symbol": symbol,
+	}
+
+	body, err := c.makeRequest(ctx, "ETF_PROFILE", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ETF profile for %s: %w", symbol, err)
+	}
+
+	var response ETFProfileResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		c.logger.Error(ctx, "Failed to unmarshal ETF profile response", err,
+			logger.String("symbol", symbol))
+		return nil, fmt.Errorf("failed to unmarshal response: %w", malformed(err))
+	}
+
+	// Los símbolos que no son ETF no tienen holdings
+	if len(response.Holdings) == 0 {
+		return nil, emptyPayload(symbol)
+	}
+
+	c.logger.Info(ctx, "Successfully retrieved ETF profile",
+		logger.String("symbol", symbol),
+		logger.Int("holdings", len(response.Holdings)))
+
+	return &response, nil
+}
+
+// GetCurrencyExchangeRate retrieves the realtime exchange rate from one currency to another
+func (c *Client) GetCurrencyExchangeRate(ctx context.Context, fromCurrency, toCurrency string) (*CurrencyExchangeRateResponse, error) {
+	params := map[string]string{
+		"from_currency": fromCurrency,
+		"to_currency":   toCurrency,
+	}
+
+	pair := fromCurrency + "/" + toCurrency
+	body, err := c.makeRequest(ctx, "CURRENCY_EXCHANGE_RATE", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exchange rate %s: %w", pair, err)
+	}
+
+	var response CurrencyExchangeRateResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		c.logger.Error(ctx, "Failed to unmarshal exchange rate response", err,
+			logger.String("pair", pair))
+		return nil, fmt.Errorf("failed to unmarshal response: %w", malformed(err))
+	}
+
+	if response.Rate.ExchangeRate == "" {
+		return nil, emptyPayload(pair)
+	}
+
+	c.logger.Info(ctx, "Successfully retrieved exchange rate",
+		logger.String("pair", pair),
+		logger.String("rate", response.Rate.ExchangeRate))
+
+	return &response, nil
+}
+
 // HealthCheck verifies the API is accessible
 func (c *Client) HealthCheck(ctx context.Context) error {
 	// Use a known symbol for health check
@@ -0,0 +1,121 @@
+package alphavantage
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/keypool"
+)
+
+// Errores tipados de Alpha Vantage: la API responde casi siempre con HTTP 200 y explica el fallo en los campos
+// Information, Error Message o Note del cuerpo
+var (
+	// ErrRateLimited is returned when Alpha Vantage reports that the call frequency or daily limit was reached
+	// (and no other API key of the pool is available)
+	ErrRateLimited = errors.New("rate limit reached")
+
+	// ErrInvalidSymbol is returned when Alpha Vantage answers "Invalid API call" or an empty payload, which is
+	// how it reports symbols it does not know
+	ErrInvalidSymbol = errors.New("invalid symbol")
+
+	// ErrPremiumEndpoint is returned when the function requires a premium plan
+	ErrPremiumEndpoint = errors.New("premium endpoint")
+
+	// ErrMalformedResponse is returned when the response is not the expected JSON document
+	ErrMalformedResponse = errors.New("malformed response")
+
+	// ErrRequestRejected is returned for any other refusal (invalid or missing API key, bad parameters, HTTP errors)
+	ErrRequestRejected = errors.New("request rejected")
+)
+
+// APIError is an Alpha Vantage failure with the message the provider sent. It matches its Kind with errors.Is
+type APIError struct {
+	Kind    error
+	Message string
+	RetryAt time.Time // Solo con ErrRateLimited: cuándo se libera la key
+}
+
+// Error returns the kind and the provider message
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return "alpha Vantage: " + e.Kind.Error()
+	}
+	return "alpha Vantage: " + e.Kind.Error() + ": " + e.Message
+}
+
+// Unwrap returns the kind of the error
+func (e *APIError) Unwrap() error {
+	return e.Kind
+}
+
+// classifyResponse inspecciona los campos de error del cuerpo y devuelve el APIError correspondiente, o nil si
+// la respuesta trae datos
+func classifyResponse(check AlphaVantageResponse) *APIError {
+	switch {
+	case isRateLimitMessage(check.Note) || isRateLimitMessage(check.Information):
+		message := strings.TrimSpace(check.Note + " " + check.Information)
+		return &APIError{Kind: ErrRateLimited, Message: message, RetryAt: rateLimitReset(message)}
+	case check.ErrorMessage != "":
+		return &APIError{Kind: errorMessageKind(check.ErrorMessage), Message: check.ErrorMessage}
+	case strings.Contains(strings.ToLower(check.Information), "premium"):
+		return &APIError{Kind: ErrPremiumEndpoint, Message: check.Information}
+	case check.Information != "":
+		return &APIError{Kind: ErrRequestRejected, Message: check.Information}
+	case check.Note != "":
+		return &APIError{Kind: ErrRequestRejected, Message: check.Note}
+	}
+	return nil
+}
+
+// CheckResponse returns the APIError described by the error fields of a raw response body, or nil when it
+// carries data (used by callers that read Alpha Vantage payloads without the client, e.g. contract checks)
+func CheckResponse(body []byte) error {
+	var check AlphaVantageResponse
+	if err := json.Unmarshal(body, &check); err != nil {
+		return malformed(err)
+	}
+	if apiErr := classifyResponse(check); apiErr != nil {
+		return apiErr
+	}
+	return nil
+}
+
+// errorMessageKind distingue en Error Message los símbolos desconocidos ("Invalid API call...") del resto de
+// fallos de la petición (apikey inválida o ausente, parámetros o funciones inexistentes), que no son un 404
+func errorMessageKind(message string) error {
+	message = strings.ToLower(strings.TrimSpace(message))
+	if strings.HasPrefix(message, "invalid api call") && !strings.Contains(message, "apikey") {
+		return ErrInvalidSymbol
+	}
+	return ErrRequestRejected
+}
+
+// isRateLimitMessage detecta los mensajes con los que Alpha Vantage indica que se superó el límite
+// de llamadas (llegan con HTTP 200 en Note o Information)
+func isRateLimitMessage(message string) bool {
+	message = strings.ToLower(message)
+	return strings.Contains(message, "rate limit") || strings.Contains(message, "call frequency")
+}
+
+// rateLimitReset estima cuándo se libera la key: el límite por minuto tras la ventana por defecto,
+// el diario a medianoche UTC
+func rateLimitReset(message string) time.Time {
+	now := time.Now().UTC()
+	message = strings.ToLower(message)
+	if !strings.Contains(message, "per minute") && strings.Contains(message, "per day") {
+		return now.Truncate(24 * time.Hour).Add(24 * time.Hour)
+	}
+	return now.Add(keypool.DefaultRateLimitWindow)
+}
+
+// malformed envuelve un error de decodificación de la respuesta
+func malformed(err error) *APIError {
+	return &APIError{Kind: ErrMalformedResponse, Message: err.Error()}
+}
+
+// emptyPayload es el error de una respuesta sin datos para symbol
+func emptyPayload(symbol string) *APIError {
+	return &APIError{Kind: ErrInvalidSymbol, Message: "no data for symbol " + symbol}
+}
@@ -0,0 +1,41 @@
+package alphavantage
+
+import (
+	"context"
+	"fmt"
+
+	domainServices "github.com/MayaCris/stock-info-app/internal/domain/services"
+)
+
+// FXRateProvider fetches realtime currency exchange rates
+type FXRateProvider struct {
+	client *Client
+}
+
+// NewFXRateProvider creates the Alpha Vantage exchange rate provider
+func NewFXRateProvider(client *Client) domainServices.FXRateProvider {
+	return &FXRateProvider{client: client}
+}
+
+// Source names the provider
+func (p *FXRateProvider) Source() string {
+	return "alpha_vantage"
+}
+
+// GetRate returns the realtime rate from fromCurrency to toCurrency (one API call)
+func (p *FXRateProvider) GetRate(ctx context.Context, fromCurrency, toCurrency string) (float64, error) {
+	resp, err := p.client.GetCurrencyExchangeRate(ctx, fromCurrency, toCurrency)
+	if err != nil {
+		return 0, err
+	}
+
+	rate, err := RequireFloat(resp.Rate.ExchangeRate)
+	if err != nil {
+		return 0, fmt.Errorf("invalid exchange rate %s/%s: %w", fromCurrency, toCurrency, malformed(err))
+	}
+	if rate <= 0 {
+		return 0, malformed(fmt.Errorf("exchange rate %s/%s is not positive: %v", fromCurrency, toCurrency, rate))
+	}
+
+	return rate, nil
+}
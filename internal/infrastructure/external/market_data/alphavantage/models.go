@@ -311,6 +311,21 @@ type QuarterlyEarning struct {
 	SurprisePercentage string `json:"surprisePercentage"`
 }
 
+// ETFProfileResponse represents the ETF_PROFILE response: fund data and its holdings
+type ETFProfileResponse struct {
+	AlphaVantageResponse
+	NetAssets     string       `json:"net_assets"`
+	InceptionDate string       `json:"inception_date"`
+	Holdings      []ETFHolding `json:"holdings"`
+}
+
+// ETFHolding represents one holding of an ETF; weight is a fraction of the fund as a string ("0.0712")
+type ETFHolding struct {
+	Symbol      string `json:"symbol"`
+	Description string `json:"description"`
+	Weight      string `json:"weight"`
+}
+
 // IncomeStatementResponse represents income statement response
 type IncomeStatementResponse struct {
 	AlphaVantageResponse
@@ -544,3 +559,17 @@ type QuarterlyCashFlow struct {
 	ChangeInExchangeRate                                      string `json:"changeInExchangeRate"`
 	NetIncome                                                 string `json:"netIncome"`
 }
+
+// CurrencyExchangeRateResponse represents the CURRENCY_EXCHANGE_RATE response
+type CurrencyExchangeRateResponse struct {
+	AlphaVantageResponse
+	Rate CurrencyExchangeRate `json:"Realtime Currency Exchange Rate"`
+}
+
+// CurrencyExchangeRate represents the realtime rate between two currencies; the rate is a string ("0.9214")
+type CurrencyExchangeRate struct {
+	FromCurrency  string `json:"1. From_Currency Code"`
+	ToCurrency    string `json:"3. To_Currency Code"`
+	ExchangeRate  string `json:"5. Exchange Rate"`
+	LastRefreshed string `json:"6. Last Refreshed"`
+}
@@ -0,0 +1,94 @@
+package alphavantage
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrMissingValue is returned by RequireFloat and RequireInt when Alpha Vantage reports no value for a field
+var ErrMissingValue = errors.New("missing value")
+
+// missingValues son los marcadores con los que Alpha Vantage indica que no hay dato
+var missingValues = map[string]bool{
+	"":     true,
+	"none": true,
+	"-":    true,
+	"n/a":  true,
+	"null": true,
+}
+
+// IsMissingValue reports whether value is one of the markers Alpha Vantage uses for a missing field
+// ("", "None", "-", "N/A", "null")
+func IsMissingValue(value string) bool {
+	return missingValues[strings.ToLower(strings.TrimSpace(value))]
+}
+
+// ParseFloat converts an Alpha Vantage numeric string to float64. ok is false when the field has no value;
+// err is only set for malformed values. Thousands separators, scientific notation ("1.23E9") and a trailing
+// percent sign ("0.54%" is 0.54) are accepted; NaN and infinities are malformed
+func ParseFloat(value string) (parsed float64, ok bool, err error) {
+	if IsMissingValue(value) {
+		return 0, false, nil
+	}
+
+	clean := strings.TrimSuffix(strings.ReplaceAll(strings.TrimSpace(value), ",", ""), "%")
+	parsed, err = strconv.ParseFloat(clean, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid number %q", value)
+	}
+	if math.IsNaN(parsed) || math.IsInf(parsed, 0) {
+		return 0, false, fmt.Errorf("invalid number %q", value)
+	}
+
+	return parsed, true, nil
+}
+
+// ParseInt converts an Alpha Vantage numeric string to int64 with the same rules as ParseFloat. Integral values
+// in scientific notation ("1.5E3") are accepted; fractional or out-of-range values are malformed
+func ParseInt(value string) (parsed int64, ok bool, err error) {
+	if IsMissingValue(value) {
+		return 0, false, nil
+	}
+
+	clean := strings.ReplaceAll(strings.TrimSpace(value), ",", "")
+	if parsed, err := strconv.ParseInt(clean, 10, 64); err == nil {
+		return parsed, true, nil
+	}
+
+	number, ok, err := ParseFloat(value)
+	if err != nil {
+		return 0, false, err
+	}
+	if number != math.Trunc(number) || number >= math.MaxInt64 || number < math.MinInt64 {
+		return 0, false, fmt.Errorf("invalid integer %q", value)
+	}
+
+	return int64(number), ok, nil
+}
+
+// RequireFloat is ParseFloat for fields that must have a value: a missing one returns ErrMissingValue
+func RequireFloat(value string) (float64, error) {
+	parsed, ok, err := ParseFloat(value)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, ErrMissingValue
+	}
+	return parsed, nil
+}
+
+// RequireInt is ParseInt for fields that must have a value: a missing one returns ErrMissingValue
+func RequireInt(value string) (int64, error) {
+	parsed, ok, err := ParseInt(value)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, ErrMissingValue
+	}
+	return parsed, nil
+}
@@ -0,0 +1,153 @@
+package alphavantage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	domainServices "github.com/MayaCris/stock-info-app/internal/domain/services"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// statementKeyRenames corrige las claves de Alpha Vantage que no siguen su propio camelCase
+var statementKeyRenames = map[string]string{
+	"costofGoodsAndServicesSold": "cost_of_goods_and_services_sold",
+	"operatingCashflow":          "operating_cash_flow",
+	"cashflowFromInvestment":     "cash_flow_from_investment",
+	"cashflowFromFinancing":      "cash_flow_from_financing",
+}
+
+// StatementProvider fetches the income statement, balance sheet and cash flow reports of a symbol
+type StatementProvider struct {
+	client  *Client
+	adapter *Adapter
+}
+
+// NewStatementProvider creates the Alpha Vantage financial statement provider
+func NewStatementProvider(client *Client, adapter *Adapter) domainServices.FinancialStatementProvider {
+	return &StatementProvider{
+		client:  client,
+		adapter: adapter,
+	}
+}
+
+// Source names the provider
+func (p *StatementProvider) Source() string {
+	return "alpha_vantage"
+}
+
+// FetchStatements returns every annual and quarterly report of one statement of symbol (one API call)
+func (p *StatementProvider) FetchStatements(ctx context.Context, companyID uuid.UUID, symbol, statement string) ([]*entities.FinancialStatement, error) {
+	var annual, quarterly interface{}
+
+	switch statement {
+	case entities.StatementIncome:
+		resp, err := p.client.GetIncomeStatement(ctx, symbol)
+		if err != nil {
+			return nil, err
+		}
+		annual, quarterly = resp.AnnualReports, resp.QuarterlyReports
+	case entities.StatementBalance:
+		resp, err := p.client.GetBalanceSheet(ctx, symbol)
+		if err != nil {
+			return nil, err
+		}
+		annual, quarterly = resp.AnnualReports, resp.QuarterlyReports
+	case entities.StatementCashFlow:
+		resp, err := p.client.GetCashFlow(ctx, symbol)
+		if err != nil {
+			return nil, err
+		}
+		annual, quarterly = resp.AnnualReports, resp.QuarterlyReports
+	default:
+		return nil, fmt.Errorf("unknown financial statement %q", statement)
+	}
+
+	statements, err := p.adapter.ReportsToFinancialStatements(ctx, annual, companyID, statement, entities.StatementPeriodAnnual)
+	if err != nil {
+		return nil, err
+	}
+	quarterlyStatements, err := p.adapter.ReportsToFinancialStatements(ctx, quarterly, companyID, statement, entities.StatementPeriodQuarterly)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(statements, quarterlyStatements...), nil
+}
+
+// ReportsToFinancialStatements converts the annual or quarterly reports of an Alpha Vantage statement response
+// to FinancialStatement entities. Every amount is parsed to a float keyed by its snake_case name; amounts
+// reported as "None" are left out
+func (a *Adapter) ReportsToFinancialStatements(ctx context.Context, reports interface{}, companyID uuid.UUID, statement, period string) ([]*entities.FinancialStatement, error) {
+	// Todos los campos de los informes son strings: se recorren como mapa en lugar de campo a campo
+	data, err := json.Marshal(reports)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s reports: %w", statement, err)
+	}
+	var rows []map[string]string
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("failed to read %s reports: %w", statement, err)
+	}
+
+	statements := make([]*entities.FinancialStatement, 0, len(rows))
+	for _, row := range rows {
+		fiscalDate, err := time.Parse("2006-01-02", row["fiscalDateEnding"])
+		if err != nil {
+			a.logger.Warn(ctx, "Skipping financial report with invalid fiscal date",
+				logger.String("statement", statement),
+				logger.String("fiscal_date_ending", row["fiscalDateEnding"]))
+			continue
+		}
+
+		items := make(entities.StatementLineItems)
+		for key, value := range row {
+			if key == "fiscalDateEnding" || key == "reportedCurrency" {
+				continue
+			}
+			amount, ok, err := ParseFloat(value)
+			if err != nil {
+				a.logger.Warn(ctx, "Skipping malformed financial report amount",
+					logger.String("statement", statement),
+					logger.String("item", key),
+					logger.String("value", value))
+				continue
+			}
+			if !ok {
+				continue
+			}
+			items[statementItemName(key)] = amount
+		}
+
+		statements = append(statements, entities.NewFinancialStatement(companyID, statement, period, fiscalDate, row["reportedCurrency"], items))
+	}
+
+	return statements, nil
+}
+
+// statementItemName convierte una clave camelCase de Alpha Vantage a snake_case; las siglas (PPE, EBIT) quedan
+// como una sola palabra
+func statementItemName(key string) string {
+	if renamed, ok := statementKeyRenames[key]; ok {
+		return renamed
+	}
+
+	runes := []rune(key)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prevLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+			acronymEnd := unicode.IsUpper(runes[i-1]) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || acronymEnd {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
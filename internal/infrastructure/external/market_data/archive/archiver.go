@@ -0,0 +1,60 @@
+package archive
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// credentialParams son los parámetros de autenticación que nunca se archivan
+var credentialParams = []string{"token", "apikey"}
+
+// Archiver stores the raw responses of the market data providers so the data they produced can be
+// re-processed later without calling the provider again. A nil Archiver archives nothing
+type Archiver struct {
+	repo   interfaces.ProviderPayloadRepository
+	ttl    time.Duration
+	logger logger.Logger
+}
+
+// New creates the payload archiver; without repository or with a ttl <= 0 archival is disabled and nil is returned
+func New(repo interfaces.ProviderPayloadRepository, ttl time.Duration, log logger.Logger) *Archiver {
+	if repo == nil || ttl <= 0 {
+		return nil
+	}
+	return &Archiver{
+		repo:   repo,
+		ttl:    ttl,
+		logger: log,
+	}
+}
+
+// Archive stores a successful provider response. Failures are only logged: archival never breaks a request
+func (a *Archiver) Archive(ctx context.Context, provider, endpoint string, params url.Values, requestID string, body []byte, fetchedAt time.Time) {
+	if a == nil || len(body) == 0 {
+		return
+	}
+
+	query := make(url.Values, len(params))
+	for key, values := range params {
+		query[key] = values
+	}
+	for _, key := range credentialParams {
+		query.Del(key)
+	}
+
+	payload, err := entities.NewProviderPayload(provider, endpoint, query.Get("symbol"), query.Encode(), requestID, body, fetchedAt, a.ttl)
+	if err == nil {
+		err = a.repo.Create(ctx, payload)
+	}
+	if err != nil {
+		a.logger.Warn(ctx, "Failed to archive provider payload",
+			logger.String("provider", provider),
+			logger.String("endpoint", endpoint),
+			logger.String("error", err.Error()))
+	}
+}
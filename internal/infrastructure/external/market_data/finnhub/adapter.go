@@ -9,18 +9,21 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	domainServices "github.com/MayaCris/stock-info-app/internal/domain/services"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
 )
 
 // Adapter converts Finnhub API responses to domain entities
 type Adapter struct {
-	logger logger.Logger
+	logger   logger.Logger
+	calendar *domainServices.TradingCalendar
 }
 
 // NewAdapter creates a new Finnhub adapter
 func NewAdapter(logger logger.Logger) *Adapter {
 	return &Adapter{
-		logger: logger,
+		logger:   logger,
+		calendar: domainServices.NewTradingCalendar(),
 	}
 }
 
@@ -34,6 +37,7 @@ func (a *Adapter) QuoteToMarketData(ctx context.Context, quote *QuoteResponse, s
 		return nil, fmt.Errorf("invalid quote data")
 	}
 
+	fetchedAt, requestID := provenance(ctx)
 	marketData := &entities.MarketData{
 		ID:              uuid.New(),
 		CompanyID:       companyID,
@@ -48,7 +52,11 @@ func (a *Adapter) QuoteToMarketData(ctx context.Context, quote *QuoteResponse, s
 		Currency:        "USD",
 		Exchange:        "US",
 		MarketTimestamp: quote.GetTimestamp(),
-		IsMarketOpen:    a.isMarketOpenNow(),
+		IsMarketOpen:    a.calendar.IsOpen(time.Now()), // Calendario con festivos y cierres anticipados
+
+		DataSource:        "finnhub",
+		FetchedAt:         fetchedAt,
+		ProviderRequestID: requestID,
 	}
 
 	a.logger.Debug(ctx, "Converted quote to market data",
@@ -78,6 +86,7 @@ func (a *Adapter) ProfileToCompanyProfile(ctx context.Context, profile *CompanyP
 		ipoDate = time.Time{} // Set to zero value if parsing fails
 	}
 
+	fetchedAt, requestID := provenance(ctx)
 	companyProfile := &entities.CompanyProfile{
 		ID:                uuid.New(),
 		Symbol:            profile.Ticker,
@@ -86,12 +95,15 @@ func (a *Adapter) ProfileToCompanyProfile(ctx context.Context, profile *CompanyP
 		Sector:            profile.Industry, // Map finnhubIndustry to both Industry and Sector
 		Country:           profile.Country,
 		Currency:          profile.Currency,
+		Exchange:          profile.Exchange,
 		MarketCap:         int64(profile.MarketCap * 1_000_000),        // Convert from millions to actual value
 		SharesOutstanding: int64(profile.ShareOutstanding * 1_000_000), // Convert from millions
 		Website:           profile.Website,
 		Logo:              profile.Logo,
 		IPODate:           ipoDate,
 		DataSource:        "finnhub",
+		FetchedAt:         fetchedAt,
+		ProviderRequestID: requestID,
 		LastUpdated:       time.Now(),
 	}
 
@@ -110,6 +122,7 @@ func (a *Adapter) NewsToNewsItems(ctx context.Context, news NewsResponse, symbol
 	}
 
 	newsItems := make([]*entities.NewsItem, 0, len(news))
+	fetchedAt, requestID := provenance(ctx)
 
 	for _, item := range news {
 		if !item.IsValid() {
@@ -131,6 +144,10 @@ func (a *Adapter) NewsToNewsItems(ctx context.Context, news NewsResponse, symbol
 			Category:    item.Category,
 			Language:    "en",
 			PublishedAt: item.GetPublishedTime(),
+
+			DataSource:        "finnhub",
+			FetchedAt:         fetchedAt,
+			ProviderRequestID: requestID,
 		}
 
 		// Add basic sentiment analysis (can be enhanced later)
@@ -158,6 +175,7 @@ func (a *Adapter) FinancialsToBasicFinancials(ctx context.Context, financials *B
 		return nil, fmt.Errorf("invalid financials data")
 	}
 
+	fetchedAt, requestID := provenance(ctx)
 	basicFinancials := &entities.BasicFinancials{
 		ID:     uuid.New(),
 		Symbol: financials.Symbol,
@@ -195,8 +213,10 @@ func (a *Adapter) FinancialsToBasicFinancials(ctx context.Context, financials *B
 		FiscalYear: time.Now().Year(),
 
 		// Data source
-		DataSource:  "finnhub",
-		LastUpdated: time.Now(),
+		DataSource:        "finnhub",
+		FetchedAt:         fetchedAt,
+		ProviderRequestID: requestID,
+		LastUpdated:       time.Now(),
 	}
 
 	// Extract EPS from time series data if available
@@ -222,6 +242,7 @@ func (a *Adapter) MarketNewsToNewsItems(ctx context.Context, news MarketNewsResp
 	}
 
 	newsItems := make([]*entities.NewsItem, 0, len(news))
+	fetchedAt, requestID := provenance(ctx)
 
 	for _, item := range news {
 		newsItem := &entities.NewsItem{
@@ -235,6 +256,10 @@ func (a *Adapter) MarketNewsToNewsItems(ctx context.Context, news MarketNewsResp
 			Category:    item.Category,
 			Language:    "en",
 			PublishedAt: item.GetPublishedTime(),
+
+			DataSource:        "finnhub",
+			FetchedAt:         fetchedAt,
+			ProviderRequestID: requestID,
 		}
 
 		// Add basic sentiment analysis
@@ -253,20 +278,16 @@ func (a *Adapter) MarketNewsToNewsItems(ctx context.Context, news MarketNewsResp
 
 // Helper methods
 
-// isMarketOpenNow checks if US market is currently open
-func (a *Adapter) isMarketOpenNow() bool {
-	now := time.Now().In(time.FixedZone("EST", -5*3600)) // Eastern Time
-
-	// Check if it's a weekday
-	if now.Weekday() == time.Saturday || now.Weekday() == time.Sunday {
-		return false
+// provenance devuelve cuándo respondió Finnhub y el identificador de la petición, registrados en el contexto
+// con WithResponseMetadata; sin ellos la respuesta se considera recibida ahora
+func provenance(ctx context.Context) (*time.Time, string) {
+	fetchedAt := time.Now().UTC()
+	metadata := ResponseMetadataFrom(ctx)
+	if metadata == nil || metadata.FetchedAt.IsZero() {
+		return &fetchedAt, ""
 	}
-
-	// Market hours: 9:30 AM to 4:00 PM EST
-	marketOpen := time.Date(now.Year(), now.Month(), now.Day(), 9, 30, 0, 0, now.Location())
-	marketClose := time.Date(now.Year(), now.Month(), now.Day(), 16, 0, 0, 0, now.Location())
-
-	return now.After(marketOpen) && now.Before(marketClose)
+	fetchedAt = metadata.FetchedAt
+	return &fetchedAt, metadata.RequestID
 }
 
 // calculateBasicSentiment provides basic sentiment analysis
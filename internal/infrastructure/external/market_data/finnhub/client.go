@@ -3,6 +3,7 @@ package finnhub
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -10,23 +11,33 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/archive"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/keypool"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
 )
 
+// ErrSymbolNotFound is returned when Finnhub has no data for the requested symbol
+var ErrSymbolNotFound = errors.New("symbol not found")
+
 // Client represents Finnhub API client
 type Client struct {
 	baseURL    string
-	apiKey     string
+	keys       *keypool.Pool // Pool de API keys, rotable en caliente
 	httpClient *http.Client
+	archiver   *archive.Archiver // Opcional: archivo de respuestas crudas
 	logger     logger.Logger
 }
 
 // ClientConfig represents configuration for Finnhub client
 type ClientConfig struct {
-	BaseURL string
-	APIKey  string
-	Timeout time.Duration
-	Logger  logger.Logger
+	BaseURL     string
+	APIKey      string
+	APIKeys     []string // Keys adicionales del pool
+	KeyStrategy string   // round_robin (por defecto) o lru
+	Timeout     time.Duration
+	HTTPClient  *http.Client // Opcional: cliente compartido (httpclient.New); sin él se usa uno con Timeout
+	Logger      logger.Logger
 }
 
 // NewClient creates a new Finnhub API client
@@ -34,17 +45,30 @@ func NewClient(config ClientConfig) *Client {
 	if config.Timeout == 0 {
 		config.Timeout = 30 * time.Second
 	}
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: config.Timeout}
+	}
 
 	return &Client{
-		baseURL: config.BaseURL,
-		apiKey:  config.APIKey,
-		httpClient: &http.Client{
-			Timeout: config.Timeout,
-		},
-		logger: config.Logger,
+		baseURL:    config.BaseURL,
+		keys:       keypool.New(append([]string{config.APIKey}, config.APIKeys...), config.KeyStrategy),
+		httpClient: httpClient,
+		logger:     config.Logger,
 	}
 }
 
+// SetAPIKeys replaces the API keys used by subsequent requests
+func (c *Client) SetAPIKeys(keys []string, strategy string) {
+	c.keys.SetKeys(keys)
+	c.keys.SetStrategy(strategy)
+}
+
+// SetArchiver enables the archival of the raw responses; nil disables it
+func (c *Client) SetArchiver(archiver *archive.Archiver) {
+	c.archiver = archiver
+}
+
 // GetRealTimeQuote gets real-time quote for a symbol
 func (c *Client) GetRealTimeQuote(ctx context.Context, symbol string) (*QuoteResponse, error) {
 	endpoint := "/quote"
@@ -60,8 +84,9 @@ func (c *Client) GetRealTimeQuote(ctx context.Context, symbol string) (*QuoteRes
 		return nil, fmt.Errorf("failed to get real-time quote for %s: %w", symbol, err)
 	}
 
+	// Finnhub responde con ceros (no con 404) cuando el símbolo no existe
 	if !quote.IsValid() {
-		return nil, fmt.Errorf("invalid quote data for symbol %s", symbol)
+		return nil, fmt.Errorf("invalid quote data for symbol %s: %w", symbol, ErrSymbolNotFound)
 	}
 
 	c.logger.Info(ctx, "Successfully retrieved real-time quote",
@@ -87,8 +112,9 @@ func (c *Client) GetCompanyProfile(ctx context.Context, symbol string) (*Company
 		return nil, fmt.Errorf("failed to get company profile for %s: %w", symbol, err)
 	}
 
+	// Para un símbolo desconocido Finnhub devuelve un perfil vacío
 	if !profile.IsValid() {
-		return nil, fmt.Errorf("invalid company profile data for symbol %s", symbol)
+		return nil, fmt.Errorf("invalid company profile data for symbol %s: %w", symbol, ErrSymbolNotFound)
 	}
 
 	c.logger.Info(ctx, "Successfully retrieved company profile",
@@ -203,6 +229,29 @@ func (c *Client) GetRecommendationTrends(ctx context.Context, symbol string) (Re
 	return trends, nil
 }
 
+// GetPeers gets the tickers of the companies Finnhub lists as peers of a symbol (same country and industry)
+func (c *Client) GetPeers(ctx context.Context, symbol string) (PeersResponse, error) {
+	endpoint := "/stock/peers"
+	params := url.Values{
+		"symbol": {symbol},
+	}
+
+	var peers PeersResponse
+	if err := c.makeRequest(ctx, endpoint, params, &peers); err != nil {
+		c.logger.Error(ctx, "Failed to get peers", err,
+			logger.String("symbol", symbol),
+		)
+		return nil, fmt.Errorf("failed to get peers for %s: %w", symbol, err)
+	}
+
+	c.logger.Info(ctx, "Successfully retrieved peers",
+		logger.String("symbol", symbol),
+		logger.Int("peers_count", len(peers)),
+	)
+
+	return peers, nil
+}
+
 // GetEarnings gets earnings data for a symbol
 func (c *Client) GetEarnings(ctx context.Context, symbol string) (EarningsResponse, error) {
 	endpoint := "/stock/earnings"
@@ -271,10 +320,73 @@ func (c *Client) GetMarketStatus(ctx context.Context, exchange string) (map[stri
 	return status, nil
 }
 
-// makeRequest makes HTTP request to Finnhub API
+// makeRequest makes HTTP request to Finnhub API. Si una key alcanza el rate limit se aparta
+// hasta que se reinicia su ventana y se reintenta con la siguiente del pool.
 func (c *Client) makeRequest(ctx context.Context, endpoint string, params url.Values, result interface{}) error {
-	// Add API key to parameters
-	params.Set("token", c.apiKey)
+	for attempt := 0; attempt < c.keys.Size(); attempt++ {
+		apiKey, err := c.keys.Acquire()
+		if err != nil {
+			return fmt.Errorf("finnhub: %w", err)
+		}
+
+		resetAt, err := c.doRequest(ctx, endpoint, params, apiKey, result)
+		if !errors.Is(err, errRateLimited) {
+			return err
+		}
+
+		c.keys.Sideline(apiKey, resetAt)
+		c.logger.Warn(ctx, "Finnhub API key rate limited, rotating to next key",
+			logger.String("endpoint", endpoint),
+			logger.String("reset_time", resetAt.Format(time.RFC3339)),
+		)
+	}
+
+	return fmt.Errorf("finnhub: %w", keypool.ErrNoAvailableKeys)
+}
+
+// errRateLimited indica que la key usada alcanzó el rate limit (HTTP 429)
+var errRateLimited = errors.New("rate limited")
+
+// ResponseMetadata describes the Finnhub response behind a piece of data: when it was received and the
+// request identifier the provider sent back, if any
+type ResponseMetadata struct {
+	RequestID string
+	FetchedAt time.Time
+}
+
+type responseMetadataKey struct{}
+
+// WithResponseMetadata returns a context whose successful requests record their response metadata in the
+// returned value; the adapter reads it back to stamp the provenance of the entities it converts
+func WithResponseMetadata(ctx context.Context) (context.Context, *ResponseMetadata) {
+	metadata := &ResponseMetadata{}
+	return context.WithValue(ctx, responseMetadataKey{}, metadata), metadata
+}
+
+// ResponseMetadataFrom returns the metadata recorded in the context (nil without WithResponseMetadata)
+func ResponseMetadataFrom(ctx context.Context) *ResponseMetadata {
+	metadata, _ := ctx.Value(responseMetadataKey{}).(*ResponseMetadata)
+	return metadata
+}
+
+// requestIDHeaders son las cabeceras que pueden identificar la petición en el proveedor, por preferencia
+var requestIDHeaders = []string{"X-Request-Id", "X-Amzn-Requestid", "Cf-Ray"}
+
+// providerRequestID devuelve el identificador de la petición enviado por el proveedor, vacío si no hay
+func providerRequestID(header http.Header) string {
+	for _, name := range requestIDHeaders {
+		if id := header.Get(name); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// doRequest ejecuta la petición con una key concreta. Con HTTP 429 devuelve errRateLimited y el
+// instante en que se reinicia la ventana (X-Ratelimit-Reset o la ventana por defecto).
+func (c *Client) doRequest(ctx context.Context, endpoint string, params url.Values, apiKey string, result interface{}) (time.Time, error) {
+	params = cloneValues(params)
+	params.Set("token", apiKey)
 
 	// Build URL
 	reqURL := fmt.Sprintf("%s%s?%s", c.baseURL, endpoint, params.Encode())
@@ -282,7 +394,7 @@ func (c *Client) makeRequest(ctx context.Context, endpoint string, params url.Va
 	// Create request
 	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return time.Time{}, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set headers
@@ -292,23 +404,27 @@ func (c *Client) makeRequest(ctx context.Context, endpoint string, params url.Va
 	// Execute request
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+		return time.Time{}, fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	// Read response body
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return time.Time{}, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return rateLimitReset(resp.Header.Get("X-Ratelimit-Reset")), errRateLimited
 	}
 
 	// Check status code
 	if resp.StatusCode != http.StatusOK {
 		var errorResp ErrorResponse
 		if json.Unmarshal(body, &errorResp) == nil && errorResp.Error != "" {
-			return fmt.Errorf("API error: %s", errorResp.Error)
+			return time.Time{}, fmt.Errorf("API error: %s", errorResp.Error)
 		}
-		return fmt.Errorf("HTTP error: %d - %s", resp.StatusCode, string(body))
+		return time.Time{}, fmt.Errorf("HTTP error: %d - %s", resp.StatusCode, string(body))
 	}
 
 	// Check for rate limiting
@@ -320,10 +436,36 @@ func (c *Client) makeRequest(ctx context.Context, endpoint string, params url.Va
 
 	// Parse JSON response
 	if err := json.Unmarshal(body, result); err != nil {
-		return fmt.Errorf("failed to parse JSON response: %w", err)
+		return time.Time{}, fmt.Errorf("failed to parse JSON response: %w", err)
 	}
 
-	return nil
+	requestID, fetchedAt := providerRequestID(resp.Header), time.Now().UTC()
+	if metadata := ResponseMetadataFrom(ctx); metadata != nil {
+		metadata.RequestID = requestID
+		metadata.FetchedAt = fetchedAt
+	}
+	c.archiver.Archive(ctx, entities.PayloadProviderFinnhub, endpoint, params, requestID, body, fetchedAt)
+
+	return time.Time{}, nil
+}
+
+// rateLimitReset interpreta X-Ratelimit-Reset (timestamp Unix en segundos)
+func rateLimitReset(header string) time.Time {
+	if seconds, err := strconv.ParseInt(header, 10, 64); err == nil {
+		if reset := time.Unix(seconds, 0); reset.After(time.Now()) {
+			return reset
+		}
+	}
+	return time.Now().Add(keypool.DefaultRateLimitWindow)
+}
+
+// cloneValues copia los parámetros para no compartir la key entre reintentos
+func cloneValues(values url.Values) url.Values {
+	cloned := make(url.Values, len(values)+1)
+	for key, value := range values {
+		cloned[key] = append([]string(nil), value...)
+	}
+	return cloned
 }
 
 // Health checks if the Finnhub API is accessible
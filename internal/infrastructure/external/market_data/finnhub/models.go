@@ -224,6 +224,9 @@ type ErrorResponse struct {
 // RecommendationTrendsResponse represents recommendation trends
 type RecommendationTrendsResponse []RecommendationTrend
 
+// PeersResponse represents the tickers of a symbol's peers (the symbol itself is usually included)
+type PeersResponse []string
+
 // RecommendationTrend represents recommendation trend data
 type RecommendationTrend struct {
 	Buy        int    `json:"buy"`
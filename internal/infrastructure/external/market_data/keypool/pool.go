@@ -0,0 +1,196 @@
+package keypool
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Estrategias de selección de la siguiente API key
+const (
+	StrategyRoundRobin        = "round_robin"
+	StrategyLeastRecentlyUsed = "lru"
+)
+
+// DefaultRateLimitWindow es el tiempo que se aparta una key cuando el proveedor no indica cuándo se reinicia
+const DefaultRateLimitWindow = time.Minute
+
+// ErrNoAvailableKeys se devuelve cuando todas las keys están apartadas por rate limit (o no hay ninguna)
+var ErrNoAvailableKeys = errors.New("no API key available")
+
+// Pool reparte las peticiones entre varias API keys de un proveedor y aparta temporalmente
+// las que alcanzan el rate limit hasta que se reinicia su ventana
+type Pool struct {
+	mu       sync.Mutex
+	strategy string
+	keys     []*keyState
+	next     int // Siguiente posición para round robin
+}
+
+type keyState struct {
+	key            string
+	lastUsed       time.Time
+	sidelinedUntil time.Time
+}
+
+// KeyStatus describe el estado de una key sin exponer su valor
+type KeyStatus struct {
+	Key            string    `json:"key"` // Enmascarada
+	LastUsed       time.Time `json:"last_used,omitempty"`
+	SidelinedUntil time.Time `json:"sidelined_until,omitempty"`
+}
+
+// New crea un pool con las keys dadas (se ignoran vacías y duplicadas)
+func New(keys []string, strategy string) *Pool {
+	pool := &Pool{strategy: normalizeStrategy(strategy)}
+	pool.SetKeys(keys)
+	return pool
+}
+
+// SetKeys reemplaza las keys del pool conservando el estado de las que siguen (recarga en caliente)
+func (p *Pool) SetKeys(keys []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	previous := make(map[string]*keyState, len(p.keys))
+	for _, state := range p.keys {
+		previous[state.key] = state
+	}
+
+	seen := make(map[string]struct{}, len(keys))
+	states := make([]*keyState, 0, len(keys))
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		if _, duplicated := seen[key]; duplicated {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		if state, ok := previous[key]; ok {
+			states = append(states, state)
+		} else {
+			states = append(states, &keyState{key: key})
+		}
+	}
+
+	p.keys = states
+	p.next = 0
+}
+
+// SetStrategy cambia la estrategia de selección
+func (p *Pool) SetStrategy(strategy string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.strategy = normalizeStrategy(strategy)
+}
+
+// Acquire devuelve la key a usar en la siguiente petición. Si todas están apartadas devuelve
+// ErrNoAvailableKeys indicando cuándo queda libre la primera.
+func (p *Pool) Acquire() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.keys) == 0 {
+		return "", ErrNoAvailableKeys
+	}
+
+	now := time.Now()
+	var selected *keyState
+
+	switch p.strategy {
+	case StrategyLeastRecentlyUsed:
+		for _, state := range p.keys {
+			if now.Before(state.sidelinedUntil) {
+				continue
+			}
+			if selected == nil || state.lastUsed.Before(selected.lastUsed) {
+				selected = state
+			}
+		}
+	default:
+		for i := 0; i < len(p.keys); i++ {
+			state := p.keys[(p.next+i)%len(p.keys)]
+			if now.Before(state.sidelinedUntil) {
+				continue
+			}
+			selected = state
+			p.next = (p.next + i + 1) % len(p.keys)
+			break
+		}
+	}
+
+	if selected == nil {
+		return "", fmt.Errorf("%w: all %d keys rate limited until %s",
+			ErrNoAvailableKeys, len(p.keys), p.earliestReset().Format(time.RFC3339))
+	}
+
+	selected.lastUsed = now
+	return selected.key, nil
+}
+
+// Sideline aparta una key hasta until (fin de su ventana de rate limit)
+func (p *Pool) Sideline(key string, until time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, state := range p.keys {
+		if state.key == key {
+			if until.After(state.sidelinedUntil) {
+				state.sidelinedUntil = until
+			}
+			return
+		}
+	}
+}
+
+// Size devuelve el número de keys configuradas
+func (p *Pool) Size() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.keys)
+}
+
+// Status devuelve el estado de cada key con el valor enmascarado
+func (p *Pool) Status() []KeyStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	status := make([]KeyStatus, 0, len(p.keys))
+	for _, state := range p.keys {
+		status = append(status, KeyStatus{
+			Key:            maskKey(state.key),
+			LastUsed:       state.lastUsed,
+			SidelinedUntil: state.sidelinedUntil,
+		})
+	}
+	return status
+}
+
+// earliestReset devuelve el primer instante en que una key vuelve a estar disponible. Requiere p.mu.
+func (p *Pool) earliestReset() time.Time {
+	var earliest time.Time
+	for _, state := range p.keys {
+		if earliest.IsZero() || state.sidelinedUntil.Before(earliest) {
+			earliest = state.sidelinedUntil
+		}
+	}
+	return earliest
+}
+
+func normalizeStrategy(strategy string) string {
+	if strategy == StrategyLeastRecentlyUsed {
+		return StrategyLeastRecentlyUsed
+	}
+	return StrategyRoundRobin
+}
+
+// maskKey deja visibles solo los últimos 4 caracteres
+func maskKey(key string) string {
+	if len(key) <= 4 {
+		return "****"
+	}
+	return "****" + key[len(key)-4:]
+}
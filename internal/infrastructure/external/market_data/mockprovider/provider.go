@@ -0,0 +1,186 @@
+package mockprovider
+
+import (
+	"hash/fnv"
+	"math"
+	"strings"
+	"time"
+)
+
+// Company is a company of the synthetic universe
+type Company struct {
+	Ticker   string
+	Name     string
+	Sector   string
+	Industry string
+	Exchange string
+}
+
+// Companies es el universo fijo de los ratings sintéticos; cualquier otro símbolo también tiene cotizaciones
+var Companies = []Company{
+	{"AAPL", "Apple Inc.", "Technology", "Consumer Electronics", "NASDAQ"},
+	{"MSFT", "Microsoft Corporation", "Technology", "Software", "NASDAQ"},
+	{"NVDA", "NVIDIA Corporation", "Technology", "Semiconductors", "NASDAQ"},
+	{"GOOGL", "Alphabet Inc.", "Communication Services", "Internet Content & Information", "NASDAQ"},
+	{"META", "Meta Platforms Inc.", "Communication Services", "Internet Content & Information", "NASDAQ"},
+	{"AMZN", "Amazon.com Inc.", "Consumer Cyclical", "Internet Retail", "NASDAQ"},
+	{"TSLA", "Tesla Inc.", "Consumer Cyclical", "Auto Manufacturers", "NASDAQ"},
+	{"JPM", "JPMorgan Chase & Co.", "Financial Services", "Banks", "NYSE"},
+	{"GS", "The Goldman Sachs Group Inc.", "Financial Services", "Capital Markets", "NYSE"},
+	{"JNJ", "Johnson & Johnson", "Healthcare", "Drug Manufacturers", "NYSE"},
+	{"PFE", "Pfizer Inc.", "Healthcare", "Drug Manufacturers", "NYSE"},
+	{"XOM", "Exxon Mobil Corporation", "Energy", "Oil & Gas Integrated", "NYSE"},
+	{"WMT", "Walmart Inc.", "Consumer Defensive", "Discount Stores", "NYSE"},
+	{"KO", "The Coca-Cola Company", "Consumer Defensive", "Beverages", "NYSE"},
+}
+
+// Candle is a synthetic daily OHLCV bar
+type Candle struct {
+	Date   time.Time // Medianoche UTC del día de negociación
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume int64
+}
+
+// Quote is a synthetic real-time quote
+type Quote struct {
+	Price         float64
+	Open          float64
+	High          float64
+	Low           float64
+	PreviousClose float64
+	Change        float64
+	PercentChange float64
+	Timestamp     time.Time
+}
+
+// Provider genera datos de mercado sintéticos y deterministas: el mismo símbolo y el mismo instante
+// producen siempre los mismos valores, así los tests y los entornos sin API keys son reproducibles
+type Provider struct {
+	now func() time.Time
+}
+
+// New creates a mock provider; now is the clock used for quotes and ratings (nil uses time.Now)
+func New(now func() time.Time) *Provider {
+	if now == nil {
+		now = time.Now
+	}
+	return &Provider{now: now}
+}
+
+// CompanyFor returns the company of the universe for the symbol, or a generic one for any other symbol
+func CompanyFor(symbol string) Company {
+	symbol = strings.ToUpper(symbol)
+	for _, company := range Companies {
+		if company.Ticker == symbol {
+			return company
+		}
+	}
+	return Company{Ticker: symbol, Name: symbol + " Holdings Inc.", Sector: "Industrials", Industry: "Conglomerates", Exchange: "NYSE"}
+}
+
+// Candle returns the daily bar of symbol for the trading day of date
+func (p *Provider) Candle(symbol string, date time.Time) Candle {
+	symbol = strings.ToUpper(symbol)
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	key := day.Format("2006-01-02")
+
+	closePrice := p.closeOn(symbol, day)
+	open := closePrice * (1 + 0.02*(unit(symbol, key, "open")-0.5))
+	high := math.Max(open, closePrice) * (1 + 0.015*unit(symbol, key, "high"))
+	low := math.Min(open, closePrice) * (1 - 0.015*unit(symbol, key, "low"))
+	volume := int64(2_000_000 + 60_000_000*unit(symbol) + 10_000_000*unit(symbol, key, "volume"))
+
+	return Candle{Date: day, Open: round(open), High: round(high), Low: round(low), Close: round(closePrice), Volume: volume}
+}
+
+// Candles returns the daily bars between from and to (inclusive), oldest first, skipping weekends
+func (p *Provider) Candles(symbol string, from, to time.Time) []Candle {
+	var candles []Candle
+	for day := from.UTC().Truncate(24 * time.Hour); !day.After(to); day = day.AddDate(0, 0, 1) {
+		if isTradingDay(day) {
+			candles = append(candles, p.Candle(symbol, day))
+		}
+	}
+	return candles
+}
+
+// LastCandles returns the last n daily bars up to the current trading day, oldest first
+func (p *Provider) LastCandles(symbol string, n int) []Candle {
+	day := lastTradingDay(p.now().UTC())
+	candles := make([]Candle, n)
+	for i := n - 1; i >= 0; i-- {
+		candles[i] = p.Candle(symbol, day)
+		day = lastTradingDay(day.AddDate(0, 0, -1))
+	}
+	return candles
+}
+
+// Quote returns the quote of symbol now: during the session the price moves from the open towards the close
+// of the day; outside it the last close is quoted
+func (p *Provider) Quote(symbol string) Quote {
+	now := p.now().UTC()
+	day := lastTradingDay(now)
+	today := p.Candle(symbol, day)
+	previous := p.Candle(symbol, lastTradingDay(day.AddDate(0, 0, -1)))
+
+	// Sesión regular de Nueva York aproximada en UTC (14:30-21:00)
+	progress := 1.0
+	if day.Equal(now.Truncate(24 * time.Hour)) {
+		elapsed := now.Sub(day.Add(14*time.Hour + 30*time.Minute))
+		progress = math.Min(math.Max(elapsed.Hours()/6.5, 0), 1)
+	}
+	price := round(today.Open + (today.Close-today.Open)*progress)
+
+	return Quote{
+		Price:         price,
+		Open:          today.Open,
+		High:          math.Max(today.High, price),
+		Low:           math.Min(today.Low, price),
+		PreviousClose: previous.Close,
+		Change:        round(price - previous.Close),
+		PercentChange: round((price - previous.Close) / previous.Close * 100),
+		Timestamp:     now,
+	}
+}
+
+// SharesOutstanding returns the synthetic number of shares of symbol
+func SharesOutstanding(symbol string) float64 {
+	return math.Round(500_000_000 + 15_000_000_000*unit(strings.ToUpper(symbol), "shares"))
+}
+
+// closeOn es el cierre de un día: un nivel base por símbolo con dos ciclos y ruido diario acotado
+func (p *Provider) closeOn(symbol string, day time.Time) float64 {
+	base := 20 + 480*unit(symbol)
+	n := float64(day.Unix() / 86400)
+	cycle := 0.15*math.Sin(n/45+2*math.Pi*unit(symbol, "phase")) + 0.05*math.Sin(n/9+2*math.Pi*unit(symbol, "phase2"))
+	noise := 0.03 * (unit(symbol, day.Format("2006-01-02"), "close") - 0.5)
+	return base * (1 + cycle + noise)
+}
+
+// unit devuelve un valor determinista en [0, 1) a partir de las partes dadas
+func unit(parts ...string) float64 {
+	h := fnv.New64a()
+	h.Write([]byte(strings.Join(parts, "|")))
+	return float64(h.Sum64()%1_000_000) / 1_000_000
+}
+
+// round redondea a céntimos
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+func isTradingDay(day time.Time) bool {
+	return day.Weekday() != time.Saturday && day.Weekday() != time.Sunday
+}
+
+// lastTradingDay devuelve la medianoche UTC del último día laborable hasta t incluido
+func lastTradingDay(t time.Time) time.Time {
+	day := t.UTC().Truncate(24 * time.Hour)
+	for !isTradingDay(day) {
+		day = day.AddDate(0, 0, -1)
+	}
+	return day
+}
@@ -0,0 +1,122 @@
+package mockprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/application/usecases/population"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/adapters"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/stock_api"
+)
+
+// Tamaño del histórico sintético de ratings
+const (
+	RatingPages    = 5
+	RatingsPerPage = 20
+)
+
+var brokerages = []string{
+	"The Goldman Sachs Group", "Morgan Stanley", "JPMorgan Chase & Co.", "Barclays",
+	"UBS Group", "Wells Fargo & Company", "Citigroup", "Jefferies Financial Group",
+}
+
+// ratingScale va de peor a mejor; las acciones suben o bajan un escalón
+var ratingScale = []string{"Sell", "Underperform", "Hold", "Outperform", "Buy"}
+
+var actions = []string{"upgraded by", "downgraded by", "target raised by", "target lowered by", "reiterated by", "initiated by"}
+
+// FetchPage implements population.StockDataProvider with a fixed history of ratings over the company universe.
+// Los ratings más recientes llegan en la primera página, como en la API real
+func (p *Provider) FetchPage(ctx context.Context, page string) (*population.StockDataPage, error) {
+	number := 1
+	if page != "" {
+		n, err := strconv.Atoi(strings.TrimPrefix(page, "mock-page-"))
+		if err != nil || n < 1 || n > RatingPages {
+			return nil, fmt.Errorf("unknown mock rating page %q", page)
+		}
+		number = n
+	}
+
+	// Anclado a la hora actual: cada sincronización ve los mismos ratings mientras no cambie la hora
+	anchor := p.now().UTC().Truncate(time.Hour)
+	parser := adapters.NewStockAPIDataProvider(nil)
+
+	result := &population.StockDataPage{}
+	for i := 0; i < RatingsPerPage; i++ {
+		index := (number-1)*RatingsPerPage + i
+		raw, err := json.Marshal(p.ratingItem(index, anchor))
+		if err != nil {
+			return nil, err
+		}
+		item, err := parser.ParseRawItem(raw)
+		if err != nil {
+			return nil, err
+		}
+		result.Items = append(result.Items, item)
+	}
+
+	if number < RatingPages {
+		result.NextPage = fmt.Sprintf("mock-page-%d", number+1)
+		result.HasMore = true
+	}
+	return result, nil
+}
+
+// GetNextPageToken implements population.StockDataProvider
+func (p *Provider) GetNextPageToken(currentPage string) string {
+	return currentPage
+}
+
+// HasMorePages implements population.StockDataProvider
+func (p *Provider) HasMorePages(response *population.StockDataPage) bool {
+	return response.HasMore
+}
+
+// ParseRawItem implements population.RawItemParser: los items sintéticos tienen el formato de la API de ratings
+func (p *Provider) ParseRawItem(raw []byte) (population.StockDataItem, error) {
+	return adapters.NewStockAPIDataProvider(nil).ParseRawItem(raw)
+}
+
+// ratingItem genera el rating index (0 es el más reciente) con el formato de la API de ratings
+func (p *Provider) ratingItem(index int, anchor time.Time) stock_api.StockRatingItem {
+	key := strconv.Itoa(index)
+	company := Companies[int(unit(key, "company")*float64(len(Companies)))]
+	brokerage := brokerages[int(unit(key, "brokerage")*float64(len(brokerages)))]
+	action := actions[int(unit(key, "action")*float64(len(actions)))]
+
+	from := int(unit(key, "rating") * float64(len(ratingScale)))
+	to := from
+	switch action {
+	case "upgraded by":
+		to = min(from+1, len(ratingScale)-1)
+	case "downgraded by":
+		to = max(from-1, 0)
+	}
+
+	eventTime := anchor.Add(-time.Duration(index)*3*time.Hour - time.Duration(unit(key, "minute")*120)*time.Minute)
+	price := p.Candle(company.Ticker, eventTime).Close
+	targetFrom := price * (0.9 + 0.3*unit(key, "target"))
+	targetTo := targetFrom
+	switch action {
+	case "target raised by", "upgraded by":
+		targetTo = targetFrom * 1.1
+	case "target lowered by", "downgraded by":
+		targetTo = targetFrom * 0.9
+	}
+
+	return stock_api.StockRatingItem{
+		Ticker:     company.Ticker,
+		Company:    company.Name,
+		Brokerage:  brokerage,
+		Action:     action,
+		RatingFrom: ratingScale[from],
+		RatingTo:   ratingScale[to],
+		TargetFrom: fmt.Sprintf("$%.2f", targetFrom),
+		TargetTo:   fmt.Sprintf("$%.2f", targetTo),
+		Time:       eventTime.Format(time.RFC3339Nano),
+	}
+}
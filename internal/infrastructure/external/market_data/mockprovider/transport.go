@@ -0,0 +1,296 @@
+package mockprovider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/alphavantage"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/finnhub"
+)
+
+// Transport returns an http.RoundTripper that answers the Finnhub and Alpha Vantage requests of the real
+// clients with synthetic payloads, so every endpoint runs its usual parsing without network access
+func (p *Provider) Transport() http.RoundTripper {
+	return roundTripper{provider: p}
+}
+
+type roundTripper struct {
+	provider *Provider
+}
+
+// RoundTrip implements http.RoundTripper. Las peticiones con parámetro function son de Alpha Vantage; el resto
+// se resuelven por el endpoint de Finnhub
+func (t roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	query := req.URL.Query()
+	symbol := strings.ToUpper(query.Get("symbol"))
+
+	var payload interface{}
+	if function := query.Get("function"); function != "" {
+		payload = t.provider.alphaVantage(function, symbol, query.Get("outputsize"))
+	} else {
+		var found bool
+		payload, found = t.provider.finnhub(req.URL.Path, symbol, query.Get("from"), query.Get("to"))
+		if !found {
+			return respond(req, http.StatusNotFound, map[string]string{"error": "unknown mock endpoint " + req.URL.Path})
+		}
+	}
+	return respond(req, http.StatusOK, payload)
+}
+
+func respond(req *http.Request, status int, payload interface{}) (*http.Response, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode:    status,
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// finnhub devuelve el payload de un endpoint de Finnhub; false si el endpoint no está simulado
+func (p *Provider) finnhub(path, symbol, from, to string) (interface{}, bool) {
+	switch {
+	case strings.HasSuffix(path, "/quote"):
+		quote := p.Quote(symbol)
+		return finnhub.QuoteResponse{
+			CurrentPrice:  quote.Price,
+			Change:        quote.Change,
+			PercentChange: quote.PercentChange,
+			HighPrice:     quote.High,
+			LowPrice:      quote.Low,
+			OpenPrice:     quote.Open,
+			PreviousClose: quote.PreviousClose,
+			Timestamp:     quote.Timestamp.Unix(),
+		}, true
+	case strings.HasSuffix(path, "/stock/profile2"):
+		company := CompanyFor(symbol)
+		shares := SharesOutstanding(symbol)
+		return finnhub.CompanyProfileResponse{
+			Country:          "US",
+			Currency:         "USD",
+			Exchange:         company.Exchange,
+			Industry:         company.Industry,
+			IPO:              fmt.Sprintf("%d-01-15", 1980+int(unit(symbol, "ipo")*40)),
+			MarketCap:        round(p.Quote(symbol).Price * shares / 1e6),
+			Name:             company.Name,
+			ShareOutstanding: round(shares / 1e6),
+			Ticker:           company.Ticker,
+			Website:          "https://example.com/" + strings.ToLower(company.Ticker),
+		}, true
+	case strings.HasSuffix(path, "/company-news"):
+		return p.news(symbol, from, to), true
+	case strings.HasSuffix(path, "/stock/metric"):
+		return p.basicFinancials(symbol), true
+	case strings.HasSuffix(path, "/stock/peers"):
+		return p.peers(symbol), true
+	case strings.HasSuffix(path, "/stock/market-status"):
+		now := p.now().UTC()
+		open := isTradingDay(now) && now.Hour()*60+now.Minute() >= 14*60+30 && now.Hour() < 21
+		return map[string]interface{}{"exchange": "US", "isOpen": open, "session": "regular", "t": now.Unix(), "timezone": "America/New_York"}, true
+	case strings.HasSuffix(path, "/news"), strings.HasSuffix(path, "/stock/recommendation"),
+		strings.HasSuffix(path, "/stock/earnings"), strings.HasSuffix(path, "/stock/symbol"):
+		return []interface{}{}, true
+	}
+	return nil, false
+}
+
+// news genera tres noticias por día en el rango pedido (como máximo una semana)
+func (p *Provider) news(symbol, from, to string) finnhub.NewsResponse {
+	end, err := time.Parse("2006-01-02", to)
+	if err != nil {
+		end = p.now().UTC().Truncate(24 * time.Hour)
+	}
+	start, err := time.Parse("2006-01-02", from)
+	if err != nil || end.Sub(start) > 7*24*time.Hour {
+		start = end.AddDate(0, 0, -7)
+	}
+
+	company := CompanyFor(symbol)
+	headlines := []string{"%s shares move as analysts update targets", "%s announces quarterly dividend", "What to watch for %s this week"}
+	news := finnhub.NewsResponse{}
+	for day := end; !day.Before(start); day = day.AddDate(0, 0, -1) {
+		for i, headline := range headlines {
+			id := int64(unit(symbol, day.Format("2006-01-02"), strconv.Itoa(i)) * 1e9)
+			news = append(news, finnhub.NewsItemResponse{
+				Category: "company",
+				DateTime: day.Add(time.Duration(13+2*i) * time.Hour).Unix(),
+				Headline: fmt.Sprintf(headline, company.Name),
+				ID:       id,
+				Related:  company.Ticker,
+				Source:   "Mock Wire",
+				Summary:  fmt.Sprintf("Synthetic news item about %s generated by the mock provider.", company.Name),
+				URL:      fmt.Sprintf("https://example.com/news/%s/%d", strings.ToLower(company.Ticker), id),
+			})
+		}
+	}
+	return news
+}
+
+func (p *Provider) basicFinancials(symbol string) finnhub.BasicFinancialsResponse {
+	candles := p.LastCandles(symbol, 252)
+	high, low := candles[0], candles[0]
+	for _, candle := range candles {
+		if candle.High > high.High {
+			high = candle
+		}
+		if candle.Low < low.Low {
+			low = candle
+		}
+	}
+
+	response := finnhub.BasicFinancialsResponse{Symbol: symbol, MetricType: "all"}
+	response.Metric = finnhub.BasicFinancialsMetric{
+		PE:              round(10 + 30*unit(symbol, "pe")),
+		PB:              round(1 + 9*unit(symbol, "pb")),
+		PS:              round(1 + 8*unit(symbol, "ps")),
+		ROE:             round(5 + 30*unit(symbol, "roe")),
+		ROA:             round(2 + 15*unit(symbol, "roa")),
+		GrossMargin:     round(20 + 50*unit(symbol, "gross")),
+		OperatingMargin: round(5 + 30*unit(symbol, "operating")),
+		NetMargin:       round(2 + 25*unit(symbol, "net")),
+		DebtEquity:      round(2 * unit(symbol, "debt")),
+		CurrentRatio:    round(0.8 + 2*unit(symbol, "current")),
+		Beta:            round(0.5 + 1.5*unit(symbol, "beta")),
+		Week52High:      high.High,
+		Week52Low:       low.Low,
+		Week52HighDate:  high.Date.Format("2006-01-02"),
+		Week52LowDate:   low.Date.Format("2006-01-02"),
+		DividendYield:   round(3 * unit(symbol, "dividend")),
+	}
+	return response
+}
+
+// peers devuelve las empresas del mismo sector, empezando por la propia como hace Finnhub
+func (p *Provider) peers(symbol string) finnhub.PeersResponse {
+	company := CompanyFor(symbol)
+	peers := finnhub.PeersResponse{company.Ticker}
+	for _, other := range Companies {
+		if other.Sector == company.Sector && other.Ticker != company.Ticker {
+			peers = append(peers, other.Ticker)
+		}
+	}
+	return peers
+}
+
+// alphaVantage devuelve el payload de una función de Alpha Vantage. Las funciones no simuladas responden con
+// Information, como hace la API con las que no admite la key
+func (p *Provider) alphaVantage(function, symbol, outputSize string) interface{} {
+	days := 100
+	if outputSize == "full" {
+		days = 5 * 252
+	}
+
+	switch function {
+	case "TIME_SERIES_DAILY", "TIME_SERIES_DAILY_ADJUSTED":
+		series := make(map[string]alphavantage.DailyStockData)
+		for _, c := range p.LastCandles(symbol, days) {
+			series[c.Date.Format("2006-01-02")] = alphavantage.DailyStockData{
+				Open: price(c.Open), High: price(c.High), Low: price(c.Low), Close: price(c.Close), AdjustedClose: price(c.Close),
+				Volume: strconv.FormatInt(c.Volume, 10), DividendAmount: "0.0000", SplitCoefficient: "1.0",
+			}
+		}
+		return alphavantage.TimeSeriesDailyResponse{MetaData: p.metaData("Daily Prices", symbol, outputSize), TimeSeries: series}
+	case "TIME_SERIES_WEEKLY", "TIME_SERIES_WEEKLY_ADJUSTED":
+		series := make(map[string]alphavantage.WeeklyStockData)
+		for date, c := range aggregate(p.LastCandles(symbol, 5*252), func(t time.Time) string {
+			year, week := t.ISOWeek()
+			return fmt.Sprintf("%d-%02d", year, week)
+		}) {
+			series[date] = alphavantage.WeeklyStockData{Open: price(c.Open), High: price(c.High), Low: price(c.Low),
+				Close: price(c.Close), AdjustedClose: price(c.Close), Volume: strconv.FormatInt(c.Volume, 10)}
+		}
+		return alphavantage.TimeSeriesWeeklyResponse{MetaData: p.metaData("Weekly Adjusted Prices", symbol, ""), TimeSeries: series}
+	case "TIME_SERIES_MONTHLY", "TIME_SERIES_MONTHLY_ADJUSTED":
+		series := make(map[string]alphavantage.MonthlyStockData)
+		for date, c := range aggregate(p.LastCandles(symbol, 5*252), func(t time.Time) string { return t.Format("2006-01") }) {
+			series[date] = alphavantage.MonthlyStockData{Open: price(c.Open), High: price(c.High), Low: price(c.Low),
+				Close: price(c.Close), AdjustedClose: price(c.Close), Volume: strconv.FormatInt(c.Volume, 10)}
+		}
+		return alphavantage.TimeSeriesMonthlyResponse{MetaData: p.metaData("Monthly Adjusted Prices", symbol, ""), TimeSeries: series}
+	case "OVERVIEW":
+		return p.overview(symbol)
+	}
+
+	return alphavantage.AlphaVantageResponse{Information: "The mock provider does not simulate the " + function + " function."}
+}
+
+func (p *Provider) metaData(information, symbol, outputSize string) alphavantage.TimeSeriesMetaData {
+	return alphavantage.TimeSeriesMetaData{
+		Information:   information + " (mock)",
+		Symbol:        symbol,
+		LastRefreshed: lastTradingDay(p.now()).Format("2006-01-02"),
+		OutputSize:    outputSize,
+		TimeZone:      "US/Eastern",
+	}
+}
+
+func (p *Provider) overview(symbol string) alphavantage.CompanyOverviewResponse {
+	company := CompanyFor(symbol)
+	financials := p.basicFinancials(symbol).Metric
+	lastPrice := p.Quote(symbol).Price
+	shares := SharesOutstanding(symbol)
+
+	return alphavantage.CompanyOverviewResponse{
+		Symbol:               company.Ticker,
+		AssetType:            "Common Stock",
+		Name:                 company.Name,
+		Description:          company.Name + " is a synthetic company generated by the mock provider.",
+		Exchange:             company.Exchange,
+		Currency:             "USD",
+		Country:              "USA",
+		Sector:               strings.ToUpper(company.Sector),
+		Industry:             strings.ToUpper(company.Industry),
+		MarketCapitalization: strconv.FormatFloat(math.Round(lastPrice*shares), 'f', 0, 64),
+		PERatio:              price(financials.PE),
+		EPS:                  price(lastPrice / financials.PE),
+		ProfitMargin:         price(financials.NetMargin / 100),
+		ReturnOnEquityTTM:    price(financials.ROE / 100),
+		DividendYield:        price(financials.DividendYield / 100),
+		Beta:                 price(financials.Beta),
+		WeekHigh52:           price(financials.Week52High),
+		WeekLow52:            price(financials.Week52Low),
+		SharesOutstanding:    strconv.FormatFloat(shares, 'f', 0, 64),
+	}
+}
+
+// aggregate agrupa velas diarias en periodos; cada periodo se indexa por la fecha de su último día
+func aggregate(candles []Candle, period func(time.Time) string) map[string]Candle {
+	bars := make(map[string]Candle)
+	dates := make(map[string]string)
+	for _, c := range candles {
+		key := period(c.Date)
+		bar, ok := bars[key]
+		if !ok {
+			bar = Candle{Open: c.Open, High: c.High, Low: c.Low}
+		}
+		bar.High = math.Max(bar.High, c.High)
+		bar.Low = math.Min(bar.Low, c.Low)
+		bar.Close = c.Close
+		bar.Volume += c.Volume
+		bars[key] = bar
+		dates[key] = c.Date.Format("2006-01-02")
+	}
+
+	result := make(map[string]Candle, len(bars))
+	for key, bar := range bars {
+		result[dates[key]] = bar
+	}
+	return result
+}
+
+// price formatea un importe como las cadenas numéricas de Alpha Vantage
+func price(value float64) string {
+	return strconv.FormatFloat(value, 'f', 4, 64)
+}
@@ -1,14 +1,20 @@
 package factory
 
 import (
-	"time"
+	"context"
+	"net/http"
 
 	"github.com/MayaCris/stock-info-app/internal/application/services"
 	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/events"
 	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	domainServices "github.com/MayaCris/stock-info-app/internal/domain/services"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/config"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/httpclient"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/alphavantage"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/archive"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/finnhub"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/mockprovider"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
 )
 
@@ -23,12 +29,25 @@ type MarketDataFactory struct {
 	newsRepo            repoInterfaces.NewsRepository
 	basicFinancialsRepo repoInterfaces.BasicFinancialsRepository
 	companyRepo         repoInterfaces.CompanyRepository
+	quoteSnapshotRepo   repoInterfaces.QuoteSnapshotRepository
+	cacheService        domainServices.CacheService
+	symbolViews         *domainServices.SymbolViews
+	eventPublisher      events.Publisher
+	planQuotas          *domainServices.PlanQuotas
 
 	// External clients
 	finnhubClient       *finnhub.Client
 	finnhubAdapter      *finnhub.Adapter
 	alphavantageClient  *alphavantage.Client
 	alphavantageAdapter *alphavantage.Adapter
+
+	// Servicios creados, para aplicarles la configuración recargada
+	createdServices []interfaces.MarketDataService
+}
+
+// ttlUpdater lo implementan los servicios cuyos TTLs se pueden cambiar en caliente
+type ttlUpdater interface {
+	UpdateTTLs(ttls services.MarketDataTTLs)
 }
 
 // MarketDataFactoryConfig represents configuration for market data factory
@@ -40,6 +59,12 @@ type MarketDataFactoryConfig struct {
 	NewsRepo            repoInterfaces.NewsRepository
 	BasicFinancialsRepo repoInterfaces.BasicFinancialsRepository
 	CompanyRepo         repoInterfaces.CompanyRepository
+	QuoteSnapshotRepo   repoInterfaces.QuoteSnapshotRepository   // Opcional: histórico intradía de cotizaciones
+	CacheService        domainServices.CacheService              // Opcional: negative caching de símbolos inexistentes
+	SymbolViews         *domainServices.SymbolViews              // Opcional: vistas por símbolo (trending)
+	PayloadRepo         repoInterfaces.ProviderPayloadRepository // Opcional: archivo de respuestas crudas
+	EventPublisher      events.Publisher                         // Opcional: publica QuoteUpdated
+	PlanQuotas          *domainServices.PlanQuotas               // Opcional: cuotas de refrescos por plan
 }
 
 // NewMarketDataFactory creates a new market data factory
@@ -52,29 +77,63 @@ func NewMarketDataFactory(config MarketDataFactoryConfig) *MarketDataFactory {
 		newsRepo:            config.NewsRepo,
 		basicFinancialsRepo: config.BasicFinancialsRepo,
 		companyRepo:         config.CompanyRepo,
+		quoteSnapshotRepo:   config.QuoteSnapshotRepo,
+		cacheService:        config.CacheService,
+		symbolViews:         config.SymbolViews,
+		eventPublisher:      config.EventPublisher,
+		planQuotas:          config.PlanQuotas,
 	}
 
 	// Initialize external clients
 	factory.initializeFinnhubClient()
 	factory.initializeAlphaVantageClient()
 
+	// Archivo de respuestas crudas, desactivado sin TTL
+	if archiver := archive.New(config.PayloadRepo, config.Config.External.PayloadArchiveTTL, config.Logger); archiver != nil {
+		factory.finnhubClient.SetArchiver(archiver)
+		factory.alphavantageClient.SetArchiver(archiver)
+	}
+
 	return factory
 }
 
 // CreateMarketDataService creates a new market data service
 func (f *MarketDataFactory) CreateMarketDataService() interfaces.MarketDataService {
-	return services.NewMarketDataService(services.MarketDataServiceConfig{
+	service := services.NewMarketDataService(services.MarketDataServiceConfig{
 		MarketDataRepo:      f.marketDataRepo,
 		CompanyProfileRepo:  f.companyProfileRepo,
 		NewsRepo:            f.newsRepo,
 		BasicFinancialsRepo: f.basicFinancialsRepo,
 		CompanyRepo:         f.companyRepo,
+		QuoteSnapshotRepo:   f.quoteSnapshotRepo,
 		FinnhubClient:       f.finnhubClient,
 		FinnhubAdapter:      f.finnhubAdapter,
 		AlphaVantageClient:  f.alphavantageClient,
 		AlphaVantageAdapter: f.alphavantageAdapter,
 		Logger:              f.logger,
+		CacheService:        f.cacheService,
+		NegativeCacheTTL:    f.config.Cache.NegativeTTL,
+		TTLs:                marketDataTTLs(f.config),
+		SymbolViews:         f.symbolViews,
+		EventPublisher:      f.eventPublisher,
+		PlanQuotas:          f.planQuotas,
+		ProviderQuota: domainServices.NewProviderQuota(f.cacheService, map[string]int{
+			domainServices.ProviderFinnhub: f.config.External.Primary.DailyQuota,
+		}),
 	})
+
+	f.createdServices = append(f.createdServices, service)
+	return service
+}
+
+// marketDataTTLs traduce la configuración de TTLs de cache al formato del servicio
+func marketDataTTLs(cfg *config.Config) services.MarketDataTTLs {
+	return services.MarketDataTTLs{
+		Quote:           cfg.Cache.TTL.MarketData,
+		CompanyProfile:  cfg.Cache.TTL.CompanyProfile,
+		BasicFinancials: cfg.Cache.TTL.BasicFinancials,
+		News:            cfg.Cache.TTL.News,
+	}
 }
 
 // GetFinnhubClient returns the Finnhub client
@@ -111,12 +170,24 @@ func (f *MarketDataFactory) initializeFinnhubClient() {
 		baseURL = "https://finnhub.io/api/v1"
 	}
 
+	// Cliente HTTP compartido: timeout del proveedor, reintentos con backoff y proxy opcional
+	httpClient, err := httpclient.New(httpclient.FromConfig(f.config.External, f.config.External.Primary), f.logger)
+	if err != nil {
+		f.logger.Error(context.Background(), "Invalid external HTTP client configuration, using defaults", err)
+	}
+	if f.config.External.IsMock() {
+		httpClient = f.mockHTTPClient()
+	}
+
 	// Create Finnhub client
 	f.finnhubClient = finnhub.NewClient(finnhub.ClientConfig{
-		BaseURL: baseURL,
-		APIKey:  apiKey,
-		Timeout: 30 * time.Second,
-		Logger:  f.logger,
+		BaseURL:     baseURL,
+		APIKey:      apiKey,
+		APIKeys:     f.config.External.Primary.Keys,
+		KeyStrategy: f.config.External.KeyStrategy,
+		Timeout:     httpclient.DefaultTimeout,
+		HTTPClient:  httpClient,
+		Logger:      f.logger,
 	})
 
 	// Create Finnhub adapter
@@ -142,6 +213,9 @@ func (f *MarketDataFactory) initializeAlphaVantageClient() {
 
 	// Create Alpha Vantage client
 	f.alphavantageClient = alphavantage.NewClient(f.config, f.logger)
+	if f.config.External.IsMock() {
+		f.alphavantageClient.SetHTTPClient(f.mockHTTPClient())
+	}
 
 	// Create Alpha Vantage adapter
 	f.alphavantageAdapter = alphavantage.NewAdapter(f.logger)
@@ -150,6 +224,11 @@ func (f *MarketDataFactory) initializeAlphaVantageClient() {
 		logger.String("component", "alphavantage_client"))
 }
 
+// mockHTTPClient devuelve un cliente que responde con datos sintéticos (EXTERNAL_PROVIDER=mock)
+func (f *MarketDataFactory) mockHTTPClient() *http.Client {
+	return &http.Client{Transport: mockprovider.New(nil).Transport()}
+}
+
 // HealthCheck checks the health of external APIs
 func (f *MarketDataFactory) HealthCheck() map[string]string {
 	results := make(map[string]string)
@@ -178,11 +257,24 @@ func (f *MarketDataFactory) HealthCheck() map[string]string {
 	return results
 }
 
-// RefreshConfiguration refreshes the configuration and reinitializes clients
+// RefreshConfiguration aplica en caliente la configuración no estructural: las API keys se cambian en los
+// clientes existentes (los servicios ya creados los comparten) y los TTLs en los servicios creados
 func (f *MarketDataFactory) RefreshConfiguration(newConfig *config.Config) {
 	f.config = newConfig
-	f.initializeFinnhubClient()
-	f.initializeAlphaVantageClient()
 
-	f.logger.Info(nil, "Market data factory configuration refreshed")
+	if f.finnhubClient != nil {
+		f.finnhubClient.SetAPIKeys(newConfig.External.Primary.AllKeys(), newConfig.External.KeyStrategy)
+	}
+	if f.alphavantageClient != nil {
+		f.alphavantageClient.SetAPIKeys(newConfig.External.Secondary.AllKeys(), newConfig.External.KeyStrategy)
+	}
+
+	ttls := marketDataTTLs(newConfig)
+	for _, service := range f.createdServices {
+		if updater, ok := service.(ttlUpdater); ok {
+			updater.UpdateTTLs(ttls)
+		}
+	}
+
+	f.logger.Info(context.Background(), "Market data factory configuration refreshed")
 }
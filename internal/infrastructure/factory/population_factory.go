@@ -11,6 +11,7 @@ import (
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/config"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/database/cache"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/database/cockroachdb"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/mockprovider"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/stock_api"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
 )
@@ -18,6 +19,8 @@ import (
 // PopulationUseCaseFactory crea instancias del caso de uso de población
 type PopulationUseCaseFactory struct {
 	config *config.Config
+	// Conexión compartida opcional; si es nil se crea una nueva
+	db *cockroachdb.DB
 	// Cached dependencies for reuse
 	cachedUseCase      *population.PopulateDatabaseUseCase
 	cachedDependencies *PopulationDependencies
@@ -29,11 +32,14 @@ type PopulationDependencies struct {
 	CompanyRepo     interfaces.TransactionalCompanyRepository
 	BrokerageRepo   interfaces.TransactionalBrokerageRepository
 	StockRatingRepo interfaces.TransactionalStockRatingRepository
+	SyncStateRepo   interfaces.SyncStateRepository
+	RejectRepo      interfaces.PopulationRejectRepository
 
 	// Services
 	CacheService       services.CacheService
 	TransactionService services.TransactionService
 	IntegrityService   services.IntegrityValidationService
+	RuleEngine         *population.RuleEngine // nil si INGESTION_RULES_ENABLED=false
 
 	// External dependencies
 	DataProvider     population.StockDataProvider
@@ -48,6 +54,14 @@ func NewPopulationUseCaseFactory(cfg *config.Config) *PopulationUseCaseFactory {
 	}
 }
 
+// NewPopulationUseCaseFactoryWithDB crea una factory que reutiliza una conexión existente
+func NewPopulationUseCaseFactoryWithDB(cfg *config.Config, db *cockroachdb.DB) *PopulationUseCaseFactory {
+	return &PopulationUseCaseFactory{
+		config: cfg,
+		db:     db,
+	}
+}
+
 // CreatePopulateDatabaseUseCase crea una instancia completa del caso de uso
 func (f *PopulationUseCaseFactory) CreatePopulateDatabaseUseCase() (*population.PopulateDatabaseUseCase, error) {
 	if f.cachedUseCase != nil {
@@ -64,28 +78,73 @@ func (f *PopulationUseCaseFactory) CreatePopulateDatabaseUseCase() (*population.
 		dependencies.CompanyRepo,
 		dependencies.BrokerageRepo,
 		dependencies.StockRatingRepo,
+		dependencies.SyncStateRepo,
+		dependencies.RejectRepo,
 		dependencies.CacheService,
+		PopulationCacheTTLs(f.config),
 		dependencies.DataProvider,
 		dependencies.TransactionService,
 		dependencies.IntegrityService,
 		dependencies.PopulationLogger,
 	)
+	useCase.SetRuleEngine(dependencies.RuleEngine)
 
 	// Cache for reuse
 	f.cachedUseCase = useCase
 	return useCase, nil
 }
 
+// PopulationCacheTTLs traduce la configuración de TTLs de cache al formato del caso de uso
+func PopulationCacheTTLs(cfg *config.Config) population.CacheTTLs {
+	return population.CacheTTLs{
+		Company:     cfg.Cache.TTL.Company,
+		Brokerage:   cfg.Cache.TTL.Brokerage,
+		StockRating: cfg.Cache.TTL.StockRating,
+	}
+}
+
+// NewIngestionRuleEngine traduce la configuración de las reglas de ingesta al motor del caso de uso (nil si están desactivadas)
+func NewIngestionRuleEngine(cfg config.IngestionConfig) (*population.RuleEngine, error) {
+	if !cfg.RulesEnabled {
+		return nil, nil
+	}
+
+	minEventTime, err := cfg.MinEventTime()
+	if err != nil {
+		return nil, err
+	}
+	settings := population.RuleSettings{
+		Actions:         make(map[string]population.RuleAction, len(cfg.Rules)),
+		FutureTolerance: cfg.FutureTolerance,
+		MinEventTime:    minEventTime,
+	}
+	for name, action := range cfg.Rules {
+		settings.Actions[name] = population.RuleAction(action)
+	}
+	return population.NewRuleEngine(settings)
+}
+
 // createDependencies crea todas las dependencias necesarias para el caso de uso de población
 func (f *PopulationUseCaseFactory) createDependencies(enableCache bool, customDataProvider population.StockDataProvider) (*PopulationDependencies, error) {
 	if f.cachedDependencies != nil {
 		return f.cachedDependencies, nil
 	}
 
-	// 1. Database connection
-	db, err := cockroachdb.NewConnection(f.config)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create database connection: %w", err)
+	// 1. Database connection (shared or new)
+	db := f.db
+	if db == nil {
+		var err error
+		db, err = cockroachdb.NewConnection(f.config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create database connection: %w", err)
+		}
+	}
+
+	// Ensure sync state and rejects tables exist
+	if f.config.Database.AutoMigrate {
+		if err := db.MigrateUp(); err != nil {
+			return nil, err
+		}
 	}
 
 	// 2. Transaction service
@@ -95,17 +154,21 @@ func (f *PopulationUseCaseFactory) createDependencies(enableCache bool, customDa
 	companyRepo := implementation.NewTransactionalCompanyRepository(db.DB)
 	brokerageRepo := implementation.NewTransactionalBrokerageRepository(db.DB)
 	stockRatingRepo := implementation.NewTransactionalStockRatingRepository(db.DB)
+	syncStateRepo := implementation.NewSyncStateRepository(db.DB)
+	rejectRepo := implementation.NewPopulationRejectRepository(db.DB)
 
 	// 4. Cache service
 	var cacheService services.CacheService
 	if enableCache && f.config.Cache.Host != "" {
-		cacheService = cache.NewCacheService(f.config)
+		cacheService = cache.NewRedisCacheServiceWithFallback(f.config)
 	}
 
 	// 5. Data provider (custom or default)
 	var dataProvider population.StockDataProvider
 	if customDataProvider != nil {
 		dataProvider = customDataProvider
+	} else if f.config.External.IsMock() {
+		dataProvider = mockprovider.New(nil)
 	} else {
 		apiClient := stock_api.NewClient(f.config)
 		dataProvider = adapters.NewStockAPIDataProvider(apiClient)
@@ -127,24 +190,24 @@ func (f *PopulationUseCaseFactory) createDependencies(enableCache bool, customDa
 	integrityLogger := logger.NewIntegrityLogger(baseLogger, &logger.LogConfig{})
 
 	// 7. Integrity validation service
-	var integrityService services.IntegrityValidationService
+	validationConfig := services.DefaultValidationConfig()
 	if f.config.App.IsProduction() {
 		// Production: Use custom strict configuration
-		integrityService = CreateIntegrityValidationServiceWithCustomConfig(
-			companyRepo,
-			brokerageRepo,
-			stockRatingRepo,
-			baseLogger,
-			true, // isProduction
-		)
-	} else {
-		// Development: Use default configuration
-		integrityService = services.NewIntegrityValidationServiceWithDefaults(
-			companyRepo,
-			brokerageRepo,
-			stockRatingRepo,
-			integrityLogger,
-		)
+		applyEnvironmentValidationRules(validationConfig, true)
+	}
+	validationConfig.Rules.StockRating.DuplicateWindow = f.config.Ingestion.DuplicateWindow
+	integrityService := services.NewIntegrityValidationService(
+		companyRepo,
+		brokerageRepo,
+		stockRatingRepo,
+		integrityLogger,
+		validationConfig,
+	)
+
+	// 8. Ingestion rules engine
+	ruleEngine, err := NewIngestionRuleEngine(f.config.Ingestion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ingestion rules engine: %w", err)
 	}
 
 	// Cache dependencies
@@ -152,9 +215,12 @@ func (f *PopulationUseCaseFactory) createDependencies(enableCache bool, customDa
 		CompanyRepo:        companyRepo,
 		BrokerageRepo:      brokerageRepo,
 		StockRatingRepo:    stockRatingRepo,
+		SyncStateRepo:      syncStateRepo,
+		RejectRepo:         rejectRepo,
 		CacheService:       cacheService,
 		TransactionService: transactionService,
 		IntegrityService:   integrityService,
+		RuleEngine:         ruleEngine,
 		DataProvider:       dataProvider,
 		PopulationLogger:   populationLogger,
 		IntegrityLogger:    integrityLogger,
@@ -177,7 +243,19 @@ func CreateIntegrityValidationServiceWithCustomConfig(
 
 	// Create custom configuration based on environment
 	config := services.DefaultValidationConfig()
+	applyEnvironmentValidationRules(config, isProduction)
+
+	return services.NewIntegrityValidationService(
+		companyRepo,
+		brokerageRepo,
+		stockRatingRepo,
+		integrityLogger,
+		config,
+	)
+}
 
+// applyEnvironmentValidationRules ajusta los límites de validación: más estrictos en producción, más laxos en desarrollo
+func applyEnvironmentValidationRules(config *services.ValidationConfig, isProduction bool) {
 	if isProduction {
 		// Production: More strict validation
 		config.Rules.Company.ViolationsForCritical = 1    // Stricter for production
@@ -189,14 +267,6 @@ func CreateIntegrityValidationServiceWithCustomConfig(
 		config.Rules.StockRating.MaxAgeYearsBusiness = 25 // More tolerance
 		config.Thresholds.BusinessRulesWarningLimit = 10  // Higher threshold
 	}
-
-	return services.NewIntegrityValidationService(
-		companyRepo,
-		brokerageRepo,
-		stockRatingRepo,
-		integrityLogger,
-		config,
-	)
 }
 
 // CreatePopulateDatabaseUseCaseWithOptions crea el caso de uso con opciones personalizadas
@@ -214,12 +284,16 @@ func (f *PopulationUseCaseFactory) CreatePopulateDatabaseUseCaseWithOptions(
 		dependencies.CompanyRepo,
 		dependencies.BrokerageRepo,
 		dependencies.StockRatingRepo,
+		dependencies.SyncStateRepo,
+		dependencies.RejectRepo,
 		dependencies.CacheService,
+		PopulationCacheTTLs(f.config),
 		dependencies.DataProvider,
 		dependencies.TransactionService,
 		dependencies.IntegrityService,
 		dependencies.PopulationLogger,
 	)
+	useCase.SetRuleEngine(dependencies.RuleEngine)
 
 	return useCase, nil
 }
@@ -9,6 +9,7 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"gopkg.in/natefinch/lumberjack.v2"
@@ -17,7 +18,7 @@ import (
 // FileLogger implementa Logger con escritura a archivos
 type FileLogger struct {
 	config   *LogConfig
-	level    LogLevel
+	level    *atomic.Int32 // Compartido con los loggers derivados para que SetLevel les afecte
 	context  string
 	fields   map[string]interface{}
 	writers  []io.Writer
@@ -36,9 +37,12 @@ func NewFileLogger(config *LogConfig) (*FileLogger, error) {
 		return nil, err
 	}
 
+	level := new(atomic.Int32)
+	level.Store(int32(config.Level))
+
 	logger := &FileLogger{
 		config:   config,
-		level:    config.Level,
+		level:    level,
 		fields:   make(map[string]interface{}),
 		writers:  make([]io.Writer, 0),
 		rotators: make(map[string]*lumberjack.Logger),
@@ -122,7 +126,7 @@ func (l *FileLogger) getPopulationWriter() io.Writer {
 
 // log escribe una entrada de log
 func (l *FileLogger) log(ctx context.Context, level LogLevel, message string, err error, fields []Field) {
-	if level < l.level || l.closed {
+	if level < LogLevel(l.level.Load()) || l.closed {
 		return
 	}
 
@@ -327,9 +331,7 @@ func (l *FileLogger) WithFields(fields ...Field) Logger {
 }
 
 func (l *FileLogger) SetLevel(level LogLevel) {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-	l.level = level
+	l.level.Store(int32(level))
 }
 
 func (l *FileLogger) Close() error {
@@ -2,6 +2,8 @@ package logger
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -16,6 +18,24 @@ const (
 	FatalLevel
 )
 
+// ParseLogLevel convierte un nivel en texto (debug, info, warn, error, fatal) en LogLevel
+func ParseLogLevel(level string) (LogLevel, error) {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "debug":
+		return DebugLevel, nil
+	case "info":
+		return InfoLevel, nil
+	case "warn", "warning":
+		return WarnLevel, nil
+	case "error":
+		return ErrorLevel, nil
+	case "fatal":
+		return FatalLevel, nil
+	default:
+		return InfoLevel, fmt.Errorf("unknown log level %q", level)
+	}
+}
+
 func (l LogLevel) String() string {
 	switch l {
 	case DebugLevel:
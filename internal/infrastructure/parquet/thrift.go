@@ -0,0 +1,101 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// Tipos de campo del protocolo compacto de Thrift, con el que se serializan los metadatos de Parquet
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thriftWriter serializa structs con el protocolo compacto de Thrift; solo cubre lo que usan
+// FileMetaData y PageHeader
+type thriftWriter struct {
+	buf         bytes.Buffer
+	lastField   int16
+	parentField []int16
+}
+
+// fieldHeader escribe la cabecera de un campo como delta respecto al anterior cuando cabe en 4 bits
+func (w *thriftWriter) fieldHeader(id int16, fieldType byte) {
+	delta := id - w.lastField
+	if delta > 0 && delta <= 15 {
+		w.buf.WriteByte(byte(delta)<<4 | fieldType)
+	} else {
+		w.buf.WriteByte(fieldType)
+		w.varint(int64(id))
+	}
+	w.lastField = id
+}
+
+func (w *thriftWriter) i32Field(id int16, value int32) {
+	w.fieldHeader(id, thriftI32)
+	w.varint(int64(value))
+}
+
+func (w *thriftWriter) i64Field(id int16, value int64) {
+	w.fieldHeader(id, thriftI64)
+	w.varint(value)
+}
+
+func (w *thriftWriter) stringField(id int16, value string) {
+	w.fieldHeader(id, thriftBinary)
+	w.binary(value)
+}
+
+// structField abre un struct anidado; cada struct numera sus campos desde cero
+func (w *thriftWriter) structField(id int16) {
+	w.fieldHeader(id, thriftStruct)
+	w.beginStruct()
+}
+
+// listField escribe la cabecera de una lista; los elementos se escriben a continuación
+func (w *thriftWriter) listField(id int16, elemType byte, size int) {
+	w.fieldHeader(id, thriftList)
+	w.listHeader(elemType, size)
+}
+
+func (w *thriftWriter) listHeader(elemType byte, size int) {
+	if size < 15 {
+		w.buf.WriteByte(byte(size)<<4 | elemType)
+		return
+	}
+	w.buf.WriteByte(0xF0 | elemType)
+	w.uvarint(uint64(size))
+}
+
+func (w *thriftWriter) beginStruct() {
+	w.parentField = append(w.parentField, w.lastField)
+	w.lastField = 0
+}
+
+// endStruct escribe el campo STOP y recupera la numeración del struct padre
+func (w *thriftWriter) endStruct() {
+	w.buf.WriteByte(0)
+	if n := len(w.parentField); n > 0 {
+		w.lastField = w.parentField[n-1]
+		w.parentField = w.parentField[:n-1]
+	}
+}
+
+func (w *thriftWriter) binary(value string) {
+	w.uvarint(uint64(len(value)))
+	w.buf.WriteString(value)
+}
+
+// varint escribe un entero con codificación zigzag
+func (w *thriftWriter) varint(value int64) {
+	w.uvarint(uint64((value << 1) ^ (value >> 63)))
+}
+
+func (w *thriftWriter) uvarint(value uint64) {
+	var scratch [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(scratch[:], value)
+	w.buf.Write(scratch[:n])
+}
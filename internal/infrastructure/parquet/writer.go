@@ -0,0 +1,392 @@
+// Package parquet escribe ficheros Apache Parquet sencillos (un row group, una página por columna,
+// codificación PLAIN) sin dependencias externas; suficiente para exportar series de precios y ratings
+// que se cargan con pandas o pyarrow
+package parquet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// Type is the logical type of a column
+type Type int
+
+const (
+	Int64     Type = iota // INT64
+	Double                // DOUBLE
+	String                // BYTE_ARRAY (UTF8)
+	Date                  // INT32 (DATE), days since the Unix epoch
+	Timestamp             // INT64 (TIMESTAMP_MILLIS), milliseconds since the Unix epoch in UTC
+)
+
+// Codec is the compression applied to the data pages
+type Codec int
+
+const (
+	Uncompressed Codec = 0
+	Gzip         Codec = 2
+)
+
+// Column describes a column of the file; optional columns accept nil values
+type Column struct {
+	Name     string
+	Type     Type
+	Optional bool
+}
+
+// Valores de los enums de parquet.thrift
+const (
+	physicalInt32     = 1
+	physicalInt64     = 2
+	physicalDouble    = 5
+	physicalByteArray = 6
+
+	repetitionRequired = 0
+	repetitionOptional = 1
+
+	convertedUTF8            = 0
+	convertedDate            = 6
+	convertedTimestampMillis = 9
+
+	encodingPlain = 0
+	encodingRLE   = 3
+
+	pageTypeData = 0
+
+	formatVersion = 1
+	createdBy     = "stock-info-app"
+)
+
+var magic = []byte("PAR1")
+
+// Writer buffers rows in memory and writes them as a single row group
+type Writer struct {
+	columns []Column
+	codec   Codec
+	values  [][]any
+	rows    int
+}
+
+// NewWriter creates a writer for the given schema
+func NewWriter(columns []Column, codec Codec) *Writer {
+	return &Writer{
+		columns: columns,
+		codec:   codec,
+		values:  make([][]any, len(columns)),
+	}
+}
+
+// Rows returns the number of rows appended so far
+func (w *Writer) Rows() int {
+	return w.rows
+}
+
+// Append adds a row; values follow the order of the columns
+func (w *Writer) Append(values ...any) error {
+	if len(values) != len(w.columns) {
+		return fmt.Errorf("expected %d values, got %d", len(w.columns), len(values))
+	}
+
+	row := make([]any, len(values))
+	for i, value := range values {
+		normalized, err := normalize(w.columns[i], value)
+		if err != nil {
+			return err
+		}
+		row[i] = normalized
+	}
+
+	for i, value := range row {
+		w.values[i] = append(w.values[i], value)
+	}
+	w.rows++
+	return nil
+}
+
+// normalize convierte el valor al tipo físico de la columna (int64, float64, string o int32)
+func normalize(column Column, value any) (any, error) {
+	if value == nil {
+		if !column.Optional {
+			return nil, fmt.Errorf("column %s is required", column.Name)
+		}
+		return nil, nil
+	}
+
+	switch column.Type {
+	case Int64:
+		switch v := value.(type) {
+		case int64:
+			return v, nil
+		case int:
+			return int64(v), nil
+		case int32:
+			return int64(v), nil
+		}
+	case Double:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case float32:
+			return float64(v), nil
+		}
+	case String:
+		if v, ok := value.(string); ok {
+			return v, nil
+		}
+	case Date:
+		if v, ok := value.(time.Time); ok {
+			day := time.Date(v.Year(), v.Month(), v.Day(), 0, 0, 0, 0, time.UTC)
+			return int32(day.Unix() / 86400), nil
+		}
+	case Timestamp:
+		if v, ok := value.(time.Time); ok {
+			return v.UnixMilli(), nil
+		}
+	}
+	return nil, fmt.Errorf("column %s: unsupported value of type %T", column.Name, value)
+}
+
+// countingWriter lleva la posición en el fichero para los offsets de los metadatos
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// chunkInfo guarda lo que el footer necesita de cada columna escrita
+type chunkInfo struct {
+	offset           int64
+	uncompressedSize int64
+	compressedSize   int64
+}
+
+// WriteTo writes the complete file: magic, one data page per column and the footer
+func (w *Writer) WriteTo(out io.Writer) (int64, error) {
+	cw := &countingWriter{w: out}
+	if _, err := cw.Write(magic); err != nil {
+		return cw.n, err
+	}
+
+	chunks := make([]chunkInfo, len(w.columns))
+	for i, column := range w.columns {
+		body, err := w.pageBody(column, w.values[i])
+		if err != nil {
+			return cw.n, err
+		}
+		compressed, err := w.compress(body)
+		if err != nil {
+			return cw.n, err
+		}
+
+		header := w.pageHeader(len(body), len(compressed))
+		chunks[i] = chunkInfo{
+			offset:           cw.n,
+			uncompressedSize: int64(len(header) + len(body)),
+			compressedSize:   int64(len(header) + len(compressed)),
+		}
+		if _, err := cw.Write(header); err != nil {
+			return cw.n, err
+		}
+		if _, err := cw.Write(compressed); err != nil {
+			return cw.n, err
+		}
+	}
+
+	footer := w.fileMetaData(chunks)
+	if _, err := cw.Write(footer); err != nil {
+		return cw.n, err
+	}
+	var length [4]byte
+	binary.LittleEndian.PutUint32(length[:], uint32(len(footer)))
+	if _, err := cw.Write(length[:]); err != nil {
+		return cw.n, err
+	}
+	_, err := cw.Write(magic)
+	return cw.n, err
+}
+
+// pageBody codifica los niveles de definición (solo columnas opcionales) y los valores no nulos en PLAIN
+func (w *Writer) pageBody(column Column, values []any) ([]byte, error) {
+	var body bytes.Buffer
+
+	if column.Optional {
+		levels := definitionLevels(values)
+		var length [4]byte
+		binary.LittleEndian.PutUint32(length[:], uint32(len(levels)))
+		body.Write(length[:])
+		body.Write(levels)
+	}
+
+	var scratch [8]byte
+	for _, value := range values {
+		switch v := value.(type) {
+		case nil:
+			continue
+		case int32:
+			binary.LittleEndian.PutUint32(scratch[:4], uint32(v))
+			body.Write(scratch[:4])
+		case int64:
+			binary.LittleEndian.PutUint64(scratch[:], uint64(v))
+			body.Write(scratch[:])
+		case float64:
+			binary.LittleEndian.PutUint64(scratch[:], math.Float64bits(v))
+			body.Write(scratch[:])
+		case string:
+			binary.LittleEndian.PutUint32(scratch[:4], uint32(len(v)))
+			body.Write(scratch[:4])
+			body.WriteString(v)
+		default:
+			return nil, fmt.Errorf("column %s: unexpected value of type %T", column.Name, value)
+		}
+	}
+	return body.Bytes(), nil
+}
+
+// definitionLevels codifica los niveles (1 presente, 0 nulo) con runs RLE del híbrido RLE/bit-packing
+// de ancho 1 bit
+func definitionLevels(values []any) []byte {
+	var encoded bytes.Buffer
+	var scratch [binary.MaxVarintLen64]byte
+
+	for start := 0; start < len(values); {
+		present := values[start] != nil
+		end := start + 1
+		for end < len(values) && (values[end] != nil) == present {
+			end++
+		}
+
+		n := binary.PutUvarint(scratch[:], uint64(end-start)<<1)
+		encoded.Write(scratch[:n])
+		if present {
+			encoded.WriteByte(1)
+		} else {
+			encoded.WriteByte(0)
+		}
+		start = end
+	}
+	return encoded.Bytes()
+}
+
+func (w *Writer) compress(body []byte) ([]byte, error) {
+	if w.codec != Gzip {
+		return body, nil
+	}
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	if _, err := gz.Write(body); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return compressed.Bytes(), nil
+}
+
+// pageHeader serializa el PageHeader de una página de datos v1
+func (w *Writer) pageHeader(uncompressedSize, compressedSize int) []byte {
+	t := &thriftWriter{}
+	t.i32Field(1, pageTypeData)
+	t.i32Field(2, int32(uncompressedSize))
+	t.i32Field(3, int32(compressedSize))
+	t.structField(5) // DataPageHeader
+	t.i32Field(1, int32(w.rows))
+	t.i32Field(2, encodingPlain)
+	t.i32Field(3, encodingRLE)
+	t.i32Field(4, encodingRLE)
+	t.endStruct()
+	t.endStruct()
+	return t.buf.Bytes()
+}
+
+// fileMetaData serializa el footer: esquema plano y un único row group
+func (w *Writer) fileMetaData(chunks []chunkInfo) []byte {
+	t := &thriftWriter{}
+	t.i32Field(1, formatVersion)
+
+	t.listField(2, thriftStruct, len(w.columns)+1)
+	t.beginStruct()
+	t.stringField(4, "schema")
+	t.i32Field(5, int32(len(w.columns)))
+	t.endStruct()
+	for _, column := range w.columns {
+		physical, converted := column.Type.physical()
+		repetition := int32(repetitionRequired)
+		if column.Optional {
+			repetition = repetitionOptional
+		}
+
+		t.beginStruct()
+		t.i32Field(1, physical)
+		t.i32Field(3, repetition)
+		t.stringField(4, column.Name)
+		if converted >= 0 {
+			t.i32Field(6, converted)
+		}
+		t.endStruct()
+	}
+
+	t.i64Field(3, int64(w.rows))
+
+	var totalSize int64
+	for _, chunk := range chunks {
+		totalSize += chunk.uncompressedSize
+	}
+
+	t.listField(4, thriftStruct, 1)
+	t.beginStruct() // RowGroup
+	t.listField(1, thriftStruct, len(w.columns))
+	for i, column := range w.columns {
+		physical, _ := column.Type.physical()
+		chunk := chunks[i]
+
+		t.beginStruct() // ColumnChunk
+		t.i64Field(2, chunk.offset)
+		t.structField(3) // ColumnMetaData
+		t.i32Field(1, physical)
+		t.listField(2, thriftI32, 2)
+		t.varint(encodingPlain)
+		t.varint(encodingRLE)
+		t.listField(3, thriftBinary, 1)
+		t.binary(column.Name)
+		t.i32Field(4, int32(w.codec))
+		t.i64Field(5, int64(w.rows))
+		t.i64Field(6, chunk.uncompressedSize)
+		t.i64Field(7, chunk.compressedSize)
+		t.i64Field(9, chunk.offset)
+		t.endStruct()
+		t.endStruct()
+	}
+	t.i64Field(2, totalSize)
+	t.i64Field(3, int64(w.rows))
+	t.endStruct()
+
+	t.stringField(6, createdBy)
+	t.endStruct()
+	return t.buf.Bytes()
+}
+
+// physical devuelve el tipo físico y el converted type (-1 si no lleva) de la columna
+func (t Type) physical() (int32, int32) {
+	switch t {
+	case Double:
+		return physicalDouble, -1
+	case String:
+		return physicalByteArray, convertedUTF8
+	case Date:
+		return physicalInt32, convertedDate
+	case Timestamp:
+		return physicalInt64, convertedTimestampMillis
+	default:
+		return physicalInt64, -1
+	}
+}
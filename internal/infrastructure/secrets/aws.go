@@ -0,0 +1,77 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	smtypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+)
+
+// AWSConfig configura el acceso a AWS Secrets Manager. Las credenciales se obtienen
+// de la cadena estándar del SDK (variables AWS_*, perfil compartido, rol de la instancia).
+type AWSConfig struct {
+	Region string // Vacío = región por defecto del entorno
+}
+
+// AWSProvider lee secretos de AWS Secrets Manager
+type AWSProvider struct {
+	client *secretsmanager.Client
+}
+
+// NewAWSProvider crea un proveedor de AWS Secrets Manager
+func NewAWSProvider(ctx context.Context, cfg AWSConfig) (*AWSProvider, error) {
+	var options []func(*awsconfig.LoadOptions) error
+	if cfg.Region != "" {
+		options = append(options, awsconfig.WithRegion(cfg.Region))
+	}
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+	if awsCfg.Region == "" {
+		return nil, fmt.Errorf("AWS region is required")
+	}
+
+	return &AWSProvider{client: secretsmanager.NewFromConfig(awsCfg)}, nil
+}
+
+// Name implements Provider
+func (p *AWSProvider) Name() string {
+	return "aws"
+}
+
+// GetSecret implements Provider. Un SecretString con un objeto JSON se expone campo a campo;
+// cualquier otro valor se expone como un único campo "value".
+func (p *AWSProvider) GetSecret(ctx context.Context, name string) (map[string]string, error) {
+	output, err := p.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: &name,
+	})
+	if err != nil {
+		var notFound *smtypes.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return nil, fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+		}
+		return nil, fmt.Errorf("failed to read secret %s from AWS Secrets Manager: %w", name, err)
+	}
+
+	if output.SecretString == nil {
+		return nil, fmt.Errorf("secret %s has no string value", name)
+	}
+	secretString := *output.SecretString
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(secretString), &fields); err != nil {
+		return map[string]string{"value": secretString}, nil
+	}
+
+	values := make(map[string]string, len(fields))
+	for key, value := range fields {
+		values[key] = fmt.Sprint(value)
+	}
+	return values, nil
+}
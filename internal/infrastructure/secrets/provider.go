@@ -0,0 +1,66 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ReferencePrefix marca un valor de variable de entorno que debe resolverse en el gestor de secretos.
+// Formato: secret:<nombre>#<campo> (p.ej. PRIMARY_API_KEY=secret:stock-info/finnhub#api_key).
+// Sin #campo se usa el valor completo del secreto si solo tiene uno.
+const ReferencePrefix = "secret:"
+
+// ErrSecretNotFound se devuelve cuando el secreto o el campo pedido no existen
+var ErrSecretNotFound = errors.New("secret not found")
+
+// Provider obtiene secretos de un gestor externo (Vault, AWS Secrets Manager)
+type Provider interface {
+	// Name identifica al proveedor en logs y errores
+	Name() string
+	// GetSecret devuelve los pares clave/valor del secreto
+	GetSecret(ctx context.Context, name string) (map[string]string, error)
+}
+
+// Reference es una referencia a un campo de un secreto
+type Reference struct {
+	Name  string
+	Field string
+}
+
+// ParseReference interpreta un valor con el prefijo secret:. Devuelve false si el valor no es una referencia.
+func ParseReference(value string) (Reference, bool) {
+	if !strings.HasPrefix(value, ReferencePrefix) {
+		return Reference{}, false
+	}
+
+	name, field, _ := strings.Cut(strings.TrimPrefix(value, ReferencePrefix), "#")
+	return Reference{Name: strings.TrimSpace(name), Field: strings.TrimSpace(field)}, true
+}
+
+// String devuelve la referencia en el formato de las variables de entorno
+func (r Reference) String() string {
+	if r.Field == "" {
+		return ReferencePrefix + r.Name
+	}
+	return ReferencePrefix + r.Name + "#" + r.Field
+}
+
+// selectField extrae el campo referenciado de un secreto
+func selectField(ref Reference, values map[string]string) (string, error) {
+	if ref.Field == "" {
+		if len(values) == 1 {
+			for _, value := range values {
+				return value, nil
+			}
+		}
+		return "", fmt.Errorf("secret %s has %d fields, reference one with #field", ref.Name, len(values))
+	}
+
+	value, ok := values[ref.Field]
+	if !ok {
+		return "", fmt.Errorf("field %q of secret %s: %w", ref.Field, ref.Name, ErrSecretNotFound)
+	}
+	return value, nil
+}
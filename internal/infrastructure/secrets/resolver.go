@@ -0,0 +1,92 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL es el tiempo que se reutiliza un secreto antes de volver a pedirlo al proveedor
+const DefaultCacheTTL = 5 * time.Minute
+
+// Resolver resuelve referencias secret: con una cache en memoria, para que las recargas de
+// configuración no consulten al gestor de secretos cada vez
+type Resolver struct {
+	provider Provider
+	ttl      time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cachedSecret
+}
+
+type cachedSecret struct {
+	values    map[string]string
+	fetchedAt time.Time
+}
+
+// NewResolver crea un resolver; un ttl no positivo usa DefaultCacheTTL
+func NewResolver(provider Provider, ttl time.Duration) *Resolver {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+
+	return &Resolver{
+		provider: provider,
+		ttl:      ttl,
+		cache:    make(map[string]cachedSecret),
+	}
+}
+
+// ProviderName devuelve el nombre del proveedor configurado
+func (r *Resolver) ProviderName() string {
+	return r.provider.Name()
+}
+
+// Resolve devuelve el valor de la referencia. Si el proveedor falla y hay una versión cacheada
+// se sigue usando esa, para que una caída del gestor no rompa una rotación.
+func (r *Resolver) Resolve(ctx context.Context, ref Reference) (string, error) {
+	if ref.Name == "" {
+		return "", fmt.Errorf("empty secret reference")
+	}
+
+	values, err := r.secret(ctx, ref.Name)
+	if err != nil {
+		return "", err
+	}
+	return selectField(ref, values)
+}
+
+// Expire marca la cache como caducada: la siguiente resolución lee la versión actual de cada secreto
+// (las versiones anteriores se conservan por si el proveedor no responde)
+func (r *Resolver) Expire() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for name, cached := range r.cache {
+		cached.fetchedAt = time.Time{}
+		r.cache[name] = cached
+	}
+}
+
+// secret devuelve el secreto cacheado o lo pide al proveedor si caducó
+func (r *Resolver) secret(ctx context.Context, name string) (map[string]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cached, ok := r.cache[name]
+	if ok && time.Since(cached.fetchedAt) < r.ttl {
+		return cached.values, nil
+	}
+
+	values, err := r.provider.GetSecret(ctx, name)
+	if err != nil {
+		if ok {
+			return cached.values, nil
+		}
+		return nil, fmt.Errorf("%s: failed to read secret %s: %w", r.provider.Name(), name, err)
+	}
+
+	r.cache[name] = cachedSecret{values: values, fetchedAt: time.Now()}
+	return values, nil
+}
@@ -0,0 +1,115 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// VaultConfig configura el acceso a un motor KV v2 de HashiCorp Vault
+type VaultConfig struct {
+	Address   string // p.ej. https://vault.internal:8200
+	Token     string
+	Mount     string // Montaje del motor KV v2 (por defecto "secret")
+	Namespace string // Solo Vault Enterprise
+	Timeout   time.Duration
+}
+
+// VaultProvider lee secretos de Vault KV v2 por su API HTTP
+type VaultProvider struct {
+	config     VaultConfig
+	httpClient *http.Client
+}
+
+// NewVaultProvider crea un proveedor de Vault
+func NewVaultProvider(cfg VaultConfig) (*VaultProvider, error) {
+	if cfg.Address == "" {
+		return nil, fmt.Errorf("vault address is required")
+	}
+	if cfg.Token == "" {
+		return nil, fmt.Errorf("vault token is required")
+	}
+	if cfg.Mount == "" {
+		cfg.Mount = "secret"
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+
+	return &VaultProvider{
+		config:     cfg,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+	}, nil
+}
+
+// Name implements Provider
+func (p *VaultProvider) Name() string {
+	return "vault"
+}
+
+// vaultKVResponse es la respuesta de GET /v1/<mount>/data/<path>
+type vaultKVResponse struct {
+	Data struct {
+		Data map[string]interface{} `json:"data"`
+	} `json:"data"`
+	Errors []string `json:"errors"`
+}
+
+// GetSecret implements Provider
+func (p *VaultProvider) GetSecret(ctx context.Context, name string) (map[string]string, error) {
+	secretPath := strings.Trim(name, "/")
+	reqURL := fmt.Sprintf("%s/v1/%s/data/%s",
+		strings.TrimRight(p.config.Address, "/"), strings.Trim(p.config.Mount, "/"), escapePath(secretPath))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vault request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", p.config.Token)
+	if p.config.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.config.Namespace)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("vault request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vault response: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("vault secret %s: %w", secretPath, ErrSecretNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vault returned status %d for secret %s", resp.StatusCode, secretPath)
+	}
+
+	var payload vaultKVResponse
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to decode vault response: %w", err)
+	}
+
+	values := make(map[string]string, len(payload.Data.Data))
+	for key, value := range payload.Data.Data {
+		values[key] = fmt.Sprint(value)
+	}
+	return values, nil
+}
+
+// escapePath escapa cada segmento del path del secreto manteniendo las barras
+func escapePath(secretPath string) string {
+	segments := strings.Split(secretPath, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,156 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	domainServices "github.com/MayaCris/stock-info-app/internal/domain/services"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/config"
+)
+
+// LocalStore stores objects as files under a root directory; keys map to relative paths
+type LocalStore struct {
+	root string
+}
+
+// NewExportStore creates the destination of the Parquet exports: a local directory or the S3 bucket
+// reached with the BACKUP_S3_* endpoint and credentials
+func NewExportStore(cfg *config.Config) (domainServices.ObjectStore, error) {
+	if cfg.Export.Destination != config.ExportDestinationS3 {
+		return NewLocalStore(cfg.Export.LocalDir)
+	}
+
+	store, err := NewS3Store(S3Options{
+		Endpoint:        cfg.Backup.Endpoint,
+		Region:          cfg.Backup.Region,
+		Bucket:          cfg.Export.Bucket,
+		PathStyle:       cfg.Backup.PathStyle,
+		AccessKeyID:     cfg.Backup.AccessKeyID,
+		SecretAccessKey: cfg.Backup.SecretAccessKey,
+		SessionToken:    cfg.Backup.SessionToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// NewLocalStore creates a store rooted at dir; the directory is created on the first upload
+func NewLocalStore(dir string) (*LocalStore, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("local store directory is required")
+	}
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("invalid local store directory %q: %w", dir, err)
+	}
+	return &LocalStore{root: root}, nil
+}
+
+// Put implements ObjectStore; the file is written to a temporary name and renamed, so readers
+// never see a partial object
+func (s *LocalStore) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", key, err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", key, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", key, err)
+	}
+	if written != size {
+		return fmt.Errorf("failed to write %s: wrote %d bytes, expected %d", key, written, size)
+	}
+
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return fmt.Errorf("failed to write %s: %w", key, err)
+	}
+	return nil
+}
+
+// Get implements ObjectStore
+func (s *LocalStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	target, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(target)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, domainServices.ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", key, err)
+	}
+	return file, nil
+}
+
+// List implements ObjectStore
+func (s *LocalStore) List(ctx context.Context, prefix string) ([]domainServices.ObjectInfo, error) {
+	var objects []domainServices.ObjectInfo
+	err := filepath.WalkDir(s.root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".upload-") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.root, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, domainServices.ObjectInfo{
+			Key:          key,
+			Size:         info.Size(),
+			LastModified: info.ModTime(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", prefix, err)
+	}
+
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+// path resuelve la clave dentro del directorio raíz, rechazando claves que escapen de él
+func (s *LocalStore) path(key string) (string, error) {
+	target := filepath.Join(s.root, filepath.FromSlash(key))
+	if key == "" || !strings.HasPrefix(target, s.root+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid object key %q", key)
+	}
+	return target, nil
+}
@@ -1,17 +1,29 @@
 package factory
 
 import (
+	"context"
 	"fmt"
+	"time"
 
+	"github.com/MayaCris/stock-info-app/internal/application/jobs"
+	"github.com/MayaCris/stock-info-app/internal/application/outbox"
 	"github.com/MayaCris/stock-info-app/internal/application/services"
 	serviceInterfaces "github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/application/usecases/enrichment"
+	"github.com/MayaCris/stock-info-app/internal/application/usecases/population"
+	"github.com/MayaCris/stock-info-app/internal/application/usecases/warmup"
+	"github.com/MayaCris/stock-info-app/internal/domain/events"
 	"github.com/MayaCris/stock-info-app/internal/domain/repositories/implementation"
+	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
 	domainServices "github.com/MayaCris/stock-info-app/internal/domain/services"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/config"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/database/cache"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/database/cockroachdb"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/eventbus"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/alphavantage"
 	infraFactory "github.com/MayaCris/stock-info-app/internal/infrastructure/factory"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/storage"
 )
 
 // APIFactory crea instancias de servicios y dependencias para handlers REST
@@ -32,14 +44,52 @@ func NewAPIFactory(cfg *config.Config) *APIFactory {
 // Dependencies representa todas las dependencias necesarias para los handlers
 type Dependencies struct {
 	CompanyService      serviceInterfaces.CompanyService
+	CompanyLogos        serviceInterfaces.CompanyLogoService
+	CompanyOverview     serviceInterfaces.CompanyOverviewService
 	BrokerageService    serviceInterfaces.BrokerageService
 	StockService        serviceInterfaces.StockRatingService
+	RatingPoll          serviceInterfaces.RatingPollService
 	AnalysisService     serviceInterfaces.AnalysisService
 	MarketDataService   serviceInterfaces.MarketDataService
 	AlphaVantageService serviceInterfaces.AlphaVantageService
+	SearchService       serviceInterfaces.SearchService
+	PeerService         serviceInterfaces.PeerService
+	RelationshipService serviceInterfaces.CompanyRelationshipService
+	TagService          serviceInterfaces.TagService
+	NoteService         serviceInterfaces.NoteService // nil si TENANCY_ENABLED=false
+	FinancialStatements serviceInterfaces.FinancialStatementService
+	BenchmarkService    serviceInterfaces.BenchmarkService
+	BacktestService     serviceInterfaces.BacktestService
+	AnomalyService      serviceInterfaces.AnomalyService
+	MarketStatusService serviceInterfaces.MarketStatusService
+	TrendingService     serviceInterfaces.TrendingService
+	DashboardService    serviceInterfaces.DashboardService
 	Logger              logger.Logger
 	CacheService        domainServices.CacheService
 	TransactionService  domainServices.TransactionService
+	PopulationRunner    *population.PopulationRunner
+	RejectService       *population.RejectService
+	EnrichmentService   *enrichment.CompanyEnrichmentService
+	AnalyticsViews      repoInterfaces.AnalyticsViewRepository // nil si DB_ANALYTICS_VIEWS=false
+	JobQueue            *jobs.JobQueue
+	JobWorkerPool       *jobs.WorkerPool
+	Database            *cockroachdb.DB
+	CacheWarmer         *warmup.CacheWarmer
+	ConfigWatcher       *config.Watcher
+	PayloadArchive      serviceInterfaces.PayloadArchiveService
+	BackupService       serviceInterfaces.BackupService // nil si BACKUP_ENABLED=false
+	ParquetExport       serviceInterfaces.ParquetExportService
+	Seed                serviceInterfaces.SeedService
+	RatingTaxonomy      serviceInterfaces.RatingTaxonomyService
+	RatingProcessing    serviceInterfaces.RatingProcessingService
+	IntegrityHistory    serviceInterfaces.IntegrityHistoryService
+	IntegrityRepair     serviceInterfaces.IntegrityRepairService
+	TenantService       serviceInterfaces.TenantService
+	UsageService        serviceInterfaces.UsageService
+	UsageCounters       *domainServices.UsageCounters
+	PlanQuotas          *domainServices.PlanQuotas
+	EventBus            events.Bus    // RatingCreated, RatingProcessed, RatingBacklogExceeded, QuoteUpdated y CompanyUpdated; se cierra en el shutdown
+	OutboxRelay         *outbox.Relay // nil si EVENTS_OUTBOX=false; lo arrancan los workers
 }
 
 // CreateDependencies crea todas las dependencias necesarias para los handlers
@@ -56,15 +106,16 @@ func (f *APIFactory) CreateDependencies() (*Dependencies, error) {
 
 	// 2. Transaction service
 	transactionService := domainServices.NewTransactionService(db.DB)
-	// 3. Repositories
-	companyRepo := implementation.NewCompanyRepository(db.DB)
-	brokerageRepo := implementation.NewBrokerageRepository(db.DB)
-	stockRatingRepo := implementation.NewStockRatingRepository(db.DB)
+	// 3. Repositories (list and analytics reads go to the read replicas when configured)
+	companyRepo := implementation.NewCompanyRepositoryWithReader(db.DB, db.Reader())
+	brokerageRepo := implementation.NewBrokerageRepositoryWithReader(db.DB, db.Reader())
+	stockRatingRepo := implementation.NewStockRatingRepositoryWithReader(db.DB, db.Reader())
 	// Market data repositories
 	marketDataRepo := implementation.NewMarketDataRepository(db.DB)
 	companyProfileRepo := implementation.NewCompanyProfileRepository(db.DB)
 	newsRepo := implementation.NewNewsRepository(db.DB)
 	basicFinancialsRepo := implementation.NewBasicFinancialsRepository(db.DB)
+	payloadRepo := implementation.NewProviderPayloadRepository(db.DB)
 
 	// Alpha Vantage specific repositories
 	historicalDataRepo := implementation.NewHistoricalDataRepository(db.DB)
@@ -74,14 +125,61 @@ func (f *APIFactory) CreateDependencies() (*Dependencies, error) {
 	// 4. Cache service
 	var cacheService domainServices.CacheService
 	if f.config.Cache.Host != "" {
-		cacheService = cache.NewCacheService(f.config)
+		// Redis con fallback en memoria; las evicciones se propagan al resto de instancias por pub/sub
+		cacheService = cache.NewRedisCacheServiceWithFallback(f.config)
 	}
 
+	// Agregados de analíticas leídos de las vistas materializadas (se refrescan con el job analytics_refresh)
+	var analyticsViews repoInterfaces.AnalyticsViewRepository
+	if f.config.Database.AnalyticsViews {
+		analyticsViews = implementation.NewAnalyticsViewRepository(db.DB, db.Reader())
+		companyRepo = implementation.NewViewCompanyRepository(companyRepo, analyticsViews)
+		stockRatingRepo = implementation.NewViewStockRatingRepository(stockRatingRepo, analyticsViews)
+		analyticsViews = implementation.NewCachedAnalyticsViewRepository(analyticsViews, cacheService, f.config.Cache.QueryTTL)
+	}
+
+	// Consultas de lectura frecuente (por ticker, sector, analíticas) cacheadas; las escrituras las invalidan
+	companyRepo = implementation.NewCachedCompanyRepository(companyRepo, cacheService, f.config.Cache.QueryTTL)
+	stockRatingRepo = implementation.NewCachedStockRatingRepository(stockRatingRepo, cacheService, f.config.Cache.QueryTTL)
+	marketDataRepo = implementation.NewCachedMarketDataRepository(marketDataRepo, cacheService, f.config.Cache.QueryTTL)
+
 	// 5. Logger
 	appLogger, err := logger.InitializeGlobalLogger()
 	if err != nil {
 		return nil, err
 	}
+	// Bus de eventos de dominio (en memoria o NATS según EVENTS_BACKEND)
+	eventBus, err := eventbus.New(f.config, appLogger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create event bus: %w", err)
+	}
+	// Con EVENTS_OUTBOX los caminos de escritura guardan los eventos en event_outbox y el relay los publica en el bus
+	var eventPublisher events.Publisher = eventBus
+	var outboxRelay *outbox.Relay
+	if f.config.Events.Outbox {
+		outboxRepo := implementation.NewOutboxRepository(db.DB)
+		eventPublisher = outbox.NewWriter(outboxRepo)
+		outboxRelay = outbox.NewRelay(outboxRepo, eventBus, outbox.RelayConfig{
+			PollInterval: f.config.Events.OutboxPollInterval,
+			BatchSize:    f.config.Events.OutboxBatchSize,
+			RetryBackoff: f.config.Events.OutboxRetryBackoff,
+			Retention:    f.config.Events.OutboxRetention,
+		}, appLogger)
+	}
+
+	// Vistas por símbolo en la cache: ranking de trending y prioridad del refresco de cotizaciones
+	symbolViews := domainServices.NewSymbolViews(cacheService)
+
+	// Cuotas diarias por plan (peticiones y refrescos de símbolos), solo con multi-tenancy
+	var tenantRepo repoInterfaces.TenantRepository
+	var planQuotas *domainServices.PlanQuotas
+	if f.config.Tenancy.Enabled {
+		tenantRepo = implementation.NewTenantRepository(db.DB)
+		if f.config.Plans.Enabled {
+			planQuotas = domainServices.NewPlanQuotas(cacheService, planLimits(f.config.Plans), f.config.Plans.Default)
+		}
+	}
+
 	// 6. Create market data service using market data factory
 	marketDataFactory := infraFactory.NewMarketDataFactory(infraFactory.MarketDataFactoryConfig{
 		Config:              f.config,
@@ -91,8 +189,56 @@ func (f *APIFactory) CreateDependencies() (*Dependencies, error) {
 		NewsRepo:            newsRepo,
 		BasicFinancialsRepo: basicFinancialsRepo,
 		CompanyRepo:         companyRepo,
+		QuoteSnapshotRepo:   implementation.NewQuoteSnapshotRepository(db.DB),
+		CacheService:        cacheService,
+		SymbolViews:         symbolViews,
+		PayloadRepo:         payloadRepo,
+		EventPublisher:      eventPublisher,
+		PlanQuotas:          planQuotas,
 	})
 	marketDataService := marketDataFactory.CreateMarketDataService()
+
+	// Grafo de competidores: Finnhub peers o, en su defecto, sector y capitalización
+	peerService := services.NewPeerService(companyRepo, implementation.NewCompanyPeerRepository(db.DB),
+		marketDataFactory.GetFinnhubClient(), f.config.Cache.TTL.Peers, appLogger)
+
+	// Relaciones declaradas entre companies (proveedores, competidores, filiales)
+	relationshipService := services.NewCompanyRelationshipService(companyRepo, implementation.NewCompanyRelationshipRepository(db.DB), appLogger)
+
+	// Etiquetas temáticas para las listas curadas (?tags=ai,ev)
+	tagService := services.NewTagService(companyRepo, implementation.NewTagRepository(db.DB), appLogger)
+
+	// Notas privadas de los usuarios: pertenecen a una credencial, así que solo existen con multi-tenancy
+	var noteRepo repoInterfaces.NoteRepository
+	var noteService serviceInterfaces.NoteService
+	if f.config.Tenancy.Enabled {
+		noteRepo = implementation.NewNoteRepository(db.DB)
+		noteService = services.NewNoteService(noteRepo, companyRepo, stockRatingRepo, appLogger)
+	}
+
+	// Estados financieros guardados; sin cliente de Alpha Vantage solo se sirven los ya descargados
+	var statementProvider domainServices.FinancialStatementProvider
+	if client := marketDataFactory.GetAlphaVantageClient(); client != nil {
+		statementProvider = alphavantage.NewStatementProvider(client, marketDataFactory.GetAlphaVantageAdapter())
+	}
+	financialStatementService := services.NewFinancialStatementService(companyRepo, implementation.NewFinancialStatementRepository(db.DB),
+		statementProvider, f.config.Cache.TTL.Statements, appLogger)
+
+	// Índices de referencia (S&P 500, NASDAQ-100); sin cliente de Alpha Vantage no se pueden sincronizar. Los
+	// personalizados se calculan con los precios históricos guardados
+	benchmarkRepo := implementation.NewBenchmarkRepository(db.DB)
+	var benchmarkProvider domainServices.BenchmarkProvider
+	if client := marketDataFactory.GetAlphaVantageClient(); client != nil {
+		benchmarkProvider = alphavantage.NewBenchmarkProvider(client, appLogger)
+	}
+	benchmarkService := services.NewBenchmarkService(benchmarkRepo, companyRepo, historicalDataRepo, benchmarkProvider, appLogger)
+
+	// Tipos de cambio para agregar capitalizaciones en distintas monedas; sin cliente solo se agrega la misma moneda
+	var fxProvider domainServices.FXRateProvider
+	if client := marketDataFactory.GetAlphaVantageClient(); client != nil {
+		fxProvider = alphavantage.NewFXRateProvider(client)
+	}
+	fxService := services.NewFXService(fxProvider, f.config.Cache.TTL.MarketData, appLogger)
 	// 7. Service factory with Alpha Vantage components
 	if f.serviceFactory == nil {
 		f.serviceFactory = services.NewServiceFactory(services.ServiceFactoryConfig{
@@ -100,10 +246,19 @@ func (f *APIFactory) CreateDependencies() (*Dependencies, error) {
 			BrokerageRepo:           brokerageRepo,
 			StockRatingRepo:         stockRatingRepo,
 			HistoricalDataRepo:      historicalDataRepo,
+			MarketDataRepo:          marketDataRepo,
+			BenchmarkRepo:           benchmarkRepo,
+			NoteRepo:                noteRepo,
+			FXService:               fxService,
 			FinancialMetricsRepo:    financialMetricsRepo,
 			TechnicalIndicatorsRepo: technicalIndicatorsRepo,
 			AlphaVantageClient:      marketDataFactory.GetAlphaVantageClient(),
 			AlphaVantageAdapter:     marketDataFactory.GetAlphaVantageAdapter(),
+			PeerService:             peerService,
+			CacheService:            cacheService,
+			AnalysisCacheTTL:        f.config.Cache.TTL.Analytics,
+			ScoringWeights:          scoringWeights(f.config.Analysis),
+			EventPublisher:          eventPublisher,
 			Logger:                  appLogger,
 		})
 	}
@@ -111,22 +266,235 @@ func (f *APIFactory) CreateDependencies() (*Dependencies, error) {
 	companyService := f.serviceFactory.GetCompanyService()
 	brokerageService := f.serviceFactory.GetBrokerageService()
 	stockService := f.serviceFactory.GetStockRatingService()
+	// Long-poll de ratings nuevos: se despierta con los rating.created del bus
+	ratingPoll := services.NewRatingPollService(stockRatingRepo, companyRepo, brokerageRepo, eventBus, appLogger)
 	analysisService := f.serviceFactory.GetAnalysisService()
+	backtestService := f.serviceFactory.GetBacktestService()
 
 	// 9. Create Alpha Vantage service using service factory
 	alphaVantageService := f.serviceFactory.GetAlphaVantageService()
 
-	// 10. Cache dependencies
+	// Búsqueda de texto completo sobre companies y noticias (columnas tsvector, migración 000009)
+	searchService := services.NewSearchService(implementation.NewSearchRepository(db.Reader()), appLogger)
+
+	// Detección de picos de ratings y de volumen (tabla anomalies, migración 000012)
+	anomalyRepo := implementation.NewAnomalyRepository(db.DB)
+	anomalyService := services.NewAnomalyService(stockRatingRepo, historicalDataRepo, anomalyRepo, appLogger)
+
+	// Resumen de la pantalla de inicio; las anomalías nuevas son las alertas sin leer
+	trendingService := services.NewTrendingService(symbolViews, companyRepo, appLogger)
+	dashboardService := services.NewDashboardService(marketDataService, stockService, trendingService, anomalyRepo,
+		cacheService, services.DefaultDashboardOptions(f.config.Cache.TTL.Dashboard), appLogger)
+
+	// 10. Population runner for on-demand admin runs (reuses the DB connection)
+	populationFactory := infraFactory.NewPopulationUseCaseFactoryWithDB(f.config, db)
+	populateUseCase, err := populationFactory.CreatePopulateDatabaseUseCase()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create population use case: %w", err)
+	}
+	populateUseCase.SetEventPublisher(eventPublisher)
+	populationRunner := population.NewPopulationRunner(populateUseCase)
+
+	// 11. Job queue (API enqueues, workers consume)
+	if f.config.Database.AutoMigrate {
+		if err := db.MigrateUp(); err != nil {
+			return nil, err
+		}
+	}
+	populationDeps, err := populationFactory.GetDependencies()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get population dependencies: %w", err)
+	}
+	jobRepo := implementation.NewJobRepository(db.DB)
+	jobQueue := jobs.NewJobQueue(jobRepo)
+	jobWorkerPool := jobs.NewWorkerPool(jobRepo, jobs.WorkerPoolConfig{
+		Concurrency:  f.config.Worker.JobConcurrency,
+		PollInterval: f.config.Worker.JobPollInterval,
+		StaleAfter:   f.config.Worker.JobStaleAfter,
+		RetryBackoff: f.config.Worker.JobRetryBackoff,
+	}, appLogger)
+	jobWorkerPool.Register(jobs.JobTypePopulation, jobs.NewPopulationJobHandler(populateUseCase))
+	jobWorkerPool.Register(jobs.JobTypeRatingsReprocess, jobs.NewRatingsReprocessJobHandler(populateUseCase))
+	integrityRepair := services.NewIntegrityRepairService(populationDeps.IntegrityService, appLogger)
+	jobWorkerPool.Register(jobs.JobTypeIntegrityRepair, jobs.NewIntegrityRepairJobHandler(integrityRepair))
+
+	// Historial de validaciones de integridad (tabla integrity_snapshots, migración 000025)
+	integrityHistory := services.NewIntegrityHistoryService(populationDeps.IntegrityService, stockRatingRepo,
+		implementation.NewIntegritySnapshotRepository(db.DB), appLogger)
+	jobWorkerPool.Register(jobs.JobTypeIntegrityCheck, jobs.NewIntegrityCheckJobHandler(integrityHistory))
+	jobWorkerPool.Register(jobs.JobTypeMarketDataRefresh, jobs.NewMarketDataRefreshJobHandler(marketDataService, tenantRepo))
+
+	// Enriquecimiento de companies desde los perfiles guardados (conflictos quedan para revisión)
+	conflictRepo := implementation.NewCompanyEnrichmentConflictRepository(db.DB)
+	enrichmentService := enrichment.NewCompanyEnrichmentService(companyRepo, companyProfileRepo, conflictRepo, appLogger)
+	jobWorkerPool.Register(jobs.JobTypeCompanyEnrichment, jobs.NewCompanyEnrichmentJobHandler(enrichmentService))
+	if analyticsViews != nil {
+		jobWorkerPool.Register(jobs.JobTypeAnalyticsRefresh, jobs.NewAnalyticsRefreshJobHandler(analyticsViews))
+	}
+	jobWorkerPool.Register(jobs.JobTypeAnomalyDetection, jobs.NewAnomalyDetectionJobHandler(anomalyService))
+	jobWorkerPool.Register(jobs.JobTypeActionTypeBackfill, jobs.NewActionTypeBackfillJobHandler(stockRatingRepo))
+	jobWorkerPool.Register(jobs.JobTypeBenchmarkRefresh, jobs.NewBenchmarkRefreshJobHandler(benchmarkService))
+
+	// Procesado de los ratings pendientes: normalización, precios objetivo y eventos rating.processed
+	ratingProcessing := services.NewRatingProcessingService(stockRatingRepo, eventPublisher,
+		int64(f.config.Worker.RatingBacklogThreshold), f.config.Worker.RatingBacklogAlertCooldown, appLogger)
+	services.NewRatingBacklogNotifier(eventBus, f.config.Worker.RatingBacklogWebhookURL, appLogger)
+	jobWorkerPool.Register(jobs.JobTypeRatingProcessing, jobs.NewRatingProcessingJobHandler(ratingProcessing))
+
+	// Snapshots de la base de datos en un bucket S3 o compatible (job database_backup)
+	backupStore, err := storage.New(f.config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create backup store: %w", err)
+	}
+	var backupService serviceInterfaces.BackupService
+	if backupStore != nil {
+		backupService = services.NewBackupService(backupStore, implementation.NewTableSnapshotRepository(db.DB),
+			f.config.Backup.Tables, f.config.Backup.Prefix, appLogger)
+		jobWorkerPool.Register(jobs.JobTypeDatabaseBackup, jobs.NewDatabaseBackupJobHandler(backupService))
+	}
+
+	// Exportación Parquet de precios históricos y ratings a disco local o S3 (job parquet_export)
+	exportStore, err := storage.NewExportStore(f.config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create export store: %w", err)
+	}
+	parquetExport := services.NewParquetExportService(exportStore, companyRepo, brokerageRepo, historicalDataRepo,
+		stockRatingRepo, f.config.Export.Destination, f.config.Export.Prefix, appLogger)
+	jobWorkerPool.Register(jobs.JobTypeParquetExport, jobs.NewParquetExportJobHandler(parquetExport))
+
+	// Datos sintéticos para entornos locales (comando seed)
+	seedService := services.NewSeedService(companyRepo, brokerageRepo, stockRatingRepo, marketDataRepo,
+		historicalDataRepo, appLogger)
+
+	// Proxy de logos de companies: originales redimensionados y guardados en LOGO_CACHE_DIR
+	logoStore, err := storage.NewLocalStore(f.config.Logo.CacheDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create logo store: %w", err)
+	}
+	companyLogos := services.NewCompanyLogoService(companyRepo, logoStore, services.CompanyLogoOptions{
+		DefaultSize:  f.config.Logo.DefaultSize,
+		MaxSize:      f.config.Logo.MaxSize,
+		MaxBytes:     f.config.Logo.MaxBytes,
+		FetchTimeout: f.config.Logo.FetchTimeout,
+	}, appLogger)
+
+	// Taxonomía de ratings: la tabla rating_mappings reemplaza los mapeos por defecto si se puede leer
+	ratingTaxonomy := services.NewRatingTaxonomyService(implementation.NewRatingMappingRepository(db.DB), appLogger)
+	loadCtx, cancelLoad := context.WithTimeout(context.Background(), 10*time.Second)
+	if err := ratingTaxonomy.Load(loadCtx); err != nil {
+		appLogger.Warn(loadCtx, "Rating mappings not loaded, using the default taxonomy", logger.ErrorField(err))
+	}
+	cancelLoad()
+
+	// Multi-tenancy: tenants identificados por API key o JWT (firmado con JWT_SECRET)
+	var tenantService serviceInterfaces.TenantService
+	if f.config.Tenancy.Enabled {
+		tenantService = services.NewTenantService(tenantRepo, implementation.NewAPIKeyRepository(db.DB),
+			f.config.Security.JWTSecret, f.config.Tenancy.JWTIssuer, f.config.Tenancy.JWTEnabled, f.config.Tenancy.AdminAPIKey, appLogger)
+	}
+
+	// Uso de la API por credencial: contadores horarios en la cache volcados a api_usage_hourly (job usage_flush)
+	var usageService serviceInterfaces.UsageService
+	var usageCounters *domainServices.UsageCounters
+	if f.config.Tenancy.Enabled {
+		usageCounters = domainServices.NewUsageCounters(cacheService)
+		usageService = services.NewUsageService(usageCounters, implementation.NewUsageRepository(db.DB),
+			tenantRepo, appLogger)
+		jobWorkerPool.Register(jobs.JobTypeUsageFlush, jobs.NewUsageFlushJobHandler(usageService))
+	}
+
+	// Population dead-letter (rejected items) inspection and reprocessing
+	rejectService := population.NewRejectService(populationDeps.RejectRepo, populateUseCase)
+
+	// Warm-up de la cache (al arrancar y desde el endpoint de administración)
+	cacheWarmer := warmup.NewCacheWarmer(companyRepo, stockRatingRepo, marketDataRepo,
+		f.config.Cache.WarmTopCompanies, cacheService != nil && f.config.Cache.QueryTTL > 0, appLogger)
+
+	// Recarga en caliente: API keys de los proveedores y TTLs de cache
+	configWatcher := config.NewWatcher(f.config)
+	configWatcher.Subscribe("market_data", func(previous, current *config.Config) error {
+		if previous.Cache.TTL != current.Cache.TTL ||
+			previous.External.Primary.Key != current.External.Primary.Key ||
+			previous.External.Secondary.Key != current.External.Secondary.Key {
+			marketDataFactory.RefreshConfiguration(current)
+		}
+		return nil
+	})
+	configWatcher.Subscribe("peers", func(_, current *config.Config) error {
+		if updater, ok := peerService.(interface{ UpdateTTL(time.Duration) }); ok {
+			updater.UpdateTTL(current.Cache.TTL.Peers)
+		}
+		return nil
+	})
+	configWatcher.Subscribe("financial_statements", func(_, current *config.Config) error {
+		if updater, ok := financialStatementService.(interface{ UpdateTTL(time.Duration) }); ok {
+			updater.UpdateTTL(current.Cache.TTL.Statements)
+		}
+		return nil
+	})
+	configWatcher.Subscribe("analysis", func(previous, current *config.Config) error {
+		if previous.Analysis == current.Analysis {
+			return nil
+		}
+		if updater, ok := analysisService.(interface{ UpdateScoringWeights(services.ScoringWeights) }); ok {
+			updater.UpdateScoringWeights(scoringWeights(current.Analysis))
+		}
+		return nil
+	})
+	configWatcher.Subscribe("population", func(_, current *config.Config) error {
+		populateUseCase.SetCacheTTLs(infraFactory.PopulationCacheTTLs(current))
+		return nil
+	})
+
+	// 12. Cache dependencies
 	f.dependencies = &Dependencies{
 		CompanyService:      companyService,
+		CompanyLogos:        companyLogos,
+		CompanyOverview:     services.NewCompanyOverviewService(companyService, stockService, marketDataService, services.DefaultCompanyOverviewOptions(), appLogger),
 		BrokerageService:    brokerageService,
 		StockService:        stockService,
+		RatingPoll:          ratingPoll,
 		AnalysisService:     analysisService,
 		MarketDataService:   marketDataService,
 		AlphaVantageService: alphaVantageService,
+		SearchService:       searchService,
+		PeerService:         peerService,
+		RelationshipService: relationshipService,
+		TagService:          tagService,
+		NoteService:         noteService,
+		FinancialStatements: financialStatementService,
+		BenchmarkService:    benchmarkService,
+		BacktestService:     backtestService,
+		AnomalyService:      anomalyService,
+		MarketStatusService: services.NewMarketStatusService(domainServices.NewTradingCalendar(), appLogger),
+		TrendingService:     trendingService,
+		DashboardService:    dashboardService,
 		Logger:              appLogger,
 		CacheService:        cacheService,
 		TransactionService:  transactionService,
+		PopulationRunner:    populationRunner,
+		RejectService:       rejectService,
+		EnrichmentService:   enrichmentService,
+		AnalyticsViews:      analyticsViews,
+		JobQueue:            jobQueue,
+		JobWorkerPool:       jobWorkerPool,
+		Database:            db,
+		CacheWarmer:         cacheWarmer,
+		ConfigWatcher:       configWatcher,
+		PayloadArchive:      services.NewPayloadArchiveService(payloadRepo, marketDataService, appLogger),
+		BackupService:       backupService,
+		ParquetExport:       parquetExport,
+		Seed:                seedService,
+		RatingTaxonomy:      ratingTaxonomy,
+		RatingProcessing:    ratingProcessing,
+		IntegrityHistory:    integrityHistory,
+		IntegrityRepair:     integrityRepair,
+		TenantService:       tenantService,
+		UsageService:        usageService,
+		UsageCounters:       usageCounters,
+		PlanQuotas:          planQuotas,
+		EventBus:            eventBus,
+		OutboxRelay:         outboxRelay,
 	}
 
 	return f.dependencies, nil
@@ -253,6 +621,27 @@ func (f *APIFactory) GetConfig() *config.Config {
 	return f.config
 }
 
+// scoringWeights traduce la configuración de análisis a los pesos del modelo de recomendación
+func scoringWeights(cfg config.AnalysisConfig) services.ScoringWeights {
+	return services.ScoringWeights{
+		Consensus: cfg.ConsensusWeight,
+		Momentum:  cfg.MomentumWeight,
+		Valuation: cfg.ValuationWeight,
+	}
+}
+
+// planLimits traduce la configuración de planes a las cuotas diarias de cada plan
+func planLimits(cfg config.PlansConfig) map[string]domainServices.PlanLimits {
+	limits := make(map[string]domainServices.PlanLimits, len(cfg.Tiers))
+	for name, tier := range cfg.Tiers {
+		limits[name] = domainServices.PlanLimits{
+			DailyRequests:  tier.DailyRequests,
+			DailyRefreshes: tier.DailyRefreshes,
+		}
+	}
+	return limits
+}
+
 // UpdateConfig actualiza la configuración y limpia la cache
 func (f *APIFactory) UpdateConfig(newConfig *config.Config) error {
 	f.config = newConfig
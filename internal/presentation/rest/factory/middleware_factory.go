@@ -119,7 +119,7 @@ func (f *MiddlewareFactory) CreateCustomRateLimitMiddleware(limit int, window ti
 // CreateDevelopmentMiddlewares crea un conjunto de middlewares optimizado para desarrollo
 func (f *MiddlewareFactory) CreateDevelopmentMiddlewares() *MiddlewareSet {
 	return &MiddlewareSet{
-		CORS:      f.CreateSimpleCORSMiddleware(), // CORS más permisivo para desarrollo
+		CORS:      f.CreateCORSMiddleware(), // Orígenes de desarrollo según la configuración (CORS_*)
 		Logging:   f.CreateLoggingMiddleware(),
 		Error:     f.CreateErrorMiddleware(),
 		RequestID: f.CreateRequestIDMiddleware(),
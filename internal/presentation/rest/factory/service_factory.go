@@ -66,10 +66,10 @@ func (f *ServiceFactory) CreateServiceDependencies() (*ServiceDependencies, erro
 		return nil, fmt.Errorf("failed to create database connection: %w", err)
 	}
 
-	// 2. Create repositories
-	companyRepo := implementation.NewCompanyRepository(db.DB)
-	brokerageRepo := implementation.NewBrokerageRepository(db.DB)
-	stockRatingRepo := implementation.NewStockRatingRepository(db.DB)
+	// 2. Create repositories (list and analytics reads go to the read replicas when configured)
+	companyRepo := implementation.NewCompanyRepositoryWithReader(db.DB, db.Reader())
+	brokerageRepo := implementation.NewBrokerageRepositoryWithReader(db.DB, db.Reader())
+	stockRatingRepo := implementation.NewStockRatingRepositoryWithReader(db.DB, db.Reader())
 
 	// 3. Create domain services
 	transactionService := domainServices.NewTransactionService(db.DB)
@@ -79,6 +79,10 @@ func (f *ServiceFactory) CreateServiceDependencies() (*ServiceDependencies, erro
 		cacheService = cache.NewCacheService(f.config)
 	}
 
+	// Consultas de lectura frecuente cacheadas; las escrituras las invalidan
+	companyRepo = implementation.NewCachedCompanyRepository(companyRepo, cacheService, f.config.Cache.QueryTTL)
+	stockRatingRepo = implementation.NewCachedStockRatingRepository(stockRatingRepo, cacheService, f.config.Cache.QueryTTL)
+
 	// 4. Create application service factory if not exists
 	if f.applicationServiceFactory == nil {
 		f.applicationServiceFactory = applicationServices.NewServiceFactory(
@@ -129,9 +133,9 @@ func (f *ServiceFactory) CreateServiceDependenciesWithConfig(config ServiceConfi
 		stockRatingRepo = implementation.NewTransactionalStockRatingRepository(db.DB)
 	} else {
 		// Use regular repositories for development/testing
-		companyRepo = implementation.NewCompanyRepository(db.DB)
-		brokerageRepo = implementation.NewBrokerageRepository(db.DB)
-		stockRatingRepo = implementation.NewStockRatingRepository(db.DB)
+		companyRepo = implementation.NewCompanyRepositoryWithReader(db.DB, db.Reader())
+		brokerageRepo = implementation.NewBrokerageRepositoryWithReader(db.DB, db.Reader())
+		stockRatingRepo = implementation.NewStockRatingRepositoryWithReader(db.DB, db.Reader())
 	}
 
 	// 3. Create domain services
@@ -142,6 +146,10 @@ func (f *ServiceFactory) CreateServiceDependenciesWithConfig(config ServiceConfi
 		cacheService = cache.NewCacheService(f.config)
 	}
 
+	// Consultas de lectura frecuente cacheadas; las escrituras las invalidan
+	companyRepo = implementation.NewCachedCompanyRepository(companyRepo, cacheService, f.config.Cache.QueryTTL)
+	stockRatingRepo = implementation.NewCachedStockRatingRepository(stockRatingRepo, cacheService, f.config.Cache.QueryTTL)
+
 	// 4. Create application services
 	applicationServiceFactory := applicationServices.NewServiceFactory(
 		applicationServices.ServiceFactoryConfig{
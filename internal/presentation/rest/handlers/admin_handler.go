@@ -0,0 +1,2145 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/jobs"
+	serviceInterfaces "github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/application/usecases/enrichment"
+	"github.com/MayaCris/stock-info-app/internal/application/usecases/population"
+	"github.com/MayaCris/stock-info-app/internal/application/usecases/warmup"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/config"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/database/cockroachdb"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
+)
+
+// defaultSlowQueriesLimit es el número de queries lentas devueltas si no se indica limit
+const defaultSlowQueriesLimit = 50
+
+// defaultPartitionMonthsAhead es el número de meses futuros particionados si no se indica months_ahead
+const defaultPartitionMonthsAhead = 3
+
+// AdminHandler maneja los endpoints administrativos
+type AdminHandler struct {
+	populationRunner *population.PopulationRunner
+	rejectService    *population.RejectService
+	enrichment       *enrichment.CompanyEnrichmentService
+	companyService   serviceInterfaces.CompanyService
+	analyticsViews   repoInterfaces.AnalyticsViewRepository
+	jobQueue         *jobs.JobQueue
+	database         *cockroachdb.DB
+	cacheWarmer      *warmup.CacheWarmer
+	configWatcher    *config.Watcher
+	payloadArchive   serviceInterfaces.PayloadArchiveService
+	backupService    serviceInterfaces.BackupService
+	parquetExport    serviceInterfaces.ParquetExportService
+	ratingTaxonomy   serviceInterfaces.RatingTaxonomyService
+	ratingProcessing serviceInterfaces.RatingProcessingService
+	integrityHistory serviceInterfaces.IntegrityHistoryService
+	integrityRepair  serviceInterfaces.IntegrityRepairService
+	logger           logger.Logger
+}
+
+// NewAdminHandler crea una nueva instancia del handler administrativo
+func NewAdminHandler(populationRunner *population.PopulationRunner, rejectService *population.RejectService, enrichmentService *enrichment.CompanyEnrichmentService, companyService serviceInterfaces.CompanyService, analyticsViews repoInterfaces.AnalyticsViewRepository, jobQueue *jobs.JobQueue, database *cockroachdb.DB, cacheWarmer *warmup.CacheWarmer, configWatcher *config.Watcher, payloadArchive serviceInterfaces.PayloadArchiveService, backupService serviceInterfaces.BackupService, parquetExport serviceInterfaces.ParquetExportService, ratingTaxonomy serviceInterfaces.RatingTaxonomyService, ratingProcessing serviceInterfaces.RatingProcessingService, integrityHistory serviceInterfaces.IntegrityHistoryService, integrityRepair serviceInterfaces.IntegrityRepairService, appLogger logger.Logger) *AdminHandler {
+	return &AdminHandler{
+		populationRunner: populationRunner,
+		rejectService:    rejectService,
+		enrichment:       enrichmentService,
+		companyService:   companyService,
+		analyticsViews:   analyticsViews,
+		jobQueue:         jobQueue,
+		database:         database,
+		cacheWarmer:      cacheWarmer,
+		configWatcher:    configWatcher,
+		payloadArchive:   payloadArchive,
+		backupService:    backupService,
+		parquetExport:    parquetExport,
+		ratingTaxonomy:   ratingTaxonomy,
+		ratingProcessing: ratingProcessing,
+		integrityHistory: integrityHistory,
+		integrityRepair:  integrityRepair,
+		logger:           appLogger,
+	}
+}
+
+// RunPopulation godoc
+// @Summary Trigger a population run
+// @Description Start the database population pipeline asynchronously and return the job to poll
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param config body request.RunPopulationRequest false "Population configuration overrides"
+// @Success 202 {object} response.APIResponse[response.PopulationJobResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 409 {object} response.APIResponse[any]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/admin/population/run [post]
+func (h *AdminHandler) RunPopulation(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	if h.populationRunner == nil {
+		errorResp := response.ServiceUnavailable("Population pipeline is not configured")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	// El body es opcional: sin body se usa la configuración por defecto
+	var req request.RunPopulationRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.logger.Warn(ctx, "Invalid request body for population run",
+			logger.String("request_id", requestID),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	config := toPopulationConfig(&req)
+
+	job, err := h.populationRunner.Start(config)
+	if err != nil {
+		if errors.Is(err, population.ErrPopulationAlreadyRunning) {
+			h.logger.Warn(ctx, "Population run rejected, another run is in progress",
+				logger.String("request_id", requestID),
+			)
+
+			errorResp := response.Conflict("A population run is already in progress")
+			middleware.RespondWithError(c, errorResp)
+			return
+		}
+
+		h.logger.Error(ctx, "Failed to start population run", err,
+			logger.String("request_id", requestID),
+		)
+
+		errorResp := response.InternalServerError("Failed to start population run")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	h.logger.Info(ctx, "Population run started",
+		logger.String("request_id", requestID),
+		logger.String("job_id", job.ID.String()),
+		logger.Int("max_pages", config.MaxPages),
+		logger.Int("batch_size", config.BatchSize),
+		logger.Bool("dry_run", config.DryRun),
+		logger.Bool("incremental", config.Incremental),
+	)
+
+	apiResponse := response.Success(toPopulationJobResponse(job))
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusAccepted, apiResponse)
+}
+
+// GetPopulationJob godoc
+// @Summary Get population job status
+// @Description Get the progress or final result of a population run
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Job ID"
+// @Success 200 {object} response.APIResponse[response.PopulationJobResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Router /api/v1/admin/population/jobs/{id} [get]
+func (h *AdminHandler) GetPopulationJob(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	idParam := c.Param("id")
+	jobID, err := uuid.Parse(idParam)
+	if err != nil {
+		h.logger.Warn(ctx, "Invalid population job ID format",
+			logger.String("request_id", requestID),
+			logger.String("id", idParam),
+		)
+
+		errorResp := response.BadRequest("Invalid job ID format")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	if h.populationRunner == nil {
+		errorResp := response.NotFound("Population job")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	job, exists := h.populationRunner.GetJob(jobID)
+	if !exists {
+		errorResp := response.NotFound("Population job")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(toPopulationJobResponse(job))
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// GetIngestionRules godoc
+// @Summary Get ingestion validation rules
+// @Description Get the validation rules applied to the items before ingesting them, with their configured action (reject, quarantine, correct or off) and per-rule counters since the server started
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.APIResponse[response.IngestionRulesResponse]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/admin/population/rules [get]
+func (h *AdminHandler) GetIngestionRules(c *gin.Context) {
+	requestID := c.GetString("request_id")
+
+	if h.populationRunner == nil {
+		errorResp := response.ServiceUnavailable("Population is not configured")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	stats := h.populationRunner.RuleStats()
+	result := &response.IngestionRulesResponse{
+		Enabled: stats != nil,
+		Rules:   make([]response.IngestionRuleResponse, 0, len(stats)),
+	}
+	for _, rule := range stats {
+		result.Rules = append(result.Rules, response.IngestionRuleResponse{
+			Name:        rule.Name,
+			Description: rule.Description,
+			Action:      string(rule.Action),
+			Evaluated:   rule.Evaluated,
+			Violations:  rule.Violations,
+			Rejected:    rule.Rejected,
+			Quarantined: rule.Quarantined,
+			Corrected:   rule.Corrected,
+		})
+	}
+
+	apiResponse := response.Success(result)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// ListPopulationRejects godoc
+// @Summary List rejected population items
+// @Description Get a paginated list of items that failed validation or FK resolution during population, most recent first
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param status query string false "Filter by status" Enums(pending, reprocessed, discarded)
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(10)
+// @Success 200 {object} response.APIResponse[response.PaginatedResponse[response.PopulationRejectResponse]]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/admin/population/rejects [get]
+func (h *AdminHandler) ListPopulationRejects(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	if h.rejectService == nil {
+		errorResp := response.ServiceUnavailable("Population rejects are not configured")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	var filter request.PopulationRejectFilterRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		errorResp := response.BadRequest("Invalid query parameters")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	pagination := response.ParsePaginationFromQuery(c.Query("page"), c.Query("per_page"))
+
+	rejects, total, err := h.rejectService.List(ctx, entities.PopulationRejectStatus(filter.Status), pagination.GetLimit(), pagination.GetOffset())
+	if err != nil {
+		h.logger.Error(ctx, "Failed to list population rejects", err,
+			logger.String("request_id", requestID),
+		)
+
+		errorResp := response.InternalServerError("Failed to list population rejects")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	items := make([]*response.PopulationRejectResponse, len(rejects))
+	for i, reject := range rejects {
+		items[i] = toPopulationRejectResponse(reject)
+	}
+
+	middleware.RespondWithPage(c, response.NewPaginatedAPIResponse(items, pagination.Page, pagination.PerPage, int(total)))
+}
+
+// GetPopulationReject godoc
+// @Summary Get rejected population item
+// @Description Get a rejected population item with its raw payload and rejection reason
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Reject ID"
+// @Success 200 {object} response.APIResponse[response.PopulationRejectResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/admin/population/rejects/{id} [get]
+func (h *AdminHandler) GetPopulationReject(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	rejectID, ok := h.parseRejectID(c)
+	if !ok {
+		return
+	}
+
+	if h.rejectService == nil {
+		errorResp := response.ServiceUnavailable("Population rejects are not configured")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	reject, err := h.rejectService.Get(ctx, rejectID)
+	if err != nil {
+		h.logger.Warn(ctx, "Population reject not found",
+			logger.String("request_id", requestID),
+			logger.String("reject_id", rejectID.String()),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Population reject", "Failed to get population reject")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(toPopulationRejectResponse(reject))
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// ReprocessPopulationRejects godoc
+// @Summary Reprocess rejected population items
+// @Description Run the given rejected items (or the most recent pending ones) through the population pipeline again
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body request.ReprocessRejectsRequest false "Rejects to reprocess"
+// @Success 200 {object} response.APIResponse[response.ReprocessRejectsResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/admin/population/rejects/reprocess [post]
+func (h *AdminHandler) ReprocessPopulationRejects(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	if h.rejectService == nil {
+		errorResp := response.ServiceUnavailable("Population rejects are not configured")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	// El body es opcional: sin body se reprocesan los rechazos pendientes más recientes
+	var req request.ReprocessRejectsRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.logger.Warn(ctx, "Invalid request body for rejects reprocess",
+			logger.String("request_id", requestID),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	limit := 0
+	if req.Limit != nil {
+		limit = *req.Limit
+	}
+
+	result, err := h.rejectService.Reprocess(ctx, req.IDs, limit)
+	if err != nil {
+		h.logger.Error(ctx, "Failed to reprocess population rejects", err,
+			logger.String("request_id", requestID),
+		)
+
+		errorResp := response.InternalServerError("Failed to reprocess population rejects")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	h.logger.Info(ctx, "Population rejects reprocessed",
+		logger.String("request_id", requestID),
+		logger.Int("requested", result.Requested),
+		logger.Int("reprocessed", result.Reprocessed),
+		logger.Int("failed", result.Failed),
+		logger.Int("skipped", result.Skipped),
+	)
+
+	apiResponse := response.Success(&response.ReprocessRejectsResponse{
+		Requested:   result.Requested,
+		Reprocessed: result.Reprocessed,
+		Failed:      result.Failed,
+		Skipped:     result.Skipped,
+		Errors:      result.Errors,
+	})
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// DiscardPopulationReject godoc
+// @Summary Discard rejected population item
+// @Description Mark a pending rejected item as discarded so it is no longer reprocessed
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Reject ID"
+// @Success 200 {object} response.APIResponse[response.PopulationRejectResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Failure 409 {object} response.APIResponse[any]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/admin/population/rejects/{id}/discard [post]
+func (h *AdminHandler) DiscardPopulationReject(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	rejectID, ok := h.parseRejectID(c)
+	if !ok {
+		return
+	}
+
+	if h.rejectService == nil {
+		errorResp := response.ServiceUnavailable("Population rejects are not configured")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	reject, err := h.rejectService.Discard(ctx, rejectID)
+	if err != nil {
+		if errors.Is(err, population.ErrRejectNotPending) {
+			errorResp := response.Conflict("Population reject is not pending")
+			middleware.RespondWithError(c, errorResp)
+			return
+		}
+
+		h.logger.Warn(ctx, "Failed to discard population reject",
+			logger.String("request_id", requestID),
+			logger.String("reject_id", rejectID.String()),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Population reject", "Failed to discard population reject")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	h.logger.Info(ctx, "Population reject discarded",
+		logger.String("request_id", requestID),
+		logger.String("reject_id", reject.ID.String()),
+	)
+
+	apiResponse := response.Success(toPopulationRejectResponse(reject))
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// ReprocessRatings godoc
+// @Summary Reprocess stored ratings from their raw data
+// @Description Enqueue a job that re-parses the raw data of the ratings whose event time falls in the inclusive date range, after adapter fixes. Ratings are upserted, so running it again is safe
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body request.ReprocessRatingsRequest true "Date range to reprocess"
+// @Success 202 {object} response.APIResponse[response.JobResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/admin/ratings/reprocess [post]
+func (h *AdminHandler) ReprocessRatings(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	if h.jobQueue == nil {
+		errorResp := response.ServiceUnavailable("Job queue is not configured")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	var req request.ReprocessRatingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn(ctx, "Invalid request body for ratings reprocess",
+			logger.String("request_id", requestID),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	if _, _, err := population.ReprocessRangeFromDates(req.From, req.To); err != nil {
+		errorResp := response.BadRequest(err.Error())
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	payload, err := json.Marshal(jobs.RatingsReprocessPayload{From: req.From, To: req.To, DryRun: req.DryRun})
+	if err != nil {
+		errorResp := response.InternalServerError("Failed to enqueue ratings reprocess")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	job, err := h.jobQueue.Enqueue(ctx, jobs.JobTypeRatingsReprocess, payload, 0)
+	if err != nil {
+		h.logger.Error(ctx, "Failed to enqueue ratings reprocess", err,
+			logger.String("request_id", requestID),
+		)
+
+		errorResp := response.InternalServerError("Failed to enqueue ratings reprocess")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	h.logger.Info(ctx, "Ratings reprocess enqueued",
+		logger.String("request_id", requestID),
+		logger.String("job_id", job.ID.String()),
+		logger.String("from", req.From),
+		logger.String("to", req.To),
+		logger.Bool("dry_run", req.DryRun),
+	)
+
+	apiResponse := response.Success(toJobResponse(job))
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusAccepted, apiResponse)
+}
+
+// GetIntegrityHistory godoc
+// @Summary Get the integrity validation history
+// @Description Get the summaries of the scheduled integrity validations (integrity_check jobs) of the last days and whether data quality is improving or degrading
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param days query int false "Last days" default(30)
+// @Success 200 {object} response.APIResponse[response.IntegrityHistoryResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/admin/integrity/history [get]
+func (h *AdminHandler) GetIntegrityHistory(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	if h.integrityHistory == nil {
+		errorResp := response.ServiceUnavailable("Integrity history is not configured")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	var req request.IntegrityHistoryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		errorResp := response.BadRequest("Invalid query parameters")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	history, err := h.integrityHistory.GetHistory(ctx, &req)
+	if err != nil {
+		h.logger.Error(ctx, "Failed to get integrity history", err,
+			logger.String("request_id", requestID),
+		)
+
+		errorResp := response.InternalServerError("Failed to get integrity history")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(history)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// RepairIntegrity godoc
+// @Summary Repair minor integrity issues
+// @Description Preview (dry_run, the default) the orphaned ratings and duplicates that would be removed, the duplicate companies that would be merged with their ratings relinked and the records that would be fixed. Applying the repair requires the plan_token of the preview as approval_token and fails with 409 if the plan changed
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body request.IntegrityRepairRequest false "Dry run or approved repair"
+// @Success 200 {object} response.APIResponse[response.IntegrityRepairResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 409 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/admin/integrity/repair [post]
+func (h *AdminHandler) RepairIntegrity(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	if h.integrityRepair == nil {
+		errorResp := response.ServiceUnavailable("Integrity repair is not configured")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	var req request.IntegrityRepairRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.logger.Warn(ctx, "Invalid request body for integrity repair",
+			logger.String("request_id", requestID),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	result, err := h.integrityRepair.Repair(ctx, &req)
+	if err != nil {
+		h.logger.Warn(ctx, "Integrity repair failed",
+			logger.String("request_id", requestID),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Integrity repair", "Failed to repair integrity issues")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	h.logger.Info(ctx, "Integrity repair completed",
+		logger.String("request_id", requestID),
+		logger.Bool("dry_run", result.DryRun),
+		logger.Int("total_repairs", result.TotalRepairs),
+	)
+
+	apiResponse := response.Success(result)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// GetRatingTaxonomy godoc
+// @Summary Get the rating taxonomy
+// @Description Get the canonical rating scale (strong_buy to strong_sell), the mappings of the free-text brokerage ratings and the most used variants still without mapping
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.APIResponse[response.RatingTaxonomyResponse]
+// @Failure 500 {object} response.APIResponse[any]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/admin/ratings/mappings [get]
+func (h *AdminHandler) GetRatingTaxonomy(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	if h.ratingTaxonomy == nil {
+		errorResp := response.ServiceUnavailable("Rating taxonomy is not configured")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	taxonomy, err := h.ratingTaxonomy.GetTaxonomy(ctx)
+	if err != nil {
+		errorResp := response.FromError(err, "Rating mappings", "Failed to get rating taxonomy")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(taxonomy)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// SaveRatingMapping godoc
+// @Summary Map a brokerage rating variant
+// @Description Create or change the canonical rating of a free-text variant (case and spacing are ignored) and re-normalize the stored ratings
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body request.SaveRatingMappingRequest true "Variant and canonical rating"
+// @Success 200 {object} response.APIResponse[response.RatingNormalizationResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/admin/ratings/mappings [put]
+func (h *AdminHandler) SaveRatingMapping(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	if h.ratingTaxonomy == nil {
+		errorResp := response.ServiceUnavailable("Rating taxonomy is not configured")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	var req request.SaveRatingMappingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RespondWithError(c, middleware.ValidationErrorResponse(err))
+		return
+	}
+
+	result, err := h.ratingTaxonomy.SaveMapping(ctx, &req)
+	if err != nil {
+		h.logger.Warn(ctx, "Rating mapping update failed",
+			logger.String("request_id", requestID),
+			logger.String("rating", req.Rating),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Rating mapping", "Failed to save rating mapping")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(result)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// DeleteRatingMapping godoc
+// @Summary Delete a brokerage rating mapping
+// @Description Remove the mapping of a free-text variant; its ratings are left without normalized rating and no longer count in the consensus
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param rating query string true "Variant to unmap (e.g. Market Perform)"
+// @Success 200 {object} response.APIResponse[response.RatingNormalizationResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/admin/ratings/mappings [delete]
+func (h *AdminHandler) DeleteRatingMapping(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	if h.ratingTaxonomy == nil {
+		errorResp := response.ServiceUnavailable("Rating taxonomy is not configured")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	result, err := h.ratingTaxonomy.DeleteMapping(ctx, c.Query("rating"))
+	if err != nil {
+		errorResp := response.FromError(err, "Rating mapping", "Failed to delete rating mapping")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(result)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// ApplyRatingMappings godoc
+// @Summary Re-normalize the stored ratings
+// @Description Reload the rating mappings and rewrite the normalized ratings that no longer match them, e.g. after editing the table from another instance
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.APIResponse[response.RatingNormalizationResponse]
+// @Failure 500 {object} response.APIResponse[any]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/admin/ratings/mappings/apply [post]
+func (h *AdminHandler) ApplyRatingMappings(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	if h.ratingTaxonomy == nil {
+		errorResp := response.ServiceUnavailable("Rating taxonomy is not configured")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	result, err := h.ratingTaxonomy.Apply(ctx)
+	if err != nil {
+		errorResp := response.FromError(err, "Rating mappings", "Failed to re-normalize ratings")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(result)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// GetProcessingStatus godoc
+// @Summary Get the rating processing status
+// @Description Get the stored ratings waiting for the rating_processing job per source, the age of the oldest one, the ratings processed in the last 15 minutes, hour and day, and whether the backlog is over WORKER_RATING_BACKLOG_THRESHOLD
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.APIResponse[response.ProcessingStatusResponse]
+// @Failure 500 {object} response.APIResponse[any]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/admin/processing/status [get]
+func (h *AdminHandler) GetProcessingStatus(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	if h.ratingProcessing == nil {
+		errorResp := response.ServiceUnavailable("Rating processing is not configured")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	status, err := h.ratingProcessing.GetStatus(ctx)
+	if err != nil {
+		errorResp := response.FromError(err, "Stock ratings", "Failed to get rating processing status")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(status)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// ListEnrichmentConflicts godoc
+// @Summary List company enrichment conflicts
+// @Description Get a paginated list of Company fields (sector, exchange, market cap) that disagree with the stored provider profile, most recent first
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param status query string false "Filter by status" Enums(pending, resolved, dismissed)
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(10)
+// @Success 200 {object} response.APIResponse[response.PaginatedResponse[response.EnrichmentConflictResponse]]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/admin/enrichment/conflicts [get]
+func (h *AdminHandler) ListEnrichmentConflicts(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	if h.enrichment == nil {
+		errorResp := response.ServiceUnavailable("Company enrichment is not configured")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	var filter request.EnrichmentConflictFilterRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		errorResp := response.BadRequest("Invalid query parameters")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	pagination := response.ParsePaginationFromQuery(c.Query("page"), c.Query("per_page"))
+
+	conflicts, total, err := h.enrichment.ListConflicts(ctx, entities.EnrichmentConflictStatus(filter.Status), pagination.GetLimit(), pagination.GetOffset())
+	if err != nil {
+		h.logger.Error(ctx, "Failed to list enrichment conflicts", err,
+			logger.String("request_id", requestID),
+		)
+
+		errorResp := response.InternalServerError("Failed to list enrichment conflicts")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	items := make([]*response.EnrichmentConflictResponse, len(conflicts))
+	for i, conflict := range conflicts {
+		items[i] = toEnrichmentConflictResponse(conflict)
+	}
+
+	middleware.RespondWithPage(c, response.NewPaginatedAPIResponse(items, pagination.Page, pagination.PerPage, int(total)))
+}
+
+// ReviewEnrichmentConflict godoc
+// @Summary Review company enrichment conflict
+// @Description Close a pending enrichment conflict as resolved (the Company was fixed) or dismissed (the Company value is correct)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Conflict ID"
+// @Param review body request.ReviewEnrichmentConflictRequest true "Review outcome"
+// @Success 200 {object} response.APIResponse[response.EnrichmentConflictResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Failure 409 {object} response.APIResponse[any]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/admin/enrichment/conflicts/{id}/review [post]
+func (h *AdminHandler) ReviewEnrichmentConflict(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	idParam := c.Param("id")
+	conflictID, err := uuid.Parse(idParam)
+	if err != nil {
+		errorResp := response.BadRequest("Invalid conflict ID format")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	var req request.ReviewEnrichmentConflictRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResp := response.BadRequest("Invalid request body: status must be resolved or dismissed")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	if h.enrichment == nil {
+		errorResp := response.ServiceUnavailable("Company enrichment is not configured")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	conflict, err := h.enrichment.ReviewConflict(ctx, conflictID, entities.EnrichmentConflictStatus(req.Status))
+	if err != nil {
+		if errors.Is(err, enrichment.ErrConflictNotPending) {
+			errorResp := response.Conflict("Enrichment conflict is not pending")
+			middleware.RespondWithError(c, errorResp)
+			return
+		}
+
+		h.logger.Warn(ctx, "Failed to review enrichment conflict",
+			logger.String("request_id", requestID),
+			logger.String("conflict_id", conflictID.String()),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Enrichment conflict", "Failed to review enrichment conflict")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	h.logger.Info(ctx, "Enrichment conflict reviewed",
+		logger.String("request_id", requestID),
+		logger.String("conflict_id", conflict.ID.String()),
+		logger.String("status", string(conflict.Status)),
+	)
+
+	apiResponse := response.Success(toEnrichmentConflictResponse(conflict))
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// MergeCompanies godoc
+// @Summary Merge duplicate companies
+// @Description Move the stock ratings, market data, profile and news of a duplicate company to the target company and soft-delete the duplicate in one transaction. The duplicate ticker keeps resolving to the target. With dry_run the counts are returned without applying changes
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Duplicate company ID"
+// @Param merge body request.MergeCompaniesRequest true "Target company and dry-run flag"
+// @Success 200 {object} response.APIResponse[response.CompanyMergeResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Failure 409 {object} response.APIResponse[any]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/admin/companies/{id}/merge [post]
+func (h *AdminHandler) MergeCompanies(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	idParam := c.Param("id")
+	duplicateID, err := uuid.Parse(idParam)
+	if err != nil {
+		errorResp := response.BadRequest("Invalid company ID format")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	var req request.MergeCompaniesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	if h.companyService == nil {
+		errorResp := response.ServiceUnavailable("Company service is not configured")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	result, err := h.companyService.MergeCompanies(ctx, duplicateID, &req)
+	if err != nil {
+		h.logger.Warn(ctx, "Failed to merge companies",
+			logger.String("request_id", requestID),
+			logger.String("duplicate_id", duplicateID.String()),
+			logger.String("target_id", req.TargetID.String()),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Company", "Failed to merge companies")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	h.logger.Info(ctx, "Company merge completed",
+		logger.String("request_id", requestID),
+		logger.String("duplicate_id", duplicateID.String()),
+		logger.String("target_id", req.TargetID.String()),
+		logger.Bool("dry_run", req.DryRun),
+	)
+
+	apiResponse := response.Success(result)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// EnqueueJob godoc
+// @Summary Enqueue a background job
+// @Description Enqueue a long-running task (population, integrity repair, market data refresh, company enrichment) to be executed by the job workers
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param job body request.EnqueueJobRequest true "Job to enqueue"
+// @Success 202 {object} response.APIResponse[response.JobResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/admin/jobs [post]
+func (h *AdminHandler) EnqueueJob(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	if h.jobQueue == nil {
+		errorResp := response.ServiceUnavailable("Job queue is not configured")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	var req request.EnqueueJobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn(ctx, "Invalid request body for job enqueue",
+			logger.String("request_id", requestID),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	maxAttempts := 0
+	if req.MaxAttempts != nil {
+		maxAttempts = *req.MaxAttempts
+	}
+
+	job, err := h.jobQueue.Enqueue(ctx, req.Type, req.Payload, maxAttempts)
+	if err != nil {
+		if errors.Is(err, jobs.ErrUnknownJobType) {
+			errorResp := response.BadRequest("Unsupported job type")
+			middleware.RespondWithError(c, errorResp)
+			return
+		}
+
+		h.logger.Error(ctx, "Failed to enqueue job", err,
+			logger.String("request_id", requestID),
+			logger.String("job_type", req.Type),
+		)
+
+		errorResp := response.InternalServerError("Failed to enqueue job")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	h.logger.Info(ctx, "Job enqueued",
+		logger.String("request_id", requestID),
+		logger.String("job_id", job.ID.String()),
+		logger.String("job_type", job.Type),
+		logger.Int("max_attempts", job.MaxAttempts),
+	)
+
+	apiResponse := response.Success(toJobResponse(job))
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusAccepted, apiResponse)
+}
+
+// ListJobs godoc
+// @Summary List background jobs
+// @Description Get a paginated list of background jobs, newest first
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param status query string false "Filter by status" Enums(pending, running, succeeded, failed, cancelled)
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(10)
+// @Success 200 {object} response.APIResponse[response.PaginatedResponse[response.JobResponse]]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Router /api/v1/admin/jobs [get]
+func (h *AdminHandler) ListJobs(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	if h.jobQueue == nil {
+		errorResp := response.ServiceUnavailable("Job queue is not configured")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	var filter request.JobFilterRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		errorResp := response.BadRequest("Invalid query parameters")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	pagination := response.ParsePaginationFromQuery(c.Query("page"), c.Query("per_page"))
+
+	jobList, total, err := h.jobQueue.List(ctx, entities.JobStatus(filter.Status), pagination.GetLimit(), pagination.GetOffset())
+	if err != nil {
+		h.logger.Error(ctx, "Failed to list jobs", err,
+			logger.String("request_id", requestID),
+		)
+
+		errorResp := response.InternalServerError("Failed to list jobs")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	items := make([]*response.JobResponse, len(jobList))
+	for i, job := range jobList {
+		items[i] = toJobResponse(job)
+	}
+
+	middleware.RespondWithPage(c, response.NewPaginatedAPIResponse(items, pagination.Page, pagination.PerPage, int(total)))
+}
+
+// GetJob godoc
+// @Summary Get background job
+// @Description Get the status, attempts and result of a background job
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Job ID"
+// @Success 200 {object} response.APIResponse[response.JobResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Router /api/v1/admin/jobs/{id} [get]
+func (h *AdminHandler) GetJob(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	jobID, ok := h.parseJobID(c)
+	if !ok {
+		return
+	}
+
+	if h.jobQueue == nil {
+		errorResp := response.ServiceUnavailable("Job queue is not configured")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	job, err := h.jobQueue.Get(ctx, jobID)
+	if err != nil {
+		h.logger.Warn(ctx, "Job not found",
+			logger.String("request_id", requestID),
+			logger.String("job_id", jobID.String()),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Job", "Failed to get job")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(toJobResponse(job))
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// CancelJob godoc
+// @Summary Cancel background job
+// @Description Cancel a pending job immediately or request cancellation of a running job
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Job ID"
+// @Success 200 {object} response.APIResponse[response.JobResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Failure 409 {object} response.APIResponse[any]
+// @Router /api/v1/admin/jobs/{id}/cancel [post]
+func (h *AdminHandler) CancelJob(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	jobID, ok := h.parseJobID(c)
+	if !ok {
+		return
+	}
+
+	if h.jobQueue == nil {
+		errorResp := response.ServiceUnavailable("Job queue is not configured")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	job, err := h.jobQueue.Cancel(ctx, jobID)
+	if err != nil {
+		h.logger.Warn(ctx, "Failed to cancel job",
+			logger.String("request_id", requestID),
+			logger.String("job_id", jobID.String()),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Job", "Failed to cancel job")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	if job.IsTerminal() && job.Status != entities.JobStatusCancelled {
+		errorResp := response.Conflict("Job has already finished")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	h.logger.Info(ctx, "Job cancellation requested",
+		logger.String("request_id", requestID),
+		logger.String("job_id", job.ID.String()),
+		logger.String("status", string(job.Status)),
+	)
+
+	apiResponse := response.Success(toJobResponse(job))
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// parseJobID extrae y valida el ID del job de la ruta; responde 400 si es inválido
+func (h *AdminHandler) parseJobID(c *gin.Context) (uuid.UUID, bool) {
+	requestID := c.GetString("request_id")
+
+	idParam := c.Param("id")
+	jobID, err := uuid.Parse(idParam)
+	if err != nil {
+		h.logger.Warn(c.Request.Context(), "Invalid job ID format",
+			logger.String("request_id", requestID),
+			logger.String("id", idParam),
+		)
+
+		errorResp := response.BadRequest("Invalid job ID format")
+		middleware.RespondWithError(c, errorResp)
+		return uuid.Nil, false
+	}
+
+	return jobID, true
+}
+
+// parseRejectID extrae y valida el ID del rechazo de la ruta; responde 400 si es inválido
+func (h *AdminHandler) parseRejectID(c *gin.Context) (uuid.UUID, bool) {
+	requestID := c.GetString("request_id")
+
+	idParam := c.Param("id")
+	rejectID, err := uuid.Parse(idParam)
+	if err != nil {
+		h.logger.Warn(c.Request.Context(), "Invalid population reject ID format",
+			logger.String("request_id", requestID),
+			logger.String("id", idParam),
+		)
+
+		errorResp := response.BadRequest("Invalid reject ID format")
+		middleware.RespondWithError(c, errorResp)
+		return uuid.Nil, false
+	}
+
+	return rejectID, true
+}
+
+// toPopulationRejectResponse convierte un rechazo de población a su DTO de respuesta
+func toPopulationRejectResponse(reject *entities.PopulationReject) *response.PopulationRejectResponse {
+	resp := &response.PopulationRejectResponse{
+		ID:            reject.ID,
+		Source:        reject.Source,
+		Stage:         reject.Stage,
+		Reason:        reject.Reason,
+		Status:        string(reject.Status),
+		Ticker:        reject.Ticker,
+		Brokerage:     reject.Brokerage,
+		Error:         reject.Error,
+		Occurrences:   reject.Occurrences,
+		Attempts:      reject.Attempts,
+		LastSeenAt:    reject.LastSeenAt,
+		LastAttemptAt: reject.LastAttemptAt,
+		ResolvedAt:    reject.ResolvedAt,
+		CreatedAt:     reject.CreatedAt,
+	}
+
+	if json.Valid([]byte(reject.RawPayload)) {
+		resp.RawPayload = json.RawMessage(reject.RawPayload)
+	}
+
+	return resp
+}
+
+// toEnrichmentConflictResponse convierte un conflicto de enriquecimiento a su DTO de respuesta
+func toEnrichmentConflictResponse(conflict *entities.CompanyEnrichmentConflict) *response.EnrichmentConflictResponse {
+	return &response.EnrichmentConflictResponse{
+		ID:           conflict.ID,
+		CompanyID:    conflict.CompanyID,
+		Ticker:       conflict.Ticker,
+		Field:        conflict.Field,
+		Status:       string(conflict.Status),
+		CompanyValue: conflict.CompanyValue,
+		ProfileValue: conflict.ProfileValue,
+		Source:       conflict.Source,
+		Occurrences:  conflict.Occurrences,
+		LastSeenAt:   conflict.LastSeenAt,
+		ResolvedAt:   conflict.ResolvedAt,
+		CreatedAt:    conflict.CreatedAt,
+	}
+}
+
+// ListProviderPayloads godoc
+// @Summary List archived provider responses
+// @Description Get a paginated list of the raw Finnhub and Alpha Vantage responses kept in the archive, most recent first
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param provider query string false "Filter by provider" Enums(finnhub, alphavantage)
+// @Param endpoint query string false "Filter by Finnhub path (/quote) or Alpha Vantage function"
+// @Param symbol query string false "Filter by symbol"
+// @Param since query string false "Only responses fetched since this RFC 3339 timestamp"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(10)
+// @Success 200 {object} response.APIResponse[response.PaginatedResponse[response.ProviderPayloadResponse]]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/admin/payloads [get]
+func (h *AdminHandler) ListProviderPayloads(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	if h.payloadArchive == nil {
+		errorResp := response.ServiceUnavailable("Payload archive is not configured")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	var filter request.PayloadArchiveFilterRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	pagination := response.ParsePaginationFromQuery(c.Query("page"), c.Query("per_page"))
+
+	items, total, err := h.payloadArchive.ListPayloads(ctx, &filter, pagination.GetLimit(), pagination.GetOffset())
+	if err != nil {
+		h.logger.Error(ctx, "Failed to list provider payloads", err,
+			logger.String("request_id", requestID),
+		)
+
+		errorResp := response.FromError(err, "Provider payloads", "Failed to list provider payloads")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	middleware.RespondWithPage(c, response.NewPaginatedAPIResponse(items, pagination.Page, pagination.PerPage, int(total)))
+}
+
+// GetProviderPayload godoc
+// @Summary Get archived provider response
+// @Description Get an archived provider response with its decompressed raw body
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Payload ID"
+// @Success 200 {object} response.APIResponse[response.ProviderPayloadResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/admin/payloads/{id} [get]
+func (h *AdminHandler) GetProviderPayload(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	payloadID, ok := h.parsePayloadID(c)
+	if !ok {
+		return
+	}
+
+	if h.payloadArchive == nil {
+		errorResp := response.ServiceUnavailable("Payload archive is not configured")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	payload, err := h.payloadArchive.GetPayload(ctx, payloadID)
+	if err != nil {
+		h.logger.Warn(ctx, "Failed to get provider payload",
+			logger.String("request_id", requestID),
+			logger.String("payload_id", payloadID.String()),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Provider payload", "Failed to get provider payload")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(payload)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// ReplayProviderPayloads godoc
+// @Summary Replay archived provider responses
+// @Description Re-process the given archived Finnhub responses (or the most recent ones matching the filters) through the market data conversion and storage, without calling the provider
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body request.ReplayPayloadsRequest false "Payloads to replay"
+// @Success 200 {object} response.APIResponse[response.ReplayPayloadsResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/admin/payloads/replay [post]
+func (h *AdminHandler) ReplayProviderPayloads(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	if h.payloadArchive == nil {
+		errorResp := response.ServiceUnavailable("Payload archive is not configured")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	// El body es opcional: sin body se re-procesan los payloads de Finnhub más recientes
+	var req request.ReplayPayloadsRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.logger.Warn(ctx, "Invalid request body for payload replay",
+			logger.String("request_id", requestID),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	result, err := h.payloadArchive.ReplayPayloads(ctx, &req)
+	if err != nil {
+		h.logger.Error(ctx, "Failed to replay provider payloads", err,
+			logger.String("request_id", requestID),
+		)
+
+		errorResp := response.FromError(err, "Provider payloads", "Failed to replay provider payloads")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(result)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// parsePayloadID extrae y valida el ID del payload archivado de la ruta; responde 400 si es inválido
+func (h *AdminHandler) parsePayloadID(c *gin.Context) (uuid.UUID, bool) {
+	requestID := c.GetString("request_id")
+
+	idParam := c.Param("id")
+	payloadID, err := uuid.Parse(idParam)
+	if err != nil {
+		h.logger.Warn(c.Request.Context(), "Invalid provider payload ID format",
+			logger.String("request_id", requestID),
+			logger.String("id", idParam),
+		)
+
+		errorResp := response.BadRequest("Invalid payload ID format")
+		middleware.RespondWithError(c, errorResp)
+		return uuid.Nil, false
+	}
+
+	return payloadID, true
+}
+
+// CreateBackup godoc
+// @Summary Snapshot the database to the backup bucket
+// @Description Enqueue a job that exports each table as gzip-compressed NDJSON to the configured S3-compatible bucket, followed by a manifest with row counts and checksums
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body request.CreateBackupRequest false "Tables to export (defaults to BACKUP_TABLES)"
+// @Success 202 {object} response.APIResponse[response.JobResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/admin/backups [post]
+func (h *AdminHandler) CreateBackup(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	if h.backupService == nil || h.jobQueue == nil {
+		errorResp := response.ServiceUnavailable("Database backups are disabled (BACKUP_ENABLED=false)")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	// El body es opcional: sin body se exportan todas las tablas configuradas
+	var req request.CreateBackupRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.logger.Warn(ctx, "Invalid request body for database backup",
+			logger.String("request_id", requestID),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	if err := h.backupService.ValidateTables(req.Tables); err != nil {
+		errorResp := response.FromError(err, "Backup", "Invalid backup tables")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	payload, err := json.Marshal(jobs.DatabaseBackupPayload{Tables: req.Tables})
+	if err != nil {
+		errorResp := response.InternalServerError("Failed to enqueue database backup")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	job, err := h.jobQueue.Enqueue(ctx, jobs.JobTypeDatabaseBackup, payload, 0)
+	if err != nil {
+		h.logger.Error(ctx, "Failed to enqueue database backup", err,
+			logger.String("request_id", requestID),
+		)
+
+		errorResp := response.InternalServerError("Failed to enqueue database backup")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	h.logger.Info(ctx, "Database backup enqueued",
+		logger.String("request_id", requestID),
+		logger.String("job_id", job.ID.String()),
+		logger.Int("tables", len(req.Tables)),
+	)
+
+	apiResponse := response.Success(toJobResponse(job))
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusAccepted, apiResponse)
+}
+
+// ListBackups godoc
+// @Summary List database snapshots
+// @Description List the completed snapshots in the backup bucket, newest first, with their per-table row counts
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.APIResponse[[]response.BackupResponse]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/admin/backups [get]
+func (h *AdminHandler) ListBackups(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	if h.backupService == nil {
+		errorResp := response.ServiceUnavailable("Database backups are disabled (BACKUP_ENABLED=false)")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	backups, err := h.backupService.ListBackups(ctx)
+	if err != nil {
+		errorResp := response.FromError(err, "Backups", "Failed to list backups")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(backups)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// GetBackup godoc
+// @Summary Get database snapshot
+// @Description Get the manifest of a snapshot: schema version, tables, row counts, sizes and checksums
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Backup ID (e.g. 20260101T020000Z)"
+// @Success 200 {object} response.APIResponse[response.BackupResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/admin/backups/{id} [get]
+func (h *AdminHandler) GetBackup(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	if h.backupService == nil {
+		errorResp := response.ServiceUnavailable("Database backups are disabled (BACKUP_ENABLED=false)")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	backup, err := h.backupService.GetBackup(ctx, c.Param("id"))
+	if err != nil {
+		errorResp := response.FromError(err, "Backup", "Failed to get backup")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(backup)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// RestoreBackupDryRun godoc
+// @Summary Check a snapshot before restoring it
+// @Description Download every table of the snapshot, verify checksums and row counts and compare its columns and schema version with the current database. Nothing is written
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Backup ID (e.g. 20260101T020000Z)"
+// @Success 200 {object} response.APIResponse[response.RestoreDryRunResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/admin/backups/{id}/restore-dry-run [post]
+func (h *AdminHandler) RestoreBackupDryRun(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	if h.backupService == nil {
+		errorResp := response.ServiceUnavailable("Database backups are disabled (BACKUP_ENABLED=false)")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	result, err := h.backupService.RestoreDryRun(ctx, c.Param("id"))
+	if err != nil {
+		h.logger.Warn(ctx, "Backup restore dry run failed",
+			logger.String("request_id", requestID),
+			logger.String("backup_id", c.Param("id")),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Backup", "Failed to check backup")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(result)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// CreateParquetExport godoc
+// @Summary Export historical prices and ratings as Parquet
+// @Description Enqueue a job that writes one Parquet file per dataset, symbol and year (<prefix>/<dataset>/<SYMBOL>/<year>.parquet) to the local export directory or the S3 bucket
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body request.CreateParquetExportRequest false "Symbols, datasets and years to export (defaults to every active company, both datasets and the last five years)"
+// @Success 202 {object} response.APIResponse[response.JobResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/admin/exports/parquet [post]
+func (h *AdminHandler) CreateParquetExport(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	if h.parquetExport == nil || h.jobQueue == nil {
+		errorResp := response.ServiceUnavailable("Parquet exports are not configured")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	// El body es opcional: sin body se exportan todas las companies activas de los últimos cinco años
+	var req request.CreateParquetExportRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.logger.Warn(ctx, "Invalid request body for Parquet export",
+			logger.String("request_id", requestID),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	options := serviceInterfaces.ParquetExportOptions{
+		Symbols:  req.Symbols,
+		Datasets: req.Datasets,
+		FromYear: req.FromYear,
+		ToYear:   req.ToYear,
+	}
+	if err := h.parquetExport.ValidateOptions(options); err != nil {
+		errorResp := response.FromError(err, "Export", "Invalid Parquet export options")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	payload, err := json.Marshal(jobs.ParquetExportPayload{
+		Symbols:  req.Symbols,
+		Datasets: req.Datasets,
+		FromYear: req.FromYear,
+		ToYear:   req.ToYear,
+	})
+	if err != nil {
+		errorResp := response.InternalServerError("Failed to enqueue Parquet export")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	job, err := h.jobQueue.Enqueue(ctx, jobs.JobTypeParquetExport, payload, 0)
+	if err != nil {
+		h.logger.Error(ctx, "Failed to enqueue Parquet export", err,
+			logger.String("request_id", requestID),
+		)
+
+		errorResp := response.InternalServerError("Failed to enqueue Parquet export")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	h.logger.Info(ctx, "Parquet export enqueued",
+		logger.String("request_id", requestID),
+		logger.String("job_id", job.ID.String()),
+		logger.Int("symbols", len(req.Symbols)),
+	)
+
+	apiResponse := response.Success(toJobResponse(job))
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusAccepted, apiResponse)
+}
+
+// ListSlowQueries godoc
+// @Summary List slow database queries
+// @Description List the most recent queries that exceeded the slow-query threshold, newest first, with the request that issued them and the connection pool settings
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param limit query int false "Maximum number of queries to return" default(50)
+// @Success 200 {object} response.APIResponse[response.SlowQueriesResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/admin/db/slow-queries [get]
+func (h *AdminHandler) ListSlowQueries(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	if h.database == nil {
+		errorResp := response.ServiceUnavailable("Database diagnostics are not configured")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	limit := defaultSlowQueriesLimit
+	if rawLimit := c.Query("limit"); rawLimit != "" {
+		parsed, err := strconv.Atoi(rawLimit)
+		if err != nil || parsed < 1 {
+			errorResp := response.BadRequest("limit must be a positive integer")
+			middleware.RespondWithError(c, errorResp)
+			return
+		}
+		limit = parsed
+	}
+
+	poolStats, err := h.database.GetStats()
+	if err != nil {
+		// Las estadísticas son informativas; el listado se devuelve igualmente
+		h.logger.Warn(ctx, "Failed to read connection pool stats",
+			logger.String("request_id", requestID),
+			logger.String("error", err.Error()),
+		)
+	}
+
+	recorder := h.database.SlowQueries()
+	recent := recorder.Recent(limit)
+
+	queries := make([]response.SlowQueryResponse, len(recent))
+	for i, query := range recent {
+		queries[i] = response.SlowQueryResponse{
+			SQL:        query.SQL,
+			DurationMs: float64(query.Duration.Microseconds()) / 1000,
+			Rows:       query.Rows,
+			RequestID:  query.RequestID,
+			Source:     query.Source,
+			Error:      query.Error,
+			OccurredAt: query.OccurredAt,
+		}
+	}
+
+	slowQueriesResponse := &response.SlowQueriesResponse{
+		Enabled:       recorder.Enabled(),
+		ThresholdMs:   recorder.Threshold().Milliseconds(),
+		TotalRecorded: recorder.Total(),
+		Pool:          h.database.PoolSettings(),
+		PoolStats:     poolStats,
+		Queries:       queries,
+	}
+
+	apiResponse := response.Success(slowQueriesResponse)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// RefreshAnalytics godoc
+// @Summary Refresh analytics views
+// @Description Recompute the materialized views behind the top companies, action-type and sector distribution analytics and drop the cached results
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.APIResponse[response.AnalyticsRefreshResponse]
+// @Failure 500 {object} response.APIResponse[any]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/admin/analytics/refresh [post]
+func (h *AdminHandler) RefreshAnalytics(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	if h.analyticsViews == nil {
+		errorResp := response.ServiceUnavailable("Analytics views are disabled (DB_ANALYTICS_VIEWS=false)")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	result, err := h.analyticsViews.Refresh(ctx)
+	if err != nil {
+		h.logger.Error(ctx, "Failed to refresh analytics views", err,
+			logger.String("request_id", requestID),
+		)
+
+		errorResp := response.DatabaseError("Failed to refresh analytics views")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	h.logger.Info(ctx, "Analytics views refreshed on demand",
+		logger.String("request_id", requestID),
+		logger.Int64("duration_ms", result.Duration.Milliseconds()),
+	)
+
+	apiResponse := response.Success(&response.AnalyticsRefreshResponse{
+		Views:       result.Views,
+		RefreshedAt: result.RefreshedAt,
+		DurationMs:  result.Duration.Milliseconds(),
+	})
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// CreatePartitions godoc
+// @Summary Create monthly table partitions
+// @Description Partition stock_ratings (event_time) and market_data (market_timestamp) by month, from the oldest row up to months_ahead months in the future. Existing partitions are kept
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param body body request.CreatePartitionsRequest false "Future months to partition (defaults to 3)"
+// @Success 200 {object} response.APIResponse[response.PartitionMaintenanceResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/admin/db/partitions [post]
+func (h *AdminHandler) CreatePartitions(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	if h.database == nil {
+		errorResp := response.ServiceUnavailable("Database maintenance is not configured")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	// El body es opcional: sin body se usan los meses por defecto
+	var req request.CreatePartitionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+	monthsAhead := req.MonthsAhead
+	if monthsAhead == 0 {
+		monthsAhead = defaultPartitionMonthsAhead
+	}
+
+	results, err := h.database.EnsureMonthlyPartitions(ctx, monthsAhead, time.Now())
+	if err != nil {
+		// Sin licencia enterprise CockroachDB rechaza el particionado; el detalle queda en el log
+		h.logger.Error(ctx, "Failed to create table partitions", err,
+			logger.String("request_id", requestID),
+		)
+
+		errorResp := response.DatabaseError("Failed to create table partitions")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	tables := make([]response.PartitionTableResponse, len(results))
+	for i, result := range results {
+		tables[i] = response.PartitionTableResponse{
+			Table:      result.Table,
+			Column:     result.Column,
+			Partitions: result.Partitions,
+			Created:    result.Created,
+			Skipped:    result.Skipped,
+		}
+
+		h.logger.Info(ctx, "Table partitions ensured",
+			logger.String("request_id", requestID),
+			logger.String("table", result.Table),
+			logger.Int("partitions", len(result.Partitions)),
+			logger.Int("created", len(result.Created)),
+		)
+	}
+
+	apiResponse := response.Success(&response.PartitionMaintenanceResponse{
+		MonthsAhead: monthsAhead,
+		Tables:      tables,
+	})
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// WarmCache godoc
+// @Summary Warm the cache
+// @Description Pre-populate the cache with the most rated companies, their profiles and latest market data
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param body body request.WarmCacheRequest false "Number of companies to warm (defaults to CACHE_WARM_TOP_COMPANIES)"
+// @Success 200 {object} response.APIResponse[response.CacheWarmResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/admin/cache/warm [post]
+func (h *AdminHandler) WarmCache(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	if !h.cacheWarmer.Enabled() {
+		errorResp := response.ServiceUnavailable("Query cache is not configured")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	// El body es opcional: sin body se usa el límite configurado
+	var req request.WarmCacheRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	result, err := h.cacheWarmer.Warm(ctx, req.Limit)
+	if err != nil {
+		h.logger.Error(ctx, "Failed to warm cache", err,
+			logger.String("request_id", requestID),
+		)
+
+		errorResp := response.InternalServerError("Failed to warm cache")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	h.logger.Info(ctx, "Cache warmed on demand",
+		logger.String("request_id", requestID),
+		logger.Int("companies_warmed", result.CompaniesWarmed),
+	)
+
+	apiResponse := response.Success(&response.CacheWarmResponse{
+		Requested:         result.Requested,
+		CompaniesWarmed:   result.CompaniesWarmed,
+		MarketDataWarmed:  result.MarketDataWarmed,
+		MarketDataMissing: result.MarketDataMissing,
+		Failed:            result.Failed,
+		Errors:            result.Errors,
+		DurationMs:        result.Duration.Milliseconds(),
+	})
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// toJobResponse convierte un job de la cola a su DTO de respuesta
+func toJobResponse(job *entities.Job) *response.JobResponse {
+	resp := &response.JobResponse{
+		ID:              job.ID,
+		Type:            job.Type,
+		Status:          string(job.Status),
+		Error:           job.Error,
+		Attempts:        job.Attempts,
+		MaxAttempts:     job.MaxAttempts,
+		CancelRequested: job.CancelRequested,
+		RunAt:           job.RunAt,
+		StartedAt:       job.StartedAt,
+		FinishedAt:      job.FinishedAt,
+		CreatedAt:       job.CreatedAt,
+		UpdatedAt:       job.UpdatedAt,
+	}
+
+	if job.Payload != "" {
+		resp.Payload = json.RawMessage(job.Payload)
+	}
+	if job.Result != "" {
+		resp.Result = json.RawMessage(job.Result)
+	}
+
+	return resp
+}
+
+// toPopulationConfig construye la configuración de población aplicando los valores por defecto
+func toPopulationConfig(req *request.RunPopulationRequest) population.PopulationConfig {
+	config := population.DefaultPopulationConfig()
+
+	if req.BatchSize != nil {
+		config.BatchSize = *req.BatchSize
+	}
+	if req.MaxPages != nil {
+		config.MaxPages = *req.MaxPages
+	}
+	if req.DelayMs != nil {
+		config.DelayBetween = time.Duration(*req.DelayMs) * time.Millisecond
+	}
+	if req.UseCache != nil {
+		config.UseCache = *req.UseCache
+	}
+	if req.ValidateAfter != nil {
+		config.ValidateAfter = *req.ValidateAfter
+	}
+	config.ClearFirst = req.ClearFirst
+	config.DryRun = req.DryRun
+	config.Incremental = req.Incremental
+
+	return config
+}
+
+// toPopulationJobResponse convierte un job de población a su DTO de respuesta
+func toPopulationJobResponse(job *population.PopulationJob) *response.PopulationJobResponse {
+	resp := &response.PopulationJobResponse{
+		ID:     job.ID,
+		Status: string(job.Status),
+		Config: response.PopulationConfigResponse{
+			BatchSize:     job.Config.BatchSize,
+			MaxPages:      job.Config.MaxPages,
+			DelayMs:       job.Config.DelayBetween.Milliseconds(),
+			ClearFirst:    job.Config.ClearFirst,
+			UseCache:      job.Config.UseCache,
+			DryRun:        job.Config.DryRun,
+			ValidateAfter: job.Config.ValidateAfter,
+			Incremental:   job.Config.Incremental,
+		},
+		Error:      job.Error,
+		CreatedAt:  job.CreatedAt,
+		StartedAt:  job.StartedAt,
+		FinishedAt: job.FinishedAt,
+	}
+
+	if job.Result != nil {
+		resp.Result = &response.PopulationResultResponse{
+			TotalPages:     job.Result.TotalPages,
+			PagesRequested: job.Result.PagesRequested,
+			TotalItems:     job.Result.TotalItems,
+			ProcessedItems: job.Result.ProcessedItems,
+			SkippedItems:   job.Result.SkippedItems,
+			ErrorCount:     job.Result.ErrorCount,
+			Companies:      job.Result.Companies,
+			Brokerages:     job.Result.Brokerages,
+			StockRatings:   job.Result.StockRatings,
+			DurationMs:     job.Result.Duration.Milliseconds(),
+			Errors:         job.Result.Errors,
+			RejectedItems:  job.Result.RejectedItems,
+
+			RuleViolations:   job.Result.RuleViolations,
+			ReachedWatermark: job.Result.ReachedWatermark,
+		}
+	}
+
+	return resp
+}
+
+// ReloadConfig godoc
+// @Summary Reload configuration
+// @Description Re-read the non-structural configuration (log level, rate limits, cache TTLs and provider API keys) and apply it without restarting. Same as sending SIGHUP to the process
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.APIResponse[response.ConfigReloadResponse]
+// @Failure 422 {object} response.APIResponse[any]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/admin/config/reload [post]
+func (h *AdminHandler) ReloadConfig(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	if h.configWatcher == nil {
+		errorResp := response.ServiceUnavailable("Configuration reload is not configured")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	result, err := h.configWatcher.Reload()
+	if err != nil {
+		h.logger.Error(ctx, "Failed to reload configuration", err,
+			logger.String("request_id", requestID),
+		)
+
+		errorResp := response.ValidationFailed("Configuration was not reloaded: " + err.Error())
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	h.logger.Info(ctx, "Configuration reloaded on demand",
+		logger.String("request_id", requestID),
+		logger.Any("changed", result.Changed),
+		logger.Int("failed", len(result.Failed)),
+	)
+
+	apiResponse := response.Success(&response.ConfigReloadResponse{
+		Changed:    result.Changed,
+		Applied:    result.Applied,
+		Failed:     result.Failed,
+		ReloadedAt: result.ReloadedAt,
+	})
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
 	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
 )
 
 // AlphaVantageHandler handles Alpha Vantage API endpoints
@@ -38,7 +39,9 @@ func NewAlphaVantageHandler(service interfaces.AlphaVantageService, log logger.L
 // @Success 200 {object} response.HistoricalDataResponse
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
+// @Failure 502 {object} response.ErrorResponse
 // @Router /api/v1/alpha/historical/{symbol} [get]
 func (h *AlphaVantageHandler) GetHistoricalData(ctx *gin.Context) {
 	symbol := ctx.Param("symbol")
@@ -64,7 +67,8 @@ func (h *AlphaVantageHandler) GetHistoricalData(ctx *gin.Context) {
 			logger.String("interval", interval),
 			logger.String("adjusted", adjusted))
 
-		ctx.JSON(500, response.InternalServerError("Failed to retrieve historical data"))
+		// Límite de Alpha Vantage 429, símbolo desconocido 404, otros fallos del proveedor 502
+		middleware.RespondWithError(ctx, response.FromError(err, "Company", "Failed to retrieve historical data"))
 		return
 	}
 	h.logger.Info(ctx.Request.Context(), "Historical data retrieved successfully",
@@ -91,7 +95,9 @@ func (h *AlphaVantageHandler) GetHistoricalData(ctx *gin.Context) {
 // @Success 200 {object} response.TechnicalIndicatorResponse
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
+// @Failure 502 {object} response.ErrorResponse
 // @Router /api/v1/alpha/technical/{symbol} [get]
 func (h *AlphaVantageHandler) GetTechnicalIndicators(ctx *gin.Context) {
 	symbol := ctx.Param("symbol")
@@ -121,7 +127,7 @@ func (h *AlphaVantageHandler) GetTechnicalIndicators(ctx *gin.Context) {
 			logger.String("time_period", timePeriod),
 			logger.String("series_type", seriesType))
 
-		ctx.JSON(500, response.InternalServerError("Failed to retrieve technical indicators"))
+		middleware.RespondWithError(ctx, response.FromError(err, "Company", "Failed to retrieve technical indicators"))
 		return
 	}
 
@@ -147,7 +153,9 @@ func (h *AlphaVantageHandler) GetTechnicalIndicators(ctx *gin.Context) {
 // @Success 200 {object} response.FinancialMetricsResponse
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
+// @Failure 502 {object} response.ErrorResponse
 // @Router /api/v1/alpha/financials/{symbol} [get]
 func (h *AlphaVantageHandler) GetFinancialMetrics(ctx *gin.Context) {
 	symbol := ctx.Param("symbol")
@@ -166,7 +174,7 @@ func (h *AlphaVantageHandler) GetFinancialMetrics(ctx *gin.Context) {
 			logger.String("symbol", symbol),
 			logger.String("function", function))
 
-		ctx.JSON(500, response.InternalServerError("Failed to retrieve financial metrics"))
+		middleware.RespondWithError(ctx, response.FromError(err, "Company", "Failed to retrieve financial metrics"))
 		return
 	}
 
@@ -7,9 +7,12 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
 	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
 	serviceInterfaces "github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/i18n"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
 )
 
 // AnalysisHandler maneja los endpoints relacionados con análisis y recomendaciones
@@ -52,10 +55,7 @@ func (h *AnalysisHandler) GetCompanyAnalysis(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Invalid company ID format")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -69,10 +69,7 @@ func (h *AnalysisHandler) GetCompanyAnalysis(c *gin.Context) {
 				logger.String("error", errorResp.Message),
 			)
 
-			apiResponse := errorResp.ToAPIResponse()
-			apiResponse.RequestID = requestID
-
-			c.JSON(errorResp.StatusCode, apiResponse)
+			middleware.RespondWithError(c, errorResp)
 			return
 		}
 
@@ -82,10 +79,7 @@ func (h *AnalysisHandler) GetCompanyAnalysis(c *gin.Context) {
 		)
 
 		errorResp := response.InternalServerError("Failed to retrieve company analysis")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -96,6 +90,8 @@ func (h *AnalysisHandler) GetCompanyAnalysis(c *gin.Context) {
 		logger.Int("total_ratings", analysisResp.TotalRatings),
 	)
 
+	analysisResp.Label = i18n.RecommendationLabel(i18n.FromContext(c), analysisResp.Recommendation)
+
 	apiResponse := response.Success(analysisResp)
 	apiResponse.RequestID = requestID
 
@@ -126,10 +122,7 @@ func (h *AnalysisHandler) GetCompanyAnalysisByTicker(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Ticker parameter is required")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -143,10 +136,7 @@ func (h *AnalysisHandler) GetCompanyAnalysisByTicker(c *gin.Context) {
 				logger.String("error", errorResp.Message),
 			)
 
-			apiResponse := errorResp.ToAPIResponse()
-			apiResponse.RequestID = requestID
-
-			c.JSON(errorResp.StatusCode, apiResponse)
+			middleware.RespondWithError(c, errorResp)
 			return
 		}
 
@@ -156,10 +146,7 @@ func (h *AnalysisHandler) GetCompanyAnalysisByTicker(c *gin.Context) {
 		)
 
 		errorResp := response.InternalServerError("Failed to retrieve company analysis")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -170,6 +157,8 @@ func (h *AnalysisHandler) GetCompanyAnalysisByTicker(c *gin.Context) {
 		logger.Int("total_ratings", analysisResp.TotalRatings),
 	)
 
+	analysisResp.Label = i18n.RecommendationLabel(i18n.FromContext(c), analysisResp.Recommendation)
+
 	apiResponse := response.Success(analysisResp)
 	apiResponse.RequestID = requestID
 
@@ -198,10 +187,7 @@ func (h *AnalysisHandler) GetMarketOverview(c *gin.Context) {
 				logger.String("error", errorResp.Message),
 			)
 
-			apiResponse := errorResp.ToAPIResponse()
-			apiResponse.RequestID = requestID
-
-			c.JSON(errorResp.StatusCode, apiResponse)
+			middleware.RespondWithError(c, errorResp)
 			return
 		}
 
@@ -210,10 +196,7 @@ func (h *AnalysisHandler) GetMarketOverview(c *gin.Context) {
 		)
 
 		errorResp := response.InternalServerError("Failed to retrieve market overview")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -227,6 +210,90 @@ func (h *AnalysisHandler) GetMarketOverview(c *gin.Context) {
 	c.JSON(http.StatusOK, apiResponse)
 }
 
+// GetMarketBreadth godoc
+// @Summary Get market breadth
+// @Description Daily breadth of the active companies from stored daily prices: advancers vs decliners against the previous session, advance/decline line and percent above the 50 and 200-session moving averages, plus advancers vs decliners in the latest stored quotes. Results are cached
+// @Tags analysis
+// @Accept json
+// @Produce json
+// @Param days query int false "Calendar days up to today (1-365)" default(30)
+// @Success 200 {object} response.APIResponse[response.MarketBreadthResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/analysis/market/breadth [get]
+func (h *AnalysisHandler) GetMarketBreadth(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	var req request.MarketBreadthRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	breadth, err := h.analysisService.GetMarketBreadth(ctx, &req)
+	if err != nil {
+		h.logger.Warn(ctx, "Market breadth failed",
+			logger.String("request_id", requestID),
+			logger.Int("days", req.Days),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Market breadth", "Failed to compute market breadth")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(breadth)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// GetMarketCapStats godoc
+// @Summary Get market cap statistics
+// @Description Market cap statistics (count, total, min, max, average) of the active companies converted to one currency at the current exchange rates, overall and optionally per exchange or listing currency, with the rate applied to each currency. Companies without a recorded currency are taken as USD; those in a currency without a rate are left out with a warning. Results are cached
+// @Tags analysis
+// @Accept json
+// @Produce json
+// @Param group_by query string false "Grouping" Enums(exchange, currency)
+// @Param currency query string false "Currency of the result (ISO 4217)" default(USD)
+// @Success 200 {object} response.APIResponse[response.MarketCapStatsResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Router /api/v1/analysis/market-cap [get]
+func (h *AnalysisHandler) GetMarketCapStats(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	var req request.MarketCapStatsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	stats, err := h.analysisService.GetMarketCapStats(ctx, &req)
+	if err != nil {
+		h.logger.Warn(ctx, "Market cap stats failed",
+			logger.String("request_id", requestID),
+			logger.String("group_by", req.GroupBy),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Market cap stats", "Failed to compute market cap stats")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(stats)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
 // GetSectorAnalysis godoc
 // @Summary Get sector analysis
 // @Description Get analysis for a specific sector
@@ -250,10 +317,7 @@ func (h *AnalysisHandler) GetSectorAnalysis(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Sector parameter is required")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -267,10 +331,7 @@ func (h *AnalysisHandler) GetSectorAnalysis(c *gin.Context) {
 				logger.String("error", errorResp.Message),
 			)
 
-			apiResponse := errorResp.ToAPIResponse()
-			apiResponse.RequestID = requestID
-
-			c.JSON(errorResp.StatusCode, apiResponse)
+			middleware.RespondWithError(c, errorResp)
 			return
 		}
 
@@ -280,10 +341,7 @@ func (h *AnalysisHandler) GetSectorAnalysis(c *gin.Context) {
 		)
 
 		errorResp := response.InternalServerError("Failed to retrieve sector analysis")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -305,6 +363,7 @@ func (h *AnalysisHandler) GetSectorAnalysis(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param limit query int false "Maximum number of companies to return" default(10) minimum(1) maximum(100)
+// @Param tags query string false "Comma-separated tag slugs; only companies with all of them"
 // @Success 200 {object} response.APIResponse[[]response.CompanyListResponse]
 // @Failure 400 {object} response.APIResponse[any]
 // @Failure 500 {object} response.APIResponse[any]
@@ -323,10 +382,7 @@ func (h *AnalysisHandler) GetTopRatedCompanies(c *gin.Context) {
 			)
 
 			errorResp := response.BadRequest("Invalid limit parameter")
-			apiResponse := errorResp.ToAPIResponse()
-			apiResponse.RequestID = requestID
-
-			c.JSON(errorResp.StatusCode, apiResponse)
+			middleware.RespondWithError(c, errorResp)
 			return
 		} else if l < 1 {
 			limit = 1
@@ -338,7 +394,7 @@ func (h *AnalysisHandler) GetTopRatedCompanies(c *gin.Context) {
 	}
 
 	// Get top rated companies
-	companies, err := h.analysisService.GetTopRatedCompanies(ctx, limit)
+	companies, err := h.analysisService.GetTopRatedCompanies(ctx, limit, c.Query("tags"))
 	if err != nil {
 		if errorResp, ok := err.(*response.ErrorResponse); ok {
 			h.logger.Warn(ctx, "Top rated companies retrieval failed",
@@ -347,10 +403,7 @@ func (h *AnalysisHandler) GetTopRatedCompanies(c *gin.Context) {
 				logger.String("error", errorResp.Message),
 			)
 
-			apiResponse := errorResp.ToAPIResponse()
-			apiResponse.RequestID = requestID
-
-			c.JSON(errorResp.StatusCode, apiResponse)
+			middleware.RespondWithError(c, errorResp)
 			return
 		}
 
@@ -360,10 +413,7 @@ func (h *AnalysisHandler) GetTopRatedCompanies(c *gin.Context) {
 		)
 
 		errorResp := response.InternalServerError("Failed to retrieve top rated companies")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -379,13 +429,157 @@ func (h *AnalysisHandler) GetTopRatedCompanies(c *gin.Context) {
 	c.JSON(http.StatusOK, apiResponse)
 }
 
+// CompareCompanies godoc
+// @Summary Compare companies side by side
+// @Description Compare up to 10 companies in one response: valuation ratios, growth metrics, brokerage consensus and recent price returns. Every metric is also normalized to 0-1 within the compared set (1 = best) and the leader of each metric is reported. Unknown tickers are listed in not_found. With ticker instead of tickers, the company is compared with its closest peers
+// @Tags analysis
+// @Accept json
+// @Produce json
+// @Param tickers query string false "Comma-separated tickers (2-10), e.g. AAPL,MSFT,NVDA. Required without ticker"
+// @Param ticker query string false "Compare this company with its peers (used when tickers is empty)"
+// @Success 200 {object} response.APIResponse[response.CompanyComparisonResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Router /api/v1/analysis/compare [get]
+func (h *AnalysisHandler) CompareCompanies(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	var req request.CompareCompaniesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	comparison, err := h.analysisService.CompareCompanies(ctx, &req)
+	if err != nil {
+		h.logger.Warn(ctx, "Company comparison failed",
+			logger.String("request_id", requestID),
+			logger.String("tickers", req.Tickers),
+			logger.String("ticker", req.Ticker),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Company", "Failed to compare companies")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	lang := i18n.FromContext(c)
+	for i := range comparison.Companies {
+		consensus := &comparison.Companies[i].Consensus
+		consensus.RatingLabel = i18n.RecommendationLabel(lang, consensus.Rating)
+	}
+
+	apiResponse := response.Success(comparison)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// GetCorrelationMatrix godoc
+// @Summary Get return correlation matrix
+// @Description Pairwise Pearson correlation of daily returns between tickers, computed from stored historical prices over the last days calendar days. Pairs with fewer than 3 common sessions are null. Results are cached
+// @Tags analysis
+// @Accept json
+// @Produce json
+// @Param tickers query string true "Comma-separated tickers (2-20), e.g. AAPL,MSFT,NVDA"
+// @Param days query int false "Window in calendar days (10-1825)" default(90)
+// @Success 200 {object} response.APIResponse[response.CorrelationMatrixResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Router /api/v1/analysis/correlation [get]
+func (h *AnalysisHandler) GetCorrelationMatrix(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	var req request.CorrelationRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	matrix, err := h.analysisService.GetCorrelationMatrix(ctx, &req)
+	if err != nil {
+		h.logger.Warn(ctx, "Correlation matrix failed",
+			logger.String("request_id", requestID),
+			logger.String("tickers", req.Tickers),
+			logger.Int("days", req.Days),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Company", "Failed to compute correlation matrix")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(matrix)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// GetPortfolioRisk godoc
+// @Summary Get portfolio risk metrics
+// @Description Annualized return and volatility, beta vs. a benchmark index, Sharpe ratio and max drawdown of a weighted portfolio of companies (and of each holding), computed from stored historical prices. The portfolio is rebalanced daily to the given weights. Results are cached
+// @Tags analysis
+// @Accept json
+// @Produce json
+// @Param holdings query string true "Comma-separated TICKER:weight pairs, e.g. AAPL:60,MSFT:40. Without weights holdings are equally weighted"
+// @Param benchmark query string false "Benchmark symbol for beta" default(SPY)
+// @Param days query int false "Window in calendar days (30-1825)" default(365)
+// @Param risk_free_rate query number false "Annual risk-free rate for the Sharpe ratio, e.g. 0.04" default(0)
+// @Success 200 {object} response.APIResponse[response.PortfolioRiskResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Router /api/v1/analysis/portfolio/risk [get]
+func (h *AnalysisHandler) GetPortfolioRisk(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	var req request.PortfolioRiskRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	risk, err := h.analysisService.GetPortfolioRisk(ctx, &req)
+	if err != nil {
+		h.logger.Warn(ctx, "Portfolio risk failed",
+			logger.String("request_id", requestID),
+			logger.String("holdings", req.Holdings),
+			logger.String("benchmark", req.Benchmark),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Company", "Failed to compute portfolio risk")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(risk)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
 // GetRatingTrends godoc
 // @Summary Get rating trends
-// @Description Get rating trends over a specified time period
+// @Description Get the ratings per action type over a preset period or a from/to range, in day, week or month buckets
 // @Tags analysis
 // @Accept json
 // @Produce json
-// @Param period query string false "Time period (week, month, quarter, year)" default("month")
+// @Param period query string false "Time period ending today when from is not set (week, month, quarter, year)" default("month")
+// @Param from query string false "First day of the range (YYYY-MM-DD)"
+// @Param to query string false "Last day of the range (YYYY-MM-DD), today by default"
+// @Param granularity query string false "Bucket size (day, week, month); by default based on the range length"
+// @Param tz query string false "IANA time zone of the days and buckets (UTC by default)"
 // @Success 200 {object} response.APIResponse[map[string]interface{}]
 // @Failure 400 {object} response.APIResponse[any]
 // @Failure 500 {object} response.APIResponse[any]
@@ -394,31 +588,20 @@ func (h *AnalysisHandler) GetRatingTrends(c *gin.Context) {
 	ctx := c.Request.Context()
 	requestID := c.GetString("request_id")
 
-	// Parse period parameter
-	period := c.DefaultQuery("period", "month")
-	validPeriods := map[string]bool{
-		"week":    true,
-		"month":   true,
-		"quarter": true,
-		"year":    true,
-	}
-
-	if !validPeriods[period] {
-		h.logger.Warn(ctx, "Invalid period parameter",
+	var req request.RatingTrendsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warn(ctx, "Invalid rating trends parameters",
 			logger.String("request_id", requestID),
-			logger.String("period", period),
+			logger.String("error", err.Error()),
 		)
 
-		errorResp := response.BadRequest("Invalid period parameter. Valid values: week, month, quarter, year")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, middleware.ValidationErrorResponse(err))
 		return
 	}
+	period := req.Period
 
 	// Get rating trends
-	trends, err := h.analysisService.GetRatingTrends(ctx, period)
+	trends, err := h.analysisService.GetRatingTrends(ctx, &req)
 	if err != nil {
 		if errorResp, ok := err.(*response.ErrorResponse); ok {
 			h.logger.Warn(ctx, "Rating trends retrieval failed",
@@ -427,10 +610,7 @@ func (h *AnalysisHandler) GetRatingTrends(c *gin.Context) {
 				logger.String("error", errorResp.Message),
 			)
 
-			apiResponse := errorResp.ToAPIResponse()
-			apiResponse.RequestID = requestID
-
-			c.JSON(errorResp.StatusCode, apiResponse)
+			middleware.RespondWithError(c, errorResp)
 			return
 		}
 
@@ -440,10 +620,7 @@ func (h *AnalysisHandler) GetRatingTrends(c *gin.Context) {
 		)
 
 		errorResp := response.InternalServerError("Failed to retrieve rating trends")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -458,6 +635,50 @@ func (h *AnalysisHandler) GetRatingTrends(c *gin.Context) {
 	c.JSON(http.StatusOK, apiResponse)
 }
 
+// GetSectorRotation godoc
+// @Summary Get sector rotation
+// @Description Upgrade/downgrade balance of each sector month over month, computed from the ratings of the companies of the sector, as a sectors x months matrix. Companies without sector are left out
+// @Tags analysis
+// @Accept json
+// @Produce json
+// @Param months query int false "Number of months (2-60)" default(12)
+// @Param to query string false "Last month included (YYYY-MM), the current month by default"
+// @Param tz query string false "IANA time zone of the months (UTC by default)"
+// @Success 200 {object} response.APIResponse[response.SectorRotationResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Router /api/v1/analysis/trends/sectors [get]
+func (h *AnalysisHandler) GetSectorRotation(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	var req request.SectorRotationRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	rotation, err := h.analysisService.GetSectorRotation(ctx, &req)
+	if err != nil {
+		h.logger.Warn(ctx, "Sector rotation failed",
+			logger.String("request_id", requestID),
+			logger.Int("months", req.Months),
+			logger.String("to", req.To),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Sector", "Failed to get sector rotation")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(rotation)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
 // GetBrokerageActivity godoc
 // @Summary Get brokerage activity analysis
 // @Description Get brokerage activity analysis over a specified time period
@@ -489,10 +710,7 @@ func (h *AnalysisHandler) GetBrokerageActivity(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Invalid period parameter. Valid values: week, month, quarter, year")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -506,10 +724,7 @@ func (h *AnalysisHandler) GetBrokerageActivity(c *gin.Context) {
 				logger.String("error", errorResp.Message),
 			)
 
-			apiResponse := errorResp.ToAPIResponse()
-			apiResponse.RequestID = requestID
-
-			c.JSON(errorResp.StatusCode, apiResponse)
+			middleware.RespondWithError(c, errorResp)
 			return
 		}
 
@@ -519,10 +734,7 @@ func (h *AnalysisHandler) GetBrokerageActivity(c *gin.Context) {
 		)
 
 		errorResp := response.InternalServerError("Failed to retrieve brokerage activity")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -539,12 +751,12 @@ func (h *AnalysisHandler) GetBrokerageActivity(c *gin.Context) {
 
 // GenerateRecommendation godoc
 // @Summary Generate recommendation for a company
-// @Description Generate investment recommendation for a specific company
+// @Description Generate investment recommendation for a specific company from a composite score (-1 to 1) that blends analyst consensus, price momentum (RSI and moving-average trend) and valuation ratios with configurable weights. The response includes the score of each factor and its contribution
 // @Tags analysis
 // @Accept json
 // @Produce json
 // @Param id path string true "Company ID"
-// @Success 200 {object} response.APIResponse[map[string]string]
+// @Success 200 {object} response.APIResponse[response.RecommendationResponse]
 // @Failure 400 {object} response.APIResponse[any]
 // @Failure 404 {object} response.APIResponse[any]
 // @Failure 500 {object} response.APIResponse[any]
@@ -563,10 +775,7 @@ func (h *AnalysisHandler) GenerateRecommendation(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Invalid company ID format")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -580,10 +789,7 @@ func (h *AnalysisHandler) GenerateRecommendation(c *gin.Context) {
 				logger.String("error", errorResp.Message),
 			)
 
-			apiResponse := errorResp.ToAPIResponse()
-			apiResponse.RequestID = requestID
-
-			c.JSON(errorResp.StatusCode, apiResponse)
+			middleware.RespondWithError(c, errorResp)
 			return
 		}
 
@@ -593,25 +799,19 @@ func (h *AnalysisHandler) GenerateRecommendation(c *gin.Context) {
 		)
 
 		errorResp := response.InternalServerError("Failed to generate recommendation")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
 	h.logger.Info(ctx, "Recommendation generated successfully",
 		logger.String("request_id", requestID),
 		logger.String("company_id", companyID.String()),
-		logger.String("recommendation", recommendation),
+		logger.String("recommendation", recommendation.Recommendation),
 	)
 
-	result := map[string]string{
-		"company_id":     companyID.String(),
-		"recommendation": recommendation,
-	}
+	recommendation.Label = i18n.RecommendationLabel(i18n.FromContext(c), recommendation.Recommendation)
 
-	apiResponse := response.Success(result)
+	apiResponse := response.Success(recommendation)
 	apiResponse.RequestID = requestID
 
 	c.JSON(http.StatusOK, apiResponse)
@@ -641,10 +841,7 @@ func (h *AnalysisHandler) GetRecommendationsByRating(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Rating parameter is required")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -658,10 +855,7 @@ func (h *AnalysisHandler) GetRecommendationsByRating(c *gin.Context) {
 			)
 
 			errorResp := response.BadRequest("Invalid limit parameter")
-			apiResponse := errorResp.ToAPIResponse()
-			apiResponse.RequestID = requestID
-
-			c.JSON(errorResp.StatusCode, apiResponse)
+			middleware.RespondWithError(c, errorResp)
 			return
 		} else if l < 1 {
 			limit = 1
@@ -683,10 +877,7 @@ func (h *AnalysisHandler) GetRecommendationsByRating(c *gin.Context) {
 				logger.String("error", errorResp.Message),
 			)
 
-			apiResponse := errorResp.ToAPIResponse()
-			apiResponse.RequestID = requestID
-
-			c.JSON(errorResp.StatusCode, apiResponse)
+			middleware.RespondWithError(c, errorResp)
 			return
 		}
 
@@ -697,10 +888,7 @@ func (h *AnalysisHandler) GetRecommendationsByRating(c *gin.Context) {
 		)
 
 		errorResp := response.InternalServerError("Failed to retrieve recommendations by rating")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
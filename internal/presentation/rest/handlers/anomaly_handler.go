@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	serviceInterfaces "github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
+)
+
+// AnomalyHandler maneja la consulta de picos anómalos de ratings y de volumen
+type AnomalyHandler struct {
+	anomalyService serviceInterfaces.AnomalyService
+	logger         logger.Logger
+}
+
+// NewAnomalyHandler crea una nueva instancia del handler de anomalías
+func NewAnomalyHandler(anomalyService serviceInterfaces.AnomalyService, appLogger logger.Logger) *AnomalyHandler {
+	return &AnomalyHandler{
+		anomalyService: anomalyService,
+		logger:         appLogger,
+	}
+}
+
+// GetAnomalies godoc
+// @Summary Get unusual activity
+// @Description Get the companies flagged for abnormal spikes: days with at least 3 ratings and 3 times the daily average of the previous 30 days (rating_activity), or sessions with 3 times the average volume of the previous 20 sessions (volume). Anomalies are detected periodically by the anomaly_detection job
+// @Tags analysis
+// @Accept json
+// @Produce json
+// @Param days query int false "Last days to include (1-90)" default(7)
+// @Param type query string false "rating_activity or volume; both by default"
+// @Param limit query int false "Maximum anomalies (1-200)" default(50)
+// @Success 200 {object} response.APIResponse[response.AnomaliesResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Router /api/v1/analysis/anomalies [get]
+func (h *AnomalyHandler) GetAnomalies(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	var req request.AnomalyRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	result, err := h.anomalyService.GetAnomalies(ctx, &req)
+	if err != nil {
+		h.logger.Warn(ctx, "Failed to get anomalies",
+			logger.String("request_id", requestID),
+			logger.Int("days", req.Days),
+			logger.String("type", req.Type),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Anomaly", "Failed to get anomalies")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(result)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
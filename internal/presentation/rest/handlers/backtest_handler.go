@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	serviceInterfaces "github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
+)
+
+// BacktestHandler maneja la simulación de estrategias basadas en ratings
+type BacktestHandler struct {
+	backtestService serviceInterfaces.BacktestService
+	logger          logger.Logger
+}
+
+// NewBacktestHandler crea una nueva instancia del handler de backtesting
+func NewBacktestHandler(backtestService serviceInterfaces.BacktestService, appLogger logger.Logger) *BacktestHandler {
+	return &BacktestHandler{
+		backtestService: backtestService,
+		logger:          appLogger,
+	}
+}
+
+// RunBacktest godoc
+// @Summary Backtest a rating-following strategy
+// @Description Simulate a strategy over stored stock ratings and daily prices: buy at the close of an upgrade session and sell after the holding period or, with upgrade_downgrade, on a downgrade. The capital is split equally between tickers. Returns the trades, the equity curve, total return, CAGR, max drawdown and hit rate
+// @Tags analysis
+// @Accept json
+// @Produce json
+// @Param tickers query string true "Comma-separated tickers (1-20), e.g. AAPL,MSFT"
+// @Param strategy query string false "upgrade_downgrade or upgrade_hold" default(upgrade_downgrade)
+// @Param holding_days query int false "Maximum calendar days per trade (1-365)" default(30)
+// @Param capital query number false "Initial capital" default(10000)
+// @Param date_from query string false "Start date (YYYY-MM-DD), one year before date_to by default"
+// @Param date_to query string false "End date (YYYY-MM-DD), today by default"
+// @Success 200 {object} response.APIResponse[response.BacktestResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Router /api/v1/analysis/backtest [get]
+func (h *BacktestHandler) RunBacktest(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	var req request.BacktestRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	result, err := h.backtestService.RunBacktest(ctx, &req)
+	if err != nil {
+		h.logger.Warn(ctx, "Backtest failed",
+			logger.String("request_id", requestID),
+			logger.String("tickers", req.Tickers),
+			logger.String("strategy", req.Strategy),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Company", "Failed to run backtest")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(result)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
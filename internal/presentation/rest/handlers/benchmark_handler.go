@@ -0,0 +1,255 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	serviceInterfaces "github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
+)
+
+// BenchmarkHandler maneja los índices de referencia (S&P 500, NASDAQ-100) y su sincronización
+type BenchmarkHandler struct {
+	benchmarkService serviceInterfaces.BenchmarkService
+	logger           logger.Logger
+}
+
+// NewBenchmarkHandler crea una nueva instancia del handler de benchmarks
+func NewBenchmarkHandler(benchmarkService serviceInterfaces.BenchmarkService, appLogger logger.Logger) *BenchmarkHandler {
+	return &BenchmarkHandler{
+		benchmarkService: benchmarkService,
+		logger:           appLogger,
+	}
+}
+
+// ListBenchmarks godoc
+// @Summary List benchmarks
+// @Description List the tracked market indices with the instrument fetched from the provider for each (SPX through SPY, NDX through QQQ) and when they were last synced
+// @Tags benchmarks
+// @Produce json
+// @Success 200 {object} response.APIResponse[[]response.BenchmarkResponse]
+// @Failure 500 {object} response.APIResponse[any]
+// @Router /api/v1/benchmarks [get]
+func (h *BenchmarkHandler) ListBenchmarks(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	benchmarks, err := h.benchmarkService.ListBenchmarks(ctx)
+	if err != nil {
+		h.logger.Warn(ctx, "Benchmark listing failed",
+			logger.String("request_id", requestID),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Benchmarks", "Failed to list benchmarks")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(benchmarks)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// GetBenchmarkValues godoc
+// @Summary Get benchmark values
+// @Description Get the stored daily values of a benchmark between two dates, oldest first, with the return over the range computed from the adjusted closes. The symbol may be the index (SPX) or its source instrument (SPY)
+// @Tags benchmarks
+// @Produce json
+// @Param symbol path string true "Benchmark symbol (SPX, NDX) or source symbol (SPY, QQQ)"
+// @Param from query string false "First date (YYYY-MM-DD, default one year before to)"
+// @Param to query string false "Last date (YYYY-MM-DD, default today)"
+// @Success 200 {object} response.APIResponse[response.BenchmarkValuesResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Router /api/v1/benchmarks/{symbol}/values [get]
+func (h *BenchmarkHandler) GetBenchmarkValues(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	symbol := c.Param("symbol")
+
+	var req request.BenchmarkValuesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		middleware.RespondWithError(c, middleware.ValidationErrorResponse(err))
+		return
+	}
+
+	values, err := h.benchmarkService.GetValues(ctx, symbol, &req)
+	if err != nil {
+		h.logger.Warn(ctx, "Benchmark values retrieval failed",
+			logger.String("request_id", requestID),
+			logger.String("benchmark", symbol),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Benchmark", "Failed to get benchmark values")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(values)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// GetBenchmarkConstituents godoc
+// @Summary Get benchmark constituents
+// @Description Get the members of a benchmark on a date, by weight descending. Memberships are kept with the date each symbol joined and left the index, so past compositions can be queried
+// @Tags benchmarks
+// @Produce json
+// @Param symbol path string true "Benchmark symbol (SPX, NDX) or source symbol (SPY, QQQ)"
+// @Param date query string false "Composition date (YYYY-MM-DD, default today)"
+// @Success 200 {object} response.APIResponse[response.BenchmarkConstituentsResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Router /api/v1/benchmarks/{symbol}/constituents [get]
+func (h *BenchmarkHandler) GetBenchmarkConstituents(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	symbol := c.Param("symbol")
+
+	var req request.BenchmarkConstituentsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		middleware.RespondWithError(c, middleware.ValidationErrorResponse(err))
+		return
+	}
+
+	constituents, err := h.benchmarkService.GetConstituents(ctx, symbol, &req)
+	if err != nil {
+		h.logger.Warn(ctx, "Benchmark constituents retrieval failed",
+			logger.String("request_id", requestID),
+			logger.String("benchmark", symbol),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Benchmark", "Failed to get benchmark constituents")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(constituents)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// CreateCustomBenchmark godoc
+// @Summary Create a custom benchmark
+// @Description Define an index from registered tickers, equal-weighted (rebalanced every session) or cap-weighted (by the current market caps, drifting with the prices), and compute its daily values from the stored prices since the base date, starting at 100. It can then be used like any other benchmark; its values are computed again by the scheduled benchmark_refresh job. The symbol cannot be the ticker of a registered company, and only the credential that creates the benchmark can delete it. Tickers without stored prices are left out with a warning
+// @Tags benchmarks
+// @Accept json
+// @Produce json
+// @Param benchmark body request.CreateCustomBenchmarkRequest true "Custom benchmark definition"
+// @Success 201 {object} response.APIResponse[response.BenchmarkSyncResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 401 {object} response.APIResponse[any]
+// @Failure 409 {object} response.APIResponse[any]
+// @Router /api/v1/benchmarks [post]
+func (h *BenchmarkHandler) CreateCustomBenchmark(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	var req request.CreateCustomBenchmarkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RespondWithError(c, middleware.ValidationErrorResponse(err))
+		return
+	}
+
+	result, err := h.benchmarkService.CreateCustom(ctx, &req)
+	if err != nil {
+		h.logger.Warn(ctx, "Custom benchmark creation failed",
+			logger.String("request_id", requestID),
+			logger.String("benchmark", req.Symbol),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Benchmark", "Failed to create custom benchmark")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(result)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusCreated, apiResponse)
+}
+
+// DeleteCustomBenchmark godoc
+// @Summary Delete a custom benchmark
+// @Description Delete a custom benchmark with its values and constituents. Only the credential that created it can delete it; benchmarks created without a tenant need the admin key. Provider benchmarks cannot be deleted
+// @Tags benchmarks
+// @Param symbol path string true "Custom benchmark symbol"
+// @Success 204
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 401 {object} response.APIResponse[any]
+// @Failure 403 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Router /api/v1/benchmarks/{symbol} [delete]
+func (h *BenchmarkHandler) DeleteCustomBenchmark(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	symbol := c.Param("symbol")
+
+	if err := h.benchmarkService.DeleteCustom(ctx, symbol); err != nil {
+		h.logger.Warn(ctx, "Custom benchmark deletion failed",
+			logger.String("request_id", requestID),
+			logger.String("benchmark", symbol),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Benchmark", "Failed to delete custom benchmark")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// SyncBenchmark godoc
+// @Summary Sync a benchmark from the provider
+// @Description Ingest the daily values (last 100 sessions, or the full history with full=true) and the current holdings of the instrument that replicates a benchmark from Alpha Vantage. Members that left the index are closed and new ones opened as of today; if the holdings cannot be fetched the stored composition is kept and a warning is returned. Custom benchmarks are computed again from the stored prices instead
+// @Tags admin
+// @Produce json
+// @Param symbol path string true "Benchmark symbol (SPX, NDX) or source symbol (SPY, QQQ)"
+// @Param full query bool false "Fetch the full history"
+// @Success 200 {object} response.APIResponse[response.BenchmarkSyncResponse]
+// @Failure 404 {object} response.APIResponse[any]
+// @Failure 429 {object} response.APIResponse[any]
+// @Failure 502 {object} response.APIResponse[any]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/admin/benchmarks/{symbol}/sync [post]
+func (h *BenchmarkHandler) SyncBenchmark(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	symbol := c.Param("symbol")
+
+	var req request.BenchmarkSyncRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		middleware.RespondWithError(c, middleware.ValidationErrorResponse(err))
+		return
+	}
+
+	result, err := h.benchmarkService.Sync(ctx, symbol, &req)
+	if err != nil {
+		h.logger.Warn(ctx, "Benchmark sync failed",
+			logger.String("request_id", requestID),
+			logger.String("benchmark", symbol),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Benchmark", "Failed to sync benchmark")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(result)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
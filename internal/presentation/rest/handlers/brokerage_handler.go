@@ -10,6 +10,7 @@ import (
 	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
 	serviceInterfaces "github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
 )
 
 // BrokerageHandler maneja los endpoints relacionados con brokerages
@@ -48,11 +49,8 @@ func (h *BrokerageHandler) CreateBrokerage(c *gin.Context) {
 			logger.String("error", err.Error()),
 		)
 
-		errorResp := response.ValidationFailed("Invalid request body")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -65,10 +63,7 @@ func (h *BrokerageHandler) CreateBrokerage(c *gin.Context) {
 		)
 
 		errorResp := response.ValidationFailed("Validation failed")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -82,10 +77,7 @@ func (h *BrokerageHandler) CreateBrokerage(c *gin.Context) {
 				logger.String("error", errorResp.Message),
 			)
 
-			apiResponse := errorResp.ToAPIResponse()
-			apiResponse.RequestID = requestID
-
-			c.JSON(errorResp.StatusCode, apiResponse)
+			middleware.RespondWithError(c, errorResp)
 			return
 		}
 
@@ -94,11 +86,8 @@ func (h *BrokerageHandler) CreateBrokerage(c *gin.Context) {
 			logger.String("name", req.Name),
 		)
 
-		errorResp := response.InternalServerError("Failed to create brokerage")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := response.FromError(err, "Brokerage", "Failed to create brokerage")
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -139,10 +128,7 @@ func (h *BrokerageHandler) GetBrokerageByID(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Invalid brokerage ID format")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -156,10 +142,7 @@ func (h *BrokerageHandler) GetBrokerageByID(c *gin.Context) {
 				logger.String("error", errorResp.Message),
 			)
 
-			apiResponse := errorResp.ToAPIResponse()
-			apiResponse.RequestID = requestID
-
-			c.JSON(errorResp.StatusCode, apiResponse)
+			middleware.RespondWithError(c, errorResp)
 			return
 		}
 
@@ -168,11 +151,8 @@ func (h *BrokerageHandler) GetBrokerageByID(c *gin.Context) {
 			logger.String("brokerage_id", brokerageID.String()),
 		)
 
-		errorResp := response.InternalServerError("Failed to retrieve brokerage")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := response.FromError(err, "Brokerage", "Failed to retrieve brokerage")
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -199,6 +179,7 @@ func (h *BrokerageHandler) GetBrokerageByID(c *gin.Context) {
 // @Success 200 {object} response.APIResponse[response.BrokerageResponse]
 // @Failure 400 {object} response.APIResponse[any]
 // @Failure 404 {object} response.APIResponse[any]
+// @Failure 409 {object} response.APIResponse[any] "Version mismatch"
 // @Failure 422 {object} response.APIResponse[any]
 // @Failure 500 {object} response.APIResponse[any]
 // @Router /api/v1/brokerages/{id} [put]
@@ -215,10 +196,7 @@ func (h *BrokerageHandler) UpdateBrokerage(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Invalid brokerage ID format")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -230,11 +208,8 @@ func (h *BrokerageHandler) UpdateBrokerage(c *gin.Context) {
 			logger.String("error", err.Error()),
 		)
 
-		errorResp := response.ValidationFailed("Invalid request body")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -247,10 +222,7 @@ func (h *BrokerageHandler) UpdateBrokerage(c *gin.Context) {
 		)
 
 		errorResp := response.ValidationFailed("Validation failed")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -264,10 +236,7 @@ func (h *BrokerageHandler) UpdateBrokerage(c *gin.Context) {
 				logger.String("error", errorResp.Message),
 			)
 
-			apiResponse := errorResp.ToAPIResponse()
-			apiResponse.RequestID = requestID
-
-			c.JSON(errorResp.StatusCode, apiResponse)
+			middleware.RespondWithError(c, errorResp)
 			return
 		}
 
@@ -276,11 +245,8 @@ func (h *BrokerageHandler) UpdateBrokerage(c *gin.Context) {
 			logger.String("brokerage_id", brokerageID.String()),
 		)
 
-		errorResp := response.InternalServerError("Failed to update brokerage")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := response.FromError(err, "Brokerage", "Failed to update brokerage")
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -321,10 +287,7 @@ func (h *BrokerageHandler) DeleteBrokerage(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Invalid brokerage ID format")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -338,10 +301,7 @@ func (h *BrokerageHandler) DeleteBrokerage(c *gin.Context) {
 				logger.String("error", errorResp.Message),
 			)
 
-			apiResponse := errorResp.ToAPIResponse()
-			apiResponse.RequestID = requestID
-
-			c.JSON(errorResp.StatusCode, apiResponse)
+			middleware.RespondWithError(c, errorResp)
 			return
 		}
 
@@ -350,11 +310,8 @@ func (h *BrokerageHandler) DeleteBrokerage(c *gin.Context) {
 			logger.String("brokerage_id", brokerageID.String()),
 		)
 
-		errorResp := response.InternalServerError("Failed to delete brokerage")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := response.FromError(err, "Brokerage", "Failed to delete brokerage")
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -377,6 +334,7 @@ func (h *BrokerageHandler) DeleteBrokerage(c *gin.Context) {
 // @Produce json
 // @Param name query string false "Filter by name (partial match)"
 // @Param is_active query boolean false "Filter by active status"
+// @Param sort query string false "Comma-separated field:asc|desc (name, country, created_at, updated_at)" default(name:asc)
 // @Param page query int false "Page number" default(1) minimum(1)
 // @Param limit query int false "Items per page" default(10) minimum(1) maximum(100)
 // @Success 200 {object} response.APIResponse[response.PaginatedResponse[response.BrokerageResponse]]
@@ -397,10 +355,7 @@ func (h *BrokerageHandler) ListBrokerages(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Invalid filter parameters")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -413,10 +368,7 @@ func (h *BrokerageHandler) ListBrokerages(c *gin.Context) {
 				logger.String("error", errorResp.Message),
 			)
 
-			apiResponse := errorResp.ToAPIResponse()
-			apiResponse.RequestID = requestID
-
-			c.JSON(errorResp.StatusCode, apiResponse)
+			middleware.RespondWithError(c, errorResp)
 			return
 		}
 
@@ -424,11 +376,8 @@ func (h *BrokerageHandler) ListBrokerages(c *gin.Context) {
 			logger.String("request_id", requestID),
 		)
 
-		errorResp := response.InternalServerError("Failed to list brokerages")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := response.FromError(err, "Brokerage", "Failed to list brokerages")
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -439,10 +388,7 @@ func (h *BrokerageHandler) ListBrokerages(c *gin.Context) {
 		logger.Int("per_page", paginatedBrokerages.Meta.PerPage),
 	)
 
-	apiResponse := response.Success(paginatedBrokerages)
-	apiResponse.RequestID = requestID
-
-	c.JSON(http.StatusOK, apiResponse)
+	middleware.RespondWithPage(c, response.Success(paginatedBrokerages))
 }
 
 // ListActiveBrokerages godoc
@@ -472,10 +418,7 @@ func (h *BrokerageHandler) ListActiveBrokerages(c *gin.Context) {
 				logger.String("error", errorResp.Message),
 			)
 
-			apiResponse := errorResp.ToAPIResponse()
-			apiResponse.RequestID = requestID
-
-			c.JSON(errorResp.StatusCode, apiResponse)
+			middleware.RespondWithError(c, errorResp)
 			return
 		}
 
@@ -483,11 +426,8 @@ func (h *BrokerageHandler) ListActiveBrokerages(c *gin.Context) {
 			logger.String("request_id", requestID),
 		)
 
-		errorResp := response.InternalServerError("Failed to list active brokerages")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := response.FromError(err, "Brokerage", "Failed to list active brokerages")
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -498,10 +438,7 @@ func (h *BrokerageHandler) ListActiveBrokerages(c *gin.Context) {
 		logger.Int("per_page", paginatedBrokerages.Meta.PerPage),
 	)
 
-	apiResponse := response.Success(paginatedBrokerages)
-	apiResponse.RequestID = requestID
-
-	c.JSON(http.StatusOK, apiResponse)
+	middleware.RespondWithPage(c, response.Success(paginatedBrokerages))
 }
 
 // ActivateBrokerage godoc
@@ -529,10 +466,7 @@ func (h *BrokerageHandler) ActivateBrokerage(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Invalid brokerage ID format")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -546,10 +480,7 @@ func (h *BrokerageHandler) ActivateBrokerage(c *gin.Context) {
 				logger.String("error", errorResp.Message),
 			)
 
-			apiResponse := errorResp.ToAPIResponse()
-			apiResponse.RequestID = requestID
-
-			c.JSON(errorResp.StatusCode, apiResponse)
+			middleware.RespondWithError(c, errorResp)
 			return
 		}
 
@@ -558,11 +489,8 @@ func (h *BrokerageHandler) ActivateBrokerage(c *gin.Context) {
 			logger.String("brokerage_id", brokerageID.String()),
 		)
 
-		errorResp := response.InternalServerError("Failed to activate brokerage")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := response.FromError(err, "Brokerage", "Failed to activate brokerage")
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -602,10 +530,7 @@ func (h *BrokerageHandler) DeactivateBrokerage(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Invalid brokerage ID format")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -619,10 +544,7 @@ func (h *BrokerageHandler) DeactivateBrokerage(c *gin.Context) {
 				logger.String("error", errorResp.Message),
 			)
 
-			apiResponse := errorResp.ToAPIResponse()
-			apiResponse.RequestID = requestID
-
-			c.JSON(errorResp.StatusCode, apiResponse)
+			middleware.RespondWithError(c, errorResp)
 			return
 		}
 
@@ -631,11 +553,8 @@ func (h *BrokerageHandler) DeactivateBrokerage(c *gin.Context) {
 			logger.String("brokerage_id", brokerageID.String()),
 		)
 
-		errorResp := response.InternalServerError("Failed to deactivate brokerage")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := response.FromError(err, "Brokerage", "Failed to deactivate brokerage")
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -674,10 +593,7 @@ func (h *BrokerageHandler) SearchBrokeragesByName(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Name parameter is required")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -694,10 +610,7 @@ func (h *BrokerageHandler) SearchBrokeragesByName(c *gin.Context) {
 				logger.String("error", errorResp.Message),
 			)
 
-			apiResponse := errorResp.ToAPIResponse()
-			apiResponse.RequestID = requestID
-
-			c.JSON(errorResp.StatusCode, apiResponse)
+			middleware.RespondWithError(c, errorResp)
 			return
 		}
 
@@ -706,11 +619,8 @@ func (h *BrokerageHandler) SearchBrokeragesByName(c *gin.Context) {
 			logger.String("name", name),
 		)
 
-		errorResp := response.InternalServerError("Failed to search brokerages")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := response.FromError(err, "Brokerage", "Failed to search brokerages")
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -722,16 +632,13 @@ func (h *BrokerageHandler) SearchBrokeragesByName(c *gin.Context) {
 		logger.Int("per_page", paginatedBrokerages.Meta.PerPage),
 	)
 
-	apiResponse := response.Success(paginatedBrokerages)
-	apiResponse.RequestID = requestID
-
-	c.JSON(http.StatusOK, apiResponse)
+	middleware.RespondWithPage(c, response.Success(paginatedBrokerages))
 }
 
 // parsePagination extrae y valida los parámetros de paginación
 func (h *BrokerageHandler) parsePagination(c *gin.Context) *response.PaginationRequest {
 	pageParam := c.Query("page")
 	perPageParam := c.Query("per_page")
-	
+
 	return response.ParsePaginationFromQuery(pageParam, perPageParam)
 }
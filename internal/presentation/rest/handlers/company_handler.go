@@ -1,7 +1,10 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -10,19 +13,31 @@ import (
 	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
 	serviceInterfaces "github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
 )
 
+// defaultSuggestLimit es el número de sugerencias devueltas si no se indica limit
+const defaultSuggestLimit = 10
+
 // CompanyHandler maneja los endpoints relacionados con companies
 type CompanyHandler struct {
-	companyService serviceInterfaces.CompanyService
-	logger         logger.Logger
+	companyService  serviceInterfaces.CompanyService
+	logoService     serviceInterfaces.CompanyLogoService
+	overviewService serviceInterfaces.CompanyOverviewService
+	noteService     serviceInterfaces.NoteService // Opcional: notas del usuario con ?include=notes
+	logoMaxAge      time.Duration
+	logger          logger.Logger
 }
 
 // NewCompanyHandler crea una nueva instancia del handler de companies
-func NewCompanyHandler(companyService serviceInterfaces.CompanyService, appLogger logger.Logger) *CompanyHandler {
+func NewCompanyHandler(companyService serviceInterfaces.CompanyService, logoService serviceInterfaces.CompanyLogoService, overviewService serviceInterfaces.CompanyOverviewService, noteService serviceInterfaces.NoteService, logoMaxAge time.Duration, appLogger logger.Logger) *CompanyHandler {
 	return &CompanyHandler{
-		companyService: companyService,
-		logger:         appLogger,
+		companyService:  companyService,
+		logoService:     logoService,
+		overviewService: overviewService,
+		noteService:     noteService,
+		logoMaxAge:      logoMaxAge,
+		logger:          appLogger,
 	}
 }
 
@@ -49,11 +64,8 @@ func (h *CompanyHandler) CreateCompany(c *gin.Context) {
 			logger.String("error", err.Error()),
 		)
 
-		errorResp := response.ValidationFailed("Invalid request body")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -72,11 +84,8 @@ func (h *CompanyHandler) CreateCompany(c *gin.Context) {
 			logger.String("ticker", req.Ticker),
 		)
 
-		errorResp := response.InternalServerError("Failed to create company")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := response.FromError(err, "Company", "Failed to create company")
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -99,6 +108,7 @@ func (h *CompanyHandler) CreateCompany(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Company ID"
+// @Param include query string false "notes: embed the private notes of the authenticated user on the company and its ratings"
 // @Success 200 {object} response.APIResponse[response.CompanyResponse]
 // @Failure 400 {object} response.APIResponse[any]
 // @Failure 404 {object} response.APIResponse[any]
@@ -117,10 +127,11 @@ func (h *CompanyHandler) GetCompanyByID(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Invalid company ID format")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+	withNotes, ok := h.parseIncludes(c)
+	if !ok {
 		return
 	}
 
@@ -137,11 +148,11 @@ func (h *CompanyHandler) GetCompanyByID(c *gin.Context) {
 			logger.String("company_id", companyID.String()),
 		)
 
-		errorResp := response.NotFound("Company")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := response.FromError(err, "Company", "Failed to get company")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+	if withNotes && !h.attachNotes(c, company) {
 		return
 	}
 
@@ -158,6 +169,7 @@ func (h *CompanyHandler) GetCompanyByID(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param ticker path string true "Company ticker symbol"
+// @Param include query string false "notes: embed the private notes of the authenticated user on the company and its ratings"
 // @Success 200 {object} response.APIResponse[response.CompanyResponse]
 // @Failure 400 {object} response.APIResponse[any]
 // @Failure 404 {object} response.APIResponse[any]
@@ -174,10 +186,11 @@ func (h *CompanyHandler) GetCompanyByTicker(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Ticker parameter is required")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+	withNotes, ok := h.parseIncludes(c)
+	if !ok {
 		return
 	}
 
@@ -194,11 +207,11 @@ func (h *CompanyHandler) GetCompanyByTicker(c *gin.Context) {
 			logger.String("ticker", ticker),
 		)
 
-		errorResp := response.NotFound("Company")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := response.FromError(err, "Company", "Failed to get company")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+	if withNotes && !h.attachNotes(c, company) {
 		return
 	}
 
@@ -219,6 +232,7 @@ func (h *CompanyHandler) GetCompanyByTicker(c *gin.Context) {
 // @Success 200 {object} response.APIResponse[response.CompanyResponse]
 // @Failure 400 {object} response.APIResponse[any]
 // @Failure 404 {object} response.APIResponse[any]
+// @Failure 409 {object} response.APIResponse[any] "Version mismatch"
 // @Failure 500 {object} response.APIResponse[any]
 // @Router /api/v1/companies/{id} [put]
 func (h *CompanyHandler) UpdateCompany(c *gin.Context) {
@@ -234,10 +248,7 @@ func (h *CompanyHandler) UpdateCompany(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Invalid company ID format")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -249,11 +260,8 @@ func (h *CompanyHandler) UpdateCompany(c *gin.Context) {
 			logger.String("error", err.Error()),
 		)
 
-		errorResp := response.ValidationFailed("Invalid request body")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -270,11 +278,8 @@ func (h *CompanyHandler) UpdateCompany(c *gin.Context) {
 			logger.String("company_id", companyID.String()),
 		)
 
-		errorResp := response.InternalServerError("Failed to update company")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := response.FromError(err, "Company", "Failed to update company")
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -314,10 +319,7 @@ func (h *CompanyHandler) DeleteCompany(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Invalid company ID format")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -334,11 +336,8 @@ func (h *CompanyHandler) DeleteCompany(c *gin.Context) {
 			logger.String("company_id", companyID.String()),
 		)
 
-		errorResp := response.InternalServerError("Failed to delete company")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := response.FromError(err, "Company", "Failed to delete company")
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -350,6 +349,162 @@ func (h *CompanyHandler) DeleteCompany(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// RestoreCompany godoc
+// @Summary Restore a deleted company
+// @Description Undo the soft delete of a company
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Param id path string true "Company ID"
+// @Success 200 {object} response.APIResponse[response.CompanyResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Failure 409 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Router /api/v1/companies/{id}/restore [post]
+func (h *CompanyHandler) RestoreCompany(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	idParam := c.Param("id")
+	companyID, err := uuid.Parse(idParam)
+	if err != nil {
+		h.logger.Warn(ctx, "Invalid company ID format",
+			logger.String("request_id", requestID),
+			logger.String("id", idParam),
+		)
+
+		errorResp := response.BadRequest("Invalid company ID format")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	h.logger.Info(ctx, "Restoring company",
+		logger.String("request_id", requestID),
+		logger.String("company_id", companyID.String()),
+	)
+
+	company, err := h.companyService.RestoreCompany(ctx, companyID)
+	if err != nil {
+		h.logger.Error(ctx, "Failed to restore company",
+			err,
+			logger.String("request_id", requestID),
+			logger.String("company_id", companyID.String()),
+		)
+
+		errorResp := response.FromError(err, "Deleted company", "Failed to restore company")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(company)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// RemapTicker godoc
+// @Summary Change a company ticker
+// @Description Rename the ticker of a company (e.g. FB -> META) keeping the previous one as an alias, so lookups and ratings under the old symbol resolve to the same company (admin)
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Param id path string true "Company ID"
+// @Param request body request.RemapTickerRequest true "New ticker"
+// @Success 200 {object} response.APIResponse[response.CompanyResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Failure 409 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Router /api/v1/companies/{id}/remap-ticker [post]
+func (h *CompanyHandler) RemapTicker(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	idParam := c.Param("id")
+	companyID, err := uuid.Parse(idParam)
+	if err != nil {
+		h.logger.Warn(ctx, "Invalid company ID format",
+			logger.String("request_id", requestID),
+			logger.String("id", idParam),
+		)
+
+		errorResp := response.BadRequest("Invalid company ID format")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	var req request.RemapTickerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn(ctx, "Invalid request body for ticker remap",
+			logger.String("request_id", requestID),
+			logger.String("company_id", companyID.String()),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	h.logger.Info(ctx, "Remapping company ticker",
+		logger.String("request_id", requestID),
+		logger.String("company_id", companyID.String()),
+		logger.String("new_ticker", req.Ticker),
+	)
+
+	company, err := h.companyService.RemapTicker(ctx, companyID, &req)
+	if err != nil {
+		h.logger.Error(ctx, "Failed to remap company ticker",
+			err,
+			logger.String("request_id", requestID),
+			logger.String("company_id", companyID.String()),
+		)
+
+		errorResp := response.FromError(err, "Company", "Failed to remap company ticker")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(company)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// ListDeletedCompanies godoc
+// @Summary List deleted companies
+// @Description Get a paginated list of soft-deleted companies that can be restored (admin)
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(20)
+// @Success 200 {object} response.APIResponse[response.PaginatedResponse[response.DeletedCompanyResponse]]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Router /api/v1/companies/deleted [get]
+func (h *CompanyHandler) ListDeletedCompanies(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	pagination := h.parsePagination(c)
+
+	companies, err := h.companyService.ListDeletedCompanies(ctx, pagination)
+	if err != nil {
+		h.logger.Error(ctx, "Failed to list deleted companies",
+			err,
+			logger.String("request_id", requestID),
+		)
+
+		errorResp := response.FromError(err, "Company", "Failed to list deleted companies")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	middleware.RespondWithPage(c, response.Success(companies))
+}
+
 // ListCompanies godoc
 // @Summary List companies with filtering and pagination
 // @Description Get a paginated list of companies with optional filters
@@ -363,6 +518,8 @@ func (h *CompanyHandler) DeleteCompany(c *gin.Context) {
 // @Param sector query string false "Filter by sector"
 // @Param exchange query string false "Filter by exchange"
 // @Param is_active query bool false "Filter by active status"
+// @Param tags query string false "Comma-separated tag slugs; only companies with all of them"
+// @Param sort query string false "Comma-separated field:asc|desc (ticker, name, sector, exchange, market_cap, created_at, updated_at)" default(ticker:asc)
 // @Success 200 {object} response.APIResponse[response.PaginatedResponse[response.CompanyListResponse]]
 // @Failure 400 {object} response.APIResponse[any]
 // @Failure 500 {object} response.APIResponse[any]
@@ -383,10 +540,7 @@ func (h *CompanyHandler) ListCompanies(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Invalid query parameters")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -405,18 +559,12 @@ func (h *CompanyHandler) ListCompanies(c *gin.Context) {
 			logger.String("request_id", requestID),
 		)
 
-		errorResp := response.InternalServerError("Failed to list companies")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := response.FromError(err, "Company", "Failed to list companies")
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
-	apiResponse := response.Success(companies)
-	apiResponse.RequestID = requestID
-
-	c.JSON(http.StatusOK, apiResponse)
+	middleware.RespondWithPage(c, response.Success(companies))
 }
 
 // ListActiveCompanies godoc
@@ -450,18 +598,12 @@ func (h *CompanyHandler) ListActiveCompanies(c *gin.Context) {
 			logger.String("request_id", requestID),
 		)
 
-		errorResp := response.InternalServerError("Failed to list active companies")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := response.FromError(err, "Company", "Failed to list active companies")
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
-	apiResponse := response.Success(companies)
-	apiResponse.RequestID = requestID
-
-	c.JSON(http.StatusOK, apiResponse)
+	middleware.RespondWithPage(c, response.Success(companies))
 }
 
 // ActivateCompany godoc
@@ -489,10 +631,7 @@ func (h *CompanyHandler) ActivateCompany(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Invalid company ID format")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -509,11 +648,8 @@ func (h *CompanyHandler) ActivateCompany(c *gin.Context) {
 			logger.String("company_id", companyID.String()),
 		)
 
-		errorResp := response.InternalServerError("Failed to activate company")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := response.FromError(err, "Company", "Failed to activate company")
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -553,10 +689,7 @@ func (h *CompanyHandler) DeactivateCompany(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Invalid company ID format")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -573,11 +706,8 @@ func (h *CompanyHandler) DeactivateCompany(c *gin.Context) {
 			logger.String("company_id", companyID.String()),
 		)
 
-		errorResp := response.InternalServerError("Failed to deactivate company")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := response.FromError(err, "Company", "Failed to deactivate company")
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -616,10 +746,7 @@ func (h *CompanyHandler) SearchCompaniesByName(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Name parameter is required")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -640,15 +767,152 @@ func (h *CompanyHandler) SearchCompaniesByName(c *gin.Context) {
 			logger.String("name", name),
 		)
 
-		errorResp := response.InternalServerError("Failed to search companies")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
+		errorResp := response.FromError(err, "Company", "Failed to search companies")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
 
-		c.JSON(errorResp.StatusCode, apiResponse)
+	middleware.RespondWithPage(c, response.Success(companies))
+}
+
+// GetCompanyLogo godoc
+// @Summary Get a company logo
+// @Description Serve the company logo from the API origin: the provider image is downloaded once, resized to fit in size pixels (PNG; SVG logos are served as is, with a sandboxing Content-Security-Policy) and cached on disk. The size is rounded up to the next power of two from 16, capped at LOGO_MAX_SIZE. Responses carry an ETag and a long Cache-Control max-age
+// @Tags companies
+// @Produce png
+// @Param ticker path string true "Company ticker symbol"
+// @Param size query int false "Maximum side in pixels (16 to LOGO_MAX_SIZE)" default(128)
+// @Success 200 {file} binary
+// @Success 304
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Failure 502 {object} response.APIResponse[any]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/companies/{ticker}/logo [get]
+func (h *CompanyHandler) GetCompanyLogo(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	if h.logoService == nil {
+		errorResp := response.ServiceUnavailable("Company logos are not configured")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	var req request.CompanyLogoRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	// La ruta comparte el parámetro :id con el resto de rutas de companies; aquí es el ticker
+	ticker := c.Param("id")
+	logo, err := h.logoService.GetLogo(ctx, ticker, req.Size)
+	if err != nil {
+		h.logger.Warn(ctx, "Failed to get company logo",
+			logger.String("request_id", requestID),
+			logger.String("ticker", ticker),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Company", "Failed to get company logo")
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
-	apiResponse := response.Success(companies)
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(h.logoMaxAge.Seconds())))
+	c.Header("ETag", logo.ETag)
+	c.Header("Cross-Origin-Resource-Policy", "cross-origin")
+	c.Header("X-Content-Type-Options", "nosniff")
+	if logo.ContentType == "image/svg+xml" {
+		// Un SVG abierto directamente es un documento: sin scripts, estilos externos ni navegación
+		c.Header("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; sandbox")
+	}
+	if c.GetHeader("If-None-Match") == logo.ETag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(http.StatusOK, logo.ContentType, logo.Data)
+}
+
+// GetCompanyOverview godoc
+// @Summary Get a company overview
+// @Description Return everything the company detail page needs in one call: the company, its provider profile, latest quote, basic financials, 5 most recent ratings and last week's news. Sections are gathered concurrently; a section that fails or times out is omitted and listed in unavailable with the reason
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Param ticker path string true "Company ticker symbol"
+// @Success 200 {object} response.APIResponse[response.CompanyOverviewResponse]
+// @Failure 404 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Router /api/v1/companies/{ticker}/overview [get]
+func (h *CompanyHandler) GetCompanyOverview(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	// Igual que el logo: el parámetro :id de la ruta es el ticker
+	ticker := c.Param("id")
+	overview, err := h.overviewService.GetOverview(ctx, ticker)
+	if err != nil {
+		h.logger.Error(ctx, "Failed to get company overview",
+			err,
+			logger.String("request_id", requestID),
+			logger.String("ticker", ticker),
+		)
+
+		errorResp := response.FromError(err, "Company", "Failed to get company overview")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(overview)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// SuggestCompanies godoc
+// @Summary Suggest companies (typeahead)
+// @Description Return ticker and name pairs of active companies whose ticker starts with q (shortest tickers first), followed by those whose name starts with q. Results are cached
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Param q query string true "Ticker or name prefix (1-20 characters)"
+// @Param limit query int false "Maximum number of suggestions (1-25)" default(10)
+// @Success 200 {object} response.APIResponse[[]response.CompanySuggestionResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Router /api/v1/companies/suggest [get]
+func (h *CompanyHandler) SuggestCompanies(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	var req request.CompanySuggestRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultSuggestLimit
+	}
+
+	suggestions, err := h.companyService.SuggestCompanies(ctx, req.Query, req.Limit)
+	if err != nil {
+		h.logger.Error(ctx, "Failed to suggest companies",
+			err,
+			logger.String("request_id", requestID),
+			logger.String("q", req.Query),
+		)
+
+		errorResp := response.FromError(err, "Company", "Failed to suggest companies")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(suggestions)
 	apiResponse.RequestID = requestID
 
 	c.JSON(http.StatusOK, apiResponse)
@@ -678,10 +942,7 @@ func (h *CompanyHandler) GetCompaniesBySector(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Sector parameter is required")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -702,18 +963,12 @@ func (h *CompanyHandler) GetCompaniesBySector(c *gin.Context) {
 			logger.String("sector", sector),
 		)
 
-		errorResp := response.InternalServerError("Failed to get companies by sector")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := response.FromError(err, "Company", "Failed to get companies by sector")
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
-	apiResponse := response.Success(companies)
-	apiResponse.RequestID = requestID
-
-	c.JSON(http.StatusOK, apiResponse)
+	middleware.RespondWithPage(c, response.Success(companies))
 }
 
 // UpdateMarketCap godoc
@@ -740,10 +995,7 @@ func (h *CompanyHandler) UpdateMarketCap(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Ticker parameter is required")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -755,11 +1007,8 @@ func (h *CompanyHandler) UpdateMarketCap(c *gin.Context) {
 			logger.String("error", err.Error()),
 		)
 
-		errorResp := response.ValidationFailed("Invalid request body")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -771,10 +1020,7 @@ func (h *CompanyHandler) UpdateMarketCap(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Valid market_cap field is required")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -792,11 +1038,8 @@ func (h *CompanyHandler) UpdateMarketCap(c *gin.Context) {
 			logger.String("ticker", ticker),
 		)
 
-		errorResp := response.InternalServerError("Failed to update market cap")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := response.FromError(err, "Company", "Failed to update market cap")
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -811,10 +1054,47 @@ func (h *CompanyHandler) UpdateMarketCap(c *gin.Context) {
 	c.JSON(http.StatusOK, apiResponse)
 }
 
+// parseIncludes lee ?include=notes de una company; si no es válido (o no hay credencial de un usuario)
+// responde con el error y devuelve false
+func (h *CompanyHandler) parseIncludes(c *gin.Context) (bool, bool) {
+	withNotes := false
+	for _, part := range strings.Split(c.Query("include"), ",") {
+		switch strings.ToLower(strings.TrimSpace(part)) {
+		case "":
+		case "notes":
+			withNotes = true
+		default:
+			middleware.RespondWithError(c, response.ValidationFailedWithFields([]response.ValidationError{
+				{Field: "include", Tag: "oneof", Message: "Must be one of: notes", Value: c.Query("include")},
+			}))
+			return false, false
+		}
+	}
+	if withNotes && !requireNoteOwner(c) {
+		return false, false
+	}
+	return withNotes, true
+}
+
+// attachNotes añade a la company las notas del usuario; si fallan responde con el error y devuelve false
+func (h *CompanyHandler) attachNotes(c *gin.Context, company *response.CompanyResponse) bool {
+	if h.noteService == nil {
+		return true
+	}
+
+	notes, err := h.noteService.GetCompanyNotes(c.Request.Context(), company.ID)
+	if err != nil {
+		middleware.RespondWithError(c, response.FromError(err, "Note", "Failed to get notes"))
+		return false
+	}
+	company.Notes = notes
+	return true
+}
+
 // parsePagination extrae y valida los parámetros de paginación
 func (h *CompanyHandler) parsePagination(c *gin.Context) *response.PaginationRequest {
 	pageParam := c.Query("page")
 	perPageParam := c.Query("per_page")
-	
+
 	return response.ParsePaginationFromQuery(pageParam, perPageParam)
 }
@@ -0,0 +1,260 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	serviceInterfaces "github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
+)
+
+// CompanyRelationshipHandler maneja las relaciones entre companies y su grafo
+type CompanyRelationshipHandler struct {
+	relationshipService serviceInterfaces.CompanyRelationshipService
+	logger              logger.Logger
+}
+
+// NewCompanyRelationshipHandler crea una nueva instancia del handler de relaciones entre companies
+func NewCompanyRelationshipHandler(relationshipService serviceInterfaces.CompanyRelationshipService, appLogger logger.Logger) *CompanyRelationshipHandler {
+	return &CompanyRelationshipHandler{
+		relationshipService: relationshipService,
+		logger:              appLogger,
+	}
+}
+
+// ListCompanyRelationships godoc
+// @Summary List company relationships
+// @Description List the supplier, competitor and subsidiary relationships declared from or to a company, oldest first. Direction tells whether the company is the one the relationship was declared from (outgoing) or the related one (incoming)
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Param id path string true "Company ID or ticker"
+// @Param type query string false "Relationship type" Enums(supplier, competitor, subsidiary)
+// @Success 200 {object} response.APIResponse[response.CompanyRelationshipsResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Router /api/v1/companies/{id}/relationships [get]
+func (h *CompanyRelationshipHandler) ListCompanyRelationships(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	ref := c.Param("id")
+
+	var req request.CompanyRelationshipsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		middleware.RespondWithError(c, middleware.ValidationErrorResponse(err))
+		return
+	}
+
+	relationships, err := h.relationshipService.ListRelationships(ctx, ref, &req)
+	if err != nil {
+		h.logger.Warn(ctx, "Company relationships retrieval failed",
+			logger.String("request_id", requestID),
+			logger.String("company", ref),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Company", "Failed to get company relationships")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(relationships)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// CreateCompanyRelationship godoc
+// @Summary Create a company relationship
+// @Description Declare that another company (by ID or ticker) is a supplier, competitor or subsidiary of the company. A company cannot be related to itself, the same relationship cannot be declared twice, and competition is symmetric, so it is declared only once per pair
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Param id path string true "Company ID or ticker"
+// @Param relationship body request.CreateCompanyRelationshipRequest true "Relationship"
+// @Success 201 {object} response.APIResponse[response.CompanyRelationshipResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Failure 409 {object} response.APIResponse[any]
+// @Router /api/v1/companies/{id}/relationships [post]
+func (h *CompanyRelationshipHandler) CreateCompanyRelationship(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	ref := c.Param("id")
+
+	var req request.CreateCompanyRelationshipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RespondWithError(c, middleware.ValidationErrorResponse(err))
+		return
+	}
+
+	relationship, err := h.relationshipService.CreateRelationship(ctx, ref, &req)
+	if err != nil {
+		h.logger.Warn(ctx, "Company relationship creation failed",
+			logger.String("request_id", requestID),
+			logger.String("company", ref),
+			logger.String("related_company", req.RelatedCompany),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Company", "Failed to create company relationship")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(relationship)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusCreated, apiResponse)
+}
+
+// UpdateCompanyRelationship godoc
+// @Summary Update a company relationship
+// @Description Change the type or the note of a relationship of the company
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Param id path string true "Company ID or ticker"
+// @Param relationship_id path string true "Relationship ID"
+// @Param relationship body request.UpdateCompanyRelationshipRequest true "Fields to change"
+// @Success 200 {object} response.APIResponse[response.CompanyRelationshipResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Failure 409 {object} response.APIResponse[any]
+// @Router /api/v1/companies/{id}/relationships/{relationship_id} [put]
+func (h *CompanyRelationshipHandler) UpdateCompanyRelationship(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	ref := c.Param("id")
+
+	relationshipID, ok := h.relationshipID(c)
+	if !ok {
+		return
+	}
+
+	var req request.UpdateCompanyRelationshipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RespondWithError(c, middleware.ValidationErrorResponse(err))
+		return
+	}
+
+	relationship, err := h.relationshipService.UpdateRelationship(ctx, ref, relationshipID, &req)
+	if err != nil {
+		h.logger.Warn(ctx, "Company relationship update failed",
+			logger.String("request_id", requestID),
+			logger.String("company", ref),
+			logger.String("relationship_id", relationshipID.String()),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Relationship", "Failed to update company relationship")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(relationship)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// DeleteCompanyRelationship godoc
+// @Summary Delete a company relationship
+// @Description Delete a relationship of the company
+// @Tags companies
+// @Param id path string true "Company ID or ticker"
+// @Param relationship_id path string true "Relationship ID"
+// @Success 204
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Router /api/v1/companies/{id}/relationships/{relationship_id} [delete]
+func (h *CompanyRelationshipHandler) DeleteCompanyRelationship(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	ref := c.Param("id")
+
+	relationshipID, ok := h.relationshipID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.relationshipService.DeleteRelationship(ctx, ref, relationshipID); err != nil {
+		h.logger.Warn(ctx, "Company relationship deletion failed",
+			logger.String("request_id", requestID),
+			logger.String("company", ref),
+			logger.String("relationship_id", relationshipID.String()),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Relationship", "Failed to delete company relationship")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetCompanyGraph godoc
+// @Summary Get the relationship graph of a company
+// @Description Get the companies reachable from a company through its relationships in either direction, up to depth hops away (2 by default, at most 3), with the relationships between them. Each node carries its distance to the company. The graph stops growing at 200 companies and is then marked as truncated
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Param id path string true "Company ID or ticker"
+// @Param depth query int false "Hops from the company (1-3)" default(2)
+// @Param types query string false "Comma-separated relationship types to follow (supplier, competitor, subsidiary); all by default"
+// @Success 200 {object} response.APIResponse[response.CompanyGraphResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Router /api/v1/companies/{id}/graph [get]
+func (h *CompanyRelationshipHandler) GetCompanyGraph(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	ref := c.Param("id")
+
+	var req request.CompanyGraphRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		middleware.RespondWithError(c, middleware.ValidationErrorResponse(err))
+		return
+	}
+
+	graph, err := h.relationshipService.GetGraph(ctx, ref, &req)
+	if err != nil {
+		h.logger.Warn(ctx, "Company graph retrieval failed",
+			logger.String("request_id", requestID),
+			logger.String("company", ref),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Company", "Failed to get company graph")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(graph)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// relationshipID lee el ID de la relación de la ruta y responde 400 si no es válido
+func (h *CompanyRelationshipHandler) relationshipID(c *gin.Context) (uuid.UUID, bool) {
+	idParam := c.Param("relationship_id")
+	relationshipID, err := uuid.Parse(idParam)
+	if err != nil {
+		h.logger.Warn(c.Request.Context(), "Invalid relationship ID format",
+			logger.String("request_id", c.GetString("request_id")),
+			logger.String("id", idParam),
+		)
+
+		errorResp := response.BadRequest("Invalid relationship ID format")
+		middleware.RespondWithError(c, errorResp)
+		return uuid.Nil, false
+	}
+	return relationshipID, true
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	serviceInterfaces "github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
+)
+
+// DashboardHandler maneja el resumen de la pantalla de inicio
+type DashboardHandler struct {
+	dashboardService serviceInterfaces.DashboardService
+	logger           logger.Logger
+}
+
+// NewDashboardHandler crea una nueva instancia del handler del dashboard
+func NewDashboardHandler(dashboardService serviceInterfaces.DashboardService, appLogger logger.Logger) *DashboardHandler {
+	return &DashboardHandler{
+		dashboardService: dashboardService,
+		logger:           appLogger,
+	}
+}
+
+// GetDashboard godoc
+// @Summary Get the home screen summary
+// @Description Return in one call the market overview, top gainers and losers, today's upgrades and downgrades (in the request time zone), trending symbols and the number of anomalies detected since alerts_since. The shared sections are cached (CACHE_TTL_DASHBOARD); a section that fails or times out is omitted and listed in unavailable with the reason
+// @Tags dashboard
+// @Accept json
+// @Produce json
+// @Param alerts_since query string false "Client's last visit (RFC 3339); defaults to the start of today"
+// @Param tz query string false "IANA time zone that defines today"
+// @Success 200 {object} response.APIResponse[response.DashboardResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Router /api/v1/dashboard [get]
+func (h *DashboardHandler) GetDashboard(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	var req request.DashboardRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	dashboard, err := h.dashboardService.GetDashboard(ctx, &req)
+	if err != nil {
+		h.logger.Warn(ctx, "Failed to get dashboard",
+			logger.String("request_id", requestID),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Dashboard", "Failed to get dashboard")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(dashboard)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
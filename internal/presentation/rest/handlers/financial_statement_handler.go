@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	serviceInterfaces "github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
+)
+
+// FinancialStatementHandler maneja los estados financieros publicados de una company
+type FinancialStatementHandler struct {
+	statementService serviceInterfaces.FinancialStatementService
+	logger           logger.Logger
+}
+
+// NewFinancialStatementHandler crea una nueva instancia del handler de estados financieros
+func NewFinancialStatementHandler(statementService serviceInterfaces.FinancialStatementService, appLogger logger.Logger) *FinancialStatementHandler {
+	return &FinancialStatementHandler{
+		statementService: statementService,
+		logger:           appLogger,
+	}
+}
+
+// GetCompanyFinancials godoc
+// @Summary Get company financial statements
+// @Description Get the latest reported periods of the income statement, balance sheet or cash flow of a company, newest first, with every amount parsed and the YoY (and, for quarterly periods, QoQ) growth rates and margins computed server-side as fractions. Statements are stored and fetched again from Alpha Vantage once they are older than CACHE_TTL_FINANCIAL_STATEMENTS; if the provider fails the stored ones are served with stale=true
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Param ticker path string true "Company ticker"
+// @Param statement query string false "income (default), balance or cash_flow"
+// @Param period query string false "quarterly (default) or annual"
+// @Param limit query int false "Periods returned (1-40, default 8)"
+// @Success 200 {object} response.APIResponse[response.FinancialStatementsResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 402 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Failure 502 {object} response.APIResponse[any]
+// @Router /api/v1/companies/{ticker}/financials [get]
+func (h *FinancialStatementHandler) GetCompanyFinancials(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	// La ruta comparte el segmento :id con el resto de /companies/:id (gin exige el mismo nombre), pero recibe un ticker
+	ticker := c.Param("id")
+
+	var req request.FinancialStatementRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		middleware.RespondWithError(c, middleware.ValidationErrorResponse(err))
+		return
+	}
+
+	statements, err := h.statementService.GetStatements(ctx, ticker, &req)
+	if err != nil {
+		h.logger.Warn(ctx, "Financial statements retrieval failed",
+			logger.String("request_id", requestID),
+			logger.String("ticker", ticker),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Company", "Failed to get financial statements")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(statements)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
@@ -20,17 +20,27 @@ type HealthHandler struct {
 	config       *config.Config
 	logger       logger.Logger
 	cacheService domainServices.CacheService
+	database     *cockroachdb.DB // Conexión compartida; si es nil cada check abre una propia
 }
 
 // NewHealthHandler crea una nueva instancia del handler de health
-func NewHealthHandler(cfg *config.Config, appLogger logger.Logger, cache domainServices.CacheService) *HealthHandler {
+func NewHealthHandler(cfg *config.Config, appLogger logger.Logger, cache domainServices.CacheService, database *cockroachdb.DB) *HealthHandler {
 	return &HealthHandler{
 		config:       cfg,
 		logger:       appLogger,
 		cacheService: cache,
+		database:     database,
 	}
 }
 
+// primaryCachePinger lo implementan las caches con fallback para saber si el backend compartido (Redis) responde
+type primaryCachePinger interface {
+	PingPrimary(ctx context.Context) error
+}
+
+// Componentes sin los que la instancia no debe recibir tráfico
+var readinessComponents = []string{"database", "migrations", "cache"}
+
 // HealthStatus representa el estado de salud de un componente
 type HealthStatus string
 
@@ -46,6 +56,7 @@ type ComponentHealth struct {
 	Message     string                 `json:"message,omitempty"`
 	LastChecked time.Time              `json:"last_checked"`
 	Duration    time.Duration          `json:"duration"`
+	LatencyMs   int64                  `json:"latency_ms"`
 	Details     map[string]interface{} `json:"details,omitempty"`
 }
 
@@ -65,17 +76,18 @@ var (
 
 // Liveness godoc
 // @Summary Liveness probe
-// @Description Check if the application is alive and running
+// @Description Check if the application process is alive. It never checks dependencies, so a slow database or cache does not get the pod restarted
 // @Tags health
 // @Accept json
 // @Produce json
 // @Success 200 {object} response.APIResponse[map[string]interface{}]
+// @Router /healthz [get]
 // @Router /health/live [get]
 func (h *HealthHandler) Liveness(c *gin.Context) {
 	ctx := c.Request.Context()
 	requestID := c.GetString("request_id")
 
-	h.logger.Info(ctx, "Liveness check requested",
+	h.logger.Debug(ctx, "Liveness check requested",
 		logger.String("request_id", requestID),
 	)
 
@@ -95,32 +107,34 @@ func (h *HealthHandler) Liveness(c *gin.Context) {
 
 // Readiness godoc
 // @Summary Readiness probe
-// @Description Check if the application is ready to serve traffic
+// @Description Check if the database, schema migrations and cache are ready. External APIs are not checked so a provider outage does not take the pod out of the load balancer
 // @Tags health
 // @Accept json
 // @Produce json
 // @Success 200 {object} response.APIResponse[OverallHealth]
 // @Success 503 {object} response.APIResponse[OverallHealth]
+// @Router /readyz [get]
 // @Router /health/ready [get]
 func (h *HealthHandler) Readiness(c *gin.Context) {
 	ctx := c.Request.Context()
 	requestID := c.GetString("request_id")
 
-	h.logger.Info(ctx, "Readiness check requested",
+	h.logger.Debug(ctx, "Readiness check requested",
 		logger.String("request_id", requestID),
 	)
 
-	health := h.performHealthChecks(ctx)
+	health := h.performReadinessChecks(ctx)
 
 	apiResponse := response.Success(health)
 	apiResponse.RequestID = requestID
 
-	// Determine HTTP status based on overall health
+	// Degraded (p.ej. Redis caído pero con fallback en memoria) sigue recibiendo tráfico
 	statusCode := http.StatusOK
 	if health.Status == HealthStatusUnhealthy {
+		h.logger.Warn(ctx, "Instance not ready to serve traffic",
+			logger.String("request_id", requestID),
+		)
 		statusCode = http.StatusServiceUnavailable
-	} else if health.Status == HealthStatusDegraded {
-		statusCode = http.StatusOK // Still return 200 for degraded but log warnings
 	}
 
 	c.JSON(statusCode, apiResponse)
@@ -128,7 +142,7 @@ func (h *HealthHandler) Readiness(c *gin.Context) {
 
 // Health godoc
 // @Summary Comprehensive health check
-// @Description Get detailed health information about all system components
+// @Description Get per-dependency status and latency (database, migrations, cache, Finnhub, Alpha Vantage and the stock API)
 // @Tags health
 // @Accept json
 // @Produce json
@@ -175,26 +189,53 @@ func (h *HealthHandler) Health(c *gin.Context) {
 func (h *HealthHandler) performHealthChecks(ctx context.Context) *OverallHealth {
 	components := make(map[string]*ComponentHealth)
 
-	// Check database
-	components["database"] = h.checkDatabase(ctx)
+	// Check database and schema migrations (share the same connection)
+	dbHealth, migrationsHealth := h.checkDatabase(ctx)
+	components["database"] = dbHealth
+	if migrationsHealth != nil {
+		components["migrations"] = migrationsHealth
+	}
 
 	// Check cache (if configured)
 	if h.cacheService != nil {
 		components["cache"] = h.checkCache(ctx)
 	}
 
-	// Check external APIs
-	components["external_api_primary"] = h.checkExternalAPI(ctx, h.config.External.Primary)
-	components["external_api_secondary"] = h.checkExternalAPI(ctx, h.config.External.Secondary)
+	// Check external market data providers
+	components["finnhub"] = h.checkExternalAPI(ctx, h.config.External.Primary)
+	components["alpha_vantage"] = h.checkExternalAPI(ctx, h.config.External.Secondary)
 
 	// Check third-party stock API
 	components["stock_api"] = h.checkStockAPI(ctx)
 
-	// Determine overall status
-	overallStatus := h.determineOverallStatus(components)
+	return h.buildOverallHealth(components, h.determineOverallStatus(components))
+}
+
+// performReadinessChecks verifica solo las dependencias necesarias para servir tráfico
+func (h *HealthHandler) performReadinessChecks(ctx context.Context) *OverallHealth {
+	components := make(map[string]*ComponentHealth)
+
+	dbHealth, migrationsHealth := h.checkDatabase(ctx)
+	components["database"] = dbHealth
+	if migrationsHealth != nil {
+		components["migrations"] = migrationsHealth
+	}
+
+	if h.cacheService != nil {
+		components["cache"] = h.checkCache(ctx)
+	}
+
+	return h.buildOverallHealth(components, determineReadinessStatus(components))
+}
+
+// buildOverallHealth arma la respuesta común y expone la latencia de cada componente en milisegundos
+func (h *HealthHandler) buildOverallHealth(components map[string]*ComponentHealth, status HealthStatus) *OverallHealth {
+	for _, component := range components {
+		component.LatencyMs = component.Duration.Milliseconds()
+	}
 
 	return &OverallHealth{
-		Status:      overallStatus,
+		Status:      status,
 		Version:     h.config.RESTAPI.Version,
 		Timestamp:   time.Now(),
 		Uptime:      time.Since(startTime),
@@ -203,25 +244,49 @@ func (h *HealthHandler) performHealthChecks(ctx context.Context) *OverallHealth
 	}
 }
 
-// checkDatabase verifica la conectividad con la base de datos
-func (h *HealthHandler) checkDatabase(ctx context.Context) *ComponentHealth {
+// determineReadinessStatus: cualquier componente requerido unhealthy deja la instancia fuera del balanceador
+func determineReadinessStatus(components map[string]*ComponentHealth) HealthStatus {
+	status := HealthStatusHealthy
+	for _, name := range readinessComponents {
+		component, exists := components[name]
+		if !exists {
+			continue
+		}
+		switch component.Status {
+		case HealthStatusUnhealthy:
+			return HealthStatusUnhealthy
+		case HealthStatusDegraded:
+			status = HealthStatusDegraded
+		}
+	}
+	return status
+}
+
+// checkDatabase verifica la conectividad con la base de datos y, si hay conexión, el estado de las migraciones
+func (h *HealthHandler) checkDatabase(ctx context.Context) (*ComponentHealth, *ComponentHealth) {
 	start := time.Now()
 
 	// Create a timeout context for the database check
 	checkCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	db, err := cockroachdb.NewConnection(h.config)
-	if err != nil {
-		return &ComponentHealth{
-			Status:      HealthStatusUnhealthy,
-			Message:     "Failed to connect to database",
-			LastChecked: time.Now(),
-			Duration:    time.Since(start),
-			Details: map[string]interface{}{
-				"error": err.Error(),
-			},
+	// Reutilizar el pool de la aplicación; abrir una conexión solo si no se inyectó
+	db := h.database
+	if db == nil {
+		conn, err := cockroachdb.NewConnection(h.config)
+		if err != nil {
+			return &ComponentHealth{
+				Status:      HealthStatusUnhealthy,
+				Message:     "Failed to connect to database",
+				LastChecked: time.Now(),
+				Duration:    time.Since(start),
+				Details: map[string]interface{}{
+					"error": err.Error(),
+				},
+			}, nil
 		}
+		defer conn.Close()
+		db = conn
 	}
 
 	// Test the connection with a simple query
@@ -235,7 +300,7 @@ func (h *HealthHandler) checkDatabase(ctx context.Context) *ComponentHealth {
 			Details: map[string]interface{}{
 				"error": err.Error(),
 			},
-		}
+		}, nil
 	}
 
 	if err := sqlDB.PingContext(checkCtx); err != nil {
@@ -247,20 +312,66 @@ func (h *HealthHandler) checkDatabase(ctx context.Context) *ComponentHealth {
 			Details: map[string]interface{}{
 				"error": err.Error(),
 			},
-		}
+		}, nil
 	}
 
+	poolStats := sqlDB.Stats()
 	return &ComponentHealth{
 		Status:      HealthStatusHealthy,
 		Message:     "Database connection successful",
 		LastChecked: time.Now(),
 		Duration:    time.Since(start),
 		Details: map[string]interface{}{
-			"host": h.config.Database.Host,
-			"port": h.config.Database.Port,
-			"name": h.config.Database.Name,
+			"host":             h.config.Database.Host,
+			"port":             h.config.Database.Port,
+			"name":             h.config.Database.Name,
+			"read_replicas":    db.ReplicaCount(),
+			"open_connections": poolStats.OpenConnections,
+			"in_use":           poolStats.InUse,
+		},
+	}, h.checkMigrations(checkCtx, db)
+}
+
+// checkMigrations compara la versión del esquema con las migraciones embebidas en el binario
+func (h *HealthHandler) checkMigrations(ctx context.Context, db *cockroachdb.DB) *ComponentHealth {
+	start := time.Now()
+
+	status, err := db.MigrationStatus(ctx)
+	if err != nil {
+		return &ComponentHealth{
+			Status:      HealthStatusDegraded,
+			Message:     "Failed to read schema migration status",
+			LastChecked: time.Now(),
+			Duration:    time.Since(start),
+			Details: map[string]interface{}{
+				"error": err.Error(),
+			},
+		}
+	}
+
+	health := &ComponentHealth{
+		Status:      HealthStatusHealthy,
+		Message:     "Schema is up to date",
+		LastChecked: time.Now(),
+		Duration:    time.Since(start),
+		Details: map[string]interface{}{
+			"current_version": status.CurrentVersion,
+			"latest_version":  status.LatestVersion,
+			"pending":         status.Pending,
+			"dirty":           status.Dirty,
 		},
 	}
+
+	// Una migración a medias deja el esquema en un estado desconocido
+	if status.Dirty {
+		health.Status = HealthStatusUnhealthy
+		health.Message = "Last migration failed and left the schema dirty"
+	} else if status.Pending > 0 {
+		health.Status = HealthStatusDegraded
+		health.Message = "There are pending schema migrations"
+	}
+
+	return health
 }
 
 // checkCache verifica la conectividad con el servicio de cache
@@ -283,7 +394,7 @@ func (h *HealthHandler) checkCache(ctx context.Context) *ComponentHealth {
 	// Use the Ping method to test cache connectivity
 	if err := h.cacheService.Ping(checkCtx); err != nil {
 		return &ComponentHealth{
-			Status:      HealthStatusDegraded,
+			Status:      HealthStatusUnhealthy,
 			Message:     "Cache ping failed",
 			LastChecked: time.Now(),
 			Duration:    time.Since(start),
@@ -293,6 +404,23 @@ func (h *HealthHandler) checkCache(ctx context.Context) *ComponentHealth {
 		}
 	}
 
+	// Con fallback la cache sigue respondiendo aunque Redis esté caído: se reporta como degradada
+	if pinger, ok := h.cacheService.(primaryCachePinger); ok {
+		if err := pinger.PingPrimary(checkCtx); err != nil {
+			return &ComponentHealth{
+				Status:      HealthStatusDegraded,
+				Message:     "Redis unreachable, serving from in-memory fallback",
+				LastChecked: time.Now(),
+				Duration:    time.Since(start),
+				Details: map[string]interface{}{
+					"host":  h.config.Cache.Host,
+					"port":  h.config.Cache.Port,
+					"error": err.Error(),
+				},
+			}
+		}
+	}
+
 	// Get cache statistics for additional health info
 	stats, err := h.cacheService.GetStats(checkCtx)
 	if err != nil {
@@ -323,10 +451,24 @@ func (h *HealthHandler) checkCache(ctx context.Context) *ComponentHealth {
 	}
 }
 
+// mockProviderHealth es el estado de los proveedores externos con EXTERNAL_PROVIDER=mock: no hay API a la que llamar
+func mockProviderHealth(start time.Time) *ComponentHealth {
+	return &ComponentHealth{
+		Status:      HealthStatusHealthy,
+		Message:     "Mock provider in use (EXTERNAL_PROVIDER=mock)",
+		LastChecked: time.Now(),
+		Duration:    time.Since(start),
+	}
+}
+
 // checkExternalAPI verifica la conectividad con APIs externas
 func (h *HealthHandler) checkExternalAPI(ctx context.Context, apiConfig config.APIConfig) *ComponentHealth {
 	start := time.Now()
 
+	if h.config.External.IsMock() {
+		return mockProviderHealth(start)
+	}
+
 	if apiConfig.BaseURL == "" {
 		return &ComponentHealth{
 			Status:      HealthStatusDegraded,
@@ -407,6 +549,10 @@ func (h *HealthHandler) checkExternalAPI(ctx context.Context, apiConfig config.A
 func (h *HealthHandler) checkStockAPI(ctx context.Context) *ComponentHealth {
 	start := time.Now()
 
+	if h.config.External.IsMock() {
+		return mockProviderHealth(start)
+	}
+
 	if h.config.ThirdStockAPI.BaseURL == "" {
 		return &ComponentHealth{
 			Status:      HealthStatusDegraded,
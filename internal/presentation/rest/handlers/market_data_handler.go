@@ -9,6 +9,7 @@ import (
 	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
 	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
 )
 
 // MarketDataHandler handles market data related requests
@@ -27,7 +28,7 @@ func NewMarketDataHandler(marketDataService interfaces.MarketDataService, logger
 
 // GetRealTimeQuote godoc
 // @Summary Get real-time quote for a stock
-// @Description Get real-time market data for a specific stock symbol
+// @Description Get real-time market data for a specific stock symbol. When the stored quote is older than 5 minutes it is returned immediately with `stale: true` and refreshed in the background.
 // @Tags market-data
 // @Accept json
 // @Produce json
@@ -49,10 +50,7 @@ func (h *MarketDataHandler) GetRealTimeQuote(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Symbol parameter is required")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -71,10 +69,7 @@ func (h *MarketDataHandler) GetRealTimeQuote(c *gin.Context) {
 				logger.String("error", errorResp.Message),
 			)
 
-			apiResponse := errorResp.ToAPIResponse()
-			apiResponse.RequestID = requestID
-
-			c.JSON(errorResp.StatusCode, apiResponse)
+			middleware.RespondWithError(c, errorResp)
 			return
 		}
 
@@ -84,10 +79,7 @@ func (h *MarketDataHandler) GetRealTimeQuote(c *gin.Context) {
 		)
 
 		errorResp := response.InternalServerError("Failed to retrieve market data")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -103,6 +95,81 @@ func (h *MarketDataHandler) GetRealTimeQuote(c *gin.Context) {
 	c.JSON(http.StatusOK, apiResponse)
 }
 
+// GetIntradayQuotes godoc
+// @Summary Get intraday quote history
+// @Description Get the quotes stored for a symbol in the last minutes, oldest first, for sparkline charts. Every quote refresh stores a snapshot and only the latest 1440 per symbol are kept
+// @Tags market-data
+// @Accept json
+// @Produce json
+// @Param symbol path string true "Stock symbol (e.g., AAPL)"
+// @Param minutes query int false "Window in minutes (1-1440)" default(60)
+// @Success 200 {object} response.APIResponse[response.IntradayQuotesResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Router /api/v1/market-data/{symbol}/intraday [get]
+func (h *MarketDataHandler) GetIntradayQuotes(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	// Get symbol from path
+	symbol := c.Param("symbol")
+	if symbol == "" {
+		h.logger.Warn(ctx, "Missing symbol parameter",
+			logger.String("request_id", requestID),
+		)
+
+		errorResp := response.BadRequest("Symbol parameter is required")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	// Parse minutes parameter (the service caps it at one day)
+	minutes := 60 // Default
+	if minutesStr := c.Query("minutes"); minutesStr != "" {
+		m, err := strconv.Atoi(minutesStr)
+		if err != nil || m < 1 {
+			h.logger.Warn(ctx, "Invalid minutes parameter",
+				logger.String("request_id", requestID),
+				logger.String("minutes", minutesStr),
+			)
+
+			errorResp := response.BadRequest("Invalid minutes parameter")
+			middleware.RespondWithError(c, errorResp)
+			return
+		}
+		minutes = m
+	}
+
+	intraday, err := h.marketDataService.GetIntradayQuotes(ctx, symbol, minutes)
+	if err != nil {
+		if errorResp, ok := err.(*response.ErrorResponse); ok {
+			h.logger.Warn(ctx, "Intraday quotes retrieval failed",
+				logger.String("request_id", requestID),
+				logger.String("symbol", symbol),
+				logger.String("error", errorResp.Message),
+			)
+
+			middleware.RespondWithError(c, errorResp)
+			return
+		}
+
+		h.logger.Error(ctx, "Unexpected error during intraday quotes retrieval", err,
+			logger.String("request_id", requestID),
+			logger.String("symbol", symbol),
+		)
+
+		errorResp := response.InternalServerError("Failed to retrieve intraday quotes")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(intraday)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
 // GetCompanyProfile godoc
 // @Summary Get company profile
 // @Description Get detailed company profile information
@@ -127,10 +194,7 @@ func (h *MarketDataHandler) GetCompanyProfile(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Symbol parameter is required")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -149,10 +213,7 @@ func (h *MarketDataHandler) GetCompanyProfile(c *gin.Context) {
 				logger.String("error", errorResp.Message),
 			)
 
-			apiResponse := errorResp.ToAPIResponse()
-			apiResponse.RequestID = requestID
-
-			c.JSON(errorResp.StatusCode, apiResponse)
+			middleware.RespondWithError(c, errorResp)
 			return
 		}
 
@@ -162,10 +223,7 @@ func (h *MarketDataHandler) GetCompanyProfile(c *gin.Context) {
 		)
 
 		errorResp := response.InternalServerError("Failed to retrieve company profile")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -206,10 +264,7 @@ func (h *MarketDataHandler) GetCompanyNews(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Symbol parameter is required")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -223,10 +278,7 @@ func (h *MarketDataHandler) GetCompanyNews(c *gin.Context) {
 			)
 
 			errorResp := response.BadRequest("Invalid days parameter")
-			apiResponse := errorResp.ToAPIResponse()
-			apiResponse.RequestID = requestID
-
-			c.JSON(errorResp.StatusCode, apiResponse)
+			middleware.RespondWithError(c, errorResp)
 			return
 		} else if d < 1 {
 			days = 1
@@ -253,10 +305,7 @@ func (h *MarketDataHandler) GetCompanyNews(c *gin.Context) {
 				logger.String("error", errorResp.Message),
 			)
 
-			apiResponse := errorResp.ToAPIResponse()
-			apiResponse.RequestID = requestID
-
-			c.JSON(errorResp.StatusCode, apiResponse)
+			middleware.RespondWithError(c, errorResp)
 			return
 		}
 
@@ -266,10 +315,7 @@ func (h *MarketDataHandler) GetCompanyNews(c *gin.Context) {
 		)
 
 		errorResp := response.InternalServerError("Failed to retrieve company news")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -309,10 +355,7 @@ func (h *MarketDataHandler) GetBasicFinancials(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Symbol parameter is required")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -331,10 +374,7 @@ func (h *MarketDataHandler) GetBasicFinancials(c *gin.Context) {
 				logger.String("error", errorResp.Message),
 			)
 
-			apiResponse := errorResp.ToAPIResponse()
-			apiResponse.RequestID = requestID
-
-			c.JSON(errorResp.StatusCode, apiResponse)
+			middleware.RespondWithError(c, errorResp)
 			return
 		}
 
@@ -344,10 +384,7 @@ func (h *MarketDataHandler) GetBasicFinancials(c *gin.Context) {
 		)
 
 		errorResp := response.InternalServerError("Failed to retrieve basic financials")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -388,10 +425,7 @@ func (h *MarketDataHandler) GetMarketOverview(c *gin.Context) {
 				logger.String("error", errorResp.Message),
 			)
 
-			apiResponse := errorResp.ToAPIResponse()
-			apiResponse.RequestID = requestID
-
-			c.JSON(errorResp.StatusCode, apiResponse)
+			middleware.RespondWithError(c, errorResp)
 			return
 		}
 
@@ -400,10 +434,7 @@ func (h *MarketDataHandler) GetMarketOverview(c *gin.Context) {
 		)
 
 		errorResp := response.InternalServerError("Failed to retrieve market overview")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -417,3 +448,67 @@ func (h *MarketDataHandler) GetMarketOverview(c *gin.Context) {
 
 	c.JSON(http.StatusOK, apiResponse)
 }
+
+// GetMarketMovers godoc
+// @Summary Get top market movers
+// @Description Rank the latest quote of every symbol: top gainers, top losers or most active by volume
+// @Tags market-data
+// @Accept json
+// @Produce json
+// @Param type query string false "Ranking type" Enums(gainers, losers, active) default(gainers)
+// @Param limit query int false "Number of movers (1-100)" default(20)
+// @Success 200 {object} response.APIResponse[response.MarketMoversResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Router /api/v1/market-data/movers [get]
+func (h *MarketDataHandler) GetMarketMovers(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	moverType := c.DefaultQuery("type", "gainers")
+
+	// Parse limit parameter (the service caps it at 100)
+	limit := 20 // Default
+	if limitStr := c.Query("limit"); limitStr != "" {
+		l, err := strconv.Atoi(limitStr)
+		if err != nil || l < 1 {
+			h.logger.Warn(ctx, "Invalid limit parameter",
+				logger.String("request_id", requestID),
+				logger.String("limit", limitStr),
+			)
+
+			errorResp := response.BadRequest("Invalid limit parameter")
+			middleware.RespondWithError(c, errorResp)
+			return
+		}
+		limit = l
+	}
+
+	movers, err := h.marketDataService.GetMarketMovers(ctx, moverType, limit)
+	if err != nil {
+		if errorResp, ok := err.(*response.ErrorResponse); ok {
+			h.logger.Warn(ctx, "Market movers retrieval failed",
+				logger.String("request_id", requestID),
+				logger.String("type", moverType),
+				logger.String("error", errorResp.Message),
+			)
+
+			middleware.RespondWithError(c, errorResp)
+			return
+		}
+
+		h.logger.Error(ctx, "Unexpected error during market movers retrieval", err,
+			logger.String("request_id", requestID),
+			logger.String("type", moverType),
+		)
+
+		errorResp := response.InternalServerError("Failed to retrieve market movers")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(movers)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
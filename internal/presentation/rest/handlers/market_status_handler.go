@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	serviceInterfaces "github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
+)
+
+// MarketStatusHandler maneja la consulta del estado del mercado según el calendario de negociación
+type MarketStatusHandler struct {
+	marketStatusService serviceInterfaces.MarketStatusService
+	logger              logger.Logger
+}
+
+// NewMarketStatusHandler crea una nueva instancia del handler de estado del mercado
+func NewMarketStatusHandler(marketStatusService serviceInterfaces.MarketStatusService, appLogger logger.Logger) *MarketStatusHandler {
+	return &MarketStatusHandler{
+		marketStatusService: marketStatusService,
+		logger:              appLogger,
+	}
+}
+
+// GetMarketStatus godoc
+// @Summary Get market status
+// @Description Get the current session of an exchange (pre_market 04:00-09:30, regular 09:30-16:00, post_market 16:00-20:00 ET, or closed) computed from the trading calendar, with today's holiday or early close and the next regular open and close
+// @Tags market
+// @Accept json
+// @Produce json
+// @Param exchange query string false "NYSE, NASDAQ, AMEX or US" default(NYSE)
+// @Success 200 {object} response.APIResponse[response.MarketStatusResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Router /api/v1/market/status [get]
+func (h *MarketStatusHandler) GetMarketStatus(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	var req request.MarketStatusRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	status, err := h.marketStatusService.GetMarketStatus(ctx, req.Exchange)
+	if err != nil {
+		h.logger.Warn(ctx, "Failed to get market status",
+			logger.String("request_id", requestID),
+			logger.String("exchange", req.Exchange),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Exchange", "Failed to get market status")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(status)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
@@ -0,0 +1,255 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	serviceInterfaces "github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/tenancy"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
+)
+
+// NoteHandler maneja las notas privadas de los usuarios sobre companies y ratings
+type NoteHandler struct {
+	noteService serviceInterfaces.NoteService
+	logger      logger.Logger
+}
+
+// NewNoteHandler crea una nueva instancia del handler de notas
+func NewNoteHandler(noteService serviceInterfaces.NoteService, appLogger logger.Logger) *NoteHandler {
+	return &NoteHandler{
+		noteService: noteService,
+		logger:      appLogger,
+	}
+}
+
+// ListNotes godoc
+// @Summary List my notes
+// @Description List the private notes of the authenticated user, newest first. The company filter (ID or ticker) includes the notes on its ratings
+// @Tags notes
+// @Produce json
+// @Param company query string false "Company ID or ticker"
+// @Param rating_id query string false "Stock rating ID"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(20)
+// @Success 200 {object} response.APIResponse[response.PaginatedResponse[response.NoteResponse]]
+// @Failure 401 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Router /api/v1/notes [get]
+func (h *NoteHandler) ListNotes(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	var filter request.NoteFilterRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		middleware.RespondWithError(c, middleware.ValidationErrorResponse(err))
+		return
+	}
+	pagination := response.ParsePaginationFromQuery(c.Query("page"), c.Query("per_page"))
+
+	notes, err := h.noteService.ListNotes(ctx, &filter, pagination)
+	if err != nil {
+		h.logger.Warn(ctx, "Notes retrieval failed",
+			logger.String("request_id", requestID),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Note", "Failed to get notes")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	middleware.RespondWithPage(c, response.Success(notes))
+}
+
+// GetNote godoc
+// @Summary Get a note
+// @Description Get a private note of the authenticated user; the notes of other users are not found
+// @Tags notes
+// @Produce json
+// @Param id path string true "Note ID"
+// @Success 200 {object} response.APIResponse[response.NoteResponse]
+// @Failure 401 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Router /api/v1/notes/{id} [get]
+func (h *NoteHandler) GetNote(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	noteID, ok := h.noteID(c)
+	if !ok {
+		return
+	}
+
+	note, err := h.noteService.GetNote(ctx, noteID)
+	if err != nil {
+		h.logger.Warn(ctx, "Note retrieval failed",
+			logger.String("request_id", requestID),
+			logger.String("note_id", noteID.String()),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Note", "Failed to get note")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(note)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// CreateNote godoc
+// @Summary Create a note
+// @Description Create a private note of the authenticated user on a company (ID or ticker) or on a stock rating
+// @Tags notes
+// @Accept json
+// @Produce json
+// @Param note body request.CreateNoteRequest true "Note"
+// @Success 201 {object} response.APIResponse[response.NoteResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 401 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Router /api/v1/notes [post]
+func (h *NoteHandler) CreateNote(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	var req request.CreateNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RespondWithError(c, middleware.ValidationErrorResponse(err))
+		return
+	}
+
+	note, err := h.noteService.CreateNote(ctx, &req)
+	if err != nil {
+		h.logger.Warn(ctx, "Note creation failed",
+			logger.String("request_id", requestID),
+			logger.String("company", req.Company),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Note", "Failed to create note")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(note)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusCreated, apiResponse)
+}
+
+// UpdateNote godoc
+// @Summary Update a note
+// @Description Replace the body of a private note of the authenticated user
+// @Tags notes
+// @Accept json
+// @Produce json
+// @Param id path string true "Note ID"
+// @Param note body request.UpdateNoteRequest true "New body"
+// @Success 200 {object} response.APIResponse[response.NoteResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 401 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Router /api/v1/notes/{id} [put]
+func (h *NoteHandler) UpdateNote(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	noteID, ok := h.noteID(c)
+	if !ok {
+		return
+	}
+
+	var req request.UpdateNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RespondWithError(c, middleware.ValidationErrorResponse(err))
+		return
+	}
+
+	note, err := h.noteService.UpdateNote(ctx, noteID, &req)
+	if err != nil {
+		h.logger.Warn(ctx, "Note update failed",
+			logger.String("request_id", requestID),
+			logger.String("note_id", noteID.String()),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Note", "Failed to update note")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(note)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// DeleteNote godoc
+// @Summary Delete a note
+// @Description Delete a private note of the authenticated user
+// @Tags notes
+// @Param id path string true "Note ID"
+// @Success 204
+// @Failure 401 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Router /api/v1/notes/{id} [delete]
+func (h *NoteHandler) DeleteNote(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	noteID, ok := h.noteID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.noteService.DeleteNote(ctx, noteID); err != nil {
+		h.logger.Warn(ctx, "Note deletion failed",
+			logger.String("request_id", requestID),
+			logger.String("note_id", noteID.String()),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Note", "Failed to delete note")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// noteID lee el ID de la nota de la ruta y responde 400 si no es válido
+func (h *NoteHandler) noteID(c *gin.Context) (uuid.UUID, bool) {
+	idParam := c.Param("id")
+	noteID, err := uuid.Parse(idParam)
+	if err != nil {
+		h.logger.Warn(c.Request.Context(), "Invalid note ID format",
+			logger.String("request_id", c.GetString("request_id")),
+			logger.String("id", idParam),
+		)
+
+		errorResp := response.BadRequest("Invalid note ID format")
+		middleware.RespondWithError(c, errorResp)
+		return uuid.Nil, false
+	}
+	return noteID, true
+}
+
+// requireNoteOwner responde 401 si la petición no tiene la credencial de un usuario, dueño de las notas de
+// ?include=notes, y devuelve false. Con notas la respuesta es privada: ninguna cache compartida debe guardarla
+func requireNoteOwner(c *gin.Context) bool {
+	if _, _, err := tenancy.Owner(c.Request.Context()); err != nil {
+		middleware.RespondWithError(c, response.Unauthorized("Notes require the API key or bearer token of a user"))
+		return false
+	}
+	c.Header("Cache-Control", "private, no-store")
+	return true
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	serviceInterfaces "github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
+)
+
+// PeerHandler maneja el descubrimiento de competidores de una company
+type PeerHandler struct {
+	peerService serviceInterfaces.PeerService
+	logger      logger.Logger
+}
+
+// NewPeerHandler crea una nueva instancia del handler de peers
+func NewPeerHandler(peerService serviceInterfaces.PeerService, appLogger logger.Logger) *PeerHandler {
+	return &PeerHandler{
+		peerService: peerService,
+		logger:      appLogger,
+	}
+}
+
+// GetCompanyPeers godoc
+// @Summary Get company peers
+// @Description Get the competitors of a company, closest first. Peers come from the Finnhub peers API or, when it knows none, from companies of the same sector with a similar market cap. The peer graph is stored and rediscovered once it is older than CACHE_TTL_PEERS
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Param ticker path string true "Company ticker"
+// @Success 200 {object} response.APIResponse[response.CompanyPeersResponse]
+// @Failure 404 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Router /api/v1/companies/{ticker}/peers [get]
+func (h *PeerHandler) GetCompanyPeers(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	// La ruta comparte el segmento :id con el resto de /companies/:id (gin exige el mismo nombre), pero recibe un ticker
+	ticker := c.Param("id")
+
+	peers, err := h.peerService.GetPeers(ctx, ticker)
+	if err != nil {
+		h.logger.Warn(ctx, "Company peers retrieval failed",
+			logger.String("request_id", requestID),
+			logger.String("ticker", ticker),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Company", "Failed to get peers")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(peers)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	serviceInterfaces "github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
+)
+
+// SearchHandler maneja la búsqueda de texto completo sobre companies y noticias
+type SearchHandler struct {
+	searchService serviceInterfaces.SearchService
+	logger        logger.Logger
+}
+
+// NewSearchHandler crea una nueva instancia del handler de búsqueda
+func NewSearchHandler(searchService serviceInterfaces.SearchService, appLogger logger.Logger) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+		logger:        appLogger,
+	}
+}
+
+// Search godoc
+// @Summary Full-text search
+// @Description Search companies (ticker and name) and news (title and summary) and return the hits of every type ranked together, best match first. Every word matches as a prefix ("appl" finds Apple)
+// @Tags search
+// @Accept json
+// @Produce json
+// @Param q query string true "Search text (2-100 characters)"
+// @Param types query string false "Comma-separated result types: company, news (default: all)"
+// @Param limit query int false "Maximum number of results (1-50)" default(20)
+// @Success 200 {object} response.APIResponse[response.SearchResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Router /api/v1/search [get]
+func (h *SearchHandler) Search(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	var req request.SearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	results, err := h.searchService.Search(ctx, &req)
+	if err != nil {
+		h.logger.Warn(ctx, "Full-text search failed",
+			logger.String("request_id", requestID),
+			logger.String("query", req.Query),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Search", "Failed to search")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(results)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
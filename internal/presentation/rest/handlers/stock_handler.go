@@ -1,27 +1,30 @@
 package handlers
 
 import (
-	"net/http"
-	"strconv"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"net/http"
+	"strconv"
 
 	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
 	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
 	serviceInterfaces "github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
 )
 
 // StockHandler maneja los endpoints relacionados con stock ratings
 type StockHandler struct {
 	stockService serviceInterfaces.StockRatingService
+	ratingPoll   serviceInterfaces.RatingPollService
 	logger       logger.Logger
 }
 
 // NewStockHandler crea una nueva instancia del handler de stocks
-func NewStockHandler(stockService serviceInterfaces.StockRatingService, appLogger logger.Logger) *StockHandler {
+func NewStockHandler(stockService serviceInterfaces.StockRatingService, ratingPoll serviceInterfaces.RatingPollService, appLogger logger.Logger) *StockHandler {
 	return &StockHandler{
 		stockService: stockService,
+		ratingPoll:   ratingPoll,
 		logger:       appLogger,
 	}
 }
@@ -49,11 +52,8 @@ func (h *StockHandler) CreateStockRating(c *gin.Context) {
 			logger.String("error", err.Error()),
 		)
 
-		errorResp := response.ValidationFailed("Invalid request body")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -73,11 +73,8 @@ func (h *StockHandler) CreateStockRating(c *gin.Context) {
 			logger.String("brokerage_id", req.BrokerageID.String()),
 		)
 
-		errorResp := response.InternalServerError("Failed to create stock rating")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := response.FromError(err, "Stock rating", "Failed to create stock rating")
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -117,10 +114,7 @@ func (h *StockHandler) GetStockRatingByID(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Invalid stock rating ID format")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -137,11 +131,8 @@ func (h *StockHandler) GetStockRatingByID(c *gin.Context) {
 			logger.String("id", id.String()),
 		)
 
-		errorResp := response.NotFound("Stock rating")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := response.FromError(err, "Stock rating", "Failed to get stock rating")
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -176,10 +167,7 @@ func (h *StockHandler) DeleteStockRating(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Invalid stock rating ID format")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -196,11 +184,8 @@ func (h *StockHandler) DeleteStockRating(c *gin.Context) {
 			logger.String("id", id.String()),
 		)
 
-		errorResp := response.InternalServerError("Failed to delete stock rating")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := response.FromError(err, "Stock rating", "Failed to delete stock rating")
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -212,6 +197,93 @@ func (h *StockHandler) DeleteStockRating(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// RestoreStockRating godoc
+// @Summary Restore a deleted stock rating
+// @Description Undo the soft delete of a stock rating; answers 409 while its company is still deleted
+// @Tags stocks
+// @Accept json
+// @Produce json
+// @Param id path string true "Stock rating ID"
+// @Success 200 {object} response.APIResponse[response.StockRatingResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Failure 409 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Router /api/v1/stocks/{id}/restore [post]
+func (h *StockHandler) RestoreStockRating(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		h.logger.Warn(ctx, "Invalid stock rating ID format",
+			logger.String("request_id", requestID),
+			logger.String("id", idParam),
+		)
+
+		errorResp := response.BadRequest("Invalid stock rating ID format")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	h.logger.Info(ctx, "Restoring stock rating",
+		logger.String("request_id", requestID),
+		logger.String("id", id.String()),
+	)
+
+	stockRating, err := h.stockService.RestoreStockRating(ctx, id)
+	if err != nil {
+		h.logger.Error(ctx, "Failed to restore stock rating",
+			err,
+			logger.String("request_id", requestID),
+			logger.String("id", id.String()),
+		)
+
+		errorResp := response.FromError(err, "Deleted stock rating", "Failed to restore stock rating")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(stockRating)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// ListDeletedStockRatings godoc
+// @Summary List deleted stock ratings
+// @Description Get a paginated list of soft-deleted stock ratings that can be restored (admin)
+// @Tags stocks
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(20)
+// @Success 200 {object} response.APIResponse[response.PaginatedResponse[response.DeletedStockRatingResponse]]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Router /api/v1/stocks/deleted [get]
+func (h *StockHandler) ListDeletedStockRatings(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	pagination := h.parsePagination(c)
+
+	stockRatings, err := h.stockService.ListDeletedStockRatings(ctx, pagination)
+	if err != nil {
+		h.logger.Error(ctx, "Failed to list deleted stock ratings",
+			err,
+			logger.String("request_id", requestID),
+		)
+
+		errorResp := response.FromError(err, "Stock rating", "Failed to list deleted stock ratings")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	middleware.RespondWithPage(c, response.Success(stockRatings))
+}
+
 // ListStockRatings godoc
 // @Summary List stock ratings
 // @Description Get a paginated list of stock ratings with optional filters
@@ -225,6 +297,11 @@ func (h *StockHandler) DeleteStockRating(c *gin.Context) {
 // @Param rating_to query string false "Rating to filter"
 // @Param date_from query string false "Date from filter (YYYY-MM-DD)"
 // @Param date_to query string false "Date to filter (YYYY-MM-DD)"
+// @Param target_min query number false "Minimum parsed target price (in target_currency)"
+// @Param target_max query number false "Maximum parsed target price (in target_currency)"
+// @Param target_currency query string false "ISO currency of the target price filter" default(USD)
+// @Param sort query string false "Comma-separated field:asc|desc (event_time, created_at, action, action_type, rating_to, target_to)" default(event_time:desc)
+// @Param include query string false "Comma-separated related entities to embed (company, brokerage, notes); notes needs the credential of a user"
 // @Param page query int false "Page number" default(1)
 // @Param per_page query int false "Items per page" default(20)
 // @Success 200 {object} response.APIResponse[response.PaginatedResponse[response.StockRatingListResponse]]
@@ -244,15 +321,16 @@ func (h *StockHandler) ListStockRatings(c *gin.Context) {
 		)
 
 		errorResp := response.ValidationFailed("Invalid query parameters")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
 	// Parse pagination
 	pagination := h.parsePagination(c)
+	includes, ok := h.parseIncludes(c)
+	if !ok {
+		return
+	}
 
 	h.logger.Info(ctx, "Listing stock ratings",
 		logger.String("request_id", requestID),
@@ -260,22 +338,147 @@ func (h *StockHandler) ListStockRatings(c *gin.Context) {
 		logger.Int("per_page", pagination.PerPage),
 	)
 
-	stockRatings, err := h.stockService.ListStockRatings(ctx, &filter, pagination)
+	stockRatings, err := h.stockService.ListStockRatings(ctx, &filter, includes, pagination)
 	if err != nil {
 		h.logger.Error(ctx, "Failed to list stock ratings",
 			err,
 			logger.String("request_id", requestID),
 		)
 
-		errorResp := response.InternalServerError("Failed to list stock ratings")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
+		errorResp := response.FromError(err, "Stock rating", "Failed to list stock ratings")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	middleware.RespondWithPage(c, response.Success(stockRatings))
+}
+
+// SearchRatings godoc
+// @Summary Search ratings with combined filters
+// @Description Search stock ratings combining brokerage, action types, normalized rating range, parsed target price range and dates in a single query. Every filter set narrows the result
+// @Tags ratings
+// @Accept json
+// @Produce json
+// @Param company_id query string false "Company ID"
+// @Param ticker query string false "Company ticker (instead of company_id)"
+// @Param brokerage_id query string false "Brokerage ID"
+// @Param action_type query string false "Comma-separated action types (upgrade, downgrade, reiterate, initiate, target_raised, target_lowered, target_set, other)"
+// @Param rating_to query string false "Comma-separated normalized ratings (strong_buy, buy, hold, sell, strong_sell)"
+// @Param min_rating query string false "Most bearish normalized rating" Enums(strong_buy, buy, hold, sell, strong_sell)
+// @Param max_rating query string false "Most bullish normalized rating" Enums(strong_buy, buy, hold, sell, strong_sell)
+// @Param min_target query number false "Minimum parsed target price (in target_currency)"
+// @Param max_target query number false "Maximum parsed target price (in target_currency)"
+// @Param target_currency query string false "ISO currency of the target price filter" default(USD)
+// @Param from query string false "First day (YYYY-MM-DD, inclusive)"
+// @Param to query string false "Last day (YYYY-MM-DD, inclusive)"
+// @Param tz query string false "IANA time zone of from/to"
+// @Param sort query string false "Comma-separated field:asc|desc (event_time, created_at, action, action_type, rating_to, target_to)" default(event_time:desc)
+// @Param include query string false "Comma-separated related entities to embed (company, brokerage, notes); notes needs the credential of a user"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(20)
+// @Success 200 {object} response.APIResponse[response.PaginatedResponse[response.StockRatingListResponse]]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Router /api/v1/ratings [get]
+func (h *StockHandler) SearchRatings(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	var req request.RatingSearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warn(ctx, "Invalid rating search parameters",
+			logger.String("request_id", requestID),
+			logger.String("error", err.Error()),
+		)
+
+		middleware.RespondWithError(c, middleware.ValidationErrorResponse(err))
+		return
+	}
+
+	pagination := h.parsePagination(c)
+	includes, ok := h.parseIncludes(c)
+	if !ok {
+		return
+	}
+
+	ratings, err := h.stockService.SearchRatings(ctx, &req, includes, pagination)
+	if err != nil {
+		h.logger.Error(ctx, "Failed to search stock ratings",
+			err,
+			logger.String("request_id", requestID),
+		)
+
+		errorResp := response.FromError(err, "Stock rating", "Failed to search stock ratings")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	middleware.RespondWithPage(c, response.Success(ratings))
+}
+
+// PollRatings godoc
+// @Summary Long-poll new ratings
+// @Description For clients that can't use WebSockets or SSE: return the ratings created after since_id, oldest first, as soon as there is one, waiting up to wait seconds for a rating.created event. Without since_id only ratings created after the request are returned. An empty response has timed_out=true; pass next_since_id in the next request
+// @Tags ratings
+// @Accept json
+// @Produce json
+// @Param since_id query string false "ID of the last rating received"
+// @Param wait query int false "Seconds to wait for new ratings (max 25)" default(20)
+// @Param limit query int false "Maximum ratings returned (max 500)" default(100)
+// @Param include query string false "Comma-separated related entities to embed (company, brokerage, notes); notes needs the credential of a user"
+// @Success 200 {object} response.APIResponse[response.RatingPollResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/ratings/poll [get]
+func (h *StockHandler) PollRatings(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	if h.ratingPoll == nil {
+		middleware.RespondWithError(c, response.ServiceUnavailable("Rating polling is not configured"))
+		return
+	}
+
+	var req request.RatingPollRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warn(ctx, "Invalid rating poll parameters",
+			logger.String("request_id", requestID),
+			logger.String("error", err.Error()),
+		)
+
+		middleware.RespondWithError(c, middleware.ValidationErrorResponse(err))
+		return
+	}
 
-		c.JSON(errorResp.StatusCode, apiResponse)
+	includes, ok := h.parseIncludes(c)
+	if !ok {
 		return
 	}
 
-	apiResponse := response.Success(stockRatings)
+	result, err := h.ratingPoll.Poll(ctx, &req, includes)
+	if err != nil {
+		// El cliente cerró la conexión durante la espera: no hay a quién responder
+		if ctx.Err() != nil {
+			return
+		}
+
+		h.logger.Error(ctx, "Failed to poll stock ratings",
+			err,
+			logger.String("request_id", requestID),
+		)
+
+		errorResp := response.FromError(err, "Stock rating", "Failed to poll new ratings")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	// Las respuestas del long-poll nunca se cachean
+	c.Header("Cache-Control", "no-store")
+
+	apiResponse := response.Success(result)
 	apiResponse.RequestID = requestID
 
 	c.JSON(http.StatusOK, apiResponse)
@@ -288,6 +491,7 @@ func (h *StockHandler) ListStockRatings(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param company_id path string true "Company ID"
+// @Param include query string false "Comma-separated related entities to embed (company, brokerage, notes); notes needs the credential of a user"
 // @Param page query int false "Page number" default(1)
 // @Param per_page query int false "Items per page" default(20)
 // @Success 200 {object} response.APIResponse[response.PaginatedResponse[response.StockRatingListResponse]]
@@ -308,21 +512,22 @@ func (h *StockHandler) GetRatingsByCompany(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Invalid company ID format")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
 	pagination := h.parsePagination(c)
+	includes, ok := h.parseIncludes(c)
+	if !ok {
+		return
+	}
 
 	h.logger.Info(ctx, "Getting ratings by company",
 		logger.String("request_id", requestID),
 		logger.String("company_id", companyID.String()),
 	)
 
-	stockRatings, err := h.stockService.GetRatingsByCompany(ctx, companyID, pagination)
+	stockRatings, err := h.stockService.GetRatingsByCompany(ctx, companyID, includes, pagination)
 	if err != nil {
 		h.logger.Error(ctx, "Failed to get ratings by company",
 			err,
@@ -330,15 +535,72 @@ func (h *StockHandler) GetRatingsByCompany(c *gin.Context) {
 			logger.String("company_id", companyID.String()),
 		)
 
-		errorResp := response.InternalServerError("Failed to get ratings by company")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
+		errorResp := response.FromError(err, "Stock rating", "Failed to get ratings by company")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	middleware.RespondWithPage(c, response.Success(stockRatings))
+}
+
+// GetRatingsAsOf godoc
+// @Summary Get a company's ratings as of a date
+// @Description Return the latest rating of each brokerage for the company with event time up to the end of date (in the request time zone), newest first: the ratings the company had on that day
+// @Tags ratings
+// @Accept json
+// @Produce json
+// @Param id path string true "Company ID"
+// @Param date query string true "Snapshot day (YYYY-MM-DD, inclusive)"
+// @Param tz query string false "IANA time zone of date"
+// @Param include query string false "Comma-separated related entities to embed (company, brokerage, notes); notes needs the credential of a user"
+// @Success 200 {object} response.APIResponse[response.RatingAsOfResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Router /api/v1/companies/{id}/ratings/asof [get]
+func (h *StockHandler) GetRatingsAsOf(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	companyIDParam := c.Param("id")
+	companyID, err := uuid.Parse(companyIDParam)
+	if err != nil {
+		h.logger.Warn(ctx, "Invalid company ID format",
+			logger.String("request_id", requestID),
+			logger.String("company_id", companyIDParam),
+		)
+
+		errorResp := response.BadRequest("Invalid company ID format")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	var req request.RatingAsOfRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		middleware.RespondWithError(c, middleware.ValidationErrorResponse(err))
+		return
+	}
+
+	includes, ok := h.parseIncludes(c)
+	if !ok {
+		return
+	}
+
+	snapshot, err := h.stockService.GetRatingsAsOf(ctx, companyID, req.Date, includes)
+	if err != nil {
+		h.logger.Error(ctx, "Failed to get ratings as of date",
+			err,
+			logger.String("request_id", requestID),
+			logger.String("company_id", companyID.String()),
+			logger.String("date", req.Date),
+		)
 
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := response.FromError(err, "Stock rating", "Failed to get ratings as of date")
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
-	apiResponse := response.Success(stockRatings)
+	apiResponse := response.Success(snapshot)
 	apiResponse.RequestID = requestID
 
 	c.JSON(http.StatusOK, apiResponse)
@@ -351,6 +613,7 @@ func (h *StockHandler) GetRatingsByCompany(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param ticker path string true "Company ticker"
+// @Param include query string false "Comma-separated related entities to embed (company, brokerage, notes); notes needs the credential of a user"
 // @Param page query int false "Page number" default(1)
 // @Param per_page query int false "Items per page" default(20)
 // @Success 200 {object} response.APIResponse[response.PaginatedResponse[response.StockRatingListResponse]]
@@ -369,21 +632,22 @@ func (h *StockHandler) GetRatingsByTicker(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Ticker parameter is required")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
 	pagination := h.parsePagination(c)
+	includes, ok := h.parseIncludes(c)
+	if !ok {
+		return
+	}
 
 	h.logger.Info(ctx, "Getting ratings by ticker",
 		logger.String("request_id", requestID),
 		logger.String("ticker", ticker),
 	)
 
-	stockRatings, err := h.stockService.GetRatingsByTicker(ctx, ticker, pagination)
+	stockRatings, err := h.stockService.GetRatingsByTicker(ctx, ticker, includes, pagination)
 	if err != nil {
 		h.logger.Error(ctx, "Failed to get ratings by ticker",
 			err,
@@ -391,18 +655,12 @@ func (h *StockHandler) GetRatingsByTicker(c *gin.Context) {
 			logger.String("ticker", ticker),
 		)
 
-		errorResp := response.InternalServerError("Failed to get ratings by ticker")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := response.FromError(err, "Stock rating", "Failed to get ratings by ticker")
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
-	apiResponse := response.Success(stockRatings)
-	apiResponse.RequestID = requestID
-
-	c.JSON(http.StatusOK, apiResponse)
+	middleware.RespondWithPage(c, response.Success(stockRatings))
 }
 
 // GetRatingsByBrokerage godoc
@@ -412,6 +670,7 @@ func (h *StockHandler) GetRatingsByTicker(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param brokerage_id path string true "Brokerage ID"
+// @Param include query string false "Comma-separated related entities to embed (company, brokerage, notes); notes needs the credential of a user"
 // @Param page query int false "Page number" default(1)
 // @Param per_page query int false "Items per page" default(20)
 // @Success 200 {object} response.APIResponse[response.PaginatedResponse[response.StockRatingListResponse]]
@@ -432,21 +691,22 @@ func (h *StockHandler) GetRatingsByBrokerage(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Invalid brokerage ID format")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
 	pagination := h.parsePagination(c)
+	includes, ok := h.parseIncludes(c)
+	if !ok {
+		return
+	}
 
 	h.logger.Info(ctx, "Getting ratings by brokerage",
 		logger.String("request_id", requestID),
 		logger.String("brokerage_id", brokerageID.String()),
 	)
 
-	stockRatings, err := h.stockService.GetRatingsByBrokerage(ctx, brokerageID, pagination)
+	stockRatings, err := h.stockService.GetRatingsByBrokerage(ctx, brokerageID, includes, pagination)
 	if err != nil {
 		h.logger.Error(ctx, "Failed to get ratings by brokerage",
 			err,
@@ -454,18 +714,12 @@ func (h *StockHandler) GetRatingsByBrokerage(c *gin.Context) {
 			logger.String("brokerage_id", brokerageID.String()),
 		)
 
-		errorResp := response.InternalServerError("Failed to get ratings by brokerage")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := response.FromError(err, "Stock rating", "Failed to get ratings by brokerage")
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
-	apiResponse := response.Success(stockRatings)
-	apiResponse.RequestID = requestID
-
-	c.JSON(http.StatusOK, apiResponse)
+	middleware.RespondWithPage(c, response.Success(stockRatings))
 }
 
 // GetRecentRatings godoc
@@ -492,10 +746,7 @@ func (h *StockHandler) GetRecentRatings(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Invalid limit parameter (must be between 1 and 100)")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -511,11 +762,8 @@ func (h *StockHandler) GetRecentRatings(c *gin.Context) {
 			logger.String("request_id", requestID),
 		)
 
-		errorResp := response.InternalServerError("Failed to get recent ratings")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := response.FromError(err, "Stock rating", "Failed to get recent ratings")
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -525,14 +773,56 @@ func (h *StockHandler) GetRecentRatings(c *gin.Context) {
 	c.JSON(http.StatusOK, apiResponse)
 }
 
+// GetRatingsForPeriod godoc
+// @Summary Get ratings of today, this week or this month
+// @Description Get the stock ratings of the current day, week (starting on Sunday) or month. The period starts at midnight in the tz time zone, else the time zone of the credential, else the server time zone
+// @Tags stocks
+// @Accept json
+// @Produce json
+// @Param period path string true "Period" Enums(today, week, month)
+// @Param tz query string false "IANA time zone (e.g. America/New_York)"
+// @Success 200 {object} response.APIResponse[[]response.StockRatingListResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Router /api/v1/stocks/period/{period} [get]
+func (h *StockHandler) GetRatingsForPeriod(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	period := c.Param("period")
+
+	h.logger.Info(ctx, "Getting ratings for period",
+		logger.String("request_id", requestID),
+		logger.String("period", period),
+	)
+
+	ratings, err := h.stockService.GetRatingsForPeriod(ctx, period)
+	if err != nil {
+		h.logger.Warn(ctx, "Failed to get ratings for period",
+			logger.String("request_id", requestID),
+			logger.String("period", period),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Stock rating", "Failed to get stock ratings")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(ratings)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
 // GetRatingsByDateRange godoc
 // @Summary Get ratings by date range
-// @Description Get stock ratings within a specific date range
+// @Description Get stock ratings within a specific date range. Both days are inclusive and interpreted in the tz time zone, else the time zone of the credential, else the server time zone
 // @Tags stocks
 // @Accept json
 // @Produce json
 // @Param start_date query string true "Start date (YYYY-MM-DD)"
 // @Param end_date query string true "End date (YYYY-MM-DD)"
+// @Param tz query string false "IANA time zone (e.g. America/New_York)"
 // @Param page query int false "Page number" default(1)
 // @Param per_page query int false "Items per page" default(20)
 // @Success 200 {object} response.APIResponse[response.PaginatedResponse[response.StockRatingListResponse]]
@@ -554,10 +844,7 @@ func (h *StockHandler) GetRatingsByDateRange(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Both start_date and end_date parameters are required")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -578,18 +865,12 @@ func (h *StockHandler) GetRatingsByDateRange(c *gin.Context) {
 			logger.String("end_date", endDate),
 		)
 
-		errorResp := response.InternalServerError("Failed to get ratings by date range")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := response.FromError(err, "Stock rating", "Failed to get ratings by date range")
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
-	apiResponse := response.Success(stockRatings)
-	apiResponse.RequestID = requestID
-
-	c.JSON(http.StatusOK, apiResponse)
+	middleware.RespondWithPage(c, response.Success(stockRatings))
 }
 
 // GetRatingStatsByCompany godoc
@@ -617,10 +898,7 @@ func (h *StockHandler) GetRatingStatsByCompany(c *gin.Context) {
 		)
 
 		errorResp := response.BadRequest("Invalid company ID format")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -637,11 +915,8 @@ func (h *StockHandler) GetRatingStatsByCompany(c *gin.Context) {
 			logger.String("company_id", companyID.String()),
 		)
 
-		errorResp := response.InternalServerError("Failed to get rating statistics")
-		apiResponse := errorResp.ToAPIResponse()
-		apiResponse.RequestID = requestID
-
-		c.JSON(errorResp.StatusCode, apiResponse)
+		errorResp := response.FromError(err, "Stock rating", "Failed to get rating statistics")
+		middleware.RespondWithError(c, errorResp)
 		return
 	}
 
@@ -651,10 +926,26 @@ func (h *StockHandler) GetRatingStatsByCompany(c *gin.Context) {
 	c.JSON(http.StatusOK, apiResponse)
 }
 
+// parseIncludes lee ?include=company,brokerage,notes; si no es válido (o pide notas sin la credencial de un
+// usuario) responde con el error y devuelve false
+func (h *StockHandler) parseIncludes(c *gin.Context) (request.RatingIncludes, bool) {
+	includes, err := request.ParseRatingIncludes(c.Query("include"))
+	if err != nil {
+		middleware.RespondWithError(c, response.ValidationFailedWithFields([]response.ValidationError{
+			{Field: "include", Tag: "oneof", Message: "Must be one of: company, brokerage, notes", Value: c.Query("include")},
+		}))
+		return request.RatingIncludes{}, false
+	}
+	if includes.Notes && !requireNoteOwner(c) {
+		return request.RatingIncludes{}, false
+	}
+	return includes, true
+}
+
 // parsePagination extrae y valida los parámetros de paginación
 func (h *StockHandler) parsePagination(c *gin.Context) *response.PaginationRequest {
 	pageParam := c.Query("page")
 	perPageParam := c.Query("per_page")
-	
+
 	return response.ParsePaginationFromQuery(pageParam, perPageParam)
 }
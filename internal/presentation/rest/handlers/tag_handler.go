@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	serviceInterfaces "github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
+)
+
+// TagHandler maneja las etiquetas temáticas y su asignación a companies
+type TagHandler struct {
+	tagService serviceInterfaces.TagService
+	logger     logger.Logger
+}
+
+// NewTagHandler crea una nueva instancia del handler de etiquetas
+func NewTagHandler(tagService serviceInterfaces.TagService, appLogger logger.Logger) *TagHandler {
+	return &TagHandler{
+		tagService: tagService,
+		logger:     appLogger,
+	}
+}
+
+// ListTags godoc
+// @Summary List tags
+// @Description List every thematic tag by slug with the number of active companies tagged with it. Slugs can be combined in the tags filter of the company listing and the top-rated ranking
+// @Tags tags
+// @Produce json
+// @Success 200 {object} response.APIResponse[[]response.TagResponse]
+// @Failure 500 {object} response.APIResponse[any]
+// @Router /api/v1/tags [get]
+func (h *TagHandler) ListTags(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	tags, err := h.tagService.ListTags(ctx)
+	if err != nil {
+		h.logger.Warn(ctx, "Tags retrieval failed",
+			logger.String("request_id", requestID),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Tag", "Failed to get tags")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(tags)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// GetCompanyTags godoc
+// @Summary Get company tags
+// @Description Get the tags of a company by ID or ticker, ordered by slug
+// @Tags tags
+// @Produce json
+// @Param id path string true "Company ID or ticker"
+// @Success 200 {object} response.APIResponse[response.CompanyTagsResponse]
+// @Failure 404 {object} response.APIResponse[any]
+// @Router /api/v1/companies/{id}/tags [get]
+func (h *TagHandler) GetCompanyTags(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	ref := c.Param("id")
+
+	tags, err := h.tagService.GetCompanyTags(ctx, ref)
+	if err != nil {
+		h.logger.Warn(ctx, "Company tags retrieval failed",
+			logger.String("request_id", requestID),
+			logger.String("company", ref),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Company", "Failed to get company tags")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(tags)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// CreateTag godoc
+// @Summary Create a tag
+// @Description Create a thematic tag. The slug is lowercased with its words joined by hyphens and, when omitted, derived from the name
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param tag body request.CreateTagRequest true "Tag"
+// @Success 201 {object} response.APIResponse[response.TagResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 409 {object} response.APIResponse[any]
+// @Router /api/v1/admin/tags [post]
+func (h *TagHandler) CreateTag(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	var req request.CreateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RespondWithError(c, middleware.ValidationErrorResponse(err))
+		return
+	}
+
+	tag, err := h.tagService.CreateTag(ctx, &req)
+	if err != nil {
+		h.logger.Warn(ctx, "Tag creation failed",
+			logger.String("request_id", requestID),
+			logger.String("name", req.Name),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Tag", "Failed to create tag")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(tag)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusCreated, apiResponse)
+}
+
+// UpdateTag godoc
+// @Summary Update a tag
+// @Description Change the name or description of a tag; the slug does not change
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param slug path string true "Tag slug"
+// @Param tag body request.UpdateTagRequest true "Fields to change"
+// @Success 200 {object} response.APIResponse[response.TagResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Router /api/v1/admin/tags/{slug} [put]
+func (h *TagHandler) UpdateTag(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	slug := c.Param("slug")
+
+	var req request.UpdateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RespondWithError(c, middleware.ValidationErrorResponse(err))
+		return
+	}
+
+	tag, err := h.tagService.UpdateTag(ctx, slug, &req)
+	if err != nil {
+		h.logger.Warn(ctx, "Tag update failed",
+			logger.String("request_id", requestID),
+			logger.String("tag", slug),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Tag", "Failed to update tag")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(tag)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// DeleteTag godoc
+// @Summary Delete a tag
+// @Description Delete a tag and remove it from every company
+// @Tags admin
+// @Param slug path string true "Tag slug"
+// @Success 204
+// @Failure 404 {object} response.APIResponse[any]
+// @Router /api/v1/admin/tags/{slug} [delete]
+func (h *TagHandler) DeleteTag(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	slug := c.Param("slug")
+
+	if err := h.tagService.DeleteTag(ctx, slug); err != nil {
+		h.logger.Warn(ctx, "Tag deletion failed",
+			logger.String("request_id", requestID),
+			logger.String("tag", slug),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Tag", "Failed to delete tag")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// TagCompany godoc
+// @Summary Tag a company
+// @Description Add a tag to a company and return the tags of the company. Tagging a company again changes nothing
+// @Tags admin
+// @Produce json
+// @Param slug path string true "Tag slug"
+// @Param company path string true "Company ID or ticker"
+// @Success 200 {object} response.APIResponse[response.CompanyTagsResponse]
+// @Failure 404 {object} response.APIResponse[any]
+// @Router /api/v1/admin/tags/{slug}/companies/{company} [put]
+func (h *TagHandler) TagCompany(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	slug, ref := c.Param("slug"), c.Param("company")
+
+	tags, err := h.tagService.TagCompany(ctx, slug, ref)
+	if err != nil {
+		h.logger.Warn(ctx, "Company tagging failed",
+			logger.String("request_id", requestID),
+			logger.String("tag", slug),
+			logger.String("company", ref),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Tag", "Failed to tag company")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(tags)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// UntagCompany godoc
+// @Summary Untag a company
+// @Description Remove a tag from a company
+// @Tags admin
+// @Param slug path string true "Tag slug"
+// @Param company path string true "Company ID or ticker"
+// @Success 204
+// @Failure 404 {object} response.APIResponse[any]
+// @Router /api/v1/admin/tags/{slug}/companies/{company} [delete]
+func (h *TagHandler) UntagCompany(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	slug, ref := c.Param("slug"), c.Param("company")
+
+	if err := h.tagService.UntagCompany(ctx, slug, ref); err != nil {
+		h.logger.Warn(ctx, "Company untagging failed",
+			logger.String("request_id", requestID),
+			logger.String("tag", slug),
+			logger.String("company", ref),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Tag", "Failed to untag company")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,370 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	serviceInterfaces "github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/tenancy"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
+)
+
+// TenantHandler maneja la administración de tenants y las API keys de cada tenant
+type TenantHandler struct {
+	tenantService serviceInterfaces.TenantService
+	logger        logger.Logger
+}
+
+// NewTenantHandler crea una nueva instancia del handler de tenants
+func NewTenantHandler(tenantService serviceInterfaces.TenantService, appLogger logger.Logger) *TenantHandler {
+	return &TenantHandler{
+		tenantService: tenantService,
+		logger:        appLogger,
+	}
+}
+
+// CreateTenant godoc
+// @Summary Create a tenant
+// @Description Create a customer of a shared deployment. The slug contains only lowercase letters, digits and hyphens
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body request.CreateTenantRequest true "Tenant"
+// @Success 201 {object} response.APIResponse[response.TenantResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 409 {object} response.APIResponse[any]
+// @Router /api/v1/admin/tenants [post]
+func (h *TenantHandler) CreateTenant(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	var req request.CreateTenantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RespondWithError(c, middleware.ValidationErrorResponse(err))
+		return
+	}
+
+	tenant, err := h.tenantService.CreateTenant(ctx, &req)
+	if err != nil {
+		h.logger.Warn(ctx, "Tenant creation failed",
+			logger.String("request_id", requestID),
+			logger.String("slug", req.Slug),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Tenant", "Failed to create tenant")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(tenant)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusCreated, apiResponse)
+}
+
+// ListTenants godoc
+// @Summary List tenants
+// @Description List the customers of the deployment
+// @Tags admin
+// @Produce json
+// @Success 200 {object} response.APIResponse[[]response.TenantResponse]
+// @Failure 500 {object} response.APIResponse[any]
+// @Router /api/v1/admin/tenants [get]
+func (h *TenantHandler) ListTenants(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	tenants, err := h.tenantService.ListTenants(ctx)
+	if err != nil {
+		h.logger.Error(ctx, "Failed to list tenants", err,
+			logger.String("request_id", requestID),
+		)
+
+		errorResp := response.FromError(err, "Tenant", "Failed to list tenants")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(tenants)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// UpdateTenant godoc
+// @Summary Activate or deactivate a tenant or change its plan
+// @Description The API keys and tokens of an inactive tenant are rejected with 401. A new plan applies its daily quotas from the next request
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Param request body request.UpdateTenantRequest true "Tenant status and plan"
+// @Success 200 {object} response.APIResponse[response.TenantResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Router /api/v1/admin/tenants/{id} [patch]
+func (h *TenantHandler) UpdateTenant(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	tenantID, ok := h.parseID(c, "Invalid tenant ID format")
+	if !ok {
+		return
+	}
+
+	var req request.UpdateTenantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RespondWithError(c, middleware.ValidationErrorResponse(err))
+		return
+	}
+
+	if req.Active == nil && req.Plan == nil {
+		middleware.RespondWithError(c, response.BadRequest("active or plan is required"))
+		return
+	}
+
+	var tenant *response.TenantResponse
+	var err error
+	if req.Plan != nil {
+		tenant, err = h.tenantService.SetTenantPlan(ctx, tenantID, *req.Plan)
+	}
+	if err == nil && req.Active != nil {
+		tenant, err = h.tenantService.SetTenantActive(ctx, tenantID, *req.Active)
+	}
+	if err != nil {
+		h.logger.Warn(ctx, "Tenant update failed",
+			logger.String("request_id", requestID),
+			logger.String("tenant_id", tenantID.String()),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Tenant", "Failed to update tenant")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(tenant)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// CreateTenantAPIKey godoc
+// @Summary Create an API key for a tenant
+// @Description Create the first (or a replacement) API key of a tenant. The key is only returned in this response
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Param request body request.CreateAPIKeyRequest true "API key"
+// @Success 201 {object} response.APIResponse[response.APIKeyCreatedResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Router /api/v1/admin/tenants/{id}/api-keys [post]
+func (h *TenantHandler) CreateTenantAPIKey(c *gin.Context) {
+	tenantID, ok := h.parseID(c, "Invalid tenant ID format")
+	if !ok {
+		return
+	}
+
+	// La ruta exige la clave de administración; la clave se crea en el ámbito del tenant indicado y, como mucho, con
+	// el rol admin del tenant, que no da acceso a las rutas de administración
+	principal := &tenancy.Principal{TenantID: tenantID, Method: tenancy.MethodAdminKey, Role: tenancy.RoleAdmin}
+	if caller, ok := tenancy.PrincipalFromContext(c.Request.Context()); ok {
+		principal.Subject = caller.Subject
+		principal.Method = caller.Method
+	}
+	c.Request = c.Request.WithContext(tenancy.WithPrincipal(c.Request.Context(), principal))
+	h.createAPIKey(c)
+}
+
+// GetCurrentTenant godoc
+// @Summary Get the current tenant
+// @Description Get the tenant and credential that authenticated the request
+// @Tags tenants
+// @Produce json
+// @Success 200 {object} response.APIResponse[response.CurrentTenantResponse]
+// @Failure 401 {object} response.APIResponse[any]
+// @Router /api/v1/me [get]
+func (h *TenantHandler) GetCurrentTenant(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	current, err := h.tenantService.CurrentTenant(ctx)
+	if err != nil {
+		errorResp := response.FromError(err, "Tenant", "Failed to get current tenant")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(current)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// ListAPIKeys godoc
+// @Summary List the API keys of the current tenant
+// @Description List the API keys of the tenant of the request, without their values
+// @Tags tenants
+// @Produce json
+// @Success 200 {object} response.APIResponse[[]response.APIKeyResponse]
+// @Failure 401 {object} response.APIResponse[any]
+// @Router /api/v1/me/api-keys [get]
+func (h *TenantHandler) ListAPIKeys(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	keys, err := h.tenantService.ListAPIKeys(ctx)
+	if err != nil {
+		errorResp := response.FromError(err, "API key", "Failed to list API keys")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(keys)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// CreateAPIKey godoc
+// @Summary Create an API key for the current tenant
+// @Description Create an API key for the tenant of the request. The key is only returned in this response
+// @Tags tenants
+// @Accept json
+// @Produce json
+// @Param request body request.CreateAPIKeyRequest true "API key"
+// @Success 201 {object} response.APIResponse[response.APIKeyCreatedResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 401 {object} response.APIResponse[any]
+// @Router /api/v1/me/api-keys [post]
+func (h *TenantHandler) CreateAPIKey(c *gin.Context) {
+	h.createAPIKey(c)
+}
+
+// RevokeAPIKey godoc
+// @Summary Revoke an API key of the current tenant
+// @Description Revoke an API key; requests using it are rejected with 401 from then on
+// @Tags tenants
+// @Produce json
+// @Param id path string true "API key ID"
+// @Success 204
+// @Failure 401 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Router /api/v1/me/api-keys/{id} [delete]
+func (h *TenantHandler) RevokeAPIKey(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	keyID, ok := h.parseID(c, "Invalid API key ID format")
+	if !ok {
+		return
+	}
+
+	if err := h.tenantService.RevokeAPIKey(ctx, keyID); err != nil {
+		h.logger.Warn(ctx, "API key revocation failed",
+			logger.String("request_id", requestID),
+			logger.String("api_key_id", keyID.String()),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "API key", "Failed to revoke API key")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// SetAPIKeyTimezone godoc
+// @Summary Set the time zone of an API key of the current tenant
+// @Description Set the IANA time zone used for "today" and "this week" in the requests made with the key; empty clears it
+// @Tags tenants
+// @Accept json
+// @Produce json
+// @Param id path string true "API key ID"
+// @Param request body request.SetAPIKeyTimezoneRequest true "Time zone"
+// @Success 200 {object} response.APIResponse[response.APIKeyResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 401 {object} response.APIResponse[any]
+// @Failure 404 {object} response.APIResponse[any]
+// @Router /api/v1/me/api-keys/{id}/timezone [put]
+func (h *TenantHandler) SetAPIKeyTimezone(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	keyID, ok := h.parseID(c, "Invalid API key ID format")
+	if !ok {
+		return
+	}
+
+	var body request.SetAPIKeyTimezoneRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		middleware.RespondWithError(c, middleware.ValidationErrorResponse(err))
+		return
+	}
+
+	key, err := h.tenantService.SetAPIKeyTimezone(ctx, keyID, &body)
+	if err != nil {
+		h.logger.Warn(ctx, "API key timezone update failed",
+			logger.String("request_id", requestID),
+			logger.String("api_key_id", keyID.String()),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "API key", "Failed to update API key")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(key)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// createAPIKey crea una API key para el tenant del contexto de la petición
+func (h *TenantHandler) createAPIKey(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	var body request.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		middleware.RespondWithError(c, middleware.ValidationErrorResponse(err))
+		return
+	}
+
+	key, err := h.tenantService.CreateAPIKey(ctx, &body)
+	if err != nil {
+		h.logger.Warn(ctx, "API key creation failed",
+			logger.String("request_id", requestID),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Tenant", "Failed to create API key")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(key)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusCreated, apiResponse)
+}
+
+// parseID lee el parámetro :id como UUID y responde 400 si no lo es
+func (h *TenantHandler) parseID(c *gin.Context, message string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.RespondWithError(c, response.BadRequest(message))
+		return uuid.Nil, false
+	}
+	return id, true
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	serviceInterfaces "github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
+)
+
+// TrendingHandler maneja la consulta de los símbolos más consultados
+type TrendingHandler struct {
+	trendingService serviceInterfaces.TrendingService
+	logger          logger.Logger
+}
+
+// NewTrendingHandler crea una nueva instancia del handler de trending
+func NewTrendingHandler(trendingService serviceInterfaces.TrendingService, appLogger logger.Logger) *TrendingHandler {
+	return &TrendingHandler{
+		trendingService: trendingService,
+		logger:          appLogger,
+	}
+}
+
+// GetTrending godoc
+// @Summary Get trending symbols
+// @Description Get the symbols with the most quote and profile requests in the last hours, counted per hour. The same ranking orders the scheduled quote refresh
+// @Tags analysis
+// @Accept json
+// @Produce json
+// @Param hours query int false "Window in hours (1-168)" default(24)
+// @Param limit query int false "Maximum symbols (1-100)" default(20)
+// @Success 200 {object} response.APIResponse[response.TrendingSymbolsResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 503 {object} response.APIResponse[any]
+// @Router /api/v1/analysis/trending [get]
+func (h *TrendingHandler) GetTrending(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	var req request.TrendingRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	result, err := h.trendingService.GetTrending(ctx, &req)
+	if err != nil {
+		h.logger.Warn(ctx, "Failed to get trending symbols",
+			logger.String("request_id", requestID),
+			logger.Int("hours", req.Hours),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Trending", "Failed to get trending symbols")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(result)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
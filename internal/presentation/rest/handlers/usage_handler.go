@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	serviceInterfaces "github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
+)
+
+// UsageHandler maneja las consultas del uso de la API por tenant y credencial
+type UsageHandler struct {
+	usageService serviceInterfaces.UsageService
+	logger       logger.Logger
+}
+
+// NewUsageHandler crea una nueva instancia del handler de uso de la API
+func NewUsageHandler(usageService serviceInterfaces.UsageService, appLogger logger.Logger) *UsageHandler {
+	return &UsageHandler{
+		usageService: usageService,
+		logger:       appLogger,
+	}
+}
+
+// GetMyUsage godoc
+// @Summary Get the API usage of the current tenant
+// @Description Get the requests, errors, provider calls and cache hits of the tenant of the request per credential and UTC day. The counters are flushed periodically, so the last minutes may not be included yet
+// @Tags tenants
+// @Produce json
+// @Param days query int false "UTC days including today (1-90)" default(7)
+// @Success 200 {object} response.APIResponse[response.UsageResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 401 {object} response.APIResponse[any]
+// @Router /api/v1/usage/me [get]
+func (h *UsageHandler) GetMyUsage(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	var req request.UsageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	result, err := h.usageService.GetMyUsage(ctx, &req)
+	if err != nil {
+		h.logger.Warn(ctx, "Failed to get API usage",
+			logger.String("request_id", requestID),
+			logger.String("error", err.Error()),
+		)
+
+		errorResp := response.FromError(err, "Usage", "Failed to get API usage")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(result)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
+
+// GetUsageRollup godoc
+// @Summary Get the API usage of every tenant
+// @Description Get the requests, errors, provider calls and cache hits of every tenant in the last UTC days, the most active first
+// @Tags admin
+// @Produce json
+// @Param days query int false "UTC days including today (1-90)" default(7)
+// @Success 200 {object} response.APIResponse[response.UsageRollupResponse]
+// @Failure 400 {object} response.APIResponse[any]
+// @Failure 500 {object} response.APIResponse[any]
+// @Router /api/v1/admin/usage [get]
+func (h *UsageHandler) GetUsageRollup(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	var req request.UsageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		errorResp := middleware.ValidationErrorResponse(err)
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	result, err := h.usageService.GetUsageRollup(ctx, &req)
+	if err != nil {
+		h.logger.Error(ctx, "Failed to get API usage roll-up", err,
+			logger.String("request_id", requestID),
+		)
+
+		errorResp := response.FromError(err, "Usage", "Failed to get API usage roll-up")
+		middleware.RespondWithError(c, errorResp)
+		return
+	}
+
+	apiResponse := response.Success(result)
+	apiResponse.RequestID = requestID
+
+	c.JSON(http.StatusOK, apiResponse)
+}
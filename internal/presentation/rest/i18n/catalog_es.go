@@ -0,0 +1,307 @@
+package i18n
+
+import (
+	"net/http"
+	"regexp"
+)
+
+// spanishCatalog traduce al español los mensajes de error, los títulos de problem+json y las etiquetas de recomendación
+var spanishCatalog = &catalog{
+	messages: map[string]string{
+		// Mensajes por defecto de los constructores de errores
+		"Authentication required":                         "Se requiere autenticación",
+		"Access denied":                                   "Acceso denegado",
+		"Internal server error":                           "Error interno del servidor",
+		"Service temporarily unavailable":                 "Servicio temporalmente no disponible",
+		"The current plan does not cover this operation":  "El plan actual no incluye esta operación",
+		"An unexpected error occurred":                    "Ocurrió un error inesperado",
+		"An error occurred while processing your request": "Ocurrió un error al procesar la petición",
+		"Response rendering failed":                       "No se pudo generar la respuesta",
+
+		// Autenticación, planes y límites
+		"Invalid API key":                                                                      "API key no válida",
+		"An API key or a bearer token is required":                                             "Se requiere una API key o un token bearer",
+		"Notes require the API key or bearer token of a user":                                  "Las notas requieren la API key o el token bearer de un usuario",
+		"Rate limit exceeded. Please try again later.":                                         "Se superó el límite de peticiones. Inténtalo de nuevo más tarde.",
+		"Daily request quota of the plan exceeded. Upgrade the plan or retry after the reset.": "Se superó la cuota diaria de peticiones del plan. Mejora el plan o reinténtalo tras el reinicio.",
+
+		// Validación de peticiones
+		"Request validation failed":                             "La validación de la petición falló",
+		"Validation failed":                                     "La validación falló",
+		"Invalid request body":                                  "Cuerpo de la petición no válido",
+		"Malformed JSON request body":                           "El cuerpo JSON de la petición está mal formado",
+		"Request body is required":                              "El cuerpo de la petición es obligatorio",
+		"Request does not match the API specification":          "La petición no cumple la especificación de la API",
+		"Invalid query parameters":                              "Parámetros de consulta no válidos",
+		"Invalid pagination parameters":                         "Parámetros de paginación no válidos",
+		"Invalid limit parameter":                               "Parámetro limit no válido",
+		"Invalid company ID format":                             "Formato de ID de empresa no válido",
+		"Invalid brokerage ID format":                           "Formato de ID de bróker no válido",
+		"Invalid stock rating ID format":                        "Formato de ID de calificación no válido",
+		"Invalid API key ID format":                             "Formato de ID de API key no válido",
+		"Symbol parameter is required":                          "El parámetro symbol es obligatorio",
+		"Ticker parameter is required":                          "El parámetro ticker es obligatorio",
+		"Symbol is required":                                    "El símbolo es obligatorio",
+		"Both start_date and end_date parameters are required":  "Los parámetros start_date y end_date son obligatorios",
+		"start_date must be a date in YYYY-MM-DD format":        "start_date debe ser una fecha con formato YYYY-MM-DD",
+		"end_date must be a date in YYYY-MM-DD format":          "end_date debe ser una fecha con formato YYYY-MM-DD",
+		"end_date must not be before start_date":                "end_date no puede ser anterior a start_date",
+		"from must be a date in YYYY-MM-DD format":              "from debe ser una fecha con formato YYYY-MM-DD",
+		"to must be a date in YYYY-MM-DD format":                "to debe ser una fecha con formato YYYY-MM-DD",
+		"date must be a date in YYYY-MM-DD format":              "date debe ser una fecha con formato YYYY-MM-DD",
+		"to must not be before from":                            "to no puede ser anterior a from",
+		"to must be a month in YYYY-MM format":                  "to debe ser un mes con formato YYYY-MM",
+		"alerts_since must be an RFC 3339 timestamp":            "alerts_since debe ser una fecha y hora RFC 3339",
+		"company_id and ticker cannot be combined":              "company_id y ticker no se pueden combinar",
+		"min_target cannot be greater than max_target":          "min_target no puede ser mayor que max_target",
+		"min_rating cannot be more bullish than max_rating":     "min_rating no puede ser más alcista que max_rating",
+		"statement must be one of income, balance or cash_flow": "statement debe ser income, balance o cash_flow",
+		"period must be annual or quarterly":                    "period debe ser annual o quarterly",
+
+		// Consultas y análisis
+		"Failed to get company":                                "No se pudo obtener la empresa",
+		"Failed to get companies":                              "No se pudieron obtener las empresas",
+		"Failed to get company stats":                          "No se pudieron obtener las estadísticas de la empresa",
+		"Failed to get brokerage":                              "No se pudo obtener el bróker",
+		"Failed to get brokerages":                             "No se pudieron obtener los brókers",
+		"Failed to get stock rating":                           "No se pudo obtener la calificación",
+		"Failed to get stock ratings":                          "No se pudieron obtener las calificaciones",
+		"Failed to search stock ratings":                       "No se pudieron buscar las calificaciones",
+		"Failed to poll new ratings":                           "No se pudieron consultar las calificaciones nuevas",
+		"Failed to get ratings as of date":                     "No se pudieron obtener las calificaciones a esa fecha",
+		"Failed to get since_id rating":                        "No se pudo obtener la calificación de since_id",
+		"Failed to get market overview":                        "No se pudo obtener el resumen del mercado",
+		"Failed to retrieve market overview":                   "No se pudo obtener el resumen del mercado",
+		"Failed to retrieve intraday quotes":                   "No se pudieron obtener las cotizaciones intradía",
+		"Failed to retrieve company analysis":                  "No se pudo obtener el análisis de la empresa",
+		"Failed to generate recommendation":                    "No se pudo generar la recomendación",
+		"Failed to compare companies":                          "No se pudieron comparar las empresas",
+		"Failed to get peers":                                  "No se pudieron obtener las empresas comparables",
+		"Failed to get financial statements":                   "No se pudieron obtener los estados financieros",
+		"Failed to fetch financial statements":                 "No se pudieron descargar los estados financieros",
+		"Failed to run backtest":                               "No se pudo ejecutar el backtest",
+		"Failed to compute portfolio risk":                     "No se pudo calcular el riesgo de la cartera",
+		"Failed to compute correlation matrix":                 "No se pudo calcular la matriz de correlación",
+		"Failed to get sector rotation":                        "No se pudo obtener la rotación sectorial",
+		"Failed to compute market breadth":                     "No se pudo calcular la amplitud del mercado",
+		"Historical prices are not available":                  "Los precios históricos no están disponibles",
+		"Failed to list benchmarks":                            "No se pudieron obtener los índices de referencia",
+		"Failed to get benchmark":                              "No se pudo obtener el índice de referencia",
+		"Failed to get benchmark values":                       "No se pudieron obtener los valores del índice de referencia",
+		"Failed to get benchmark constituents":                 "No se pudo obtener la composición del índice de referencia",
+		"Failed to sync benchmark":                             "No se pudo sincronizar el índice de referencia",
+		"Failed to fetch benchmark values":                     "No se pudieron descargar los valores del índice de referencia",
+		"Failed to store benchmark values":                     "No se pudieron guardar los valores del índice de referencia",
+		"Failed to store benchmark constituents":               "No se pudo guardar la composición del índice de referencia",
+		"Benchmark provider is not configured":                 "El proveedor de índices de referencia no está configurado",
+		"Failed to compute market cap stats":                   "No se pudieron calcular las estadísticas de capitalización",
+		"Exchange rates are not available":                     "Los tipos de cambio no están disponibles",
+		"Failed to fetch exchange rate":                        "No se pudo descargar el tipo de cambio",
+		"Failed to create custom benchmark":                    "No se pudo crear el índice personalizado",
+		"Failed to delete custom benchmark":                    "No se pudo eliminar el índice personalizado",
+		"Failed to get company relationships":                  "No se pudieron obtener las relaciones de la empresa",
+		"Failed to get company relationship":                   "No se pudo obtener la relación de la empresa",
+		"Failed to create company relationship":                "No se pudo crear la relación de la empresa",
+		"Failed to update company relationship":                "No se pudo actualizar la relación de la empresa",
+		"Failed to delete company relationship":                "No se pudo eliminar la relación de la empresa",
+		"Failed to get company graph":                          "No se pudo obtener el grafo de relaciones de la empresa",
+		"Invalid relationship ID format":                       "Formato de ID de relación no válido",
+		"a company cannot be related to itself":                "Una empresa no puede relacionarse consigo misma",
+		"types must be supplier, competitor or subsidiary":     "types debe ser supplier, competitor o subsidiary",
+		"Failed to get tags":                                   "No se pudieron obtener las etiquetas",
+		"Failed to get tag":                                    "No se pudo obtener la etiqueta",
+		"Failed to get company tags":                           "No se pudieron obtener las etiquetas de la empresa",
+		"Failed to create tag":                                 "No se pudo crear la etiqueta",
+		"Failed to update tag":                                 "No se pudo actualizar la etiqueta",
+		"Failed to delete tag":                                 "No se pudo eliminar la etiqueta",
+		"Failed to tag company":                                "No se pudo etiquetar la empresa",
+		"Failed to untag company":                              "No se pudo quitar la etiqueta de la empresa",
+		"Failed to get notes":                                  "No se pudieron obtener las notas",
+		"Failed to get note":                                   "No se pudo obtener la nota",
+		"Failed to create note":                                "No se pudo crear la nota",
+		"Failed to update note":                                "No se pudo actualizar la nota",
+		"Failed to delete note":                                "No se pudo eliminar la nota",
+		"Invalid note ID format":                               "Formato de ID de nota no válido",
+		"note body cannot be empty":                            "El texto de la nota no puede estar vacío",
+		"a note needs a company or a rating_id":                "Una nota necesita una empresa o un rating_id",
+		"the rating does not belong to the company":            "La calificación no pertenece a la empresa",
+		"tag slug must contain letters or digits":              "El slug de la etiqueta debe contener letras o dígitos",
+		"tag name cannot be empty":                             "El nombre de la etiqueta no puede estar vacío",
+		"Failed to compute custom benchmark":                   "No se pudo calcular el índice personalizado",
+		"only custom benchmarks can be deleted":                "Solo se pueden eliminar los índices personalizados",
+		"base_date must be before today":                       "base_date debe ser anterior a hoy",
+		"a custom benchmark needs at least 2 distinct tickers": "Un índice personalizado necesita al menos 2 tickers distintos",
+		"not enough stored prices to compute the benchmark; sync the historical data of its tickers first": "No hay suficientes precios guardados para calcular el índice; sincronice antes los datos históricos de sus tickers",
+		"Failed to fetch historical data":               "No se pudieron descargar los datos históricos",
+		"Failed to fetch technical indicators":          "No se pudieron descargar los indicadores técnicos",
+		"Failed to fetch fundamental data":              "No se pudieron descargar los datos fundamentales",
+		"Failed to fetch earnings data":                 "No se pudieron descargar los resultados trimestrales",
+		"Alpha Vantage rate limit reached; retry later": "Se alcanzó el límite de llamadas de Alpha Vantage; inténtalo más tarde",
+
+		// Escrituras
+		"Failed to create company":            "No se pudo crear la empresa",
+		"Failed to update company":            "No se pudo actualizar la empresa",
+		"Failed to delete company":            "No se pudo eliminar la empresa",
+		"Failed to activate company":          "No se pudo activar la empresa",
+		"Failed to deactivate company":        "No se pudo desactivar la empresa",
+		"Failed to restore company":           "No se pudo restaurar la empresa",
+		"Failed to merge companies":           "No se pudieron fusionar las empresas",
+		"Failed to update market cap":         "No se pudo actualizar la capitalización de mercado",
+		"Failed to create brokerage":          "No se pudo crear el bróker",
+		"Failed to update brokerage":          "No se pudo actualizar el bróker",
+		"Failed to delete brokerage":          "No se pudo eliminar el bróker",
+		"Failed to activate brokerage":        "No se pudo activar el bróker",
+		"Failed to deactivate brokerage":      "No se pudo desactivar el bróker",
+		"Failed to create stock rating":       "No se pudo crear la calificación",
+		"Failed to delete stock rating":       "No se pudo eliminar la calificación",
+		"Failed to restore stock rating":      "No se pudo restaurar la calificación",
+		"Failed to get deleted companies":     "No se pudieron obtener las empresas eliminadas",
+		"Failed to get deleted stock ratings": "No se pudieron obtener las calificaciones eliminadas",
+
+		// Funcionalidades sin configurar
+		"Job queue is not configured":          "La cola de jobs no está configurada",
+		"Company enrichment is not configured": "El enriquecimiento de empresas no está configurado",
+		"Rating polling is not configured":     "La consulta de calificaciones nuevas no está configurada",
+	},
+
+	patterns: []pattern{
+		{regexp.MustCompile(`^(.+) not found$`), func(c *catalog, m []string) (string, bool) {
+			resource, ok := c.resource(m[1])
+			return "No se encontró " + resource, ok
+		}},
+		{regexp.MustCompile(`^Companies (.+) not found$`), func(c *catalog, m []string) (string, bool) {
+			return "No se encontraron las empresas " + m[1], true
+		}},
+		{regexp.MustCompile(`^(.+) already exists$`), func(c *catalog, m []string) (string, bool) {
+			resource, ok := c.resource(m[1])
+			return "Ya existe " + resource, ok
+		}},
+		{regexp.MustCompile(`^(.+) conflicts with existing data$`), func(c *catalog, m []string) (string, bool) {
+			resource, ok := c.resource(m[1])
+			return capitalize(resource) + " entra en conflicto con datos existentes", ok
+		}},
+		{regexp.MustCompile(`^(.+) was modified by another request; reload it and retry$`), func(c *catalog, m []string) (string, bool) {
+			resource, ok := c.resource(m[1])
+			return "Otra petición modificó " + resource + "; recarga los datos y reinténtalo", ok
+		}},
+		{regexp.MustCompile(`^(\S+) is already a competitor of (\S+)$`), func(c *catalog, m []string) (string, bool) {
+			return m[1] + " ya es competidora de " + m[2], true
+		}},
+		{regexp.MustCompile(`^(\S+) is not tagged with (\S+)$`), func(c *catalog, m []string) (string, bool) {
+			return m[1] + " no tiene la etiqueta " + m[2], true
+		}},
+		fixed(`^tag slug must be at most (\d+) characters$`, "El slug de la etiqueta debe tener como máximo $1 caracteres"),
+		fixed(`^at most (\d+) tags can be combined$`, "Se pueden combinar como máximo $1 etiquetas"),
+		{regexp.MustCompile(`^Invalid tz parameter: unknown time zone (.+)$`), func(c *catalog, m []string) (string, bool) {
+			return "Parámetro tz no válido: zona horaria desconocida " + m[1], true
+		}},
+		{regexp.MustCompile(`^This operation requires the (\S+) role$`), func(c *catalog, m []string) (string, bool) {
+			return "Esta operación requiere el rol " + m[1], true
+		}},
+		{regexp.MustCompile(`^Rate limit exceeded for endpoint (.+)$`), func(c *catalog, m []string) (string, bool) {
+			return "Se superó el límite de peticiones del endpoint " + m[1], true
+		}},
+		{regexp.MustCompile(`^External API error \((.+?)\): (.+)$`), func(c *catalog, m []string) (string, bool) {
+			message, ok := c.messages[m[2]]
+			return "Error de la API externa (" + m[1] + "): " + message, ok
+		}},
+
+		// Mensajes de validación por campo (ver middleware.ValidationErrorResponse)
+		fixed(`^This field is required$`, "Este campo es obligatorio"),
+		fixed(`^Must be a valid email address$`, "Debe ser un correo electrónico válido"),
+		fixed(`^Must be at least (\S+) characters long$`, "Debe tener al menos $1 caracteres"),
+		fixed(`^Must be at least (\S+) items$`, "Debe tener al menos $1 elementos"),
+		fixed(`^Must be at least (.+)$`, "Debe ser como mínimo $1"),
+		fixed(`^Must be no more than (\S+) characters long$`, "Debe tener como máximo $1 caracteres"),
+		fixed(`^Must be no more than (\S+) items$`, "Debe tener como máximo $1 elementos"),
+		fixed(`^Must be no more than (.+)$`, "Debe ser como máximo $1"),
+		fixed(`^Must be exactly (\S+) characters long$`, "Debe tener exactamente $1 caracteres"),
+		fixed(`^Must be exactly (\S+) items$`, "Debe tener exactamente $1 elementos"),
+		fixed(`^Must be exactly (.+)$`, "Debe ser exactamente $1"),
+		fixed(`^Must be one of: (.+)$`, "Debe ser uno de: $1"),
+		fixed(`^Must be a valid URL$`, "Debe ser una URL válida"),
+		fixed(`^Must be a valid UUID$`, "Debe ser un UUID válido"),
+		fixed(`^Must contain only letters$`, "Solo puede contener letras"),
+		fixed(`^Must contain only letters and numbers$`, "Solo puede contener letras y números"),
+		fixed(`^Must be a numeric value$`, "Debe ser un valor numérico"),
+		fixed(`^Must be greater than or equal to (.+)$`, "Debe ser mayor o igual que $1"),
+		fixed(`^Must be greater than (.+)$`, "Debe ser mayor que $1"),
+		fixed(`^Must be less than or equal to (.+)$`, "Debe ser menor o igual que $1"),
+		fixed(`^Must be less than (.+)$`, "Debe ser menor que $1"),
+		fixed(`^Must be a valid datetime in format (.+)$`, "Debe ser una fecha válida con el formato $1"),
+		fixed(`^Must be of type (.+)$`, "Debe ser de tipo $1"),
+		fixed(`^Contains invalid elements$`, "Contiene elementos no válidos"),
+		fixed(`^Failed the '(.+)' validation$`, "No cumple la validación '$1'"),
+		fixed(`^unknown sort field (\S+) \(allowed: (.+)\)$`, "Campo de ordenación desconocido $1 (permitidos: $2)"),
+		fixed(`^duplicate sort field (\S+)$`, "Campo de ordenación repetido $1"),
+		fixed(`^invalid sort direction (\S+) for field (\S+) \(use asc or desc\)$`, "Dirección de ordenación $1 no válida para el campo $2 (usa asc o desc)"),
+	},
+
+	// Recursos de NotFound/FromError con su artículo; las variantes "X with ticker Y" se resuelven por prefijo
+	resources: map[string]string{
+		"API key":              "la API key",
+		"Anomaly":              "la anomalía",
+		"Backup":               "el backup",
+		"Brokerage":            "el bróker",
+		"Companies":            "las empresas",
+		"Company":              "la empresa",
+		"Company logo":         "el logo de la empresa",
+		"Company profile":      "el perfil de la empresa",
+		"Credential":           "la credencial",
+		"Deleted company":      "la empresa eliminada",
+		"Deleted stock rating": "la calificación eliminada",
+		"Endpoint":             "el endpoint",
+		"Enrichment conflict":  "el conflicto de enriquecimiento",
+		"Exchange":             "el mercado",
+		"Financial statements": "los estados financieros",
+		"Export":               "la exportación",
+		"Job":                  "el job",
+		"Market data":          "los datos de mercado",
+		"Note":                 "la nota",
+		"Population job":       "el job de población",
+		"Population reject":    "el rechazo de población",
+		"Provider payload":     "el payload del proveedor",
+		"Rating mapping":       "el mapeo de rating",
+		"Relationship":         "la relación",
+		"Route":                "la ruta",
+		"Stock rating":         "la calificación",
+		"Tag":                  "la etiqueta",
+		"Tenant":               "el tenant",
+		"Ticker":               "el ticker",
+	},
+	qualifiers: map[string]string{
+		"with symbol ": "con símbolo ",
+		"with ticker ": "con ticker ",
+		"with id ":     "con id ",
+		"with slug ":   "con slug ",
+		"for symbol ":  "del símbolo ",
+	},
+
+	titles: map[int]string{
+		http.StatusBadRequest:            "Petición incorrecta",
+		http.StatusUnauthorized:          "No autorizado",
+		http.StatusPaymentRequired:       "Pago requerido",
+		http.StatusForbidden:             "Prohibido",
+		http.StatusNotFound:              "No encontrado",
+		http.StatusMethodNotAllowed:      "Método no permitido",
+		http.StatusConflict:              "Conflicto",
+		http.StatusPreconditionFailed:    "Precondición fallida",
+		http.StatusRequestEntityTooLarge: "Contenido demasiado grande",
+		http.StatusUnprocessableEntity:   "Entidad no procesable",
+		http.StatusTooManyRequests:       "Demasiadas peticiones",
+		http.StatusInternalServerError:   "Error interno del servidor",
+		http.StatusNotImplemented:        "No implementado",
+		http.StatusBadGateway:            "Puerta de enlace incorrecta",
+		http.StatusServiceUnavailable:    "Servicio no disponible",
+		http.StatusGatewayTimeout:        "Tiempo de espera agotado",
+	},
+
+	labels: map[string]string{
+		"Strong Buy":        "Compra fuerte",
+		"Buy":               "Comprar",
+		"Hold":              "Mantener",
+		"Sell":              "Vender",
+		"Strong Sell":       "Venta fuerte",
+		"No data available": "Sin datos disponibles",
+	},
+}
@@ -0,0 +1,111 @@
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Language identifica un idioma soportado por los catálogos de mensajes (subtag primario de BCP 47)
+type Language string
+
+const (
+	English Language = "en"
+	Spanish Language = "es"
+
+	// DefaultLanguage se usa si Accept-Language falta o no incluye un idioma soportado.
+	// Los mensajes se escriben en inglés en el código, así que no necesita catálogo
+	DefaultLanguage = English
+)
+
+// languageContextKey guarda el idioma negociado en el contexto de gin
+const languageContextKey = "language"
+
+// SupportedLanguages lists the languages with a message catalog
+func SupportedLanguages() []Language {
+	return []Language{English, Spanish}
+}
+
+// IsSupported reports whether the language has a message catalog
+func IsSupported(lang Language) bool {
+	return lang == English || catalogs[lang] != nil
+}
+
+// ParseAcceptLanguage returns the supported language with the highest quality in an Accept-Language header.
+// Regional variants fall back to their primary language (es-CO -> es); ties keep the header order
+func ParseAcceptLanguage(header string) Language {
+	type candidate struct {
+		lang    Language
+		quality float64
+	}
+
+	candidates := make([]candidate, 0, 4)
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range strings.Split(params, ";") {
+			key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || strings.TrimSpace(key) != "q" {
+				continue
+			}
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				quality = 0
+			} else {
+				quality = parsed
+			}
+		}
+		if quality <= 0 {
+			continue
+		}
+
+		// "*" acepta cualquier idioma: se resuelve al idioma por defecto
+		lang := DefaultLanguage
+		if tag != "*" {
+			primary, _, _ := strings.Cut(tag, "-")
+			lang = Language(primary)
+		}
+		if IsSupported(lang) {
+			candidates = append(candidates, candidate{lang: lang, quality: quality})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return DefaultLanguage
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+	return candidates[0].lang
+}
+
+// LanguageMiddleware negotiates the response language from Accept-Language and stores it in the context
+func LanguageMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang := ParseAcceptLanguage(c.GetHeader("Accept-Language"))
+		c.Set(languageContextKey, lang)
+
+		// Las respuestas varían según Accept-Language: las caches compartidas deben tenerlo en cuenta
+		c.Header("Content-Language", string(lang))
+		c.Writer.Header().Add("Vary", "Accept-Language")
+
+		c.Next()
+	}
+}
+
+// FromContext returns the language negotiated for the request, or the default language
+func FromContext(c *gin.Context) Language {
+	if value, ok := c.Get(languageContextKey); ok {
+		if lang, ok := value.(Language); ok {
+			return lang
+		}
+	}
+	return DefaultLanguage
+}
@@ -0,0 +1,149 @@
+package i18n
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+)
+
+// catalog agrupa las traducciones de un idioma. Los mensajes se indexan por su texto en inglés (estilo gettext),
+// así los servicios siguen construyendo errores como hasta ahora y la traducción ocurre al responder
+type catalog struct {
+	messages   map[string]string // Mensajes fijos
+	patterns   []pattern         // Mensajes con partes variables, en orden de prioridad
+	resources  map[string]string // Nombres de recurso de NotFound/FromError
+	qualifiers map[string]string // Complementos de los recursos ("with ticker " ...)
+	titles     map[int]string    // Títulos de problem+json por status
+	labels     map[string]string // Etiquetas de recomendación
+}
+
+// pattern traduce los mensajes que coinciden con re; render devuelve false si no sabe traducir las partes variables
+type pattern struct {
+	re     *regexp.Regexp
+	render func(c *catalog, matches []string) (string, bool)
+}
+
+// fixed crea un pattern cuya traducción es una plantilla con $1, $2...
+func fixed(expr, template string) pattern {
+	re := regexp.MustCompile(expr)
+	return pattern{re: re, render: func(c *catalog, matches []string) (string, bool) {
+		return re.ReplaceAllString(matches[0], template), true
+	}}
+}
+
+// catalogs contiene los idiomas con traducción; el inglés no la necesita
+var catalogs = map[Language]*catalog{
+	Spanish: spanishCatalog,
+}
+
+// resource traduce un nombre de recurso, admitiendo complementos como "Company with ticker AAPL"
+func (c *catalog) resource(name string) (string, bool) {
+	if translated, ok := c.resources[name]; ok {
+		return translated, true
+	}
+
+	// Prefijo de recurso más largo primero: "Company profile for symbol X" antes que "Company ..."
+	prefixes := make([]string, 0, len(c.resources))
+	for prefix := range c.resources {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Slice(prefixes, func(i, j int) bool { return len(prefixes[i]) > len(prefixes[j]) })
+
+	for _, prefix := range prefixes {
+		rest, ok := strings.CutPrefix(name, prefix+" ")
+		if !ok {
+			continue
+		}
+		for qualifier, translated := range c.qualifiers {
+			if value, ok := strings.CutPrefix(rest, qualifier); ok {
+				return c.resources[prefix] + " " + translated + value, true
+			}
+		}
+	}
+	return name, false
+}
+
+// capitalize pone en mayúscula la primera letra de un recurso traducido ("la empresa" -> "La empresa")
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// Translate returns the message in the given language. Messages without translation are returned unchanged,
+// so a missing catalog entry degrades to the English text instead of failing
+func Translate(lang Language, message string) string {
+	c := catalogs[lang]
+	if c == nil || message == "" {
+		return message
+	}
+
+	if translated, ok := c.messages[message]; ok {
+		return translated
+	}
+	for _, p := range c.patterns {
+		matches := p.re.FindStringSubmatch(message)
+		if matches == nil {
+			continue
+		}
+		if translated, ok := p.render(c, matches); ok {
+			return translated
+		}
+	}
+	return message
+}
+
+// StatusTitle returns the localized title of an HTTP status used in problem+json responses
+func StatusTitle(lang Language, status int) string {
+	if c := catalogs[lang]; c != nil {
+		if title, ok := c.titles[status]; ok {
+			return title
+		}
+	}
+	return http.StatusText(status)
+}
+
+// RecommendationLabel returns the localized label of a recommendation or consensus rating ("Buy", "Hold"...)
+func RecommendationLabel(lang Language, recommendation string) string {
+	if c := catalogs[lang]; c != nil {
+		if label, ok := c.labels[recommendation]; ok {
+			return label
+		}
+	}
+	return recommendation
+}
+
+// LocalizeError returns a copy of the error with its message and per-field validation messages translated.
+// The code, status and details are kept: clients should branch on the code, not on the message
+func LocalizeError(lang Language, errorResp *response.ErrorResponse) *response.ErrorResponse {
+	if catalogs[lang] == nil || errorResp == nil {
+		return errorResp
+	}
+
+	localized := *errorResp
+	localized.Message = Translate(lang, errorResp.Message)
+	if len(errorResp.ValidationErrors) > 0 {
+		localized.ValidationErrors = make([]response.ValidationError, len(errorResp.ValidationErrors))
+		for i, fieldError := range errorResp.ValidationErrors {
+			fieldError.Message = Translate(lang, fieldError.Message)
+			localized.ValidationErrors[i] = fieldError
+		}
+
+		// El envelope legacy repite los errores por campo en details.validation_errors
+		if _, ok := errorResp.Details["validation_errors"]; ok {
+			localized.Details = make(map[string]interface{}, len(errorResp.Details))
+			for key, value := range errorResp.Details {
+				localized.Details[key] = value
+			}
+			localized.Details["validation_errors"] = localized.ValidationErrors
+		}
+	}
+	return &localized
+}
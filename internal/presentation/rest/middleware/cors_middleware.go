@@ -3,7 +3,6 @@ package middleware
 import (
 	"net/http"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/gin-contrib/cors"
@@ -21,17 +20,14 @@ func CORSMiddleware(corsConfig config.CORSConfig) gin.HandlerFunc {
 		})
 	}
 
-	// Configure gin-contrib/cors
+	// Configure gin-contrib/cors. Todos los orígenes se validan con IsOriginAllowed, que devuelve el
+	// origen concreto en Access-Control-Allow-Origin (necesario con credenciales, incluso con "*")
 	corsMiddleware := cors.New(cors.Config{
-		AllowOrigins:     corsConfig.AllowOrigins,
 		AllowMethods:     corsConfig.AllowMethods,
 		AllowHeaders:     corsConfig.AllowHeaders,
 		ExposeHeaders:    corsConfig.ExposeHeaders,
 		AllowCredentials: corsConfig.AllowCredentials,
 		MaxAge:           corsConfig.MaxAge,
-		AllowWildcard:    corsConfig.AllowWildcard,
-
-		// Custom origin validation if needed
 		AllowOriginFunc: func(origin string) bool {
 			return corsConfig.IsOriginAllowed(origin)
 		},
@@ -129,28 +125,3 @@ func ProductionCORSMiddleware(allowedOrigins []string) gin.HandlerFunc {
 		c.Next()
 	})
 }
-
-// validateOrigin checks if an origin matches allowed patterns
-func validateOrigin(origin string, allowedOrigins []string) bool {
-	if origin == "" {
-		return false
-	}
-
-	for _, allowed := range allowedOrigins {
-		if allowed == "*" {
-			return true
-		}
-		if allowed == origin {
-			return true
-		}
-		// Support wildcard subdomains (e.g., *.example.com)
-		if strings.HasPrefix(allowed, "*.") {
-			domain := strings.TrimPrefix(allowed, "*.")
-			if strings.HasSuffix(origin, "."+domain) || origin == domain {
-				return true
-			}
-		}
-	}
-
-	return false
-}
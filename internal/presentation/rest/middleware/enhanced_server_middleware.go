@@ -198,7 +198,9 @@ func ResourceMonitoringMiddleware(serverLogger logger.ServerLogger, interval tim
 func HealthCheckMiddleware(serverLogger logger.ServerLogger) gin.HandlerFunc {
 	return gin.HandlerFunc(func(c *gin.Context) {
 		// Solo aplicar en rutas de health check
-		if c.Request.URL.Path != "/health" && c.Request.URL.Path != "/health/ready" && c.Request.URL.Path != "/health/live" {
+		switch c.Request.URL.Path {
+		case "/health", "/health/ready", "/health/live", "/healthz", "/readyz":
+		default:
 			c.Next()
 			return
 		}
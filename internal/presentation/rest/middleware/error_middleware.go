@@ -13,8 +13,31 @@ import (
 
 	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/i18n"
 )
 
+// RespondWithError writes an error response using the configured format:
+// application/problem+json (RFC 7807) or the legacy APIResponse envelope
+func RespondWithError(c *gin.Context, errorResp *response.ErrorResponse) {
+	requestID := c.GetString("request_id")
+
+	// Mensajes en el idioma negociado por Accept-Language; el código de error no se traduce
+	lang := i18n.FromContext(c)
+	errorResp = i18n.LocalizeError(lang, errorResp)
+
+	if response.ProblemDetailsEnabled() {
+		problem := errorResp.ToProblem(requestID)
+		problem.Title = i18n.StatusTitle(lang, errorResp.StatusCode)
+		c.Header("Content-Type", response.ProblemContentType)
+		c.JSON(errorResp.StatusCode, problem)
+		return
+	}
+
+	apiResponse := errorResp.ToAPIResponse()
+	apiResponse.RequestID = requestID
+	c.JSON(errorResp.StatusCode, apiResponse)
+}
+
 // ErrorHandlingMiddleware provides centralized error handling
 func ErrorHandlingMiddleware(appLogger logger.Logger) gin.HandlerFunc {
 	return gin.HandlerFunc(func(c *gin.Context) {
@@ -39,7 +62,7 @@ func ErrorHandlingMiddleware(appLogger logger.Logger) gin.HandlerFunc {
 
 				// Return internal server error
 				errorResp := response.InternalServerError("An unexpected error occurred")
-				c.JSON(errorResp.StatusCode, errorResp.ToAPIResponse())
+				RespondWithError(c, errorResp)
 				c.Abort()
 			}
 		}()
@@ -70,16 +93,16 @@ func handleGinErrors(c *gin.Context, appLogger logger.Logger) {
 	if errors.As(err, &errorResp) {
 		// Log the structured error
 		logStructuredError(ctx, appLogger, errorResp, c)
-		c.JSON(errorResp.StatusCode, errorResp.ToAPIResponse())
+		RespondWithError(c, errorResp)
 		return
 	}
 
 	// Handle validation errors
 	var validationErrors validator.ValidationErrors
 	if errors.As(err, &validationErrors) {
-		errorResp = handleValidationErrors(validationErrors)
+		errorResp = ValidationErrorResponse(validationErrors)
 		logStructuredError(ctx, appLogger, errorResp, c)
-		c.JSON(errorResp.StatusCode, errorResp.ToAPIResponse())
+		RespondWithError(c, errorResp)
 		return
 	}
 
@@ -87,7 +110,7 @@ func handleGinErrors(c *gin.Context, appLogger logger.Logger) {
 	if strings.Contains(err.Error(), "bind") || strings.Contains(err.Error(), "unmarshal") {
 		errorResp = response.BadRequest("Invalid request format: " + err.Error())
 		logStructuredError(ctx, appLogger, errorResp, c)
-		c.JSON(errorResp.StatusCode, errorResp.ToAPIResponse())
+		RespondWithError(c, errorResp)
 		return
 	}
 
@@ -112,74 +135,7 @@ func handleGinErrors(c *gin.Context, appLogger logger.Logger) {
 	}
 
 	logStructuredError(ctx, appLogger, errorResp, c)
-	c.JSON(errorResp.StatusCode, errorResp.ToAPIResponse())
-}
-
-// handleValidationErrors converts validation errors to structured response
-func handleValidationErrors(validationErrors validator.ValidationErrors) *response.ErrorResponse {
-	var fieldErrors []response.ValidationError
-
-	for _, fieldError := range validationErrors {
-		fieldErrors = append(fieldErrors, response.ValidationError{
-			Field:   getJSONFieldName(fieldError),
-			Message: getValidationErrorMessage(fieldError),
-			Value:   fmt.Sprintf("%v", fieldError.Value()),
-		})
-	}
-
-	return response.ValidationFailedWithFields(fieldErrors)
-}
-
-// getJSONFieldName extracts the JSON field name from validation error
-func getJSONFieldName(fieldError validator.FieldError) string {
-	// Convert struct field name to JSON field name
-	// This is a simplified version - in production you might want to use struct tags
-	fieldName := fieldError.Field()
-
-	// Convert PascalCase to snake_case for JSON
-	var result strings.Builder
-	for i, r := range fieldName {
-		if i > 0 && 'A' <= r && r <= 'Z' {
-			result.WriteRune('_')
-		}
-		result.WriteRune(r)
-	}
-
-	return strings.ToLower(result.String())
-}
-
-// getValidationErrorMessage returns a human-readable validation error message
-func getValidationErrorMessage(fieldError validator.FieldError) string {
-	switch fieldError.Tag() {
-	case "required":
-		return "This field is required"
-	case "email":
-		return "Must be a valid email address"
-	case "min":
-		return fmt.Sprintf("Must be at least %s characters long", fieldError.Param())
-	case "max":
-		return fmt.Sprintf("Must be no more than %s characters long", fieldError.Param())
-	case "len":
-		return fmt.Sprintf("Must be exactly %s characters long", fieldError.Param())
-	case "oneof":
-		return fmt.Sprintf("Must be one of: %s", fieldError.Param())
-	case "url":
-		return "Must be a valid URL"
-	case "uuid":
-		return "Must be a valid UUID"
-	case "gt":
-		return fmt.Sprintf("Must be greater than %s", fieldError.Param())
-	case "gte":
-		return fmt.Sprintf("Must be greater than or equal to %s", fieldError.Param())
-	case "lt":
-		return fmt.Sprintf("Must be less than %s", fieldError.Param())
-	case "lte":
-		return fmt.Sprintf("Must be less than or equal to %s", fieldError.Param())
-	case "datetime":
-		return fmt.Sprintf("Must be a valid datetime in format %s", fieldError.Param())
-	default:
-		return fmt.Sprintf("Invalid value for %s", fieldError.Field())
-	}
+	RespondWithError(c, errorResp)
 }
 
 // logStructuredError logs structured error with appropriate level
@@ -215,7 +171,7 @@ func logStructuredError(ctx context.Context, appLogger logger.Logger, errorResp
 func NotFoundMiddleware() gin.HandlerFunc {
 	return gin.HandlerFunc(func(c *gin.Context) {
 		errorResp := response.NotFound("Endpoint")
-		c.JSON(errorResp.StatusCode, errorResp.ToAPIResponse())
+		RespondWithError(c, errorResp)
 	})
 }
 
@@ -227,6 +183,6 @@ func MethodNotAllowedMiddleware() gin.HandlerFunc {
 			fmt.Sprintf("Method %s not allowed for this endpoint", c.Request.Method),
 			http.StatusMethodNotAllowed,
 		)
-		c.JSON(errorResp.StatusCode, errorResp.ToAPIResponse())
+		RespondWithError(c, errorResp)
 	})
 }
@@ -0,0 +1,224 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/gin-gonic/gin"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+)
+
+// OpenAPIValidator valida las peticiones entrantes contra la especificación generada por swag
+// (Swagger 2.0) o una especificación OpenAPI 3, para que la documentación y la API no diverjan
+type OpenAPIValidator struct {
+	router routers.Router
+}
+
+// LoadOpenAPIValidator crea el validador a partir del fichero de especificación (p.ej. docs/swagger.json)
+func LoadOpenAPIValidator(specPath string) (*OpenAPIValidator, error) {
+	spec, err := os.ReadFile(specPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenAPI spec: %w", err)
+	}
+	return NewOpenAPIValidator(spec)
+}
+
+// NewOpenAPIValidator crea el validador a partir del contenido JSON de la especificación
+func NewOpenAPIValidator(spec []byte) (*OpenAPIValidator, error) {
+	doc, err := loadOpenAPIDocument(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	router, err := legacy.NewRouter(doc, openapi3.DisableExamplesValidation())
+	if err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI spec: %w", err)
+	}
+
+	return &OpenAPIValidator{router: router}, nil
+}
+
+// loadOpenAPIDocument carga la especificación (convirtiendo Swagger 2.0 a OpenAPI 3) y mueve el path
+// base de los servers a las rutas, para validar por path sin depender del host con que se generó
+func loadOpenAPIDocument(spec []byte) (*openapi3.T, error) {
+	var version struct {
+		Swagger string `json:"swagger"`
+	}
+	if err := json.Unmarshal(spec, &version); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI spec: %w", err)
+	}
+
+	var doc *openapi3.T
+	basePath := ""
+	if strings.HasPrefix(version.Swagger, "2") {
+		var doc2 openapi2.T
+		if err := json.Unmarshal(spec, &doc2); err != nil {
+			return nil, fmt.Errorf("failed to parse Swagger 2.0 spec: %w", err)
+		}
+		converted, err := openapi2conv.ToV3(&doc2)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert Swagger 2.0 spec: %w", err)
+		}
+		doc = converted
+		// Sin host la conversión no genera servers: el basePath se toma directamente
+		basePath = doc2.BasePath
+	} else {
+		loaded, err := openapi3.NewLoader().LoadFromData(spec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load OpenAPI spec: %w", err)
+		}
+		doc = loaded
+	}
+
+	if basePath == "" && len(doc.Servers) > 0 {
+		if serverURL, err := url.Parse(doc.Servers[0].URL); err == nil {
+			basePath = serverURL.Path
+		}
+	}
+	basePath = strings.TrimSuffix(basePath, "/")
+	if basePath != "" && doc.Paths != nil {
+		paths := openapi3.NewPaths()
+		for path, item := range doc.Paths.Map() {
+			paths.Set(basePath+path, item)
+		}
+		doc.Paths = paths
+	}
+	doc.Servers = nil
+
+	return doc, nil
+}
+
+// OpenAPIValidationMiddleware rechaza con 400 las peticiones cuyos parámetros o cuerpo no cumplen la
+// especificación. Las rutas que no están documentadas pasan sin validar.
+func OpenAPIValidationMiddleware(validator *OpenAPIValidator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route, pathParams, err := validator.router.FindRoute(c.Request)
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: pathParams,
+			Route:      route,
+			Options: &openapi3filter.Options{
+				MultiError: true,
+				// La autenticación la resuelven los middlewares propios de cada grupo de rutas
+				AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+			},
+		}
+
+		if err := openapi3filter.ValidateRequest(c.Request.Context(), input); err != nil {
+			RespondWithError(c, OpenAPIValidationErrorResponse(err))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// OpenAPIValidationErrorResponse traduce los errores de validación de la especificación a una respuesta
+// ValidationFailed con un error por campo, igual que los errores de binding
+func OpenAPIValidationErrorResponse(err error) *response.ErrorResponse {
+	fieldErrors := make([]response.ValidationError, 0)
+	for _, validationErr := range flattenOpenAPIErrors(err) {
+		fieldErrors = append(fieldErrors, openAPIFieldErrors(validationErr)...)
+	}
+	if len(fieldErrors) == 0 {
+		return response.ValidationFailed("Request does not match the API specification")
+	}
+	return response.ValidationFailedWithFields(fieldErrors)
+}
+
+// flattenOpenAPIErrors despliega los MultiError anidados. Solo los del nivel actual: un RequestError
+// también envuelve un MultiError, pero hay que conservarlo para saber a qué parámetro corresponde.
+func flattenOpenAPIErrors(err error) []error {
+	if multi, ok := err.(openapi3.MultiError); ok {
+		flattened := make([]error, 0, len(multi))
+		for _, inner := range multi {
+			flattened = append(flattened, flattenOpenAPIErrors(inner)...)
+		}
+		return flattened
+	}
+	return []error{err}
+}
+
+// openAPIFieldErrors convierte un error de parámetro o de cuerpo en errores por campo
+func openAPIFieldErrors(err error) []response.ValidationError {
+	var requestErr *openapi3filter.RequestError
+	if !errors.As(err, &requestErr) {
+		return []response.ValidationError{{Field: "request", Tag: "spec", Message: capitalize(err.Error())}}
+	}
+
+	field := "body"
+	if requestErr.Parameter != nil {
+		field = requestErr.Parameter.Name
+	}
+
+	schemaErrors := flattenOpenAPIErrors(requestErr.Err)
+	fieldErrors := make([]response.ValidationError, 0, len(schemaErrors))
+	for _, inner := range schemaErrors {
+		var schemaErr *openapi3.SchemaError
+		if inner == nil || !errors.As(inner, &schemaErr) {
+			continue
+		}
+
+		name := field
+		if pointer := schemaErr.JSONPointer(); requestErr.Parameter == nil && len(pointer) > 0 {
+			name = strings.Join(pointer, ".")
+		}
+		fieldErrors = append(fieldErrors, response.ValidationError{
+			Field:   name,
+			Tag:     schemaErr.SchemaField,
+			Message: capitalize(schemaErr.Reason),
+			Value:   schemaValue(schemaErr.Value),
+		})
+	}
+
+	if len(fieldErrors) == 0 {
+		reason := requestErr.Reason
+		if reason == "" && requestErr.Err != nil {
+			reason = requestErr.Err.Error()
+		}
+		tag := "spec"
+		if strings.Contains(reason, "required") || errors.Is(requestErr.Err, openapi3filter.ErrInvalidRequired) {
+			tag = "required"
+		}
+		fieldErrors = append(fieldErrors, response.ValidationError{Field: field, Tag: tag, Message: capitalize(reason)})
+	}
+	return fieldErrors
+}
+
+// schemaValue devuelve el valor rechazado si es escalar (los objetos no se repiten en la respuesta)
+func schemaValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil, map[string]interface{}, []interface{}:
+		return ""
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// capitalize pone en mayúscula la primera letra, como el resto de mensajes de validación
+func capitalize(message string) string {
+	runes := []rune(message)
+	if len(runes) == 0 {
+		return message
+	}
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
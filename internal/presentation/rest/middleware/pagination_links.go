@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+)
+
+// RespondWithPage writes a successful paginated response. The pagination metadata gets first/prev/next/last links
+// built from the request URL, which are also sent in an RFC 5988 Link header, so clients never assemble page URLs
+func RespondWithPage[T any](c *gin.Context, apiResponse *response.APIResponse[*response.PaginatedResponse[T]]) {
+	apiResponse.RequestID = c.GetString("request_id")
+
+	if page := apiResponse.Data; page != nil {
+		page.Meta.Links = response.NewPaginationLinks(c.Request.URL, page.Meta)
+		c.Header("Link", page.Meta.Links.Header())
+	}
+
+	c.JSON(http.StatusOK, apiResponse)
+}
@@ -1,18 +1,29 @@
 package middleware
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"math"
 	"net/http"
 	"strconv"
 	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 
 	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/domain/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/tenancy"
+	"github.com/MayaCris/stock-info-app/internal/domain/usage"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/config"
 )
 
+// rateLimitKeyPrefix prefijo de los buckets de rate limiting en la cache
+const rateLimitKeyPrefix = "ratelimit:"
+
 // RateLimiter interface for different rate limiting implementations
 type RateLimiter interface {
 	Allow(key string) bool
@@ -160,7 +171,7 @@ func RateLimitMiddleware(rateLimitConfig config.RateLimitConfig) gin.HandlerFunc
 				"retry_after":    int(time.Until(reset).Seconds()),
 			})
 
-			c.JSON(errorResp.StatusCode, errorResp.ToAPIResponse())
+			RespondWithError(c, errorResp)
 			c.Abort()
 			return
 		}
@@ -234,7 +245,7 @@ func PerEndpointRateLimitMiddleware(limits map[string]config.RateLimitConfig) gi
 				http.StatusTooManyRequests,
 			)
 
-			c.JSON(errorResp.StatusCode, errorResp.ToAPIResponse())
+			RespondWithError(c, errorResp)
 			c.Abort()
 			return
 		}
@@ -250,3 +261,230 @@ func PerEndpointRateLimitMiddleware(limits map[string]config.RateLimitConfig) gi
 		c.Next()
 	}
 }
+
+// RateLimitSettings guarda la configuración de rate limiting vigente para poder cambiar
+// límites, ventana y API keys en caliente sin recrear los middlewares
+type RateLimitSettings struct {
+	mu     sync.RWMutex
+	config config.RateLimitConfig
+}
+
+// NewRateLimitSettings crea los ajustes compartidos a partir de la configuración inicial
+func NewRateLimitSettings(rateLimitConfig config.RateLimitConfig) *RateLimitSettings {
+	return &RateLimitSettings{config: rateLimitConfig}
+}
+
+// Get returns the rate limiting configuration currently in effect
+func (s *RateLimitSettings) Get() config.RateLimitConfig {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.config
+}
+
+// Update reemplaza la configuración vigente; las siguientes requests usan los nuevos límites
+func (s *RateLimitSettings) Update(rateLimitConfig config.RateLimitConfig) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.config = rateLimitConfig
+}
+
+// TokenBucketRateLimitMiddleware crea un rate limiting por token bucket compartido entre instancias a través
+// del cache service (Redis). Cada grupo de rutas tiene su propio bucket por cliente y los clientes con API key
+// registrada usan su propio límite. Responde con los headers estándar RateLimit-* y 429 al agotarse el bucket.
+func TokenBucketRateLimitMiddleware(cacheService services.CacheService, rateLimitConfig config.RateLimitConfig, group string) gin.HandlerFunc {
+	return TokenBucketRateLimitMiddlewareWithSettings(cacheService, NewRateLimitSettings(rateLimitConfig), group)
+}
+
+// TokenBucketRateLimitMiddlewareWithSettings es TokenBucketRateLimitMiddleware leyendo los límites en cada
+// request, de forma que una recarga de configuración se aplica sin reiniciar. Activar o desactivar el
+// rate limiting sigue requiriendo un reinicio, igual que el rate limiter en memoria (sin cache service).
+func TokenBucketRateLimitMiddlewareWithSettings(cacheService services.CacheService, settings *RateLimitSettings, group string) gin.HandlerFunc {
+	initialConfig := settings.Get()
+	if !initialConfig.Enabled {
+		return gin.HandlerFunc(func(c *gin.Context) {
+			c.Next()
+		})
+	}
+
+	// Sin cache service se usa el rate limiter en memoria con el límite del grupo
+	if cacheService == nil {
+		groupConfig := initialConfig
+		groupConfig.Limit = initialConfig.LimitFor(group, "")
+		return RateLimitMiddleware(groupConfig)
+	}
+
+	return func(c *gin.Context) {
+		rateLimitConfig := settings.Get()
+		window := rateLimitConfig.RequestsPer
+
+		identity, apiKey := getClientIdentity(c, rateLimitConfig)
+		limit := rateLimitConfig.LimitFor(group, apiKey)
+		bucketKey := rateLimitKeyPrefix + group + ":" + identity
+
+		result, err := cacheService.TakeToken(c.Request.Context(), bucketKey, limit, window)
+		if err != nil {
+			// Si la cache no responde no se bloquea el tráfico
+			c.Next()
+			return
+		}
+
+		setRateLimitHeaders(c, result, window)
+
+		if !result.Allowed {
+			retryAfter := durationToSeconds(result.RetryAfter)
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+
+			errorResp := response.NewErrorResponse(
+				response.ErrCodeRateLimitExceeded,
+				"Rate limit exceeded. Please try again later.",
+				http.StatusTooManyRequests,
+			).WithDetails(map[string]interface{}{
+				"group":          group,
+				"limit":          result.Limit,
+				"window_seconds": int(window.Seconds()),
+				"retry_after":    retryAfter,
+			})
+
+			RespondWithError(c, errorResp)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// getClientIdentity identifica al cliente para el rate limiting y devuelve la API key cuyo límite propio aplica.
+// El header no está autenticado, así que una clave solo cuenta si está verificada: una clave registrada en
+// APIKeyLimits (el propio secreto de la configuración), y con un principal solo si es la que lo autenticó. Con
+// KeyFunc "api_key" el bucket es la credencial del principal autenticado; las claves desconocidas usan la IP,
+// de forma que rotar claves inventadas no da un bucket nuevo en cada petición
+func getClientIdentity(c *gin.Context, rateLimitConfig config.RateLimitConfig) (string, string) {
+	apiKey := ""
+	if rateLimitConfig.APIKeyHeader != "" {
+		apiKey = c.GetHeader(rateLimitConfig.APIKeyHeader)
+	}
+	_, registered := rateLimitConfig.APIKeyLimits[apiKey]
+	registered = registered && apiKey != ""
+
+	principal, authenticated := tenancy.PrincipalFromContext(c.Request.Context())
+	if registered && (!authenticated || c.GetString(authenticatedKeyContextKey) == apiKeyFingerprint(apiKey)) {
+		return "key:" + apiKeyFingerprint(apiKey), apiKey
+	}
+	if authenticated && rateLimitConfig.KeyFunc == "api_key" {
+		credential := principal.Credential()
+		if credential == "" {
+			credential = principal.Method
+		}
+		return "credential:" + credential, ""
+	}
+
+	return getKeyForRequest(c, rateLimitConfig.KeyFunc), ""
+}
+
+// apiKeyFingerprint identifica una API key sin guardarla en claro en la cache ni en el contexto
+func apiKeyFingerprint(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:8])
+}
+
+// setRateLimitHeaders añade los headers RateLimit-* (draft IETF httpapi-ratelimit-headers)
+func setRateLimitHeaders(c *gin.Context, result services.TokenBucketResult, window time.Duration) {
+	c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+	c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	c.Header("RateLimit-Reset", strconv.Itoa(durationToSeconds(result.ResetAfter)))
+	c.Header("RateLimit-Policy", fmt.Sprintf("%d;w=%d", result.Limit, int(window.Seconds())))
+}
+
+// durationToSeconds redondea hacia arriba a segundos enteros
+func durationToSeconds(d time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+	return int(math.Ceil(d.Seconds()))
+}
+
+// PlanQuotaMiddleware aplica las cuotas diarias del plan del tenant. Cada petición consume la cuota de
+// peticiones y, agotada, responde 429 hasta el siguiente día UTC. Sin cuota de refrescos la petición solo
+// sirve datos guardados (el servicio responde 402 si no los hay); las llamadas al proveedor que cause se
+// descuentan de esa cuota al terminar. Debe ir después de TenantMiddleware y UsageMiddleware
+func PlanQuotaMiddleware(quotas *services.PlanQuotas) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := tenancy.PrincipalFromContext(c.Request.Context())
+		if !ok || principal.TenantID == uuid.Nil {
+			c.Next()
+			return
+		}
+
+		requests, err := quotas.Consume(c.Request.Context(), principal.TenantID, principal.Plan, services.QuotaRequests, 1)
+		if err != nil {
+			// Igual que el rate limiting, si la cache no responde no se bloquea el tráfico
+			c.Next()
+			return
+		}
+		setQuotaHeaders(c, "X-Quota-", requests)
+
+		if requests.Exceeded() {
+			retryAfter := durationToSeconds(time.Until(requests.ResetAt))
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+
+			errorResp := response.NewErrorResponse(
+				response.ErrCodeQuotaExceeded,
+				"Daily request quota of the plan exceeded. Upgrade the plan or retry after the reset.",
+				http.StatusTooManyRequests,
+			).WithDetails(quotaDetails(requests, retryAfter))
+
+			RespondWithError(c, errorResp)
+			c.Abort()
+			return
+		}
+
+		refreshes, err := quotas.Status(c.Request.Context(), principal.TenantID, principal.Plan, services.QuotaRefreshes)
+		if err != nil || !refreshes.Limited() {
+			c.Next()
+			return
+		}
+		setQuotaHeaders(c, "X-Refresh-Quota-", refreshes)
+
+		tracker, ok := usage.FromContext(c.Request.Context())
+		if !ok {
+			var ctx context.Context
+			ctx, tracker = usage.WithTracker(c.Request.Context())
+			c.Request = c.Request.WithContext(ctx)
+		}
+		if refreshes.Exhausted() {
+			tracker.BlockRefreshes()
+		}
+
+		c.Next()
+
+		if calls := tracker.ProviderCalls(); calls > 0 {
+			consumeCtx, cancel := context.WithTimeout(context.WithoutCancel(c.Request.Context()), usageRecordTimeout)
+			defer cancel()
+			_, _ = quotas.Consume(consumeCtx, principal.TenantID, principal.Plan, services.QuotaRefreshes, int(calls))
+		}
+	}
+}
+
+// setQuotaHeaders añade los headers de una cuota diaria del plan con el prefijo dado
+func setQuotaHeaders(c *gin.Context, prefix string, status services.QuotaStatus) {
+	c.Header(prefix+"Plan", status.Plan)
+	if !status.Limited() {
+		return
+	}
+	c.Header(prefix+"Limit", strconv.Itoa(status.Limit))
+	c.Header(prefix+"Remaining", strconv.Itoa(status.Remaining))
+	c.Header(prefix+"Reset", strconv.FormatInt(status.ResetAt.Unix(), 10))
+}
+
+// quotaDetails describe una cuota agotada en los detalles del error
+func quotaDetails(status services.QuotaStatus, retryAfter int) map[string]interface{} {
+	return map[string]interface{}{
+		"plan":        status.Plan,
+		"quota":       status.Quota,
+		"limit":       status.Limit,
+		"used":        status.Used,
+		"reset_at":    status.ResetAt,
+		"retry_after": retryAfter,
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
 )
 
@@ -93,11 +94,7 @@ func handlePanicRecovery(c *gin.Context, serverLogger logger.ServerLogger, recov
 	}
 
 	// Responder con error 500
-	c.JSON(http.StatusInternalServerError, gin.H{
-		"error":     "Internal Server Error",
-		"message":   "An unexpected error occurred",
-		"timestamp": time.Now().Format(time.RFC3339),
-	})
+	RespondWithError(c, response.InternalServerError("An unexpected error occurred"))
 
 	// Abortar la cadena de middleware
 	c.Abort()
@@ -320,15 +317,6 @@ func AdvancedRecoveryMiddleware(serverLogger logger.ServerLogger) gin.HandlerFun
 	return ServerErrorMiddleware(serverLogger, DefaultErrorLoggingConfig())
 }
 
-// CustomErrorResponse estructura para respuestas de error personalizadas
-type CustomErrorResponse struct {
-	Error     string                 `json:"error"`
-	Message   string                 `json:"message"`
-	Timestamp string                 `json:"timestamp"`
-	RequestID string                 `json:"request_id,omitempty"`
-	Details   map[string]interface{} `json:"details,omitempty"`
-}
-
 // ErrorResponseMiddleware middleware para estandarizar las respuestas de error
 func ErrorResponseMiddleware() gin.HandlerFunc {
 	return gin.HandlerFunc(func(c *gin.Context) {
@@ -344,14 +332,12 @@ func ErrorResponseMiddleware() gin.HandlerFunc {
 				statusCode = http.StatusInternalServerError
 			}
 
-			response := CustomErrorResponse{
-				Error:     http.StatusText(statusCode),
-				Message:   err.Error(),
-				Timestamp: time.Now().Format(time.RFC3339),
-				RequestID: getRequestIDFromGin(c),
+			code := response.ErrCodeInternalServer
+			if statusCode < http.StatusInternalServerError {
+				code = response.ErrCodeBadRequest
 			}
 
-			c.JSON(statusCode, response)
+			RespondWithError(c, response.NewErrorResponse(code, err.Error(), statusCode))
 		}
 	})
 }
@@ -38,7 +38,7 @@ func DefaultServerLoggingConfig() ServerLoggingConfig {
 		LogRequestBody:       false, // Por seguridad, no logear bodies por defecto
 		LogResponseBody:      false,
 		SkipPaths:            []string{"/metrics", "/favicon.ico"},
-		SkipSuccessfulPaths:  []string{"/health"},
+		SkipSuccessfulPaths:  []string{"/health", "/readyz"},
 		LogSlowRequests:      true,
 		SlowRequestThreshold: 1 * time.Second,
 		SensitiveHeaders: []string{
@@ -133,6 +133,21 @@ func getOrGenerateRequestID(c *gin.Context) string {
 	return requestID
 }
 
+// RequestIDFromContext returns the request ID stored in the context by the logging middleware
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if requestID, ok := ctx.Value(RequestIDKey).(string); ok {
+		return requestID
+	}
+	// gin.Context guarda el request ID bajo la key "request_id" de tipo string
+	if requestID, ok := ctx.Value("request_id").(string); ok {
+		return requestID
+	}
+	return ""
+}
+
 // createContextWithRequestID crea un contexto con el request ID
 func createContextWithRequestID(ctx context.Context, requestID string) context.Context {
 	return context.WithValue(ctx, RequestIDKey, requestID)
@@ -0,0 +1,142 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/domain/tenancy"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/config"
+)
+
+// TenantContextKey es la key del gin.Context con el ID del tenant autenticado
+const TenantContextKey = "tenant_id"
+
+// authenticatedKeyContextKey es la key del gin.Context con la huella de la API key que autenticó la petición, para
+// que el rate limiting aplique el límite propio de la clave solo si está verificada
+const authenticatedKeyContextKey = "authenticated_api_key"
+
+// TenantAuthenticator resuelve el principal de una API key o de un JWT
+type TenantAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (*tenancy.Principal, error)
+	AuthenticateToken(ctx context.Context, token string) (*tenancy.Principal, error)
+}
+
+// TenantMiddleware identifica el tenant de la petición por la API key (header configurable) o por
+// Authorization: Bearer, que admite tanto una API key como un JWT. Las peticiones sin credenciales
+// continúan sin tenant (RequireTenant las rechaza donde haga falta); las credenciales inválidas reciben 401
+func TenantMiddleware(authenticator TenantAuthenticator, cfg config.TenancyConfig) gin.HandlerFunc {
+	header := cfg.APIKeyHeader
+	if header == "" {
+		header = "X-API-Key"
+	}
+
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		var (
+			principal *tenancy.Principal
+			apiKey    string
+			err       error
+		)
+		if key := strings.TrimSpace(c.GetHeader(header)); key != "" {
+			apiKey = key
+			principal, err = authenticator.AuthenticateAPIKey(ctx, key)
+		} else if token := bearerToken(c.GetHeader("Authorization")); token != "" {
+			// Los JWT tienen tres segmentos separados por puntos; las API keys ninguno
+			if strings.Count(token, ".") == 2 {
+				principal, err = authenticator.AuthenticateToken(ctx, token)
+			} else {
+				apiKey = token
+				principal, err = authenticator.AuthenticateAPIKey(ctx, token)
+			}
+		} else {
+			c.Next()
+			return
+		}
+
+		if err != nil {
+			RespondWithError(c, response.FromError(err, "Credential", "Failed to authenticate request"))
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(tenancy.WithPrincipal(ctx, principal))
+		if apiKey != "" {
+			c.Set(authenticatedKeyContextKey, apiKeyFingerprint(apiKey))
+		}
+		if principal.TenantID != uuid.Nil {
+			c.Set(TenantContextKey, principal.TenantID.String())
+		}
+		c.Next()
+	}
+}
+
+// RequireTenant rechaza con 401 las peticiones sin un tenant autenticado
+func RequireTenant() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, err := tenancy.TenantID(c.Request.Context()); err != nil {
+			RespondWithError(c, response.Unauthorized("An API key or a bearer token is required"))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireRole exige que el principal de la petición tenga al menos el rol indicado. Las peticiones sin
+// credenciales usan anonymousRole (vacío = ninguno): si no alcanza reciben 401; un rol insuficiente recibe 403
+func RequireRole(required tenancy.Role, anonymousRole tenancy.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := tenancy.PrincipalFromContext(c.Request.Context())
+		if !ok {
+			if anonymousRole.Allows(required) {
+				c.Next()
+				return
+			}
+			RespondWithError(c, response.Unauthorized("An API key or a bearer token is required"))
+			c.Abort()
+			return
+		}
+
+		if !principal.Role.Allows(required) {
+			RespondWithError(c, response.Forbidden("This operation requires the "+string(required)+" role"))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequirePlatformAdmin exige la clave de administración sin tenant (tenancy.MethodAdminKey). Las rutas que la
+// usan actúan sobre todos los tenants, así que ningún rol de una credencial de tenant basta: sin credenciales
+// reciben 401 y con la credencial de un tenant 403
+func RequirePlatformAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := tenancy.PrincipalFromContext(c.Request.Context())
+		if !ok {
+			RespondWithError(c, response.Unauthorized("The administration key is required"))
+			c.Abort()
+			return
+		}
+
+		if !principal.IsPlatformAdmin() {
+			RespondWithError(c, response.Forbidden("This operation requires the administration key"))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// bearerToken extrae el token de un header Authorization: Bearer <token>
+func bearerToken(authorization string) string {
+	scheme, token, found := strings.Cut(strings.TrimSpace(authorization), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
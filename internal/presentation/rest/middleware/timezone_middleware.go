@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/domain/tenancy"
+	"github.com/MayaCris/stock-info-app/internal/domain/timezone"
+)
+
+// TimezoneQueryParam is the query parameter that overrides the time zone of a request
+const TimezoneQueryParam = "tz"
+
+// TimezoneMiddleware resolves the time zone used for "today" and "this week" and stores it in the request context:
+// the tz query parameter, else the preference of the authenticated credential, else the server local time.
+// Must run after TenantMiddleware so the preference of the principal is available
+func TimezoneMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		name := c.Query(TimezoneQueryParam)
+		if name != "" {
+			location, err := timezone.Load(name)
+			if err != nil {
+				RespondWithError(c, response.BadRequest("Invalid tz parameter: "+err.Error()))
+				c.Abort()
+				return
+			}
+			c.Request = c.Request.WithContext(timezone.WithLocation(ctx, location))
+			c.Next()
+			return
+		}
+
+		// Una preferencia guardada que ya no es válida (zona retirada de la base IANA) no bloquea la petición
+		if principal, ok := tenancy.PrincipalFromContext(ctx); ok && principal.Timezone != "" {
+			if location, err := timezone.Load(principal.Timezone); err == nil {
+				c.Request = c.Request.WithContext(timezone.WithLocation(ctx, location))
+			}
+		}
+		c.Next()
+	}
+}
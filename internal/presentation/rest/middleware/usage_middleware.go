@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/tenancy"
+	"github.com/MayaCris/stock-info-app/internal/domain/usage"
+)
+
+// usageRecordTimeout limita la escritura de los contadores para no retrasar la respuesta si Redis no responde
+const usageRecordTimeout = 500 * time.Millisecond
+
+// UsageMiddleware cuenta las peticiones de cada credencial autenticada (API key o JWT) junto con los errores,
+// las llamadas a proveedores externos y las respuestas servidas desde datos guardados que hayan causado.
+// Debe ir después de TenantMiddleware; las peticiones anónimas no se cuentan
+func UsageMiddleware(counters *services.UsageCounters) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := tenancy.PrincipalFromContext(c.Request.Context())
+		if !ok || principal.TenantID == uuid.Nil || principal.Credential() == "" {
+			c.Next()
+			return
+		}
+
+		ctx, tracker := usage.WithTracker(c.Request.Context())
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		counts := services.UsageCounts{
+			Requests:      1,
+			ProviderCalls: tracker.ProviderCalls(),
+			CacheHits:     tracker.CacheHits(),
+		}
+		if c.Writer.Status() >= http.StatusBadRequest {
+			counts.Errors = 1
+		}
+
+		// El uso es informativo: un fallo de la cache no afecta a la respuesta ya escrita
+		recordCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), usageRecordTimeout)
+		defer cancel()
+		_ = counters.Record(recordCtx, services.UsageKey{
+			TenantID:   principal.TenantID,
+			Credential: principal.Credential(),
+		}, counts)
+	}
+}
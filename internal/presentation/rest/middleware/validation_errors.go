@@ -0,0 +1,197 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+)
+
+// RegisterJSONFieldNames makes the gin validator report fields by their JSON name instead of the Go field name
+func RegisterJSONFieldNames() {
+	validate, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, tag := range []string{"json", "form"} {
+			name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
+		}
+		return field.Name
+	})
+}
+
+// ValidationErrorResponse translates a binding/validation error into a ValidationFailed response
+// with the failing fields, the violated tags and human-readable messages
+func ValidationErrorResponse(err error) *response.ErrorResponse {
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		fieldErrors := make([]response.ValidationError, 0, len(validationErrors))
+		for _, fieldError := range validationErrors {
+			fieldErrors = append(fieldErrors, response.ValidationError{
+				Field:   getJSONFieldName(fieldError),
+				Tag:     fieldError.Tag(),
+				Message: getValidationErrorMessage(fieldError),
+				Value:   fmt.Sprintf("%v", fieldError.Value()),
+			})
+		}
+		return response.ValidationFailedWithFields(fieldErrors)
+	}
+
+	var typeError *json.UnmarshalTypeError
+	if errors.As(err, &typeError) {
+		return response.ValidationFailedWithFields([]response.ValidationError{{
+			Field:   typeError.Field,
+			Tag:     "type",
+			Message: fmt.Sprintf("Must be of type %s", jsonTypeName(typeError.Type)),
+			Value:   typeError.Value,
+		}})
+	}
+
+	var syntaxError *json.SyntaxError
+	if errors.As(err, &syntaxError) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return response.ValidationFailed("Malformed JSON request body")
+	}
+
+	if errors.Is(err, io.EOF) {
+		return response.ValidationFailed("Request body is required")
+	}
+
+	return response.ValidationFailed("Invalid request body")
+}
+
+// getJSONFieldName returns the field path as sent by the client (e.g. "ticker" or "items[0].ticker")
+func getJSONFieldName(fieldError validator.FieldError) string {
+	// Sin RegisterJSONFieldNames el validador reporta nombres de Go; se convierten a snake_case
+	if fieldError.Field() == fieldError.StructField() {
+		return toSnakeCase(fieldError.Field())
+	}
+
+	// El namespace incluye el nombre del struct raíz, que no forma parte del payload
+	namespace := fieldError.Namespace()
+	if idx := strings.Index(namespace, "."); idx >= 0 {
+		return namespace[idx+1:]
+	}
+	return fieldError.Field()
+}
+
+// getValidationErrorMessage returns a human-readable validation error message
+func getValidationErrorMessage(fieldError validator.FieldError) string {
+	switch fieldError.Tag() {
+	case "required", "required_if", "required_with", "required_without":
+		return "This field is required"
+	case "email":
+		return "Must be a valid email address"
+	case "min":
+		return fmt.Sprintf("Must be at least %s", sizeDescription(fieldError))
+	case "max":
+		return fmt.Sprintf("Must be no more than %s", sizeDescription(fieldError))
+	case "len":
+		return fmt.Sprintf("Must be exactly %s", sizeDescription(fieldError))
+	case "oneof":
+		return fmt.Sprintf("Must be one of: %s", fieldError.Param())
+	case "url":
+		return "Must be a valid URL"
+	case "uuid", "uuid4":
+		return "Must be a valid UUID"
+	case "alpha":
+		return "Must contain only letters"
+	case "alphanum":
+		return "Must contain only letters and numbers"
+	case "numeric":
+		return "Must be a numeric value"
+	case "gt", "gtfield":
+		return fmt.Sprintf("Must be greater than %s", fieldParam(fieldError))
+	case "gte", "gtefield":
+		return fmt.Sprintf("Must be greater than or equal to %s", fieldParam(fieldError))
+	case "lt", "ltfield":
+		return fmt.Sprintf("Must be less than %s", fieldParam(fieldError))
+	case "lte", "ltefield":
+		return fmt.Sprintf("Must be less than or equal to %s", fieldParam(fieldError))
+	case "datetime":
+		return fmt.Sprintf("Must be a valid datetime in format %s", fieldError.Param())
+	case "dive":
+		return "Contains invalid elements"
+	default:
+		return fmt.Sprintf("Failed the '%s' validation", fieldError.Tag())
+	}
+}
+
+// fieldParam returns the tag param, converting referenced struct fields (gtfield, ltfield...) to snake_case
+func fieldParam(fieldError validator.FieldError) string {
+	if strings.HasSuffix(fieldError.Tag(), "field") {
+		return toSnakeCase(fieldError.Param())
+	}
+	return fieldError.Param()
+}
+
+// sizeDescription describes min/max/len params according to the field kind
+func sizeDescription(fieldError validator.FieldError) string {
+	switch fieldError.Kind() {
+	case reflect.String:
+		return fmt.Sprintf("%s characters long", fieldError.Param())
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return fmt.Sprintf("%s items", fieldError.Param())
+	default:
+		return fieldError.Param()
+	}
+}
+
+// jsonTypeName returns the JSON name of a Go type for type mismatch messages
+func jsonTypeName(t reflect.Type) string {
+	if t == nil {
+		return "unknown"
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	default:
+		return t.String()
+	}
+}
+
+// toSnakeCase converts a Go field name to snake_case keeping acronyms together (CompanyID -> company_id)
+func toSnakeCase(name string) string {
+	runes := []rune(name)
+
+	var result strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prevLower := unicode.IsLower(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (nextLower && unicode.IsUpper(runes[i-1])) {
+				result.WriteRune('_')
+			}
+		}
+		result.WriteRune(unicode.ToLower(r))
+	}
+
+	return result.String()
+}
@@ -0,0 +1,312 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/handlers"
+)
+
+// AdminRoutes encapsula la configuración de rutas administrativas
+type AdminRoutes struct {
+	middlewareManager *MiddlewareManager
+}
+
+// NewAdminRoutes crea una nueva instancia del configurador de rutas administrativas
+func NewAdminRoutes(middlewareManager *MiddlewareManager) *AdminRoutes {
+	return &AdminRoutes{
+		middlewareManager: middlewareManager,
+	}
+}
+
+// SetupAdminRoutes configura todas las rutas administrativas
+// Todas las rutas de este grupo pasan por los middlewares de administración
+func (ar *AdminRoutes) SetupAdminRoutes(routerGroup *gin.RouterGroup, adminHandler *handlers.AdminHandler) {
+	// Verificar que el handler existe
+	if adminHandler == nil {
+		return
+	}
+
+	admin := routerGroup.Group("/admin")
+	if ar.middlewareManager != nil {
+		ar.middlewareManager.ApplyAdminMiddlewares(admin)
+	}
+	{
+		// Population pipeline operations
+		ar.setupPopulationRoutes(admin, adminHandler)
+
+		// Background job queue
+		ar.setupJobRoutes(admin, adminHandler)
+
+		// Stock ratings maintenance
+		ar.setupRatingRoutes(admin, adminHandler)
+
+		// Rating processing backlog
+		ar.setupProcessingRoutes(admin, adminHandler)
+
+		// Company enrichment review
+		ar.setupEnrichmentRoutes(admin, adminHandler)
+
+		// Duplicate company maintenance
+		ar.setupCompanyRoutes(admin, adminHandler)
+
+		// Analytics materialized views
+		ar.setupAnalyticsRoutes(admin, adminHandler)
+
+		// Data quality history
+		ar.setupIntegrityRoutes(admin, adminHandler)
+
+		// Database diagnostics
+		ar.setupDatabaseRoutes(admin, adminHandler)
+
+		// Cache operations
+		ar.setupCacheRoutes(admin, adminHandler)
+
+		// Raw provider payload archive
+		ar.setupPayloadRoutes(admin, adminHandler)
+
+		// Database snapshots in the backup bucket
+		ar.setupBackupRoutes(admin, adminHandler)
+
+		// Parquet exports for data science
+		ar.setupExportRoutes(admin, adminHandler)
+
+		// Runtime configuration
+		ar.setupConfigRoutes(admin, adminHandler)
+	}
+}
+
+// setupPopulationRoutes configura las rutas del pipeline de población
+func (ar *AdminRoutes) setupPopulationRoutes(admin *gin.RouterGroup, adminHandler *handlers.AdminHandler) {
+	populationGroup := admin.Group("/population")
+	{
+		// Lanzar una población asíncrona
+		populationGroup.POST("/run", adminHandler.RunPopulation)
+
+		// Consultar progreso/resultado de una población
+		populationGroup.GET("/jobs/:id", adminHandler.GetPopulationJob)
+
+		// Items rechazados (dead-letter): inspección, reproceso y descarte
+		populationGroup.GET("/rejects", adminHandler.ListPopulationRejects)
+		populationGroup.POST("/rejects/reprocess", adminHandler.ReprocessPopulationRejects)
+		populationGroup.GET("/rejects/:id", adminHandler.GetPopulationReject)
+		populationGroup.POST("/rejects/:id/discard", adminHandler.DiscardPopulationReject)
+
+		// Reglas de validación de la ingesta y sus métricas
+		populationGroup.GET("/rules", adminHandler.GetIngestionRules)
+	}
+}
+
+// setupJobRoutes configura las rutas de la cola de jobs
+func (ar *AdminRoutes) setupJobRoutes(admin *gin.RouterGroup, adminHandler *handlers.AdminHandler) {
+	jobsGroup := admin.Group("/jobs")
+	{
+		// Encolar y listar jobs
+		jobsGroup.POST("", adminHandler.EnqueueJob)
+		jobsGroup.GET("", adminHandler.ListJobs)
+
+		// Consultar y cancelar un job
+		jobsGroup.GET("/:id", adminHandler.GetJob)
+		jobsGroup.POST("/:id/cancel", adminHandler.CancelJob)
+	}
+}
+
+// setupEnrichmentRoutes configura las rutas de revisión del enriquecimiento de companies
+func (ar *AdminRoutes) setupEnrichmentRoutes(admin *gin.RouterGroup, adminHandler *handlers.AdminHandler) {
+	enrichmentGroup := admin.Group("/enrichment")
+	{
+		// Conflictos entre companies y perfiles de proveedores
+		enrichmentGroup.GET("/conflicts", adminHandler.ListEnrichmentConflicts)
+		enrichmentGroup.POST("/conflicts/:id/review", adminHandler.ReviewEnrichmentConflict)
+	}
+}
+
+// setupRatingRoutes configura las operaciones de mantenimiento de stock ratings
+func (ar *AdminRoutes) setupRatingRoutes(admin *gin.RouterGroup, adminHandler *handlers.AdminHandler) {
+	ratingsGroup := admin.Group("/ratings")
+	{
+		// Re-convertir el RawData de un rango de fechas tras corregir el adapter (job en background)
+		ratingsGroup.POST("/reprocess", adminHandler.ReprocessRatings)
+
+		// Taxonomía: mapeo de los ratings de texto libre a la escala canónica (strong_buy ... strong_sell)
+		ratingsGroup.GET("/mappings", adminHandler.GetRatingTaxonomy)
+		ratingsGroup.PUT("/mappings", adminHandler.SaveRatingMapping)
+		ratingsGroup.DELETE("/mappings", adminHandler.DeleteRatingMapping)
+		ratingsGroup.POST("/mappings/apply", adminHandler.ApplyRatingMappings)
+	}
+}
+
+// setupProcessingRoutes configura el estado del procesado de ratings
+func (ar *AdminRoutes) setupProcessingRoutes(admin *gin.RouterGroup, adminHandler *handlers.AdminHandler) {
+	processingGroup := admin.Group("/processing")
+	{
+		// Backlog por fuente, antigüedad y throughput reciente del job rating_processing
+		processingGroup.GET("/status", adminHandler.GetProcessingStatus)
+	}
+}
+
+// setupCompanyRoutes configura las operaciones de mantenimiento de companies
+func (ar *AdminRoutes) setupCompanyRoutes(admin *gin.RouterGroup, adminHandler *handlers.AdminHandler) {
+	companiesGroup := admin.Group("/companies")
+	{
+		// Fusionar un duplicado en la company destino (soporta dry_run)
+		companiesGroup.POST("/:id/merge", adminHandler.MergeCompanies)
+	}
+}
+
+// setupAnalyticsRoutes configura el refresco manual de las vistas de analíticas
+func (ar *AdminRoutes) setupAnalyticsRoutes(admin *gin.RouterGroup, adminHandler *handlers.AdminHandler) {
+	analyticsGroup := admin.Group("/analytics")
+	{
+		analyticsGroup.POST("/refresh", adminHandler.RefreshAnalytics)
+	}
+}
+
+// setupIntegrityRoutes configura el historial y la reparación de las validaciones de integridad
+func (ar *AdminRoutes) setupIntegrityRoutes(admin *gin.RouterGroup, adminHandler *handlers.AdminHandler) {
+	integrityGroup := admin.Group("/integrity")
+	{
+		// Snapshots de los jobs integrity_check y tendencia de la calidad de datos
+		integrityGroup.GET("/history", adminHandler.GetIntegrityHistory)
+		// Vista previa (dry run) y reparación aprobada con el plan_token de la vista previa
+		integrityGroup.POST("/repair", adminHandler.RepairIntegrity)
+	}
+}
+
+// setupDatabaseRoutes configura las rutas de diagnóstico de la base de datos
+func (ar *AdminRoutes) setupDatabaseRoutes(admin *gin.RouterGroup, adminHandler *handlers.AdminHandler) {
+	dbGroup := admin.Group("/db")
+	{
+		// Queries que superaron el umbral de lentitud
+		dbGroup.GET("/slow-queries", adminHandler.ListSlowQueries)
+
+		// Particiones mensuales de stock_ratings y market_data
+		dbGroup.POST("/partitions", adminHandler.CreatePartitions)
+	}
+}
+
+// setupCacheRoutes configura las rutas de operación de la cache
+func (ar *AdminRoutes) setupCacheRoutes(admin *gin.RouterGroup, adminHandler *handlers.AdminHandler) {
+	cacheGroup := admin.Group("/cache")
+	{
+		// Precargar las companies más consultadas
+		cacheGroup.POST("/warm", adminHandler.WarmCache)
+	}
+}
+
+// setupPayloadRoutes configura las rutas del archivo de respuestas crudas de los proveedores
+func (ar *AdminRoutes) setupPayloadRoutes(admin *gin.RouterGroup, adminHandler *handlers.AdminHandler) {
+	payloadsGroup := admin.Group("/payloads")
+	{
+		// Consultar las respuestas archivadas
+		payloadsGroup.GET("", adminHandler.ListProviderPayloads)
+		payloadsGroup.GET("/:id", adminHandler.GetProviderPayload)
+
+		// Re-procesar respuestas archivadas sin llamar al proveedor
+		payloadsGroup.POST("/replay", adminHandler.ReplayProviderPayloads)
+	}
+}
+
+// setupBackupRoutes configura las rutas de los snapshots de la base de datos
+func (ar *AdminRoutes) setupBackupRoutes(admin *gin.RouterGroup, adminHandler *handlers.AdminHandler) {
+	backupsGroup := admin.Group("/backups")
+	{
+		// Exportar las tablas al bucket (job en background) y listar los snapshots
+		backupsGroup.POST("", adminHandler.CreateBackup)
+		backupsGroup.GET("", adminHandler.ListBackups)
+
+		// Consultar un snapshot y comprobar si se puede restaurar sin escribir nada
+		backupsGroup.GET("/:id", adminHandler.GetBackup)
+		backupsGroup.POST("/:id/restore-dry-run", adminHandler.RestoreBackupDryRun)
+	}
+}
+
+// setupExportRoutes configura las rutas de las exportaciones de datos
+func (ar *AdminRoutes) setupExportRoutes(admin *gin.RouterGroup, adminHandler *handlers.AdminHandler) {
+	exportsGroup := admin.Group("/exports")
+	{
+		// Precios históricos y ratings en Parquet por símbolo y año (job en background)
+		exportsGroup.POST("/parquet", adminHandler.CreateParquetExport)
+	}
+}
+
+// setupConfigRoutes configura las rutas de configuración en caliente
+func (ar *AdminRoutes) setupConfigRoutes(admin *gin.RouterGroup, adminHandler *handlers.AdminHandler) {
+	configGroup := admin.Group("/config")
+	{
+		// Recargar log level, rate limits, TTLs de cache y API keys sin reiniciar
+		configGroup.POST("/reload", adminHandler.ReloadConfig)
+	}
+}
+
+// GetAdminRoutesInfo retorna información sobre las rutas administrativas disponibles
+func (ar *AdminRoutes) GetAdminRoutesInfo() map[string]interface{} {
+	return map[string]interface{}{
+		"entity":    "admin",
+		"base_path": "/admin",
+		"operations": map[string][]string{
+			"population": {
+				"POST /admin/population/run",
+				"GET /admin/population/jobs/:id",
+				"GET /admin/population/rejects",
+				"POST /admin/population/rejects/reprocess",
+				"GET /admin/population/rejects/:id",
+				"POST /admin/population/rejects/:id/discard",
+				"GET /admin/population/rules",
+			},
+			"jobs": {
+				"POST /admin/jobs",
+				"GET /admin/jobs",
+				"GET /admin/jobs/:id",
+				"POST /admin/jobs/:id/cancel",
+			},
+			"ratings": {
+				"POST /admin/ratings/reprocess",
+				"GET /admin/ratings/mappings",
+				"PUT /admin/ratings/mappings",
+				"DELETE /admin/ratings/mappings",
+				"POST /admin/ratings/mappings/apply",
+			},
+			"processing": {
+				"GET /admin/processing/status",
+			},
+			"enrichment": {
+				"GET /admin/enrichment/conflicts",
+				"POST /admin/enrichment/conflicts/:id/review",
+			},
+			"companies": {
+				"POST /admin/companies/:id/merge",
+			},
+			"analytics": {
+				"POST /admin/analytics/refresh",
+			},
+			"integrity": {
+				"GET /admin/integrity/history",
+				"POST /admin/integrity/repair",
+			},
+			"db": {
+				"GET /admin/db/slow-queries",
+				"POST /admin/db/partitions",
+			},
+			"cache": {
+				"POST /admin/cache/warm",
+			},
+			"payloads": {
+				"GET /admin/payloads",
+				"GET /admin/payloads/:id",
+				"POST /admin/payloads/replay",
+			},
+			"backups": {
+				"POST /admin/backups",
+				"GET /admin/backups",
+				"GET /admin/backups/:id",
+				"POST /admin/backups/:id/restore-dry-run",
+			},
+			"exports": {
+				"POST /admin/exports/parquet",
+			},
+			"config": {
+				"POST /admin/config/reload",
+			},
+		},
+	}
+}
@@ -29,6 +29,15 @@ func (ar *AnalysisRoutes) SetupAnalysisRoutes(routerGroup *gin.RouterGroup, anal
 	// Configurar el grupo de rutas de analysis
 	analysis := routerGroup.Group("/analysis")
 	{
+		// Comparación de varias empresas lado a lado
+		analysis.GET("/compare", analysisHandler.CompareCompanies)
+
+		// Correlación de rendimientos diarios entre tickers
+		analysis.GET("/correlation", analysisHandler.GetCorrelationMatrix)
+
+		// Métricas de riesgo de un portfolio ponderado
+		analysis.GET("/portfolio/risk", analysisHandler.GetPortfolioRisk)
+
 		// Company analysis routes
 		ar.setupCompanyAnalysisRoutes(analysis, analysisHandler)
 
@@ -70,11 +79,17 @@ func (ar *AnalysisRoutes) setupMarketAnalysisRoutes(analysis *gin.RouterGroup, a
 		// Overview general del mercado
 		market.GET("/overview", analysisHandler.GetMarketOverview)
 
+		// Amplitud de mercado: avances/retrocesos y % sobre las medias de 50/200 sesiones
+		market.GET("/breadth", analysisHandler.GetMarketBreadth)
+
 		// Futuras rutas de análisis de mercado
 		// market.GET("/sentiment", analysisHandler.GetMarketSentiment)
 		// market.GET("/volatility", analysisHandler.GetMarketVolatility)
 		// market.GET("/volume", analysisHandler.GetMarketVolume)
 	}
+
+	// Estadísticas de capitalización convertidas a una moneda, opcionalmente por exchange o moneda
+	analysis.GET("/market-cap", analysisHandler.GetMarketCapStats)
 }
 
 // setupSectorAnalysisRoutes configura las rutas de análisis por sector
@@ -101,8 +116,10 @@ func (ar *AnalysisRoutes) setupTrendsAnalysisRoutes(analysis *gin.RouterGroup, a
 		// Actividad de brokerages
 		trends.GET("/brokerages", analysisHandler.GetBrokerageActivity)
 
+		// Rotación sectorial: balance de upgrades/downgrades por sector y mes
+		trends.GET("/sectors", analysisHandler.GetSectorRotation)
+
 		// Futuras rutas de tendencias
-		// trends.GET("/sectors", analysisHandler.GetSectorTrends)
 		// trends.GET("/volume", analysisHandler.GetVolumeTrends)
 		// trends.GET("/sentiment", analysisHandler.GetSentimentTrends)
 	}
@@ -130,6 +147,15 @@ func (ar *AnalysisRoutes) GetAnalysisRoutesInfo() map[string]interface{} {
 		"entity":    "analysis",
 		"base_path": "/analysis",
 		"operations": map[string][]string{
+			"comparison": {
+				"GET /analysis/compare?tickers=",
+			},
+			"correlation": {
+				"GET /analysis/correlation?tickers=&days=",
+			},
+			"portfolio": {
+				"GET /analysis/portfolio/risk?holdings=&benchmark=&days=",
+			},
 			"company_analysis": {
 				"GET /analysis/companies/:id",
 				"GET /analysis/companies/ticker/:ticker",
@@ -137,6 +163,8 @@ func (ar *AnalysisRoutes) GetAnalysisRoutesInfo() map[string]interface{} {
 			},
 			"market_analysis": {
 				"GET /analysis/market/overview",
+				"GET /analysis/market/breadth",
+				"GET /analysis/market-cap",
 			},
 			"sector_analysis": {
 				"GET /analysis/sectors/:sector",
@@ -144,6 +172,7 @@ func (ar *AnalysisRoutes) GetAnalysisRoutesInfo() map[string]interface{} {
 			"trends_analysis": {
 				"GET /analysis/trends/ratings",
 				"GET /analysis/trends/brokerages",
+				"GET /analysis/trends/sectors",
 			},
 			"recommendations": {
 				"GET /analysis/recommendations/companies/:id",
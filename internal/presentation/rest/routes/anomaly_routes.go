@@ -0,0 +1,48 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/handlers"
+)
+
+// AnomalyRoutes encapsula la configuración de rutas de anomalías
+type AnomalyRoutes struct {
+	middlewareManager *MiddlewareManager
+}
+
+// NewAnomalyRoutes crea una nueva instancia del configurador de rutas de anomalías
+func NewAnomalyRoutes(middlewareManager *MiddlewareManager) *AnomalyRoutes {
+	return &AnomalyRoutes{
+		middlewareManager: middlewareManager,
+	}
+}
+
+// SetupAnomalyRoutes configura la consulta de anomalías bajo /analysis
+func (ar *AnomalyRoutes) SetupAnomalyRoutes(routerGroup *gin.RouterGroup, anomalyHandler *handlers.AnomalyHandler) {
+	// Verificar que el handler existe
+	if anomalyHandler == nil {
+		return
+	}
+
+	anomalies := routerGroup.Group("/analysis")
+	if ar.middlewareManager != nil {
+		ar.middlewareManager.ApplyReadOnlyMiddlewares(anomalies)
+	}
+	{
+		anomalies.GET("/anomalies", anomalyHandler.GetAnomalies)
+	}
+}
+
+// GetAnomalyRoutesInfo retorna información sobre las rutas de anomalías disponibles
+func (ar *AnomalyRoutes) GetAnomalyRoutesInfo() map[string]interface{} {
+	return map[string]interface{}{
+		"entity":    "anomaly",
+		"base_path": "/analysis",
+		"operations": map[string][]string{
+			"list": {
+				"GET /analysis/anomalies?days=&type=&limit=",
+			},
+		},
+	}
+}
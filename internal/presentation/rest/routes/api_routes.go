@@ -6,6 +6,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/config"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
 )
 
 // APIRoutes encapsula la configuración de rutas de la API con versioning
@@ -28,6 +29,22 @@ func (ar *APIRoutes) SetupAPIRoutes(engine *gin.Engine, handlers *Handlers) {
 	// API v1 group - configuración del versionado principal
 	v1 := ar.setupAPIv1Group(engine)
 
+	// Identificación del tenant por API key o JWT; las rutas que lo exigen usan RequireTenant
+	if ar.config.Tenancy.Enabled && handlers.TenantAuth != nil {
+		v1.Use(middleware.TenantMiddleware(handlers.TenantAuth, ar.config.Tenancy))
+		// Uso por credencial: peticiones, errores, llamadas a proveedores y aciertos de cache
+		if handlers.UsageCounters != nil {
+			v1.Use(middleware.UsageMiddleware(handlers.UsageCounters))
+		}
+		// Cuotas diarias del plan: 429 sin peticiones, solo datos guardados sin refrescos
+		if ar.config.Plans.Enabled && handlers.PlanQuotas != nil {
+			v1.Use(middleware.PlanQuotaMiddleware(handlers.PlanQuotas))
+		}
+	}
+
+	// Zona horaria de "hoy" y "esta semana": ?tz= o la preferencia de la credencial autenticada
+	v1.Use(middleware.TimezoneMiddleware())
+
 	// Configurar rutas por entidades en el grupo v1
 	ar.setupEntityRoutes(v1, handlers)
 
@@ -63,6 +80,60 @@ func (ar *APIRoutes) setupEntityRoutes(v1 *gin.RouterGroup, handlers *Handlers)
 		companyRoutes.SetupCompanyRoutes(v1, handlers.Company)
 	}
 
+	// Configurar rutas de peers (competidores) usando PeerRoutes
+	if handlers.Peers != nil {
+		peerRoutes := NewPeerRoutes(ar.middlewareManager)
+		peerRoutes.SetupPeerRoutes(v1, handlers.Peers)
+	}
+
+	// Configurar rutas de relaciones entre companies usando CompanyRelationshipRoutes
+	if handlers.Relations != nil {
+		relationshipRoutes := NewCompanyRelationshipRoutes(ar.middlewareManager)
+		relationshipRoutes.SetupCompanyRelationshipRoutes(v1, handlers.Relations)
+	}
+
+	// Configurar rutas de etiquetas usando TagRoutes
+	if handlers.Tags != nil {
+		tagRoutes := NewTagRoutes(ar.middlewareManager)
+		tagRoutes.SetupTagRoutes(v1, handlers.Tags)
+	}
+
+	// Configurar rutas de estados financieros usando FinancialStatementRoutes
+	if handlers.Financials != nil {
+		financialRoutes := NewFinancialStatementRoutes(ar.middlewareManager)
+		financialRoutes.SetupFinancialStatementRoutes(v1, handlers.Financials)
+	}
+
+	// Configurar rutas de benchmarks usando BenchmarkRoutes
+	if handlers.Benchmarks != nil {
+		benchmarkRoutes := NewBenchmarkRoutes(ar.middlewareManager)
+		benchmarkRoutes.SetupBenchmarkRoutes(v1, handlers.Benchmarks)
+	}
+
+	// Configurar rutas de backtesting usando BacktestRoutes
+	if handlers.Backtest != nil {
+		backtestRoutes := NewBacktestRoutes(ar.middlewareManager)
+		backtestRoutes.SetupBacktestRoutes(v1, handlers.Backtest)
+	}
+
+	// Configurar rutas de anomalías usando AnomalyRoutes
+	if handlers.Anomalies != nil {
+		anomalyRoutes := NewAnomalyRoutes(ar.middlewareManager)
+		anomalyRoutes.SetupAnomalyRoutes(v1, handlers.Anomalies)
+	}
+
+	// Configurar rutas de trending usando TrendingRoutes
+	if handlers.Trending != nil {
+		trendingRoutes := NewTrendingRoutes(ar.middlewareManager)
+		trendingRoutes.SetupTrendingRoutes(v1, handlers.Trending)
+	}
+
+	// Configurar rutas del dashboard usando DashboardRoutes
+	if handlers.Dashboard != nil {
+		dashboardRoutes := NewDashboardRoutes(ar.middlewareManager)
+		dashboardRoutes.SetupDashboardRoutes(v1, handlers.Dashboard)
+	}
+
 	// Configurar rutas de brokerages usando BrokerageRoutes
 	if handlers.Brokerage != nil {
 		brokerageRoutes := NewBrokerageRoutes(ar.middlewareManager)
@@ -80,11 +151,47 @@ func (ar *APIRoutes) setupEntityRoutes(v1 *gin.RouterGroup, handlers *Handlers)
 		marketDataRoutes.SetupMarketDataRoutes(v1, handlers.MarketData)
 	}
 
+	// Configurar rutas del estado del mercado usando MarketStatusRoutes
+	if handlers.MarketStatus != nil {
+		marketStatusRoutes := NewMarketStatusRoutes(ar.middlewareManager)
+		marketStatusRoutes.SetupMarketStatusRoutes(v1, handlers.MarketStatus)
+	}
+
 	// Configurar rutas de Alpha Vantage usando AlphaVantageRoutes
 	if handlers.AlphaVantage != nil {
 		alphaVantageRoutes := NewAlphaVantageRoutes(ar.middlewareManager)
 		alphaVantageRoutes.SetupAlphaVantageRoutes(v1, handlers.AlphaVantage)
 	}
+
+	// Configurar rutas de búsqueda usando SearchRoutes
+	if handlers.Search != nil {
+		searchRoutes := NewSearchRoutes(ar.middlewareManager)
+		searchRoutes.SetupSearchRoutes(v1, handlers.Search)
+	}
+
+	// Configurar rutas de tenants y API keys usando TenantRoutes
+	if handlers.Tenants != nil {
+		tenantRoutes := NewTenantRoutes(ar.middlewareManager)
+		tenantRoutes.SetupTenantRoutes(v1, handlers.Tenants)
+	}
+
+	// Configurar rutas del uso de la API usando UsageRoutes
+	if handlers.Usage != nil {
+		usageRoutes := NewUsageRoutes(ar.middlewareManager)
+		usageRoutes.SetupUsageRoutes(v1, handlers.Usage)
+	}
+
+	// Configurar rutas de notas de los usuarios usando NoteRoutes
+	if handlers.Notes != nil {
+		noteRoutes := NewNoteRoutes(ar.middlewareManager)
+		noteRoutes.SetupNoteRoutes(v1, handlers.Notes)
+	}
+
+	// Configurar rutas administrativas usando AdminRoutes
+	if handlers.Admin != nil {
+		adminRoutes := NewAdminRoutes(ar.middlewareManager)
+		adminRoutes.SetupAdminRoutes(v1, handlers.Admin)
+	}
 }
 
 // GetAPIInfo retorna información sobre las versiones de API disponibles
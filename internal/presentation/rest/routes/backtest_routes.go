@@ -0,0 +1,48 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/handlers"
+)
+
+// BacktestRoutes encapsula la configuración de rutas de backtesting
+type BacktestRoutes struct {
+	middlewareManager *MiddlewareManager
+}
+
+// NewBacktestRoutes crea una nueva instancia del configurador de rutas de backtesting
+func NewBacktestRoutes(middlewareManager *MiddlewareManager) *BacktestRoutes {
+	return &BacktestRoutes{
+		middlewareManager: middlewareManager,
+	}
+}
+
+// SetupBacktestRoutes configura la simulación de estrategias bajo /analysis
+func (br *BacktestRoutes) SetupBacktestRoutes(routerGroup *gin.RouterGroup, backtestHandler *handlers.BacktestHandler) {
+	// Verificar que el handler existe
+	if backtestHandler == nil {
+		return
+	}
+
+	backtest := routerGroup.Group("/analysis")
+	if br.middlewareManager != nil {
+		br.middlewareManager.ApplyReadOnlyMiddlewares(backtest)
+	}
+	{
+		backtest.GET("/backtest", backtestHandler.RunBacktest)
+	}
+}
+
+// GetBacktestRoutesInfo retorna información sobre las rutas de backtesting disponibles
+func (br *BacktestRoutes) GetBacktestRoutesInfo() map[string]interface{} {
+	return map[string]interface{}{
+		"entity":    "backtest",
+		"base_path": "/analysis",
+		"operations": map[string][]string{
+			"backtest": {
+				"GET /analysis/backtest?tickers=&strategy=&holding_days=&capital=",
+			},
+		},
+	}
+}
@@ -0,0 +1,79 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/handlers"
+)
+
+// BenchmarkRoutes encapsula la configuración de rutas de los índices de referencia
+type BenchmarkRoutes struct {
+	middlewareManager *MiddlewareManager
+}
+
+// NewBenchmarkRoutes crea una nueva instancia del configurador de rutas de benchmarks
+func NewBenchmarkRoutes(middlewareManager *MiddlewareManager) *BenchmarkRoutes {
+	return &BenchmarkRoutes{
+		middlewareManager: middlewareManager,
+	}
+}
+
+// SetupBenchmarkRoutes configura la consulta y los índices personalizados bajo /benchmarks y la sincronización
+// bajo /admin/benchmarks
+func (br *BenchmarkRoutes) SetupBenchmarkRoutes(routerGroup *gin.RouterGroup, benchmarkHandler *handlers.BenchmarkHandler) {
+	// Verificar que el handler existe
+	if benchmarkHandler == nil {
+		return
+	}
+
+	benchmarks := routerGroup.Group("/benchmarks")
+	if br.middlewareManager != nil {
+		br.middlewareManager.ApplyReadOnlyMiddlewares(benchmarks)
+	}
+	{
+		benchmarks.GET("", benchmarkHandler.ListBenchmarks)
+		benchmarks.GET("/:symbol/values", benchmarkHandler.GetBenchmarkValues)
+		benchmarks.GET("/:symbol/constituents", benchmarkHandler.GetBenchmarkConstituents)
+	}
+
+	custom := routerGroup.Group("/benchmarks")
+	if br.middlewareManager != nil {
+		br.middlewareManager.ApplyWriteMiddlewares(custom)
+	}
+	{
+		// Índices personalizados calculados con los precios guardados
+		custom.POST("", benchmarkHandler.CreateCustomBenchmark)
+		custom.DELETE("/:symbol", benchmarkHandler.DeleteCustomBenchmark)
+	}
+
+	admin := routerGroup.Group("/admin/benchmarks")
+	if br.middlewareManager != nil {
+		br.middlewareManager.ApplyAdminMiddlewares(admin)
+	}
+	{
+		// Descarga valores y composición del proveedor
+		admin.POST("/:symbol/sync", benchmarkHandler.SyncBenchmark)
+	}
+}
+
+// GetBenchmarkRoutesInfo retorna información sobre las rutas de benchmarks disponibles
+func (br *BenchmarkRoutes) GetBenchmarkRoutesInfo() map[string]interface{} {
+	return map[string]interface{}{
+		"entity":    "benchmarks",
+		"base_path": "/benchmarks",
+		"operations": map[string][]string{
+			"read": {
+				"GET /benchmarks",
+				"GET /benchmarks/:symbol/values",
+				"GET /benchmarks/:symbol/constituents",
+			},
+			"write": {
+				"POST /benchmarks",
+				"DELETE /benchmarks/:symbol",
+			},
+			"admin": {
+				"POST /admin/benchmarks/:symbol/sync",
+			},
+		},
+	}
+}
@@ -42,38 +42,66 @@ func (br *BrokerageRoutes) SetupBrokerageRoutes(routerGroup *gin.RouterGroup, br
 
 // setupCRUDRoutes configura las operaciones básicas CRUD
 func (br *BrokerageRoutes) setupCRUDRoutes(brokerages *gin.RouterGroup, brokerageHandler *handlers.BrokerageHandler) {
-	// Create - Crear un nuevo brokerage
-	brokerages.POST("/", brokerageHandler.CreateBrokerage)
+	// Grupo para operaciones de escritura (CREATE, UPDATE, DELETE)
+	writeOps := brokerages.Group("")
+	if br.middlewareManager != nil {
+		br.middlewareManager.ApplyWriteMiddlewares(writeOps)
+	}
+	{
+		// Create - Crear un nuevo brokerage
+		writeOps.POST("/", brokerageHandler.CreateBrokerage)
 
-	// Read - Obtener brokerage por ID
-	brokerages.GET("/:id", brokerageHandler.GetBrokerageByID)
+		// Update - Actualizar brokerage completo
+		writeOps.PUT("/:id", brokerageHandler.UpdateBrokerage)
 
-	// Update - Actualizar brokerage completo
-	brokerages.PUT("/:id", brokerageHandler.UpdateBrokerage)
+		// Delete - Eliminar brokerage
+		writeOps.DELETE("/:id", brokerageHandler.DeleteBrokerage)
+	}
 
-	// Delete - Eliminar brokerage
-	brokerages.DELETE("/:id", brokerageHandler.DeleteBrokerage)
+	// Grupo para operaciones de lectura (READ, LIST)
+	readOps := brokerages.Group("")
+	if br.middlewareManager != nil {
+		br.middlewareManager.ApplyReadOnlyMiddlewares(readOps)
+	}
+	{
+		// Read - Obtener brokerage por ID
+		readOps.GET("/:id", brokerageHandler.GetBrokerageByID)
 
-	// List operations
-	brokerages.GET("/", brokerageHandler.ListBrokerages)
-	brokerages.GET("/active", brokerageHandler.ListActiveBrokerages)
+		// List operations
+		readOps.GET("/", brokerageHandler.ListBrokerages)
+		readOps.GET("/active", brokerageHandler.ListActiveBrokerages)
+	}
 }
 
 // setupStateRoutes configura las rutas de gestión de estado
 func (br *BrokerageRoutes) setupStateRoutes(brokerages *gin.RouterGroup, brokerageHandler *handlers.BrokerageHandler) {
-	// Activación y desactivación
-	brokerages.PATCH("/:id/activate", brokerageHandler.ActivateBrokerage)
-	brokerages.PATCH("/:id/deactivate", brokerageHandler.DeactivateBrokerage)
+	// Grupo para operaciones de administración (requieren permisos especiales)
+	adminOps := brokerages.Group("")
+	if br.middlewareManager != nil {
+		br.middlewareManager.ApplyAdminMiddlewares(adminOps)
+	}
+	{
+		// Activación y desactivación
+		adminOps.PATCH("/:id/activate", brokerageHandler.ActivateBrokerage)
+		adminOps.PATCH("/:id/deactivate", brokerageHandler.DeactivateBrokerage)
 
-	// Futuras operaciones de estado se pueden agregar aquí
-	// brokerages.PATCH("/:id/suspend", brokerageHandler.SuspendBrokerage)
-	// brokerages.PATCH("/:id/verify", brokerageHandler.VerifyBrokerage)
+		// Futuras operaciones de estado se pueden agregar aquí
+		// adminOps.PATCH("/:id/suspend", brokerageHandler.SuspendBrokerage)
+		// adminOps.PATCH("/:id/verify", brokerageHandler.VerifyBrokerage)
+	}
 }
 
 // setupSearchRoutes configura las rutas de búsqueda
 func (br *BrokerageRoutes) setupSearchRoutes(brokerages *gin.RouterGroup, brokerageHandler *handlers.BrokerageHandler) {
-	// Búsqueda por nombre
-	brokerages.GET("/search", brokerageHandler.SearchBrokeragesByName)
+	// Grupo para operaciones de búsqueda
+	searchOps := brokerages.Group("")
+	if br.middlewareManager != nil {
+		br.middlewareManager.ApplySearchMiddlewares(searchOps)
+	}
+	{
+		// Búsqueda por nombre
+		searchOps.GET("/search", brokerageHandler.SearchBrokeragesByName)
+	}
 
 	// Futuras búsquedas se pueden agregar aquí
 	// brokerages.GET("/country/:country", brokerageHandler.GetBrokeragesByCountry)
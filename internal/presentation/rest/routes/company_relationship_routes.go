@@ -0,0 +1,66 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/handlers"
+)
+
+// CompanyRelationshipRoutes encapsula la configuración de rutas de relaciones entre companies
+type CompanyRelationshipRoutes struct {
+	middlewareManager *MiddlewareManager
+}
+
+// NewCompanyRelationshipRoutes crea una nueva instancia del configurador de rutas de relaciones
+func NewCompanyRelationshipRoutes(middlewareManager *MiddlewareManager) *CompanyRelationshipRoutes {
+	return &CompanyRelationshipRoutes{
+		middlewareManager: middlewareManager,
+	}
+}
+
+// SetupCompanyRelationshipRoutes configura las relaciones y el grafo de una company bajo /companies/:id
+func (rr *CompanyRelationshipRoutes) SetupCompanyRelationshipRoutes(routerGroup *gin.RouterGroup, relationshipHandler *handlers.CompanyRelationshipHandler) {
+	// Verificar que el handler existe
+	if relationshipHandler == nil {
+		return
+	}
+
+	readOps := routerGroup.Group("/companies")
+	if rr.middlewareManager != nil {
+		rr.middlewareManager.ApplyReadOnlyMiddlewares(readOps)
+	}
+	{
+		// La company se indica por ID o ticker en el segmento :id
+		readOps.GET("/:id/relationships", relationshipHandler.ListCompanyRelationships)
+		readOps.GET("/:id/graph", relationshipHandler.GetCompanyGraph)
+	}
+
+	writeOps := routerGroup.Group("/companies")
+	if rr.middlewareManager != nil {
+		rr.middlewareManager.ApplyWriteMiddlewares(writeOps)
+	}
+	{
+		writeOps.POST("/:id/relationships", relationshipHandler.CreateCompanyRelationship)
+		writeOps.PUT("/:id/relationships/:relationship_id", relationshipHandler.UpdateCompanyRelationship)
+		writeOps.DELETE("/:id/relationships/:relationship_id", relationshipHandler.DeleteCompanyRelationship)
+	}
+}
+
+// GetCompanyRelationshipRoutesInfo retorna información sobre las rutas de relaciones disponibles
+func (rr *CompanyRelationshipRoutes) GetCompanyRelationshipRoutesInfo() map[string]interface{} {
+	return map[string]interface{}{
+		"entity":    "company_relationships",
+		"base_path": "/companies",
+		"operations": map[string][]string{
+			"read": {
+				"GET /companies/:id/relationships",
+				"GET /companies/:id/graph",
+			},
+			"write": {
+				"POST /companies/:id/relationships",
+				"PUT /companies/:id/relationships/:relationship_id",
+				"DELETE /companies/:id/relationships/:relationship_id",
+			},
+		},
+	}
+}
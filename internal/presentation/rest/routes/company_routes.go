@@ -68,6 +68,12 @@ func (cr *CompanyRoutes) setupCRUDRoutes(companies *gin.RouterGroup, companyHand
 		readOps.GET("/:id", companyHandler.GetCompanyByID)
 		readOps.GET("/ticker/:ticker", companyHandler.GetCompanyByTicker)
 
+		// Proxy de logos: gin exige el mismo nombre de parámetro que /:id, pero el valor es el ticker
+		readOps.GET("/:id/logo", companyHandler.GetCompanyLogo)
+
+		// Agregado para la página de detalle (perfil, cotización, financieros, ratings y noticias)
+		readOps.GET("/:id/overview", companyHandler.GetCompanyOverview)
+
 		// List operations
 		readOps.GET("/", companyHandler.ListCompanies)
 		readOps.GET("/active", companyHandler.ListActiveCompanies)
@@ -89,6 +95,13 @@ func (cr *CompanyRoutes) setupStateRoutes(companies *gin.RouterGroup, companyHan
 		// Actualización de market cap
 		adminOps.PATCH("/:id/market-cap", companyHandler.UpdateMarketCap)
 
+		// Recuperación de companies eliminadas (soft delete)
+		adminOps.GET("/deleted", companyHandler.ListDeletedCompanies)
+		adminOps.POST("/:id/restore", companyHandler.RestoreCompany)
+
+		// Cambio de ticker (el anterior queda como alias)
+		adminOps.POST("/:id/remap-ticker", companyHandler.RemapTicker)
+
 		// Futuras operaciones de estado se pueden agregar aquí
 		// adminOps.PATCH("/:id/suspend", companyHandler.SuspendCompany)
 		// adminOps.PATCH("/:id/verify", companyHandler.VerifyCompany)
@@ -106,6 +119,9 @@ func (cr *CompanyRoutes) setupSearchRoutes(companies *gin.RouterGroup, companyHa
 		// Búsqueda por nombre
 		searchOps.GET("/search", companyHandler.SearchCompaniesByName)
 
+		// Autocompletado por prefijo de ticker o nombre
+		searchOps.GET("/suggest", companyHandler.SuggestCompanies)
+
 		// Filtrado por sector
 		searchOps.GET("/sector/:sector", companyHandler.GetCompaniesBySector)
 
@@ -125,6 +141,8 @@ func (cr *CompanyRoutes) GetCompanyRoutesInfo() map[string]interface{} {
 				"POST /companies",
 				"GET /companies/:id",
 				"GET /companies/ticker/:ticker",
+				"GET /companies/:ticker/logo",
+				"GET /companies/:ticker/overview",
 				"PUT /companies/:id",
 				"DELETE /companies/:id",
 				"GET /companies",
@@ -134,6 +152,9 @@ func (cr *CompanyRoutes) GetCompanyRoutesInfo() map[string]interface{} {
 				"PATCH /companies/:id/activate",
 				"PATCH /companies/:id/deactivate",
 				"PATCH /companies/:id/market-cap",
+				"GET /companies/deleted",
+				"POST /companies/:id/restore",
+				"POST /companies/:id/remap-ticker",
 			},
 			"search": {
 				"GET /companies/search",
@@ -0,0 +1,48 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/handlers"
+)
+
+// DashboardRoutes encapsula la configuración de rutas del dashboard
+type DashboardRoutes struct {
+	middlewareManager *MiddlewareManager
+}
+
+// NewDashboardRoutes crea una nueva instancia del configurador de rutas del dashboard
+func NewDashboardRoutes(middlewareManager *MiddlewareManager) *DashboardRoutes {
+	return &DashboardRoutes{
+		middlewareManager: middlewareManager,
+	}
+}
+
+// SetupDashboardRoutes configura el resumen de la pantalla de inicio
+func (dr *DashboardRoutes) SetupDashboardRoutes(routerGroup *gin.RouterGroup, dashboardHandler *handlers.DashboardHandler) {
+	// Verificar que el handler existe
+	if dashboardHandler == nil {
+		return
+	}
+
+	dashboard := routerGroup.Group("/dashboard")
+	if dr.middlewareManager != nil {
+		dr.middlewareManager.ApplyReadOnlyMiddlewares(dashboard)
+	}
+	{
+		dashboard.GET("", dashboardHandler.GetDashboard)
+	}
+}
+
+// GetDashboardRoutesInfo retorna información sobre las rutas del dashboard disponibles
+func (dr *DashboardRoutes) GetDashboardRoutesInfo() map[string]interface{} {
+	return map[string]interface{}{
+		"entity":    "dashboard",
+		"base_path": "/dashboard",
+		"operations": map[string][]string{
+			"read": {
+				"GET /dashboard?alerts_since=",
+			},
+		},
+	}
+}
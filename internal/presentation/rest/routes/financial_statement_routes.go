@@ -0,0 +1,49 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/handlers"
+)
+
+// FinancialStatementRoutes encapsula la configuración de rutas de estados financieros de companies
+type FinancialStatementRoutes struct {
+	middlewareManager *MiddlewareManager
+}
+
+// NewFinancialStatementRoutes crea una nueva instancia del configurador de rutas de estados financieros
+func NewFinancialStatementRoutes(middlewareManager *MiddlewareManager) *FinancialStatementRoutes {
+	return &FinancialStatementRoutes{
+		middlewareManager: middlewareManager,
+	}
+}
+
+// SetupFinancialStatementRoutes configura los estados financieros bajo /companies
+func (fr *FinancialStatementRoutes) SetupFinancialStatementRoutes(routerGroup *gin.RouterGroup, statementHandler *handlers.FinancialStatementHandler) {
+	// Verificar que el handler existe
+	if statementHandler == nil {
+		return
+	}
+
+	financials := routerGroup.Group("/companies")
+	if fr.middlewareManager != nil {
+		fr.middlewareManager.ApplyReadOnlyMiddlewares(financials)
+	}
+	{
+		// Estados por ticker (el parámetro se llama :id como en el resto de /companies/:id)
+		financials.GET("/:id/financials", statementHandler.GetCompanyFinancials)
+	}
+}
+
+// GetFinancialStatementRoutesInfo retorna información sobre las rutas de estados financieros disponibles
+func (fr *FinancialStatementRoutes) GetFinancialStatementRoutesInfo() map[string]interface{} {
+	return map[string]interface{}{
+		"entity":    "financial_statements",
+		"base_path": "/companies",
+		"operations": map[string][]string{
+			"financials": {
+				"GET /companies/:ticker/financials",
+			},
+		},
+	}
+}
@@ -52,6 +52,10 @@ func (hr *HealthRoutes) setupHealthGroup(engine *gin.Engine, healthHandler *hand
 		health.GET("/ready", healthHandler.Readiness)
 	}
 
+	// Alias en la raíz con los nombres convencionales de Kubernetes
+	engine.GET("/healthz", healthHandler.Liveness)
+	engine.GET("/readyz", healthHandler.Readiness)
+
 	// Configurar rutas adicionales de health si están habilitadas
 	hr.setupExtendedHealthRoutes(health, healthHandler)
 }
@@ -82,13 +86,13 @@ func (hr *HealthRoutes) GetHealthEndpoints() map[string]interface{} {
 	if hr.config.RESTAPI.EnableHealthChecks {
 		endpoints["available_endpoints"] = map[string]string{
 			"health":    "/health",
-			"liveness":  "/health/live",
-			"readiness": "/health/ready",
+			"liveness":  "/healthz",
+			"readiness": "/readyz",
 		}
 		endpoints["description"] = map[string]string{
-			"/health":       "General health status with all components",
-			"/health/live":  "Liveness probe - application is running",
-			"/health/ready": "Readiness probe - application is ready to serve traffic",
+			"/health":  "Per-dependency status and latency (database, cache, Finnhub, Alpha Vantage, stock API)",
+			"/healthz": "Liveness probe - application is running (alias: /health/live)",
+			"/readyz":  "Readiness probe - database and cache are ready to serve traffic (alias: /health/ready)",
 		}
 	}
 
@@ -25,6 +25,7 @@ func (mr *MarketDataRoutes) SetupMarketDataRoutes(group *gin.RouterGroup, handle
 	{
 		// Real-time market data endpoints
 		marketData.GET("/quote/:symbol", handler.GetRealTimeQuote)
+		marketData.GET("/:symbol/intraday", handler.GetIntradayQuotes)
 
 		// Company profile endpoints
 		marketData.GET("/profile/:symbol", handler.GetCompanyProfile)
@@ -37,5 +38,6 @@ func (mr *MarketDataRoutes) SetupMarketDataRoutes(group *gin.RouterGroup, handle
 
 		// Market overview endpoints
 		marketData.GET("/overview", handler.GetMarketOverview)
+		marketData.GET("/movers", handler.GetMarketMovers)
 	}
 }
@@ -0,0 +1,48 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/handlers"
+)
+
+// MarketStatusRoutes encapsula la configuración de rutas del estado del mercado
+type MarketStatusRoutes struct {
+	middlewareManager *MiddlewareManager
+}
+
+// NewMarketStatusRoutes crea una nueva instancia del configurador de rutas del estado del mercado
+func NewMarketStatusRoutes(middlewareManager *MiddlewareManager) *MarketStatusRoutes {
+	return &MarketStatusRoutes{
+		middlewareManager: middlewareManager,
+	}
+}
+
+// SetupMarketStatusRoutes configura las rutas bajo /market
+func (mr *MarketStatusRoutes) SetupMarketStatusRoutes(routerGroup *gin.RouterGroup, marketStatusHandler *handlers.MarketStatusHandler) {
+	// Verificar que el handler existe
+	if marketStatusHandler == nil {
+		return
+	}
+
+	market := routerGroup.Group("/market")
+	if mr.middlewareManager != nil {
+		mr.middlewareManager.ApplyReadOnlyMiddlewares(market)
+	}
+	{
+		market.GET("/status", marketStatusHandler.GetMarketStatus)
+	}
+}
+
+// GetMarketStatusRoutesInfo retorna información sobre las rutas del estado del mercado disponibles
+func (mr *MarketStatusRoutes) GetMarketStatusRoutesInfo() map[string]interface{} {
+	return map[string]interface{}{
+		"entity":    "market",
+		"base_path": "/market",
+		"operations": map[string][]string{
+			"status": {
+				"GET /market/status?exchange=",
+			},
+		},
+	}
+}
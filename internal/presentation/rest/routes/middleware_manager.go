@@ -3,21 +3,28 @@ package routes
 import (
 	"github.com/gin-gonic/gin"
 
+	"github.com/MayaCris/stock-info-app/internal/domain/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/tenancy"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/config"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
 )
 
 // MiddlewareManager gestiona la aplicación de middlewares específicos por tipo de ruta
 type MiddlewareManager struct {
-	config *config.Config
-	logger logger.Logger
+	config            *config.Config
+	logger            logger.Logger
+	cacheService      services.CacheService
+	rateLimitSettings *middleware.RateLimitSettings
 }
 
 // NewMiddlewareManager crea una nueva instancia del gestor de middlewares
-func NewMiddlewareManager(cfg *config.Config, appLogger logger.Logger) *MiddlewareManager {
+func NewMiddlewareManager(cfg *config.Config, appLogger logger.Logger, cacheService services.CacheService, rateLimitSettings *middleware.RateLimitSettings) *MiddlewareManager {
 	return &MiddlewareManager{
-		config: cfg,
-		logger: appLogger,
+		config:            cfg,
+		logger:            appLogger,
+		cacheService:      cacheService,
+		rateLimitSettings: rateLimitSettings,
 	}
 }
 
@@ -28,10 +35,10 @@ func (mm *MiddlewareManager) ApplyReadOnlyMiddlewares(group *gin.RouterGroup) {
 	group.Use(mm.cacheHeadersMiddleware())
 
 	// Rate limiting específico para lecturas (más permisivo)
-	if mm.config.RateLimit.Enabled {
-		// Se puede implementar un rate limit específico para lecturas
-		// group.Use(middleware.ReadOnlyRateLimitMiddleware(mm.config.RateLimit))
-	}
+	mm.applyGroupRateLimit(group, config.RateLimitGroupRead)
+
+	// Rol mínimo para lecturas (viewer por defecto, abierto a peticiones anónimas)
+	mm.applyGroupAuthorization(group, config.RateLimitGroupRead)
 }
 
 // ApplyWriteMiddlewares aplica middlewares específicos para operaciones de escritura
@@ -41,28 +48,22 @@ func (mm *MiddlewareManager) ApplyWriteMiddlewares(group *gin.RouterGroup) {
 	group.Use(mm.writeValidationMiddleware())
 
 	// Rate limiting más estricto para escrituras
-	if mm.config.RateLimit.Enabled {
-		// Rate limit más restrictivo para operaciones de escritura
-		// group.Use(middleware.WriteRateLimitMiddleware(mm.config.RateLimit))
-	}
+	mm.applyGroupRateLimit(group, config.RateLimitGroupWrite)
 
-	// Futuro: Authentication/Authorization middleware
-	// group.Use(middleware.AuthenticationMiddleware())
-	// group.Use(middleware.AuthorizationMiddleware("write"))
+	// Rol mínimo para escrituras (editor por defecto)
+	mm.applyGroupAuthorization(group, config.RateLimitGroupWrite)
 }
 
 // ApplyAdminMiddlewares aplica middlewares específicos para operaciones administrativas
 // Estas operaciones requieren los permisos más altos
 func (mm *MiddlewareManager) ApplyAdminMiddlewares(group *gin.RouterGroup) {
 	// Rate limiting muy estricto para operaciones admin
-	if mm.config.RateLimit.Enabled {
-		// Rate limit muy restrictivo para operaciones administrativas
-		// group.Use(middleware.AdminRateLimitMiddleware(mm.config.RateLimit))
-	}
+	mm.applyGroupRateLimit(group, config.RateLimitGroupAdmin)
 
-	// Futuro: Admin authentication/authorization
-	// group.Use(middleware.AuthenticationMiddleware())
-	// group.Use(middleware.AuthorizationMiddleware("admin"))
+	// Con multi-tenancy solo la clave de administración; el rol de una credencial de tenant no basta
+	if mm.config.Tenancy.Enabled {
+		group.Use(middleware.RequirePlatformAdmin())
+	}
 
 	// Audit logging para operaciones administrativas
 	group.Use(mm.auditLoggingMiddleware())
@@ -72,13 +73,43 @@ func (mm *MiddlewareManager) ApplyAdminMiddlewares(group *gin.RouterGroup) {
 // Estas operaciones pueden ser costosas computacionalmente
 func (mm *MiddlewareManager) ApplySearchMiddlewares(group *gin.RouterGroup) {
 	// Rate limiting específico para búsquedas (puede ser costoso)
-	if mm.config.RateLimit.Enabled {
-		// Rate limit específico para búsquedas
-		// group.Use(middleware.SearchRateLimitMiddleware(mm.config.RateLimit))
-	}
+	mm.applyGroupRateLimit(group, config.RateLimitGroupSearch)
 
 	// Cache headers optimizados para búsquedas
 	group.Use(mm.searchCacheHeadersMiddleware())
+
+	// Rol mínimo para búsquedas (viewer por defecto)
+	mm.applyGroupAuthorization(group, config.RateLimitGroupSearch)
+}
+
+// applyGroupRateLimit añade un bucket propio para el grupo de rutas si tiene un límite configurado.
+// Sin límite propio el grupo queda cubierto únicamente por el rate limit global.
+func (mm *MiddlewareManager) applyGroupRateLimit(group *gin.RouterGroup, rateLimitGroup string) {
+	if !mm.config.RateLimit.Enabled || !mm.config.RateLimit.HasGroupLimit(rateLimitGroup) {
+		return
+	}
+
+	group.Use(middleware.TokenBucketRateLimitMiddlewareWithSettings(mm.cacheService, mm.rateLimitSettings, rateLimitGroup))
+}
+
+// applyGroupAuthorization exige el rol del mapa de permisos para el grupo de rutas.
+// Solo se aplica con multi-tenancy y RBAC activos; un grupo sin rol en el mapa no se restringe.
+func (mm *MiddlewareManager) applyGroupAuthorization(group *gin.RouterGroup, routeGroup string) {
+	tenancyConfig := mm.config.Tenancy
+	if !tenancyConfig.Enabled || !tenancyConfig.RBACEnabled {
+		return
+	}
+
+	required, ok := tenancyConfig.RolePermissions[routeGroup]
+	if !ok || required == "" {
+		return
+	}
+
+	anonymousRole := tenancy.Role(tenancyConfig.AnonymousRole)
+	if tenancyConfig.AnonymousRole == config.AnonymousRoleNone {
+		anonymousRole = ""
+	}
+	group.Use(middleware.RequireRole(tenancy.Role(required), anonymousRole))
 }
 
 // cacheHeadersMiddleware añade headers de cache apropiados para operaciones de lectura
@@ -157,16 +188,17 @@ func (mm *MiddlewareManager) GetMiddlewareInfo() map[string]interface{} {
 			"search",
 		},
 		"features": map[string][]string{
-			"read_only": {"cache_headers", "permissive_rate_limit"},
-			"write":     {"validation", "strict_rate_limit", "security_headers"},
-			"admin":     {"audit_logging", "very_strict_rate_limit", "admin_auth"},
-			"search":    {"search_rate_limit", "search_cache_headers"},
+			"read_only": {"cache_headers", "permissive_rate_limit", "viewer_role"},
+			"write":     {"validation", "strict_rate_limit", "security_headers", "editor_role"},
+			"admin":     {"audit_logging", "very_strict_rate_limit", "admin_role"},
+			"search":    {"search_rate_limit", "search_cache_headers", "viewer_role"},
 		},
 		"security": map[string]bool{
 			"content_type_validation": true,
 			"security_headers":        true,
 			"audit_logging":           true,
 			"rate_limiting":           mm.config.RateLimit.Enabled,
+			"role_based_access":       mm.config.Tenancy.Enabled && mm.config.Tenancy.RBACEnabled,
 		},
 	}
 }
@@ -0,0 +1,56 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/handlers"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
+)
+
+// NoteRoutes encapsula la configuración de rutas de las notas de los usuarios
+type NoteRoutes struct {
+	middlewareManager *MiddlewareManager
+}
+
+// NewNoteRoutes crea una nueva instancia del configurador de rutas de notas
+func NewNoteRoutes(middlewareManager *MiddlewareManager) *NoteRoutes {
+	return &NoteRoutes{
+		middlewareManager: middlewareManager,
+	}
+}
+
+// SetupNoteRoutes configura las notas privadas del usuario autenticado bajo /notes
+func (nr *NoteRoutes) SetupNoteRoutes(routerGroup *gin.RouterGroup, noteHandler *handlers.NoteHandler) {
+	// Verificar que el handler existe
+	if noteHandler == nil {
+		return
+	}
+
+	// Cada usuario ve solo sus notas; cualquier rol las gestiona y nunca se cachean como lecturas públicas
+	notes := routerGroup.Group("/notes")
+	notes.Use(middleware.RequireTenant())
+	{
+		notes.GET("", noteHandler.ListNotes)
+		notes.POST("", noteHandler.CreateNote)
+		notes.GET("/:id", noteHandler.GetNote)
+		notes.PUT("/:id", noteHandler.UpdateNote)
+		notes.DELETE("/:id", noteHandler.DeleteNote)
+	}
+}
+
+// GetNoteRoutesInfo retorna información sobre las rutas de notas disponibles
+func (nr *NoteRoutes) GetNoteRoutesInfo() map[string]interface{} {
+	return map[string]interface{}{
+		"entity":    "notes",
+		"base_path": "/notes",
+		"operations": map[string][]string{
+			"self_service": {
+				"GET /notes",
+				"POST /notes",
+				"GET /notes/:id",
+				"PUT /notes/:id",
+				"DELETE /notes/:id",
+			},
+		},
+	}
+}
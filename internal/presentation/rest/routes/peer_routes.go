@@ -0,0 +1,49 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/handlers"
+)
+
+// PeerRoutes encapsula la configuración de rutas de competidores de companies
+type PeerRoutes struct {
+	middlewareManager *MiddlewareManager
+}
+
+// NewPeerRoutes crea una nueva instancia del configurador de rutas de peers
+func NewPeerRoutes(middlewareManager *MiddlewareManager) *PeerRoutes {
+	return &PeerRoutes{
+		middlewareManager: middlewareManager,
+	}
+}
+
+// SetupPeerRoutes configura el descubrimiento de peers bajo /companies
+func (pr *PeerRoutes) SetupPeerRoutes(routerGroup *gin.RouterGroup, peerHandler *handlers.PeerHandler) {
+	// Verificar que el handler existe
+	if peerHandler == nil {
+		return
+	}
+
+	peers := routerGroup.Group("/companies")
+	if pr.middlewareManager != nil {
+		pr.middlewareManager.ApplyReadOnlyMiddlewares(peers)
+	}
+	{
+		// Competidores por ticker (el parámetro se llama :id como en el resto de /companies/:id)
+		peers.GET("/:id/peers", peerHandler.GetCompanyPeers)
+	}
+}
+
+// GetPeerRoutesInfo retorna información sobre las rutas de peers disponibles
+func (pr *PeerRoutes) GetPeerRoutesInfo() map[string]interface{} {
+	return map[string]interface{}{
+		"entity":    "peers",
+		"base_path": "/companies",
+		"operations": map[string][]string{
+			"peers": {
+				"GET /companies/:ticker/peers",
+			},
+		},
+	}
+}
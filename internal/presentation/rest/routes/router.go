@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"context"
 	"net/http"
 	"time"
 
@@ -9,9 +10,12 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 
 	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/domain/services"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/config"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/database/cockroachdb"
 	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
 	"github.com/MayaCris/stock-info-app/internal/presentation/rest/handlers"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/i18n"
 	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
 )
 
@@ -21,6 +25,10 @@ type Router struct {
 	config       *config.Config
 	logger       logger.Logger
 	serverLogger logger.ServerLogger
+	cacheService services.CacheService
+
+	// Límites de rate limiting compartidos por todos los middlewares (recargables en caliente)
+	rateLimitSettings *middleware.RateLimitSettings
 }
 
 // Handlers contiene todas las instancias de handlers
@@ -32,13 +40,45 @@ type Handlers struct {
 	Analysis     *handlers.AnalysisHandler
 	MarketData   *handlers.MarketDataHandler
 	AlphaVantage *handlers.AlphaVantageHandler
+	Search       *handlers.SearchHandler
+	Peers        *handlers.PeerHandler
+	Relations    *handlers.CompanyRelationshipHandler
+	Tags         *handlers.TagHandler
+	Financials   *handlers.FinancialStatementHandler
+	Benchmarks   *handlers.BenchmarkHandler
+	Backtest     *handlers.BacktestHandler
+	Anomalies    *handlers.AnomalyHandler
+	MarketStatus *handlers.MarketStatusHandler
+	Trending     *handlers.TrendingHandler
+	Dashboard    *handlers.DashboardHandler
+	Admin        *handlers.AdminHandler
+	Tenants      *handlers.TenantHandler
+	Usage        *handlers.UsageHandler
+	Notes        *handlers.NoteHandler
+
+	// Identifica el tenant de cada petición de la API; nil desactiva la multi-tenancy
+	TenantAuth middleware.TenantAuthenticator
+	// Contadores de uso por credencial; nil no cuenta las peticiones
+	UsageCounters *services.UsageCounters
+	// Cuotas diarias del plan de cada tenant; nil no aplica cuotas
+	PlanQuotas *services.PlanQuotas
 }
 
 // NewRouter crea una nueva instancia del router principal
-func NewRouter(cfg *config.Config, appLogger logger.Logger, serverLogger logger.ServerLogger, handlers *Handlers) *Router {
+// cacheService es opcional: sin él el rate limiting se mantiene en memoria por instancia
+func NewRouter(cfg *config.Config, appLogger logger.Logger, serverLogger logger.ServerLogger, cacheService services.CacheService, handlers *Handlers) *Router {
 	// Configurar modo de Gin
 	gin.SetMode(cfg.Server.Mode)
 
+	// Formato de las respuestas de error (problem+json o envelope legacy)
+	response.ConfigureErrorFormat(cfg.RESTAPI.ErrorFormat, cfg.RESTAPI.ProblemTypeBaseURI)
+
+	// Los errores de validación reportan los campos con su nombre JSON
+	middleware.RegisterJSONFieldNames()
+
+	// Las queries lentas se registran con el request ID que las originó
+	cockroachdb.SetRequestIDResolver(middleware.RequestIDFromContext)
+
 	// Crear engine de Gin
 	engine := gin.New()
 
@@ -47,6 +87,9 @@ func NewRouter(cfg *config.Config, appLogger logger.Logger, serverLogger logger.
 		config:       cfg,
 		logger:       appLogger,
 		serverLogger: serverLogger,
+		cacheService: cacheService,
+
+		rateLimitSettings: middleware.NewRateLimitSettings(cfg.RateLimit),
 	}
 
 	// Configurar middlewares globales
@@ -63,6 +106,11 @@ func (r *Router) GetEngine() *gin.Engine {
 	return r.engine
 }
 
+// RateLimitSettings retorna los límites de rate limiting vigentes para actualizarlos en caliente
+func (r *Router) RateLimitSettings() *middleware.RateLimitSettings {
+	return r.rateLimitSettings
+}
+
 // setupGlobalMiddlewares configura los middlewares globales
 func (r *Router) setupGlobalMiddlewares() {
 	// Advanced Recovery middleware usando ServerLogger - debe ir primero
@@ -71,6 +119,9 @@ func (r *Router) setupGlobalMiddlewares() {
 	// Request ID middleware - para trazabilidad
 	r.engine.Use(middleware.RequestIDMiddleware())
 
+	// Language middleware - negocia el idioma de los mensajes con Accept-Language
+	r.engine.Use(i18n.LanguageMiddleware())
+
 	// Enhanced Server Logging middleware - usar configuración del config
 	serverLoggingConfig := r.config.ServerLogging
 	middlewareConfig := convertToMiddlewareConfig(serverLoggingConfig.Middleware)
@@ -89,8 +140,25 @@ func (r *Router) setupGlobalMiddlewares() {
 	// CORS middleware - para permitir requests cross-origin
 	r.engine.Use(middleware.CORSMiddleware(r.config.CORS))
 
-	// Rate limiting middleware - para controlar el tráfico
-	r.engine.Use(middleware.RateLimitMiddleware(r.config.RateLimit))
+	// Rate limiting middleware - para controlar el tráfico (token bucket compartido si hay cache)
+	if r.cacheService != nil {
+		r.engine.Use(middleware.TokenBucketRateLimitMiddlewareWithSettings(r.cacheService, r.rateLimitSettings, config.RateLimitGroupDefault))
+	} else {
+		r.engine.Use(middleware.RateLimitMiddleware(r.config.RateLimit))
+	}
+
+	// OpenAPI validation middleware - rechaza peticiones que no cumplen la especificación generada
+	if r.config.RESTAPI.ValidateRequests {
+		validator, err := middleware.LoadOpenAPIValidator(r.config.RESTAPI.OpenAPISpecPath)
+		if err != nil {
+			r.logger.Warn(context.Background(), "OpenAPI request validation disabled: spec could not be loaded",
+				logger.String("spec_path", r.config.RESTAPI.OpenAPISpecPath),
+				logger.String("error", err.Error()),
+			)
+		} else {
+			r.engine.Use(middleware.OpenAPIValidationMiddleware(validator))
+		}
+	}
 
 	// Error Response middleware - para estandarizar respuestas de error
 	r.engine.Use(middleware.ErrorResponseMiddleware())
@@ -99,7 +167,7 @@ func (r *Router) setupGlobalMiddlewares() {
 // setupRoutes configura todas las rutas de la aplicación
 func (r *Router) setupRoutes(handlers *Handlers) {
 	// Crear el gestor de middlewares
-	middlewareManager := NewMiddlewareManager(r.config, r.logger)
+	middlewareManager := NewMiddlewareManager(r.config, r.logger, r.cacheService, r.rateLimitSettings)
 
 	// Ruta raíz
 	r.engine.GET("/", r.rootHandler)
@@ -172,12 +240,7 @@ func (r *Router) notFoundHandler(c *gin.Context) {
 	)
 
 	errorResp := response.NotFound("Route")
-	apiResponse := errorResp.ToAPIResponse()
-	if requestID := c.GetString("request_id"); requestID != "" {
-		apiResponse.RequestID = requestID
-	}
-
-	c.JSON(errorResp.StatusCode, apiResponse)
+	middleware.RespondWithError(c, errorResp)
 }
 
 // debugVarsHandler proporciona información de variables del sistema
@@ -0,0 +1,49 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/handlers"
+)
+
+// SearchRoutes encapsula la configuración de rutas de búsqueda de texto completo
+type SearchRoutes struct {
+	middlewareManager *MiddlewareManager
+}
+
+// NewSearchRoutes crea una nueva instancia del configurador de rutas de búsqueda
+func NewSearchRoutes(middlewareManager *MiddlewareManager) *SearchRoutes {
+	return &SearchRoutes{
+		middlewareManager: middlewareManager,
+	}
+}
+
+// SetupSearchRoutes configura la búsqueda global sobre companies y noticias
+func (sr *SearchRoutes) SetupSearchRoutes(routerGroup *gin.RouterGroup, searchHandler *handlers.SearchHandler) {
+	// Verificar que el handler existe
+	if searchHandler == nil {
+		return
+	}
+
+	search := routerGroup.Group("/search")
+	if sr.middlewareManager != nil {
+		sr.middlewareManager.ApplySearchMiddlewares(search)
+	}
+	{
+		// Resultados mixtos (company, news) ordenados por relevancia
+		search.GET("", searchHandler.Search)
+	}
+}
+
+// GetSearchRoutesInfo retorna información sobre las rutas de búsqueda disponibles
+func (sr *SearchRoutes) GetSearchRoutesInfo() map[string]interface{} {
+	return map[string]interface{}{
+		"entity":    "search",
+		"base_path": "/search",
+		"operations": map[string][]string{
+			"search": {
+				"GET /search?q=",
+			},
+		},
+	}
+}
@@ -37,6 +37,42 @@ func (sr *StockRoutes) SetupStockRoutes(routerGroup *gin.RouterGroup, stockHandl
 
 		// Statistics operations
 		sr.setupStatisticsRoutes(stocks, stockHandler)
+
+		// Soft-delete recovery operations
+		sr.setupRecoveryRoutes(stocks, stockHandler)
+	}
+
+	// Búsqueda combinada de ratings
+	sr.setupSearchRoutes(routerGroup, stockHandler)
+
+	// Ratings de una company a una fecha
+	sr.setupAsOfRoutes(routerGroup, stockHandler)
+}
+
+// setupSearchRoutes configura GET /ratings, que combina en una sola consulta los filtros de las rutas de
+// consulta por brokerage, acción, rating, precio objetivo y fechas, y el long-poll GET /ratings/poll
+func (sr *StockRoutes) setupSearchRoutes(routerGroup *gin.RouterGroup, stockHandler *handlers.StockHandler) {
+	ratings := routerGroup.Group("/ratings")
+	if sr.middlewareManager != nil {
+		sr.middlewareManager.ApplyReadOnlyMiddlewares(ratings)
+	}
+	{
+		ratings.GET("", stockHandler.SearchRatings)
+
+		// Long-poll de ratings nuevos para clientes sin WebSockets ni SSE
+		ratings.GET("/poll", stockHandler.PollRatings)
+	}
+}
+
+// setupAsOfRoutes configura GET /companies/:id/ratings/asof, el último rating de cada brokerage hasta una fecha.
+// Vive junto a las rutas de ratings porque lo sirve el handler de stocks; :id coincide con las rutas de companies
+func (sr *StockRoutes) setupAsOfRoutes(routerGroup *gin.RouterGroup, stockHandler *handlers.StockHandler) {
+	companyRatings := routerGroup.Group("/companies/:id/ratings")
+	if sr.middlewareManager != nil {
+		sr.middlewareManager.ApplyReadOnlyMiddlewares(companyRatings)
+	}
+	{
+		companyRatings.GET("/asof", stockHandler.GetRatingsAsOf)
 	}
 }
 
@@ -85,6 +121,7 @@ func (sr *StockRoutes) setupQueryRoutes(stocks *gin.RouterGroup, stockHandler *h
 		// Consultas por tiempo
 		queryOps.GET("/recent", stockHandler.GetRecentRatings)
 		queryOps.GET("/date-range", stockHandler.GetRatingsByDateRange)
+		queryOps.GET("/period/:period", stockHandler.GetRatingsForPeriod) // today, week o month en la zona de ?tz=
 	}
 }
 
@@ -103,6 +140,19 @@ func (sr *StockRoutes) setupStatisticsRoutes(stocks *gin.RouterGroup, stockHandl
 	}
 }
 
+// setupRecoveryRoutes configura las rutas de recuperación de ratings eliminados (soft delete)
+func (sr *StockRoutes) setupRecoveryRoutes(stocks *gin.RouterGroup, stockHandler *handlers.StockHandler) {
+	// Grupo para operaciones de administración (requieren permisos especiales)
+	adminOps := stocks.Group("")
+	if sr.middlewareManager != nil {
+		sr.middlewareManager.ApplyAdminMiddlewares(adminOps)
+	}
+	{
+		adminOps.GET("/deleted", stockHandler.ListDeletedStockRatings)
+		adminOps.POST("/:id/restore", stockHandler.RestoreStockRating)
+	}
+}
+
 // GetStockRoutesInfo retorna información sobre las rutas de stocks disponibles
 func (sr *StockRoutes) GetStockRoutesInfo() map[string]interface{} {
 	return map[string]interface{}{
@@ -121,10 +171,22 @@ func (sr *StockRoutes) GetStockRoutesInfo() map[string]interface{} {
 				"GET /stocks/brokerage/:brokerage_id",
 				"GET /stocks/recent",
 				"GET /stocks/date-range",
+				"GET /stocks/period/:period",
 			},
 			"statistics": {
 				"GET /stocks/stats/company/:company_id",
 			},
+			"search": {
+				"GET /ratings",
+				"GET /ratings/poll",
+			},
+			"history": {
+				"GET /companies/:id/ratings/asof",
+			},
+			"recovery": {
+				"GET /stocks/deleted",
+				"POST /stocks/:id/restore",
+			},
 		},
 	}
 }
@@ -0,0 +1,73 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/handlers"
+)
+
+// TagRoutes encapsula la configuración de rutas de etiquetas temáticas
+type TagRoutes struct {
+	middlewareManager *MiddlewareManager
+}
+
+// NewTagRoutes crea una nueva instancia del configurador de rutas de etiquetas
+func NewTagRoutes(middlewareManager *MiddlewareManager) *TagRoutes {
+	return &TagRoutes{
+		middlewareManager: middlewareManager,
+	}
+}
+
+// SetupTagRoutes configura la consulta de etiquetas bajo /tags y /companies/:id y su gestión bajo /admin/tags
+func (tr *TagRoutes) SetupTagRoutes(routerGroup *gin.RouterGroup, tagHandler *handlers.TagHandler) {
+	// Verificar que el handler existe
+	if tagHandler == nil {
+		return
+	}
+
+	tags := routerGroup.Group("")
+	if tr.middlewareManager != nil {
+		tr.middlewareManager.ApplyReadOnlyMiddlewares(tags)
+	}
+	{
+		tags.GET("/tags", tagHandler.ListTags)
+
+		// La company se indica por ID o ticker en el segmento :id
+		tags.GET("/companies/:id/tags", tagHandler.GetCompanyTags)
+	}
+
+	admin := routerGroup.Group("/admin/tags")
+	if tr.middlewareManager != nil {
+		tr.middlewareManager.ApplyAdminMiddlewares(admin)
+	}
+	{
+		admin.POST("", tagHandler.CreateTag)
+		admin.PUT("/:slug", tagHandler.UpdateTag)
+		admin.DELETE("/:slug", tagHandler.DeleteTag)
+
+		// Asignación a companies (por ID o ticker)
+		admin.PUT("/:slug/companies/:company", tagHandler.TagCompany)
+		admin.DELETE("/:slug/companies/:company", tagHandler.UntagCompany)
+	}
+}
+
+// GetTagRoutesInfo retorna información sobre las rutas de etiquetas disponibles
+func (tr *TagRoutes) GetTagRoutesInfo() map[string]interface{} {
+	return map[string]interface{}{
+		"entity":    "tags",
+		"base_path": "/tags",
+		"operations": map[string][]string{
+			"read": {
+				"GET /tags",
+				"GET /companies/:id/tags",
+			},
+			"admin": {
+				"POST /admin/tags",
+				"PUT /admin/tags/:slug",
+				"DELETE /admin/tags/:slug",
+				"PUT /admin/tags/:slug/companies/:company",
+				"DELETE /admin/tags/:slug/companies/:company",
+			},
+		},
+	}
+}
@@ -0,0 +1,74 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/handlers"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
+)
+
+// TenantRoutes encapsula la configuración de rutas de tenants y API keys
+type TenantRoutes struct {
+	middlewareManager *MiddlewareManager
+}
+
+// NewTenantRoutes crea una nueva instancia del configurador de rutas de tenants
+func NewTenantRoutes(middlewareManager *MiddlewareManager) *TenantRoutes {
+	return &TenantRoutes{
+		middlewareManager: middlewareManager,
+	}
+}
+
+// SetupTenantRoutes configura la administración de tenants bajo /admin/tenants y el autoservicio bajo /me
+func (tr *TenantRoutes) SetupTenantRoutes(routerGroup *gin.RouterGroup, tenantHandler *handlers.TenantHandler) {
+	// Verificar que el handler existe
+	if tenantHandler == nil {
+		return
+	}
+
+	tenants := routerGroup.Group("/admin/tenants")
+	if tr.middlewareManager != nil {
+		tr.middlewareManager.ApplyAdminMiddlewares(tenants)
+	}
+	{
+		tenants.POST("", tenantHandler.CreateTenant)
+		tenants.GET("", tenantHandler.ListTenants)
+		tenants.PATCH("/:id", tenantHandler.UpdateTenant)
+		tenants.POST("/:id/api-keys", tenantHandler.CreateTenantAPIKey)
+	}
+
+	// Autoservicio del tenant autenticado por su API key o JWT; cualquier rol gestiona sus claves,
+	// sin poder crear claves con un rol superior al propio
+	me := routerGroup.Group("/me")
+	me.Use(middleware.RequireTenant())
+	{
+		me.GET("", tenantHandler.GetCurrentTenant)
+		me.GET("/api-keys", tenantHandler.ListAPIKeys)
+		me.POST("/api-keys", tenantHandler.CreateAPIKey)
+		me.DELETE("/api-keys/:id", tenantHandler.RevokeAPIKey)
+		me.PUT("/api-keys/:id/timezone", tenantHandler.SetAPIKeyTimezone)
+	}
+}
+
+// GetTenantRoutesInfo retorna información sobre las rutas de tenants disponibles
+func (tr *TenantRoutes) GetTenantRoutesInfo() map[string]interface{} {
+	return map[string]interface{}{
+		"entity":    "tenants",
+		"base_path": "/admin/tenants",
+		"operations": map[string][]string{
+			"admin": {
+				"POST /admin/tenants",
+				"GET /admin/tenants",
+				"PATCH /admin/tenants/:id",
+				"POST /admin/tenants/:id/api-keys",
+			},
+			"self_service": {
+				"GET /me",
+				"GET /me/api-keys",
+				"POST /me/api-keys",
+				"DELETE /me/api-keys/:id",
+				"PUT /me/api-keys/:id/timezone",
+			},
+		},
+	}
+}
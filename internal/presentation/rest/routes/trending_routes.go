@@ -0,0 +1,48 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/handlers"
+)
+
+// TrendingRoutes encapsula la configuración de rutas de trending
+type TrendingRoutes struct {
+	middlewareManager *MiddlewareManager
+}
+
+// NewTrendingRoutes crea una nueva instancia del configurador de rutas de trending
+func NewTrendingRoutes(middlewareManager *MiddlewareManager) *TrendingRoutes {
+	return &TrendingRoutes{
+		middlewareManager: middlewareManager,
+	}
+}
+
+// SetupTrendingRoutes configura la consulta de los símbolos más consultados bajo /analysis
+func (tr *TrendingRoutes) SetupTrendingRoutes(routerGroup *gin.RouterGroup, trendingHandler *handlers.TrendingHandler) {
+	// Verificar que el handler existe
+	if trendingHandler == nil {
+		return
+	}
+
+	trending := routerGroup.Group("/analysis")
+	if tr.middlewareManager != nil {
+		tr.middlewareManager.ApplyReadOnlyMiddlewares(trending)
+	}
+	{
+		trending.GET("/trending", trendingHandler.GetTrending)
+	}
+}
+
+// GetTrendingRoutesInfo retorna información sobre las rutas de trending disponibles
+func (tr *TrendingRoutes) GetTrendingRoutesInfo() map[string]interface{} {
+	return map[string]interface{}{
+		"entity":    "trending",
+		"base_path": "/analysis",
+		"operations": map[string][]string{
+			"list": {
+				"GET /analysis/trending?hours=&limit=",
+			},
+		},
+	}
+}
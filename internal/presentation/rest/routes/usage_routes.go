@@ -0,0 +1,58 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/handlers"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
+)
+
+// UsageRoutes encapsula la configuración de rutas del uso de la API
+type UsageRoutes struct {
+	middlewareManager *MiddlewareManager
+}
+
+// NewUsageRoutes crea una nueva instancia del configurador de rutas de uso
+func NewUsageRoutes(middlewareManager *MiddlewareManager) *UsageRoutes {
+	return &UsageRoutes{
+		middlewareManager: middlewareManager,
+	}
+}
+
+// SetupUsageRoutes configura el uso del tenant autenticado bajo /usage y el roll-up bajo /admin/usage
+func (ur *UsageRoutes) SetupUsageRoutes(routerGroup *gin.RouterGroup, usageHandler *handlers.UsageHandler) {
+	// Verificar que el handler existe
+	if usageHandler == nil {
+		return
+	}
+
+	usage := routerGroup.Group("/usage")
+	usage.Use(middleware.RequireTenant())
+	{
+		usage.GET("/me", usageHandler.GetMyUsage)
+	}
+
+	admin := routerGroup.Group("/admin/usage")
+	if ur.middlewareManager != nil {
+		ur.middlewareManager.ApplyAdminMiddlewares(admin)
+	}
+	{
+		admin.GET("", usageHandler.GetUsageRollup)
+	}
+}
+
+// GetUsageRoutesInfo retorna información sobre las rutas de uso disponibles
+func (ur *UsageRoutes) GetUsageRoutesInfo() map[string]interface{} {
+	return map[string]interface{}{
+		"entity":    "usage",
+		"base_path": "/usage",
+		"operations": map[string][]string{
+			"self_service": {
+				"GET /usage/me",
+			},
+			"admin": {
+				"GET /admin/usage",
+			},
+		},
+	}
+}
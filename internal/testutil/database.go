@@ -0,0 +1,140 @@
+// Package testutil arranca dependencias desechables en contenedores (testcontainers) para los tests de integración:
+// una base de datos con todas las migraciones aplicadas y un Redis. Los contenedores se comparten entre los tests
+// del mismo binario y el reaper de testcontainers los elimina al terminar; sin Docker los tests se omiten
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/config"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/database/cockroachdb"
+)
+
+// CockroachImage es la imagen de la base de datos. Las migraciones usan SQL de CockroachDB (STRING, índices
+// invertidos), así que el contenedor es un CockroachDB de un nodo y no un Postgres: habla el mismo protocolo
+// y se conecta con el driver de Postgres, como en producción
+const CockroachImage = "cockroachdb/cockroach:v24.1.5"
+
+// startupTimeout limita el arranque de cada contenedor (la primera vez incluye descargar la imagen)
+const startupTimeout = 3 * time.Minute
+
+// preservedTables no se vacían entre tests: el control de migraciones y los datos de referencia que cargan
+var preservedTables = map[string]bool{
+	"schema_migrations": true,
+	"schema_lock":       true,
+	"rating_mappings":   true,
+	"benchmarks":        true,
+}
+
+var database struct {
+	once sync.Once
+	db   *cockroachdb.DB
+	cfg  *config.Config
+	err  error
+}
+
+// NewDatabase returns the shared throwaway database with every migration applied and the data tables emptied,
+// so each test starts from a clean schema. Tests using it must not run in parallel. The test is skipped when
+// Docker is not available
+func NewDatabase(t *testing.T) *cockroachdb.DB {
+	t.Helper()
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+
+	database.once.Do(func() {
+		database.cfg, database.db, database.err = startDatabase()
+	})
+	if database.err != nil {
+		t.Fatalf("failed to start test database: %v", database.err)
+	}
+
+	Reset(t, database.db)
+	return database.db
+}
+
+// DatabaseConfig returns the configuration pointing to the shared test database (NewDatabase must run first)
+func DatabaseConfig(t *testing.T) *config.Config {
+	t.Helper()
+	if database.cfg == nil {
+		t.Fatal("test database not started: call testutil.NewDatabase first")
+	}
+	return database.cfg
+}
+
+// Reset empties every data table of the database, keeping the migrations and the reference data
+func Reset(t *testing.T, db *cockroachdb.DB) {
+	t.Helper()
+
+	var tables []string
+	if err := db.DB.Raw(`SELECT table_name FROM information_schema.tables
+		WHERE table_schema = 'public' AND table_type = 'BASE TABLE' ORDER BY table_name`).Scan(&tables).Error; err != nil {
+		t.Fatalf("failed to list tables: %v", err)
+	}
+
+	for _, table := range tables {
+		if preservedTables[table] {
+			continue
+		}
+		if err := db.DB.Exec(fmt.Sprintf("TRUNCATE TABLE %q CASCADE", table)).Error; err != nil {
+			t.Fatalf("failed to truncate %s: %v", table, err)
+		}
+	}
+}
+
+// startDatabase arranca CockroachDB, abre la conexión de la aplicación y aplica las migraciones embebidas
+func startDatabase() (*config.Config, *cockroachdb.DB, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), startupTimeout)
+	defer cancel()
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        CockroachImage,
+			Cmd:          []string{"start-single-node", "--insecure"},
+			ExposedPorts: []string{"26257/tcp", "8080/tcp"},
+			WaitingFor:   wait.ForHTTP("/health?ready=1").WithPort("8080/tcp").WithStartupTimeout(startupTimeout),
+		},
+		Started: true,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to start %s: %w", CockroachImage, err)
+	}
+
+	host, err := container.Host(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	port, err := container.MappedPort(ctx, "26257/tcp")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cfg := &config.Config{
+		App: config.AppConfig{Name: "stock-info-app-test"},
+		Database: config.DatabaseConfig{
+			Host:            host,
+			Port:            port.Port(),
+			User:            "root",
+			Name:            "defaultdb",
+			SSLMode:         "disable",
+			MaxOpenConns:    10,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: 5 * time.Minute,
+		},
+	}
+
+	db, err := cockroachdb.NewConnection(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := db.MigrateUp(); err != nil {
+		db.Close()
+		return nil, nil, err
+	}
+	return cfg, db, nil
+}
@@ -0,0 +1,71 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/config"
+)
+
+// RedisImage es la imagen del Redis de los tests
+const RedisImage = "redis:7-alpine"
+
+var redisServer struct {
+	once sync.Once
+	cfg  config.CacheConfig
+	err  error
+}
+
+// NewRedis returns the cache configuration of the shared throwaway Redis, flushed so each test starts empty.
+// The test is skipped when Docker is not available
+func NewRedis(t *testing.T) config.CacheConfig {
+	t.Helper()
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+
+	redisServer.once.Do(func() {
+		redisServer.cfg, redisServer.err = startRedis()
+	})
+	if redisServer.err != nil {
+		t.Fatalf("failed to start test redis: %v", redisServer.err)
+	}
+
+	client := redis.NewClient(&redis.Options{Addr: redisServer.cfg.Host + ":" + redisServer.cfg.Port})
+	defer client.Close()
+	if err := client.FlushAll(context.Background()).Err(); err != nil {
+		t.Fatalf("failed to flush test redis: %v", err)
+	}
+	return redisServer.cfg
+}
+
+func startRedis() (config.CacheConfig, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), startupTimeout)
+	defer cancel()
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        RedisImage,
+			ExposedPorts: []string{"6379/tcp"},
+			WaitingFor:   wait.ForLog("Ready to accept connections").WithStartupTimeout(startupTimeout),
+		},
+		Started: true,
+	})
+	if err != nil {
+		return config.CacheConfig{}, fmt.Errorf("failed to start %s: %w", RedisImage, err)
+	}
+
+	host, err := container.Host(ctx)
+	if err != nil {
+		return config.CacheConfig{}, err
+	}
+	port, err := container.MappedPort(ctx, "6379/tcp")
+	if err != nil {
+		return config.CacheConfig{}, err
+	}
+	return config.CacheConfig{Host: host, Port: port.Port()}, nil
+}
@@ -0,0 +1,412 @@
+package testdoubles
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+)
+
+// brokerageRepository implements the BrokerageRepository interface over the in-memory Store
+type brokerageRepository struct {
+	store *Store
+}
+
+// NewBrokerageRepository creates a new in-memory brokerage repository
+func NewBrokerageRepository(store *Store) interfaces.BrokerageRepository {
+	return &brokerageRepository{store: store}
+}
+
+// brokerageColumns son las columnas de BrokerageSortFields
+var brokerageColumns = map[string]comparator[*entities.Brokerage]{
+	"name":       func(a, b *entities.Brokerage) int { return cmp.Compare(a.Name, b.Name) },
+	"country":    func(a, b *entities.Brokerage) int { return cmp.Compare(a.Country, b.Country) },
+	"created_at": func(a, b *entities.Brokerage) int { return a.CreatedAt.Compare(b.CreatedAt) },
+	"updated_at": func(a, b *entities.Brokerage) int { return a.UpdatedAt.Compare(b.UpdatedAt) },
+}
+
+func brokerageID(b *entities.Brokerage) uuid.UUID { return b.ID }
+
+func brokerageDeletedAt(b *entities.Brokerage) gorm.DeletedAt { return b.DeletedAt }
+
+// copyBrokerage devuelve una copia sin relaciones
+func copyBrokerage(brokerage *entities.Brokerage) *entities.Brokerage {
+	copied := *brokerage
+	copied.StockRatings = nil
+	return &copied
+}
+
+func copyBrokerages(brokerages []*entities.Brokerage) []*entities.Brokerage {
+	result := make([]*entities.Brokerage, len(brokerages))
+	for i, brokerage := range brokerages {
+		result[i] = copyBrokerage(brokerage)
+	}
+	return result
+}
+
+// ========================================
+// STORE OPERATIONS (llamadas con el Store bloqueado)
+// ========================================
+
+func (s *Store) liveBrokerages() []*entities.Brokerage {
+	return live(s.data.brokerages, brokerageDeletedAt)
+}
+
+func (s *Store) activeBrokerages() []*entities.Brokerage {
+	return filter(s.liveBrokerages(), func(b *entities.Brokerage) bool { return b.IsActive })
+}
+
+func (s *Store) brokerageIndex(id uuid.UUID) int {
+	return slices.IndexFunc(s.data.brokerages, func(b *entities.Brokerage) bool { return b.ID == id })
+}
+
+func (s *Store) findBrokerage(id uuid.UUID) *entities.Brokerage {
+	index := s.brokerageIndex(id)
+	if index < 0 || s.data.brokerages[index].DeletedAt.Valid {
+		return nil
+	}
+	return s.data.brokerages[index]
+}
+
+func (s *Store) findBrokerageByName(name string) *entities.Brokerage {
+	for _, brokerage := range s.liveBrokerages() {
+		if brokerage.Name == name {
+			return brokerage
+		}
+	}
+	return nil
+}
+
+// insertBrokerage aplica el hook y los valores por defecto de las columnas y comprueba las claves únicas
+func (s *Store) insertBrokerage(brokerage *entities.Brokerage) error {
+	if err := brokerage.BeforeCreate(nil); err != nil {
+		return err
+	}
+	if !brokerage.IsActive {
+		brokerage.IsActive = true // Columna con DEFAULT true: GORM no inserta el valor cero
+	}
+
+	for _, existing := range s.data.brokerages {
+		if existing.ID == brokerage.ID || existing.Name == brokerage.Name {
+			return domainerrors.Duplicate(nil, "failed to create brokerage %s", brokerage.Name)
+		}
+	}
+
+	now := s.now()
+	if brokerage.CreatedAt.IsZero() {
+		brokerage.CreatedAt = now
+	}
+	if brokerage.UpdatedAt.IsZero() {
+		brokerage.UpdatedAt = now
+	}
+	s.data.brokerages = append(s.data.brokerages, copyBrokerage(brokerage))
+	return nil
+}
+
+// updateBrokerage aplica fn a una copia del brokerage vivo con ese id; devuelve false si no existe
+func (s *Store) updateBrokerage(id uuid.UUID, fn func(brokerage *entities.Brokerage)) bool {
+	index := s.brokerageIndex(id)
+	if index < 0 || s.data.brokerages[index].DeletedAt.Valid {
+		return false
+	}
+	brokerage := copyBrokerage(s.data.brokerages[index])
+	fn(brokerage)
+	brokerage.UpdatedAt = s.now()
+	s.data.brokerages[index] = brokerage
+	return true
+}
+
+// ========================================
+// CREATE OPERATIONS
+// ========================================
+
+// Create creates a new brokerage in the store
+func (r *brokerageRepository) Create(ctx context.Context, brokerage *entities.Brokerage) error {
+	return r.store.transaction(func() error {
+		return r.store.insertBrokerage(brokerage)
+	})
+}
+
+// CreateMany creates multiple brokerages in a single transaction
+func (r *brokerageRepository) CreateMany(ctx context.Context, brokerages []*entities.Brokerage) error {
+	return r.store.transaction(func() error {
+		for _, brokerage := range brokerages {
+			if err := r.store.insertBrokerage(brokerage); err != nil {
+				return fmt.Errorf("failed to create brokerage %s: %w", brokerage.Name, err)
+			}
+		}
+		return nil
+	})
+}
+
+// ========================================
+// READ OPERATIONS
+// ========================================
+
+// GetByID retrieves a brokerage by its ID
+func (r *brokerageRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Brokerage, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	brokerage := r.store.findBrokerage(id)
+	if brokerage == nil {
+		return nil, domainerrors.NotFound("brokerage with id %s not found", id)
+	}
+	return copyBrokerage(brokerage), nil
+}
+
+// GetByName retrieves a brokerage by its name
+func (r *brokerageRepository) GetByName(ctx context.Context, name string) (*entities.Brokerage, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	brokerage := r.store.findBrokerageByName(name)
+	if brokerage == nil {
+		return nil, domainerrors.NotFound("brokerage with name %s not found", name)
+	}
+	return copyBrokerage(brokerage), nil
+}
+
+// GetAll retrieves all brokerages (including inactive)
+func (r *brokerageRepository) GetAll(ctx context.Context) ([]*entities.Brokerage, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return copyBrokerages(r.store.liveBrokerages()), nil
+}
+
+// GetAllActive retrieves only active brokerages
+func (r *brokerageRepository) GetAllActive(ctx context.Context) ([]*entities.Brokerage, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return copyBrokerages(r.store.activeBrokerages()), nil
+}
+
+// List retrieves a page of the brokerages matching filter, ordered by sort (name by default)
+func (r *brokerageRepository) List(ctx context.Context, listFilter interfaces.BrokerageListFilter, sort []interfaces.SortField, limit, offset int) ([]*entities.Brokerage, int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	name := strings.ToLower(strings.TrimSpace(listFilter.Name))
+	brokerages := filter(r.store.liveBrokerages(), func(b *entities.Brokerage) bool {
+		return strings.Contains(strings.ToLower(b.Name), name) &&
+			(listFilter.IsActive == nil || b.IsActive == *listFilter.IsActive)
+	})
+	if err := sortRows(brokerages, sort, brokerageColumns, brokerageID, interfaces.SortField{Column: "name"}); err != nil {
+		return nil, 0, fmt.Errorf("failed to list brokerages: %w", err)
+	}
+
+	return copyBrokerages(paginate(brokerages, limit, offset)), int64(len(brokerages)), nil
+}
+
+// ========================================
+// UPDATE OPERATIONS
+// ========================================
+
+// Update updates an existing brokerage if its version has not changed since it was read
+func (r *brokerageRepository) Update(ctx context.Context, brokerage *entities.Brokerage) error {
+	return r.store.transaction(func() error {
+		current := r.store.findBrokerage(brokerage.ID)
+		if current == nil {
+			return domainerrors.NotFound("brokerage with id %s not found for update", brokerage.ID)
+		}
+		if current.Version != brokerage.Version {
+			return domainerrors.VersionMismatch("brokerage with id %s was modified concurrently (expected version %d)", brokerage.ID, brokerage.Version)
+		}
+
+		if err := brokerage.BeforeUpdate(nil); err != nil {
+			return err
+		}
+		for _, existing := range r.store.data.brokerages {
+			if existing.ID != brokerage.ID && existing.Name == brokerage.Name {
+				return domainerrors.Duplicate(nil, "failed to update brokerage")
+			}
+		}
+
+		brokerage.Version++
+		brokerage.UpdatedAt = r.store.now()
+		r.store.data.brokerages[r.store.brokerageIndex(brokerage.ID)] = copyBrokerage(brokerage)
+		return nil
+	})
+}
+
+// Activate activates a brokerage by ID
+func (r *brokerageRepository) Activate(ctx context.Context, id uuid.UUID) error {
+	return r.store.transaction(func() error {
+		if !r.store.updateBrokerage(id, func(b *entities.Brokerage) { b.IsActive = true }) {
+			return domainerrors.NotFound("brokerage with id %s not found for activation", id)
+		}
+		return nil
+	})
+}
+
+// Deactivate deactivates a brokerage by ID
+func (r *brokerageRepository) Deactivate(ctx context.Context, id uuid.UUID) error {
+	return r.store.transaction(func() error {
+		if !r.store.updateBrokerage(id, func(b *entities.Brokerage) { b.IsActive = false }) {
+			return domainerrors.NotFound("brokerage with id %s not found for deactivation", id)
+		}
+		return nil
+	})
+}
+
+// ========================================
+// DELETE OPERATIONS
+// ========================================
+
+// Delete performs a soft delete on a brokerage
+func (r *brokerageRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.store.transaction(func() error {
+		index := r.store.brokerageIndex(id)
+		if index < 0 || r.store.data.brokerages[index].DeletedAt.Valid {
+			return domainerrors.NotFound("brokerage with id %s not found for deletion", id)
+		}
+		deleted := copyBrokerage(r.store.data.brokerages[index])
+		deleted.DeletedAt = r.store.softDeleted()
+		r.store.data.brokerages[index] = deleted
+		return nil
+	})
+}
+
+// HardDelete permanently deletes a brokerage; its ratings go with it (ON DELETE CASCADE)
+func (r *brokerageRepository) HardDelete(ctx context.Context, id uuid.UUID) error {
+	return r.store.transaction(func() error {
+		s := r.store
+		if s.brokerageIndex(id) < 0 {
+			return domainerrors.NotFound("brokerage with id %s not found for hard deletion", id)
+		}
+		s.data.brokerages = filter(s.data.brokerages, func(b *entities.Brokerage) bool { return b.ID != id })
+		s.data.ratings = filter(s.data.ratings, func(sr *entities.StockRating) bool { return sr.BrokerageID != id })
+		s.deleteOrphanNotes()
+		return nil
+	})
+}
+
+// ========================================
+// QUERY OPERATIONS
+// ========================================
+
+// Exists checks if a brokerage with the given name exists
+func (r *brokerageRepository) Exists(ctx context.Context, name string) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.store.findBrokerageByName(name) != nil, nil
+}
+
+// Count returns the total number of brokerages (including inactive)
+func (r *brokerageRepository) Count(ctx context.Context) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return int64(len(r.store.liveBrokerages())), nil
+}
+
+// CountActive returns the number of active brokerages
+func (r *brokerageRepository) CountActive(ctx context.Context) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return int64(len(r.store.activeBrokerages())), nil
+}
+
+// ========================================
+// BUSINESS OPERATIONS (FOR API SYNC)
+// ========================================
+
+// FindOrCreate finds a brokerage by name or creates it if it doesn't exist
+func (r *brokerageRepository) FindOrCreate(ctx context.Context, name string) (*entities.Brokerage, error) {
+	brokerage, err := r.GetByName(ctx, name)
+	if err == nil {
+		return brokerage, nil
+	}
+	if !domainerrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to find or create brokerage: %w", err)
+	}
+
+	newBrokerage := entities.NewBrokerage(name)
+	if err := r.Create(ctx, newBrokerage); err != nil {
+		return nil, fmt.Errorf("failed to create new brokerage %s: %w", name, err)
+	}
+	return newBrokerage, nil
+}
+
+// FindOrCreateWithDetails finds or creates a brokerage, filling in the details it is missing
+func (r *brokerageRepository) FindOrCreateWithDetails(ctx context.Context, name, website, country string) (*entities.Brokerage, error) {
+	brokerage, err := r.GetByName(ctx, name)
+	if err == nil {
+		updated := false
+		if brokerage.Website == "" && website != "" {
+			brokerage.Website = website
+			updated = true
+		}
+		if brokerage.Country == "" && country != "" {
+			brokerage.Country = country
+			updated = true
+		}
+
+		if updated {
+			if err := r.Update(ctx, brokerage); err != nil {
+				return nil, fmt.Errorf("failed to update brokerage details: %w", err)
+			}
+		}
+		return brokerage, nil
+	}
+	if !domainerrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to find or create brokerage with details: %w", err)
+	}
+
+	newBrokerage := entities.NewBrokerageWithDetails(name, website, country)
+	if err := r.Create(ctx, newBrokerage); err != nil {
+		return nil, fmt.Errorf("failed to create new brokerage with details %s: %w", name, err)
+	}
+	return newBrokerage, nil
+}
+
+// ========================================
+// RELATIONSHIP OPERATIONS
+// ========================================
+
+// GetWithRatings retrieves a brokerage with its stock ratings preloaded
+func (r *brokerageRepository) GetWithRatings(ctx context.Context, id uuid.UUID) (*entities.Brokerage, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	brokerage := r.store.findBrokerage(id)
+	if brokerage == nil {
+		return nil, domainerrors.NotFound("brokerage with id %s not found", id)
+	}
+
+	result := copyBrokerage(brokerage)
+	for _, rating := range r.store.liveRatings() {
+		if rating.BrokerageID == id {
+			result.StockRatings = append(result.StockRatings, *copyRating(rating))
+		}
+	}
+	return result, nil
+}
+
+// GetByRatingCount retrieves brokerages ordered by their rating count (most active first)
+func (r *brokerageRepository) GetByRatingCount(ctx context.Context, limit int) ([]*entities.Brokerage, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// LEFT JOIN sin filtrar los ratings eliminados, como la consulta del repositorio
+	counts := make(map[uuid.UUID]int64)
+	for _, rating := range r.store.data.ratings {
+		counts[rating.BrokerageID]++
+	}
+
+	brokerages := slices.Clone(r.store.liveBrokerages())
+	slices.SortStableFunc(brokerages, func(a, b *entities.Brokerage) int { return cmp.Compare(counts[b.ID], counts[a.ID]) })
+	return copyBrokerages(paginate(brokerages, limitIfPositive(limit), 0)), nil
+}
@@ -0,0 +1,950 @@
+package testdoubles
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+)
+
+// companyRepository implements the CompanyRepository interface over the in-memory Store
+type companyRepository struct {
+	store *Store
+}
+
+// NewCompanyRepository creates a new in-memory company repository
+func NewCompanyRepository(store *Store) interfaces.CompanyRepository {
+	return &companyRepository{store: store}
+}
+
+// companyColumns son las columnas de CompanySortFields
+var companyColumns = map[string]comparator[*entities.Company]{
+	"ticker":     func(a, b *entities.Company) int { return cmp.Compare(a.Ticker, b.Ticker) },
+	"name":       func(a, b *entities.Company) int { return cmp.Compare(a.Name, b.Name) },
+	"sector":     func(a, b *entities.Company) int { return cmp.Compare(a.Sector, b.Sector) },
+	"exchange":   func(a, b *entities.Company) int { return cmp.Compare(a.Exchange, b.Exchange) },
+	"market_cap": func(a, b *entities.Company) int { return cmp.Compare(a.MarketCap, b.MarketCap) },
+	"created_at": func(a, b *entities.Company) int { return a.CreatedAt.Compare(b.CreatedAt) },
+	"updated_at": func(a, b *entities.Company) int { return a.UpdatedAt.Compare(b.UpdatedAt) },
+}
+
+func companyID(c *entities.Company) uuid.UUID { return c.ID }
+
+func companyDeletedAt(c *entities.Company) gorm.DeletedAt { return c.DeletedAt }
+
+// copyCompany devuelve una copia sin relaciones
+func copyCompany(company *entities.Company) *entities.Company {
+	copied := *company
+	copied.StockRatings = nil
+	return &copied
+}
+
+func copyCompanies(companies []*entities.Company) []*entities.Company {
+	result := make([]*entities.Company, len(companies))
+	for i, company := range companies {
+		result[i] = copyCompany(company)
+	}
+	return result
+}
+
+// ========================================
+// STORE OPERATIONS (llamadas con el Store bloqueado)
+// ========================================
+
+func (s *Store) liveCompanies() []*entities.Company {
+	return live(s.data.companies, companyDeletedAt)
+}
+
+// companyIndex devuelve la posición de la company (incluidas las eliminadas) o -1
+func (s *Store) companyIndex(id uuid.UUID) int {
+	return slices.IndexFunc(s.data.companies, func(c *entities.Company) bool { return c.ID == id })
+}
+
+func (s *Store) findCompany(id uuid.UUID) *entities.Company {
+	index := s.companyIndex(id)
+	if index < 0 || s.data.companies[index].DeletedAt.Valid {
+		return nil
+	}
+	return s.data.companies[index]
+}
+
+// findCompanyByTicker busca por ticker actual y, si no existe, por un ticker anterior (alias)
+func (s *Store) findCompanyByTicker(ticker string) *entities.Company {
+	ticker = strings.ToUpper(ticker)
+	for _, company := range s.liveCompanies() {
+		if company.Ticker == ticker {
+			return company
+		}
+	}
+	for _, alias := range s.data.aliases {
+		if alias.Ticker == ticker {
+			return s.findCompany(alias.CompanyID)
+		}
+	}
+	return nil
+}
+
+// insertCompany aplica el hook y los valores por defecto de las columnas y comprueba las claves únicas
+func (s *Store) insertCompany(company *entities.Company) error {
+	if err := company.BeforeCreate(nil); err != nil {
+		return err
+	}
+	if company.DataSource == "" {
+		company.DataSource = "manual"
+	}
+	if !company.IsActive {
+		company.IsActive = true // Columna con DEFAULT true: GORM no inserta el valor cero
+	}
+
+	for _, existing := range s.data.companies {
+		if existing.ID == company.ID || existing.Ticker == company.Ticker {
+			return domainerrors.Duplicate(nil, "failed to create company %s", company.Ticker)
+		}
+	}
+
+	now := s.now()
+	if company.CreatedAt.IsZero() {
+		company.CreatedAt = now
+	}
+	if company.UpdatedAt.IsZero() {
+		company.UpdatedAt = now
+	}
+	s.data.companies = append(s.data.companies, copyCompany(company))
+	return nil
+}
+
+// saveCompany reproduce Save: actualiza todas las columnas por id o inserta si no existe
+func (s *Store) saveCompany(company *entities.Company) error {
+	index := s.companyIndex(company.ID)
+	if index < 0 {
+		return s.insertCompany(company)
+	}
+
+	if err := company.BeforeUpdate(nil); err != nil {
+		return err
+	}
+	for _, existing := range s.data.companies {
+		if existing.ID != company.ID && existing.Ticker == company.Ticker {
+			return domainerrors.Duplicate(nil, "failed to save company %s", company.Ticker)
+		}
+	}
+	company.UpdatedAt = s.now()
+	s.data.companies[index] = copyCompany(company)
+	return nil
+}
+
+// updateCompany aplica fn a una copia de la company viva con ese id; devuelve false si no existe
+func (s *Store) updateCompany(id uuid.UUID, fn func(company *entities.Company)) bool {
+	index := s.companyIndex(id)
+	if index < 0 || s.data.companies[index].DeletedAt.Valid {
+		return false
+	}
+	company := copyCompany(s.data.companies[index])
+	fn(company)
+	company.UpdatedAt = s.now()
+	s.data.companies[index] = company
+	return true
+}
+
+// upsertAlias apunta el alias al company, creándolo si no existe (ON CONFLICT (ticker) DO UPDATE)
+func (s *Store) upsertAlias(ticker string, companyID uuid.UUID) {
+	alias := entities.NewTickerAlias(ticker, companyID)
+	now := s.now()
+	for i, existing := range s.data.aliases {
+		if existing.Ticker == alias.Ticker {
+			updated := *existing
+			updated.CompanyID = companyID
+			updated.UpdatedAt = now
+			s.data.aliases[i] = &updated
+			return
+		}
+	}
+	alias.CreatedAt = now
+	alias.UpdatedAt = now
+	s.data.aliases = append(s.data.aliases, alias)
+}
+
+// ratingCounts cuenta los ratings de cada company (LEFT JOIN sin filtrar los eliminados)
+func (s *Store) ratingCounts(since time.Time) map[uuid.UUID]int64 {
+	counts := make(map[uuid.UUID]int64)
+	for _, rating := range s.data.ratings {
+		if !rating.EventTime.Before(since) {
+			counts[rating.CompanyID]++
+		}
+	}
+	return counts
+}
+
+// ========================================
+// CREATE OPERATIONS
+// ========================================
+
+// Create creates a new company in the store
+func (r *companyRepository) Create(ctx context.Context, company *entities.Company) error {
+	return r.store.transaction(func() error {
+		return r.store.insertCompany(company)
+	})
+}
+
+// CreateMany creates multiple companies in a single transaction
+func (r *companyRepository) CreateMany(ctx context.Context, companies []*entities.Company) error {
+	return r.store.transaction(func() error {
+		for _, company := range companies {
+			if err := r.store.insertCompany(company); err != nil {
+				return fmt.Errorf("failed to create company %s: %w", company.Ticker, err)
+			}
+		}
+		return nil
+	})
+}
+
+// ========================================
+// READ OPERATIONS
+// ========================================
+
+// GetByID retrieves a company by its ID
+func (r *companyRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Company, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	company := r.store.findCompany(id)
+	if company == nil {
+		return nil, domainerrors.NotFound("company with id %s not found", id)
+	}
+	return copyCompany(company), nil
+}
+
+// GetByTicker retrieves a company by its ticker symbol, resolving former tickers
+func (r *companyRepository) GetByTicker(ctx context.Context, ticker string) (*entities.Company, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	company := r.store.findCompanyByTicker(ticker)
+	if company == nil {
+		return nil, domainerrors.NotFound("company with ticker %s not found", ticker)
+	}
+	return copyCompany(company), nil
+}
+
+// GetByName retrieves a company by its name
+func (r *companyRepository) GetByName(ctx context.Context, name string) (*entities.Company, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, company := range r.store.liveCompanies() {
+		if company.Name == name {
+			return copyCompany(company), nil
+		}
+	}
+	return nil, domainerrors.NotFound("company with name %s not found", name)
+}
+
+// GetAll retrieves all companies (including inactive)
+func (r *companyRepository) GetAll(ctx context.Context) ([]*entities.Company, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return copyCompanies(r.store.liveCompanies()), nil
+}
+
+// GetAllActive retrieves only active companies
+func (r *companyRepository) GetAllActive(ctx context.Context) ([]*entities.Company, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return copyCompanies(r.store.activeCompanies()), nil
+}
+
+func (s *Store) activeCompanies() []*entities.Company {
+	return filter(s.liveCompanies(), func(c *entities.Company) bool { return c.IsActive })
+}
+
+// List retrieves a page of the companies matching filter, ordered by sort (ticker by default)
+func (r *companyRepository) List(ctx context.Context, listFilter interfaces.CompanyListFilter, sort []interfaces.SortField, limit, offset int) ([]*entities.Company, int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	companies := filter(r.store.liveCompanies(), func(c *entities.Company) bool {
+		return (listFilter.Sector == "" || c.Sector == listFilter.Sector) &&
+			(listFilter.Exchange == "" || c.Exchange == listFilter.Exchange) &&
+			(listFilter.IsActive == nil || c.IsActive == *listFilter.IsActive) &&
+			r.store.hasTags(c.ID, listFilter.Tags)
+	})
+	if err := sortRows(companies, sort, companyColumns, companyID, interfaces.SortField{Column: "ticker"}); err != nil {
+		return nil, 0, fmt.Errorf("failed to list companies: %w", err)
+	}
+
+	return copyCompanies(paginate(companies, limit, offset)), int64(len(companies)), nil
+}
+
+// ========================================
+// UPDATE OPERATIONS
+// ========================================
+
+// Update updates an existing company if its version has not changed since it was read
+func (r *companyRepository) Update(ctx context.Context, company *entities.Company, hooks ...interfaces.WriteHook[entities.Company]) error {
+	return r.store.transaction(func() error {
+		current := r.store.findCompany(company.ID)
+		if current == nil {
+			return domainerrors.NotFound("company with id %s not found for update", company.ID)
+		}
+		if current.Version != company.Version {
+			return domainerrors.VersionMismatch("company with id %s was modified concurrently (expected version %d)", company.ID, company.Version)
+		}
+
+		if err := company.BeforeUpdate(nil); err != nil {
+			return err
+		}
+		for _, existing := range r.store.data.companies {
+			if existing.ID != company.ID && existing.Ticker == company.Ticker {
+				return domainerrors.Duplicate(nil, "failed to update company")
+			}
+		}
+
+		version, updatedAt := company.Version, company.UpdatedAt
+		company.Version++
+		company.UpdatedAt = r.store.now()
+		r.store.data.companies[r.store.companyIndex(company.ID)] = copyCompany(company)
+		if err := runHooks(company, hooks); err != nil {
+			company.Version, company.UpdatedAt = version, updatedAt
+			return err
+		}
+		return nil
+	})
+}
+
+// UpdateMarketCap updates only the market cap of a company by ticker
+func (r *companyRepository) UpdateMarketCap(ctx context.Context, ticker string, marketCap float64) error {
+	return r.store.transaction(func() error {
+		for _, company := range r.store.liveCompanies() {
+			if company.Ticker == strings.ToUpper(ticker) {
+				r.store.updateCompany(company.ID, func(c *entities.Company) { c.MarketCap = marketCap })
+				return nil
+			}
+		}
+		return domainerrors.NotFound("company with ticker %s not found for market cap update", ticker)
+	})
+}
+
+// Activate activates a company by ID
+func (r *companyRepository) Activate(ctx context.Context, id uuid.UUID, hooks ...interfaces.WriteHook[entities.Company]) error {
+	return r.store.transaction(func() error {
+		if !r.store.updateCompany(id, func(c *entities.Company) { c.IsActive = true }) {
+			return domainerrors.NotFound("company with id %s not found for activation", id)
+		}
+		return runHooks(copyCompany(r.store.findCompany(id)), hooks)
+	})
+}
+
+// Deactivate deactivates a company by ID
+func (r *companyRepository) Deactivate(ctx context.Context, id uuid.UUID, hooks ...interfaces.WriteHook[entities.Company]) error {
+	return r.store.transaction(func() error {
+		if !r.store.updateCompany(id, func(c *entities.Company) { c.IsActive = false }) {
+			return domainerrors.NotFound("company with id %s not found for deactivation", id)
+		}
+		return runHooks(copyCompany(r.store.findCompany(id)), hooks)
+	})
+}
+
+// ========================================
+// TICKER CHANGE OPERATIONS
+// ========================================
+
+// RemapTicker renames the company ticker and records the previous ticker as an alias
+func (r *companyRepository) RemapTicker(ctx context.Context, id uuid.UUID, newTicker string, hooks ...interfaces.WriteHook[entities.Company]) (*entities.Company, error) {
+	newTicker = strings.ToUpper(strings.TrimSpace(newTicker))
+
+	var company *entities.Company
+	err := r.store.transaction(func() error {
+		current := r.store.findCompany(id)
+		if current == nil {
+			return domainerrors.NotFound("company with id %s not found", id)
+		}
+		company = copyCompany(current)
+		if company.Ticker == newTicker {
+			return runHooks(company, hooks)
+		}
+
+		// La restricción única de ticker incluye las companies eliminadas (soft delete)
+		for _, existing := range r.store.data.companies {
+			if existing.ID != id && existing.Ticker == newTicker {
+				return domainerrors.Duplicate(nil, "ticker %s is already used by another company", newTicker)
+			}
+		}
+
+		// El nuevo ticker deja de ser alias y el anterior pasa a serlo
+		r.store.data.aliases = filter(r.store.data.aliases, func(a *entities.TickerAlias) bool { return a.Ticker != newTicker })
+		r.store.upsertAlias(company.Ticker, company.ID)
+
+		r.store.updateCompany(id, func(c *entities.Company) {
+			c.Ticker = newTicker
+			c.Version++
+		})
+		company = copyCompany(r.store.findCompany(id))
+		return runHooks(company, hooks)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return company, nil
+}
+
+// GetTickerAliases retrieves the former tickers of a company, most recent change first
+func (r *companyRepository) GetTickerAliases(ctx context.Context, id uuid.UUID) ([]*entities.TickerAlias, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	aliases := filter(r.store.data.aliases, func(a *entities.TickerAlias) bool { return a.CompanyID == id })
+	slices.SortStableFunc(aliases, func(a, b *entities.TickerAlias) int { return b.CreatedAt.Compare(a.CreatedAt) })
+
+	result := make([]*entities.TickerAlias, len(aliases))
+	for i, alias := range aliases {
+		copied := *alias
+		result[i] = &copied
+	}
+	return result, nil
+}
+
+// ========================================
+// MERGE OPERATIONS
+// ========================================
+
+// errMergeDryRun deshace la transacción de un merge en modo dry-run
+var errMergeDryRun = fmt.Errorf("company merge dry run")
+
+// MergeInto re-points the ratings, market data and ticker aliases of a duplicate company to target, keeps
+// the duplicate ticker as an alias of target and soft-deletes the duplicate. The store has no profiles or news
+func (r *companyRepository) MergeInto(ctx context.Context, duplicateID, targetID uuid.UUID, dryRun bool) (*interfaces.CompanyMergeResult, error) {
+	if duplicateID == targetID {
+		return nil, domainerrors.Conflict(nil, "a company cannot be merged into itself")
+	}
+
+	result := &interfaces.CompanyMergeResult{}
+	err := r.store.transaction(func() error {
+		s := r.store
+		duplicate := s.findCompany(duplicateID)
+		if duplicate == nil {
+			return domainerrors.NotFound("company with id %s not found", duplicateID)
+		}
+		target := s.findCompany(targetID)
+		if target == nil {
+			return domainerrors.NotFound("company with id %s not found", targetID)
+		}
+		result.Duplicate = copyCompany(duplicate)
+		result.Target = copyCompany(target)
+
+		// Stock ratings: la restricción única (company, brokerage, event_time) incluye los eliminados
+		now := s.now()
+		for i, rating := range s.data.ratings {
+			if rating.CompanyID != duplicateID {
+				continue
+			}
+			collides := slices.ContainsFunc(s.data.ratings, func(t *entities.StockRating) bool {
+				return t.CompanyID == targetID && t.BrokerageID == rating.BrokerageID && t.EventTime.Equal(rating.EventTime)
+			})
+			if collides {
+				continue
+			}
+			moved := copyRating(rating)
+			moved.CompanyID = targetID
+			moved.UpdatedAt = now
+			s.data.ratings[i] = moved
+			result.RatingsMoved++
+		}
+		for i, rating := range s.data.ratings {
+			if rating.CompanyID == duplicateID && !rating.DeletedAt.Valid {
+				deleted := copyRating(rating)
+				deleted.DeletedAt = s.softDeleted()
+				s.data.ratings[i] = deleted
+				result.RatingsDuplicated++
+			}
+		}
+
+		for i, marketData := range s.data.marketData {
+			if marketData.CompanyID == duplicateID {
+				moved := copyMarketData(marketData)
+				moved.CompanyID = targetID
+				moved.Symbol = target.Ticker
+				moved.UpdatedAt = now
+				s.data.marketData[i] = moved
+				result.MarketDataMoved++
+			}
+		}
+
+		// Los tickers del duplicado siguen resolviendo, ahora a target
+		for i, alias := range s.data.aliases {
+			if alias.CompanyID == duplicateID {
+				moved := *alias
+				moved.CompanyID = targetID
+				moved.UpdatedAt = now
+				s.data.aliases[i] = &moved
+				result.AliasesMoved++
+			}
+		}
+		if duplicate.Ticker != target.Ticker {
+			s.upsertAlias(duplicate.Ticker, targetID)
+		}
+
+		// Las notas de los usuarios siguen a la company y a sus ratings
+		for i, note := range s.data.notes {
+			if note.CompanyID == duplicateID {
+				moved := copyNote(note)
+				moved.CompanyID = targetID
+				moved.UpdatedAt = now
+				s.data.notes[i] = moved
+			}
+		}
+
+		deleted := copyCompany(duplicate)
+		deleted.DeletedAt = s.softDeleted()
+		s.data.companies[s.companyIndex(duplicateID)] = deleted
+
+		if dryRun {
+			return errMergeDryRun
+		}
+		return nil
+	})
+	if err != nil && err != errMergeDryRun {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ========================================
+// DELETE OPERATIONS
+// ========================================
+
+// Delete performs a soft delete on a company
+func (r *companyRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.store.transaction(func() error {
+		if r.store.findCompany(id) == nil {
+			return domainerrors.NotFound("company with id %s not found for deletion", id)
+		}
+		deleted := copyCompany(r.store.findCompany(id))
+		deleted.DeletedAt = r.store.softDeleted()
+		r.store.data.companies[r.store.companyIndex(id)] = deleted
+		return nil
+	})
+}
+
+// HardDelete permanently deletes a company; its ratings, market data and aliases go with it (ON DELETE CASCADE)
+func (r *companyRepository) HardDelete(ctx context.Context, id uuid.UUID) error {
+	return r.store.transaction(func() error {
+		s := r.store
+		if s.companyIndex(id) < 0 {
+			return domainerrors.NotFound("company with id %s not found for hard deletion", id)
+		}
+		s.data.companies = filter(s.data.companies, func(c *entities.Company) bool { return c.ID != id })
+		s.data.ratings = filter(s.data.ratings, func(sr *entities.StockRating) bool { return sr.CompanyID != id })
+		s.data.marketData = filter(s.data.marketData, func(md *entities.MarketData) bool { return md.CompanyID != id })
+		s.data.aliases = filter(s.data.aliases, func(a *entities.TickerAlias) bool { return a.CompanyID != id })
+		s.data.tagged = filter(s.data.tagged, func(ct *entities.CompanyTag) bool { return ct.CompanyID != id })
+		s.data.notes = filter(s.data.notes, func(n *entities.Note) bool { return n.CompanyID != id })
+		return nil
+	})
+}
+
+// ========================================
+// SOFT-DELETE RECOVERY OPERATIONS
+// ========================================
+
+// ListDeleted retrieves soft-deleted companies, most recently deleted first
+func (r *companyRepository) ListDeleted(ctx context.Context, limit, offset int) ([]*entities.Company, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	deleted := filter(r.store.data.companies, func(c *entities.Company) bool { return c.DeletedAt.Valid })
+	slices.SortStableFunc(deleted, func(a, b *entities.Company) int { return b.DeletedAt.Time.Compare(a.DeletedAt.Time) })
+	return copyCompanies(paginate(deleted, limit, offset)), nil
+}
+
+// CountDeleted returns the number of soft-deleted companies
+func (r *companyRepository) CountDeleted(ctx context.Context) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return int64(len(r.store.data.companies) - len(r.store.liveCompanies())), nil
+}
+
+// Restore undoes the soft delete of a company
+func (r *companyRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return r.store.transaction(func() error {
+		index := r.store.companyIndex(id)
+		if index < 0 || !r.store.data.companies[index].DeletedAt.Valid {
+			return domainerrors.NotFound("deleted company with id %s not found", id)
+		}
+		restored := copyCompany(r.store.data.companies[index])
+		restored.DeletedAt = gorm.DeletedAt{}
+		restored.UpdatedAt = r.store.now()
+		r.store.data.companies[index] = restored
+		return nil
+	})
+}
+
+// ========================================
+// QUERY OPERATIONS - BASIC
+// ========================================
+
+// ExistsByTicker checks if a company with the given ticker exists
+func (r *companyRepository) ExistsByTicker(ctx context.Context, ticker string) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return slices.ContainsFunc(r.store.liveCompanies(), func(c *entities.Company) bool {
+		return c.Ticker == strings.ToUpper(ticker)
+	}), nil
+}
+
+// ExistsByName checks if a company with the given name exists
+func (r *companyRepository) ExistsByName(ctx context.Context, name string) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return slices.ContainsFunc(r.store.liveCompanies(), func(c *entities.Company) bool { return c.Name == name }), nil
+}
+
+// Count returns the total number of companies (including inactive)
+func (r *companyRepository) Count(ctx context.Context) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return int64(len(r.store.liveCompanies())), nil
+}
+
+// CountActive returns the number of active companies
+func (r *companyRepository) CountActive(ctx context.Context) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return int64(len(r.store.activeCompanies())), nil
+}
+
+// ========================================
+// QUERY OPERATIONS - FINANCIAL
+// ========================================
+
+// GetBySector retrieves active companies by sector
+func (r *companyRepository) GetBySector(ctx context.Context, sector string) ([]*entities.Company, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return copyCompanies(filter(r.store.activeCompanies(), func(c *entities.Company) bool { return c.Sector == sector })), nil
+}
+
+// GetByExchange retrieves active companies by exchange
+func (r *companyRepository) GetByExchange(ctx context.Context, exchange string) ([]*entities.Company, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	exchange = strings.ToUpper(exchange)
+	return copyCompanies(filter(r.store.activeCompanies(), func(c *entities.Company) bool { return c.Exchange == exchange })), nil
+}
+
+// GetByMarketCapRange retrieves active companies within a market cap range; a zero bound does not filter
+func (r *companyRepository) GetByMarketCapRange(ctx context.Context, minCap, maxCap float64) ([]*entities.Company, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return copyCompanies(filter(r.store.activeCompanies(), func(c *entities.Company) bool {
+		return (minCap <= 0 || c.MarketCap >= minCap) && (maxCap <= 0 || c.MarketCap <= maxCap)
+	})), nil
+}
+
+// GetLargestByMarketCap retrieves companies ordered by market cap (largest first)
+func (r *companyRepository) GetLargestByMarketCap(ctx context.Context, limit int) ([]*entities.Company, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	companies := filter(r.store.activeCompanies(), func(c *entities.Company) bool { return c.MarketCap > 0 })
+	slices.SortStableFunc(companies, func(a, b *entities.Company) int { return cmp.Compare(b.MarketCap, a.MarketCap) })
+	return copyCompanies(paginate(companies, limitIfPositive(limit), 0)), nil
+}
+
+// ========================================
+// BUSINESS OPERATIONS (CRITICAL FOR API SYNC)
+// ========================================
+
+// FindOrCreateByTicker finds a company by ticker or creates it if it doesn't exist
+func (r *companyRepository) FindOrCreateByTicker(ctx context.Context, ticker, name string) (*entities.Company, error) {
+	company, err := r.GetByTicker(ctx, ticker)
+	if err == nil {
+		return company, nil
+	}
+	if !domainerrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to find or create company: %w", err)
+	}
+
+	newCompany := entities.NewCompany(ticker, name)
+	if err := r.Create(ctx, newCompany); err != nil {
+		return nil, fmt.Errorf("failed to create new company %s: %w", ticker, err)
+	}
+	return newCompany, nil
+}
+
+// FindOrCreateWithDetails finds or creates a company, filling in the details it is missing
+func (r *companyRepository) FindOrCreateWithDetails(ctx context.Context, ticker, name, sector, exchange string, marketCap float64) (*entities.Company, error) {
+	company, err := r.GetByTicker(ctx, ticker)
+	if err == nil {
+		updated := false
+		if company.Sector == "" && sector != "" {
+			company.Sector = sector
+			updated = true
+		}
+		if company.Exchange == "" && exchange != "" {
+			company.Exchange = exchange
+			updated = true
+		}
+		if company.MarketCap == 0 && marketCap > 0 {
+			company.MarketCap = marketCap
+			updated = true
+		}
+
+		if updated {
+			if err := r.Update(ctx, company); err != nil {
+				return nil, fmt.Errorf("failed to update company details: %w", err)
+			}
+		}
+		return company, nil
+	}
+	if !domainerrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to find or create company with details: %w", err)
+	}
+
+	newCompany := entities.NewCompanyWithDetails(ticker, name, sector, exchange, marketCap)
+	if err := r.Create(ctx, newCompany); err != nil {
+		return nil, fmt.Errorf("failed to create new company with details %s: %w", ticker, err)
+	}
+	return newCompany, nil
+}
+
+// UpsertMany creates the new companies and saves over the existing ones (by ticker) in one transaction
+func (r *companyRepository) UpsertMany(ctx context.Context, companies []*entities.Company) error {
+	return r.store.transaction(func() error {
+		for _, company := range companies {
+			var existing *entities.Company
+			for _, candidate := range r.store.liveCompanies() {
+				if candidate.Ticker == company.Ticker {
+					existing = candidate
+					break
+				}
+			}
+
+			if existing == nil {
+				if err := r.store.insertCompany(company); err != nil {
+					return fmt.Errorf("failed to create company %s in batch: %w", company.Ticker, err)
+				}
+				continue
+			}
+
+			company.ID = existing.ID // Preserve ID
+			if company.CreatedAt.IsZero() {
+				company.CreatedAt = existing.CreatedAt
+			}
+			if err := r.store.saveCompany(company); err != nil {
+				return fmt.Errorf("failed to update company %s in batch: %w", company.Ticker, err)
+			}
+		}
+		return nil
+	})
+}
+
+// ========================================
+// RELATIONSHIP OPERATIONS
+// ========================================
+
+// GetWithRatings retrieves a company with its stock ratings preloaded
+func (r *companyRepository) GetWithRatings(ctx context.Context, id uuid.UUID) (*entities.Company, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	company := r.store.findCompany(id)
+	if company == nil {
+		return nil, domainerrors.NotFound("company with id %s not found", id)
+	}
+
+	result := copyCompany(company)
+	for _, rating := range r.store.liveRatings() {
+		if rating.CompanyID == id {
+			result.StockRatings = append(result.StockRatings, *copyRating(rating))
+		}
+	}
+	return result, nil
+}
+
+// GetByRatingCount retrieves active companies ordered by their rating count (most active first)
+func (r *companyRepository) GetByRatingCount(ctx context.Context, limit int) ([]*entities.Company, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	counts := r.store.ratingCounts(time.Time{})
+	companies := r.store.activeCompanies()
+	slices.SortStableFunc(companies, func(a, b *entities.Company) int { return cmp.Compare(counts[b.ID], counts[a.ID]) })
+	return copyCompanies(paginate(companies, limitIfPositive(limit), 0)), nil
+}
+
+// GetMostActiveCompanies retrieves the companies with most ratings in the last N days
+func (r *companyRepository) GetMostActiveCompanies(ctx context.Context, days int, limit int) ([]*entities.Company, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// El filtro sobre event_time convierte el LEFT JOIN en un INNER JOIN: solo companies con ratings recientes
+	counts := r.store.ratingCounts(time.Now().AddDate(0, 0, -days))
+	companies := filter(r.store.activeCompanies(), func(c *entities.Company) bool { return counts[c.ID] > 0 })
+	slices.SortStableFunc(companies, func(a, b *entities.Company) int { return cmp.Compare(counts[b.ID], counts[a.ID]) })
+	return copyCompanies(paginate(companies, limitIfPositive(limit), 0)), nil
+}
+
+// ========================================
+// SEARCH OPERATIONS
+// ========================================
+
+// SearchByName searches active companies by name using case-insensitive partial matching
+func (r *companyRepository) SearchByName(ctx context.Context, query string, limit int) ([]*entities.Company, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	query = strings.ToLower(query)
+	companies := filter(r.store.activeCompanies(), func(c *entities.Company) bool {
+		return strings.Contains(strings.ToLower(c.Name), query)
+	})
+	slices.SortStableFunc(companies, func(a, b *entities.Company) int { return cmp.Compare(a.Name, b.Name) })
+	return copyCompanies(paginate(companies, limitIfPositive(limit), 0)), nil
+}
+
+// SearchByTicker searches active companies by ticker using case-insensitive partial matching
+func (r *companyRepository) SearchByTicker(ctx context.Context, query string, limit int) ([]*entities.Company, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	query = strings.ToUpper(query)
+	companies := filter(r.store.activeCompanies(), func(c *entities.Company) bool {
+		return strings.Contains(strings.ToUpper(c.Ticker), query)
+	})
+	slices.SortStableFunc(companies, func(a, b *entities.Company) int { return cmp.Compare(a.Ticker, b.Ticker) })
+	return copyCompanies(paginate(companies, limitIfPositive(limit), 0)), nil
+}
+
+// SuggestByPrefix returns active companies whose ticker starts with prefix (shortest tickers first) followed,
+// if there is room left, by those whose name starts with it
+func (r *companyRepository) SuggestByPrefix(ctx context.Context, prefix string, limit int) ([]interfaces.CompanySuggestion, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	prefix = strings.TrimSpace(prefix)
+	byTicker := filter(r.store.activeCompanies(), func(c *entities.Company) bool {
+		return strings.HasPrefix(c.Ticker, strings.ToUpper(prefix))
+	})
+	slices.SortStableFunc(byTicker, func(a, b *entities.Company) int {
+		return cmp.Or(cmp.Compare(len(a.Ticker), len(b.Ticker)), cmp.Compare(a.Ticker, b.Ticker))
+	})
+	byName := filter(r.store.activeCompanies(), func(c *entities.Company) bool {
+		return strings.HasPrefix(strings.ToLower(c.Name), strings.ToLower(prefix)) && !slices.Contains(byTicker, c)
+	})
+	slices.SortStableFunc(byName, func(a, b *entities.Company) int { return cmp.Compare(a.Name, b.Name) })
+
+	suggestions := make([]interfaces.CompanySuggestion, 0, limit)
+	for _, company := range paginate(append(byTicker, byName...), limit, 0) {
+		suggestions = append(suggestions, interfaces.CompanySuggestion{ID: company.ID, Ticker: company.Ticker, Name: company.Name})
+	}
+	return suggestions, nil
+}
+
+// ========================================
+// ANALYTICS OPERATIONS
+// ========================================
+
+// GetSectorDistribution returns the count of active companies per sector
+func (r *companyRepository) GetSectorDistribution(ctx context.Context) (map[string]int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	distribution := make(map[string]int64)
+	for _, company := range r.store.activeCompanies() {
+		if company.Sector != "" {
+			distribution[company.Sector]++
+		}
+	}
+	return distribution, nil
+}
+
+// GetExchangeDistribution returns the count of active companies per exchange
+func (r *companyRepository) GetExchangeDistribution(ctx context.Context) (map[string]int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	distribution := make(map[string]int64)
+	for _, company := range r.store.activeCompanies() {
+		if company.Exchange != "" {
+			distribution[company.Exchange]++
+		}
+	}
+	return distribution, nil
+}
+
+// GetMarketCapStats returns market cap statistics (min, max, avg) of the active companies with a market cap
+func (r *companyRepository) GetMarketCapStats(ctx context.Context) (map[string]float64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stats := map[string]float64{"min": 0, "max": 0, "avg": 0, "count": 0}
+	var sum float64
+	for _, company := range r.store.activeCompanies() {
+		if company.MarketCap <= 0 {
+			continue
+		}
+		if stats["count"] == 0 || company.MarketCap < stats["min"] {
+			stats["min"] = company.MarketCap
+		}
+		stats["max"] = max(stats["max"], company.MarketCap)
+		sum += company.MarketCap
+		stats["count"]++
+	}
+	if stats["count"] > 0 {
+		stats["avg"] = sum / stats["count"]
+	}
+	return stats, nil
+}
+
+// GetMarketCapStatsByGroup returns market cap statistics per exchange and currency of the active companies with
+// a market cap, ordered by exchange and currency
+func (r *companyRepository) GetMarketCapStatsByGroup(ctx context.Context) ([]interfaces.MarketCapGroupStats, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	groups := make(map[[2]string]*interfaces.MarketCapGroupStats)
+	for _, company := range r.store.activeCompanies() {
+		if company.MarketCap <= 0 {
+			continue
+		}
+		key := [2]string{company.Exchange, strings.ToUpper(company.Currency)}
+		group, ok := groups[key]
+		if !ok {
+			group = &interfaces.MarketCapGroupStats{Exchange: key[0], Currency: key[1], Min: company.MarketCap}
+			groups[key] = group
+		}
+		group.Count++
+		group.Sum += company.MarketCap
+		group.Min = min(group.Min, company.MarketCap)
+		group.Max = max(group.Max, company.MarketCap)
+	}
+
+	result := make([]interfaces.MarketCapGroupStats, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, *group)
+	}
+	slices.SortFunc(result, func(a, b interfaces.MarketCapGroupStats) int {
+		return cmp.Or(cmp.Compare(a.Exchange, b.Exchange), cmp.Compare(a.Currency, b.Currency))
+	})
+	return result, nil
+}
@@ -0,0 +1,392 @@
+package testdoubles
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+)
+
+// marketDataRepository implements the MarketDataRepository interface over the in-memory Store
+type marketDataRepository struct {
+	store *Store
+}
+
+// NewMarketDataRepository creates a new in-memory market data repository
+func NewMarketDataRepository(store *Store) interfaces.MarketDataRepository {
+	return &marketDataRepository{store: store}
+}
+
+func marketDataDeletedAt(md *entities.MarketData) gorm.DeletedAt { return md.DeletedAt }
+
+// latestFirst es el orden de las cotizaciones: market_timestamp DESC
+func latestFirst(a, b *entities.MarketData) int { return b.MarketTimestamp.Compare(a.MarketTimestamp) }
+
+// copyMarketData devuelve una copia sin relaciones
+func copyMarketData(marketData *entities.MarketData) *entities.MarketData {
+	copied := *marketData
+	copied.Company = entities.Company{}
+	if marketData.FetchedAt != nil {
+		fetchedAt := *marketData.FetchedAt
+		copied.FetchedAt = &fetchedAt
+	}
+	return &copied
+}
+
+func copyMarketDataList(marketData []*entities.MarketData) []*entities.MarketData {
+	result := make([]*entities.MarketData, len(marketData))
+	for i, md := range marketData {
+		result[i] = copyMarketData(md)
+	}
+	return result
+}
+
+// sortedMarketData ordena una copia de las filas con less
+func sortedMarketData(marketData []*entities.MarketData, less func(a, b *entities.MarketData) int) []*entities.MarketData {
+	marketData = slices.Clone(marketData)
+	slices.SortStableFunc(marketData, less)
+	return marketData
+}
+
+// ========================================
+// STORE OPERATIONS (llamadas con el Store bloqueado)
+// ========================================
+
+func (s *Store) liveMarketData() []*entities.MarketData {
+	return live(s.data.marketData, marketDataDeletedAt)
+}
+
+func (s *Store) marketDataIndex(id uuid.UUID) int {
+	return slices.IndexFunc(s.data.marketData, func(md *entities.MarketData) bool { return md.ID == id })
+}
+
+// insertMarketData aplica el hook y los valores por defecto de las columnas y comprueba la foreign key
+func (s *Store) insertMarketData(marketData *entities.MarketData) error {
+	if err := marketData.BeforeCreate(nil); err != nil {
+		return err
+	}
+	if marketData.Currency == "" {
+		marketData.Currency = "USD"
+	}
+	if marketData.DataSource == "" {
+		marketData.DataSource = "finnhub"
+	}
+
+	if !s.companyExists(marketData.CompanyID) {
+		return domainerrors.Conflict(nil, "failed to create market data for %s", marketData.Symbol)
+	}
+	if s.marketDataIndex(marketData.ID) >= 0 {
+		return domainerrors.Duplicate(nil, "failed to create market data for %s", marketData.Symbol)
+	}
+
+	now := s.now()
+	if marketData.CreatedAt.IsZero() {
+		marketData.CreatedAt = now
+	}
+	if marketData.UpdatedAt.IsZero() {
+		marketData.UpdatedAt = now
+	}
+	s.data.marketData = append(s.data.marketData, copyMarketData(marketData))
+	return nil
+}
+
+// saveMarketData reproduce Save: actualiza todas las columnas por id o inserta si no existe
+func (s *Store) saveMarketData(marketData *entities.MarketData) error {
+	index := s.marketDataIndex(marketData.ID)
+	if index < 0 {
+		return s.insertMarketData(marketData)
+	}
+
+	if !s.companyExists(marketData.CompanyID) {
+		return domainerrors.Conflict(nil, "failed to save market data for %s", marketData.Symbol)
+	}
+	if marketData.CreatedAt.IsZero() {
+		marketData.CreatedAt = s.data.marketData[index].CreatedAt
+	}
+	marketData.UpdatedAt = s.now()
+	s.data.marketData[index] = copyMarketData(marketData)
+	return nil
+}
+
+// latestPerSymbol devuelve la cotización viva más reciente de cada símbolo
+func (s *Store) latestPerSymbol() []*entities.MarketData {
+	latest := make(map[string]*entities.MarketData)
+	for _, md := range s.liveMarketData() {
+		if current, ok := latest[md.Symbol]; !ok || md.MarketTimestamp.After(current.MarketTimestamp) {
+			latest[md.Symbol] = md
+		}
+	}
+	return filter(s.liveMarketData(), func(md *entities.MarketData) bool { return latest[md.Symbol] == md })
+}
+
+// ========================================
+// CREATE OPERATIONS
+// ========================================
+
+// Create creates a new market data record in the store
+func (r *marketDataRepository) Create(ctx context.Context, marketData *entities.MarketData) error {
+	return r.store.transaction(func() error {
+		if err := r.store.insertMarketData(marketData); err != nil {
+			return fmt.Errorf("failed to create market data: %w", err)
+		}
+		return nil
+	})
+}
+
+// BulkCreate creates multiple market data records in a single transaction
+func (r *marketDataRepository) BulkCreate(ctx context.Context, marketData []*entities.MarketData) error {
+	return r.store.transaction(func() error {
+		for _, md := range marketData {
+			if err := r.store.insertMarketData(md); err != nil {
+				return fmt.Errorf("failed to create market data in batch: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// ========================================
+// READ OPERATIONS
+// ========================================
+
+// GetByID retrieves market data by its unique ID
+func (r *marketDataRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.MarketData, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	index := r.store.marketDataIndex(id)
+	if index < 0 || r.store.data.marketData[index].DeletedAt.Valid {
+		return nil, domainerrors.NotFound("market data not found with id %s", id.String())
+	}
+	return copyMarketData(r.store.data.marketData[index]), nil
+}
+
+// GetBySymbol retrieves the latest market data for a stock symbol
+func (r *marketDataRepository) GetBySymbol(ctx context.Context, symbol string) (*entities.MarketData, error) {
+	latest := r.latest(func(md *entities.MarketData) bool { return md.Symbol == symbol })
+	if latest == nil {
+		return nil, domainerrors.NotFound("market data not found for symbol %s", symbol)
+	}
+	return latest, nil
+}
+
+// GetByCompanyID retrieves the latest market data for a company
+func (r *marketDataRepository) GetByCompanyID(ctx context.Context, companyID uuid.UUID) (*entities.MarketData, error) {
+	latest := r.latest(func(md *entities.MarketData) bool { return md.CompanyID == companyID })
+	if latest == nil {
+		return nil, domainerrors.NotFound("market data not found for company id %s", companyID.String())
+	}
+	return latest, nil
+}
+
+// latest devuelve una copia de la cotización viva más reciente que cumple keep, o nil
+func (r *marketDataRepository) latest(keep func(*entities.MarketData) bool) *entities.MarketData {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	marketData := sortedMarketData(filter(r.store.liveMarketData(), keep), latestFirst)
+	if len(marketData) == 0 {
+		return nil
+	}
+	return copyMarketData(marketData[0])
+}
+
+// GetByCompanyIDs retrieves the latest market data for multiple companies
+func (r *marketDataRepository) GetByCompanyIDs(ctx context.Context, companyIDs []uuid.UUID) ([]*entities.MarketData, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	latest := make(map[uuid.UUID]*entities.MarketData)
+	for _, md := range r.store.liveMarketData() {
+		if !slices.Contains(companyIDs, md.CompanyID) {
+			continue
+		}
+		if current, ok := latest[md.CompanyID]; !ok || md.MarketTimestamp.After(current.MarketTimestamp) {
+			latest[md.CompanyID] = md
+		}
+	}
+
+	result := filter(r.store.liveMarketData(), func(md *entities.MarketData) bool { return latest[md.CompanyID] == md })
+	return copyMarketDataList(result), nil
+}
+
+// GetLatest retrieves the most recent market data records
+func (r *marketDataRepository) GetLatest(ctx context.Context, limit int) ([]*entities.MarketData, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	marketData := sortedMarketData(r.store.liveMarketData(), latestFirst)
+	return copyMarketDataList(paginate(marketData, limitIfPositive(limit), 0)), nil
+}
+
+// GetByTimeRange retrieves market data within a time range (both ends included)
+func (r *marketDataRepository) GetByTimeRange(ctx context.Context, startTime, endTime time.Time) ([]*entities.MarketData, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	marketData := filter(r.store.liveMarketData(), func(md *entities.MarketData) bool {
+		return !md.MarketTimestamp.Before(startTime) && !md.MarketTimestamp.After(endTime)
+	})
+	return copyMarketDataList(sortedMarketData(marketData, latestFirst)), nil
+}
+
+// GetStaleData retrieves market data older than maxAge
+func (r *marketDataRepository) GetStaleData(ctx context.Context, maxAge time.Duration) ([]*entities.MarketData, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	staleTime := time.Now().Add(-maxAge)
+	marketData := filter(r.store.liveMarketData(), func(md *entities.MarketData) bool { return md.MarketTimestamp.Before(staleTime) })
+	return copyMarketDataList(sortedMarketData(marketData, latestFirst)), nil
+}
+
+// GetLatestTimestamps retrieves the market timestamp of the latest quote of each symbol
+func (r *marketDataRepository) GetLatestTimestamps(ctx context.Context, symbols []string) (map[string]time.Time, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	latest := make(map[string]time.Time, len(symbols))
+	for _, md := range r.store.latestPerSymbol() {
+		if slices.Contains(symbols, md.Symbol) {
+			latest[md.Symbol] = md.MarketTimestamp
+		}
+	}
+	return latest, nil
+}
+
+// ========================================
+// MARKET ANALYSIS OPERATIONS
+// ========================================
+
+// GetTopGainers retrieves the latest quotes with highest percentage gains
+func (r *marketDataRepository) GetTopGainers(ctx context.Context, limit int) ([]*entities.MarketData, error) {
+	return r.topMovers(func(md *entities.MarketData) bool { return md.PriceChangePerc > 0 },
+		func(a, b *entities.MarketData) int { return cmp.Compare(b.PriceChangePerc, a.PriceChangePerc) }, limit), nil
+}
+
+// GetTopLosers retrieves the latest quotes with highest percentage losses
+func (r *marketDataRepository) GetTopLosers(ctx context.Context, limit int) ([]*entities.MarketData, error) {
+	return r.topMovers(func(md *entities.MarketData) bool { return md.PriceChangePerc < 0 },
+		func(a, b *entities.MarketData) int { return cmp.Compare(a.PriceChangePerc, b.PriceChangePerc) }, limit), nil
+}
+
+// GetMostActive retrieves the latest quotes with highest trading volume
+func (r *marketDataRepository) GetMostActive(ctx context.Context, limit int) ([]*entities.MarketData, error) {
+	return r.topMovers(func(md *entities.MarketData) bool { return md.Volume > 0 },
+		func(a, b *entities.MarketData) int { return cmp.Compare(b.Volume, a.Volume) }, limit), nil
+}
+
+// topMovers ordena la cotización más reciente de cada símbolo que cumple keep
+func (r *marketDataRepository) topMovers(keep func(*entities.MarketData) bool, less func(a, b *entities.MarketData) int, limit int) []*entities.MarketData {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	marketData := sortedMarketData(filter(r.store.latestPerSymbol(), keep), less)
+	return copyMarketDataList(paginate(marketData, limitIfPositive(limit), 0))
+}
+
+// ========================================
+// UPDATE OPERATIONS
+// ========================================
+
+// Update saves every column of a market data record, inserting it if it does not exist
+func (r *marketDataRepository) Update(ctx context.Context, marketData *entities.MarketData) error {
+	return r.store.transaction(func() error {
+		if err := r.store.saveMarketData(marketData); err != nil {
+			return fmt.Errorf("failed to update market data: %w", err)
+		}
+		return nil
+	})
+}
+
+// BulkUpdate saves multiple market data records
+func (r *marketDataRepository) BulkUpdate(ctx context.Context, marketData []*entities.MarketData) error {
+	for _, md := range marketData {
+		if err := r.Update(ctx, md); err != nil {
+			return fmt.Errorf("failed to update market data in bulk: %w", err)
+		}
+	}
+	return nil
+}
+
+// UpsertBySymbol creates or updates the market data of a symbol at its market timestamp
+func (r *marketDataRepository) UpsertBySymbol(ctx context.Context, marketData *entities.MarketData, hooks ...interfaces.WriteHook[entities.MarketData]) error {
+	return r.store.transaction(func() error {
+		for _, existing := range r.store.liveMarketData() {
+			if existing.Symbol == marketData.Symbol && existing.MarketTimestamp.Equal(marketData.MarketTimestamp) {
+				marketData.ID = existing.ID
+				if err := r.store.saveMarketData(marketData); err != nil {
+					return fmt.Errorf("failed to update market data during upsert: %w", err)
+				}
+				return runHooks(marketData, hooks)
+			}
+		}
+
+		if err := r.store.insertMarketData(marketData); err != nil {
+			return fmt.Errorf("failed to create market data during upsert: %w", err)
+		}
+		return runHooks(marketData, hooks)
+	})
+}
+
+// ========================================
+// DELETE OPERATIONS
+// ========================================
+
+// Delete soft-deletes market data by ID; an unknown ID is not an error
+func (r *marketDataRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.store.transaction(func() error {
+		r.store.softDeleteMarketData(func(md *entities.MarketData) bool { return md.ID == id })
+		return nil
+	})
+}
+
+// CleanupOldData soft-deletes market data older than the specified time and returns the count of deleted records
+func (r *marketDataRepository) CleanupOldData(ctx context.Context, olderThan time.Time) (int64, error) {
+	var deleted int64
+	err := r.store.transaction(func() error {
+		deleted = r.store.softDeleteMarketData(func(md *entities.MarketData) bool { return md.MarketTimestamp.Before(olderThan) })
+		return nil
+	})
+	return deleted, err
+}
+
+// softDeleteMarketData marca como eliminadas las cotizaciones vivas que cumplen match y devuelve cuántas
+func (s *Store) softDeleteMarketData(match func(*entities.MarketData) bool) int64 {
+	var deleted int64
+	for i, md := range s.data.marketData {
+		if md.DeletedAt.Valid || !match(md) {
+			continue
+		}
+		removed := copyMarketData(md)
+		removed.DeletedAt = s.softDeleted()
+		s.data.marketData[i] = removed
+		deleted++
+	}
+	return deleted
+}
+
+// ========================================
+// DATA MANAGEMENT OPERATIONS
+// ========================================
+
+// Count returns the total number of market data records
+func (r *marketDataRepository) Count(ctx context.Context) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return int64(len(r.store.liveMarketData())), nil
+}
+
+// Health always succeeds: the store has no connection to check
+func (r *marketDataRepository) Health(ctx context.Context) error {
+	return nil
+}
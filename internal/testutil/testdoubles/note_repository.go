@@ -0,0 +1,184 @@
+package testdoubles
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/tenancy"
+)
+
+// noteRepository implements the NoteRepository interface over the in-memory Store
+type noteRepository struct {
+	store *Store
+}
+
+// NewNoteRepository creates a new in-memory note repository
+func NewNoteRepository(store *Store) interfaces.NoteRepository {
+	return &noteRepository{store: store}
+}
+
+func copyNote(note *entities.Note) *entities.Note {
+	copied := *note
+	if note.RatingID != nil {
+		ratingID := *note.RatingID
+		copied.RatingID = &ratingID
+	}
+	return &copied
+}
+
+// newestNoteFirst es el orden de las notas: created_at DESC
+func newestNoteFirst(a, b *entities.Note) int { return b.CreatedAt.Compare(a.CreatedAt) }
+
+// ========================================
+// STORE OPERATIONS (llamadas con el Store bloqueado)
+// ========================================
+
+// ownedNotes devuelve las notas de la credencial del contexto, como el scope de los repositorios de implementation
+func (s *Store) ownedNotes(ctx context.Context) ([]*entities.Note, error) {
+	tenantID, author, err := tenancy.Owner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return filter(s.data.notes, func(n *entities.Note) bool { return n.TenantID == tenantID && n.Author == author }), nil
+}
+
+// ownedNoteIndex busca una nota de la credencial del contexto; -1 si no existe o es de otro usuario
+func (s *Store) ownedNoteIndex(ctx context.Context, id uuid.UUID) (int, error) {
+	tenantID, author, err := tenancy.Owner(ctx)
+	if err != nil {
+		return -1, err
+	}
+	return slices.IndexFunc(s.data.notes, func(n *entities.Note) bool {
+		return n.ID == id && n.TenantID == tenantID && n.Author == author
+	}), nil
+}
+
+// deleteOrphanNotes elimina las notas de los ratings eliminados físicamente (ON DELETE CASCADE)
+func (s *Store) deleteOrphanNotes() {
+	s.data.notes = filter(s.data.notes, func(n *entities.Note) bool { return n.RatingID == nil || s.ratingIndex(*n.RatingID) >= 0 })
+}
+
+// ========================================
+// REPOSITORY OPERATIONS
+// ========================================
+
+// Create stores a note of the user of the context
+func (r *noteRepository) Create(ctx context.Context, note *entities.Note) error {
+	tenantID, author, err := tenancy.Owner(ctx)
+	if err != nil {
+		return err
+	}
+
+	return r.store.transaction(func() error {
+		s := r.store
+		if err := note.BeforeCreate(nil); err != nil {
+			return err
+		}
+		if !s.companyExists(note.CompanyID) || (note.RatingID != nil && s.ratingIndex(*note.RatingID) < 0) {
+			return domainerrors.Conflict(nil, "failed to create note")
+		}
+
+		now := s.now()
+		note.TenantID, note.Author = tenantID, author
+		note.CreatedAt, note.UpdatedAt = now, now
+		s.data.notes = append(s.data.notes, copyNote(note))
+		return nil
+	})
+}
+
+// GetByID retrieves a note of the user of the context
+func (r *noteRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Note, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	index, err := r.store.ownedNoteIndex(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if index < 0 {
+		return nil, domainerrors.NotFound("note with id %s", id)
+	}
+	return copyNote(r.store.data.notes[index]), nil
+}
+
+// List retrieves the notes of the user of the context, newest first
+func (r *noteRepository) List(ctx context.Context, listFilter interfaces.NoteListFilter, limit, offset int) ([]*entities.Note, int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	notes, err := r.store.ownedNotes(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	notes = filter(notes, func(n *entities.Note) bool {
+		return (listFilter.CompanyID == nil || n.CompanyID == *listFilter.CompanyID) &&
+			(listFilter.RatingID == nil || (n.RatingID != nil && *n.RatingID == *listFilter.RatingID))
+	})
+	slices.SortStableFunc(notes, newestNoteFirst)
+
+	result := paginate(notes, limitIfPositive(limit), offset)
+	copied := make([]*entities.Note, len(result))
+	for i, note := range result {
+		copied[i] = copyNote(note)
+	}
+	return copied, int64(len(notes)), nil
+}
+
+// GetByRatingIDs retrieves the notes of the user of the context on the given ratings, newest first
+func (r *noteRepository) GetByRatingIDs(ctx context.Context, ratingIDs []uuid.UUID) ([]*entities.Note, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	notes, err := r.store.ownedNotes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	notes = filter(notes, func(n *entities.Note) bool { return n.RatingID != nil && slices.Contains(ratingIDs, *n.RatingID) })
+	slices.SortStableFunc(notes, newestNoteFirst)
+
+	for i, note := range notes {
+		notes[i] = copyNote(note)
+	}
+	return notes, nil
+}
+
+// Update stores the body of a note of the user of the context
+func (r *noteRepository) Update(ctx context.Context, note *entities.Note) error {
+	return r.store.transaction(func() error {
+		s := r.store
+		index, err := s.ownedNoteIndex(ctx, note.ID)
+		if err != nil {
+			return err
+		}
+		if index < 0 {
+			return domainerrors.NotFound("note with id %s", note.ID)
+		}
+		updated := copyNote(s.data.notes[index])
+		updated.Body = note.Body
+		updated.UpdatedAt = s.now()
+		s.data.notes[index] = updated
+		note.UpdatedAt = updated.UpdatedAt
+		return nil
+	})
+}
+
+// Delete removes a note of the user of the context
+func (r *noteRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.store.transaction(func() error {
+		s := r.store
+		index, err := s.ownedNoteIndex(ctx, id)
+		if err != nil {
+			return err
+		}
+		if index < 0 {
+			return domainerrors.NotFound("note with id %s", id)
+		}
+		s.data.notes = slices.Delete(s.data.notes, index, index+1)
+		return nil
+	})
+}
@@ -0,0 +1,1280 @@
+package testdoubles
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/timezone"
+)
+
+// stockRatingRepository implements the StockRatingRepository interface over the in-memory Store
+type stockRatingRepository struct {
+	store *Store
+}
+
+// NewStockRatingRepository creates a new in-memory stock rating repository
+func NewStockRatingRepository(store *Store) interfaces.StockRatingRepository {
+	return &stockRatingRepository{store: store}
+}
+
+// stockRatingColumns son las columnas de StockRatingSortFields
+var stockRatingColumns = map[string]comparator[*entities.StockRating]{
+	"event_time":      func(a, b *entities.StockRating) int { return a.EventTime.Compare(b.EventTime) },
+	"created_at":      func(a, b *entities.StockRating) int { return a.CreatedAt.Compare(b.CreatedAt) },
+	"action":          func(a, b *entities.StockRating) int { return cmp.Compare(a.Action, b.Action) },
+	"action_type":     func(a, b *entities.StockRating) int { return cmp.Compare(a.ActionType, b.ActionType) },
+	"rating_to":       func(a, b *entities.StockRating) int { return cmp.Compare(a.RatingTo, b.RatingTo) },
+	"target_to_value": func(a, b *entities.StockRating) int { return compareNullable(a.TargetToValue, b.TargetToValue) },
+}
+
+func ratingID(sr *entities.StockRating) uuid.UUID { return sr.ID }
+
+func ratingDeletedAt(sr *entities.StockRating) gorm.DeletedAt { return sr.DeletedAt }
+
+// newestFirst es el orden por defecto de las lecturas: event_time DESC
+func newestFirst(a, b *entities.StockRating) int { return b.EventTime.Compare(a.EventTime) }
+
+// oldestCreatedFirst es el orden de las colas de procesamiento: created_at ASC
+func oldestCreatedFirst(a, b *entities.StockRating) int { return a.CreatedAt.Compare(b.CreatedAt) }
+
+// copyRating devuelve una copia sin relaciones que no comparte memoria con la fila guardada
+func copyRating(rating *entities.StockRating) *entities.StockRating {
+	copied := *rating
+	copied.TargetFromValue = cloneFloat(rating.TargetFromValue)
+	copied.TargetToValue = cloneFloat(rating.TargetToValue)
+	if rating.ProcessedAt != nil {
+		processedAt := *rating.ProcessedAt
+		copied.ProcessedAt = &processedAt
+	}
+	copied.RawData = slices.Clone(rating.RawData)
+	copied.Company = entities.Company{}
+	copied.Brokerage = entities.Brokerage{}
+	return &copied
+}
+
+func copyRatings(ratings []*entities.StockRating) []*entities.StockRating {
+	result := make([]*entities.StockRating, len(ratings))
+	for i, rating := range ratings {
+		result[i] = copyRating(rating)
+	}
+	return result
+}
+
+// sortedRatings ordena una copia de las filas con less
+func sortedRatings(ratings []*entities.StockRating, less func(a, b *entities.StockRating) int) []*entities.StockRating {
+	ratings = slices.Clone(ratings)
+	slices.SortStableFunc(ratings, less)
+	return ratings
+}
+
+// ========================================
+// STORE OPERATIONS (llamadas con el Store bloqueado)
+// ========================================
+
+func (s *Store) liveRatings() []*entities.StockRating {
+	return live(s.data.ratings, ratingDeletedAt)
+}
+
+func (s *Store) ratingIndex(id uuid.UUID) int {
+	return slices.IndexFunc(s.data.ratings, func(sr *entities.StockRating) bool { return sr.ID == id })
+}
+
+func (s *Store) findRating(id uuid.UUID) *entities.StockRating {
+	index := s.ratingIndex(id)
+	if index < 0 || s.data.ratings[index].DeletedAt.Valid {
+		return nil
+	}
+	return s.data.ratings[index]
+}
+
+// ratingByEvent busca por la clave única (company, brokerage, event_time); unscoped incluye los eliminados
+func (s *Store) ratingByEvent(companyID, brokerageID uuid.UUID, eventTime time.Time, unscoped bool) *entities.StockRating {
+	eventTime = eventTime.Truncate(time.Microsecond)
+	for _, rating := range s.data.ratings {
+		if rating.CompanyID == companyID && rating.BrokerageID == brokerageID && rating.EventTime.Equal(eventTime) &&
+			(unscoped || !rating.DeletedAt.Valid) {
+			return rating
+		}
+	}
+	return nil
+}
+
+// insertRating aplica el hook y comprueba las foreign keys y la clave única. Con defaults aplica los valores por
+// defecto de las columnas omitidas, como Create; los INSERT en SQL de los repositorios escriben los valores tal cual
+func (s *Store) insertRating(rating *entities.StockRating, defaults bool) error {
+	if err := rating.BeforeCreate(nil); err != nil {
+		return err
+	}
+	if defaults && rating.Source == "" {
+		rating.Source = "api"
+	}
+
+	if !s.companyExists(rating.CompanyID) || !s.brokerageExists(rating.BrokerageID) {
+		return domainerrors.Conflict(nil, "failed to create stock rating")
+	}
+	if s.ratingIndex(rating.ID) >= 0 || s.ratingByEvent(rating.CompanyID, rating.BrokerageID, rating.EventTime, true) != nil {
+		return domainerrors.Duplicate(nil, "rating already exists for company %s, brokerage %s at time %s",
+			rating.CompanyID, rating.BrokerageID, rating.EventTime)
+	}
+
+	now := s.now()
+	stored := copyRating(rating)
+	stored.EventTime = rating.EventTime.Truncate(time.Microsecond)
+	if defaults {
+		if rating.CreatedAt.IsZero() {
+			rating.CreatedAt = now
+		}
+		if rating.UpdatedAt.IsZero() {
+			rating.UpdatedAt = now
+		}
+		stored.CreatedAt, stored.UpdatedAt = rating.CreatedAt, rating.UpdatedAt
+	} else {
+		stored.CreatedAt, stored.UpdatedAt = now, now
+		stored.Version = 1
+		stored.DeletedAt = gorm.DeletedAt{}
+	}
+	s.data.ratings = append(s.data.ratings, stored)
+	return nil
+}
+
+// saveRating reproduce Save: actualiza todas las columnas por id o inserta si no existe
+func (s *Store) saveRating(rating *entities.StockRating) error {
+	index := s.ratingIndex(rating.ID)
+	if index < 0 {
+		return s.insertRating(rating, true)
+	}
+
+	if err := rating.BeforeUpdate(nil); err != nil {
+		return err
+	}
+	if err := s.checkRatingUpdate(rating); err != nil {
+		return err
+	}
+	rating.UpdatedAt = s.now()
+	stored := copyRating(rating)
+	stored.EventTime = rating.EventTime.Truncate(time.Microsecond)
+	s.data.ratings[index] = stored
+	return nil
+}
+
+// checkRatingUpdate comprueba las foreign keys y la clave única de un rating que se va a sobrescribir
+func (s *Store) checkRatingUpdate(rating *entities.StockRating) error {
+	if !s.companyExists(rating.CompanyID) || !s.brokerageExists(rating.BrokerageID) {
+		return domainerrors.Conflict(nil, "failed to update stock rating")
+	}
+	if existing := s.ratingByEvent(rating.CompanyID, rating.BrokerageID, rating.EventTime, true); existing != nil && existing.ID != rating.ID {
+		return domainerrors.Duplicate(nil, "failed to update stock rating")
+	}
+	return nil
+}
+
+// updateRating aplica fn a una copia del rating vivo con ese id; devuelve false si no existe
+func (s *Store) updateRating(id uuid.UUID, fn func(rating *entities.StockRating)) bool {
+	index := s.ratingIndex(id)
+	if index < 0 || s.data.ratings[index].DeletedAt.Valid {
+		return false
+	}
+	rating := copyRating(s.data.ratings[index])
+	fn(rating)
+	rating.UpdatedAt = s.now()
+	s.data.ratings[index] = rating
+	return true
+}
+
+// markProcessed marca el rating como procesado con la hora del store
+func (s *Store) markProcessed(rating *entities.StockRating) {
+	processedAt := s.now()
+	rating.IsProcessed, rating.ProcessedAt = true, &processedAt
+}
+
+// withRelations devuelve copias de los ratings con la company y el brokerage precargados (si no están eliminados)
+func (s *Store) withRelations(ratings []*entities.StockRating, company, brokerage bool) []*entities.StockRating {
+	result := copyRatings(ratings)
+	for _, rating := range result {
+		if found := s.findCompany(rating.CompanyID); company && found != nil {
+			rating.Company = *copyCompany(found)
+		}
+		if found := s.findBrokerage(rating.BrokerageID); brokerage && found != nil {
+			rating.Brokerage = *copyBrokerage(found)
+		}
+	}
+	return result
+}
+
+// ratingsBetween devuelve los ratings vivos con event_time en [start, end], los más recientes primero
+func (s *Store) ratingsBetween(start, end time.Time) []*entities.StockRating {
+	ratings := filter(s.liveRatings(), func(sr *entities.StockRating) bool {
+		return !sr.EventTime.Before(start) && !sr.EventTime.After(end)
+	})
+	return sortedRatings(ratings, newestFirst)
+}
+
+// orphanReason explica por qué el rating es huérfano (company o brokerage inexistente o eliminado); vacío si no lo es
+func (s *Store) orphanReason(rating *entities.StockRating) string {
+	company, brokerage := s.findCompany(rating.CompanyID) != nil, s.findBrokerage(rating.BrokerageID) != nil
+	switch {
+	case !company && !brokerage:
+		return "Both company and brokerage not found"
+	case !company:
+		return "Company not found"
+	case !brokerage:
+		return "Brokerage not found"
+	}
+	return ""
+}
+
+// invalidEventTime es el criterio de fechas inválidas: más de un día en el futuro o anteriores al año 2000
+func invalidEventTime(eventTime time.Time) bool {
+	return eventTime.After(time.Now().Add(24*time.Hour)) || eventTime.Before(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+}
+
+// ========================================
+// CREATE OPERATIONS
+// ========================================
+
+// Create creates a new stock rating in the store
+func (r *stockRatingRepository) Create(ctx context.Context, rating *entities.StockRating) error {
+	return r.store.transaction(func() error {
+		return r.store.insertRating(rating, true)
+	})
+}
+
+// CreateMany creates multiple stock ratings in a single transaction, skipping duplicates
+func (r *stockRatingRepository) CreateMany(ctx context.Context, ratings []*entities.StockRating) error {
+	return r.store.transaction(func() error {
+		for _, rating := range ratings {
+			if err := r.store.insertRating(rating, true); err != nil {
+				if domainerrors.IsDuplicate(err) {
+					continue
+				}
+				return fmt.Errorf("failed to create stock rating in batch: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// ========================================
+// READ OPERATIONS - BASIC
+// ========================================
+
+// GetByID retrieves a stock rating by its ID
+func (r *stockRatingRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.StockRating, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	rating := r.store.findRating(id)
+	if rating == nil {
+		return nil, domainerrors.NotFound("stock rating with id %s not found", id)
+	}
+	return copyRating(rating), nil
+}
+
+// GetByIDWithDeleted retrieves a stock rating by its ID, including soft-deleted ones
+func (r *stockRatingRepository) GetByIDWithDeleted(ctx context.Context, id uuid.UUID) (*entities.StockRating, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	index := r.store.ratingIndex(id)
+	if index < 0 {
+		return nil, domainerrors.NotFound("stock rating with id %s not found", id)
+	}
+	return copyRating(r.store.data.ratings[index]), nil
+}
+
+// GetAll retrieves all stock ratings, newest first
+func (r *stockRatingRepository) GetAll(ctx context.Context) ([]*entities.StockRating, error) {
+	return r.query(func(*entities.StockRating) bool { return true }, newestFirst, -1), nil
+}
+
+// GetByCompanyID retrieves all ratings for a specific company
+func (r *stockRatingRepository) GetByCompanyID(ctx context.Context, companyID uuid.UUID) ([]*entities.StockRating, error) {
+	return r.query(func(sr *entities.StockRating) bool { return sr.CompanyID == companyID }, newestFirst, -1), nil
+}
+
+// GetByBrokerageID retrieves all ratings from a specific brokerage
+func (r *stockRatingRepository) GetByBrokerageID(ctx context.Context, brokerageID uuid.UUID) ([]*entities.StockRating, error) {
+	return r.query(func(sr *entities.StockRating) bool { return sr.BrokerageID == brokerageID }, newestFirst, -1), nil
+}
+
+// query devuelve copias de los ratings vivos que cumplen keep, en el orden de less y hasta limit (negativo: todos)
+func (r *stockRatingRepository) query(keep func(*entities.StockRating) bool, less func(a, b *entities.StockRating) int, limit int) []*entities.StockRating {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	ratings := sortedRatings(filter(r.store.liveRatings(), keep), less)
+	return copyRatings(paginate(ratings, limit, 0))
+}
+
+// ========================================
+// READ OPERATIONS - ADVANCED FILTERING
+// ========================================
+
+// GetByCompanyAndBrokerage retrieves ratings for specific company and brokerage combination
+func (r *stockRatingRepository) GetByCompanyAndBrokerage(ctx context.Context, companyID, brokerageID uuid.UUID) ([]*entities.StockRating, error) {
+	return r.query(func(sr *entities.StockRating) bool {
+		return sr.CompanyID == companyID && sr.BrokerageID == brokerageID
+	}, newestFirst, -1), nil
+}
+
+// GetByEventTimeRange retrieves ratings within a specific time range (both ends included)
+func (r *stockRatingRepository) GetByEventTimeRange(ctx context.Context, startTime, endTime time.Time) ([]*entities.StockRating, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return copyRatings(r.store.ratingsBetween(startTime, endTime)), nil
+}
+
+// GetByCompanyAndDateRange retrieves ratings for a company within a date range (both ends included)
+func (r *stockRatingRepository) GetByCompanyAndDateRange(ctx context.Context, companyID uuid.UUID, startTime, endTime time.Time) ([]*entities.StockRating, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	ratings := filter(r.store.ratingsBetween(startTime, endTime), func(sr *entities.StockRating) bool { return sr.CompanyID == companyID })
+	return copyRatings(ratings), nil
+}
+
+// GetLatestByBrokerageAsOf retrieves the latest rating of each brokerage for the company before the given time
+func (r *stockRatingRepository) GetLatestByBrokerageAsOf(ctx context.Context, companyID uuid.UUID, before time.Time) ([]*entities.StockRating, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	latest := make(map[uuid.UUID]*entities.StockRating)
+	for _, rating := range r.store.liveRatings() {
+		if rating.CompanyID != companyID || !rating.EventTime.Before(before) {
+			continue
+		}
+		if current, ok := latest[rating.BrokerageID]; !ok || rating.EventTime.After(current.EventTime) {
+			latest[rating.BrokerageID] = rating
+		}
+	}
+
+	ratings := sortedRatings(slices.Collect(maps.Values(latest)), func(a, b *entities.StockRating) int {
+		return cmp.Or(newestFirst(a, b), compareIDs(a.BrokerageID, b.BrokerageID))
+	})
+	return r.store.withRelations(ratings, true, true), nil
+}
+
+// GetRecent retrieves recent ratings from the last N days
+func (r *stockRatingRepository) GetRecent(ctx context.Context, days int, limit int) ([]*entities.StockRating, error) {
+	cutoffTime := time.Now().AddDate(0, 0, -days)
+	return r.query(func(sr *entities.StockRating) bool { return !sr.EventTime.Before(cutoffTime) }, newestFirst, limitIfPositive(limit)), nil
+}
+
+// ListCreatedAfter retrieves the ratings created after the (afterCreatedAt, afterID) cursor, oldest first
+func (r *stockRatingRepository) ListCreatedAfter(ctx context.Context, afterCreatedAt time.Time, afterID uuid.UUID, limit int) ([]*entities.StockRating, error) {
+	return r.query(func(sr *entities.StockRating) bool {
+		return cmp.Or(sr.CreatedAt.Compare(afterCreatedAt), compareIDs(sr.ID, afterID)) > 0
+	}, func(a, b *entities.StockRating) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), compareIDs(a.ID, b.ID))
+	}, limitIfPositive(limit)), nil
+}
+
+// ========================================
+// READ OPERATIONS - BY ACTION TYPE
+// ========================================
+
+// GetUpgrades retrieves upgrade ratings
+func (r *stockRatingRepository) GetUpgrades(ctx context.Context, limit int) ([]*entities.StockRating, error) {
+	return r.GetByActionType(ctx, entities.ActionUpgrade, limit)
+}
+
+// GetDowngrades retrieves downgrade ratings
+func (r *stockRatingRepository) GetDowngrades(ctx context.Context, limit int) ([]*entities.StockRating, error) {
+	return r.GetByActionType(ctx, entities.ActionDowngrade, limit)
+}
+
+// GetReiterations retrieves reiteration ratings
+func (r *stockRatingRepository) GetReiterations(ctx context.Context, limit int) ([]*entities.StockRating, error) {
+	return r.GetByActionType(ctx, entities.ActionReiterate, limit)
+}
+
+// GetByActionType retrieves ratings by stored action type, newest first
+func (r *stockRatingRepository) GetByActionType(ctx context.Context, actionType entities.ActionType, limit int) ([]*entities.StockRating, error) {
+	return r.query(func(sr *entities.StockRating) bool { return sr.ActionType == actionType }, newestFirst, limitIfPositive(limit)), nil
+}
+
+// Search retrieves a page of the ratings matching every filter set, newest first by default
+func (r *stockRatingRepository) Search(ctx context.Context, searchFilter interfaces.RatingSearchFilter, sort []interfaces.SortField, limit, offset int) ([]*entities.StockRating, int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	f := searchFilter
+	ratings := filter(r.store.liveRatings(), func(sr *entities.StockRating) bool {
+		if f.CompanyID != nil && sr.CompanyID != *f.CompanyID {
+			return false
+		}
+		if f.BrokerageID != nil && sr.BrokerageID != *f.BrokerageID {
+			return false
+		}
+		if len(f.ActionTypes) > 0 && !slices.Contains(f.ActionTypes, sr.ActionType) {
+			return false
+		}
+		if len(f.Ratings) > 0 && !slices.Contains(f.Ratings, sr.NormalizedTo) {
+			return false
+		}
+		// Los precios objetivo solo son comparables dentro de una moneda
+		if f.TargetCurrency != "" {
+			if sr.TargetCurrency != f.TargetCurrency || sr.TargetToValue == nil {
+				return false
+			}
+			if (f.TargetMin != nil && *sr.TargetToValue < *f.TargetMin) || (f.TargetMax != nil && *sr.TargetToValue > *f.TargetMax) {
+				return false
+			}
+		}
+		return (f.From.IsZero() || !sr.EventTime.Before(f.From)) && (f.To.IsZero() || sr.EventTime.Before(f.To))
+	})
+	if err := sortRows(ratings, sort, stockRatingColumns, ratingID, interfaces.SortField{Column: "event_time", Desc: true}); err != nil {
+		return nil, 0, fmt.Errorf("failed to search stock ratings: %w", err)
+	}
+
+	page := r.store.withRelations(paginate(ratings, limit, offset), f.PreloadCompany, f.PreloadBrokerage)
+	return page, int64(len(ratings)), nil
+}
+
+// ========================================
+// UPDATE OPERATIONS
+// ========================================
+
+// Update updates an existing stock rating if its version has not changed since it was read
+func (r *stockRatingRepository) Update(ctx context.Context, rating *entities.StockRating) error {
+	return r.store.transaction(func() error {
+		current := r.store.findRating(rating.ID)
+		if current == nil {
+			return domainerrors.NotFound("stock rating with id %s not found for update", rating.ID)
+		}
+		if current.Version != rating.Version {
+			return domainerrors.VersionMismatch("stock rating with id %s was modified concurrently (expected version %d)", rating.ID, rating.Version)
+		}
+
+		if err := rating.BeforeUpdate(nil); err != nil {
+			return err
+		}
+		if err := r.store.checkRatingUpdate(rating); err != nil {
+			return err
+		}
+
+		rating.Version++
+		rating.UpdatedAt = r.store.now()
+		stored := copyRating(rating)
+		stored.EventTime = rating.EventTime.Truncate(time.Microsecond)
+		r.store.data.ratings[r.store.ratingIndex(rating.ID)] = stored
+		return nil
+	})
+}
+
+// MarkAsProcessed marks a rating as processed
+func (r *stockRatingRepository) MarkAsProcessed(ctx context.Context, id uuid.UUID) error {
+	return r.store.transaction(func() error {
+		if !r.store.updateRating(id, r.store.markProcessed) {
+			return domainerrors.NotFound("stock rating with id %s not found for processing", id)
+		}
+		return nil
+	})
+}
+
+// MarkAsUnprocessed marks a rating as unprocessed
+func (r *stockRatingRepository) MarkAsUnprocessed(ctx context.Context, id uuid.UUID) error {
+	return r.store.transaction(func() error {
+		if !r.store.updateRating(id, func(sr *entities.StockRating) { sr.IsProcessed, sr.ProcessedAt = false, nil }) {
+			return domainerrors.NotFound("stock rating with id %s not found for unprocessing", id)
+		}
+		return nil
+	})
+}
+
+// MarkManyAsProcessed marks multiple ratings as processed; unknown ids are ignored
+func (r *stockRatingRepository) MarkManyAsProcessed(ctx context.Context, ids []uuid.UUID) error {
+	return r.store.transaction(func() error {
+		for _, id := range ids {
+			r.store.updateRating(id, r.store.markProcessed)
+		}
+		return nil
+	})
+}
+
+// ========================================
+// DELETE OPERATIONS
+// ========================================
+
+// Delete performs a soft delete on a stock rating
+func (r *stockRatingRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.store.transaction(func() error {
+		if !r.store.softDeleteRating(id) {
+			return domainerrors.NotFound("stock rating with id %s not found for deletion", id)
+		}
+		return nil
+	})
+}
+
+// softDeleteRating marca el rating como eliminado; devuelve false si no existe o ya estaba eliminado
+func (s *Store) softDeleteRating(id uuid.UUID) bool {
+	index := s.ratingIndex(id)
+	if index < 0 || s.data.ratings[index].DeletedAt.Valid {
+		return false
+	}
+	deleted := copyRating(s.data.ratings[index])
+	deleted.DeletedAt = s.softDeleted()
+	s.data.ratings[index] = deleted
+	return true
+}
+
+// HardDelete permanently deletes a stock rating
+func (r *stockRatingRepository) HardDelete(ctx context.Context, id uuid.UUID) error {
+	return r.store.transaction(func() error {
+		if r.store.ratingIndex(id) < 0 {
+			return domainerrors.NotFound("stock rating with id %s not found for hard deletion", id)
+		}
+		r.store.data.ratings = filter(r.store.data.ratings, func(sr *entities.StockRating) bool { return sr.ID != id })
+		r.store.deleteOrphanNotes()
+		return nil
+	})
+}
+
+// ========================================
+// SOFT-DELETE RECOVERY OPERATIONS
+// ========================================
+
+// ListDeleted retrieves soft-deleted stock ratings, most recently deleted first
+func (r *stockRatingRepository) ListDeleted(ctx context.Context, limit, offset int) ([]*entities.StockRating, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	deleted := filter(r.store.data.ratings, func(sr *entities.StockRating) bool { return sr.DeletedAt.Valid })
+	deleted = sortedRatings(deleted, func(a, b *entities.StockRating) int { return b.DeletedAt.Time.Compare(a.DeletedAt.Time) })
+	return copyRatings(paginate(deleted, limit, offset)), nil
+}
+
+// CountDeleted returns the number of soft-deleted stock ratings
+func (r *stockRatingRepository) CountDeleted(ctx context.Context) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return int64(len(r.store.data.ratings) - len(r.store.liveRatings())), nil
+}
+
+// Restore undoes the soft delete of a stock rating
+func (r *stockRatingRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return r.store.transaction(func() error {
+		index := r.store.ratingIndex(id)
+		if index < 0 || !r.store.data.ratings[index].DeletedAt.Valid {
+			return domainerrors.NotFound("deleted stock rating with id %s not found", id)
+		}
+		restored := copyRating(r.store.data.ratings[index])
+		restored.DeletedAt = gorm.DeletedAt{}
+		restored.UpdatedAt = r.store.now()
+		r.store.data.ratings[index] = restored
+		return nil
+	})
+}
+
+// ========================================
+// QUERY OPERATIONS - BASIC STATS
+// ========================================
+
+// Count returns the total number of stock ratings
+func (r *stockRatingRepository) Count(ctx context.Context) (int64, error) {
+	return r.count(func(*entities.StockRating) bool { return true }), nil
+}
+
+// CountByCompany returns the number of ratings for a specific company
+func (r *stockRatingRepository) CountByCompany(ctx context.Context, companyID uuid.UUID) (int64, error) {
+	return r.count(func(sr *entities.StockRating) bool { return sr.CompanyID == companyID }), nil
+}
+
+// CountByBrokerage returns the number of ratings from a specific brokerage
+func (r *stockRatingRepository) CountByBrokerage(ctx context.Context, brokerageID uuid.UUID) (int64, error) {
+	return r.count(func(sr *entities.StockRating) bool { return sr.BrokerageID == brokerageID }), nil
+}
+
+// CountByActionType returns the number of ratings by action type
+func (r *stockRatingRepository) CountByActionType(ctx context.Context, actionType entities.ActionType) (int64, error) {
+	return r.count(func(sr *entities.StockRating) bool { return sr.ActionType == actionType }), nil
+}
+
+func (r *stockRatingRepository) count(keep func(*entities.StockRating) bool) int64 {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return int64(len(filter(r.store.liveRatings(), keep)))
+}
+
+// ========================================
+// BUSINESS OPERATIONS - CRITICAL FOR API SYNC
+// ========================================
+
+// FindExisting finds an existing rating with exact match; returns nil without error when there is none
+func (r *stockRatingRepository) FindExisting(ctx context.Context, companyID, brokerageID uuid.UUID, eventTime time.Time) (*entities.StockRating, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	rating := r.store.ratingByEvent(companyID, brokerageID, eventTime, false)
+	if rating == nil {
+		return nil, nil
+	}
+	return copyRating(rating), nil
+}
+
+// FindOrCreateRating finds or creates a stock rating
+func (r *stockRatingRepository) FindOrCreateRating(ctx context.Context, companyID, brokerageID uuid.UUID, eventTime time.Time,
+	action, ratingFrom, ratingTo, targetFrom, targetTo string, rawData []byte) (*entities.StockRating, error) {
+
+	existing, err := r.FindExisting(ctx, companyID, brokerageID, eventTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing rating: %w", err)
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	newRating := entities.NewStockRating(companyID, brokerageID, action, eventTime)
+	newRating.RatingFrom = ratingFrom
+	newRating.RatingTo = ratingTo
+	newRating.TargetFrom = targetFrom
+	newRating.TargetTo = targetTo
+	if rawData != nil {
+		newRating.RawData = rawData
+	}
+
+	if err := r.Create(ctx, newRating); err != nil {
+		return nil, fmt.Errorf("failed to create new rating: %w", err)
+	}
+	return newRating, nil
+}
+
+// UpsertMany creates the new ratings and saves over the existing ones (by event) in one transaction
+func (r *stockRatingRepository) UpsertMany(ctx context.Context, ratings []*entities.StockRating) error {
+	return r.store.transaction(func() error {
+		for _, rating := range ratings {
+			existing := r.store.ratingByEvent(rating.CompanyID, rating.BrokerageID, rating.EventTime, false)
+			if existing == nil {
+				if err := r.store.insertRating(rating, true); err != nil {
+					return fmt.Errorf("failed to create rating in upsert: %w", err)
+				}
+				continue
+			}
+
+			rating.ID = existing.ID // Preserve ID
+			if rating.CreatedAt.IsZero() {
+				rating.CreatedAt = existing.CreatedAt
+			}
+			if err := r.store.saveRating(rating); err != nil {
+				return fmt.Errorf("failed to update rating in upsert: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// BulkInsertIgnoreDuplicates inserts ratings ignoring duplicates (also within the batch), returns count inserted
+func (r *stockRatingRepository) BulkInsertIgnoreDuplicates(ctx context.Context, ratings []*entities.StockRating) (int, error) {
+	inserted := 0
+	err := r.store.transaction(func() error {
+		inserted = 0
+		for _, rating := range ratings {
+			err := r.store.insertRating(rating, false)
+			if domainerrors.IsDuplicate(err) {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("failed to bulk insert %d ratings: %w", len(ratings), err)
+			}
+			inserted++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return inserted, nil
+}
+
+// ========================================
+// REPROCESSING OPERATIONS
+// ========================================
+
+// ListWithRawData retrieves ratings that keep their raw data in [from, to), paginated by (event_time, id) keyset
+func (r *stockRatingRepository) ListWithRawData(ctx context.Context, from, to time.Time, afterEventTime time.Time, afterID uuid.UUID, limit int) ([]*entities.StockRating, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	ratings := filter(r.store.liveRatings(), func(sr *entities.StockRating) bool {
+		if len(sr.RawData) == 0 || sr.EventTime.Before(from) || !sr.EventTime.Before(to) {
+			return false
+		}
+		if afterID == uuid.Nil {
+			return true
+		}
+		return cmp.Or(sr.EventTime.Compare(afterEventTime), compareIDs(sr.ID, afterID)) > 0
+	})
+	ratings = sortedRatings(ratings, func(a, b *entities.StockRating) int {
+		return cmp.Or(a.EventTime.Compare(b.EventTime), compareIDs(a.ID, b.ID))
+	})
+	return copyRatings(paginate(ratings, limit, 0)), nil
+}
+
+// ReplaceRating upserts the re-parsed rating on its event key and soft-deletes the original when the key changed
+func (r *stockRatingRepository) ReplaceRating(ctx context.Context, originalID uuid.UUID, rating *entities.StockRating) error {
+	return r.store.transaction(func() error {
+		s := r.store
+		if err := rating.BeforeCreate(nil); err != nil {
+			return fmt.Errorf("failed to prepare rating: %w", err)
+		}
+
+		existing := s.ratingByEvent(rating.CompanyID, rating.BrokerageID, rating.EventTime, true)
+		if existing == nil {
+			if err := s.insertRating(rating, false); err != nil {
+				return fmt.Errorf("failed to upsert rating: %w", err)
+			}
+		} else {
+			// ON CONFLICT DO UPDATE: solo las columnas derivadas del payload, y la fila vuelve a estar viva
+			updated := copyRating(existing)
+			updated.Action, updated.ActionType = rating.Action, rating.ActionType
+			updated.RatingFrom, updated.RatingTo = rating.RatingFrom, rating.RatingTo
+			updated.NormalizedFrom, updated.NormalizedTo = rating.NormalizedFrom, rating.NormalizedTo
+			updated.TargetFrom, updated.TargetTo = rating.TargetFrom, rating.TargetTo
+			updated.TargetFromValue, updated.TargetToValue = cloneFloat(rating.TargetFromValue), cloneFloat(rating.TargetToValue)
+			updated.TargetCurrency = rating.TargetCurrency
+			updated.RawData = slices.Clone(rating.RawData)
+			updated.DeletedAt = gorm.DeletedAt{}
+			updated.UpdatedAt = s.now()
+			updated.Version++
+			s.data.ratings[s.ratingIndex(existing.ID)] = updated
+			rating.ID = existing.ID
+		}
+
+		if rating.ID != originalID {
+			s.softDeleteRating(originalID)
+		}
+		return nil
+	})
+}
+
+// BackfillActionTypes parses the action of the ratings without action type (every rating when all is true).
+// Las filas guardadas ya pasan por el hook, así que solo cambian las que se sembraron con otro action type
+func (r *stockRatingRepository) BackfillActionTypes(ctx context.Context, all bool, batchSize int) (int64, error) {
+	var updated int64
+	err := r.store.transaction(func() error {
+		s := r.store
+		actions := make(map[string]bool)
+		for _, rating := range s.data.ratings {
+			if all || rating.ActionType == "" {
+				actions[rating.Action] = true
+			}
+		}
+
+		for i, rating := range s.data.ratings {
+			actionType := entities.ParseActionType(rating.Action)
+			if !actions[rating.Action] || rating.ActionType == actionType {
+				continue
+			}
+			backfilled := copyRating(rating)
+			backfilled.ActionType = actionType
+			s.data.ratings[i] = backfilled
+			updated++
+		}
+		return nil
+	})
+	return updated, err
+}
+
+// BackfillTargetPrices re-parses the price targets. Every rating of the store is normalized when saved, so
+// without all there is nothing left to parse; with all every rating is rewritten and counted
+func (r *stockRatingRepository) BackfillTargetPrices(ctx context.Context, all bool, batchSize int) (int64, error) {
+	if !all {
+		return 0, nil
+	}
+
+	var updated int64
+	err := r.store.transaction(func() error {
+		for i, rating := range r.store.data.ratings {
+			parsed := copyRating(rating)
+			parsed.Normalize()
+
+			backfilled := copyRating(rating)
+			backfilled.TargetFromValue, backfilled.TargetToValue = parsed.TargetFromValue, parsed.TargetToValue
+			backfilled.TargetCurrency = parsed.TargetCurrency
+			r.store.data.ratings[i] = backfilled
+			updated++
+		}
+		return nil
+	})
+	return updated, err
+}
+
+// ========================================
+// PROCESSING OPERATIONS (FOR BACKGROUND JOBS)
+// ========================================
+
+// GetUnprocessed retrieves unprocessed ratings, oldest first
+func (r *stockRatingRepository) GetUnprocessed(ctx context.Context, limit int) ([]*entities.StockRating, error) {
+	return r.query(func(sr *entities.StockRating) bool { return !sr.IsProcessed }, oldestCreatedFirst, limitIfPositive(limit)), nil
+}
+
+// GetUnprocessedBySource retrieves unprocessed ratings from a specific source, oldest first
+func (r *stockRatingRepository) GetUnprocessedBySource(ctx context.Context, source string, limit int) ([]*entities.StockRating, error) {
+	return r.query(func(sr *entities.StockRating) bool {
+		return !sr.IsProcessed && sr.Source == source
+	}, oldestCreatedFirst, limitIfPositive(limit)), nil
+}
+
+// GetProcessingBatch retrieves a batch of ratings for processing
+func (r *stockRatingRepository) GetProcessingBatch(ctx context.Context, batchSize int) ([]*entities.StockRating, error) {
+	return r.GetUnprocessed(ctx, batchSize)
+}
+
+// CountUnprocessed counts the ratings waiting to be processed
+func (r *stockRatingRepository) CountUnprocessed(ctx context.Context) (int64, error) {
+	return r.count(func(sr *entities.StockRating) bool { return !sr.IsProcessed }), nil
+}
+
+// CountUnprocessedBySource counts the ratings waiting to be processed grouped by source
+func (r *stockRatingRepository) CountUnprocessedBySource(ctx context.Context) (map[string]int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	counts := make(map[string]int64)
+	for _, rating := range r.store.liveRatings() {
+		if !rating.IsProcessed {
+			counts[rating.Source]++
+		}
+	}
+	return counts, nil
+}
+
+// CountProcessedSince counts the ratings processed at or after since
+func (r *stockRatingRepository) CountProcessedSince(ctx context.Context, since time.Time) (int64, error) {
+	return r.count(func(sr *entities.StockRating) bool {
+		return sr.ProcessedAt != nil && !sr.ProcessedAt.Before(since)
+	}), nil
+}
+
+// ========================================
+// RELATIONSHIP OPERATIONS - WITH PRELOADING
+// ========================================
+
+// GetWithCompany retrieves a rating with company preloaded
+func (r *stockRatingRepository) GetWithCompany(ctx context.Context, id uuid.UUID) (*entities.StockRating, error) {
+	return r.getWithRelations(id, true, false)
+}
+
+// GetWithBrokerage retrieves a rating with brokerage preloaded
+func (r *stockRatingRepository) GetWithBrokerage(ctx context.Context, id uuid.UUID) (*entities.StockRating, error) {
+	return r.getWithRelations(id, false, true)
+}
+
+// GetWithRelations retrieves a rating with both company and brokerage preloaded
+func (r *stockRatingRepository) GetWithRelations(ctx context.Context, id uuid.UUID) (*entities.StockRating, error) {
+	return r.getWithRelations(id, true, true)
+}
+
+func (r *stockRatingRepository) getWithRelations(id uuid.UUID, company, brokerage bool) (*entities.StockRating, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	rating := r.store.findRating(id)
+	if rating == nil {
+		return nil, domainerrors.NotFound("stock rating with id %s not found", id)
+	}
+	return r.store.withRelations([]*entities.StockRating{rating}, company, brokerage)[0], nil
+}
+
+// GetAllWithRelations retrieves ratings with relations preloaded, newest first
+func (r *stockRatingRepository) GetAllWithRelations(ctx context.Context, limit int) ([]*entities.StockRating, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	ratings := sortedRatings(r.store.liveRatings(), newestFirst)
+	return r.store.withRelations(paginate(ratings, limitIfPositive(limit), 0), true, true), nil
+}
+
+// ========================================
+// ANALYTICS OPERATIONS
+// ========================================
+
+// GetActionTypeDistribution returns count of each action type in the last N days
+func (r *stockRatingRepository) GetActionTypeDistribution(ctx context.Context, days int) (map[string]int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	cutoffTime := time.Now().AddDate(0, 0, -days)
+	distribution := make(map[string]int64)
+	for _, rating := range r.store.liveRatings() {
+		if !rating.EventTime.Before(cutoffTime) && rating.ActionType != "" {
+			distribution[string(rating.ActionType)]++
+		}
+	}
+	return distribution, nil
+}
+
+// GetActionTypeTrend counts ratings per bucket and action type; buckets start at midnight of the location
+func (r *stockRatingRepository) GetActionTypeTrend(ctx context.Context, from, to time.Time, granularity interfaces.TrendGranularity, location *time.Location) ([]interfaces.RatingTrendBucket, error) {
+	if !granularity.Valid() {
+		return nil, fmt.Errorf("invalid trend granularity %q", granularity)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	buckets := make([]interfaces.RatingTrendBucket, 0)
+	for _, rating := range r.store.liveRatings() {
+		if rating.EventTime.Before(from) || !rating.EventTime.Before(to) {
+			continue
+		}
+
+		start := trendBucketStart(rating.EventTime.In(location), granularity)
+		index := slices.IndexFunc(buckets, func(b interfaces.RatingTrendBucket) bool { return b.Start.Equal(start) })
+		if index < 0 {
+			buckets = append(buckets, interfaces.RatingTrendBucket{Start: start, Actions: make(map[string]int64)})
+			index = len(buckets) - 1
+		}
+
+		actionType := cmp.Or(string(rating.ActionType), string(entities.ActionOther))
+		buckets[index].Actions[actionType]++
+		buckets[index].Total++
+	}
+
+	slices.SortFunc(buckets, func(a, b interfaces.RatingTrendBucket) int { return a.Start.Compare(b.Start) })
+	return buckets, nil
+}
+
+// GetSectorActionTrend counts ratings per bucket and company sector; companies deleted or without sector are left out
+func (r *stockRatingRepository) GetSectorActionTrend(ctx context.Context, from, to time.Time, granularity interfaces.TrendGranularity, location *time.Location) ([]interfaces.SectorActionCount, error) {
+	if !granularity.Valid() {
+		return nil, fmt.Errorf("invalid trend granularity %q", granularity)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	counts := make([]interfaces.SectorActionCount, 0)
+	for _, rating := range r.store.liveRatings() {
+		if rating.EventTime.Before(from) || !rating.EventTime.Before(to) {
+			continue
+		}
+		company := r.store.findCompany(rating.CompanyID)
+		if company == nil || company.Sector == "" {
+			continue
+		}
+
+		start := trendBucketStart(rating.EventTime.In(location), granularity)
+		index := slices.IndexFunc(counts, func(c interfaces.SectorActionCount) bool {
+			return c.Start.Equal(start) && c.Sector == company.Sector
+		})
+		if index < 0 {
+			counts = append(counts, interfaces.SectorActionCount{Start: start, Sector: company.Sector})
+			index = len(counts) - 1
+		}
+
+		counts[index].Total++
+		switch rating.ActionType {
+		case entities.ActionUpgrade:
+			counts[index].Upgrades++
+		case entities.ActionDowngrade:
+			counts[index].Downgrades++
+		}
+	}
+
+	slices.SortFunc(counts, func(a, b interfaces.SectorActionCount) int {
+		return cmp.Or(a.Start.Compare(b.Start), cmp.Compare(a.Sector, b.Sector))
+	})
+	return counts, nil
+}
+
+// trendBucketStart trunca como date_trunc: semanas ISO (lunes) y meses naturales
+func trendBucketStart(local time.Time, granularity interfaces.TrendGranularity) time.Time {
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+	switch granularity {
+	case interfaces.TrendGranularityWeek:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case interfaces.TrendGranularityMonth:
+		return time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, local.Location())
+	}
+	return day
+}
+
+// GetTopCompaniesByRatingCount returns companies with most ratings in last N days
+func (r *stockRatingRepository) GetTopCompaniesByRatingCount(ctx context.Context, days int, limit int) ([]interfaces.CompanyRatingCount, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	cutoffTime := time.Now().AddDate(0, 0, -days)
+	var results []interfaces.CompanyRatingCount
+	for _, rating := range r.store.liveRatings() {
+		company := r.store.findCompany(rating.CompanyID)
+		if company == nil || rating.EventTime.Before(cutoffTime) {
+			continue
+		}
+		index := slices.IndexFunc(results, func(c interfaces.CompanyRatingCount) bool { return c.CompanyID == company.ID })
+		if index < 0 {
+			results = append(results, interfaces.CompanyRatingCount{CompanyID: company.ID, CompanyName: company.Name, Ticker: company.Ticker})
+			index = len(results) - 1
+		}
+		results[index].RatingCount++
+	}
+
+	slices.SortStableFunc(results, func(a, b interfaces.CompanyRatingCount) int { return cmp.Compare(b.RatingCount, a.RatingCount) })
+	return paginate(results, limitIfPositive(limit), 0), nil
+}
+
+// GetTopBrokeragesByRatingCount returns brokerages with most ratings in last N days
+func (r *stockRatingRepository) GetTopBrokeragesByRatingCount(ctx context.Context, days int, limit int) ([]interfaces.BrokerageRatingCount, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	cutoffTime := time.Now().AddDate(0, 0, -days)
+	var results []interfaces.BrokerageRatingCount
+	for _, rating := range r.store.liveRatings() {
+		brokerage := r.store.findBrokerage(rating.BrokerageID)
+		if brokerage == nil || rating.EventTime.Before(cutoffTime) {
+			continue
+		}
+		index := slices.IndexFunc(results, func(b interfaces.BrokerageRatingCount) bool { return b.BrokerageID == brokerage.ID })
+		if index < 0 {
+			results = append(results, interfaces.BrokerageRatingCount{BrokerageID: brokerage.ID, BrokerageName: brokerage.Name})
+			index = len(results) - 1
+		}
+		results[index].RatingCount++
+	}
+
+	slices.SortStableFunc(results, func(a, b interfaces.BrokerageRatingCount) int { return cmp.Compare(b.RatingCount, a.RatingCount) })
+	return paginate(results, limitIfPositive(limit), 0), nil
+}
+
+// GetRatingTrend returns daily rating counts (UTC days) for a company over last N days, most recent day first
+func (r *stockRatingRepository) GetRatingTrend(ctx context.Context, companyID uuid.UUID, days int) ([]interfaces.DailyRatingCount, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	cutoffTime := time.Now().AddDate(0, 0, -days)
+	var results []interfaces.DailyRatingCount
+	for _, rating := range r.store.liveRatings() {
+		if rating.CompanyID != companyID || rating.EventTime.Before(cutoffTime) {
+			continue
+		}
+
+		utc := rating.EventTime.UTC()
+		date := time.Date(utc.Year(), utc.Month(), utc.Day(), 0, 0, 0, 0, time.UTC)
+		index := slices.IndexFunc(results, func(d interfaces.DailyRatingCount) bool { return d.Date.Equal(date) })
+		if index < 0 {
+			results = append(results, interfaces.DailyRatingCount{Date: date})
+			index = len(results) - 1
+		}
+
+		day := &results[index]
+		day.RatingCount++
+		switch rating.ActionType {
+		case entities.ActionUpgrade:
+			day.Upgrades++
+		case entities.ActionDowngrade:
+			day.Downgrades++
+		case entities.ActionReiterate:
+			day.Reiterations++
+		}
+	}
+
+	slices.SortFunc(results, func(a, b interfaces.DailyRatingCount) int { return b.Date.Compare(a.Date) })
+	return results, nil
+}
+
+// ========================================
+// TIME-BASED QUERIES
+// ========================================
+
+// GetTodaysRatings retrieves ratings from today in the time zone of the context
+func (r *stockRatingRepository) GetTodaysRatings(ctx context.Context) ([]*entities.StockRating, error) {
+	startOfDay := timezone.StartOfDay(timezone.Now(ctx))
+	return r.GetByEventTimeRange(ctx, startOfDay, startOfDay.AddDate(0, 0, 1))
+}
+
+// GetThisWeeksRatings retrieves ratings from this week in the time zone of the context
+func (r *stockRatingRepository) GetThisWeeksRatings(ctx context.Context) ([]*entities.StockRating, error) {
+	now := timezone.Now(ctx)
+	return r.GetByEventTimeRange(ctx, timezone.StartOfWeek(now), now)
+}
+
+// GetThisMonthsRatings retrieves ratings from this month in the time zone of the context
+func (r *stockRatingRepository) GetThisMonthsRatings(ctx context.Context) ([]*entities.StockRating, error) {
+	now := timezone.Now(ctx)
+	return r.GetByEventTimeRange(ctx, timezone.StartOfMonth(now), now)
+}
+
+// ========================================
+// DUPLICATE DETECTION AND CLEANUP
+// ========================================
+
+// FindDuplicates finds groups of live ratings sharing company, brokerage and event time.
+// La clave única del Store lo impide, igual que en la base de datos: solo aparecen en datos heredados
+func (r *stockRatingRepository) FindDuplicates(ctx context.Context) ([]interfaces.DuplicateGroup, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.store.duplicateGroups(), nil
+}
+
+func (s *Store) duplicateGroups() []interfaces.DuplicateGroup {
+	var groups []interfaces.DuplicateGroup
+	for _, rating := range s.liveRatings() {
+		index := slices.IndexFunc(groups, func(g interfaces.DuplicateGroup) bool {
+			return g.CompanyID == rating.CompanyID && g.BrokerageID == rating.BrokerageID && g.EventTime.Equal(rating.EventTime)
+		})
+		if index < 0 {
+			groups = append(groups, interfaces.DuplicateGroup{CompanyID: rating.CompanyID, BrokerageID: rating.BrokerageID, EventTime: rating.EventTime})
+			index = len(groups) - 1
+		}
+		groups[index].RatingIDs = append(groups[index].RatingIDs, rating.ID)
+		groups[index].Count++
+	}
+	return filter(groups, func(g interfaces.DuplicateGroup) bool { return g.Count > 1 })
+}
+
+// FindNearDuplicates finds groups of duplicate ratings including the same rating change re-emitted within window
+func (r *stockRatingRepository) FindNearDuplicates(ctx context.Context, window time.Duration) ([]interfaces.DuplicateGroup, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	ratings := sortedRatings(r.store.liveRatings(), func(a, b *entities.StockRating) int {
+		return cmp.Or(compareIDs(a.CompanyID, b.CompanyID), compareIDs(a.BrokerageID, b.BrokerageID), a.EventTime.Compare(b.EventTime))
+	})
+
+	groups := entities.GroupDuplicateRatings(copyRatings(ratings), window)
+	results := make([]interfaces.DuplicateGroup, 0, len(groups))
+	for _, group := range groups {
+		first, last := group[0], group[len(group)-1]
+		result := interfaces.DuplicateGroup{
+			CompanyID:   first.CompanyID,
+			BrokerageID: first.BrokerageID,
+			EventTime:   first.EventTime,
+			RatingIDs:   make([]uuid.UUID, 0, len(group)),
+			Count:       len(group),
+			Near:        !last.EventTime.Equal(first.EventTime),
+		}
+		for _, rating := range group {
+			result.RatingIDs = append(result.RatingIDs, rating.ID)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// RemoveDuplicates soft-deletes duplicate ratings, keeping either the newest or the oldest (by created_at)
+func (r *stockRatingRepository) RemoveDuplicates(ctx context.Context, keepNewest bool) (int, error) {
+	removed := 0
+	err := r.store.transaction(func() error {
+		for _, group := range r.store.duplicateGroups() {
+			ratings := make([]*entities.StockRating, 0, len(group.RatingIDs))
+			for _, id := range group.RatingIDs {
+				ratings = append(ratings, r.store.findRating(id))
+			}
+			keep := slices.MinFunc(ratings, oldestCreatedFirst)
+			if keepNewest {
+				keep = slices.MaxFunc(ratings, oldestCreatedFirst)
+			}
+
+			for _, rating := range ratings {
+				if rating.ID != keep.ID && r.store.softDeleteRating(rating.ID) {
+					removed++
+				}
+			}
+		}
+		return nil
+	})
+	return removed, err
+}
+
+// ========================================
+// DATA QUALITY OPERATIONS
+// ========================================
+
+// GetRatingsWithMissingData retrieves ratings that have missing required data
+func (r *stockRatingRepository) GetRatingsWithMissingData(ctx context.Context) ([]*entities.StockRating, error) {
+	return r.query(func(sr *entities.StockRating) bool {
+		return sr.Action == "" || sr.CompanyID == uuid.Nil || sr.BrokerageID == uuid.Nil
+	}, func(a, b *entities.StockRating) int { return 0 }, -1), nil
+}
+
+// GetRatingsWithInvalidDates retrieves ratings with invalid or future dates
+func (r *stockRatingRepository) GetRatingsWithInvalidDates(ctx context.Context) ([]*entities.StockRating, error) {
+	return r.query(func(sr *entities.StockRating) bool { return invalidEventTime(sr.EventTime) },
+		func(a, b *entities.StockRating) int { return 0 }, -1), nil
+}
+
+// ValidateDataIntegrity performs comprehensive data integrity validation
+func (r *stockRatingRepository) ValidateDataIntegrity(ctx context.Context) (interfaces.DataIntegrityReport, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var report interfaces.DataIntegrityReport
+	for _, rating := range r.store.liveRatings() {
+		report.TotalRatings++
+		if rating.CompanyID == uuid.Nil {
+			report.MissingCompany++
+		}
+		if rating.BrokerageID == uuid.Nil {
+			report.MissingBrokerage++
+		}
+		if invalidEventTime(rating.EventTime) {
+			report.InvalidEventTime++
+		}
+		if rating.Action == "" {
+			report.EmptyAction++
+		}
+		// Como el LEFT JOIN del repositorio: las companies y brokerages eliminadas siguen contando como existentes
+		if !r.store.companyExists(rating.CompanyID) || !r.store.brokerageExists(rating.BrokerageID) {
+			report.OrphanedRatings++
+		}
+		if rating.IsProcessed {
+			report.ProcessedRatings++
+		} else {
+			report.UnprocessedRatings++
+		}
+	}
+	report.DuplicateCount = int64(len(r.store.duplicateGroups()))
+
+	return report, nil
+}
+
+// ========================================
+// ORPHAN DETECTION OPERATIONS
+// ========================================
+
+// GetOrphanedStockRatings finds the live ratings whose company or brokerage is missing or soft-deleted
+func (r *stockRatingRepository) GetOrphanedStockRatings(ctx context.Context) ([]*entities.StockRating, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return copyRatings(filter(r.store.liveRatings(), func(sr *entities.StockRating) bool {
+		return r.store.orphanReason(sr) != ""
+	})), nil
+}
+
+// GetOrphanedStockRatingsWithReasons finds the orphaned ratings with the reason, newest first
+func (r *stockRatingRepository) GetOrphanedStockRatingsWithReasons(ctx context.Context) ([]interfaces.OrphanedRatingResult, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var results []interfaces.OrphanedRatingResult
+	for _, rating := range sortedRatings(r.store.liveRatings(), newestFirst) {
+		if reason := r.store.orphanReason(rating); reason != "" {
+			results = append(results, interfaces.OrphanedRatingResult{
+				ID:          rating.ID,
+				CompanyID:   rating.CompanyID,
+				BrokerageID: rating.BrokerageID,
+				EventTime:   rating.EventTime,
+				Action:      rating.Action,
+				Reason:      reason,
+			})
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,209 @@
+// Package testdoubles implementa en memoria los repositorios de dominio para los tests unitarios de los servicios,
+// sin GORM ni mocks escritos en cada fichero de test. Los repositorios comparten un Store, igual que los de
+// implementation comparten la conexión, y reproducen la semántica de la base de datos de la que dependen los
+// servicios: soft delete, claves únicas (incluidas las filas eliminadas), foreign keys, bloqueo optimista,
+// hooks de normalización de las entidades, valores por defecto de las columnas y órdenes por defecto.
+//
+// Cada repositorio guarda copias: modificar una entidad devuelta no cambia el Store hasta guardarla.
+package testdoubles
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+)
+
+// Store is the in-memory database shared by the test double repositories
+type Store struct {
+	mu       sync.Mutex
+	data     tables
+	lastTime time.Time
+}
+
+// tables son las filas de cada tabla en orden de inserción. Las filas guardadas nunca se modifican en el sitio
+// (se sustituyen por una copia nueva), así una copia de los slices basta para deshacer una transacción
+type tables struct {
+	companies  []*entities.Company
+	aliases    []*entities.TickerAlias
+	brokerages []*entities.Brokerage
+	ratings    []*entities.StockRating
+	marketData []*entities.MarketData
+	tags       []*entities.Tag
+	tagged     []*entities.CompanyTag
+	notes      []*entities.Note
+}
+
+// NewStore creates an empty in-memory database
+func NewStore() *Store {
+	return &Store{}
+}
+
+// now devuelve la hora de los timestamps: estrictamente creciente y con la precisión de microsegundos de la base
+// de datos, así los órdenes por created_at son deterministas
+func (s *Store) now() time.Time {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	if !now.After(s.lastTime) {
+		now = s.lastTime.Add(time.Microsecond)
+	}
+	s.lastTime = now
+	return now
+}
+
+// transaction ejecuta fn con el Store bloqueado y restaura las tablas si devuelve un error
+func (s *Store) transaction(fn func() error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot := tables{
+		companies:  slices.Clone(s.data.companies),
+		aliases:    slices.Clone(s.data.aliases),
+		brokerages: slices.Clone(s.data.brokerages),
+		ratings:    slices.Clone(s.data.ratings),
+		marketData: slices.Clone(s.data.marketData),
+		tags:       slices.Clone(s.data.tags),
+		tagged:     slices.Clone(s.data.tagged),
+		notes:      slices.Clone(s.data.notes),
+	}
+	if err := fn(); err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+// runHooks ejecuta los hooks de escritura dentro de la transacción del store: sin base de datos reciben tx nil y
+// un error revierte el cambio igual que en el repositorio real
+func runHooks[T any](entity *T, hooks []interfaces.WriteHook[T]) error {
+	for _, hook := range hooks {
+		if err := hook(nil, entity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// softDeleted marca una fila como eliminada con la hora actual
+func (s *Store) softDeleted() gorm.DeletedAt {
+	return gorm.DeletedAt{Time: s.now(), Valid: true}
+}
+
+// companyExists comprueba la foreign key: las filas con soft delete siguen existiendo para la base de datos
+func (s *Store) companyExists(id uuid.UUID) bool {
+	return slices.ContainsFunc(s.data.companies, func(c *entities.Company) bool { return c.ID == id })
+}
+
+func (s *Store) brokerageExists(id uuid.UUID) bool {
+	return slices.ContainsFunc(s.data.brokerages, func(b *entities.Brokerage) bool { return b.ID == id })
+}
+
+// ========================================
+// QUERY HELPERS
+// ========================================
+
+// live devuelve las filas sin soft delete, como el scope por defecto de GORM
+func live[T any](rows []T, deletedAt func(T) gorm.DeletedAt) []T {
+	result := make([]T, 0, len(rows))
+	for _, row := range rows {
+		if !deletedAt(row).Valid {
+			result = append(result, row)
+		}
+	}
+	return result
+}
+
+// filter devuelve las filas que cumplen keep
+func filter[T any](rows []T, keep func(T) bool) []T {
+	result := make([]T, 0, len(rows))
+	for _, row := range rows {
+		if keep(row) {
+			result = append(result, row)
+		}
+	}
+	return result
+}
+
+// paginate aplica OFFSET y LIMIT como SQL: un límite negativo no limita
+func paginate[T any](rows []T, limit, offset int) []T {
+	if offset > 0 {
+		if offset >= len(rows) {
+			return rows[:0]
+		}
+		rows = rows[offset:]
+	}
+	if limit >= 0 && limit < len(rows) {
+		rows = rows[:limit]
+	}
+	return rows
+}
+
+// limitIfPositive traduce el `if limit > 0 { query.Limit(limit) }` de los repositorios
+func limitIfPositive(limit int) int {
+	if limit > 0 {
+		return limit
+	}
+	return -1
+}
+
+// comparator ordena dos filas por una columna
+type comparator[T any] func(a, b T) int
+
+// sortRows ordena por los campos pedidos (o los de por defecto) y desempata por id, como applySort
+func sortRows[T any](rows []T, sort []interfaces.SortField, columns map[string]comparator[T], id func(T) uuid.UUID, defaults ...interfaces.SortField) error {
+	if len(sort) == 0 {
+		sort = defaults
+	}
+	for _, field := range sort {
+		if _, ok := columns[field.Column]; !ok {
+			return fmt.Errorf("unknown sort column %q", field.Column)
+		}
+	}
+
+	slices.SortStableFunc(rows, func(a, b T) int {
+		for _, field := range sort {
+			result := columns[field.Column](a, b)
+			if field.Desc {
+				result = -result
+			}
+			if result != 0 {
+				return result
+			}
+		}
+		return compareIDs(id(a), id(b))
+	})
+	return nil
+}
+
+func compareIDs(a, b uuid.UUID) int {
+	return bytes.Compare(a[:], b[:])
+}
+
+// compareNullable ordena los NULL primero en orden ascendente, como CockroachDB
+func compareNullable[T cmp.Ordered](a, b *T) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	return cmp.Compare(*a, *b)
+}
+
+// cloneFloat copia un valor opcional para que la fila guardada no comparta memoria con el llamador
+func cloneFloat(value *float64) *float64 {
+	if value == nil {
+		return nil
+	}
+	copied := *value
+	return &copied
+}
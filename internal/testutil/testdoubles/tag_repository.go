@@ -0,0 +1,193 @@
+package testdoubles
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+)
+
+// tagRepository implements the TagRepository interface over the in-memory Store
+type tagRepository struct {
+	store *Store
+}
+
+// NewTagRepository creates a new in-memory tag repository
+func NewTagRepository(store *Store) interfaces.TagRepository {
+	return &tagRepository{store: store}
+}
+
+func copyTag(tag *entities.Tag) *entities.Tag {
+	copied := *tag
+	return &copied
+}
+
+// bySlug es el orden de las etiquetas: slug ASC
+func bySlug(a, b *entities.Tag) int { return strings.Compare(a.Slug, b.Slug) }
+
+// ========================================
+// STORE OPERATIONS (llamadas con el Store bloqueado)
+// ========================================
+
+func (s *Store) tagIndex(id uuid.UUID) int {
+	return slices.IndexFunc(s.data.tags, func(t *entities.Tag) bool { return t.ID == id })
+}
+
+func (s *Store) findTagBySlug(slug string) *entities.Tag {
+	for _, tag := range s.data.tags {
+		if tag.Slug == slug {
+			return tag
+		}
+	}
+	return nil
+}
+
+// hasTags comprueba que la company tiene todas las etiquetas (slugs); sin etiquetas no filtra
+func (s *Store) hasTags(companyID uuid.UUID, slugs []string) bool {
+	for _, slug := range slugs {
+		tag := s.findTagBySlug(slug)
+		if tag == nil || !slices.ContainsFunc(s.data.tagged, func(ct *entities.CompanyTag) bool {
+			return ct.CompanyID == companyID && ct.TagID == tag.ID
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+// ========================================
+// REPOSITORY OPERATIONS
+// ========================================
+
+// Create stores a new tag
+func (r *tagRepository) Create(ctx context.Context, tag *entities.Tag) error {
+	return r.store.transaction(func() error {
+		s := r.store
+		if err := tag.BeforeCreate(nil); err != nil {
+			return err
+		}
+		for _, existing := range s.data.tags {
+			if existing.ID == tag.ID || existing.Slug == tag.Slug {
+				return domainerrors.Duplicate(nil, "tag %s already exists", tag.Slug)
+			}
+		}
+
+		now := s.now()
+		tag.CreatedAt, tag.UpdatedAt = now, now
+		s.data.tags = append(s.data.tags, copyTag(tag))
+		return nil
+	})
+}
+
+// GetBySlug retrieves a tag by its slug
+func (r *tagRepository) GetBySlug(ctx context.Context, slug string) (*entities.Tag, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	tag := r.store.findTagBySlug(entities.NormalizeTagSlug(slug))
+	if tag == nil {
+		return nil, domainerrors.NotFound("tag %s not found", slug)
+	}
+	return copyTag(tag), nil
+}
+
+// GetAll retrieves every tag by slug with its number of active companies
+func (r *tagRepository) GetAll(ctx context.Context) ([]interfaces.TagUsage, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	tags := slices.Clone(r.store.data.tags)
+	slices.SortStableFunc(tags, bySlug)
+
+	usages := make([]interfaces.TagUsage, len(tags))
+	for i, tag := range tags {
+		usages[i].Tag = copyTag(tag)
+		for _, ct := range r.store.data.tagged {
+			if company := r.store.findCompany(ct.CompanyID); ct.TagID == tag.ID && company != nil && company.IsActive {
+				usages[i].Companies++
+			}
+		}
+	}
+	return usages, nil
+}
+
+// Update stores the name and description of a tag
+func (r *tagRepository) Update(ctx context.Context, tag *entities.Tag) error {
+	return r.store.transaction(func() error {
+		s := r.store
+		index := s.tagIndex(tag.ID)
+		if index < 0 {
+			return domainerrors.NotFound("tag %s not found", tag.Slug)
+		}
+		updated := copyTag(s.data.tags[index])
+		updated.Name, updated.Description = tag.Name, tag.Description
+		updated.UpdatedAt = s.now()
+		s.data.tags[index] = updated
+		return nil
+	})
+}
+
+// Delete removes a tag and its assignments
+func (r *tagRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.store.transaction(func() error {
+		s := r.store
+		if s.tagIndex(id) < 0 {
+			return domainerrors.NotFound("tag %s not found", id)
+		}
+		s.data.tags = filter(s.data.tags, func(t *entities.Tag) bool { return t.ID != id })
+		s.data.tagged = filter(s.data.tagged, func(ct *entities.CompanyTag) bool { return ct.TagID != id })
+		return nil
+	})
+}
+
+// GetByCompanyID retrieves the tags of a company by slug
+func (r *tagRepository) GetByCompanyID(ctx context.Context, companyID uuid.UUID) ([]*entities.Tag, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	tags := make([]*entities.Tag, 0)
+	for _, ct := range r.store.data.tagged {
+		if index := r.store.tagIndex(ct.TagID); ct.CompanyID == companyID && index >= 0 {
+			tags = append(tags, copyTag(r.store.data.tags[index]))
+		}
+	}
+	slices.SortStableFunc(tags, bySlug)
+	return tags, nil
+}
+
+// AddCompany tags a company; an existing assignment is kept as is
+func (r *tagRepository) AddCompany(ctx context.Context, tagID, companyID uuid.UUID) error {
+	return r.store.transaction(func() error {
+		s := r.store
+		if s.tagIndex(tagID) < 0 || !s.companyExists(companyID) {
+			return domainerrors.Conflict(nil, "failed to tag company %s with %s", companyID, tagID)
+		}
+		if slices.ContainsFunc(s.data.tagged, func(ct *entities.CompanyTag) bool {
+			return ct.CompanyID == companyID && ct.TagID == tagID
+		}) {
+			return nil
+		}
+		s.data.tagged = append(s.data.tagged, &entities.CompanyTag{CompanyID: companyID, TagID: tagID, CreatedAt: s.now()})
+		return nil
+	})
+}
+
+// RemoveCompany untags a company
+func (r *tagRepository) RemoveCompany(ctx context.Context, tagID, companyID uuid.UUID) error {
+	return r.store.transaction(func() error {
+		s := r.store
+		count := len(s.data.tagged)
+		s.data.tagged = filter(s.data.tagged, func(ct *entities.CompanyTag) bool {
+			return ct.CompanyID != companyID || ct.TagID != tagID
+		})
+		if len(s.data.tagged) == count {
+			return domainerrors.NotFound("company %s is not tagged with %s", companyID, tagID)
+		}
+		return nil
+	})
+}
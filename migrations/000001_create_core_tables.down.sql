@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS stock_ratings;
+DROP TABLE IF EXISTS brokerages;
+DROP TABLE IF EXISTS companies;
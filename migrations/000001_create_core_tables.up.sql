@@ -0,0 +1,70 @@
+-- Esquema base de las tablas de negocio. IF NOT EXISTS permite adoptar bases de datos
+-- creadas antes de introducir las migraciones versionadas.
+
+CREATE TABLE IF NOT EXISTS companies (
+    id                   UUID          NOT NULL PRIMARY KEY,
+    ticker               STRING        NOT NULL,
+    name                 STRING        NOT NULL,
+    created_at           TIMESTAMPTZ   NOT NULL DEFAULT now(),
+    updated_at           TIMESTAMPTZ   NOT NULL DEFAULT now(),
+    deleted_at           TIMESTAMPTZ   NULL,
+    is_active            BOOL          NOT NULL DEFAULT true,
+    sector               STRING        NULL,
+    market_cap           DECIMAL(15,2) NULL,
+    exchange             STRING        NULL,
+    logo                 STRING        NULL,
+    description          STRING        NULL,
+    industry             STRING        NULL,
+    country              STRING        NULL,
+    currency             STRING(3)     NULL,
+    website              STRING        NULL,
+    shares_outstanding   INT8          NULL,
+    pe_ratio             DECIMAL(10,4) NULL,
+    dividend_yield       DECIMAL(8,4)  NULL,
+    eps                  DECIMAL(10,4) NULL,
+    beta                 DECIMAL(8,4)  NULL,
+    week_52_high         DECIMAL(15,4) NULL,
+    week_52_low          DECIMAL(15,4) NULL,
+    employee_count       INT4          NULL,
+    ipo_date             DATE          NULL,
+    data_source          STRING        NULL DEFAULT 'manual',
+    profile_last_updated TIMESTAMPTZ   NULL,
+    CONSTRAINT uni_companies_ticker UNIQUE (ticker)
+);
+
+CREATE INDEX IF NOT EXISTS idx_companies_deleted_at ON companies (deleted_at);
+
+CREATE TABLE IF NOT EXISTS brokerages (
+    id         UUID        NOT NULL PRIMARY KEY,
+    name       STRING      NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    deleted_at TIMESTAMPTZ NULL,
+    is_active  BOOL        NOT NULL DEFAULT true,
+    website    STRING      NULL,
+    country    STRING      NULL,
+    CONSTRAINT uni_brokerages_name UNIQUE (name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_brokerages_deleted_at ON brokerages (deleted_at);
+
+CREATE TABLE IF NOT EXISTS stock_ratings (
+    id           UUID        NOT NULL PRIMARY KEY,
+    company_id   UUID        NOT NULL REFERENCES companies (id) ON DELETE CASCADE,
+    brokerage_id UUID        NOT NULL REFERENCES brokerages (id) ON DELETE CASCADE,
+    action       STRING      NOT NULL,
+    rating_from  STRING      NULL,
+    rating_to    STRING      NULL,
+    target_from  STRING      NULL,
+    target_to    STRING      NULL,
+    event_time   TIMESTAMPTZ NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    deleted_at   TIMESTAMPTZ NULL,
+    source       STRING      NOT NULL DEFAULT 'api',
+    raw_data     JSONB       NULL,
+    is_processed BOOL        NOT NULL DEFAULT false,
+    CONSTRAINT unique_rating_per_company_brokerage_time UNIQUE (company_id, brokerage_id, event_time)
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_ratings_deleted_at ON stock_ratings (deleted_at);
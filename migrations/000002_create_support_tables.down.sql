@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS population_rejects;
+DROP TABLE IF EXISTS sync_states;
+DROP TABLE IF EXISTS jobs;
@@ -0,0 +1,61 @@
+-- Tablas operativas: cola de jobs, estado de sincronización incremental y rechazos de población.
+-- Antes se creaban con AutoMigrate al arrancar.
+
+CREATE TABLE IF NOT EXISTS jobs (
+    id               UUID        NOT NULL PRIMARY KEY,
+    type             STRING      NOT NULL,
+    status           STRING      NOT NULL DEFAULT 'pending',
+    payload          STRING      NULL,
+    result           STRING      NULL,
+    error            STRING      NULL,
+    attempts         INT8        NOT NULL DEFAULT 0,
+    max_attempts     INT8        NOT NULL DEFAULT 3,
+    run_at           TIMESTAMPTZ NOT NULL,
+    locked_by        STRING      NULL,
+    locked_at        TIMESTAMPTZ NULL,
+    cancel_requested BOOL        NOT NULL DEFAULT false,
+    started_at       TIMESTAMPTZ NULL,
+    finished_at      TIMESTAMPTZ NULL,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    deleted_at       TIMESTAMPTZ NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_jobs_type ON jobs (type);
+CREATE INDEX IF NOT EXISTS idx_jobs_status ON jobs (status);
+CREATE INDEX IF NOT EXISTS idx_jobs_run_at ON jobs (run_at);
+CREATE INDEX IF NOT EXISTS idx_jobs_deleted_at ON jobs (deleted_at);
+
+CREATE TABLE IF NOT EXISTS sync_states (
+    source          STRING      NOT NULL PRIMARY KEY,
+    last_event_time TIMESTAMPTZ NOT NULL,
+    last_synced_at  TIMESTAMPTZ NOT NULL,
+    last_run_items  INT8        NOT NULL DEFAULT 0,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS population_rejects (
+    id              UUID        NOT NULL PRIMARY KEY,
+    source          STRING      NOT NULL,
+    stage           STRING      NOT NULL,
+    reason          STRING      NOT NULL,
+    status          STRING      NOT NULL DEFAULT 'pending',
+    ticker          STRING      NULL,
+    brokerage       STRING      NULL,
+    raw_payload     STRING      NOT NULL,
+    error           STRING      NULL,
+    fingerprint     STRING      NOT NULL,
+    occurrences     INT8        NOT NULL DEFAULT 1,
+    last_seen_at    TIMESTAMPTZ NOT NULL,
+    attempts        INT8        NOT NULL DEFAULT 0,
+    last_attempt_at TIMESTAMPTZ NULL,
+    resolved_at     TIMESTAMPTZ NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_population_rejects_source ON population_rejects (source);
+CREATE INDEX IF NOT EXISTS idx_population_rejects_stage ON population_rejects (stage);
+CREATE INDEX IF NOT EXISTS idx_population_rejects_status ON population_rejects (status);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_population_rejects_fingerprint ON population_rejects (fingerprint);
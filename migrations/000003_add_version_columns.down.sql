@@ -0,0 +1,3 @@
+ALTER TABLE stock_ratings DROP COLUMN IF EXISTS version;
+ALTER TABLE brokerages DROP COLUMN IF EXISTS version;
+ALTER TABLE companies DROP COLUMN IF EXISTS version;
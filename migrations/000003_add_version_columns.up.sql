@@ -0,0 +1,4 @@
+-- Bloqueo optimista: cada Update compara e incrementa la versión
+ALTER TABLE companies ADD COLUMN IF NOT EXISTS version INT8 NOT NULL DEFAULT 1;
+ALTER TABLE brokerages ADD COLUMN IF NOT EXISTS version INT8 NOT NULL DEFAULT 1;
+ALTER TABLE stock_ratings ADD COLUMN IF NOT EXISTS version INT8 NOT NULL DEFAULT 1;
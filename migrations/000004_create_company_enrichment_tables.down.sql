@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS company_enrichment_conflicts;
+DROP TABLE IF EXISTS company_profiles;
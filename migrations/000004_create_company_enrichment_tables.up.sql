@@ -0,0 +1,58 @@
+-- Perfiles de proveedores externos guardados aparte de companies y conflictos detectados
+-- por el pipeline de enriquecimiento (companies <- company_profiles).
+
+CREATE TABLE IF NOT EXISTS company_profiles (
+    id                 UUID          NOT NULL PRIMARY KEY,
+    symbol             STRING        NOT NULL,
+    name               STRING        NOT NULL,
+    description        STRING        NULL,
+    industry           STRING        NULL,
+    sector             STRING        NULL,
+    country            STRING        NULL,
+    currency           STRING(3)     NULL,
+    exchange           STRING        NULL,
+    market_cap         INT8          NULL,
+    shares_outstanding INT8          NULL,
+    pe_ratio           DECIMAL(10,4) NULL,
+    peg_ratio          DECIMAL(10,4) NULL,
+    price_to_book      DECIMAL(10,4) NULL,
+    dividend_yield     DECIMAL(8,4)  NULL,
+    eps                DECIMAL(10,4) NULL,
+    beta               DECIMAL(8,4)  NULL,
+    week_52_high       DECIMAL(15,4) NULL,
+    week_52_low        DECIMAL(15,4) NULL,
+    website            STRING        NULL,
+    logo               STRING        NULL,
+    ipo_date           DATE          NULL,
+    employee_count     INT4          NULL,
+    data_source        STRING        NULL DEFAULT 'finnhub',
+    last_updated       TIMESTAMPTZ   NOT NULL,
+    created_at         TIMESTAMPTZ   NOT NULL DEFAULT now(),
+    updated_at         TIMESTAMPTZ   NOT NULL DEFAULT now(),
+    deleted_at         TIMESTAMPTZ   NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_company_profiles_symbol ON company_profiles (symbol);
+CREATE INDEX IF NOT EXISTS idx_company_profiles_deleted_at ON company_profiles (deleted_at);
+
+CREATE TABLE IF NOT EXISTS company_enrichment_conflicts (
+    id            UUID        NOT NULL PRIMARY KEY,
+    company_id    UUID        NOT NULL,
+    ticker        STRING      NOT NULL,
+    field         STRING      NOT NULL,
+    status        STRING      NOT NULL DEFAULT 'pending',
+    company_value STRING      NOT NULL,
+    profile_value STRING      NOT NULL,
+    source        STRING      NOT NULL,
+    fingerprint   STRING      NOT NULL,
+    occurrences   INT8        NOT NULL DEFAULT 1,
+    last_seen_at  TIMESTAMPTZ NOT NULL,
+    resolved_at   TIMESTAMPTZ NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_company_enrichment_conflicts_company_id ON company_enrichment_conflicts (company_id);
+CREATE INDEX IF NOT EXISTS idx_company_enrichment_conflicts_ticker ON company_enrichment_conflicts (ticker);
+CREATE INDEX IF NOT EXISTS idx_company_enrichment_conflicts_status ON company_enrichment_conflicts (status);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_company_enrichment_conflicts_fingerprint ON company_enrichment_conflicts (fingerprint);
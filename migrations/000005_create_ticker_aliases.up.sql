@@ -0,0 +1,13 @@
+-- Tickers anteriores de una company (FB -> META): las búsquedas por ticker los resuelven
+-- para que los ratings publicados con el símbolo antiguo sigan asociados a la misma company.
+
+CREATE TABLE IF NOT EXISTS ticker_aliases (
+    id         UUID        NOT NULL PRIMARY KEY,
+    ticker     STRING      NOT NULL,
+    company_id UUID        NOT NULL REFERENCES companies (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_ticker_aliases_ticker ON ticker_aliases (ticker);
+CREATE INDEX IF NOT EXISTS idx_ticker_aliases_company_id ON ticker_aliases (company_id);
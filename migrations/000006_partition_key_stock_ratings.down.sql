@@ -0,0 +1,2 @@
+ALTER TABLE stock_ratings PARTITION BY NOTHING;
+ALTER TABLE stock_ratings ALTER PRIMARY KEY USING COLUMNS (id);
@@ -0,0 +1,4 @@
+-- Particionado mensual por event_time: CockroachDB solo particiona por un prefijo de la primary key,
+-- así que event_time pasa a encabezarla. El id conserva un índice único (stock_ratings_id_key).
+-- Las particiones se crean con POST /api/v1/admin/db/partitions, porque dependen de la fecha actual.
+ALTER TABLE stock_ratings ALTER PRIMARY KEY USING COLUMNS (event_time, id);
@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS market_data PARTITION BY NOTHING;
+ALTER TABLE IF EXISTS market_data ALTER PRIMARY KEY USING COLUMNS (id);
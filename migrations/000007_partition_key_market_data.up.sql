@@ -0,0 +1,3 @@
+-- Igual que stock_ratings, particionado mensual por market_timestamp. market_data puede no existir
+-- en bases creadas sin el módulo de market data.
+ALTER TABLE IF EXISTS market_data ALTER PRIMARY KEY USING COLUMNS (market_timestamp, id);
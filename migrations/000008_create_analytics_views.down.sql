@@ -0,0 +1,3 @@
+DROP MATERIALIZED VIEW IF EXISTS analytics_sector_distribution;
+DROP MATERIALIZED VIEW IF EXISTS analytics_action_daily_counts;
+DROP MATERIALIZED VIEW IF EXISTS analytics_company_daily_ratings;
@@ -0,0 +1,29 @@
+-- Agregados de las analíticas precalculados en vistas materializadas. Se refrescan con el job
+-- analytics_refresh (WORKER_ANALYTICS_REFRESH_INTERVAL) o con POST /api/v1/admin/analytics/refresh.
+-- El día se calcula en UTC para que la vista no dependa de la zona horaria de la sesión.
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS analytics_company_daily_ratings AS
+SELECT sr.company_id,
+       c.name                               AS company_name,
+       c.ticker,
+       (sr.event_time AT TIME ZONE 'UTC')::DATE AS day,
+       count(*)                             AS rating_count
+FROM stock_ratings sr
+JOIN companies c ON c.id = sr.company_id
+WHERE sr.deleted_at IS NULL AND c.deleted_at IS NULL
+GROUP BY sr.company_id, c.name, c.ticker, day;
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS analytics_action_daily_counts AS
+SELECT action,
+       (event_time AT TIME ZONE 'UTC')::DATE AS day,
+       count(*)                              AS rating_count
+FROM stock_ratings
+WHERE deleted_at IS NULL
+GROUP BY action, day;
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS analytics_sector_distribution AS
+SELECT sector,
+       count(*) AS company_count
+FROM companies
+WHERE deleted_at IS NULL AND is_active = true AND sector IS NOT NULL AND sector != ''
+GROUP BY sector;
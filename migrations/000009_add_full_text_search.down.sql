@@ -0,0 +1,5 @@
+-- news_items se conserva: puede contener datos anteriores a esta migración
+DROP INDEX IF EXISTS news_items@idx_news_items_search_vector;
+ALTER TABLE news_items DROP COLUMN IF EXISTS search_vector;
+DROP INDEX IF EXISTS companies@idx_companies_search_vector;
+ALTER TABLE companies DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,31 @@
+-- Búsqueda de texto completo: columnas tsvector calculadas e índices invertidos (GIN).
+-- news_items no tenía migración propia; se crea aquí para bases nuevas.
+
+CREATE TABLE IF NOT EXISTS news_items (
+    id              UUID         NOT NULL PRIMARY KEY,
+    symbol          STRING       NOT NULL,
+    title           STRING       NOT NULL,
+    summary         STRING       NULL,
+    url             STRING       NOT NULL,
+    image_url       STRING       NULL,
+    source          STRING       NOT NULL,
+    category        STRING       NULL,
+    language        STRING(2)    NULL DEFAULT 'en',
+    sentiment_score DECIMAL(4,3) NULL,
+    sentiment_label STRING       NULL,
+    published_at    TIMESTAMPTZ  NOT NULL,
+    created_at      TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    deleted_at      TIMESTAMPTZ  NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_news_items_symbol ON news_items (symbol);
+CREATE INDEX IF NOT EXISTS idx_news_items_deleted_at ON news_items (deleted_at);
+
+ALTER TABLE companies ADD COLUMN IF NOT EXISTS search_vector TSVECTOR
+    AS (to_tsvector('english', ticker || ' ' || name)) STORED;
+CREATE INDEX IF NOT EXISTS idx_companies_search_vector ON companies USING GIN (search_vector);
+
+ALTER TABLE news_items ADD COLUMN IF NOT EXISTS search_vector TSVECTOR
+    AS (to_tsvector('english', title || ' ' || COALESCE(summary, ''))) STORED;
+CREATE INDEX IF NOT EXISTS idx_news_items_search_vector ON news_items USING GIN (search_vector);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS companies@idx_companies_name_trgm;
+DROP INDEX IF EXISTS companies@idx_companies_ticker_suggest;
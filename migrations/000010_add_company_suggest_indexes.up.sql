@@ -0,0 +1,7 @@
+-- Autocompletado de companies (GET /api/v1/companies/suggest).
+-- Prefijo de ticker: índice parcial que incluye name para responder sin leer la tabla.
+CREATE INDEX IF NOT EXISTS idx_companies_ticker_suggest ON companies (ticker)
+    STORING (name, is_active) WHERE deleted_at IS NULL;
+
+-- Prefijo de nombre (ILIKE 'app%'): índice de trigramas
+CREATE INDEX IF NOT EXISTS idx_companies_name_trgm ON companies USING GIN (name gin_trgm_ops);
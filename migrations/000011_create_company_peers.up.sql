@@ -0,0 +1,15 @@
+-- Grafo de competidores por company (GET /api/v1/companies/{ticker}/peers). Se rellena con la API de peers
+-- de Finnhub o, si no hay datos, con companies del mismo sector y capitalización parecida.
+
+CREATE TABLE IF NOT EXISTS company_peers (
+    id              UUID        NOT NULL PRIMARY KEY,
+    company_id      UUID        NOT NULL REFERENCES companies (id) ON DELETE CASCADE,
+    peer_company_id UUID        NOT NULL REFERENCES companies (id) ON DELETE CASCADE,
+    source          STRING      NOT NULL,
+    rank            INT8        NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_company_peers_pair ON company_peers (company_id, peer_company_id);
+CREATE INDEX IF NOT EXISTS idx_company_peers_peer_company_id ON company_peers (peer_company_id);
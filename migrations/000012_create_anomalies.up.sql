@@ -0,0 +1,17 @@
+-- Actividad anómala detectada por el job anomaly_detection (GET /api/v1/analysis/anomalies): días con un pico de
+-- ratings o de volumen negociado frente a la media de las semanas anteriores. Una fila por company, tipo y día.
+
+CREATE TABLE IF NOT EXISTS anomalies (
+    id          UUID          NOT NULL PRIMARY KEY,
+    company_id  UUID          NOT NULL REFERENCES companies (id) ON DELETE CASCADE,
+    type        STRING        NOT NULL,
+    date        DATE          NOT NULL,
+    value       DECIMAL(20,4) NOT NULL,
+    baseline    DECIMAL(20,4) NOT NULL,
+    ratio       DECIMAL(12,4) NOT NULL,
+    created_at  TIMESTAMPTZ   NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ   NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_anomalies_company_type_date ON anomalies (company_id, type, date);
+CREATE INDEX IF NOT EXISTS idx_anomalies_date ON anomalies (date DESC);
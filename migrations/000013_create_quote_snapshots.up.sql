@@ -0,0 +1,18 @@
+-- Histórico intradía de cotizaciones (GET /api/v1/market-data/{symbol}/intraday). Cada refresco de la cotización
+-- guarda una fila y se podan las más antiguas para que cada símbolo funcione como un buffer circular.
+
+CREATE TABLE IF NOT EXISTS quote_snapshots (
+    id                UUID          NOT NULL PRIMARY KEY,
+    company_id        UUID          NOT NULL REFERENCES companies (id) ON DELETE CASCADE,
+    symbol            STRING        NOT NULL,
+    price             DECIMAL(15,4) NOT NULL,
+    price_change      DECIMAL(15,4),
+    price_change_perc DECIMAL(8,4),
+    volume            INT8,
+    market_timestamp  TIMESTAMPTZ   NOT NULL,
+    created_at        TIMESTAMPTZ   NOT NULL DEFAULT now()
+);
+
+-- Un refresco con el mismo timestamp de mercado (mercado cerrado) no duplica la fila
+CREATE UNIQUE INDEX IF NOT EXISTS idx_quote_snapshots_symbol_timestamp ON quote_snapshots (symbol, market_timestamp);
+CREATE INDEX IF NOT EXISTS idx_quote_snapshots_company_id ON quote_snapshots (company_id);
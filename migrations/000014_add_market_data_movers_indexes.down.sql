@@ -0,0 +1,2 @@
+-- La tabla market_data no se elimina: puede existir desde antes de la migración 000014
+DROP INDEX IF EXISTS market_data@idx_market_data_symbol_timestamp;
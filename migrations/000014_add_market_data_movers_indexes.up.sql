@@ -0,0 +1,31 @@
+-- Top movers (GET /api/v1/market-data/movers): la cotización más reciente de cada símbolo se resuelve con el
+-- índice (symbol, market_timestamp DESC), que guarda las columnas por las que se ordena.
+-- market_data no tenía migración propia; se crea aquí para bases nuevas con la clave de la migración 000007.
+
+CREATE TABLE IF NOT EXISTS market_data (
+    id                UUID          NOT NULL,
+    company_id        UUID          NOT NULL REFERENCES companies (id) ON DELETE CASCADE,
+    symbol            STRING        NOT NULL,
+    current_price     DECIMAL(15,4) NOT NULL,
+    open_price        DECIMAL(15,4) NULL,
+    high_price        DECIMAL(15,4) NULL,
+    low_price         DECIMAL(15,4) NULL,
+    previous_close    DECIMAL(15,4) NULL,
+    price_change      DECIMAL(15,4) NULL,
+    price_change_perc DECIMAL(8,4)  NULL,
+    volume            INT8          NULL,
+    avg_volume        INT8          NULL,
+    market_cap        INT8          NULL,
+    is_market_open    BOOL          NULL DEFAULT false,
+    currency          STRING(3)     NULL DEFAULT 'USD',
+    exchange          STRING(10)    NULL,
+    market_timestamp  TIMESTAMPTZ   NOT NULL,
+    created_at        TIMESTAMPTZ   NOT NULL DEFAULT now(),
+    updated_at        TIMESTAMPTZ   NOT NULL DEFAULT now(),
+    deleted_at        TIMESTAMPTZ   NULL,
+    PRIMARY KEY (market_timestamp, id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_market_data_deleted_at ON market_data (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_market_data_symbol_timestamp ON market_data (symbol, market_timestamp DESC)
+    STORING (price_change_perc, volume, deleted_at);
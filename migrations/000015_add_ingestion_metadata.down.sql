@@ -0,0 +1,16 @@
+ALTER TABLE companies DROP COLUMN IF EXISTS profile_request_id;
+
+-- La tabla basic_financials no se elimina: puede existir desde antes de la migración 000015
+ALTER TABLE basic_financials DROP COLUMN IF EXISTS provider_request_id;
+ALTER TABLE basic_financials DROP COLUMN IF EXISTS fetched_at;
+
+ALTER TABLE company_profiles DROP COLUMN IF EXISTS provider_request_id;
+ALTER TABLE company_profiles DROP COLUMN IF EXISTS fetched_at;
+
+ALTER TABLE news_items DROP COLUMN IF EXISTS provider_request_id;
+ALTER TABLE news_items DROP COLUMN IF EXISTS fetched_at;
+ALTER TABLE news_items DROP COLUMN IF EXISTS data_source;
+
+ALTER TABLE market_data DROP COLUMN IF EXISTS provider_request_id;
+ALTER TABLE market_data DROP COLUMN IF EXISTS fetched_at;
+ALTER TABLE market_data DROP COLUMN IF EXISTS data_source;
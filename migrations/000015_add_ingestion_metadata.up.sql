@@ -0,0 +1,56 @@
+-- Procedencia de los datos externos: proveedor, momento de la respuesta e identificador de la petición en el
+-- proveedor. basic_financials no tenía migración propia; se crea aquí para bases nuevas antes de alterarla.
+
+CREATE TABLE IF NOT EXISTS basic_financials (
+    id                   UUID          NOT NULL PRIMARY KEY,
+    symbol               STRING        NOT NULL,
+    market_cap           DECIMAL(20,2) NULL,
+    pe_ratio             DECIMAL(10,4) NULL,
+    peg_ratio            DECIMAL(10,4) NULL,
+    price_to_sales       DECIMAL(10,4) NULL,
+    price_to_book        DECIMAL(10,4) NULL,
+    price_to_cash_flow   DECIMAL(10,4) NULL,
+    roe                  DECIMAL(8,4)  NULL,
+    roa                  DECIMAL(8,4)  NULL,
+    roi                  DECIMAL(8,4)  NULL,
+    gross_margin         DECIMAL(8,4)  NULL,
+    operating_margin     DECIMAL(8,4)  NULL,
+    net_margin           DECIMAL(8,4)  NULL,
+    revenue_growth       DECIMAL(8,4)  NULL,
+    earnings_growth      DECIMAL(8,4)  NULL,
+    dividend_growth      DECIMAL(8,4)  NULL,
+    debt_to_equity       DECIMAL(8,4)  NULL,
+    current_ratio        DECIMAL(8,4)  NULL,
+    quick_ratio          DECIMAL(8,4)  NULL,
+    eps                  DECIMAL(10,4) NULL,
+    book_value_per_share DECIMAL(10,4) NULL,
+    cash_per_share       DECIMAL(10,4) NULL,
+    dividend_per_share   DECIMAL(10,4) NULL,
+    period               STRING        NULL,
+    fiscal_year          INT4          NULL,
+    fiscal_quarter       INT4          NULL,
+    data_source          STRING        NULL DEFAULT 'finnhub',
+    last_updated         TIMESTAMPTZ   NOT NULL,
+    created_at           TIMESTAMPTZ   NOT NULL DEFAULT now(),
+    updated_at           TIMESTAMPTZ   NOT NULL DEFAULT now(),
+    deleted_at           TIMESTAMPTZ   NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_basic_financials_symbol ON basic_financials (symbol);
+CREATE INDEX IF NOT EXISTS idx_basic_financials_deleted_at ON basic_financials (deleted_at);
+
+ALTER TABLE market_data ADD COLUMN IF NOT EXISTS data_source STRING NULL DEFAULT 'finnhub';
+ALTER TABLE market_data ADD COLUMN IF NOT EXISTS fetched_at TIMESTAMPTZ NULL;
+ALTER TABLE market_data ADD COLUMN IF NOT EXISTS provider_request_id STRING NULL;
+
+ALTER TABLE news_items ADD COLUMN IF NOT EXISTS data_source STRING NULL DEFAULT 'finnhub';
+ALTER TABLE news_items ADD COLUMN IF NOT EXISTS fetched_at TIMESTAMPTZ NULL;
+ALTER TABLE news_items ADD COLUMN IF NOT EXISTS provider_request_id STRING NULL;
+
+ALTER TABLE company_profiles ADD COLUMN IF NOT EXISTS fetched_at TIMESTAMPTZ NULL;
+ALTER TABLE company_profiles ADD COLUMN IF NOT EXISTS provider_request_id STRING NULL;
+
+ALTER TABLE basic_financials ADD COLUMN IF NOT EXISTS fetched_at TIMESTAMPTZ NULL;
+ALTER TABLE basic_financials ADD COLUMN IF NOT EXISTS provider_request_id STRING NULL;
+
+ALTER TABLE companies ADD COLUMN IF NOT EXISTS profile_request_id STRING NULL;
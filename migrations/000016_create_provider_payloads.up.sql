@@ -0,0 +1,19 @@
+-- Archivo de respuestas crudas de los proveedores externos (Finnhub, Alpha Vantage), comprimidas con gzip.
+-- Las filas caducan solas con el TTL por fila de CockroachDB sobre expires_at.
+
+CREATE TABLE IF NOT EXISTS provider_payloads (
+    id         UUID        NOT NULL PRIMARY KEY,
+    provider   STRING      NOT NULL,
+    endpoint   STRING      NOT NULL,
+    symbol     STRING      NULL,
+    params     STRING      NULL,
+    request_id STRING      NULL,
+    payload    BYTES       NOT NULL,
+    size       INT8        NOT NULL,
+    fetched_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+) WITH (ttl_expiration_expression = 'expires_at', ttl_job_cron = '@hourly');
+
+CREATE INDEX IF NOT EXISTS idx_provider_payloads_symbol_fetched_at ON provider_payloads (symbol, fetched_at DESC);
+CREATE INDEX IF NOT EXISTS idx_provider_payloads_fetched_at ON provider_payloads (fetched_at DESC);
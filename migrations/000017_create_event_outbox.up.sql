@@ -0,0 +1,20 @@
+-- Outbox de eventos de dominio: se escribe en la misma transacción que el cambio que los produce y un relay
+-- los publica en el bus, así que un broker caído retrasa los eventos pero no los pierde.
+
+CREATE TABLE IF NOT EXISTS event_outbox (
+    id              UUID        NOT NULL PRIMARY KEY, -- ID del evento (los suscriptores deduplican por él)
+    event_type      STRING      NOT NULL,
+    payload         JSONB       NOT NULL,
+    occurred_at     TIMESTAMPTZ NOT NULL,
+    attempts        INT8        NOT NULL DEFAULT 0,
+    last_error      STRING      NULL,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    published_at    TIMESTAMPTZ NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Eventos pendientes en orden de escritura (el relay solo lee estos)
+CREATE INDEX IF NOT EXISTS idx_event_outbox_pending ON event_outbox (created_at, id) WHERE published_at IS NULL;
+
+-- Limpieza de los eventos ya publicados
+CREATE INDEX IF NOT EXISTS idx_event_outbox_published_at ON event_outbox (published_at) WHERE published_at IS NOT NULL;
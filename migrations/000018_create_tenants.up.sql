@@ -0,0 +1,29 @@
+-- Multi-tenancy: cada cliente de un despliegue compartido es un tenant y los recursos propios de los usuarios
+-- llevan tenant_id. Los datos de mercado (companies, ratings, quotes...) siguen siendo compartidos.
+
+CREATE TABLE IF NOT EXISTS tenants (
+    id         UUID        NOT NULL PRIMARY KEY,
+    name       STRING      NOT NULL,
+    slug       STRING      NOT NULL,
+    active     BOOL        NOT NULL DEFAULT true,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT uq_tenants_slug UNIQUE (slug)
+);
+
+-- API keys de los tenants: solo se guarda el hash SHA-256, la clave en claro se muestra una única vez
+CREATE TABLE IF NOT EXISTS api_keys (
+    id           UUID        NOT NULL PRIMARY KEY,
+    tenant_id    UUID        NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    name         STRING      NOT NULL,
+    prefix       STRING      NOT NULL, -- Primeros caracteres de la clave, para reconocerla en los listados
+    key_hash     STRING      NOT NULL,
+    last_used_at TIMESTAMPTZ NULL,
+    expires_at   TIMESTAMPTZ NULL,
+    revoked_at   TIMESTAMPTZ NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT uq_api_keys_key_hash UNIQUE (key_hash)
+);
+
+-- Todas las consultas de recursos de un tenant filtran por tenant_id
+CREATE INDEX IF NOT EXISTS idx_api_keys_tenant_id ON api_keys (tenant_id, created_at);
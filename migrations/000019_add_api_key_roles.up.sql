@@ -0,0 +1,3 @@
+-- RBAC: cada API key tiene un rol (viewer, editor o admin). Las claves existentes quedan como viewer
+
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS role STRING NOT NULL DEFAULT 'viewer';
@@ -0,0 +1,17 @@
+-- Uso de la API por credencial y hora: los contadores horarios de Redis se vuelcan periódicamente aquí.
+-- El volcado sobrescribe la hora completa, así repetirlo no duplica el uso
+
+CREATE TABLE IF NOT EXISTS api_usage_hourly (
+    tenant_id      UUID        NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    credential     STRING      NOT NULL, -- api_key:<id> o jwt:<sub>
+    hour           TIMESTAMPTZ NOT NULL,
+    requests       INT8        NOT NULL DEFAULT 0,
+    errors         INT8        NOT NULL DEFAULT 0,
+    provider_calls INT8        NOT NULL DEFAULT 0,
+    cache_hits     INT8        NOT NULL DEFAULT 0,
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (tenant_id, credential, hour)
+);
+
+-- El roll-up de administración recorre todos los tenants por rango de horas
+CREATE INDEX IF NOT EXISTS idx_api_usage_hourly_hour ON api_usage_hourly (hour);
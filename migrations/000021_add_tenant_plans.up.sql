@@ -0,0 +1,3 @@
+-- Planes: cada tenant tiene un plan (free o pro) con sus cuotas diarias. Los tenants existentes quedan en free
+
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS plan STRING NOT NULL DEFAULT 'free';
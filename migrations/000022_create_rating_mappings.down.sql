@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS stock_ratings@idx_stock_ratings_company_normalized;
+ALTER TABLE stock_ratings DROP COLUMN IF EXISTS normalized_rating_to;
+ALTER TABLE stock_ratings DROP COLUMN IF EXISTS normalized_rating_from;
+DROP TABLE IF EXISTS rating_mappings;
@@ -0,0 +1,61 @@
+-- Taxonomía de ratings: las variantes de texto libre de los brokerages ("Overweight", "Outperform"...) se
+-- normalizan a una escala canónica (strong_buy, buy, hold, sell, strong_sell) guardada junto al rating original.
+-- La tabla de mapeo la editan los administradores; las variantes sin mapeo quedan sin normalizar
+
+CREATE TABLE IF NOT EXISTS rating_mappings (
+    raw_rating STRING      NOT NULL PRIMARY KEY, -- En minúsculas y con los espacios colapsados
+    normalized STRING      NOT NULL CHECK (normalized IN ('strong_buy', 'buy', 'hold', 'sell', 'strong_sell')),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+INSERT INTO rating_mappings (raw_rating, normalized) VALUES
+    ('strong buy', 'strong_buy'),
+    ('strong-buy', 'strong_buy'),
+    ('conviction buy', 'strong_buy'),
+    ('top pick', 'strong_buy'),
+    ('buy', 'buy'),
+    ('outperform', 'buy'),
+    ('overweight', 'buy'),
+    ('market outperform', 'buy'),
+    ('sector outperform', 'buy'),
+    ('positive', 'buy'),
+    ('accumulate', 'buy'),
+    ('add', 'buy'),
+    ('speculative buy', 'buy'),
+    ('moderate buy', 'buy'),
+    ('hold', 'hold'),
+    ('neutral', 'hold'),
+    ('equal weight', 'hold'),
+    ('equal-weight', 'hold'),
+    ('market perform', 'hold'),
+    ('sector perform', 'hold'),
+    ('sector weight', 'hold'),
+    ('in-line', 'hold'),
+    ('peer perform', 'hold'),
+    ('mixed', 'hold'),
+    ('sell', 'sell'),
+    ('underperform', 'sell'),
+    ('underweight', 'sell'),
+    ('market underperform', 'sell'),
+    ('sector underperform', 'sell'),
+    ('negative', 'sell'),
+    ('reduce', 'sell'),
+    ('moderate sell', 'sell'),
+    ('strong sell', 'strong_sell'),
+    ('strong-sell', 'strong_sell')
+ON CONFLICT (raw_rating) DO NOTHING;
+
+ALTER TABLE stock_ratings ADD COLUMN IF NOT EXISTS normalized_rating_from STRING NULL;
+ALTER TABLE stock_ratings ADD COLUMN IF NOT EXISTS normalized_rating_to STRING NULL;
+
+-- Los ratings ya guardados se normalizan con los mapeos iniciales
+UPDATE stock_ratings SET normalized_rating_from = m.normalized
+FROM rating_mappings m
+WHERE m.raw_rating = regexp_replace(lower(trim(stock_ratings.rating_from)), '\s+', ' ', 'g');
+
+UPDATE stock_ratings SET normalized_rating_to = m.normalized
+FROM rating_mappings m
+WHERE m.raw_rating = regexp_replace(lower(trim(stock_ratings.rating_to)), '\s+', ' ', 'g');
+
+-- El consenso agrupa el último rating normalizado de cada brokerage por company
+CREATE INDEX IF NOT EXISTS idx_stock_ratings_company_normalized ON stock_ratings (company_id, normalized_rating_to);
@@ -0,0 +1,12 @@
+DROP MATERIALIZED VIEW IF EXISTS analytics_action_daily_counts;
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS analytics_action_daily_counts AS
+SELECT action,
+       (event_time AT TIME ZONE 'UTC')::DATE AS day,
+       count(*)                              AS rating_count
+FROM stock_ratings
+WHERE deleted_at IS NULL
+GROUP BY action, day;
+
+DROP INDEX IF EXISTS stock_ratings@idx_stock_ratings_action_type;
+ALTER TABLE stock_ratings DROP COLUMN IF EXISTS action_type;
@@ -0,0 +1,19 @@
+-- Tipo de acción estructurado: el texto libre de la acción ("upgraded by", "target raised by"...) se clasifica
+-- al guardar (upgrade, downgrade, reiterate, initiate, target_raised, target_lowered, target_set u other).
+-- Los ratings existentes se rellenan en la migración 000035; el job action_type_backfill usa el mismo parser que la ingesta
+
+ALTER TABLE stock_ratings ADD COLUMN IF NOT EXISTS action_type STRING NULL;
+
+-- GetUpgrades, GetDowngrades y las analíticas filtran por igualdad sobre el tipo y ordenan por fecha
+CREATE INDEX IF NOT EXISTS idx_stock_ratings_action_type ON stock_ratings (action_type, event_time DESC);
+
+-- La vista de la distribución de acciones agrupa por el tipo en lugar del texto libre
+DROP MATERIALIZED VIEW IF EXISTS analytics_action_daily_counts;
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS analytics_action_daily_counts AS
+SELECT action_type,
+       (event_time AT TIME ZONE 'UTC')::DATE AS day,
+       count(*)                              AS rating_count
+FROM stock_ratings
+WHERE deleted_at IS NULL AND action_type IS NOT NULL
+GROUP BY action_type, day;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS stock_ratings@idx_stock_ratings_target_to_value;
+ALTER TABLE stock_ratings DROP COLUMN IF EXISTS target_currency;
+ALTER TABLE stock_ratings DROP COLUMN IF EXISTS target_to_value;
+ALTER TABLE stock_ratings DROP COLUMN IF EXISTS target_from_value;
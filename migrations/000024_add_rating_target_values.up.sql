@@ -0,0 +1,12 @@
+-- Precios objetivo parseados: el texto de target_from/target_to ("$150.00", "€12.5", "250p") se guarda también
+-- como valor numérico en la unidad principal de la moneda y su código ISO, para filtrar y comparar por moneda.
+-- target_currency NULL indica que el rating no se ha parseado; los existentes se rellenan con
+-- `stock-info-app ratings backfill-targets`
+
+ALTER TABLE stock_ratings ADD COLUMN IF NOT EXISTS target_from_value DECIMAL(18,4) NULL;
+ALTER TABLE stock_ratings ADD COLUMN IF NOT EXISTS target_to_value DECIMAL(18,4) NULL;
+ALTER TABLE stock_ratings ADD COLUMN IF NOT EXISTS target_currency STRING(3) NULL;
+
+-- Los filtros numéricos siempre fijan la moneda
+CREATE INDEX IF NOT EXISTS idx_stock_ratings_target_to_value ON stock_ratings (target_currency, target_to_value)
+    WHERE target_to_value IS NOT NULL;
@@ -0,0 +1,25 @@
+-- Resumen de cada validación de integridad programada (job integrity_check) para seguir la evolución de la
+-- calidad de datos en GET /api/v1/admin/integrity/history
+
+CREATE TABLE IF NOT EXISTS integrity_snapshots (
+    id                  UUID        NOT NULL PRIMARY KEY,
+    status              STRING      NOT NULL, -- GOOD, WARNING o CRITICAL
+    total_issues        INT8        NOT NULL DEFAULT 0,
+    critical_issues     INT8        NOT NULL DEFAULT 0,
+    warning_issues      INT8        NOT NULL DEFAULT 0,
+    orphans             INT8        NOT NULL DEFAULT 0,
+    inconsistencies     INT8        NOT NULL DEFAULT 0,
+    duplicates          INT8        NOT NULL DEFAULT 0,
+    business_violations INT8        NOT NULL DEFAULT 0,
+    total_ratings       INT8        NOT NULL DEFAULT 0,
+    missing_company     INT8        NOT NULL DEFAULT 0,
+    missing_brokerage   INT8        NOT NULL DEFAULT 0,
+    invalid_event_time  INT8        NOT NULL DEFAULT 0,
+    empty_action        INT8        NOT NULL DEFAULT 0,
+    duplicate_ratings   INT8        NOT NULL DEFAULT 0,
+    orphaned_ratings    INT8        NOT NULL DEFAULT 0,
+    duration_ms         INT8        NOT NULL DEFAULT 0,
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_integrity_snapshots_created_at ON integrity_snapshots (created_at DESC);
@@ -0,0 +1,3 @@
+-- Zona horaria preferida del usuario de cada API key: define "hoy" y "esta semana" en sus consultas
+
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS timezone STRING NOT NULL DEFAULT '';
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS stock_ratings@idx_stock_ratings_normalized_event;
+DROP INDEX IF EXISTS stock_ratings@idx_stock_ratings_brokerage_action;
+DROP INDEX IF EXISTS stock_ratings@idx_stock_ratings_brokerage_event;
+DROP INDEX IF EXISTS stock_ratings@idx_stock_ratings_company_event;
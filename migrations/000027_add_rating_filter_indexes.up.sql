@@ -0,0 +1,13 @@
+-- Índices compuestos para GET /api/v1/ratings, que combina filtros por empresa, brokerage, tipo de acción,
+-- rating normalizado, precio objetivo y fechas. Cada índice termina en event_time para devolver la página más
+-- reciente sin ordenar en memoria; el filtro por precio objetivo usa idx_stock_ratings_target_to_value (000024)
+
+CREATE INDEX IF NOT EXISTS idx_stock_ratings_company_event ON stock_ratings (company_id, event_time DESC);
+
+CREATE INDEX IF NOT EXISTS idx_stock_ratings_brokerage_event ON stock_ratings (brokerage_id, event_time DESC);
+
+-- brokerage + acción es la combinación más frecuente ("upgrades de Goldman")
+CREATE INDEX IF NOT EXISTS idx_stock_ratings_brokerage_action ON stock_ratings (brokerage_id, action_type, event_time DESC);
+
+CREATE INDEX IF NOT EXISTS idx_stock_ratings_normalized_event ON stock_ratings (normalized_rating_to, event_time DESC)
+    WHERE normalized_rating_to IS NOT NULL;
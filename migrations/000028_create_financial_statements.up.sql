@@ -0,0 +1,18 @@
+-- Estados financieros de Alpha Vantage (GET /api/v1/companies/{ticker}/financials): cuenta de resultados, balance y
+-- flujos de caja anuales y trimestrales. Los importes se guardan ya parseados por partida en line_items.
+
+CREATE TABLE IF NOT EXISTS financial_statements (
+    id                  UUID        NOT NULL PRIMARY KEY,
+    company_id          UUID        NOT NULL REFERENCES companies (id) ON DELETE CASCADE,
+    statement           STRING      NOT NULL,
+    period              STRING      NOT NULL,
+    fiscal_date_ending  DATE        NOT NULL,
+    currency            STRING,
+    line_items          JSONB       NOT NULL DEFAULT '{}',
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at          TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Una fila por company, estado, periodo y fecha fiscal; sirve también la consulta de los más recientes
+CREATE UNIQUE INDEX IF NOT EXISTS idx_financial_statements_company_period_date
+    ON financial_statements (company_id, statement, period, fiscal_date_ending DESC);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS stock_ratings@idx_stock_ratings_processed_at;
+DROP INDEX IF EXISTS stock_ratings@idx_stock_ratings_unprocessed;
+ALTER TABLE stock_ratings DROP COLUMN IF EXISTS processed_at;
@@ -0,0 +1,11 @@
+-- Procesado de ratings: processed_at guarda cuándo el job rating_processing marcó cada rating, para medir el
+-- throughput reciente en GET /api/v1/admin/processing/status. Los ratings procesados antes quedan con NULL
+
+ALTER TABLE stock_ratings ADD COLUMN IF NOT EXISTS processed_at TIMESTAMPTZ NULL;
+
+-- El backlog se lee del más antiguo al más nuevo y se cuenta por source
+CREATE INDEX IF NOT EXISTS idx_stock_ratings_unprocessed ON stock_ratings (created_at, source)
+    WHERE is_processed = false AND deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_stock_ratings_processed_at ON stock_ratings (processed_at)
+    WHERE processed_at IS NOT NULL;
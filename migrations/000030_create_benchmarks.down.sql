@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS benchmark_constituents;
+DROP TABLE IF EXISTS benchmark_values;
+DROP TABLE IF EXISTS benchmarks;
@@ -0,0 +1,57 @@
+-- Índices de referencia (GET /api/v1/benchmarks): valores diarios y composición histórica descargados del
+-- proveedor a través del ETF que replica cada índice. El riesgo de portfolio calcula la beta contra estos valores.
+
+CREATE TABLE IF NOT EXISTS benchmarks (
+    id              UUID        NOT NULL PRIMARY KEY,
+    symbol          STRING      NOT NULL,
+    name            STRING      NOT NULL,
+    source_symbol   STRING      NOT NULL,
+    currency        STRING      NOT NULL DEFAULT 'USD',
+    last_synced_at  TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_benchmarks_symbol ON benchmarks (symbol);
+
+-- Una fila por índice y sesión
+CREATE TABLE IF NOT EXISTS benchmark_values (
+    benchmark_id    UUID          NOT NULL REFERENCES benchmarks (id) ON DELETE CASCADE,
+    date            DATE          NOT NULL,
+    open            DECIMAL(15,4) NOT NULL,
+    high            DECIMAL(15,4) NOT NULL,
+    low             DECIMAL(15,4) NOT NULL,
+    close           DECIMAL(15,4) NOT NULL,
+    adjusted_close  DECIMAL(15,4) NOT NULL DEFAULT 0,
+    volume          INT8          NOT NULL DEFAULT 0,
+    source          STRING        NOT NULL,
+    created_at      TIMESTAMPTZ   NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ   NOT NULL DEFAULT now(),
+    PRIMARY KEY (benchmark_id, date)
+);
+
+-- Cada periodo de pertenencia de un símbolo es una fila: removed_at NULL es la composición actual
+CREATE TABLE IF NOT EXISTS benchmark_constituents (
+    id              UUID          NOT NULL PRIMARY KEY,
+    benchmark_id    UUID          NOT NULL REFERENCES benchmarks (id) ON DELETE CASCADE,
+    company_id      UUID          REFERENCES companies (id) ON DELETE SET NULL,
+    symbol          STRING        NOT NULL,
+    name            STRING,
+    weight          DECIMAL(9,6),
+    added_at        DATE          NOT NULL,
+    removed_at      DATE,
+    created_at      TIMESTAMPTZ   NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ   NOT NULL DEFAULT now()
+);
+
+-- Un solo periodo abierto por símbolo e índice
+CREATE UNIQUE INDEX IF NOT EXISTS idx_benchmark_constituents_current
+    ON benchmark_constituents (benchmark_id, symbol) WHERE removed_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_benchmark_constituents_period
+    ON benchmark_constituents (benchmark_id, added_at, removed_at);
+
+-- Índices iniciales; el ETF es el símbolo que se descarga del proveedor
+INSERT INTO benchmarks (id, symbol, name, source_symbol) VALUES
+    (gen_random_uuid(), 'SPX', 'S&P 500', 'SPY'),
+    (gen_random_uuid(), 'NDX', 'NASDAQ-100', 'QQQ')
+ON CONFLICT (symbol) DO NOTHING;
@@ -0,0 +1,4 @@
+DELETE FROM benchmarks WHERE kind = 'custom';
+ALTER TABLE benchmarks DROP COLUMN IF EXISTS base_date;
+ALTER TABLE benchmarks DROP COLUMN IF EXISTS weighting;
+ALTER TABLE benchmarks DROP COLUMN IF EXISTS kind;
@@ -0,0 +1,7 @@
+-- Índices personalizados (POST /api/v1/benchmarks): los define el usuario a partir de tickers registrados y sus
+-- valores diarios se calculan con los precios guardados en lugar de descargarse. Los índices del proveedor quedan
+-- con kind 'provider'
+
+ALTER TABLE benchmarks ADD COLUMN IF NOT EXISTS kind STRING NOT NULL DEFAULT 'provider';
+ALTER TABLE benchmarks ADD COLUMN IF NOT EXISTS weighting STRING NULL;
+ALTER TABLE benchmarks ADD COLUMN IF NOT EXISTS base_date DATE NULL;
@@ -0,0 +1,17 @@
+-- Relaciones declaradas entre companies (proveedores, competidores, filiales) para explorar la cadena de
+-- suministro: related_company_id es el <type> de company_id (su proveedor, su competidor o su filial).
+
+CREATE TABLE IF NOT EXISTS company_relationships (
+    id                 UUID        NOT NULL PRIMARY KEY,
+    company_id         UUID        NOT NULL REFERENCES companies (id) ON DELETE CASCADE,
+    related_company_id UUID        NOT NULL REFERENCES companies (id) ON DELETE CASCADE,
+    type               STRING      NOT NULL,
+    note               STRING      NULL,
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at         TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT chk_company_relationships_type CHECK (type IN ('supplier', 'competitor', 'subsidiary')),
+    CONSTRAINT chk_company_relationships_not_self CHECK (company_id != related_company_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_company_relationships_edge ON company_relationships (company_id, related_company_id, type);
+CREATE INDEX IF NOT EXISTS idx_company_relationships_related_company_id ON company_relationships (related_company_id);
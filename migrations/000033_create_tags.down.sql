@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS company_tags;
+DROP TABLE IF EXISTS tags;
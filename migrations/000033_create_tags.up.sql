@@ -0,0 +1,22 @@
+-- Etiquetas temáticas (ai, ev, semiconductors...) para construir listas curadas de companies sin cambiar el
+-- esquema. slug es el identificador de los filtros (?tags=ai,ev) y name la etiqueta que se muestra.
+
+CREATE TABLE IF NOT EXISTS tags (
+    id          UUID        NOT NULL PRIMARY KEY,
+    slug        STRING      NOT NULL,
+    name        STRING      NOT NULL,
+    description STRING      NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT uq_tags_slug UNIQUE (slug),
+    CONSTRAINT chk_tags_slug CHECK (slug ~ '^[a-z0-9]+(-[a-z0-9]+)*$')
+);
+
+CREATE TABLE IF NOT EXISTS company_tags (
+    company_id UUID        NOT NULL REFERENCES companies (id) ON DELETE CASCADE,
+    tag_id     UUID        NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (company_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_company_tags_tag_id ON company_tags (tag_id);
@@ -0,0 +1,18 @@
+-- Notas privadas de los usuarios sobre una company o un rating concreto. Pertenecen a la credencial que las
+-- creó (api_key:<id> o jwt:<sub>) dentro de su tenant: nadie más las ve. Las notas de un rating guardan también
+-- su company, así las notas de una company incluyen las de sus ratings
+
+CREATE TABLE IF NOT EXISTS notes (
+    id         UUID        NOT NULL PRIMARY KEY,
+    tenant_id  UUID        NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    author     STRING      NOT NULL, -- api_key:<id> o jwt:<sub>
+    company_id UUID        NOT NULL REFERENCES companies (id) ON DELETE CASCADE,
+    rating_id  UUID        NULL REFERENCES stock_ratings (id) ON DELETE CASCADE,
+    body       STRING      NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Las notas de un usuario se listan por company, las más recientes primero, y se buscan por rating para ?include=notes
+CREATE INDEX IF NOT EXISTS idx_notes_author_company ON notes (tenant_id, author, company_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_notes_author_rating ON notes (tenant_id, author, rating_id) WHERE rating_id IS NOT NULL;
@@ -0,0 +1,2 @@
+-- Los tipos rellenados no se distinguen de los clasificados en la ingesta: revertir no borra ninguno
+SELECT 1;
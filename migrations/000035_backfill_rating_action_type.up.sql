@@ -0,0 +1,23 @@
+-- Rellena action_type en los ratings guardados antes de la migración 000023, para que los listados de upgrades y
+-- downgrades no queden vacíos hasta que alguien lance el job action_type_backfill. El CASE replica
+-- entities.ParseActionType: acción en minúsculas con los espacios colapsados y el primer patrón contenido decide
+
+UPDATE stock_ratings AS sr
+SET action_type = CASE
+        WHEN n.action = '' THEN ''
+        WHEN n.action LIKE '%upgrade%' THEN 'upgrade'
+        WHEN n.action LIKE '%downgrade%' THEN 'downgrade'
+        WHEN n.action LIKE '%reiterat%' THEN 'reiterate'
+        WHEN n.action LIKE '%initiat%' THEN 'initiate'
+        WHEN n.action LIKE '%resumed%' THEN 'initiate'
+        WHEN n.action LIKE '%target raised%' THEN 'target_raised'
+        WHEN n.action LIKE '%target lowered%' THEN 'target_lowered'
+        WHEN n.action LIKE '%target set%' THEN 'target_set'
+        ELSE 'other'
+    END
+FROM (
+    SELECT id, btrim(regexp_replace(lower(action), '\s+', ' ', 'g')) AS action
+    FROM stock_ratings
+    WHERE action_type IS NULL
+) AS n
+WHERE sr.id = n.id;
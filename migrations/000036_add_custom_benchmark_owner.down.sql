@@ -0,0 +1,2 @@
+ALTER TABLE benchmarks DROP COLUMN IF EXISTS owner;
+ALTER TABLE benchmarks DROP COLUMN IF EXISTS owner_tenant_id;
@@ -0,0 +1,5 @@
+-- Creador de los índices personalizados: solo la credencial del tenant que lo creó puede eliminarlo. Los índices
+-- existentes quedan sin dueño y solo se eliminan sin tenant (clave de administración)
+
+ALTER TABLE benchmarks ADD COLUMN IF NOT EXISTS owner_tenant_id UUID NULL;
+ALTER TABLE benchmarks ADD COLUMN IF NOT EXISTS owner STRING NULL;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS technical_indicators;
+DROP TABLE IF EXISTS historical_data_summaries;
+DROP TABLE IF EXISTS historical_data;
+DROP TABLE IF EXISTS financial_metrics;
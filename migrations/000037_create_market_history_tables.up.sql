@@ -0,0 +1,178 @@
+-- Datos de Alpha Vantage: métricas fundamentales, precios históricos con sus resúmenes e indicadores técnicos.
+-- Estas tablas no tenían migración propia; se crean aquí con el esquema de sus entidades.
+
+CREATE TABLE IF NOT EXISTS financial_metrics (
+    id                         UUID          NOT NULL PRIMARY KEY,
+    company_id                 UUID          NOT NULL REFERENCES companies (id) ON DELETE CASCADE,
+    symbol                     STRING        NOT NULL,
+    pe_ratio                   DECIMAL(10,4) NULL,
+    peg_ratio                  DECIMAL(10,4) NULL,
+    price_to_book              DECIMAL(10,4) NULL,
+    price_to_sales             DECIMAL(10,4) NULL,
+    ev_to_revenue              DECIMAL(10,4) NULL,
+    ev_to_ebitda               DECIMAL(10,4) NULL,
+    enterprise_value           INT8          NULL,
+    roe                        DECIMAL(8,4)  NULL,
+    roa                        DECIMAL(8,4)  NULL,
+    roic                       DECIMAL(8,4)  NULL,
+    gross_margin               DECIMAL(8,4)  NULL,
+    operating_margin           DECIMAL(8,4)  NULL,
+    net_margin                 DECIMAL(8,4)  NULL,
+    debt_to_equity             DECIMAL(8,4)  NULL,
+    current_ratio              DECIMAL(8,4)  NULL,
+    quick_ratio                DECIMAL(8,4)  NULL,
+    interest_coverage          DECIMAL(8,4)  NULL,
+    book_value_per_share       DECIMAL(10,4) NULL,
+    revenue_growth_ttm         DECIMAL(8,4)  NULL,
+    earnings_growth_ttm        DECIMAL(8,4)  NULL,
+    revenue_growth3_y          DECIMAL(8,4)  NULL,
+    earnings_growth3_y         DECIMAL(8,4)  NULL,
+    revenue_growth5_y          DECIMAL(8,4)  NULL,
+    earnings_growth5_y         DECIMAL(8,4)  NULL,
+    eps                        DECIMAL(10,4) NULL,
+    eps_growth_ttm             DECIMAL(8,4)  NULL,
+    dividend_per_share         DECIMAL(10,4) NULL,
+    dividend_yield             DECIMAL(8,4)  NULL,
+    free_cash_flow_per_share   DECIMAL(10,4) NULL,
+    analyst_target_price       DECIMAL(15,4) NULL,
+    analyst_rating_strong      INT4          NULL,
+    analyst_rating_buy         INT4          NULL,
+    analyst_rating_hold        INT4          NULL,
+    analyst_rating_sell        INT4          NULL,
+    analyst_rating_strong_sell INT4          NULL,
+    data_source                STRING        NULL DEFAULT 'alphavantage',
+    last_updated               TIMESTAMPTZ   NOT NULL,
+    reporting_date             DATE          NULL,
+    currency                   STRING(3)     NULL DEFAULT 'USD',
+    created_at                 TIMESTAMPTZ   NOT NULL DEFAULT now(),
+    updated_at                 TIMESTAMPTZ   NOT NULL DEFAULT now(),
+    deleted_at                 TIMESTAMPTZ   NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_financial_metrics_symbol ON financial_metrics (symbol);
+CREATE INDEX IF NOT EXISTS idx_financial_metrics_deleted_at ON financial_metrics (deleted_at);
+
+CREATE TABLE IF NOT EXISTS historical_data (
+    id               UUID          NOT NULL PRIMARY KEY,
+    company_id       UUID          NOT NULL REFERENCES companies (id) ON DELETE CASCADE,
+    symbol           STRING        NOT NULL,
+    date             DATE          NOT NULL,
+    open_price       DECIMAL(15,4) NOT NULL,
+    high_price       DECIMAL(15,4) NOT NULL,
+    low_price        DECIMAL(15,4) NOT NULL,
+    close_price      DECIMAL(15,4) NOT NULL,
+    adjusted_close   DECIMAL(15,4) NOT NULL,
+    volume           INT8          NOT NULL,
+    daily_return     DECIMAL(8,4)  NULL,
+    daily_range      DECIMAL(15,4) NULL,
+    daily_volatility DECIMAL(8,4)  NULL,
+    is_gap_up        BOOL          NULL DEFAULT false,
+    is_gap_down      BOOL          NULL DEFAULT false,
+    gap_percent      DECIMAL(8,4)  NULL,
+    is_breakout      BOOL          NULL DEFAULT false,
+    is_breakdown     BOOL          NULL DEFAULT false,
+    time_frame       STRING(10)    NULL DEFAULT '1D',
+    data_source      STRING        NULL DEFAULT 'alphavantage',
+    last_updated     TIMESTAMPTZ   NOT NULL,
+    created_at       TIMESTAMPTZ   NOT NULL DEFAULT now(),
+    updated_at       TIMESTAMPTZ   NOT NULL DEFAULT now(),
+    deleted_at       TIMESTAMPTZ   NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_historical_data_symbol ON historical_data (symbol);
+CREATE INDEX IF NOT EXISTS idx_historical_data_date ON historical_data (date);
+CREATE INDEX IF NOT EXISTS idx_historical_data_deleted_at ON historical_data (deleted_at);
+
+CREATE TABLE IF NOT EXISTS historical_data_summaries (
+    id                 UUID          NOT NULL PRIMARY KEY,
+    company_id         UUID          NOT NULL REFERENCES companies (id) ON DELETE CASCADE,
+    symbol             STRING        NOT NULL,
+    start_date         DATE          NOT NULL,
+    end_date           DATE          NOT NULL,
+    period             STRING(10)    NULL,
+    highest_price      DECIMAL(15,4) NULL,
+    lowest_price       DECIMAL(15,4) NULL,
+    average_price      DECIMAL(15,4) NULL,
+    start_price        DECIMAL(15,4) NULL,
+    end_price          DECIMAL(15,4) NULL,
+    price_change       DECIMAL(15,4) NULL,
+    price_change_perc  DECIMAL(8,4)  NULL,
+    volatility         DECIMAL(8,4)  NULL,
+    beta               DECIMAL(8,4)  NULL,
+    sharpe_ratio       DECIMAL(8,4)  NULL,
+    max_drawdown       DECIMAL(8,4)  NULL,
+    average_volume     INT8          NULL,
+    total_volume       INT8          NULL,
+    highest_volume     INT8          NULL,
+    lowest_volume      INT8          NULL,
+    total_trading_days INT4          NULL,
+    up_days            INT4          NULL,
+    down_days          INT4          NULL,
+    unchanged_days     INT4          NULL,
+    win_rate           DECIMAL(8,4)  NULL,
+    average_gain       DECIMAL(8,4)  NULL,
+    average_loss       DECIMAL(8,4)  NULL,
+    gain_loss_ratio    DECIMAL(8,4)  NULL,
+    data_source        STRING        NULL DEFAULT 'alphavantage',
+    last_updated       TIMESTAMPTZ   NOT NULL,
+    created_at         TIMESTAMPTZ   NOT NULL DEFAULT now(),
+    updated_at         TIMESTAMPTZ   NOT NULL DEFAULT now(),
+    deleted_at         TIMESTAMPTZ   NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_historical_data_summaries_symbol ON historical_data_summaries (symbol);
+CREATE INDEX IF NOT EXISTS idx_historical_data_summaries_deleted_at ON historical_data_summaries (deleted_at);
+
+CREATE TABLE IF NOT EXISTS technical_indicators (
+    id              UUID          NOT NULL PRIMARY KEY,
+    company_id      UUID          NOT NULL REFERENCES companies (id) ON DELETE CASCADE,
+    symbol          STRING        NOT NULL,
+    sma20           DECIMAL(15,4) NULL,
+    sma50           DECIMAL(15,4) NULL,
+    sma200          DECIMAL(15,4) NULL,
+    ema12           DECIMAL(15,4) NULL,
+    ema26           DECIMAL(15,4) NULL,
+    rsi             DECIMAL(8,4)  NULL,
+    stoch_k         DECIMAL(8,4)  NULL,
+    stoch_d         DECIMAL(8,4)  NULL,
+    williams_r      DECIMAL(8,4)  NULL,
+    cci             DECIMAL(8,4)  NULL,
+    macd            DECIMAL(15,4) NULL,
+    macd_signal     DECIMAL(15,4) NULL,
+    macd_histogram  DECIMAL(15,4) NULL,
+    bb_upper        DECIMAL(15,4) NULL,
+    bb_middle       DECIMAL(15,4) NULL,
+    bb_lower        DECIMAL(15,4) NULL,
+    vwap            DECIMAL(15,4) NULL,
+    volume_ma20     INT8          NULL,
+    obv             INT8          NULL,
+    adline          DECIMAL(15,4) NULL,
+    adx             DECIMAL(8,4)  NULL,
+    aroon_up        DECIMAL(8,4)  NULL,
+    aroon_down      DECIMAL(8,4)  NULL,
+    sar             DECIMAL(15,4) NULL,
+    atr             DECIMAL(15,4) NULL,
+    band_width      DECIMAL(8,4)  NULL,
+    bb_percent_b    DECIMAL(8,4)  NULL,
+    resistance1     DECIMAL(15,4) NULL,
+    resistance2     DECIMAL(15,4) NULL,
+    support1        DECIMAL(15,4) NULL,
+    support2        DECIMAL(15,4) NULL,
+    pivot_point     DECIMAL(15,4) NULL,
+    time_frame      STRING(10)    NULL DEFAULT '1D',
+    period          INT4          NULL DEFAULT 14,
+    trend_signal    STRING(20)    NULL,
+    momentum_signal STRING(20)    NULL,
+    volume_signal   STRING(20)    NULL,
+    overall_signal  STRING(20)    NULL,
+    signal_strength DECIMAL(8,4)  NULL,
+    data_source     STRING        NULL DEFAULT 'alphavantage',
+    last_updated    TIMESTAMPTZ   NOT NULL,
+    market_date     DATE          NULL,
+    created_at      TIMESTAMPTZ   NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ   NOT NULL DEFAULT now(),
+    deleted_at      TIMESTAMPTZ   NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_technical_indicators_symbol ON technical_indicators (symbol);
+CREATE INDEX IF NOT EXISTS idx_technical_indicators_deleted_at ON technical_indicators (deleted_at);
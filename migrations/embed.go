@@ -0,0 +1,10 @@
+// Package migrations contiene las migraciones SQL versionadas del esquema (formato golang-migrate:
+// NNNNNN_descripcion.up.sql / .down.sql). Se embeben en el binario para no depender del directorio de trabajo.
+package migrations
+
+import "embed"
+
+// FS holds every versioned migration file
+//
+//go:embed *.sql
+var FS embed.FS
@@ -33,6 +33,7 @@ func PopulateDatabaseScript(cfg *config.Config, options PopulationScriptOptions)
 		UseCache:      options.UseCache,
 		DryRun:        options.DryRun,
 		ValidateAfter: options.ValidateAfter,
+		Incremental:   options.Incremental,
 	}
 
 	// Execute population
@@ -59,6 +60,7 @@ type PopulationScriptOptions struct {
 	UseCache      bool // Usar cache
 	DryRun        bool // Solo simular
 	ValidateAfter bool // Validar después
+	Incremental   bool // Solo datos nuevos desde el último sync
 	ShowDetails   bool // Mostrar detalles
 }
 
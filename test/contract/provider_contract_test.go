@@ -0,0 +1,69 @@
+//go:build contract
+// +build contract
+
+package contract
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/contract"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// Ejecutar con: go test -tags contract ./test/contract/ -v
+// CONTRACT_FINNHUB_KEY es una key de sandbox de Finnhub (sin ella se omite Finnhub); Alpha Vantage usa la key
+// "demo", que solo responde para IBM
+func TestProviderContracts(t *testing.T) {
+	log, err := logger.NewLoggerBuilder().WithLevel(logger.WarnLevel).WithFileOutput(false).WithConsoleOutput(true).Build()
+	require.NoError(t, err)
+
+	symbol := getEnv("CONTRACT_SYMBOL", "IBM")
+	runner := contract.NewRunner(contract.RunnerConfig{
+		Finnhub: contract.Endpoint{
+			BaseURL: getEnv("CONTRACT_FINNHUB_BASE_URL", "https://finnhub.io/api/v1"),
+			APIKey:  os.Getenv("CONTRACT_FINNHUB_KEY"),
+		},
+		AlphaVantage: contract.Endpoint{
+			BaseURL: getEnv("CONTRACT_ALPHAVANTAGE_BASE_URL", "https://www.alphavantage.co/query"),
+			APIKey:  getEnv("CONTRACT_ALPHAVANTAGE_KEY", "demo"),
+		},
+		Logger: log,
+	})
+
+	for _, check := range contract.DefaultChecks(symbol, time.Now()) {
+		t.Run(check.Provider+" "+check.Endpoint, func(t *testing.T) {
+			if check.Provider == contract.ProviderFinnhub && os.Getenv("CONTRACT_FINNHUB_KEY") == "" {
+				t.Skip("CONTRACT_FINNHUB_KEY not set")
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+
+			result := runner.Run(ctx, symbol, []contract.Check{check}).Results[0]
+			switch result.Status {
+			case contract.StatusSkipped:
+				t.Skipf("no access with this key: %s", result.Error)
+			case contract.StatusError:
+				t.Fatalf("check could not run: %s", result.Error)
+			}
+			for _, drift := range result.Drifts {
+				t.Errorf("%s %s (expected %s, got %s)", drift.Kind, drift.Path, drift.Expected, drift.Actual)
+			}
+		})
+		if check.Provider == contract.ProviderAlphaVantage {
+			time.Sleep(15 * time.Second) // Capa gratuita de Alpha Vantage: 5 llamadas por minuto
+		}
+	}
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
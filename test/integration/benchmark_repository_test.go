@@ -0,0 +1,113 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/implementation"
+	"github.com/MayaCris/stock-info-app/internal/testutil"
+)
+
+func TestBenchmarkRepository_ValuesAndConstituentHistory(t *testing.T) {
+	db := testutil.NewDatabase(t)
+	repo := implementation.NewBenchmarkRepository(db.DB)
+	ctx := context.Background()
+
+	// La migración registra el S&P 500 a través de SPY
+	spx, err := repo.GetBySymbol(ctx, "spy")
+	require.NoError(t, err)
+	assert.Equal(t, "SPX", spx.Symbol)
+	_, err = repo.GetBySymbol(ctx, "DAX")
+	assert.True(t, domainerrors.IsNotFound(err), "%v", err)
+
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+	require.NoError(t, repo.UpsertValues(ctx, []*entities.BenchmarkValue{
+		{BenchmarkID: spx.ID, Date: day(2), Close: 579.8, Source: "test"},
+		{BenchmarkID: spx.ID, Date: day(3), Close: 583.2, Source: "test"},
+	}))
+	// Volver a ingerir una sesión actualiza su valor
+	require.NoError(t, repo.UpsertValues(ctx, []*entities.BenchmarkValue{
+		{BenchmarkID: spx.ID, Date: day(3), Close: 583.2, AdjustedClose: 581.9, Source: "test"},
+	}))
+	values, err := repo.GetValues(ctx, spx.ID, day(1), day(31))
+	require.NoError(t, err)
+	require.Len(t, values, 2)
+	assert.True(t, values[0].Date.Equal(day(2)))
+	assert.Equal(t, 581.9, values[1].Price())
+
+	apple, nvidia := 0.07, 0.06
+	added, removed, err := repo.SyncConstituents(ctx, spx.ID, []*entities.BenchmarkConstituent{
+		{Symbol: "AAPL", Weight: &apple},
+		{Symbol: "MSFT"},
+	}, day(2))
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 0}, []int{added, removed})
+
+	// MSFT sale y NVDA entra el día 10
+	added, removed, err = repo.SyncConstituents(ctx, spx.ID, []*entities.BenchmarkConstituent{
+		{Symbol: "AAPL", Weight: &apple},
+		{Symbol: "NVDA", Weight: &nvidia},
+	}, day(10))
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 1}, []int{added, removed})
+
+	symbols := func(date time.Time) []string {
+		constituents, err := repo.GetConstituents(ctx, spx.ID, date)
+		require.NoError(t, err)
+		result := make([]string, len(constituents))
+		for i, constituent := range constituents {
+			result[i] = constituent.Symbol
+		}
+		return result
+	}
+	assert.Empty(t, symbols(day(1)))
+	assert.Equal(t, []string{"AAPL", "MSFT"}, symbols(day(5)))
+	assert.Equal(t, []string{"AAPL", "NVDA"}, symbols(day(10)))
+}
+
+func TestBenchmarkRepository_CustomBenchmarkLifecycle(t *testing.T) {
+	db := testutil.NewDatabase(t)
+	repo := implementation.NewBenchmarkRepository(db.DB)
+	ctx := context.Background()
+
+	base := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	half := 0.5
+	custom := entities.NewCustomBenchmark("big2", "Big two", entities.BenchmarkWeightingEqual, base)
+	require.NoError(t, repo.Create(ctx, custom, []*entities.BenchmarkConstituent{
+		{Symbol: "AAPL", Weight: &half, AddedAt: base},
+		{Symbol: "MSFT", Weight: &half, AddedAt: base},
+	}))
+	require.NoError(t, repo.UpsertValues(ctx, []*entities.BenchmarkValue{
+		{BenchmarkID: custom.ID, Date: base, Close: 100, Source: "custom"},
+	}))
+
+	stored, err := repo.GetBySymbol(ctx, "BIG2")
+	require.NoError(t, err)
+	assert.True(t, stored.IsCustom())
+	assert.Equal(t, entities.BenchmarkWeightingEqual, stored.Weighting)
+	require.NotNil(t, stored.BaseDate)
+	assert.True(t, stored.BaseDate.Equal(base))
+	constituents, err := repo.GetConstituents(ctx, custom.ID, base)
+	require.NoError(t, err)
+	assert.Len(t, constituents, 2)
+
+	// El símbolo no se puede repetir
+	err = repo.Create(ctx, entities.NewCustomBenchmark("BIG2", "Again", entities.BenchmarkWeightingCap, base), nil)
+	assert.True(t, domainerrors.IsDuplicate(err), "%v", err)
+
+	// Eliminar el índice elimina sus valores y su composición
+	require.NoError(t, repo.Delete(ctx, custom.ID))
+	values, err := repo.GetValues(ctx, custom.ID, base, base)
+	require.NoError(t, err)
+	assert.Empty(t, values)
+	constituents, err = repo.GetConstituents(ctx, custom.ID, base)
+	require.NoError(t, err)
+	assert.Empty(t, constituents)
+	assert.True(t, domainerrors.IsNotFound(repo.Delete(ctx, custom.ID)))
+}
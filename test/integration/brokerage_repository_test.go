@@ -0,0 +1,88 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/implementation"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/testutil"
+)
+
+func newBrokerageRepository(t *testing.T) interfaces.BrokerageRepository {
+	db := testutil.NewDatabase(t)
+	return implementation.NewBrokerageRepositoryWithReader(db.DB, db.Reader())
+}
+
+func TestBrokerageRepository_CreateAndFindOrCreate(t *testing.T) {
+	repo := newBrokerageRepository(t)
+	ctx := context.Background()
+
+	brokerage := entities.NewBrokerageWithDetails("Goldman Sachs", "GoldmanSachs.com", "us")
+	require.NoError(t, repo.Create(ctx, brokerage))
+
+	// La normalización del hook queda persistida
+	found, err := repo.GetByName(ctx, "Goldman Sachs")
+	require.NoError(t, err)
+	assert.Equal(t, brokerage.ID, found.ID)
+	assert.Equal(t, "https://goldmansachs.com", found.Website)
+	assert.Equal(t, "US", found.Country)
+
+	err = repo.Create(ctx, entities.NewBrokerage("Goldman Sachs"))
+	assert.True(t, domainerrors.IsDuplicate(err), "%v", err)
+
+	// FindOrCreate reutiliza la existente y crea solo las nuevas
+	same, err := repo.FindOrCreate(ctx, "Goldman Sachs")
+	require.NoError(t, err)
+	assert.Equal(t, brokerage.ID, same.ID)
+
+	created, err := repo.FindOrCreateWithDetails(ctx, "Morgan Stanley", "", "US")
+	require.NoError(t, err)
+	completed, err := repo.FindOrCreateWithDetails(ctx, "Morgan Stanley", "morganstanley.com", "GB")
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, completed.ID)
+	assert.Equal(t, "https://morganstanley.com", completed.Website)
+	assert.Equal(t, "US", completed.Country)
+
+	_, err = repo.GetByName(ctx, "Unknown Brokerage")
+	assert.True(t, domainerrors.IsNotFound(err), "%v", err)
+}
+
+func TestBrokerageRepository_ListAndDeactivate(t *testing.T) {
+	repo := newBrokerageRepository(t)
+	ctx := context.Background()
+
+	require.NoError(t, repo.CreateMany(ctx, []*entities.Brokerage{
+		entities.NewBrokerage("JPMorgan Chase"),
+		entities.NewBrokerage("Morgan Stanley"),
+		entities.NewBrokerage("Barclays"),
+	}))
+	morgan, err := repo.GetByName(ctx, "Morgan Stanley")
+	require.NoError(t, err)
+	require.NoError(t, repo.Deactivate(ctx, morgan.ID))
+
+	active, err := repo.CountActive(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), active)
+
+	// El filtro por nombre no distingue mayúsculas; por defecto se ordena por nombre
+	brokerages, total, err := repo.List(ctx, interfaces.BrokerageListFilter{Name: "morgan"}, nil, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, brokerages, 2)
+	assert.Equal(t, "JPMorgan Chase", brokerages[0].Name)
+	assert.False(t, brokerages[1].IsActive)
+
+	isActive := true
+	brokerages, total, err = repo.List(ctx, interfaces.BrokerageListFilter{IsActive: &isActive},
+		[]interfaces.SortField{{Column: "name", Desc: true}}, 1, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, brokerages, 1)
+	assert.Equal(t, "JPMorgan Chase", brokerages[0].Name)
+}
@@ -0,0 +1,71 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/implementation"
+	"github.com/MayaCris/stock-info-app/internal/testutil"
+)
+
+func TestCompanyRelationshipRepository_Lifecycle(t *testing.T) {
+	db := testutil.NewDatabase(t)
+	companyRepo := implementation.NewCompanyRepository(db.DB)
+	repo := implementation.NewCompanyRelationshipRepository(db.DB)
+	ctx := context.Background()
+
+	companies := make(map[string]*entities.Company)
+	for _, ticker := range []string{"AAPL", "TSM", "MSFT", "ASML"} {
+		company := entities.NewCompany(ticker, ticker+" Inc")
+		require.NoError(t, companyRepo.Create(ctx, company))
+		companies[ticker] = company
+	}
+
+	supplier := entities.NewCompanyRelationship(companies["AAPL"].ID, companies["TSM"].ID, entities.RelationshipSupplier, "chips")
+	require.NoError(t, repo.Create(ctx, supplier))
+	require.NoError(t, repo.Create(ctx, entities.NewCompanyRelationship(companies["MSFT"].ID, companies["AAPL"].ID, entities.RelationshipCompetitor, "")))
+	require.NoError(t, repo.Create(ctx, entities.NewCompanyRelationship(companies["TSM"].ID, companies["ASML"].ID, entities.RelationshipSupplier, "")))
+
+	// La misma relación dos veces es un duplicado y una company desconocida un conflicto
+	err := repo.Create(ctx, entities.NewCompanyRelationship(companies["AAPL"].ID, companies["TSM"].ID, entities.RelationshipSupplier, ""))
+	assert.True(t, domainerrors.IsDuplicate(err), "%v", err)
+	err = repo.Create(ctx, entities.NewCompanyRelationship(companies["AAPL"].ID, uuid.New(), entities.RelationshipSupplier, ""))
+	assert.True(t, domainerrors.IsConflict(err), "%v", err)
+
+	found, err := repo.GetByID(ctx, supplier.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "AAPL", found.Company.Ticker)
+	assert.Equal(t, "TSM", found.RelatedCompany.Ticker)
+
+	// Las relaciones de AAPL en ambos sentidos, con filtro de tipo
+	relationships, err := repo.GetByCompanyIDs(ctx, []uuid.UUID{companies["AAPL"].ID}, nil)
+	require.NoError(t, err)
+	assert.Len(t, relationships, 2)
+	relationships, err = repo.GetByCompanyIDs(ctx, []uuid.UUID{companies["AAPL"].ID, companies["ASML"].ID}, []string{entities.RelationshipSupplier})
+	require.NoError(t, err)
+	assert.Len(t, relationships, 2)
+
+	found.Type, found.Note = entities.RelationshipCompetitor, "rivals"
+	require.NoError(t, repo.Update(ctx, found))
+	found, err = repo.GetByID(ctx, supplier.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.RelationshipCompetitor, found.Type)
+	assert.Equal(t, "rivals", found.Note)
+
+	require.NoError(t, repo.Delete(ctx, supplier.ID))
+	assert.True(t, domainerrors.IsNotFound(repo.Delete(ctx, supplier.ID)))
+	_, err = repo.GetByID(ctx, supplier.ID)
+	assert.True(t, domainerrors.IsNotFound(err), "%v", err)
+
+	// Eliminar una company elimina sus relaciones
+	require.NoError(t, companyRepo.HardDelete(ctx, companies["ASML"].ID))
+	relationships, err = repo.GetByCompanyIDs(ctx, []uuid.UUID{companies["TSM"].ID}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, relationships)
+}
@@ -0,0 +1,179 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/implementation"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/config"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/database/cache"
+	"github.com/MayaCris/stock-info-app/internal/testutil"
+)
+
+func newCompanyRepository(t *testing.T) interfaces.CompanyRepository {
+	db := testutil.NewDatabase(t)
+	return implementation.NewCompanyRepositoryWithReader(db.DB, db.Reader())
+}
+
+func TestCompanyRepository_CreateAndGet(t *testing.T) {
+	repo := newCompanyRepository(t)
+	ctx := context.Background()
+
+	company := entities.NewCompanyWithDetails("aapl", "Apple Inc.", "Technology", "NASDAQ", 3e12)
+	require.NoError(t, repo.Create(ctx, company))
+
+	found, err := repo.GetByTicker(ctx, "AAPL")
+	require.NoError(t, err)
+	assert.Equal(t, company.ID, found.ID)
+	assert.Equal(t, "Apple Inc.", found.Name)
+
+	// El ticker es único aunque cambie el nombre
+	err = repo.Create(ctx, entities.NewCompany("AAPL", "Apple Duplicate"))
+	assert.True(t, domainerrors.IsDuplicate(err), "%v", err)
+
+	_, err = repo.GetByTicker(ctx, "MSFT")
+	assert.True(t, domainerrors.IsNotFound(err), "%v", err)
+}
+
+func TestCompanyRepository_RemapTickerResolvesAlias(t *testing.T) {
+	repo := newCompanyRepository(t)
+	ctx := context.Background()
+
+	company := entities.NewCompany("FB", "Meta Platforms")
+	require.NoError(t, repo.Create(ctx, company))
+
+	remapped, err := repo.RemapTicker(ctx, company.ID, "meta")
+	require.NoError(t, err)
+	assert.Equal(t, "META", remapped.Ticker)
+
+	// El ticker anterior se resuelve a la company por su alias
+	found, err := repo.GetByTicker(ctx, "FB")
+	require.NoError(t, err)
+	assert.Equal(t, company.ID, found.ID)
+
+	aliases, err := repo.GetTickerAliases(ctx, company.ID)
+	require.NoError(t, err)
+	require.Len(t, aliases, 1)
+	assert.Equal(t, "FB", aliases[0].Ticker)
+
+	other := entities.NewCompany("GOOG", "Alphabet")
+	require.NoError(t, repo.Create(ctx, other))
+	_, err = repo.RemapTicker(ctx, other.ID, "META")
+	assert.True(t, domainerrors.IsDuplicate(err), "%v", err)
+}
+
+func TestCompanyRepository_FindOrCreateAndUpsert(t *testing.T) {
+	repo := newCompanyRepository(t)
+	ctx := context.Background()
+
+	created, err := repo.FindOrCreateWithDetails(ctx, "NVDA", "NVIDIA", "", "NASDAQ", 0)
+	require.NoError(t, err)
+
+	// Una segunda llamada reutiliza la company y completa solo los datos vacíos
+	found, err := repo.FindOrCreateWithDetails(ctx, "NVDA", "Other name", "Technology", "NYSE", 2e12)
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, found.ID)
+	assert.Equal(t, "NVIDIA", found.Name)
+	assert.Equal(t, "Technology", found.Sector)
+	assert.Equal(t, "NASDAQ", found.Exchange)
+	assert.Equal(t, 2e12, found.MarketCap)
+
+	updated := entities.NewCompanyWithDetails("NVDA", "NVIDIA Corporation", "Technology", "NASDAQ", 3e12)
+	require.NoError(t, repo.UpsertMany(ctx, []*entities.Company{
+		updated,
+		entities.NewCompanyWithDetails("AMD", "Advanced Micro Devices", "Technology", "NASDAQ", 2e11),
+	}))
+	assert.Equal(t, created.ID, updated.ID)
+
+	count, err := repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	active := true
+	companies, total, err := repo.List(ctx, interfaces.CompanyListFilter{IsActive: &active}, nil, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, companies, 2)
+	assert.Equal(t, "AMD", companies[0].Ticker) // Orden por ticker por defecto
+	assert.Equal(t, "NVIDIA Corporation", companies[1].Name)
+}
+
+func TestCompanyRepository_SoftDeleteAndRestore(t *testing.T) {
+	repo := newCompanyRepository(t)
+	ctx := context.Background()
+
+	company := entities.NewCompany("TWTR", "Twitter")
+	require.NoError(t, repo.Create(ctx, company))
+	require.NoError(t, repo.Delete(ctx, company.ID))
+
+	_, err := repo.GetByID(ctx, company.ID)
+	assert.True(t, domainerrors.IsNotFound(err), "%v", err)
+	deleted, err := repo.CountDeleted(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	require.NoError(t, repo.Restore(ctx, company.ID))
+	restored, err := repo.GetByID(ctx, company.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "TWTR", restored.Ticker)
+
+	assert.True(t, domainerrors.IsNotFound(repo.Delete(ctx, uuid.New())))
+}
+
+func TestCompanyRepository_QueryCacheOnRedis(t *testing.T) {
+	repo := newCompanyRepository(t)
+	cacheService, err := cache.NewRedisCacheService(&config.Config{Cache: testutil.NewRedis(t)})
+	require.NoError(t, err)
+	cached := implementation.NewCachedCompanyRepository(repo, cacheService, time.Minute)
+	ctx := context.Background()
+
+	require.NoError(t, cached.Create(ctx, entities.NewCompanyWithDetails("IBM", "IBM", "Technology", "NYSE", 1e11)))
+	first, err := cached.GetByTicker(ctx, "IBM")
+	require.NoError(t, err)
+
+	// Una escritura que no pasa por la cache deja la consulta cacheada sin cambios
+	require.NoError(t, repo.UpdateMarketCap(ctx, "IBM", 2e11))
+	stale, err := cached.GetByTicker(ctx, "IBM")
+	require.NoError(t, err)
+	assert.Equal(t, first.MarketCap, stale.MarketCap)
+
+	// Las escrituras del repositorio cacheado invalidan las consultas
+	require.NoError(t, cached.UpdateMarketCap(ctx, "IBM", 3e11))
+	fresh, err := cached.GetByTicker(ctx, "IBM")
+	require.NoError(t, err)
+	assert.Equal(t, 3e11, fresh.MarketCap)
+}
+
+func TestCompanyRepository_MarketCapStatsByGroup(t *testing.T) {
+	repo := newCompanyRepository(t)
+	ctx := context.Background()
+
+	for _, company := range []*entities.Company{
+		entities.NewCompanyWithDetails("AAPL", "Apple Inc.", "Technology", "NASDAQ", 3000),
+		entities.NewCompanyWithDetails("MSFT", "Microsoft Corp.", "Technology", "NASDAQ", 2000),
+		entities.NewCompanyWithDetails("ASML", "ASML Holding", "Technology", "NASDAQ", 400),
+		entities.NewCompanyWithDetails("SAP", "SAP SE", "Technology", "XETRA", 200),
+		entities.NewCompanyWithDetails("NONE", "No Cap Inc.", "Technology", "NYSE", 0),
+	} {
+		if company.Ticker == "ASML" || company.Ticker == "SAP" {
+			company.Currency = "eur"
+		}
+		require.NoError(t, repo.Create(ctx, company))
+	}
+
+	groups, err := repo.GetMarketCapStatsByGroup(ctx)
+	require.NoError(t, err)
+	// Las companies sin capitalización no cuentan; la moneda vacía queda vacía
+	require.Len(t, groups, 3)
+	assert.Equal(t, interfaces.MarketCapGroupStats{Exchange: "NASDAQ", Currency: "", Count: 2, Sum: 5000, Min: 2000, Max: 3000}, groups[0])
+	assert.Equal(t, interfaces.MarketCapGroupStats{Exchange: "NASDAQ", Currency: "EUR", Count: 1, Sum: 400, Min: 400, Max: 400}, groups[1])
+	assert.Equal(t, "XETRA", groups[2].Exchange)
+}
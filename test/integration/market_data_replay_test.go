@@ -0,0 +1,101 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/config"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/httpclient"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/alphavantage"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/finnhub"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+// fixtureDir contiene las respuestas grabadas de Finnhub y Alpha Vantage (EXTERNAL_HTTP_FIXTURE_MODE=record)
+const fixtureDir = "../fixtures/http"
+
+// emptyMarketDataRepository no tiene cotizaciones guardadas, así cada petición llega al proveedor
+type emptyMarketDataRepository struct {
+	interfaces.MarketDataRepository
+	saved []*entities.MarketData
+}
+
+func (r *emptyMarketDataRepository) GetBySymbol(ctx context.Context, symbol string) (*entities.MarketData, error) {
+	return nil, errors.New("not found")
+}
+
+func (r *emptyMarketDataRepository) UpsertBySymbol(ctx context.Context, marketData *entities.MarketData, hooks ...interfaces.WriteHook[entities.MarketData]) error {
+	r.saved = append(r.saved, marketData)
+	return nil
+}
+
+// anyTickerCompanyRepository devuelve una empresa para cualquier ticker
+type anyTickerCompanyRepository struct {
+	interfaces.CompanyRepository
+}
+
+func (r *anyTickerCompanyRepository) GetByTicker(ctx context.Context, ticker string) (*entities.Company, error) {
+	return &entities.Company{ID: uuid.New(), Ticker: ticker}, nil
+}
+
+func TestMarketDataService_ReplaysRecordedProviderResponses(t *testing.T) {
+	log, err := logger.NewLoggerBuilder().WithLevel(logger.ErrorLevel).WithFileOutput(false).WithConsoleOutput(true).Build()
+	require.NoError(t, err)
+
+	cfg := &config.Config{External: config.ExternalConfig{
+		Primary:   config.APIConfig{Key: "unused", BaseURL: "https://finnhub.io/api/v1"},
+		Secondary: config.APIConfig{Key: "unused", BaseURL: "https://www.alphavantage.co/query"},
+		HTTP:      config.HTTPClientConfig{FixtureMode: httpclient.FixtureModeReplay, FixtureDir: fixtureDir},
+	}}
+	httpClient, err := httpclient.New(httpclient.FromConfig(cfg.External, cfg.External.Primary), log)
+	require.NoError(t, err)
+
+	marketDataRepo := &emptyMarketDataRepository{}
+	service := services.NewMarketDataService(services.MarketDataServiceConfig{
+		MarketDataRepo: marketDataRepo,
+		CompanyRepo:    &anyTickerCompanyRepository{},
+		FinnhubClient: finnhub.NewClient(finnhub.ClientConfig{
+			BaseURL:    cfg.External.Primary.BaseURL,
+			APIKey:     cfg.External.Primary.Key,
+			HTTPClient: httpClient,
+			Logger:     log,
+		}),
+		FinnhubAdapter:      finnhub.NewAdapter(log),
+		AlphaVantageClient:  alphavantage.NewClient(cfg, log),
+		AlphaVantageAdapter: alphavantage.NewAdapter(log),
+		Logger:              log,
+	})
+	ctx := context.Background()
+
+	quote, err := service.GetRealTimeQuote(ctx, "AAPL")
+	require.NoError(t, err)
+	assert.Equal(t, 189.84, quote.CurrentPrice)
+	assert.Equal(t, 188.61, quote.PreviousClose)
+	require.Len(t, marketDataRepo.saved, 1)
+	assert.Equal(t, "fixture-quote-aapl", marketDataRepo.saved[0].ProviderRequestID)
+
+	// Finnhub responde con ceros a un símbolo desconocido
+	_, err = service.GetRealTimeQuote(ctx, "ZZZZ")
+	var errorResp *response.ErrorResponse
+	require.True(t, errors.As(err, &errorResp))
+	assert.Equal(t, http.StatusNotFound, errorResp.StatusCode)
+
+	// La nota de límite de Alpha Vantage grabada se traduce a 429
+	_, err = service.GetHistoricalData(ctx, "AAPL", "daily", "compact")
+	require.True(t, errors.As(err, &errorResp))
+	assert.Equal(t, http.StatusTooManyRequests, errorResp.StatusCode)
+
+	// Sin fixture no hay red: la petición falla
+	_, err = service.GetRealTimeQuote(ctx, "MSFT")
+	assert.Error(t, err)
+}
@@ -0,0 +1,243 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/implementation"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/testutil"
+)
+
+// ratingFixture guarda una company y una brokerage reales para las foreign keys de los ratings
+type ratingFixture struct {
+	ratings    interfaces.StockRatingRepository
+	companies  interfaces.CompanyRepository
+	brokerages interfaces.BrokerageRepository
+	company    *entities.Company
+	brokerage  *entities.Brokerage
+}
+
+func newRatingFixture(t *testing.T) ratingFixture {
+	db := testutil.NewDatabase(t)
+	ctx := context.Background()
+
+	companies := implementation.NewCompanyRepository(db.DB)
+	company := entities.NewCompany("AAPL", "Apple Inc.")
+	company.Sector = "Technology"
+	require.NoError(t, companies.Create(ctx, company))
+	brokerages := implementation.NewBrokerageRepository(db.DB)
+	brokerage := entities.NewBrokerage("Goldman Sachs")
+	require.NoError(t, brokerages.Create(ctx, brokerage))
+
+	return ratingFixture{
+		ratings:    implementation.NewStockRatingRepositoryWithReader(db.DB, db.Reader()),
+		companies:  companies,
+		brokerages: brokerages,
+		company:    company,
+		brokerage:  brokerage,
+	}
+}
+
+func (f ratingFixture) newRating(action string, eventTime time.Time, ratingTo, targetTo string) *entities.StockRating {
+	rating := entities.NewStockRating(f.company.ID, f.brokerage.ID, action, eventTime)
+	rating.RatingTo = ratingTo
+	rating.TargetTo = targetTo
+	return rating
+}
+
+func TestStockRatingRepository_BulkInsertIgnoresDuplicates(t *testing.T) {
+	f := newRatingFixture(t)
+	ctx := context.Background()
+	day := time.Date(2026, 3, 2, 13, 30, 0, 0, time.UTC)
+
+	ratings := []*entities.StockRating{
+		f.newRating("Upgraded by", day, "Buy", "$210.00"),
+		f.newRating("Downgraded by", day.Add(time.Hour), "Sell", "$150.00"),
+		f.newRating("Reiterated by", day.Add(2*time.Hour), "Neutral", "$180.00"),
+	}
+	inserted, err := f.ratings.BulkInsertIgnoreDuplicates(ctx, ratings)
+	require.NoError(t, err)
+	assert.Equal(t, 3, inserted)
+
+	// El mismo evento (company, brokerage, event time) no se inserta dos veces
+	again := f.newRating("upgraded by", day, "Strong-Buy", "$220.00")
+	inserted, err = f.ratings.BulkInsertIgnoreDuplicates(ctx, []*entities.StockRating{
+		again,
+		f.newRating("target raised by", day.Add(3*time.Hour), "Buy", "$230.00"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, inserted)
+
+	count, err := f.ratings.CountByCompany(ctx, f.company.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), count)
+
+	// La normalización que el INSERT en bloque aplica sin hooks queda persistida
+	stored, err := f.ratings.GetByID(ctx, ratings[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "upgraded by", stored.Action)
+	assert.Equal(t, entities.ActionUpgrade, stored.ActionType)
+	assert.Equal(t, entities.RatingBuy, stored.NormalizedTo)
+	require.NotNil(t, stored.TargetToValue)
+	assert.Equal(t, 210.0, *stored.TargetToValue)
+	assert.Equal(t, "USD", stored.TargetCurrency)
+}
+
+func TestStockRatingRepository_FindOrCreateRating(t *testing.T) {
+	f := newRatingFixture(t)
+	ctx := context.Background()
+	eventTime := time.Date(2026, 3, 3, 14, 0, 0, 0, time.UTC)
+
+	existing, err := f.ratings.FindExisting(ctx, f.company.ID, f.brokerage.ID, eventTime)
+	require.NoError(t, err)
+	assert.Nil(t, existing)
+
+	created, err := f.ratings.FindOrCreateRating(ctx, f.company.ID, f.brokerage.ID, eventTime,
+		"Initiated by", "", "Buy", "", "$200.00", []byte(`{"ticker":"AAPL"}`))
+	require.NoError(t, err)
+	assert.Equal(t, entities.ActionInitiate, created.ActionType)
+
+	found, err := f.ratings.FindOrCreateRating(ctx, f.company.ID, f.brokerage.ID, eventTime,
+		"Reiterated by", "Buy", "Buy", "$200.00", "$210.00", nil)
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, found.ID)
+	assert.Equal(t, "$200.00", found.TargetTo)
+	assert.JSONEq(t, `{"ticker":"AAPL"}`, string(found.RawData))
+
+	// Un rating con una company inexistente viola la foreign key
+	orphan := entities.NewStockRating(uuid.New(), f.brokerage.ID, "upgraded by", eventTime)
+	err = f.ratings.Create(ctx, orphan)
+	assert.True(t, domainerrors.IsConflict(err), "%v", err)
+}
+
+func TestStockRatingRepository_SearchAndProcessing(t *testing.T) {
+	f := newRatingFixture(t)
+	ctx := context.Background()
+	day := time.Date(2026, 3, 4, 13, 30, 0, 0, time.UTC)
+
+	ratings := []*entities.StockRating{
+		f.newRating("upgraded by", day, "Buy", "$210.00"),
+		f.newRating("upgraded by", day.Add(24*time.Hour), "Outperform", "$240.00"),
+		f.newRating("downgraded by", day.Add(48*time.Hour), "Sell", "$150.00"),
+	}
+	_, err := f.ratings.BulkInsertIgnoreDuplicates(ctx, ratings)
+	require.NoError(t, err)
+
+	// Filtros combinados, los más recientes primero
+	minTarget := 200.0
+	page, total, err := f.ratings.Search(ctx, interfaces.RatingSearchFilter{
+		CompanyID:      &f.company.ID,
+		ActionTypes:    []entities.ActionType{entities.ActionUpgrade},
+		Ratings:        []entities.RatingScale{entities.RatingBuy},
+		TargetCurrency: "USD",
+		TargetMin:      &minTarget,
+		From:           day,
+		To:             day.Add(72 * time.Hour),
+		PreloadCompany: true,
+	}, nil, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, page, 2)
+	assert.Equal(t, ratings[1].ID, page[0].ID)
+	assert.Equal(t, "AAPL", page[0].Company.Ticker)
+
+	require.NoError(t, f.ratings.MarkManyAsProcessed(ctx, []uuid.UUID{ratings[0].ID, ratings[1].ID}))
+	unprocessed, err := f.ratings.GetUnprocessed(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, unprocessed, 1)
+	assert.Equal(t, ratings[2].ID, unprocessed[0].ID)
+
+	// El soft delete se puede deshacer
+	require.NoError(t, f.ratings.Delete(ctx, ratings[2].ID))
+	deleted, err := f.ratings.CountDeleted(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	require.NoError(t, f.ratings.Restore(ctx, ratings[2].ID))
+	count, err := f.ratings.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+}
+
+func TestStockRatingRepository_LatestByBrokerageAsOf(t *testing.T) {
+	f := newRatingFixture(t)
+	ctx := context.Background()
+	day := time.Date(2024, 6, 28, 14, 0, 0, 0, time.UTC)
+
+	morgan := entities.NewBrokerage("Morgan Stanley")
+	require.NoError(t, f.brokerages.Create(ctx, morgan))
+	morganRating := entities.NewStockRating(f.company.ID, morgan.ID, "initiated by", day.Add(-24*time.Hour))
+	morganRating.RatingTo = "Overweight"
+
+	ratings := []*entities.StockRating{
+		f.newRating("initiated by", day.Add(-48*time.Hour), "Neutral", "$180.00"),
+		f.newRating("upgraded by", day, "Buy", "$210.00"),
+		f.newRating("downgraded by", day.Add(72*time.Hour), "Sell", "$150.00"),
+		morganRating,
+	}
+	_, err := f.ratings.BulkInsertIgnoreDuplicates(ctx, ratings)
+	require.NoError(t, err)
+
+	// DISTINCT ON deja el último rating de cada brokerage anterior al corte, los más recientes primero
+	latest, err := f.ratings.GetLatestByBrokerageAsOf(ctx, f.company.ID, day.Add(24*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, latest, 2)
+	assert.Equal(t, ratings[1].ID, latest[0].ID)
+	assert.Equal(t, "Goldman Sachs", latest[0].Brokerage.Name)
+	assert.Equal(t, "AAPL", latest[0].Company.Ticker)
+	assert.Equal(t, morganRating.ID, latest[1].ID)
+
+	// Los ratings eliminados no cuentan: la brokerage vuelve a su rating anterior
+	require.NoError(t, f.ratings.Delete(ctx, ratings[1].ID))
+	latest, err = f.ratings.GetLatestByBrokerageAsOf(ctx, f.company.ID, day.Add(24*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, latest, 2)
+	assert.Equal(t, morganRating.ID, latest[0].ID)
+	assert.Equal(t, ratings[0].ID, latest[1].ID)
+}
+
+func TestStockRatingRepository_SectorActionTrend(t *testing.T) {
+	f := newRatingFixture(t)
+	ctx := context.Background()
+
+	pfizer := entities.NewCompany("PFE", "Pfizer Inc.")
+	pfizer.Sector = "Healthcare"
+	unclassified := entities.NewCompany("XYZ", "Unclassified Corp")
+	require.NoError(t, f.companies.Create(ctx, pfizer))
+	require.NoError(t, f.companies.Create(ctx, unclassified))
+
+	pfizerRating := entities.NewStockRating(pfizer.ID, f.brokerage.ID, "downgraded by", time.Date(2026, 1, 15, 14, 0, 0, 0, time.UTC))
+	ratings := []*entities.StockRating{
+		f.newRating("upgraded by", time.Date(2026, 1, 10, 14, 0, 0, 0, time.UTC), "Buy", ""),
+		f.newRating("reiterated by", time.Date(2026, 1, 20, 14, 0, 0, 0, time.UTC), "Buy", ""),
+		// 1 de febrero a las 02:00 UTC: todavía enero en Nueva York
+		f.newRating("downgraded by", time.Date(2026, 2, 1, 2, 0, 0, 0, time.UTC), "Neutral", ""),
+		pfizerRating,
+		entities.NewStockRating(unclassified.ID, f.brokerage.ID, "upgraded by", time.Date(2026, 1, 12, 14, 0, 0, 0, time.UTC)),
+	}
+	_, err := f.ratings.BulkInsertIgnoreDuplicates(ctx, ratings)
+	require.NoError(t, err)
+
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, newYork)
+	counts, err := f.ratings.GetSectorActionTrend(ctx, from, from.AddDate(0, 2, 0), interfaces.TrendGranularityMonth, newYork)
+	require.NoError(t, err)
+
+	// Las companies sin sector no cuentan; buckets ordenados por mes y sector
+	require.Len(t, counts, 2)
+	assert.True(t, counts[0].Start.Equal(from))
+	assert.Equal(t, "Healthcare", counts[0].Sector)
+	assert.Equal(t, int64(1), counts[0].Downgrades)
+	assert.Equal(t, "Technology", counts[1].Sector)
+	assert.Equal(t, int64(3), counts[1].Total)
+	assert.Equal(t, int64(1), counts[1].Upgrades)
+	assert.Equal(t, int64(1), counts[1].Downgrades)
+}
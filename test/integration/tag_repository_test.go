@@ -0,0 +1,84 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/implementation"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/testutil"
+)
+
+func TestTagRepository_Lifecycle(t *testing.T) {
+	db := testutil.NewDatabase(t)
+	companyRepo := implementation.NewCompanyRepository(db.DB)
+	repo := implementation.NewTagRepository(db.DB)
+	ctx := context.Background()
+
+	companies := make(map[string]*entities.Company)
+	for _, ticker := range []string{"TSLA", "NVDA", "F"} {
+		company := entities.NewCompany(ticker, ticker+" Inc")
+		require.NoError(t, companyRepo.Create(ctx, company))
+		companies[ticker] = company
+	}
+
+	ai := entities.NewTag("ai", "Artificial intelligence", "")
+	ev := entities.NewTag("", "Electric Vehicles", "Battery electric cars")
+	require.NoError(t, repo.Create(ctx, ai))
+	require.NoError(t, repo.Create(ctx, ev))
+	err := repo.Create(ctx, entities.NewTag("AI", "Duplicate", ""))
+	assert.True(t, domainerrors.IsDuplicate(err), "%v", err)
+
+	found, err := repo.GetBySlug(ctx, "Electric Vehicles")
+	require.NoError(t, err)
+	assert.Equal(t, ev.ID, found.ID)
+
+	// Asignar dos veces no falla y una company desconocida es un conflicto
+	require.NoError(t, repo.AddCompany(ctx, ai.ID, companies["TSLA"].ID))
+	require.NoError(t, repo.AddCompany(ctx, ai.ID, companies["TSLA"].ID))
+	require.NoError(t, repo.AddCompany(ctx, ai.ID, companies["NVDA"].ID))
+	require.NoError(t, repo.AddCompany(ctx, ev.ID, companies["TSLA"].ID))
+	require.NoError(t, repo.AddCompany(ctx, ev.ID, companies["F"].ID))
+	err = repo.AddCompany(ctx, ai.ID, uuid.New())
+	assert.True(t, domainerrors.IsConflict(err), "%v", err)
+
+	usages, err := repo.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, usages, 2)
+	assert.Equal(t, "ai", usages[0].Tag.Slug)
+	assert.Equal(t, int64(2), usages[0].Companies)
+	assert.Equal(t, int64(2), usages[1].Companies)
+
+	// El filtro de etiquetas exige todas
+	listed, total, err := companyRepo.List(ctx, interfaces.CompanyListFilter{Tags: []string{"ai", "electric-vehicles"}}, nil, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, listed, 1)
+	assert.Equal(t, "TSLA", listed[0].Ticker)
+
+	tags, err := repo.GetByCompanyID(ctx, companies["TSLA"].ID)
+	require.NoError(t, err)
+	require.Len(t, tags, 2)
+	assert.Equal(t, "ai", tags[0].Slug)
+
+	require.NoError(t, repo.RemoveCompany(ctx, ai.ID, companies["NVDA"].ID))
+	assert.True(t, domainerrors.IsNotFound(repo.RemoveCompany(ctx, ai.ID, companies["NVDA"].ID)))
+
+	// Eliminar la etiqueta o la company elimina sus asignaciones
+	require.NoError(t, repo.Delete(ctx, ai.ID))
+	assert.True(t, domainerrors.IsNotFound(repo.Delete(ctx, ai.ID)))
+	require.NoError(t, companyRepo.HardDelete(ctx, companies["F"].ID))
+	tags, err = repo.GetByCompanyID(ctx, companies["TSLA"].ID)
+	require.NoError(t, err)
+	require.Len(t, tags, 1)
+	usages, err = repo.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, usages, 1)
+	assert.Equal(t, int64(1), usages[0].Companies)
+}
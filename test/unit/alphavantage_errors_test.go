@@ -0,0 +1,107 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/config"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/alphavantage"
+)
+
+func TestAlphaVantageClient_ClassifiesErrors(t *testing.T) {
+	// Alpha Vantage responde con HTTP 200 y explica el fallo en el cuerpo
+	bodies := map[string]string{
+		"AAPL":   `{"Symbol": "AAPL", "Name": "Apple Inc"}`,
+		"LIMIT":  `{"Note": "Thank you for using Alpha Vantage! Our standard API call frequency is 5 calls per minute."}`,
+		"DAILY":  `{"Information": "We have detected your API key and our standard API rate limit is 25 requests per day."}`,
+		"BADSYM": `{"Error Message": "Invalid API call. Please retry or visit the documentation."}`,
+		"BADKEY": `{"Error Message": "the parameter apikey is invalid or missing. Please claim your free API key on (https://www.alphavantage.co/support/#api-key)."}`,
+		"BADFN":  `{"Error Message": "This API function (OVERVIEWS) does not exist."}`,
+		"EMPTY":  `{}`,
+		"HTML":   `<html>maintenance</html>`,
+		"PAID":   `{"Information": "Thank you for using Alpha Vantage! This is a premium endpoint."}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(bodies[r.URL.Query().Get("symbol")]))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{External: config.ExternalConfig{
+		Secondary:   config.APIConfig{Key: "test-key", BaseURL: server.URL},
+		KeyStrategy: "round_robin",
+	}}
+	client := alphavantage.NewClient(cfg, newEventBusTestLogger(t))
+	overview, err := client.GetCompanyOverview(context.Background(), "AAPL")
+	require.NoError(t, err)
+	assert.Equal(t, "Apple Inc", overview.Name)
+
+	tests := []struct {
+		symbol string
+		kind   error
+	}{
+		{"LIMIT", alphavantage.ErrRateLimited},
+		{"DAILY", alphavantage.ErrRateLimited},
+		{"BADSYM", alphavantage.ErrInvalidSymbol},
+		{"BADKEY", alphavantage.ErrRequestRejected},
+		{"BADFN", alphavantage.ErrRequestRejected},
+		{"EMPTY", alphavantage.ErrInvalidSymbol},
+		{"HTML", alphavantage.ErrMalformedResponse},
+		{"PAID", alphavantage.ErrPremiumEndpoint},
+	}
+	for _, tt := range tests {
+		t.Run(tt.symbol, func(t *testing.T) {
+			// Cliente nuevo en cada caso: una key que alcanza el límite queda apartada
+			client := alphavantage.NewClient(cfg, newEventBusTestLogger(t))
+			_, err := client.GetCompanyOverview(context.Background(), tt.symbol)
+			assert.ErrorIs(t, err, tt.kind)
+		})
+	}
+
+	// El límite diario se libera a medianoche UTC
+	_, err = alphavantage.NewClient(cfg, newEventBusTestLogger(t)).GetCompanyOverview(context.Background(), "DAILY")
+	var apiErr *alphavantage.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, time.Now().UTC().Truncate(24*time.Hour).Add(24*time.Hour), apiErr.RetryAt)
+}
+
+func TestAlphaVantageErrors_MapToHTTPStatus(t *testing.T) {
+	company := &entities.Company{ID: uuid.New(), Ticker: "AAPL"}
+	repo := &memoryStatementRepository{statements: map[string]*entities.FinancialStatement{}}
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"rate limited", &alphavantage.APIError{Kind: alphavantage.ErrRateLimited, RetryAt: time.Now().Add(time.Minute)}, http.StatusTooManyRequests},
+		{"invalid symbol", &alphavantage.APIError{Kind: alphavantage.ErrInvalidSymbol}, http.StatusNotFound},
+		{"malformed response", &alphavantage.APIError{Kind: alphavantage.ErrMalformedResponse}, http.StatusBadGateway},
+		{"invalid api key", &alphavantage.APIError{Kind: alphavantage.ErrRequestRejected}, http.StatusBadGateway},
+		{"transport failure", errors.New("connection reset"), http.StatusBadGateway},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &fakeStatementProvider{err: tt.err}
+			service := services.NewFinancialStatementService(&tickerCompanyRepository{company: company}, repo, provider, 24*time.Hour, newEventBusTestLogger(t))
+
+			_, err := service.GetStatements(context.Background(), "AAPL", nil)
+			var errorResp *response.ErrorResponse
+			require.True(t, errors.As(err, &errorResp))
+			assert.Equal(t, tt.status, errorResp.StatusCode)
+			if tt.status == http.StatusTooManyRequests {
+				assert.Contains(t, errorResp.Details, "retry_at")
+			}
+		})
+	}
+}
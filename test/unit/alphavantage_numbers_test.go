@@ -0,0 +1,115 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/alphavantage"
+)
+
+func TestAlphaVantageNumbers_ParseFloat(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    float64
+		ok      bool
+		wantErr bool
+	}{
+		{"123.45", 123.45, true, false},
+		{" -0.5 ", -0.5, true, false},
+		{"1.23E9", 1.23e9, true, false},
+		{"2,904,582,000", 2904582000, true, false},
+		{"0.5436%", 0.5436, true, false},
+		{"None", 0, false, false},
+		{"none", 0, false, false},
+		{"-", 0, false, false},
+		{"", 0, false, false},
+		{"N/A", 0, false, false},
+		{"abc", 0, false, true},
+		{"NaN", 0, false, true},
+		{"Inf", 0, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, ok, err := alphavantage.ParseFloat(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAlphaVantageNumbers_ParseInt(t *testing.T) {
+	got, ok, err := alphavantage.ParseInt("48087681")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, int64(48087681), got)
+
+	// Notación científica entera
+	got, ok, err = alphavantage.ParseInt("1.5E3")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1500), got)
+
+	_, ok, err = alphavantage.ParseInt("None")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	for _, value := range []string{"12.5", "1E30", "twelve"} {
+		_, _, err = alphavantage.ParseInt(value)
+		assert.Error(t, err, value)
+	}
+
+	// Los campos obligatorios distinguen el dato ausente del mal formado
+	_, err = alphavantage.RequireFloat("None")
+	assert.True(t, errors.Is(err, alphavantage.ErrMissingValue))
+	_, err = alphavantage.RequireInt("x")
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, alphavantage.ErrMissingValue))
+}
+
+func TestAlphaVantageAdapter_TimeSeriesNumbers(t *testing.T) {
+	adapter := alphavantage.NewAdapter(newEventBusTestLogger(t))
+
+	data, err := adapter.TimeSeriesDataToHistoricalData(context.Background(), &alphavantage.TimeSeriesDailyResponse{
+		TimeSeries: map[string]alphavantage.DailyStockData{
+			"2026-03-02": {Open: "100", High: "110", Low: "95", Close: "105", AdjustedClose: "None", Volume: "1.2E6"},
+			"2026-03-03": {Open: "105", High: "112", Low: "101", Close: "None", Volume: "900000"}, // Sin cierre: se descarta
+		},
+	}, "AAPL", uuid.New())
+	require.NoError(t, err)
+	require.Len(t, data, 1)
+
+	assert.Equal(t, 105.0, data[0].ClosePrice)
+	assert.Equal(t, 105.0, data[0].AdjustedClose) // Sin ajustado se usa el cierre
+	assert.Equal(t, int64(1200000), data[0].Volume)
+}
+
+func TestAlphaVantageAdapter_OverviewMissingValues(t *testing.T) {
+	adapter := alphavantage.NewAdapter(newEventBusTestLogger(t))
+
+	metrics, err := adapter.CompanyOverviewToFinancialMetrics(context.Background(), &alphavantage.CompanyOverviewResponse{
+		Symbol:               "AAPL",
+		MarketCapitalization: "2.9E12",
+		PERatio:              "None",
+		PEGRatio:             "-",
+		EPS:                  "6.43",
+		DividendYield:        "not-a-number",
+	}, uuid.New())
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(2.9e12), metrics.EnterpriseValue)
+	assert.Equal(t, 6.43, metrics.EPS)
+	assert.Zero(t, metrics.PERatio)
+	assert.Zero(t, metrics.PEGRatio)
+	assert.Zero(t, metrics.DividendYield)
+}
@@ -0,0 +1,81 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/implementation"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/database/cache"
+)
+
+// fakeAnalyticsViews counts the reads served from the views
+type fakeAnalyticsViews struct {
+	actionCalls int
+	refreshes   int
+}
+
+func (v *fakeAnalyticsViews) Refresh(ctx context.Context) (*interfaces.AnalyticsRefreshResult, error) {
+	v.refreshes++
+	return &interfaces.AnalyticsRefreshResult{RefreshedAt: time.Now()}, nil
+}
+
+func (v *fakeAnalyticsViews) GetTopCompaniesByRatingCount(ctx context.Context, days int, limit int) ([]interfaces.CompanyRatingCount, error) {
+	return []interfaces.CompanyRatingCount{{Ticker: "AAPL", RatingCount: 12}}, nil
+}
+
+func (v *fakeAnalyticsViews) GetActionTypeDistribution(ctx context.Context, days int) (map[string]int64, error) {
+	v.actionCalls++
+	return map[string]int64{"upgraded by": int64(v.actionCalls)}, nil
+}
+
+func (v *fakeAnalyticsViews) GetSectorDistribution(ctx context.Context) (map[string]int64, error) {
+	return map[string]int64{"Technology": 3}, nil
+}
+
+// emptyStockRatingRepository stands in for the live repository; analytics reads must not reach it
+type emptyStockRatingRepository struct {
+	interfaces.StockRatingRepository
+}
+
+func TestViewStockRatingRepository_ReadsAggregatesFromViews(t *testing.T) {
+	ctx := context.Background()
+	views := &fakeAnalyticsViews{}
+	repo := implementation.NewViewStockRatingRepository(&emptyStockRatingRepository{}, views)
+
+	top, err := repo.GetTopCompaniesByRatingCount(ctx, 30, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, "AAPL", top[0].Ticker)
+
+	distribution, err := repo.GetActionTypeDistribution(ctx, 30)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), distribution["upgraded by"])
+}
+
+func TestCachedAnalyticsViewRepository_RefreshInvalidatesCachedAggregates(t *testing.T) {
+	ctx := context.Background()
+	views := &fakeAnalyticsViews{}
+	cacheService := cache.NewMemoryCacheService()
+	repo := implementation.NewCachedStockRatingRepository(
+		implementation.NewViewStockRatingRepository(&emptyStockRatingRepository{}, views), cacheService, time.Minute)
+	refresher := implementation.NewCachedAnalyticsViewRepository(views, cacheService, time.Minute)
+
+	_, err := repo.GetActionTypeDistribution(ctx, 7)
+	assert.NoError(t, err)
+	_, err = repo.GetActionTypeDistribution(ctx, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, views.actionCalls)
+
+	_, err = refresher.Refresh(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, views.refreshes)
+
+	// Tras el refresco el agregado se vuelve a leer de la vista
+	distribution, err := repo.GetActionTypeDistribution(ctx, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, views.actionCalls)
+	assert.Equal(t, int64(2), distribution["upgraded by"])
+}
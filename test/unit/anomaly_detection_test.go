@@ -0,0 +1,71 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+func TestDetectRatingSpikes(t *testing.T) {
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	// Sin historial el suelo de la media (0.25) hace que 3 ratings en un día ya sean un pico
+	spikes := services.DetectRatingSpikes(map[time.Time]int{day: 3}, day, day)
+	require.Len(t, spikes, 1)
+	assert.Equal(t, day, spikes[0].Date)
+	assert.Equal(t, 3.0, spikes[0].Value)
+	assert.InDelta(t, 0, spikes[0].Baseline, 1e-9)
+	assert.InDelta(t, 12, spikes[0].Ratio, 1e-9)
+
+	// Con dos ratings diarios durante los 30 días anteriores, 5 no llega al triple y 6 sí
+	counts := map[time.Time]int{day: 5}
+	for k := 1; k <= 30; k++ {
+		counts[day.AddDate(0, 0, -k)] = 2
+	}
+	assert.Empty(t, services.DetectRatingSpikes(counts, day, day))
+
+	counts[day] = 6
+	spikes = services.DetectRatingSpikes(counts, day, day)
+	require.Len(t, spikes, 1)
+	assert.InDelta(t, 2, spikes[0].Baseline, 1e-9)
+	assert.InDelta(t, 3, spikes[0].Ratio, 1e-9)
+
+	// Menos de 3 ratings nunca es un pico y solo se revisan los días entre from y to
+	assert.Empty(t, services.DetectRatingSpikes(map[time.Time]int{day: 2}, day, day))
+	assert.Empty(t, services.DetectRatingSpikes(map[time.Time]int{day: 5}, day.AddDate(0, 0, 1), day.AddDate(0, 0, 7)))
+}
+
+func TestDetectVolumeSpikes(t *testing.T) {
+	start := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	sessions := make([]*entities.HistoricalData, 0, 22)
+	for i := 0; i < 20; i++ {
+		sessions = append(sessions, &entities.HistoricalData{Date: start.AddDate(0, 0, i), Volume: 1000})
+	}
+	spikeDay := start.AddDate(0, 0, 20)
+	sessions = append(sessions,
+		&entities.HistoricalData{Date: spikeDay, Volume: 3500},
+		&entities.HistoricalData{Date: start.AddDate(0, 0, 21), Volume: 1200},
+	)
+
+	// El orden de entrada no importa; la media es la de las 20 sesiones anteriores
+	reversed := make([]*entities.HistoricalData, len(sessions))
+	for i, session := range sessions {
+		reversed[len(sessions)-1-i] = session
+	}
+	spikes := services.DetectVolumeSpikes(reversed, start)
+	require.Len(t, spikes, 1)
+	assert.Equal(t, spikeDay, spikes[0].Date)
+	assert.InDelta(t, 1000, spikes[0].Baseline, 1e-9)
+	assert.InDelta(t, 3.5, spikes[0].Ratio, 1e-9)
+
+	// Las sesiones anteriores a from solo sirven de referencia
+	assert.Empty(t, services.DetectVolumeSpikes(sessions, start.AddDate(0, 0, 21)))
+
+	// Con menos de 10 sesiones previas no hay media fiable
+	assert.Empty(t, services.DetectVolumeSpikes(sessions[11:], start))
+}
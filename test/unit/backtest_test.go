@@ -0,0 +1,60 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+func backtestPrices(start time.Time, closes ...float64) []*entities.HistoricalData {
+	prices := make([]*entities.HistoricalData, len(closes))
+	for i, close := range closes {
+		prices[i] = &entities.HistoricalData{Date: start.AddDate(0, 0, i), ClosePrice: close}
+	}
+	return prices
+}
+
+func TestSimulateRatingStrategy(t *testing.T) {
+	start := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	prices := backtestPrices(start, 100, 110, 120, 90, 100)
+	ratings := []*entities.StockRating{
+		{Action: "downgraded by", EventTime: start.AddDate(0, 0, 2).Add(9 * time.Hour)},
+		{Action: "upgraded by", EventTime: start.Add(14 * time.Hour)},
+	}
+
+	// Compra a 100 con el upgrade y vende a 120 con el downgrade
+	curve, trades := services.SimulateRatingStrategy("AAA", prices, ratings,
+		services.BacktestStrategy{SellOnDowngrade: true, HoldingDays: 30}, 1000)
+	require.Len(t, trades, 1)
+	assert.Equal(t, services.ExitReasonDowngrade, trades[0].ExitReason)
+	assert.InDelta(t, 0.20, trades[0].Return, 1e-9)
+	require.Len(t, curve, 5)
+	assert.InDelta(t, 1200, curve[4].Equity, 1e-9)
+
+	// Sin vender con downgrades, el plazo de 3 días cierra a 90
+	_, trades = services.SimulateRatingStrategy("AAA", prices, ratings,
+		services.BacktestStrategy{HoldingDays: 3}, 1000)
+	require.Len(t, trades, 1)
+	assert.Equal(t, services.ExitReasonHoldingPeriod, trades[0].ExitReason)
+	assert.InDelta(t, -0.10, trades[0].Return, 1e-9)
+}
+
+func TestCombineEquityCurves(t *testing.T) {
+	day := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	curves := [][]response.EquityPoint{
+		{{Date: day, Equity: 510}, {Date: day.AddDate(0, 0, 1), Equity: 520}},
+		{{Date: day.AddDate(0, 0, 1), Equity: 490}},
+	}
+
+	combined := services.CombineEquityCurves(curves, 500)
+	require.Len(t, combined, 2)
+	// La segunda cartera aún no tiene sesión el primer día y conserva su capital inicial
+	assert.InDelta(t, 1010, combined[0].Equity, 1e-9)
+	assert.InDelta(t, 1010, combined[1].Equity, 1e-9)
+}
@@ -0,0 +1,505 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/config"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/alphavantage"
+	"github.com/MayaCris/stock-info-app/internal/testutil/testdoubles"
+)
+
+// memoryBenchmarkRepository guarda benchmarks, valores y periodos de pertenencia en memoria
+type memoryBenchmarkRepository struct {
+	benchmarks   []*entities.Benchmark
+	values       map[string]*entities.BenchmarkValue
+	constituents []*entities.BenchmarkConstituent
+}
+
+func newMemoryBenchmarkRepository(benchmarks ...*entities.Benchmark) *memoryBenchmarkRepository {
+	return &memoryBenchmarkRepository{benchmarks: benchmarks, values: make(map[string]*entities.BenchmarkValue)}
+}
+
+func (r *memoryBenchmarkRepository) GetAll(ctx context.Context) ([]*entities.Benchmark, error) {
+	return r.benchmarks, nil
+}
+
+func (r *memoryBenchmarkRepository) GetBySymbol(ctx context.Context, symbol string) (*entities.Benchmark, error) {
+	symbol = strings.ToUpper(symbol)
+	for _, benchmark := range r.benchmarks {
+		if benchmark.Symbol == symbol || benchmark.SourceSymbol == symbol {
+			return benchmark, nil
+		}
+	}
+	return nil, domainerrors.NotFound("benchmark %s not found", symbol)
+}
+
+func (r *memoryBenchmarkRepository) Create(ctx context.Context, benchmark *entities.Benchmark, constituents []*entities.BenchmarkConstituent) error {
+	if _, err := r.GetBySymbol(ctx, benchmark.Symbol); err == nil {
+		return domainerrors.Duplicate(nil, "benchmark %s already exists", benchmark.Symbol)
+	}
+	r.benchmarks = append(r.benchmarks, benchmark)
+	for _, constituent := range constituents {
+		constituent.BenchmarkID = benchmark.ID
+		r.constituents = append(r.constituents, constituent)
+	}
+	return nil
+}
+
+func (r *memoryBenchmarkRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	for i, benchmark := range r.benchmarks {
+		if benchmark.ID == id {
+			r.benchmarks = append(r.benchmarks[:i], r.benchmarks[i+1:]...)
+			return nil
+		}
+	}
+	return domainerrors.NotFound("benchmark %s not found", id)
+}
+
+func (r *memoryBenchmarkRepository) MarkSynced(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return nil
+}
+
+func (r *memoryBenchmarkRepository) UpsertValues(ctx context.Context, values []*entities.BenchmarkValue) error {
+	for _, value := range values {
+		r.values[value.BenchmarkID.String()+value.Date.Format("2006-01-02")] = value
+	}
+	return nil
+}
+
+func (r *memoryBenchmarkRepository) GetValues(ctx context.Context, benchmarkID uuid.UUID, from, to time.Time) ([]*entities.BenchmarkValue, error) {
+	var result []*entities.BenchmarkValue
+	for _, value := range r.values {
+		if value.BenchmarkID == benchmarkID && !value.Date.Before(from) && !value.Date.After(to) {
+			result = append(result, value)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Date.Before(result[j].Date) })
+	return result, nil
+}
+
+func (r *memoryBenchmarkRepository) SyncConstituents(ctx context.Context, benchmarkID uuid.UUID, constituents []*entities.BenchmarkConstituent, asOf time.Time) (int, int, error) {
+	asOf = asOf.UTC().Truncate(24 * time.Hour)
+	current := make(map[string]*entities.BenchmarkConstituent)
+	for _, member := range r.constituents {
+		if member.BenchmarkID == benchmarkID && member.RemovedAt == nil {
+			current[member.Symbol] = member
+		}
+	}
+	added, removed := 0, 0
+	seen := make(map[string]bool)
+	for _, constituent := range constituents {
+		seen[constituent.Symbol] = true
+		if member, ok := current[constituent.Symbol]; ok {
+			member.Weight, member.CompanyID = constituent.Weight, constituent.CompanyID
+			continue
+		}
+		constituent.BenchmarkID, constituent.AddedAt = benchmarkID, asOf
+		r.constituents = append(r.constituents, constituent)
+		added++
+	}
+	for symbol, member := range current {
+		if !seen[symbol] {
+			removedAt := asOf
+			member.RemovedAt = &removedAt
+			removed++
+		}
+	}
+	return added, removed, nil
+}
+
+func (r *memoryBenchmarkRepository) GetConstituents(ctx context.Context, benchmarkID uuid.UUID, date time.Time) ([]*entities.BenchmarkConstituent, error) {
+	var result []*entities.BenchmarkConstituent
+	for _, member := range r.constituents {
+		if member.BenchmarkID == benchmarkID && member.IsMemberOn(date) {
+			result = append(result, member)
+		}
+	}
+	return result, nil
+}
+
+// fakeBenchmarkProvider devuelve valores y miembros fijos; constituentsErr simula un fallo de los holdings
+type fakeBenchmarkProvider struct {
+	values          []*entities.BenchmarkValue
+	constituents    []*entities.BenchmarkConstituent
+	constituentsErr error
+}
+
+func (p *fakeBenchmarkProvider) FetchValues(ctx context.Context, benchmark *entities.Benchmark, full bool) ([]*entities.BenchmarkValue, error) {
+	for _, value := range p.values {
+		value.BenchmarkID = benchmark.ID
+	}
+	return p.values, nil
+}
+
+func (p *fakeBenchmarkProvider) FetchConstituents(ctx context.Context, benchmark *entities.Benchmark) ([]*entities.BenchmarkConstituent, error) {
+	return p.constituents, p.constituentsErr
+}
+
+func (p *fakeBenchmarkProvider) Source() string {
+	return "fake"
+}
+
+func TestBenchmarkService_SyncAndQuery(t *testing.T) {
+	store := testdoubles.NewStore()
+	companyRepo := testdoubles.NewCompanyRepository(store)
+	ctx := context.Background()
+
+	apple := entities.NewCompany("AAPL", "Apple Inc.")
+	require.NoError(t, companyRepo.Create(ctx, apple))
+
+	spx := entities.NewBenchmark("SPX", "S&P 500", "SPY")
+	repo := newMemoryBenchmarkRepository(spx)
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+	weight := 0.07
+	provider := &fakeBenchmarkProvider{
+		values: []*entities.BenchmarkValue{
+			{Date: day(2), Close: 500, AdjustedClose: 500},
+			{Date: day(3), Close: 505, AdjustedClose: 505},
+			{Date: day(4), Close: 510, AdjustedClose: 510},
+		},
+		constituents: []*entities.BenchmarkConstituent{
+			{Symbol: "AAPL", Weight: &weight},
+			{Symbol: "XYZ"},
+		},
+	}
+	service := services.NewBenchmarkService(repo, companyRepo, nil, provider, newEventBusTestLogger(t))
+
+	// Se puede sincronizar por el índice o por el ETF que lo replica
+	result, err := service.Sync(ctx, "spy", &request.BenchmarkSyncRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "SPX", result.Benchmark.Symbol)
+	assert.Equal(t, 3, result.Values)
+	assert.Equal(t, 2, result.ConstituentsAdded)
+	assert.Empty(t, result.Warnings)
+	require.NotNil(t, result.Benchmark.LastSyncedAt)
+
+	values, err := service.GetValues(ctx, "SPX", &request.BenchmarkValuesRequest{From: "2026-03-01", To: "2026-03-31"})
+	require.NoError(t, err)
+	require.Len(t, values.Values, 3)
+	require.NotNil(t, values.Return)
+	assert.Equal(t, 0.02, *values.Return)
+
+	constituents, err := service.GetConstituents(ctx, "SPX", &request.BenchmarkConstituentsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, 2, constituents.Count)
+	// Solo los miembros con company registrada quedan enlazados
+	assert.Equal(t, &apple.ID, constituents.Constituents[0].CompanyID)
+	assert.Nil(t, constituents.Constituents[1].CompanyID)
+
+	// Si fallan los holdings los valores se guardan igual y la composición no cambia
+	provider.constituents, provider.constituentsErr = nil, errors.New("holdings unavailable")
+	result, err = service.Sync(ctx, "SPX", &request.BenchmarkSyncRequest{})
+	require.NoError(t, err)
+	assert.Len(t, result.Warnings, 1)
+	assert.Zero(t, result.ConstituentsRemoved)
+
+	_, err = service.GetValues(ctx, "SPX", &request.BenchmarkValuesRequest{From: "2026-04-01", To: "2026-03-01"})
+	assert.Error(t, err)
+	_, err = service.GetValues(ctx, "DAX", &request.BenchmarkValuesRequest{})
+	var errorResp *response.ErrorResponse
+	require.True(t, errors.As(err, &errorResp))
+	assert.Equal(t, response.ErrCodeNotFound, errorResp.Code)
+
+	// Sin proveedor solo se sirven los valores guardados
+	withoutProvider := services.NewBenchmarkService(repo, companyRepo, nil, nil, newEventBusTestLogger(t))
+	_, err = withoutProvider.Sync(ctx, "SPX", &request.BenchmarkSyncRequest{})
+	assert.Error(t, err)
+}
+
+// riskHistoricalRepo devuelve los precios guardados de cada símbolo
+type riskHistoricalRepo struct {
+	repoInterfaces.HistoricalDataRepository
+	prices map[string][]*entities.HistoricalData
+}
+
+func (r *riskHistoricalRepo) GetBySymbol(ctx context.Context, symbol string, startDate, endDate time.Time) ([]*entities.HistoricalData, error) {
+	return r.prices[symbol], nil
+}
+
+func TestAnalysisService_PortfolioRiskAgainstBenchmarkValues(t *testing.T) {
+	store := testdoubles.NewStore()
+	companyRepo := testdoubles.NewCompanyRepository(store)
+	ctx := context.Background()
+
+	apple := entities.NewCompany("AAPL", "Apple Inc.")
+	require.NoError(t, companyRepo.Create(ctx, apple))
+
+	spx := entities.NewBenchmark("SPX", "S&P 500", "SPY")
+	benchmarkRepo := newMemoryBenchmarkRepository(spx)
+
+	// AAPL se mueve el doble que el índice; los precios guardados de SPY no siguen al índice
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	history := &riskHistoricalRepo{prices: make(map[string][]*entities.HistoricalData)}
+	moves := []float64{0.01, -0.02, 0.015, 0.005, -0.01, 0.02}
+	index, stock := 100.0, 50.0
+	for i, move := range append([]float64{0}, moves...) {
+		date := today.AddDate(0, 0, i-len(moves))
+		index *= 1 + move
+		stock *= 1 + 2*move
+		require.NoError(t, benchmarkRepo.UpsertValues(ctx, []*entities.BenchmarkValue{{BenchmarkID: spx.ID, Date: date, Close: index}}))
+		history.prices["AAPL"] = append(history.prices["AAPL"], &entities.HistoricalData{Symbol: "AAPL", Date: date, ClosePrice: stock})
+		history.prices["SPY"] = append(history.prices["SPY"], &entities.HistoricalData{Symbol: "SPY", Date: date, ClosePrice: 400 + float64(i%2)})
+	}
+
+	service := services.NewAnalysisService(nil, companyRepo, nil, nil, history, nil, benchmarkRepo, nil, nil, nil, 0, services.DefaultScoringWeights(), newEventBusTestLogger(t))
+
+	risk, err := service.GetPortfolioRisk(ctx, &request.PortfolioRiskRequest{Holdings: "AAPL"})
+	require.NoError(t, err)
+	assert.Equal(t, "SPY", risk.Benchmark)
+	assert.Equal(t, "S&P 500", risk.BenchmarkName)
+	require.NotNil(t, risk.Portfolio.Beta)
+	assert.InDelta(t, 2.0, *risk.Portfolio.Beta, 1e-9)
+	require.NotNil(t, risk.BenchmarkRisk)
+	assert.InDelta(t, 1.0, *risk.BenchmarkRisk.Beta, 1e-9)
+	assert.Empty(t, risk.Warnings)
+
+	// Sin benchmarks registrados se usan los precios guardados del símbolo
+	withoutBenchmarks := services.NewAnalysisService(nil, companyRepo, nil, nil, history, nil, nil, nil, nil, nil, 0, services.DefaultScoringWeights(), newEventBusTestLogger(t))
+	risk, err = withoutBenchmarks.GetPortfolioRisk(ctx, &request.PortfolioRiskRequest{Holdings: "AAPL"})
+	require.NoError(t, err)
+	assert.Empty(t, risk.BenchmarkName)
+	require.NotNil(t, risk.Portfolio.Beta)
+	assert.NotEqual(t, 2.0, *risk.Portfolio.Beta)
+
+	// Pesos no finitos se rechazan antes de calcular nada
+	for _, holdings := range []string{"AAPL:NaN", "AAPL:Inf", "AAPL:1e308,MSFT:1e308"} {
+		_, err = service.GetPortfolioRisk(ctx, &request.PortfolioRiskRequest{Holdings: holdings})
+		var errorResp *response.ErrorResponse
+		require.True(t, errors.As(err, &errorResp), holdings)
+		assert.Equal(t, http.StatusBadRequest, errorResp.StatusCode, holdings)
+	}
+}
+
+func TestCustomIndexValues_EqualAndCapWeighting(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+	series := func(symbol string, closes ...float64) []*entities.HistoricalData {
+		prices := make([]*entities.HistoricalData, len(closes))
+		for i, closeValue := range closes {
+			prices[i] = &entities.HistoricalData{Symbol: symbol, Date: day(2 + i), ClosePrice: closeValue, TimeFrame: "1D"}
+		}
+		return prices
+	}
+	// AAA sube 10% y luego 10%; BBB no se mueve y luego cae 10%
+	prices := map[string][]*entities.HistoricalData{
+		"AAA": series("AAA", 100, 110, 121),
+		"BBB": series("BBB", 50, 50, 45),
+	}
+	// Las sesiones anteriores a la fecha base y las barras semanales no cuentan
+	prices["AAA"] = append(prices["AAA"],
+		&entities.HistoricalData{Symbol: "AAA", Date: day(1), ClosePrice: 10},
+		&entities.HistoricalData{Symbol: "AAA", Date: day(9), ClosePrice: 500, TimeFrame: "1W"})
+
+	equal := services.CustomIndexValues(prices, map[string]float64{"AAA": 0.5, "BBB": 0.5}, true, day(2))
+	require.Len(t, equal, 3)
+	assert.True(t, equal[0].Date.Equal(day(2)))
+	assert.InDelta(t, 100.0, equal[0].Close, 1e-9)
+	assert.InDelta(t, 105.0, equal[1].Close, 1e-9)
+	// Rebalanceado: (10% - 10%) / 2 = 0
+	assert.InDelta(t, 105.0, equal[2].Close, 1e-9)
+	assert.Equal(t, equal[2].Close, equal[2].Price())
+
+	// Por capitalización los pesos derivan: AAA pesa 0.55/1.05 el segundo día
+	capWeighted := services.CustomIndexValues(prices, map[string]float64{"AAA": 0.5, "BBB": 0.5}, false, day(2))
+	require.Len(t, capWeighted, 3)
+	assert.InDelta(t, 105.0, capWeighted[1].Close, 1e-9)
+	assert.InDelta(t, 105*(1+(0.55*0.1-0.5*0.1)/1.05), capWeighted[2].Close, 1e-9)
+}
+
+func TestBenchmarkService_CustomBenchmark(t *testing.T) {
+	store := testdoubles.NewStore()
+	companyRepo := testdoubles.NewCompanyRepository(store)
+	ctx := context.Background()
+
+	apple := entities.NewCompany("AAPL", "Apple Inc.")
+	apple.MarketCap = 3000000
+	microsoft := entities.NewCompany("MSFT", "Microsoft Corp.")
+	microsoft.MarketCap = 1000000
+	tesla := entities.NewCompany("TSLA", "Tesla Inc.")
+	for _, company := range []*entities.Company{apple, microsoft, tesla} {
+		require.NoError(t, companyRepo.Create(ctx, company))
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	history := &riskHistoricalRepo{prices: make(map[string][]*entities.HistoricalData)}
+	for i, closeValue := range []float64{100, 102, 101, 104} {
+		date := today.AddDate(0, 0, i-3)
+		history.prices["AAPL"] = append(history.prices["AAPL"], &entities.HistoricalData{Symbol: "AAPL", Date: date, ClosePrice: closeValue})
+		history.prices["MSFT"] = append(history.prices["MSFT"], &entities.HistoricalData{Symbol: "MSFT", Date: date, ClosePrice: 2 * closeValue})
+	}
+
+	spx := entities.NewBenchmark("SPX", "S&P 500", "SPY")
+	repo := newMemoryBenchmarkRepository(spx)
+	service := services.NewBenchmarkService(repo, companyRepo, history, nil, newEventBusTestLogger(t))
+	baseDate := today.AddDate(0, 0, -10).Format("2006-01-02")
+
+	result, err := service.CreateCustom(ctx, &request.CreateCustomBenchmarkRequest{
+		Symbol: "big2", Name: "Big two", Tickers: []string{"aapl", "MSFT", "AAPL"}, Weighting: "cap", BaseDate: baseDate,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "BIG2", result.Benchmark.Symbol)
+	assert.Equal(t, entities.BenchmarkKindCustom, result.Benchmark.Kind)
+	assert.Equal(t, 2, result.ConstituentsAdded)
+	assert.Equal(t, 4, result.Values)
+
+	constituents, err := service.GetConstituents(ctx, "BIG2", &request.BenchmarkConstituentsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, 2, constituents.Count)
+	assert.InDelta(t, 0.75, *constituents.Constituents[0].Weight, 1e-9)
+
+	// Ambos miembros se mueven igual, así que el índice también
+	values, err := service.GetValues(ctx, "BIG2", &request.BenchmarkValuesRequest{})
+	require.NoError(t, err)
+	require.Len(t, values.Values, 4)
+	assert.InDelta(t, 100.0, values.Values[0].Close, 1e-9)
+	require.NotNil(t, values.Return)
+	assert.InDelta(t, 0.04, *values.Return, 1e-9)
+
+	// Sin proveedor el índice personalizado se recalcula igualmente
+	synced, err := service.Sync(ctx, "BIG2", &request.BenchmarkSyncRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 4, synced.Values)
+
+	var errorResp *response.ErrorResponse
+	_, err = service.CreateCustom(ctx, &request.CreateCustomBenchmarkRequest{Symbol: "BIG2", Name: "Again", Tickers: []string{"AAPL", "MSFT"}})
+	require.True(t, errors.As(err, &errorResp))
+	assert.Equal(t, response.ErrCodeConflict, errorResp.Code)
+	// El símbolo de una empresa registrada no puede ser un índice
+	_, err = service.CreateCustom(ctx, &request.CreateCustomBenchmarkRequest{Symbol: "tsla", Name: "Tesla", Tickers: []string{"AAPL", "MSFT"}})
+	require.True(t, errors.As(err, &errorResp))
+	assert.Equal(t, response.ErrCodeConflict, errorResp.Code)
+	// TSLA no tiene capitalización ni precios
+	_, err = service.CreateCustom(ctx, &request.CreateCustomBenchmarkRequest{Symbol: "MIX", Name: "Mix", Tickers: []string{"AAPL", "TSLA"}, Weighting: "cap"})
+	assert.Error(t, err)
+	_, err = service.CreateCustom(ctx, &request.CreateCustomBenchmarkRequest{Symbol: "ONE", Name: "One", Tickers: []string{"AAPL", "aapl"}})
+	assert.Error(t, err)
+	_, err = service.CreateCustom(ctx, &request.CreateCustomBenchmarkRequest{Symbol: "GHOST", Name: "Ghost", Tickers: []string{"AAPL", "ZZZZ"}})
+	assert.Error(t, err)
+
+	// Solo se eliminan los personalizados
+	assert.Error(t, service.DeleteCustom(ctx, "SPX"))
+	require.NoError(t, service.DeleteCustom(ctx, "big2"))
+	_, err = service.GetValues(ctx, "BIG2", &request.BenchmarkValuesRequest{})
+	assert.Error(t, err)
+}
+
+func TestBenchmarkService_CustomBenchmarkOwnerAndRefresh(t *testing.T) {
+	store := testdoubles.NewStore()
+	companyRepo := testdoubles.NewCompanyRepository(store)
+	background := context.Background()
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	history := &riskHistoricalRepo{prices: make(map[string][]*entities.HistoricalData)}
+	for _, ticker := range []string{"AAPL", "MSFT"} {
+		require.NoError(t, companyRepo.Create(background, entities.NewCompany(ticker, ticker+" Inc.")))
+		for i, closeValue := range []float64{100, 102, 101} {
+			history.prices[ticker] = append(history.prices[ticker], &entities.HistoricalData{Symbol: ticker, Date: today.AddDate(0, 0, i-2), ClosePrice: closeValue})
+		}
+	}
+
+	repo := newMemoryBenchmarkRepository()
+	service := services.NewBenchmarkService(repo, companyRepo, history, nil, newEventBusTestLogger(t))
+	tenantID := uuid.New()
+	alice, bob := noteUserContext(tenantID, "alice"), noteUserContext(tenantID, "bob")
+	create := func(ctx context.Context, symbol string) {
+		_, err := service.CreateCustom(ctx, &request.CreateCustomBenchmarkRequest{Symbol: symbol, Name: symbol, Tickers: []string{"AAPL", "MSFT"}})
+		require.NoError(t, err)
+	}
+	create(alice, "DUO")
+	create(background, "PAIR")
+
+	// Solo la credencial que creó el índice lo elimina; los creados sin tenant, solo sin tenant
+	var errorResp *response.ErrorResponse
+	require.True(t, errors.As(service.DeleteCustom(bob, "DUO"), &errorResp))
+	assert.Equal(t, response.ErrCodeForbidden, errorResp.Code)
+	require.True(t, errors.As(service.DeleteCustom(alice, "PAIR"), &errorResp))
+	assert.Equal(t, response.ErrCodeForbidden, errorResp.Code)
+	require.True(t, errors.As(service.DeleteCustom(background, "DUO"), &errorResp))
+	assert.Equal(t, response.ErrCodeForbidden, errorResp.Code)
+	require.NoError(t, service.DeleteCustom(alice, "duo"))
+	require.NoError(t, service.DeleteCustom(background, "PAIR"))
+
+	// Leer los valores no los recalcula: el job benchmark_refresh recalcula los que no están al día
+	create(background, "DUO")
+	duo, err := repo.GetBySymbol(background, "DUO")
+	require.NoError(t, err)
+	yesterday := today.Add(-time.Hour)
+	duo.LastSyncedAt = &yesterday
+	for key := range repo.values {
+		delete(repo.values, key)
+	}
+	values, err := service.GetValues(background, "DUO", &request.BenchmarkValuesRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, values.Values)
+
+	refreshed, err := service.RefreshCustom(background)
+	require.NoError(t, err)
+	assert.Equal(t, 1, refreshed.Benchmarks)
+	assert.Equal(t, 1, refreshed.Refreshed)
+	assert.Empty(t, refreshed.Failed)
+	values, err = service.GetValues(background, "DUO", &request.BenchmarkValuesRequest{})
+	require.NoError(t, err)
+	assert.Len(t, values.Values, 3)
+
+	// Ya calculado hoy
+	refreshed, err = service.RefreshCustom(background)
+	require.NoError(t, err)
+	assert.Equal(t, 0, refreshed.Refreshed)
+}
+
+func TestAlphaVantageBenchmarkProvider_ValuesAndHoldings(t *testing.T) {
+	bodies := map[string]string{
+		"TIME_SERIES_DAILY_ADJUSTED": `{"Time Series (Daily)": {
+			"2026-03-03": {"1. open": "580.10", "2. high": "584.00", "3. low": "579.50", "4. close": "583.20", "5. adjusted close": "581.90", "6. volume": "51234567"},
+			"2026-03-02": {"1. open": "575.00", "2. high": "580.50", "3. low": "574.20", "4. close": "579.80", "5. adjusted close": "578.50", "6. volume": "48000000"},
+			"bad-date": {"4. close": "1"}}}`,
+		"ETF_PROFILE": `{"net_assets": "600000000000", "holdings": [
+			{"symbol": "NVDA", "description": "NVIDIA CORP", "weight": "0.0712"},
+			{"symbol": "aapl", "description": "APPLE INC", "weight": "0.0655"},
+			{"symbol": "n/a", "description": "CASH", "weight": "0.0010"}]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "SPY", r.URL.Query().Get("symbol"))
+		w.Write([]byte(bodies[r.URL.Query().Get("function")]))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{External: config.ExternalConfig{
+		Secondary:   config.APIConfig{Key: "test-key", BaseURL: server.URL},
+		KeyStrategy: "round_robin",
+	}}
+	provider := alphavantage.NewBenchmarkProvider(alphavantage.NewClient(cfg, newEventBusTestLogger(t)), newEventBusTestLogger(t))
+	spx := entities.NewBenchmark("SPX", "S&P 500", "SPY")
+
+	values, err := provider.FetchValues(context.Background(), spx, false)
+	require.NoError(t, err)
+	require.Len(t, values, 2)
+	sort.Slice(values, func(i, j int) bool { return values[i].Date.Before(values[j].Date) })
+	assert.Equal(t, spx.ID, values[1].BenchmarkID)
+	assert.Equal(t, 583.20, values[1].Close)
+	assert.Equal(t, 581.90, values[1].Price())
+	assert.Equal(t, int64(51234567), values[1].Volume)
+
+	constituents, err := provider.FetchConstituents(context.Background(), spx)
+	require.NoError(t, err)
+	require.Len(t, constituents, 2)
+	assert.Equal(t, "AAPL", constituents[1].Symbol)
+	require.NotNil(t, constituents[0].Weight)
+	assert.Equal(t, 0.0712, *constituents[0].Weight)
+}
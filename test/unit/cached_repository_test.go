@@ -0,0 +1,106 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/implementation"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/database/cache"
+)
+
+// countingCompanyRepository implements only the methods exercised by the caching decorator
+type countingCompanyRepository struct {
+	interfaces.CompanyRepository
+	tickerCalls int
+}
+
+func (r *countingCompanyRepository) GetByTicker(ctx context.Context, ticker string) (*entities.Company, error) {
+	r.tickerCalls++
+	return &entities.Company{Ticker: ticker, Name: "Apple Inc.", Version: int64(r.tickerCalls)}, nil
+}
+
+func (r *countingCompanyRepository) Update(ctx context.Context, company *entities.Company, hooks ...interfaces.WriteHook[entities.Company]) error {
+	return nil
+}
+
+func (r *countingCompanyRepository) RemapTicker(ctx context.Context, id uuid.UUID, newTicker string, hooks ...interfaces.WriteHook[entities.Company]) (*entities.Company, error) {
+	return &entities.Company{ID: id, Ticker: newTicker}, nil
+}
+
+func (r *countingCompanyRepository) MergeInto(ctx context.Context, duplicateID, targetID uuid.UUID, dryRun bool) (*interfaces.CompanyMergeResult, error) {
+	return &interfaces.CompanyMergeResult{}, nil
+}
+
+func TestCachedCompanyRepository_CachesReadsAndInvalidatesOnWrite(t *testing.T) {
+	ctx := context.Background()
+	inner := &countingCompanyRepository{}
+	repo := implementation.NewCachedCompanyRepository(inner, cache.NewMemoryCacheService(), time.Minute)
+
+	first, err := repo.GetByTicker(ctx, "AAPL")
+	assert.NoError(t, err)
+	second, err := repo.GetByTicker(ctx, "aapl")
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, inner.tickerCalls)
+	assert.Equal(t, first.Name, second.Name)
+
+	assert.NoError(t, repo.Update(ctx, first))
+
+	third, err := repo.GetByTicker(ctx, "AAPL")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, inner.tickerCalls)
+	assert.Equal(t, int64(2), third.Version)
+}
+
+func TestCachedCompanyRepository_DisabledWithoutTTL(t *testing.T) {
+	inner := &countingCompanyRepository{}
+	repo := implementation.NewCachedCompanyRepository(inner, cache.NewMemoryCacheService(), 0)
+
+	assert.Same(t, inner, repo)
+}
+
+func TestCachedCompanyRepository_RemapTickerInvalidatesLookups(t *testing.T) {
+	ctx := context.Background()
+	inner := &countingCompanyRepository{}
+	repo := implementation.NewCachedCompanyRepository(inner, cache.NewMemoryCacheService(), time.Minute)
+
+	_, err := repo.GetByTicker(ctx, "FB")
+	assert.NoError(t, err)
+
+	company, err := repo.RemapTicker(ctx, uuid.New(), "META")
+	assert.NoError(t, err)
+	assert.Equal(t, "META", company.Ticker)
+
+	// La búsqueda por el ticker anterior vuelve al repositorio para resolver el alias
+	_, err = repo.GetByTicker(ctx, "FB")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, inner.tickerCalls)
+}
+
+func TestCachedCompanyRepository_MergeInvalidatesOnlyWhenApplied(t *testing.T) {
+	ctx := context.Background()
+	inner := &countingCompanyRepository{}
+	repo := implementation.NewCachedCompanyRepository(inner, cache.NewMemoryCacheService(), time.Minute)
+
+	_, err := repo.GetByTicker(ctx, "GOOG")
+	assert.NoError(t, err)
+
+	// Un dry-run no modifica datos, la cache sigue siendo válida
+	_, err = repo.MergeInto(ctx, uuid.New(), uuid.New(), true)
+	assert.NoError(t, err)
+	_, err = repo.GetByTicker(ctx, "GOOG")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, inner.tickerCalls)
+
+	_, err = repo.MergeInto(ctx, uuid.New(), uuid.New(), false)
+	assert.NoError(t, err)
+	_, err = repo.GetByTicker(ctx, "GOOG")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, inner.tickerCalls)
+}
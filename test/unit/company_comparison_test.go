@@ -0,0 +1,38 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+)
+
+func float64Ptr(v float64) *float64 { return &v }
+
+func TestNormalizeComparisonScores(t *testing.T) {
+	scores := services.NormalizeComparisonScores([]*float64{float64Ptr(10), nil, float64Ptr(30), float64Ptr(20)}, false)
+	require.Len(t, scores, 4)
+	assert.Equal(t, 0.0, *scores[0])
+	assert.Nil(t, scores[1])
+	assert.Equal(t, 1.0, *scores[2])
+	assert.Equal(t, 0.5, *scores[3])
+
+	// En los múltiplos de valoración el menor es el mejor
+	scores = services.NormalizeComparisonScores([]*float64{float64Ptr(15), float64Ptr(45)}, true)
+	assert.Equal(t, 1.0, *scores[0])
+	assert.Equal(t, 0.0, *scores[1])
+
+	// Con un solo valor no hay nada que comparar
+	scores = services.NormalizeComparisonScores([]*float64{float64Ptr(15), nil}, false)
+	assert.Nil(t, scores[0])
+}
+
+func TestPeriodReturn(t *testing.T) {
+	closes := []float64{110, 105, 100}
+	require.NotNil(t, services.PeriodReturn(closes, 2))
+	assert.InDelta(t, 10.0, *services.PeriodReturn(closes, 2), 1e-9)
+	assert.Nil(t, services.PeriodReturn(closes, 3))
+	assert.Nil(t, services.PeriodReturn([]float64{110, 0}, 1))
+}
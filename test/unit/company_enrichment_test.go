@@ -0,0 +1,75 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MayaCris/stock-info-app/internal/application/usecases/enrichment"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+func TestEnrichCompany_BackfillsMissingFields(t *testing.T) {
+	company := &entities.Company{ID: uuid.New(), Ticker: "AAPL", Name: "Apple Inc"}
+	profile := &entities.CompanyProfile{
+		Symbol:     "AAPL",
+		Sector:     "Technology",
+		Exchange:   "nasdaq",
+		MarketCap:  3_000_000_000_000,
+		DataSource: "finnhub",
+	}
+
+	filled, conflicts := enrichment.EnrichCompany(company, profile, enrichment.DefaultMarketCapTolerance)
+
+	assert.ElementsMatch(t, []string{
+		entities.EnrichmentFieldSector,
+		entities.EnrichmentFieldExchange,
+		entities.EnrichmentFieldMarketCap,
+	}, filled)
+	assert.Empty(t, conflicts)
+	assert.Equal(t, "Technology", company.Sector)
+	assert.Equal(t, "NASDAQ", company.Exchange)
+	assert.Equal(t, 3_000_000.0, company.MarketCap) // En millones USD
+}
+
+func TestEnrichCompany_FlagsConflictsWithoutOverwriting(t *testing.T) {
+	company := &entities.Company{
+		ID:        uuid.New(),
+		Ticker:    "AAPL",
+		Sector:    "technology",
+		Exchange:  "NYSE",
+		MarketCap: 1_000,
+	}
+	profile := &entities.CompanyProfile{
+		Symbol:     "AAPL",
+		Sector:     "Technology",
+		Exchange:   "NASDAQ",
+		MarketCap:  3_000_000_000_000,
+		DataSource: "finnhub",
+	}
+
+	filled, conflicts := enrichment.EnrichCompany(company, profile, enrichment.DefaultMarketCapTolerance)
+
+	assert.Empty(t, filled)
+	assert.Len(t, conflicts, 2) // El sector solo difiere en mayúsculas
+	assert.Equal(t, "NYSE", company.Exchange)
+	assert.Equal(t, 1_000.0, company.MarketCap)
+
+	assert.Equal(t, entities.EnrichmentFieldExchange, conflicts[0].Field)
+	assert.Equal(t, "NYSE", conflicts[0].CompanyValue)
+	assert.Equal(t, "NASDAQ", conflicts[0].ProfileValue)
+	assert.Equal(t, entities.EnrichmentConflictStatusPending, conflicts[0].Status)
+	assert.Equal(t, entities.EnrichmentFieldMarketCap, conflicts[1].Field)
+	assert.Equal(t, "3000000.00", conflicts[1].ProfileValue)
+}
+
+func TestEnrichCompany_MarketCapWithinToleranceIsNotAConflict(t *testing.T) {
+	company := &entities.Company{ID: uuid.New(), Ticker: "MSFT", MarketCap: 2_900_000}
+	profile := &entities.CompanyProfile{Symbol: "MSFT", MarketCap: 3_000_000_000_000}
+
+	filled, conflicts := enrichment.EnrichCompany(company, profile, enrichment.DefaultMarketCapTolerance)
+
+	assert.Empty(t, filled)
+	assert.Empty(t, conflicts)
+}
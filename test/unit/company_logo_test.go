@@ -0,0 +1,164 @@
+package unit
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	serviceInterfaces "github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/storage"
+)
+
+// fakeLogoCompanyRepository resuelve companies por ticker desde un mapa
+type fakeLogoCompanyRepository struct {
+	interfaces.CompanyRepository
+	companies map[string]*entities.Company
+}
+
+func (r *fakeLogoCompanyRepository) GetByTicker(ctx context.Context, ticker string) (*entities.Company, error) {
+	company, ok := r.companies[ticker]
+	if !ok {
+		return nil, response.NotFound("Company")
+	}
+	return company, nil
+}
+
+func TestCompanyLogoService_ResizesAndCaches(t *testing.T) {
+	ctx := context.Background()
+
+	// Logo original de 300x150 servido por el "proveedor"
+	var buf bytes.Buffer
+	original := image.NewRGBA(image.Rect(0, 0, 300, 150))
+	for x := 0; x < 300; x++ {
+		for y := 0; y < 150; y++ {
+			original.Set(x, y, color.RGBA{R: 200, A: 255})
+		}
+	}
+	require.NoError(t, png.Encode(&buf, original))
+
+	var downloads atomic.Int32
+	provider := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downloads.Add(1)
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(buf.Bytes())
+	}))
+	defer provider.Close()
+
+	store, err := storage.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	companies := &fakeLogoCompanyRepository{companies: map[string]*entities.Company{
+		"AAPL": {Ticker: "AAPL", Logo: provider.URL + "/aapl.png"},
+		"NOLG": {Ticker: "NOLG"},
+	}}
+	service := services.NewCompanyLogoService(companies, store, services.CompanyLogoOptions{
+		DefaultSize:  128,
+		MaxSize:      512,
+		MaxBytes:     1 << 20,
+		FetchTimeout: 5 * time.Second,
+		HTTPClient:   provider.Client(),
+	}, newEventBusTestLogger(t))
+
+	logo, err := service.GetLogo(ctx, "aapl", 0)
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", logo.ContentType)
+	assert.NotEmpty(t, logo.ETag)
+
+	resized, err := png.Decode(bytes.NewReader(logo.Data))
+	require.NoError(t, err)
+	assert.Equal(t, 128, resized.Bounds().Dx())
+	assert.Equal(t, 64, resized.Bounds().Dy())
+	r, _, _, a := resized.At(10, 10).RGBA()
+	assert.Equal(t, uint32(200), r>>8)
+	assert.Equal(t, uint32(255), a>>8)
+
+	// La segunda petición se sirve desde el disco; otro tamaño se descarga y guarda aparte
+	cached, err := service.GetLogo(ctx, "AAPL", 0)
+	require.NoError(t, err)
+	assert.Equal(t, logo.ETag, cached.ETag)
+	assert.Equal(t, int32(1), downloads.Load())
+
+	_, err = service.GetLogo(ctx, "AAPL", 32)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), downloads.Load())
+
+	// Los tamaños se redondean al siguiente de la escala: 40 y 50 comparten el logo de 64
+	small, err := service.GetLogo(ctx, "AAPL", 40)
+	require.NoError(t, err)
+	_, err = service.GetLogo(ctx, "AAPL", 50)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), downloads.Load())
+	resized, err = png.Decode(bytes.NewReader(small.Data))
+	require.NoError(t, err)
+	assert.Equal(t, 64, resized.Bounds().Dx())
+
+	// Tamaño fuera de rango y company sin logo
+	var errorResp *response.ErrorResponse
+	_, err = service.GetLogo(ctx, "AAPL", 1024)
+	require.True(t, errors.As(err, &errorResp))
+	assert.Equal(t, http.StatusBadRequest, errorResp.StatusCode)
+
+	_, err = service.GetLogo(ctx, "NOLG", 0)
+	require.True(t, errors.As(err, &errorResp))
+	assert.Equal(t, http.StatusNotFound, errorResp.StatusCode)
+}
+
+func TestCompanyLogoService_RefusesInternalHostsAndHugeImages(t *testing.T) {
+	ctx := context.Background()
+
+	// PNG de 1x1 cuya cabecera declara 100000x100000 píxeles
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, 1, 1))))
+	bomb := buf.Bytes()
+	binary.BigEndian.PutUint32(bomb[16:20], 100000)
+	binary.BigEndian.PutUint32(bomb[20:24], 100000)
+	binary.BigEndian.PutUint32(bomb[29:33], crc32.ChecksumIEEE(bomb[12:29]))
+
+	var downloads atomic.Int32
+	provider := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downloads.Add(1)
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(bomb)
+	}))
+	defer provider.Close()
+
+	companies := &fakeLogoCompanyRepository{companies: map[string]*entities.Company{
+		"BOMB": {Ticker: "BOMB", Logo: provider.URL + "/bomb.png"},
+	}}
+	options := services.CompanyLogoOptions{DefaultSize: 128, MaxSize: 512, MaxBytes: 1 << 20, FetchTimeout: 5 * time.Second}
+	newService := func(options services.CompanyLogoOptions) serviceInterfaces.CompanyLogoService {
+		store, err := storage.NewLocalStore(t.TempDir())
+		require.NoError(t, err)
+		return services.NewCompanyLogoService(companies, store, options, newEventBusTestLogger(t))
+	}
+
+	// El cliente por defecto no conecta con loopback: el servidor nunca recibe la petición
+	var errorResp *response.ErrorResponse
+	_, err := newService(options).GetLogo(ctx, "BOMB", 0)
+	require.True(t, errors.As(err, &errorResp))
+	assert.Equal(t, http.StatusBadGateway, errorResp.StatusCode)
+	assert.Equal(t, int32(0), downloads.Load())
+
+	// Descargado, el logo se rechaza por sus dimensiones sin decodificarlo
+	options.HTTPClient = provider.Client()
+	_, err = newService(options).GetLogo(ctx, "BOMB", 0)
+	require.True(t, errors.As(err, &errorResp))
+	assert.Equal(t, http.StatusBadGateway, errorResp.StatusCode)
+	assert.Equal(t, int32(1), downloads.Load())
+}
@@ -0,0 +1,118 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	serviceInterfaces "github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+)
+
+// overviewCompanyService resuelve un alias de ticker a la company canónica
+type overviewCompanyService struct {
+	serviceInterfaces.CompanyService
+	company *response.CompanyResponse
+}
+
+func (s *overviewCompanyService) GetCompanyByTicker(ctx context.Context, ticker string) (*response.CompanyResponse, error) {
+	if ticker != s.company.Ticker && ticker != "FB" {
+		return nil, response.NotFound("Company with ticker " + ticker)
+	}
+	return s.company, nil
+}
+
+// overviewRatingService guarda el ticker, los includes y la página pedidos
+type overviewRatingService struct {
+	serviceInterfaces.StockRatingService
+	ticker     string
+	includes   request.RatingIncludes
+	pagination *response.PaginationRequest
+}
+
+func (s *overviewRatingService) GetRatingsByTicker(ctx context.Context, ticker string, includes request.RatingIncludes, pagination *response.PaginationRequest) (*response.PaginatedResponse[*response.StockRatingListResponse], error) {
+	s.ticker, s.includes, s.pagination = ticker, includes, pagination
+	return &response.PaginatedResponse[*response.StockRatingListResponse]{
+		Items: []*response.StockRatingListResponse{{ID: uuid.New(), Ticker: ticker, Brokerage: "Goldman Sachs"}},
+	}, nil
+}
+
+// overviewMarketDataService: el perfil falla, los financieros no responden a tiempo y hay más noticias que el límite
+type overviewMarketDataService struct {
+	serviceInterfaces.MarketDataService
+	symbols chan string
+}
+
+func (s *overviewMarketDataService) GetCompanyProfile(ctx context.Context, symbol string) (*response.CompanyProfileResponse, error) {
+	s.symbols <- symbol
+	return nil, errors.New("finnhub: connection reset")
+}
+
+func (s *overviewMarketDataService) GetRealTimeQuote(ctx context.Context, symbol string) (*response.MarketDataResponse, error) {
+	s.symbols <- symbol
+	return &response.MarketDataResponse{Symbol: symbol}, nil
+}
+
+func (s *overviewMarketDataService) GetBasicFinancials(ctx context.Context, symbol string) (*response.BasicFinancialsResponse, error) {
+	s.symbols <- symbol
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (s *overviewMarketDataService) GetCompanyNews(ctx context.Context, symbol string, days int) ([]*response.NewsResponse, error) {
+	s.symbols <- symbol
+	news := make([]*response.NewsResponse, 4)
+	for i := range news {
+		news[i] = &response.NewsResponse{}
+	}
+	return news, nil
+}
+
+func TestCompanyOverviewService_GetOverview(t *testing.T) {
+	company := &response.CompanyResponse{ID: uuid.New(), Ticker: "META", Name: "Meta Platforms"}
+	ratings := &overviewRatingService{}
+	marketData := &overviewMarketDataService{symbols: make(chan string, 4)}
+
+	options := services.DefaultCompanyOverviewOptions()
+	options.NewsLimit = 3
+	options.SectionTimeout = 50 * time.Millisecond
+	service := services.NewCompanyOverviewService(&overviewCompanyService{company: company}, ratings, marketData, options, newEventBusTestLogger(t))
+
+	overview, err := service.GetOverview(context.Background(), "FB")
+	require.NoError(t, err)
+
+	// Las secciones se piden con el ticker canónico, no con el alias
+	close(marketData.symbols)
+	for symbol := range marketData.symbols {
+		assert.Equal(t, "META", symbol)
+	}
+	assert.Equal(t, "META", ratings.ticker)
+	assert.Equal(t, request.RatingIncludes{Brokerage: true}, ratings.includes)
+	assert.Equal(t, 5, ratings.pagination.PerPage)
+
+	assert.Equal(t, company, overview.Company)
+	require.NotNil(t, overview.Quote)
+	assert.Len(t, overview.RecentRatings, 1)
+	assert.Len(t, overview.News, 3)
+
+	// Las secciones que fallan no tumban la respuesta ni exponen el error interno
+	assert.Nil(t, overview.Profile)
+	assert.Nil(t, overview.Financials)
+	assert.Equal(t, map[string]string{
+		"profile":    "temporarily unavailable",
+		"financials": "timed out",
+	}, overview.Unavailable)
+
+	_, err = service.GetOverview(context.Background(), "ZZZZ")
+	var errorResp *response.ErrorResponse
+	require.True(t, errors.As(err, &errorResp))
+	assert.Equal(t, http.StatusNotFound, errorResp.StatusCode)
+}
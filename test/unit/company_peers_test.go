@@ -0,0 +1,38 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+func peerCandidate(ticker string, marketCap float64, active bool) *entities.Company {
+	return &entities.Company{ID: uuid.New(), Ticker: ticker, MarketCap: marketCap, IsActive: active}
+}
+
+func TestRankPeersByMarketCap(t *testing.T) {
+	company := peerCandidate("MSFT", 3000, true)
+	candidates := []*entities.Company{
+		company,
+		peerCandidate("TINY", 3, true),
+		peerCandidate("NOCAP", 0, true),
+		peerCandidate("AAPL", 2800, true),
+		peerCandidate("GONE", 3000, false),
+		peerCandidate("ORCL", 400, true),
+	}
+
+	ranked := services.RankPeersByMarketCap(company, candidates, 10)
+
+	tickers := make([]string, len(ranked))
+	for i, peer := range ranked {
+		tickers[i] = peer.Ticker
+	}
+	// Ni la propia company ni las inactivas; sin market cap al final
+	assert.Equal(t, []string{"AAPL", "ORCL", "TINY", "NOCAP"}, tickers)
+
+	assert.Len(t, services.RankPeersByMarketCap(company, candidates, 2), 2)
+}
@@ -0,0 +1,218 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/testutil/testdoubles"
+)
+
+// memoryRelationshipRepository guarda las relaciones en memoria y carga las companies del repositorio de companies
+type memoryRelationshipRepository struct {
+	companyRepo   repoInterfaces.CompanyRepository
+	relationships []*entities.CompanyRelationship
+	queries       int
+}
+
+func (r *memoryRelationshipRepository) Create(ctx context.Context, relationship *entities.CompanyRelationship) error {
+	for _, existing := range r.relationships {
+		if existing.CompanyID == relationship.CompanyID && existing.RelatedCompanyID == relationship.RelatedCompanyID &&
+			existing.Type == relationship.Type {
+			return domainerrors.Duplicate(nil, "relationship already exists")
+		}
+	}
+	stored := *relationship
+	r.relationships = append(r.relationships, &stored)
+	return nil
+}
+
+func (r *memoryRelationshipRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.CompanyRelationship, error) {
+	for _, relationship := range r.relationships {
+		if relationship.ID == id {
+			return r.load(ctx, relationship), nil
+		}
+	}
+	return nil, domainerrors.NotFound("relationship with id %s not found", id)
+}
+
+func (r *memoryRelationshipRepository) Update(ctx context.Context, relationship *entities.CompanyRelationship) error {
+	for _, existing := range r.relationships {
+		if existing.ID == relationship.ID {
+			existing.Type, existing.Note = relationship.Type, relationship.Note
+			return nil
+		}
+	}
+	return domainerrors.NotFound("relationship with id %s not found", relationship.ID)
+}
+
+func (r *memoryRelationshipRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	for i, relationship := range r.relationships {
+		if relationship.ID == id {
+			r.relationships = slices.Delete(r.relationships, i, i+1)
+			return nil
+		}
+	}
+	return domainerrors.NotFound("relationship with id %s not found", id)
+}
+
+func (r *memoryRelationshipRepository) GetByCompanyIDs(ctx context.Context, companyIDs []uuid.UUID, types []string) ([]*entities.CompanyRelationship, error) {
+	r.queries++
+	var result []*entities.CompanyRelationship
+	for _, relationship := range r.relationships {
+		if len(types) > 0 && !slices.Contains(types, relationship.Type) {
+			continue
+		}
+		if slices.Contains(companyIDs, relationship.CompanyID) || slices.Contains(companyIDs, relationship.RelatedCompanyID) {
+			result = append(result, r.load(ctx, relationship))
+		}
+	}
+	return result, nil
+}
+
+// load devuelve una copia con ambas companies cargadas, como los joins del repositorio real
+func (r *memoryRelationshipRepository) load(ctx context.Context, relationship *entities.CompanyRelationship) *entities.CompanyRelationship {
+	loaded := *relationship
+	company, _ := r.companyRepo.GetByID(ctx, relationship.CompanyID)
+	related, _ := r.companyRepo.GetByID(ctx, relationship.RelatedCompanyID)
+	loaded.Company, loaded.RelatedCompany = *company, *related
+	return &loaded
+}
+
+func relationshipTestCompanies(t *testing.T, companyRepo repoInterfaces.CompanyRepository, tickers ...string) map[string]*entities.Company {
+	companies := make(map[string]*entities.Company)
+	for _, ticker := range tickers {
+		company := entities.NewCompany(ticker, ticker+" Inc")
+		require.NoError(t, companyRepo.Create(context.Background(), company))
+		companies[ticker] = company
+	}
+	return companies
+}
+
+func requireErrorStatus(t *testing.T, err error, status int) {
+	t.Helper()
+	var errorResp *response.ErrorResponse
+	require.True(t, errors.As(err, &errorResp), "%v", err)
+	assert.Equal(t, status, errorResp.StatusCode, errorResp.Message)
+}
+
+func TestCompanyRelationshipService_CRUD(t *testing.T) {
+	companyRepo := testdoubles.NewCompanyRepository(testdoubles.NewStore())
+	companies := relationshipTestCompanies(t, companyRepo, "AAPL", "TSM", "MSFT", "IBM")
+	repo := &memoryRelationshipRepository{companyRepo: companyRepo}
+	service := services.NewCompanyRelationshipService(companyRepo, repo, newEventBusTestLogger(t))
+	ctx := context.Background()
+
+	// La company se indica por ticker o por ID
+	supplier, err := service.CreateRelationship(ctx, "aapl", &request.CreateCompanyRelationshipRequest{
+		RelatedCompany: companies["TSM"].ID.String(), Type: entities.RelationshipSupplier, Note: " chips ",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "outgoing", supplier.Direction)
+	assert.Equal(t, "TSM", supplier.RelatedCompany.Ticker)
+	assert.Equal(t, "chips", supplier.Note)
+
+	_, err = service.CreateRelationship(ctx, "AAPL", &request.CreateCompanyRelationshipRequest{RelatedCompany: "aapl", Type: entities.RelationshipCompetitor})
+	requireErrorStatus(t, err, http.StatusBadRequest)
+	_, err = service.CreateRelationship(ctx, "AAPL", &request.CreateCompanyRelationshipRequest{RelatedCompany: "TSM", Type: entities.RelationshipSupplier})
+	requireErrorStatus(t, err, http.StatusConflict)
+	_, err = service.CreateRelationship(ctx, "AAPL", &request.CreateCompanyRelationshipRequest{RelatedCompany: "NOPE", Type: entities.RelationshipSupplier})
+	requireErrorStatus(t, err, http.StatusNotFound)
+
+	// La competencia es simétrica: no se declara en los dos sentidos
+	_, err = service.CreateRelationship(ctx, "MSFT", &request.CreateCompanyRelationshipRequest{RelatedCompany: "AAPL", Type: entities.RelationshipCompetitor})
+	require.NoError(t, err)
+	_, err = service.CreateRelationship(ctx, "AAPL", &request.CreateCompanyRelationshipRequest{RelatedCompany: "MSFT", Type: entities.RelationshipCompetitor})
+	requireErrorStatus(t, err, http.StatusConflict)
+
+	listed, err := service.ListRelationships(ctx, "AAPL", &request.CompanyRelationshipsRequest{})
+	require.NoError(t, err)
+	require.Len(t, listed.Relationships, 2)
+	assert.Equal(t, "outgoing", listed.Relationships[0].Direction)
+	assert.Equal(t, "incoming", listed.Relationships[1].Direction)
+	assert.Equal(t, "MSFT", listed.Relationships[1].Company.Ticker)
+	listed, err = service.ListRelationships(ctx, "AAPL", &request.CompanyRelationshipsRequest{Type: entities.RelationshipCompetitor})
+	require.NoError(t, err)
+	assert.Len(t, listed.Relationships, 1)
+
+	// Las relaciones solo se gestionan a través de una de sus companies
+	note := "foundry"
+	updated, err := service.UpdateRelationship(ctx, "TSM", supplier.ID, &request.UpdateCompanyRelationshipRequest{Note: &note})
+	require.NoError(t, err)
+	assert.Equal(t, "incoming", updated.Direction)
+	assert.Equal(t, entities.RelationshipSupplier, updated.Type)
+	assert.Equal(t, "foundry", updated.Note)
+	_, err = service.UpdateRelationship(ctx, "IBM", supplier.ID, &request.UpdateCompanyRelationshipRequest{Type: entities.RelationshipCompetitor})
+	requireErrorStatus(t, err, http.StatusNotFound)
+
+	require.NoError(t, service.DeleteRelationship(ctx, "AAPL", supplier.ID))
+	requireErrorStatus(t, service.DeleteRelationship(ctx, "AAPL", supplier.ID), http.StatusNotFound)
+	assert.Len(t, repo.relationships, 1)
+}
+
+func TestCompanyRelationshipService_GetGraph(t *testing.T) {
+	companyRepo := testdoubles.NewCompanyRepository(testdoubles.NewStore())
+	companies := relationshipTestCompanies(t, companyRepo, "AAPL", "TSM", "MSFT", "ASML", "GOOG", "ZEISS", "IBM")
+	repo := &memoryRelationshipRepository{companyRepo: companyRepo}
+	service := services.NewCompanyRelationshipService(companyRepo, repo, newEventBusTestLogger(t))
+	ctx := context.Background()
+
+	relate := func(from, to, relationshipType string) {
+		require.NoError(t, repo.Create(ctx, entities.NewCompanyRelationship(companies[from].ID, companies[to].ID, relationshipType, "")))
+	}
+	relate("AAPL", "TSM", entities.RelationshipSupplier)
+	relate("AAPL", "MSFT", entities.RelationshipCompetitor)
+	relate("TSM", "ASML", entities.RelationshipSupplier)
+	relate("GOOG", "MSFT", entities.RelationshipCompetitor)
+	relate("GOOG", "ASML", entities.RelationshipSupplier)
+	relate("ASML", "ZEISS", entities.RelationshipSubsidiary)
+
+	depths := func(graph *response.CompanyGraphResponse) map[string]int {
+		result := make(map[string]int)
+		for _, node := range graph.Nodes {
+			result[node.Ticker] = node.Depth
+		}
+		return result
+	}
+
+	// Dos saltos por defecto en ambos sentidos; ZEISS queda a tres e IBM no está relacionada
+	graph, err := service.GetGraph(ctx, companies["AAPL"].ID.String(), &request.CompanyGraphRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, graph.Depth)
+	assert.Equal(t, "AAPL", graph.Root.Ticker)
+	assert.Equal(t, entities.RelationshipTypes, graph.Types)
+	assert.Equal(t, map[string]int{"AAPL": 0, "TSM": 1, "MSFT": 1, "ASML": 2, "GOOG": 2}, depths(graph))
+	// Incluye la relación entre las dos companies del último nivel
+	assert.Len(t, graph.Edges, 5)
+	assert.False(t, graph.Truncated)
+	assert.Equal(t, 3, repo.queries)
+
+	graph, err = service.GetGraph(ctx, "AAPL", &request.CompanyGraphRequest{Depth: 1})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"AAPL": 0, "TSM": 1, "MSFT": 1}, depths(graph))
+	assert.Len(t, graph.Edges, 2)
+
+	// Solo proveedores: la competencia no se sigue
+	graph, err = service.GetGraph(ctx, "AAPL", &request.CompanyGraphRequest{Depth: 3, Types: "supplier, SUPPLIER"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{entities.RelationshipSupplier}, graph.Types)
+	assert.Equal(t, map[string]int{"AAPL": 0, "TSM": 1, "ASML": 2, "GOOG": 3}, depths(graph))
+	assert.Len(t, graph.Edges, 3)
+
+	_, err = service.GetGraph(ctx, "AAPL", &request.CompanyGraphRequest{Types: "supplier,partner"})
+	requireErrorStatus(t, err, http.StatusBadRequest)
+	_, err = service.GetGraph(ctx, uuid.NewString(), &request.CompanyGraphRequest{})
+	requireErrorStatus(t, err, http.StatusNotFound)
+}
@@ -0,0 +1,17 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/implementation"
+)
+
+func TestEscapeLikePattern(t *testing.T) {
+	assert.Equal(t, "AP", implementation.EscapeLikePattern("AP"))
+	// Los comodines se buscan literalmente
+	assert.Equal(t, `BRK\_B`, implementation.EscapeLikePattern("BRK_B"))
+	assert.Equal(t, `100\%`, implementation.EscapeLikePattern("100%"))
+	assert.Equal(t, `a\\b`, implementation.EscapeLikePattern(`a\b`))
+}
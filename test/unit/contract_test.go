@@ -0,0 +1,121 @@
+package unit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/contract"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/alphavantage"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/finnhub"
+)
+
+func TestContract_CompareShape(t *testing.T) {
+	// Payload idéntico al modelo
+	drifts, err := contract.CompareShape([]byte(`{"c":189.84,"d":1.2,"dp":0.6,"h":190,"l":187,"o":188,"pc":188.6,"t":1773259200}`), finnhub.QuoteResponse{})
+	require.NoError(t, err)
+	assert.Empty(t, drifts)
+
+	// Campo renombrado, tipo cambiado y campo nuevo
+	drifts, err = contract.CompareShape([]byte(`{"price":189.84,"d":"1.2","dp":0.6,"h":190,"l":187,"o":188,"pc":188.6,"t":1773259200}`), &finnhub.QuoteResponse{})
+	require.NoError(t, err)
+	assert.Equal(t, []contract.Drift{
+		{Path: "c", Kind: contract.DriftMissingField, Expected: "number"},
+		{Path: "d", Kind: contract.DriftTypeMismatch, Expected: "number", Actual: "string"},
+		{Path: "price", Kind: contract.DriftNewField, Actual: "number"},
+	}, drifts)
+	assert.False(t, drifts[2].Breaking())
+
+	// Los arrays y mapas se comprueban elemento a elemento, con una desviación por ruta
+	drifts, err = contract.CompareShape([]byte(`[
+		{"symbol":"AAPL","period":"2026-01-01","actual":1.5,"estimate":null,"surprise":0.1,"surprisePercent":7},
+		{"symbol":"AAPL","period":"2025-10-01","actual":"1.4","estimate":1.3,"surprise":0.1},
+		{"symbol":"AAPL","period":"2025-07-01","actual":"1.2","estimate":1.1,"surprise":0.1}
+	]`), finnhub.EarningsResponse{})
+	require.NoError(t, err)
+	assert.Equal(t, []contract.Drift{
+		{Path: "[].actual", Kind: contract.DriftTypeMismatch, Expected: "number", Actual: "string"},
+		{Path: "[].surprisePercent", Kind: contract.DriftMissingField, Expected: "number"},
+	}, drifts)
+
+	// Los campos omitempty del sobre de Alpha Vantage son opcionales; null es válido
+	drifts, err = contract.CompareShape([]byte(`{
+		"Meta Data": {"1. Information": "Weekly", "2. Symbol": "IBM", "3. Last Refreshed": "2026-03-06", "4. Output Size": null, "5. Time Zone": "US/Eastern"},
+		"Weekly Time Series": {
+			"2026-03-06": {"1. open": "1", "2. high": "2", "3. low": "0.5", "4. close": "1.5", "5. adjusted close": "1.5", "6. volume": "100"},
+			"2026-02-27": {"1. open": "1", "2. high": "2", "3. low": "0.5", "4. close": 1.5, "5. adjusted close": "1.5"}
+		}
+	}`), alphavantage.TimeSeriesWeeklyResponse{})
+	require.NoError(t, err)
+	assert.Equal(t, []contract.Drift{
+		{Path: "Weekly Time Series.*.4. close", Kind: contract.DriftTypeMismatch, Expected: "string", Actual: "number"},
+		{Path: "Weekly Time Series.*.6. volume", Kind: contract.DriftMissingField, Expected: "string"},
+	}, drifts)
+
+	_, err = contract.CompareShape([]byte(`<html>`), finnhub.QuoteResponse{})
+	assert.Error(t, err)
+}
+
+func TestContract_RunnerReportsDriftByEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/quote":
+			assert.Equal(t, "sandbox-key", r.URL.Query().Get("token"))
+			w.Write([]byte(`{"c":189.84,"d":1.2,"dp":0.6,"h":190,"l":187,"o":188,"pc":188.6,"t":1773259200,"extra":true}`))
+		case r.URL.Path == "/stock/profile2":
+			w.Write([]byte(`{"name":"Apple Inc.","ticker":"AAPL","marketCapitalization":"3T"}`))
+		case r.URL.Path == "/stock/metric":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error":"You don't have access to this resource."}`))
+		case r.URL.Query().Get("function") == "OVERVIEW":
+			w.Write([]byte(`{"Information":"This is a premium endpoint."}`))
+		default:
+			w.Write([]byte(`{"Note":"Thank you for using Alpha Vantage! Our standard API call frequency is 5 calls per minute."}`))
+		}
+	}))
+	defer server.Close()
+
+	var checks []contract.Check
+	for _, check := range contract.DefaultChecks("aapl", time.Now()) {
+		switch check.Endpoint {
+		case "/quote", "/stock/profile2", "/stock/metric", "OVERVIEW", "EARNINGS":
+			checks = append(checks, check)
+		}
+	}
+	require.Len(t, checks, 5)
+
+	runner := contract.NewRunner(contract.RunnerConfig{
+		Finnhub:      contract.Endpoint{BaseURL: server.URL, APIKey: "sandbox-key"},
+		AlphaVantage: contract.Endpoint{BaseURL: server.URL, APIKey: "demo"},
+		Logger:       newEventBusTestLogger(t),
+	})
+	report := runner.Run(context.Background(), "aapl", checks)
+
+	assert.Equal(t, "AAPL", report.Symbol)
+	assert.Equal(t, contract.Summary{Total: 5, OK: 1, Drift: 1, Skipped: 2, Error: 1}, report.Summary)
+	assert.True(t, report.Failed())
+
+	statuses := make(map[string]contract.Result)
+	for _, result := range report.Results {
+		statuses[result.Endpoint] = result
+	}
+	assert.Equal(t, contract.StatusOK, statuses["/quote"].Status)
+	assert.Equal(t, 1, statuses["/quote"].NewFields)
+	assert.Empty(t, statuses["/quote"].Drifts) // Los campos nuevos solo se listan con ReportNewFields
+
+	profile := statuses["/stock/profile2"]
+	assert.Equal(t, contract.StatusDrift, profile.Status)
+	assert.Equal(t, "finnhub.CompanyProfileResponse", profile.Model)
+	assert.Contains(t, profile.Drifts, contract.Drift{Path: "marketCapitalization", Kind: contract.DriftTypeMismatch, Expected: "number", Actual: "string"})
+	assert.Contains(t, profile.Drifts, contract.Drift{Path: "weburl", Kind: contract.DriftMissingField, Expected: "string"})
+
+	assert.Equal(t, contract.StatusSkipped, statuses["/stock/metric"].Status)
+	assert.Equal(t, contract.StatusSkipped, statuses["OVERVIEW"].Status)
+	assert.Equal(t, contract.StatusError, statuses["EARNINGS"].Status)
+	assert.Contains(t, statuses["EARNINGS"].Error, "rate limit")
+}
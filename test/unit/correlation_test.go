@@ -0,0 +1,44 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+func TestPearsonCorrelation(t *testing.T) {
+	x := []float64{0.01, -0.02, 0.03, 0.00}
+
+	perfect := services.PearsonCorrelation(x, []float64{0.02, -0.04, 0.06, 0.00})
+	require.NotNil(t, perfect)
+	assert.InDelta(t, 1.0, *perfect, 1e-9)
+
+	inverse := services.PearsonCorrelation(x, []float64{-0.01, 0.02, -0.03, 0.00})
+	require.NotNil(t, inverse)
+	assert.InDelta(t, -1.0, *inverse, 1e-9)
+
+	// Sin varianza o con menos de 3 observaciones no hay correlación
+	assert.Nil(t, services.PearsonCorrelation(x, []float64{0.01, 0.01, 0.01, 0.01}))
+	assert.Nil(t, services.PearsonCorrelation([]float64{0.01, 0.02}, []float64{0.03, 0.04}))
+	assert.Nil(t, services.PearsonCorrelation(x, x[:3]))
+}
+
+func TestDailyReturns(t *testing.T) {
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	// Orden descendente, como lo devuelve el repositorio
+	prices := []*entities.HistoricalData{
+		{Date: day.AddDate(0, 0, 2), ClosePrice: 99, AdjustedClose: 99},
+		{Date: day.AddDate(0, 0, 1), ClosePrice: 110, AdjustedClose: 110},
+		{Date: day, ClosePrice: 100},
+	}
+
+	returns := services.DailyReturns(prices)
+	require.Len(t, returns, 2)
+	assert.InDelta(t, 0.10, returns["2026-03-03"], 1e-9)
+	assert.InDelta(t, -0.10, returns["2026-03-04"], 1e-9)
+}
@@ -0,0 +1,67 @@
+package unit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/config"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
+)
+
+func TestCORSConfig_IsOriginAllowed(t *testing.T) {
+	cfg := config.CORSConfig{
+		Enabled:      true,
+		AllowOrigins: []string{"https://dashboard.example.com", "https://*.partner.io"},
+	}
+
+	assert.True(t, cfg.IsOriginAllowed("https://dashboard.example.com"))
+	assert.True(t, cfg.IsOriginAllowed("https://app.partner.io"))
+	assert.False(t, cfg.IsOriginAllowed("http://app.partner.io"))
+	assert.False(t, cfg.IsOriginAllowed("https://partner.io"))
+	assert.False(t, cfg.IsOriginAllowed("https://evil.com"))
+
+	cfg.Enabled = false
+	assert.False(t, cfg.IsOriginAllowed("https://dashboard.example.com"))
+}
+
+func TestCORSConfig_ValidateRejectsWildcardWithCredentialsInProduction(t *testing.T) {
+	cfg := config.CORSConfig{Enabled: true, AllowOrigins: []string{"*"}, AllowCredentials: true}
+
+	assert.Error(t, cfg.Validate(true))
+	assert.NoError(t, cfg.Validate(false))
+
+	cfg.AllowCredentials = false
+	assert.NoError(t, cfg.Validate(true))
+}
+
+func TestCORSMiddleware_Preflight(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := *config.GetProductionCORSConfig()
+	cfg.AllowOrigins = []string{"https://*.example.com"}
+
+	router := gin.New()
+	router.Use(middleware.CORSMiddleware(cfg))
+	router.GET("/api/v1/stocks", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	preflight := func(origin string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodOptions, "/api/v1/stocks", nil)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	allowed := preflight("https://dashboard.example.com")
+	assert.Equal(t, http.StatusNoContent, allowed.Code)
+	assert.Equal(t, "https://dashboard.example.com", allowed.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", allowed.Header().Get("Access-Control-Allow-Credentials"))
+
+	denied := preflight("https://evil.com")
+	assert.Equal(t, http.StatusForbidden, denied.Code)
+	assert.Empty(t, denied.Header().Get("Access-Control-Allow-Origin"))
+}
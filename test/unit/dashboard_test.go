@@ -0,0 +1,152 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	serviceInterfaces "github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	domainServices "github.com/MayaCris/stock-info-app/internal/domain/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/timezone"
+)
+
+// dashboardMarketDataService cuenta las consultas; losers falla mientras failLosers esté activo
+type dashboardMarketDataService struct {
+	serviceInterfaces.MarketDataService
+	failLosers bool
+	calls      chan string
+}
+
+func (s *dashboardMarketDataService) GetMarketOverview(ctx context.Context) (*response.MarketOverviewResponse, error) {
+	s.calls <- "overview"
+	return &response.MarketOverviewResponse{TotalStocks: 120}, nil
+}
+
+func (s *dashboardMarketDataService) GetMarketMovers(ctx context.Context, moverType string, limit int) (*response.MarketMoversResponse, error) {
+	s.calls <- moverType
+	if moverType == "losers" && s.failLosers {
+		return nil, errors.New("redis: connection refused")
+	}
+	movers := make([]*response.MarketDataResponse, limit)
+	for i := range movers {
+		movers[i] = &response.MarketDataResponse{Symbol: moverType}
+	}
+	return &response.MarketMoversResponse{Type: moverType, Movers: movers}, nil
+}
+
+// dashboardRatingService devuelve un total por tipo de acción y guarda las búsquedas
+type dashboardRatingService struct {
+	serviceInterfaces.StockRatingService
+	searches chan *request.RatingSearchRequest
+}
+
+func (s *dashboardRatingService) SearchRatings(ctx context.Context, req *request.RatingSearchRequest, includes request.RatingIncludes, pagination *response.PaginationRequest) (*response.PaginatedResponse[*response.StockRatingListResponse], error) {
+	s.searches <- req
+	total := map[string]int{"upgrade": 7, "downgrade": 2}[req.ActionType]
+	return &response.PaginatedResponse[*response.StockRatingListResponse]{
+		Items: []*response.StockRatingListResponse{{Ticker: "AAPL", Action: req.ActionType}},
+		Meta:  response.Pagination{Total: total},
+	}, nil
+}
+
+type dashboardTrendingService struct{}
+
+func (dashboardTrendingService) GetTrending(ctx context.Context, req *request.TrendingRequest) (*response.TrendingSymbolsResponse, error) {
+	return &response.TrendingSymbolsResponse{Symbols: []response.TrendingSymbolResponse{{Rank: 1, Symbol: "NVDA"}}}, nil
+}
+
+// alertCountingAnomalyRepository guarda el instante pedido a CountCreatedSince
+type alertCountingAnomalyRepository struct {
+	interfaces.AnomalyRepository
+	since time.Time
+}
+
+func (r *alertCountingAnomalyRepository) CountCreatedSince(ctx context.Context, since time.Time) (int64, error) {
+	r.since = since
+	return 3, nil
+}
+
+// memoryBytesCache guarda entradas en memoria para probar la cache del dashboard
+type memoryBytesCache struct {
+	domainServices.CacheService
+	entries map[string][]byte
+}
+
+func (c *memoryBytesCache) Get(ctx context.Context, key string) ([]byte, error) {
+	return c.entries[key], nil
+}
+
+func (c *memoryBytesCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.entries[key] = value
+	return nil
+}
+
+func TestDashboardService_GetDashboard(t *testing.T) {
+	marketData := &dashboardMarketDataService{failLosers: true, calls: make(chan string, 100)}
+	ratings := &dashboardRatingService{searches: make(chan *request.RatingSearchRequest, 100)}
+	anomalies := &alertCountingAnomalyRepository{}
+	cache := &memoryBytesCache{entries: make(map[string][]byte)}
+	service := services.NewDashboardService(marketData, ratings, dashboardTrendingService{}, anomalies, cache,
+		services.DefaultDashboardOptions(time.Minute), newEventBusTestLogger(t))
+
+	tokyo, err := timezone.Load("Asia/Tokyo")
+	require.NoError(t, err)
+	ctx := timezone.WithLocation(context.Background(), tokyo)
+	today := time.Now().In(tokyo).Format("2006-01-02")
+
+	dashboard, err := service.GetDashboard(ctx, &request.DashboardRequest{})
+	require.NoError(t, err)
+
+	assert.Equal(t, 120, dashboard.Market.TotalStocks)
+	assert.Len(t, dashboard.TopGainers, 5)
+	assert.Empty(t, dashboard.TopLosers)
+	assert.Equal(t, 7, dashboard.Upgrades.Total)
+	assert.Equal(t, 2, dashboard.Downgrades.Total)
+	assert.Equal(t, "NVDA", dashboard.Trending[0].Symbol)
+	assert.Equal(t, map[string]string{"top_losers": "temporarily unavailable"}, dashboard.Unavailable)
+
+	// Los ratings de hoy se buscan en la zona horaria de la petición
+	require.Len(t, ratings.searches, 2)
+	for len(ratings.searches) > 0 {
+		search := <-ratings.searches
+		assert.Equal(t, today, search.From)
+		assert.Equal(t, today, search.To)
+	}
+
+	// Sin alerts_since las alertas se cuentan desde el inicio de hoy
+	require.NotNil(t, dashboard.UnreadAlerts)
+	assert.Equal(t, int64(3), *dashboard.UnreadAlerts)
+	assert.True(t, anomalies.since.Equal(timezone.StartOfDay(time.Now().In(tokyo))))
+
+	// Un dashboard incompleto no se cachea; el completo sí, sin el contador de alertas
+	assert.Empty(t, cache.entries)
+	marketData.failLosers = false
+	_, err = service.GetDashboard(ctx, &request.DashboardRequest{})
+	require.NoError(t, err)
+	require.Len(t, cache.entries, 1)
+	for _, data := range cache.entries {
+		assert.NotContains(t, string(data), "unread_alerts")
+	}
+
+	calls := len(marketData.calls)
+	dashboard, err = service.GetDashboard(ctx, &request.DashboardRequest{AlertsSince: "2026-03-02T09:30:00+01:00"})
+	require.NoError(t, err)
+	assert.Equal(t, calls, len(marketData.calls))
+	assert.Len(t, dashboard.TopLosers, 5)
+	assert.Nil(t, dashboard.Unavailable)
+	assert.True(t, anomalies.since.Equal(time.Date(2026, 3, 2, 8, 30, 0, 0, time.UTC)))
+
+	_, err = service.GetDashboard(ctx, &request.DashboardRequest{AlertsSince: "yesterday"})
+	var errorResp *response.ErrorResponse
+	require.True(t, errors.As(err, &errorResp))
+	assert.Equal(t, http.StatusBadRequest, errorResp.StatusCode)
+}
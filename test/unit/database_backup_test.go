@@ -0,0 +1,243 @@
+package unit
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	domainServices "github.com/MayaCris/stock-info-app/internal/domain/services"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/storage"
+)
+
+// memoryObjectStore keeps the uploaded objects in memory
+type memoryObjectStore struct {
+	objects map[string][]byte
+}
+
+func newMemoryObjectStore() *memoryObjectStore {
+	return &memoryObjectStore{objects: make(map[string][]byte)}
+}
+
+func (s *memoryObjectStore) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	if int64(len(data)) != size {
+		return fmt.Errorf("size mismatch for %s: read %d, declared %d", key, len(data), size)
+	}
+	s.objects[key] = data
+	return nil
+}
+
+func (s *memoryObjectStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	data, ok := s.objects[key]
+	if !ok {
+		return nil, domainServices.ErrObjectNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *memoryObjectStore) List(ctx context.Context, prefix string) ([]domainServices.ObjectInfo, error) {
+	var objects []domainServices.ObjectInfo
+	for key, data := range s.objects {
+		if strings.HasPrefix(key, prefix) {
+			objects = append(objects, domainServices.ObjectInfo{Key: key, Size: int64(len(data))})
+		}
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+// fakeSnapshotRepository exports rows kept as NDJSON lines per table
+type fakeSnapshotRepository struct {
+	rows    map[string][]string
+	columns map[string][]string
+	version uint
+}
+
+func (r *fakeSnapshotRepository) TableExists(ctx context.Context, table string) (bool, error) {
+	_, ok := r.columns[table]
+	return ok, nil
+}
+
+func (r *fakeSnapshotRepository) ExportTable(ctx context.Context, table string, w io.Writer) (int64, error) {
+	for _, row := range r.rows[table] {
+		if _, err := io.WriteString(w, row+"\n"); err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(r.rows[table])), nil
+}
+
+func (r *fakeSnapshotRepository) Columns(ctx context.Context, table string) ([]string, error) {
+	return r.columns[table], nil
+}
+
+func (r *fakeSnapshotRepository) CountRows(ctx context.Context, table string) (int64, error) {
+	return int64(len(r.rows[table])), nil
+}
+
+func (r *fakeSnapshotRepository) SchemaVersion(ctx context.Context) (uint, error) {
+	return r.version, nil
+}
+
+func newBackupTestRepository() *fakeSnapshotRepository {
+	return &fakeSnapshotRepository{
+		rows: map[string][]string{
+			"companies":  {`{"id":"c1","ticker":"AAPL"}`, `{"id":"c2","ticker":"MSFT"}`},
+			"brokerages": {`{"id":"b1","name":"Goldman"}`},
+		},
+		columns: map[string][]string{
+			"companies":  {"id", "ticker"},
+			"brokerages": {"id", "name"},
+		},
+		version: 17,
+	}
+}
+
+func TestBackupService_CreateBackupUploadsTablesAndManifest(t *testing.T) {
+	store := newMemoryObjectStore()
+	repo := newBackupTestRepository()
+	service := services.NewBackupService(store, repo, []string{"companies", "brokerages", "anomalies"}, "backups", newEventBusTestLogger(t))
+
+	var progress []response.BackupResponse
+	backup, err := service.CreateBackup(context.Background(), interfaces.BackupOptions{
+		OnProgress: func(p response.BackupResponse) { progress = append(progress, p) },
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, services.BackupFormatNDJSON, backup.Format)
+	assert.Equal(t, uint(17), backup.SchemaVersion)
+	assert.Equal(t, int64(3), backup.TotalRows)
+	require.Len(t, backup.Tables, 3)
+	assert.Equal(t, "backups/"+backup.ID+"/companies.ndjson.gz", backup.Tables[0].Key)
+	assert.Equal(t, int64(2), backup.Tables[0].Rows)
+	assert.NotEmpty(t, backup.Tables[0].SHA256)
+	assert.Equal(t, "table does not exist", backup.Tables[2].Skipped)
+	assert.Len(t, progress, 3)
+
+	assert.Contains(t, store.objects, "backups/"+backup.ID+"/manifest.json")
+	assert.Contains(t, store.objects, "backups/"+backup.ID+"/brokerages.ndjson.gz")
+	assert.NotContains(t, store.objects, "backups/"+backup.ID+"/anomalies.ndjson.gz")
+
+	backups, err := service.ListBackups(context.Background())
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	assert.Equal(t, backup.ID, backups[0].ID)
+	assert.Equal(t, int64(3), backups[0].TotalRows)
+}
+
+func TestBackupService_RejectsTablesOutsideTheBackupSet(t *testing.T) {
+	service := services.NewBackupService(newMemoryObjectStore(), newBackupTestRepository(), []string{"companies"}, "backups", newEventBusTestLogger(t))
+
+	assert.NoError(t, service.ValidateTables([]string{"companies"}))
+	assert.Error(t, service.ValidateTables([]string{"jobs"}))
+
+	_, err := service.CreateBackup(context.Background(), interfaces.BackupOptions{Tables: []string{"jobs"}})
+	assert.Error(t, err)
+}
+
+func TestBackupService_RestoreDryRun(t *testing.T) {
+	store := newMemoryObjectStore()
+	repo := newBackupTestRepository()
+	service := services.NewBackupService(store, repo, []string{"companies", "brokerages"}, "backups", newEventBusTestLogger(t))
+
+	backup, err := service.CreateBackup(context.Background(), interfaces.BackupOptions{})
+	require.NoError(t, err)
+
+	result, err := service.RestoreDryRun(context.Background(), backup.ID)
+	require.NoError(t, err)
+	assert.True(t, result.Restorable)
+	assert.False(t, result.SchemaVersionMismatch)
+	require.Len(t, result.Tables, 2)
+	assert.Equal(t, int64(2), result.Tables[0].BackupRows)
+	assert.True(t, result.Tables[0].ChecksumValid)
+
+	// La tabla perdió una columna y ganó otra desde el snapshot
+	repo.columns["companies"] = []string{"id", "symbol"}
+	repo.version = 18
+	result, err = service.RestoreDryRun(context.Background(), backup.ID)
+	require.NoError(t, err)
+	assert.False(t, result.Restorable)
+	assert.True(t, result.SchemaVersionMismatch)
+	assert.Equal(t, []string{"ticker"}, result.Tables[0].UnknownColumns)
+	assert.Equal(t, []string{"symbol"}, result.Tables[0].MissingColumns)
+
+	// Un objeto alterado no pasa la verificación del checksum
+	key := backup.Tables[1].Key
+	store.objects[key] = append(store.objects[key], 0)
+	result, err = service.RestoreDryRun(context.Background(), backup.ID)
+	require.NoError(t, err)
+	assert.False(t, result.Tables[1].ChecksumValid)
+}
+
+func TestBackupService_GetBackupValidatesID(t *testing.T) {
+	service := services.NewBackupService(newMemoryObjectStore(), newBackupTestRepository(), []string{"companies"}, "backups", newEventBusTestLogger(t))
+
+	_, err := service.GetBackup(context.Background(), "../jobs")
+	require.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, response.FromError(err, "Backup", "").StatusCode)
+
+	_, err = service.GetBackup(context.Background(), "20260101T020000Z")
+	require.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, response.FromError(err, "Backup", "").StatusCode)
+}
+
+func TestS3Store_SignsRequestsAndListsObjects(t *testing.T) {
+	var uploaded []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access/"))
+		assert.Contains(t, r.Header.Get("Authorization"), "/us-east-1/s3/aws4_request")
+		assert.NotEmpty(t, r.Header.Get("X-Amz-Date"))
+
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/bucket/backups/a.json":
+			uploaded, _ = io.ReadAll(r.Body)
+		case r.Method == http.MethodGet && r.URL.Path == "/bucket/" && r.URL.Query().Get("list-type") == "2":
+			assert.Equal(t, "backups/", r.URL.Query().Get("prefix"))
+			fmt.Fprint(w, `<ListBucketResult><Contents><Key>backups/b.json</Key><Size>2</Size>`+
+				`<LastModified>2026-01-01T02:00:00.000Z</LastModified></Contents>`+
+				`<Contents><Key>backups/a.json</Key><Size>7</Size>`+
+				`<LastModified>2026-01-01T02:00:00.000Z</LastModified></Contents>`+
+				`<IsTruncated>false</IsTruncated></ListBucketResult>`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `<Error><Code>NoSuchKey</Code><Message>missing</Message></Error>`)
+		}
+	}))
+	defer server.Close()
+
+	store, err := storage.NewS3Store(storage.S3Options{
+		Endpoint:        server.URL,
+		Bucket:          "bucket",
+		PathStyle:       true,
+		AccessKeyID:     "access",
+		SecretAccessKey: "secret",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put(context.Background(), "backups/a.json", strings.NewReader(`{"a":1}`), 7, "application/json"))
+	assert.Equal(t, `{"a":1}`, string(uploaded))
+
+	objects, err := store.List(context.Background(), "backups/")
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+	assert.Equal(t, "backups/a.json", objects[0].Key)
+	assert.Equal(t, int64(7), objects[0].Size)
+
+	_, err = store.Get(context.Background(), "backups/missing.json")
+	assert.ErrorIs(t, err, domainServices.ErrObjectNotFound)
+}
@@ -0,0 +1,41 @@
+package unit
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+)
+
+func TestDomainErrors_KindSurvivesWrapping(t *testing.T) {
+	cause := errors.New("duplicate key value violates unique constraint")
+	err := fmt.Errorf("failed to find or create company: %w", domainerrors.Duplicate(cause, "company %s already exists", "AAPL"))
+
+	assert.True(t, domainerrors.IsDuplicate(err))
+	assert.False(t, domainerrors.IsNotFound(err))
+	assert.ErrorIs(t, err, cause)
+}
+
+func TestFromError_MapsDomainErrorsToHTTPStatus(t *testing.T) {
+	assert.Equal(t, http.StatusNotFound, response.FromError(domainerrors.NotFound("company with id %s not found", "1"), "Company", "Failed").StatusCode)
+	assert.Equal(t, http.StatusConflict, response.FromError(domainerrors.Duplicate(nil, "duplicate"), "Company", "Failed").StatusCode)
+	assert.Equal(t, http.StatusConflict, response.FromError(domainerrors.Conflict(nil, "conflict"), "Company", "Failed").StatusCode)
+	assert.Equal(t, http.StatusInternalServerError, response.FromError(errors.New("connection refused"), "Company", "Failed").StatusCode)
+
+	// Los ErrorResponse generados por los servicios se conservan
+	assert.Equal(t, http.StatusBadRequest, response.FromError(response.BadRequest("bad"), "Company", "Failed").StatusCode)
+}
+
+func TestFromError_VersionMismatchIsConflict(t *testing.T) {
+	err := fmt.Errorf("failed to update: %w", domainerrors.VersionMismatch("company with id %s was modified concurrently", "1"))
+
+	errorResp := response.FromError(err, "Company", "Failed")
+	assert.Equal(t, http.StatusConflict, errorResp.StatusCode)
+	assert.Equal(t, response.ErrCodeVersionMismatch, errorResp.Code)
+	assert.False(t, domainerrors.IsConflict(err))
+}
@@ -0,0 +1,114 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/events"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/eventbus"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/logger"
+)
+
+func newEventBusTestLogger(t *testing.T) logger.Logger {
+	appLogger, err := logger.NewLoggerBuilder().
+		WithLevel(logger.ErrorLevel).
+		WithFileOutput(false).
+		WithConsoleOutput(true).
+		Build()
+	require.NoError(t, err)
+	return appLogger
+}
+
+func TestEvent_NewAndDecode(t *testing.T) {
+	rating := entities.NewStockRating(uuid.New(), uuid.New(), "upgraded by", time.Date(2025, 1, 13, 0, 30, 5, 0, time.UTC))
+	rating.ID = uuid.New()
+	rating.RatingTo = "Buy"
+	rating.TargetTo = "$4.70"
+
+	event, err := events.NewRatingCreated(rating)
+	require.NoError(t, err)
+	assert.Equal(t, events.RatingCreated, event.Type)
+	assert.NotEqual(t, uuid.Nil, event.ID)
+	assert.False(t, event.OccurredAt.IsZero())
+
+	var payload events.RatingCreatedPayload
+	require.NoError(t, event.Decode(&payload))
+	assert.Equal(t, rating.ID, payload.RatingID)
+	assert.Equal(t, rating.CompanyID, payload.CompanyID)
+	assert.Equal(t, "Buy", payload.RatingTo)
+	assert.Equal(t, "$4.70", payload.TargetTo)
+	assert.True(t, rating.EventTime.Equal(payload.EventTime))
+}
+
+func TestEventBusSubject(t *testing.T) {
+	assert.Equal(t, "stockinfo.quote.updated", eventbus.Subject("stockinfo", events.QuoteUpdated))
+	assert.Equal(t, "company.updated", eventbus.Subject("", events.CompanyUpdated))
+}
+
+func TestMemoryBus_DeliversToSubscribersOfTheType(t *testing.T) {
+	bus := eventbus.NewMemoryBus(16, newEventBusTestLogger(t))
+
+	var (
+		mu       sync.Mutex
+		received []events.Type
+	)
+	record := func(_ context.Context, event events.Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, event.Type)
+		return nil
+	}
+
+	_, err := bus.Subscribe(events.QuoteUpdated, record)
+	require.NoError(t, err)
+	// Un handler que falla no impide la entrega al resto
+	_, err = bus.Subscribe(events.QuoteUpdated, func(context.Context, events.Event) error { return errors.New("boom") })
+	require.NoError(t, err)
+
+	quote, err := events.New(events.QuoteUpdated, events.QuoteUpdatedPayload{Symbol: "AAPL"})
+	require.NoError(t, err)
+	company, err := events.New(events.CompanyUpdated, events.CompanyUpdatedPayload{Ticker: "AAPL"})
+	require.NoError(t, err)
+
+	require.NoError(t, bus.Publish(context.Background(), quote, company, quote))
+
+	// Close entrega los eventos pendientes
+	require.NoError(t, bus.Close())
+	assert.Equal(t, []events.Type{events.QuoteUpdated, events.QuoteUpdated}, received)
+
+	assert.ErrorIs(t, bus.Publish(context.Background(), quote), events.ErrBusClosed)
+}
+
+func TestMemoryBus_Unsubscribe(t *testing.T) {
+	bus := eventbus.NewMemoryBus(16, newEventBusTestLogger(t))
+
+	delivered := make(chan struct{}, 4)
+	unsubscribe, err := bus.Subscribe(events.RatingCreated, func(context.Context, events.Event) error {
+		delivered <- struct{}{}
+		return nil
+	})
+	require.NoError(t, err)
+
+	event, err := events.New(events.RatingCreated, events.RatingCreatedPayload{})
+	require.NoError(t, err)
+
+	require.NoError(t, bus.Publish(context.Background(), event))
+	select {
+	case <-delivered:
+	case <-time.After(time.Second):
+		t.Fatal("event was not delivered")
+	}
+
+	unsubscribe()
+	require.NoError(t, bus.Publish(context.Background(), event))
+	require.NoError(t, bus.Close())
+	assert.Empty(t, delivered)
+}
@@ -0,0 +1,271 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/outbox"
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/events"
+	"github.com/MayaCris/stock-info-app/internal/testutil/testdoubles"
+)
+
+// fakeOutboxRepository keeps the outbox in memory
+type fakeOutboxRepository struct {
+	rows []*entities.OutboxEvent
+}
+
+func (r *fakeOutboxRepository) Add(ctx context.Context, rows []*entities.OutboxEvent) error {
+	r.rows = append(r.rows, rows...)
+	return nil
+}
+
+func (r *fakeOutboxRepository) AddWithTx(ctx context.Context, tx *gorm.DB, rows []*entities.OutboxEvent) error {
+	return r.Add(ctx, rows)
+}
+
+func (r *fakeOutboxRepository) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*entities.OutboxEvent, error) {
+	now := time.Now()
+	var pending []*entities.OutboxEvent
+	for _, row := range r.rows {
+		if !row.IsPublished() && !row.NextAttemptAt.After(now) && len(pending) < limit {
+			row.NextAttemptAt = now.Add(lease)
+			pending = append(pending, row)
+		}
+	}
+	return pending, nil
+}
+
+func (r *fakeOutboxRepository) CountPending(ctx context.Context) (int64, error) {
+	var count int64
+	for _, row := range r.rows {
+		if !row.IsPublished() {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *fakeOutboxRepository) MarkPublished(ctx context.Context, ids []uuid.UUID) error {
+	now := time.Now()
+	for _, id := range ids {
+		if row := r.find(id); row != nil {
+			row.PublishedAt = &now
+		}
+	}
+	return nil
+}
+
+func (r *fakeOutboxRepository) MarkFailed(ctx context.Context, id uuid.UUID, cause string, nextAttemptAt time.Time) error {
+	if row := r.find(id); row != nil {
+		row.Attempts++
+		row.LastError = cause
+		row.NextAttemptAt = nextAttemptAt
+	}
+	return nil
+}
+
+func (r *fakeOutboxRepository) Release(ctx context.Context, ids []uuid.UUID, nextAttemptAt time.Time) error {
+	for _, id := range ids {
+		if row := r.find(id); row != nil && !row.IsPublished() {
+			row.NextAttemptAt = nextAttemptAt
+		}
+	}
+	return nil
+}
+
+func (r *fakeOutboxRepository) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (r *fakeOutboxRepository) find(id uuid.UUID) *entities.OutboxEvent {
+	for _, row := range r.rows {
+		if row.ID == id {
+			return row
+		}
+	}
+	return nil
+}
+
+// flakyPublisher records the published events and fails while down is set
+type flakyPublisher struct {
+	down      bool
+	published []events.Event
+}
+
+func (p *flakyPublisher) Publish(ctx context.Context, evts ...events.Event) error {
+	if p.down {
+		return errors.New("broker unavailable")
+	}
+	p.published = append(p.published, evts...)
+	return nil
+}
+
+func newOutboxTestEvent(t *testing.T, ticker string) events.Event {
+	event, err := events.New(events.CompanyUpdated, events.CompanyUpdatedPayload{Ticker: ticker})
+	require.NoError(t, err)
+	return event
+}
+
+func TestEvent_OutboxRoundTrip(t *testing.T) {
+	event := newOutboxTestEvent(t, "AAPL")
+
+	row := event.ToOutbox()
+	assert.Equal(t, event.ID, row.ID)
+	assert.Equal(t, "company.updated", row.EventType)
+	assert.False(t, row.IsPublished())
+
+	restored := events.FromOutbox(row)
+	assert.Equal(t, event.ID, restored.ID)
+	assert.Equal(t, event.Type, restored.Type)
+	assert.JSONEq(t, string(event.Payload), string(restored.Payload))
+
+	var payload events.CompanyUpdatedPayload
+	require.NoError(t, restored.Decode(&payload))
+	assert.Equal(t, "AAPL", payload.Ticker)
+}
+
+func TestOutboxWriter_StoresEventsInsteadOfPublishing(t *testing.T) {
+	repo := &fakeOutboxRepository{}
+	writer := outbox.NewWriter(repo)
+
+	require.NoError(t, writer.Publish(context.Background(), newOutboxTestEvent(t, "AAPL"), newOutboxTestEvent(t, "MSFT")))
+
+	pending, err := repo.CountPending(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), pending)
+}
+
+func TestOutboxRelay_PublishesPendingEvents(t *testing.T) {
+	repo := &fakeOutboxRepository{}
+	bus := &flakyPublisher{}
+	writer := outbox.NewWriter(repo)
+	require.NoError(t, writer.Publish(context.Background(), newOutboxTestEvent(t, "AAPL"), newOutboxTestEvent(t, "MSFT")))
+
+	relay := outbox.NewRelay(repo, bus, outbox.RelayConfig{BatchSize: 10}, newEventBusTestLogger(t))
+	result, err := relay.RelayPending(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, outbox.RelayResult{Published: 2}, result)
+	require.Len(t, bus.published, 2)
+	assert.Equal(t, repo.rows[0].ID, bus.published[0].ID)
+
+	pending, err := repo.CountPending(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, pending)
+
+	// Una segunda pasada no vuelve a publicar nada
+	result, err = relay.RelayPending(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, outbox.RelayResult{}, result)
+}
+
+func TestOutboxRelay_KeepsEventsWhileBrokerIsDown(t *testing.T) {
+	repo := &fakeOutboxRepository{}
+	bus := &flakyPublisher{down: true}
+	writer := outbox.NewWriter(repo)
+	require.NoError(t, writer.Publish(context.Background(), newOutboxTestEvent(t, "AAPL"), newOutboxTestEvent(t, "MSFT")))
+
+	relay := outbox.NewRelay(repo, bus, outbox.RelayConfig{BatchSize: 10, RetryBackoff: time.Millisecond}, newEventBusTestLogger(t))
+	result, err := relay.RelayPending(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, outbox.RelayResult{Failed: 1}, result)
+	assert.Equal(t, 1, repo.rows[0].Attempts)
+	assert.Equal(t, "broker unavailable", repo.rows[0].LastError)
+	assert.Zero(t, repo.rows[1].Attempts, "the pass stops at the first failure")
+
+	bus.down = false
+	time.Sleep(5 * time.Millisecond)
+
+	result, err = relay.RelayPending(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Published)
+	require.Len(t, bus.published, 2)
+	assert.Equal(t, repo.rows[0].ID, bus.published[0].ID)
+}
+
+func TestOutboxRelay_ClaimedEventsAreSkippedByOtherRelays(t *testing.T) {
+	repo := &fakeOutboxRepository{}
+	writer := outbox.NewWriter(repo)
+	require.NoError(t, writer.Publish(context.Background(), newOutboxTestEvent(t, "AAPL"), newOutboxTestEvent(t, "MSFT")))
+
+	// Un relay que reclamó el lote y no terminó lo deja reservado: otro relay no lo vuelve a publicar
+	claimed, err := repo.ClaimPending(context.Background(), 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, claimed, 2)
+
+	bus := &flakyPublisher{}
+	relay := outbox.NewRelay(repo, bus, outbox.RelayConfig{BatchSize: 10}, newEventBusTestLogger(t))
+	result, err := relay.RelayPending(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, outbox.RelayResult{}, result)
+	assert.Empty(t, bus.published)
+
+	// Al vencer la reserva (aquí, al liberarla) los eventos vuelven a estar pendientes
+	require.NoError(t, repo.Release(context.Background(), []uuid.UUID{claimed[0].ID, claimed[1].ID}, time.Now()))
+	result, err = relay.RelayPending(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Published)
+}
+
+func TestOutboxRetryBackoff(t *testing.T) {
+	assert.Equal(t, 5*time.Second, outbox.RetryBackoff(5*time.Second, 0))
+	assert.Equal(t, 10*time.Second, outbox.RetryBackoff(5*time.Second, 1))
+	assert.Equal(t, 40*time.Second, outbox.RetryBackoff(5*time.Second, 3))
+	assert.Equal(t, 10*time.Minute, outbox.RetryBackoff(5*time.Second, 20))
+}
+
+// txRecordingPublisher records the events stored in the transaction of the change; fail makes the store fail
+type txRecordingPublisher struct {
+	flakyPublisher
+	fail bool
+	inTx []events.Event
+}
+
+func (p *txRecordingPublisher) PublishWithTx(ctx context.Context, tx *gorm.DB, evts ...events.Event) error {
+	if p.fail {
+		return errors.New("outbox unavailable")
+	}
+	p.inTx = append(p.inTx, evts...)
+	return nil
+}
+
+func TestCompanyService_StoresCompanyUpdatedInTheUpdateTransaction(t *testing.T) {
+	ctx := context.Background()
+	store := testdoubles.NewStore()
+	companyRepo := testdoubles.NewCompanyRepository(store)
+	company := entities.NewCompany("AAPL", "Apple Inc.")
+	require.NoError(t, companyRepo.Create(ctx, company))
+
+	publisher := &txRecordingPublisher{}
+	service := services.NewCompanyService(companyRepo, publisher, newEventBusTestLogger(t))
+
+	name := "Apple"
+	_, err := service.UpdateCompany(ctx, company.ID, &request.UpdateCompanyRequest{Name: &name})
+	require.NoError(t, err)
+	require.NoError(t, service.DeactivateCompany(ctx, company.ID))
+	require.Len(t, publisher.inTx, 2)
+	assert.Empty(t, publisher.published, "with the outbox nothing is published after the write")
+
+	var payload events.CompanyUpdatedPayload
+	require.NoError(t, publisher.inTx[1].Decode(&payload))
+	assert.Equal(t, "AAPL", payload.Ticker)
+
+	// Si el evento no se puede guardar, el cambio se revierte
+	publisher.fail = true
+	other := "Apple Computer"
+	_, err = service.UpdateCompany(ctx, company.ID, &request.UpdateCompanyRequest{Name: &other})
+	require.Error(t, err)
+	stored, err := companyRepo.GetByID(ctx, company.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Apple", stored.Name)
+	assert.False(t, stored.IsActive)
+}
@@ -0,0 +1,177 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/usage"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/alphavantage"
+)
+
+// memoryStatementRepository guarda los estados en memoria con la misma clave única que la tabla
+type memoryStatementRepository struct {
+	statements map[string]*entities.FinancialStatement
+}
+
+func statementKey(s *entities.FinancialStatement) string {
+	return s.CompanyID.String() + s.Statement + s.Period + s.FiscalDateEnding.Format("2006-01-02")
+}
+
+func (r *memoryStatementRepository) Upsert(ctx context.Context, statements []*entities.FinancialStatement) error {
+	for _, s := range statements {
+		stored := *s
+		stored.UpdatedAt = time.Now()
+		r.statements[statementKey(s)] = &stored
+	}
+	return nil
+}
+
+func (r *memoryStatementRepository) GetByCompany(ctx context.Context, companyID uuid.UUID, statement, period string, limit int) ([]*entities.FinancialStatement, error) {
+	var result []*entities.FinancialStatement
+	for _, s := range r.statements {
+		if s.CompanyID == companyID && s.Statement == statement && s.Period == period {
+			result = append(result, s)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].FiscalDateEnding.After(result[j].FiscalDateEnding) })
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// fakeStatementProvider devuelve estados fijos o un error y cuenta las descargas
+type fakeStatementProvider struct {
+	statements []*entities.FinancialStatement
+	err        error
+	fetches    int
+}
+
+func (p *fakeStatementProvider) FetchStatements(ctx context.Context, companyID uuid.UUID, symbol, statement string) ([]*entities.FinancialStatement, error) {
+	p.fetches++
+	return p.statements, p.err
+}
+
+func (p *fakeStatementProvider) Source() string {
+	return "fake"
+}
+
+// quarterlyIncome crea un trimestre de la cuenta de resultados
+func quarterlyIncome(companyID uuid.UUID, date string, revenue, grossProfit, netIncome float64) *entities.FinancialStatement {
+	fiscalDate, _ := time.Parse("2006-01-02", date)
+	return entities.NewFinancialStatement(companyID, entities.StatementIncome, entities.StatementPeriodQuarterly, fiscalDate, "USD",
+		entities.StatementLineItems{"total_revenue": revenue, "gross_profit": grossProfit, "net_income": netIncome})
+}
+
+func TestFinancialStatementService_GrowthAndMargins(t *testing.T) {
+	company := &entities.Company{ID: uuid.New(), Ticker: "AAPL"}
+	provider := &fakeStatementProvider{statements: []*entities.FinancialStatement{
+		// Cierres de 52/53 semanas: el trimestre del año anterior cierra unos días antes
+		quarterlyIncome(company.ID, "2025-12-27", 120, 60, 30),
+		quarterlyIncome(company.ID, "2025-09-27", 100, 45, 20),
+		quarterlyIncome(company.ID, "2025-06-28", 90, 40, 0),
+		quarterlyIncome(company.ID, "2025-03-29", 95, 42, 18),
+		quarterlyIncome(company.ID, "2024-12-28", 80, 36, -10),
+	}}
+	repo := &memoryStatementRepository{statements: map[string]*entities.FinancialStatement{}}
+	service := services.NewFinancialStatementService(&tickerCompanyRepository{company: company}, repo, provider, time.Hour, newEventBusTestLogger(t))
+
+	result, err := service.GetStatements(context.Background(), "AAPL", &request.FinancialStatementRequest{Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 1, provider.fetches)
+	assert.Equal(t, entities.StatementIncome, result.Statement)
+	assert.Equal(t, entities.StatementPeriodQuarterly, result.Period)
+	assert.Equal(t, "fake", result.Source)
+	assert.False(t, result.Stale)
+
+	require.Len(t, result.Periods, 2)
+	latest := result.Periods[0]
+	assert.Equal(t, "2025-12-27", latest.FiscalDateEnding)
+	assert.Equal(t, 0.5, latest.Metrics["gross_margin"])
+	assert.Equal(t, 0.25, latest.Metrics["net_margin"])
+	assert.NotContains(t, latest.Metrics, "operating_margin")
+	assert.Equal(t, 0.5, latest.YoYGrowth["total_revenue"])
+	assert.Equal(t, 4.0, latest.YoYGrowth["net_income"]) // Frente a una pérdida: (30 - -10) / 10
+	assert.Equal(t, 0.2, latest.QoQGrowth["total_revenue"])
+
+	// El trimestre anterior a una partida cero no tiene crecimiento de esa partida
+	previous := result.Periods[1]
+	assert.Nil(t, previous.YoYGrowth)
+	assert.NotContains(t, previous.QoQGrowth, "net_income")
+	assert.InDelta(t, 0.1111, previous.QoQGrowth["total_revenue"], 1e-9)
+
+	// Mientras son frescos se sirven de la base de datos
+	_, err = service.GetStatements(context.Background(), "AAPL", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, provider.fetches)
+
+	// Anuales sin datos y sin cuota de refrescos
+	ctx, tracker := usage.WithTracker(context.Background())
+	tracker.BlockRefreshes()
+	_, err = service.GetStatements(ctx, "AAPL", &request.FinancialStatementRequest{Period: entities.StatementPeriodAnnual})
+	var errorResp *response.ErrorResponse
+	require.True(t, errors.As(err, &errorResp))
+	assert.Equal(t, http.StatusPaymentRequired, errorResp.StatusCode)
+	assert.Equal(t, 1, provider.fetches)
+}
+
+func TestFinancialStatementService_ServesStoredWhenProviderFails(t *testing.T) {
+	company := &entities.Company{ID: uuid.New(), Ticker: "AAPL"}
+	repo := &memoryStatementRepository{statements: map[string]*entities.FinancialStatement{}}
+	old := quarterlyIncome(company.ID, "2025-09-27", 100, 45, 20)
+	require.NoError(t, repo.Upsert(context.Background(), []*entities.FinancialStatement{old}))
+	repo.statements[statementKey(old)].UpdatedAt = time.Now().Add(-48 * time.Hour)
+
+	provider := &fakeStatementProvider{err: errors.New("rate limited")}
+	service := services.NewFinancialStatementService(&tickerCompanyRepository{company: company}, repo, provider, 24*time.Hour, newEventBusTestLogger(t))
+
+	result, err := service.GetStatements(context.Background(), "AAPL", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, provider.fetches)
+	assert.True(t, result.Stale)
+	require.Len(t, result.Periods, 1)
+	assert.Equal(t, 100.0, result.Periods[0].Items["total_revenue"])
+
+	// Sin nada guardado el fallo del proveedor es un 502
+	_, err = service.GetStatements(context.Background(), "AAPL", &request.FinancialStatementRequest{Statement: entities.StatementCashFlow})
+	var errorResp *response.ErrorResponse
+	require.True(t, errors.As(err, &errorResp))
+	assert.Equal(t, http.StatusBadGateway, errorResp.StatusCode)
+}
+
+func TestAlphaVantageAdapter_ReportsToFinancialStatements(t *testing.T) {
+	adapter := alphavantage.NewAdapter(newEventBusTestLogger(t))
+	companyID := uuid.New()
+
+	statements, err := adapter.ReportsToFinancialStatements(context.Background(), []alphavantage.AnnualBalanceSheet{{
+		FiscalDateEnding:                       "2025-09-30",
+		ReportedCurrency:                       "USD",
+		TotalCurrentAssets:                     "152987000000",
+		AccumulatedDepreciationAmortizationPPE: "None",
+		ShortLongTermDebtTotal:                 "101698000000",
+	}}, companyID, entities.StatementBalance, entities.StatementPeriodAnnual)
+	require.NoError(t, err)
+	require.Len(t, statements, 1)
+
+	statement := statements[0]
+	assert.Equal(t, companyID, statement.CompanyID)
+	assert.Equal(t, "USD", statement.Currency)
+	assert.Equal(t, time.Date(2025, 9, 30, 0, 0, 0, 0, time.UTC), statement.FiscalDateEnding)
+	assert.Equal(t, 152987000000.0, statement.LineItems["total_current_assets"])
+	assert.Equal(t, 101698000000.0, statement.LineItems["short_long_term_debt_total"])
+	// Los importes "None" y vacíos no se guardan
+	assert.NotContains(t, statement.LineItems, "accumulated_depreciation_amortization_ppe")
+	assert.NotContains(t, statement.LineItems, "goodwill")
+}
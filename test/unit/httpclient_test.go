@@ -0,0 +1,175 @@
+package unit
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/config"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/httpclient"
+)
+
+func TestHTTPClient_RetriesTransientFailures(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		switch r.URL.Path {
+		case "/flaky":
+			// Dos 5xx y después éxito
+			if n <= 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+		case "/throttled":
+			w.Header().Set("Retry-After", "0")
+			if n == 1 {
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+		case "/daily-limit":
+			// Espera mayor que el backoff máximo: se devuelve el 429 para que el pool de keys rote
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		case "/down":
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := httpclient.New(httpclient.Options{
+		Timeout:        5 * time.Second,
+		MaxRetries:     2,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     10 * time.Millisecond,
+	}, newEventBusTestLogger(t))
+	require.NoError(t, err)
+
+	tests := []struct {
+		path   string
+		status int
+		calls  int32
+	}{
+		{"/flaky", http.StatusOK, 3},
+		{"/throttled", http.StatusOK, 2},
+		{"/daily-limit", http.StatusTooManyRequests, 1},
+		{"/down", http.StatusBadGateway, 3},
+		{"/missing", http.StatusNotFound, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			calls.Store(0)
+			resp, err := client.Get(server.URL + tt.path)
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			assert.Equal(t, tt.calls, calls.Load())
+		})
+	}
+
+	// Las peticiones no idempotentes no se repiten
+	calls.Store(0)
+	resp, err := client.Post(server.URL+"/down", "application/json", http.NoBody)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestHTTPClient_ProxyAndConfig(t *testing.T) {
+	var proxied atomic.Int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Un proxy HTTP recibe la URL absoluta del destino
+		proxied.Add(1)
+		assert.Equal(t, "provider.invalid", r.URL.Host)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	client, err := httpclient.New(httpclient.Options{ProxyURL: proxy.URL}, newEventBusTestLogger(t))
+	require.NoError(t, err)
+	resp, err := client.Get("http://provider.invalid/query")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, int32(1), proxied.Load())
+
+	_, err = httpclient.New(httpclient.Options{ProxyURL: "not a proxy"}, newEventBusTestLogger(t))
+	assert.Error(t, err)
+
+	// El timeout del proveedor tiene prioridad sobre el compartido
+	external := config.ExternalConfig{HTTP: config.HTTPClientConfig{Timeout: 30 * time.Second, MaxRetries: 3, ProxyURL: proxy.URL}}
+	options := httpclient.FromConfig(external, config.APIConfig{Timeout: 5 * time.Second})
+	assert.Equal(t, 5*time.Second, options.Timeout)
+	assert.Equal(t, 3, options.MaxRetries)
+	assert.Equal(t, proxy.URL, options.ProxyURL)
+	assert.Equal(t, 30*time.Second, httpclient.FromConfig(external, config.APIConfig{}).Timeout)
+}
+
+func TestHTTPClient_RecordAndReplayFixtures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.Write([]byte(`{"c":189.84,"symbol":"` + r.URL.Query().Get("symbol") + `"}`))
+	}))
+	dir := t.TempDir()
+	url := server.URL + "/api/v1/quote?token=secret-key&symbol=AAPL"
+
+	recorder, err := httpclient.New(httpclient.Options{FixtureMode: httpclient.FixtureModeRecord, FixtureDir: dir}, newEventBusTestLogger(t))
+	require.NoError(t, err)
+	resp, err := recorder.Get(url)
+	require.NoError(t, err)
+	recorded, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+
+	// La fixture no guarda credenciales ni cookies
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	path := httpclient.FixturePath(dir, req)
+	assert.True(t, strings.HasSuffix(path, "symbol=AAPL.json"), path)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "secret")
+
+	// En replay no hay acceso a la red: el servidor ya está cerrado
+	server.Close()
+	replayer, err := httpclient.New(httpclient.Options{FixtureMode: httpclient.FixtureModeReplay, FixtureDir: dir}, newEventBusTestLogger(t))
+	require.NoError(t, err)
+	resp, err = replayer.Get(url)
+	require.NoError(t, err)
+	replayed, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.JSONEq(t, string(recorded), string(replayed))
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+	// Cambiar la API key no cambia la fixture; otro símbolo no tiene fixture
+	resp, err = replayer.Get(strings.Replace(url, "secret-key", "other-key", 1))
+	require.NoError(t, err)
+	resp.Body.Close()
+	_, err = replayer.Get(strings.Replace(url, "AAPL", "MSFT", 1))
+	assert.True(t, errors.Is(err, httpclient.ErrFixtureNotFound), "unexpected error: %v", err)
+
+	_, err = httpclient.New(httpclient.Options{FixtureMode: "rewind", FixtureDir: dir}, newEventBusTestLogger(t))
+	assert.Error(t, err)
+
+	// Las fixtures nunca se activan en producción
+	assert.Error(t, config.HTTPClientConfig{FixtureMode: httpclient.FixtureModeReplay, FixtureDir: dir}.Validate(true))
+	assert.NoError(t, config.HTTPClientConfig{FixtureMode: httpclient.FixtureModeReplay, FixtureDir: dir}.Validate(false))
+	assert.NoError(t, config.HTTPClientConfig{FixtureMode: httpclient.FixtureModeOff}.Validate(true))
+}
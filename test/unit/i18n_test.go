@@ -0,0 +1,96 @@
+package unit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/i18n"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected i18n.Language
+	}{
+		{"", i18n.English},
+		{"es", i18n.Spanish},
+		{"es-CO,es;q=0.9,en;q=0.8", i18n.Spanish},
+		{"fr-FR,es;q=0.5,en;q=0.7", i18n.English},
+		{"fr, de;q=0.8", i18n.English},
+		{"en;q=0, es;q=0.1", i18n.Spanish},
+		{"*", i18n.English},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, i18n.ParseAcceptLanguage(tt.header), tt.header)
+	}
+}
+
+func TestTranslate_Spanish(t *testing.T) {
+	assert.Equal(t, "Parámetros de paginación no válidos", i18n.Translate(i18n.Spanish, "Invalid pagination parameters"))
+	assert.Equal(t, "No se encontró la empresa", i18n.Translate(i18n.Spanish, response.NotFound("Company").Message))
+	assert.Equal(t, "No se encontró la empresa con símbolo AAPL", i18n.Translate(i18n.Spanish, response.NotFound("Company with symbol AAPL").Message))
+	assert.Equal(t, "No se encontraron las empresas AAPL, MSFT", i18n.Translate(i18n.Spanish, response.NotFound("Companies AAPL, MSFT").Message))
+	assert.Equal(t, "Debe tener al menos 3 caracteres", i18n.Translate(i18n.Spanish, "Must be at least 3 characters long"))
+	assert.Equal(t, "Debe ser mayor o igual que 1", i18n.Translate(i18n.Spanish, "Must be greater than or equal to 1"))
+
+	// Sin traducción se devuelve el mensaje original; en inglés nunca se traduce
+	assert.Equal(t, "Something unusual happened", i18n.Translate(i18n.Spanish, "Something unusual happened"))
+	assert.Equal(t, "Widget not found", i18n.Translate(i18n.Spanish, "Widget not found"))
+	assert.Equal(t, "Invalid pagination parameters", i18n.Translate(i18n.English, "Invalid pagination parameters"))
+
+	assert.Equal(t, "Comprar", i18n.RecommendationLabel(i18n.Spanish, "Buy"))
+	assert.Equal(t, "Sin datos disponibles", i18n.RecommendationLabel(i18n.Spanish, "No data available"))
+	assert.Equal(t, "Hold", i18n.RecommendationLabel(i18n.English, "Hold"))
+}
+
+func TestRespondWithError_LocalizedByAcceptLanguage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	defer response.ConfigureErrorFormat(response.ErrorFormatProblem, response.DefaultProblemTypeBaseURI)
+
+	errorResp := response.ValidationFailedWithFields([]response.ValidationError{
+		{Field: "ticker", Tag: "required", Message: "This field is required"},
+	})
+	router := gin.New()
+	router.Use(i18n.LanguageMiddleware())
+	router.GET("/fail", func(c *gin.Context) {
+		middleware.RespondWithError(c, errorResp)
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/fail", nil)
+	request.Header.Set("Accept-Language", "es-ES,es;q=0.9")
+
+	response.ConfigureErrorFormat(response.ErrorFormatProblem, "")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	assert.Equal(t, "es", recorder.Header().Get("Content-Language"))
+	assert.Contains(t, recorder.Header().Values("Vary"), "Accept-Language")
+
+	var problem response.ProblemDetails
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &problem))
+	assert.Equal(t, "Petición incorrecta", problem.Title)
+	assert.Equal(t, "La validación de la petición falló", problem.Detail)
+	assert.Equal(t, string(response.ErrCodeValidationFailed), problem.Code)
+	require.Len(t, problem.Errors, 1)
+	assert.Equal(t, "Este campo es obligatorio", problem.Errors[0].Message)
+
+	// El envelope legacy también se traduce y el error original no se modifica
+	response.ConfigureErrorFormat(response.ErrorFormatLegacy, "")
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	var apiResponse response.APIResponse[interface{}]
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &apiResponse))
+	assert.Equal(t, "La validación de la petición falló", apiResponse.Error.Message)
+	assert.Equal(t, "Request validation failed", errorResp.Message)
+	assert.Equal(t, "This field is required", errorResp.ValidationErrors[0].Message)
+}
@@ -0,0 +1,118 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/usecases/population"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/config"
+)
+
+func newRuleTestItem(ticker string, eventTime time.Time) population.StockDataItem {
+	return population.StockDataItem{
+		Ticker:     ticker,
+		Company:    "Apple Inc.",
+		Brokerage:  "Goldman Sachs",
+		Action:     "upgraded by",
+		RatingFrom: "Neutral",
+		RatingTo:   "Buy",
+		TargetTo:   "$210.00",
+		EventTime:  eventTime,
+	}
+}
+
+func TestRuleEngine_DefaultActions(t *testing.T) {
+	engine, err := population.NewRuleEngine(population.DefaultRuleSettings())
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	items := []population.StockDataItem{
+		newRuleTestItem("AAPL", now.Add(-time.Hour)),
+		newRuleTestItem(" msft ", now.Add(-time.Hour)),                      // Se corrige
+		newRuleTestItem("NVDA", now.Add(72*time.Hour)),                      // Futuro: cuarentena
+		newRuleTestItem("IBM", time.Date(1999, 6, 1, 0, 0, 0, 0, time.UTC)), // Antiguo: cuarentena
+		{Ticker: "TSLA", EventTime: now},                                    // Faltan campos
+	}
+
+	accepted, violations := engine.Apply(items)
+	require.Len(t, accepted, 2)
+	assert.Equal(t, "MSFT", accepted[1].Ticker)
+
+	require.Len(t, violations, 3)
+	assert.Equal(t, population.RuleFutureEventTime, violations[0].Rule)
+	assert.Equal(t, population.RuleActionQuarantine, violations[0].Action)
+	assert.Equal(t, population.RuleStaleEventTime, violations[1].Rule)
+	assert.Equal(t, population.RuleMissingFields, violations[2].Rule)
+
+	stats := make(map[string]population.RuleStats)
+	for _, s := range engine.Stats() {
+		stats[s.Name] = s
+	}
+	assert.Equal(t, int64(1), stats[population.RuleTickerFormat].Corrected)
+	assert.Equal(t, int64(1), stats[population.RuleFutureEventTime].Quarantined)
+	assert.Equal(t, int64(5), stats[population.RuleMissingFields].Evaluated)
+	assert.Equal(t, population.RuleActionOff, stats[population.RuleUnknownRating].Action)
+	assert.Zero(t, stats[population.RuleUnknownRating].Evaluated)
+}
+
+func TestRuleEngine_ConfiguredActions(t *testing.T) {
+	settings := population.DefaultRuleSettings()
+	settings.Actions = map[string]population.RuleAction{
+		population.RuleFutureEventTime:   population.RuleActionCorrect,
+		population.RuleUnknownRating:     population.RuleActionReject,
+		population.RuleUnparseableTarget: population.RuleActionCorrect,
+	}
+	engine, err := population.NewRuleEngine(settings)
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	future := newRuleTestItem("NVDA", now.Add(72*time.Hour))
+	future.TargetFrom = "n/a"
+	unmapped := newRuleTestItem("AMD", now)
+	unmapped.RatingTo = "Speculative Hold"
+
+	accepted, violations := engine.Apply([]population.StockDataItem{future, unmapped})
+	require.Len(t, accepted, 1)
+	assert.False(t, accepted[0].EventTime.After(time.Now()))
+	assert.Empty(t, accepted[0].TargetFrom)
+	assert.Equal(t, "$210.00", accepted[0].TargetTo)
+
+	require.Len(t, violations, 1)
+	assert.Equal(t, population.RuleUnknownRating, violations[0].Rule)
+	assert.Equal(t, population.RuleActionReject, violations[0].Action)
+}
+
+func TestRuleEngine_InvalidSettings(t *testing.T) {
+	settings := population.DefaultRuleSettings()
+	settings.Actions = map[string]population.RuleAction{"price_range": population.RuleActionReject}
+	_, err := population.NewRuleEngine(settings)
+	assert.Error(t, err)
+
+	settings.Actions = map[string]population.RuleAction{population.RuleStaleEventTime: population.RuleActionCorrect}
+	_, err = population.NewRuleEngine(settings)
+	assert.Error(t, err)
+}
+
+func TestIngestionConfig_Validate(t *testing.T) {
+	ingestion := config.IngestionConfig{
+		RulesEnabled:    true,
+		Rules:           map[string]string{"future_event_time": config.RuleActionCorrect},
+		FutureTolerance: time.Hour,
+		MinEventDate:    "2010-01-01",
+	}
+	assert.NoError(t, ingestion.Validate())
+
+	ingestion.Rules["ticker_format"] = "drop"
+	assert.Error(t, ingestion.Validate())
+
+	delete(ingestion.Rules, "ticker_format")
+	ingestion.MinEventDate = "01/01/2010"
+	assert.Error(t, ingestion.Validate())
+
+	ingestion.MinEventDate = "2010-01-01"
+	ingestion.DuplicateWindow = -time.Minute
+	assert.Error(t, ingestion.Validate())
+}
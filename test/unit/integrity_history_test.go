@@ -0,0 +1,74 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+// fakeIntegritySnapshotRepository guarda los snapshots en memoria, del más antiguo al más reciente
+type fakeIntegritySnapshotRepository struct {
+	snapshots []*entities.IntegritySnapshot
+}
+
+func (r *fakeIntegritySnapshotRepository) Create(ctx context.Context, snapshot *entities.IntegritySnapshot) error {
+	snapshot.CreatedAt = time.Now().UTC()
+	r.snapshots = append(r.snapshots, snapshot)
+	return nil
+}
+
+func (r *fakeIntegritySnapshotRepository) GetSince(ctx context.Context, since time.Time, limit int) ([]*entities.IntegritySnapshot, error) {
+	result := make([]*entities.IntegritySnapshot, 0, len(r.snapshots))
+	for _, snapshot := range r.snapshots {
+		if !snapshot.CreatedAt.Before(since) {
+			result = append(result, snapshot)
+		}
+	}
+	return result, nil
+}
+
+func TestIntegrityTrend(t *testing.T) {
+	earlier := &entities.IntegritySnapshot{TotalIssues: 10, CriticalIssues: 2}
+
+	assert.Equal(t, entities.IntegrityTrendImproving, entities.IntegrityTrend(earlier, &entities.IntegritySnapshot{TotalIssues: 4, CriticalIssues: 2}))
+	assert.Equal(t, entities.IntegrityTrendDegrading, entities.IntegrityTrend(earlier, &entities.IntegritySnapshot{TotalIssues: 10, CriticalIssues: 3}))
+	assert.Equal(t, entities.IntegrityTrendStable, entities.IntegrityTrend(earlier, &entities.IntegritySnapshot{TotalIssues: 10, CriticalIssues: 2}))
+	assert.Equal(t, entities.IntegrityTrendInsufficient, entities.IntegrityTrend(nil, earlier))
+
+	// Menos problemas críticos es una mejora aunque crezcan los avisos
+	assert.Equal(t, entities.IntegrityTrendImproving, entities.IntegrityTrend(earlier, &entities.IntegritySnapshot{TotalIssues: 15, CriticalIssues: 1}))
+}
+
+func TestIntegrityHistoryService_GetHistory(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	repo := &fakeIntegritySnapshotRepository{snapshots: []*entities.IntegritySnapshot{
+		{Status: "WARNING", TotalIssues: 50, CriticalIssues: 1, CreatedAt: now.AddDate(0, 0, -60)},
+		{Status: "WARNING", TotalIssues: 12, CriticalIssues: 1, WarningIssues: 11, CreatedAt: now.AddDate(0, 0, -20)},
+		{Status: "CRITICAL", TotalIssues: 20, CriticalIssues: 3, WarningIssues: 17, CreatedAt: now.AddDate(0, 0, -1)},
+	}}
+	service := services.NewIntegrityHistoryService(nil, nil, repo, newEventBusTestLogger(t))
+
+	history, err := service.GetHistory(ctx, &request.IntegrityHistoryRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 30, history.Days)
+	require.Len(t, history.Snapshots, 2)
+	assert.Equal(t, "CRITICAL", history.Latest.Status)
+	assert.Equal(t, entities.IntegrityTrendDegrading, history.Trend.Direction)
+	assert.Equal(t, 8, history.Trend.TotalIssuesChange)
+	assert.Equal(t, 2, history.Trend.CriticalIssuesChange)
+	assert.Equal(t, 6, history.Trend.WarningIssuesChange)
+
+	// Con un solo snapshot no hay tendencia
+	history, err = service.GetHistory(ctx, &request.IntegrityHistoryRequest{Days: 7})
+	require.NoError(t, err)
+	require.Len(t, history.Snapshots, 1)
+	assert.Equal(t, entities.IntegrityTrendInsufficient, history.Trend.Direction)
+}
@@ -0,0 +1,131 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/jobs"
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	domainServices "github.com/MayaCris/stock-info-app/internal/domain/services"
+)
+
+// fakeRepairIntegrityService devuelve el plan configurado y registra las reparaciones aplicadas
+type fakeRepairIntegrityService struct {
+	domainServices.IntegrityValidationService
+	actions []domainServices.RepairAction
+	applied int
+}
+
+func (s *fakeRepairIntegrityService) RepairMinorIssues(ctx context.Context, dryRun bool) (*domainServices.RepairReport, error) {
+	if !dryRun {
+		s.applied++
+	}
+	return &domainServices.RepairReport{
+		DryRun:            dryRun,
+		Status:            domainServices.IntegrityStatusHealthy,
+		RemovedDuplicates: len(s.actions),
+		TotalRepairs:      len(s.actions),
+		Actions:           s.actions,
+	}, nil
+}
+
+func TestRepairReport_PlanToken(t *testing.T) {
+	keptID := uuid.New()
+	orphan := domainServices.RepairAction{Action: domainServices.RepairActionDelete, Entity: "stock_rating", ID: uuid.New()}
+	merge := domainServices.RepairAction{Action: domainServices.RepairActionMerge, Entity: "company", ID: uuid.New(), TargetID: &keptID, RelinkedRatings: 3}
+
+	report := &domainServices.RepairReport{Actions: []domainServices.RepairAction{orphan, merge}}
+	token := report.PlanToken()
+	assert.Len(t, token, 64)
+
+	// El orden de las acciones y los ratings re-enlazados no cambian el plan
+	merge.RelinkedRatings = 5
+	assert.Equal(t, token, (&domainServices.RepairReport{Actions: []domainServices.RepairAction{merge, orphan}}).PlanToken())
+
+	// Un registro nuevo sí
+	extra := domainServices.RepairAction{Action: domainServices.RepairActionDelete, Entity: "stock_rating", ID: uuid.New()}
+	assert.NotEqual(t, token, (&domainServices.RepairReport{Actions: []domainServices.RepairAction{orphan, merge, extra}}).PlanToken())
+}
+
+func TestIntegrityRepairService_ApprovalFlow(t *testing.T) {
+	ctx := context.Background()
+	keptID := uuid.New()
+	integrity := &fakeRepairIntegrityService{actions: []domainServices.RepairAction{
+		{Action: domainServices.RepairActionMerge, Entity: "company", ID: uuid.New(), TargetID: &keptID, RelinkedRatings: 2},
+	}}
+	service := services.NewIntegrityRepairService(integrity, newEventBusTestLogger(t))
+
+	// Sin dry_run se hace una vista previa
+	preview, err := service.Repair(ctx, &request.IntegrityRepairRequest{})
+	require.NoError(t, err)
+	assert.True(t, preview.DryRun)
+	assert.NotEmpty(t, preview.PlanToken)
+	require.Len(t, preview.Actions, 1)
+	assert.Equal(t, int64(2), preview.Actions[0].RelinkedRatings)
+	assert.Equal(t, 0, integrity.applied)
+
+	// Una reparación destructiva necesita aprobación
+	apply := false
+	_, err = service.Repair(ctx, &request.IntegrityRepairRequest{DryRun: &apply})
+	var errorResp *response.ErrorResponse
+	require.True(t, errors.As(err, &errorResp))
+	assert.Equal(t, response.ErrCodeBadRequest, errorResp.Code)
+
+	// Si el plan cambió desde la vista previa no se aplica
+	integrity.actions = append(integrity.actions, domainServices.RepairAction{Action: domainServices.RepairActionDelete, Entity: "stock_rating", ID: uuid.New()})
+	_, err = service.Repair(ctx, &request.IntegrityRepairRequest{DryRun: &apply, ApprovalToken: preview.PlanToken})
+	require.True(t, errors.As(err, &errorResp))
+	assert.Equal(t, response.ErrCodeConflict, errorResp.Code)
+	assert.Equal(t, 0, integrity.applied)
+
+	// Con el token del plan vigente se aplica
+	preview, err = service.Repair(ctx, &request.IntegrityRepairRequest{})
+	require.NoError(t, err)
+	result, err := service.Repair(ctx, &request.IntegrityRepairRequest{DryRun: &apply, ApprovalToken: preview.PlanToken})
+	require.NoError(t, err)
+	assert.False(t, result.DryRun)
+	assert.Empty(t, result.PlanToken)
+	assert.Len(t, result.Actions, 2)
+	assert.Equal(t, 1, integrity.applied)
+}
+
+func TestIntegrityRepairJobHandler_RequiresApproval(t *testing.T) {
+	ctx := context.Background()
+	integrity := &fakeRepairIntegrityService{actions: []domainServices.RepairAction{
+		{Action: domainServices.RepairActionDelete, Entity: "stock_rating", ID: uuid.New()},
+	}}
+	handler := jobs.NewIntegrityRepairJobHandler(services.NewIntegrityRepairService(integrity, newEventBusTestLogger(t)))
+
+	// Sin payload el job solo simula
+	for _, payload := range []string{"", `{}`, `{"dry_run":true}`} {
+		_, err := handler(ctx, entities.NewJob(jobs.JobTypeIntegrityRepair, payload, 3))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 0, integrity.applied)
+
+	// Aplicar sin token o con un token ajeno se rechaza
+	wrongToken := strings.Repeat("0", 64)
+	for _, payload := range []string{`{"dry_run":false}`, `{"dry_run":false,"approval_token":"` + wrongToken + `"}`} {
+		_, err := handler(ctx, entities.NewJob(jobs.JobTypeIntegrityRepair, payload, 3))
+		var errorResp *response.ErrorResponse
+		require.True(t, errors.As(err, &errorResp))
+	}
+	assert.Equal(t, 0, integrity.applied)
+
+	// Con el plan_token de la simulación se aplica
+	result, err := handler(ctx, entities.NewJob(jobs.JobTypeIntegrityRepair, `{}`, 3))
+	require.NoError(t, err)
+	token := result.(*response.IntegrityRepairResponse).PlanToken
+	_, err = handler(ctx, entities.NewJob(jobs.JobTypeIntegrityRepair, `{"dry_run":false,"approval_token":"`+token+`"}`, 3))
+	require.NoError(t, err)
+	assert.Equal(t, 1, integrity.applied)
+}
@@ -0,0 +1,53 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+func TestNewIntradayQuotesResponse(t *testing.T) {
+	to := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+	from := to.Add(-time.Hour)
+	snapshots := []*entities.QuoteSnapshot{
+		{Price: 100, Volume: 10, MarketTimestamp: from.Add(5 * time.Minute)},
+		{Price: 104, Volume: 20, MarketTimestamp: from.Add(20 * time.Minute)},
+		{Price: 98, Volume: 30, MarketTimestamp: from.Add(40 * time.Minute)},
+		{Price: 102, Volume: 40, MarketTimestamp: from.Add(55 * time.Minute)},
+	}
+
+	result := services.NewIntradayQuotesResponse("AAPL", 60, from, to, snapshots)
+	assert.Equal(t, "AAPL", result.Symbol)
+	assert.Equal(t, 4, result.Count)
+	require.Len(t, result.Points, 4)
+	assert.Equal(t, snapshots[1].MarketTimestamp, result.Points[1].Timestamp)
+	assert.Equal(t, int64(40), result.Points[3].Volume)
+
+	require.NotNil(t, result.High)
+	require.NotNil(t, result.Low)
+	assert.Equal(t, 104.0, *result.High)
+	assert.Equal(t, 98.0, *result.Low)
+
+	// El cambio compara el último precio de la ventana con el primero
+	require.NotNil(t, result.Change)
+	require.NotNil(t, result.ChangePerc)
+	assert.InDelta(t, 2, *result.Change, 1e-9)
+	assert.InDelta(t, 2, *result.ChangePerc, 1e-9)
+}
+
+func TestNewIntradayQuotesResponse_Empty(t *testing.T) {
+	to := time.Now().UTC()
+	result := services.NewIntradayQuotesResponse("AAPL", 60, to.Add(-time.Hour), to, nil)
+
+	assert.Equal(t, 0, result.Count)
+	assert.NotNil(t, result.Points)
+	assert.Empty(t, result.Points)
+	assert.Nil(t, result.High)
+	assert.Nil(t, result.Change)
+	assert.Nil(t, result.ChangePerc)
+}
@@ -0,0 +1,46 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/jobs"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+func TestJob_NewJobDefaults(t *testing.T) {
+	job := entities.NewJob(jobs.JobTypePopulation, "", 0)
+
+	assert.Equal(t, entities.JobStatusPending, job.Status)
+	assert.Equal(t, 1, job.MaxAttempts)
+	assert.False(t, job.IsTerminal())
+	assert.True(t, job.CanRetry())
+}
+
+func TestJob_RetryBackoff(t *testing.T) {
+	job := entities.NewJob(jobs.JobTypeIntegrityRepair, `{"dry_run":true}`, 3)
+	base := 10 * time.Second
+
+	job.Attempts = 1
+	assert.Equal(t, 10*time.Second, job.NextRetryDelay(base))
+	job.Attempts = 2
+	assert.Equal(t, 20*time.Second, job.NextRetryDelay(base))
+	job.Attempts = 3
+	assert.Equal(t, 40*time.Second, job.NextRetryDelay(base))
+	assert.False(t, job.CanRetry())
+}
+
+func TestJob_SupportedJobTypes(t *testing.T) {
+	assert.True(t, jobs.IsSupportedJobType(jobs.JobTypeMarketDataRefresh))
+	assert.False(t, jobs.IsSupportedJobType("unknown"))
+
+	// La petición de encolado acepta todos los tipos registrados; la cola rechaza el resto
+	for _, jobType := range jobs.SupportedJobTypes() {
+		assert.NoError(t, binding.Validator.ValidateStruct(request.EnqueueJobRequest{Type: jobType}), jobType)
+	}
+	assert.True(t, jobs.IsSupportedJobType(jobs.JobTypeRatingProcessing))
+}
@@ -0,0 +1,51 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/keypool"
+)
+
+func TestKeyPool_RoundRobinSkipsSidelinedKeys(t *testing.T) {
+	pool := keypool.New([]string{"a", "b", "c", "a", ""}, keypool.StrategyRoundRobin)
+	assert.Equal(t, 3, pool.Size())
+
+	first, _ := pool.Acquire()
+	second, _ := pool.Acquire()
+	assert.Equal(t, "a", first)
+	assert.Equal(t, "b", second)
+
+	pool.Sideline("c", time.Now().Add(time.Minute))
+	next, err := pool.Acquire()
+	assert.NoError(t, err)
+	assert.Equal(t, "a", next)
+}
+
+func TestKeyPool_LeastRecentlyUsed(t *testing.T) {
+	pool := keypool.New([]string{"a", "b"}, keypool.StrategyLeastRecentlyUsed)
+
+	first, _ := pool.Acquire()
+	second, _ := pool.Acquire()
+	third, _ := pool.Acquire()
+	assert.Equal(t, "a", first)
+	assert.Equal(t, "b", second)
+	assert.Equal(t, "a", third)
+}
+
+func TestKeyPool_AllKeysSidelined(t *testing.T) {
+	pool := keypool.New([]string{"a", "b"}, keypool.StrategyRoundRobin)
+	pool.Sideline("a", time.Now().Add(time.Minute))
+	pool.Sideline("b", time.Now().Add(time.Minute))
+
+	_, err := pool.Acquire()
+	assert.ErrorIs(t, err, keypool.ErrNoAvailableKeys)
+
+	// Al recargar las keys se conserva el estado de las que siguen en el pool
+	pool.SetKeys([]string{"a", "b", "d"})
+	key, err := pool.Acquire()
+	assert.NoError(t, err)
+	assert.Equal(t, "d", key)
+}
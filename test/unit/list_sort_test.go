@@ -0,0 +1,80 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+)
+
+// sortRecordingCompanyRepository guarda el filtro, el orden y la página pedidos a List
+type sortRecordingCompanyRepository struct {
+	interfaces.CompanyRepository
+	filter        interfaces.CompanyListFilter
+	sort          []interfaces.SortField
+	limit, offset int
+}
+
+func (r *sortRecordingCompanyRepository) List(ctx context.Context, filter interfaces.CompanyListFilter, sort []interfaces.SortField, limit, offset int) ([]*entities.Company, int64, error) {
+	r.filter, r.sort, r.limit, r.offset = filter, sort, limit, offset
+	return []*entities.Company{{Ticker: "MSFT"}, {Ticker: "AAPL"}}, 42, nil
+}
+
+func TestSortableFields_Parse(t *testing.T) {
+	sort, err := interfaces.CompanySortFields.Parse(" market_cap:DESC, ticker ")
+	require.NoError(t, err)
+	assert.Equal(t, []interfaces.SortField{{Column: "market_cap", Desc: true}, {Column: "ticker"}}, sort)
+
+	// Los campos públicos se traducen a su columna
+	sort, err = interfaces.StockRatingSortFields.Parse("target_to:asc")
+	require.NoError(t, err)
+	assert.Equal(t, []interfaces.SortField{{Column: "target_to_value"}}, sort)
+
+	sort, err = interfaces.BrokerageSortFields.Parse("")
+	require.NoError(t, err)
+	assert.Empty(t, sort)
+
+	for _, value := range []string{"ticker;drop table companies", "name:sideways", "name,name:desc", "market_cap"} {
+		_, err = interfaces.BrokerageSortFields.Parse(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestCompanyService_ListCompaniesSort(t *testing.T) {
+	repo := &sortRecordingCompanyRepository{}
+	service := services.NewCompanyService(repo, nil, newEventBusTestLogger(t))
+
+	active := true
+	pagination := &response.PaginationRequest{Page: 3, PerPage: 20}
+	result, err := service.ListCompanies(context.Background(), &request.CompanyFilterRequest{
+		Sector:   "Technology",
+		IsActive: &active,
+		Sort:     "market_cap:desc",
+	}, pagination)
+	require.NoError(t, err)
+
+	// El orden de la base de datos se conserva y la paginación se resuelve en la consulta
+	assert.Equal(t, interfaces.CompanyListFilter{Sector: "Technology", IsActive: &active}, repo.filter)
+	assert.Equal(t, []interfaces.SortField{{Column: "market_cap", Desc: true}}, repo.sort)
+	assert.Equal(t, 20, repo.limit)
+	assert.Equal(t, 40, repo.offset)
+	assert.Equal(t, 42, result.Meta.Total)
+	require.Len(t, result.Items, 2)
+	assert.Equal(t, "MSFT", result.Items[0].Ticker)
+
+	_, err = service.ListCompanies(context.Background(), &request.CompanyFilterRequest{Sort: "price:desc"}, pagination)
+	var errorResp *response.ErrorResponse
+	require.True(t, errors.As(err, &errorResp))
+	assert.Equal(t, http.StatusBadRequest, errorResp.StatusCode)
+	require.Len(t, errorResp.ValidationErrors, 1)
+	assert.Equal(t, "sort", errorResp.ValidationErrors[0].Field)
+}
@@ -0,0 +1,143 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/testutil/testdoubles"
+)
+
+// breadthHistoricalRepo devuelve los precios guardados del rango pedido
+type breadthHistoricalRepo struct {
+	repoInterfaces.HistoricalDataRepository
+	prices []*entities.HistoricalData
+}
+
+func (r *breadthHistoricalRepo) GetByDateRange(ctx context.Context, startDate, endDate time.Time) ([]*entities.HistoricalData, error) {
+	var result []*entities.HistoricalData
+	for _, data := range r.prices {
+		if !data.Date.Before(startDate) && !data.Date.After(endDate) {
+			result = append(result, data)
+		}
+	}
+	return result, nil
+}
+
+func TestAnalysisService_GetMarketBreadth(t *testing.T) {
+	store := testdoubles.NewStore()
+	companyRepo := testdoubles.NewCompanyRepository(store)
+	marketDataRepo := testdoubles.NewMarketDataRepository(store)
+	ctx := context.Background()
+
+	newCompany := func(ticker string) *entities.Company {
+		company := entities.NewCompany(ticker, ticker+" Inc.")
+		require.NoError(t, companyRepo.Create(ctx, company))
+		return company
+	}
+	rising, falling, young, inactive := newCompany("UP"), newCompany("DOWN"), newCompany("NEW"), newCompany("OLD")
+	require.NoError(t, companyRepo.Deactivate(ctx, inactive.ID))
+
+	// Una sesión por día natural hasta hoy: UP sube y DOWN baja cada día; NEW solo tiene 10 sesiones planas
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	history := &breadthHistoricalRepo{}
+	addSessions := func(company *entities.Company, sessions int, price func(i int) float64) {
+		for i := 0; i < sessions; i++ {
+			history.prices = append(history.prices, &entities.HistoricalData{
+				CompanyID:  company.ID,
+				Symbol:     company.Ticker,
+				Date:       today.AddDate(0, 0, i-sessions+1),
+				ClosePrice: price(i),
+				TimeFrame:  "1D",
+			})
+		}
+	}
+	addSessions(rising, 260, func(i int) float64 { return 100 + float64(i) })
+	addSessions(falling, 260, func(i int) float64 { return 400 - float64(i) })
+	addSessions(young, 10, func(int) float64 { return 50 })
+	addSessions(inactive, 260, func(i int) float64 { return 100 + float64(i) })
+
+	newQuote := func(company *entities.Company, change float64, timestamp time.Time) {
+		require.NoError(t, marketDataRepo.Create(ctx, &entities.MarketData{
+			CompanyID:       company.ID,
+			Symbol:          company.Ticker,
+			CurrentPrice:    100,
+			PriceChange:     change,
+			MarketTimestamp: timestamp,
+		}))
+	}
+	quoteTime := time.Date(2026, 3, 2, 20, 0, 0, 0, time.UTC)
+	newQuote(rising, -1, quoteTime.Add(-24*time.Hour))
+	newQuote(rising, 2, quoteTime)
+	newQuote(falling, -3, quoteTime.Add(-time.Hour))
+	newQuote(young, 0, quoteTime.Add(-time.Hour))
+	newQuote(inactive, 5, quoteTime)
+
+	service := services.NewAnalysisService(nil, companyRepo, nil, nil, history, marketDataRepo, nil, nil, nil, nil, 0, services.DefaultScoringWeights(), newEventBusTestLogger(t))
+
+	breadth, err := service.GetMarketBreadth(ctx, &request.MarketBreadthRequest{Days: 5})
+	require.NoError(t, err)
+	assert.Equal(t, 5, breadth.Days)
+	assert.Equal(t, today.Format("2006-01-02"), breadth.To)
+	assert.Equal(t, 3, breadth.Tracked)
+	require.Len(t, breadth.Series, 5)
+
+	last := breadth.Series[4]
+	assert.Equal(t, today.Format("2006-01-02"), last.Date)
+	assert.Equal(t, 3, last.Symbols)
+	assert.Equal(t, 1, last.Advancers)
+	assert.Equal(t, 1, last.Decliners)
+	assert.Equal(t, 1, last.Unchanged)
+	assert.Equal(t, 0, last.NetAdvances)
+	require.NotNil(t, last.AdvanceDeclineRatio)
+	assert.Equal(t, 1.0, *last.AdvanceDeclineRatio)
+	// NEW no tiene 50 sesiones: solo cuentan UP (por encima) y DOWN (por debajo)
+	assert.Equal(t, 1, last.AboveMA50)
+	require.NotNil(t, last.PercentAboveMA50)
+	assert.Equal(t, 50.0, *last.PercentAboveMA50)
+	require.NotNil(t, last.PercentAboveMA200)
+	assert.Equal(t, 50.0, *last.PercentAboveMA200)
+
+	require.NotNil(t, breadth.Latest)
+	assert.Equal(t, 3, breadth.Latest.Quotes)
+	assert.Equal(t, 1, breadth.Latest.Advancers)
+	assert.Equal(t, 1, breadth.Latest.Decliners)
+	assert.Equal(t, 1, breadth.Latest.Unchanged)
+	require.NotNil(t, breadth.Latest.AsOf)
+	assert.True(t, breadth.Latest.AsOf.Equal(quoteTime))
+
+	// Sin precios históricos el servicio no está disponible
+	withoutHistory := services.NewAnalysisService(nil, companyRepo, nil, nil, nil, nil, nil, nil, nil, nil, 0, services.DefaultScoringWeights(), newEventBusTestLogger(t))
+	_, err = withoutHistory.GetMarketBreadth(ctx, &request.MarketBreadthRequest{})
+	assert.Error(t, err)
+}
+
+func TestMarketBreadthSeries_AdvanceDeclineLine(t *testing.T) {
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	session := func(day int, price float64) *entities.HistoricalData {
+		return &entities.HistoricalData{CompanyID: uuid.New(), Date: from.AddDate(0, 0, day), ClosePrice: price}
+	}
+	sessions := map[string][]*entities.HistoricalData{
+		// La sesión anterior al rango solo da el cierre previo
+		"AAA": {session(2, 12), session(-1, 10), session(0, 11), session(1, 11)},
+		"BBB": {session(-1, 20), session(0, 19), session(1, 18), session(2, 19)},
+	}
+
+	series := services.MarketBreadthSeries(sessions, from)
+	require.Len(t, series, 3)
+	assert.Equal(t, "2026-03-02", series[0].Date)
+	assert.Equal(t, 0, series[0].NetAdvances)
+	assert.Equal(t, -1, series[1].NetAdvances)
+	assert.Nil(t, series[1].PercentAboveMA50)
+	assert.Equal(t, 2, series[2].Advancers)
+	assert.Nil(t, series[2].AdvanceDeclineRatio)
+	assert.Equal(t, []int{0, -1, 1}, []int{series[0].AdvanceDeclineLine, series[1].AdvanceDeclineLine, series[2].AdvanceDeclineLine})
+}
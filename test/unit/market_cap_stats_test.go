@@ -0,0 +1,161 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/config"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/alphavantage"
+	"github.com/MayaCris/stock-info-app/internal/testutil/testdoubles"
+)
+
+// fakeFXRateProvider devuelve tipos fijos por par y cuenta las llamadas
+type fakeFXRateProvider struct {
+	rates map[string]float64
+	calls int
+}
+
+func (p *fakeFXRateProvider) GetRate(ctx context.Context, fromCurrency, toCurrency string) (float64, error) {
+	p.calls++
+	rate, ok := p.rates[fromCurrency+"/"+toCurrency]
+	if !ok {
+		return 0, errors.New("rate unavailable")
+	}
+	return rate, nil
+}
+
+func (p *fakeFXRateProvider) Source() string {
+	return "fake"
+}
+
+func TestFXService_CachesRatesAndInverses(t *testing.T) {
+	provider := &fakeFXRateProvider{rates: map[string]float64{"EUR/USD": 1.25}}
+	fx := services.NewFXService(provider, time.Hour, newEventBusTestLogger(t))
+	ctx := context.Background()
+
+	amount, err := fx.Convert(ctx, 100, "eur", "USD")
+	require.NoError(t, err)
+	assert.InDelta(t, 125.0, amount, 1e-9)
+
+	// El par ya descargado y su inverso no vuelven al proveedor
+	rate, err := fx.Rate(ctx, "USD", "EUR")
+	require.NoError(t, err)
+	assert.InDelta(t, 0.8, rate, 1e-9)
+	_, err = fx.Rate(ctx, "EUR", "USD")
+	require.NoError(t, err)
+	assert.Equal(t, 1, provider.calls)
+
+	rate, err = fx.Rate(ctx, "USD", "usd")
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, rate)
+
+	_, err = fx.Rate(ctx, "JPY", "USD")
+	assert.Error(t, err)
+
+	// Sin proveedor solo se convierte entre la misma moneda
+	withoutProvider := services.NewFXService(nil, time.Hour, newEventBusTestLogger(t))
+	_, err = withoutProvider.Rate(ctx, "EUR", "USD")
+	assert.Error(t, err)
+}
+
+func TestAnalysisService_GetMarketCapStatsByExchangeAndCurrency(t *testing.T) {
+	store := testdoubles.NewStore()
+	companyRepo := testdoubles.NewCompanyRepository(store)
+	ctx := context.Background()
+
+	add := func(ticker, exchange, currency string, marketCap float64) {
+		company := entities.NewCompany(ticker, ticker+" Corp")
+		company.Exchange, company.Currency, company.MarketCap = exchange, currency, marketCap
+		require.NoError(t, companyRepo.Create(ctx, company))
+	}
+	add("AAPL", "NASDAQ", "USD", 3000)
+	add("MSFT", "NASDAQ", "", 2000) // Sin moneda se asume USD
+	add("SAP", "XETRA", "EUR", 200)
+	add("ASML", "NASDAQ", "EUR", 400)
+	add("TM", "TSE", "JPY", 30000)
+	add("NOCAP", "NYSE", "USD", 0)
+
+	provider := &fakeFXRateProvider{rates: map[string]float64{"EUR/USD": 1.25}}
+	fx := services.NewFXService(provider, time.Hour, newEventBusTestLogger(t))
+	service := services.NewAnalysisService(nil, companyRepo, nil, nil, nil, nil, nil, fx, nil, nil, 0, services.DefaultScoringWeights(), newEventBusTestLogger(t))
+
+	stats, err := service.GetMarketCapStats(ctx, &request.MarketCapStatsRequest{GroupBy: "exchange"})
+	require.NoError(t, err)
+	assert.Equal(t, "USD", stats.Currency)
+	assert.Equal(t, map[string]float64{"USD": 1, "EUR": 1.25}, stats.Rates)
+	// TM queda fuera: no hay tipo JPY/USD
+	require.Len(t, stats.Warnings, 1)
+	assert.Contains(t, stats.Warnings[0], "JPY")
+
+	assert.Equal(t, int64(4), stats.Total.Count)
+	assert.InDelta(t, 3000+2000+250+500, stats.Total.Total, 1e-9)
+	assert.InDelta(t, 250.0, stats.Total.Min, 1e-9)
+	assert.InDelta(t, 3000.0, stats.Total.Max, 1e-9)
+
+	require.Len(t, stats.Groups, 2)
+	nasdaq := stats.Groups[0]
+	assert.Equal(t, "NASDAQ", nasdaq.Key)
+	assert.Equal(t, []string{"EUR", "USD"}, nasdaq.Currencies)
+	assert.Equal(t, int64(3), nasdaq.Count)
+	assert.InDelta(t, 5500.0, nasdaq.Total, 1e-9)
+	assert.InDelta(t, 500.0, nasdaq.Min, 1e-9)
+	require.NotNil(t, nasdaq.Share)
+	assert.InDelta(t, 0.9565, *nasdaq.Share, 1e-9)
+	assert.Equal(t, "XETRA", stats.Groups[1].Key)
+
+	// En EUR y por moneda de cotización
+	stats, err = service.GetMarketCapStats(ctx, &request.MarketCapStatsRequest{GroupBy: "currency", Currency: "eur"})
+	require.NoError(t, err)
+	assert.Equal(t, "EUR", stats.Currency)
+	require.Len(t, stats.Groups, 2)
+	assert.Equal(t, "USD", stats.Groups[0].Key)
+	assert.InDelta(t, 4000.0, stats.Groups[0].Total, 1e-9)
+	assert.Equal(t, "EUR", stats.Groups[1].Key)
+	assert.InDelta(t, 600.0, stats.Groups[1].Total, 1e-9)
+	// USD/EUR es el inverso del par ya descargado; solo se pide JPY/EUR
+	assert.Equal(t, 3, provider.calls)
+
+	// Sin servicio de divisas solo se agregan las companies en la moneda pedida
+	withoutFX := services.NewAnalysisService(nil, companyRepo, nil, nil, nil, nil, nil, nil, nil, nil, 0, services.DefaultScoringWeights(), newEventBusTestLogger(t))
+	stats, err = withoutFX.GetMarketCapStats(ctx, &request.MarketCapStatsRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, stats.Groups)
+	assert.Equal(t, int64(2), stats.Total.Count)
+	assert.Len(t, stats.Warnings, 2)
+}
+
+func TestAlphaVantageFXRateProvider_GetRate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "CURRENCY_EXCHANGE_RATE", r.URL.Query().Get("function"))
+		if r.URL.Query().Get("from_currency") == "XXX" {
+			w.Write([]byte(`{"Error Message": "Invalid API call."}`))
+			return
+		}
+		w.Write([]byte(`{"Realtime Currency Exchange Rate": {"1. From_Currency Code": "EUR", "3. To_Currency Code": "USD",
+			"5. Exchange Rate": "1.08450000", "6. Last Refreshed": "2026-03-03 10:15:01"}}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{External: config.ExternalConfig{
+		Secondary:   config.APIConfig{Key: "test-key", BaseURL: server.URL},
+		KeyStrategy: "round_robin",
+	}}
+	provider := alphavantage.NewFXRateProvider(alphavantage.NewClient(cfg, newEventBusTestLogger(t)))
+
+	rate, err := provider.GetRate(context.Background(), "EUR", "USD")
+	require.NoError(t, err)
+	assert.Equal(t, 1.0845, rate)
+
+	_, err = provider.GetRate(context.Background(), "XXX", "USD")
+	assert.Error(t, err)
+}
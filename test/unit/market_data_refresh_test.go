@@ -0,0 +1,68 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	domainServices "github.com/MayaCris/stock-info-app/internal/domain/services"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/database/cache"
+)
+
+func TestPrioritizeRefresh_ViewsThenStaleness(t *testing.T) {
+	now := time.Now()
+	queue := services.PrioritizeRefresh([]services.RefreshCandidate{
+		{Symbol: "FRESH", LastUpdated: now},
+		{Symbol: "OLD", LastUpdated: now.Add(-time.Hour)},
+		{Symbol: "NEVER"},
+		{Symbol: "POPULAR", LastUpdated: now, Views: 10},
+		{Symbol: "ALSO", LastUpdated: now},
+	})
+
+	symbols := make([]string, len(queue))
+	for i, candidate := range queue {
+		symbols[i] = candidate.Symbol
+	}
+	assert.Equal(t, []string{"POPULAR", "NEVER", "OLD", "ALSO", "FRESH"}, symbols)
+}
+
+func TestProviderQuota_CountsAgainstDailyBudget(t *testing.T) {
+	ctx := context.Background()
+	quota := domainServices.NewProviderQuota(cache.NewMemoryCacheServiceOnly(), map[string]int{
+		domainServices.ProviderFinnhub: 2,
+	})
+
+	remaining, limited, err := quota.Remaining(ctx, domainServices.ProviderFinnhub)
+	require.NoError(t, err)
+	assert.True(t, limited)
+	assert.Equal(t, 2, remaining)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, quota.Record(ctx, domainServices.ProviderFinnhub))
+	}
+
+	used, err := quota.Used(ctx, domainServices.ProviderFinnhub)
+	require.NoError(t, err)
+	assert.Equal(t, 3, used)
+
+	remaining, _, err = quota.Remaining(ctx, domainServices.ProviderFinnhub)
+	require.NoError(t, err)
+	assert.Equal(t, 0, remaining)
+
+	_, limited, err = quota.Remaining(ctx, "other")
+	require.NoError(t, err)
+	assert.False(t, limited)
+}
+
+func TestProviderQuota_NilIsUnlimited(t *testing.T) {
+	var quota *domainServices.ProviderQuota
+
+	assert.NoError(t, quota.Record(context.Background(), domainServices.ProviderFinnhub))
+	_, limited, err := quota.Remaining(context.Background(), domainServices.ProviderFinnhub)
+	require.NoError(t, err)
+	assert.False(t, limited)
+}
@@ -0,0 +1,26 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+)
+
+func TestNormalizeMoverType(t *testing.T) {
+	cases := map[string]string{
+		"":         services.MoverTypeGainers,
+		"gainers":  services.MoverTypeGainers,
+		" Losers ": services.MoverTypeLosers,
+		"ACTIVE":   services.MoverTypeActive,
+	}
+	for input, expected := range cases {
+		moverType, ok := services.NormalizeMoverType(input)
+		assert.True(t, ok, input)
+		assert.Equal(t, expected, moverType, input)
+	}
+
+	_, ok := services.NormalizeMoverType("volume")
+	assert.False(t, ok)
+}
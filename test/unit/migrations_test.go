@@ -0,0 +1,48 @@
+package unit
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/migrations"
+)
+
+func TestMigrations_EveryUpHasDown(t *testing.T) {
+	upFiles, err := fs.Glob(migrations.FS, "*.up.sql")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, upFiles)
+
+	for _, upFile := range upFiles {
+		downFile := strings.TrimSuffix(upFile, ".up.sql") + ".down.sql"
+		_, err := fs.Stat(migrations.FS, downFile)
+		assert.NoError(t, err, "missing down migration for %s", upFile)
+	}
+}
+
+func TestMigrations_CreateMarketDataTables(t *testing.T) {
+	upFiles, err := fs.Glob(migrations.FS, "*.up.sql")
+	assert.NoError(t, err)
+
+	var schema strings.Builder
+	for _, upFile := range upFiles {
+		content, err := fs.ReadFile(migrations.FS, upFile)
+		assert.NoError(t, err)
+		schema.Write(content)
+	}
+
+	// Las tablas que antes solo creaba AutoMigrate deben existir en una base nueva
+	for _, table := range []string{
+		entities.MarketData{}.TableName(),
+		entities.BasicFinancials{}.TableName(),
+		entities.FinancialMetrics{}.TableName(),
+		entities.HistoricalData{}.TableName(),
+		entities.HistoricalDataSummary{}.TableName(),
+		entities.TechnicalIndicators{}.TableName(),
+	} {
+		assert.Contains(t, schema.String(), "CREATE TABLE IF NOT EXISTS "+table+" (", "no migration creates %s", table)
+	}
+}
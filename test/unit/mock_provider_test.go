@@ -0,0 +1,121 @@
+package unit
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/config"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/alphavantage"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/finnhub"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/external/market_data/mockprovider"
+)
+
+// mockProviderClock es un miércoles a media sesión de Nueva York
+func mockProviderClock() time.Time {
+	return time.Date(2026, 3, 11, 17, 45, 0, 0, time.UTC)
+}
+
+func TestMockProvider_ClientsParseSyntheticPayloads(t *testing.T) {
+	provider := mockprovider.New(mockProviderClock)
+	httpClient := &http.Client{Transport: provider.Transport()}
+	ctx := context.Background()
+
+	finnhubClient := finnhub.NewClient(finnhub.ClientConfig{
+		BaseURL:    "http://finnhub.mock/api/v1",
+		APIKey:     "mock",
+		HTTPClient: httpClient,
+		Logger:     newEventBusTestLogger(t),
+	})
+
+	quote, err := finnhubClient.GetRealTimeQuote(ctx, "AAPL")
+	require.NoError(t, err)
+	assert.Equal(t, provider.Quote("AAPL").Price, quote.CurrentPrice)
+	assert.InDelta(t, quote.CurrentPrice-quote.PreviousClose, quote.Change, 0.011)
+
+	// Deterministas: otro proveedor con el mismo reloj da los mismos datos
+	assert.Equal(t, provider.Quote("AAPL"), mockprovider.New(mockProviderClock).Quote("aapl"))
+
+	profile, err := finnhubClient.GetCompanyProfile(ctx, "MSFT")
+	require.NoError(t, err)
+	assert.Equal(t, "Microsoft Corporation", profile.Name)
+
+	peers, err := finnhubClient.GetPeers(ctx, "JPM")
+	require.NoError(t, err)
+	assert.Equal(t, finnhub.PeersResponse{"JPM", "GS"}, peers)
+
+	cfg := &config.Config{External: config.ExternalConfig{
+		Provider:  config.ExternalProviderMock,
+		Secondary: config.APIConfig{Key: "mock", BaseURL: "http://alphavantage.mock/query"},
+	}}
+	alphaClient := alphavantage.NewClient(cfg, newEventBusTestLogger(t))
+	alphaClient.SetHTTPClient(httpClient)
+
+	daily, err := alphaClient.GetTimeSeriesDaily(ctx, "NVDA", "compact")
+	require.NoError(t, err)
+	assert.Len(t, daily.TimeSeries, 100)
+	bar := daily.TimeSeries["2026-03-10"]
+	candle := provider.Candle("NVDA", time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC))
+	closePrice, err := alphavantage.RequireFloat(bar.Close)
+	require.NoError(t, err)
+	assert.Equal(t, candle.Close, closePrice)
+	assert.GreaterOrEqual(t, candle.High, candle.Close)
+	assert.LessOrEqual(t, candle.Low, candle.Open)
+	assert.NotContains(t, daily.TimeSeries, "2026-03-08") // Domingo
+
+	overview, err := alphaClient.GetCompanyOverview(ctx, "KO")
+	require.NoError(t, err)
+	assert.Equal(t, "The Coca-Cola Company", overview.Name)
+
+	// Las funciones no simuladas fallan como una petición rechazada por Alpha Vantage
+	_, err = alphaClient.GetRSI(ctx, "KO", "daily", "14", "close")
+	assert.ErrorIs(t, err, alphavantage.ErrRequestRejected)
+}
+
+func TestMockProvider_Ratings(t *testing.T) {
+	provider := mockprovider.New(mockProviderClock)
+	ctx := context.Background()
+
+	var latest time.Time
+	page, pages := "", 0
+	for {
+		result, err := provider.FetchPage(ctx, page)
+		require.NoError(t, err)
+		require.Len(t, result.Items, mockprovider.RatingsPerPage)
+		pages++
+
+		for _, item := range result.Items {
+			assert.NotEqual(t, entities.ActionOther, entities.ParseActionType(item.Action))
+			if !latest.IsZero() {
+				assert.True(t, item.EventTime.Before(latest), "ratings must come newest first")
+			}
+			latest = item.EventTime
+
+			// El item original se puede volver a procesar
+			parsed, err := provider.ParseRawItem(item.RawData)
+			require.NoError(t, err)
+			assert.Equal(t, item.Ticker, parsed.Ticker)
+		}
+
+		if !provider.HasMorePages(result) {
+			break
+		}
+		page = provider.GetNextPageToken(result.NextPage)
+	}
+	assert.Equal(t, mockprovider.RatingPages, pages)
+
+	// La misma página da los mismos ratings
+	first, err := provider.FetchPage(ctx, "")
+	require.NoError(t, err)
+	again, err := mockprovider.New(mockProviderClock).FetchPage(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, first.Items[0].TargetTo, again.Items[0].TargetTo)
+
+	_, err = provider.FetchPage(ctx, "mock-page-99")
+	assert.Error(t, err)
+}
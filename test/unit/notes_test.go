@@ -0,0 +1,164 @@
+package unit
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/tenancy"
+	"github.com/MayaCris/stock-info-app/internal/testutil/testdoubles"
+)
+
+// noteUserContext devuelve el contexto de un usuario autenticado con JWT en el tenant
+func noteUserContext(tenantID uuid.UUID, subject string) context.Context {
+	return tenancy.WithPrincipal(context.Background(), &tenancy.Principal{
+		TenantID: tenantID,
+		Subject:  subject,
+		Method:   tenancy.MethodJWT,
+		Role:     tenancy.RoleViewer,
+	})
+}
+
+func TestTenancyOwner(t *testing.T) {
+	tenantID := uuid.New()
+	owner, credential, err := tenancy.Owner(noteUserContext(tenantID, "alice"))
+	require.NoError(t, err)
+	assert.Equal(t, tenantID, owner)
+	assert.Equal(t, "jwt:alice", credential)
+
+	_, _, err = tenancy.Owner(context.Background())
+	assert.ErrorIs(t, err, tenancy.ErrNoTenant)
+	_, _, err = tenancy.Owner(tenancy.WithTenant(context.Background(), tenantID))
+	assert.ErrorIs(t, err, tenancy.ErrNoCredential)
+}
+
+func TestNoteService_PrivateNotesOnCompaniesAndRatings(t *testing.T) {
+	store := testdoubles.NewStore()
+	companyRepo := testdoubles.NewCompanyRepository(store)
+	brokerageRepo := testdoubles.NewBrokerageRepository(store)
+	ratingRepo := testdoubles.NewStockRatingRepository(store)
+	companies := relationshipTestCompanies(t, companyRepo, "AAPL", "MSFT")
+	service := services.NewNoteService(testdoubles.NewNoteRepository(store), companyRepo, ratingRepo, newEventBusTestLogger(t))
+
+	brokerage := entities.NewBrokerage("Goldman Sachs")
+	require.NoError(t, brokerageRepo.Create(context.Background(), brokerage))
+	rating := entities.NewStockRating(companies["AAPL"].ID, brokerage.ID, "upgraded by", time.Now().Add(-time.Hour))
+	require.NoError(t, ratingRepo.Create(context.Background(), rating))
+
+	tenantID := uuid.New()
+	alice := noteUserContext(tenantID, "alice")
+	bob := noteUserContext(tenantID, "bob")
+
+	onCompany, err := service.CreateNote(alice, &request.CreateNoteRequest{Company: "aapl", Body: "  Services margin keeps growing "})
+	require.NoError(t, err)
+	assert.Equal(t, companies["AAPL"].ID, onCompany.CompanyID)
+	assert.Nil(t, onCompany.RatingID)
+	assert.Equal(t, "Services margin keeps growing", onCompany.Body)
+
+	// La nota de un rating toma la company del rating
+	onRating, err := service.CreateNote(alice, &request.CreateNoteRequest{RatingID: &rating.ID, Body: "Upgrade after the earnings call"})
+	require.NoError(t, err)
+	assert.Equal(t, companies["AAPL"].ID, onRating.CompanyID)
+	require.NotNil(t, onRating.RatingID)
+	_, err = service.CreateNote(alice, &request.CreateNoteRequest{Company: "MSFT", RatingID: &rating.ID, Body: "Wrong company"})
+	requireErrorStatus(t, err, http.StatusBadRequest)
+	_, err = service.CreateNote(alice, &request.CreateNoteRequest{Body: "Nothing"})
+	requireErrorStatus(t, err, http.StatusBadRequest)
+	_, err = service.CreateNote(alice, &request.CreateNoteRequest{Company: "TSLA", Body: "Unknown"})
+	requireErrorStatus(t, err, http.StatusNotFound)
+	_, err = service.CreateNote(bob, &request.CreateNoteRequest{Company: companies["MSFT"].ID.String(), Body: "Azure"})
+	require.NoError(t, err)
+
+	pagination := &response.PaginationRequest{Page: 1, PerPage: 20}
+	notes, err := service.ListNotes(alice, &request.NoteFilterRequest{Company: "AAPL"}, pagination)
+	require.NoError(t, err)
+	require.Len(t, notes.Items, 2)
+	assert.Equal(t, onRating.ID, notes.Items[0].ID)
+	notes, err = service.ListNotes(alice, &request.NoteFilterRequest{RatingID: &rating.ID}, pagination)
+	require.NoError(t, err)
+	require.Len(t, notes.Items, 1)
+
+	// Cada usuario ve solo sus notas
+	notes, err = service.ListNotes(bob, &request.NoteFilterRequest{}, pagination)
+	require.NoError(t, err)
+	require.Len(t, notes.Items, 1)
+	assert.Equal(t, "Azure", notes.Items[0].Body)
+	_, err = service.GetNote(bob, onCompany.ID)
+	requireErrorStatus(t, err, http.StatusNotFound)
+	requireErrorStatus(t, service.DeleteNote(bob, onCompany.ID), http.StatusNotFound)
+	_, err = service.GetNote(noteUserContext(uuid.New(), "alice"), onCompany.ID)
+	requireErrorStatus(t, err, http.StatusNotFound)
+
+	// Sin la credencial de un usuario no hay notas
+	_, err = service.ListNotes(context.Background(), &request.NoteFilterRequest{}, pagination)
+	requireErrorStatus(t, err, http.StatusUnauthorized)
+	_, err = service.CreateNote(tenancy.WithTenant(context.Background(), tenantID), &request.CreateNoteRequest{Company: "AAPL", Body: "Internal"})
+	requireErrorStatus(t, err, http.StatusUnauthorized)
+
+	updated, err := service.UpdateNote(alice, onCompany.ID, &request.UpdateNoteRequest{Body: "Services margin at a record"})
+	require.NoError(t, err)
+	assert.Equal(t, "Services margin at a record", updated.Body)
+	_, err = service.UpdateNote(alice, onCompany.ID, &request.UpdateNoteRequest{Body: "   "})
+	requireErrorStatus(t, err, http.StatusBadRequest)
+
+	companyNotes, err := service.GetCompanyNotes(alice, companies["AAPL"].ID)
+	require.NoError(t, err)
+	assert.Len(t, companyNotes, 2)
+
+	require.NoError(t, service.DeleteNote(alice, onCompany.ID))
+	_, err = service.GetNote(alice, onCompany.ID)
+	requireErrorStatus(t, err, http.StatusNotFound)
+
+	// Eliminar el rating elimina sus notas
+	require.NoError(t, ratingRepo.HardDelete(context.Background(), rating.ID))
+	companyNotes, err = service.GetCompanyNotes(alice, companies["AAPL"].ID)
+	require.NoError(t, err)
+	assert.Empty(t, companyNotes)
+}
+
+func TestStockRatingService_IncludeNotes(t *testing.T) {
+	store := testdoubles.NewStore()
+	companyRepo := testdoubles.NewCompanyRepository(store)
+	brokerageRepo := testdoubles.NewBrokerageRepository(store)
+	ratingRepo := testdoubles.NewStockRatingRepository(store)
+	noteRepo := testdoubles.NewNoteRepository(store)
+	companies := relationshipTestCompanies(t, companyRepo, "AAPL")
+
+	brokerage := entities.NewBrokerage("Goldman Sachs")
+	require.NoError(t, brokerageRepo.Create(context.Background(), brokerage))
+	older := entities.NewStockRating(companies["AAPL"].ID, brokerage.ID, "initiated by", time.Now().Add(-48*time.Hour))
+	newer := entities.NewStockRating(companies["AAPL"].ID, brokerage.ID, "upgraded by", time.Now().Add(-time.Hour))
+	require.NoError(t, ratingRepo.Create(context.Background(), older))
+	require.NoError(t, ratingRepo.Create(context.Background(), newer))
+
+	tenantID := uuid.New()
+	alice := noteUserContext(tenantID, "alice")
+	require.NoError(t, noteRepo.Create(alice, entities.NewNote(companies["AAPL"].ID, &newer.ID, "Finally")))
+	require.NoError(t, noteRepo.Create(alice, entities.NewNote(companies["AAPL"].ID, nil, "Company note")))
+	require.NoError(t, noteRepo.Create(noteUserContext(tenantID, "bob"), entities.NewNote(companies["AAPL"].ID, &older.ID, "Bob's")))
+
+	service := services.NewStockRatingService(ratingRepo, companyRepo, brokerageRepo, noteRepo, newEventBusTestLogger(t))
+	pagination := &response.PaginationRequest{Page: 1, PerPage: 20}
+
+	ratings, err := service.GetRatingsByCompany(alice, companies["AAPL"].ID, request.RatingIncludes{Notes: true}, pagination)
+	require.NoError(t, err)
+	require.Len(t, ratings.Items, 2)
+	assert.Equal(t, newer.ID, ratings.Items[0].ID)
+	require.Len(t, ratings.Items[0].Notes, 1)
+	assert.Equal(t, "Finally", ratings.Items[0].Notes[0].Body)
+	assert.Empty(t, ratings.Items[1].Notes)
+
+	// Sin include no se buscan
+	ratings, err = service.GetRatingsByCompany(alice, companies["AAPL"].ID, request.RatingIncludes{}, pagination)
+	require.NoError(t, err)
+	assert.Empty(t, ratings.Items[0].Notes)
+}
@@ -0,0 +1,108 @@
+package unit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
+)
+
+// Fragmento de especificación con el formato que genera swag (Swagger 2.0)
+const testSwaggerSpec = `{
+  "swagger": "2.0",
+  "info": {"title": "Stock Info API", "version": "1.0"},
+  "basePath": "/api/v1",
+  "paths": {
+    "/companies": {
+      "post": {
+        "consumes": ["application/json"],
+        "parameters": [{
+          "in": "body", "name": "company", "required": true,
+          "schema": {"$ref": "#/definitions/request.CreateCompanyRequest"}
+        }],
+        "responses": {"201": {"description": "Created"}}
+      }
+    },
+    "/stocks": {
+      "get": {
+        "parameters": [{"in": "query", "name": "limit", "type": "integer", "minimum": 1, "maximum": 100}],
+        "responses": {"200": {"description": "OK"}}
+      }
+    }
+  },
+  "definitions": {
+    "request.CreateCompanyRequest": {
+      "type": "object",
+      "required": ["ticker", "name"],
+      "properties": {
+        "ticker": {"type": "string", "maxLength": 10},
+        "name": {"type": "string"}
+      }
+    }
+  }
+}`
+
+func newOpenAPITestRouter(t *testing.T) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	validator, err := middleware.NewOpenAPIValidator([]byte(testSwaggerSpec))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	router := gin.New()
+	router.Use(middleware.OpenAPIValidationMiddleware(validator))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.POST("/api/v1/companies", ok)
+	router.GET("/api/v1/stocks", ok)
+	router.GET("/api/v1/undocumented", ok)
+	return router
+}
+
+func TestOpenAPIValidation_RejectsInvalidBody(t *testing.T) {
+	router := newOpenAPITestRouter(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/companies", strings.NewReader(`{"ticker": "TOOLONGTICKER"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), string(response.ErrCodeValidationFailed))
+	assert.Contains(t, w.Body.String(), `"field":"ticker"`)
+	assert.Contains(t, w.Body.String(), `"tag":"maxLength"`)
+	assert.Contains(t, w.Body.String(), `"tag":"required"`)
+}
+
+func TestOpenAPIValidation_RejectsInvalidQueryParam(t *testing.T) {
+	router := newOpenAPITestRouter(t)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/stocks?limit=500", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `"field":"limit"`)
+}
+
+func TestOpenAPIValidation_AllowsValidAndUndocumentedRequests(t *testing.T) {
+	router := newOpenAPITestRouter(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/companies", strings.NewReader(`{"ticker": "AAPL", "name": "Apple"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/stocks?limit=10", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/undocumented?anything=1", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,69 @@
+package unit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
+)
+
+func TestNewPaginationLinks(t *testing.T) {
+	requestURL, err := url.Parse("/api/v1/stocks?action_type=upgrade&page=2&per_page=20&sort=event_time:desc")
+	require.NoError(t, err)
+
+	links := response.NewPaginationLinks(requestURL, response.NewPagination(2, 20, 95))
+	assert.Equal(t, "/api/v1/stocks?action_type=upgrade&page=1&per_page=20&sort=event_time%3Adesc", links.First)
+	assert.Equal(t, "/api/v1/stocks?action_type=upgrade&page=1&per_page=20&sort=event_time%3Adesc", links.Prev)
+	assert.Equal(t, "/api/v1/stocks?action_type=upgrade&page=3&per_page=20&sort=event_time%3Adesc", links.Next)
+	assert.Equal(t, "/api/v1/stocks?action_type=upgrade&page=5&per_page=20&sort=event_time%3Adesc", links.Last)
+
+	// Primera y última página; per_page se fija aunque la petición usara el valor por defecto
+	requestURL, err = url.Parse("/api/v1/companies")
+	require.NoError(t, err)
+	links = response.NewPaginationLinks(requestURL, response.NewPagination(1, 10, 0))
+	assert.Equal(t, &response.PaginationLinks{
+		First: "/api/v1/companies?page=1&per_page=10",
+		Last:  "/api/v1/companies?page=1&per_page=10",
+	}, links)
+	assert.Equal(t, `</api/v1/companies?page=1&per_page=10>; rel="first", </api/v1/companies?page=1&per_page=10>; rel="last"`, links.Header())
+
+	// Fuera de rango: prev apunta a la última página existente
+	links = response.NewPaginationLinks(requestURL, response.NewPagination(9, 10, 25))
+	assert.Equal(t, "/api/v1/companies?page=3&per_page=10", links.Prev)
+	assert.Empty(t, links.Next)
+}
+
+func TestRespondWithPage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.GET("/companies", func(c *gin.Context) {
+		c.Set("request_id", "req-1")
+		pagination := response.ParsePaginationFromQuery(c.Query("page"), c.Query("per_page"))
+		middleware.RespondWithPage(c, response.Success(response.NewPaginatedResponse([]string{"AAPL", "MSFT"}, pagination.Page, pagination.PerPage, 6)))
+	})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/companies?sector=Technology&page=2&per_page=2", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	assert.Equal(t, `</companies?page=1&per_page=2&sector=Technology>; rel="first", `+
+		`</companies?page=1&per_page=2&sector=Technology>; rel="prev", `+
+		`</companies?page=3&per_page=2&sector=Technology>; rel="next", `+
+		`</companies?page=3&per_page=2&sector=Technology>; rel="last"`, recorder.Header().Get("Link"))
+
+	var body response.APIResponse[response.PaginatedResponse[string]]
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+	assert.Equal(t, "req-1", body.RequestID)
+	assert.Equal(t, 3, body.Data.Meta.TotalPages)
+	require.NotNil(t, body.Data.Meta.Links)
+	assert.Equal(t, "/companies?page=3&per_page=2&sector=Technology", body.Data.Meta.Links.Next)
+}
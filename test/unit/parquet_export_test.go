@@ -0,0 +1,96 @@
+package unit
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	domainServices "github.com/MayaCris/stock-info-app/internal/domain/services"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/parquet"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/storage"
+)
+
+func TestParquetWriter_WritesMagicAndFooter(t *testing.T) {
+	writer := parquet.NewWriter([]parquet.Column{
+		{Name: "symbol", Type: parquet.String},
+		{Name: "date", Type: parquet.Date},
+		{Name: "close", Type: parquet.Double},
+		{Name: "volume", Type: parquet.Int64},
+		{Name: "brokerage", Type: parquet.String, Optional: true},
+	}, parquet.Gzip)
+
+	day := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, writer.Append("AAPL", day, 185.64, int64(82488700), "Goldman"))
+	require.NoError(t, writer.Append("AAPL", day.AddDate(0, 0, 1), 184.25, 58414500, nil))
+	assert.Equal(t, 2, writer.Rows())
+
+	var buf bytes.Buffer
+	written, err := writer.WriteTo(&buf)
+	require.NoError(t, err)
+	data := buf.Bytes()
+	assert.Equal(t, int64(len(data)), written)
+
+	// PAR1 al principio y al final; el footer ocupa los bytes anteriores a su longitud
+	require.Greater(t, len(data), 12)
+	assert.Equal(t, "PAR1", string(data[:4]))
+	assert.Equal(t, "PAR1", string(data[len(data)-4:]))
+	footerLength := int(binary.LittleEndian.Uint32(data[len(data)-8 : len(data)-4]))
+	require.Less(t, footerLength, len(data)-12)
+	footer := data[len(data)-8-footerLength : len(data)-8]
+	for _, name := range []string{"symbol", "date", "close", "volume", "brokerage", "stock-info-app"} {
+		assert.Contains(t, string(footer), name)
+	}
+}
+
+func TestParquetWriter_RejectsInvalidValues(t *testing.T) {
+	writer := parquet.NewWriter([]parquet.Column{
+		{Name: "symbol", Type: parquet.String},
+		{Name: "close", Type: parquet.Double, Optional: true},
+	}, parquet.Uncompressed)
+
+	assert.Error(t, writer.Append("AAPL"))
+	assert.Error(t, writer.Append(nil, 1.5))
+	assert.Error(t, writer.Append("AAPL", "1.5"))
+	assert.NoError(t, writer.Append("AAPL", nil))
+	assert.Equal(t, 1, writer.Rows())
+}
+
+func TestLocalStore_PutGetList(t *testing.T) {
+	store, err := storage.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, store.Put(ctx, "parquet/ratings/AAPL/2024.parquet", strings.NewReader("abc"), 3, ""))
+	require.NoError(t, store.Put(ctx, "parquet/historical_prices/AAPL/2024.parquet", strings.NewReader("defg"), 4, ""))
+	assert.Error(t, store.Put(ctx, "parquet/short.parquet", strings.NewReader("ab"), 3, ""))
+	assert.Error(t, store.Put(ctx, "../outside.parquet", strings.NewReader("a"), 1, ""))
+
+	reader, err := store.Get(ctx, "parquet/ratings/AAPL/2024.parquet")
+	require.NoError(t, err)
+	content, _ := io.ReadAll(reader)
+	reader.Close()
+	assert.Equal(t, "abc", string(content))
+
+	_, err = store.Get(ctx, "parquet/ratings/MSFT/2024.parquet")
+	assert.ErrorIs(t, err, domainServices.ErrObjectNotFound)
+
+	objects, err := store.List(ctx, "parquet/")
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+	assert.Equal(t, "parquet/historical_prices/AAPL/2024.parquet", objects[0].Key)
+	assert.Equal(t, int64(4), objects[0].Size)
+}
+
+func TestParquetExportKey(t *testing.T) {
+	assert.Equal(t, "parquet/historical_prices/AAPL/2024.parquet",
+		services.ParquetExportKey("parquet", services.ParquetDatasetHistoricalPrices, "AAPL", 2024))
+	assert.Equal(t, "ratings/MSFT/2023.parquet", services.ParquetExportKey("", services.ParquetDatasetRatings, "MSFT", 2023))
+}
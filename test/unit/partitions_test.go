@@ -0,0 +1,38 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/database/cockroachdb"
+)
+
+func TestMonthlyPartitions_CoversEveryMonthAcrossYears(t *testing.T) {
+	from := time.Date(2025, time.November, 17, 13, 0, 0, 0, time.UTC)
+	to := time.Date(2026, time.February, 3, 0, 0, 0, 0, time.UTC)
+
+	partitions := cockroachdb.MonthlyPartitions(from, to)
+
+	names := make([]string, len(partitions))
+	for i, partition := range partitions {
+		names[i] = partition.Name
+	}
+	assert.Equal(t, []string{"p2025_11", "p2025_12", "p2026_01", "p2026_02"}, names)
+	assert.Equal(t, time.Date(2025, time.November, 1, 0, 0, 0, 0, time.UTC), partitions[0].From)
+	assert.Equal(t, partitions[1].From, partitions[0].To)
+}
+
+func TestPartitionByRangeSQL_ListsAllPartitions(t *testing.T) {
+	table := cockroachdb.PartitionedTable{Name: "stock_ratings", Column: "event_time"}
+	partitions := cockroachdb.MonthlyPartitions(
+		time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC),
+	)
+
+	assert.Equal(t, "ALTER TABLE stock_ratings PARTITION BY RANGE (event_time) ("+
+		"PARTITION p2026_01 VALUES FROM ('2026-01-01T00:00:00Z') TO ('2026-02-01T00:00:00Z'), "+
+		"PARTITION p2026_02 VALUES FROM ('2026-02-01T00:00:00Z') TO ('2026-03-01T00:00:00Z'))",
+		cockroachdb.PartitionByRangeSQL(table, partitions))
+}
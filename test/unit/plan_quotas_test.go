@@ -0,0 +1,139 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	domainServices "github.com/MayaCris/stock-info-app/internal/domain/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/tenancy"
+	"github.com/MayaCris/stock-info-app/internal/domain/usage"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/config"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/database/cache"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
+)
+
+func newTestPlanQuotas() *domainServices.PlanQuotas {
+	return domainServices.NewPlanQuotas(cache.NewMemoryCacheServiceOnly(), map[string]domainServices.PlanLimits{
+		"free": {DailyRequests: 2, DailyRefreshes: 1},
+		"pro":  {DailyRequests: 0, DailyRefreshes: 100},
+	}, "free")
+}
+
+func TestPlanQuotas_Consume(t *testing.T) {
+	ctx := context.Background()
+	quotas := newTestPlanQuotas()
+	tenantID := uuid.New()
+
+	status, err := quotas.Consume(ctx, tenantID, "free", domainServices.QuotaRequests, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, status.Limit)
+	assert.Equal(t, 1, status.Remaining)
+	assert.False(t, status.Exceeded())
+
+	status, err = quotas.Consume(ctx, tenantID, "free", domainServices.QuotaRequests, 1)
+	require.NoError(t, err)
+	assert.True(t, status.Exhausted())
+	assert.False(t, status.Exceeded())
+
+	status, err = quotas.Consume(ctx, tenantID, "free", domainServices.QuotaRequests, 1)
+	require.NoError(t, err)
+	assert.True(t, status.Exceeded())
+	assert.Equal(t, 0, status.Remaining)
+
+	// Un plan desconocido usa el plan por defecto; pro no limita las peticiones
+	assert.Equal(t, "free", quotas.Plan("enterprise"))
+	status, err = quotas.Consume(ctx, uuid.New(), "pro", domainServices.QuotaRequests, 1)
+	require.NoError(t, err)
+	assert.False(t, status.Limited())
+
+	// Status no consume
+	status, err = quotas.Status(ctx, tenantID, "free", domainServices.QuotaRefreshes)
+	require.NoError(t, err)
+	assert.Equal(t, 0, status.Used)
+	assert.Equal(t, 1, status.Remaining)
+}
+
+func TestPlansConfig_Validate(t *testing.T) {
+	plans := config.PlansConfig{
+		Default: config.PlanFree,
+		Tiers:   map[string]config.PlanConfig{config.PlanFree: {DailyRequests: 10}, config.PlanPro: {}},
+	}
+	assert.NoError(t, plans.Validate())
+	assert.True(t, plans.IsPlan(config.PlanPro))
+
+	plans.Default = "enterprise"
+	assert.Error(t, plans.Validate())
+
+	plans.Default = config.PlanFree
+	plans.Tiers[config.PlanPro] = config.PlanConfig{DailyRefreshes: -1}
+	assert.Error(t, plans.Validate())
+}
+
+func TestUsageTracker_BlockRefreshes(t *testing.T) {
+	assert.True(t, usage.RefreshAllowed(context.Background()))
+
+	ctx, tracker := usage.WithTracker(context.Background())
+	assert.True(t, usage.RefreshAllowed(ctx))
+	tracker.BlockRefreshes()
+	assert.False(t, usage.RefreshAllowed(ctx))
+}
+
+func TestPlanQuotaMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	quotas := newTestPlanQuotas()
+	principal := &tenancy.Principal{TenantID: uuid.New(), Method: tenancy.MethodAPIKey, APIKeyID: uuid.New(), Plan: "free"}
+
+	refreshAllowed := make([]bool, 0, 2)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(tenancy.WithPrincipal(c.Request.Context(), principal))
+		c.Next()
+	})
+	router.Use(middleware.PlanQuotaMiddleware(quotas))
+	router.GET("/quote", func(c *gin.Context) {
+		allowed := usage.RefreshAllowed(c.Request.Context())
+		refreshAllowed = append(refreshAllowed, allowed)
+		if allowed {
+			usage.RecordProviderCall(c.Request.Context())
+		}
+		c.Status(http.StatusOK)
+	})
+
+	// La primera petición obtiene el símbolo del proveedor y agota la cuota de refrescos
+	first := httptest.NewRecorder()
+	router.ServeHTTP(first, httptest.NewRequest(http.MethodGet, "/quote", nil))
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "free", first.Header().Get("X-Quota-Plan"))
+	assert.Equal(t, "2", first.Header().Get("X-Quota-Limit"))
+	assert.Equal(t, "1", first.Header().Get("X-Quota-Remaining"))
+	assert.Equal(t, "1", first.Header().Get("X-Refresh-Quota-Remaining"))
+
+	// La segunda solo puede servir datos guardados
+	second := httptest.NewRecorder()
+	router.ServeHTTP(second, httptest.NewRequest(http.MethodGet, "/quote", nil))
+	assert.Equal(t, http.StatusOK, second.Code)
+	assert.Equal(t, "0", second.Header().Get("X-Refresh-Quota-Remaining"))
+	assert.Equal(t, []bool{true, false}, refreshAllowed)
+
+	// La tercera supera la cuota diaria de peticiones
+	third := httptest.NewRecorder()
+	router.ServeHTTP(third, httptest.NewRequest(http.MethodGet, "/quote", nil))
+	assert.Equal(t, http.StatusTooManyRequests, third.Code)
+	assert.NotEmpty(t, third.Header().Get("Retry-After"))
+	assert.Len(t, refreshAllowed, 2)
+
+	var problem response.ProblemDetails
+	require.NoError(t, json.Unmarshal(third.Body.Bytes(), &problem))
+	assert.Equal(t, string(response.ErrCodeQuotaExceeded), problem.Code)
+	assert.Equal(t, "free", problem.Details["plan"])
+	assert.Equal(t, domainServices.QuotaRequests, problem.Details["quota"])
+}
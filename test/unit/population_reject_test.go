@@ -0,0 +1,63 @@
+package unit
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MayaCris/stock-info-app/internal/application/usecases/population"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+func TestPopulationReject_NewRejectDefaults(t *testing.T) {
+	reject := entities.NewPopulationReject(" stock_api ", entities.RejectStageValidation, population.RejectReasonMissingFields, `{"ticker":"AAPL"}`)
+
+	assert.Equal(t, "stock_api", reject.Source)
+	assert.Equal(t, entities.PopulationRejectStatusPending, reject.Status)
+	assert.Equal(t, 1, reject.Occurrences)
+	assert.True(t, reject.IsPending())
+	assert.Equal(t, entities.RejectFingerprint("stock_api", entities.RejectStageValidation, `{"ticker":"AAPL"}`), reject.Fingerprint)
+}
+
+func TestPopulationReject_FingerprintDependsOnStage(t *testing.T) {
+	payload := `{"ticker":"AAPL"}`
+
+	assert.NotEqual(t,
+		entities.RejectFingerprint("stock_api", entities.RejectStageCompanyResolution, payload),
+		entities.RejectFingerprint("stock_api", entities.RejectStageBrokerageResolution, payload))
+}
+
+func TestPopulationReject_MergeRejectsByFingerprint(t *testing.T) {
+	first := entities.NewPopulationReject("stock_api", entities.RejectStageValidation, population.RejectReasonMissingFields, `{"ticker":"AAPL"}`)
+	other := entities.NewPopulationReject("stock_api", entities.RejectStageValidation, population.RejectReasonMissingFields, `{"ticker":"MSFT"}`)
+	repeated := entities.NewPopulationReject("stock_api", entities.RejectStageValidation, "invalid_event_time", `{"ticker":"AAPL"}`)
+	repeated.LastSeenAt = first.LastSeenAt.Add(time.Second)
+
+	merged := entities.MergeRejectsByFingerprint([]*entities.PopulationReject{first, other, repeated})
+
+	assert.Equal(t, []*entities.PopulationReject{first, other}, merged)
+	assert.Equal(t, 2, first.Occurrences)
+	assert.Equal(t, "invalid_event_time", first.Reason)
+	assert.Equal(t, repeated.LastSeenAt, first.LastSeenAt)
+	assert.Equal(t, 1, other.Occurrences)
+}
+
+func TestStockDataItem_DecodesRawAPIPayload(t *testing.T) {
+	raw := `{"ticker":"BSBR","company":"Banco Santander (Brasil)","brokerage":"The Goldman Sachs Group","action":"upgraded by","rating_from":"Sell","rating_to":"Neutral","target_from":"$4.20","target_to":"$4.70","time":"2025-01-13T00:30:05.813548892Z"}`
+
+	var item population.StockDataItem
+	assert.NoError(t, json.Unmarshal([]byte(raw), &item))
+	assert.NoError(t, item.Validate())
+	assert.Equal(t, "BSBR", item.Ticker)
+	assert.Equal(t, 2025, item.EventTime.Year())
+}
+
+func TestStockDataItem_ValidateMissingFields(t *testing.T) {
+	item := population.StockDataItem{Ticker: "AAPL", EventTime: time.Now()}
+	assert.Error(t, item.Validate())
+
+	item = population.StockDataItem{Ticker: "AAPL", Company: "Apple", Brokerage: "Goldman", Action: "upgraded by"}
+	assert.Error(t, item.Validate())
+}
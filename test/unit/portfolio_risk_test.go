@@ -0,0 +1,52 @@
+package unit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+)
+
+func TestMaxDrawdown(t *testing.T) {
+	// 1 -> 1.1 -> 0.88 -> 0.968: la caída máxima es del pico 1.1 a 0.88
+	drawdown := services.MaxDrawdown([]float64{0.10, -0.20, 0.10})
+	require.NotNil(t, drawdown)
+	assert.InDelta(t, 0.20, *drawdown, 1e-9)
+
+	assert.Equal(t, 0.0, *services.MaxDrawdown([]float64{0.01, 0.02}))
+	assert.Nil(t, services.MaxDrawdown(nil))
+}
+
+func TestBetaAndVolatility(t *testing.T) {
+	market := []float64{0.01, -0.02, 0.015, 0.005}
+	asset := []float64{0.02, -0.04, 0.03, 0.01}
+
+	beta := services.Beta(asset, market)
+	require.NotNil(t, beta)
+	assert.InDelta(t, 2.0, *beta, 1e-9)
+	assert.Nil(t, services.Beta(asset, []float64{0.01, 0.01, 0.01, 0.01}))
+
+	volatility := services.AnnualizedVolatility([]float64{0.01, -0.01})
+	require.NotNil(t, volatility)
+	assert.InDelta(t, math.Sqrt(2)*0.01*math.Sqrt(252), *volatility, 1e-9)
+	assert.Nil(t, services.AnnualizedVolatility([]float64{0.01}))
+
+	// Sin volatilidad no hay Sharpe
+	assert.Nil(t, services.SharpeRatio([]float64{0.01, 0.01, 0.01}, 0))
+}
+
+func TestWeightedReturns(t *testing.T) {
+	holdings := []response.PortfolioHolding{{Ticker: "AAA", Weight: 0.75}, {Ticker: "BBB", Weight: 0.25}}
+	returns := []map[string]float64{
+		{"2026-03-02": 0.04, "2026-03-03": 0.01},
+		{"2026-03-02": -0.04},
+	}
+
+	combined := services.WeightedReturns(returns, holdings)
+	require.Len(t, combined, 1)
+	assert.InDelta(t, 0.02, combined["2026-03-02"], 1e-9)
+}
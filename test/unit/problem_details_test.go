@@ -0,0 +1,65 @@
+package unit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
+)
+
+func newErrorRouter(errorResp *response.ErrorResponse) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.GET("/fail", func(c *gin.Context) {
+		c.Set("request_id", "req-123")
+		middleware.RespondWithError(c, errorResp)
+	})
+	return router
+}
+
+func TestRespondWithError_ProblemDetails(t *testing.T) {
+	response.ConfigureErrorFormat(response.ErrorFormatProblem, "https://errors.example.com/")
+	defer response.ConfigureErrorFormat(response.ErrorFormatProblem, response.DefaultProblemTypeBaseURI)
+
+	errorResp := response.ValidationFailedWithFields([]response.ValidationError{
+		{Field: "ticker", Message: "This field is required"},
+	})
+
+	recorder := httptest.NewRecorder()
+	newErrorRouter(errorResp).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/fail", nil))
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Equal(t, response.ProblemContentType, recorder.Header().Get("Content-Type"))
+
+	var problem response.ProblemDetails
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &problem))
+	assert.Equal(t, "https://errors.example.com/validation-failed", problem.Type)
+	assert.Equal(t, "req-123", problem.Instance)
+	assert.Equal(t, http.StatusBadRequest, problem.Status)
+	assert.Len(t, problem.Errors, 1)
+	assert.Nil(t, problem.Details)
+}
+
+func TestRespondWithError_LegacyEnvelope(t *testing.T) {
+	response.ConfigureErrorFormat(response.ErrorFormatLegacy, "")
+	defer response.ConfigureErrorFormat(response.ErrorFormatProblem, response.DefaultProblemTypeBaseURI)
+
+	recorder := httptest.NewRecorder()
+	newErrorRouter(response.NotFound("Company")).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/fail", nil))
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	assert.Contains(t, recorder.Header().Get("Content-Type"), "application/json")
+
+	var apiResponse response.APIResponse[interface{}]
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &apiResponse))
+	assert.False(t, apiResponse.Success)
+	assert.Equal(t, "req-123", apiResponse.RequestID)
+	assert.Equal(t, string(response.ErrCodeNotFound), apiResponse.Error.Code)
+}
@@ -0,0 +1,37 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+func TestNewProviderPayload_CompressesAndRestoresRaw(t *testing.T) {
+	raw := []byte(`{"c":189.5,"d":1.2,"dp":0.64,"h":190.1,"l":187.3,"o":188,"pc":188.3,"t":1718000000}`)
+	fetchedAt := time.Date(2024, 6, 10, 14, 30, 0, 0, time.UTC)
+
+	payload, err := entities.NewProviderPayload(entities.PayloadProviderFinnhub, "/quote", " aapl ", "symbol=AAPL",
+		"req-123", raw, fetchedAt, 24*time.Hour)
+	require.NoError(t, err)
+
+	assert.Equal(t, "AAPL", payload.Symbol)
+	assert.Equal(t, len(raw), payload.Size)
+	assert.NotEqual(t, raw, payload.Payload)
+	assert.Equal(t, fetchedAt, payload.FetchedAt)
+	assert.Equal(t, fetchedAt.Add(24*time.Hour), payload.ExpiresAt)
+
+	restored, err := payload.Raw()
+	require.NoError(t, err)
+	assert.Equal(t, raw, restored)
+}
+
+func TestProviderPayload_RawRejectsCorruptedPayload(t *testing.T) {
+	payload := &entities.ProviderPayload{Payload: []byte("not gzip")}
+
+	_, err := payload.Raw()
+	assert.Error(t, err)
+}
@@ -0,0 +1,154 @@
+package unit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/tenancy"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/config"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/database/cache"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
+)
+
+func TestRateLimitConfig_LimitForPrecedence(t *testing.T) {
+	cfg := config.RateLimitConfig{
+		Limit:        100,
+		GroupLimits:  map[string]int{config.RateLimitGroupWrite: 10},
+		APIKeyLimits: map[string]int{"partner": 1000},
+	}
+
+	assert.Equal(t, 100, cfg.LimitFor(config.RateLimitGroupRead, ""))
+	assert.Equal(t, 10, cfg.LimitFor(config.RateLimitGroupWrite, ""))
+	assert.Equal(t, 1000, cfg.LimitFor(config.RateLimitGroupWrite, "partner"))
+	assert.Equal(t, 10, cfg.LimitFor(config.RateLimitGroupWrite, "unknown"))
+}
+
+func TestMemoryCache_TakeTokenExhaustsBucket(t *testing.T) {
+	cacheService := cache.NewMemoryCacheServiceOnly()
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		result, err := cacheService.TakeToken(ctx, "ratelimit:test", 3, time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 2-i, result.Remaining)
+	}
+
+	result, err := cacheService.TakeToken(ctx, "ratelimit:test", 3, time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Greater(t, result.RetryAfter, time.Duration(0))
+}
+
+func TestTokenBucketRateLimitMiddleware_Returns429WithHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := config.RateLimitConfig{
+		Enabled:      true,
+		RequestsPer:  time.Minute,
+		Limit:        1,
+		KeyFunc:      "ip",
+		APIKeyHeader: "X-API-Key",
+	}
+
+	router := gin.New()
+	router.Use(middleware.TokenBucketRateLimitMiddleware(cache.NewMemoryCacheServiceOnly(), cfg, config.RateLimitGroupDefault))
+	router.GET("/ping", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	first := httptest.NewRecorder()
+	router.ServeHTTP(first, httptest.NewRequest(http.MethodGet, "/ping", nil))
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "1", first.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "0", first.Header().Get("RateLimit-Remaining"))
+
+	second := httptest.NewRecorder()
+	router.ServeHTTP(second, httptest.NewRequest(http.MethodGet, "/ping", nil))
+	assert.Equal(t, http.StatusTooManyRequests, second.Code)
+	assert.NotEmpty(t, second.Header().Get("Retry-After"))
+	assert.Equal(t, "1;w=60", second.Header().Get("RateLimit-Policy"))
+}
+
+func TestTokenBucketRateLimitMiddleware_AppliesUpdatedSettings(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	settings := middleware.NewRateLimitSettings(config.RateLimitConfig{
+		Enabled:     true,
+		RequestsPer: time.Minute,
+		Limit:       1,
+		KeyFunc:     "ip",
+	})
+
+	router := gin.New()
+	router.Use(middleware.TokenBucketRateLimitMiddlewareWithSettings(cache.NewMemoryCacheServiceOnly(), settings, config.RateLimitGroupDefault))
+	router.GET("/ping", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	first := httptest.NewRecorder()
+	router.ServeHTTP(first, httptest.NewRequest(http.MethodGet, "/ping", nil))
+	assert.Equal(t, "1", first.Header().Get("RateLimit-Limit"))
+
+	// Recarga en caliente: las siguientes requests usan el nuevo límite sin recrear el middleware
+	updated := settings.Get()
+	updated.Limit = 5
+	settings.Update(updated)
+
+	second := httptest.NewRecorder()
+	router.ServeHTTP(second, httptest.NewRequest(http.MethodGet, "/ping", nil))
+	assert.Equal(t, "5", second.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "5;w=60", second.Header().Get("RateLimit-Policy"))
+}
+
+func TestTokenBucketRateLimitMiddleware_UnverifiedKeysShareTheIPBucket(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := config.RateLimitConfig{
+		Enabled:      true,
+		RequestsPer:  time.Minute,
+		Limit:        2,
+		KeyFunc:      "api_key",
+		APIKeyHeader: "X-API-Key",
+		APIKeyLimits: map[string]int{"partner-secret": 100},
+	}
+
+	var principal *tenancy.Principal
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if principal != nil {
+			c.Request = c.Request.WithContext(tenancy.WithPrincipal(c.Request.Context(), principal))
+		}
+		c.Next()
+	})
+	router.Use(middleware.TokenBucketRateLimitMiddleware(cache.NewMemoryCacheServiceOnly(), cfg, config.RateLimitGroupDefault))
+	router.GET("/ping", func(c *gin.Context) { c.Status(http.StatusOK) })
+	do := func(apiKey string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		req.Header.Set("X-API-Key", apiKey)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Una clave inventada en cada petición no da un bucket nuevo: todas comparten el de la IP
+	assert.Equal(t, http.StatusOK, do(uuid.NewString()).Code)
+	assert.Equal(t, http.StatusOK, do(uuid.NewString()).Code)
+	assert.Equal(t, http.StatusTooManyRequests, do(uuid.NewString()).Code)
+
+	// La clave registrada tiene su propio límite
+	registered := do("partner-secret")
+	assert.Equal(t, http.StatusOK, registered.Code)
+	assert.Equal(t, "100", registered.Header().Get("RateLimit-Limit"))
+
+	// Con un principal autenticado por otra credencial, la clave del header no aporta su límite: el bucket es la
+	// credencial del principal
+	principal = &tenancy.Principal{TenantID: uuid.New(), Subject: "alice", Method: tenancy.MethodJWT, Role: tenancy.RoleViewer}
+	authenticated := do("partner-secret")
+	assert.Equal(t, http.StatusOK, authenticated.Code)
+	assert.Equal(t, "2", authenticated.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", authenticated.Header().Get("RateLimit-Remaining"))
+}
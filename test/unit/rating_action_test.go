@@ -0,0 +1,44 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+func TestParseActionType(t *testing.T) {
+	cases := map[string]entities.ActionType{
+		"upgraded by":         entities.ActionUpgrade,
+		"Downgraded  by":      entities.ActionDowngrade,
+		"reiterated by":       entities.ActionReiterate,
+		"initiated by":        entities.ActionInitiate,
+		"resumed by":          entities.ActionInitiate,
+		"target raised by":    entities.ActionTargetRaised,
+		"target lowered by":   entities.ActionTargetLowered,
+		"target set by":       entities.ActionTargetSet,
+		"coverage dropped by": entities.ActionOther,
+		"":                    "",
+	}
+	for action, expected := range cases {
+		assert.Equal(t, expected, entities.ParseActionType(action), action)
+	}
+	assert.True(t, entities.ActionTargetSet.Valid())
+	assert.False(t, entities.ActionType("upgraded by").Valid())
+}
+
+func TestStockRating_ActionType(t *testing.T) {
+	rating := entities.NewStockRating(uuid.New(), uuid.New(), " Upgraded by ", time.Now())
+
+	// Sin guardar, la clasificación se calcula a partir de la acción
+	assert.Equal(t, entities.ActionType(""), rating.ActionType)
+	assert.True(t, rating.IsUpgrade())
+
+	rating.Normalize()
+	assert.Equal(t, "upgraded by", rating.Action)
+	assert.Equal(t, entities.ActionUpgrade, rating.ActionType)
+	assert.False(t, rating.IsDowngrade())
+}
@@ -0,0 +1,85 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/timezone"
+	"github.com/MayaCris/stock-info-app/internal/testutil/testdoubles"
+)
+
+func TestRatingsAsOf_LatestPerBrokerageUpToDate(t *testing.T) {
+	store := testdoubles.NewStore()
+	companyRepo := testdoubles.NewCompanyRepository(store)
+	brokerageRepo := testdoubles.NewBrokerageRepository(store)
+	ratingRepo := testdoubles.NewStockRatingRepository(store)
+	ctx := context.Background()
+
+	company := entities.NewCompany("AAPL", "Apple Inc.")
+	other := entities.NewCompany("MSFT", "Microsoft")
+	require.NoError(t, companyRepo.Create(ctx, company))
+	require.NoError(t, companyRepo.Create(ctx, other))
+	goldman := entities.NewBrokerage("Goldman Sachs")
+	morgan := entities.NewBrokerage("Morgan Stanley")
+	barclays := entities.NewBrokerage("Barclays")
+	for _, brokerage := range []*entities.Brokerage{goldman, morgan, barclays} {
+		require.NoError(t, brokerageRepo.Create(ctx, brokerage))
+	}
+
+	newRating := func(companyID, brokerageID uuid.UUID, action, ratingTo string, eventTime time.Time) *entities.StockRating {
+		rating := entities.NewStockRating(companyID, brokerageID, action, eventTime)
+		rating.RatingTo = ratingTo
+		require.NoError(t, ratingRepo.Create(ctx, rating))
+		return rating
+	}
+	newRating(company.ID, goldman.ID, "initiated by", "Neutral", time.Date(2024, 1, 10, 14, 0, 0, 0, time.UTC))
+	goldmanUpgrade := newRating(company.ID, goldman.ID, "upgraded by", "Buy", time.Date(2024, 6, 30, 20, 0, 0, 0, time.UTC))
+	newRating(company.ID, goldman.ID, "downgraded by", "Sell", time.Date(2024, 7, 2, 14, 0, 0, 0, time.UTC))
+	morganRating := newRating(company.ID, morgan.ID, "reiterated by", "Overweight", time.Date(2024, 3, 5, 14, 0, 0, 0, time.UTC))
+	deleted := newRating(company.ID, barclays.ID, "initiated by", "Underweight", time.Date(2024, 5, 1, 14, 0, 0, 0, time.UTC))
+	require.NoError(t, ratingRepo.Delete(ctx, deleted.ID))
+	newRating(other.ID, goldman.ID, "upgraded by", "Buy", time.Date(2024, 6, 1, 14, 0, 0, 0, time.UTC))
+
+	service := services.NewStockRatingService(ratingRepo, companyRepo, brokerageRepo, nil, newEventBusTestLogger(t))
+
+	// El día se incluye completo: el upgrade de las 20:00 UTC del 30 de junio entra, el downgrade de julio no
+	snapshot, err := service.GetRatingsAsOf(ctx, company.ID, "2024-06-30", request.RatingIncludes{Brokerage: true})
+	require.NoError(t, err)
+	assert.Equal(t, "AAPL", snapshot.Ticker)
+	assert.Equal(t, "2024-06-30", snapshot.Date)
+	require.Len(t, snapshot.Ratings, 2)
+	assert.Equal(t, goldmanUpgrade.ID, snapshot.Ratings[0].ID)
+	assert.Equal(t, "Goldman Sachs", snapshot.Ratings[0].Brokerage)
+	require.NotNil(t, snapshot.Ratings[0].BrokerageSummary)
+	assert.Equal(t, morganRating.ID, snapshot.Ratings[1].ID)
+
+	// En Tokio el 30 de junio termina a las 15:00 UTC: el upgrade todavía no había llegado
+	tokyo, err := timezone.Load("Asia/Tokyo")
+	require.NoError(t, err)
+	snapshot, err = service.GetRatingsAsOf(timezone.WithLocation(ctx, tokyo), company.ID, "2024-06-30", request.RatingIncludes{})
+	require.NoError(t, err)
+	require.Len(t, snapshot.Ratings, 2)
+	assert.Equal(t, morganRating.ID, snapshot.Ratings[0].ID)
+	assert.Equal(t, "Neutral", snapshot.Ratings[1].RatingTo)
+
+	snapshot, err = service.GetRatingsAsOf(ctx, company.ID, "2023-12-31", request.RatingIncludes{})
+	require.NoError(t, err)
+	assert.Empty(t, snapshot.Ratings)
+
+	_, err = service.GetRatingsAsOf(ctx, company.ID, "30/06/2024", request.RatingIncludes{})
+	assert.Error(t, err)
+	_, err = service.GetRatingsAsOf(ctx, uuid.New(), "2024-06-30", request.RatingIncludes{})
+	var errorResp *response.ErrorResponse
+	require.True(t, errors.As(err, &errorResp))
+	assert.Equal(t, response.ErrCodeNotFound, errorResp.Code)
+}
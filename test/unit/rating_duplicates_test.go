@@ -0,0 +1,49 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+func newDuplicateTestRating(companyID, brokerageID uuid.UUID, action, targetTo string, eventTime time.Time) *entities.StockRating {
+	rating := entities.NewStockRating(companyID, brokerageID, action, eventTime)
+	rating.RatingFrom = "Hold"
+	rating.RatingTo = "Buy"
+	rating.TargetTo = targetTo
+	return rating
+}
+
+func TestGroupDuplicateRatings(t *testing.T) {
+	companyID, brokerageID := uuid.New(), uuid.New()
+	base := time.Date(2026, 3, 2, 14, 0, 0, 0, time.UTC)
+
+	original := newDuplicateTestRating(companyID, brokerageID, "upgraded by", "$150.00", base)
+	reEmitted := newDuplicateTestRating(companyID, brokerageID, "upgraded by", "$150.00", base.Add(40*time.Second))
+	chained := newDuplicateTestRating(companyID, brokerageID, "upgraded by", "$150.00", base.Add(4*time.Minute))
+	otherTarget := newDuplicateTestRating(companyID, brokerageID, "upgraded by", "$160.00", base.Add(time.Minute))
+	nextDay := newDuplicateTestRating(companyID, brokerageID, "upgraded by", "$150.00", base.Add(24*time.Hour))
+	exact := newDuplicateTestRating(companyID, brokerageID, "target raised by", "$170.00", base.Add(24*time.Hour))
+
+	ratings := []*entities.StockRating{chained, otherTarget, nextDay, exact, reEmitted, original}
+
+	// Sin ventana solo se agrupan los event_time idénticos
+	groups := entities.GroupDuplicateRatings(ratings, 0)
+	require.Len(t, groups, 1)
+	assert.ElementsMatch(t, []*entities.StockRating{nextDay, exact}, groups[0])
+
+	// Con ventana se encadenan las re-emisiones del mismo cambio; un target distinto no es duplicado
+	groups = entities.GroupDuplicateRatings(ratings, 5*time.Minute)
+	require.Len(t, groups, 2)
+	assert.Equal(t, []*entities.StockRating{original, reEmitted, chained}, groups[0])
+	assert.ElementsMatch(t, []*entities.StockRating{nextDay, exact}, groups[1])
+
+	groups = entities.GroupDuplicateRatings(ratings, 30*time.Second)
+	require.Len(t, groups, 1)
+	assert.ElementsMatch(t, []*entities.StockRating{nextDay, exact}, groups[0])
+}
@@ -0,0 +1,170 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/events"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/eventbus"
+	"github.com/MayaCris/stock-info-app/internal/testutil/testdoubles"
+)
+
+func TestRatingPoll_ReturnsRatingsAfterSinceID(t *testing.T) {
+	store := testdoubles.NewStore()
+	companyRepo := testdoubles.NewCompanyRepository(store)
+	brokerageRepo := testdoubles.NewBrokerageRepository(store)
+	ratingRepo := testdoubles.NewStockRatingRepository(store)
+	ctx := context.Background()
+
+	company := entities.NewCompany("AAPL", "Apple Inc.")
+	require.NoError(t, companyRepo.Create(ctx, company))
+	brokerage := entities.NewBrokerage("Goldman Sachs")
+	require.NoError(t, brokerageRepo.Create(ctx, brokerage))
+
+	eventTime := time.Date(2025, 3, 10, 14, 0, 0, 0, time.UTC)
+	ratings := make([]*entities.StockRating, 3)
+	for i := range ratings {
+		ratings[i] = entities.NewStockRating(company.ID, brokerage.ID, "upgraded by", eventTime.Add(time.Duration(i)*time.Hour))
+		require.NoError(t, ratingRepo.Create(ctx, ratings[i]))
+	}
+
+	service := services.NewRatingPollService(ratingRepo, companyRepo, brokerageRepo, nil, newEventBusTestLogger(t))
+
+	// Con ratings ya creados tras since_id la respuesta no espera
+	start := time.Now()
+	result, err := service.Poll(ctx, &request.RatingPollRequest{SinceID: &ratings[0].ID, Wait: 5, Limit: 1}, request.RatingIncludes{Company: true})
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+	require.Len(t, result.Ratings, 1)
+	assert.Equal(t, ratings[1].ID, result.Ratings[0].ID)
+	assert.Equal(t, "AAPL", result.Ratings[0].Ticker)
+	require.NotNil(t, result.Ratings[0].CompanySummary)
+	assert.Equal(t, &ratings[1].ID, result.NextSinceID)
+	assert.False(t, result.TimedOut)
+
+	// La respuesta repite los ratings de la ventana anterior a since_id (ya entregados) antes de los nuevos
+	result, err = service.Poll(ctx, &request.RatingPollRequest{SinceID: result.NextSinceID}, request.RatingIncludes{})
+	require.NoError(t, err)
+	require.Len(t, result.Ratings, 2)
+	assert.Equal(t, ratings[0].ID, result.Ratings[0].ID)
+	assert.Equal(t, ratings[2].ID, result.Ratings[1].ID)
+	assert.Equal(t, &ratings[2].ID, result.NextSinceID)
+
+	unknown := uuid.New()
+	_, err = service.Poll(ctx, &request.RatingPollRequest{SinceID: &unknown}, request.RatingIncludes{})
+	assert.Error(t, err)
+}
+
+func TestRatingPoll_WaitsForRatingCreatedEvent(t *testing.T) {
+	store := testdoubles.NewStore()
+	companyRepo := testdoubles.NewCompanyRepository(store)
+	brokerageRepo := testdoubles.NewBrokerageRepository(store)
+	ratingRepo := testdoubles.NewStockRatingRepository(store)
+	ctx := context.Background()
+
+	company := entities.NewCompany("MSFT", "Microsoft")
+	require.NoError(t, companyRepo.Create(ctx, company))
+	brokerage := entities.NewBrokerage("Morgan Stanley")
+	require.NoError(t, brokerageRepo.Create(ctx, brokerage))
+	existing := entities.NewStockRating(company.ID, brokerage.ID, "reiterated by", time.Date(2025, 3, 10, 14, 0, 0, 0, time.UTC))
+	require.NoError(t, ratingRepo.Create(ctx, existing))
+
+	bus := eventbus.NewMemoryBus(16, newEventBusTestLogger(t))
+	defer bus.Close()
+	service := services.NewRatingPollService(ratingRepo, companyRepo, brokerageRepo, bus, newEventBusTestLogger(t))
+
+	// El rating llega durante la espera y su evento la despierta antes de los 20 segundos
+	created := entities.NewStockRating(company.ID, brokerage.ID, "upgraded by", time.Date(2025, 3, 11, 14, 0, 0, 0, time.UTC))
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		if err := ratingRepo.Create(ctx, created); err != nil {
+			t.Error(err)
+			return
+		}
+		event, err := events.NewRatingCreated(created)
+		if err == nil {
+			err = bus.Publish(ctx, event)
+		}
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	start := time.Now()
+	result, err := service.Poll(ctx, &request.RatingPollRequest{SinceID: &existing.ID, Wait: 20}, request.RatingIncludes{})
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+	require.Len(t, result.Ratings, 1)
+	assert.Equal(t, created.ID, result.Ratings[0].ID)
+
+	// Sin ratings nuevos la espera termina sin ellos (solo la ventana de solapamiento) y el cursor no cambia
+	result, err = service.Poll(ctx, &request.RatingPollRequest{SinceID: &created.ID, Wait: 1}, request.RatingIncludes{})
+	require.NoError(t, err)
+	assert.True(t, result.TimedOut)
+	require.Len(t, result.Ratings, 1)
+	assert.Equal(t, existing.ID, result.Ratings[0].ID)
+	assert.Equal(t, &created.ID, result.NextSinceID)
+
+	// Una petición cancelada deja de esperar
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = service.Poll(cancelled, &request.RatingPollRequest{SinceID: &created.ID, Wait: 20}, request.RatingIncludes{})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRatingPoll_RescansOverlapForLateRatingsAndAcceptsDeletedSinceID(t *testing.T) {
+	store := testdoubles.NewStore()
+	companyRepo := testdoubles.NewCompanyRepository(store)
+	brokerageRepo := testdoubles.NewBrokerageRepository(store)
+	ratingRepo := testdoubles.NewStockRatingRepository(store)
+	ctx := context.Background()
+
+	company := entities.NewCompany("NVDA", "NVIDIA")
+	require.NoError(t, companyRepo.Create(ctx, company))
+	brokerage := entities.NewBrokerage("Jefferies")
+	require.NoError(t, brokerageRepo.Create(ctx, brokerage))
+
+	now := time.Now().UTC()
+	eventTime := time.Date(2025, 3, 10, 14, 0, 0, 0, time.UTC)
+	newRating := func(hours int, createdAt time.Time) *entities.StockRating {
+		rating := entities.NewStockRating(company.ID, brokerage.ID, "upgraded by", eventTime.Add(time.Duration(hours)*time.Hour))
+		rating.CreatedAt = createdAt
+		require.NoError(t, ratingRepo.Create(ctx, rating))
+		return rating
+	}
+	old := newRating(0, now.Add(-10*time.Minute))
+	since := newRating(1, now)
+
+	service := services.NewRatingPollService(ratingRepo, companyRepo, brokerageRepo, nil, newEventBusTestLogger(t))
+
+	// Un lote que confirma tarde queda con created_at anterior a since_id: la ventana de solapamiento lo recupera,
+	// pero no los ratings anteriores a ella
+	late := newRating(2, now.Add(-10*time.Second))
+	fresh := newRating(3, now.Add(time.Second))
+
+	result, err := service.Poll(ctx, &request.RatingPollRequest{SinceID: &since.ID, Wait: 1}, request.RatingIncludes{})
+	require.NoError(t, err)
+	assert.False(t, result.TimedOut)
+	ids := make([]uuid.UUID, len(result.Ratings))
+	for i, rating := range result.Ratings {
+		ids[i] = rating.ID
+	}
+	assert.Equal(t, []uuid.UUID{late.ID, fresh.ID}, ids)
+	assert.NotContains(t, ids, old.ID)
+	assert.Equal(t, &fresh.ID, result.NextSinceID)
+
+	// Un since_id eliminado tras entregarse sigue siendo un cursor válido
+	require.NoError(t, ratingRepo.Delete(ctx, fresh.ID))
+	result, err = service.Poll(ctx, &request.RatingPollRequest{SinceID: &fresh.ID, Wait: 1}, request.RatingIncludes{})
+	require.NoError(t, err)
+	assert.True(t, result.TimedOut)
+	assert.Equal(t, &fresh.ID, result.NextSinceID)
+}
@@ -0,0 +1,252 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/events"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/eventbus"
+	"github.com/MayaCris/stock-info-app/internal/testutil/testdoubles"
+)
+
+func TestRatingProcessing_NormalizesAndMarksBacklog(t *testing.T) {
+	store := testdoubles.NewStore()
+	companyRepo := testdoubles.NewCompanyRepository(store)
+	brokerageRepo := testdoubles.NewBrokerageRepository(store)
+	ratingRepo := testdoubles.NewStockRatingRepository(store)
+	ctx := context.Background()
+
+	company := entities.NewCompany("AAPL", "Apple Inc.")
+	require.NoError(t, companyRepo.Create(ctx, company))
+	brokerage := entities.NewBrokerage("Goldman Sachs")
+	require.NoError(t, brokerageRepo.Create(ctx, brokerage))
+
+	// Tres ratings guardados sin mapeos (sin escala normalizada) y dos ya normalizados
+	eventTime := time.Date(2025, 3, 10, 14, 0, 0, 0, time.UTC)
+	newRating := func(i int) *entities.StockRating {
+		rating := entities.NewStockRating(company.ID, brokerage.ID, "upgraded by", eventTime.Add(time.Duration(i)*time.Hour))
+		rating.RatingTo = "Buy"
+		return rating
+	}
+	entities.SetRatingMappings(map[string]entities.RatingScale{})
+	defer entities.SetRatingMappings(entities.DefaultRatingMappings)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, ratingRepo.Create(ctx, newRating(i)))
+	}
+	entities.SetRatingMappings(entities.DefaultRatingMappings)
+	for i := 3; i < 5; i++ {
+		require.NoError(t, ratingRepo.Create(ctx, newRating(i)))
+	}
+
+	bus := eventbus.NewMemoryBus(16, newEventBusTestLogger(t))
+	var (
+		mu        sync.Mutex
+		processed []events.RatingProcessedPayload
+	)
+	_, err := bus.Subscribe(events.RatingProcessed, func(_ context.Context, event events.Event) error {
+		var payload events.RatingProcessedPayload
+		if err := event.Decode(&payload); err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		processed = append(processed, payload)
+		return nil
+	})
+	require.NoError(t, err)
+
+	service := services.NewRatingProcessingService(ratingRepo, bus, 0, 0, newEventBusTestLogger(t))
+	var progress []int
+	result, err := service.Process(ctx, interfaces.RatingProcessingOptions{
+		BatchSize:   2,
+		Concurrency: 3,
+		OnProgress:  func(p response.RatingProcessingResponse) { progress = append(progress, p.Processed) },
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(5), result.BacklogBefore)
+	assert.Equal(t, int64(0), result.BacklogAfter)
+	assert.Equal(t, 3, result.Batches)
+	assert.Equal(t, 5, result.Processed)
+	assert.Equal(t, 3, result.Normalized)
+	assert.Equal(t, 0, result.Failed)
+	assert.Equal(t, 5, result.Events)
+	assert.False(t, result.Alerted)
+	assert.Equal(t, []int{2, 4, 5}, progress)
+
+	ratings, err := ratingRepo.GetAll(ctx)
+	require.NoError(t, err)
+	for _, rating := range ratings {
+		assert.True(t, rating.IsProcessed)
+		assert.Equal(t, entities.RatingBuy, rating.NormalizedTo)
+	}
+
+	require.NoError(t, bus.Close())
+	require.Len(t, processed, 5)
+	assert.Equal(t, entities.ActionUpgrade, processed[0].ActionType)
+	assert.Equal(t, entities.RatingBuy, processed[0].NormalizedTo)
+
+	// Sin backlog no hay lotes
+	again, err := service.Process(ctx, interfaces.RatingProcessingOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, again.Batches)
+	status, err := service.GetStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), status.Unprocessed)
+	assert.Nil(t, status.OldestUnprocessedAt)
+	require.Len(t, status.Throughput, 3)
+	assert.Equal(t, "15m", status.Throughput[0].Window)
+	assert.Equal(t, int64(5), status.Throughput[0].Processed)
+	assert.Equal(t, int64(5), status.Throughput[2].Processed)
+}
+
+func TestRatingProcessing_StatusAndBacklogAlert(t *testing.T) {
+	store := testdoubles.NewStore()
+	companyRepo := testdoubles.NewCompanyRepository(store)
+	brokerageRepo := testdoubles.NewBrokerageRepository(store)
+	ratingRepo := testdoubles.NewStockRatingRepository(store)
+	ctx := context.Background()
+
+	company := entities.NewCompany("MSFT", "Microsoft")
+	require.NoError(t, companyRepo.Create(ctx, company))
+	brokerage := entities.NewBrokerage("Morgan Stanley")
+	require.NoError(t, brokerageRepo.Create(ctx, brokerage))
+	day := 10
+	addRatings := func(count int, source string) {
+		for i := 0; i < count; i++ {
+			rating := entities.NewStockRating(company.ID, brokerage.ID, "reiterated by", time.Date(2025, 3, day, 14, 0, 0, 0, time.UTC))
+			rating.Source = source
+			require.NoError(t, ratingRepo.Create(ctx, rating))
+			day++
+		}
+	}
+	addRatings(2, "api")
+	addRatings(1, "manual")
+
+	bus := eventbus.NewMemoryBus(16, newEventBusTestLogger(t))
+	var (
+		mu     sync.Mutex
+		alerts []events.RatingBacklogExceededPayload
+	)
+	_, err := bus.Subscribe(events.RatingBacklogExceeded, func(_ context.Context, event events.Event) error {
+		var payload events.RatingBacklogExceededPayload
+		if err := event.Decode(&payload); err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		alerts = append(alerts, payload)
+		return nil
+	})
+	require.NoError(t, err)
+
+	service := services.NewRatingProcessingService(ratingRepo, bus, 1, 0, newEventBusTestLogger(t))
+	status, err := service.GetStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), status.Unprocessed)
+	assert.Equal(t, map[string]int64{"api": 2, "manual": 1}, status.BySource)
+	require.NotNil(t, status.OldestUnprocessedAt)
+	assert.True(t, status.OverThreshold)
+	assert.Equal(t, int64(0), status.Throughput[0].Processed)
+
+	// MaxBatches deja el resto para la próxima ejecución; el backlog cruza el umbral y se alerta
+	oneBatch := interfaces.RatingProcessingOptions{BatchSize: 1, MaxBatches: 1}
+	result, err := service.Process(ctx, oneBatch)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Processed)
+	assert.Equal(t, int64(2), result.BacklogAfter)
+	assert.Equal(t, 1, result.Events)
+	assert.True(t, result.Alerted)
+
+	// Un backlog que se mantiene no vuelve a alertar; uno que sigue creciendo sí
+	addRatings(1, "api")
+	result, err = service.Process(ctx, oneBatch)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), result.BacklogAfter)
+	assert.False(t, result.Alerted)
+
+	addRatings(2, "api")
+	result, err = service.Process(ctx, oneBatch)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), result.BacklogAfter)
+	assert.True(t, result.Alerted)
+
+	// Por debajo del umbral no se alerta
+	result, err = service.Process(ctx, interfaces.RatingProcessingOptions{})
+	require.NoError(t, err)
+	assert.False(t, result.Alerted)
+
+	// El cooldown limita las alertas aunque el backlog crezca
+	cooled := services.NewRatingProcessingService(ratingRepo, bus, 1, time.Hour, newEventBusTestLogger(t))
+	addRatings(3, "api")
+	result, err = cooled.Process(ctx, oneBatch)
+	require.NoError(t, err)
+	assert.True(t, result.Alerted)
+	addRatings(2, "api")
+	result, err = cooled.Process(ctx, oneBatch)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), result.BacklogAfter)
+	assert.False(t, result.Alerted)
+
+	require.NoError(t, bus.Close())
+	require.Len(t, alerts, 3)
+	assert.Equal(t, int64(3), alerts[0].Previous)
+	assert.Equal(t, int64(2), alerts[0].Unprocessed)
+	assert.Equal(t, int64(1), alerts[0].Threshold)
+	assert.NotNil(t, alerts[0].OldestUnprocessedAt)
+	assert.Equal(t, int64(3), alerts[1].Unprocessed)
+
+	for _, invalid := range []interfaces.RatingProcessingOptions{
+		{BatchSize: -1},
+		{BatchSize: 100000},
+		{Concurrency: -1},
+		{MaxBatches: -1},
+	} {
+		assert.Error(t, service.ValidateOptions(invalid), "%+v", invalid)
+	}
+}
+
+func TestRatingBacklogNotifier_PostsAlertsToWebhook(t *testing.T) {
+	received := make(chan events.Event, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event events.Event
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		received <- event
+	}))
+	defer server.Close()
+
+	bus := eventbus.NewMemoryBus(16, newEventBusTestLogger(t))
+	services.NewRatingBacklogNotifier(bus, server.URL, newEventBusTestLogger(t))
+
+	alert, err := events.NewRatingBacklogExceeded(12000, 9000, 10000, nil)
+	require.NoError(t, err)
+	require.NoError(t, bus.Publish(context.Background(), alert))
+	require.NoError(t, bus.Close())
+
+	event := <-received
+	assert.Equal(t, alert.ID, event.ID)
+	var payload events.RatingBacklogExceededPayload
+	require.NoError(t, event.Decode(&payload))
+	assert.Equal(t, int64(12000), payload.Unprocessed)
+
+	// Un webhook que falla se devuelve al bus, que solo lo registra
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+	notifier := services.NewRatingBacklogNotifier(nil, failing.URL, newEventBusTestLogger(t))
+	assert.Error(t, notifier.Notify(context.Background(), alert))
+}
@@ -0,0 +1,179 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/timezone"
+)
+
+// searchRecordingRatingRepository guarda el filtro y el orden de la última búsqueda y devuelve ratings fijos
+type searchRecordingRatingRepository struct {
+	interfaces.StockRatingRepository
+	filter   interfaces.RatingSearchFilter
+	sort     []interfaces.SortField
+	searches int
+	ratings  []*entities.StockRating
+}
+
+func (r *searchRecordingRatingRepository) Search(ctx context.Context, filter interfaces.RatingSearchFilter, sort []interfaces.SortField, limit, offset int) ([]*entities.StockRating, int64, error) {
+	r.filter, r.sort = filter, sort
+	r.searches++
+	return r.ratings, int64(len(r.ratings)), nil
+}
+
+// tickerCompanyRepository resuelve un único ticker
+type tickerCompanyRepository struct {
+	interfaces.CompanyRepository
+	company *entities.Company
+}
+
+func (r *tickerCompanyRepository) GetByTicker(ctx context.Context, ticker string) (*entities.Company, error) {
+	if ticker != r.company.Ticker {
+		return nil, errors.New("not found")
+	}
+	return r.company, nil
+}
+
+func TestStockRatingService_SearchRatings(t *testing.T) {
+	ratings := &searchRecordingRatingRepository{}
+	company := &entities.Company{ID: uuid.New(), Ticker: "AAPL"}
+	service := services.NewStockRatingService(ratings, &tickerCompanyRepository{company: company}, nil, nil, newEventBusTestLogger(t))
+
+	tokyo, err := timezone.Load("Asia/Tokyo")
+	require.NoError(t, err)
+	ctx := timezone.WithLocation(context.Background(), tokyo)
+	pagination := &response.PaginationRequest{Page: 1, PerPage: 20}
+
+	brokerageID := uuid.New()
+	minTarget := 150.0
+	_, err = service.SearchRatings(ctx, &request.RatingSearchRequest{
+		Ticker:      "AAPL",
+		BrokerageID: &brokerageID,
+		ActionType:  "upgrade, initiate",
+		MinRating:   "hold",
+		MinTarget:   &minTarget,
+		From:        "2026-03-02",
+		To:          "2026-03-06",
+		Sort:        "target_to:desc",
+	}, request.RatingIncludes{}, pagination)
+	require.NoError(t, err)
+
+	filter := ratings.filter
+	assert.Equal(t, company.ID, *filter.CompanyID)
+	assert.Equal(t, brokerageID, *filter.BrokerageID)
+	assert.Equal(t, []entities.ActionType{entities.ActionUpgrade, entities.ActionInitiate}, filter.ActionTypes)
+	assert.Equal(t, []entities.RatingScale{entities.RatingStrongBuy, entities.RatingBuy, entities.RatingHold}, filter.Ratings)
+	assert.Equal(t, "USD", filter.TargetCurrency)
+	assert.Equal(t, &minTarget, filter.TargetMin)
+	assert.Nil(t, filter.TargetMax)
+	// Días completos en hora de Tokio; to es exclusivo
+	assert.True(t, filter.From.Equal(time.Date(2026, 3, 1, 15, 0, 0, 0, time.UTC)))
+	assert.True(t, filter.To.Equal(time.Date(2026, 3, 6, 15, 0, 0, 0, time.UTC)))
+	assert.Equal(t, []interfaces.SortField{{Column: "target_to_value", Desc: true}}, ratings.sort)
+
+	// Lista y rango se intersectan; sin ningún rating en común no se consulta
+	_, err = service.SearchRatings(ctx, &request.RatingSearchRequest{RatingTo: "buy,sell", MaxRating: "hold"}, request.RatingIncludes{}, pagination)
+	require.NoError(t, err)
+	assert.Equal(t, []entities.RatingScale{entities.RatingSell}, ratings.filter.Ratings)
+
+	searches := ratings.searches
+	result, err := service.SearchRatings(ctx, &request.RatingSearchRequest{RatingTo: "sell", MinRating: "buy"}, request.RatingIncludes{}, pagination)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Meta.Total)
+	assert.Equal(t, searches, ratings.searches)
+
+	// Sin filtros no se restringe nada
+	_, err = service.SearchRatings(ctx, &request.RatingSearchRequest{}, request.RatingIncludes{}, pagination)
+	require.NoError(t, err)
+	assert.Equal(t, interfaces.RatingSearchFilter{}, ratings.filter)
+
+	tests := []struct {
+		name   string
+		req    request.RatingSearchRequest
+		status int
+		field  string
+	}{
+		{"unknown action type", request.RatingSearchRequest{ActionType: "upgrade,splurge"}, http.StatusBadRequest, "action_type"},
+		{"unknown rating", request.RatingSearchRequest{RatingTo: "overweight"}, http.StatusBadRequest, "rating_to"},
+		{"inverted rating range", request.RatingSearchRequest{MinRating: "buy", MaxRating: "sell"}, http.StatusBadRequest, ""},
+		{"inverted dates", request.RatingSearchRequest{From: "2026-03-06", To: "2026-03-02"}, http.StatusBadRequest, ""},
+		{"company and ticker", request.RatingSearchRequest{CompanyID: &company.ID, Ticker: "AAPL"}, http.StatusBadRequest, ""},
+		{"unknown ticker", request.RatingSearchRequest{Ticker: "ZZZZ"}, http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.SearchRatings(ctx, &tt.req, request.RatingIncludes{}, pagination)
+
+			var errorResp *response.ErrorResponse
+			require.True(t, errors.As(err, &errorResp))
+			assert.Equal(t, tt.status, errorResp.StatusCode)
+			if tt.field != "" {
+				require.Len(t, errorResp.ValidationErrors, 1)
+				assert.Equal(t, tt.field, errorResp.ValidationErrors[0].Field)
+			}
+		})
+	}
+}
+
+func TestParseRatingIncludes(t *testing.T) {
+	includes, err := request.ParseRatingIncludes(" Company , brokerage")
+	require.NoError(t, err)
+	assert.Equal(t, request.RatingIncludes{Company: true, Brokerage: true}, includes)
+
+	includes, err = request.ParseRatingIncludes("")
+	require.NoError(t, err)
+	assert.Equal(t, request.RatingIncludes{}, includes)
+
+	includes, err = request.ParseRatingIncludes("notes")
+	require.NoError(t, err)
+	assert.Equal(t, request.RatingIncludes{Notes: true}, includes)
+
+	_, err = request.ParseRatingIncludes("company,analyst")
+	assert.Error(t, err)
+}
+
+func TestStockRatingService_IncludesPreloadRelations(t *testing.T) {
+	company := entities.Company{ID: uuid.New(), Ticker: "AAPL", Name: "Apple Inc.", Sector: "Technology"}
+	brokerage := entities.Brokerage{ID: uuid.New(), Name: "Goldman Sachs", Country: "USA"}
+	ratings := &searchRecordingRatingRepository{ratings: []*entities.StockRating{{
+		ID:          uuid.New(),
+		CompanyID:   company.ID,
+		BrokerageID: brokerage.ID,
+		Company:     company,
+		Brokerage:   brokerage,
+		Action:      "upgraded by",
+	}}}
+	// Los repositorios de company y brokerage no implementan GetByID: las relaciones deben venir precargadas
+	service := services.NewStockRatingService(ratings, &tickerCompanyRepository{company: &company}, nil, nil, newEventBusTestLogger(t))
+
+	result, err := service.SearchRatings(context.Background(), &request.RatingSearchRequest{},
+		request.RatingIncludes{Company: true, Brokerage: true}, &response.PaginationRequest{Page: 1, PerPage: 20})
+	require.NoError(t, err)
+
+	assert.True(t, ratings.filter.PreloadCompany)
+	assert.True(t, ratings.filter.PreloadBrokerage)
+
+	require.Len(t, result.Items, 1)
+	item := result.Items[0]
+	assert.Equal(t, brokerage.ID, item.BrokerageID)
+	assert.Equal(t, "AAPL", item.Ticker)
+	assert.Equal(t, "Goldman Sachs", item.Brokerage)
+	require.NotNil(t, item.CompanySummary)
+	assert.Equal(t, "Apple Inc.", item.CompanySummary.Name)
+	assert.Equal(t, "Technology", item.CompanySummary.Sector)
+	require.NotNil(t, item.BrokerageSummary)
+	assert.Equal(t, "USA", item.BrokerageSummary.Country)
+}
@@ -0,0 +1,119 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+)
+
+// fakeRatingMappingRepository guarda los mapeos en memoria y cuenta las re-normalizaciones
+type fakeRatingMappingRepository struct {
+	mappings map[string]entities.RatingScale
+	applied  int
+}
+
+func (r *fakeRatingMappingRepository) List(ctx context.Context) ([]*entities.RatingMapping, error) {
+	result := make([]*entities.RatingMapping, 0, len(r.mappings))
+	for rating, normalized := range r.mappings {
+		result = append(result, &entities.RatingMapping{Rating: rating, Normalized: normalized})
+	}
+	return result, nil
+}
+
+func (r *fakeRatingMappingRepository) Upsert(ctx context.Context, mapping *entities.RatingMapping) error {
+	r.mappings[mapping.Rating] = mapping.Normalized
+	return nil
+}
+
+func (r *fakeRatingMappingRepository) Delete(ctx context.Context, rating string) error {
+	delete(r.mappings, rating)
+	return nil
+}
+
+func (r *fakeRatingMappingRepository) ApplyToRatings(ctx context.Context) (int64, error) {
+	r.applied++
+	return 3, nil
+}
+
+func (r *fakeRatingMappingRepository) Unmapped(ctx context.Context, limit int) ([]interfaces.UnmappedRating, error) {
+	return []interfaces.UnmappedRating{{Rating: "speculative hold", Count: 2}}, nil
+}
+
+func TestRatingScale(t *testing.T) {
+	scale, err := entities.ParseRatingScale(" Strong_Buy ")
+	require.NoError(t, err)
+	assert.Equal(t, entities.RatingStrongBuy, scale)
+	assert.Equal(t, 2, scale.Score())
+	assert.Equal(t, "buy", scale.Bucket())
+	assert.Equal(t, "Strong Buy", scale.Label())
+
+	assert.Equal(t, "sell", entities.RatingStrongSell.Bucket())
+	assert.Equal(t, -2, entities.RatingStrongSell.Score())
+	assert.Equal(t, "", entities.RatingScale("").Bucket())
+
+	_, err = entities.ParseRatingScale("overweight")
+	assert.Error(t, err)
+}
+
+func TestNormalizeRating_DefaultMappings(t *testing.T) {
+	assert.Equal(t, entities.RatingBuy, entities.NormalizeRating("Overweight"))
+	assert.Equal(t, entities.RatingBuy, entities.NormalizeRating("  Market   Outperform "))
+	assert.Equal(t, entities.RatingHold, entities.NormalizeRating("EQUAL WEIGHT"))
+	assert.Equal(t, entities.RatingStrongSell, entities.NormalizeRating("Strong-Sell"))
+	assert.Equal(t, entities.RatingScale(""), entities.NormalizeRating("Speculative Hold"))
+	assert.Equal(t, entities.RatingScale(""), entities.NormalizeRating(""))
+}
+
+func TestStockRating_NormalizesRatings(t *testing.T) {
+	rating := entities.NewStockRating(uuid.New(), uuid.New(), "upgraded by", time.Now())
+	rating.RatingFrom = " Underweight"
+	rating.RatingTo = "Sector Perform "
+	rating.Normalize()
+
+	assert.Equal(t, "Underweight", rating.RatingFrom)
+	assert.Equal(t, entities.RatingSell, rating.NormalizedFrom)
+	assert.Equal(t, entities.RatingHold, rating.NormalizedTo)
+}
+
+func TestRatingTaxonomyService_SaveAndDelete(t *testing.T) {
+	defer entities.SetRatingMappings(entities.DefaultRatingMappings)
+
+	ctx := context.Background()
+	repo := &fakeRatingMappingRepository{mappings: map[string]entities.RatingScale{"buy": entities.RatingBuy}}
+	service := services.NewRatingTaxonomyService(repo, newEventBusTestLogger(t))
+
+	// Load reemplaza los mapeos por defecto por la tabla
+	require.NoError(t, service.Load(ctx))
+	assert.Equal(t, entities.RatingScale(""), entities.NormalizeRating("Overweight"))
+
+	result, err := service.SaveMapping(ctx, &request.SaveRatingMappingRequest{Rating: "  Speculative   HOLD ", Normalized: "hold"})
+	require.NoError(t, err)
+	assert.Equal(t, entities.RatingHold, repo.mappings["speculative hold"])
+	assert.Equal(t, 2, result.Mappings)
+	assert.Equal(t, int64(3), result.Updated)
+	assert.Equal(t, 1, repo.applied)
+	assert.Equal(t, entities.RatingHold, entities.NormalizeRating("Speculative Hold"))
+
+	_, err = service.SaveMapping(ctx, &request.SaveRatingMappingRequest{Rating: "neutral", Normalized: "flat"})
+	assert.Error(t, err)
+
+	_, err = service.DeleteMapping(ctx, "Speculative Hold")
+	require.NoError(t, err)
+	assert.Equal(t, entities.RatingScale(""), entities.NormalizeRating("Speculative Hold"))
+
+	taxonomy, err := service.GetTaxonomy(ctx)
+	require.NoError(t, err)
+	assert.Len(t, taxonomy.Scale, 5)
+	assert.Len(t, taxonomy.Mappings, 1)
+	assert.Equal(t, "Buy", taxonomy.Mappings[0].Label)
+	assert.Len(t, taxonomy.Unmapped, 1)
+}
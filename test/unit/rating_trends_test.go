@@ -0,0 +1,83 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/timezone"
+)
+
+// trendRatingRepository devuelve buckets fijos y guarda los argumentos de GetActionTypeTrend
+type trendRatingRepository struct {
+	interfaces.StockRatingRepository
+	buckets     []interfaces.RatingTrendBucket
+	from, to    time.Time
+	granularity interfaces.TrendGranularity
+	location    *time.Location
+}
+
+func (r *trendRatingRepository) GetActionTypeTrend(ctx context.Context, from, to time.Time, granularity interfaces.TrendGranularity, location *time.Location) ([]interfaces.RatingTrendBucket, error) {
+	r.from, r.to, r.granularity, r.location = from, to, granularity, location
+	return r.buckets, nil
+}
+
+func TestAnalysisService_GetRatingTrends_CustomRange(t *testing.T) {
+	newYork, err := timezone.Load("America/New_York")
+	require.NoError(t, err)
+	ctx := timezone.WithLocation(context.Background(), newYork)
+
+	// Buckets semanales (lunes) devueltos por SQL; la semana del 9 de marzo no tiene ratings
+	repo := &trendRatingRepository{buckets: []interfaces.RatingTrendBucket{
+		{Start: time.Date(2026, 3, 2, 0, 0, 0, 0, newYork), Total: 3, Actions: map[string]int64{"upgrade": 2, "downgrade": 1}},
+		{Start: time.Date(2026, 3, 16, 0, 0, 0, 0, newYork), Total: 1, Actions: map[string]int64{"upgrade": 1}},
+	}}
+	service := services.NewAnalysisService(repo, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, services.DefaultScoringWeights(), newEventBusTestLogger(t))
+
+	trends, err := service.GetRatingTrends(ctx, &request.RatingTrendsRequest{From: "2026-03-04", To: "2026-03-17", Granularity: "week"})
+	require.NoError(t, err)
+
+	// El rango va de la medianoche de Nueva York del primer día a la del día siguiente al último
+	assert.True(t, repo.from.Equal(time.Date(2026, 3, 4, 5, 0, 0, 0, time.UTC)))
+	assert.True(t, repo.to.Equal(time.Date(2026, 3, 18, 4, 0, 0, 0, time.UTC))) // Ya en horario de verano
+	assert.Equal(t, interfaces.TrendGranularityWeek, repo.granularity)
+	assert.Equal(t, newYork, repo.location)
+
+	buckets := trends["buckets"].([]interfaces.RatingTrendBucket)
+	require.Len(t, buckets, 3)
+	assert.Equal(t, int64(0), buckets[1].Total)
+	assert.True(t, buckets[1].Start.Equal(time.Date(2026, 3, 9, 0, 0, 0, 0, newYork)))
+	assert.Equal(t, map[string]int64{"upgrade": 3, "downgrade": 1}, trends["actions"])
+	assert.Equal(t, 14, trends["days"])
+	assert.NotContains(t, trends, "period")
+
+	// Sin granularidad se elige por la longitud del rango
+	_, err = service.GetRatingTrends(ctx, &request.RatingTrendsRequest{From: "2026-01-01", To: "2026-03-31"})
+	require.NoError(t, err)
+	assert.Equal(t, interfaces.TrendGranularityWeek, repo.granularity)
+
+	trends, err = service.GetRatingTrends(ctx, &request.RatingTrendsRequest{Period: "week"})
+	require.NoError(t, err)
+	assert.Equal(t, interfaces.TrendGranularityDay, repo.granularity)
+	assert.Equal(t, "week", trends["period"])
+	assert.Len(t, trends["buckets"], 7)
+
+	// Rangos invertidos o demasiado largos para buckets diarios
+	var errorResp *response.ErrorResponse
+	_, err = service.GetRatingTrends(ctx, &request.RatingTrendsRequest{From: "2026-03-10", To: "2026-03-01"})
+	require.True(t, errors.As(err, &errorResp))
+	assert.Equal(t, http.StatusBadRequest, errorResp.StatusCode)
+
+	_, err = service.GetRatingTrends(ctx, &request.RatingTrendsRequest{From: "2020-01-01", To: "2026-01-01", Granularity: "day"})
+	require.True(t, errors.As(err, &errorResp))
+	assert.Equal(t, http.StatusBadRequest, errorResp.StatusCode)
+}
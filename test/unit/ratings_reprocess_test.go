@@ -0,0 +1,84 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/usecases/population"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/adapters"
+)
+
+func TestStockAPIDataProvider_ParseRawItem(t *testing.T) {
+	provider := adapters.NewStockAPIDataProvider(nil)
+	raw := []byte(`{"ticker":"BSBR","company":"Banco Santander (Brasil)","brokerage":"The Goldman Sachs Group",` +
+		`"action":"upgraded by","rating_from":"Sell","rating_to":"Neutral","target_from":"$4.20","target_to":"$4.70",` +
+		`"time":"2025-01-13T00:30:05.813548892Z"}`)
+
+	item, err := provider.ParseRawItem(raw)
+	require.NoError(t, err)
+
+	assert.Equal(t, "BSBR", item.Ticker)
+	assert.Equal(t, "The Goldman Sachs Group", item.Brokerage)
+	assert.Equal(t, "$4.70", item.TargetTo)
+	assert.Equal(t, time.Date(2025, 1, 13, 0, 30, 5, 813548892, time.UTC), item.EventTime)
+	assert.JSONEq(t, string(raw), string(item.RawData))
+}
+
+func TestStockAPIDataProvider_ParseRawItemRejectsInvalidData(t *testing.T) {
+	provider := adapters.NewStockAPIDataProvider(nil)
+
+	_, err := provider.ParseRawItem([]byte(`{"ticker":"BSBR"}`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), population.RejectReasonMissingFields)
+
+	_, err = provider.ParseRawItem([]byte(`not json`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), population.RejectReasonInvalidPayload)
+}
+
+func TestReprocessRangeFromDates(t *testing.T) {
+	from, to, err := population.ReprocessRangeFromDates("2025-01-01", "2025-01-31")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), to)
+
+	// Un solo día
+	from, to, err = population.ReprocessRangeFromDates("2025-03-10", "2025-03-10")
+	require.NoError(t, err)
+	assert.Equal(t, 24*time.Hour, to.Sub(from))
+
+	_, _, err = population.ReprocessRangeFromDates("2025-02-01", "2025-01-31")
+	assert.Error(t, err)
+
+	_, _, err = population.ReprocessRangeFromDates("01/01/2025", "2025-01-31")
+	assert.Error(t, err)
+}
+
+func TestStockRating_HasSameData(t *testing.T) {
+	companyID, brokerageID := uuid.New(), uuid.New()
+	eventTime := time.Date(2025, 1, 13, 0, 30, 5, 813548000, time.UTC)
+
+	stored := entities.NewStockRating(companyID, brokerageID, "upgraded by", eventTime)
+	stored.RatingFrom, stored.RatingTo = "Sell", "Neutral"
+	stored.TargetFrom, stored.TargetTo = "$4.20", "$4.70"
+
+	// Nanosegundos que la base de datos no guarda
+	reparsed := entities.NewStockRating(companyID, brokerageID, "upgraded by", eventTime.Add(892*time.Nanosecond))
+	reparsed.RatingFrom, reparsed.RatingTo = "Sell", "Neutral"
+	reparsed.TargetFrom, reparsed.TargetTo = "$4.20", "$4.70"
+
+	assert.True(t, reparsed.SameEvent(stored))
+	assert.True(t, reparsed.HasSameData(stored))
+
+	reparsed.TargetTo = "$4.80"
+	assert.True(t, reparsed.SameEvent(stored))
+	assert.False(t, reparsed.HasSameData(stored))
+
+	moved := entities.NewStockRating(companyID, brokerageID, "upgraded by", eventTime.Add(time.Second))
+	assert.False(t, moved.SameEvent(stored))
+}
@@ -0,0 +1,100 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/testutil/testdoubles"
+)
+
+func TestRelativeStrengthIndex(t *testing.T) {
+	rising := make([]float64, 20)
+	for i := range rising {
+		rising[i] = 100 + float64(i)
+	}
+	rsi := services.RelativeStrengthIndex(rising, 14)
+	require.NotNil(t, rsi)
+	assert.Equal(t, 100.0, *rsi)
+
+	// Subidas y bajadas iguales dejan el RSI en 50
+	alternating := []float64{100, 101, 100, 101, 100, 101, 100, 101, 100, 101, 100, 101, 100, 101, 100}
+	rsi = services.RelativeStrengthIndex(alternating, 14)
+	require.NotNil(t, rsi)
+	assert.InDelta(t, 50, *rsi, 1e-9)
+
+	assert.Nil(t, services.RelativeStrengthIndex(rising[:14], 14))
+}
+
+func TestScoreValuation(t *testing.T) {
+	cheap := services.ScoreValuation(float64Ptr(12), float64Ptr(0.8))
+	require.NotNil(t, cheap)
+	assert.Equal(t, 1.0, *cheap)
+
+	fair := services.ScoreValuation(float64Ptr(25), nil)
+	require.NotNil(t, fair)
+	assert.InDelta(t, 0.0, *fair, 1e-9)
+
+	assert.Nil(t, services.ScoreValuation(nil, nil))
+}
+
+func TestCompositeScore(t *testing.T) {
+	factors := []response.RecommendationFactor{
+		{Name: services.FactorConsensus, Weight: 0.5, Score: float64Ptr(1)},
+		{Name: services.FactorMomentum, Weight: 0.25, Score: float64Ptr(-0.2)},
+		{Name: services.FactorValuation, Weight: 0.25}, // Sin datos: su peso se reparte
+	}
+
+	score := services.CompositeScore(factors)
+	require.NotNil(t, score)
+	assert.InDelta(t, (0.5*1-0.25*0.2)/0.75, *score, 1e-9)
+	assert.Equal(t, "Buy", services.RecommendationLabel(score))
+	require.NotNil(t, factors[0].Contribution)
+	assert.Nil(t, factors[2].Contribution)
+
+	assert.Equal(t, "Hold", services.RecommendationLabel(float64Ptr(0.1)))
+	assert.Equal(t, "Sell", services.RecommendationLabel(float64Ptr(-0.3)))
+	assert.Nil(t, services.CompositeScore(factors[2:]))
+}
+
+func TestAnalysisService_CompanyAnalysisUsesCompositeRecommendation(t *testing.T) {
+	store := testdoubles.NewStore()
+	companyRepo := testdoubles.NewCompanyRepository(store)
+	brokerageRepo := testdoubles.NewBrokerageRepository(store)
+	ratingRepo := testdoubles.NewStockRatingRepository(store)
+	ctx := context.Background()
+
+	company := entities.NewCompany("AAPL", "Apple Inc.")
+	require.NoError(t, companyRepo.Create(ctx, company))
+
+	// Tres buy de la misma brokerage y dos sell de otras: por mayoría sería Buy, por consenso de brokerages Sell
+	rate := func(brokerageName, ratingTo string, count int) {
+		brokerage := entities.NewBrokerage(brokerageName)
+		require.NoError(t, brokerageRepo.Create(ctx, brokerage))
+		for i := 0; i < count; i++ {
+			rating := entities.NewStockRating(company.ID, brokerage.ID, "reiterated by", time.Now().Add(-time.Duration(i+1)*time.Hour))
+			rating.RatingTo = ratingTo
+			require.NoError(t, ratingRepo.Create(ctx, rating))
+		}
+	}
+	rate("Goldman Sachs", "Buy", 3)
+	rate("Morgan Stanley", "Sell", 1)
+	rate("Barclays", "Sell", 1)
+
+	service := services.NewAnalysisService(ratingRepo, companyRepo, brokerageRepo, nil, nil, nil, nil, nil, nil, nil, 0, services.DefaultScoringWeights(), newEventBusTestLogger(t))
+
+	recommendation, err := service.GenerateRecommendation(ctx, company.ID)
+	require.NoError(t, err)
+	analysis, err := service.GetCompanyAnalysis(ctx, company.ID)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Sell", recommendation.Recommendation)
+	assert.Equal(t, recommendation.Recommendation, analysis.Recommendation)
+	assert.Equal(t, recommendation.Score, analysis.RecommendationScore)
+}
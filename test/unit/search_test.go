@@ -0,0 +1,17 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/implementation"
+)
+
+func TestBuildPrefixTSQuery(t *testing.T) {
+	assert.Equal(t, "appl:*", implementation.BuildPrefixTSQuery("Appl"))
+	assert.Equal(t, "alphabet:* & inc:*", implementation.BuildPrefixTSQuery("  Alphabet, Inc. "))
+	// Los operadores de tsquery se descartan como puntuación
+	assert.Equal(t, "meta:* & ai:*", implementation.BuildPrefixTSQuery("meta & !ai:*"))
+	assert.Equal(t, "", implementation.BuildPrefixTSQuery("&|!"))
+}
@@ -0,0 +1,86 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/secrets"
+)
+
+type fakeSecretsProvider struct {
+	values map[string]map[string]string
+	err    error
+	calls  int
+}
+
+func (p *fakeSecretsProvider) Name() string {
+	return "fake"
+}
+
+func (p *fakeSecretsProvider) GetSecret(ctx context.Context, name string) (map[string]string, error) {
+	p.calls++
+	if p.err != nil {
+		return nil, p.err
+	}
+	values, ok := p.values[name]
+	if !ok {
+		return nil, secrets.ErrSecretNotFound
+	}
+	return values, nil
+}
+
+func TestParseReference(t *testing.T) {
+	ref, ok := secrets.ParseReference("secret:stock-info/finnhub#api_key")
+	assert.True(t, ok)
+	assert.Equal(t, "stock-info/finnhub", ref.Name)
+	assert.Equal(t, "api_key", ref.Field)
+
+	_, ok = secrets.ParseReference("plain-value")
+	assert.False(t, ok)
+}
+
+func TestSecretsResolver_CachesAndRotates(t *testing.T) {
+	provider := &fakeSecretsProvider{values: map[string]map[string]string{
+		"finnhub": {"api_key": "old"},
+	}}
+	resolver := secrets.NewResolver(provider, time.Hour)
+	ref := secrets.Reference{Name: "finnhub", Field: "api_key"}
+
+	value, err := resolver.Resolve(context.Background(), ref)
+	assert.NoError(t, err)
+	assert.Equal(t, "old", value)
+
+	// Dentro del TTL no se vuelve a consultar al proveedor
+	provider.values["finnhub"] = map[string]string{"api_key": "new"}
+	value, _ = resolver.Resolve(context.Background(), ref)
+	assert.Equal(t, "old", value)
+	assert.Equal(t, 1, provider.calls)
+
+	resolver.Expire()
+	value, _ = resolver.Resolve(context.Background(), ref)
+	assert.Equal(t, "new", value)
+
+	// Si el proveedor cae se sigue usando la última versión conocida
+	provider.err = errors.New("unavailable")
+	resolver.Expire()
+	value, err = resolver.Resolve(context.Background(), ref)
+	assert.NoError(t, err)
+	assert.Equal(t, "new", value)
+}
+
+func TestSecretsResolver_MissingField(t *testing.T) {
+	provider := &fakeSecretsProvider{values: map[string]map[string]string{
+		"db": {"user": "app", "password": "pw"},
+	}}
+	resolver := secrets.NewResolver(provider, time.Minute)
+
+	_, err := resolver.Resolve(context.Background(), secrets.Reference{Name: "db", Field: "token"})
+	assert.ErrorIs(t, err, secrets.ErrSecretNotFound)
+
+	_, err = resolver.Resolve(context.Background(), secrets.Reference{Name: "db"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,105 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/timezone"
+	"github.com/MayaCris/stock-info-app/internal/testutil/testdoubles"
+)
+
+func TestAnalysisService_GetSectorRotation(t *testing.T) {
+	store := testdoubles.NewStore()
+	companyRepo := testdoubles.NewCompanyRepository(store)
+	brokerageRepo := testdoubles.NewBrokerageRepository(store)
+	ratingRepo := testdoubles.NewStockRatingRepository(store)
+	ctx := context.Background()
+
+	newCompany := func(ticker, sector string) *entities.Company {
+		company := entities.NewCompany(ticker, ticker+" Inc.")
+		company.Sector = sector
+		require.NoError(t, companyRepo.Create(ctx, company))
+		return company
+	}
+	apple := newCompany("AAPL", "Technology")
+	pfizer := newCompany("PFE", "Healthcare")
+	unclassified := newCompany("XYZ", "")
+	brokerage := entities.NewBrokerage("Goldman Sachs")
+	require.NoError(t, brokerageRepo.Create(ctx, brokerage))
+
+	newRating := func(companyID uuid.UUID, action string, eventTime time.Time) {
+		require.NoError(t, ratingRepo.Create(ctx, entities.NewStockRating(companyID, brokerage.ID, action, eventTime)))
+	}
+	// Tecnología pasa de upgrades a downgrades; salud al revés
+	newRating(apple.ID, "upgraded by", time.Date(2026, 1, 10, 14, 0, 0, 0, time.UTC))
+	newRating(apple.ID, "upgraded by", time.Date(2026, 1, 20, 14, 0, 0, 0, time.UTC))
+	newRating(apple.ID, "reiterated by", time.Date(2026, 1, 21, 14, 0, 0, 0, time.UTC))
+	newRating(apple.ID, "downgraded by", time.Date(2026, 3, 5, 14, 0, 0, 0, time.UTC))
+	newRating(pfizer.ID, "downgraded by", time.Date(2026, 1, 15, 14, 0, 0, 0, time.UTC))
+	newRating(pfizer.ID, "upgraded by", time.Date(2026, 3, 2, 14, 0, 0, 0, time.UTC))
+	newRating(pfizer.ID, "upgraded by", time.Date(2026, 3, 3, 14, 0, 0, 0, time.UTC))
+	// El 1 de marzo a las 02:00 UTC todavía es febrero en Nueva York
+	newRating(pfizer.ID, "upgraded by", time.Date(2026, 3, 1, 2, 0, 0, 0, time.UTC))
+	// Fuera del rango o sin sector no cuentan
+	newRating(apple.ID, "upgraded by", time.Date(2025, 12, 31, 14, 0, 0, 0, time.UTC))
+	newRating(unclassified.ID, "upgraded by", time.Date(2026, 2, 10, 14, 0, 0, 0, time.UTC))
+
+	service := services.NewAnalysisService(ratingRepo, companyRepo, brokerageRepo, nil, nil, nil, nil, nil, nil, nil, 0, services.DefaultScoringWeights(), newEventBusTestLogger(t))
+
+	rotation, err := service.GetSectorRotation(ctx, &request.SectorRotationRequest{Months: 3, To: "2026-03"})
+	require.NoError(t, err)
+	assert.Equal(t, "2026-01", rotation.From)
+	assert.Equal(t, "2026-03", rotation.To)
+	assert.Equal(t, "UTC", rotation.Timezone)
+	assert.Equal(t, []string{"2026-01", "2026-02", "2026-03"}, rotation.Months)
+	// Salud termina con neto +2 y tecnología con +1
+	require.Equal(t, []string{"Healthcare", "Technology"}, rotation.Sectors)
+	require.Len(t, rotation.Cells, 2)
+
+	tech := rotation.Cells[1]
+	assert.Equal(t, int64(3), tech[0].Ratings)
+	assert.Equal(t, int64(2), tech[0].Net)
+	assert.Equal(t, 1.0, tech[0].Balance)
+	assert.Nil(t, tech[0].Change)
+	assert.Zero(t, tech[1].Ratings)
+	assert.Nil(t, tech[1].Change)
+	assert.Equal(t, -1.0, tech[2].Balance)
+	assert.Nil(t, tech[2].Change)
+
+	health := rotation.Cells[0]
+	assert.Equal(t, -1.0, health[0].Balance)
+	assert.Zero(t, health[1].Ratings)
+	assert.Equal(t, int64(3), health[2].Upgrades)
+	assert.Equal(t, 1.0, health[2].Balance)
+
+	assert.Equal(t, int64(2), rotation.Totals[0].Net)
+	assert.Equal(t, 0.5, rotation.Totals[0].Balance)
+	assert.Equal(t, int64(1), rotation.Totals[1].Net)
+
+	// En Nueva York el upgrade del 1 de marzo a las 02:00 UTC cae en febrero
+	newYork, err := timezone.Load("America/New_York")
+	require.NoError(t, err)
+	rotation, err = service.GetSectorRotation(timezone.WithLocation(ctx, newYork), &request.SectorRotationRequest{Months: 2, To: "2026-03"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2026-02", "2026-03"}, rotation.Months)
+	assert.Equal(t, []string{"Healthcare", "Technology"}, rotation.Sectors)
+	health = rotation.Cells[0]
+	assert.Equal(t, int64(1), health[0].Upgrades)
+	assert.Equal(t, int64(2), health[1].Upgrades)
+	require.NotNil(t, health[1].Change)
+	assert.Zero(t, *health[1].Change)
+	tech = rotation.Cells[1]
+	assert.Nil(t, tech[1].Change)
+	assert.Equal(t, -1.0, tech[1].Balance)
+
+	_, err = service.GetSectorRotation(ctx, &request.SectorRotationRequest{To: "2026-03-01"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,110 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	"github.com/MayaCris/stock-info-app/internal/application/services/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	repoInterfaces "github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/testutil/testdoubles"
+)
+
+// El histórico de precios no tiene doble en testdoubles
+
+type seedHistoricalRepo struct {
+	repoInterfaces.HistoricalDataRepository
+	prices []*entities.HistoricalData
+}
+
+func (r *seedHistoricalRepo) GetBySymbol(ctx context.Context, symbol string, startDate, endDate time.Time) ([]*entities.HistoricalData, error) {
+	var result []*entities.HistoricalData
+	for _, data := range r.prices {
+		if data.Symbol == symbol && !data.Date.Before(startDate) && !data.Date.After(endDate) {
+			result = append(result, data)
+		}
+	}
+	return result, nil
+}
+
+func (r *seedHistoricalRepo) BulkCreate(ctx context.Context, data []*entities.HistoricalData) error {
+	r.prices = append(r.prices, data...)
+	return nil
+}
+
+func TestSeedService_GeneratesDataThroughRepositories(t *testing.T) {
+	store := testdoubles.NewStore()
+	companyRepo := testdoubles.NewCompanyRepository(store)
+	brokerageRepo := testdoubles.NewBrokerageRepository(store)
+	ratingRepo := testdoubles.NewStockRatingRepository(store)
+	marketDataRepo := testdoubles.NewMarketDataRepository(store)
+	historicalRepo := &seedHistoricalRepo{}
+	service := services.NewSeedService(companyRepo, brokerageRepo, ratingRepo, marketDataRepo, historicalRepo, newEventBusTestLogger(t))
+	ctx := context.Background()
+
+	options := interfaces.SeedOptions{Companies: 16, Brokerages: 3, RatingsPerCompany: 5, PriceDays: 30, Seed: 7}
+	result, err := service.Seed(ctx, options)
+	require.NoError(t, err)
+
+	assert.Equal(t, 16, result.Companies)
+	assert.Equal(t, 3, result.Brokerages)
+	assert.Equal(t, 80, result.Ratings)
+	assert.Equal(t, 16, result.MarketData)
+	assert.Len(t, historicalRepo.prices, result.HistoricalPrices)
+	assert.Greater(t, result.HistoricalPrices, 16*18) // ~21 días laborables por símbolo
+
+	// Las primeras companies son las del proveedor mock, después las sintéticas
+	apple, err := companyRepo.GetByTicker(ctx, "AAPL")
+	require.NoError(t, err)
+	assert.Equal(t, "Apple Inc.", apple.Name)
+	assert.Equal(t, services.SeedDataSource, apple.DataSource)
+	assert.Greater(t, apple.MarketCap, 0.0)
+	synthetic, err := companyRepo.GetByTicker(ctx, "APET")
+	require.NoError(t, err)
+	assert.Equal(t, "Apex Technologies Inc.", synthetic.Name)
+
+	ratings, err := ratingRepo.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, ratings, result.Ratings)
+	for _, rating := range ratings {
+		assert.True(t, rating.IsValid())
+		assert.Equal(t, services.SeedDataSource, rating.Source)
+		assert.NotEqual(t, entities.ActionOther, rating.ActionType)
+		assert.NotNil(t, rating.TargetToValue)
+		assert.False(t, rating.EventTime.After(time.Now()))
+	}
+	quote, err := marketDataRepo.GetBySymbol(ctx, "MSFT")
+	require.NoError(t, err)
+	assert.Greater(t, quote.CurrentPrice, 0.0)
+	microsoft, err := companyRepo.GetByTicker(ctx, "MSFT")
+	require.NoError(t, err)
+	assert.Equal(t, microsoft.ID, quote.CompanyID)
+
+	// Repetir el seed reutiliza companies, ratings y precios
+	again, err := service.Seed(ctx, options)
+	require.NoError(t, err)
+	assert.Equal(t, 0, again.Companies)
+	assert.Equal(t, 16, again.ExistingCompanies)
+	assert.Equal(t, 0, again.Ratings)
+	assert.Equal(t, 0, again.HistoricalPrices)
+	brokerages, err := brokerageRepo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), brokerages)
+	companies, err := companyRepo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(16), companies)
+
+	for _, invalid := range []interfaces.SeedOptions{
+		{Companies: 0, Brokerages: 1},
+		{Companies: services.MaxSeedCompanies + 1, Brokerages: 1},
+		{Companies: 1, Brokerages: services.MaxSeedBrokerages + 1},
+		{Companies: 1, Brokerages: 1, PriceDays: -1},
+	} {
+		assert.Error(t, service.ValidateOptions(invalid), "%+v", invalid)
+	}
+}
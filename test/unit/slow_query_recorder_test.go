@@ -0,0 +1,36 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/database/cockroachdb"
+)
+
+func TestSlowQueryRecorder_RecentNewestFirstWithEviction(t *testing.T) {
+	recorder := cockroachdb.NewSlowQueryRecorder(100*time.Millisecond, 3)
+	assert.True(t, recorder.Enabled())
+
+	for _, sql := range []string{"q1", "q2", "q3", "q4", "q5"} {
+		recorder.Record(cockroachdb.SlowQuery{SQL: sql})
+	}
+
+	assert.Equal(t, int64(5), recorder.Total())
+
+	recent := recorder.Recent(0)
+	if assert.Len(t, recent, 3) {
+		assert.Equal(t, "q5", recent[0].SQL)
+		assert.Equal(t, "q4", recent[1].SQL)
+		assert.Equal(t, "q3", recent[2].SQL)
+	}
+
+	assert.Len(t, recorder.Recent(2), 2)
+}
+
+func TestSlowQueryRecorder_ZeroThresholdDisablesRecording(t *testing.T) {
+	recorder := cockroachdb.NewSlowQueryRecorder(0, 10)
+	assert.False(t, recorder.Enabled())
+	assert.Empty(t, recorder.Recent(10))
+}
@@ -0,0 +1,48 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/testutil/testdoubles"
+)
+
+func TestStockRatingService_RestoreRequiresActiveCompany(t *testing.T) {
+	ctx := context.Background()
+	store := testdoubles.NewStore()
+	companyRepo := testdoubles.NewCompanyRepository(store)
+	brokerageRepo := testdoubles.NewBrokerageRepository(store)
+	ratingRepo := testdoubles.NewStockRatingRepository(store)
+	companies := relationshipTestCompanies(t, companyRepo, "AAPL")
+
+	brokerage := entities.NewBrokerage("Goldman Sachs")
+	require.NoError(t, brokerageRepo.Create(ctx, brokerage))
+	rating := entities.NewStockRating(companies["AAPL"].ID, brokerage.ID, "upgraded by", time.Now().Add(-time.Hour))
+	require.NoError(t, ratingRepo.Create(ctx, rating))
+	require.NoError(t, ratingRepo.Delete(ctx, rating.ID))
+	require.NoError(t, companyRepo.Delete(ctx, companies["AAPL"].ID))
+
+	service := services.NewStockRatingService(ratingRepo, companyRepo, brokerageRepo, nil, newEventBusTestLogger(t))
+
+	// Con la compañía eliminada el rating sigue eliminado
+	_, err := service.RestoreStockRating(ctx, rating.ID)
+	var errorResp *response.ErrorResponse
+	require.True(t, errors.As(err, &errorResp))
+	assert.Equal(t, response.ErrCodeConflict, errorResp.Code)
+	_, err = ratingRepo.GetByID(ctx, rating.ID)
+	assert.Error(t, err)
+
+	// Restaurada la compañía, el rating se restaura
+	require.NoError(t, companyRepo.Restore(ctx, companies["AAPL"].ID))
+	restored, err := service.RestoreStockRating(ctx, rating.ID)
+	require.NoError(t, err)
+	assert.Equal(t, rating.ID, restored.ID)
+}
@@ -0,0 +1,54 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainServices "github.com/MayaCris/stock-info-app/internal/domain/services"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/database/cache"
+)
+
+func TestRankSymbolViews_OrdersByViewsThenSymbol(t *testing.T) {
+	ranking := domainServices.RankSymbolViews(map[string]int64{
+		"MSFT": 3,
+		"AAPL": 5,
+		"AMZN": 3,
+		"IDLE": 0,
+	}, 0)
+
+	assert.Equal(t, []domainServices.SymbolViewCount{
+		{Symbol: "AAPL", Views: 5},
+		{Symbol: "AMZN", Views: 3},
+		{Symbol: "MSFT", Views: 3},
+	}, ranking)
+
+	assert.Len(t, domainServices.RankSymbolViews(map[string]int64{"AAPL": 1, "MSFT": 2}, 1), 1)
+}
+
+func TestSymbolViews_RecordAndTop(t *testing.T) {
+	ctx := context.Background()
+	views := domainServices.NewSymbolViews(cache.NewMemoryCacheServiceOnly())
+
+	for _, symbol := range []string{"aapl", "AAPL", " msft ", "AAPL", ""} {
+		require.NoError(t, views.Record(ctx, symbol))
+	}
+
+	top, err := views.Top(ctx, 24, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []domainServices.SymbolViewCount{
+		{Symbol: "AAPL", Views: 3},
+		{Symbol: "MSFT", Views: 1},
+	}, top)
+}
+
+func TestSymbolViews_NilIsNoop(t *testing.T) {
+	var views *domainServices.SymbolViews
+	require.NoError(t, views.Record(context.Background(), "AAPL"))
+
+	counts, err := views.Counts(context.Background(), 24)
+	require.NoError(t, err)
+	assert.Empty(t, counts)
+}
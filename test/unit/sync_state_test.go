@@ -0,0 +1,42 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MayaCris/stock-info-app/internal/application/usecases/population"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+func TestSyncState_Watermark(t *testing.T) {
+	var missing *entities.SyncState
+	assert.False(t, missing.HasWatermark())
+	assert.False(t, missing.IsAlreadyIngested(time.Now()))
+
+	watermark := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	state := entities.NewSyncState("stock_api", watermark)
+
+	assert.True(t, state.HasWatermark())
+	assert.True(t, state.IsAlreadyIngested(watermark.Add(-time.Minute)))
+	// Same event time may be a different rating: keep it, the insert ignores duplicates
+	assert.False(t, state.IsAlreadyIngested(watermark))
+	assert.False(t, state.IsAlreadyIngested(watermark.Add(time.Minute)))
+}
+
+func TestPopulationResult_CoversWatermark(t *testing.T) {
+	watermark := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	state := entities.NewSyncState("stock_api", watermark)
+
+	// Sin watermark previo cualquier ejecución lo fija
+	assert.True(t, (&population.PopulationResult{}).CoversWatermark(nil))
+
+	// Cortada en MaxPages con todos los items posteriores al watermark: quedan páginas sin leer
+	truncated := &population.PopulationResult{OldestEventTime: watermark.Add(time.Hour)}
+	assert.False(t, truncated.CoversWatermark(state))
+
+	assert.True(t, (&population.PopulationResult{OldestEventTime: watermark.Add(time.Hour), ReachedWatermark: true}).CoversWatermark(state))
+	assert.True(t, (&population.PopulationResult{OldestEventTime: watermark.Add(time.Hour), SourceExhausted: true}).CoversWatermark(state))
+	assert.True(t, (&population.PopulationResult{OldestEventTime: watermark}).CoversWatermark(state))
+}
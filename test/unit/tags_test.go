@@ -0,0 +1,151 @@
+package unit
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/testutil/testdoubles"
+)
+
+func TestNormalizeTagSlug(t *testing.T) {
+	assert.Equal(t, "electric-vehicles", entities.NormalizeTagSlug("  Electric Vehicles "))
+	assert.Equal(t, "ai", entities.NormalizeTagSlug("AI"))
+	assert.Equal(t, "s-p-500", entities.NormalizeTagSlug("S&P_500"))
+	assert.Empty(t, entities.NormalizeTagSlug("!!!"))
+}
+
+func TestTagService_TagsAndAssignments(t *testing.T) {
+	store := testdoubles.NewStore()
+	companyRepo := testdoubles.NewCompanyRepository(store)
+	companies := relationshipTestCompanies(t, companyRepo, "TSLA", "NVDA")
+	service := services.NewTagService(companyRepo, testdoubles.NewTagRepository(store), newEventBusTestLogger(t))
+	ctx := context.Background()
+
+	// Sin slug se deriva del nombre
+	ev, err := service.CreateTag(ctx, &request.CreateTagRequest{Name: "Electric Vehicles"})
+	require.NoError(t, err)
+	assert.Equal(t, "electric-vehicles", ev.Slug)
+	_, err = service.CreateTag(ctx, &request.CreateTagRequest{Slug: "AI", Name: "Artificial intelligence"})
+	require.NoError(t, err)
+
+	_, err = service.CreateTag(ctx, &request.CreateTagRequest{Slug: "Electric vehicles", Name: "EV"})
+	requireErrorStatus(t, err, http.StatusConflict)
+	_, err = service.CreateTag(ctx, &request.CreateTagRequest{Slug: "!!!", Name: "Nothing"})
+	requireErrorStatus(t, err, http.StatusBadRequest)
+
+	// Etiquetar dos veces no cambia nada
+	tags, err := service.TagCompany(ctx, "electric-vehicles", "tsla")
+	require.NoError(t, err)
+	assert.Equal(t, "TSLA", tags.Ticker)
+	_, err = service.TagCompany(ctx, "ai", companies["TSLA"].ID.String())
+	require.NoError(t, err)
+	tags, err = service.TagCompany(ctx, "AI", "TSLA")
+	require.NoError(t, err)
+	require.Len(t, tags.Tags, 2)
+	assert.Equal(t, "ai", tags.Tags[0].Slug)
+	_, err = service.TagCompany(ctx, "ai", "NVDA")
+	require.NoError(t, err)
+	_, err = service.TagCompany(ctx, "crypto", "NVDA")
+	requireErrorStatus(t, err, http.StatusNotFound)
+
+	listed, err := service.ListTags(ctx)
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	assert.Equal(t, "ai", listed[0].Slug)
+	require.NotNil(t, listed[0].Companies)
+	assert.Equal(t, int64(2), *listed[0].Companies)
+	assert.Equal(t, int64(1), *listed[1].Companies)
+
+	name := "Electric cars"
+	updated, err := service.UpdateTag(ctx, "electric-vehicles", &request.UpdateTagRequest{Name: &name})
+	require.NoError(t, err)
+	assert.Equal(t, "Electric cars", updated.Name)
+	assert.Equal(t, "electric-vehicles", updated.Slug)
+
+	require.NoError(t, service.UntagCompany(ctx, "ai", "NVDA"))
+	requireErrorStatus(t, service.UntagCompany(ctx, "ai", "NVDA"), http.StatusNotFound)
+
+	// Eliminar la etiqueta la quita de las companies
+	require.NoError(t, service.DeleteTag(ctx, "ai"))
+	tags, err = service.GetCompanyTags(ctx, "TSLA")
+	require.NoError(t, err)
+	require.Len(t, tags.Tags, 1)
+	assert.Equal(t, "electric-vehicles", tags.Tags[0].Slug)
+	requireErrorStatus(t, service.DeleteTag(ctx, "ai"), http.StatusNotFound)
+}
+
+func TestTagFilter_CompanyListingAndTopRated(t *testing.T) {
+	store := testdoubles.NewStore()
+	companyRepo := testdoubles.NewCompanyRepository(store)
+	brokerageRepo := testdoubles.NewBrokerageRepository(store)
+	ratingRepo := testdoubles.NewStockRatingRepository(store)
+	tagRepo := testdoubles.NewTagRepository(store)
+	companies := relationshipTestCompanies(t, companyRepo, "TSLA", "NVDA", "AAPL", "F")
+	ctx := context.Background()
+
+	tag := func(slug string, tickers ...string) {
+		created := entities.NewTag(slug, strings.ToUpper(slug), "")
+		require.NoError(t, tagRepo.Create(ctx, created))
+		for _, ticker := range tickers {
+			require.NoError(t, tagRepo.AddCompany(ctx, created.ID, companies[ticker].ID))
+		}
+	}
+	tag("ai", "TSLA", "NVDA", "AAPL")
+	tag("ev", "TSLA", "F")
+
+	// Una etiqueta o varias (todas)
+	companyService := services.NewCompanyService(companyRepo, nil, newEventBusTestLogger(t))
+	pagination := &response.PaginationRequest{Page: 1, PerPage: 20}
+	tickers := func(filter string) []string {
+		result, err := companyService.ListCompanies(ctx, &request.CompanyFilterRequest{Tags: filter}, pagination)
+		require.NoError(t, err)
+		var listed []string
+		for _, company := range result.Items {
+			listed = append(listed, company.Ticker)
+		}
+		return listed
+	}
+	assert.Equal(t, []string{"AAPL", "NVDA", "TSLA"}, tickers("ai"))
+	assert.Equal(t, []string{"TSLA"}, tickers("AI, ev,ai"))
+	assert.Empty(t, tickers("crypto"))
+	assert.Len(t, tickers(""), 4)
+	_, err := companyService.ListCompanies(ctx, &request.CompanyFilterRequest{Tags: "a,b,c,d,e,f,g,h,i,j,k"}, pagination)
+	requireErrorStatus(t, err, http.StatusBadRequest)
+
+	// El ranking por número de ratings solo con las companies etiquetadas
+	brokerage := entities.NewBrokerage("Goldman Sachs")
+	require.NoError(t, brokerageRepo.Create(ctx, brokerage))
+	ratings := map[string]int{"AAPL": 5, "F": 4, "NVDA": 3, "TSLA": 2}
+	for ticker, count := range ratings {
+		for i := 0; i < count; i++ {
+			rating := entities.NewStockRating(companies[ticker].ID, brokerage.ID, "upgraded by", time.Now().Add(-time.Duration(i+1)*time.Hour))
+			require.NoError(t, ratingRepo.Create(ctx, rating))
+		}
+	}
+	analysisService := services.NewAnalysisService(ratingRepo, companyRepo, brokerageRepo, nil, nil, nil, nil, nil, nil, nil, 0, services.DefaultScoringWeights(), newEventBusTestLogger(t))
+
+	top, err := analysisService.GetTopRatedCompanies(ctx, 2, "")
+	require.NoError(t, err)
+	require.Len(t, top, 2)
+	assert.Equal(t, []string{"AAPL", "F"}, []string{top[0].Ticker, top[1].Ticker})
+
+	top, err = analysisService.GetTopRatedCompanies(ctx, 10, "ev")
+	require.NoError(t, err)
+	require.Len(t, top, 2)
+	assert.Equal(t, []string{"F", "TSLA"}, []string{top[0].Ticker, top[1].Ticker})
+
+	top, err = analysisService.GetTopRatedCompanies(ctx, 1, "ai")
+	require.NoError(t, err)
+	require.Len(t, top, 1)
+	assert.Equal(t, "AAPL", top[0].Ticker)
+}
@@ -0,0 +1,76 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+)
+
+func TestParseTargetPrice(t *testing.T) {
+	cases := []struct {
+		raw      string
+		value    float64
+		currency string
+	}{
+		{"$150.00", 150, "USD"},
+		{" $1,234.50 ", 1234.5, "USD"},
+		{"C$42", 42, "CAD"},
+		{"HK$ 88.8", 88.8, "HKD"},
+		{"€12,50", 12.5, "EUR"},
+		{"£4.20", 4.2, "GBP"},
+		{"250p", 2.5, "GBP"},
+		{"GBX 250", 2.5, "GBP"},
+		{"USD 75", 75, "USD"},
+		{"150 eur", 150, "EUR"},
+		{"99¢", 0.99, "USD"},
+		{"18", 18, entities.DefaultTargetCurrency},
+	}
+	for _, tc := range cases {
+		target, ok := entities.ParseTargetPrice(tc.raw)
+		require.True(t, ok, tc.raw)
+		assert.InDelta(t, tc.value, target.Value, 0.0001, tc.raw)
+		assert.Equal(t, tc.currency, target.Currency, tc.raw)
+	}
+
+	for _, raw := range []string{"", "N/A", "$", "NaN", "$-5", "$0", "1e5"} {
+		_, ok := entities.ParseTargetPrice(raw)
+		assert.False(t, ok, raw)
+	}
+}
+
+func TestStockRating_NormalizesTargets(t *testing.T) {
+	rating := entities.NewStockRating(uuid.New(), uuid.New(), "target raised by", time.Now())
+	rating.TargetFrom = "$4.20"
+	rating.TargetTo = "$4.70"
+	rating.Normalize()
+
+	require.NotNil(t, rating.TargetFromValue)
+	require.NotNil(t, rating.TargetToValue)
+	assert.InDelta(t, 4.2, *rating.TargetFromValue, 0.0001)
+	assert.InDelta(t, 4.7, *rating.TargetToValue, 0.0001)
+	assert.Equal(t, "USD", rating.TargetCurrency)
+
+	// Un objetivo inicial en otra moneda queda sin valor: el rating tiene una sola moneda
+	rating.TargetFrom = "€4.00"
+	rating.Normalize()
+	assert.Nil(t, rating.TargetFromValue)
+	assert.Equal(t, "USD", rating.TargetCurrency)
+
+	rating.TargetFrom, rating.TargetTo = "", "n/a"
+	rating.Normalize()
+	assert.Nil(t, rating.TargetToValue)
+	assert.Empty(t, rating.TargetCurrency)
+}
+
+func TestStockRatingFilterRequest_HasTargetFilter(t *testing.T) {
+	min := 10.0
+	assert.False(t, (&request.StockRatingFilterRequest{Ticker: "AAPL"}).HasTargetFilter())
+	assert.True(t, (&request.StockRatingFilterRequest{TargetMin: &min}).HasTargetFilter())
+	assert.True(t, (&request.StockRatingFilterRequest{TargetCurrency: "EUR"}).HasTargetFilter())
+}
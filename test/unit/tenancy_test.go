@@ -0,0 +1,259 @@
+package unit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/tenancy"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/auth"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/config"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
+)
+
+func TestJWT_SignAndVerify(t *testing.T) {
+	secret := []byte("a-secret-of-at-least-16-bytes")
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	tenantID := uuid.New()
+
+	token, err := auth.SignHS256(auth.Claims{
+		Subject:   "user-42",
+		TenantID:  tenantID.String(),
+		Issuer:    "https://id.example.com",
+		ExpiresAt: now.Add(time.Hour).Unix(),
+	}, secret)
+	require.NoError(t, err)
+
+	claims, err := auth.VerifyHS256(token, secret, "https://id.example.com", now)
+	require.NoError(t, err)
+	assert.Equal(t, "user-42", claims.Subject)
+	assert.Equal(t, tenantID.String(), claims.TenantID)
+
+	_, err = auth.VerifyHS256(token, []byte("another-secret-of-16-bytes"), "", now)
+	assert.ErrorIs(t, err, auth.ErrInvalidSignature)
+
+	_, err = auth.VerifyHS256(token, secret, "https://other.example.com", now)
+	assert.ErrorIs(t, err, auth.ErrInvalidClaims)
+
+	_, err = auth.VerifyHS256(token, secret, "", now.Add(2*time.Hour))
+	assert.ErrorIs(t, err, auth.ErrTokenExpired)
+
+	_, err = auth.VerifyHS256("not-a-token", secret, "", now)
+	assert.ErrorIs(t, err, auth.ErrMalformedToken)
+}
+
+func TestJWT_RequiresTenantClaim(t *testing.T) {
+	secret := []byte("a-secret-of-at-least-16-bytes")
+	token, err := auth.SignHS256(auth.Claims{Subject: "user-42"}, secret)
+	require.NoError(t, err)
+
+	_, err = auth.VerifyHS256(token, secret, "", time.Now())
+	assert.ErrorIs(t, err, auth.ErrInvalidClaims)
+}
+
+func TestTenancyContext(t *testing.T) {
+	_, err := tenancy.TenantID(context.Background())
+	assert.ErrorIs(t, err, tenancy.ErrNoTenant)
+
+	tenantID := uuid.New()
+	ctx := tenancy.WithTenant(context.Background(), tenantID)
+	got, err := tenancy.TenantID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, tenantID, got)
+
+	_, err = tenancy.TenantID(tenancy.WithPrincipal(context.Background(), &tenancy.Principal{}))
+	assert.ErrorIs(t, err, tenancy.ErrNoTenant)
+}
+
+func TestAPIKey_IsUsable(t *testing.T) {
+	now := time.Now()
+	past, future := now.Add(-time.Minute), now.Add(time.Minute)
+
+	assert.True(t, (&entities.APIKey{}).IsUsable(now))
+	assert.True(t, (&entities.APIKey{ExpiresAt: &future}).IsUsable(now))
+	assert.False(t, (&entities.APIKey{ExpiresAt: &past}).IsUsable(now))
+	assert.False(t, (&entities.APIKey{RevokedAt: &past}).IsUsable(now))
+}
+
+func TestGenerateAPIKey(t *testing.T) {
+	first, err := services.GenerateAPIKey()
+	require.NoError(t, err)
+	second, err := services.GenerateAPIKey()
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(first, services.APIKeyPrefix))
+	assert.NotEqual(t, first, second)
+	assert.Len(t, services.HashAPIKey(first), 64)
+	assert.Equal(t, services.HashAPIKey(first), services.HashAPIKey(first))
+}
+
+// fakeTenantAuthenticator acepta una única API key y cualquier JWT con tres segmentos
+type fakeTenantAuthenticator struct {
+	key      string
+	tenantID uuid.UUID
+}
+
+func (f *fakeTenantAuthenticator) AuthenticateAPIKey(_ context.Context, key string) (*tenancy.Principal, error) {
+	if key != f.key {
+		return nil, response.Unauthorized("Invalid API key")
+	}
+	return &tenancy.Principal{TenantID: f.tenantID, Method: tenancy.MethodAPIKey}, nil
+}
+
+func (f *fakeTenantAuthenticator) AuthenticateToken(_ context.Context, _ string) (*tenancy.Principal, error) {
+	return &tenancy.Principal{TenantID: f.tenantID, Method: tenancy.MethodJWT}, nil
+}
+
+func TestTenantMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	authenticator := &fakeTenantAuthenticator{key: "sia_valid", tenantID: uuid.New()}
+
+	router := gin.New()
+	router.Use(middleware.TenantMiddleware(authenticator, config.TenancyConfig{APIKeyHeader: "X-API-Key"}))
+	router.GET("/public", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/me", middleware.RequireTenant(), func(c *gin.Context) {
+		principal, _ := tenancy.PrincipalFromContext(c.Request.Context())
+		c.String(http.StatusOK, principal.Method+" "+c.GetString(middleware.TenantContextKey))
+	})
+
+	do := func(path string, headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Sin credenciales: las rutas públicas siguen abiertas y /me exige tenant
+	assert.Equal(t, http.StatusOK, do("/public", nil).Code)
+	assert.Equal(t, http.StatusUnauthorized, do("/me", nil).Code)
+
+	rec := do("/me", map[string]string{"X-API-Key": "sia_valid"})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "api_key "+authenticator.tenantID.String(), rec.Body.String())
+
+	rec = do("/me", map[string]string{"Authorization": "Bearer sia_valid"})
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = do("/me", map[string]string{"Authorization": "Bearer aaa.bbb.ccc"})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, strings.HasPrefix(rec.Body.String(), "jwt "))
+
+	// Credenciales inválidas: 401 incluso en las rutas públicas
+	assert.Equal(t, http.StatusUnauthorized, do("/public", map[string]string{"X-API-Key": "sia_invalid"}).Code)
+}
+
+func TestRoles_Hierarchy(t *testing.T) {
+	role, err := tenancy.ParseRole(" Editor ")
+	require.NoError(t, err)
+	assert.Equal(t, tenancy.RoleEditor, role)
+
+	_, err = tenancy.ParseRole("owner")
+	assert.Error(t, err)
+
+	assert.True(t, tenancy.RoleAdmin.Allows(tenancy.RoleEditor))
+	assert.True(t, tenancy.RoleEditor.Allows(tenancy.RoleEditor))
+	assert.False(t, tenancy.RoleViewer.Allows(tenancy.RoleEditor))
+	assert.False(t, tenancy.Role("").Allows(tenancy.RoleViewer))
+}
+
+func TestTenancyConfig_Validate(t *testing.T) {
+	cfg := config.TenancyConfig{
+		AnonymousRole:   "viewer",
+		RolePermissions: map[string]string{"read": "viewer", "write": "editor"},
+	}
+	assert.NoError(t, cfg.Validate())
+
+	// El grupo admin exige siempre la clave de administración
+	cfg.RolePermissions["admin"] = "admin"
+	assert.Error(t, cfg.Validate())
+	delete(cfg.RolePermissions, "admin")
+
+	cfg.AnonymousRole = config.AnonymousRoleNone
+	assert.NoError(t, cfg.Validate())
+
+	cfg.RolePermissions["write"] = "owner"
+	assert.Error(t, cfg.Validate())
+
+	cfg.RolePermissions["write"] = "editor"
+	cfg.AdminAPIKey = "short"
+	assert.Error(t, cfg.Validate())
+}
+
+func TestRequireRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(principal *tenancy.Principal, anonymousRole tenancy.Role) *gin.Engine {
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			if principal != nil {
+				c.Request = c.Request.WithContext(tenancy.WithPrincipal(c.Request.Context(), principal))
+			}
+			c.Next()
+		})
+		router.GET("/companies", middleware.RequireRole(tenancy.RoleViewer, anonymousRole), func(c *gin.Context) { c.Status(http.StatusOK) })
+		router.POST("/companies", middleware.RequireRole(tenancy.RoleEditor, anonymousRole), func(c *gin.Context) { c.Status(http.StatusCreated) })
+		return router
+	}
+	status := func(router *gin.Engine, method string) int {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(method, "/companies", nil))
+		return rec.Code
+	}
+
+	// Anónimo con rol viewer: lee pero no escribe
+	anonymous := newRouter(nil, tenancy.RoleViewer)
+	assert.Equal(t, http.StatusOK, status(anonymous, http.MethodGet))
+	assert.Equal(t, http.StatusUnauthorized, status(anonymous, http.MethodPost))
+
+	// Sin rol anónimo todo exige credenciales
+	assert.Equal(t, http.StatusUnauthorized, status(newRouter(nil, ""), http.MethodGet))
+
+	viewer := newRouter(&tenancy.Principal{TenantID: uuid.New(), Role: tenancy.RoleViewer}, tenancy.RoleViewer)
+	assert.Equal(t, http.StatusOK, status(viewer, http.MethodGet))
+	assert.Equal(t, http.StatusForbidden, status(viewer, http.MethodPost))
+
+	editor := newRouter(&tenancy.Principal{TenantID: uuid.New(), Role: tenancy.RoleEditor}, tenancy.RoleViewer)
+	assert.Equal(t, http.StatusCreated, status(editor, http.MethodPost))
+}
+
+func TestRequirePlatformAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	status := func(principal *tenancy.Principal) int {
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			if principal != nil {
+				c.Request = c.Request.WithContext(tenancy.WithPrincipal(c.Request.Context(), principal))
+			}
+			c.Next()
+		})
+		router.GET("/admin/tenants", middleware.RequirePlatformAdmin(), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/tenants", nil))
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, status(nil))
+	assert.Equal(t, http.StatusOK, status(&tenancy.Principal{Subject: "admin", Method: tenancy.MethodAdminKey, Role: tenancy.RoleAdmin}))
+
+	// Las credenciales admin de un tenant (API key, JWT o la clave creada desde /admin/tenants) no llegan
+	tenantID := uuid.New()
+	assert.Equal(t, http.StatusForbidden, status(&tenancy.Principal{TenantID: tenantID, Method: tenancy.MethodAPIKey, Role: tenancy.RoleAdmin}))
+	assert.Equal(t, http.StatusForbidden, status(&tenancy.Principal{TenantID: tenantID, Method: tenancy.MethodJWT, Role: tenancy.RoleAdmin}))
+	assert.Equal(t, http.StatusForbidden, status(&tenancy.Principal{TenantID: tenantID, Method: tenancy.MethodAdminKey, Role: tenancy.RoleAdmin}))
+}
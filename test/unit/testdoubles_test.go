@@ -0,0 +1,223 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/domainerrors"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/testutil/testdoubles"
+)
+
+// Los dobles en memoria deben comportarse como los repositorios de GORM en lo que usan los servicios
+
+func TestTestDoubles_CompanyUniqueTickerIncludesDeleted(t *testing.T) {
+	store := testdoubles.NewStore()
+	repo := testdoubles.NewCompanyRepository(store)
+	ctx := context.Background()
+
+	company := entities.NewCompanyWithDetails("aapl", "Apple Inc.", "Technology", "NASDAQ", 3e12)
+	require.NoError(t, repo.Create(ctx, company))
+
+	found, err := repo.GetByTicker(ctx, "AAPL")
+	require.NoError(t, err)
+	assert.Equal(t, company.ID, found.ID)
+	assert.True(t, found.IsActive)
+
+	err = repo.Create(ctx, entities.NewCompany("AAPL", "Apple Duplicate"))
+	assert.True(t, domainerrors.IsDuplicate(err), "%v", err)
+
+	// Tras el soft delete el ticker sigue ocupado, como con el índice único de la base de datos
+	require.NoError(t, repo.Delete(ctx, company.ID))
+	_, err = repo.GetByTicker(ctx, "AAPL")
+	assert.True(t, domainerrors.IsNotFound(err), "%v", err)
+	err = repo.Create(ctx, entities.NewCompany("AAPL", "Apple Again"))
+	assert.True(t, domainerrors.IsDuplicate(err), "%v", err)
+
+	require.NoError(t, repo.Restore(ctx, company.ID))
+	count, err := repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestTestDoubles_CompanyOptimisticLockAndCopies(t *testing.T) {
+	repo := testdoubles.NewCompanyRepository(testdoubles.NewStore())
+	ctx := context.Background()
+
+	company := entities.NewCompany("MSFT", "Microsoft")
+	require.NoError(t, repo.Create(ctx, company))
+
+	first, err := repo.GetByID(ctx, company.ID)
+	require.NoError(t, err)
+	second, err := repo.GetByID(ctx, company.ID)
+	require.NoError(t, err)
+
+	// Modificar una copia devuelta no cambia el store hasta guardarla
+	first.Name = "Microsoft Corporation"
+	stored, err := repo.GetByID(ctx, company.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Microsoft", stored.Name)
+
+	require.NoError(t, repo.Update(ctx, first))
+	second.Sector = "Technology"
+	err = repo.Update(ctx, second)
+	assert.True(t, domainerrors.IsVersionMismatch(err), "%v", err)
+
+	stored, err = repo.GetByID(ctx, company.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Microsoft Corporation", stored.Name)
+	assert.Empty(t, stored.Sector)
+}
+
+func TestTestDoubles_RemapTickerAndMerge(t *testing.T) {
+	store := testdoubles.NewStore()
+	companyRepo := testdoubles.NewCompanyRepository(store)
+	brokerageRepo := testdoubles.NewBrokerageRepository(store)
+	ratingRepo := testdoubles.NewStockRatingRepository(store)
+	marketDataRepo := testdoubles.NewMarketDataRepository(store)
+	ctx := context.Background()
+
+	target := entities.NewCompany("FB", "Meta Platforms")
+	duplicate := entities.NewCompany("METAX", "Meta Platforms Duplicate")
+	require.NoError(t, companyRepo.Create(ctx, target))
+	require.NoError(t, companyRepo.Create(ctx, duplicate))
+
+	remapped, err := companyRepo.RemapTicker(ctx, target.ID, "meta")
+	require.NoError(t, err)
+	assert.Equal(t, "META", remapped.Ticker)
+	found, err := companyRepo.GetByTicker(ctx, "FB")
+	require.NoError(t, err)
+	assert.Equal(t, target.ID, found.ID)
+
+	brokerage := entities.NewBrokerage("Goldman Sachs")
+	require.NoError(t, brokerageRepo.Create(ctx, brokerage))
+	eventTime := time.Date(2025, 3, 10, 14, 0, 0, 0, time.UTC)
+	require.NoError(t, ratingRepo.Create(ctx, entities.NewStockRating(duplicate.ID, brokerage.ID, "upgraded by", eventTime)))
+	require.NoError(t, marketDataRepo.Create(ctx, &entities.MarketData{CompanyID: duplicate.ID, Symbol: "METAX", CurrentPrice: 500, MarketTimestamp: eventTime}))
+
+	// El dry run calcula el resultado sin cambiar el store
+	preview, err := companyRepo.MergeInto(ctx, duplicate.ID, target.ID, true)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), preview.RatingsMoved)
+	assert.Equal(t, int64(1), preview.MarketDataMoved)
+	_, err = companyRepo.GetByID(ctx, duplicate.ID)
+	require.NoError(t, err)
+
+	result, err := companyRepo.MergeInto(ctx, duplicate.ID, target.ID, false)
+	require.NoError(t, err)
+	assert.Equal(t, preview.RatingsMoved, result.RatingsMoved)
+
+	_, err = companyRepo.GetByID(ctx, duplicate.ID)
+	assert.True(t, domainerrors.IsNotFound(err), "%v", err)
+	found, err = companyRepo.GetByTicker(ctx, "METAX")
+	require.NoError(t, err)
+	assert.Equal(t, target.ID, found.ID)
+
+	ratings, err := ratingRepo.GetByCompanyID(ctx, target.ID)
+	require.NoError(t, err)
+	require.Len(t, ratings, 1)
+	assert.Equal(t, entities.ActionUpgrade, ratings[0].ActionType)
+	quote, err := marketDataRepo.GetByCompanyID(ctx, target.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "META", quote.Symbol)
+}
+
+func TestTestDoubles_StockRatingConstraints(t *testing.T) {
+	store := testdoubles.NewStore()
+	companyRepo := testdoubles.NewCompanyRepository(store)
+	brokerageRepo := testdoubles.NewBrokerageRepository(store)
+	ratingRepo := testdoubles.NewStockRatingRepository(store)
+	ctx := context.Background()
+
+	company := entities.NewCompany("NVDA", "NVIDIA")
+	require.NoError(t, companyRepo.Create(ctx, company))
+	brokerage := entities.NewBrokerage("Morgan Stanley")
+	require.NoError(t, brokerageRepo.Create(ctx, brokerage))
+
+	// Sin company no hay foreign key
+	eventTime := time.Date(2025, 3, 10, 14, 0, 0, 0, time.UTC)
+	orphan := entities.NewStockRating(entities.NewCompany("NONE", "Missing").ID, brokerage.ID, "upgraded by", eventTime)
+	err := ratingRepo.Create(ctx, orphan)
+	assert.True(t, domainerrors.IsConflict(err), "%v", err)
+
+	ratings := []*entities.StockRating{
+		entities.NewStockRating(company.ID, brokerage.ID, "upgraded by", eventTime),
+		entities.NewStockRating(company.ID, brokerage.ID, "reiterated by", eventTime), // Mismo evento
+		entities.NewStockRating(company.ID, brokerage.ID, "downgraded by", eventTime.Add(time.Hour)),
+	}
+	ratings[2].TargetTo = "$150.00"
+	inserted, err := ratingRepo.BulkInsertIgnoreDuplicates(ctx, ratings)
+	require.NoError(t, err)
+	assert.Equal(t, 2, inserted)
+
+	minTarget := 100.0
+	page, total, err := ratingRepo.Search(ctx, interfaces.RatingSearchFilter{
+		CompanyID:      &company.ID,
+		TargetCurrency: "USD",
+		TargetMin:      &minTarget,
+	}, nil, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, page, 1)
+	assert.Equal(t, entities.ActionDowngrade, page[0].ActionType)
+
+	page, _, err = ratingRepo.Search(ctx, interfaces.RatingSearchFilter{CompanyID: &company.ID}, []interfaces.SortField{{Column: "event_time"}}, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.True(t, page[0].EventTime.Before(page[1].EventTime))
+
+	_, _, err = ratingRepo.Search(ctx, interfaces.RatingSearchFilter{}, []interfaces.SortField{{Column: "unknown"}}, 10, 0)
+	assert.Error(t, err)
+}
+
+func TestTestDoubles_MarketDataUpsertAndMovers(t *testing.T) {
+	store := testdoubles.NewStore()
+	companyRepo := testdoubles.NewCompanyRepository(store)
+	marketDataRepo := testdoubles.NewMarketDataRepository(store)
+	ctx := context.Background()
+
+	apple := entities.NewCompany("AAPL", "Apple Inc.")
+	tesla := entities.NewCompany("TSLA", "Tesla")
+	require.NoError(t, companyRepo.Create(ctx, apple))
+	require.NoError(t, companyRepo.Create(ctx, tesla))
+
+	marketTime := time.Date(2025, 3, 10, 20, 0, 0, 0, time.UTC)
+	quote := &entities.MarketData{CompanyID: apple.ID, Symbol: "AAPL", CurrentPrice: 200, PriceChangePerc: 1.5, MarketTimestamp: marketTime}
+	require.NoError(t, marketDataRepo.UpsertBySymbol(ctx, quote))
+	assert.Equal(t, "USD", quote.Currency)
+
+	// El mismo símbolo y timestamp actualiza la fila existente
+	update := &entities.MarketData{CompanyID: apple.ID, Symbol: "AAPL", CurrentPrice: 210, PriceChangePerc: 2.5, MarketTimestamp: marketTime}
+	require.NoError(t, marketDataRepo.UpsertBySymbol(ctx, update))
+	assert.Equal(t, quote.ID, update.ID)
+
+	require.NoError(t, marketDataRepo.UpsertBySymbol(ctx, &entities.MarketData{CompanyID: tesla.ID, Symbol: "TSLA", CurrentPrice: 180, PriceChangePerc: 4, MarketTimestamp: marketTime.Add(-24 * time.Hour)}))
+	require.NoError(t, marketDataRepo.UpsertBySymbol(ctx, &entities.MarketData{CompanyID: tesla.ID, Symbol: "TSLA", CurrentPrice: 170, PriceChangePerc: -3, MarketTimestamp: marketTime}))
+
+	count, err := marketDataRepo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	// Los movers solo miran la cotización más reciente de cada símbolo
+	gainers, err := marketDataRepo.GetTopGainers(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, gainers, 1)
+	assert.Equal(t, "AAPL", gainers[0].Symbol)
+	assert.Equal(t, 210.0, gainers[0].CurrentPrice)
+	losers, err := marketDataRepo.GetTopLosers(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, losers, 1)
+	assert.Equal(t, "TSLA", losers[0].Symbol)
+
+	deleted, err := marketDataRepo.CleanupOldData(ctx, marketTime)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	err = marketDataRepo.Create(ctx, &entities.MarketData{CompanyID: entities.NewCompany("NONE", "Missing").ID, Symbol: "NONE", MarketTimestamp: marketTime})
+	assert.True(t, domainerrors.IsConflict(err), "%v", err)
+}
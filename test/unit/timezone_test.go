@@ -0,0 +1,121 @@
+package unit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	"github.com/MayaCris/stock-info-app/internal/domain/repositories/interfaces"
+	"github.com/MayaCris/stock-info-app/internal/domain/tenancy"
+	"github.com/MayaCris/stock-info-app/internal/domain/timezone"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
+)
+
+// rangeRecordingRatingRepository guarda el rango pedido a GetByEventTimeRange
+type rangeRecordingRatingRepository struct {
+	interfaces.StockRatingRepository
+	start, end time.Time
+}
+
+func (r *rangeRecordingRatingRepository) GetByEventTimeRange(ctx context.Context, start, end time.Time) ([]*entities.StockRating, error) {
+	r.start, r.end = start, end
+	return nil, nil
+}
+
+func TestTimezone_LoadAndBoundaries(t *testing.T) {
+	newYork, err := timezone.Load("America/New_York")
+	require.NoError(t, err)
+
+	_, err = timezone.Load("Mars/Olympus")
+	assert.Error(t, err)
+	_, err = timezone.Load("Local")
+	assert.Error(t, err)
+
+	// Miércoles 11 de marzo de 2026, 01:30 en Nueva York: en UTC ya es otro día
+	now := time.Date(2026, 3, 11, 1, 30, 0, 0, newYork)
+	assert.Equal(t, time.Date(2026, 3, 11, 0, 0, 0, 0, newYork), timezone.StartOfDay(now))
+	assert.Equal(t, time.Date(2026, 3, 8, 0, 0, 0, 0, newYork), timezone.StartOfWeek(now))
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, newYork), timezone.StartOfMonth(now))
+
+	assert.Equal(t, time.Local, timezone.FromContext(context.Background()))
+	assert.Equal(t, newYork, timezone.FromContext(timezone.WithLocation(context.Background(), newYork)))
+}
+
+func TestTimezoneMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	principal := &tenancy.Principal{Timezone: "Europe/Madrid"}
+	newRouter := func(withPrincipal bool) *gin.Engine {
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			if withPrincipal {
+				c.Request = c.Request.WithContext(tenancy.WithPrincipal(c.Request.Context(), principal))
+			}
+			c.Next()
+		})
+		router.Use(middleware.TimezoneMiddleware())
+		router.GET("/tz", func(c *gin.Context) {
+			c.String(http.StatusOK, timezone.FromContext(c.Request.Context()).String())
+		})
+		return router
+	}
+
+	tests := []struct {
+		name          string
+		url           string
+		withPrincipal bool
+		status        int
+		body          string
+	}{
+		{"query parameter", "/tz?tz=America/New_York", false, http.StatusOK, "America/New_York"},
+		{"query overrides preference", "/tz?tz=Asia/Tokyo", true, http.StatusOK, "Asia/Tokyo"},
+		{"credential preference", "/tz", true, http.StatusOK, "Europe/Madrid"},
+		{"server time zone", "/tz", false, http.StatusOK, time.Local.String()},
+		{"invalid time zone", "/tz?tz=Nowhere/City", false, http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			newRouter(tt.withPrincipal).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.url, nil))
+
+			assert.Equal(t, tt.status, recorder.Code)
+			if tt.body != "" {
+				assert.Equal(t, tt.body, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestStockRatingService_DateRangeUsesContextTimezone(t *testing.T) {
+	repo := &rangeRecordingRatingRepository{}
+	service := services.NewStockRatingService(repo, nil, nil, nil, newEventBusTestLogger(t))
+
+	tokyo, err := timezone.Load("Asia/Tokyo")
+	require.NoError(t, err)
+	ctx := timezone.WithLocation(context.Background(), tokyo)
+
+	pagination := &response.PaginationRequest{Page: 1, PerPage: 20}
+	result, err := service.GetRatingsByDateRange(ctx, "2026-03-02", "2026-03-03", pagination)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Meta.Total)
+
+	// Ambos días completos en hora de Tokio
+	assert.True(t, repo.start.Equal(time.Date(2026, 3, 1, 15, 0, 0, 0, time.UTC)))
+	assert.True(t, repo.end.Equal(time.Date(2026, 3, 3, 14, 59, 59, 999999999, time.UTC)))
+
+	_, err = service.GetRatingsByDateRange(ctx, "2026-03-03", "2026-03-02", pagination)
+	assert.Error(t, err)
+
+	_, err = service.GetRatingsForPeriod(ctx, "decade")
+	assert.Error(t, err)
+}
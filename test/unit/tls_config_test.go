@@ -0,0 +1,21 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/config"
+)
+
+func TestTLSConfig_Validate(t *testing.T) {
+	assert.NoError(t, (&config.TLSConfig{Enabled: false}).Validate())
+
+	// Con certificados en disco hacen falta ambos ficheros
+	assert.Error(t, (&config.TLSConfig{Enabled: true, CertFile: "server.crt"}).Validate())
+	assert.NoError(t, (&config.TLSConfig{Enabled: true, CertFile: "server.crt", KeyFile: "server.key"}).Validate())
+
+	// Con autocert hacen falta dominios y no certificados
+	assert.Error(t, (&config.TLSConfig{Enabled: true, Autocert: true}).Validate())
+	assert.NoError(t, (&config.TLSConfig{Enabled: true, Autocert: true, AutocertDomains: []string{"api.example.com"}}).Validate())
+}
@@ -0,0 +1,104 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/domain/services"
+)
+
+func newYork(t *testing.T, year int, month time.Month, day, hour, minute int) time.Time {
+	location, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	return time.Date(year, month, day, hour, minute, 0, 0, location)
+}
+
+func TestTradingCalendar_Holidays2026(t *testing.T) {
+	calendar := services.NewTradingCalendar()
+
+	holidays := map[string]time.Time{
+		"New Year's Day":                       newYork(t, 2026, time.January, 1, 12, 0),
+		"Martin Luther King Jr. Day":           newYork(t, 2026, time.January, 19, 12, 0),
+		"Washington's Birthday":                newYork(t, 2026, time.February, 16, 12, 0),
+		"Good Friday":                          newYork(t, 2026, time.April, 3, 12, 0),
+		"Memorial Day":                         newYork(t, 2026, time.May, 25, 12, 0),
+		"Juneteenth National Independence Day": newYork(t, 2026, time.June, 19, 12, 0),
+		"Independence Day":                     newYork(t, 2026, time.July, 3, 12, 0), // 4 de julio en sábado
+		"Labor Day":                            newYork(t, 2026, time.September, 7, 12, 0),
+		"Thanksgiving Day":                     newYork(t, 2026, time.November, 26, 12, 0),
+		"Christmas Day":                        newYork(t, 2026, time.December, 25, 12, 0),
+	}
+	for name, date := range holidays {
+		holiday, ok := calendar.Holiday(date)
+		assert.True(t, ok, name)
+		assert.Equal(t, name, holiday)
+		assert.False(t, calendar.IsTradingDay(date), name)
+	}
+
+	assert.True(t, calendar.IsTradingDay(newYork(t, 2026, time.March, 10, 12, 0)))
+	assert.False(t, calendar.IsTradingDay(newYork(t, 2026, time.March, 14, 12, 0))) // Sábado
+
+	// Año Nuevo en sábado no se observa el viernes anterior
+	_, ok := calendar.Holiday(newYork(t, 2021, time.December, 31, 12, 0))
+	assert.False(t, ok)
+}
+
+func TestTradingCalendar_Status(t *testing.T) {
+	calendar := services.NewTradingCalendar()
+
+	status, err := calendar.Status("nasdaq", newYork(t, 2026, time.March, 10, 10, 0))
+	require.NoError(t, err)
+	assert.Equal(t, "NASDAQ", status.Exchange)
+	assert.Equal(t, services.MarketSessionRegular, status.Session)
+	assert.True(t, status.IsOpen)
+	assert.Equal(t, newYork(t, 2026, time.March, 10, 16, 0), status.NextClose)
+	assert.Equal(t, newYork(t, 2026, time.March, 11, 9, 30), status.NextOpen)
+
+	status, err = calendar.Status("NYSE", newYork(t, 2026, time.March, 10, 8, 0))
+	require.NoError(t, err)
+	assert.Equal(t, services.MarketSessionPreMarket, status.Session)
+	assert.False(t, status.IsOpen)
+
+	status, err = calendar.Status("NYSE", newYork(t, 2026, time.March, 10, 18, 0))
+	require.NoError(t, err)
+	assert.Equal(t, services.MarketSessionPostMarket, status.Session)
+
+	// Viernes de Semana Santa: cerrado todo el día, abre el lunes
+	status, err = calendar.Status("NYSE", newYork(t, 2026, time.April, 3, 11, 0))
+	require.NoError(t, err)
+	assert.Equal(t, services.MarketSessionClosed, status.Session)
+	assert.Equal(t, "Good Friday", status.Holiday)
+	assert.Nil(t, status.Hours)
+	assert.Equal(t, newYork(t, 2026, time.April, 6, 9, 30), status.NextOpen)
+
+	// Viernes después de Thanksgiving: la sesión regular cierra a las 13:00
+	status, err = calendar.Status("NYSE", newYork(t, 2026, time.November, 27, 14, 0))
+	require.NoError(t, err)
+	require.NotNil(t, status.Hours)
+	assert.True(t, status.Hours.EarlyClose)
+	assert.Equal(t, services.MarketSessionPostMarket, status.Session)
+	assert.False(t, calendar.IsOpen(newYork(t, 2026, time.November, 27, 14, 0)))
+	assert.True(t, calendar.IsOpen(newYork(t, 2026, time.November, 27, 12, 0)))
+
+	_, err = calendar.Status("LSE", time.Now())
+	assert.ErrorIs(t, err, services.ErrUnsupportedExchange)
+}
+
+func TestNormalizeExchange(t *testing.T) {
+	for input, expected := range map[string]string{
+		"NASDAQ NMS - GLOBAL MARKET":    "NASDAQ",
+		"NEW YORK STOCK EXCHANGE, INC.": "NYSE",
+		"nyse":                          "NYSE",
+		"US":                            "US",
+	} {
+		code, ok := services.NormalizeExchange(input)
+		assert.True(t, ok, input)
+		assert.Equal(t, expected, code, input)
+	}
+
+	_, ok := services.NormalizeExchange("TSX")
+	assert.False(t, ok)
+}
@@ -0,0 +1,156 @@
+package unit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MayaCris/stock-info-app/internal/application/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/entities"
+	domainServices "github.com/MayaCris/stock-info-app/internal/domain/services"
+	"github.com/MayaCris/stock-info-app/internal/domain/tenancy"
+	"github.com/MayaCris/stock-info-app/internal/domain/usage"
+	"github.com/MayaCris/stock-info-app/internal/infrastructure/database/cache"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
+)
+
+func TestPrincipal_Credential(t *testing.T) {
+	keyID := uuid.New()
+	assert.Equal(t, "api_key:"+keyID.String(), (&tenancy.Principal{Method: tenancy.MethodAPIKey, APIKeyID: keyID}).Credential())
+	assert.Equal(t, "jwt:user-42", (&tenancy.Principal{Method: tenancy.MethodJWT, Subject: "user-42"}).Credential())
+	assert.Empty(t, (&tenancy.Principal{Method: tenancy.MethodAdminKey}).Credential())
+}
+
+func TestUsageTracker(t *testing.T) {
+	// Sin tracker en el context no se cuenta nada
+	usage.RecordProviderCall(context.Background())
+
+	ctx, tracker := usage.WithTracker(context.Background())
+	usage.RecordProviderCall(ctx)
+	usage.RecordCacheHit(ctx)
+	usage.RecordCacheHit(ctx)
+
+	assert.Equal(t, int64(1), tracker.ProviderCalls())
+	assert.Equal(t, int64(2), tracker.CacheHits())
+}
+
+func TestUsageCounters_RecordAndHour(t *testing.T) {
+	ctx := context.Background()
+	counters := domainServices.NewUsageCounters(cache.NewMemoryCacheServiceOnly())
+	key := domainServices.UsageKey{TenantID: uuid.New(), Credential: "jwt:team|ops"}
+	other := domainServices.UsageKey{TenantID: uuid.New(), Credential: "api_key:" + uuid.NewString()}
+
+	require.NoError(t, counters.Record(ctx, key, domainServices.UsageCounts{Requests: 1, ProviderCalls: 2}))
+	require.NoError(t, counters.Record(ctx, key, domainServices.UsageCounts{Requests: 1, Errors: 1, CacheHits: 1}))
+	require.NoError(t, counters.Record(ctx, other, domainServices.UsageCounts{Requests: 1}))
+	// Sin tenant no se cuenta
+	require.NoError(t, counters.Record(ctx, domainServices.UsageKey{Credential: "jwt:anonymous"}, domainServices.UsageCounts{Requests: 1}))
+
+	counts, err := counters.Hour(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Len(t, counts, 2)
+	assert.Equal(t, domainServices.UsageCounts{Requests: 2, Errors: 1, ProviderCalls: 2, CacheHits: 1}, counts[key])
+	assert.Equal(t, domainServices.UsageCounts{Requests: 1}, counts[other])
+
+	hours := counters.Hours()
+	assert.Len(t, hours, domainServices.UsageRetentionHours)
+	assert.Equal(t, time.Now().UTC().Truncate(time.Hour), hours[0])
+
+	require.NoError(t, counters.DeleteHour(ctx, time.Now()))
+	counts, err = counters.Hour(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, counts)
+}
+
+func TestUsageMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	counters := domainServices.NewUsageCounters(cache.NewMemoryCacheServiceOnly())
+	principal := &tenancy.Principal{TenantID: uuid.New(), Method: tenancy.MethodAPIKey, APIKeyID: uuid.New()}
+
+	newRouter := func(principal *tenancy.Principal) *gin.Engine {
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			if principal != nil {
+				c.Request = c.Request.WithContext(tenancy.WithPrincipal(c.Request.Context(), principal))
+			}
+			c.Next()
+		})
+		router.Use(middleware.UsageMiddleware(counters))
+		router.GET("/quote", func(c *gin.Context) {
+			usage.RecordProviderCall(c.Request.Context())
+			c.Status(http.StatusOK)
+		})
+		router.GET("/cached", func(c *gin.Context) {
+			usage.RecordCacheHit(c.Request.Context())
+			c.Status(http.StatusNotFound)
+		})
+		return router
+	}
+
+	authenticated := newRouter(principal)
+	for _, path := range []string{"/quote", "/cached", "/cached"} {
+		authenticated.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	// Las peticiones anónimas no se cuentan
+	newRouter(nil).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/quote", nil))
+
+	counts, err := counters.Hour(context.Background(), time.Now())
+	require.NoError(t, err)
+	require.Len(t, counts, 1)
+	assert.Equal(t, domainServices.UsageCounts{Requests: 3, Errors: 2, ProviderCalls: 1, CacheHits: 2},
+		counts[domainServices.UsageKey{TenantID: principal.TenantID, Credential: principal.Credential()}])
+}
+
+// fakeUsageRepository guarda las filas por tenant, credencial y hora, como el upsert de api_usage_hourly
+type fakeUsageRepository struct {
+	rows map[string]*entities.APIUsage
+}
+
+func (f *fakeUsageRepository) SaveHourly(_ context.Context, rows []*entities.APIUsage) error {
+	for _, row := range rows {
+		f.rows[row.TenantID.String()+row.Credential+row.Hour.String()] = row
+	}
+	return nil
+}
+
+func (f *fakeUsageRepository) DailyByTenant(_ context.Context, _, _ time.Time) ([]*entities.APIUsage, error) {
+	return nil, nil
+}
+
+func (f *fakeUsageRepository) Daily(_ context.Context, _, _ time.Time) ([]*entities.APIUsage, error) {
+	return nil, nil
+}
+
+func TestUsageService_FlushIsIdempotent(t *testing.T) {
+	ctx := context.Background()
+	counters := domainServices.NewUsageCounters(cache.NewMemoryCacheServiceOnly())
+	repo := &fakeUsageRepository{rows: make(map[string]*entities.APIUsage)}
+	service := services.NewUsageService(counters, repo, nil, newEventBusTestLogger(t))
+
+	key := domainServices.UsageKey{TenantID: uuid.New(), Credential: "jwt:user-42"}
+	require.NoError(t, counters.Record(ctx, key, domainServices.UsageCounts{Requests: 2, ProviderCalls: 1}))
+
+	for i := 0; i < 2; i++ {
+		result, err := service.Flush(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Hours)
+		assert.Equal(t, 1, result.Rows)
+		// La hora en curso sigue en la cache
+		assert.Zero(t, result.HoursPurged)
+	}
+
+	require.Len(t, repo.rows, 1)
+	for _, row := range repo.rows {
+		assert.Equal(t, key.TenantID, row.TenantID)
+		assert.Equal(t, int64(2), row.Requests)
+		assert.Equal(t, int64(1), row.ProviderCalls)
+		assert.Equal(t, time.Now().UTC().Truncate(time.Hour), row.Hour)
+	}
+}
@@ -0,0 +1,53 @@
+package unit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MayaCris/stock-info-app/internal/application/dto/request"
+	"github.com/MayaCris/stock-info-app/internal/application/dto/response"
+	"github.com/MayaCris/stock-info-app/internal/presentation/rest/middleware"
+)
+
+func bindCreateCompany(t *testing.T, body string) *response.ErrorResponse {
+	gin.SetMode(gin.TestMode)
+	middleware.RegisterJSONFieldNames()
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/companies", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	var req request.CreateCompanyRequest
+	err := c.ShouldBindJSON(&req)
+	assert.Error(t, err)
+
+	return middleware.ValidationErrorResponse(err)
+}
+
+func TestValidationErrorResponse_ListsFailingFields(t *testing.T) {
+	errorResp := bindCreateCompany(t, `{"name": "Apple"}`)
+
+	assert.Equal(t, http.StatusBadRequest, errorResp.StatusCode)
+	assert.Equal(t, response.ErrCodeValidationFailed, errorResp.Code)
+	if assert.NotEmpty(t, errorResp.ValidationErrors) {
+		assert.Equal(t, "ticker", errorResp.ValidationErrors[0].Field)
+		assert.Equal(t, "required", errorResp.ValidationErrors[0].Tag)
+		assert.Equal(t, "This field is required", errorResp.ValidationErrors[0].Message)
+	}
+}
+
+func TestValidationErrorResponse_TypeMismatchAndMalformedJSON(t *testing.T) {
+	errorResp := bindCreateCompany(t, `{"ticker": 42, "name": "Apple"}`)
+	if assert.Len(t, errorResp.ValidationErrors, 1) {
+		assert.Equal(t, "ticker", errorResp.ValidationErrors[0].Field)
+		assert.Equal(t, "type", errorResp.ValidationErrors[0].Tag)
+	}
+
+	errorResp = bindCreateCompany(t, `{"ticker": `)
+	assert.Equal(t, "Malformed JSON request body", errorResp.Message)
+}